@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -17,6 +18,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/common-go/tracing"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/progress"
 
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/xerrors"
@@ -151,6 +153,50 @@ func (c *Client) GitWithOutput(ctx context.Context, subcommand string, args ...s
 	span, _ := opentracing.StartSpanFromContext(ctx, fmt.Sprintf("git.%s", subcommand))
 	defer tracing.FinishSpan(span, &err)
 
+	cmd, err := c.command(subcommand, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, ErrGitOpFailed{
+			Args:       args,
+			ExecErr:    err,
+			Output:     string(res),
+			Subcommand: subcommand,
+		}
+	}
+	return res, nil
+}
+
+// gitWithProgress runs git and reports the progress git prints, which requires the --progress flag for most subcommands
+func (c *Client) gitWithProgress(ctx context.Context, subcommand string, args ...string) (err error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, fmt.Sprintf("git.%s", subcommand))
+	defer tracing.FinishSpan(span, &err)
+
+	cmd, err := c.command(subcommand, args...)
+	if err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	pw := &progressWriter{ctx: ctx}
+	cmd.Stdout = &out
+	cmd.Stderr = io.MultiWriter(&out, pw)
+	err = cmd.Run()
+	pw.Flush()
+	if err != nil {
+		return ErrGitOpFailed{
+			Args:       args,
+			ExecErr:    err,
+			Output:     out.String(),
+			Subcommand: subcommand,
+		}
+	}
+	return nil
+}
+
+// command creates the git command using the client configuration
+func (c *Client) command(subcommand string, args ...string) (*exec.Cmd, error) {
 	fullArgs := make([]string, 0)
 	env := make([]string, 0)
 	if c.AuthMethod == BasicAuth {
@@ -183,17 +229,7 @@ func (c *Client) GitWithOutput(ctx context.Context, subcommand string, args ...s
 	cmd.Dir = c.Location
 	cmd.Env = env
 	// cmd.SysProcAttr = &syscall.SysProcAttr{Credential: &syscall.Credential{Uid: common.GitpodUID, Gid: common.GitpodGID}}
-
-	res, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, ErrGitOpFailed{
-			Args:       args,
-			ExecErr:    err,
-			Output:     string(res),
-			Subcommand: subcommand,
-		}
-	}
-	return res, nil
+	return cmd, nil
 }
 
 // Git executes git using the client configuration
@@ -266,6 +302,11 @@ func (c *Client) Clone(ctx context.Context) (err error) {
 		args = append(args, strings.TrimSpace(key)+"="+strings.TrimSpace(value))
 	}
 	args = append(args, ".")
+	if progress.Enabled(ctx) {
+		ctx = progress.WithPhase(ctx, progress.PhaseCloning)
+		progress.Report(ctx, progress.Progress{Percentage: -1})
+		return c.gitWithProgress(ctx, "clone", append([]string{"--progress"}, args...)...)
+	}
 	if err := c.Git(ctx, "clone", args...); err != nil {
 		return err
 	}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/gitpod-io/gitpod/content-service/pkg/progress"
+)
+
+// progressPattern matches the progress lines git prints, e.g. "Receiving objects:  45% (450/1000), 1.20 MiB | 2.00 MiB/s"
+var progressPattern = regexp.MustCompile(`^(?:remote: )?([A-Za-z ]+):\s+(\d+)% \(\d+/\d+\)(?:, ([\d.]+) ([KMG]?i?B))?`)
+
+var byteUnits = map[string]float64{
+	"B":   1,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+}
+
+// parseProgress parses a progress line of git
+func parseProgress(line string) (p progress.Progress, ok bool) {
+	m := progressPattern.FindStringSubmatch(line)
+	if m == nil {
+		return p, false
+	}
+	p.Message = m[1]
+	p.Percentage, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		if v, err := strconv.ParseFloat(m[3], 64); err == nil {
+			p.BytesDone = int64(v * byteUnits[m[4]])
+		}
+	}
+	return p, true
+}
+
+// progressWriter reports the progress lines written to it. Git terminates updates of a line with \r.
+type progressWriter struct {
+	ctx  context.Context
+	buf  []byte
+	last progress.Progress
+}
+
+func (w *progressWriter) Write(b []byte) (n int, err error) {
+	w.buf = append(w.buf, b...)
+	for {
+		idx := bytes.IndexAny(w.buf, "\r\n")
+		if idx < 0 {
+			break
+		}
+		w.report(string(w.buf[:idx]))
+		w.buf = w.buf[idx+1:]
+	}
+	return len(b), nil
+}
+
+// Flush reports a pending line which was not terminated
+func (w *progressWriter) Flush() {
+	if len(w.buf) == 0 {
+		return
+	}
+	w.report(string(w.buf))
+	w.buf = nil
+}
+
+func (w *progressWriter) report(line string) {
+	p, ok := parseProgress(line)
+	if !ok || p == w.last {
+		return
+	}
+	w.last = p
+	progress.Report(w.ctx, p)
+}
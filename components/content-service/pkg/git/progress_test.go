@@ -0,0 +1,59 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/content-service/pkg/progress"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProgressWriter(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Output      []string
+		Expectation []progress.Progress
+	}{
+		{
+			Desc:   "clone",
+			Output: []string{"Cloning into '.'...\n", "remote: Counting objects:  50% (5/10)\rremote: Counting objects: 100% (10/10), done.\n"},
+			Expectation: []progress.Progress{
+				{Phase: progress.PhaseCloning, Message: "Counting objects", Percentage: 50},
+				{Phase: progress.PhaseCloning, Message: "Counting objects", Percentage: 100},
+			},
+		},
+		{
+			Desc:   "bytes",
+			Output: []string{"Receiving objects:  45% (450/1000), 1.50 MiB | 2.00 MiB/s\r", "Receiving objects:  46% (460/", "1000), 512.00 KiB | 2.00 MiB/s"},
+			Expectation: []progress.Progress{
+				{Phase: progress.PhaseCloning, Message: "Receiving objects", Percentage: 45, BytesDone: 1572864},
+				{Phase: progress.PhaseCloning, Message: "Receiving objects", Percentage: 46, BytesDone: 524288},
+			},
+		},
+		{
+			Desc:   "repeated line",
+			Output: []string{"Updating files:  10% (1/10)\r", "Updating files:  10% (1/10)\r"},
+			Expectation: []progress.Progress{
+				{Phase: progress.PhaseCloning, Message: "Updating files", Percentage: 10},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			var act []progress.Progress
+			ctx := progress.WithReporter(context.Background(), func(p progress.Progress) { act = append(act, p) })
+			w := &progressWriter{ctx: progress.WithPhase(ctx, progress.PhaseCloning)}
+			for _, out := range test.Output {
+				_, _ = w.Write([]byte(out))
+			}
+			w.Flush()
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected progress (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
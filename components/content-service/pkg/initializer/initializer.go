@@ -20,6 +20,7 @@ import (
 	"github.com/gitpod-io/gitpod/common-go/tracing"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/content-service/pkg/git"
+	"github.com/gitpod-io/gitpod/content-service/pkg/progress"
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/xerrors"
@@ -331,7 +332,7 @@ func InitializeWorkspace(ctx context.Context, location string, remoteStorage sto
 	}
 
 	// Run the initializer
-	hasBackup, err := remoteStorage.Download(ctx, location, storage.DefaultBackup)
+	hasBackup, err := remoteStorage.Download(progress.WithPhase(ctx, progress.PhaseRestoringBackup), location, storage.DefaultBackup)
 	if err != nil {
 		return src, xerrors.Errorf("cannot restore backup: %w", err)
 	}
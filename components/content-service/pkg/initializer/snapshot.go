@@ -9,6 +9,7 @@ import (
 
 	"github.com/gitpod-io/gitpod/common-go/tracing"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/content-service/pkg/progress"
 	"github.com/gitpod-io/gitpod/content-service/pkg/storage"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/xerrors"
@@ -29,6 +30,8 @@ func (s *SnapshotInitializer) Run(ctx context.Context) (src csapi.WorkspaceInitS
 
 	src = csapi.WorkspaceInitFromOther
 
+	ctx = progress.WithPhase(ctx, progress.PhaseDownloadingPrebuild)
+	progress.Report(ctx, progress.Progress{Percentage: -1})
 	ok, err := s.Storage.DownloadSnapshot(ctx, s.Location, s.Snapshot)
 	if err != nil {
 		return src, xerrors.Errorf("snapshot initializer: %w", err)
@@ -0,0 +1,109 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package progress
+
+import (
+	"context"
+	"io"
+)
+
+// Phase is a phase of the workspace content initialization
+type Phase string
+
+const (
+	// PhaseRestoringBackup downloads and extracts the backup of a workspace
+	PhaseRestoringBackup Phase = "restoring-backup"
+	// PhaseDownloadingPrebuild downloads and extracts the snapshot of a prebuild
+	PhaseDownloadingPrebuild Phase = "downloading-prebuild"
+	// PhaseCloning clones and checks out a Git repository
+	PhaseCloning Phase = "cloning"
+)
+
+// Progress describes how far the content initialization is in a phase
+type Progress struct {
+	Phase Phase
+	// Percentage is the completion of the phase from 0 to 100, or -1 if unknown
+	Percentage int
+	BytesDone  int64
+	// BytesTotal is 0 if unknown
+	BytesTotal int64
+	// Message describes the current step of the phase, e.g. "Receiving objects"
+	Message string
+}
+
+// Reporter receives progress reports
+type Reporter func(Progress)
+
+type reporterKey struct{}
+
+type phaseKey struct{}
+
+// WithReporter makes the content initialization report its progress to r
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, r)
+}
+
+// WithPhase sets the phase progress reported with ctx belongs to
+func WithPhase(ctx context.Context, phase Phase) context.Context {
+	return context.WithValue(ctx, phaseKey{}, phase)
+}
+
+// Enabled returns true if progress reported with ctx is received by someone
+func Enabled(ctx context.Context) bool {
+	_, ok := ctx.Value(reporterKey{}).(Reporter)
+	return ok
+}
+
+// Report reports progress to the reporter of ctx if there is one. If the progress has no phase, the phase of ctx is used.
+func Report(ctx context.Context, p Progress) {
+	r, ok := ctx.Value(reporterKey{}).(Reporter)
+	if !ok {
+		return
+	}
+	if p.Phase == "" {
+		p.Phase, _ = ctx.Value(phaseKey{}).(Phase)
+	}
+	r(p)
+}
+
+// reportEvery is the number of bytes after which a Reader reports progress at the latest
+const reportEvery = 1 << 20
+
+// NewReader returns a reader which reports the bytes read from r, of total bytes if total > 0
+func NewReader(ctx context.Context, r io.Reader, total int64) io.Reader {
+	if !Enabled(ctx) {
+		return r
+	}
+	return &reader{ctx: ctx, r: r, total: total, lastPercentage: -1}
+}
+
+type reader struct {
+	ctx   context.Context
+	r     io.Reader
+	total int64
+
+	done           int64
+	lastReported   int64
+	lastPercentage int
+}
+
+func (r *reader) Read(p []byte) (n int, err error) {
+	n, err = r.r.Read(p)
+	r.done += int64(n)
+
+	pct := -1
+	if r.total > 0 {
+		pct = int(r.done * 100 / r.total)
+		if pct > 100 {
+			pct = 100
+		}
+	}
+	if pct != r.lastPercentage || r.done-r.lastReported >= reportEvery || (err == io.EOF && r.done != r.lastReported) {
+		r.lastPercentage = pct
+		r.lastReported = r.done
+		Report(r.ctx, Progress{Percentage: pct, BytesDone: r.done, BytesTotal: r.total})
+	}
+	return n, err
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package progress
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReader(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Size        int
+		Total       int64
+		Expectation []Progress
+	}{
+		{
+			Desc:  "known size",
+			Size:  4,
+			Total: 4,
+			Expectation: []Progress{
+				{Phase: PhaseRestoringBackup, Percentage: 25, BytesDone: 1, BytesTotal: 4},
+				{Phase: PhaseRestoringBackup, Percentage: 50, BytesDone: 2, BytesTotal: 4},
+				{Phase: PhaseRestoringBackup, Percentage: 75, BytesDone: 3, BytesTotal: 4},
+				{Phase: PhaseRestoringBackup, Percentage: 100, BytesDone: 4, BytesTotal: 4},
+			},
+		},
+		{
+			Desc: "unknown size",
+			Size: 3,
+			Expectation: []Progress{
+				{Phase: PhaseRestoringBackup, Percentage: -1, BytesDone: 3},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			var act []Progress
+			ctx := WithReporter(context.Background(), func(p Progress) { act = append(act, p) })
+			ctx = WithPhase(ctx, PhaseRestoringBackup)
+
+			r := NewReader(ctx, &oneByteReader{bytes.NewReader(make([]byte, test.Size))}, test.Total)
+			_, err := io.Copy(ioutil.Discard, r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected progress (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReaderWithoutReporter(t *testing.T) {
+	r := bytes.NewReader(nil)
+	if NewReader(context.Background(), r, 0) != io.Reader(r) {
+		t.Error("expected the reader to be returned as is if there is no reporter")
+	}
+}
+
+// oneByteReader reads a single byte at a time
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (r *oneByteReader) Read(p []byte) (int, error) {
+	if len(p) > 1 {
+		p = p[:1]
+	}
+	return r.r.Read(p)
+}
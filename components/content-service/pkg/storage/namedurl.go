@@ -8,6 +8,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/gitpod-io/gitpod/content-service/pkg/progress"
 	"golang.org/x/xerrors"
 )
 
@@ -40,7 +41,7 @@ func (d *NamedURLDownloader) Download(ctx context.Context, destination string, n
 	}
 	defer resp.Body.Close()
 
-	err = extractTarbal(destination, resp.Body)
+	err = extractTarbal(destination, progress.NewReader(ctx, resp.Body, resp.ContentLength))
 	if err != nil {
 		return true, err
 	}
@@ -8,25 +8,46 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"os"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
 	"github.com/spf13/cobra"
 )
 
+const (
+	awaitServed  = "served"
+	awaitExposed = "exposed"
+	awaitReady   = "ready"
+)
+
 var awaitPortCmd = &cobra.Command{
-	Use:   "await-port <port>",
+	Use:   "await-port <port|port-name>",
 	Short: "Waits for a process to listen on a port",
-	Args:  cobra.ExactArgs(1),
+	Long: `Waits for a process to listen on a port. Use --until to wait for more:
+    exposed  the port is exposed and has a URL
+    ready    the port answers HTTP requests to --path with a status below 500
+For example:
+    gp await-port 3000 --until ready --path /health --timeout 2m`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		port, err := strconv.ParseInt(args[0], 10, 32)
+		port, err := parsePort(args[0])
 		if err != nil {
-			log.Fatalf("port cannot be parsed as int: %s", err)
+			log.Fatalf("port cannot be resolved: %s", err)
 		}
 		if err := checkPortRange(port); err != nil {
 			log.Fatalf("port: %s", err)
 		}
+		until, _ := cmd.Flags().GetString("until")
+		path, _ := cmd.Flags().GetString("path")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if until != awaitServed && until != awaitExposed && until != awaitReady {
+			log.Fatalf("--until must be one of %s, %s or %s", awaitServed, awaitExposed, awaitReady)
+		}
 
 		// Expected format: local port (in hex), remote address (irrelevant here), connection state ("0A" is "TCP_LISTEN")
 		pattern, err := regexp.Compile(fmt.Sprintf(":[0]*%X \\w+:\\w+ 0A ", port))
@@ -34,29 +55,66 @@ var awaitPortCmd = &cobra.Command{
 			log.Fatal("cannot compile regexp pattern")
 		}
 
+		var deadline <-chan time.Time
+		if timeout > 0 {
+			deadline = time.After(timeout)
+		}
 		fmt.Printf("Awaiting port %d... ", port)
 		for {
-			tcp, err := ioutil.ReadFile("/proc/net/tcp")
-			if err != nil {
-				log.Fatalf("cannot read /proc/net/tcp: %s", err)
-			}
-
-			tcp6, err := ioutil.ReadFile("/proc/net/tcp6")
-			if err != nil {
-				log.Fatalf("cannot read /proc/net/tcp6: %s", err)
-			}
-
-			if pattern.MatchString(string(tcp)) || pattern.MatchString(string(tcp6)) {
+			if portServed(pattern) && (until == awaitServed || portAwaited(port, until, path)) {
 				break
 			}
 
-			time.Sleep(2 * time.Second)
+			select {
+			case <-deadline:
+				fmt.Println("timed out")
+				fmt.Fprintf(os.Stderr, "port %d is not %s after %s\n", port, until, timeout)
+				os.Exit(1)
+			case <-time.After(2 * time.Second):
+			}
 		}
 
 		fmt.Println("ok")
 	},
 }
 
+// portServed returns true if a process listens on the port matched by pattern
+func portServed(pattern *regexp.Regexp) bool {
+	tcp, err := ioutil.ReadFile("/proc/net/tcp")
+	if err != nil {
+		log.Fatalf("cannot read /proc/net/tcp: %s", err)
+	}
+
+	tcp6, err := ioutil.ReadFile("/proc/net/tcp6")
+	if err != nil {
+		log.Fatalf("cannot read /proc/net/tcp6: %s", err)
+	}
+
+	return pattern.MatchString(string(tcp)) || pattern.MatchString(string(tcp6))
+}
+
+// portAwaited returns true if a served port is exposed or ready
+func portAwaited(port int64, until, path string) bool {
+	if until == awaitExposed {
+		resolved, err := supervisor.ResolvePort(strconv.FormatInt(port, 10))
+		return err == nil && resolved.URL != ""
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://localhost:%d%s", port, path))
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
 func init() {
 	rootCmd.AddCommand(awaitPortCmd)
+	awaitPortCmd.Flags().String("until", awaitServed, "what to wait for: served, exposed or ready")
+	awaitPortCmd.Flags().String("path", "/", "path to request when waiting until the port is ready")
+	awaitPortCmd.Flags().Duration("timeout", 0, "maximum time to wait, e.g. 2m; waits forever if 0")
 }
@@ -0,0 +1,51 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/spf13/cobra"
+)
+
+var certificateHosts []string
+
+// certificateCmd represents the certificate command
+var certificateCmd = &cobra.Command{
+	Use:   "certificate <port>",
+	Short: "Issues a TLS certificate for serving HTTPS on a port",
+	Long: `Issues a TLS certificate which is valid for the URL of a port and for
+localhost, and prints where the certificate and its key are. The certificate
+is signed by the CA of this workspace, which the system trusts. For example
+    gp certificate 3000
+followed by
+    npx http-server -S -C <certificate> -K <key> -p 3000`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		port, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s is not a port\n", args[0])
+			os.Exit(1)
+		}
+		cert, err := supervisor.GetCertificate(port, certificateHosts)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Printf("certificate: %s\n", cert.CertFile)
+		fmt.Printf("key:         %s\n", cert.KeyFile)
+		fmt.Printf("CA:          %s\n", cert.CaFile)
+		fmt.Fprintf(os.Stderr, "Valid for %s until %s.\n", strings.Join(cert.Hosts, ", "), cert.NotAfter.Format("2006-01-02"))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(certificateCmd)
+	certificateCmd.Flags().StringSliceVar(&certificateHosts, "host", nil, "additional host name or IP the certificate is valid for")
+}
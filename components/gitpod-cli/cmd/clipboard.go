@@ -0,0 +1,73 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/spf13/cobra"
+)
+
+// clipboardCmd represents the clipboard command
+var clipboardCmd = &cobra.Command{
+	Use:   "clipboard",
+	Short: "Copies to and pastes from the clipboard shared with your IDE",
+	Long: `Copies to and pastes from the clipboard shared between this workspace
+and the clients connected to it, e.g. in SSH sessions:
+    echo hello | gp clipboard copy
+    gp clipboard paste`,
+}
+
+// clipboardCopyCmd represents the clipboard copy command
+var clipboardCopyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copies stdin to the clipboard",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		mimeType, _ := cmd.Flags().GetString("mime-type")
+		err = supervisor.SetClipboard(supervisor.ClipboardContent{
+			MimeType: mimeType,
+			Data:     data,
+			Source:   "gp",
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	},
+}
+
+// clipboardPasteCmd represents the clipboard paste command
+var clipboardPasteCmd = &cobra.Command{
+	Use:   "paste",
+	Short: "Writes the clipboard to stdout",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		content, err := supervisor.GetClipboard()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if content == nil {
+			return
+		}
+		os.Stdout.Write(content.Data)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(clipboardCmd)
+	clipboardCmd.AddCommand(clipboardCopyCmd)
+	clipboardCmd.AddCommand(clipboardPasteCmd)
+	clipboardCopyCmd.Flags().String("mime-type", "text/plain", "type of the copied data")
+}
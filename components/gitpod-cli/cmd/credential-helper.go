@@ -7,6 +7,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/exec"
@@ -14,19 +15,20 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/theialib"
 	"github.com/spf13/cobra"
 )
 
 var credentialHelper = &cobra.Command{
-	Use:    "credential-helper get",
+	Use:    "credential-helper get|erase",
 	Short:  "Gitpod Credential Helper for Git",
-	Long:   "Supports reading of credentials per host.",
+	Long:   "Supports reading of credentials per host. Credentials the host rejected are erased from supervisor's cache.",
 	Args:   cobra.MinimumNArgs(1),
 	Hidden: true,
 	Run: func(cmd *cobra.Command, args []string) {
 		action := args[0]
-		if action != "get" {
+		if action != "get" && action != "erase" {
 			return
 		}
 
@@ -37,11 +39,31 @@ var credentialHelper = &cobra.Command{
 		defer f.Close()
 		log.SetOutput(f)
 
-		url, gitCommand := parsePstree()
-		host := parseHostFromStdin()
+		input := parseCredentialInput(os.Stdin)
+		if action == "erase" {
+			if input["password"] == "" {
+				return
+			}
+			err := supervisor.ClearToken(input["password"])
+			if err != nil {
+				log.Println(err)
+			}
+			return
+		}
+
+		host := input["host"]
 		if len(host) == 0 {
 			log.Println("'host' is missing")
 		}
+		url, gitCommand := parsePstree()
+
+		// supervisor fetches the token of the host and caches it until it expires
+		tkn, err := supervisor.GetToken(host, []string{gitTokenScope(gitCommand)}, strings.TrimSpace(fmt.Sprintf("git %s %s", gitCommand, url)))
+		if err == nil {
+			fmt.Printf("username=%s\npassword=%s\n", tkn.User, tkn.Token)
+			return
+		}
+		log.Printf("cannot get token from supervisor, asking Theia: %v\n", err)
 
 		service, err := theialib.NewServiceFromEnv()
 		if err != nil {
@@ -61,24 +83,34 @@ var credentialHelper = &cobra.Command{
 	},
 }
 
-func parseHostFromStdin() string {
-	host := ""
-	scanner := bufio.NewScanner(os.Stdin)
+// gitTokenScope returns the token scope which tells what a Git command uses the token for. The token supervisor
+// provides is the same for either scope.
+func gitTokenScope(gitCommand string) string {
+	if gitCommand == "push" {
+		return "git:write"
+	}
+	return "git:read"
+}
+
+// parseCredentialInput parses the key=value lines Git passes to credential helpers
+func parseCredentialInput(in io.Reader) map[string]string {
+	res := make(map[string]string)
+	scanner := bufio.NewScanner(in)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if len(line) > 0 {
-			tuple := strings.Split(line, "=")
-			if len(tuple) == 2 {
-				if strings.TrimSpace(tuple[0]) == "host" {
-					host = strings.TrimSpace(tuple[1])
-				}
-			}
+		if len(line) == 0 {
+			// a blank line ends the input
+			break
+		}
+		tuple := strings.SplitN(line, "=", 2)
+		if len(tuple) == 2 {
+			res[strings.TrimSpace(tuple[0])] = strings.TrimSpace(tuple[1])
 		}
 	}
 	if err := scanner.Err(); err != nil {
 		log.Println(err)
 	}
-	return host
+	return res
 }
 
 func logDebug(v ...interface{}) {
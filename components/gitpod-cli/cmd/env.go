@@ -9,7 +9,7 @@ import (
 	"os"
 	"strings"
 
-	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/theialib"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
 	"github.com/spf13/cobra"
 )
 
@@ -20,24 +20,23 @@ var unsetEnvs = false
 var envCmd = &cobra.Command{
 	Use:   "env",
 	Short: "Controls user-defined, persistent environment variables.",
-	Long: `This command can print and modify the persistent environment variables associated with your user, for this repository.
+	Long: `This command can print and modify the persistent environment variables associated with your user.
 
-To set the persistent environment variable 'foo' to the value 'bar' use:
+To set the persistent environment variable 'foo' to the value 'bar' for this repository use:
 	gp env foo=bar
 
-Beware that this does not modify your current terminal session, but rather persists this variable for the next workspace on this repository.
-This command can only interact with environment variables for this repository. If you want to set that environment variable in your terminal,
-you can do so using -e:
-	eval $(gp env -e foo=bar)
+The variable is persisted for the next workspaces on this repository, and applies to this workspace right away:
+new terminals get it, and running terminals pick it up before their next prompt. Variables for other or all of
+your repositories can be set in the settings of your Gitpod account.
 
-To update the current terminal session with the latest set of persistent environment variables, use:
-    eval $(gp env -e)
+To print the variables in a form that can be eval'ed in a shell, e.g. in a script which does not run in a terminal, use -e:
+	eval $(gp env -e)
 
 To delete a persistent environment variable use:
 	gp env -u foo
 
-Note that you can delete/unset variables if their repository pattern matches the repository of this workspace exactly. I.e. you cannot
-delete environment variables with a repository pattern of */foo, foo/* or */*.
+Note that you can delete/unset variables if their repository pattern matches the repository of this workspace exactly.
+You cannot delete environment variables with a repository pattern of */*, */foo or foo/*.
 `,
 	Args: cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -46,15 +45,10 @@ delete environment variables with a repository pattern of */foo, foo/* or */*.
 			os.Exit(-1)
 		}
 
-		service, err := theialib.NewServiceFromEnv()
-		if err != nil {
-			fail(err.Error())
-		}
-
 		setEnvs := func() {
-			vars := make([]theialib.EnvironmentVariable, len(args))
+			vars := make([]supervisor.EnvVar, len(args))
 			for i, arg := range args {
-				kv := strings.Split(arg, "=")
+				kv := strings.SplitN(arg, "=", 2)
 				if len(kv) != 2 {
 					fail(fmt.Sprintf("%s has no value (correct format is %s=some_value)", arg, arg))
 				}
@@ -64,18 +58,17 @@ delete environment variables with a repository pattern of */foo, foo/* or */*.
 					fail(fmt.Sprintf("variable must have a name"))
 				}
 				// Do not trim value - the user might want whitespace here
-				// Also do not check if the value is empty, as an empty value means we want to delete the variable
 				val := kv[1]
 				if val == "" {
 					fail(fmt.Sprintf("variable must have a value; use -u to unset a variable"))
 				}
 
-				vars[i] = theialib.EnvironmentVariable{Name: key, Value: val}
+				vars[i] = supervisor.EnvVar{Name: key, Value: val}
 			}
 
-			_, err = service.SetEnvVar(theialib.SetEnvvarRequest{Variables: vars})
+			err := supervisor.SetEnvVars(vars, supervisor.EnvVarScopeProject)
 			if err != nil {
-				fail(fmt.Sprintf("cannot set environment variables: %v", err))
+				fail(err.Error())
 			}
 
 			for _, v := range vars {
@@ -83,23 +76,23 @@ delete environment variables with a repository pattern of */foo, foo/* or */*.
 			}
 		}
 		getEnvs := func() {
-			vars, err := service.GetEnvVars(theialib.GetEnvvarsRequest{})
+			vars, err := supervisor.ListEnvVars()
 			if err != nil {
-				fail(fmt.Sprintf("cannot get environment variables: %v", err))
+				fail(err.Error())
 			}
 
-			for _, v := range vars.Variables {
+			for _, v := range vars {
 				printVar(v, exportEnvs)
 			}
 		}
 		doUnsetEnvs := func() {
-			resp, err := service.DeleteEnvVar(theialib.DeleteEnvvarRequest{Variables: args})
+			notUnset, err := supervisor.UnsetEnvVars(args, supervisor.EnvVarScopeProject)
 			if err != nil {
-				fail(fmt.Sprintf("cannot unset environment variables: %v", err))
+				fail(err.Error())
 			}
 
-			if len(resp.NotDeleted) != 0 {
-				fail(fmt.Sprintf("cannot unset environment variables: %s", strings.Join(resp.NotDeleted, ", ")))
+			if len(notUnset) != 0 {
+				fail(fmt.Sprintf("cannot unset environment variables: %s", strings.Join(notUnset, ", ")))
 			}
 		}
 
@@ -116,7 +109,7 @@ delete environment variables with a repository pattern of */foo, foo/* or */*.
 	},
 }
 
-func printVar(v theialib.EnvironmentVariable, export bool) {
+func printVar(v supervisor.EnvVar, export bool) {
 	val := strings.Replace(v.Value, "\"", "\\\"", -1)
 	if export {
 		fmt.Printf("export %s=\"%s\"\n", v.Name, val)
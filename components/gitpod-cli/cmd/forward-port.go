@@ -13,6 +13,7 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
 	"github.com/google/tcpproxy"
 	"github.com/gorilla/handlers"
 	"github.com/spf13/cobra"
@@ -21,14 +22,14 @@ import (
 var rewriteHostHeader bool
 
 var portFwdCmd = &cobra.Command{
-	Use:   "forward-port <local-port> [target-port]",
+	Use:   "forward-port <local-port|port-name> [target-port]",
 	Short: "Makes a port available on 0.0.0.0 so that it can be exposed to the internet",
 	Long:  ``,
 	Args:  cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
-		srcp, err := strconv.ParseInt(args[0], 10, 32)
+		srcp, err := parsePort(args[0])
 		if err != nil {
-			log.Fatalf("local-port cannot be parsed as int: %s", err)
+			log.Fatalf("local-port cannot be resolved: %s", err)
 			os.Exit(-1)
 			return
 		}
@@ -88,6 +89,19 @@ func checkPortRange(prt int64) error {
 	return nil
 }
 
+// parsePort parses a port number, or resolves the name of a port as configured in the .gitpod.yml
+func parsePort(arg string) (int64, error) {
+	port, err := strconv.ParseInt(arg, 10, 32)
+	if err == nil {
+		return port, nil
+	}
+	resolved, err := supervisor.ResolvePort(arg)
+	if err != nil {
+		return 0, err
+	}
+	return int64(resolved.LocalPort), nil
+}
+
 func init() {
 	rootCmd.AddCommand(portFwdCmd)
 	portFwdCmd.Flags().BoolVarP(&rewriteHostHeader, "rewrite-host-header", "r", false, "rewrites the host header of passing HTTP requests to localhost")
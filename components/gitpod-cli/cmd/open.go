@@ -5,10 +5,15 @@
 package cmd
 
 import (
+	"fmt"
 	"log"
+	"net/url"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
 	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/theialib"
 	"github.com/spf13/cobra"
 )
@@ -17,50 +22,74 @@ import (
 var openCmd = &cobra.Command{
 	Use:   "open <filename>",
 	Short: "Opens a file in Gitpod",
-	Long:  ``,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Opens files in the IDE you work in, be it in the browser or on the desktop.
+If no IDE is connected to the workspace, you are notified instead.`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		wait, _ := cmd.Flags().GetBool("wait")
-
-		service, err := theialib.NewServiceFromEnv()
-		if err != nil {
-			log.Fatal(err)
+		if wait {
+			openAndWait(args)
+			return
 		}
 
-		var wg sync.WaitGroup
+		var failed bool
 		for _, fn := range args {
-			_, err := service.OpenFile(theialib.OpenFileRequest{Path: fn})
+			path, err := filepath.Abs(fn)
 			if err != nil {
-				log.Println(err)
+				fmt.Fprintln(os.Stderr, err)
+				failed = true
 				continue
 			}
+			_, err = supervisor.Open((&url.URL{Scheme: "file", Path: path}).String(), supervisor.OpenTargetEditor)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed = true
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// openAndWait opens the files in Theia, which is the only IDE that tells whether a file is still open
+func openAndWait(files []string) {
+	service, err := theialib.NewServiceFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
 
-			if wait {
-				wg.Add(1)
-				go func(fn string) {
-					defer wg.Done()
+	var wg sync.WaitGroup
+	for _, fn := range files {
+		_, err := service.OpenFile(theialib.OpenFileRequest{Path: fn})
+		if err != nil {
+			log.Println(err)
+			continue
+		}
 
-					for {
-						resp, err := service.IsFileOpen(theialib.IsFileOpenRequest{Path: fn})
-						if err != nil {
-							log.Fatal(err)
-							return
-						}
-						if !resp.IsOpen {
-							return
-						}
+		wg.Add(1)
+		go func(fn string) {
+			defer wg.Done()
 
-						time.Sleep(1 * time.Second)
-					}
-				}(fn)
+			for {
+				resp, err := service.IsFileOpen(theialib.IsFileOpenRequest{Path: fn})
+				if err != nil {
+					log.Fatal(err)
+					return
+				}
+				if !resp.IsOpen {
+					return
+				}
+
+				time.Sleep(1 * time.Second)
 			}
-		}
+		}(fn)
+	}
 
-		wg.Wait()
-	},
+	wg.Wait()
 }
 
 func init() {
 	rootCmd.AddCommand(openCmd)
-	openCmd.Flags().BoolP("wait", "w", false, "wait until all opened files are closed again")
+	openCmd.Flags().BoolP("wait", "w", false, "wait until all opened files are closed again (Theia only)")
 }
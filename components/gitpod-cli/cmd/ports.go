@@ -0,0 +1,174 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/spf13/cobra"
+)
+
+// portsCmd represents the ports command
+var portsCmd = &cobra.Command{
+	Use:   "ports",
+	Short: "Lists and controls the ports of this workspace",
+	Long: `Lists and controls the ports of this workspace. Ports can be referred to
+by their number or by their name as configured in the .gitpod.yml, e.g.
+    gp ports visibility api public`,
+}
+
+// portsListCmd represents the ports list command
+var portsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the ports of this workspace",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ports, err := supervisor.ListPorts()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(ports)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "PORT\tNAME\tSTATUS\tVISIBILITY\tURL")
+		for _, p := range ports {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", p.LocalPort, p.Name, portState(p), portVisibility(p), portURL(p))
+		}
+		w.Flush()
+	},
+}
+
+func portState(p *supervisor.PortStatus) string {
+	switch {
+	case p.PolicyViolation != "":
+		return "denied"
+	case p.Served && p.Exposed != nil:
+		return "open"
+	case p.Served:
+		return "served"
+	case p.Exposed != nil:
+		return "not served"
+	default:
+		return "closed"
+	}
+}
+
+func portVisibility(p *supervisor.PortStatus) string {
+	if p.PendingPublic {
+		return "awaiting approval"
+	}
+	if p.Exposed == nil {
+		return "-"
+	}
+	return p.Exposed.Visibility
+}
+
+func portURL(p *supervisor.PortStatus) string {
+	if p.Exposed == nil {
+		return ""
+	}
+	return p.Exposed.URL
+}
+
+// portsExposeCmd represents the ports expose command
+var portsExposeCmd = &cobra.Command{
+	Use:   "expose <port|port-name> [target-port]",
+	Short: "Exposes a port of this workspace",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		port := portArg(args[0])
+		var target int64
+		if len(args) > 1 {
+			var err error
+			target, err = strconv.ParseInt(args[1], 10, 32)
+			if err == nil {
+				err = checkPortRange(target)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid target port %q: %s\n", args[1], err)
+				os.Exit(1)
+			}
+		}
+		err := supervisor.ExposePort(port, uint32(target))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	},
+}
+
+// portsCloseCmd represents the ports close command
+var portsCloseCmd = &cobra.Command{
+	Use:   "close <port|port-name>",
+	Short: "Closes an exposed port of this workspace",
+	Long: `Closes an exposed port of this workspace. The port is not exposed
+automatically anymore until it is exposed again using gp ports expose.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := supervisor.ClosePort(portArg(args[0]))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	},
+}
+
+// portsVisibilityCmd represents the ports visibility command
+var portsVisibilityCmd = &cobra.Command{
+	Use:       "visibility <port|port-name> <public|private>",
+	Short:     "Makes an exposed port public or private",
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: []string{"public", "private"},
+	Run: func(cmd *cobra.Command, args []string) {
+		port := portArg(args[0])
+		if args[1] != "public" && args[1] != "private" {
+			fmt.Fprintf(os.Stderr, "visibility must be public or private, not %q\n", args[1])
+			os.Exit(1)
+		}
+		err := supervisor.SetPortVisibility(port, args[1] == "public")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	},
+}
+
+// portArg parses a port number or name argument, or exits
+func portArg(arg string) uint32 {
+	port, err := parsePort(arg)
+	if err == nil {
+		err = checkPortRange(port)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "port %q is neither a valid number nor a port name: %s\n", arg, err)
+		os.Exit(1)
+	}
+	return uint32(port)
+}
+
+func init() {
+	rootCmd.AddCommand(portsCmd)
+	portsCmd.AddCommand(portsListCmd)
+	portsCmd.AddCommand(portsExposeCmd)
+	portsCmd.AddCommand(portsCloseCmd)
+	portsCmd.AddCommand(portsVisibilityCmd)
+	portsListCmd.Flags().Bool("json", false, "print the ports as JSON")
+}
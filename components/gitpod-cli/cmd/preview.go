@@ -5,12 +5,13 @@
 package cmd
 
 import (
-	"log"
+	"fmt"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
 
-	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/theialib"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
 
 	"github.com/spf13/cobra"
 )
@@ -20,25 +21,24 @@ var regexLocalhost = regexp.MustCompile("((^(localhost|127\\.0\\.0\\.1))|(https?
 // previewCmd represents the preview command
 var previewCmd = &cobra.Command{
 	Use:   "preview <url>",
-	Short: "Opens a URL in Theia's preview view",
-	Args:  cobra.ExactArgs(1),
+	Short: "Opens a URL in the preview of your IDE",
+	Long: `Opens a URL in the preview of the IDE you work in. URLs pointing to
+localhost are replaced with the public URL of the port. If no IDE is connected
+to the workspace, you are notified instead.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		service, err := theialib.NewServiceFromEnv()
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		url := args[0]
-		url = replaceLocalhostInURL(service, url)
+		url := replaceLocalhostInURL(supervisor.PortURL, args[0])
 
-		_, err = service.OpenPreview(theialib.OpenPreviewRequest{URL: url})
+		_, err := supervisor.Open(url, supervisor.OpenTargetPreview)
 		if err != nil {
-			log.Fatal(err)
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
 	},
 }
 
-func replaceLocalhostInURL(service theialib.TheiaCLIService, url string) string {
+// replaceLocalhostInURL replaces localhost in url with the public URL of the port as returned by portURL
+func replaceLocalhostInURL(portURL func(port uint16) (string, error), url string) string {
 	return regexLocalhost.ReplaceAllStringFunc(url, func(input string) string {
 		hasScheme := strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://")
 		input = strings.TrimPrefix(strings.TrimPrefix(input, "http://"), "https://")
@@ -49,12 +49,11 @@ func replaceLocalhostInURL(service theialib.TheiaCLIService, url string) string
 			port, _ = strconv.Atoi(strings.TrimPrefix(segs[1], ":"))
 		}
 
-		resp, err := service.GetPortURL(theialib.GetPortURLRequest{Port: uint16(port)})
+		result, err := portURL(uint16(port))
 		if err != nil {
 			return input
 		}
 
-		result := resp.URL
 		if !hasScheme {
 			result = strings.TrimPrefix(strings.TrimPrefix(result, "http://"), "https://")
 		}
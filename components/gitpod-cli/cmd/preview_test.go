@@ -7,9 +7,6 @@ package cmd
 import (
 	"os"
 	"testing"
-
-	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/theialib"
-	"github.com/golang/mock/gomock"
 )
 
 func TestReplaceLocalhostInURL(t *testing.T) {
@@ -51,13 +48,14 @@ func TestReplaceLocalhostInURL(t *testing.T) {
 	os.Setenv("GITPOD_WORKSPACE_URL", "https://workspace-url")
 	for _, test := range tests {
 		t.Run(test.Desc, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			srv := theialib.NewMockTheiaCLIService(ctrl)
-			srv.EXPECT().GetPortURL(gomock.Eq(theialib.GetPortURLRequest{Port: test.ExpectedPort})).Return(&theialib.GetPortURLResponse{URL: test.PortURL}, nil).AnyTimes()
+			portURL := func(port uint16) (string, error) {
+				if port != test.ExpectedPort {
+					t.Errorf("unexpected port: %d, expected %d", port, test.ExpectedPort)
+				}
+				return test.PortURL, nil
+			}
 
-			act := replaceLocalhostInURL(srv, test.Input)
+			act := replaceLocalhostInURL(portURL, test.Input)
 			if act != test.Expectation {
 				t.Errorf("unexpected result: %s, expected %s", act, test.Expectation)
 			}
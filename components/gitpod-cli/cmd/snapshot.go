@@ -0,0 +1,44 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/spf13/cobra"
+)
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Takes a snapshot of this workspace and prints the URL to share it",
+	Long: `Takes a snapshot of this workspace and prints the URL to share it. Anyone
+who opens the URL starts their own copy of the workspace. The onSnapshot hook
+of the .gitpod.yml runs before the snapshot is taken.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		skipHook, _ := cmd.Flags().GetBool("skip-hook")
+		res, err := supervisor.TakeSnapshot(skipHook, func(p *supervisor.SnapshotProgress) {
+			switch p.Phase {
+			case supervisor.SnapshotPhaseHook:
+				fmt.Fprintln(os.Stderr, "Running the onSnapshot hook ...")
+			default:
+				fmt.Fprintf(os.Stderr, "Taking snapshot ... (%ds)\n", p.ElapsedSeconds)
+			}
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(res.URL)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.Flags().Bool("skip-hook", false, "do not run the onSnapshot hook")
+}
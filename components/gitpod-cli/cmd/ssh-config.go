@@ -0,0 +1,121 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/spf13/cobra"
+)
+
+// sshConfigCmd represents the ssh-config command
+var sshConfigCmd = &cobra.Command{
+	Use:   "ssh-config",
+	Short: "Prints an SSH config entry to connect to this workspace from your machine",
+	Long: `Prints an SSH config entry for this workspace. Add it to the ~/.ssh/config on your machine
+to connect with ssh, scp, rsync or IDEs which work over SSH, e.g.
+    ssh <workspace-id>
+
+The SSH port of the workspace is not exposed. Connections are tunneled through the workspace URL
+instead, using websocat (https://github.com/vi/websocat) on your machine as proxy command. The proxy
+command carries the owner token of the workspace, so keep the entry to yourself. Pass another proxy
+command with --proxy-command, its %h and %p refer to the host name and the SSH port of the workspace.
+You authenticate with an SSH key registered with your Gitpod account.
+
+To trust the workspace, add its host key to the ~/.ssh/known_hosts on your machine - print it using
+    gp ssh-config --known-hosts`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		info, err := supervisor.GetWorkspaceInfo()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if info.SSH == nil {
+			fmt.Fprintln(os.Stderr, "SSH is not enabled in this workspace")
+			os.Exit(1)
+		}
+
+		alias, _ := cmd.Flags().GetString("host")
+		if alias == "" {
+			alias = info.WorkspaceID
+		}
+		if knownHosts, _ := cmd.Flags().GetBool("known-hosts"); knownHosts {
+			fmt.Printf("%s %s\n", alias, info.SSH.HostKey)
+			return
+		}
+
+		wsURL, err := url.Parse(os.Getenv("GITPOD_WORKSPACE_URL"))
+		if err != nil || wsURL.Host == "" {
+			fmt.Fprintln(os.Stderr, "GITPOD_WORKSPACE_URL is not set")
+			os.Exit(1)
+		}
+		login, _ := cmd.Flags().GetString("user")
+		if login == "" {
+			if u, err := user.Current(); err == nil {
+				login = u.Username
+			}
+		}
+		proxyCommand, _ := cmd.Flags().GetString("proxy-command")
+		if proxyCommand == "" {
+			if info.SSH.TunnelPath == "" || info.SSH.TunnelCookie == "" {
+				fmt.Fprintln(os.Stderr, "this workspace cannot tunnel SSH through its URL, pass the command which connects to the SSH port with --proxy-command")
+				os.Exit(1)
+			}
+			proxyCommand = tunnelProxyCommand(wsURL, info.SSH.TunnelPath, info.SSH.TunnelCookie)
+		}
+		identityFile, _ := cmd.Flags().GetString("identity-file")
+		fmt.Print(sshConfig(alias, wsURL.Hostname(), info.SSH.Port, proxyCommand, login, identityFile, info.SSH.HostKey))
+	},
+}
+
+// sshConfig renders the SSH config entry of a workspace. The host key alias lets known_hosts refer to the
+// workspace by its alias rather than by its host name.
+func sshConfig(alias, hostName string, port uint32, proxyCommand, login, identityFile, hostKey string) string {
+	var res strings.Builder
+	fmt.Fprintf(&res, "Host %s\n", alias)
+	fmt.Fprintf(&res, "    HostName %s\n", hostName)
+	fmt.Fprintf(&res, "    Port %d\n", port)
+	if login != "" {
+		fmt.Fprintf(&res, "    User %s\n", login)
+	}
+	fmt.Fprintf(&res, "    HostKeyAlias %s\n", alias)
+	fmt.Fprintf(&res, "    ProxyCommand %s\n", proxyCommand)
+	if identityFile != "" {
+		fmt.Fprintf(&res, "    IdentityFile %s\n", identityFile)
+		res.WriteString("    IdentitiesOnly yes\n")
+	}
+	if hostKey != "" {
+		res.WriteString("# add the host key to your ~/.ssh/known_hosts:\n")
+		fmt.Fprintf(&res, "# %s %s\n", alias, hostKey)
+	}
+	return res.String()
+}
+
+// tunnelProxyCommand returns the websocat command which tunnels SSH through the workspace URL. The owner cookie
+// makes the workspace proxy admit the connection. Percent signs are escaped since SSH expands them in proxy commands.
+func tunnelProxyCommand(wsURL *url.URL, tunnelPath, cookie string) string {
+	scheme := "wss"
+	if wsURL.Scheme == "http" {
+		scheme = "ws"
+	}
+	cmd := fmt.Sprintf("websocat --binary -H 'Cookie: %s' %s://%s%s", cookie, scheme, wsURL.Host, tunnelPath)
+	return strings.ReplaceAll(cmd, "%", "%%")
+}
+
+func init() {
+	rootCmd.AddCommand(sshConfigCmd)
+
+	sshConfigCmd.Flags().String("host", "", "host alias to connect with (defaults to the workspace ID)")
+	sshConfigCmd.Flags().String("user", "", "user to log in as (defaults to the current user)")
+	sshConfigCmd.Flags().String("proxy-command", "", "command on your machine which connects to the SSH port of the workspace (defaults to tunneling through the workspace URL)")
+	sshConfigCmd.Flags().StringP("identity-file", "i", "", "private key on your machine to authenticate with, e.g. ~/.ssh/id_ed25519")
+	sshConfigCmd.Flags().Bool("known-hosts", false, "print the known_hosts entry of the workspace instead")
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSSHConfig(t *testing.T) {
+	tests := []struct {
+		Desc         string
+		ProxyCommand string
+		Expectation  string
+	}{
+		{
+			Desc:         "tunnel through the workspace URL",
+			ProxyCommand: tunnelProxyCommand(&url.URL{Scheme: "https", Host: "amber-dog-1234.ws-eu.gitpod.io"}, "/_supervisor/v1/ssh/tunnel", "_gitpod_io_ws_a3b5c7_owner_=%257J%27"),
+			Expectation: `Host amber-dog-1234
+    HostName amber-dog-1234.ws-eu.gitpod.io
+    Port 23001
+    User gitpod
+    HostKeyAlias amber-dog-1234
+    ProxyCommand websocat --binary -H 'Cookie: _gitpod_io_ws_a3b5c7_owner_=%%257J%%27' wss://amber-dog-1234.ws-eu.gitpod.io/_supervisor/v1/ssh/tunnel
+    IdentityFile ~/.ssh/id_ed25519
+    IdentitiesOnly yes
+# add the host key to your ~/.ssh/known_hosts:
+# amber-dog-1234 ssh-ed25519 AAAAC3
+`,
+		},
+		{
+			Desc:         "with proxy command",
+			ProxyCommand: "my-tunnel %h %p",
+			Expectation: `Host amber-dog-1234
+    HostName amber-dog-1234.ws-eu.gitpod.io
+    Port 23001
+    User gitpod
+    HostKeyAlias amber-dog-1234
+    ProxyCommand my-tunnel %h %p
+    IdentityFile ~/.ssh/id_ed25519
+    IdentitiesOnly yes
+# add the host key to your ~/.ssh/known_hosts:
+# amber-dog-1234 ssh-ed25519 AAAAC3
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := sshConfig("amber-dog-1234", "amber-dog-1234.ws-eu.gitpod.io", 23001, test.ProxyCommand, "gitpod", "~/.ssh/id_ed25519", "ssh-ed25519 AAAAC3")
+			if act != test.Expectation {
+				t.Errorf("unexpected config:\n%s\nexpected:\n%s", act, test.Expectation)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/spf13/cobra"
+)
+
+// stopCmd represents the stop command
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stops this workspace",
+	Long: `Stops this workspace, e.g. at the end of a job which runs in it. A non-zero
+exit code fails the workspace, s.t. the dashboard shows the exit code and message:
+    gp stop --exit-code 1 --message "tests failed"`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		exitCode, _ := cmd.Flags().GetUint32("exit-code")
+		message, _ := cmd.Flags().GetString("message")
+		err := supervisor.StopWorkspace(exitCode, message)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(stopCmd)
+	stopCmd.Flags().Uint32("exit-code", 0, "exit status of the workspace, non-zero fails it")
+	stopCmd.Flags().StringP("message", "m", "", "message explaining the exit code")
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/manifoldco/promptui"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// detachKey detaches from a task's terminal, it is Ctrl+] as in telnet
+const detachKey = 0x1d
+
+// tasksCmd represents the tasks command
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Lists and controls the tasks of this workspace",
+	Long: `Lists and controls the tasks of this workspace, as configured in the .gitpod.yml.
+Tasks can be referred to by their ID or by their name, e.g.
+    gp tasks attach backend`,
+}
+
+// tasksListCmd represents the tasks list command
+var tasksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the tasks of this workspace and their state",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		tasks, err := supervisor.ListTasks()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(tasks)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSTATE\tTERMINAL")
+		for _, t := range tasks {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.ID, t.Presentation.Name, taskState(t), t.Terminal)
+		}
+		w.Flush()
+	},
+}
+
+// tasksAttachCmd represents the tasks attach command
+var tasksAttachCmd = &cobra.Command{
+	Use:   "attach [id|name]",
+	Short: "Attaches to the terminal of a task",
+	Long: `Attaches to the terminal of a running task, which shows its output and forwards your input.
+Without a task, you are asked which of the running tasks to attach to.
+Press Ctrl+] to detach - the task keeps running.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tasks, err := supervisor.ListTasks()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		var task *supervisor.TaskStatus
+		if len(args) > 0 {
+			task, err = findTask(tasks, args[0])
+		} else {
+			task, err = selectRunningTask(tasks)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if task.State != supervisor.TaskStateRunning || task.Terminal == "" {
+			fmt.Fprintf(os.Stderr, "task %s is not running\n", taskDisplayName(task))
+			os.Exit(1)
+		}
+
+		fmt.Fprintf(os.Stderr, "Attached to task %s. Press Ctrl+] to detach.\n", taskDisplayName(task))
+		err = attachTerminal(task.Terminal)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	},
+}
+
+// tasksRestartCmd represents the tasks restart command
+var tasksRestartCmd = &cobra.Command{
+	Use:   "restart <id|name>",
+	Short: "Restarts a task",
+	Long: `Re-runs the command of a task in a fresh terminal, e.g. after it failed.
+A task which is still running is only restarted with --force, which closes its terminal first.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tasks, err := supervisor.ListTasks()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		task, err := findTask(tasks, args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		err = supervisor.RestartTask(task.ID, force)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Printf("restarted task %s\n", taskDisplayName(task))
+	},
+}
+
+func taskState(t *supervisor.TaskStatus) string {
+	if t.State == supervisor.TaskStateClosed && t.ExitCode >= 0 {
+		return fmt.Sprintf("%s (exit code %d)", t.State, t.ExitCode)
+	}
+	return t.State
+}
+
+func taskDisplayName(t *supervisor.TaskStatus) string {
+	if t.Presentation.Name != "" {
+		return t.Presentation.Name
+	}
+	return t.ID
+}
+
+// findTask finds a task by its ID, or by its name if the name is unique
+func findTask(tasks []*supervisor.TaskStatus, ref string) (*supervisor.TaskStatus, error) {
+	var byName []*supervisor.TaskStatus
+	for _, t := range tasks {
+		if t.ID == ref {
+			return t, nil
+		}
+		if t.Presentation.Name == ref {
+			byName = append(byName, t)
+		}
+	}
+	switch len(byName) {
+	case 0:
+		return nil, errors.Errorf("task %s does not exist", ref)
+	case 1:
+		return byName[0], nil
+	default:
+		return nil, errors.Errorf("there are %d tasks named %s - use the task ID instead", len(byName), ref)
+	}
+}
+
+// selectRunningTask asks which running task to use, unless there is only one
+func selectRunningTask(tasks []*supervisor.TaskStatus) (*supervisor.TaskStatus, error) {
+	var running []*supervisor.TaskStatus
+	for _, t := range tasks {
+		if t.State == supervisor.TaskStateRunning {
+			running = append(running, t)
+		}
+	}
+	switch len(running) {
+	case 0:
+		return nil, errors.New("no task is running")
+	case 1:
+		return running[0], nil
+	}
+
+	items := make([]string, len(running))
+	for i, t := range running {
+		items[i] = fmt.Sprintf("%s (%s)", taskDisplayName(t), t.ID)
+	}
+	prompt := promptui.Select{
+		Label: "Task",
+		Items: items,
+	}
+	idx, _, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+	return running[idx], nil
+}
+
+// attachTerminal shows the output of a terminal and forwards the input to it until the terminal is closed
+// or the user detaches
+func attachTerminal(alias string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fd := int(os.Stdin.Fd())
+	if terminal.IsTerminal(fd) {
+		state, err := terminal.MakeRaw(fd)
+		if err != nil {
+			return errors.Wrap(err, "cannot put terminal into raw mode")
+		}
+		defer terminal.Restore(fd, state)
+
+		resize := func() {
+			cols, rows, err := terminal.GetSize(fd)
+			if err != nil {
+				return
+			}
+			_ = supervisor.SetTerminalSize(alias, uint32(rows), uint32(cols))
+		}
+		resize()
+		winch := make(chan os.Signal, 1)
+		signal.Notify(winch, syscall.SIGWINCH)
+		defer signal.Stop(winch)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-winch:
+					resize()
+				}
+			}
+		}()
+	}
+
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				input := buf[:n]
+				detach := bytes.IndexByte(input, detachKey)
+				if detach >= 0 {
+					input = input[:detach]
+				}
+				if len(input) > 0 && supervisor.WriteTerminal(alias, input) != nil {
+					cancel()
+					return
+				}
+				if detach >= 0 {
+					cancel()
+					return
+				}
+			}
+			if err != nil {
+				// without input, e.g. if stdin is /dev/null, the output is still shown
+				return
+			}
+		}
+	}()
+
+	return supervisor.ListenTerminal(ctx, alias, os.Stdout)
+}
+
+func init() {
+	rootCmd.AddCommand(tasksCmd)
+	tasksCmd.AddCommand(tasksListCmd)
+	tasksCmd.AddCommand(tasksAttachCmd)
+	tasksCmd.AddCommand(tasksRestartCmd)
+
+	tasksListCmd.Flags().Bool("json", false, "print the tasks as JSON")
+	tasksRestartCmd.Flags().BoolP("force", "f", false, "restart the task even if it is still running")
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+)
+
+func TestFindTask(t *testing.T) {
+	task := func(id, name string) *supervisor.TaskStatus {
+		res := &supervisor.TaskStatus{ID: id}
+		res.Presentation.Name = name
+		return res
+	}
+	tasks := []*supervisor.TaskStatus{task("0", "backend"), task("1", "frontend"), task("2", "watch"), task("3", "watch")}
+
+	tests := []struct {
+		Desc        string
+		Ref         string
+		Expectation string
+	}{
+		{"by ID", "1", "1"},
+		{"by name", "backend", "0"},
+		{"ambiguous name", "watch", ""},
+		{"unknown", "docs", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act, err := findTask(tasks, test.Ref)
+			if test.Expectation == "" {
+				if err == nil {
+					t.Errorf("expected an error, got task %s", act.ID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if act.ID != test.Expectation {
+				t.Errorf("unexpected task: %s, expected %s", act.ID, test.Expectation)
+			}
+		})
+	}
+}
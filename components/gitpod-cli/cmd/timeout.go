@@ -0,0 +1,66 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
+	"github.com/spf13/cobra"
+)
+
+// timeoutCmd represents the timeout command
+var timeoutCmd = &cobra.Command{
+	Use:   "timeout",
+	Short: "Prints the inactivity timeout of this workspace",
+	Long: `Prints the inactivity timeout of this workspace, after which it stops
+unless someone works in it. Use
+    gp timeout extend
+to extend the timeout, if your plan allows for it.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		timeout, err := supervisor.GetTimeout()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(timeout.Timeout)
+		if timeout.CanExtend {
+			fmt.Fprintf(os.Stderr, "The timeout can be extended to %s using gp timeout extend.\n", strings.Join(timeout.Durations, ", "))
+		}
+	},
+}
+
+// timeoutExtendCmd represents the timeout extend command
+var timeoutExtendCmd = &cobra.Command{
+	Use:   "extend [duration]",
+	Short: "Extends the inactivity timeout of this workspace",
+	Long: `Extends the inactivity timeout of this workspace to the given duration,
+e.g. 60m, or to the longest timeout if no duration is given. Other running
+workspaces fall back to the default timeout.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var duration string
+		if len(args) > 0 {
+			duration = args[0]
+		}
+		res, err := supervisor.ExtendTimeout(duration)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Workspace timeout extended to %s.\n", res.Timeout)
+		if len(res.ResetWorkspaces) > 0 {
+			fmt.Printf("The timeout of %s was reset to the default.\n", strings.Join(res.ResetWorkspaces, ", "))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(timeoutCmd)
+	timeoutCmd.AddCommand(timeoutExtendCmd)
+}
@@ -6,54 +6,86 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 	"math"
+	"net/url"
 	"os"
-	"strconv"
 
-	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/theialib"
+	"github.com/gitpod-io/gitpod/gitpod-cli/pkg/supervisor"
 	"github.com/spf13/cobra"
 )
 
 // urlCmd represents the url command
 var urlCmd = &cobra.Command{
-	Use:   "url [port]",
+	Use:   "url [port|port-name]",
 	Short: "Prints the URL of this workspace",
 	Long: `Prints the URL of this workspace. This command can print the URL of
 the current workspace itself, or of a service running in this workspace on a
 particular port. For example:
     gp url 8080
-will print the URL of a service/server exposed on port 8080.`,
+will print the URL of a service/server exposed on port 8080. Ports which are
+named in the .gitpod.yml can be referred to by their name as well, e.g.
+    gp url api
+Use --scheme ws to print the URL for WebSocket clients.`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		scheme, _ := cmd.Flags().GetString("scheme")
+		if scheme != "" && scheme != "https" && scheme != "ws" {
+			fmt.Fprintf(os.Stderr, "scheme must be https or ws, not %q\n", scheme)
+			os.Exit(1)
+		}
+
 		if len(args) == 0 {
-			fmt.Println(os.Getenv("GITPOD_WORKSPACE_URL"))
+			fmt.Println(withScheme(os.Getenv("GITPOD_WORKSPACE_URL"), scheme))
 			return
 		}
 
-		port, err := strconv.Atoi(args[0])
+		// supervisor resolves port names and knows the URL ports are exposed at, including custom ones
+		resolved, err := supervisor.ResolvePort(args[0])
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "port \"%s\" is not a valid number\n", args[0])
-			return
+			fmt.Fprintf(os.Stderr, "port \"%s\" is neither a valid number nor a port name: %s\n", args[0], err)
+			os.Exit(1)
 		}
-		if port <= 0 || port > math.MaxUint16 {
+		port := resolved.LocalPort
+		if port == 0 || port > math.MaxUint16 {
 			fmt.Fprintf(os.Stderr, "port \"%s\" is out of range\n", args[0])
-			return
+			os.Exit(1)
 		}
 
-		service, err := theialib.NewServiceFromEnv()
-		if err != nil {
-			log.Fatal(err)
+		portURL := resolved.URL
+		if portURL == "" {
+			portURL, err = supervisor.PortURL(uint16(port))
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Port %d is not exposed yet, it will be available at this URL once it is.\n", port)
 		}
-		resp, err := service.GetPortURL(theialib.GetPortURLRequest{Port: uint16(port)})
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		fmt.Println(resp.URL)
+		fmt.Println(withScheme(portURL, scheme))
 	},
 }
 
+// withScheme changes the scheme of u. The ws scheme becomes wss for https URLs.
+func withScheme(u, scheme string) string {
+	if scheme == "" {
+		return u
+	}
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Scheme == "" {
+		return u
+	}
+	secure := parsed.Scheme == "https" || parsed.Scheme == "wss"
+	switch {
+	case scheme == "ws" && secure:
+		parsed.Scheme = "wss"
+	case scheme == "ws":
+		parsed.Scheme = "ws"
+	default:
+		parsed.Scheme = scheme
+	}
+	return parsed.String()
+}
+
 func init() {
 	rootCmd.AddCommand(urlCmd)
+	urlCmd.Flags().String("scheme", "", "scheme of the printed URL: https or ws")
 }
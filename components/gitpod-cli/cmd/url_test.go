@@ -0,0 +1,30 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import "testing"
+
+func TestWithScheme(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		URL         string
+		Scheme      string
+		Expectation string
+	}{
+		{"no scheme", "https://8080-workspace-url", "", "https://8080-workspace-url"},
+		{"https", "https://8080-workspace-url", "https", "https://8080-workspace-url"},
+		{"ws on https", "https://8080-workspace-url/path", "ws", "wss://8080-workspace-url/path"},
+		{"ws on http", "http://localhost:8080", "ws", "ws://localhost:8080"},
+		{"not a URL", "workspace-url", "ws", "workspace-url"},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := withScheme(test.URL, test.Scheme)
+			if act != test.Expectation {
+				t.Errorf("unexpected result: %s, expected %s", act, test.Expectation)
+			}
+		})
+	}
+}
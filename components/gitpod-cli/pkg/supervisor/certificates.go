@@ -0,0 +1,39 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Certificate is a development certificate of a port, signed by the CA of the workspace
+type Certificate struct {
+	CertFile string    `json:"certFile"`
+	KeyFile  string    `json:"keyFile"`
+	CaFile   string    `json:"caFile"`
+	Hosts    []string  `json:"hosts"`
+	NotAfter time.Time `json:"notAfter"`
+}
+
+// GetCertificate returns the certificate of a port, which is valid for the URL of the port and for additional hosts
+func GetCertificate(port int, hosts []string) (*Certificate, error) {
+	query := url.Values{"hosts": hosts}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/_supervisor/v1/certificates/port/%d?%s", Addr(), port, query.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res Certificate
+	err = readResponse(resp, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get certificate")
+	}
+	return &res, nil
+}
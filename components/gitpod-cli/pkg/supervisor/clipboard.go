@@ -0,0 +1,58 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ClipboardContent is the content of the clipboard shared with the clients connected to the workspace
+type ClipboardContent struct {
+	MimeType string `json:"mimeType"`
+	Data     []byte `json:"data"`
+	Source   string `json:"source"`
+}
+
+// GetClipboard returns the content of the clipboard, or nil if nothing was copied yet
+func GetClipboard() (*ClipboardContent, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/_supervisor/v1/clipboard", Addr()))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct {
+		Content *ClipboardContent `json:"content"`
+	}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get clipboard")
+	}
+	return res.Content, nil
+}
+
+// SetClipboard replaces the content of the clipboard
+func SetClipboard(content ClipboardContent) error {
+	body, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s/_supervisor/v1/clipboard", Addr()), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct{}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return errors.Wrap(err, "cannot set clipboard")
+	}
+	return nil
+}
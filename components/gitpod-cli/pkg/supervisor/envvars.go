@@ -0,0 +1,95 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EnvVarScope determines the repositories a variable applies to
+type EnvVarScope string
+
+const (
+	// EnvVarScopeProject scopes a variable to the repository of this workspace
+	EnvVarScopeProject EnvVarScope = "env_var_scope_project"
+)
+
+// EnvVar is a user-defined environment variable
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// RepositoryPattern is the owner/repo pattern of the repositories the variable applies to, e.g. foo/* or */*
+	RepositoryPattern string `json:"repositoryPattern,omitempty"`
+}
+
+// ListEnvVars lists the environment variables of the user which apply to this workspace
+func ListEnvVars() ([]EnvVar, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/_supervisor/v1/env", Addr()))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct {
+		Variables []EnvVar `json:"variables"`
+	}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get environment variables")
+	}
+	return res.Variables, nil
+}
+
+// SetEnvVars persists environment variables in a scope and applies them to this workspace
+func SetEnvVars(vars []EnvVar, scope EnvVarScope) error {
+	body, err := json.Marshal(map[string]interface{}{"variables": vars, "scope": scope})
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s/_supervisor/v1/env", Addr()), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct{}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return errors.Wrap(err, "cannot set environment variables")
+	}
+	return nil
+}
+
+// UnsetEnvVars deletes persisted environment variables of a scope. It returns the names of the variables
+// which do not exist in the scope.
+func UnsetEnvVars(names []string, scope EnvVarScope) (notUnset []string, err error) {
+	escaped := make([]string, len(names))
+	for i, name := range names {
+		escaped[i] = url.PathEscape(name)
+	}
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("http://%s/_supervisor/v1/env/%s?scope=%s", Addr(), strings.Join(escaped, ","), scope), nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct {
+		NotUnset []string `json:"notUnset"`
+	}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot unset environment variables")
+	}
+	return res.NotUnset, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WorkspaceInfo describes this workspace
+type WorkspaceInfo struct {
+	WorkspaceID      string `json:"workspaceId"`
+	InstanceID       string `json:"instanceId"`
+	CheckoutLocation string `json:"checkoutLocation"`
+	UserHome         string `json:"userHome"`
+	// SSH is nil if the SSH server is disabled
+	SSH *struct {
+		Port               uint32 `json:"port"`
+		HostKeyFingerprint string `json:"hostKeyFingerprint"`
+		HostKey            string `json:"hostKey"`
+		TunnelPath         string `json:"tunnelPath"`
+		TunnelCookie       string `json:"tunnelCookie"`
+	} `json:"ssh"`
+}
+
+// GetWorkspaceInfo returns information about this workspace
+func GetWorkspaceInfo() (*WorkspaceInfo, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/_supervisor/v1/info/workspace", Addr()))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res WorkspaceInfo
+	err = readResponse(resp, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get workspace info")
+	}
+	return &res, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// OpenTarget is where an IDE opens a URI
+type OpenTarget string
+
+const (
+	// OpenTargetDefault lets the IDE choose
+	OpenTargetDefault OpenTarget = "open_target_default"
+	// OpenTargetEditor opens the URI in an editor
+	OpenTargetEditor OpenTarget = "open_target_editor"
+	// OpenTargetPreview opens the URI in the preview of the IDE
+	OpenTargetPreview OpenTarget = "open_target_preview"
+)
+
+// OpenResult tells who opened a URI
+type OpenResult struct {
+	// HandledBy is the client name of the IDE which opened the URI
+	HandledBy string `json:"handledBy"`
+	// Notified is true if no IDE opened the URI and the user was notified instead
+	Notified bool `json:"notified"`
+}
+
+// Open asks the IDE the user works in to open the URI, e.g. file:///workspace/README.md
+func Open(uri string, target OpenTarget) (*OpenResult, error) {
+	body, err := json.Marshal(map[string]string{"uri": uri, "target": string(target)})
+	if err != nil {
+		return nil, err
+	}
+	// IDEs are asked in turn, each of which may take a while to respond
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s/_supervisor/v1/open", Addr()), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res OpenResult
+	err = readResponse(resp, &res)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open %s", uri)
+	}
+	return &res, nil
+}
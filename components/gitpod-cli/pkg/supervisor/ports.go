@@ -0,0 +1,156 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ResolvedPort is the current state of a port as resolved by supervisor
+type ResolvedPort struct {
+	LocalPort  uint32 `json:"localPort"`
+	GlobalPort uint32 `json:"globalPort"`
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+}
+
+// Addr returns the address of the supervisor API
+func Addr() string {
+	addr := os.Getenv("SUPERVISOR_ADDR")
+	if addr == "" {
+		addr = "localhost:22999"
+	}
+	return addr
+}
+
+// ResolvePort resolves a port name as configured in the .gitpod.yml (e.g. api), or a port number, to the current ports
+func ResolvePort(name string) (*ResolvedPort, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/_supervisor/v1/port/resolve/%s", Addr(), url.PathEscape(name)))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		var status struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &status) == nil && status.Message != "" {
+			return nil, errors.New(status.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot resolve port %q: %d %s", name, resp.StatusCode, resp.Status)
+	}
+
+	var res ResolvedPort
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse supervisor response")
+	}
+	return &res, nil
+}
+
+// PortURL returns the public URL of a port. Ports which are not exposed yet get the URL they will be exposed at.
+func PortURL(port uint16) (string, error) {
+	resolved, err := ResolvePort(strconv.Itoa(int(port)))
+	if err == nil && resolved.URL != "" {
+		return resolved.URL, nil
+	}
+
+	wsURL, err := url.Parse(os.Getenv("GITPOD_WORKSPACE_URL"))
+	if err != nil || wsURL.Host == "" {
+		return "", errors.New("GITPOD_WORKSPACE_URL is not set")
+	}
+	wsURL.Host = fmt.Sprintf("%d-%s", port, wsURL.Host)
+	return wsURL.String(), nil
+}
+
+// PortStatus is the status of a port in the workspace
+type PortStatus struct {
+	LocalPort  uint32 `json:"localPort"`
+	GlobalPort uint32 `json:"globalPort"`
+	Name       string `json:"name,omitempty"`
+	Served     bool   `json:"served"`
+	// Exposed is nil if the port is not exposed
+	Exposed *struct {
+		Visibility string `json:"visibility"`
+		URL        string `json:"url"`
+	} `json:"exposed,omitempty"`
+	// PendingPublic is true if the port waits for the user's approval to become public
+	PendingPublic   bool   `json:"pendingPublic"`
+	DetectedAs      string `json:"detectedAs,omitempty"`
+	Process         string `json:"process,omitempty"`
+	PolicyViolation string `json:"policyViolation,omitempty"`
+}
+
+// ListPorts returns the status of the ports ordered by local port
+func ListPorts() ([]*PortStatus, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/_supervisor/v1/port/list", Addr()))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct {
+		Ports []*PortStatus `json:"ports"`
+	}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot list ports")
+	}
+	return res.Ports, nil
+}
+
+// ExposePort exposes a port, on targetPort if it is not zero
+func ExposePort(port, targetPort uint32) error {
+	return postPort(port, "expose", map[string]interface{}{"targetPort": targetPort})
+}
+
+// ClosePort retracts the exposure of a port
+func ClosePort(port uint32) error {
+	return postPort(port, "close", nil)
+}
+
+// SetPortVisibility makes an exposed port public or private
+func SetPortVisibility(port uint32, public bool) error {
+	visibility := "private"
+	if public {
+		visibility = "public"
+	}
+	return postPort(port, "visibility", map[string]interface{}{"visibility": visibility})
+}
+
+func postPort(port uint32, action string, req map[string]interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s/_supervisor/v1/port/%d/%s", Addr(), port, action), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct{}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return errors.Wrapf(err, "cannot %s port %d", action, port)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SnapshotPhaseHook means the onSnapshot hook runs
+	SnapshotPhaseHook = "snapshot_phase_hook"
+	// SnapshotPhaseDone means the snapshot was taken
+	SnapshotPhaseDone = "snapshot_phase_done"
+)
+
+// SnapshotProgress is the progress of taking a snapshot
+type SnapshotProgress struct {
+	Phase          string `json:"phase"`
+	ElapsedSeconds uint32 `json:"elapsedSeconds"`
+	SnapshotID     string `json:"snapshotId"`
+	URL            string `json:"url"`
+}
+
+// TakeSnapshot takes a snapshot of the workspace and calls onProgress until it was taken
+func TakeSnapshot(skipHook bool, onProgress func(*SnapshotProgress)) (*SnapshotProgress, error) {
+	body, err := json.Marshal(map[string]bool{"skipHook": skipHook})
+	if err != nil {
+		return nil, err
+	}
+	// snapshots of large workspaces take a while, the server streams the progress meanwhile
+	resp, err := http.Post(fmt.Sprintf("http://%s/_supervisor/v1/workspace/snapshot", Addr()), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	if resp.StatusCode != http.StatusOK {
+		var res struct{}
+		err = readResponse(resp, &res)
+		return nil, errors.Wrap(err, "cannot take snapshot")
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var msg struct {
+			Result *SnapshotProgress `json:"result"`
+			Error  *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		err := dec.Decode(&msg)
+		if err == io.EOF {
+			return nil, errors.New("cannot take snapshot: supervisor closed the connection")
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse supervisor response")
+		}
+		if msg.Error != nil {
+			return nil, errors.Errorf("cannot take snapshot: %s", msg.Error.Message)
+		}
+		if msg.Result == nil {
+			continue
+		}
+		if msg.Result.Phase == SnapshotPhaseDone {
+			return msg.Result, nil
+		}
+		onProgress(msg.Result)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// StopWorkspace stops the workspace. A non-zero exit code fails the workspace with the message.
+func StopWorkspace(exitCode uint32, message string) error {
+	body, err := json.Marshal(map[string]interface{}{"exitCode": exitCode, "message": message})
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s/_supervisor/v1/workspace/stop", Addr()), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct{}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return errors.Wrap(err, "cannot stop workspace")
+	}
+	return nil
+}
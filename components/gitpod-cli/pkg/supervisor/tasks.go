@@ -0,0 +1,93 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TaskStateOpening means the terminal of the task is being opened
+	TaskStateOpening = "opening"
+	// TaskStateRunning means the task runs in its terminal
+	TaskStateRunning = "running"
+	// TaskStateClosed means the terminal of the task was closed
+	TaskStateClosed = "closed"
+)
+
+// TaskStatus is the state of a task configured in the .gitpod.yml
+type TaskStatus struct {
+	ID           string `json:"id"`
+	State        string `json:"state"`
+	Terminal     string `json:"terminal"`
+	Presentation struct {
+		Name     string `json:"name"`
+		OpenIn   string `json:"openIn"`
+		OpenMode string `json:"openMode"`
+	} `json:"presentation"`
+	// ExitCode is the exit code of the task's terminal once the task is closed, -1 if it is unknown
+	ExitCode int32 `json:"exitCode"`
+}
+
+// ListTasks returns the current state of the tasks of this workspace
+func ListTasks() ([]*TaskStatus, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/_supervisor/v1/status/tasks", Addr()))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	if resp.StatusCode != http.StatusOK {
+		var res struct{}
+		err = readResponse(resp, &res)
+		return nil, errors.Wrap(err, "cannot list tasks")
+	}
+	defer resp.Body.Close()
+
+	// the status is a stream, which ends after the current state unless it is observed
+	var msg struct {
+		Result *struct {
+			Tasks []*TaskStatus `json:"tasks"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&msg)
+	if err == io.EOF {
+		return nil, errors.New("cannot list tasks: supervisor closed the connection")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse supervisor response")
+	}
+	if msg.Error != nil {
+		return nil, errors.Errorf("cannot list tasks: %s", msg.Error.Message)
+	}
+	if msg.Result == nil {
+		return nil, nil
+	}
+	return msg.Result.Tasks, nil
+}
+
+// RestartTask re-runs the command of a task in a fresh terminal. A running task is only restarted if forced.
+func RestartTask(id string, force bool) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s/_supervisor/v1/task/restart/%s?force=%t", Addr(), url.PathEscape(id), force), "application/json", nil)
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct{}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return errors.Wrapf(err, "cannot restart task %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ListenTerminal copies the output of a terminal to w, its scrollback first, until the terminal is closed or ctx is done
+func ListenTerminal(ctx context.Context, alias string, w io.Writer) error {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/_supervisor/v1/terminal/listen/%s", Addr(), url.PathEscape(alias)), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to supervisor")
+	}
+	if resp.StatusCode != http.StatusOK {
+		var res struct{}
+		err = readResponse(resp, &res)
+		return errors.Wrapf(err, "cannot listen to terminal %s", alias)
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var msg struct {
+			Result *struct {
+				Stdout []byte `json:"stdout"`
+				Stderr []byte `json:"stderr"`
+			} `json:"result"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		err := dec.Decode(&msg)
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "cannot parse supervisor response")
+		}
+		if msg.Error != nil {
+			return errors.Errorf("cannot listen to terminal %s: %s", alias, msg.Error.Message)
+		}
+		if msg.Result == nil {
+			continue
+		}
+		_, err = w.Write(append(msg.Result.Stdout, msg.Result.Stderr...))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// WriteTerminal writes input to a terminal
+func WriteTerminal(alias string, stdin []byte) error {
+	// the input is no request body but a query parameter, which carries bytes base64 encoded
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s/_supervisor/v1/terminal/write/%s?stdin=%s", Addr(), url.PathEscape(alias), url.QueryEscape(base64.StdEncoding.EncodeToString(stdin))), "application/json", nil)
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct{}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return errors.Wrapf(err, "cannot write to terminal %s", alias)
+	}
+	return nil
+}
+
+// SetTerminalSize resizes a terminal, regardless of the size other listeners asked for
+func SetTerminalSize(alias string, rows, cols uint32) error {
+	body, err := json.Marshal(map[string]interface{}{"force": true, "rows": rows, "cols": cols})
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s/_supervisor/v1/terminal/size/%s", Addr(), url.PathEscape(alias)), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res struct{}
+	err = readResponse(resp, &res)
+	if err != nil {
+		return errors.Wrapf(err, "cannot resize terminal %s", alias)
+	}
+	return nil
+}
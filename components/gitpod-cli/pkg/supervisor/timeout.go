@@ -0,0 +1,88 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WorkspaceTimeout is the inactivity timeout of the workspace
+type WorkspaceTimeout struct {
+	Timeout string `json:"timeout"`
+	// CanExtend is false if the plan of the user does not allow for extending the timeout
+	CanExtend bool     `json:"canExtend"`
+	Durations []string `json:"durations"`
+}
+
+// ExtendedTimeout is the result of extending the timeout of the workspace
+type ExtendedTimeout struct {
+	Timeout string `json:"timeout"`
+	// ResetWorkspaces are the other running workspaces whose timeout was reset to the default
+	ResetWorkspaces []string `json:"resetWorkspaces"`
+}
+
+// GetTimeout returns the timeout of the workspace
+func GetTimeout() (*WorkspaceTimeout, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/_supervisor/v1/workspace/timeout", Addr()))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res WorkspaceTimeout
+	err = readResponse(resp, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get timeout")
+	}
+	return &res, nil
+}
+
+// ExtendTimeout extends the timeout of the workspace. If duration is empty, the longest timeout is used.
+func ExtendTimeout(duration string) (*ExtendedTimeout, error) {
+	body, err := json.Marshal(map[string]string{"duration": duration})
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s/_supervisor/v1/workspace/timeout", Addr()), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	var res ExtendedTimeout
+	err = readResponse(resp, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot extend timeout")
+	}
+	return &res, nil
+}
+
+// readResponse reads the JSON response of supervisor's REST API into res, or the error message it failed with
+func readResponse(resp *http.Response, res interface{}) error {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var status struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &status) == nil && status.Message != "" {
+			return errors.New(status.Message)
+		}
+		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
+	err = json.Unmarshal(body, res)
+	if err != nil {
+		return errors.Wrap(err, "cannot parse supervisor response")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Token is a token supervisor provides for a host
+type Token struct {
+	Token string `json:"token"`
+	User  string `json:"user"`
+}
+
+// GetToken asks supervisor for a token of a host with the given scopes. The description tells what the token is used for.
+func GetToken(host string, scopes []string, description string) (*Token, error) {
+	if len(scopes) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+	query := url.Values{"description": {description}}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/_supervisor/v1/token/%s/%s?%s", Addr(), url.PathEscape(host), url.PathEscape(strings.Join(scopes, ",")), query.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot connect to supervisor")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot get token for %s: %d %s", host, resp.StatusCode, resp.Status)
+	}
+
+	var res Token
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse supervisor response")
+	}
+	return &res, nil
+}
+
+// ClearToken makes supervisor forget a token, e.g. because the host rejected it
+func ClearToken(token string) error {
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("http://%s/_supervisor/v1/token/%s", Addr(), url.PathEscape(token)), nil)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to supervisor")
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("cannot clear token: %d %s", resp.StatusCode, resp.Status)
+	}
+	return nil
+}
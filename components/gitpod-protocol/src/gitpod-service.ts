@@ -13,7 +13,7 @@ import {
 import { JsonRpcProxy, JsonRpcServer } from './messaging/proxy-factory';
 import { Disposable, CancellationTokenSource } from 'vscode-jsonrpc';
 import { HeadlessLogEvent } from './headless-workspace-log';
-import { WorkspaceInstance, WorkspaceInstancePort, WorkspaceInstancePhase } from './workspace-instance';
+import { WorkspaceInstance, WorkspaceInstancePort, WorkspaceInstancePhase, PortExposureAuditRecord } from './workspace-instance';
 import { AdminServer } from './admin-protocol';
 import { GitpodHostUrl } from './util/gitpod-host-url';
 import { WebSocketConnectionProvider } from './messaging/browser/connection';
@@ -89,6 +89,7 @@ export interface GitpodServer extends JsonRpcServer<GitpodClient>, AdminServer,
     getOpenPorts(workspaceId: string): Promise<WorkspaceInstancePort[]>;
     openPort(workspaceId: string, port: WorkspaceInstancePort): Promise<WorkspaceInstancePort | undefined>;
     closePort(workspaceId: string, port: number): Promise<void>;
+    auditPortExposure(workspaceId: string, record: PortExposureAuditRecord): Promise<void>;
 
     // User messages
     getUserMessages(options: GitpodServer.GetUserMessagesOptions): Promise<UserMessage[]>;
@@ -103,6 +104,9 @@ export interface GitpodServer extends JsonRpcServer<GitpodClient>, AdminServer,
     setEnvVar(variable: UserEnvVarValue): Promise<void>;
     deleteEnvVar(variable: UserEnvVarValue): Promise<void>;
 
+    // user SSH keys
+    getSSHPublicKeys(): Promise<string[]>;
+
     // Gitpod token
     getGitpodTokens(): Promise<GitpodToken[]>;
     generateNewGitpodToken(options: GitpodServer.GenerateNewGitpodTokenOptions): Promise<string>;
@@ -4,7 +4,7 @@
  * See License-AGPL.txt in the project root for license information.
  */
 
-import { WorkspaceInstance, PortVisibility } from "./workspace-instance";
+import { WorkspaceInstance, PortVisibility, PortCorsConfig, PortInsecureRequests } from "./workspace-instance";
 import { RoleOrPermission } from "./permission";
 
 export interface UserInfo {
@@ -89,6 +89,10 @@ export interface AdditionalUserData {
     emailNotificationSettings?: EmailNotificationSettings;
     featurePreview?: boolean;
     ideSettings?: IDESettings;
+    // SSH public keys in the authorized_keys format, which may log into the user's workspaces
+    sshPublicKeys?: string[];
+    // the Git repository whose dotfiles are installed into the user's workspaces
+    dotfileRepo?: string;
 }
 
 export interface EmailNotificationSettings {
@@ -511,6 +515,7 @@ export interface VSCodeConfig {
 export interface WorkspaceConfig {
     image?: ImageConfig;
     ports?: PortConfig[];
+    portsPolicy?: PortsPolicyConfig;
     tasks?: TaskConfig[];
     checkoutLocation?: string;
     workspaceLocation?: string;
@@ -518,6 +523,7 @@ export interface WorkspaceConfig {
     gitConfig?: { [config: string]: string };
     github?: GithubAppConfig;
     vscode?: VSCodeConfig;
+    hooks?: HooksConfig;
     
     /**
      * Where the config object originates from.
@@ -633,10 +639,23 @@ export interface WhitelistedRepository {
 
 export type PortOnOpen = 'open-browser' | 'open-preview' | 'notify' | 'ignore';
 
+export type PortProtocol = 'http' | 'tcp' | 'udp' | 'grpc';
+
 export interface PortConfig {
     port: number;
     onOpen?: PortOnOpen;
     visibility?: PortVisibility;
+    override?: boolean;
+    globalPort?: number;
+    connectionRateLimit?: number;
+    insecureRequests?: PortInsecureRequests;
+    cors?: PortCorsConfig;
+    // whether traffic on the port keeps the workspace from timing out
+    keepAlive?: boolean;
+    // name of the port, e.g. 'api', which can be used instead of the port number
+    name?: string;
+    // the protocol spoken on the port, which decides how supervisor proxies the port if it listens on localhost only
+    protocol?: PortProtocol;
 }
 export namespace PortConfig {
     export function is(config: any): config is PortConfig {
@@ -644,9 +663,35 @@ export namespace PortConfig {
     }
 }
 
+export interface PortsPolicyConfig {
+    // 'configured' only auto-exposes services listening on localhost if their port is configured
+    localhostPorts?: 'auto' | 'configured';
+    // ports and port ranges, e.g. 22 or '6000-6100', which are never exposed or proxied
+    deny?: (number | string)[];
+}
+
+export interface HooksConfig {
+    onStart?: HookConfig;
+    onStop?: HookConfig;
+    onSnapshot?: HookConfig;
+    onPrebuild?: HookConfig;
+}
+
+export interface HookConfig {
+    command: string;
+    // how long the command may run, e.g. '30s'
+    timeout?: string;
+}
+
 export interface PortRangeConfig {
     port: string;
     onOpen?: PortOnOpen;
+    override?: boolean;
+    connectionRateLimit?: number;
+    insecureRequests?: PortInsecureRequests;
+    cors?: PortCorsConfig;
+    keepAlive?: boolean;
+    protocol?: PortProtocol;
 }
 export namespace PortRangeConfig {
     export function is(config: any): config is PortRangeConfig {
@@ -660,9 +705,18 @@ export interface TaskConfig {
     init?: string;
     prebuild?: string;
     command?: string;
+    dependsOn?: (string | TaskDependency)[];
     env?: { [env: string]: string };
     openIn?: 'bottom' | 'main' | 'left' | 'right';
     openMode?: 'split-top' | 'split-left' | 'split-right' | 'split-bottom' | 'tab-before' | 'tab-after';
+    terminationGracePeriod?: string;
+}
+
+export interface TaskDependency {
+    task?: string;
+    port?: number;
+    file?: string;
+    http?: string;
 }
 
 export namespace TaskConfig {
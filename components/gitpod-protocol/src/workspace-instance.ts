@@ -141,6 +141,9 @@ export type AdmissionLevel = 'owner_only' | 'everyone';
 // PortVisibility describes how a port can be accessed
 export type PortVisibility = 'public' | 'private';
 
+// PortInsecureRequests describes how plain HTTP requests to a port are handled
+export type PortInsecureRequests = 'allow' | 'redirect' | 'reject';
+
 // WorkspaceInstancePort describes a port exposed on a workspace instance
 export interface WorkspaceInstancePort {
     // The outward-facing port number
@@ -154,6 +157,39 @@ export interface WorkspaceInstancePort {
 
     // Public, outward-facing URL where the port can be accessed on.
     url?: string;
+
+    // How the proxy handles plain HTTP requests. Optional for backwards compatibility, defaults to 'allow'.
+    insecureRequests?: PortInsecureRequests;
+
+    // The CORS policy applied by the proxy. If not present, CORS requests are passed on to the port.
+    cors?: PortCorsConfig;
+}
+
+// PortCorsConfig describes which cross-origin requests a port accepts
+export interface PortCorsConfig {
+    // Origins which may access the port, '*' allows any origin.
+    allowedOrigins: string[];
+
+    // Whether cross-origin requests may include credentials. Must not be combined with the '*' origin.
+    allowCredentials?: boolean;
+}
+
+// PortExposureAuditRecord describes a port which was made public, so that organizations can review what was shared from their workspaces
+export interface PortExposureAuditRecord {
+    // The instance the port was exposed on
+    instanceId: string;
+
+    // The port in the workspace
+    port: number;
+
+    // Public URL the port was exposed at
+    url: string;
+
+    // When the port became public, as ISO 8601 timestamp
+    exposedAt: string;
+
+    // Whether the user exposed the port, as opposed to it being exposed automatically
+    manual?: boolean;
 }
 
 // WorkspaceInstanceRepoStatus describes the status of th Git working copy of a workspace
@@ -7,7 +7,7 @@
 import { suite, test } from "mocha-typescript";
 import * as chai from 'chai';
 const expect = chai.expect;
-import { TokenResourceGuard, ScopedResourceGuard, GuardedResource } from "./resource-access";
+import { TokenResourceGuard, ScopedResourceGuard, GuardedResource, ResourceAccessOp } from "./resource-access";
 
 @suite class TestResourceAccess {
 
@@ -109,6 +109,30 @@ import { TokenResourceGuard, ScopedResourceGuard, GuardedResource } from "./reso
         }))
     }
 
+    @test public async tokenResourceGuardCanAccessEnvVar() {
+        const envVar = (repositoryPattern: string): GuardedResource => ({kind: "envVar", subject: {id: "varid", userId: "foo", name: "FOO", value: "bar", repositoryPattern}});
+        const guard = new TokenResourceGuard("foo", [
+            "resource:"+ScopedResourceGuard.marshalResourceScope({kind: "envVar", subjectID: "gitpod-io/gitpod", operations: ["create", "update"]}),
+        ]);
+        const tests: {
+            name: string
+            resource: GuardedResource
+            operation: ResourceAccessOp
+            expectation: boolean
+        }[] = [
+            {name: "own repository", resource: envVar("gitpod-io/gitpod"), operation: "create", expectation: true},
+            {name: "own repository, missing op", resource: envVar("gitpod-io/gitpod"), operation: "delete", expectation: false},
+            {name: "other repository", resource: envVar("gitpod-io/website"), operation: "create", expectation: false},
+            {name: "owner pattern", resource: envVar("gitpod-io/*"), operation: "create", expectation: false},
+            {name: "any repository", resource: envVar("*/*"), operation: "update", expectation: false},
+        ];
+
+        await Promise.all(tests.map(async t => {
+            const res = await guard.canAccess(t.resource, t.operation)
+            expect(res).to.be.eq(t.expectation, `"${t.name}" expected canAccess(...) === ${t.expectation}, but was ${res}`);
+        }))
+    }
+
     @test public async scopedResourceGuardCanAccess() {
         const workspaceResource: GuardedResource = {kind: "workspace", subject: {id:"wsid", ownerId: "foo"} as any};
         const tests: {
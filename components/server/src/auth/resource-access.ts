@@ -4,7 +4,7 @@
  * See License-AGPL.txt in the project root for license information.
  */
 
-import { Workspace, WorkspaceInstance, User, Snapshot, GitpodToken, Token } from "@gitpod/gitpod-protocol";
+import { Workspace, WorkspaceInstance, User, Snapshot, GitpodToken, Token, UserEnvVar } from "@gitpod/gitpod-protocol";
 
 declare var resourceInstance: GuardedResource;
 export type GuardedResourceKind = typeof resourceInstance.kind;
@@ -16,7 +16,8 @@ export type GuardedResource =
     GuardedSnapshot |
     GuardedGitpodToken |
     GuardedToken |
-    GuardedUserStorage
+    GuardedUserStorage |
+    GuardedEnvVar
 ;
 
 export interface GuardedWorkspace {
@@ -56,6 +57,15 @@ export interface GuardedToken {
     kind: "token";
     subject: Token;
     tokenOwnerID: string;
+    // host is the host the token authenticates with. Scoped access to tokens is granted per host.
+    host?: string;
+}
+
+export interface GuardedEnvVar {
+    kind: "envVar";
+    // subject is the environment variable as it is stored, i.e. with a normalized repository pattern.
+    // Scoped access to environment variables is granted per repository pattern.
+    subject: UserEnvVar;
 }
 
 export type ResourceAccessOp =
@@ -110,6 +120,8 @@ export class OwnerResourceGuard implements ResourceAccessGuard {
                 return resource.subject.id === this.userId;
             case "userStorage":
                 return resource.userID === this.userId;
+            case "envVar":
+                return resource.subject.userId === this.userId;
             case "workspace":
                 return resource.subject.ownerId === this.userId;
             case "workspaceInstance":
@@ -205,11 +217,13 @@ export namespace ScopedResourceGuard {
             case "snapshot":
                 return resource.subject ? resource.subject.id : undefined;
             case "token":
-                return;
+                return resource.host;
             case "user":
                 return resource.subject.id;
             case "userStorage":
                 return `${resource.userID}:${resource.uri}`;
+            case "envVar":
+                return resource.subject.repositoryPattern;
             case "workspace":
                 return resource.subject.id;
             case "workspaceInstance":
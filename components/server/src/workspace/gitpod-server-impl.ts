@@ -13,7 +13,7 @@ import {
     CreateWorkspaceMode, PrebuiltWorkspace, Token, UserEnvVarValue, UserEnvVar, ResolvePluginsParams,
     ResolvedPlugins, PreparePluginUploadParams, WorkspaceImageBuild, StartWorkspaceResult,
     StartPrebuildContext, WorkspaceTimeoutDuration,
-    SetWorkspaceTimeoutResult, GetWorkspaceTimeoutResult, Configuration, PortVisibility, InstallPluginsParams, UninstallPluginParams, PermissionName, GitpodTokenType, GitpodToken, AuthProviderEntry, WorkspaceInstancePort
+    SetWorkspaceTimeoutResult, GetWorkspaceTimeoutResult, Configuration, PortVisibility, InstallPluginsParams, UninstallPluginParams, PermissionName, GitpodTokenType, GitpodToken, AuthProviderEntry, WorkspaceInstancePort, PortExposureAuditRecord, PortCorsConfig, PortInsecureRequests
 } from '@gitpod/gitpod-protocol';
 import { LicenseValidationResult, GetLicenseInfoResult, LicenseFeature } from '@gitpod/gitpod-protocol/lib/license-protocol';
 import { ErrorCodes } from '@gitpod/gitpod-protocol/lib/messaging/error';
@@ -42,7 +42,7 @@ import { TracedWorkspaceDB, DBWithTracing } from '@gitpod/gitpod-db/lib/traced-d
 import * as uuidv4 from 'uuid/v4';
 import { WorkspaceStarter } from './workspace-starter';
 import { WorkspaceManagerClientProvider } from '@gitpod/ws-manager/lib/client-provider';
-import { StopWorkspaceRequest, StopWorkspacePolicy, DescribeWorkspaceRequest, ControlPortRequest, PortSpec, MarkActiveRequest, PortVisibility as ProtoPortVisibility } from '@gitpod/ws-manager/lib/core_pb';
+import { StopWorkspaceRequest, StopWorkspacePolicy, DescribeWorkspaceRequest, ControlPortRequest, PortSpec, PortCorsPolicy, MarkActiveRequest, PortVisibility as ProtoPortVisibility, PortInsecureRequests as ProtoPortInsecureRequests } from '@gitpod/ws-manager/lib/core_pb';
 import { TheiaPluginService } from '../theia-plugin/theia-plugin-service';
 import { ImageBuilderClientProvider, LogsRequest } from '@gitpod/image-builder/lib';
 import { URL } from 'url';
@@ -304,7 +304,7 @@ export class GitpodServerImpl<Client extends GitpodClient, Server extends Gitpod
         const { host } = query;
         try {
             const token = await this.tokenProvider.getTokenForHost(user, host);
-            await this.guardAccess({ kind: "token", subject: token, tokenOwnerID: user.id }, "get");
+            await this.guardAccess({ kind: "token", subject: token, tokenOwnerID: user.id, host }, "get");
 
             return token;
         } catch (error) {
@@ -953,7 +953,9 @@ export class GitpodServerImpl<Client extends GitpodClient, Server extends Gitpod
                 port: p.getPort(),
                 targetPort: p.getTarget(),
                 url: p.getUrl(),
-                visibility: this.portVisibilityFromProto(p.getVisibility())
+                visibility: this.portVisibilityFromProto(p.getVisibility()),
+                insecureRequests: this.portInsecureRequestsFromProto(p.getInsecureRequests()),
+                cors: this.portCorsFromProto(p.getCors()),
             });
 
             return ports;
@@ -991,6 +993,10 @@ export class GitpodServerImpl<Client extends GitpodClient, Server extends Gitpod
                 spec.setTarget(port.port);
             }
             spec.setVisibility(this.portVisibilityToProto(port.visibility))
+            spec.setInsecureRequests(this.portInsecureRequestsToProto(port.insecureRequests));
+            if (port.cors) {
+                spec.setCors(this.portCorsToProto(port.cors));
+            }
             req.setSpec(spec);
             req.setExpose(true);
 
@@ -1024,6 +1030,47 @@ export class GitpodServerImpl<Client extends GitpodClient, Server extends Gitpod
         }
     }
 
+    protected portInsecureRequestsFromProto(insecureRequests: ProtoPortInsecureRequests): PortInsecureRequests {
+        switch (insecureRequests) {
+            default:    // the default in the protobuf def is: allow
+            case ProtoPortInsecureRequests.PORT_INSECURE_REQUESTS_ALLOW:
+                return 'allow';
+            case ProtoPortInsecureRequests.PORT_INSECURE_REQUESTS_REDIRECT:
+                return 'redirect';
+            case ProtoPortInsecureRequests.PORT_INSECURE_REQUESTS_REJECT:
+                return 'reject';
+        }
+    }
+
+    protected portInsecureRequestsToProto(insecureRequests: PortInsecureRequests | undefined): ProtoPortInsecureRequests {
+        switch (insecureRequests) {
+            default:    // the default for requests is: allow
+            case 'allow':
+                return ProtoPortInsecureRequests.PORT_INSECURE_REQUESTS_ALLOW;
+            case 'redirect':
+                return ProtoPortInsecureRequests.PORT_INSECURE_REQUESTS_REDIRECT;
+            case 'reject':
+                return ProtoPortInsecureRequests.PORT_INSECURE_REQUESTS_REJECT;
+        }
+    }
+
+    protected portCorsFromProto(cors: PortCorsPolicy | undefined): PortCorsConfig | undefined {
+        if (!cors) {
+            return undefined;
+        }
+        return {
+            allowedOrigins: cors.getAllowedOriginsList(),
+            allowCredentials: cors.getAllowCredentials(),
+        };
+    }
+
+    protected portCorsToProto(cors: PortCorsConfig): PortCorsPolicy {
+        const policy = new PortCorsPolicy();
+        policy.setAllowedOriginsList(cors.allowedOrigins);
+        policy.setAllowCredentials(!!cors.allowCredentials);
+        return policy;
+    }
+
     public async closePort(workspaceId: string, port: number) {
         const user = this.checkAndBlockUser("closePort");
         const span = opentracing.globalTracer().startSpan("closePort");
@@ -1056,6 +1103,36 @@ export class GitpodServerImpl<Client extends GitpodClient, Server extends Gitpod
         }
     }
 
+    public async auditPortExposure(workspaceId: string, record: PortExposureAuditRecord): Promise<void> {
+        const user = this.checkUser("auditPortExposure");
+        const span = opentracing.globalTracer().startSpan("auditPortExposure");
+        span.setTag("workspaceId", workspaceId);
+        span.setTag("userId", user.id);
+        span.setTag("port", record.port);
+
+        try {
+            const workspace = await this.internalGetWorkspace(workspaceId, this.workspaceDb.trace({ span }));
+            const instance = await this.workspaceDb.trace({ span }).findInstanceById(record.instanceId);
+            if (!instance || instance.workspaceId !== workspaceId) {
+                throw new ResponseError(ErrorCodes.NOT_FOUND, `Instance ${record.instanceId} of workspace ${workspaceId} not found.`);
+            }
+            await this.guardAccess({ kind: "workspaceInstance", subject: instance, workspaceOwnerID: workspace.ownerId }, "update");
+
+            // the audit log is the structured log, which is retained and reviewed like the server's other logs
+            log.info({ userId: user.id, workspaceId, instanceId: instance.id }, 'audit: port exposed publicly', {
+                port: record.port,
+                url: record.url,
+                exposedAt: record.exposedAt,
+                manual: !!record.manual,
+            });
+        } catch (e) {
+            TraceContext.logError({ span }, e);
+            throw e;
+        } finally {
+            span.finish();
+        }
+    }
+
     async watchWorkspaceImageBuildLogs(workspaceId: string): Promise<void> {
         const user = this.checkAndBlockUser("watchWorkspaceImageBuildLogs");
         const span = opentracing.globalTracer().startSpan("watchWorkspaceImageBuildLogs");
@@ -1230,6 +1307,13 @@ export class GitpodServerImpl<Client extends GitpodClient, Server extends Gitpod
         return layoutData.layoutData;
     }
 
+    async getSSHPublicKeys(): Promise<string[]> {
+        // Note: this operation is per-user only, hence needs no resource guard
+
+        const user = this.checkUser("getSSHPublicKeys");
+        return (user.additionalData && user.additionalData.sshPublicKeys) || [];
+    }
+
     async getEnvVars(): Promise<UserEnvVarValue[]> {
         // Note: this operation is per-user only, hence needs no resource guard
 
@@ -1245,7 +1329,7 @@ export class GitpodServerImpl<Client extends GitpodClient, Server extends Gitpod
     }
 
     async setEnvVar(variable: UserEnvVarValue): Promise<void> {
-        // Note: this operation is per-user only, hence needs no resource guard
+        // Note: writing is guarded per repository pattern, so that workspace tokens can only write the variables of their repository
         const user = this.checkUser("setEnvVar");
 
         variable.repositoryPattern = UserEnvVar.normalizeRepoPattern(variable.repositoryPattern);
@@ -1269,23 +1353,26 @@ export class GitpodServerImpl<Client extends GitpodClient, Server extends Gitpod
             id: variable.id || uuidv4(),
             userId: user.id,
         };
+        await this.guardAccess({ kind: "envVar", subject: envvar }, !!existingVar ? "update" : "create");
         await this.userDB.setEnvVar(envvar);
     }
 
     async deleteEnvVar(variable: UserEnvVarValue): Promise<void> {
-        // Note: this operation is per-user only, hence needs no resource guard
+        // Note: deleting is guarded per repository pattern, so that workspace tokens can only delete the variables of their repository
         const user = this.checkUser("deleteEnvVar");
 
         if (!variable.id) {
             throw new ResponseError(ErrorCodes.NOT_FOUND, "Missing ID field")
         }
 
-        const envvar: UserEnvVar = {
-            ...variable,
-            id: variable.id!,
-            userId: user.id,
-        };
-        await this.userDB.deleteEnvVar(envvar);
+        // guard and delete the variable as it is stored rather than the one the caller claims it is
+        const existingVar = (await this.userDB.getEnvVars(user.id)).find(v => v.id === variable.id);
+        if (!existingVar) {
+            throw new ResponseError(ErrorCodes.NOT_FOUND, `environment variable ${variable.id} not found`);
+        }
+        await this.guardAccess({ kind: "envVar", subject: existingVar }, "delete");
+
+        await this.userDB.deleteEnvVar(existingVar);
     }
 
     public async getGitpodTokens(): Promise<GitpodToken[]> {
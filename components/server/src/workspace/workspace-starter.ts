@@ -510,6 +510,12 @@ export class WorkspaceStarter {
             ev.setValue(JSON.stringify(workspace.config.tasks));
             envvars.push(ev);
         }
+        if (user.additionalData && user.additionalData.dotfileRepo) {
+            const ev = new EnvironmentVariable();
+            ev.setName("SUPERVISOR_DOTFILE_REPO");
+            ev.setValue(user.additionalData.dotfileRepo);
+            envvars.push(ev);
+        }
         const addExtensionsToEnvvarPromise = this.theiaService.resolvePlugins(user.id, { config: workspace.config }).then(
             resolvedExtensions => {
                 if (resolvedExtensions) {
@@ -620,7 +626,7 @@ export class WorkspaceStarter {
     }
 
     protected createDefaultGitpodAPITokenScopes(workspace: Workspace, instance: WorkspaceInstance): string[] {
-        return [
+        const scopes = [
             "function:getWorkspace",
             "function:getLoggedInUser",
             "function:getPortAuthenticationToken",
@@ -634,6 +640,12 @@ export class WorkspaceStarter {
             "function:getOpenPorts",
             "function:openPort",
             "function:closePort",
+            "function:auditPortExposure",
+            "function:getSSHPublicKeys",
+            "function:getToken",
+            "function:getEnvVars",
+            "function:setEnvVar",
+            "function:deleteEnvVar",
             "function:getLayout",
             "function:generateNewGitpodToken",
             "function:takeSnapshot",
@@ -645,7 +657,16 @@ export class WorkspaceStarter {
             "resource:"+ScopedResourceGuard.marshalResourceScope({kind: "snapshot", subjectID: "*", operations: ["create", "get"]}),
             "resource:"+ScopedResourceGuard.marshalResourceScope({kind: "gitpodToken", subjectID: "*", operations: ["create"]}),
             "resource:"+ScopedResourceGuard.marshalResourceScope({kind: "userStorage", subjectID: "*", operations: ["create", "get", "update"]}),
-        ]
+        ];
+        if (CommitContext.is(workspace.context)) {
+            const repo = workspace.context.repository;
+            // git credentials for the host of the repository the workspace was started from, but no other host
+            scopes.push("resource:"+ScopedResourceGuard.marshalResourceScope({kind: "token", subjectID: repo.host, operations: ["get"]}));
+            // environment variables of the repository the workspace was started from, but of no other repository
+            const repositoryPattern = UserEnvVar.normalizeRepoPattern(`${repo.owner}/${repo.name}`);
+            scopes.push("resource:"+ScopedResourceGuard.marshalResourceScope({kind: "envVar", subjectID: repositoryPattern, operations: ["create", "update", "delete"]}));
+        }
+        return scopes;
     }
 
     protected createGitSpec(workspace: Workspace, user: User): GitSpec {
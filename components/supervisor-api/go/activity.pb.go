@@ -0,0 +1,397 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: activity.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type RecordActivityRequest struct {
+	// source is the name of what the user was active with, e.g. "vscode-extension"
+	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecordActivityRequest) Reset()         { *m = RecordActivityRequest{} }
+func (m *RecordActivityRequest) String() string { return proto.CompactTextString(m) }
+func (*RecordActivityRequest) ProtoMessage()    {}
+func (*RecordActivityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a684c9a0549e7832, []int{0}
+}
+
+func (m *RecordActivityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordActivityRequest.Unmarshal(m, b)
+}
+func (m *RecordActivityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordActivityRequest.Marshal(b, m, deterministic)
+}
+func (m *RecordActivityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordActivityRequest.Merge(m, src)
+}
+func (m *RecordActivityRequest) XXX_Size() int {
+	return xxx_messageInfo_RecordActivityRequest.Size(m)
+}
+func (m *RecordActivityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordActivityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordActivityRequest proto.InternalMessageInfo
+
+func (m *RecordActivityRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type RecordActivityResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecordActivityResponse) Reset()         { *m = RecordActivityResponse{} }
+func (m *RecordActivityResponse) String() string { return proto.CompactTextString(m) }
+func (*RecordActivityResponse) ProtoMessage()    {}
+func (*RecordActivityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a684c9a0549e7832, []int{1}
+}
+
+func (m *RecordActivityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordActivityResponse.Unmarshal(m, b)
+}
+func (m *RecordActivityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordActivityResponse.Marshal(b, m, deterministic)
+}
+func (m *RecordActivityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordActivityResponse.Merge(m, src)
+}
+func (m *RecordActivityResponse) XXX_Size() int {
+	return xxx_messageInfo_RecordActivityResponse.Size(m)
+}
+func (m *RecordActivityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordActivityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordActivityResponse proto.InternalMessageInfo
+
+type ActivityStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActivityStatusRequest) Reset()         { *m = ActivityStatusRequest{} }
+func (m *ActivityStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ActivityStatusRequest) ProtoMessage()    {}
+func (*ActivityStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a684c9a0549e7832, []int{2}
+}
+
+func (m *ActivityStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivityStatusRequest.Unmarshal(m, b)
+}
+func (m *ActivityStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivityStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *ActivityStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivityStatusRequest.Merge(m, src)
+}
+func (m *ActivityStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ActivityStatusRequest.Size(m)
+}
+func (m *ActivityStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivityStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivityStatusRequest proto.InternalMessageInfo
+
+type ActivityStatusResponse struct {
+	Sources []*ActivityStatusResponse_Source `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	// last_heartbeat is unset if no heartbeat was sent yet
+	LastHeartbeat        *timestamp.Timestamp `protobuf:"bytes,2,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ActivityStatusResponse) Reset()         { *m = ActivityStatusResponse{} }
+func (m *ActivityStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ActivityStatusResponse) ProtoMessage()    {}
+func (*ActivityStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a684c9a0549e7832, []int{3}
+}
+
+func (m *ActivityStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivityStatusResponse.Unmarshal(m, b)
+}
+func (m *ActivityStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivityStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *ActivityStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivityStatusResponse.Merge(m, src)
+}
+func (m *ActivityStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ActivityStatusResponse.Size(m)
+}
+func (m *ActivityStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivityStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivityStatusResponse proto.InternalMessageInfo
+
+func (m *ActivityStatusResponse) GetSources() []*ActivityStatusResponse_Source {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+func (m *ActivityStatusResponse) GetLastHeartbeat() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastHeartbeat
+	}
+	return nil
+}
+
+type ActivityStatusResponse_Source struct {
+	Name         string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LastActivity *timestamp.Timestamp `protobuf:"bytes,2,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	// ignored sources do not keep the workspace running
+	Ignored              bool     `protobuf:"varint,3,opt,name=ignored,proto3" json:"ignored,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActivityStatusResponse_Source) Reset()         { *m = ActivityStatusResponse_Source{} }
+func (m *ActivityStatusResponse_Source) String() string { return proto.CompactTextString(m) }
+func (*ActivityStatusResponse_Source) ProtoMessage()    {}
+func (*ActivityStatusResponse_Source) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a684c9a0549e7832, []int{3, 0}
+}
+
+func (m *ActivityStatusResponse_Source) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivityStatusResponse_Source.Unmarshal(m, b)
+}
+func (m *ActivityStatusResponse_Source) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivityStatusResponse_Source.Marshal(b, m, deterministic)
+}
+func (m *ActivityStatusResponse_Source) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivityStatusResponse_Source.Merge(m, src)
+}
+func (m *ActivityStatusResponse_Source) XXX_Size() int {
+	return xxx_messageInfo_ActivityStatusResponse_Source.Size(m)
+}
+func (m *ActivityStatusResponse_Source) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivityStatusResponse_Source.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivityStatusResponse_Source proto.InternalMessageInfo
+
+func (m *ActivityStatusResponse_Source) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ActivityStatusResponse_Source) GetLastActivity() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastActivity
+	}
+	return nil
+}
+
+func (m *ActivityStatusResponse_Source) GetIgnored() bool {
+	if m != nil {
+		return m.Ignored
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*RecordActivityRequest)(nil), "supervisor.RecordActivityRequest")
+	proto.RegisterType((*RecordActivityResponse)(nil), "supervisor.RecordActivityResponse")
+	proto.RegisterType((*ActivityStatusRequest)(nil), "supervisor.ActivityStatusRequest")
+	proto.RegisterType((*ActivityStatusResponse)(nil), "supervisor.ActivityStatusResponse")
+	proto.RegisterType((*ActivityStatusResponse_Source)(nil), "supervisor.ActivityStatusResponse.Source")
+}
+
+func init() {
+	proto.RegisterFile("activity.proto", fileDescriptor_a684c9a0549e7832)
+}
+
+var fileDescriptor_a684c9a0549e7832 = []byte{
+	// 370 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x51, 0xc1, 0x4e, 0xe3, 0x30,
+	0x14, 0x54, 0xda, 0xdd, 0x76, 0xf7, 0xb5, 0x9b, 0x95, 0x2c, 0xda, 0x46, 0x11, 0x88, 0x90, 0x53,
+	0xb8, 0xd8, 0xa2, 0x7c, 0x00, 0x2a, 0x5c, 0x38, 0xa7, 0x9c, 0xb8, 0x54, 0x6e, 0x6a, 0x8a, 0xa5,
+	0x26, 0x0e, 0xb6, 0x13, 0x84, 0x10, 0x17, 0x7e, 0x81, 0x13, 0xdf, 0xc5, 0x2f, 0xf0, 0x11, 0x1c,
+	0x51, 0xe3, 0x18, 0xda, 0xaa, 0xaa, 0xb8, 0xe5, 0xe5, 0xcd, 0xbc, 0xf1, 0xcc, 0x80, 0x4b, 0x13,
+	0xcd, 0x4b, 0xae, 0x1f, 0x70, 0x2e, 0x85, 0x16, 0x08, 0x54, 0x91, 0x33, 0x59, 0x72, 0x25, 0xa4,
+	0xbf, 0x3f, 0x17, 0x62, 0xbe, 0x60, 0x84, 0xe6, 0x9c, 0xd0, 0x2c, 0x13, 0x9a, 0x6a, 0x2e, 0x32,
+	0x65, 0x90, 0xfe, 0x61, 0xbd, 0xad, 0xa6, 0x69, 0x71, 0x43, 0x34, 0x4f, 0x99, 0xd2, 0x34, 0xcd,
+	0x0d, 0x20, 0x24, 0xd0, 0x8b, 0x59, 0x22, 0xe4, 0x6c, 0x54, 0x4b, 0xc4, 0xec, 0xae, 0x60, 0x4a,
+	0xa3, 0x3e, 0xb4, 0x94, 0x28, 0x64, 0xc2, 0x3c, 0x27, 0x70, 0xa2, 0xbf, 0x71, 0x3d, 0x85, 0x1e,
+	0xf4, 0x37, 0x09, 0x2a, 0x17, 0x99, 0x62, 0xe1, 0x00, 0x7a, 0xf6, 0xdf, 0x58, 0x53, 0x5d, 0xa8,
+	0xfa, 0x54, 0xf8, 0xda, 0x80, 0xfe, 0xe6, 0xc6, 0x70, 0xd0, 0x05, 0xb4, 0xcd, 0x5d, 0xe5, 0x39,
+	0x41, 0x33, 0xea, 0x0c, 0x8f, 0xf1, 0xb7, 0x37, 0xbc, 0x9d, 0x84, 0xc7, 0x15, 0x23, 0xb6, 0x4c,
+	0x34, 0x02, 0x77, 0x41, 0x95, 0x9e, 0xdc, 0x32, 0x2a, 0xf5, 0x94, 0x51, 0xed, 0x35, 0x02, 0x27,
+	0xea, 0x0c, 0x7d, 0x6c, 0xdc, 0x63, 0xeb, 0x1e, 0x5f, 0x59, 0xf7, 0xf1, 0xbf, 0x25, 0xe3, 0xd2,
+	0x12, 0xfc, 0x7b, 0x68, 0x99, 0xab, 0x08, 0xc1, 0xaf, 0x8c, 0xa6, 0xd6, 0x75, 0xf5, 0x8d, 0xce,
+	0xa0, 0x82, 0x4f, 0x6c, 0x0d, 0x3f, 0xb8, 0xdf, 0x5d, 0x12, 0xec, 0xfb, 0x91, 0x07, 0x6d, 0x3e,
+	0xcf, 0x84, 0x64, 0x33, 0xaf, 0x19, 0x38, 0xd1, 0x9f, 0xd8, 0x8e, 0xc3, 0x0f, 0x07, 0xfe, 0x7f,
+	0xd9, 0x5c, 0xfa, 0x4e, 0x18, 0x2a, 0xc1, 0x5d, 0x8f, 0x18, 0x1d, 0xad, 0xa6, 0xb2, 0xb5, 0x2f,
+	0x3f, 0xdc, 0x05, 0xa9, 0x1b, 0x3a, 0x78, 0x7e, 0x7b, 0x7f, 0x69, 0x0c, 0xc2, 0x1e, 0x29, 0x4f,
+	0x88, 0x35, 0x43, 0x1e, 0x4d, 0x8e, 0x4f, 0x28, 0x05, 0x77, 0x3d, 0xf1, 0x75, 0xdd, 0xad, 0xe5,
+	0xfa, 0xe1, 0x2e, 0x48, 0xad, 0xbb, 0x57, 0xe9, 0xba, 0xa8, 0xbb, 0xaa, 0x7b, 0xfe, 0xfb, 0xba,
+	0x49, 0x73, 0x3e, 0x6d, 0x55, 0xe9, 0x9d, 0x7e, 0x0e, 0x00, 0x5e, 0xad, 0xcf, 0x15, 0xe5, 0x02,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ActivityServiceClient is the client API for ActivityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ActivityServiceClient interface {
+	// RecordActivity records activity of a source, e.g. an IDE extension or a CLI tool.
+	RecordActivity(ctx context.Context, in *RecordActivityRequest, opts ...grpc.CallOption) (*RecordActivityResponse, error)
+	// ActivityStatus returns when each source was last active and when the last heartbeat was sent.
+	ActivityStatus(ctx context.Context, in *ActivityStatusRequest, opts ...grpc.CallOption) (*ActivityStatusResponse, error)
+}
+
+type activityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewActivityServiceClient(cc grpc.ClientConnInterface) ActivityServiceClient {
+	return &activityServiceClient{cc}
+}
+
+func (c *activityServiceClient) RecordActivity(ctx context.Context, in *RecordActivityRequest, opts ...grpc.CallOption) (*RecordActivityResponse, error) {
+	out := new(RecordActivityResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ActivityService/RecordActivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *activityServiceClient) ActivityStatus(ctx context.Context, in *ActivityStatusRequest, opts ...grpc.CallOption) (*ActivityStatusResponse, error) {
+	out := new(ActivityStatusResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ActivityService/ActivityStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActivityServiceServer is the server API for ActivityService service.
+type ActivityServiceServer interface {
+	// RecordActivity records activity of a source, e.g. an IDE extension or a CLI tool.
+	RecordActivity(context.Context, *RecordActivityRequest) (*RecordActivityResponse, error)
+	// ActivityStatus returns when each source was last active and when the last heartbeat was sent.
+	ActivityStatus(context.Context, *ActivityStatusRequest) (*ActivityStatusResponse, error)
+}
+
+// UnimplementedActivityServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedActivityServiceServer struct {
+}
+
+func (*UnimplementedActivityServiceServer) RecordActivity(ctx context.Context, req *RecordActivityRequest) (*RecordActivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordActivity not implemented")
+}
+func (*UnimplementedActivityServiceServer) ActivityStatus(ctx context.Context, req *ActivityStatusRequest) (*ActivityStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivityStatus not implemented")
+}
+
+func RegisterActivityServiceServer(s *grpc.Server, srv ActivityServiceServer) {
+	s.RegisterService(&_ActivityService_serviceDesc, srv)
+}
+
+func _ActivityService_RecordActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityServiceServer).RecordActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ActivityService/RecordActivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityServiceServer).RecordActivity(ctx, req.(*RecordActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActivityService_ActivityStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivityStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActivityServiceServer).ActivityStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ActivityService/ActivityStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActivityServiceServer).ActivityStatus(ctx, req.(*ActivityStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ActivityService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.ActivityService",
+	HandlerType: (*ActivityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RecordActivity",
+			Handler:    _ActivityService_RecordActivity_Handler,
+		},
+		{
+			MethodName: "ActivityStatus",
+			Handler:    _ActivityService_ActivityStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "activity.proto",
+}
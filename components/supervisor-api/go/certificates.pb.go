@@ -0,0 +1,405 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: certificates.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type CertificateRequest struct {
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// hosts are additional host names or IP addresses the certificate is valid for
+	Hosts                []string `protobuf:"bytes,2,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CertificateRequest) Reset()         { *m = CertificateRequest{} }
+func (m *CertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*CertificateRequest) ProtoMessage()    {}
+func (*CertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d4cfe162e62df58, []int{0}
+}
+
+func (m *CertificateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CertificateRequest.Unmarshal(m, b)
+}
+func (m *CertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CertificateRequest.Marshal(b, m, deterministic)
+}
+func (m *CertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateRequest.Merge(m, src)
+}
+func (m *CertificateRequest) XXX_Size() int {
+	return xxx_messageInfo_CertificateRequest.Size(m)
+}
+func (m *CertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateRequest proto.InternalMessageInfo
+
+func (m *CertificateRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *CertificateRequest) GetHosts() []string {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
+type CertificateResponse struct {
+	// cert_file is the PEM encoded certificate, followed by the CA certificate
+	CertFile string `protobuf:"bytes,1,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	// key_file is the PEM encoded private key of the certificate
+	KeyFile string `protobuf:"bytes,2,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	// ca_file is the PEM encoded CA certificate
+	CaFile string `protobuf:"bytes,3,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+	// hosts are the host names and IP addresses the certificate is valid for
+	Hosts                []string             `protobuf:"bytes,4,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	NotAfter             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CertificateResponse) Reset()         { *m = CertificateResponse{} }
+func (m *CertificateResponse) String() string { return proto.CompactTextString(m) }
+func (*CertificateResponse) ProtoMessage()    {}
+func (*CertificateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d4cfe162e62df58, []int{1}
+}
+
+func (m *CertificateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CertificateResponse.Unmarshal(m, b)
+}
+func (m *CertificateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CertificateResponse.Marshal(b, m, deterministic)
+}
+func (m *CertificateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateResponse.Merge(m, src)
+}
+func (m *CertificateResponse) XXX_Size() int {
+	return xxx_messageInfo_CertificateResponse.Size(m)
+}
+func (m *CertificateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateResponse proto.InternalMessageInfo
+
+func (m *CertificateResponse) GetCertFile() string {
+	if m != nil {
+		return m.CertFile
+	}
+	return ""
+}
+
+func (m *CertificateResponse) GetKeyFile() string {
+	if m != nil {
+		return m.KeyFile
+	}
+	return ""
+}
+
+func (m *CertificateResponse) GetCaFile() string {
+	if m != nil {
+		return m.CaFile
+	}
+	return ""
+}
+
+func (m *CertificateResponse) GetHosts() []string {
+	if m != nil {
+		return m.Hosts
+	}
+	return nil
+}
+
+func (m *CertificateResponse) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+type CARequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CARequest) Reset()         { *m = CARequest{} }
+func (m *CARequest) String() string { return proto.CompactTextString(m) }
+func (*CARequest) ProtoMessage()    {}
+func (*CARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d4cfe162e62df58, []int{2}
+}
+
+func (m *CARequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CARequest.Unmarshal(m, b)
+}
+func (m *CARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CARequest.Marshal(b, m, deterministic)
+}
+func (m *CARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CARequest.Merge(m, src)
+}
+func (m *CARequest) XXX_Size() int {
+	return xxx_messageInfo_CARequest.Size(m)
+}
+func (m *CARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CARequest proto.InternalMessageInfo
+
+type CAResponse struct {
+	// ca_file is the PEM encoded CA certificate
+	CaFile string `protobuf:"bytes,1,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+	// ca_pem is the content of ca_file
+	CaPem string `protobuf:"bytes,2,opt,name=ca_pem,json=caPem,proto3" json:"ca_pem,omitempty"`
+	// trusted is true if the CA was installed into the system trust store
+	Trusted              bool     `protobuf:"varint,3,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CAResponse) Reset()         { *m = CAResponse{} }
+func (m *CAResponse) String() string { return proto.CompactTextString(m) }
+func (*CAResponse) ProtoMessage()    {}
+func (*CAResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d4cfe162e62df58, []int{3}
+}
+
+func (m *CAResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CAResponse.Unmarshal(m, b)
+}
+func (m *CAResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CAResponse.Marshal(b, m, deterministic)
+}
+func (m *CAResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CAResponse.Merge(m, src)
+}
+func (m *CAResponse) XXX_Size() int {
+	return xxx_messageInfo_CAResponse.Size(m)
+}
+func (m *CAResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CAResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CAResponse proto.InternalMessageInfo
+
+func (m *CAResponse) GetCaFile() string {
+	if m != nil {
+		return m.CaFile
+	}
+	return ""
+}
+
+func (m *CAResponse) GetCaPem() string {
+	if m != nil {
+		return m.CaPem
+	}
+	return ""
+}
+
+func (m *CAResponse) GetTrusted() bool {
+	if m != nil {
+		return m.Trusted
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*CertificateRequest)(nil), "supervisor.CertificateRequest")
+	proto.RegisterType((*CertificateResponse)(nil), "supervisor.CertificateResponse")
+	proto.RegisterType((*CARequest)(nil), "supervisor.CARequest")
+	proto.RegisterType((*CAResponse)(nil), "supervisor.CAResponse")
+}
+
+func init() {
+	proto.RegisterFile("certificates.proto", fileDescriptor_6d4cfe162e62df58)
+}
+
+var fileDescriptor_6d4cfe162e62df58 = []byte{
+	// 397 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xbf, 0x6e, 0xd4, 0x40,
+	0x10, 0xc6, 0xe5, 0xbb, 0xdc, 0x1f, 0xcf, 0x89, 0x66, 0xc2, 0xc1, 0xe1, 0x44, 0xc9, 0xc9, 0xa2,
+	0xb8, 0xca, 0x16, 0xa1, 0xa0, 0x43, 0x3a, 0x2c, 0x51, 0x47, 0x06, 0x51, 0xd0, 0x9c, 0x36, 0xcb,
+	0x38, 0xac, 0x72, 0xf6, 0x2e, 0xde, 0x71, 0xa4, 0x08, 0xd1, 0xf0, 0x0a, 0x3c, 0x0b, 0x4f, 0x42,
+	0x4b, 0xc9, 0x83, 0x20, 0xaf, 0xed, 0xc4, 0xe6, 0x44, 0x63, 0x79, 0xe6, 0x37, 0xfa, 0xe6, 0x9b,
+	0x99, 0x05, 0x94, 0x54, 0xb2, 0xca, 0x94, 0x14, 0x4c, 0x36, 0x32, 0xa5, 0x66, 0x8d, 0x60, 0x2b,
+	0x43, 0xe5, 0xad, 0xb2, 0xba, 0x0c, 0x4e, 0xaf, 0xb5, 0xbe, 0xde, 0x53, 0x2c, 0x8c, 0x8a, 0x45,
+	0x51, 0x68, 0x16, 0xac, 0x74, 0xd1, 0x56, 0x06, 0xe7, 0x2d, 0x75, 0xd1, 0x55, 0x95, 0xc5, 0xac,
+	0x72, 0xb2, 0x2c, 0x72, 0xd3, 0x14, 0x84, 0xaf, 0x01, 0x93, 0x87, 0x06, 0x29, 0x7d, 0xa9, 0xc8,
+	0x32, 0x22, 0x1c, 0x19, 0x5d, 0xf2, 0xca, 0x5b, 0x7b, 0x9b, 0x47, 0xa9, 0xfb, 0xc7, 0xc7, 0x30,
+	0xf9, 0xac, 0x2d, 0xdb, 0xd5, 0x68, 0x3d, 0xde, 0xf8, 0x69, 0x13, 0x84, 0x3f, 0x3d, 0x38, 0x1e,
+	0x08, 0x58, 0xa3, 0x0b, 0x4b, 0x78, 0x02, 0x7e, 0x6d, 0x7c, 0x97, 0xa9, 0x3d, 0x39, 0x19, 0x3f,
+	0x9d, 0xd7, 0x89, 0xb7, 0x6a, 0x4f, 0xf8, 0x0c, 0xe6, 0x37, 0x74, 0xd7, 0xb0, 0x91, 0x63, 0xb3,
+	0x1b, 0xba, 0x73, 0xe8, 0x29, 0xcc, 0xa4, 0x68, 0xc8, 0xd8, 0x91, 0xa9, 0x14, 0x0e, 0xdc, 0xb7,
+	0x3f, 0xea, 0xb5, 0xc7, 0x57, 0xe0, 0x17, 0x9a, 0x77, 0x22, 0x63, 0x2a, 0x57, 0x93, 0xb5, 0xb7,
+	0x59, 0x5c, 0x04, 0x51, 0x33, 0x73, 0xd4, 0xcd, 0x1c, 0xbd, 0xef, 0x66, 0x4e, 0xe7, 0x85, 0xe6,
+	0x6d, 0x5d, 0x1b, 0x2e, 0xc0, 0x4f, 0xb6, 0xed, 0xb8, 0xe1, 0x07, 0x80, 0x64, 0x7b, 0x6f, 0xbd,
+	0x67, 0xc1, 0x1b, 0x58, 0x58, 0xc2, 0x54, 0x8a, 0x9d, 0xa1, 0xbc, 0x35, 0x3d, 0x91, 0xe2, 0x92,
+	0x72, 0x5c, 0xc1, 0x8c, 0xcb, 0xca, 0x32, 0x7d, 0x72, 0x96, 0xe7, 0x69, 0x17, 0x5e, 0xfc, 0xf6,
+	0x06, 0xdb, 0x7d, 0x57, 0xdf, 0x4c, 0x12, 0x32, 0x2c, 0x7a, 0x59, 0x3c, 0x8b, 0x1e, 0xce, 0x19,
+	0x1d, 0x1e, 0x23, 0x38, 0xff, 0x2f, 0x6f, 0x0c, 0x87, 0xcf, 0xbf, 0xff, 0xfa, 0xf3, 0x63, 0x74,
+	0x86, 0xa7, 0xf1, 0xed, 0x8b, 0xb8, 0xff, 0x5c, 0xe2, 0xfa, 0x70, 0xf1, 0xd7, 0xfa, 0xfb, 0x0d,
+	0x2f, 0x61, 0x94, 0x6c, 0x71, 0x39, 0x10, 0xeb, 0x36, 0x10, 0x3c, 0xf9, 0x37, 0xdd, 0x4a, 0x9f,
+	0x38, 0xe9, 0x25, 0x1e, 0x1f, 0x48, 0x4b, 0xf1, 0x66, 0xf2, 0x71, 0x2c, 0x8c, 0xba, 0x9a, 0xba,
+	0x45, 0xbf, 0xfc, 0x3b, 0x00, 0x98, 0x91, 0xc1, 0xce, 0xaa, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// CertificateServiceClient is the client API for CertificateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CertificateServiceClient interface {
+	// Certificate issues a certificate for a port, which is valid for the URL of the port, localhost and 127.0.0.1.
+	// An issued certificate is reused until it is about to expire.
+	Certificate(ctx context.Context, in *CertificateRequest, opts ...grpc.CallOption) (*CertificateResponse, error)
+	// CA returns the CA certificate of the workspace, e.g. to trust it in the local browser
+	CA(ctx context.Context, in *CARequest, opts ...grpc.CallOption) (*CAResponse, error)
+}
+
+type certificateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCertificateServiceClient(cc grpc.ClientConnInterface) CertificateServiceClient {
+	return &certificateServiceClient{cc}
+}
+
+func (c *certificateServiceClient) Certificate(ctx context.Context, in *CertificateRequest, opts ...grpc.CallOption) (*CertificateResponse, error) {
+	out := new(CertificateResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.CertificateService/Certificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *certificateServiceClient) CA(ctx context.Context, in *CARequest, opts ...grpc.CallOption) (*CAResponse, error) {
+	out := new(CAResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.CertificateService/CA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CertificateServiceServer is the server API for CertificateService service.
+type CertificateServiceServer interface {
+	// Certificate issues a certificate for a port, which is valid for the URL of the port, localhost and 127.0.0.1.
+	// An issued certificate is reused until it is about to expire.
+	Certificate(context.Context, *CertificateRequest) (*CertificateResponse, error)
+	// CA returns the CA certificate of the workspace, e.g. to trust it in the local browser
+	CA(context.Context, *CARequest) (*CAResponse, error)
+}
+
+// UnimplementedCertificateServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCertificateServiceServer struct {
+}
+
+func (*UnimplementedCertificateServiceServer) Certificate(ctx context.Context, req *CertificateRequest) (*CertificateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Certificate not implemented")
+}
+func (*UnimplementedCertificateServiceServer) CA(ctx context.Context, req *CARequest) (*CAResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CA not implemented")
+}
+
+func RegisterCertificateServiceServer(s *grpc.Server, srv CertificateServiceServer) {
+	s.RegisterService(&_CertificateService_serviceDesc, srv)
+}
+
+func _CertificateService_Certificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertificateServiceServer).Certificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.CertificateService/Certificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertificateServiceServer).Certificate(ctx, req.(*CertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CertificateService_CA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertificateServiceServer).CA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.CertificateService/CA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertificateServiceServer).CA(ctx, req.(*CARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CertificateService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.CertificateService",
+	HandlerType: (*CertificateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Certificate",
+			Handler:    _CertificateService_Certificate_Handler,
+		},
+		{
+			MethodName: "CA",
+			Handler:    _CertificateService_CA_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "certificates.proto",
+}
@@ -0,0 +1,582 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: clipboard.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ClipboardContent struct {
+	// mime_type is the type of the data, e.g. text/plain
+	MimeType string `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Data     []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// source identifies the client which set the content, e.g. "gp" or "vscode-desktop"
+	Source               string               `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Updated              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ClipboardContent) Reset()         { *m = ClipboardContent{} }
+func (m *ClipboardContent) String() string { return proto.CompactTextString(m) }
+func (*ClipboardContent) ProtoMessage()    {}
+func (*ClipboardContent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_72275e738ef73aac, []int{0}
+}
+
+func (m *ClipboardContent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClipboardContent.Unmarshal(m, b)
+}
+func (m *ClipboardContent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClipboardContent.Marshal(b, m, deterministic)
+}
+func (m *ClipboardContent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClipboardContent.Merge(m, src)
+}
+func (m *ClipboardContent) XXX_Size() int {
+	return xxx_messageInfo_ClipboardContent.Size(m)
+}
+func (m *ClipboardContent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClipboardContent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClipboardContent proto.InternalMessageInfo
+
+func (m *ClipboardContent) GetMimeType() string {
+	if m != nil {
+		return m.MimeType
+	}
+	return ""
+}
+
+func (m *ClipboardContent) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ClipboardContent) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ClipboardContent) GetUpdated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+type GetClipboardRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClipboardRequest) Reset()         { *m = GetClipboardRequest{} }
+func (m *GetClipboardRequest) String() string { return proto.CompactTextString(m) }
+func (*GetClipboardRequest) ProtoMessage()    {}
+func (*GetClipboardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_72275e738ef73aac, []int{1}
+}
+
+func (m *GetClipboardRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClipboardRequest.Unmarshal(m, b)
+}
+func (m *GetClipboardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClipboardRequest.Marshal(b, m, deterministic)
+}
+func (m *GetClipboardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClipboardRequest.Merge(m, src)
+}
+func (m *GetClipboardRequest) XXX_Size() int {
+	return xxx_messageInfo_GetClipboardRequest.Size(m)
+}
+func (m *GetClipboardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClipboardRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClipboardRequest proto.InternalMessageInfo
+
+type GetClipboardResponse struct {
+	// content is empty if nothing was copied yet
+	Content *ClipboardContent `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	// max_size is the size limit of the content in bytes
+	MaxSize              uint32   `protobuf:"varint,2,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClipboardResponse) Reset()         { *m = GetClipboardResponse{} }
+func (m *GetClipboardResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClipboardResponse) ProtoMessage()    {}
+func (*GetClipboardResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_72275e738ef73aac, []int{2}
+}
+
+func (m *GetClipboardResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClipboardResponse.Unmarshal(m, b)
+}
+func (m *GetClipboardResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClipboardResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClipboardResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClipboardResponse.Merge(m, src)
+}
+func (m *GetClipboardResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClipboardResponse.Size(m)
+}
+func (m *GetClipboardResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClipboardResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClipboardResponse proto.InternalMessageInfo
+
+func (m *GetClipboardResponse) GetContent() *ClipboardContent {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *GetClipboardResponse) GetMaxSize() uint32 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+type SetClipboardRequest struct {
+	// mime_type defaults to text/plain
+	MimeType             string   `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Source               string   `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetClipboardRequest) Reset()         { *m = SetClipboardRequest{} }
+func (m *SetClipboardRequest) String() string { return proto.CompactTextString(m) }
+func (*SetClipboardRequest) ProtoMessage()    {}
+func (*SetClipboardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_72275e738ef73aac, []int{3}
+}
+
+func (m *SetClipboardRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetClipboardRequest.Unmarshal(m, b)
+}
+func (m *SetClipboardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetClipboardRequest.Marshal(b, m, deterministic)
+}
+func (m *SetClipboardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetClipboardRequest.Merge(m, src)
+}
+func (m *SetClipboardRequest) XXX_Size() int {
+	return xxx_messageInfo_SetClipboardRequest.Size(m)
+}
+func (m *SetClipboardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetClipboardRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetClipboardRequest proto.InternalMessageInfo
+
+func (m *SetClipboardRequest) GetMimeType() string {
+	if m != nil {
+		return m.MimeType
+	}
+	return ""
+}
+
+func (m *SetClipboardRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SetClipboardRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type SetClipboardResponse struct {
+	Content              *ClipboardContent `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetClipboardResponse) Reset()         { *m = SetClipboardResponse{} }
+func (m *SetClipboardResponse) String() string { return proto.CompactTextString(m) }
+func (*SetClipboardResponse) ProtoMessage()    {}
+func (*SetClipboardResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_72275e738ef73aac, []int{4}
+}
+
+func (m *SetClipboardResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetClipboardResponse.Unmarshal(m, b)
+}
+func (m *SetClipboardResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetClipboardResponse.Marshal(b, m, deterministic)
+}
+func (m *SetClipboardResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetClipboardResponse.Merge(m, src)
+}
+func (m *SetClipboardResponse) XXX_Size() int {
+	return xxx_messageInfo_SetClipboardResponse.Size(m)
+}
+func (m *SetClipboardResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetClipboardResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetClipboardResponse proto.InternalMessageInfo
+
+func (m *SetClipboardResponse) GetContent() *ClipboardContent {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+type WatchClipboardRequest struct {
+	// exclude_source skips changes made by the given source, e.g. by the watching client itself
+	ExcludeSource        string   `protobuf:"bytes,1,opt,name=exclude_source,json=excludeSource,proto3" json:"exclude_source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchClipboardRequest) Reset()         { *m = WatchClipboardRequest{} }
+func (m *WatchClipboardRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchClipboardRequest) ProtoMessage()    {}
+func (*WatchClipboardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_72275e738ef73aac, []int{5}
+}
+
+func (m *WatchClipboardRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchClipboardRequest.Unmarshal(m, b)
+}
+func (m *WatchClipboardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchClipboardRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchClipboardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchClipboardRequest.Merge(m, src)
+}
+func (m *WatchClipboardRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchClipboardRequest.Size(m)
+}
+func (m *WatchClipboardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchClipboardRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchClipboardRequest proto.InternalMessageInfo
+
+func (m *WatchClipboardRequest) GetExcludeSource() string {
+	if m != nil {
+		return m.ExcludeSource
+	}
+	return ""
+}
+
+type WatchClipboardResponse struct {
+	Content              *ClipboardContent `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *WatchClipboardResponse) Reset()         { *m = WatchClipboardResponse{} }
+func (m *WatchClipboardResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchClipboardResponse) ProtoMessage()    {}
+func (*WatchClipboardResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_72275e738ef73aac, []int{6}
+}
+
+func (m *WatchClipboardResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchClipboardResponse.Unmarshal(m, b)
+}
+func (m *WatchClipboardResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchClipboardResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchClipboardResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchClipboardResponse.Merge(m, src)
+}
+func (m *WatchClipboardResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchClipboardResponse.Size(m)
+}
+func (m *WatchClipboardResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchClipboardResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchClipboardResponse proto.InternalMessageInfo
+
+func (m *WatchClipboardResponse) GetContent() *ClipboardContent {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ClipboardContent)(nil), "supervisor.ClipboardContent")
+	proto.RegisterType((*GetClipboardRequest)(nil), "supervisor.GetClipboardRequest")
+	proto.RegisterType((*GetClipboardResponse)(nil), "supervisor.GetClipboardResponse")
+	proto.RegisterType((*SetClipboardRequest)(nil), "supervisor.SetClipboardRequest")
+	proto.RegisterType((*SetClipboardResponse)(nil), "supervisor.SetClipboardResponse")
+	proto.RegisterType((*WatchClipboardRequest)(nil), "supervisor.WatchClipboardRequest")
+	proto.RegisterType((*WatchClipboardResponse)(nil), "supervisor.WatchClipboardResponse")
+}
+
+func init() {
+	proto.RegisterFile("clipboard.proto", fileDescriptor_72275e738ef73aac)
+}
+
+var fileDescriptor_72275e738ef73aac = []byte{
+	// 442 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0x86, 0xe5, 0xb4, 0x34, 0xed, 0x34, 0x69, 0xd1, 0xa4, 0xa9, 0x8c, 0x5b, 0xa9, 0x61, 0x25,
+	0xa4, 0x88, 0x83, 0x0d, 0x01, 0x71, 0xe0, 0xc0, 0x81, 0x1e, 0xb8, 0x21, 0x64, 0x57, 0x42, 0xe2,
+	0x40, 0xb4, 0xb1, 0x87, 0x76, 0x45, 0xec, 0x5d, 0xbc, 0xeb, 0x90, 0xf6, 0xc8, 0x1b, 0x20, 0x1e,
+	0x8d, 0x27, 0x40, 0xe2, 0x41, 0x50, 0xd6, 0x76, 0x89, 0xdb, 0x14, 0x0e, 0xed, 0xcd, 0x3b, 0xfe,
+	0x3d, 0xff, 0x37, 0xf3, 0x7b, 0x61, 0x37, 0x9e, 0x0a, 0x35, 0x91, 0x3c, 0x4f, 0x7c, 0x95, 0x4b,
+	0x23, 0x11, 0x74, 0xa1, 0x28, 0x9f, 0x09, 0x2d, 0x73, 0xef, 0xf0, 0x54, 0xca, 0xd3, 0x29, 0x05,
+	0x5c, 0x89, 0x80, 0x67, 0x99, 0x34, 0xdc, 0x08, 0x99, 0xe9, 0x52, 0xe9, 0x1d, 0x55, 0x6f, 0xed,
+	0x69, 0x52, 0x7c, 0x0a, 0x8c, 0x48, 0x49, 0x1b, 0x9e, 0xaa, 0x52, 0xc0, 0xbe, 0x3b, 0x70, 0xff,
+	0xb8, 0x6e, 0x7f, 0x2c, 0x33, 0x43, 0x99, 0xc1, 0x03, 0xd8, 0x4a, 0x45, 0x4a, 0x63, 0x73, 0xae,
+	0xc8, 0x75, 0x06, 0xce, 0x70, 0x2b, 0xdc, 0x5c, 0x14, 0x4e, 0xce, 0x15, 0x21, 0xc2, 0x7a, 0xc2,
+	0x0d, 0x77, 0x5b, 0x03, 0x67, 0xd8, 0x09, 0xed, 0x33, 0xee, 0xc3, 0x86, 0x96, 0x45, 0x1e, 0x93,
+	0xbb, 0x66, 0xd5, 0xd5, 0x09, 0x9f, 0x43, 0xbb, 0x50, 0x09, 0x37, 0x94, 0xb8, 0xeb, 0x03, 0x67,
+	0xb8, 0x3d, 0xf2, 0xfc, 0x12, 0xc8, 0xaf, 0x81, 0xfc, 0x93, 0x1a, 0x28, 0xac, 0xa5, 0xac, 0x0f,
+	0xbd, 0x37, 0x64, 0x2e, 0xa9, 0x42, 0xfa, 0x52, 0x90, 0x36, 0x4c, 0xc0, 0x5e, 0xb3, 0xac, 0x95,
+	0xcc, 0x34, 0xe1, 0x0b, 0x68, 0xc7, 0x25, 0xb8, 0x65, 0xdd, 0x1e, 0x1d, 0xfa, 0x7f, 0xf7, 0xe3,
+	0x5f, 0x1d, 0x2e, 0xac, 0xc5, 0xf8, 0x00, 0x36, 0x53, 0x3e, 0x1f, 0x6b, 0x71, 0x41, 0x76, 0x98,
+	0x6e, 0xd8, 0x4e, 0xf9, 0x3c, 0x12, 0x17, 0xc4, 0x3e, 0x42, 0x2f, 0xba, 0x4e, 0x70, 0x67, 0x7b,
+	0x61, 0x6f, 0x61, 0x2f, 0xba, 0xc3, 0x51, 0xd8, 0x2b, 0xe8, 0xbf, 0xe7, 0x26, 0x3e, 0xbb, 0x46,
+	0xfc, 0x08, 0x76, 0x68, 0x1e, 0x4f, 0x8b, 0x84, 0xc6, 0x15, 0x48, 0x89, 0xdd, 0xad, 0xaa, 0x51,
+	0xc9, 0xf3, 0x0e, 0xf6, 0xaf, 0x7e, 0x7f, 0x3b, 0xa2, 0xd1, 0xaf, 0xd6, 0xd2, 0x7f, 0x15, 0x2d,
+	0xf4, 0x31, 0xe1, 0x19, 0x74, 0x96, 0x13, 0xc4, 0xa3, 0xe5, 0x5e, 0x2b, 0x22, 0xf7, 0x06, 0x37,
+	0x0b, 0x4a, 0x3e, 0xd6, 0xff, 0xf6, 0xf3, 0xf7, 0x8f, 0xd6, 0x2e, 0x76, 0x83, 0xd9, 0xd3, 0xe0,
+	0xf2, 0x9e, 0xe0, 0x67, 0xe8, 0x44, 0x37, 0x3a, 0x45, 0xff, 0x73, 0x5a, 0x95, 0x0d, 0x73, 0xad,
+	0x13, 0xb2, 0xa6, 0xd3, 0x4b, 0xe7, 0x31, 0xce, 0x60, 0xa7, 0xb9, 0x3d, 0x7c, 0xb8, 0xdc, 0x6d,
+	0x65, 0x32, 0x1e, 0xfb, 0x97, 0xa4, 0xb2, 0x3c, 0xb0, 0x96, 0x7d, 0xec, 0x35, 0x2c, 0x83, 0xaf,
+	0x0b, 0xf5, 0x13, 0xe7, 0xf5, 0xbd, 0x0f, 0x6b, 0x5c, 0x89, 0xc9, 0x86, 0xbd, 0x4b, 0xcf, 0xfe,
+	0x0c, 0x00, 0xb7, 0x17, 0x9d, 0xfe, 0x27, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ClipboardServiceClient is the client API for ClipboardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ClipboardServiceClient interface {
+	// GetClipboard returns the content of the clipboard.
+	GetClipboard(ctx context.Context, in *GetClipboardRequest, opts ...grpc.CallOption) (*GetClipboardResponse, error)
+	// SetClipboard replaces the content of the clipboard. Content beyond the size limit is rejected.
+	SetClipboard(ctx context.Context, in *SetClipboardRequest, opts ...grpc.CallOption) (*SetClipboardResponse, error)
+	// WatchClipboard streams the content of the clipboard whenever it changes, starting with the current one.
+	WatchClipboard(ctx context.Context, in *WatchClipboardRequest, opts ...grpc.CallOption) (ClipboardService_WatchClipboardClient, error)
+}
+
+type clipboardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClipboardServiceClient(cc grpc.ClientConnInterface) ClipboardServiceClient {
+	return &clipboardServiceClient{cc}
+}
+
+func (c *clipboardServiceClient) GetClipboard(ctx context.Context, in *GetClipboardRequest, opts ...grpc.CallOption) (*GetClipboardResponse, error) {
+	out := new(GetClipboardResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ClipboardService/GetClipboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clipboardServiceClient) SetClipboard(ctx context.Context, in *SetClipboardRequest, opts ...grpc.CallOption) (*SetClipboardResponse, error) {
+	out := new(SetClipboardResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ClipboardService/SetClipboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clipboardServiceClient) WatchClipboard(ctx context.Context, in *WatchClipboardRequest, opts ...grpc.CallOption) (ClipboardService_WatchClipboardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ClipboardService_serviceDesc.Streams[0], "/supervisor.ClipboardService/WatchClipboard", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clipboardServiceWatchClipboardClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ClipboardService_WatchClipboardClient interface {
+	Recv() (*WatchClipboardResponse, error)
+	grpc.ClientStream
+}
+
+type clipboardServiceWatchClipboardClient struct {
+	grpc.ClientStream
+}
+
+func (x *clipboardServiceWatchClipboardClient) Recv() (*WatchClipboardResponse, error) {
+	m := new(WatchClipboardResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ClipboardServiceServer is the server API for ClipboardService service.
+type ClipboardServiceServer interface {
+	// GetClipboard returns the content of the clipboard.
+	GetClipboard(context.Context, *GetClipboardRequest) (*GetClipboardResponse, error)
+	// SetClipboard replaces the content of the clipboard. Content beyond the size limit is rejected.
+	SetClipboard(context.Context, *SetClipboardRequest) (*SetClipboardResponse, error)
+	// WatchClipboard streams the content of the clipboard whenever it changes, starting with the current one.
+	WatchClipboard(*WatchClipboardRequest, ClipboardService_WatchClipboardServer) error
+}
+
+// UnimplementedClipboardServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedClipboardServiceServer struct {
+}
+
+func (*UnimplementedClipboardServiceServer) GetClipboard(ctx context.Context, req *GetClipboardRequest) (*GetClipboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClipboard not implemented")
+}
+func (*UnimplementedClipboardServiceServer) SetClipboard(ctx context.Context, req *SetClipboardRequest) (*SetClipboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClipboard not implemented")
+}
+func (*UnimplementedClipboardServiceServer) WatchClipboard(req *WatchClipboardRequest, srv ClipboardService_WatchClipboardServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchClipboard not implemented")
+}
+
+func RegisterClipboardServiceServer(s *grpc.Server, srv ClipboardServiceServer) {
+	s.RegisterService(&_ClipboardService_serviceDesc, srv)
+}
+
+func _ClipboardService_GetClipboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClipboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipboardServiceServer).GetClipboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ClipboardService/GetClipboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipboardServiceServer).GetClipboard(ctx, req.(*GetClipboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClipboardService_SetClipboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetClipboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClipboardServiceServer).SetClipboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ClipboardService/SetClipboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClipboardServiceServer).SetClipboard(ctx, req.(*SetClipboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClipboardService_WatchClipboard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchClipboardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClipboardServiceServer).WatchClipboard(m, &clipboardServiceWatchClipboardServer{stream})
+}
+
+type ClipboardService_WatchClipboardServer interface {
+	Send(*WatchClipboardResponse) error
+	grpc.ServerStream
+}
+
+type clipboardServiceWatchClipboardServer struct {
+	grpc.ServerStream
+}
+
+func (x *clipboardServiceWatchClipboardServer) Send(m *WatchClipboardResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ClipboardService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.ClipboardService",
+	HandlerType: (*ClipboardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetClipboard",
+			Handler:    _ClipboardService_GetClipboard_Handler,
+		},
+		{
+			MethodName: "SetClipboard",
+			Handler:    _ClipboardService_SetClipboard_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchClipboard",
+			Handler:       _ClipboardService_WatchClipboard_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "clipboard.proto",
+}
@@ -108,9 +108,805 @@ func (m *ExposePortResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ExposePortResponse proto.InternalMessageInfo
 
+type ApprovePublicPortRequest struct {
+	// local port
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// approve makes the port public, otherwise it stays private
+	Approve              bool     `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApprovePublicPortRequest) Reset()         { *m = ApprovePublicPortRequest{} }
+func (m *ApprovePublicPortRequest) String() string { return proto.CompactTextString(m) }
+func (*ApprovePublicPortRequest) ProtoMessage()    {}
+func (*ApprovePublicPortRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{2}
+}
+
+func (m *ApprovePublicPortRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApprovePublicPortRequest.Unmarshal(m, b)
+}
+func (m *ApprovePublicPortRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApprovePublicPortRequest.Marshal(b, m, deterministic)
+}
+func (m *ApprovePublicPortRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApprovePublicPortRequest.Merge(m, src)
+}
+func (m *ApprovePublicPortRequest) XXX_Size() int {
+	return xxx_messageInfo_ApprovePublicPortRequest.Size(m)
+}
+func (m *ApprovePublicPortRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApprovePublicPortRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApprovePublicPortRequest proto.InternalMessageInfo
+
+func (m *ApprovePublicPortRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *ApprovePublicPortRequest) GetApprove() bool {
+	if m != nil {
+		return m.Approve
+	}
+	return false
+}
+
+type ApprovePublicPortResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApprovePublicPortResponse) Reset()         { *m = ApprovePublicPortResponse{} }
+func (m *ApprovePublicPortResponse) String() string { return proto.CompactTextString(m) }
+func (*ApprovePublicPortResponse) ProtoMessage()    {}
+func (*ApprovePublicPortResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{3}
+}
+
+func (m *ApprovePublicPortResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApprovePublicPortResponse.Unmarshal(m, b)
+}
+func (m *ApprovePublicPortResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApprovePublicPortResponse.Marshal(b, m, deterministic)
+}
+func (m *ApprovePublicPortResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApprovePublicPortResponse.Merge(m, src)
+}
+func (m *ApprovePublicPortResponse) XXX_Size() int {
+	return xxx_messageInfo_ApprovePublicPortResponse.Size(m)
+}
+func (m *ApprovePublicPortResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApprovePublicPortResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApprovePublicPortResponse proto.InternalMessageInfo
+
+type AcceptPortRemapRequest struct {
+	// local port
+	Port                 uint32   `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcceptPortRemapRequest) Reset()         { *m = AcceptPortRemapRequest{} }
+func (m *AcceptPortRemapRequest) String() string { return proto.CompactTextString(m) }
+func (*AcceptPortRemapRequest) ProtoMessage()    {}
+func (*AcceptPortRemapRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{4}
+}
+
+func (m *AcceptPortRemapRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcceptPortRemapRequest.Unmarshal(m, b)
+}
+func (m *AcceptPortRemapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcceptPortRemapRequest.Marshal(b, m, deterministic)
+}
+func (m *AcceptPortRemapRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcceptPortRemapRequest.Merge(m, src)
+}
+func (m *AcceptPortRemapRequest) XXX_Size() int {
+	return xxx_messageInfo_AcceptPortRemapRequest.Size(m)
+}
+func (m *AcceptPortRemapRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcceptPortRemapRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcceptPortRemapRequest proto.InternalMessageInfo
+
+func (m *AcceptPortRemapRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type AcceptPortRemapResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcceptPortRemapResponse) Reset()         { *m = AcceptPortRemapResponse{} }
+func (m *AcceptPortRemapResponse) String() string { return proto.CompactTextString(m) }
+func (*AcceptPortRemapResponse) ProtoMessage()    {}
+func (*AcceptPortRemapResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{5}
+}
+
+func (m *AcceptPortRemapResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcceptPortRemapResponse.Unmarshal(m, b)
+}
+func (m *AcceptPortRemapResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcceptPortRemapResponse.Marshal(b, m, deterministic)
+}
+func (m *AcceptPortRemapResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcceptPortRemapResponse.Merge(m, src)
+}
+func (m *AcceptPortRemapResponse) XXX_Size() int {
+	return xxx_messageInfo_AcceptPortRemapResponse.Size(m)
+}
+func (m *AcceptPortRemapResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcceptPortRemapResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcceptPortRemapResponse proto.InternalMessageInfo
+
+type SetPortsVerboseRequest struct {
+	// verbose logs every observation, port change and exposure made by the ports manager
+	Verbose              bool     `protobuf:"varint,1,opt,name=verbose,proto3" json:"verbose,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetPortsVerboseRequest) Reset()         { *m = SetPortsVerboseRequest{} }
+func (m *SetPortsVerboseRequest) String() string { return proto.CompactTextString(m) }
+func (*SetPortsVerboseRequest) ProtoMessage()    {}
+func (*SetPortsVerboseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{6}
+}
+
+func (m *SetPortsVerboseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetPortsVerboseRequest.Unmarshal(m, b)
+}
+func (m *SetPortsVerboseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetPortsVerboseRequest.Marshal(b, m, deterministic)
+}
+func (m *SetPortsVerboseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetPortsVerboseRequest.Merge(m, src)
+}
+func (m *SetPortsVerboseRequest) XXX_Size() int {
+	return xxx_messageInfo_SetPortsVerboseRequest.Size(m)
+}
+func (m *SetPortsVerboseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetPortsVerboseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetPortsVerboseRequest proto.InternalMessageInfo
+
+func (m *SetPortsVerboseRequest) GetVerbose() bool {
+	if m != nil {
+		return m.Verbose
+	}
+	return false
+}
+
+type SetPortsVerboseResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetPortsVerboseResponse) Reset()         { *m = SetPortsVerboseResponse{} }
+func (m *SetPortsVerboseResponse) String() string { return proto.CompactTextString(m) }
+func (*SetPortsVerboseResponse) ProtoMessage()    {}
+func (*SetPortsVerboseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{7}
+}
+
+func (m *SetPortsVerboseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetPortsVerboseResponse.Unmarshal(m, b)
+}
+func (m *SetPortsVerboseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetPortsVerboseResponse.Marshal(b, m, deterministic)
+}
+func (m *SetPortsVerboseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetPortsVerboseResponse.Merge(m, src)
+}
+func (m *SetPortsVerboseResponse) XXX_Size() int {
+	return xxx_messageInfo_SetPortsVerboseResponse.Size(m)
+}
+func (m *SetPortsVerboseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetPortsVerboseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetPortsVerboseResponse proto.InternalMessageInfo
+
+type SimulatePortRequest struct {
+	// local port
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// served simulates a process serving the port on all interfaces
+	Served bool `protobuf:"varint,2,opt,name=served,proto3" json:"served,omitempty"`
+	// process is the command name of the simulated process serving the port
+	Process string `protobuf:"bytes,3,opt,name=process,proto3" json:"process,omitempty"`
+	// exposed simulates that the port is exposed, regardless of whether it is served.
+	// A port which is neither served nor exposed is no longer simulated.
+	Exposed bool `protobuf:"varint,4,opt,name=exposed,proto3" json:"exposed,omitempty"`
+	// public simulates a public exposure
+	Public bool `protobuf:"varint,5,opt,name=public,proto3" json:"public,omitempty"`
+	// url is the URL the port is exposed on, a localhost URL if empty
+	Url                  string   `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SimulatePortRequest) Reset()         { *m = SimulatePortRequest{} }
+func (m *SimulatePortRequest) String() string { return proto.CompactTextString(m) }
+func (*SimulatePortRequest) ProtoMessage()    {}
+func (*SimulatePortRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{8}
+}
+
+func (m *SimulatePortRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulatePortRequest.Unmarshal(m, b)
+}
+func (m *SimulatePortRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulatePortRequest.Marshal(b, m, deterministic)
+}
+func (m *SimulatePortRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulatePortRequest.Merge(m, src)
+}
+func (m *SimulatePortRequest) XXX_Size() int {
+	return xxx_messageInfo_SimulatePortRequest.Size(m)
+}
+func (m *SimulatePortRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulatePortRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulatePortRequest proto.InternalMessageInfo
+
+func (m *SimulatePortRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *SimulatePortRequest) GetServed() bool {
+	if m != nil {
+		return m.Served
+	}
+	return false
+}
+
+func (m *SimulatePortRequest) GetProcess() string {
+	if m != nil {
+		return m.Process
+	}
+	return ""
+}
+
+func (m *SimulatePortRequest) GetExposed() bool {
+	if m != nil {
+		return m.Exposed
+	}
+	return false
+}
+
+func (m *SimulatePortRequest) GetPublic() bool {
+	if m != nil {
+		return m.Public
+	}
+	return false
+}
+
+func (m *SimulatePortRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+type SimulatePortResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SimulatePortResponse) Reset()         { *m = SimulatePortResponse{} }
+func (m *SimulatePortResponse) String() string { return proto.CompactTextString(m) }
+func (*SimulatePortResponse) ProtoMessage()    {}
+func (*SimulatePortResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{9}
+}
+
+func (m *SimulatePortResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulatePortResponse.Unmarshal(m, b)
+}
+func (m *SimulatePortResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulatePortResponse.Marshal(b, m, deterministic)
+}
+func (m *SimulatePortResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulatePortResponse.Merge(m, src)
+}
+func (m *SimulatePortResponse) XXX_Size() int {
+	return xxx_messageInfo_SimulatePortResponse.Size(m)
+}
+func (m *SimulatePortResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulatePortResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulatePortResponse proto.InternalMessageInfo
+
+type RunLifecycleHookRequest struct {
+	// hook is the name of the hook as in the .gitpod.yml, i.e. onStart, onStop, onSnapshot or onPrebuild
+	Hook                 string   `protobuf:"bytes,1,opt,name=hook,proto3" json:"hook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunLifecycleHookRequest) Reset()         { *m = RunLifecycleHookRequest{} }
+func (m *RunLifecycleHookRequest) String() string { return proto.CompactTextString(m) }
+func (*RunLifecycleHookRequest) ProtoMessage()    {}
+func (*RunLifecycleHookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{10}
+}
+
+func (m *RunLifecycleHookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunLifecycleHookRequest.Unmarshal(m, b)
+}
+func (m *RunLifecycleHookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunLifecycleHookRequest.Marshal(b, m, deterministic)
+}
+func (m *RunLifecycleHookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunLifecycleHookRequest.Merge(m, src)
+}
+func (m *RunLifecycleHookRequest) XXX_Size() int {
+	return xxx_messageInfo_RunLifecycleHookRequest.Size(m)
+}
+func (m *RunLifecycleHookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunLifecycleHookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunLifecycleHookRequest proto.InternalMessageInfo
+
+func (m *RunLifecycleHookRequest) GetHook() string {
+	if m != nil {
+		return m.Hook
+	}
+	return ""
+}
+
+type RunLifecycleHookResponse struct {
+	// configured is false if the .gitpod.yml configures no such hook, in which case nothing ran
+	Configured           bool     `protobuf:"varint,1,opt,name=configured,proto3" json:"configured,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunLifecycleHookResponse) Reset()         { *m = RunLifecycleHookResponse{} }
+func (m *RunLifecycleHookResponse) String() string { return proto.CompactTextString(m) }
+func (*RunLifecycleHookResponse) ProtoMessage()    {}
+func (*RunLifecycleHookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{11}
+}
+
+func (m *RunLifecycleHookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunLifecycleHookResponse.Unmarshal(m, b)
+}
+func (m *RunLifecycleHookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunLifecycleHookResponse.Marshal(b, m, deterministic)
+}
+func (m *RunLifecycleHookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunLifecycleHookResponse.Merge(m, src)
+}
+func (m *RunLifecycleHookResponse) XXX_Size() int {
+	return xxx_messageInfo_RunLifecycleHookResponse.Size(m)
+}
+func (m *RunLifecycleHookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunLifecycleHookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunLifecycleHookResponse proto.InternalMessageInfo
+
+func (m *RunLifecycleHookResponse) GetConfigured() bool {
+	if m != nil {
+		return m.Configured
+	}
+	return false
+}
+
+type CreateDiagnosticsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateDiagnosticsRequest) Reset()         { *m = CreateDiagnosticsRequest{} }
+func (m *CreateDiagnosticsRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateDiagnosticsRequest) ProtoMessage()    {}
+func (*CreateDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{12}
+}
+
+func (m *CreateDiagnosticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateDiagnosticsRequest.Unmarshal(m, b)
+}
+func (m *CreateDiagnosticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateDiagnosticsRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateDiagnosticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateDiagnosticsRequest.Merge(m, src)
+}
+func (m *CreateDiagnosticsRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateDiagnosticsRequest.Size(m)
+}
+func (m *CreateDiagnosticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateDiagnosticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateDiagnosticsRequest proto.InternalMessageInfo
+
+type CreateDiagnosticsResponse struct {
+	// location is the path of the tarball in the workspace
+	Location             string   `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateDiagnosticsResponse) Reset()         { *m = CreateDiagnosticsResponse{} }
+func (m *CreateDiagnosticsResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateDiagnosticsResponse) ProtoMessage()    {}
+func (*CreateDiagnosticsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{13}
+}
+
+func (m *CreateDiagnosticsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateDiagnosticsResponse.Unmarshal(m, b)
+}
+func (m *CreateDiagnosticsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateDiagnosticsResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateDiagnosticsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateDiagnosticsResponse.Merge(m, src)
+}
+func (m *CreateDiagnosticsResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateDiagnosticsResponse.Size(m)
+}
+func (m *CreateDiagnosticsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateDiagnosticsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateDiagnosticsResponse proto.InternalMessageInfo
+
+func (m *CreateDiagnosticsResponse) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+type SetLogLevelRequest struct {
+	// subsystem is the subsystem whose level is changed, e.g. ports. If empty, the level of supervisor
+	// and of all subsystems without a level of their own is changed.
+	Subsystem string `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	// level is the level to log with, e.g. debug. If empty, the subsystem logs with the level of supervisor again.
+	Level                string   `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelRequest) Reset()         { *m = SetLogLevelRequest{} }
+func (m *SetLogLevelRequest) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelRequest) ProtoMessage()    {}
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{14}
+}
+
+func (m *SetLogLevelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelRequest.Unmarshal(m, b)
+}
+func (m *SetLogLevelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelRequest.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelRequest.Merge(m, src)
+}
+func (m *SetLogLevelRequest) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelRequest.Size(m)
+}
+func (m *SetLogLevelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelRequest proto.InternalMessageInfo
+
+func (m *SetLogLevelRequest) GetSubsystem() string {
+	if m != nil {
+		return m.Subsystem
+	}
+	return ""
+}
+
+func (m *SetLogLevelRequest) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+type SetLogLevelResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelResponse) Reset()         { *m = SetLogLevelResponse{} }
+func (m *SetLogLevelResponse) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelResponse) ProtoMessage()    {}
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{15}
+}
+
+func (m *SetLogLevelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelResponse.Unmarshal(m, b)
+}
+func (m *SetLogLevelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelResponse.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelResponse.Merge(m, src)
+}
+func (m *SetLogLevelResponse) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelResponse.Size(m)
+}
+func (m *SetLogLevelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelResponse proto.InternalMessageInfo
+
+type LogLevelsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogLevelsRequest) Reset()         { *m = LogLevelsRequest{} }
+func (m *LogLevelsRequest) String() string { return proto.CompactTextString(m) }
+func (*LogLevelsRequest) ProtoMessage()    {}
+func (*LogLevelsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{16}
+}
+
+func (m *LogLevelsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogLevelsRequest.Unmarshal(m, b)
+}
+func (m *LogLevelsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogLevelsRequest.Marshal(b, m, deterministic)
+}
+func (m *LogLevelsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogLevelsRequest.Merge(m, src)
+}
+func (m *LogLevelsRequest) XXX_Size() int {
+	return xxx_messageInfo_LogLevelsRequest.Size(m)
+}
+func (m *LogLevelsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogLevelsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogLevelsRequest proto.InternalMessageInfo
+
+type LogLevelsResponse struct {
+	// level is the level supervisor logs with
+	Level                string               `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Subsystems           []*SubsystemLogLevel `protobuf:"bytes,2,rep,name=subsystems,proto3" json:"subsystems,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *LogLevelsResponse) Reset()         { *m = LogLevelsResponse{} }
+func (m *LogLevelsResponse) String() string { return proto.CompactTextString(m) }
+func (*LogLevelsResponse) ProtoMessage()    {}
+func (*LogLevelsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{17}
+}
+
+func (m *LogLevelsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogLevelsResponse.Unmarshal(m, b)
+}
+func (m *LogLevelsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogLevelsResponse.Marshal(b, m, deterministic)
+}
+func (m *LogLevelsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogLevelsResponse.Merge(m, src)
+}
+func (m *LogLevelsResponse) XXX_Size() int {
+	return xxx_messageInfo_LogLevelsResponse.Size(m)
+}
+func (m *LogLevelsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogLevelsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogLevelsResponse proto.InternalMessageInfo
+
+func (m *LogLevelsResponse) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *LogLevelsResponse) GetSubsystems() []*SubsystemLogLevel {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+type SubsystemLogLevel struct {
+	Subsystem string `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	Level     string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	// own is true if the level was set for the subsystem, rather than being the level of supervisor
+	Own                  bool     `protobuf:"varint,3,opt,name=own,proto3" json:"own,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubsystemLogLevel) Reset()         { *m = SubsystemLogLevel{} }
+func (m *SubsystemLogLevel) String() string { return proto.CompactTextString(m) }
+func (*SubsystemLogLevel) ProtoMessage()    {}
+func (*SubsystemLogLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{18}
+}
+
+func (m *SubsystemLogLevel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubsystemLogLevel.Unmarshal(m, b)
+}
+func (m *SubsystemLogLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubsystemLogLevel.Marshal(b, m, deterministic)
+}
+func (m *SubsystemLogLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubsystemLogLevel.Merge(m, src)
+}
+func (m *SubsystemLogLevel) XXX_Size() int {
+	return xxx_messageInfo_SubsystemLogLevel.Size(m)
+}
+func (m *SubsystemLogLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubsystemLogLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubsystemLogLevel proto.InternalMessageInfo
+
+func (m *SubsystemLogLevel) GetSubsystem() string {
+	if m != nil {
+		return m.Subsystem
+	}
+	return ""
+}
+
+func (m *SubsystemLogLevel) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *SubsystemLogLevel) GetOwn() bool {
+	if m != nil {
+		return m.Own
+	}
+	return false
+}
+
+type BackupRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{19}
+}
+
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupRequest.Size(m)
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+type BackupResponse struct {
+	// duration_ms is how long the backup took
+	DurationMs           uint64   `protobuf:"varint,1,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupResponse) Reset()         { *m = BackupResponse{} }
+func (m *BackupResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupResponse) ProtoMessage()    {}
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{20}
+}
+
+func (m *BackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupResponse.Unmarshal(m, b)
+}
+func (m *BackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupResponse.Merge(m, src)
+}
+func (m *BackupResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupResponse.Size(m)
+}
+func (m *BackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupResponse proto.InternalMessageInfo
+
+func (m *BackupResponse) GetDurationMs() uint64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ExposePortRequest)(nil), "supervisor.ExposePortRequest")
 	proto.RegisterType((*ExposePortResponse)(nil), "supervisor.ExposePortResponse")
+	proto.RegisterType((*ApprovePublicPortRequest)(nil), "supervisor.ApprovePublicPortRequest")
+	proto.RegisterType((*ApprovePublicPortResponse)(nil), "supervisor.ApprovePublicPortResponse")
+	proto.RegisterType((*AcceptPortRemapRequest)(nil), "supervisor.AcceptPortRemapRequest")
+	proto.RegisterType((*AcceptPortRemapResponse)(nil), "supervisor.AcceptPortRemapResponse")
+	proto.RegisterType((*SetPortsVerboseRequest)(nil), "supervisor.SetPortsVerboseRequest")
+	proto.RegisterType((*SetPortsVerboseResponse)(nil), "supervisor.SetPortsVerboseResponse")
+	proto.RegisterType((*SimulatePortRequest)(nil), "supervisor.SimulatePortRequest")
+	proto.RegisterType((*SimulatePortResponse)(nil), "supervisor.SimulatePortResponse")
+	proto.RegisterType((*RunLifecycleHookRequest)(nil), "supervisor.RunLifecycleHookRequest")
+	proto.RegisterType((*RunLifecycleHookResponse)(nil), "supervisor.RunLifecycleHookResponse")
+	proto.RegisterType((*CreateDiagnosticsRequest)(nil), "supervisor.CreateDiagnosticsRequest")
+	proto.RegisterType((*CreateDiagnosticsResponse)(nil), "supervisor.CreateDiagnosticsResponse")
+	proto.RegisterType((*SetLogLevelRequest)(nil), "supervisor.SetLogLevelRequest")
+	proto.RegisterType((*SetLogLevelResponse)(nil), "supervisor.SetLogLevelResponse")
+	proto.RegisterType((*LogLevelsRequest)(nil), "supervisor.LogLevelsRequest")
+	proto.RegisterType((*LogLevelsResponse)(nil), "supervisor.LogLevelsResponse")
+	proto.RegisterType((*SubsystemLogLevel)(nil), "supervisor.SubsystemLogLevel")
+	proto.RegisterType((*BackupRequest)(nil), "supervisor.BackupRequest")
+	proto.RegisterType((*BackupResponse)(nil), "supervisor.BackupResponse")
 }
 
 func init() {
@@ -118,18 +914,52 @@ func init() {
 }
 
 var fileDescriptor_0c5120591600887d = []byte{
-	// 169 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4d, 0xce, 0xcf, 0x2b,
-	0x29, 0xca, 0xcf, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x2a, 0x2e, 0x2d, 0x48, 0x2d,
-	0x2a, 0xcb, 0x2c, 0xce, 0x2f, 0x52, 0xf2, 0xe0, 0x12, 0x74, 0xad, 0x28, 0xc8, 0x2f, 0x4e, 0x0d,
-	0xc8, 0x2f, 0x2a, 0x09, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x11, 0x12, 0xe2, 0x62, 0x29, 0xc8,
-	0x2f, 0x2a, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0d, 0x02, 0xb3, 0x85, 0xe4, 0xb9, 0xb8, 0x4b,
-	0x12, 0x8b, 0xd2, 0x53, 0x4b, 0xe2, 0xc1, 0x52, 0x4c, 0x60, 0x29, 0x2e, 0x88, 0x10, 0x48, 0xaf,
-	0x92, 0x08, 0x97, 0x10, 0xb2, 0x49, 0xc5, 0x05, 0xf9, 0x79, 0xc5, 0xa9, 0x46, 0xf1, 0x5c, 0x7c,
-	0xce, 0x10, 0xcb, 0x83, 0x41, 0x56, 0x26, 0xa7, 0x0a, 0xf9, 0x72, 0x71, 0x21, 0xd4, 0x09, 0xc9,
-	0xea, 0x21, 0x1c, 0xa3, 0x87, 0xe1, 0x12, 0x29, 0x39, 0x5c, 0xd2, 0x10, 0xe3, 0x95, 0x18, 0x9c,
-	0x58, 0xa3, 0x98, 0x13, 0x0b, 0x32, 0x93, 0xd8, 0xc0, 0x5e, 0x33, 0x06, 0x04, 0x00, 0x00, 0xff,
-	0xff, 0xf3, 0x3a, 0x46, 0x2b, 0xeb, 0x00, 0x00, 0x00,
+	// 707 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x5d, 0x4f, 0xdb, 0x3c,
+	0x14, 0x7e, 0x4b, 0xa1, 0xb4, 0xa7, 0x2f, 0x1f, 0x35, 0xac, 0xa4, 0x1e, 0xd0, 0xca, 0x80, 0xc4,
+	0xc5, 0x86, 0x34, 0x76, 0x31, 0x69, 0xd2, 0x2e, 0x80, 0x4d, 0xaa, 0x26, 0x90, 0x50, 0x2a, 0x4d,
+	0xda, 0x34, 0x09, 0xa5, 0xa9, 0x29, 0x11, 0x69, 0x9c, 0xd9, 0x4e, 0x37, 0x7e, 0xcd, 0xfe, 0xe7,
+	0xae, 0xa6, 0x38, 0x4e, 0x70, 0x9a, 0xa6, 0x95, 0x76, 0xe7, 0x73, 0xce, 0x73, 0x9e, 0xf3, 0x91,
+	0x9e, 0xa7, 0xb0, 0xe1, 0xb2, 0x40, 0x72, 0xe6, 0x9f, 0x85, 0x9c, 0x49, 0x86, 0x40, 0x44, 0x21,
+	0xe5, 0x53, 0x4f, 0x30, 0x4e, 0xfa, 0xd0, 0xfa, 0xf4, 0x2b, 0x64, 0x82, 0xde, 0x32, 0x2e, 0x6d,
+	0xfa, 0x23, 0xa2, 0x42, 0x22, 0x04, 0xab, 0x21, 0xe3, 0xd2, 0xaa, 0xf4, 0x2a, 0xa7, 0x1b, 0xb6,
+	0x7a, 0xa3, 0x2e, 0x34, 0xa5, 0xc3, 0xc7, 0x54, 0xde, 0xa9, 0xd0, 0x8a, 0x0a, 0x41, 0xe2, 0x8a,
+	0x73, 0xc9, 0x2e, 0x20, 0x93, 0x49, 0x84, 0x2c, 0x10, 0x94, 0xf4, 0xc1, 0xba, 0x08, 0x43, 0xce,
+	0xa6, 0xf4, 0x36, 0x1a, 0xfa, 0x9e, 0xbb, 0xac, 0x8c, 0x05, 0xeb, 0x4e, 0x82, 0x57, 0x25, 0xea,
+	0x76, 0x6a, 0x92, 0x97, 0xd0, 0x99, 0xc3, 0xa4, 0xcb, 0xbc, 0x82, 0xf6, 0x85, 0xeb, 0xd2, 0x50,
+	0x26, 0xde, 0x89, 0x13, 0x2e, 0x28, 0x42, 0x3a, 0xb0, 0x57, 0x40, 0x6b, 0xa2, 0x73, 0x68, 0x0f,
+	0x92, 0x81, 0xc4, 0x17, 0xca, 0x87, 0x4c, 0xd0, 0x94, 0xc8, 0x82, 0xf5, 0x69, 0xe2, 0x51, 0x5c,
+	0x75, 0x3b, 0x35, 0x63, 0xba, 0x42, 0x8e, 0xa6, 0xfb, 0x5d, 0x81, 0x9d, 0x81, 0x37, 0x89, 0x7c,
+	0x47, 0x2e, 0xdd, 0x70, 0x1b, 0x6a, 0x82, 0xf2, 0x29, 0x1d, 0xe9, 0xc9, 0xb5, 0x15, 0x17, 0x0e,
+	0x39, 0x73, 0xa9, 0x10, 0x56, 0xb5, 0x57, 0x39, 0x6d, 0xd8, 0xa9, 0x19, 0x47, 0xa8, 0x5a, 0xf9,
+	0xc8, 0x5a, 0x4d, 0x5a, 0xd2, 0x66, 0xcc, 0x15, 0xaa, 0x2d, 0x59, 0x6b, 0x09, 0x57, 0x62, 0xa1,
+	0x6d, 0xa8, 0x46, 0xdc, 0xb7, 0x6a, 0x8a, 0x27, 0x7e, 0x92, 0x36, 0xec, 0xe6, 0x1b, 0xd4, 0x9d,
+	0xbf, 0x86, 0x3d, 0x3b, 0x0a, 0xae, 0xbd, 0x7b, 0xea, 0x3e, 0xb9, 0x3e, 0xed, 0x33, 0xf6, 0x68,
+	0x34, 0xff, 0xc0, 0xd8, 0xa3, 0x6a, 0xbe, 0x61, 0xab, 0x37, 0x79, 0x0f, 0x56, 0x11, 0x9e, 0x50,
+	0xa1, 0x43, 0x00, 0x97, 0x05, 0xf7, 0xde, 0x38, 0xe2, 0x74, 0xa4, 0x97, 0x67, 0x78, 0x08, 0x06,
+	0xeb, 0x8a, 0x53, 0x47, 0xd2, 0x8f, 0x9e, 0x33, 0x0e, 0x98, 0x90, 0x9e, 0x2b, 0x74, 0x2d, 0xf2,
+	0x0e, 0x3a, 0x73, 0x62, 0x9a, 0x18, 0x43, 0xdd, 0x67, 0xae, 0x23, 0x3d, 0x16, 0xe8, 0x66, 0x32,
+	0x9b, 0xf4, 0x01, 0x0d, 0xa8, 0xbc, 0x66, 0xe3, 0x6b, 0x3a, 0xa5, 0x7e, 0xda, 0xfa, 0x3e, 0x34,
+	0x44, 0x34, 0x14, 0x4f, 0x42, 0xd2, 0x89, 0x4e, 0x79, 0x76, 0xa0, 0x5d, 0x58, 0xf3, 0x63, 0xb4,
+	0xfa, 0x00, 0x0d, 0x3b, 0x31, 0xc8, 0x0b, 0xd8, 0xc9, 0x31, 0xe9, 0x05, 0x21, 0xd8, 0x4e, 0x7d,
+	0x59, 0xb7, 0x0f, 0xd0, 0x32, 0x7c, 0xba, 0xcb, 0x8c, 0xb5, 0x62, 0xb0, 0xa2, 0x0f, 0x00, 0x59,
+	0x61, 0x61, 0xad, 0xf4, 0xaa, 0xa7, 0xcd, 0xf3, 0x83, 0xb3, 0xe7, 0xcb, 0x3c, 0x1b, 0xa4, 0xd1,
+	0xac, 0xb2, 0x91, 0x40, 0xbe, 0x42, 0xab, 0x00, 0xf8, 0x97, 0xe9, 0xe2, 0x5f, 0x04, 0xfb, 0x19,
+	0xa8, 0x5f, 0x56, 0xdd, 0x8e, 0x9f, 0x64, 0x0b, 0x36, 0x2e, 0x1d, 0xf7, 0x31, 0x4a, 0x4f, 0x88,
+	0xbc, 0x81, 0xcd, 0xd4, 0xa1, 0x47, 0xea, 0x42, 0x73, 0x14, 0x71, 0xb5, 0xe8, 0xbb, 0x89, 0x50,
+	0xa5, 0x56, 0x6d, 0x48, 0x5d, 0x37, 0xe2, 0xfc, 0x4f, 0x0d, 0x36, 0xaf, 0x12, 0xd1, 0x19, 0xc4,
+	0x03, 0xb9, 0x14, 0xdd, 0x00, 0x3c, 0xeb, 0x03, 0xca, 0x8d, 0x5a, 0x50, 0x20, 0x7c, 0x58, 0x16,
+	0xd6, 0xcb, 0xff, 0x0f, 0x0d, 0xa1, 0x55, 0x90, 0x03, 0x74, 0x6c, 0xa6, 0x95, 0xe9, 0x0e, 0x3e,
+	0x59, 0x82, 0xca, 0x6a, 0x7c, 0x87, 0xad, 0x19, 0x9d, 0x40, 0x24, 0x97, 0x3b, 0x57, 0x72, 0xf0,
+	0xd1, 0x42, 0x8c, 0xc9, 0x3e, 0x23, 0x1b, 0x79, 0xf6, 0xf9, 0x3a, 0x84, 0x8f, 0x16, 0x62, 0x32,
+	0xf6, 0x01, 0xfc, 0x6f, 0xde, 0x35, 0xea, 0xe6, 0xd2, 0x8a, 0x92, 0x84, 0x7b, 0xe5, 0x80, 0x8c,
+	0xf4, 0x0e, 0xb6, 0x67, 0xaf, 0x1c, 0xe5, 0xfa, 0x29, 0x91, 0x0c, 0x7c, 0xbc, 0x18, 0x64, 0x7e,
+	0xd5, 0xc2, 0xb9, 0xe7, 0xbf, 0x6a, 0x99, 0x52, 0xe0, 0x93, 0x25, 0xa8, 0xac, 0xc6, 0x2d, 0x34,
+	0x8d, 0x7b, 0x46, 0x87, 0x33, 0xfb, 0x9c, 0x91, 0x0c, 0xdc, 0x2d, 0x8d, 0x67, 0x8c, 0x9f, 0xa1,
+	0x91, 0x7a, 0x05, 0xda, 0x37, 0xf1, 0xb3, 0x0a, 0x81, 0x0f, 0x4a, 0xa2, 0x19, 0xd7, 0x05, 0xd4,
+	0x92, 0x63, 0x43, 0x1d, 0x13, 0x9a, 0xbb, 0x48, 0x8c, 0xe7, 0x85, 0x52, 0x8a, 0xcb, 0xb5, 0x6f,
+	0x55, 0x27, 0xf4, 0x86, 0x35, 0xf5, 0x6f, 0xff, 0xf6, 0xef, 0x00, 0xc6, 0x8a, 0x73, 0x05, 0xfe,
+	0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -146,6 +976,29 @@ const _ = grpc.SupportPackageIsVersion6
 type ControlServiceClient interface {
 	// ExposePort exposes a port
 	ExposePort(ctx context.Context, in *ExposePortRequest, opts ...grpc.CallOption) (*ExposePortResponse, error)
+	// ApprovePublicPort approves or rejects making a port public which waits for approval
+	ApprovePublicPort(ctx context.Context, in *ApprovePublicPortRequest, opts ...grpc.CallOption) (*ApprovePublicPortResponse, error)
+	// AcceptPortRemap proxies a port on the suggested free global port, because another process serves its configured global port
+	AcceptPortRemap(ctx context.Context, in *AcceptPortRemapRequest, opts ...grpc.CallOption) (*AcceptPortRemapResponse, error)
+	// SetPortsVerbose switches verbose logging of the ports manager on or off, e.g. to diagnose why a port is not exposed
+	SetPortsVerbose(ctx context.Context, in *SetPortsVerboseRequest, opts ...grpc.CallOption) (*SetPortsVerboseResponse, error)
+	// SimulatePort injects or removes a synthetic port, e.g. to test the ports view of an IDE without running servers.
+	// It is only available if supervisor is configured to allow ports simulation.
+	SimulatePort(ctx context.Context, in *SimulatePortRequest, opts ...grpc.CallOption) (*SimulatePortResponse, error)
+	// RunLifecycleHook runs a lifecycle hook the .gitpod.yml configures, e.g. onSnapshot before a snapshot is taken.
+	// It returns once the hook finished.
+	RunLifecycleHook(ctx context.Context, in *RunLifecycleHookRequest, opts ...grpc.CallOption) (*RunLifecycleHookResponse, error)
+	// CreateDiagnostics writes a bundle of supervisor's recent logs, its goroutines, config and the state of its
+	// subsystems, e.g. ports and tasks, to a tarball in the workspace, which users can attach to support requests.
+	CreateDiagnostics(ctx context.Context, in *CreateDiagnosticsRequest, opts ...grpc.CallOption) (*CreateDiagnosticsResponse, error)
+	// SetLogLevel changes the level supervisor or one of its subsystems logs with, e.g. to debug the ports
+	// without restarting the workspace
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// LogLevels returns the levels supervisor and its subsystems log with
+	LogLevels(ctx context.Context, in *LogLevelsRequest, opts ...grpc.CallOption) (*LogLevelsResponse, error)
+	// Backup asks ws-daemon to back up the workspace content right away, e.g. before the user attempts a risky
+	// operation like upgrading dependencies. It returns once the backup is complete.
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
 }
 
 type controlServiceClient struct {
@@ -165,10 +1018,114 @@ func (c *controlServiceClient) ExposePort(ctx context.Context, in *ExposePortReq
 	return out, nil
 }
 
+func (c *controlServiceClient) ApprovePublicPort(ctx context.Context, in *ApprovePublicPortRequest, opts ...grpc.CallOption) (*ApprovePublicPortResponse, error) {
+	out := new(ApprovePublicPortResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/ApprovePublicPort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) AcceptPortRemap(ctx context.Context, in *AcceptPortRemapRequest, opts ...grpc.CallOption) (*AcceptPortRemapResponse, error) {
+	out := new(AcceptPortRemapResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/AcceptPortRemap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) SetPortsVerbose(ctx context.Context, in *SetPortsVerboseRequest, opts ...grpc.CallOption) (*SetPortsVerboseResponse, error) {
+	out := new(SetPortsVerboseResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/SetPortsVerbose", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) SimulatePort(ctx context.Context, in *SimulatePortRequest, opts ...grpc.CallOption) (*SimulatePortResponse, error) {
+	out := new(SimulatePortResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/SimulatePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) RunLifecycleHook(ctx context.Context, in *RunLifecycleHookRequest, opts ...grpc.CallOption) (*RunLifecycleHookResponse, error) {
+	out := new(RunLifecycleHookResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/RunLifecycleHook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) CreateDiagnostics(ctx context.Context, in *CreateDiagnosticsRequest, opts ...grpc.CallOption) (*CreateDiagnosticsResponse, error) {
+	out := new(CreateDiagnosticsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/CreateDiagnostics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) LogLevels(ctx context.Context, in *LogLevelsRequest, opts ...grpc.CallOption) (*LogLevelsResponse, error) {
+	out := new(LogLevelsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/LogLevels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ControlService/Backup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ControlServiceServer is the server API for ControlService service.
 type ControlServiceServer interface {
 	// ExposePort exposes a port
 	ExposePort(context.Context, *ExposePortRequest) (*ExposePortResponse, error)
+	// ApprovePublicPort approves or rejects making a port public which waits for approval
+	ApprovePublicPort(context.Context, *ApprovePublicPortRequest) (*ApprovePublicPortResponse, error)
+	// AcceptPortRemap proxies a port on the suggested free global port, because another process serves its configured global port
+	AcceptPortRemap(context.Context, *AcceptPortRemapRequest) (*AcceptPortRemapResponse, error)
+	// SetPortsVerbose switches verbose logging of the ports manager on or off, e.g. to diagnose why a port is not exposed
+	SetPortsVerbose(context.Context, *SetPortsVerboseRequest) (*SetPortsVerboseResponse, error)
+	// SimulatePort injects or removes a synthetic port, e.g. to test the ports view of an IDE without running servers.
+	// It is only available if supervisor is configured to allow ports simulation.
+	SimulatePort(context.Context, *SimulatePortRequest) (*SimulatePortResponse, error)
+	// RunLifecycleHook runs a lifecycle hook the .gitpod.yml configures, e.g. onSnapshot before a snapshot is taken.
+	// It returns once the hook finished.
+	RunLifecycleHook(context.Context, *RunLifecycleHookRequest) (*RunLifecycleHookResponse, error)
+	// CreateDiagnostics writes a bundle of supervisor's recent logs, its goroutines, config and the state of its
+	// subsystems, e.g. ports and tasks, to a tarball in the workspace, which users can attach to support requests.
+	CreateDiagnostics(context.Context, *CreateDiagnosticsRequest) (*CreateDiagnosticsResponse, error)
+	// SetLogLevel changes the level supervisor or one of its subsystems logs with, e.g. to debug the ports
+	// without restarting the workspace
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// LogLevels returns the levels supervisor and its subsystems log with
+	LogLevels(context.Context, *LogLevelsRequest) (*LogLevelsResponse, error)
+	// Backup asks ws-daemon to back up the workspace content right away, e.g. before the user attempts a risky
+	// operation like upgrading dependencies. It returns once the backup is complete.
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
 }
 
 // UnimplementedControlServiceServer can be embedded to have forward compatible implementations.
@@ -178,6 +1135,33 @@ type UnimplementedControlServiceServer struct {
 func (*UnimplementedControlServiceServer) ExposePort(ctx context.Context, req *ExposePortRequest) (*ExposePortResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExposePort not implemented")
 }
+func (*UnimplementedControlServiceServer) ApprovePublicPort(ctx context.Context, req *ApprovePublicPortRequest) (*ApprovePublicPortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApprovePublicPort not implemented")
+}
+func (*UnimplementedControlServiceServer) AcceptPortRemap(ctx context.Context, req *AcceptPortRemapRequest) (*AcceptPortRemapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptPortRemap not implemented")
+}
+func (*UnimplementedControlServiceServer) SetPortsVerbose(ctx context.Context, req *SetPortsVerboseRequest) (*SetPortsVerboseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPortsVerbose not implemented")
+}
+func (*UnimplementedControlServiceServer) SimulatePort(ctx context.Context, req *SimulatePortRequest) (*SimulatePortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulatePort not implemented")
+}
+func (*UnimplementedControlServiceServer) RunLifecycleHook(ctx context.Context, req *RunLifecycleHookRequest) (*RunLifecycleHookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunLifecycleHook not implemented")
+}
+func (*UnimplementedControlServiceServer) CreateDiagnostics(ctx context.Context, req *CreateDiagnosticsRequest) (*CreateDiagnosticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDiagnostics not implemented")
+}
+func (*UnimplementedControlServiceServer) SetLogLevel(ctx context.Context, req *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (*UnimplementedControlServiceServer) LogLevels(ctx context.Context, req *LogLevelsRequest) (*LogLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogLevels not implemented")
+}
+func (*UnimplementedControlServiceServer) Backup(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
 
 func RegisterControlServiceServer(s *grpc.Server, srv ControlServiceServer) {
 	s.RegisterService(&_ControlService_serviceDesc, srv)
@@ -201,6 +1185,168 @@ func _ControlService_ExposePort_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ControlService_ApprovePublicPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovePublicPortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ApprovePublicPort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/ApprovePublicPort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ApprovePublicPort(ctx, req.(*ApprovePublicPortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_AcceptPortRemap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptPortRemapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).AcceptPortRemap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/AcceptPortRemap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).AcceptPortRemap(ctx, req.(*AcceptPortRemapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_SetPortsVerbose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPortsVerboseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).SetPortsVerbose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/SetPortsVerbose",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).SetPortsVerbose(ctx, req.(*SetPortsVerboseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_SimulatePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulatePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).SimulatePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/SimulatePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).SimulatePort(ctx, req.(*SimulatePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_RunLifecycleHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunLifecycleHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).RunLifecycleHook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/RunLifecycleHook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).RunLifecycleHook(ctx, req.(*RunLifecycleHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_CreateDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).CreateDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/CreateDiagnostics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).CreateDiagnostics(ctx, req.(*CreateDiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_LogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).LogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/LogLevels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).LogLevels(ctx, req.(*LogLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ControlService/Backup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).Backup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ControlService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "supervisor.ControlService",
 	HandlerType: (*ControlServiceServer)(nil),
@@ -209,6 +1355,42 @@ var _ControlService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExposePort",
 			Handler:    _ControlService_ExposePort_Handler,
 		},
+		{
+			MethodName: "ApprovePublicPort",
+			Handler:    _ControlService_ApprovePublicPort_Handler,
+		},
+		{
+			MethodName: "AcceptPortRemap",
+			Handler:    _ControlService_AcceptPortRemap_Handler,
+		},
+		{
+			MethodName: "SetPortsVerbose",
+			Handler:    _ControlService_SetPortsVerbose_Handler,
+		},
+		{
+			MethodName: "SimulatePort",
+			Handler:    _ControlService_SimulatePort_Handler,
+		},
+		{
+			MethodName: "RunLifecycleHook",
+			Handler:    _ControlService_RunLifecycleHook_Handler,
+		},
+		{
+			MethodName: "CreateDiagnostics",
+			Handler:    _ControlService_CreateDiagnostics_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _ControlService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "LogLevels",
+			Handler:    _ControlService_LogLevels_Handler,
+		},
+		{
+			MethodName: "Backup",
+			Handler:    _ControlService_Backup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "control.proto",
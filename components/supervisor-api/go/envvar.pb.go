@@ -0,0 +1,571 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: envvar.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// EnvVarScope determines the repositories a variable applies to
+type EnvVarScope int32
+
+const (
+	// env_var_scope_project scopes a variable to the repository of this workspace
+	EnvVarScope_env_var_scope_project EnvVarScope = 0
+)
+
+var EnvVarScope_name = map[int32]string{
+	0: "env_var_scope_project",
+}
+
+var EnvVarScope_value = map[string]int32{
+	"env_var_scope_project": 0,
+}
+
+func (x EnvVarScope) String() string {
+	return proto.EnumName(EnvVarScope_name, int32(x))
+}
+
+func (EnvVarScope) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_324274357f1c6914, []int{0}
+}
+
+type EnvVar struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// repository_pattern is the owner/repo pattern of the repositories the variable applies to, e.g. foo/* or */*
+	RepositoryPattern    string   `protobuf:"bytes,3,opt,name=repository_pattern,json=repositoryPattern,proto3" json:"repository_pattern,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnvVar) Reset()         { *m = EnvVar{} }
+func (m *EnvVar) String() string { return proto.CompactTextString(m) }
+func (*EnvVar) ProtoMessage()    {}
+func (*EnvVar) Descriptor() ([]byte, []int) {
+	return fileDescriptor_324274357f1c6914, []int{0}
+}
+
+func (m *EnvVar) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnvVar.Unmarshal(m, b)
+}
+func (m *EnvVar) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnvVar.Marshal(b, m, deterministic)
+}
+func (m *EnvVar) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnvVar.Merge(m, src)
+}
+func (m *EnvVar) XXX_Size() int {
+	return xxx_messageInfo_EnvVar.Size(m)
+}
+func (m *EnvVar) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnvVar.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnvVar proto.InternalMessageInfo
+
+func (m *EnvVar) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EnvVar) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *EnvVar) GetRepositoryPattern() string {
+	if m != nil {
+		return m.RepositoryPattern
+	}
+	return ""
+}
+
+type ListEnvVarsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEnvVarsRequest) Reset()         { *m = ListEnvVarsRequest{} }
+func (m *ListEnvVarsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListEnvVarsRequest) ProtoMessage()    {}
+func (*ListEnvVarsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_324274357f1c6914, []int{1}
+}
+
+func (m *ListEnvVarsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEnvVarsRequest.Unmarshal(m, b)
+}
+func (m *ListEnvVarsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEnvVarsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListEnvVarsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEnvVarsRequest.Merge(m, src)
+}
+func (m *ListEnvVarsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListEnvVarsRequest.Size(m)
+}
+func (m *ListEnvVarsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEnvVarsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEnvVarsRequest proto.InternalMessageInfo
+
+type ListEnvVarsResponse struct {
+	Variables []*EnvVar `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty"`
+	// repository_pattern is the pattern of the repository of this workspace, which set variables are stored with
+	RepositoryPattern    string   `protobuf:"bytes,2,opt,name=repository_pattern,json=repositoryPattern,proto3" json:"repository_pattern,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEnvVarsResponse) Reset()         { *m = ListEnvVarsResponse{} }
+func (m *ListEnvVarsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListEnvVarsResponse) ProtoMessage()    {}
+func (*ListEnvVarsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_324274357f1c6914, []int{2}
+}
+
+func (m *ListEnvVarsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEnvVarsResponse.Unmarshal(m, b)
+}
+func (m *ListEnvVarsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEnvVarsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListEnvVarsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEnvVarsResponse.Merge(m, src)
+}
+func (m *ListEnvVarsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListEnvVarsResponse.Size(m)
+}
+func (m *ListEnvVarsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEnvVarsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEnvVarsResponse proto.InternalMessageInfo
+
+func (m *ListEnvVarsResponse) GetVariables() []*EnvVar {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+func (m *ListEnvVarsResponse) GetRepositoryPattern() string {
+	if m != nil {
+		return m.RepositoryPattern
+	}
+	return ""
+}
+
+type SetEnvVarsRequest struct {
+	// variables are the variables to set. Their repository pattern is ignored.
+	Variables            []*EnvVar   `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty"`
+	Scope                EnvVarScope `protobuf:"varint,2,opt,name=scope,proto3,enum=supervisor.EnvVarScope" json:"scope,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SetEnvVarsRequest) Reset()         { *m = SetEnvVarsRequest{} }
+func (m *SetEnvVarsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetEnvVarsRequest) ProtoMessage()    {}
+func (*SetEnvVarsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_324274357f1c6914, []int{3}
+}
+
+func (m *SetEnvVarsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetEnvVarsRequest.Unmarshal(m, b)
+}
+func (m *SetEnvVarsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetEnvVarsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetEnvVarsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetEnvVarsRequest.Merge(m, src)
+}
+func (m *SetEnvVarsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetEnvVarsRequest.Size(m)
+}
+func (m *SetEnvVarsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetEnvVarsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetEnvVarsRequest proto.InternalMessageInfo
+
+func (m *SetEnvVarsRequest) GetVariables() []*EnvVar {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+func (m *SetEnvVarsRequest) GetScope() EnvVarScope {
+	if m != nil {
+		return m.Scope
+	}
+	return EnvVarScope_env_var_scope_project
+}
+
+type SetEnvVarsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetEnvVarsResponse) Reset()         { *m = SetEnvVarsResponse{} }
+func (m *SetEnvVarsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetEnvVarsResponse) ProtoMessage()    {}
+func (*SetEnvVarsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_324274357f1c6914, []int{4}
+}
+
+func (m *SetEnvVarsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetEnvVarsResponse.Unmarshal(m, b)
+}
+func (m *SetEnvVarsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetEnvVarsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetEnvVarsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetEnvVarsResponse.Merge(m, src)
+}
+func (m *SetEnvVarsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetEnvVarsResponse.Size(m)
+}
+func (m *SetEnvVarsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetEnvVarsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetEnvVarsResponse proto.InternalMessageInfo
+
+type UnsetEnvVarsRequest struct {
+	Names                []string    `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Scope                EnvVarScope `protobuf:"varint,2,opt,name=scope,proto3,enum=supervisor.EnvVarScope" json:"scope,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *UnsetEnvVarsRequest) Reset()         { *m = UnsetEnvVarsRequest{} }
+func (m *UnsetEnvVarsRequest) String() string { return proto.CompactTextString(m) }
+func (*UnsetEnvVarsRequest) ProtoMessage()    {}
+func (*UnsetEnvVarsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_324274357f1c6914, []int{5}
+}
+
+func (m *UnsetEnvVarsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsetEnvVarsRequest.Unmarshal(m, b)
+}
+func (m *UnsetEnvVarsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsetEnvVarsRequest.Marshal(b, m, deterministic)
+}
+func (m *UnsetEnvVarsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsetEnvVarsRequest.Merge(m, src)
+}
+func (m *UnsetEnvVarsRequest) XXX_Size() int {
+	return xxx_messageInfo_UnsetEnvVarsRequest.Size(m)
+}
+func (m *UnsetEnvVarsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsetEnvVarsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsetEnvVarsRequest proto.InternalMessageInfo
+
+func (m *UnsetEnvVarsRequest) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func (m *UnsetEnvVarsRequest) GetScope() EnvVarScope {
+	if m != nil {
+		return m.Scope
+	}
+	return EnvVarScope_env_var_scope_project
+}
+
+type UnsetEnvVarsResponse struct {
+	// unset are the names of the deleted variables
+	Unset []string `protobuf:"bytes,1,rep,name=unset,proto3" json:"unset,omitempty"`
+	// not_unset are the names of the variables which do not exist in the scope
+	NotUnset             []string `protobuf:"bytes,2,rep,name=not_unset,json=notUnset,proto3" json:"not_unset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnsetEnvVarsResponse) Reset()         { *m = UnsetEnvVarsResponse{} }
+func (m *UnsetEnvVarsResponse) String() string { return proto.CompactTextString(m) }
+func (*UnsetEnvVarsResponse) ProtoMessage()    {}
+func (*UnsetEnvVarsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_324274357f1c6914, []int{6}
+}
+
+func (m *UnsetEnvVarsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsetEnvVarsResponse.Unmarshal(m, b)
+}
+func (m *UnsetEnvVarsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsetEnvVarsResponse.Marshal(b, m, deterministic)
+}
+func (m *UnsetEnvVarsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsetEnvVarsResponse.Merge(m, src)
+}
+func (m *UnsetEnvVarsResponse) XXX_Size() int {
+	return xxx_messageInfo_UnsetEnvVarsResponse.Size(m)
+}
+func (m *UnsetEnvVarsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsetEnvVarsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsetEnvVarsResponse proto.InternalMessageInfo
+
+func (m *UnsetEnvVarsResponse) GetUnset() []string {
+	if m != nil {
+		return m.Unset
+	}
+	return nil
+}
+
+func (m *UnsetEnvVarsResponse) GetNotUnset() []string {
+	if m != nil {
+		return m.NotUnset
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("supervisor.EnvVarScope", EnvVarScope_name, EnvVarScope_value)
+	proto.RegisterType((*EnvVar)(nil), "supervisor.EnvVar")
+	proto.RegisterType((*ListEnvVarsRequest)(nil), "supervisor.ListEnvVarsRequest")
+	proto.RegisterType((*ListEnvVarsResponse)(nil), "supervisor.ListEnvVarsResponse")
+	proto.RegisterType((*SetEnvVarsRequest)(nil), "supervisor.SetEnvVarsRequest")
+	proto.RegisterType((*SetEnvVarsResponse)(nil), "supervisor.SetEnvVarsResponse")
+	proto.RegisterType((*UnsetEnvVarsRequest)(nil), "supervisor.UnsetEnvVarsRequest")
+	proto.RegisterType((*UnsetEnvVarsResponse)(nil), "supervisor.UnsetEnvVarsResponse")
+}
+
+func init() {
+	proto.RegisterFile("envvar.proto", fileDescriptor_324274357f1c6914)
+}
+
+var fileDescriptor_324274357f1c6914 = []byte{
+	// 444 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xc1, 0x6e, 0xd3, 0x40,
+	0x14, 0xc4, 0x4e, 0x5d, 0xea, 0x97, 0x40, 0x9b, 0x57, 0xa3, 0x86, 0x00, 0x25, 0xf2, 0xa9, 0x8a,
+	0x44, 0x0c, 0xe1, 0xc6, 0xb1, 0x12, 0x07, 0x24, 0x0e, 0xc8, 0x11, 0x1c, 0x7a, 0xb1, 0xb6, 0xd1,
+	0x53, 0xe5, 0x2a, 0xec, 0x2e, 0xbb, 0xeb, 0x95, 0x10, 0xe2, 0xc2, 0x2f, 0xf0, 0x3d, 0x7c, 0x05,
+	0xbf, 0xc0, 0x87, 0x20, 0xef, 0xba, 0x8a, 0x53, 0xe3, 0x03, 0xbd, 0x65, 0x77, 0x26, 0x33, 0xf3,
+	0x66, 0x9f, 0x61, 0x44, 0xdc, 0x5a, 0xa6, 0x16, 0x52, 0x09, 0x23, 0x10, 0x74, 0x25, 0x49, 0xd9,
+	0x52, 0x0b, 0x35, 0x7d, 0x7a, 0x25, 0xc4, 0xd5, 0x86, 0x32, 0x26, 0xcb, 0x8c, 0x71, 0x2e, 0x0c,
+	0x33, 0xa5, 0xe0, 0xda, 0x33, 0x53, 0x06, 0xfb, 0x6f, 0xb9, 0xfd, 0xc4, 0x14, 0x22, 0xec, 0x71,
+	0xf6, 0x99, 0x26, 0xc1, 0x2c, 0x38, 0x8b, 0x73, 0xf7, 0x1b, 0x13, 0x88, 0x2c, 0xdb, 0x54, 0x34,
+	0x09, 0xdd, 0xa5, 0x3f, 0xe0, 0x0b, 0x40, 0x45, 0x52, 0xe8, 0xd2, 0x08, 0xf5, 0xb5, 0x90, 0xcc,
+	0x18, 0x52, 0x7c, 0x32, 0x70, 0x94, 0xf1, 0x16, 0xf9, 0xe0, 0x81, 0x34, 0x01, 0x7c, 0x5f, 0x6a,
+	0xe3, 0x6d, 0x74, 0x4e, 0x5f, 0x2a, 0xd2, 0x26, 0xb5, 0x70, 0xbc, 0x73, 0xab, 0xa5, 0xe0, 0x9a,
+	0xf0, 0x25, 0xc4, 0x96, 0xa9, 0x92, 0x5d, 0x6e, 0x48, 0x4f, 0x82, 0xd9, 0xe0, 0x6c, 0xb8, 0xc4,
+	0xc5, 0x76, 0x9a, 0x85, 0xe7, 0xe7, 0x5b, 0x52, 0x4f, 0x9a, 0xb0, 0x2f, 0x8d, 0x81, 0xf1, 0x8a,
+	0x6e, 0x85, 0xb9, 0x93, 0x6b, 0xa4, 0xd7, 0x42, 0xfa, 0x66, 0x1e, 0x2e, 0x4f, 0xba, 0xec, 0x55,
+	0x0d, 0xe7, 0x9e, 0x55, 0x77, 0xb0, 0xa2, 0xdb, 0xc3, 0xa6, 0x17, 0x70, 0xfc, 0x91, 0xeb, 0x4e,
+	0x9a, 0x04, 0xa2, 0xba, 0x7d, 0x9f, 0x24, 0xce, 0xfd, 0xe1, 0x7f, 0x1d, 0xdf, 0x41, 0xb2, 0xab,
+	0xdd, 0x14, 0x9c, 0x40, 0x54, 0xd5, 0xf7, 0x37, 0xe2, 0xee, 0x80, 0x4f, 0x20, 0xe6, 0xc2, 0x14,
+	0x1e, 0x09, 0x1d, 0x72, 0xc0, 0x85, 0x71, 0x0a, 0xf3, 0x73, 0x18, 0xb6, 0x0c, 0xf0, 0x31, 0x3c,
+	0x22, 0x6e, 0x0b, 0xcb, 0x54, 0xe1, 0xac, 0x0a, 0xa9, 0xc4, 0x35, 0xad, 0xcd, 0xd1, 0xbd, 0x74,
+	0xef, 0x20, 0x38, 0x0a, 0xe6, 0xb8, 0x0b, 0x57, 0x9a, 0xd4, 0xf2, 0x57, 0x08, 0x0f, 0x1a, 0x91,
+	0x3a, 0xf4, 0x9a, 0xb0, 0x80, 0x61, 0x6b, 0x01, 0xf0, 0xb4, 0x3d, 0x4f, 0x77, 0x5f, 0xa6, 0xcf,
+	0x7b, 0xf1, 0xa6, 0xcc, 0xc3, 0x1f, 0xbf, 0xff, 0xfc, 0x0c, 0x63, 0xbc, 0x9f, 0xd9, 0x57, 0x19,
+	0x71, 0x8b, 0x05, 0xc0, 0xb6, 0x73, 0x7c, 0xd6, 0xfe, 0x7f, 0x67, 0x03, 0xa6, 0xa7, 0x7d, 0x70,
+	0xa3, 0x8e, 0x4e, 0x7d, 0x94, 0xde, 0xa8, 0xbf, 0x09, 0xe6, 0x78, 0x0d, 0xa3, 0x76, 0xc5, 0xb8,
+	0x13, 0xf1, 0x1f, 0x0f, 0x3b, 0x9d, 0xf5, 0x13, 0x1a, 0x9b, 0x13, 0x67, 0x33, 0x9e, 0x1f, 0x36,
+	0x36, 0xd9, 0x37, 0xf7, 0xf8, 0xdf, 0xcf, 0xa3, 0x8b, 0x01, 0x93, 0xe5, 0xe5, 0xbe, 0xfb, 0x6a,
+	0x5f, 0xff, 0x1d, 0x00, 0x56, 0x25, 0xe5, 0xf5, 0xef, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// EnvVarServiceClient is the client API for EnvVarService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EnvVarServiceClient interface {
+	// ListEnvVars lists the environment variables of the user which apply to the repository of this workspace.
+	ListEnvVars(ctx context.Context, in *ListEnvVarsRequest, opts ...grpc.CallOption) (*ListEnvVarsResponse, error)
+	// SetEnvVars sets environment variables for the repository of this workspace.
+	SetEnvVars(ctx context.Context, in *SetEnvVarsRequest, opts ...grpc.CallOption) (*SetEnvVarsResponse, error)
+	// UnsetEnvVars deletes environment variables. Only variables whose repository pattern matches the scope exactly
+	// can be deleted, i.e. the repository of this workspace, not those of patterns like */foo or foo/*.
+	UnsetEnvVars(ctx context.Context, in *UnsetEnvVarsRequest, opts ...grpc.CallOption) (*UnsetEnvVarsResponse, error)
+}
+
+type envVarServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEnvVarServiceClient(cc grpc.ClientConnInterface) EnvVarServiceClient {
+	return &envVarServiceClient{cc}
+}
+
+func (c *envVarServiceClient) ListEnvVars(ctx context.Context, in *ListEnvVarsRequest, opts ...grpc.CallOption) (*ListEnvVarsResponse, error) {
+	out := new(ListEnvVarsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.EnvVarService/ListEnvVars", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envVarServiceClient) SetEnvVars(ctx context.Context, in *SetEnvVarsRequest, opts ...grpc.CallOption) (*SetEnvVarsResponse, error) {
+	out := new(SetEnvVarsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.EnvVarService/SetEnvVars", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envVarServiceClient) UnsetEnvVars(ctx context.Context, in *UnsetEnvVarsRequest, opts ...grpc.CallOption) (*UnsetEnvVarsResponse, error) {
+	out := new(UnsetEnvVarsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.EnvVarService/UnsetEnvVars", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnvVarServiceServer is the server API for EnvVarService service.
+type EnvVarServiceServer interface {
+	// ListEnvVars lists the environment variables of the user which apply to the repository of this workspace.
+	ListEnvVars(context.Context, *ListEnvVarsRequest) (*ListEnvVarsResponse, error)
+	// SetEnvVars sets environment variables for the repository of this workspace.
+	SetEnvVars(context.Context, *SetEnvVarsRequest) (*SetEnvVarsResponse, error)
+	// UnsetEnvVars deletes environment variables. Only variables whose repository pattern matches the scope exactly
+	// can be deleted, i.e. the repository of this workspace, not those of patterns like */foo or foo/*.
+	UnsetEnvVars(context.Context, *UnsetEnvVarsRequest) (*UnsetEnvVarsResponse, error)
+}
+
+// UnimplementedEnvVarServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedEnvVarServiceServer struct {
+}
+
+func (*UnimplementedEnvVarServiceServer) ListEnvVars(ctx context.Context, req *ListEnvVarsRequest) (*ListEnvVarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEnvVars not implemented")
+}
+func (*UnimplementedEnvVarServiceServer) SetEnvVars(ctx context.Context, req *SetEnvVarsRequest) (*SetEnvVarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetEnvVars not implemented")
+}
+func (*UnimplementedEnvVarServiceServer) UnsetEnvVars(ctx context.Context, req *UnsetEnvVarsRequest) (*UnsetEnvVarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsetEnvVars not implemented")
+}
+
+func RegisterEnvVarServiceServer(s *grpc.Server, srv EnvVarServiceServer) {
+	s.RegisterService(&_EnvVarService_serviceDesc, srv)
+}
+
+func _EnvVarService_ListEnvVars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEnvVarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvVarServiceServer).ListEnvVars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.EnvVarService/ListEnvVars",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvVarServiceServer).ListEnvVars(ctx, req.(*ListEnvVarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvVarService_SetEnvVars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnvVarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvVarServiceServer).SetEnvVars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.EnvVarService/SetEnvVars",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvVarServiceServer).SetEnvVars(ctx, req.(*SetEnvVarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvVarService_UnsetEnvVars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetEnvVarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvVarServiceServer).UnsetEnvVars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.EnvVarService/UnsetEnvVars",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvVarServiceServer).UnsetEnvVars(ctx, req.(*UnsetEnvVarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _EnvVarService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.EnvVarService",
+	HandlerType: (*EnvVarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListEnvVars",
+			Handler:    _EnvVarService_ListEnvVars_Handler,
+		},
+		{
+			MethodName: "SetEnvVars",
+			Handler:    _EnvVarService_SetEnvVars_Handler,
+		},
+		{
+			MethodName: "UnsetEnvVars",
+			Handler:    _EnvVarService_UnsetEnvVars_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "envvar.proto",
+}
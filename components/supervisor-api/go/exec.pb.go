@@ -0,0 +1,410 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: exec.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ExecRequest struct {
+	// command is the executable, which is looked up in the PATH if it contains no slash
+	Command string   `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// env are environment variables, e.g. FOO=bar, in addition to the environment of terminals
+	Env []string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty"`
+	// workdir is the working directory. If empty, the command runs in the repository root.
+	Workdir string `protobuf:"bytes,4,opt,name=workdir,proto3" json:"workdir,omitempty"`
+	// stdin is written to the standard input of the command, which is closed afterwards unless tty is set
+	Stdin []byte `protobuf:"bytes,5,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// tty runs the command in a pseudo-terminal, in which case the output is sent as stdout only
+	Tty bool `protobuf:"varint,6,opt,name=tty,proto3" json:"tty,omitempty"`
+	// tty_size is the size of the pseudo-terminal
+	TtySize              *TTYSize `protobuf:"bytes,7,opt,name=tty_size,json=ttySize,proto3" json:"tty_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecRequest) Reset()         { *m = ExecRequest{} }
+func (m *ExecRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecRequest) ProtoMessage()    {}
+func (*ExecRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d737c7315c25422, []int{0}
+}
+
+func (m *ExecRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecRequest.Unmarshal(m, b)
+}
+func (m *ExecRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecRequest.Marshal(b, m, deterministic)
+}
+func (m *ExecRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecRequest.Merge(m, src)
+}
+func (m *ExecRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecRequest.Size(m)
+}
+func (m *ExecRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecRequest proto.InternalMessageInfo
+
+func (m *ExecRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetWorkdir() string {
+	if m != nil {
+		return m.Workdir
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetStdin() []byte {
+	if m != nil {
+		return m.Stdin
+	}
+	return nil
+}
+
+func (m *ExecRequest) GetTty() bool {
+	if m != nil {
+		return m.Tty
+	}
+	return false
+}
+
+func (m *ExecRequest) GetTtySize() *TTYSize {
+	if m != nil {
+		return m.TtySize
+	}
+	return nil
+}
+
+type TTYSize struct {
+	Cols                 uint32   `protobuf:"varint,1,opt,name=cols,proto3" json:"cols,omitempty"`
+	Rows                 uint32   `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TTYSize) Reset()         { *m = TTYSize{} }
+func (m *TTYSize) String() string { return proto.CompactTextString(m) }
+func (*TTYSize) ProtoMessage()    {}
+func (*TTYSize) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d737c7315c25422, []int{1}
+}
+
+func (m *TTYSize) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TTYSize.Unmarshal(m, b)
+}
+func (m *TTYSize) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TTYSize.Marshal(b, m, deterministic)
+}
+func (m *TTYSize) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TTYSize.Merge(m, src)
+}
+func (m *TTYSize) XXX_Size() int {
+	return xxx_messageInfo_TTYSize.Size(m)
+}
+func (m *TTYSize) XXX_DiscardUnknown() {
+	xxx_messageInfo_TTYSize.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TTYSize proto.InternalMessageInfo
+
+func (m *TTYSize) GetCols() uint32 {
+	if m != nil {
+		return m.Cols
+	}
+	return 0
+}
+
+func (m *TTYSize) GetRows() uint32 {
+	if m != nil {
+		return m.Rows
+	}
+	return 0
+}
+
+type ExecResponse struct {
+	// Types that are valid to be assigned to Output:
+	//	*ExecResponse_Stdout
+	//	*ExecResponse_Stderr
+	//	*ExecResponse_ExitCode
+	Output               isExecResponse_Output `protobuf_oneof:"output"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ExecResponse) Reset()         { *m = ExecResponse{} }
+func (m *ExecResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecResponse) ProtoMessage()    {}
+func (*ExecResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d737c7315c25422, []int{2}
+}
+
+func (m *ExecResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecResponse.Unmarshal(m, b)
+}
+func (m *ExecResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecResponse.Merge(m, src)
+}
+func (m *ExecResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecResponse.Size(m)
+}
+func (m *ExecResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecResponse proto.InternalMessageInfo
+
+type isExecResponse_Output interface {
+	isExecResponse_Output()
+}
+
+type ExecResponse_Stdout struct {
+	Stdout []byte `protobuf:"bytes,1,opt,name=stdout,proto3,oneof"`
+}
+
+type ExecResponse_Stderr struct {
+	Stderr []byte `protobuf:"bytes,2,opt,name=stderr,proto3,oneof"`
+}
+
+type ExecResponse_ExitCode struct {
+	ExitCode int32 `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3,oneof"`
+}
+
+func (*ExecResponse_Stdout) isExecResponse_Output() {}
+
+func (*ExecResponse_Stderr) isExecResponse_Output() {}
+
+func (*ExecResponse_ExitCode) isExecResponse_Output() {}
+
+func (m *ExecResponse) GetOutput() isExecResponse_Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *ExecResponse) GetStdout() []byte {
+	if x, ok := m.GetOutput().(*ExecResponse_Stdout); ok {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (m *ExecResponse) GetStderr() []byte {
+	if x, ok := m.GetOutput().(*ExecResponse_Stderr); ok {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (m *ExecResponse) GetExitCode() int32 {
+	if x, ok := m.GetOutput().(*ExecResponse_ExitCode); ok {
+		return x.ExitCode
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ExecResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ExecResponse_Stdout)(nil),
+		(*ExecResponse_Stderr)(nil),
+		(*ExecResponse_ExitCode)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterType((*ExecRequest)(nil), "supervisor.ExecRequest")
+	proto.RegisterType((*TTYSize)(nil), "supervisor.TTYSize")
+	proto.RegisterType((*ExecResponse)(nil), "supervisor.ExecResponse")
+}
+
+func init() {
+	proto.RegisterFile("exec.proto", fileDescriptor_4d737c7315c25422)
+}
+
+var fileDescriptor_4d737c7315c25422 = []byte{
+	// 321 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0x4f, 0x6b, 0xe3, 0x30,
+	0x10, 0xc5, 0xa3, 0x38, 0xfe, 0x93, 0x49, 0x16, 0x16, 0xed, 0xc2, 0x8a, 0x85, 0x82, 0xf1, 0xc9,
+	0x27, 0xd3, 0xa6, 0xe7, 0x5e, 0x52, 0x0a, 0x39, 0xf4, 0xa4, 0xe4, 0xd2, 0x5e, 0x42, 0x6a, 0x0f,
+	0x45, 0xa4, 0xb1, 0x5c, 0x69, 0x9c, 0x7f, 0x5f, 0xae, 0x5f, 0xad, 0x48, 0x76, 0x69, 0xa0, 0xb7,
+	0xdf, 0x7b, 0xc3, 0x88, 0xf7, 0x34, 0x00, 0x78, 0xc4, 0xb2, 0x68, 0x8c, 0x26, 0xcd, 0xc1, 0xb6,
+	0x0d, 0x9a, 0xbd, 0xb2, 0xda, 0x64, 0x1f, 0x0c, 0x26, 0x0f, 0x47, 0x2c, 0x25, 0xbe, 0xb7, 0x68,
+	0x89, 0x0b, 0x88, 0x4b, 0xbd, 0xdb, 0x6d, 0xea, 0x4a, 0xb0, 0x94, 0xe5, 0x63, 0xf9, 0x25, 0x39,
+	0x87, 0xd1, 0xc6, 0xbc, 0x5a, 0x31, 0x4c, 0x83, 0x7c, 0x2c, 0x3d, 0xf3, 0xdf, 0x10, 0x60, 0xbd,
+	0x17, 0x81, 0xb7, 0x1c, 0xba, 0xfd, 0x83, 0x36, 0xdb, 0x4a, 0x19, 0x31, 0xea, 0xf6, 0x7b, 0xc9,
+	0xff, 0x42, 0x68, 0xa9, 0x52, 0xb5, 0x08, 0x53, 0x96, 0x4f, 0x65, 0x27, 0xdc, 0x0b, 0x44, 0x27,
+	0x11, 0xa5, 0x2c, 0x4f, 0xa4, 0x43, 0x5e, 0x40, 0x42, 0x74, 0x5a, 0x5b, 0x75, 0x46, 0x11, 0xa7,
+	0x2c, 0x9f, 0xcc, 0xfe, 0x14, 0xdf, 0x81, 0x8b, 0xd5, 0xea, 0x69, 0xa9, 0xce, 0x28, 0x63, 0xa2,
+	0x93, 0x83, 0xec, 0x06, 0xe2, 0xde, 0x73, 0x11, 0x4b, 0xfd, 0x66, 0x7d, 0xf2, 0x5f, 0xd2, 0xb3,
+	0xf3, 0x8c, 0x3e, 0xb8, 0xd8, 0xde, 0x73, 0x9c, 0x6d, 0x61, 0xda, 0x75, 0xb6, 0x8d, 0xae, 0x2d,
+	0x72, 0x01, 0x91, 0xa5, 0x4a, 0xb7, 0xe4, 0x37, 0xa7, 0x8b, 0x81, 0xec, 0x75, 0x3f, 0x41, 0x63,
+	0xc4, 0xf0, 0x62, 0x82, 0xc6, 0xf0, 0x2b, 0x18, 0xe3, 0x51, 0xd1, 0xba, 0xd4, 0x15, 0x8a, 0x20,
+	0x65, 0x79, 0xb8, 0x18, 0xc8, 0xc4, 0x59, 0xf7, 0xba, 0xc2, 0x79, 0x02, 0x91, 0x6e, 0xa9, 0x69,
+	0x69, 0xf6, 0xd8, 0x7d, 0xf0, 0xd2, 0x15, 0x28, 0x91, 0xdf, 0xc1, 0xc8, 0x49, 0xfe, 0xef, 0xb2,
+	0xd4, 0xc5, 0x05, 0xfe, 0x8b, 0x9f, 0x83, 0x2e, 0x66, 0x36, 0xb8, 0x66, 0xf3, 0xf0, 0x39, 0xd8,
+	0x34, 0xea, 0x25, 0xf2, 0x97, 0xbc, 0xfd, 0x1c, 0x00, 0x5d, 0xf2, 0x00, 0xc8, 0xd7, 0x01, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ExecServiceClient is the client API for ExecService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ExecServiceClient interface {
+	// Exec runs a command and streams its output, followed by its exit code. The command and the processes it started
+	// are killed once the call is cancelled.
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (ExecService_ExecClient, error)
+}
+
+type execServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecServiceClient(cc grpc.ClientConnInterface) ExecServiceClient {
+	return &execServiceClient{cc}
+}
+
+func (c *execServiceClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (ExecService_ExecClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ExecService_serviceDesc.Streams[0], "/supervisor.ExecService/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &execServiceExecClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExecService_ExecClient interface {
+	Recv() (*ExecResponse, error)
+	grpc.ClientStream
+}
+
+type execServiceExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *execServiceExecClient) Recv() (*ExecResponse, error) {
+	m := new(ExecResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExecServiceServer is the server API for ExecService service.
+type ExecServiceServer interface {
+	// Exec runs a command and streams its output, followed by its exit code. The command and the processes it started
+	// are killed once the call is cancelled.
+	Exec(*ExecRequest, ExecService_ExecServer) error
+}
+
+// UnimplementedExecServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedExecServiceServer struct {
+}
+
+func (*UnimplementedExecServiceServer) Exec(req *ExecRequest, srv ExecService_ExecServer) error {
+	return status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+
+func RegisterExecServiceServer(s *grpc.Server, srv ExecServiceServer) {
+	s.RegisterService(&_ExecService_serviceDesc, srv)
+}
+
+func _ExecService_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecServiceServer).Exec(m, &execServiceExecServer{stream})
+}
+
+type ExecService_ExecServer interface {
+	Send(*ExecResponse) error
+	grpc.ServerStream
+}
+
+type execServiceExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *execServiceExecServer) Send(m *ExecResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ExecService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.ExecService",
+	HandlerType: (*ExecServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Exec",
+			Handler:       _ExecService_Exec_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "exec.proto",
+}
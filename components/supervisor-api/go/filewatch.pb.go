@@ -0,0 +1,380 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: filewatch.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type FileChangeType int32
+
+const (
+	FileChangeType_file_created FileChangeType = 0
+	FileChangeType_file_changed FileChangeType = 1
+	FileChangeType_file_deleted FileChangeType = 2
+	// the file was moved from old_path to path
+	FileChangeType_file_renamed FileChangeType = 3
+)
+
+var FileChangeType_name = map[int32]string{
+	0: "file_created",
+	1: "file_changed",
+	2: "file_deleted",
+	3: "file_renamed",
+}
+
+var FileChangeType_value = map[string]int32{
+	"file_created": 0,
+	"file_changed": 1,
+	"file_deleted": 2,
+	"file_renamed": 3,
+}
+
+func (x FileChangeType) String() string {
+	return proto.EnumName(FileChangeType_name, int32(x))
+}
+
+func (FileChangeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_617db749765847a1, []int{0}
+}
+
+type WatchFilesRequest struct {
+	// includes are glob patterns of the paths relative to the watched root which are of interest, e.g. src/**/*.go.
+	// ** matches any number of folders. If empty, all changes are sent.
+	Includes []string `protobuf:"bytes,1,rep,name=includes,proto3" json:"includes,omitempty"`
+	// excludes are glob patterns of the paths whose changes are not sent, e.g. **/node_modules/**
+	Excludes []string `protobuf:"bytes,2,rep,name=excludes,proto3" json:"excludes,omitempty"`
+	// debounce_ms is how long changes are collected before they are sent, 100ms if zero
+	DebounceMs           uint32   `protobuf:"varint,3,opt,name=debounce_ms,json=debounceMs,proto3" json:"debounce_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchFilesRequest) Reset()         { *m = WatchFilesRequest{} }
+func (m *WatchFilesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchFilesRequest) ProtoMessage()    {}
+func (*WatchFilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_617db749765847a1, []int{0}
+}
+
+func (m *WatchFilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchFilesRequest.Unmarshal(m, b)
+}
+func (m *WatchFilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchFilesRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchFilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchFilesRequest.Merge(m, src)
+}
+func (m *WatchFilesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchFilesRequest.Size(m)
+}
+func (m *WatchFilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchFilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchFilesRequest proto.InternalMessageInfo
+
+func (m *WatchFilesRequest) GetIncludes() []string {
+	if m != nil {
+		return m.Includes
+	}
+	return nil
+}
+
+func (m *WatchFilesRequest) GetExcludes() []string {
+	if m != nil {
+		return m.Excludes
+	}
+	return nil
+}
+
+func (m *WatchFilesRequest) GetDebounceMs() uint32 {
+	if m != nil {
+		return m.DebounceMs
+	}
+	return 0
+}
+
+type WatchFilesResponse struct {
+	// root is the absolute path of the watched folder
+	Root    string        `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Changes []*FileChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	// overflow is true if changes were lost, e.g. because the client did not keep up.
+	// The client should re-read the files it is interested in.
+	Overflow             bool     `protobuf:"varint,3,opt,name=overflow,proto3" json:"overflow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchFilesResponse) Reset()         { *m = WatchFilesResponse{} }
+func (m *WatchFilesResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchFilesResponse) ProtoMessage()    {}
+func (*WatchFilesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_617db749765847a1, []int{1}
+}
+
+func (m *WatchFilesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchFilesResponse.Unmarshal(m, b)
+}
+func (m *WatchFilesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchFilesResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchFilesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchFilesResponse.Merge(m, src)
+}
+func (m *WatchFilesResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchFilesResponse.Size(m)
+}
+func (m *WatchFilesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchFilesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchFilesResponse proto.InternalMessageInfo
+
+func (m *WatchFilesResponse) GetRoot() string {
+	if m != nil {
+		return m.Root
+	}
+	return ""
+}
+
+func (m *WatchFilesResponse) GetChanges() []*FileChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *WatchFilesResponse) GetOverflow() bool {
+	if m != nil {
+		return m.Overflow
+	}
+	return false
+}
+
+type FileChange struct {
+	Type FileChangeType `protobuf:"varint,1,opt,name=type,proto3,enum=supervisor.FileChangeType" json:"type,omitempty"`
+	// path is the absolute path of the file
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// old_path is the absolute path a renamed file was moved from
+	OldPath              string   `protobuf:"bytes,3,opt,name=old_path,json=oldPath,proto3" json:"old_path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileChange) Reset()         { *m = FileChange{} }
+func (m *FileChange) String() string { return proto.CompactTextString(m) }
+func (*FileChange) ProtoMessage()    {}
+func (*FileChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_617db749765847a1, []int{2}
+}
+
+func (m *FileChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileChange.Unmarshal(m, b)
+}
+func (m *FileChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileChange.Marshal(b, m, deterministic)
+}
+func (m *FileChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChange.Merge(m, src)
+}
+func (m *FileChange) XXX_Size() int {
+	return xxx_messageInfo_FileChange.Size(m)
+}
+func (m *FileChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChange proto.InternalMessageInfo
+
+func (m *FileChange) GetType() FileChangeType {
+	if m != nil {
+		return m.Type
+	}
+	return FileChangeType_file_created
+}
+
+func (m *FileChange) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileChange) GetOldPath() string {
+	if m != nil {
+		return m.OldPath
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("supervisor.FileChangeType", FileChangeType_name, FileChangeType_value)
+	proto.RegisterType((*WatchFilesRequest)(nil), "supervisor.WatchFilesRequest")
+	proto.RegisterType((*WatchFilesResponse)(nil), "supervisor.WatchFilesResponse")
+	proto.RegisterType((*FileChange)(nil), "supervisor.FileChange")
+}
+
+func init() {
+	proto.RegisterFile("filewatch.proto", fileDescriptor_617db749765847a1)
+}
+
+var fileDescriptor_617db749765847a1 = []byte{
+	// 334 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x5f, 0x4f, 0xfa, 0x30,
+	0x14, 0x65, 0x0c, 0x7e, 0xc0, 0xe5, 0x27, 0xce, 0x3e, 0x98, 0x49, 0xa2, 0x92, 0x3d, 0x11, 0x1f,
+	0x16, 0x82, 0xdf, 0x40, 0x13, 0x1f, 0x4c, 0x4c, 0xcc, 0x34, 0xd1, 0xf8, 0x20, 0x29, 0xeb, 0xc5,
+	0x2d, 0x96, 0xb5, 0xb6, 0x1d, 0xc8, 0xb7, 0x37, 0x2d, 0x30, 0x30, 0xea, 0x5b, 0xcf, 0x9f, 0xde,
+	0x73, 0xb6, 0x5b, 0x38, 0x9c, 0xe5, 0x1c, 0x97, 0xd4, 0xa4, 0x59, 0x2c, 0x95, 0x30, 0x82, 0x80,
+	0x2e, 0x25, 0xaa, 0x45, 0xae, 0x85, 0x8a, 0x38, 0x1c, 0x3d, 0x59, 0xe9, 0x26, 0xe7, 0xa8, 0x13,
+	0xfc, 0x28, 0x51, 0x1b, 0xd2, 0x87, 0x76, 0x5e, 0xa4, 0xbc, 0x64, 0xa8, 0x43, 0x6f, 0xe0, 0x0f,
+	0x3b, 0x49, 0x85, 0xad, 0x86, 0x9f, 0x1b, 0xad, 0xbe, 0xd6, 0xb6, 0x98, 0x9c, 0x43, 0x97, 0xe1,
+	0x54, 0x94, 0x45, 0x8a, 0x93, 0xb9, 0x0e, 0xfd, 0x81, 0x37, 0x3c, 0x48, 0x60, 0x4b, 0xdd, 0xe9,
+	0x68, 0x01, 0x64, 0x3f, 0x4d, 0x4b, 0x51, 0x68, 0x24, 0x04, 0x1a, 0x4a, 0x08, 0x13, 0x7a, 0x03,
+	0x6f, 0xd8, 0x49, 0xdc, 0x99, 0x8c, 0xa0, 0x95, 0x66, 0xb4, 0x78, 0xdb, 0xa4, 0x74, 0xc7, 0xc7,
+	0xf1, 0xae, 0x75, 0x6c, 0xef, 0x5f, 0x3b, 0x39, 0xd9, 0xda, 0x6c, 0x31, 0xb1, 0x40, 0x35, 0xe3,
+	0x62, 0xe9, 0x92, 0xdb, 0x49, 0x85, 0xa3, 0x77, 0x80, 0xdd, 0x15, 0x12, 0x43, 0xc3, 0xac, 0x24,
+	0xba, 0xbc, 0xde, 0xb8, 0xff, 0xfb, 0xe0, 0xc7, 0x95, 0xc4, 0xc4, 0xf9, 0x6c, 0x3f, 0x49, 0x4d,
+	0x16, 0xd6, 0xd7, 0xfd, 0xec, 0x99, 0x9c, 0x40, 0x5b, 0x70, 0x36, 0x71, 0xbc, 0xef, 0xf8, 0x96,
+	0xe0, 0xec, 0x9e, 0x9a, 0xec, 0xe2, 0x19, 0x7a, 0xdf, 0xc7, 0x90, 0x00, 0xfe, 0xdb, 0x1d, 0x4c,
+	0x52, 0x85, 0xd4, 0x20, 0x0b, 0x6a, 0x3b, 0xc6, 0x99, 0x58, 0xe0, 0x55, 0x0c, 0x43, 0x8e, 0xd6,
+	0x53, 0xaf, 0x18, 0x85, 0x05, 0x9d, 0x23, 0x0b, 0xfc, 0xf1, 0x2b, 0x04, 0x76, 0xb2, 0xfb, 0x85,
+	0x0f, 0xb6, 0x72, 0x8a, 0xe4, 0x16, 0x9a, 0x0e, 0x93, 0xd3, 0xfd, 0xef, 0xf8, 0xb1, 0xd3, 0xfe,
+	0xd9, 0x5f, 0xf2, 0x7a, 0x09, 0x51, 0x6d, 0xe4, 0x5d, 0x35, 0x5f, 0x7c, 0x2a, 0xf3, 0xe9, 0x3f,
+	0xf7, 0x4c, 0x2e, 0xbf, 0x06, 0x00, 0x8d, 0xf4, 0xec, 0x23, 0x39, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// FileWatchServiceClient is the client API for FileWatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FileWatchServiceClient interface {
+	// Watch streams the changes of files in the workspace. Changes are debounced and sent in batches.
+	Watch(ctx context.Context, in *WatchFilesRequest, opts ...grpc.CallOption) (FileWatchService_WatchClient, error)
+}
+
+type fileWatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFileWatchServiceClient(cc grpc.ClientConnInterface) FileWatchServiceClient {
+	return &fileWatchServiceClient{cc}
+}
+
+func (c *fileWatchServiceClient) Watch(ctx context.Context, in *WatchFilesRequest, opts ...grpc.CallOption) (FileWatchService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileWatchService_serviceDesc.Streams[0], "/supervisor.FileWatchService/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileWatchServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileWatchService_WatchClient interface {
+	Recv() (*WatchFilesResponse, error)
+	grpc.ClientStream
+}
+
+type fileWatchServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileWatchServiceWatchClient) Recv() (*WatchFilesResponse, error) {
+	m := new(WatchFilesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FileWatchServiceServer is the server API for FileWatchService service.
+type FileWatchServiceServer interface {
+	// Watch streams the changes of files in the workspace. Changes are debounced and sent in batches.
+	Watch(*WatchFilesRequest, FileWatchService_WatchServer) error
+}
+
+// UnimplementedFileWatchServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedFileWatchServiceServer struct {
+}
+
+func (*UnimplementedFileWatchServiceServer) Watch(req *WatchFilesRequest, srv FileWatchService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+
+func RegisterFileWatchServiceServer(s *grpc.Server, srv FileWatchServiceServer) {
+	s.RegisterService(&_FileWatchService_serviceDesc, srv)
+}
+
+func _FileWatchService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFilesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileWatchServiceServer).Watch(m, &fileWatchServiceWatchServer{stream})
+}
+
+type FileWatchService_WatchServer interface {
+	Send(*WatchFilesResponse) error
+	grpc.ServerStream
+}
+
+type fileWatchServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileWatchServiceWatchServer) Send(m *WatchFilesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _FileWatchService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.FileWatchService",
+	HandlerType: (*FileWatchServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _FileWatchService_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "filewatch.proto",
+}
@@ -11,6 +11,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -76,10 +77,14 @@ type WorkspaceInfoResponse struct {
 	// user_home is the path to the user's home.
 	UserHome string `protobuf:"bytes,6,opt,name=user_home,json=userHome,proto3" json:"user_home,omitempty"`
 	// GitpodAPI provides information to reach the Gitpod server API.
-	GitpodApi            *WorkspaceInfoResponse_GitpodAPI `protobuf:"bytes,7,opt,name=gitpod_api,json=gitpodApi,proto3" json:"gitpod_api,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
-	XXX_unrecognized     []byte                           `json:"-"`
-	XXX_sizecache        int32                            `json:"-"`
+	GitpodApi *WorkspaceInfoResponse_GitpodAPI `protobuf:"bytes,7,opt,name=gitpod_api,json=gitpodApi,proto3" json:"gitpod_api,omitempty"`
+	// ssh provides the connection details of the SSH server, if it is enabled.
+	Ssh *WorkspaceInfoResponse_SSH `protobuf:"bytes,8,opt,name=ssh,proto3" json:"ssh,omitempty"`
+	// jetbrains_backend is the status of the JetBrains Gateway backend, if the workspace offers one.
+	JetbrainsBackend     *JetBrainsBackendStatus `protobuf:"bytes,9,opt,name=jetbrains_backend,json=jetbrainsBackend,proto3" json:"jetbrains_backend,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
 func (m *WorkspaceInfoResponse) Reset()         { *m = WorkspaceInfoResponse{} }
@@ -179,6 +184,20 @@ func (m *WorkspaceInfoResponse) GetGitpodApi() *WorkspaceInfoResponse_GitpodAPI
 	return nil
 }
 
+func (m *WorkspaceInfoResponse) GetSsh() *WorkspaceInfoResponse_SSH {
+	if m != nil {
+		return m.Ssh
+	}
+	return nil
+}
+
+func (m *WorkspaceInfoResponse) GetJetbrainsBackend() *JetBrainsBackendStatus {
+	if m != nil {
+		return m.JetbrainsBackend
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*WorkspaceInfoResponse) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -236,10 +255,386 @@ func (m *WorkspaceInfoResponse_GitpodAPI) GetHost() string {
 	return ""
 }
 
+type WorkspaceInfoResponse_SSH struct {
+	// port is the port on which supervisor serves SSH
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// host_key_fingerprint is the SHA256 fingerprint of the host key, e.g. for known_hosts
+	HostKeyFingerprint string `protobuf:"bytes,2,opt,name=host_key_fingerprint,json=hostKeyFingerprint,proto3" json:"host_key_fingerprint,omitempty"`
+	// host_key is the public host key in the authorized_keys format, e.g. "ssh-ed25519 AAAA..."
+	HostKey string `protobuf:"bytes,3,opt,name=host_key,json=hostKey,proto3" json:"host_key,omitempty"`
+	// tunnel_path is the path on the workspace URL which tunnels SSH through a websocket. The SSH port
+	// is not exposed, clients outside the workspace connect through the tunnel instead, e.g. with a ProxyCommand.
+	TunnelPath string `protobuf:"bytes,4,opt,name=tunnel_path,json=tunnelPath,proto3" json:"tunnel_path,omitempty"`
+	// tunnel_cookie is the owner cookie with which the workspace proxy admits clients to the tunnel,
+	// e.g. "_gitpod_io_ws_<instance-id>_owner_=<token>". Empty if the workspace has no owner token.
+	TunnelCookie         string   `protobuf:"bytes,5,opt,name=tunnel_cookie,json=tunnelCookie,proto3" json:"tunnel_cookie,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkspaceInfoResponse_SSH) Reset()         { *m = WorkspaceInfoResponse_SSH{} }
+func (m *WorkspaceInfoResponse_SSH) String() string { return proto.CompactTextString(m) }
+func (*WorkspaceInfoResponse_SSH) ProtoMessage()    {}
+func (*WorkspaceInfoResponse_SSH) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{1, 1}
+}
+
+func (m *WorkspaceInfoResponse_SSH) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkspaceInfoResponse_SSH.Unmarshal(m, b)
+}
+func (m *WorkspaceInfoResponse_SSH) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkspaceInfoResponse_SSH.Marshal(b, m, deterministic)
+}
+func (m *WorkspaceInfoResponse_SSH) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkspaceInfoResponse_SSH.Merge(m, src)
+}
+func (m *WorkspaceInfoResponse_SSH) XXX_Size() int {
+	return xxx_messageInfo_WorkspaceInfoResponse_SSH.Size(m)
+}
+func (m *WorkspaceInfoResponse_SSH) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkspaceInfoResponse_SSH.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkspaceInfoResponse_SSH proto.InternalMessageInfo
+
+func (m *WorkspaceInfoResponse_SSH) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *WorkspaceInfoResponse_SSH) GetHostKeyFingerprint() string {
+	if m != nil {
+		return m.HostKeyFingerprint
+	}
+	return ""
+}
+
+func (m *WorkspaceInfoResponse_SSH) GetHostKey() string {
+	if m != nil {
+		return m.HostKey
+	}
+	return ""
+}
+
+func (m *WorkspaceInfoResponse_SSH) GetTunnelPath() string {
+	if m != nil {
+		return m.TunnelPath
+	}
+	return ""
+}
+
+func (m *WorkspaceInfoResponse_SSH) GetTunnelCookie() string {
+	if m != nil {
+		return m.TunnelCookie
+	}
+	return ""
+}
+
+type WorkspaceMetadataRequest struct {
+	// if observe is true, we'll return a stream of changes rather than just the
+	// current metadata.
+	Observe              bool     `protobuf:"varint,1,opt,name=observe,proto3" json:"observe,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkspaceMetadataRequest) Reset()         { *m = WorkspaceMetadataRequest{} }
+func (m *WorkspaceMetadataRequest) String() string { return proto.CompactTextString(m) }
+func (*WorkspaceMetadataRequest) ProtoMessage()    {}
+func (*WorkspaceMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{2}
+}
+
+func (m *WorkspaceMetadataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkspaceMetadataRequest.Unmarshal(m, b)
+}
+func (m *WorkspaceMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkspaceMetadataRequest.Marshal(b, m, deterministic)
+}
+func (m *WorkspaceMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkspaceMetadataRequest.Merge(m, src)
+}
+func (m *WorkspaceMetadataRequest) XXX_Size() int {
+	return xxx_messageInfo_WorkspaceMetadataRequest.Size(m)
+}
+func (m *WorkspaceMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkspaceMetadataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkspaceMetadataRequest proto.InternalMessageInfo
+
+func (m *WorkspaceMetadataRequest) GetObserve() bool {
+	if m != nil {
+		return m.Observe
+	}
+	return false
+}
+
+type WorkspaceMetadataResponse struct {
+	WorkspaceId string `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	InstanceId  string `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// owner_id is the ID of the user who owns the workspace
+	OwnerId string `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	// context_url is the URL the workspace was created from
+	ContextUrl string `protobuf:"bytes,4,opt,name=context_url,json=contextUrl,proto3" json:"context_url,omitempty"`
+	// workspace_class is the class of the workspace, which determines its resources
+	WorkspaceClass string `protobuf:"bytes,5,opt,name=workspace_class,json=workspaceClass,proto3" json:"workspace_class,omitempty"`
+	// cluster is the region of the cluster the workspace runs in
+	Cluster string `protobuf:"bytes,6,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	// node_name is the node the workspace runs on
+	NodeName string `protobuf:"bytes,7,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// phase is the lifecycle phase of the workspace instance, e.g. "running" or "stopping"
+	Phase string `protobuf:"bytes,8,opt,name=phase,proto3" json:"phase,omitempty"`
+	// timeout is the inactivity timeout of the workspace, e.g. "30m"
+	Timeout     string               `protobuf:"bytes,9,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	StartedTime *timestamp.Timestamp `protobuf:"bytes,10,opt,name=started_time,json=startedTime,proto3" json:"started_time,omitempty"`
+	// complete is false until the metadata were fetched from the Gitpod server
+	Complete             bool     `protobuf:"varint,11,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkspaceMetadataResponse) Reset()         { *m = WorkspaceMetadataResponse{} }
+func (m *WorkspaceMetadataResponse) String() string { return proto.CompactTextString(m) }
+func (*WorkspaceMetadataResponse) ProtoMessage()    {}
+func (*WorkspaceMetadataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{3}
+}
+
+func (m *WorkspaceMetadataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkspaceMetadataResponse.Unmarshal(m, b)
+}
+func (m *WorkspaceMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkspaceMetadataResponse.Marshal(b, m, deterministic)
+}
+func (m *WorkspaceMetadataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkspaceMetadataResponse.Merge(m, src)
+}
+func (m *WorkspaceMetadataResponse) XXX_Size() int {
+	return xxx_messageInfo_WorkspaceMetadataResponse.Size(m)
+}
+func (m *WorkspaceMetadataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkspaceMetadataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkspaceMetadataResponse proto.InternalMessageInfo
+
+func (m *WorkspaceMetadataResponse) GetWorkspaceId() string {
+	if m != nil {
+		return m.WorkspaceId
+	}
+	return ""
+}
+
+func (m *WorkspaceMetadataResponse) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *WorkspaceMetadataResponse) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *WorkspaceMetadataResponse) GetContextUrl() string {
+	if m != nil {
+		return m.ContextUrl
+	}
+	return ""
+}
+
+func (m *WorkspaceMetadataResponse) GetWorkspaceClass() string {
+	if m != nil {
+		return m.WorkspaceClass
+	}
+	return ""
+}
+
+func (m *WorkspaceMetadataResponse) GetCluster() string {
+	if m != nil {
+		return m.Cluster
+	}
+	return ""
+}
+
+func (m *WorkspaceMetadataResponse) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+func (m *WorkspaceMetadataResponse) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *WorkspaceMetadataResponse) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
+func (m *WorkspaceMetadataResponse) GetStartedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedTime
+	}
+	return nil
+}
+
+func (m *WorkspaceMetadataResponse) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+type APIVersionRequest struct {
+	// client_version is the API version the client was built against
+	ClientVersion uint32 `protobuf:"varint,1,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	// client identifies the client in supervisor's logs, e.g. the name and version of an IDE extension
+	Client               string   `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *APIVersionRequest) Reset()         { *m = APIVersionRequest{} }
+func (m *APIVersionRequest) String() string { return proto.CompactTextString(m) }
+func (*APIVersionRequest) ProtoMessage()    {}
+func (*APIVersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{4}
+}
+
+func (m *APIVersionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_APIVersionRequest.Unmarshal(m, b)
+}
+func (m *APIVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_APIVersionRequest.Marshal(b, m, deterministic)
+}
+func (m *APIVersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APIVersionRequest.Merge(m, src)
+}
+func (m *APIVersionRequest) XXX_Size() int {
+	return xxx_messageInfo_APIVersionRequest.Size(m)
+}
+func (m *APIVersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_APIVersionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APIVersionRequest proto.InternalMessageInfo
+
+func (m *APIVersionRequest) GetClientVersion() uint32 {
+	if m != nil {
+		return m.ClientVersion
+	}
+	return 0
+}
+
+func (m *APIVersionRequest) GetClient() string {
+	if m != nil {
+		return m.Client
+	}
+	return ""
+}
+
+type APIVersionResponse struct {
+	// version is the API version supervisor implements
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// min_client_version is the oldest API version of clients supervisor still supports
+	MinClientVersion uint32 `protobuf:"varint,2,opt,name=min_client_version,json=minClientVersion,proto3" json:"min_client_version,omitempty"`
+	// compatible is false if the client is too old for supervisor
+	Compatible bool `protobuf:"varint,3,opt,name=compatible,proto3" json:"compatible,omitempty"`
+	// methods are the full names of the gRPC methods supervisor serves, e.g. /supervisor.PortService/Expose
+	Methods []string `protobuf:"bytes,4,rep,name=methods,proto3" json:"methods,omitempty"`
+	// capabilities are the optional features enabled in this workspace, e.g. ssh
+	Capabilities         []string `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *APIVersionResponse) Reset()         { *m = APIVersionResponse{} }
+func (m *APIVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*APIVersionResponse) ProtoMessage()    {}
+func (*APIVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f140d5b28dddb141, []int{5}
+}
+
+func (m *APIVersionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_APIVersionResponse.Unmarshal(m, b)
+}
+func (m *APIVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_APIVersionResponse.Marshal(b, m, deterministic)
+}
+func (m *APIVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APIVersionResponse.Merge(m, src)
+}
+func (m *APIVersionResponse) XXX_Size() int {
+	return xxx_messageInfo_APIVersionResponse.Size(m)
+}
+func (m *APIVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_APIVersionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APIVersionResponse proto.InternalMessageInfo
+
+func (m *APIVersionResponse) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *APIVersionResponse) GetMinClientVersion() uint32 {
+	if m != nil {
+		return m.MinClientVersion
+	}
+	return 0
+}
+
+func (m *APIVersionResponse) GetCompatible() bool {
+	if m != nil {
+		return m.Compatible
+	}
+	return false
+}
+
+func (m *APIVersionResponse) GetMethods() []string {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+func (m *APIVersionResponse) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*WorkspaceInfoRequest)(nil), "supervisor.WorkspaceInfoRequest")
 	proto.RegisterType((*WorkspaceInfoResponse)(nil), "supervisor.WorkspaceInfoResponse")
 	proto.RegisterType((*WorkspaceInfoResponse_GitpodAPI)(nil), "supervisor.WorkspaceInfoResponse.GitpodAPI")
+	proto.RegisterType((*WorkspaceInfoResponse_SSH)(nil), "supervisor.WorkspaceInfoResponse.SSH")
+	proto.RegisterType((*WorkspaceMetadataRequest)(nil), "supervisor.WorkspaceMetadataRequest")
+	proto.RegisterType((*WorkspaceMetadataResponse)(nil), "supervisor.WorkspaceMetadataResponse")
+	proto.RegisterType((*APIVersionRequest)(nil), "supervisor.APIVersionRequest")
+	proto.RegisterType((*APIVersionResponse)(nil), "supervisor.APIVersionResponse")
 }
 
 func init() {
@@ -247,31 +642,64 @@ func init() {
 }
 
 var fileDescriptor_f140d5b28dddb141 = []byte{
-	// 382 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcd, 0xee, 0xd2, 0x40,
-	0x14, 0xc5, 0xe5, 0xcf, 0x87, 0xf4, 0x56, 0x13, 0xbd, 0x01, 0xa9, 0xd5, 0x44, 0x60, 0x45, 0x42,
-	0xd2, 0x46, 0xdc, 0x98, 0xe8, 0x06, 0x16, 0x4a, 0x8d, 0x0b, 0x53, 0x17, 0x26, 0x6e, 0x9a, 0xda,
-	0x0e, 0x30, 0xa1, 0xcc, 0x1d, 0x3b, 0x53, 0x5c, 0x9a, 0xf8, 0x0a, 0xbe, 0x8e, 0x6f, 0xe1, 0x2b,
-	0xf8, 0x20, 0xa6, 0x43, 0x5b, 0xa2, 0x10, 0xdd, 0xb5, 0xe7, 0x9e, 0x5f, 0xe6, 0xdc, 0x93, 0x0b,
-	0xc0, 0xc5, 0x86, 0x3c, 0x99, 0x93, 0x26, 0x04, 0x55, 0x48, 0x96, 0x1f, 0xb9, 0xa2, 0xdc, 0x7d,
-	0xbc, 0x25, 0xda, 0x66, 0xcc, 0x8f, 0x25, 0xf7, 0x63, 0x21, 0x48, 0xc7, 0x9a, 0x93, 0x50, 0x27,
-	0xe7, 0xf4, 0x01, 0x0c, 0x3e, 0x50, 0xbe, 0x57, 0x32, 0x4e, 0x58, 0x20, 0x36, 0x14, 0xb2, 0xcf,
-	0x05, 0x53, 0x7a, 0xfa, 0xa3, 0x0d, 0xc3, 0xbf, 0x06, 0x4a, 0x92, 0x50, 0x0c, 0x27, 0x70, 0xe7,
-	0x4b, 0x3d, 0x88, 0x78, 0xea, 0xb4, 0xc6, 0xad, 0x99, 0x15, 0xda, 0x8d, 0x16, 0xa4, 0xf8, 0x04,
-	0x6c, 0x2e, 0x94, 0x8e, 0xc5, 0xc9, 0x71, 0x63, 0x1c, 0x50, 0x4b, 0x41, 0x8a, 0x73, 0xb8, 0x9f,
-	0xec, 0x58, 0xb2, 0xa7, 0x42, 0x47, 0x19, 0x25, 0x26, 0x91, 0xd3, 0x36, 0xb6, 0x7b, 0xf5, 0xe0,
-	0x6d, 0xa5, 0xe3, 0x73, 0x18, 0x9d, 0x1f, 0xac, 0xdd, 0xd1, 0x86, 0x67, 0xcc, 0xe9, 0x94, 0xc8,
-	0xfa, 0x56, 0x38, 0x6c, 0x0c, 0x35, 0xf5, 0x8a, 0x67, 0x0c, 0x5f, 0xc2, 0xc3, 0x6b, 0x24, 0x65,
-	0x29, 0xcb, 0x9d, 0x6e, 0xc5, 0x8e, 0x2e, 0x59, 0x63, 0xc0, 0x47, 0x60, 0x15, 0x8a, 0xe5, 0xd1,
-	0x8e, 0x0e, 0xcc, 0xe9, 0x99, 0x70, 0xfd, 0x52, 0x58, 0xd3, 0x81, 0xe1, 0x1b, 0x80, 0x2d, 0xd7,
-	0x92, 0xd2, 0x28, 0x96, 0xdc, 0xb9, 0x3d, 0x6e, 0xcd, 0xec, 0xc5, 0xdc, 0x3b, 0xd7, 0xee, 0x5d,
-	0x2d, 0xcf, 0x7b, 0x6d, 0x98, 0xe5, 0xbb, 0x20, 0xb4, 0x4e, 0xf8, 0x52, 0x72, 0xf7, 0x05, 0x58,
-	0x8d, 0x8e, 0x2e, 0xf4, 0x99, 0x48, 0x25, 0x71, 0xa1, 0xab, 0x6a, 0x9b, 0x7f, 0x44, 0xe8, 0xec,
-	0x48, 0xe9, 0xaa, 0x50, 0xf3, 0xbd, 0x1a, 0x00, 0x5e, 0xee, 0xb8, 0xf8, 0x0a, 0x76, 0xf9, 0xee,
-	0xfb, 0x32, 0x4d, 0xc2, 0x50, 0xc2, 0xdd, 0x3f, 0xf2, 0xe0, 0xf8, 0x1f, 0x51, 0xcd, 0x01, 0xb8,
-	0x93, 0xff, 0x2e, 0x33, 0x75, 0xbf, 0xfd, 0xfc, 0xf5, 0xfd, 0x66, 0x80, 0xe8, 0x1f, 0x9f, 0xfa,
-	0xe5, 0xf5, 0xf9, 0x4d, 0x92, 0x55, 0xf7, 0x63, 0x3b, 0x96, 0xfc, 0x53, 0xcf, 0x5c, 0xd9, 0xb3,
-	0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x96, 0x60, 0x4e, 0xc6, 0x9d, 0x02, 0x00, 0x00,
+	// 908 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdb, 0x72, 0x1b, 0x45,
+	0x10, 0x45, 0x96, 0x2f, 0x52, 0xcb, 0x4e, 0xec, 0x2e, 0xc5, 0x59, 0x6f, 0x6e, 0x8e, 0x82, 0x8b,
+	0x54, 0x05, 0xa4, 0x10, 0xa8, 0x82, 0x2a, 0xe0, 0xc1, 0x76, 0x55, 0xb0, 0xc3, 0xcd, 0xb5, 0xe2,
+	0x52, 0xc5, 0xcb, 0xd6, 0x68, 0xb7, 0x6d, 0x0d, 0xde, 0x9d, 0x19, 0x76, 0x66, 0x1d, 0xf2, 0xca,
+	0x03, 0x7c, 0x00, 0xbf, 0xc1, 0x17, 0xf0, 0x1b, 0xfc, 0x02, 0x9f, 0x00, 0xef, 0xd4, 0xcc, 0xce,
+	0xae, 0x6c, 0xcb, 0xe0, 0x97, 0xbc, 0xa9, 0x4f, 0x9f, 0xd3, 0xbd, 0xdb, 0x7d, 0xb6, 0x05, 0xc0,
+	0xc5, 0xb1, 0x1c, 0xaa, 0x42, 0x1a, 0x89, 0xa0, 0x4b, 0x45, 0xc5, 0x19, 0xd7, 0xb2, 0x08, 0xef,
+	0x9e, 0x48, 0x79, 0x92, 0xd1, 0x88, 0x29, 0x3e, 0x62, 0x42, 0x48, 0xc3, 0x0c, 0x97, 0x42, 0x57,
+	0xcc, 0xf0, 0x81, 0xcf, 0xba, 0x68, 0x52, 0x1e, 0x8f, 0x0c, 0xcf, 0x49, 0x1b, 0x96, 0x2b, 0x4f,
+	0xb8, 0xf9, 0x03, 0x99, 0x49, 0xc1, 0x78, 0xad, 0x18, 0x6c, 0x42, 0xff, 0x3b, 0x59, 0x9c, 0x6a,
+	0xc5, 0x12, 0x3a, 0x14, 0xc7, 0x32, 0xa2, 0x1f, 0x4b, 0xd2, 0x66, 0xf0, 0xcf, 0x12, 0xdc, 0xba,
+	0x94, 0xd0, 0x4a, 0x0a, 0x4d, 0xf8, 0x10, 0x56, 0x5f, 0xd6, 0x89, 0x98, 0xa7, 0x41, 0x6b, 0xbb,
+	0xf5, 0xb8, 0x1b, 0xf5, 0x1a, 0xec, 0x30, 0xc5, 0x07, 0xd0, 0xe3, 0x42, 0x1b, 0x26, 0x2a, 0xc6,
+	0x82, 0x63, 0x40, 0x0d, 0x1d, 0xa6, 0xf8, 0x04, 0x36, 0x92, 0x29, 0x25, 0xa7, 0xb2, 0x34, 0x71,
+	0x26, 0x13, 0xf7, 0x0e, 0x41, 0xdb, 0xd1, 0xd6, 0xeb, 0xc4, 0xe7, 0x1e, 0xc7, 0x0f, 0xe1, 0xf6,
+	0xac, 0x61, 0xcd, 0x8e, 0x8f, 0x79, 0x46, 0xc1, 0xa2, 0x95, 0x1c, 0xbc, 0x11, 0xdd, 0x6a, 0x08,
+	0xb5, 0xea, 0x39, 0xcf, 0x08, 0x3f, 0x86, 0xad, 0xab, 0x94, 0x32, 0x4b, 0xa9, 0x08, 0x96, 0xbc,
+	0xf6, 0xf6, 0xbc, 0xd6, 0x11, 0xf0, 0x0e, 0x74, 0x4b, 0x4d, 0x45, 0x3c, 0x95, 0x39, 0x05, 0xcb,
+	0xee, 0xe1, 0x3a, 0x16, 0x38, 0x90, 0x39, 0xe1, 0x0b, 0x80, 0x13, 0x6e, 0x94, 0x4c, 0x63, 0xa6,
+	0x78, 0xb0, 0xb2, 0xdd, 0x7a, 0xdc, 0x7b, 0xf6, 0x64, 0x38, 0x5b, 0xd4, 0xf0, 0xca, 0xe1, 0x0d,
+	0x3f, 0x75, 0x9a, 0xdd, 0xa3, 0xc3, 0xa8, 0x5b, 0xc9, 0x77, 0x15, 0xc7, 0x0f, 0xa0, 0xad, 0xf5,
+	0x34, 0xe8, 0xb8, 0x22, 0x3b, 0xd7, 0x17, 0x19, 0x8f, 0x0f, 0x22, 0xab, 0xc0, 0xaf, 0x60, 0xa3,
+	0xd9, 0x67, 0x3c, 0x61, 0xc9, 0x29, 0x89, 0x34, 0xe8, 0xba, 0x32, 0x83, 0xf3, 0x65, 0x5e, 0x90,
+	0xd9, 0x73, 0xa4, 0xbd, 0x8a, 0x33, 0x36, 0xcc, 0x94, 0x3a, 0x5a, 0x6f, 0xc4, 0x1e, 0x0f, 0x3f,
+	0x82, 0x6e, 0xf3, 0x84, 0x18, 0x42, 0x87, 0x44, 0xaa, 0x24, 0x17, 0xc6, 0x2f, 0xb9, 0x89, 0x11,
+	0x61, 0x71, 0x2a, 0xb5, 0xf1, 0xab, 0x75, 0xbf, 0xc3, 0xdf, 0x5b, 0xd0, 0x1e, 0x8f, 0x0f, 0x6c,
+	0x4e, 0xc9, 0xa2, 0xd2, 0xac, 0x45, 0xee, 0x37, 0x3e, 0x85, 0xbe, 0xe5, 0xc4, 0xa7, 0xf4, 0x2a,
+	0x3e, 0xe6, 0xe2, 0x84, 0x0a, 0x55, 0x70, 0x51, 0xeb, 0xd1, 0xe6, 0x3e, 0xa3, 0x57, 0xcf, 0x67,
+	0x19, 0xdc, 0x82, 0x4e, 0xad, 0xf0, 0xce, 0x58, 0xf1, 0x2c, 0x6b, 0x2f, 0x53, 0x0a, 0x41, 0x59,
+	0xac, 0x98, 0x99, 0x56, 0x26, 0x88, 0xa0, 0x82, 0x8e, 0x98, 0x99, 0xe2, 0x23, 0x58, 0xf3, 0x84,
+	0x44, 0xca, 0x53, 0x4e, 0xd5, 0xae, 0xa3, 0xd5, 0x0a, 0xdc, 0x77, 0xd8, 0x5e, 0x1f, 0x70, 0xde,
+	0x1c, 0x83, 0xf7, 0x21, 0x68, 0x86, 0xfe, 0x05, 0x19, 0x96, 0x32, 0xc3, 0xfc, 0x37, 0x81, 0x01,
+	0xac, 0xc8, 0x89, 0xa6, 0xe2, 0x8c, 0xdc, 0xbb, 0x75, 0xa2, 0x3a, 0x1c, 0xfc, 0xd2, 0x86, 0xad,
+	0x2b, 0x64, 0xaf, 0xf1, 0x8b, 0xd9, 0x82, 0x8e, 0x7c, 0x29, 0xa8, 0xb0, 0x59, 0x3f, 0x0e, 0x17,
+	0x57, 0xda, 0x44, 0x0a, 0x43, 0x3f, 0x99, 0xb8, 0x2c, 0xb2, 0x7a, 0x1c, 0x1e, 0xfa, 0xa6, 0xc8,
+	0xf0, 0x2d, 0xb8, 0x39, 0xeb, 0x9f, 0x64, 0x4c, 0x6b, 0x3f, 0x90, 0x1b, 0x0d, 0xbc, 0x6f, 0x51,
+	0xfb, 0x82, 0x49, 0x56, 0x6a, 0x43, 0x85, 0xf7, 0x7b, 0x1d, 0xda, 0x6f, 0x41, 0xc8, 0x94, 0x62,
+	0xc1, 0x72, 0x72, 0x6e, 0xef, 0x46, 0x1d, 0x0b, 0x7c, 0xc9, 0x72, 0xc2, 0x3e, 0x2c, 0xa9, 0x29,
+	0xd3, 0xe4, 0x1c, 0xdc, 0x8d, 0xaa, 0xc0, 0x16, 0xb3, 0xd7, 0x47, 0x96, 0xc6, 0x59, 0xb2, 0x1b,
+	0xd5, 0x21, 0x7e, 0x02, 0xab, 0xda, 0xb0, 0xc2, 0x50, 0x1a, 0x5b, 0x28, 0x00, 0xe7, 0xd8, 0x70,
+	0x58, 0x1d, 0xaf, 0x61, 0x7d, 0xbc, 0x86, 0x5f, 0xd7, 0xc7, 0x2b, 0xea, 0x79, 0xbe, 0x45, 0xac,
+	0x2f, 0x13, 0x99, 0xab, 0x8c, 0x0c, 0x05, 0x3d, 0xb7, 0x87, 0x26, 0x1e, 0x44, 0xb0, 0xb1, 0x7b,
+	0x74, 0xf8, 0x2d, 0x15, 0x9a, 0x4b, 0x51, 0xef, 0x6d, 0x07, 0x6e, 0x24, 0x19, 0x27, 0x61, 0xe2,
+	0xb3, 0x2a, 0xe1, 0xad, 0xb9, 0x56, 0xa1, 0x9e, 0x8d, 0x9b, 0xb0, 0x5c, 0x01, 0x7e, 0xfc, 0x3e,
+	0x1a, 0xfc, 0xd1, 0x02, 0x3c, 0x5f, 0xd4, 0x6f, 0x35, 0x80, 0x95, 0x8b, 0xe5, 0xea, 0x10, 0xdf,
+	0x06, 0xcc, 0xb9, 0x88, 0x2f, 0xf5, 0x5c, 0x70, 0xa4, 0xf5, 0x9c, 0x8b, 0xfd, 0x0b, 0x6d, 0xef,
+	0x03, 0xd8, 0xc7, 0x67, 0x86, 0x4f, 0x32, 0x72, 0xbb, 0xed, 0x44, 0xe7, 0x10, 0xdb, 0x27, 0x27,
+	0x33, 0x95, 0xa9, 0x0e, 0x16, 0xb7, 0xdb, 0x76, 0x8e, 0x3e, 0xc4, 0x01, 0xac, 0x26, 0x4c, 0xb1,
+	0x09, 0xcf, 0xb8, 0xe1, 0x64, 0x97, 0x6a, 0xd3, 0x17, 0xb0, 0x67, 0x7f, 0x2f, 0x40, 0xcf, 0x1e,
+	0x8f, 0xb1, 0xbd, 0x05, 0x09, 0xa1, 0x82, 0xb5, 0x0b, 0x47, 0x05, 0xb7, 0xff, 0xe7, 0xde, 0xb8,
+	0xf1, 0x85, 0x0f, 0xaf, 0xbd, 0x48, 0x83, 0xf0, 0xe7, 0x3f, 0xff, 0xfa, 0x6d, 0xa1, 0x8f, 0x38,
+	0x3a, 0x7b, 0x77, 0x64, 0xff, 0xb9, 0x46, 0x8d, 0xb3, 0xf0, 0xd7, 0x16, 0x6c, 0xcc, 0x7d, 0x1b,
+	0xf8, 0xe6, 0x95, 0x45, 0x2f, 0x7d, 0x71, 0xe1, 0xce, 0x35, 0x2c, 0xdf, 0xfe, 0x91, 0x6b, 0x7f,
+	0x0f, 0xef, 0xcc, 0xb7, 0x1f, 0xe5, 0x9e, 0xfc, 0xb4, 0x85, 0x1c, 0x60, 0xb6, 0x47, 0xbc, 0x77,
+	0xbe, 0xf6, 0x9c, 0x69, 0xc2, 0xfb, 0xff, 0x95, 0xf6, 0x3d, 0xef, 0xba, 0x9e, 0x9b, 0xd8, 0x6f,
+	0x7a, 0x32, 0xc5, 0xdf, 0xf1, 0xcb, 0xde, 0x5b, 0xfa, 0xbe, 0xcd, 0x14, 0x9f, 0x2c, 0x3b, 0x2f,
+	0xbf, 0xf7, 0xef, 0x00, 0x94, 0x23, 0xc8, 0x3e, 0xce, 0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -287,6 +715,12 @@ const _ = grpc.SupportPackageIsVersion6
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type InfoServiceClient interface {
 	WorkspaceInfo(ctx context.Context, in *WorkspaceInfoRequest, opts ...grpc.CallOption) (*WorkspaceInfoResponse, error)
+	// WorkspaceMetadata returns who owns the workspace, where it runs and in which phase it is.
+	// If observe is true, changes, e.g. of the timeout, are streamed until the workspace stops.
+	WorkspaceMetadata(ctx context.Context, in *WorkspaceMetadataRequest, opts ...grpc.CallOption) (InfoService_WorkspaceMetadataClient, error)
+	// APIVersion returns the version of the API supervisor implements and the methods it serves, s.t. clients
+	// can detect which features are available and degrade gracefully. Supervisors without this method implement version 0.
+	APIVersion(ctx context.Context, in *APIVersionRequest, opts ...grpc.CallOption) (*APIVersionResponse, error)
 }
 
 type infoServiceClient struct {
@@ -306,9 +740,56 @@ func (c *infoServiceClient) WorkspaceInfo(ctx context.Context, in *WorkspaceInfo
 	return out, nil
 }
 
+func (c *infoServiceClient) WorkspaceMetadata(ctx context.Context, in *WorkspaceMetadataRequest, opts ...grpc.CallOption) (InfoService_WorkspaceMetadataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_InfoService_serviceDesc.Streams[0], "/supervisor.InfoService/WorkspaceMetadata", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &infoServiceWorkspaceMetadataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type InfoService_WorkspaceMetadataClient interface {
+	Recv() (*WorkspaceMetadataResponse, error)
+	grpc.ClientStream
+}
+
+type infoServiceWorkspaceMetadataClient struct {
+	grpc.ClientStream
+}
+
+func (x *infoServiceWorkspaceMetadataClient) Recv() (*WorkspaceMetadataResponse, error) {
+	m := new(WorkspaceMetadataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *infoServiceClient) APIVersion(ctx context.Context, in *APIVersionRequest, opts ...grpc.CallOption) (*APIVersionResponse, error) {
+	out := new(APIVersionResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.InfoService/APIVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InfoServiceServer is the server API for InfoService service.
 type InfoServiceServer interface {
 	WorkspaceInfo(context.Context, *WorkspaceInfoRequest) (*WorkspaceInfoResponse, error)
+	// WorkspaceMetadata returns who owns the workspace, where it runs and in which phase it is.
+	// If observe is true, changes, e.g. of the timeout, are streamed until the workspace stops.
+	WorkspaceMetadata(*WorkspaceMetadataRequest, InfoService_WorkspaceMetadataServer) error
+	// APIVersion returns the version of the API supervisor implements and the methods it serves, s.t. clients
+	// can detect which features are available and degrade gracefully. Supervisors without this method implement version 0.
+	APIVersion(context.Context, *APIVersionRequest) (*APIVersionResponse, error)
 }
 
 // UnimplementedInfoServiceServer can be embedded to have forward compatible implementations.
@@ -318,6 +799,12 @@ type UnimplementedInfoServiceServer struct {
 func (*UnimplementedInfoServiceServer) WorkspaceInfo(ctx context.Context, req *WorkspaceInfoRequest) (*WorkspaceInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WorkspaceInfo not implemented")
 }
+func (*UnimplementedInfoServiceServer) WorkspaceMetadata(req *WorkspaceMetadataRequest, srv InfoService_WorkspaceMetadataServer) error {
+	return status.Errorf(codes.Unimplemented, "method WorkspaceMetadata not implemented")
+}
+func (*UnimplementedInfoServiceServer) APIVersion(ctx context.Context, req *APIVersionRequest) (*APIVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method APIVersion not implemented")
+}
 
 func RegisterInfoServiceServer(s *grpc.Server, srv InfoServiceServer) {
 	s.RegisterService(&_InfoService_serviceDesc, srv)
@@ -341,6 +828,45 @@ func _InfoService_WorkspaceInfo_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InfoService_WorkspaceMetadata_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WorkspaceMetadataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InfoServiceServer).WorkspaceMetadata(m, &infoServiceWorkspaceMetadataServer{stream})
+}
+
+type InfoService_WorkspaceMetadataServer interface {
+	Send(*WorkspaceMetadataResponse) error
+	grpc.ServerStream
+}
+
+type infoServiceWorkspaceMetadataServer struct {
+	grpc.ServerStream
+}
+
+func (x *infoServiceWorkspaceMetadataServer) Send(m *WorkspaceMetadataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _InfoService_APIVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InfoServiceServer).APIVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.InfoService/APIVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InfoServiceServer).APIVersion(ctx, req.(*APIVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _InfoService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "supervisor.InfoService",
 	HandlerType: (*InfoServiceServer)(nil),
@@ -349,7 +875,17 @@ var _InfoService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "WorkspaceInfo",
 			Handler:    _InfoService_WorkspaceInfo_Handler,
 		},
+		{
+			MethodName: "APIVersion",
+			Handler:    _InfoService_APIVersion_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WorkspaceMetadata",
+			Handler:       _InfoService_WorkspaceMetadata_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "info.proto",
 }
@@ -0,0 +1,436 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: jetbrains.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type JetBrainsBackendState int32
+
+const (
+	JetBrainsBackendState_jetbrains_backend_stopped     JetBrainsBackendState = 0
+	JetBrainsBackendState_jetbrains_backend_downloading JetBrainsBackendState = 1
+	JetBrainsBackendState_jetbrains_backend_starting    JetBrainsBackendState = 2
+	JetBrainsBackendState_jetbrains_backend_running     JetBrainsBackendState = 3
+	JetBrainsBackendState_jetbrains_backend_failed      JetBrainsBackendState = 4
+)
+
+var JetBrainsBackendState_name = map[int32]string{
+	0: "jetbrains_backend_stopped",
+	1: "jetbrains_backend_downloading",
+	2: "jetbrains_backend_starting",
+	3: "jetbrains_backend_running",
+	4: "jetbrains_backend_failed",
+}
+
+var JetBrainsBackendState_value = map[string]int32{
+	"jetbrains_backend_stopped":     0,
+	"jetbrains_backend_downloading": 1,
+	"jetbrains_backend_starting":    2,
+	"jetbrains_backend_running":     3,
+	"jetbrains_backend_failed":      4,
+}
+
+func (x JetBrainsBackendState) String() string {
+	return proto.EnumName(JetBrainsBackendState_name, int32(x))
+}
+
+func (JetBrainsBackendState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_02e8cca57cd88459, []int{0}
+}
+
+type JetBrainsBackendStatus struct {
+	State JetBrainsBackendState `protobuf:"varint,1,opt,name=state,proto3,enum=supervisor.JetBrainsBackendState" json:"state,omitempty"`
+	// port is the internal port the backend listens on. Gateway reaches it by forwarding the port via SSH.
+	Port uint32 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	// join_link is the link Gateway joins the running backend with
+	JoinLink string `protobuf:"bytes,3,opt,name=join_link,json=joinLink,proto3" json:"join_link,omitempty"`
+	// error is why the backend failed
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JetBrainsBackendStatus) Reset()         { *m = JetBrainsBackendStatus{} }
+func (m *JetBrainsBackendStatus) String() string { return proto.CompactTextString(m) }
+func (*JetBrainsBackendStatus) ProtoMessage()    {}
+func (*JetBrainsBackendStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02e8cca57cd88459, []int{0}
+}
+
+func (m *JetBrainsBackendStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JetBrainsBackendStatus.Unmarshal(m, b)
+}
+func (m *JetBrainsBackendStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JetBrainsBackendStatus.Marshal(b, m, deterministic)
+}
+func (m *JetBrainsBackendStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JetBrainsBackendStatus.Merge(m, src)
+}
+func (m *JetBrainsBackendStatus) XXX_Size() int {
+	return xxx_messageInfo_JetBrainsBackendStatus.Size(m)
+}
+func (m *JetBrainsBackendStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_JetBrainsBackendStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JetBrainsBackendStatus proto.InternalMessageInfo
+
+func (m *JetBrainsBackendStatus) GetState() JetBrainsBackendState {
+	if m != nil {
+		return m.State
+	}
+	return JetBrainsBackendState_jetbrains_backend_stopped
+}
+
+func (m *JetBrainsBackendStatus) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *JetBrainsBackendStatus) GetJoinLink() string {
+	if m != nil {
+		return m.JoinLink
+	}
+	return ""
+}
+
+func (m *JetBrainsBackendStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type StartJetBrainsBackendRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartJetBrainsBackendRequest) Reset()         { *m = StartJetBrainsBackendRequest{} }
+func (m *StartJetBrainsBackendRequest) String() string { return proto.CompactTextString(m) }
+func (*StartJetBrainsBackendRequest) ProtoMessage()    {}
+func (*StartJetBrainsBackendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02e8cca57cd88459, []int{1}
+}
+
+func (m *StartJetBrainsBackendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartJetBrainsBackendRequest.Unmarshal(m, b)
+}
+func (m *StartJetBrainsBackendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartJetBrainsBackendRequest.Marshal(b, m, deterministic)
+}
+func (m *StartJetBrainsBackendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartJetBrainsBackendRequest.Merge(m, src)
+}
+func (m *StartJetBrainsBackendRequest) XXX_Size() int {
+	return xxx_messageInfo_StartJetBrainsBackendRequest.Size(m)
+}
+func (m *StartJetBrainsBackendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartJetBrainsBackendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartJetBrainsBackendRequest proto.InternalMessageInfo
+
+type StartJetBrainsBackendResponse struct {
+	Status               *JetBrainsBackendStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *StartJetBrainsBackendResponse) Reset()         { *m = StartJetBrainsBackendResponse{} }
+func (m *StartJetBrainsBackendResponse) String() string { return proto.CompactTextString(m) }
+func (*StartJetBrainsBackendResponse) ProtoMessage()    {}
+func (*StartJetBrainsBackendResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02e8cca57cd88459, []int{2}
+}
+
+func (m *StartJetBrainsBackendResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartJetBrainsBackendResponse.Unmarshal(m, b)
+}
+func (m *StartJetBrainsBackendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartJetBrainsBackendResponse.Marshal(b, m, deterministic)
+}
+func (m *StartJetBrainsBackendResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartJetBrainsBackendResponse.Merge(m, src)
+}
+func (m *StartJetBrainsBackendResponse) XXX_Size() int {
+	return xxx_messageInfo_StartJetBrainsBackendResponse.Size(m)
+}
+func (m *StartJetBrainsBackendResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartJetBrainsBackendResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartJetBrainsBackendResponse proto.InternalMessageInfo
+
+func (m *StartJetBrainsBackendResponse) GetStatus() *JetBrainsBackendStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type StopJetBrainsBackendRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopJetBrainsBackendRequest) Reset()         { *m = StopJetBrainsBackendRequest{} }
+func (m *StopJetBrainsBackendRequest) String() string { return proto.CompactTextString(m) }
+func (*StopJetBrainsBackendRequest) ProtoMessage()    {}
+func (*StopJetBrainsBackendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02e8cca57cd88459, []int{3}
+}
+
+func (m *StopJetBrainsBackendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopJetBrainsBackendRequest.Unmarshal(m, b)
+}
+func (m *StopJetBrainsBackendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopJetBrainsBackendRequest.Marshal(b, m, deterministic)
+}
+func (m *StopJetBrainsBackendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopJetBrainsBackendRequest.Merge(m, src)
+}
+func (m *StopJetBrainsBackendRequest) XXX_Size() int {
+	return xxx_messageInfo_StopJetBrainsBackendRequest.Size(m)
+}
+func (m *StopJetBrainsBackendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopJetBrainsBackendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopJetBrainsBackendRequest proto.InternalMessageInfo
+
+type StopJetBrainsBackendResponse struct {
+	Status               *JetBrainsBackendStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *StopJetBrainsBackendResponse) Reset()         { *m = StopJetBrainsBackendResponse{} }
+func (m *StopJetBrainsBackendResponse) String() string { return proto.CompactTextString(m) }
+func (*StopJetBrainsBackendResponse) ProtoMessage()    {}
+func (*StopJetBrainsBackendResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_02e8cca57cd88459, []int{4}
+}
+
+func (m *StopJetBrainsBackendResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopJetBrainsBackendResponse.Unmarshal(m, b)
+}
+func (m *StopJetBrainsBackendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopJetBrainsBackendResponse.Marshal(b, m, deterministic)
+}
+func (m *StopJetBrainsBackendResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopJetBrainsBackendResponse.Merge(m, src)
+}
+func (m *StopJetBrainsBackendResponse) XXX_Size() int {
+	return xxx_messageInfo_StopJetBrainsBackendResponse.Size(m)
+}
+func (m *StopJetBrainsBackendResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopJetBrainsBackendResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopJetBrainsBackendResponse proto.InternalMessageInfo
+
+func (m *StopJetBrainsBackendResponse) GetStatus() *JetBrainsBackendStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("supervisor.JetBrainsBackendState", JetBrainsBackendState_name, JetBrainsBackendState_value)
+	proto.RegisterType((*JetBrainsBackendStatus)(nil), "supervisor.JetBrainsBackendStatus")
+	proto.RegisterType((*StartJetBrainsBackendRequest)(nil), "supervisor.StartJetBrainsBackendRequest")
+	proto.RegisterType((*StartJetBrainsBackendResponse)(nil), "supervisor.StartJetBrainsBackendResponse")
+	proto.RegisterType((*StopJetBrainsBackendRequest)(nil), "supervisor.StopJetBrainsBackendRequest")
+	proto.RegisterType((*StopJetBrainsBackendResponse)(nil), "supervisor.StopJetBrainsBackendResponse")
+}
+
+func init() {
+	proto.RegisterFile("jetbrains.proto", fileDescriptor_02e8cca57cd88459)
+}
+
+var fileDescriptor_02e8cca57cd88459 = []byte{
+	// 426 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xbb, 0x8e, 0xd3, 0x40,
+	0x14, 0x65, 0xf2, 0x58, 0xb1, 0x77, 0x79, 0x44, 0x23, 0x40, 0xc6, 0x49, 0x96, 0xec, 0x48, 0x80,
+	0x49, 0x11, 0x8b, 0x50, 0x20, 0xa5, 0x4c, 0x89, 0xa8, 0x9c, 0x6e, 0x29, 0xa2, 0xc9, 0x7a, 0x88,
+	0x66, 0x13, 0xcd, 0x1d, 0x66, 0xae, 0x97, 0x9e, 0x86, 0x82, 0x92, 0x86, 0x6f, 0xe0, 0x03, 0xf8,
+	0x11, 0x7e, 0x81, 0x0f, 0x41, 0x9e, 0x64, 0xc3, 0x63, 0x6d, 0x82, 0xb4, 0x9d, 0xed, 0x73, 0xee,
+	0x3d, 0xe7, 0xdc, 0x23, 0xc3, 0xdd, 0x73, 0x45, 0x0b, 0x27, 0xb5, 0xf1, 0x23, 0xeb, 0x90, 0x90,
+	0x83, 0x2f, 0xac, 0x72, 0x17, 0xda, 0xa3, 0x8b, 0x7b, 0x4b, 0xc4, 0xe5, 0x5a, 0xa5, 0xd2, 0xea,
+	0x54, 0x1a, 0x83, 0x24, 0x49, 0xe3, 0x25, 0x53, 0x7c, 0x61, 0xf0, 0xe0, 0x95, 0xa2, 0x69, 0x98,
+	0x9e, 0xca, 0xb3, 0x95, 0x32, 0xf9, 0x8c, 0x24, 0x15, 0x9e, 0xbf, 0x84, 0xb6, 0x27, 0x49, 0x2a,
+	0x62, 0x03, 0x96, 0xdc, 0x19, 0x9f, 0x8c, 0x7e, 0x2d, 0x1d, 0x55, 0x8d, 0xa8, 0x6c, 0xc3, 0xe7,
+	0x1c, 0x5a, 0x16, 0x1d, 0x45, 0x8d, 0x01, 0x4b, 0x6e, 0x67, 0xe1, 0x99, 0x77, 0xe1, 0xf0, 0x1c,
+	0xb5, 0x99, 0xaf, 0xb5, 0x59, 0x45, 0xcd, 0x01, 0x4b, 0x0e, 0xb3, 0x9b, 0xe5, 0x87, 0xd7, 0xda,
+	0xac, 0xf8, 0x3d, 0x68, 0x2b, 0xe7, 0xd0, 0x45, 0xad, 0x00, 0x6c, 0x5e, 0xc4, 0x31, 0xf4, 0x66,
+	0x24, 0x1d, 0xfd, 0xad, 0x95, 0xa9, 0x77, 0x85, 0xf2, 0x24, 0xde, 0x40, 0xbf, 0x06, 0xf7, 0x16,
+	0x8d, 0x57, 0x7c, 0x02, 0x07, 0x3e, 0x44, 0x09, 0x09, 0x8e, 0xc6, 0x62, 0x5f, 0x82, 0xc2, 0x67,
+	0xdb, 0x09, 0xd1, 0x87, 0xee, 0x8c, 0xd0, 0xd6, 0x69, 0x9f, 0x42, 0xaf, 0x1a, 0xbe, 0xbe, 0xf4,
+	0xf0, 0x1b, 0x83, 0xfb, 0x95, 0xf7, 0xe5, 0x7d, 0x78, 0xb8, 0x6b, 0x7a, 0xbe, 0xd8, 0x20, 0x73,
+	0x4f, 0x68, 0xad, 0xca, 0x3b, 0x37, 0xf8, 0x09, 0xf4, 0xaf, 0xc2, 0x39, 0xbe, 0x37, 0x6b, 0x94,
+	0xb9, 0x36, 0xcb, 0x0e, 0xe3, 0xc7, 0x10, 0x57, 0x6d, 0x90, 0x8e, 0x4a, 0xbc, 0x51, 0xad, 0xe0,
+	0x0a, 0x63, 0x4a, 0xb8, 0xc9, 0x7b, 0x10, 0x5d, 0x85, 0xdf, 0x4a, 0xbd, 0x56, 0x79, 0xa7, 0x35,
+	0xfe, 0xda, 0x80, 0xce, 0xce, 0xf8, 0xac, 0x4c, 0x7b, 0xa6, 0xf8, 0x27, 0x06, 0xb7, 0x42, 0x4d,
+	0xdb, 0x24, 0x3c, 0xf9, 0xfd, 0x14, 0xff, 0x2a, 0x38, 0x7e, 0xf6, 0x1f, 0xcc, 0xcd, 0xbd, 0xc5,
+	0x93, 0x0f, 0xdf, 0x7f, 0x7c, 0x6e, 0x0c, 0x44, 0x37, 0xbd, 0x78, 0x9e, 0xee, 0x2c, 0xa6, 0x5b,
+	0x8b, 0x69, 0x48, 0x38, 0x61, 0x43, 0xfe, 0x91, 0xc1, 0x51, 0x59, 0xdc, 0xa5, 0x99, 0xa7, 0x7f,
+	0x4a, 0xd4, 0x16, 0x1e, 0x27, 0xfb, 0x89, 0x5b, 0x2b, 0x8f, 0x83, 0x95, 0x47, 0x22, 0xae, 0xb3,
+	0x82, 0x76, 0xc2, 0x86, 0xd3, 0xf6, 0x69, 0x53, 0x5a, 0xbd, 0x38, 0x08, 0xbf, 0xe1, 0x8b, 0x9f,
+	0x03, 0x00, 0xbf, 0x75, 0x59, 0x76, 0xc3, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// JetBrainsServiceClient is the client API for JetBrainsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type JetBrainsServiceClient interface {
+	// StartBackend downloads the backend unless it is installed already and starts it. It returns
+	// once the backend accepts connections or failed to start.
+	StartBackend(ctx context.Context, in *StartJetBrainsBackendRequest, opts ...grpc.CallOption) (*StartJetBrainsBackendResponse, error)
+	// StopBackend stops the backend if it runs.
+	StopBackend(ctx context.Context, in *StopJetBrainsBackendRequest, opts ...grpc.CallOption) (*StopJetBrainsBackendResponse, error)
+}
+
+type jetBrainsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJetBrainsServiceClient(cc grpc.ClientConnInterface) JetBrainsServiceClient {
+	return &jetBrainsServiceClient{cc}
+}
+
+func (c *jetBrainsServiceClient) StartBackend(ctx context.Context, in *StartJetBrainsBackendRequest, opts ...grpc.CallOption) (*StartJetBrainsBackendResponse, error) {
+	out := new(StartJetBrainsBackendResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.JetBrainsService/StartBackend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jetBrainsServiceClient) StopBackend(ctx context.Context, in *StopJetBrainsBackendRequest, opts ...grpc.CallOption) (*StopJetBrainsBackendResponse, error) {
+	out := new(StopJetBrainsBackendResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.JetBrainsService/StopBackend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JetBrainsServiceServer is the server API for JetBrainsService service.
+type JetBrainsServiceServer interface {
+	// StartBackend downloads the backend unless it is installed already and starts it. It returns
+	// once the backend accepts connections or failed to start.
+	StartBackend(context.Context, *StartJetBrainsBackendRequest) (*StartJetBrainsBackendResponse, error)
+	// StopBackend stops the backend if it runs.
+	StopBackend(context.Context, *StopJetBrainsBackendRequest) (*StopJetBrainsBackendResponse, error)
+}
+
+// UnimplementedJetBrainsServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedJetBrainsServiceServer struct {
+}
+
+func (*UnimplementedJetBrainsServiceServer) StartBackend(ctx context.Context, req *StartJetBrainsBackendRequest) (*StartJetBrainsBackendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBackend not implemented")
+}
+func (*UnimplementedJetBrainsServiceServer) StopBackend(ctx context.Context, req *StopJetBrainsBackendRequest) (*StopJetBrainsBackendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopBackend not implemented")
+}
+
+func RegisterJetBrainsServiceServer(s *grpc.Server, srv JetBrainsServiceServer) {
+	s.RegisterService(&_JetBrainsService_serviceDesc, srv)
+}
+
+func _JetBrainsService_StartBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartJetBrainsBackendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JetBrainsServiceServer).StartBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.JetBrainsService/StartBackend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JetBrainsServiceServer).StartBackend(ctx, req.(*StartJetBrainsBackendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JetBrainsService_StopBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopJetBrainsBackendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JetBrainsServiceServer).StopBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.JetBrainsService/StopBackend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JetBrainsServiceServer).StopBackend(ctx, req.(*StopJetBrainsBackendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _JetBrainsService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.JetBrainsService",
+	HandlerType: (*JetBrainsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartBackend",
+			Handler:    _JetBrainsService_StartBackend_Handler,
+		},
+		{
+			MethodName: "StopBackend",
+			Handler:    _JetBrainsService_StopBackend_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "jetbrains.proto",
+}
@@ -0,0 +1,591 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: notification.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type NotificationLevel int32
+
+const (
+	NotificationLevel_notification_info    NotificationLevel = 0
+	NotificationLevel_notification_warning NotificationLevel = 1
+	NotificationLevel_notification_error   NotificationLevel = 2
+)
+
+var NotificationLevel_name = map[int32]string{
+	0: "notification_info",
+	1: "notification_warning",
+	2: "notification_error",
+}
+
+var NotificationLevel_value = map[string]int32{
+	"notification_info":    0,
+	"notification_warning": 1,
+	"notification_error":   2,
+}
+
+func (x NotificationLevel) String() string {
+	return proto.EnumName(NotificationLevel_name, int32(x))
+}
+
+func (NotificationLevel) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_736a457d4a5efa07, []int{0}
+}
+
+type NotifyRequest struct {
+	Level   NotificationLevel `protobuf:"varint,1,opt,name=level,proto3,enum=supervisor.NotificationLevel" json:"level,omitempty"`
+	Message string            `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// actions are the labels of the buttons offered to the user, e.g. "Make Public"
+	Actions []string `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	// timeout_seconds is the time after which the default action is taken. If 0, the notification waits
+	// for a response until the call is canceled.
+	TimeoutSeconds uint32 `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// default_action is the response once the timeout elapsed. It must be one of the actions or empty, which
+	// stands for dismissing the notification.
+	DefaultAction        string   `protobuf:"bytes,5,opt,name=default_action,json=defaultAction,proto3" json:"default_action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NotifyRequest) Reset()         { *m = NotifyRequest{} }
+func (m *NotifyRequest) String() string { return proto.CompactTextString(m) }
+func (*NotifyRequest) ProtoMessage()    {}
+func (*NotifyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_736a457d4a5efa07, []int{0}
+}
+
+func (m *NotifyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotifyRequest.Unmarshal(m, b)
+}
+func (m *NotifyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotifyRequest.Marshal(b, m, deterministic)
+}
+func (m *NotifyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotifyRequest.Merge(m, src)
+}
+func (m *NotifyRequest) XXX_Size() int {
+	return xxx_messageInfo_NotifyRequest.Size(m)
+}
+func (m *NotifyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotifyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotifyRequest proto.InternalMessageInfo
+
+func (m *NotifyRequest) GetLevel() NotificationLevel {
+	if m != nil {
+		return m.Level
+	}
+	return NotificationLevel_notification_info
+}
+
+func (m *NotifyRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *NotifyRequest) GetActions() []string {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *NotifyRequest) GetTimeoutSeconds() uint32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+func (m *NotifyRequest) GetDefaultAction() string {
+	if m != nil {
+		return m.DefaultAction
+	}
+	return ""
+}
+
+type NotifyResponse struct {
+	// action is the action the user chose, or empty if the notification was dismissed
+	Action string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	// timed_out is true if no client responded in time and the action is the default action
+	TimedOut             bool     `protobuf:"varint,2,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NotifyResponse) Reset()         { *m = NotifyResponse{} }
+func (m *NotifyResponse) String() string { return proto.CompactTextString(m) }
+func (*NotifyResponse) ProtoMessage()    {}
+func (*NotifyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_736a457d4a5efa07, []int{1}
+}
+
+func (m *NotifyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotifyResponse.Unmarshal(m, b)
+}
+func (m *NotifyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotifyResponse.Marshal(b, m, deterministic)
+}
+func (m *NotifyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotifyResponse.Merge(m, src)
+}
+func (m *NotifyResponse) XXX_Size() int {
+	return xxx_messageInfo_NotifyResponse.Size(m)
+}
+func (m *NotifyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotifyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotifyResponse proto.InternalMessageInfo
+
+func (m *NotifyResponse) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *NotifyResponse) GetTimedOut() bool {
+	if m != nil {
+		return m.TimedOut
+	}
+	return false
+}
+
+type SubscribeNotificationsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeNotificationsRequest) Reset()         { *m = SubscribeNotificationsRequest{} }
+func (m *SubscribeNotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeNotificationsRequest) ProtoMessage()    {}
+func (*SubscribeNotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_736a457d4a5efa07, []int{2}
+}
+
+func (m *SubscribeNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeNotificationsRequest.Unmarshal(m, b)
+}
+func (m *SubscribeNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeNotificationsRequest.Merge(m, src)
+}
+func (m *SubscribeNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeNotificationsRequest.Size(m)
+}
+func (m *SubscribeNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeNotificationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeNotificationsRequest proto.InternalMessageInfo
+
+type SubscribeNotificationsResponse struct {
+	RequestId uint64         `protobuf:"varint,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Request   *NotifyRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	// closed is true once the notification was responded to, e.g. by another client, or timed out.
+	// IDEs should hide the notification then.
+	Closed               bool     `protobuf:"varint,3,opt,name=closed,proto3" json:"closed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeNotificationsResponse) Reset()         { *m = SubscribeNotificationsResponse{} }
+func (m *SubscribeNotificationsResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeNotificationsResponse) ProtoMessage()    {}
+func (*SubscribeNotificationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_736a457d4a5efa07, []int{3}
+}
+
+func (m *SubscribeNotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeNotificationsResponse.Unmarshal(m, b)
+}
+func (m *SubscribeNotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeNotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscribeNotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeNotificationsResponse.Merge(m, src)
+}
+func (m *SubscribeNotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscribeNotificationsResponse.Size(m)
+}
+func (m *SubscribeNotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeNotificationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeNotificationsResponse proto.InternalMessageInfo
+
+func (m *SubscribeNotificationsResponse) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *SubscribeNotificationsResponse) GetRequest() *NotifyRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SubscribeNotificationsResponse) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+type RespondNotificationRequest struct {
+	RequestId uint64 `protobuf:"varint,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// action is the action the user chose, or empty if the user dismissed the notification
+	Action               string   `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespondNotificationRequest) Reset()         { *m = RespondNotificationRequest{} }
+func (m *RespondNotificationRequest) String() string { return proto.CompactTextString(m) }
+func (*RespondNotificationRequest) ProtoMessage()    {}
+func (*RespondNotificationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_736a457d4a5efa07, []int{4}
+}
+
+func (m *RespondNotificationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespondNotificationRequest.Unmarshal(m, b)
+}
+func (m *RespondNotificationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespondNotificationRequest.Marshal(b, m, deterministic)
+}
+func (m *RespondNotificationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespondNotificationRequest.Merge(m, src)
+}
+func (m *RespondNotificationRequest) XXX_Size() int {
+	return xxx_messageInfo_RespondNotificationRequest.Size(m)
+}
+func (m *RespondNotificationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespondNotificationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespondNotificationRequest proto.InternalMessageInfo
+
+func (m *RespondNotificationRequest) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *RespondNotificationRequest) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+type RespondNotificationResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespondNotificationResponse) Reset()         { *m = RespondNotificationResponse{} }
+func (m *RespondNotificationResponse) String() string { return proto.CompactTextString(m) }
+func (*RespondNotificationResponse) ProtoMessage()    {}
+func (*RespondNotificationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_736a457d4a5efa07, []int{5}
+}
+
+func (m *RespondNotificationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespondNotificationResponse.Unmarshal(m, b)
+}
+func (m *RespondNotificationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespondNotificationResponse.Marshal(b, m, deterministic)
+}
+func (m *RespondNotificationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespondNotificationResponse.Merge(m, src)
+}
+func (m *RespondNotificationResponse) XXX_Size() int {
+	return xxx_messageInfo_RespondNotificationResponse.Size(m)
+}
+func (m *RespondNotificationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespondNotificationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespondNotificationResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("supervisor.NotificationLevel", NotificationLevel_name, NotificationLevel_value)
+	proto.RegisterType((*NotifyRequest)(nil), "supervisor.NotifyRequest")
+	proto.RegisterType((*NotifyResponse)(nil), "supervisor.NotifyResponse")
+	proto.RegisterType((*SubscribeNotificationsRequest)(nil), "supervisor.SubscribeNotificationsRequest")
+	proto.RegisterType((*SubscribeNotificationsResponse)(nil), "supervisor.SubscribeNotificationsResponse")
+	proto.RegisterType((*RespondNotificationRequest)(nil), "supervisor.RespondNotificationRequest")
+	proto.RegisterType((*RespondNotificationResponse)(nil), "supervisor.RespondNotificationResponse")
+}
+
+func init() {
+	proto.RegisterFile("notification.proto", fileDescriptor_736a457d4a5efa07)
+}
+
+var fileDescriptor_736a457d4a5efa07 = []byte{
+	// 518 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x41, 0x6f, 0xd3, 0x4c,
+	0x10, 0xfd, 0x36, 0x69, 0x92, 0x66, 0x3e, 0x25, 0xa4, 0x0b, 0xad, 0x8c, 0xd3, 0x40, 0xb4, 0x52,
+	0x69, 0xc8, 0x21, 0x2e, 0xc9, 0xad, 0x37, 0x90, 0x38, 0x20, 0xa1, 0x22, 0x39, 0x37, 0x54, 0xc9,
+	0x72, 0xec, 0x4d, 0xb4, 0x92, 0xeb, 0x35, 0xbb, 0xeb, 0x20, 0x84, 0xb8, 0x70, 0xe2, 0x50, 0x4e,
+	0xfc, 0x29, 0xee, 0xfc, 0x05, 0x7e, 0x08, 0xf2, 0xee, 0x9a, 0xb8, 0xa4, 0xa1, 0x1c, 0x67, 0xf6,
+	0xcd, 0xbc, 0x37, 0x6f, 0x46, 0x0b, 0x38, 0xe5, 0x8a, 0x2d, 0x59, 0x14, 0x2a, 0xc6, 0xd3, 0x49,
+	0x26, 0xb8, 0xe2, 0x18, 0x64, 0x9e, 0x51, 0xb1, 0x66, 0x92, 0x0b, 0xf7, 0x78, 0xc5, 0xf9, 0x2a,
+	0xa1, 0x5e, 0x98, 0x31, 0x2f, 0x4c, 0x53, 0xae, 0x34, 0x50, 0x1a, 0x24, 0xf9, 0x8e, 0xa0, 0x73,
+	0x51, 0x34, 0xf8, 0xe0, 0xd3, 0x77, 0x39, 0x95, 0x0a, 0xcf, 0xa0, 0x91, 0xd0, 0x35, 0x4d, 0x1c,
+	0x34, 0x44, 0xa3, 0xee, 0x74, 0x30, 0xd9, 0xf4, 0x9a, 0x5c, 0x54, 0xa8, 0x5e, 0x17, 0x20, 0xdf,
+	0x60, 0xb1, 0x03, 0xad, 0x2b, 0x2a, 0x65, 0xb8, 0xa2, 0x4e, 0x6d, 0x88, 0x46, 0x6d, 0xbf, 0x0c,
+	0x8b, 0x97, 0x30, 0xd2, 0x8c, 0x4e, 0x7d, 0x58, 0x2f, 0x5e, 0x6c, 0x88, 0x4f, 0xe1, 0x9e, 0x62,
+	0x57, 0x94, 0xe7, 0x2a, 0x90, 0x34, 0xe2, 0x69, 0x2c, 0x9d, 0xbd, 0x21, 0x1a, 0x75, 0xfc, 0xae,
+	0x4d, 0xcf, 0x4d, 0x16, 0x9f, 0x40, 0x37, 0xa6, 0xcb, 0x30, 0x4f, 0x54, 0x60, 0x6a, 0x9d, 0x86,
+	0xe6, 0xe8, 0xd8, 0xec, 0x73, 0x9d, 0x24, 0x2f, 0xa1, 0x5b, 0x4e, 0x22, 0x33, 0x9e, 0x4a, 0x8a,
+	0x8f, 0xa0, 0x69, 0x0b, 0x90, 0x2e, 0xb0, 0x11, 0xee, 0x43, 0xbb, 0xa0, 0x88, 0x03, 0x9e, 0x2b,
+	0xad, 0x77, 0xdf, 0xdf, 0xd7, 0x89, 0x37, 0xb9, 0x22, 0x8f, 0x61, 0x30, 0xcf, 0x17, 0x32, 0x12,
+	0x6c, 0x41, 0xab, 0xf3, 0x4a, 0x6b, 0x10, 0xb9, 0x46, 0xf0, 0x68, 0x17, 0xc2, 0x12, 0x0f, 0x00,
+	0x84, 0x41, 0x07, 0x2c, 0xd6, 0xe4, 0x7b, 0x7e, 0xdb, 0x66, 0x5e, 0xc5, 0x78, 0x06, 0x2d, 0x1b,
+	0x68, 0xf6, 0xff, 0xa7, 0x0f, 0xb7, 0x4c, 0x2e, 0xd7, 0xe1, 0x97, 0xc8, 0x62, 0x98, 0x28, 0xe1,
+	0x92, 0xc6, 0x4e, 0x5d, 0x2b, 0xb6, 0x11, 0x99, 0x83, 0x6b, 0x78, 0xe3, 0xaa, 0x96, 0x72, 0x9b,
+	0x77, 0x28, 0xd9, 0x38, 0x54, 0xab, 0x3a, 0x44, 0x06, 0xd0, 0xbf, 0xb5, 0xa9, 0x99, 0x6f, 0x7c,
+	0x09, 0x07, 0x5b, 0xa7, 0x80, 0x0f, 0xe1, 0xa0, 0x7a, 0x8a, 0x01, 0x4b, 0x97, 0xbc, 0xf7, 0x1f,
+	0x76, 0xe0, 0xc1, 0x8d, 0xf4, 0xfb, 0x50, 0xa4, 0x2c, 0x5d, 0xf5, 0x10, 0x3e, 0xba, 0x79, 0xbb,
+	0x01, 0x15, 0x82, 0x8b, 0x5e, 0x6d, 0xfa, 0xb5, 0x0e, 0xf7, 0xab, 0xed, 0xe7, 0x85, 0x33, 0x11,
+	0xc5, 0x97, 0xd0, 0x34, 0xde, 0xe0, 0xdd, 0x7e, 0xb9, 0xee, 0x6d, 0x4f, 0x46, 0x36, 0xe9, 0x7f,
+	0xfe, 0xf1, 0xf3, 0x5b, 0xed, 0x90, 0xf4, 0xbc, 0xf5, 0x33, 0xaf, 0x4a, 0x7d, 0x8e, 0xc6, 0xf8,
+	0x0b, 0x82, 0xf6, 0xef, 0xb5, 0xe2, 0xa7, 0xd5, 0x36, 0x7f, 0xbd, 0x07, 0x77, 0xfc, 0x2f, 0x50,
+	0xab, 0x80, 0x68, 0x05, 0xc7, 0xd8, 0xfd, 0x53, 0x81, 0x27, 0xcb, 0xc2, 0x33, 0x84, 0xaf, 0x11,
+	0xb4, 0xac, 0xfd, 0xf8, 0x49, 0xb5, 0xfb, 0xee, 0x45, 0xbb, 0xa7, 0x77, 0xe2, 0xac, 0x84, 0x33,
+	0x2d, 0x61, 0x4c, 0x4e, 0xb6, 0x24, 0x7c, 0xdc, 0x5c, 0xca, 0x27, 0x4f, 0x98, 0x16, 0xe7, 0x68,
+	0xfc, 0xa2, 0xf1, 0xb6, 0x1e, 0x66, 0x6c, 0xd1, 0xd4, 0x3f, 0xc6, 0xec, 0xd7, 0x00, 0x72, 0xcf,
+	0xe1, 0x0b, 0x71, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// NotificationServiceClient is the client API for NotificationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NotificationServiceClient interface {
+	// Notify shows a notification. If the notification has actions, the call returns once a client responded,
+	// the timeout elapsed or the call was canceled. Otherwise it returns right away.
+	Notify(ctx context.Context, in *NotifyRequest, opts ...grpc.CallOption) (*NotifyResponse, error)
+	// Subscribe streams the notifications to show, starting with those which wait for a response.
+	// It is used by IDEs.
+	Subscribe(ctx context.Context, in *SubscribeNotificationsRequest, opts ...grpc.CallOption) (NotificationService_SubscribeClient, error)
+	// Respond delivers the action the user chose for a notification.
+	Respond(ctx context.Context, in *RespondNotificationRequest, opts ...grpc.CallOption) (*RespondNotificationResponse, error)
+}
+
+type notificationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNotificationServiceClient(cc grpc.ClientConnInterface) NotificationServiceClient {
+	return &notificationServiceClient{cc}
+}
+
+func (c *notificationServiceClient) Notify(ctx context.Context, in *NotifyRequest, opts ...grpc.CallOption) (*NotifyResponse, error) {
+	out := new(NotifyResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.NotificationService/Notify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) Subscribe(ctx context.Context, in *SubscribeNotificationsRequest, opts ...grpc.CallOption) (NotificationService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NotificationService_serviceDesc.Streams[0], "/supervisor.NotificationService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &notificationServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NotificationService_SubscribeClient interface {
+	Recv() (*SubscribeNotificationsResponse, error)
+	grpc.ClientStream
+}
+
+type notificationServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *notificationServiceSubscribeClient) Recv() (*SubscribeNotificationsResponse, error) {
+	m := new(SubscribeNotificationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *notificationServiceClient) Respond(ctx context.Context, in *RespondNotificationRequest, opts ...grpc.CallOption) (*RespondNotificationResponse, error) {
+	out := new(RespondNotificationResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.NotificationService/Respond", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationServiceServer is the server API for NotificationService service.
+type NotificationServiceServer interface {
+	// Notify shows a notification. If the notification has actions, the call returns once a client responded,
+	// the timeout elapsed or the call was canceled. Otherwise it returns right away.
+	Notify(context.Context, *NotifyRequest) (*NotifyResponse, error)
+	// Subscribe streams the notifications to show, starting with those which wait for a response.
+	// It is used by IDEs.
+	Subscribe(*SubscribeNotificationsRequest, NotificationService_SubscribeServer) error
+	// Respond delivers the action the user chose for a notification.
+	Respond(context.Context, *RespondNotificationRequest) (*RespondNotificationResponse, error)
+}
+
+// UnimplementedNotificationServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedNotificationServiceServer struct {
+}
+
+func (*UnimplementedNotificationServiceServer) Notify(ctx context.Context, req *NotifyRequest) (*NotifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Notify not implemented")
+}
+func (*UnimplementedNotificationServiceServer) Subscribe(req *SubscribeNotificationsRequest, srv NotificationService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (*UnimplementedNotificationServiceServer) Respond(ctx context.Context, req *RespondNotificationRequest) (*RespondNotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Respond not implemented")
+}
+
+func RegisterNotificationServiceServer(s *grpc.Server, srv NotificationServiceServer) {
+	s.RegisterService(&_NotificationService_serviceDesc, srv)
+}
+
+func _NotificationService_Notify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).Notify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.NotificationService/Notify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).Notify(ctx, req.(*NotifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NotificationServiceServer).Subscribe(m, &notificationServiceSubscribeServer{stream})
+}
+
+type NotificationService_SubscribeServer interface {
+	Send(*SubscribeNotificationsResponse) error
+	grpc.ServerStream
+}
+
+type notificationServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *notificationServiceSubscribeServer) Send(m *SubscribeNotificationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NotificationService_Respond_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).Respond(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.NotificationService/Respond",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).Respond(ctx, req.(*RespondNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _NotificationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.NotificationService",
+	HandlerType: (*NotificationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Notify",
+			Handler:    _NotificationService_Notify_Handler,
+		},
+		{
+			MethodName: "Respond",
+			Handler:    _NotificationService_Respond_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _NotificationService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "notification.proto",
+}
@@ -0,0 +1,686 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: opener.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type OpenTarget int32
+
+const (
+	// open_target_default lets the IDE choose, e.g. an editor for files and a browser for URLs
+	OpenTarget_open_target_default  OpenTarget = 0
+	OpenTarget_open_target_editor   OpenTarget = 1
+	OpenTarget_open_target_preview  OpenTarget = 2
+	OpenTarget_open_target_external OpenTarget = 3
+)
+
+var OpenTarget_name = map[int32]string{
+	0: "open_target_default",
+	1: "open_target_editor",
+	2: "open_target_preview",
+	3: "open_target_external",
+}
+
+var OpenTarget_value = map[string]int32{
+	"open_target_default":  0,
+	"open_target_editor":   1,
+	"open_target_preview":  2,
+	"open_target_external": 3,
+}
+
+func (x OpenTarget) String() string {
+	return proto.EnumName(OpenTarget_name, int32(x))
+}
+
+func (OpenTarget) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fd2dd0ff091ab60b, []int{0}
+}
+
+type OpenRequest struct {
+	// uri is the file or URL to open, e.g. file:///workspace/README.md or https://8080-workspace-url
+	Uri                  string     `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Target               OpenTarget `protobuf:"varint,2,opt,name=target,proto3,enum=supervisor.OpenTarget" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *OpenRequest) Reset()         { *m = OpenRequest{} }
+func (m *OpenRequest) String() string { return proto.CompactTextString(m) }
+func (*OpenRequest) ProtoMessage()    {}
+func (*OpenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd2dd0ff091ab60b, []int{0}
+}
+
+func (m *OpenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenRequest.Unmarshal(m, b)
+}
+func (m *OpenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenRequest.Marshal(b, m, deterministic)
+}
+func (m *OpenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenRequest.Merge(m, src)
+}
+func (m *OpenRequest) XXX_Size() int {
+	return xxx_messageInfo_OpenRequest.Size(m)
+}
+func (m *OpenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenRequest proto.InternalMessageInfo
+
+func (m *OpenRequest) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+func (m *OpenRequest) GetTarget() OpenTarget {
+	if m != nil {
+		return m.Target
+	}
+	return OpenTarget_open_target_default
+}
+
+type OpenResponse struct {
+	// handled_by is the client name of the IDE which opened the URI, or empty if the user was notified instead
+	HandledBy string `protobuf:"bytes,1,opt,name=handled_by,json=handledBy,proto3" json:"handled_by,omitempty"`
+	// notified is true if no IDE handled the request and the user was notified instead
+	Notified             bool     `protobuf:"varint,2,opt,name=notified,proto3" json:"notified,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenResponse) Reset()         { *m = OpenResponse{} }
+func (m *OpenResponse) String() string { return proto.CompactTextString(m) }
+func (*OpenResponse) ProtoMessage()    {}
+func (*OpenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd2dd0ff091ab60b, []int{1}
+}
+
+func (m *OpenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenResponse.Unmarshal(m, b)
+}
+func (m *OpenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenResponse.Marshal(b, m, deterministic)
+}
+func (m *OpenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenResponse.Merge(m, src)
+}
+func (m *OpenResponse) XXX_Size() int {
+	return xxx_messageInfo_OpenResponse.Size(m)
+}
+func (m *OpenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenResponse proto.InternalMessageInfo
+
+func (m *OpenResponse) GetHandledBy() string {
+	if m != nil {
+		return m.HandledBy
+	}
+	return ""
+}
+
+func (m *OpenResponse) GetNotified() bool {
+	if m != nil {
+		return m.Notified
+	}
+	return false
+}
+
+type SubscribeOpenRequest struct {
+	// client_name identifies the IDE, e.g. "vscode-desktop"
+	ClientName           string   `protobuf:"bytes,1,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeOpenRequest) Reset()         { *m = SubscribeOpenRequest{} }
+func (m *SubscribeOpenRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeOpenRequest) ProtoMessage()    {}
+func (*SubscribeOpenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd2dd0ff091ab60b, []int{2}
+}
+
+func (m *SubscribeOpenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeOpenRequest.Unmarshal(m, b)
+}
+func (m *SubscribeOpenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeOpenRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeOpenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeOpenRequest.Merge(m, src)
+}
+func (m *SubscribeOpenRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeOpenRequest.Size(m)
+}
+func (m *SubscribeOpenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeOpenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeOpenRequest proto.InternalMessageInfo
+
+func (m *SubscribeOpenRequest) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
+
+type SubscribeOpenResponse struct {
+	// handler_id is set in the first message only
+	HandlerId            string       `protobuf:"bytes,1,opt,name=handler_id,json=handlerId,proto3" json:"handler_id,omitempty"`
+	RequestId            uint64       `protobuf:"varint,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Request              *OpenRequest `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SubscribeOpenResponse) Reset()         { *m = SubscribeOpenResponse{} }
+func (m *SubscribeOpenResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeOpenResponse) ProtoMessage()    {}
+func (*SubscribeOpenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd2dd0ff091ab60b, []int{3}
+}
+
+func (m *SubscribeOpenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeOpenResponse.Unmarshal(m, b)
+}
+func (m *SubscribeOpenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeOpenResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscribeOpenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeOpenResponse.Merge(m, src)
+}
+func (m *SubscribeOpenResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscribeOpenResponse.Size(m)
+}
+func (m *SubscribeOpenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeOpenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeOpenResponse proto.InternalMessageInfo
+
+func (m *SubscribeOpenResponse) GetHandlerId() string {
+	if m != nil {
+		return m.HandlerId
+	}
+	return ""
+}
+
+func (m *SubscribeOpenResponse) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *SubscribeOpenResponse) GetRequest() *OpenRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+type FocusOpenHandlerRequest struct {
+	HandlerId            string   `protobuf:"bytes,1,opt,name=handler_id,json=handlerId,proto3" json:"handler_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FocusOpenHandlerRequest) Reset()         { *m = FocusOpenHandlerRequest{} }
+func (m *FocusOpenHandlerRequest) String() string { return proto.CompactTextString(m) }
+func (*FocusOpenHandlerRequest) ProtoMessage()    {}
+func (*FocusOpenHandlerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd2dd0ff091ab60b, []int{4}
+}
+
+func (m *FocusOpenHandlerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FocusOpenHandlerRequest.Unmarshal(m, b)
+}
+func (m *FocusOpenHandlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FocusOpenHandlerRequest.Marshal(b, m, deterministic)
+}
+func (m *FocusOpenHandlerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FocusOpenHandlerRequest.Merge(m, src)
+}
+func (m *FocusOpenHandlerRequest) XXX_Size() int {
+	return xxx_messageInfo_FocusOpenHandlerRequest.Size(m)
+}
+func (m *FocusOpenHandlerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FocusOpenHandlerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FocusOpenHandlerRequest proto.InternalMessageInfo
+
+func (m *FocusOpenHandlerRequest) GetHandlerId() string {
+	if m != nil {
+		return m.HandlerId
+	}
+	return ""
+}
+
+type FocusOpenHandlerResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FocusOpenHandlerResponse) Reset()         { *m = FocusOpenHandlerResponse{} }
+func (m *FocusOpenHandlerResponse) String() string { return proto.CompactTextString(m) }
+func (*FocusOpenHandlerResponse) ProtoMessage()    {}
+func (*FocusOpenHandlerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd2dd0ff091ab60b, []int{5}
+}
+
+func (m *FocusOpenHandlerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FocusOpenHandlerResponse.Unmarshal(m, b)
+}
+func (m *FocusOpenHandlerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FocusOpenHandlerResponse.Marshal(b, m, deterministic)
+}
+func (m *FocusOpenHandlerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FocusOpenHandlerResponse.Merge(m, src)
+}
+func (m *FocusOpenHandlerResponse) XXX_Size() int {
+	return xxx_messageInfo_FocusOpenHandlerResponse.Size(m)
+}
+func (m *FocusOpenHandlerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FocusOpenHandlerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FocusOpenHandlerResponse proto.InternalMessageInfo
+
+type RespondOpenRequest struct {
+	RequestId uint64 `protobuf:"varint,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// handled is false if the IDE cannot open the URI, in which case the next IDE is asked
+	Handled              bool     `protobuf:"varint,2,opt,name=handled,proto3" json:"handled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespondOpenRequest) Reset()         { *m = RespondOpenRequest{} }
+func (m *RespondOpenRequest) String() string { return proto.CompactTextString(m) }
+func (*RespondOpenRequest) ProtoMessage()    {}
+func (*RespondOpenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd2dd0ff091ab60b, []int{6}
+}
+
+func (m *RespondOpenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespondOpenRequest.Unmarshal(m, b)
+}
+func (m *RespondOpenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespondOpenRequest.Marshal(b, m, deterministic)
+}
+func (m *RespondOpenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespondOpenRequest.Merge(m, src)
+}
+func (m *RespondOpenRequest) XXX_Size() int {
+	return xxx_messageInfo_RespondOpenRequest.Size(m)
+}
+func (m *RespondOpenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespondOpenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespondOpenRequest proto.InternalMessageInfo
+
+func (m *RespondOpenRequest) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *RespondOpenRequest) GetHandled() bool {
+	if m != nil {
+		return m.Handled
+	}
+	return false
+}
+
+type RespondOpenResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespondOpenResponse) Reset()         { *m = RespondOpenResponse{} }
+func (m *RespondOpenResponse) String() string { return proto.CompactTextString(m) }
+func (*RespondOpenResponse) ProtoMessage()    {}
+func (*RespondOpenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd2dd0ff091ab60b, []int{7}
+}
+
+func (m *RespondOpenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespondOpenResponse.Unmarshal(m, b)
+}
+func (m *RespondOpenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespondOpenResponse.Marshal(b, m, deterministic)
+}
+func (m *RespondOpenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespondOpenResponse.Merge(m, src)
+}
+func (m *RespondOpenResponse) XXX_Size() int {
+	return xxx_messageInfo_RespondOpenResponse.Size(m)
+}
+func (m *RespondOpenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespondOpenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespondOpenResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("supervisor.OpenTarget", OpenTarget_name, OpenTarget_value)
+	proto.RegisterType((*OpenRequest)(nil), "supervisor.OpenRequest")
+	proto.RegisterType((*OpenResponse)(nil), "supervisor.OpenResponse")
+	proto.RegisterType((*SubscribeOpenRequest)(nil), "supervisor.SubscribeOpenRequest")
+	proto.RegisterType((*SubscribeOpenResponse)(nil), "supervisor.SubscribeOpenResponse")
+	proto.RegisterType((*FocusOpenHandlerRequest)(nil), "supervisor.FocusOpenHandlerRequest")
+	proto.RegisterType((*FocusOpenHandlerResponse)(nil), "supervisor.FocusOpenHandlerResponse")
+	proto.RegisterType((*RespondOpenRequest)(nil), "supervisor.RespondOpenRequest")
+	proto.RegisterType((*RespondOpenResponse)(nil), "supervisor.RespondOpenResponse")
+}
+
+func init() {
+	proto.RegisterFile("opener.proto", fileDescriptor_fd2dd0ff091ab60b)
+}
+
+var fileDescriptor_fd2dd0ff091ab60b = []byte{
+	// 541 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x94, 0x4f, 0x6f, 0x12, 0x41,
+	0x18, 0xc6, 0x5d, 0x68, 0x0b, 0xbc, 0xb4, 0x86, 0x0c, 0xb4, 0x6c, 0x36, 0xd6, 0xe2, 0x54, 0x13,
+	0x82, 0x09, 0x6b, 0xf1, 0xa0, 0xe9, 0xb1, 0x07, 0x23, 0x07, 0x4b, 0xb2, 0xf5, 0xe4, 0x65, 0xb3,
+	0xb0, 0x2f, 0x38, 0x09, 0x9d, 0x59, 0x67, 0x67, 0xd1, 0xa6, 0x69, 0x4c, 0x3c, 0x79, 0xf7, 0x3b,
+	0xf9, 0x05, 0xfc, 0x0a, 0x7e, 0x10, 0xb3, 0x3b, 0x03, 0x2c, 0x20, 0xdc, 0x76, 0xe6, 0xfd, 0xf3,
+	0x7b, 0xe6, 0x7d, 0x9f, 0x2c, 0x1c, 0x8a, 0x08, 0x39, 0xca, 0x6e, 0x24, 0x85, 0x12, 0x04, 0xe2,
+	0x24, 0x42, 0x39, 0x63, 0xb1, 0x90, 0xce, 0x93, 0x89, 0x10, 0x93, 0x29, 0xba, 0x41, 0xc4, 0xdc,
+	0x80, 0x73, 0xa1, 0x02, 0xc5, 0x04, 0x8f, 0x75, 0x26, 0x1d, 0x40, 0x75, 0x10, 0x21, 0xf7, 0xf0,
+	0x4b, 0x82, 0xb1, 0x22, 0x35, 0x28, 0x26, 0x92, 0xd9, 0x56, 0xcb, 0x6a, 0x57, 0xbc, 0xf4, 0x93,
+	0x74, 0xe1, 0x40, 0x05, 0x72, 0x82, 0xca, 0x2e, 0xb4, 0xac, 0xf6, 0xe3, 0xde, 0x49, 0x77, 0xd9,
+	0xbb, 0x9b, 0x96, 0x7e, 0xcc, 0xa2, 0x9e, 0xc9, 0xa2, 0x7d, 0x38, 0xd4, 0x0d, 0xe3, 0x48, 0xf0,
+	0x18, 0xc9, 0x29, 0xc0, 0xe7, 0x80, 0x87, 0x53, 0x0c, 0xfd, 0xe1, 0x9d, 0x69, 0x5c, 0x31, 0x37,
+	0x57, 0x77, 0xc4, 0x81, 0x32, 0x17, 0x8a, 0x8d, 0x19, 0x86, 0x19, 0xa0, 0xec, 0x2d, 0xce, 0xf4,
+	0x0d, 0x34, 0x6e, 0x92, 0x61, 0x3c, 0x92, 0x6c, 0x88, 0x79, 0x91, 0x67, 0x50, 0x1d, 0x4d, 0x19,
+	0x72, 0xe5, 0xf3, 0xe0, 0x16, 0x4d, 0x4f, 0xd0, 0x57, 0xd7, 0xc1, 0x2d, 0xd2, 0x9f, 0x16, 0x1c,
+	0xaf, 0x55, 0xae, 0xab, 0x91, 0x3e, 0x0b, 0x57, 0xd5, 0xc8, 0x7e, 0x98, 0x86, 0xa5, 0x86, 0xf8,
+	0x4c, 0xeb, 0xd9, 0xf3, 0x2a, 0xe6, 0xa6, 0x1f, 0x92, 0x0b, 0x28, 0x99, 0x83, 0x5d, 0x6c, 0x59,
+	0xed, 0x6a, 0xaf, 0xb9, 0x3e, 0x0c, 0x23, 0xd1, 0x9b, 0xe7, 0xd1, 0xb7, 0xd0, 0x7c, 0x27, 0x46,
+	0x49, 0x9c, 0x06, 0xdf, 0x6b, 0xce, 0xfc, 0x19, 0xbb, 0xb5, 0x50, 0x07, 0xec, 0xcd, 0x4a, 0xfd,
+	0x0c, 0xfa, 0x01, 0x88, 0xfe, 0x0e, 0xf3, 0x73, 0x59, 0x55, 0x6f, 0xad, 0xab, 0xb7, 0xa1, 0x64,
+	0xe6, 0x6e, 0x26, 0x3d, 0x3f, 0xd2, 0x63, 0xa8, 0xaf, 0xb4, 0xd3, 0x94, 0x4e, 0x04, 0xb0, 0x5c,
+	0x30, 0x69, 0x42, 0x3d, 0xf5, 0x98, 0xaf, 0xf7, 0xec, 0x87, 0x38, 0x0e, 0x92, 0xa9, 0xaa, 0x3d,
+	0x22, 0x27, 0x40, 0xf2, 0x01, 0x0c, 0x99, 0x12, 0xb2, 0x66, 0xad, 0x17, 0x44, 0x12, 0x67, 0x0c,
+	0xbf, 0xd6, 0x0a, 0xc4, 0x86, 0xc6, 0x4a, 0xc1, 0x37, 0x85, 0x92, 0x07, 0xd3, 0x5a, 0xb1, 0xf7,
+	0xbb, 0x08, 0x47, 0x83, 0xcc, 0xc8, 0x37, 0xe9, 0x54, 0x47, 0x48, 0xae, 0x61, 0x2f, 0xbd, 0x20,
+	0xdb, 0x26, 0xed, 0xd8, 0x9b, 0x01, 0x33, 0xa4, 0xfa, 0x8f, 0x3f, 0x7f, 0x7f, 0x15, 0x8e, 0x68,
+	0xd9, 0x9d, 0x5d, 0xb8, 0x29, 0xf0, 0xd2, 0xea, 0x10, 0x0e, 0x95, 0x85, 0x33, 0x48, 0x2b, 0x5f,
+	0xfb, 0x3f, 0xab, 0x39, 0xcf, 0x76, 0x64, 0x18, 0x8c, 0x93, 0x61, 0x1a, 0x84, 0xcc, 0x31, 0x6e,
+	0x3c, 0xcf, 0x7b, 0x65, 0x91, 0xef, 0xb0, 0x9f, 0x6d, 0x91, 0x9c, 0xe7, 0x3b, 0x6d, 0xb1, 0x84,
+	0xf3, 0x7c, 0x77, 0x92, 0x21, 0xbe, 0xcc, 0x88, 0x2f, 0xe8, 0xf9, 0x82, 0x68, 0x5c, 0xe3, 0xde,
+	0x2f, 0x0d, 0xf5, 0xe0, 0x8e, 0x33, 0xae, 0x82, 0x92, 0xd9, 0x2d, 0x79, 0x9a, 0xef, 0xbe, 0xe9,
+	0x1f, 0xe7, 0x6c, 0x6b, 0xdc, 0x80, 0xdb, 0x19, 0x98, 0xd2, 0xd3, 0x05, 0xf8, 0x7e, 0x69, 0xb8,
+	0x07, 0x57, 0xea, 0x92, 0x4b, 0xab, 0x73, 0xb5, 0xff, 0xa9, 0x18, 0x44, 0x6c, 0x78, 0x90, 0xfd,
+	0x64, 0x5e, 0xff, 0x1b, 0x00, 0x55, 0xdb, 0x6a, 0x09, 0x9e, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// OpenerServiceClient is the client API for OpenerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OpenerServiceClient interface {
+	// Open opens the URI in the IDE which was focused last. If that IDE does not handle the request, the other IDEs
+	// are asked in turn. If no IDE handles the request, the user is notified instead.
+	Open(ctx context.Context, in *OpenRequest, opts ...grpc.CallOption) (*OpenResponse, error)
+	// Subscribe streams the open requests to handle, starting with a message which carries the id of the handler.
+	// It is used by IDEs.
+	Subscribe(ctx context.Context, in *SubscribeOpenRequest, opts ...grpc.CallOption) (OpenerService_SubscribeClient, error)
+	// Focus marks a handler as the one the user works in, s.t. it is asked first.
+	Focus(ctx context.Context, in *FocusOpenHandlerRequest, opts ...grpc.CallOption) (*FocusOpenHandlerResponse, error)
+	// Respond reports whether an IDE handled an open request.
+	Respond(ctx context.Context, in *RespondOpenRequest, opts ...grpc.CallOption) (*RespondOpenResponse, error)
+}
+
+type openerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOpenerServiceClient(cc grpc.ClientConnInterface) OpenerServiceClient {
+	return &openerServiceClient{cc}
+}
+
+func (c *openerServiceClient) Open(ctx context.Context, in *OpenRequest, opts ...grpc.CallOption) (*OpenResponse, error) {
+	out := new(OpenResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.OpenerService/Open", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openerServiceClient) Subscribe(ctx context.Context, in *SubscribeOpenRequest, opts ...grpc.CallOption) (OpenerService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_OpenerService_serviceDesc.Streams[0], "/supervisor.OpenerService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &openerServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OpenerService_SubscribeClient interface {
+	Recv() (*SubscribeOpenResponse, error)
+	grpc.ClientStream
+}
+
+type openerServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *openerServiceSubscribeClient) Recv() (*SubscribeOpenResponse, error) {
+	m := new(SubscribeOpenResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *openerServiceClient) Focus(ctx context.Context, in *FocusOpenHandlerRequest, opts ...grpc.CallOption) (*FocusOpenHandlerResponse, error) {
+	out := new(FocusOpenHandlerResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.OpenerService/Focus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openerServiceClient) Respond(ctx context.Context, in *RespondOpenRequest, opts ...grpc.CallOption) (*RespondOpenResponse, error) {
+	out := new(RespondOpenResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.OpenerService/Respond", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OpenerServiceServer is the server API for OpenerService service.
+type OpenerServiceServer interface {
+	// Open opens the URI in the IDE which was focused last. If that IDE does not handle the request, the other IDEs
+	// are asked in turn. If no IDE handles the request, the user is notified instead.
+	Open(context.Context, *OpenRequest) (*OpenResponse, error)
+	// Subscribe streams the open requests to handle, starting with a message which carries the id of the handler.
+	// It is used by IDEs.
+	Subscribe(*SubscribeOpenRequest, OpenerService_SubscribeServer) error
+	// Focus marks a handler as the one the user works in, s.t. it is asked first.
+	Focus(context.Context, *FocusOpenHandlerRequest) (*FocusOpenHandlerResponse, error)
+	// Respond reports whether an IDE handled an open request.
+	Respond(context.Context, *RespondOpenRequest) (*RespondOpenResponse, error)
+}
+
+// UnimplementedOpenerServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedOpenerServiceServer struct {
+}
+
+func (*UnimplementedOpenerServiceServer) Open(ctx context.Context, req *OpenRequest) (*OpenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Open not implemented")
+}
+func (*UnimplementedOpenerServiceServer) Subscribe(req *SubscribeOpenRequest, srv OpenerService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (*UnimplementedOpenerServiceServer) Focus(ctx context.Context, req *FocusOpenHandlerRequest) (*FocusOpenHandlerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Focus not implemented")
+}
+func (*UnimplementedOpenerServiceServer) Respond(ctx context.Context, req *RespondOpenRequest) (*RespondOpenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Respond not implemented")
+}
+
+func RegisterOpenerServiceServer(s *grpc.Server, srv OpenerServiceServer) {
+	s.RegisterService(&_OpenerService_serviceDesc, srv)
+}
+
+func _OpenerService_Open_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenerServiceServer).Open(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.OpenerService/Open",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenerServiceServer).Open(ctx, req.(*OpenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenerService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeOpenRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OpenerServiceServer).Subscribe(m, &openerServiceSubscribeServer{stream})
+}
+
+type OpenerService_SubscribeServer interface {
+	Send(*SubscribeOpenResponse) error
+	grpc.ServerStream
+}
+
+type openerServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *openerServiceSubscribeServer) Send(m *SubscribeOpenResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _OpenerService_Focus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FocusOpenHandlerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenerServiceServer).Focus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.OpenerService/Focus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenerServiceServer).Focus(ctx, req.(*FocusOpenHandlerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenerService_Respond_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondOpenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenerServiceServer).Respond(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.OpenerService/Respond",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenerServiceServer).Respond(ctx, req.(*RespondOpenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OpenerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.OpenerService",
+	HandlerType: (*OpenerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Open",
+			Handler:    _OpenerService_Open_Handler,
+		},
+		{
+			MethodName: "Focus",
+			Handler:    _OpenerService_Focus_Handler,
+		},
+		{
+			MethodName: "Respond",
+			Handler:    _OpenerService_Respond_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _OpenerService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "opener.proto",
+}
@@ -0,0 +1,903 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: port.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type TunnelRequest struct {
+	// Types that are valid to be assigned to Message:
+	//	*TunnelRequest_Open
+	//	*TunnelRequest_Data
+	Message              isTunnelRequest_Message `protobuf_oneof:"message"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *TunnelRequest) Reset()         { *m = TunnelRequest{} }
+func (m *TunnelRequest) String() string { return proto.CompactTextString(m) }
+func (*TunnelRequest) ProtoMessage()    {}
+func (*TunnelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{0}
+}
+
+func (m *TunnelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TunnelRequest.Unmarshal(m, b)
+}
+func (m *TunnelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TunnelRequest.Marshal(b, m, deterministic)
+}
+func (m *TunnelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TunnelRequest.Merge(m, src)
+}
+func (m *TunnelRequest) XXX_Size() int {
+	return xxx_messageInfo_TunnelRequest.Size(m)
+}
+func (m *TunnelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TunnelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TunnelRequest proto.InternalMessageInfo
+
+type isTunnelRequest_Message interface {
+	isTunnelRequest_Message()
+}
+
+type TunnelRequest_Open struct {
+	Open *TunnelOpen `protobuf:"bytes,1,opt,name=open,proto3,oneof"`
+}
+
+type TunnelRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*TunnelRequest_Open) isTunnelRequest_Message() {}
+
+func (*TunnelRequest_Data) isTunnelRequest_Message() {}
+
+func (m *TunnelRequest) GetMessage() isTunnelRequest_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *TunnelRequest) GetOpen() *TunnelOpen {
+	if x, ok := m.GetMessage().(*TunnelRequest_Open); ok {
+		return x.Open
+	}
+	return nil
+}
+
+func (m *TunnelRequest) GetData() []byte {
+	if x, ok := m.GetMessage().(*TunnelRequest_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TunnelRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TunnelRequest_Open)(nil),
+		(*TunnelRequest_Data)(nil),
+	}
+}
+
+type TunnelOpen struct {
+	// port is the local port to connect to in the workspace
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// client identifies the tunneling client (e.g. "vscode-desktop")
+	Client               string   `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TunnelOpen) Reset()         { *m = TunnelOpen{} }
+func (m *TunnelOpen) String() string { return proto.CompactTextString(m) }
+func (*TunnelOpen) ProtoMessage()    {}
+func (*TunnelOpen) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{1}
+}
+
+func (m *TunnelOpen) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TunnelOpen.Unmarshal(m, b)
+}
+func (m *TunnelOpen) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TunnelOpen.Marshal(b, m, deterministic)
+}
+func (m *TunnelOpen) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TunnelOpen.Merge(m, src)
+}
+func (m *TunnelOpen) XXX_Size() int {
+	return xxx_messageInfo_TunnelOpen.Size(m)
+}
+func (m *TunnelOpen) XXX_DiscardUnknown() {
+	xxx_messageInfo_TunnelOpen.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TunnelOpen proto.InternalMessageInfo
+
+func (m *TunnelOpen) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *TunnelOpen) GetClient() string {
+	if m != nil {
+		return m.Client
+	}
+	return ""
+}
+
+type TunnelResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TunnelResponse) Reset()         { *m = TunnelResponse{} }
+func (m *TunnelResponse) String() string { return proto.CompactTextString(m) }
+func (*TunnelResponse) ProtoMessage()    {}
+func (*TunnelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{2}
+}
+
+func (m *TunnelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TunnelResponse.Unmarshal(m, b)
+}
+func (m *TunnelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TunnelResponse.Marshal(b, m, deterministic)
+}
+func (m *TunnelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TunnelResponse.Merge(m, src)
+}
+func (m *TunnelResponse) XXX_Size() int {
+	return xxx_messageInfo_TunnelResponse.Size(m)
+}
+func (m *TunnelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TunnelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TunnelResponse proto.InternalMessageInfo
+
+func (m *TunnelResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ResolvePortRequest struct {
+	// name is the name of a configured port (e.g. "api") or a port number (e.g. "3000")
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolvePortRequest) Reset()         { *m = ResolvePortRequest{} }
+func (m *ResolvePortRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolvePortRequest) ProtoMessage()    {}
+func (*ResolvePortRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{3}
+}
+
+func (m *ResolvePortRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolvePortRequest.Unmarshal(m, b)
+}
+func (m *ResolvePortRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolvePortRequest.Marshal(b, m, deterministic)
+}
+func (m *ResolvePortRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolvePortRequest.Merge(m, src)
+}
+func (m *ResolvePortRequest) XXX_Size() int {
+	return xxx_messageInfo_ResolvePortRequest.Size(m)
+}
+func (m *ResolvePortRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolvePortRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolvePortRequest proto.InternalMessageInfo
+
+func (m *ResolvePortRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ResolvePortResponse struct {
+	// local_port is the port the service is served on in the workspace
+	LocalPort uint32 `protobuf:"varint,1,opt,name=local_port,json=localPort,proto3" json:"local_port,omitempty"`
+	// global_port is the port the service is reachable on from outside the container, i.e. the port
+	// of the proxy if the service listens on localhost only. Zero if the port is neither served nor exposed.
+	GlobalPort uint32 `protobuf:"varint,2,opt,name=global_port,json=globalPort,proto3" json:"global_port,omitempty"`
+	// name is the configured name of the port, if any
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// url is the URL the port is exposed at. Empty if the port is not exposed.
+	Url                  string   `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolvePortResponse) Reset()         { *m = ResolvePortResponse{} }
+func (m *ResolvePortResponse) String() string { return proto.CompactTextString(m) }
+func (*ResolvePortResponse) ProtoMessage()    {}
+func (*ResolvePortResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{4}
+}
+
+func (m *ResolvePortResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolvePortResponse.Unmarshal(m, b)
+}
+func (m *ResolvePortResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolvePortResponse.Marshal(b, m, deterministic)
+}
+func (m *ResolvePortResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolvePortResponse.Merge(m, src)
+}
+func (m *ResolvePortResponse) XXX_Size() int {
+	return xxx_messageInfo_ResolvePortResponse.Size(m)
+}
+func (m *ResolvePortResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolvePortResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolvePortResponse proto.InternalMessageInfo
+
+func (m *ResolvePortResponse) GetLocalPort() uint32 {
+	if m != nil {
+		return m.LocalPort
+	}
+	return 0
+}
+
+func (m *ResolvePortResponse) GetGlobalPort() uint32 {
+	if m != nil {
+		return m.GlobalPort
+	}
+	return 0
+}
+
+func (m *ResolvePortResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ResolvePortResponse) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+type ListPortsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPortsRequest) Reset()         { *m = ListPortsRequest{} }
+func (m *ListPortsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPortsRequest) ProtoMessage()    {}
+func (*ListPortsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{5}
+}
+
+func (m *ListPortsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPortsRequest.Unmarshal(m, b)
+}
+func (m *ListPortsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPortsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPortsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPortsRequest.Merge(m, src)
+}
+func (m *ListPortsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPortsRequest.Size(m)
+}
+func (m *ListPortsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPortsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPortsRequest proto.InternalMessageInfo
+
+type ListPortsResponse struct {
+	Ports                []*PortsStatus `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListPortsResponse) Reset()         { *m = ListPortsResponse{} }
+func (m *ListPortsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPortsResponse) ProtoMessage()    {}
+func (*ListPortsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{6}
+}
+
+func (m *ListPortsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPortsResponse.Unmarshal(m, b)
+}
+func (m *ListPortsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPortsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPortsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPortsResponse.Merge(m, src)
+}
+func (m *ListPortsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPortsResponse.Size(m)
+}
+func (m *ListPortsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPortsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPortsResponse proto.InternalMessageInfo
+
+func (m *ListPortsResponse) GetPorts() []*PortsStatus {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+type ClosePortRequest struct {
+	Port                 uint32   `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClosePortRequest) Reset()         { *m = ClosePortRequest{} }
+func (m *ClosePortRequest) String() string { return proto.CompactTextString(m) }
+func (*ClosePortRequest) ProtoMessage()    {}
+func (*ClosePortRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{7}
+}
+
+func (m *ClosePortRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClosePortRequest.Unmarshal(m, b)
+}
+func (m *ClosePortRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClosePortRequest.Marshal(b, m, deterministic)
+}
+func (m *ClosePortRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClosePortRequest.Merge(m, src)
+}
+func (m *ClosePortRequest) XXX_Size() int {
+	return xxx_messageInfo_ClosePortRequest.Size(m)
+}
+func (m *ClosePortRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClosePortRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClosePortRequest proto.InternalMessageInfo
+
+func (m *ClosePortRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type ClosePortResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClosePortResponse) Reset()         { *m = ClosePortResponse{} }
+func (m *ClosePortResponse) String() string { return proto.CompactTextString(m) }
+func (*ClosePortResponse) ProtoMessage()    {}
+func (*ClosePortResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{8}
+}
+
+func (m *ClosePortResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClosePortResponse.Unmarshal(m, b)
+}
+func (m *ClosePortResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClosePortResponse.Marshal(b, m, deterministic)
+}
+func (m *ClosePortResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClosePortResponse.Merge(m, src)
+}
+func (m *ClosePortResponse) XXX_Size() int {
+	return xxx_messageInfo_ClosePortResponse.Size(m)
+}
+func (m *ClosePortResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClosePortResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClosePortResponse proto.InternalMessageInfo
+
+type SetPortVisibilityRequest struct {
+	Port                 uint32         `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	Visibility           PortVisibility `protobuf:"varint,2,opt,name=visibility,proto3,enum=supervisor.PortVisibility" json:"visibility,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SetPortVisibilityRequest) Reset()         { *m = SetPortVisibilityRequest{} }
+func (m *SetPortVisibilityRequest) String() string { return proto.CompactTextString(m) }
+func (*SetPortVisibilityRequest) ProtoMessage()    {}
+func (*SetPortVisibilityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{9}
+}
+
+func (m *SetPortVisibilityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetPortVisibilityRequest.Unmarshal(m, b)
+}
+func (m *SetPortVisibilityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetPortVisibilityRequest.Marshal(b, m, deterministic)
+}
+func (m *SetPortVisibilityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetPortVisibilityRequest.Merge(m, src)
+}
+func (m *SetPortVisibilityRequest) XXX_Size() int {
+	return xxx_messageInfo_SetPortVisibilityRequest.Size(m)
+}
+func (m *SetPortVisibilityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetPortVisibilityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetPortVisibilityRequest proto.InternalMessageInfo
+
+func (m *SetPortVisibilityRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *SetPortVisibilityRequest) GetVisibility() PortVisibility {
+	if m != nil {
+		return m.Visibility
+	}
+	return PortVisibility_private
+}
+
+type SetPortVisibilityResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetPortVisibilityResponse) Reset()         { *m = SetPortVisibilityResponse{} }
+func (m *SetPortVisibilityResponse) String() string { return proto.CompactTextString(m) }
+func (*SetPortVisibilityResponse) ProtoMessage()    {}
+func (*SetPortVisibilityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_729c3d36e9010a8e, []int{10}
+}
+
+func (m *SetPortVisibilityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetPortVisibilityResponse.Unmarshal(m, b)
+}
+func (m *SetPortVisibilityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetPortVisibilityResponse.Marshal(b, m, deterministic)
+}
+func (m *SetPortVisibilityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetPortVisibilityResponse.Merge(m, src)
+}
+func (m *SetPortVisibilityResponse) XXX_Size() int {
+	return xxx_messageInfo_SetPortVisibilityResponse.Size(m)
+}
+func (m *SetPortVisibilityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetPortVisibilityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetPortVisibilityResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*TunnelRequest)(nil), "supervisor.TunnelRequest")
+	proto.RegisterType((*TunnelOpen)(nil), "supervisor.TunnelOpen")
+	proto.RegisterType((*TunnelResponse)(nil), "supervisor.TunnelResponse")
+	proto.RegisterType((*ResolvePortRequest)(nil), "supervisor.ResolvePortRequest")
+	proto.RegisterType((*ResolvePortResponse)(nil), "supervisor.ResolvePortResponse")
+	proto.RegisterType((*ListPortsRequest)(nil), "supervisor.ListPortsRequest")
+	proto.RegisterType((*ListPortsResponse)(nil), "supervisor.ListPortsResponse")
+	proto.RegisterType((*ClosePortRequest)(nil), "supervisor.ClosePortRequest")
+	proto.RegisterType((*ClosePortResponse)(nil), "supervisor.ClosePortResponse")
+	proto.RegisterType((*SetPortVisibilityRequest)(nil), "supervisor.SetPortVisibilityRequest")
+	proto.RegisterType((*SetPortVisibilityResponse)(nil), "supervisor.SetPortVisibilityResponse")
+}
+
+func init() {
+	proto.RegisterFile("port.proto", fileDescriptor_729c3d36e9010a8e)
+}
+
+var fileDescriptor_729c3d36e9010a8e = []byte{
+	// 603 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0xad, 0x9b, 0xb4, 0xc8, 0x93, 0xa6, 0xb4, 0x53, 0x9a, 0x0f, 0xb7, 0x69, 0xc2, 0xaa, 0x45,
+	0x51, 0x05, 0x31, 0x84, 0x0b, 0xca, 0x31, 0xa8, 0x12, 0x07, 0x24, 0x90, 0x83, 0x38, 0x70, 0xa0,
+	0x72, 0xc2, 0x2a, 0x32, 0xda, 0xee, 0x1a, 0xef, 0x26, 0x02, 0x45, 0xbd, 0x70, 0x41, 0x9c, 0xf9,
+	0x69, 0xfc, 0x05, 0x7e, 0x08, 0xda, 0xf5, 0x26, 0x71, 0x9c, 0x06, 0x2e, 0xd6, 0xee, 0xcc, 0x9b,
+	0xf7, 0xde, 0xec, 0x8c, 0x0c, 0x10, 0x8b, 0x44, 0x75, 0xe2, 0x44, 0x28, 0x81, 0x20, 0x27, 0x31,
+	0x4d, 0xa6, 0x91, 0x14, 0x89, 0x77, 0x3a, 0x16, 0x62, 0xcc, 0xa8, 0x1f, 0xc6, 0x91, 0x1f, 0x72,
+	0x2e, 0x54, 0xa8, 0x22, 0xc1, 0x65, 0x8a, 0xf4, 0xca, 0x23, 0xc1, 0x55, 0x22, 0x98, 0xbd, 0xee,
+	0x49, 0x15, 0xaa, 0x89, 0x4d, 0x92, 0x8f, 0x50, 0x7e, 0x37, 0xe1, 0x9c, 0xb2, 0x80, 0x7e, 0x99,
+	0x50, 0xa9, 0xf0, 0x31, 0x14, 0x45, 0x4c, 0x79, 0xcd, 0x69, 0x39, 0xed, 0x52, 0xb7, 0xd2, 0x59,
+	0xca, 0x74, 0x52, 0xe0, 0x9b, 0x98, 0xf2, 0x57, 0x5b, 0x81, 0x41, 0xe1, 0x03, 0x28, 0x7e, 0x0a,
+	0x55, 0x58, 0xdb, 0x6e, 0x39, 0xed, 0x3d, 0x1d, 0xd5, 0xb7, 0xbe, 0x0b, 0xf7, 0x6e, 0xa8, 0x94,
+	0xe1, 0x98, 0x92, 0x17, 0x00, 0xcb, 0x32, 0x44, 0x28, 0xea, 0x16, 0x0c, 0x79, 0x39, 0x30, 0x67,
+	0xac, 0xc0, 0xee, 0x88, 0x45, 0x94, 0x2b, 0x43, 0xe2, 0x06, 0xf6, 0x46, 0xce, 0x61, 0x7f, 0xee,
+	0x4c, 0xc6, 0x82, 0x4b, 0xaa, 0xab, 0x8d, 0x98, 0xae, 0xde, 0x4b, 0xa5, 0x48, 0x1b, 0x30, 0xa0,
+	0x52, 0xb0, 0x29, 0x7d, 0x2b, 0x12, 0x35, 0x6f, 0x02, 0xa1, 0xc8, 0xc3, 0x1b, 0x6a, 0x90, 0x6e,
+	0x60, 0xce, 0x64, 0x06, 0x47, 0x2b, 0x48, 0x4b, 0xda, 0x00, 0x60, 0x62, 0x14, 0xb2, 0xeb, 0x8c,
+	0x31, 0xd7, 0x44, 0x34, 0x0c, 0x9b, 0x50, 0x1a, 0x33, 0x31, 0x9c, 0xe7, 0xb7, 0x4d, 0x1e, 0xd2,
+	0x90, 0x01, 0xcc, 0xa5, 0x0a, 0x4b, 0x29, 0x3c, 0x80, 0xc2, 0x24, 0x61, 0xb5, 0xa2, 0x09, 0xe9,
+	0x23, 0x41, 0x38, 0x78, 0x1d, 0x49, 0xa5, 0x2b, 0xa4, 0x35, 0x49, 0xfa, 0x70, 0x98, 0x89, 0x59,
+	0x3b, 0x4f, 0x60, 0x47, 0x0b, 0xc9, 0x9a, 0xd3, 0x2a, 0xb4, 0x4b, 0xdd, 0x6a, 0xf6, 0xfd, 0x0d,
+	0x72, 0x60, 0xa6, 0x17, 0xa4, 0x28, 0xf2, 0x08, 0x0e, 0x5e, 0x32, 0x21, 0xf3, 0xcd, 0xe7, 0x1f,
+	0x99, 0x1c, 0xc1, 0x61, 0x06, 0x97, 0x6a, 0x91, 0xcf, 0x50, 0x1b, 0x50, 0xa3, 0xff, 0x3e, 0x92,
+	0xd1, 0x30, 0x62, 0x91, 0xfa, 0xf6, 0x0f, 0x12, 0xec, 0x01, 0x4c, 0x17, 0x40, 0xf3, 0x14, 0xfb,
+	0x5d, 0x2f, 0x6f, 0x30, 0x43, 0x95, 0x41, 0x93, 0x13, 0xa8, 0xdf, 0xa1, 0x95, 0x1a, 0xe9, 0xfe,
+	0xdc, 0x81, 0x92, 0x4e, 0x0d, 0x34, 0xd1, 0x88, 0xe2, 0x15, 0xec, 0xa6, 0xa3, 0xc7, 0xfa, 0xfa,
+	0xfe, 0x59, 0x87, 0x9e, 0x77, 0x57, 0xca, 0x76, 0xb6, 0xd5, 0x76, 0x9e, 0x3a, 0x28, 0xa0, 0x94,
+	0x99, 0x38, 0x9e, 0x65, 0x0b, 0xd6, 0x97, 0xc6, 0x6b, 0x6e, 0xcc, 0x5b, 0xd6, 0xe6, 0xf7, 0xdf,
+	0x7f, 0x7e, 0x6d, 0xd7, 0xb1, 0xea, 0x4f, 0x9f, 0xf9, 0xfa, 0x45, 0xfc, 0x24, 0x45, 0xf9, 0x33,
+	0x3d, 0xf6, 0x5b, 0xbc, 0x06, 0x77, 0x31, 0x51, 0x3c, 0xcd, 0xd2, 0xe5, 0x87, 0xef, 0x35, 0x36,
+	0x64, 0xad, 0xd4, 0xb1, 0x91, 0xba, 0x8f, 0xe5, 0x85, 0x14, 0x8b, 0xa4, 0x42, 0x0e, 0x70, 0xf5,
+	0x35, 0xb6, 0x73, 0xc4, 0x15, 0x8e, 0x65, 0x7c, 0x2e, 0x71, 0xb6, 0x29, 0x6d, 0x35, 0x1e, 0x1a,
+	0x8d, 0x13, 0x52, 0x59, 0x68, 0xcc, 0xf4, 0xf7, 0xd6, 0xa7, 0x06, 0xdb, 0x73, 0x2e, 0x71, 0x0c,
+	0xee, 0x62, 0x6d, 0x56, 0x1b, 0xca, 0x6f, 0x9d, 0xd7, 0xd8, 0x90, 0xb5, 0x62, 0x0d, 0x23, 0x56,
+	0x25, 0xc7, 0x79, 0xb1, 0x91, 0x86, 0xe2, 0x0f, 0x07, 0x0e, 0xd7, 0xf6, 0x03, 0xcf, 0xb3, 0x9c,
+	0x9b, 0x56, 0xd5, 0xbb, 0xf8, 0x0f, 0xca, 0x3a, 0xb8, 0x30, 0x0e, 0x9a, 0xc4, 0xcb, 0x3b, 0x58,
+	0x6e, 0x69, 0xcf, 0xb9, 0xec, 0xef, 0x7c, 0x28, 0x84, 0x71, 0x34, 0xdc, 0x35, 0xbf, 0xc7, 0xe7,
+	0x7f, 0x07, 0x00, 0x02, 0x9b, 0xe8, 0x32, 0x73, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PortServiceClient is the client API for PortService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PortServiceClient interface {
+	// Tunnel relays a TCP connection to a port served in the workspace over this stream.
+	// Each call is one TCP connection, many tunnels share the client's connection to the supervisor.
+	// The first request must open the tunnel, all further requests carry data. The client closes its
+	// sending side to close the writing side of the TCP connection. The stream ends once the
+	// workspace side closed the connection.
+	Tunnel(ctx context.Context, opts ...grpc.CallOption) (PortService_TunnelClient, error)
+	// ResolvePort resolves a port name, as configured in the .gitpod.yml, or a port number
+	// to the current local and global port of the port.
+	ResolvePort(ctx context.Context, in *ResolvePortRequest, opts ...grpc.CallOption) (*ResolvePortResponse, error)
+	// ListPorts returns the current status of the ports, ordered by local port.
+	ListPorts(ctx context.Context, in *ListPortsRequest, opts ...grpc.CallOption) (*ListPortsResponse, error)
+	// ExposePort exposes a port served in the workspace. Its visibility follows the port configuration.
+	ExposePort(ctx context.Context, in *ExposePortRequest, opts ...grpc.CallOption) (*ExposePortResponse, error)
+	// ClosePort retracts the exposure of a port. The port is not exposed automatically anymore until
+	// it is exposed again.
+	ClosePort(ctx context.Context, in *ClosePortRequest, opts ...grpc.CallOption) (*ClosePortResponse, error)
+	// SetPortVisibility makes an exposed port public or private. Making a port public may wait for
+	// the user's approval, see ControlService.ApprovePublicPort.
+	SetPortVisibility(ctx context.Context, in *SetPortVisibilityRequest, opts ...grpc.CallOption) (*SetPortVisibilityResponse, error)
+}
+
+type portServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPortServiceClient(cc grpc.ClientConnInterface) PortServiceClient {
+	return &portServiceClient{cc}
+}
+
+func (c *portServiceClient) Tunnel(ctx context.Context, opts ...grpc.CallOption) (PortService_TunnelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PortService_serviceDesc.Streams[0], "/supervisor.PortService/Tunnel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &portServiceTunnelClient{stream}
+	return x, nil
+}
+
+type PortService_TunnelClient interface {
+	Send(*TunnelRequest) error
+	Recv() (*TunnelResponse, error)
+	grpc.ClientStream
+}
+
+type portServiceTunnelClient struct {
+	grpc.ClientStream
+}
+
+func (x *portServiceTunnelClient) Send(m *TunnelRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *portServiceTunnelClient) Recv() (*TunnelResponse, error) {
+	m := new(TunnelResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *portServiceClient) ResolvePort(ctx context.Context, in *ResolvePortRequest, opts ...grpc.CallOption) (*ResolvePortResponse, error) {
+	out := new(ResolvePortResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.PortService/ResolvePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portServiceClient) ListPorts(ctx context.Context, in *ListPortsRequest, opts ...grpc.CallOption) (*ListPortsResponse, error) {
+	out := new(ListPortsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.PortService/ListPorts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portServiceClient) ExposePort(ctx context.Context, in *ExposePortRequest, opts ...grpc.CallOption) (*ExposePortResponse, error) {
+	out := new(ExposePortResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.PortService/ExposePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portServiceClient) ClosePort(ctx context.Context, in *ClosePortRequest, opts ...grpc.CallOption) (*ClosePortResponse, error) {
+	out := new(ClosePortResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.PortService/ClosePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portServiceClient) SetPortVisibility(ctx context.Context, in *SetPortVisibilityRequest, opts ...grpc.CallOption) (*SetPortVisibilityResponse, error) {
+	out := new(SetPortVisibilityResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.PortService/SetPortVisibility", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PortServiceServer is the server API for PortService service.
+type PortServiceServer interface {
+	// Tunnel relays a TCP connection to a port served in the workspace over this stream.
+	// Each call is one TCP connection, many tunnels share the client's connection to the supervisor.
+	// The first request must open the tunnel, all further requests carry data. The client closes its
+	// sending side to close the writing side of the TCP connection. The stream ends once the
+	// workspace side closed the connection.
+	Tunnel(PortService_TunnelServer) error
+	// ResolvePort resolves a port name, as configured in the .gitpod.yml, or a port number
+	// to the current local and global port of the port.
+	ResolvePort(context.Context, *ResolvePortRequest) (*ResolvePortResponse, error)
+	// ListPorts returns the current status of the ports, ordered by local port.
+	ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error)
+	// ExposePort exposes a port served in the workspace. Its visibility follows the port configuration.
+	ExposePort(context.Context, *ExposePortRequest) (*ExposePortResponse, error)
+	// ClosePort retracts the exposure of a port. The port is not exposed automatically anymore until
+	// it is exposed again.
+	ClosePort(context.Context, *ClosePortRequest) (*ClosePortResponse, error)
+	// SetPortVisibility makes an exposed port public or private. Making a port public may wait for
+	// the user's approval, see ControlService.ApprovePublicPort.
+	SetPortVisibility(context.Context, *SetPortVisibilityRequest) (*SetPortVisibilityResponse, error)
+}
+
+// UnimplementedPortServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedPortServiceServer struct {
+}
+
+func (*UnimplementedPortServiceServer) Tunnel(srv PortService_TunnelServer) error {
+	return status.Errorf(codes.Unimplemented, "method Tunnel not implemented")
+}
+func (*UnimplementedPortServiceServer) ResolvePort(ctx context.Context, req *ResolvePortRequest) (*ResolvePortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolvePort not implemented")
+}
+func (*UnimplementedPortServiceServer) ListPorts(ctx context.Context, req *ListPortsRequest) (*ListPortsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPorts not implemented")
+}
+func (*UnimplementedPortServiceServer) ExposePort(ctx context.Context, req *ExposePortRequest) (*ExposePortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExposePort not implemented")
+}
+func (*UnimplementedPortServiceServer) ClosePort(ctx context.Context, req *ClosePortRequest) (*ClosePortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClosePort not implemented")
+}
+func (*UnimplementedPortServiceServer) SetPortVisibility(ctx context.Context, req *SetPortVisibilityRequest) (*SetPortVisibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPortVisibility not implemented")
+}
+
+func RegisterPortServiceServer(s *grpc.Server, srv PortServiceServer) {
+	s.RegisterService(&_PortService_serviceDesc, srv)
+}
+
+func _PortService_Tunnel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PortServiceServer).Tunnel(&portServiceTunnelServer{stream})
+}
+
+type PortService_TunnelServer interface {
+	Send(*TunnelResponse) error
+	Recv() (*TunnelRequest, error)
+	grpc.ServerStream
+}
+
+type portServiceTunnelServer struct {
+	grpc.ServerStream
+}
+
+func (x *portServiceTunnelServer) Send(m *TunnelResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *portServiceTunnelServer) Recv() (*TunnelRequest, error) {
+	m := new(TunnelRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _PortService_ResolvePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolvePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortServiceServer).ResolvePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.PortService/ResolvePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortServiceServer).ResolvePort(ctx, req.(*ResolvePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortService_ListPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPortsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortServiceServer).ListPorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.PortService/ListPorts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortServiceServer).ListPorts(ctx, req.(*ListPortsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortService_ExposePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExposePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortServiceServer).ExposePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.PortService/ExposePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortServiceServer).ExposePort(ctx, req.(*ExposePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortService_ClosePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortServiceServer).ClosePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.PortService/ClosePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortServiceServer).ClosePort(ctx, req.(*ClosePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortService_SetPortVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPortVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortServiceServer).SetPortVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.PortService/SetPortVisibility",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortServiceServer).SetPortVisibility(ctx, req.(*SetPortVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PortService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.PortService",
+	HandlerType: (*PortServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ResolvePort",
+			Handler:    _PortService_ResolvePort_Handler,
+		},
+		{
+			MethodName: "ListPorts",
+			Handler:    _PortService_ListPorts_Handler,
+		},
+		{
+			MethodName: "ExposePort",
+			Handler:    _PortService_ExposePort_Handler,
+		},
+		{
+			MethodName: "ClosePort",
+			Handler:    _PortService_ClosePort_Handler,
+		},
+		{
+			MethodName: "SetPortVisibility",
+			Handler:    _PortService_SetPortVisibility_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Tunnel",
+			Handler:       _PortService_Tunnel_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "port.proto",
+}
@@ -0,0 +1,535 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: presence.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type PresenceClient int32
+
+const (
+	PresenceClient_presence_ide PresenceClient = 0
+	PresenceClient_presence_ssh PresenceClient = 1
+)
+
+var PresenceClient_name = map[int32]string{
+	0: "presence_ide",
+	1: "presence_ssh",
+}
+
+var PresenceClient_value = map[string]int32{
+	"presence_ide": 0,
+	"presence_ssh": 1,
+}
+
+func (x PresenceClient) String() string {
+	return proto.EnumName(PresenceClient_name, int32(x))
+}
+
+func (PresenceClient) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_09da13d0a6600b92, []int{0}
+}
+
+type PresenceConnection struct {
+	Id     string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Client PresenceClient `protobuf:"varint,2,opt,name=client,proto3,enum=supervisor.PresenceClient" json:"client,omitempty"`
+	// user_id is the Gitpod user id of an IDE connection. SSH connections are identified by the fingerprint
+	// of the key the client authenticated with instead.
+	UserId string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// user_name is the name of the user of an IDE connection or the name an SSH client logged in as
+	UserName string `protobuf:"bytes,4,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	// client_name is the IDE the user is connected with, e.g. "vscode", or the fingerprint of the SSH key
+	ClientName           string               `protobuf:"bytes,5,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	Since                *timestamp.Timestamp `protobuf:"bytes,6,opt,name=since,proto3" json:"since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PresenceConnection) Reset()         { *m = PresenceConnection{} }
+func (m *PresenceConnection) String() string { return proto.CompactTextString(m) }
+func (*PresenceConnection) ProtoMessage()    {}
+func (*PresenceConnection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_09da13d0a6600b92, []int{0}
+}
+
+func (m *PresenceConnection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresenceConnection.Unmarshal(m, b)
+}
+func (m *PresenceConnection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresenceConnection.Marshal(b, m, deterministic)
+}
+func (m *PresenceConnection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresenceConnection.Merge(m, src)
+}
+func (m *PresenceConnection) XXX_Size() int {
+	return xxx_messageInfo_PresenceConnection.Size(m)
+}
+func (m *PresenceConnection) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresenceConnection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresenceConnection proto.InternalMessageInfo
+
+func (m *PresenceConnection) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PresenceConnection) GetClient() PresenceClient {
+	if m != nil {
+		return m.Client
+	}
+	return PresenceClient_presence_ide
+}
+
+func (m *PresenceConnection) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *PresenceConnection) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *PresenceConnection) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
+
+func (m *PresenceConnection) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+type JoinPresenceRequest struct {
+	UserId   string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserName string `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	// client_name is the IDE the user is connected with, e.g. "vscode"
+	ClientName           string   `protobuf:"bytes,3,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinPresenceRequest) Reset()         { *m = JoinPresenceRequest{} }
+func (m *JoinPresenceRequest) String() string { return proto.CompactTextString(m) }
+func (*JoinPresenceRequest) ProtoMessage()    {}
+func (*JoinPresenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_09da13d0a6600b92, []int{1}
+}
+
+func (m *JoinPresenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinPresenceRequest.Unmarshal(m, b)
+}
+func (m *JoinPresenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinPresenceRequest.Marshal(b, m, deterministic)
+}
+func (m *JoinPresenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinPresenceRequest.Merge(m, src)
+}
+func (m *JoinPresenceRequest) XXX_Size() int {
+	return xxx_messageInfo_JoinPresenceRequest.Size(m)
+}
+func (m *JoinPresenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinPresenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinPresenceRequest proto.InternalMessageInfo
+
+func (m *JoinPresenceRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *JoinPresenceRequest) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *JoinPresenceRequest) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
+
+type JoinPresenceResponse struct {
+	ConnectionId         string   `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinPresenceResponse) Reset()         { *m = JoinPresenceResponse{} }
+func (m *JoinPresenceResponse) String() string { return proto.CompactTextString(m) }
+func (*JoinPresenceResponse) ProtoMessage()    {}
+func (*JoinPresenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_09da13d0a6600b92, []int{2}
+}
+
+func (m *JoinPresenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinPresenceResponse.Unmarshal(m, b)
+}
+func (m *JoinPresenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinPresenceResponse.Marshal(b, m, deterministic)
+}
+func (m *JoinPresenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinPresenceResponse.Merge(m, src)
+}
+func (m *JoinPresenceResponse) XXX_Size() int {
+	return xxx_messageInfo_JoinPresenceResponse.Size(m)
+}
+func (m *JoinPresenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinPresenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinPresenceResponse proto.InternalMessageInfo
+
+func (m *JoinPresenceResponse) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+type PresenceRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PresenceRequest) Reset()         { *m = PresenceRequest{} }
+func (m *PresenceRequest) String() string { return proto.CompactTextString(m) }
+func (*PresenceRequest) ProtoMessage()    {}
+func (*PresenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_09da13d0a6600b92, []int{3}
+}
+
+func (m *PresenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresenceRequest.Unmarshal(m, b)
+}
+func (m *PresenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresenceRequest.Marshal(b, m, deterministic)
+}
+func (m *PresenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresenceRequest.Merge(m, src)
+}
+func (m *PresenceRequest) XXX_Size() int {
+	return xxx_messageInfo_PresenceRequest.Size(m)
+}
+func (m *PresenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresenceRequest proto.InternalMessageInfo
+
+type PresenceResponse struct {
+	Connections []*PresenceConnection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	// users is the number of distinct users connected, users connected with several clients count once
+	Users                uint32   `protobuf:"varint,2,opt,name=users,proto3" json:"users,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PresenceResponse) Reset()         { *m = PresenceResponse{} }
+func (m *PresenceResponse) String() string { return proto.CompactTextString(m) }
+func (*PresenceResponse) ProtoMessage()    {}
+func (*PresenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_09da13d0a6600b92, []int{4}
+}
+
+func (m *PresenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PresenceResponse.Unmarshal(m, b)
+}
+func (m *PresenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PresenceResponse.Marshal(b, m, deterministic)
+}
+func (m *PresenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresenceResponse.Merge(m, src)
+}
+func (m *PresenceResponse) XXX_Size() int {
+	return xxx_messageInfo_PresenceResponse.Size(m)
+}
+func (m *PresenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresenceResponse proto.InternalMessageInfo
+
+func (m *PresenceResponse) GetConnections() []*PresenceConnection {
+	if m != nil {
+		return m.Connections
+	}
+	return nil
+}
+
+func (m *PresenceResponse) GetUsers() uint32 {
+	if m != nil {
+		return m.Users
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("supervisor.PresenceClient", PresenceClient_name, PresenceClient_value)
+	proto.RegisterType((*PresenceConnection)(nil), "supervisor.PresenceConnection")
+	proto.RegisterType((*JoinPresenceRequest)(nil), "supervisor.JoinPresenceRequest")
+	proto.RegisterType((*JoinPresenceResponse)(nil), "supervisor.JoinPresenceResponse")
+	proto.RegisterType((*PresenceRequest)(nil), "supervisor.PresenceRequest")
+	proto.RegisterType((*PresenceResponse)(nil), "supervisor.PresenceResponse")
+}
+
+func init() {
+	proto.RegisterFile("presence.proto", fileDescriptor_09da13d0a6600b92)
+}
+
+var fileDescriptor_09da13d0a6600b92 = []byte{
+	// 432 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xd1, 0x6e, 0x94, 0x40,
+	0x14, 0x86, 0x3b, 0x6c, 0x77, 0x6d, 0x0f, 0x5b, 0xc4, 0x71, 0x13, 0x09, 0x6d, 0x5c, 0x82, 0x37,
+	0xc4, 0x0b, 0x58, 0xd1, 0x3b, 0x6f, 0x8c, 0x5e, 0xd5, 0x44, 0x63, 0xd0, 0x2b, 0x13, 0xd3, 0x50,
+	0x38, 0xd6, 0x31, 0xcb, 0x0c, 0x32, 0xd0, 0x07, 0xf0, 0x15, 0x7c, 0x20, 0x1f, 0xc2, 0x27, 0x30,
+	0xf1, 0x41, 0x0c, 0x33, 0xb0, 0x94, 0xca, 0x7a, 0x39, 0xe7, 0xff, 0xcf, 0x99, 0xef, 0x9f, 0x33,
+	0x60, 0x95, 0x15, 0x4a, 0xe4, 0x19, 0x86, 0x65, 0x25, 0x6a, 0x41, 0x41, 0x36, 0x25, 0x56, 0xd7,
+	0x4c, 0x8a, 0xca, 0x3d, 0xbb, 0x12, 0xe2, 0x6a, 0x8b, 0x51, 0x5a, 0xb2, 0x28, 0xe5, 0x5c, 0xd4,
+	0x69, 0xcd, 0x04, 0x97, 0xda, 0xe9, 0xae, 0x3b, 0x55, 0x9d, 0x2e, 0x9b, 0xcf, 0x51, 0xcd, 0x0a,
+	0x94, 0x75, 0x5a, 0x94, 0xda, 0xe0, 0xff, 0x26, 0x40, 0xdf, 0x75, 0xd3, 0x5f, 0x09, 0xce, 0x31,
+	0x6b, 0xdb, 0xa9, 0x05, 0x06, 0xcb, 0x1d, 0xe2, 0x91, 0xe0, 0x38, 0x31, 0x58, 0x4e, 0x63, 0x58,
+	0x64, 0x5b, 0x86, 0xbc, 0x76, 0x0c, 0x8f, 0x04, 0x56, 0xec, 0x86, 0x03, 0x42, 0xb8, 0xeb, 0x57,
+	0x8e, 0xa4, 0x73, 0xd2, 0x07, 0x70, 0xa7, 0x91, 0x58, 0x5d, 0xb0, 0xdc, 0x99, 0xa9, 0x41, 0x8b,
+	0xf6, 0x78, 0x9e, 0xd3, 0x53, 0x38, 0x56, 0x02, 0x4f, 0x0b, 0x74, 0x0e, 0x95, 0x74, 0xd4, 0x16,
+	0xde, 0xa6, 0x05, 0xd2, 0x35, 0x98, 0xba, 0x5f, 0xcb, 0x73, 0x25, 0x83, 0x2e, 0x29, 0xc3, 0x06,
+	0xe6, 0x92, 0xf1, 0x0c, 0x9d, 0x85, 0x47, 0x02, 0x33, 0x76, 0x43, 0x1d, 0x31, 0xec, 0x23, 0x86,
+	0x1f, 0xfa, 0x88, 0x89, 0x36, 0xfa, 0x5b, 0xb8, 0xff, 0x5a, 0x30, 0xde, 0x63, 0x26, 0xf8, 0xad,
+	0x41, 0x39, 0xe2, 0x23, 0xfb, 0xf9, 0x8c, 0xff, 0xf3, 0xcd, 0x6e, 0xf3, 0xf9, 0xcf, 0x61, 0x35,
+	0xbe, 0x4d, 0x96, 0x82, 0x4b, 0xa4, 0x8f, 0xe0, 0x24, 0xdb, 0x3d, 0xf0, 0x70, 0xe9, 0x72, 0x28,
+	0x9e, 0xe7, 0xfe, 0x3d, 0xb8, 0x7b, 0x0b, 0xd3, 0xff, 0x0a, 0xf6, 0x3f, 0xb3, 0x5e, 0x80, 0x39,
+	0xb4, 0x49, 0x87, 0x78, 0xb3, 0xc0, 0x8c, 0x1f, 0x4e, 0xee, 0x64, 0x67, 0x4b, 0x6e, 0xb6, 0xd0,
+	0x15, 0xcc, 0xdb, 0x48, 0x52, 0xe5, 0x3b, 0x49, 0xf4, 0xe1, 0xf1, 0x33, 0xb0, 0xc6, 0xcb, 0xa4,
+	0x36, 0x2c, 0xfb, 0xcf, 0x77, 0xc1, 0x72, 0xb4, 0x0f, 0x46, 0x15, 0x29, 0xbf, 0xd8, 0x24, 0xfe,
+	0x49, 0x06, 0xea, 0xf7, 0x2d, 0x40, 0x86, 0xf4, 0x0d, 0x1c, 0xb6, 0xaf, 0x40, 0xd7, 0x37, 0xa1,
+	0x26, 0xb6, 0xe0, 0x7a, 0xfb, 0x0d, 0x3a, 0xac, 0x7f, 0xb0, 0x21, 0xf4, 0x13, 0x1c, 0xf5, 0x75,
+	0x7a, 0x3a, 0x95, 0xb3, 0x1f, 0x77, 0x36, 0x2d, 0x76, 0xa3, 0x56, 0xdf, 0x7f, 0xfd, 0xf9, 0x61,
+	0x58, 0x74, 0x19, 0x5d, 0x3f, 0x89, 0xfa, 0x14, 0x1b, 0xf2, 0x72, 0xfe, 0x71, 0x96, 0x96, 0xec,
+	0x72, 0xa1, 0xfe, 0xd0, 0xd3, 0xbf, 0x03, 0x00, 0x10, 0x91, 0x3f, 0xc4, 0x70, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PresenceServiceClient is the client API for PresenceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PresenceServiceClient interface {
+	// Join marks a user as connected until the call is canceled. The first response carries the id
+	// of the connection, no further responses are sent.
+	Join(ctx context.Context, in *JoinPresenceRequest, opts ...grpc.CallOption) (PresenceService_JoinClient, error)
+	// Presence streams the connections to the workspace, starting with the current ones. A new response
+	// with all connections is sent whenever a client connects or disconnects.
+	Presence(ctx context.Context, in *PresenceRequest, opts ...grpc.CallOption) (PresenceService_PresenceClient, error)
+}
+
+type presenceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPresenceServiceClient(cc grpc.ClientConnInterface) PresenceServiceClient {
+	return &presenceServiceClient{cc}
+}
+
+func (c *presenceServiceClient) Join(ctx context.Context, in *JoinPresenceRequest, opts ...grpc.CallOption) (PresenceService_JoinClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PresenceService_serviceDesc.Streams[0], "/supervisor.PresenceService/Join", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &presenceServiceJoinClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PresenceService_JoinClient interface {
+	Recv() (*JoinPresenceResponse, error)
+	grpc.ClientStream
+}
+
+type presenceServiceJoinClient struct {
+	grpc.ClientStream
+}
+
+func (x *presenceServiceJoinClient) Recv() (*JoinPresenceResponse, error) {
+	m := new(JoinPresenceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *presenceServiceClient) Presence(ctx context.Context, in *PresenceRequest, opts ...grpc.CallOption) (PresenceService_PresenceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PresenceService_serviceDesc.Streams[1], "/supervisor.PresenceService/Presence", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &presenceServicePresenceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PresenceService_PresenceClient interface {
+	Recv() (*PresenceResponse, error)
+	grpc.ClientStream
+}
+
+type presenceServicePresenceClient struct {
+	grpc.ClientStream
+}
+
+func (x *presenceServicePresenceClient) Recv() (*PresenceResponse, error) {
+	m := new(PresenceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PresenceServiceServer is the server API for PresenceService service.
+type PresenceServiceServer interface {
+	// Join marks a user as connected until the call is canceled. The first response carries the id
+	// of the connection, no further responses are sent.
+	Join(*JoinPresenceRequest, PresenceService_JoinServer) error
+	// Presence streams the connections to the workspace, starting with the current ones. A new response
+	// with all connections is sent whenever a client connects or disconnects.
+	Presence(*PresenceRequest, PresenceService_PresenceServer) error
+}
+
+// UnimplementedPresenceServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedPresenceServiceServer struct {
+}
+
+func (*UnimplementedPresenceServiceServer) Join(req *JoinPresenceRequest, srv PresenceService_JoinServer) error {
+	return status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (*UnimplementedPresenceServiceServer) Presence(req *PresenceRequest, srv PresenceService_PresenceServer) error {
+	return status.Errorf(codes.Unimplemented, "method Presence not implemented")
+}
+
+func RegisterPresenceServiceServer(s *grpc.Server, srv PresenceServiceServer) {
+	s.RegisterService(&_PresenceService_serviceDesc, srv)
+}
+
+func _PresenceService_Join_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(JoinPresenceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PresenceServiceServer).Join(m, &presenceServiceJoinServer{stream})
+}
+
+type PresenceService_JoinServer interface {
+	Send(*JoinPresenceResponse) error
+	grpc.ServerStream
+}
+
+type presenceServiceJoinServer struct {
+	grpc.ServerStream
+}
+
+func (x *presenceServiceJoinServer) Send(m *JoinPresenceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PresenceService_Presence_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PresenceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PresenceServiceServer).Presence(m, &presenceServicePresenceServer{stream})
+}
+
+type PresenceService_PresenceServer interface {
+	Send(*PresenceResponse) error
+	grpc.ServerStream
+}
+
+type presenceServicePresenceServer struct {
+	grpc.ServerStream
+}
+
+func (x *presenceServicePresenceServer) Send(m *PresenceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _PresenceService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.PresenceService",
+	HandlerType: (*PresenceServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Join",
+			Handler:       _PresenceService_Join_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Presence",
+			Handler:       _PresenceService_Presence_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "presence.proto",
+}
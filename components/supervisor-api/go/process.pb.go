@@ -0,0 +1,411 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: process.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ListProcessesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListProcessesRequest) Reset()         { *m = ListProcessesRequest{} }
+func (m *ListProcessesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListProcessesRequest) ProtoMessage()    {}
+func (*ListProcessesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_54c4d0e8c0aaf5c3, []int{0}
+}
+
+func (m *ListProcessesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListProcessesRequest.Unmarshal(m, b)
+}
+func (m *ListProcessesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListProcessesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListProcessesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListProcessesRequest.Merge(m, src)
+}
+func (m *ListProcessesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListProcessesRequest.Size(m)
+}
+func (m *ListProcessesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListProcessesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListProcessesRequest proto.InternalMessageInfo
+
+type ListProcessesResponse struct {
+	Processes []*Process `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	// reaped_zombies is the number of exited processes supervisor has reaped
+	ReapedZombies        uint64   `protobuf:"varint,2,opt,name=reaped_zombies,json=reapedZombies,proto3" json:"reaped_zombies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListProcessesResponse) Reset()         { *m = ListProcessesResponse{} }
+func (m *ListProcessesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListProcessesResponse) ProtoMessage()    {}
+func (*ListProcessesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_54c4d0e8c0aaf5c3, []int{1}
+}
+
+func (m *ListProcessesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListProcessesResponse.Unmarshal(m, b)
+}
+func (m *ListProcessesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListProcessesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListProcessesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListProcessesResponse.Merge(m, src)
+}
+func (m *ListProcessesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListProcessesResponse.Size(m)
+}
+func (m *ListProcessesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListProcessesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListProcessesResponse proto.InternalMessageInfo
+
+func (m *ListProcessesResponse) GetProcesses() []*Process {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
+func (m *ListProcessesResponse) GetReapedZombies() uint64 {
+	if m != nil {
+		return m.ReapedZombies
+	}
+	return 0
+}
+
+type Process struct {
+	Pid  int64 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Ppid int64 `protobuf:"varint,2,opt,name=ppid,proto3" json:"ppid,omitempty"`
+	// command is the name of the process' executable
+	Command string   `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	// state is the state of the process as in /proc/<pid>/stat, e.g. R for running or Z for zombie
+	State   string               `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Started *timestamp.Timestamp `protobuf:"bytes,6,opt,name=started,proto3" json:"started,omitempty"`
+	// orphan is true if the parent of the process exited and supervisor adopted it
+	Orphan bool `protobuf:"varint,7,opt,name=orphan,proto3" json:"orphan,omitempty"`
+	// orphaned_since is when the process was found to be adopted
+	OrphanedSince *timestamp.Timestamp `protobuf:"bytes,8,opt,name=orphaned_since,json=orphanedSince,proto3" json:"orphaned_since,omitempty"`
+	// descendants is the number of processes in the tree below the process
+	Descendants uint32 `protobuf:"varint,9,opt,name=descendants,proto3" json:"descendants,omitempty"`
+	// runaway is true if the process is a direct child of supervisor whose tree grew beyond the runaway limit
+	Runaway bool `protobuf:"varint,10,opt,name=runaway,proto3" json:"runaway,omitempty"`
+	// cpu_percent is the CPU usage of the process since the previous scan, 100 being one core
+	CpuPercent float64 `protobuf:"fixed64,11,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	// rss_bytes is the memory the process holds
+	RssBytes uint64 `protobuf:"varint,12,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`
+	// ports are the TCP ports the process listens on
+	Ports []uint32 `protobuf:"varint,13,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	// cgroup is the path of the cgroup the process belongs to, e.g. the one of a container
+	Cgroup string `protobuf:"bytes,14,opt,name=cgroup,proto3" json:"cgroup,omitempty"`
+	// tree_cpu_percent is the CPU usage of the process and its descendants
+	TreeCpuPercent float64 `protobuf:"fixed64,15,opt,name=tree_cpu_percent,json=treeCpuPercent,proto3" json:"tree_cpu_percent,omitempty"`
+	// tree_rss_bytes is the memory the process and its descendants hold
+	TreeRssBytes         uint64   `protobuf:"varint,16,opt,name=tree_rss_bytes,json=treeRssBytes,proto3" json:"tree_rss_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Process) Reset()         { *m = Process{} }
+func (m *Process) String() string { return proto.CompactTextString(m) }
+func (*Process) ProtoMessage()    {}
+func (*Process) Descriptor() ([]byte, []int) {
+	return fileDescriptor_54c4d0e8c0aaf5c3, []int{2}
+}
+
+func (m *Process) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Process.Unmarshal(m, b)
+}
+func (m *Process) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Process.Marshal(b, m, deterministic)
+}
+func (m *Process) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Process.Merge(m, src)
+}
+func (m *Process) XXX_Size() int {
+	return xxx_messageInfo_Process.Size(m)
+}
+func (m *Process) XXX_DiscardUnknown() {
+	xxx_messageInfo_Process.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Process proto.InternalMessageInfo
+
+func (m *Process) GetPid() int64 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *Process) GetPpid() int64 {
+	if m != nil {
+		return m.Ppid
+	}
+	return 0
+}
+
+func (m *Process) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *Process) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Process) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Process) GetStarted() *timestamp.Timestamp {
+	if m != nil {
+		return m.Started
+	}
+	return nil
+}
+
+func (m *Process) GetOrphan() bool {
+	if m != nil {
+		return m.Orphan
+	}
+	return false
+}
+
+func (m *Process) GetOrphanedSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.OrphanedSince
+	}
+	return nil
+}
+
+func (m *Process) GetDescendants() uint32 {
+	if m != nil {
+		return m.Descendants
+	}
+	return 0
+}
+
+func (m *Process) GetRunaway() bool {
+	if m != nil {
+		return m.Runaway
+	}
+	return false
+}
+
+func (m *Process) GetCpuPercent() float64 {
+	if m != nil {
+		return m.CpuPercent
+	}
+	return 0
+}
+
+func (m *Process) GetRssBytes() uint64 {
+	if m != nil {
+		return m.RssBytes
+	}
+	return 0
+}
+
+func (m *Process) GetPorts() []uint32 {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+func (m *Process) GetCgroup() string {
+	if m != nil {
+		return m.Cgroup
+	}
+	return ""
+}
+
+func (m *Process) GetTreeCpuPercent() float64 {
+	if m != nil {
+		return m.TreeCpuPercent
+	}
+	return 0
+}
+
+func (m *Process) GetTreeRssBytes() uint64 {
+	if m != nil {
+		return m.TreeRssBytes
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ListProcessesRequest)(nil), "supervisor.ListProcessesRequest")
+	proto.RegisterType((*ListProcessesResponse)(nil), "supervisor.ListProcessesResponse")
+	proto.RegisterType((*Process)(nil), "supervisor.Process")
+}
+
+func init() {
+	proto.RegisterFile("process.proto", fileDescriptor_54c4d0e8c0aaf5c3)
+}
+
+var fileDescriptor_54c4d0e8c0aaf5c3 = []byte{
+	// 498 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xe5, 0x38, 0x7f, 0x27, 0x75, 0x1a, 0x2d, 0x6d, 0xb5, 0x0a, 0x48, 0x5d, 0x22, 0x90,
+	0x7c, 0x72, 0xd4, 0xc0, 0x0b, 0x50, 0xae, 0x1c, 0xaa, 0x2d, 0xa7, 0x5e, 0xac, 0x8d, 0x3d, 0x04,
+	0x0b, 0xe2, 0xdd, 0xee, 0xac, 0x8b, 0x8a, 0xc4, 0x85, 0x57, 0xe0, 0xd1, 0x78, 0x05, 0x1e, 0x83,
+	0x03, 0xf2, 0xda, 0x26, 0x01, 0x21, 0x7a, 0x9b, 0xef, 0xe7, 0x6f, 0xf6, 0xf3, 0xce, 0x0e, 0x44,
+	0xc6, 0xea, 0x0c, 0x89, 0x12, 0x63, 0xb5, 0xd3, 0x0c, 0xa8, 0x32, 0x68, 0xef, 0x0a, 0xd2, 0x76,
+	0xf1, 0x64, 0xab, 0xf5, 0xf6, 0x23, 0xae, 0x94, 0x29, 0x56, 0xaa, 0x2c, 0xb5, 0x53, 0xae, 0xd0,
+	0x65, 0xeb, 0x5c, 0x9c, 0xb7, 0x5f, 0xbd, 0xda, 0x54, 0xef, 0x56, 0xae, 0xd8, 0x21, 0x39, 0xb5,
+	0x33, 0x8d, 0x61, 0x79, 0x06, 0x27, 0x6f, 0x0a, 0x72, 0x57, 0xcd, 0xf9, 0x48, 0x12, 0x6f, 0x2b,
+	0x24, 0xb7, 0xbc, 0x85, 0xd3, 0xbf, 0x38, 0x19, 0x5d, 0x12, 0xb2, 0x0b, 0x98, 0x98, 0x0e, 0xf2,
+	0x40, 0x84, 0xf1, 0x74, 0xfd, 0x28, 0xd9, 0xff, 0x4f, 0xd2, 0x76, 0xc8, 0xbd, 0x8b, 0x3d, 0x87,
+	0x99, 0x45, 0x65, 0x30, 0x4f, 0x3f, 0xeb, 0xdd, 0xa6, 0x40, 0xe2, 0x3d, 0x11, 0xc4, 0x7d, 0x19,
+	0x35, 0xf4, 0xa6, 0x81, 0xcb, 0x9f, 0x21, 0x8c, 0xda, 0x6e, 0x36, 0x87, 0xd0, 0x14, 0x39, 0x0f,
+	0x44, 0x10, 0x87, 0xb2, 0x2e, 0x19, 0x83, 0xbe, 0xa9, 0x51, 0xcf, 0x23, 0x5f, 0x33, 0x0e, 0xa3,
+	0x4c, 0xef, 0x76, 0xaa, 0xcc, 0x79, 0x28, 0x82, 0x78, 0x22, 0x3b, 0x59, 0xbb, 0x95, 0xdd, 0x12,
+	0xef, 0x8b, 0x30, 0x9e, 0x48, 0x5f, 0xb3, 0x13, 0x18, 0x90, 0x53, 0x0e, 0xf9, 0xc0, 0x7b, 0x1b,
+	0xc1, 0x5e, 0xc2, 0x88, 0x9c, 0xb2, 0x0e, 0x73, 0x3e, 0x14, 0x41, 0x3c, 0x5d, 0x2f, 0x92, 0x66,
+	0x66, 0x49, 0x37, 0xb3, 0xe4, 0x6d, 0x37, 0x33, 0xd9, 0x59, 0xd9, 0x19, 0x0c, 0xb5, 0x35, 0xef,
+	0x55, 0xc9, 0x47, 0x22, 0x88, 0xc7, 0xb2, 0x55, 0xec, 0x15, 0xcc, 0x9a, 0x0a, 0xf3, 0x94, 0x8a,
+	0x32, 0x43, 0x3e, 0x7e, 0xf0, 0xd0, 0xa8, 0xeb, 0xb8, 0xae, 0x1b, 0x98, 0x80, 0x69, 0x8e, 0x94,
+	0x61, 0x99, 0xab, 0xd2, 0x11, 0x9f, 0x88, 0x20, 0x8e, 0xe4, 0x21, 0xaa, 0xaf, 0x6d, 0xab, 0x52,
+	0x7d, 0x52, 0xf7, 0x1c, 0x7c, 0x7a, 0x27, 0xd9, 0x39, 0x4c, 0x33, 0x53, 0xa5, 0x06, 0x6d, 0x86,
+	0xa5, 0xe3, 0x53, 0x11, 0xc4, 0x81, 0x84, 0xcc, 0x54, 0x57, 0x0d, 0x61, 0x8f, 0x61, 0x62, 0x89,
+	0xd2, 0xcd, 0xbd, 0x43, 0xe2, 0x47, 0xfe, 0x15, 0xc6, 0x96, 0xe8, 0xb2, 0xd6, 0xf5, 0x80, 0x8c,
+	0xb6, 0x8e, 0x78, 0x24, 0xc2, 0x38, 0x92, 0x8d, 0xa8, 0xaf, 0x9a, 0x6d, 0xad, 0xae, 0x0c, 0x9f,
+	0xf9, 0xb9, 0xb5, 0x8a, 0xc5, 0x30, 0x77, 0x16, 0x31, 0x3d, 0x0c, 0x3c, 0xf6, 0x81, 0xb3, 0x9a,
+	0xbf, 0xde, 0x87, 0x3e, 0x03, 0x4f, 0xd2, 0x7d, 0xf2, 0xdc, 0x27, 0x1f, 0xd5, 0x54, 0xb6, 0xe9,
+	0xeb, 0x2f, 0x30, 0x6b, 0x5f, 0xff, 0xba, 0xde, 0xa5, 0x0c, 0xd9, 0x07, 0x88, 0xfe, 0xd8, 0x41,
+	0x26, 0x0e, 0x17, 0xed, 0x5f, 0x6b, 0xbb, 0x78, 0xfa, 0x1f, 0x47, 0xb3, 0xc0, 0xcb, 0xd3, 0xaf,
+	0xdf, 0x7f, 0x7c, 0xeb, 0x1d, 0xb3, 0x68, 0x75, 0x77, 0xb1, 0xfa, 0xbd, 0xa4, 0x97, 0x83, 0x9b,
+	0x50, 0x99, 0x62, 0x33, 0xf4, 0x0f, 0xf4, 0xe2, 0xd7, 0x00, 0x21, 0xbf, 0x94, 0x4c, 0x72, 0x03,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ProcessServiceClient is the client API for ProcessService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ProcessServiceClient interface {
+	// ListProcesses lists the processes of the workspace with their resource usage. The processes form a tree
+	// by their ppid.
+	ListProcesses(ctx context.Context, in *ListProcessesRequest, opts ...grpc.CallOption) (*ListProcessesResponse, error)
+}
+
+type processServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProcessServiceClient(cc grpc.ClientConnInterface) ProcessServiceClient {
+	return &processServiceClient{cc}
+}
+
+func (c *processServiceClient) ListProcesses(ctx context.Context, in *ListProcessesRequest, opts ...grpc.CallOption) (*ListProcessesResponse, error) {
+	out := new(ListProcessesResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.ProcessService/ListProcesses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProcessServiceServer is the server API for ProcessService service.
+type ProcessServiceServer interface {
+	// ListProcesses lists the processes of the workspace with their resource usage. The processes form a tree
+	// by their ppid.
+	ListProcesses(context.Context, *ListProcessesRequest) (*ListProcessesResponse, error)
+}
+
+// UnimplementedProcessServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedProcessServiceServer struct {
+}
+
+func (*UnimplementedProcessServiceServer) ListProcesses(ctx context.Context, req *ListProcessesRequest) (*ListProcessesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProcesses not implemented")
+}
+
+func RegisterProcessServiceServer(s *grpc.Server, srv ProcessServiceServer) {
+	s.RegisterService(&_ProcessService_serviceDesc, srv)
+}
+
+func _ProcessService_ListProcesses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProcessesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).ListProcesses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.ProcessService/ListProcesses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).ListProcesses(ctx, req.(*ListProcessesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ProcessService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.ProcessService",
+	HandlerType: (*ProcessServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListProcesses",
+			Handler:    _ProcessService_ListProcesses_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "process.proto",
+}
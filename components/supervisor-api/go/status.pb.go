@@ -11,6 +11,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -29,6 +30,39 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type HealthState int32
+
+const (
+	// the subsystem has not reported its health yet
+	HealthState_health_starting HealthState = 0
+	HealthState_health_ok       HealthState = 1
+	// the subsystem works, but has problems, e.g. the Gitpod server is unreachable
+	HealthState_health_degraded HealthState = 2
+	HealthState_health_failed   HealthState = 3
+)
+
+var HealthState_name = map[int32]string{
+	0: "health_starting",
+	1: "health_ok",
+	2: "health_degraded",
+	3: "health_failed",
+}
+
+var HealthState_value = map[string]int32{
+	"health_starting": 0,
+	"health_ok":       1,
+	"health_degraded": 2,
+	"health_failed":   3,
+}
+
+func (x HealthState) String() string {
+	return proto.EnumName(HealthState_name, int32(x))
+}
+
+func (HealthState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{0}
+}
+
 type ContentSource int32
 
 const (
@@ -54,7 +88,139 @@ func (x ContentSource) String() string {
 }
 
 func (ContentSource) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{0}
+	return fileDescriptor_dfe4fce6682daf5b, []int{1}
+}
+
+type ContentPhase int32
+
+const (
+	// the content initialization has not started yet or does not report progress,
+	// e.g. if the content is provided by the node the workspace runs on
+	ContentPhase_content_pending              ContentPhase = 0
+	ContentPhase_content_restoring_backup     ContentPhase = 1
+	ContentPhase_content_downloading_prebuild ContentPhase = 2
+	ContentPhase_content_cloning              ContentPhase = 3
+	ContentPhase_content_ready                ContentPhase = 4
+	ContentPhase_content_failed               ContentPhase = 5
+)
+
+var ContentPhase_name = map[int32]string{
+	0: "content_pending",
+	1: "content_restoring_backup",
+	2: "content_downloading_prebuild",
+	3: "content_cloning",
+	4: "content_ready",
+	5: "content_failed",
+}
+
+var ContentPhase_value = map[string]int32{
+	"content_pending":              0,
+	"content_restoring_backup":     1,
+	"content_downloading_prebuild": 2,
+	"content_cloning":              3,
+	"content_ready":                4,
+	"content_failed":               5,
+}
+
+func (x ContentPhase) String() string {
+	return proto.EnumName(ContentPhase_name, int32(x))
+}
+
+func (ContentPhase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{2}
+}
+
+type DotfilesPhase int32
+
+const (
+	// the user has not configured a dotfiles repository
+	DotfilesPhase_dotfiles_none       DotfilesPhase = 0
+	DotfilesPhase_dotfiles_cloning    DotfilesPhase = 1
+	DotfilesPhase_dotfiles_installing DotfilesPhase = 2
+	DotfilesPhase_dotfiles_installed  DotfilesPhase = 3
+	DotfilesPhase_dotfiles_failed     DotfilesPhase = 4
+)
+
+var DotfilesPhase_name = map[int32]string{
+	0: "dotfiles_none",
+	1: "dotfiles_cloning",
+	2: "dotfiles_installing",
+	3: "dotfiles_installed",
+	4: "dotfiles_failed",
+}
+
+var DotfilesPhase_value = map[string]int32{
+	"dotfiles_none":       0,
+	"dotfiles_cloning":    1,
+	"dotfiles_installing": 2,
+	"dotfiles_installed":  3,
+	"dotfiles_failed":     4,
+}
+
+func (x DotfilesPhase) String() string {
+	return proto.EnumName(DotfilesPhase_name, int32(x))
+}
+
+func (DotfilesPhase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{3}
+}
+
+type PortsUpdateTrigger int32
+
+const (
+	PortsUpdateTrigger_unspecified_trigger PortsUpdateTrigger = 0
+	// a process started or stopped serving a port
+	PortsUpdateTrigger_served_ports_changed PortsUpdateTrigger = 1
+	// a port was exposed, closed or changed its visibility
+	PortsUpdateTrigger_exposed_ports_changed PortsUpdateTrigger = 2
+	// the port configuration (.gitpod.yml) changed
+	PortsUpdateTrigger_port_configs_changed PortsUpdateTrigger = 3
+	// a port was exposed on request, e.g. by the user
+	PortsUpdateTrigger_manual_action PortsUpdateTrigger = 4
+	// the ports management is shutting down
+	PortsUpdateTrigger_ports_shutdown PortsUpdateTrigger = 5
+	// a client opened or closed a tunnel to a port
+	PortsUpdateTrigger_tunnels_changed PortsUpdateTrigger = 6
+	// the proxy of a port restarted, or became degraded or healthy again
+	PortsUpdateTrigger_proxy_health_changed PortsUpdateTrigger = 7
+	// exposures queued while the Gitpod server was unreachable were made, or new ones were queued
+	PortsUpdateTrigger_pending_exposures_changed PortsUpdateTrigger = 8
+	// the sampled connection statistics of exposed ports changed
+	PortsUpdateTrigger_connection_stats_changed PortsUpdateTrigger = 9
+)
+
+var PortsUpdateTrigger_name = map[int32]string{
+	0: "unspecified_trigger",
+	1: "served_ports_changed",
+	2: "exposed_ports_changed",
+	3: "port_configs_changed",
+	4: "manual_action",
+	5: "ports_shutdown",
+	6: "tunnels_changed",
+	7: "proxy_health_changed",
+	8: "pending_exposures_changed",
+	9: "connection_stats_changed",
+}
+
+var PortsUpdateTrigger_value = map[string]int32{
+	"unspecified_trigger":       0,
+	"served_ports_changed":      1,
+	"exposed_ports_changed":     2,
+	"port_configs_changed":      3,
+	"manual_action":             4,
+	"ports_shutdown":            5,
+	"tunnels_changed":           6,
+	"proxy_health_changed":      7,
+	"pending_exposures_changed": 8,
+	"connection_stats_changed":  9,
+}
+
+func (x PortsUpdateTrigger) String() string {
+	return proto.EnumName(PortsUpdateTrigger_name, int32(x))
+}
+
+func (PortsUpdateTrigger) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{4}
 }
 
 type PortVisibility int32
@@ -79,7 +245,40 @@ func (x PortVisibility) String() string {
 }
 
 func (PortVisibility) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{1}
+	return fileDescriptor_dfe4fce6682daf5b, []int{5}
+}
+
+// PortProtocol is the protocol spoken on a port, which decides how supervisor proxies a port
+// that listens on localhost only. It does not affect how the port is served on its public URL.
+type PortProtocol int32
+
+const (
+	PortProtocol_http PortProtocol = 0
+	PortProtocol_tcp  PortProtocol = 1
+	PortProtocol_udp  PortProtocol = 2
+	PortProtocol_grpc PortProtocol = 3
+)
+
+var PortProtocol_name = map[int32]string{
+	0: "http",
+	1: "tcp",
+	2: "udp",
+	3: "grpc",
+}
+
+var PortProtocol_value = map[string]int32{
+	"http": 0,
+	"tcp":  1,
+	"udp":  2,
+	"grpc": 3,
+}
+
+func (x PortProtocol) String() string {
+	return proto.EnumName(PortProtocol_name, int32(x))
+}
+
+func (PortProtocol) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{6}
 }
 
 type OnPortExposedAction int32
@@ -113,7 +312,38 @@ func (x OnPortExposedAction) String() string {
 }
 
 func (OnPortExposedAction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{2}
+	return fileDescriptor_dfe4fce6682daf5b, []int{7}
+}
+
+type PortConfigSource int32
+
+const (
+	// workspace_config is the port configuration the workspace was created with
+	PortConfigSource_workspace_config PortConfigSource = 0
+	// instance_config is the port configuration read from the .gitpod.yml in the workspace
+	PortConfigSource_instance_config PortConfigSource = 1
+	// organization_config is the port policy of the organization the workspace belongs to
+	PortConfigSource_organization_config PortConfigSource = 2
+)
+
+var PortConfigSource_name = map[int32]string{
+	0: "workspace_config",
+	1: "instance_config",
+	2: "organization_config",
+}
+
+var PortConfigSource_value = map[string]int32{
+	"workspace_config":    0,
+	"instance_config":     1,
+	"organization_config": 2,
+}
+
+func (x PortConfigSource) String() string {
+	return proto.EnumName(PortConfigSource_name, int32(x))
+}
+
+func (PortConfigSource) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{8}
 }
 
 type TaskState int32
@@ -141,7 +371,7 @@ func (x TaskState) String() string {
 }
 
 func (TaskState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{3}
+	return fileDescriptor_dfe4fce6682daf5b, []int{9}
 }
 
 type SupervisorStatusRequest struct {
@@ -214,6 +444,167 @@ func (m *SupervisorStatusResponse) GetOk() bool {
 	return false
 }
 
+type HealthStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthStatusRequest) Reset()         { *m = HealthStatusRequest{} }
+func (m *HealthStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*HealthStatusRequest) ProtoMessage()    {}
+func (*HealthStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{2}
+}
+
+func (m *HealthStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthStatusRequest.Unmarshal(m, b)
+}
+func (m *HealthStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *HealthStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthStatusRequest.Merge(m, src)
+}
+func (m *HealthStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_HealthStatusRequest.Size(m)
+}
+func (m *HealthStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthStatusRequest proto.InternalMessageInfo
+
+type HealthStatusResponse struct {
+	// state is the worst state of all subsystems
+	State                HealthState        `protobuf:"varint,1,opt,name=state,proto3,enum=supervisor.HealthState" json:"state,omitempty"`
+	Subsystems           []*SubsystemHealth `protobuf:"bytes,2,rep,name=subsystems,proto3" json:"subsystems,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *HealthStatusResponse) Reset()         { *m = HealthStatusResponse{} }
+func (m *HealthStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*HealthStatusResponse) ProtoMessage()    {}
+func (*HealthStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{3}
+}
+
+func (m *HealthStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthStatusResponse.Unmarshal(m, b)
+}
+func (m *HealthStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *HealthStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthStatusResponse.Merge(m, src)
+}
+func (m *HealthStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_HealthStatusResponse.Size(m)
+}
+func (m *HealthStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthStatusResponse proto.InternalMessageInfo
+
+func (m *HealthStatusResponse) GetState() HealthState {
+	if m != nil {
+		return m.State
+	}
+	return HealthState_health_starting
+}
+
+func (m *HealthStatusResponse) GetSubsystems() []*SubsystemHealth {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+type SubsystemHealth struct {
+	Name  string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State HealthState `protobuf:"varint,2,opt,name=state,proto3,enum=supervisor.HealthState" json:"state,omitempty"`
+	// since is when the subsystem entered its state
+	Since *timestamp.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	// last_error is the last error the subsystem reported, which may be resolved by now
+	LastError     string               `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastErrorTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=last_error_time,json=lastErrorTime,proto3" json:"last_error_time,omitempty"`
+	// restarts is how often the subsystem was restarted, e.g. the IDE after it crashed
+	Restarts             uint32   `protobuf:"varint,6,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubsystemHealth) Reset()         { *m = SubsystemHealth{} }
+func (m *SubsystemHealth) String() string { return proto.CompactTextString(m) }
+func (*SubsystemHealth) ProtoMessage()    {}
+func (*SubsystemHealth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{4}
+}
+
+func (m *SubsystemHealth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubsystemHealth.Unmarshal(m, b)
+}
+func (m *SubsystemHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubsystemHealth.Marshal(b, m, deterministic)
+}
+func (m *SubsystemHealth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubsystemHealth.Merge(m, src)
+}
+func (m *SubsystemHealth) XXX_Size() int {
+	return xxx_messageInfo_SubsystemHealth.Size(m)
+}
+func (m *SubsystemHealth) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubsystemHealth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubsystemHealth proto.InternalMessageInfo
+
+func (m *SubsystemHealth) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SubsystemHealth) GetState() HealthState {
+	if m != nil {
+		return m.State
+	}
+	return HealthState_health_starting
+}
+
+func (m *SubsystemHealth) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *SubsystemHealth) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *SubsystemHealth) GetLastErrorTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastErrorTime
+	}
+	return nil
+}
+
+func (m *SubsystemHealth) GetRestarts() uint32 {
+	if m != nil {
+		return m.Restarts
+	}
+	return 0
+}
+
 type IDEStatusRequest struct {
 	// if true this request will return either when it times out or when the workspace IDE
 	// has become available.
@@ -227,7 +618,7 @@ func (m *IDEStatusRequest) Reset()         { *m = IDEStatusRequest{} }
 func (m *IDEStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*IDEStatusRequest) ProtoMessage()    {}
 func (*IDEStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{2}
+	return fileDescriptor_dfe4fce6682daf5b, []int{5}
 }
 
 func (m *IDEStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -256,17 +647,20 @@ func (m *IDEStatusRequest) GetWait() bool {
 }
 
 type IDEStatusResponse struct {
-	Ok                   bool     `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// ok is true once the IDE and, if the workspace runs one, the desktop IDE backend are ready
+	Ok bool `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	// desktop is the status of the desktop IDE backend running next to the IDE, if any
+	Desktop              *IDEStatusResponse_DesktopStatus `protobuf:"bytes,2,opt,name=desktop,proto3" json:"desktop,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
 }
 
 func (m *IDEStatusResponse) Reset()         { *m = IDEStatusResponse{} }
 func (m *IDEStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*IDEStatusResponse) ProtoMessage()    {}
 func (*IDEStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{3}
+	return fileDescriptor_dfe4fce6682daf5b, []int{6}
 }
 
 func (m *IDEStatusResponse) XXX_Unmarshal(b []byte) error {
@@ -294,6 +688,52 @@ func (m *IDEStatusResponse) GetOk() bool {
 	return false
 }
 
+func (m *IDEStatusResponse) GetDesktop() *IDEStatusResponse_DesktopStatus {
+	if m != nil {
+		return m.Desktop
+	}
+	return nil
+}
+
+type IDEStatusResponse_DesktopStatus struct {
+	Ok                   bool     `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IDEStatusResponse_DesktopStatus) Reset()         { *m = IDEStatusResponse_DesktopStatus{} }
+func (m *IDEStatusResponse_DesktopStatus) String() string { return proto.CompactTextString(m) }
+func (*IDEStatusResponse_DesktopStatus) ProtoMessage()    {}
+func (*IDEStatusResponse_DesktopStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{6, 0}
+}
+
+func (m *IDEStatusResponse_DesktopStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IDEStatusResponse_DesktopStatus.Unmarshal(m, b)
+}
+func (m *IDEStatusResponse_DesktopStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IDEStatusResponse_DesktopStatus.Marshal(b, m, deterministic)
+}
+func (m *IDEStatusResponse_DesktopStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IDEStatusResponse_DesktopStatus.Merge(m, src)
+}
+func (m *IDEStatusResponse_DesktopStatus) XXX_Size() int {
+	return xxx_messageInfo_IDEStatusResponse_DesktopStatus.Size(m)
+}
+func (m *IDEStatusResponse_DesktopStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_IDEStatusResponse_DesktopStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IDEStatusResponse_DesktopStatus proto.InternalMessageInfo
+
+func (m *IDEStatusResponse_DesktopStatus) GetOk() bool {
+	if m != nil {
+		return m.Ok
+	}
+	return false
+}
+
 type ContentStatusRequest struct {
 	// if true this request will return either when it times out or when the workspace content
 	// has become available.
@@ -307,7 +747,7 @@ func (m *ContentStatusRequest) Reset()         { *m = ContentStatusRequest{} }
 func (m *ContentStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*ContentStatusRequest) ProtoMessage()    {}
 func (*ContentStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{4}
+	return fileDescriptor_dfe4fce6682daf5b, []int{7}
 }
 
 func (m *ContentStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -349,7 +789,7 @@ func (m *ContentStatusResponse) Reset()         { *m = ContentStatusResponse{} }
 func (m *ContentStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*ContentStatusResponse) ProtoMessage()    {}
 func (*ContentStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{5}
+	return fileDescriptor_dfe4fce6682daf5b, []int{8}
 }
 
 func (m *ContentStatusResponse) XXX_Unmarshal(b []byte) error {
@@ -384,90 +824,327 @@ func (m *ContentStatusResponse) GetSource() ContentSource {
 	return ContentSource_from_other
 }
 
-type BackupStatusRequest struct {
+type ContentProgressRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BackupStatusRequest) Reset()         { *m = BackupStatusRequest{} }
-func (m *BackupStatusRequest) String() string { return proto.CompactTextString(m) }
-func (*BackupStatusRequest) ProtoMessage()    {}
-func (*BackupStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{6}
+func (m *ContentProgressRequest) Reset()         { *m = ContentProgressRequest{} }
+func (m *ContentProgressRequest) String() string { return proto.CompactTextString(m) }
+func (*ContentProgressRequest) ProtoMessage()    {}
+func (*ContentProgressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{9}
 }
 
-func (m *BackupStatusRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BackupStatusRequest.Unmarshal(m, b)
+func (m *ContentProgressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContentProgressRequest.Unmarshal(m, b)
 }
-func (m *BackupStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BackupStatusRequest.Marshal(b, m, deterministic)
+func (m *ContentProgressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContentProgressRequest.Marshal(b, m, deterministic)
 }
-func (m *BackupStatusRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BackupStatusRequest.Merge(m, src)
+func (m *ContentProgressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContentProgressRequest.Merge(m, src)
 }
-func (m *BackupStatusRequest) XXX_Size() int {
-	return xxx_messageInfo_BackupStatusRequest.Size(m)
+func (m *ContentProgressRequest) XXX_Size() int {
+	return xxx_messageInfo_ContentProgressRequest.Size(m)
 }
-func (m *BackupStatusRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_BackupStatusRequest.DiscardUnknown(m)
+func (m *ContentProgressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContentProgressRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BackupStatusRequest proto.InternalMessageInfo
+var xxx_messageInfo_ContentProgressRequest proto.InternalMessageInfo
 
-type BackupStatusResponse struct {
-	CanaryAvailable      bool     `protobuf:"varint,1,opt,name=canary_available,json=canaryAvailable,proto3" json:"canary_available,omitempty"`
+type ContentProgressResponse struct {
+	Phase ContentPhase `protobuf:"varint,1,opt,name=phase,proto3,enum=supervisor.ContentPhase" json:"phase,omitempty"`
+	// percentage is the completion of the phase from 0 to 100, or -1 if unknown
+	Percentage int32 `protobuf:"varint,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// bytes_done is the number of bytes downloaded in the phase so far
+	BytesDone uint64 `protobuf:"varint,3,opt,name=bytes_done,json=bytesDone,proto3" json:"bytes_done,omitempty"`
+	// bytes_total is the number of bytes to download in the phase, 0 if unknown
+	BytesTotal uint64 `protobuf:"varint,4,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	// message describes the current step of the phase, e.g. "Receiving objects" while cloning
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// error describes why the content initialization failed
+	Error                string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BackupStatusResponse) Reset()         { *m = BackupStatusResponse{} }
-func (m *BackupStatusResponse) String() string { return proto.CompactTextString(m) }
-func (*BackupStatusResponse) ProtoMessage()    {}
-func (*BackupStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{7}
+func (m *ContentProgressResponse) Reset()         { *m = ContentProgressResponse{} }
+func (m *ContentProgressResponse) String() string { return proto.CompactTextString(m) }
+func (*ContentProgressResponse) ProtoMessage()    {}
+func (*ContentProgressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{10}
 }
 
-func (m *BackupStatusResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BackupStatusResponse.Unmarshal(m, b)
+func (m *ContentProgressResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContentProgressResponse.Unmarshal(m, b)
 }
-func (m *BackupStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BackupStatusResponse.Marshal(b, m, deterministic)
+func (m *ContentProgressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContentProgressResponse.Marshal(b, m, deterministic)
 }
-func (m *BackupStatusResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BackupStatusResponse.Merge(m, src)
+func (m *ContentProgressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContentProgressResponse.Merge(m, src)
 }
-func (m *BackupStatusResponse) XXX_Size() int {
-	return xxx_messageInfo_BackupStatusResponse.Size(m)
+func (m *ContentProgressResponse) XXX_Size() int {
+	return xxx_messageInfo_ContentProgressResponse.Size(m)
 }
-func (m *BackupStatusResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_BackupStatusResponse.DiscardUnknown(m)
+func (m *ContentProgressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContentProgressResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BackupStatusResponse proto.InternalMessageInfo
+var xxx_messageInfo_ContentProgressResponse proto.InternalMessageInfo
 
-func (m *BackupStatusResponse) GetCanaryAvailable() bool {
+func (m *ContentProgressResponse) GetPhase() ContentPhase {
 	if m != nil {
-		return m.CanaryAvailable
+		return m.Phase
 	}
-	return false
+	return ContentPhase_content_pending
 }
 
-type PortsStatusRequest struct {
-	// if observe is true, we'll return a stream of changes rather than just the
-	// current state of affairs.
-	Observe              bool     `protobuf:"varint,1,opt,name=observe,proto3" json:"observe,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ContentProgressResponse) GetPercentage() int32 {
+	if m != nil {
+		return m.Percentage
+	}
+	return 0
 }
 
-func (m *PortsStatusRequest) Reset()         { *m = PortsStatusRequest{} }
-func (m *PortsStatusRequest) String() string { return proto.CompactTextString(m) }
-func (*PortsStatusRequest) ProtoMessage()    {}
-func (*PortsStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{8}
+func (m *ContentProgressResponse) GetBytesDone() uint64 {
+	if m != nil {
+		return m.BytesDone
+	}
+	return 0
+}
+
+func (m *ContentProgressResponse) GetBytesTotal() uint64 {
+	if m != nil {
+		return m.BytesTotal
+	}
+	return 0
+}
+
+func (m *ContentProgressResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ContentProgressResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DotfilesStatusRequest struct {
+	// if true this request will return either when it times out or when the dotfiles
+	// were installed or failed to install.
+	Wait                 bool     `protobuf:"varint,1,opt,name=wait,proto3" json:"wait,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DotfilesStatusRequest) Reset()         { *m = DotfilesStatusRequest{} }
+func (m *DotfilesStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*DotfilesStatusRequest) ProtoMessage()    {}
+func (*DotfilesStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{11}
+}
+
+func (m *DotfilesStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DotfilesStatusRequest.Unmarshal(m, b)
+}
+func (m *DotfilesStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DotfilesStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *DotfilesStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DotfilesStatusRequest.Merge(m, src)
+}
+func (m *DotfilesStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_DotfilesStatusRequest.Size(m)
+}
+func (m *DotfilesStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DotfilesStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DotfilesStatusRequest proto.InternalMessageInfo
+
+func (m *DotfilesStatusRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+type DotfilesStatusResponse struct {
+	// repository is the dotfiles repository configured by the user
+	Repository string        `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	Phase      DotfilesPhase `protobuf:"varint,2,opt,name=phase,proto3,enum=supervisor.DotfilesPhase" json:"phase,omitempty"`
+	// install_script is the script of the repository which installs the dotfiles, relative to the repository.
+	// Empty if the repository has no install script, in which case its files are linked into the home directory.
+	InstallScript string `protobuf:"bytes,3,opt,name=install_script,json=installScript,proto3" json:"install_script,omitempty"`
+	// error describes why the dotfiles failed to install
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// log_location is the file the output of cloning the repository and of the install script is written to
+	LogLocation          string   `protobuf:"bytes,5,opt,name=log_location,json=logLocation,proto3" json:"log_location,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DotfilesStatusResponse) Reset()         { *m = DotfilesStatusResponse{} }
+func (m *DotfilesStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*DotfilesStatusResponse) ProtoMessage()    {}
+func (*DotfilesStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{12}
+}
+
+func (m *DotfilesStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DotfilesStatusResponse.Unmarshal(m, b)
+}
+func (m *DotfilesStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DotfilesStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *DotfilesStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DotfilesStatusResponse.Merge(m, src)
+}
+func (m *DotfilesStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_DotfilesStatusResponse.Size(m)
+}
+func (m *DotfilesStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DotfilesStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DotfilesStatusResponse proto.InternalMessageInfo
+
+func (m *DotfilesStatusResponse) GetRepository() string {
+	if m != nil {
+		return m.Repository
+	}
+	return ""
+}
+
+func (m *DotfilesStatusResponse) GetPhase() DotfilesPhase {
+	if m != nil {
+		return m.Phase
+	}
+	return DotfilesPhase_dotfiles_none
+}
+
+func (m *DotfilesStatusResponse) GetInstallScript() string {
+	if m != nil {
+		return m.InstallScript
+	}
+	return ""
+}
+
+func (m *DotfilesStatusResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DotfilesStatusResponse) GetLogLocation() string {
+	if m != nil {
+		return m.LogLocation
+	}
+	return ""
+}
+
+type BackupStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupStatusRequest) Reset()         { *m = BackupStatusRequest{} }
+func (m *BackupStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupStatusRequest) ProtoMessage()    {}
+func (*BackupStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{13}
+}
+
+func (m *BackupStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupStatusRequest.Unmarshal(m, b)
+}
+func (m *BackupStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupStatusRequest.Merge(m, src)
+}
+func (m *BackupStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupStatusRequest.Size(m)
+}
+func (m *BackupStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupStatusRequest proto.InternalMessageInfo
+
+type BackupStatusResponse struct {
+	CanaryAvailable      bool     `protobuf:"varint,1,opt,name=canary_available,json=canaryAvailable,proto3" json:"canary_available,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupStatusResponse) Reset()         { *m = BackupStatusResponse{} }
+func (m *BackupStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupStatusResponse) ProtoMessage()    {}
+func (*BackupStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{14}
+}
+
+func (m *BackupStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupStatusResponse.Unmarshal(m, b)
+}
+func (m *BackupStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupStatusResponse.Merge(m, src)
+}
+func (m *BackupStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupStatusResponse.Size(m)
+}
+func (m *BackupStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupStatusResponse proto.InternalMessageInfo
+
+func (m *BackupStatusResponse) GetCanaryAvailable() bool {
+	if m != nil {
+		return m.CanaryAvailable
+	}
+	return false
+}
+
+type PortsStatusRequest struct {
+	// if observe is true, we'll return a stream of changes rather than just the
+	// current state of affairs.
+	Observe bool `protobuf:"varint,1,opt,name=observe,proto3" json:"observe,omitempty"`
+	// client identifies the observing client (e.g. "vscode" or "gp-cli") when listing the ports subscribers.
+	Client string `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	// resume_token is the resume token of the last response a reconnecting client received. If set and the
+	// changes since then are still known, the stream starts with those changes rather than with all ports.
+	ResumeToken          string   `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortsStatusRequest) Reset()         { *m = PortsStatusRequest{} }
+func (m *PortsStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*PortsStatusRequest) ProtoMessage()    {}
+func (*PortsStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{15}
 }
 
 func (m *PortsStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -495,6 +1172,20 @@ func (m *PortsStatusRequest) GetObserve() bool {
 	return false
 }
 
+func (m *PortsStatusRequest) GetClient() string {
+	if m != nil {
+		return m.Client
+	}
+	return ""
+}
+
+func (m *PortsStatusRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
 // PortsStatusResponse indicates that information about some ports has been changed.
 // First event provides information about all ports accessible via `added` field.
 // Subsequent events from the same stream provides the diff against the previous event.
@@ -507,17 +1198,34 @@ type PortsStatusResponse struct {
 	Updated []*PortsStatus `protobuf:"bytes,2,rep,name=updated,proto3" json:"updated,omitempty"`
 	// Omitted for first event.
 	// Subsequent events from the same stream provides information about removed ports.
-	Removed              []uint32 `protobuf:"varint,3,rep,packed,name=removed,proto3" json:"removed,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Removed []uint32 `protobuf:"varint,3,rep,packed,name=removed,proto3" json:"removed,omitempty"`
+	// Provided with the first event and whenever problems in the port configuration change.
+	// If set, it replaces all previously received diagnostics.
+	Diagnostics *PortConfigDiagnostics `protobuf:"bytes,4,opt,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	// Omitted for first event.
+	// Subsequent events from the same stream provide what caused the change. If several changes
+	// were coalesced into one event, this is the cause of the latest change, see also triggers.
+	Trigger PortsUpdateTrigger `protobuf:"varint,5,opt,name=trigger,proto3,enum=supervisor.PortsUpdateTrigger" json:"trigger,omitempty"`
+	// revision numbers the state of the ports after this event. Revisions increase monotonically.
+	Revision uint64 `protobuf:"varint,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	// resume_token lets a client which reconnects continue the stream after this event, see PortsStatusRequest.
+	ResumeToken string `protobuf:"bytes,7,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// resumed is true on the first event of a resumed stream. This event carries no changes and the resume
+	// token the client resumed with. The changes the client missed follow as regular events.
+	Resumed bool `protobuf:"varint,8,opt,name=resumed,proto3" json:"resumed,omitempty"`
+	// Only set if changes with different causes were coalesced into this event, e.g. because the client fell behind.
+	// triggers maps each added, updated or removed port to the cause of its latest change.
+	Triggers             map[uint32]PortsUpdateTrigger `protobuf:"bytes,9,rep,name=triggers,proto3" json:"triggers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=supervisor.PortsUpdateTrigger"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *PortsStatusResponse) Reset()         { *m = PortsStatusResponse{} }
 func (m *PortsStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*PortsStatusResponse) ProtoMessage()    {}
 func (*PortsStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{9}
+	return fileDescriptor_dfe4fce6682daf5b, []int{16}
 }
 
 func (m *PortsStatusResponse) XXX_Unmarshal(b []byte) error {
@@ -559,6 +1267,48 @@ func (m *PortsStatusResponse) GetRemoved() []uint32 {
 	return nil
 }
 
+func (m *PortsStatusResponse) GetDiagnostics() *PortConfigDiagnostics {
+	if m != nil {
+		return m.Diagnostics
+	}
+	return nil
+}
+
+func (m *PortsStatusResponse) GetTrigger() PortsUpdateTrigger {
+	if m != nil {
+		return m.Trigger
+	}
+	return PortsUpdateTrigger_unspecified_trigger
+}
+
+func (m *PortsStatusResponse) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *PortsStatusResponse) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+func (m *PortsStatusResponse) GetResumed() bool {
+	if m != nil {
+		return m.Resumed
+	}
+	return false
+}
+
+func (m *PortsStatusResponse) GetTriggers() map[uint32]PortsUpdateTrigger {
+	if m != nil {
+		return m.Triggers
+	}
+	return nil
+}
+
 type PortsStatus struct {
 	// local_port is the port a service actually bound to. Some services bind
 	// to localhost:<port>, in which case they cannot be made accessible from
@@ -571,17 +1321,83 @@ type PortsStatus struct {
 	Served bool `protobuf:"varint,4,opt,name=served,proto3" json:"served,omitempty"`
 	// Exposed provides information when a port is exposed. If this field isn't set,
 	// the port is not available from outside the workspace (i.e. the internet).
-	Exposed              *PortsStatus_ExposedPortInfo `protobuf:"bytes,5,opt,name=exposed,proto3" json:"exposed,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+	Exposed *PortsStatus_ExposedPortInfo `protobuf:"bytes,5,opt,name=exposed,proto3" json:"exposed,omitempty"`
+	// config describes the port configuration which applies to this port. If this field isn't set,
+	// the port is not configured and defaults apply.
+	Config *PortConfigMatch `protobuf:"bytes,6,opt,name=config,proto3" json:"config,omitempty"`
+	// detected_as is the name of the well-known dev server serving this port (e.g. "vite" or "jupyter").
+	// Unconfigured ports of a detected dev server get its defaults. Empty if no dev server was detected.
+	DetectedAs string `protobuf:"bytes,7,opt,name=detected_as,json=detectedAs,proto3" json:"detected_as,omitempty"`
+	// group identifies the task whose process serves this port, i.e. the task id as in TasksStatus.
+	// Empty if the port is not served by a task.
+	Group string `protobuf:"bytes,8,opt,name=group,proto3" json:"group,omitempty"`
+	// pending_public is true if the port is to become public but waits for the user's approval,
+	// see ControlService.ApprovePublicPort. Until then the port is private.
+	PendingPublic bool `protobuf:"varint,9,opt,name=pending_public,json=pendingPublic,proto3" json:"pending_public,omitempty"`
+	// would_expose is only set if the supervisor runs the ports in dry-run mode. It describes the exposure
+	// the supervisor would have made for this port, but did not.
+	WouldExpose *PortsStatus_ExposedPortInfo `protobuf:"bytes,10,opt,name=would_expose,json=wouldExpose,proto3" json:"would_expose,omitempty"`
+	// tunneled is true while a client tunnels to this port through the supervisor, see PortService.Tunnel.
+	Tunneled bool `protobuf:"varint,11,opt,name=tunneled,proto3" json:"tunneled,omitempty"`
+	// compose_service is the name of the Docker Compose service serving this port. Empty if the port
+	// is not served by a compose service.
+	ComposeService string `protobuf:"bytes,12,opt,name=compose_service,json=composeService,proto3" json:"compose_service,omitempty"`
+	// kubernetes_service is the <namespace>/<name> of the service of a Kubernetes cluster in the workspace,
+	// e.g. k3s or kind, which serves this port through a node port or kubectl port-forward. Empty otherwise.
+	KubernetesService string `protobuf:"bytes,13,opt,name=kubernetes_service,json=kubernetesService,proto3" json:"kubernetes_service,omitempty"`
+	// debuggable is true if the port speaks a debugger protocol. Unless configured explicitly, debug ports
+	// are private and their on_exposed action is ignore.
+	Debuggable bool `protobuf:"varint,14,opt,name=debuggable,proto3" json:"debuggable,omitempty"`
+	// debugger is the debugger protocol spoken on this port: node-inspector, jdwp or delve
+	Debugger string `protobuf:"bytes,15,opt,name=debugger,proto3" json:"debugger,omitempty"`
+	// debug_url is what debugger clients attach with: a devtools:// URL for the Node.js inspector,
+	// a localhost:<port> address for JDWP and delve.
+	DebugUrl string `protobuf:"bytes,16,opt,name=debug_url,json=debugUrl,proto3" json:"debug_url,omitempty"`
+	// policy_violation explains why the port is neither exposed nor proxied automatically, e.g. because
+	// it is on the operator's denylist or denied by the ports policy of the .gitpod.yml. Empty otherwise.
+	PolicyViolation string `protobuf:"bytes,17,opt,name=policy_violation,json=policyViolation,proto3" json:"policy_violation,omitempty"`
+	// proxy is only set if the port is served on localhost only and hence proxied to its global port.
+	// The error counters are refreshed whenever the port status is updated, a change of the degraded
+	// state or a restart trigger an update.
+	Proxy *PortsStatus_ProxyStatus `protobuf:"bytes,18,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	// name is the name the port is configured with in the .gitpod.yml, e.g. "api". It can be used instead of
+	// the port number, see PortService.ResolvePort. Empty if the port has no name.
+	Name string `protobuf:"bytes,19,opt,name=name,proto3" json:"name,omitempty"`
+	// process is the command name of the process serving this port, e.g. "node". Empty if the port
+	// is not served or its process is unknown, e.g. because it runs as another user.
+	Process string `protobuf:"bytes,20,opt,name=process,proto3" json:"process,omitempty"`
+	// pending_exposure is true if exposing this port failed because the Gitpod server was unreachable.
+	// The exposure is made as soon as the server is reachable again.
+	PendingExposure bool `protobuf:"varint,21,opt,name=pending_exposure,json=pendingExposure,proto3" json:"pending_exposure,omitempty"`
+	// protocol is the protocol configured for this port, http unless configured otherwise.
+	Protocol PortProtocol `protobuf:"varint,22,opt,name=protocol,proto3,enum=supervisor.PortProtocol" json:"protocol,omitempty"`
+	// privileged_remap is true if this port is below 1024 and served on all interfaces, but proxied to
+	// the high global_port because the workspace proxy cannot route to privileged ports.
+	PrivilegedRemap bool `protobuf:"varint,23,opt,name=privileged_remap,json=privilegedRemap,proto3" json:"privileged_remap,omitempty"`
+	// remap_suggestion is set if the configured global port of this port is served by another process.
+	// Clients offer the remap to the user and accept it with ControlService.AcceptPortRemap, which
+	// exposes the port on the suggested port. Until then the port is not proxied.
+	RemapSuggestion *PortsStatus_RemapSuggestion `protobuf:"bytes,24,opt,name=remap_suggestion,json=remapSuggestion,proto3" json:"remap_suggestion,omitempty"`
+	// connections are the connection statistics of an exposed port. They are sampled periodically,
+	// and hence lag behind the actual connections.
+	Connections *PortsStatus_ConnectionStats `protobuf:"bytes,25,opt,name=connections,proto3" json:"connections,omitempty"`
+	// network_namespace labels the network namespace serving the port if it is not the workspace's, i.e. the short ID
+	// of the container or otherwise the command name of the namespace's first process. Such ports are auto-exposed only
+	// if supervisor is configured to auto-expose the namespace.
+	NetworkNamespace string `protobuf:"bytes,26,opt,name=network_namespace,json=networkNamespace,proto3" json:"network_namespace,omitempty"`
+	// announced is true if supervisor notifies the user once the service of the task serving this port is ready,
+	// see group. The on_exposed action of announced ports is ignore, s.t. clients do not notify twice.
+	Announced            bool     `protobuf:"varint,27,opt,name=announced,proto3" json:"announced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *PortsStatus) Reset()         { *m = PortsStatus{} }
 func (m *PortsStatus) String() string { return proto.CompactTextString(m) }
 func (*PortsStatus) ProtoMessage()    {}
 func (*PortsStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{10}
+	return fileDescriptor_dfe4fce6682daf5b, []int{17}
 }
 
 func (m *PortsStatus) XXX_Unmarshal(b []byte) error {
@@ -630,283 +1446,1473 @@ func (m *PortsStatus) GetExposed() *PortsStatus_ExposedPortInfo {
 	return nil
 }
 
-type PortsStatus_ExposedPortInfo struct {
-	// public determines if the port is available without authentication or not
-	Visibility PortVisibility `protobuf:"varint,1,opt,name=visibility,proto3,enum=supervisor.PortVisibility" json:"visibility,omitempty"`
-	// url is the URL at which the port is available
-	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	// action hint on expose
-	OnExposed            OnPortExposedAction `protobuf:"varint,3,opt,name=on_exposed,json=onExposed,proto3,enum=supervisor.OnPortExposedAction" json:"on_exposed,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
-}
-
-func (m *PortsStatus_ExposedPortInfo) Reset()         { *m = PortsStatus_ExposedPortInfo{} }
-func (m *PortsStatus_ExposedPortInfo) String() string { return proto.CompactTextString(m) }
-func (*PortsStatus_ExposedPortInfo) ProtoMessage()    {}
-func (*PortsStatus_ExposedPortInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{10, 0}
-}
-
-func (m *PortsStatus_ExposedPortInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PortsStatus_ExposedPortInfo.Unmarshal(m, b)
-}
-func (m *PortsStatus_ExposedPortInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PortsStatus_ExposedPortInfo.Marshal(b, m, deterministic)
-}
-func (m *PortsStatus_ExposedPortInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PortsStatus_ExposedPortInfo.Merge(m, src)
-}
-func (m *PortsStatus_ExposedPortInfo) XXX_Size() int {
-	return xxx_messageInfo_PortsStatus_ExposedPortInfo.Size(m)
-}
-func (m *PortsStatus_ExposedPortInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_PortsStatus_ExposedPortInfo.DiscardUnknown(m)
+func (m *PortsStatus) GetConfig() *PortConfigMatch {
+	if m != nil {
+		return m.Config
+	}
+	return nil
 }
 
-var xxx_messageInfo_PortsStatus_ExposedPortInfo proto.InternalMessageInfo
-
-func (m *PortsStatus_ExposedPortInfo) GetVisibility() PortVisibility {
+func (m *PortsStatus) GetDetectedAs() string {
 	if m != nil {
-		return m.Visibility
+		return m.DetectedAs
 	}
-	return PortVisibility_private
+	return ""
 }
 
-func (m *PortsStatus_ExposedPortInfo) GetUrl() string {
+func (m *PortsStatus) GetGroup() string {
 	if m != nil {
-		return m.Url
+		return m.Group
 	}
 	return ""
 }
 
-func (m *PortsStatus_ExposedPortInfo) GetOnExposed() OnPortExposedAction {
+func (m *PortsStatus) GetPendingPublic() bool {
 	if m != nil {
-		return m.OnExposed
+		return m.PendingPublic
+	}
+	return false
+}
+
+func (m *PortsStatus) GetWouldExpose() *PortsStatus_ExposedPortInfo {
+	if m != nil {
+		return m.WouldExpose
+	}
+	return nil
+}
+
+func (m *PortsStatus) GetTunneled() bool {
+	if m != nil {
+		return m.Tunneled
+	}
+	return false
+}
+
+func (m *PortsStatus) GetComposeService() string {
+	if m != nil {
+		return m.ComposeService
+	}
+	return ""
+}
+
+func (m *PortsStatus) GetKubernetesService() string {
+	if m != nil {
+		return m.KubernetesService
+	}
+	return ""
+}
+
+func (m *PortsStatus) GetDebuggable() bool {
+	if m != nil {
+		return m.Debuggable
+	}
+	return false
+}
+
+func (m *PortsStatus) GetDebugger() string {
+	if m != nil {
+		return m.Debugger
+	}
+	return ""
+}
+
+func (m *PortsStatus) GetDebugUrl() string {
+	if m != nil {
+		return m.DebugUrl
+	}
+	return ""
+}
+
+func (m *PortsStatus) GetPolicyViolation() string {
+	if m != nil {
+		return m.PolicyViolation
+	}
+	return ""
+}
+
+func (m *PortsStatus) GetProxy() *PortsStatus_ProxyStatus {
+	if m != nil {
+		return m.Proxy
+	}
+	return nil
+}
+
+func (m *PortsStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PortsStatus) GetProcess() string {
+	if m != nil {
+		return m.Process
+	}
+	return ""
+}
+
+func (m *PortsStatus) GetPendingExposure() bool {
+	if m != nil {
+		return m.PendingExposure
+	}
+	return false
+}
+
+func (m *PortsStatus) GetProtocol() PortProtocol {
+	if m != nil {
+		return m.Protocol
+	}
+	return PortProtocol_http
+}
+
+func (m *PortsStatus) GetPrivilegedRemap() bool {
+	if m != nil {
+		return m.PrivilegedRemap
+	}
+	return false
+}
+
+func (m *PortsStatus) GetRemapSuggestion() *PortsStatus_RemapSuggestion {
+	if m != nil {
+		return m.RemapSuggestion
+	}
+	return nil
+}
+
+func (m *PortsStatus) GetConnections() *PortsStatus_ConnectionStats {
+	if m != nil {
+		return m.Connections
+	}
+	return nil
+}
+
+func (m *PortsStatus) GetNetworkNamespace() string {
+	if m != nil {
+		return m.NetworkNamespace
+	}
+	return ""
+}
+
+func (m *PortsStatus) GetAnnounced() bool {
+	if m != nil {
+		return m.Announced
+	}
+	return false
+}
+
+type PortsStatus_ExposedPortInfo struct {
+	// public determines if the port is available without authentication or not
+	Visibility PortVisibility `protobuf:"varint,1,opt,name=visibility,proto3,enum=supervisor.PortVisibility" json:"visibility,omitempty"`
+	// url is the URL at which the port is available
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// action hint on expose
+	OnExposed            OnPortExposedAction `protobuf:"varint,3,opt,name=on_exposed,json=onExposed,proto3,enum=supervisor.OnPortExposedAction" json:"on_exposed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PortsStatus_ExposedPortInfo) Reset()         { *m = PortsStatus_ExposedPortInfo{} }
+func (m *PortsStatus_ExposedPortInfo) String() string { return proto.CompactTextString(m) }
+func (*PortsStatus_ExposedPortInfo) ProtoMessage()    {}
+func (*PortsStatus_ExposedPortInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{17, 0}
+}
+
+func (m *PortsStatus_ExposedPortInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortsStatus_ExposedPortInfo.Unmarshal(m, b)
+}
+func (m *PortsStatus_ExposedPortInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortsStatus_ExposedPortInfo.Marshal(b, m, deterministic)
+}
+func (m *PortsStatus_ExposedPortInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortsStatus_ExposedPortInfo.Merge(m, src)
+}
+func (m *PortsStatus_ExposedPortInfo) XXX_Size() int {
+	return xxx_messageInfo_PortsStatus_ExposedPortInfo.Size(m)
+}
+func (m *PortsStatus_ExposedPortInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortsStatus_ExposedPortInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortsStatus_ExposedPortInfo proto.InternalMessageInfo
+
+func (m *PortsStatus_ExposedPortInfo) GetVisibility() PortVisibility {
+	if m != nil {
+		return m.Visibility
+	}
+	return PortVisibility_private
+}
+
+func (m *PortsStatus_ExposedPortInfo) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *PortsStatus_ExposedPortInfo) GetOnExposed() OnPortExposedAction {
+	if m != nil {
+		return m.OnExposed
 	}
 	return OnPortExposedAction_ignore
 }
 
-type TasksStatusRequest struct {
-	// if observe is true, we'll return a stream of changes rather than just the
-	// current state of affairs.
-	Observe              bool     `protobuf:"varint,1,opt,name=observe,proto3" json:"observe,omitempty"`
+type PortsStatus_ProxyStatus struct {
+	// accept_errors counts the times the proxy stopped accepting connections
+	AcceptErrors uint64 `protobuf:"varint,1,opt,name=accept_errors,json=acceptErrors,proto3" json:"accept_errors,omitempty"`
+	// dial_errors counts the requests which could not be forwarded to the served port
+	DialErrors uint64 `protobuf:"varint,2,opt,name=dial_errors,json=dialErrors,proto3" json:"dial_errors,omitempty"`
+	// restarts counts the times the proxy was restarted after it failed
+	Restarts uint64 `protobuf:"varint,3,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	// degraded is true while the proxy keeps failing, e.g. it cannot be restarted or
+	// the served port does not answer
+	Degraded bool `protobuf:"varint,4,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	// last_error is the latest error of the proxy
+	LastError            string   `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortsStatus_ProxyStatus) Reset()         { *m = PortsStatus_ProxyStatus{} }
+func (m *PortsStatus_ProxyStatus) String() string { return proto.CompactTextString(m) }
+func (*PortsStatus_ProxyStatus) ProtoMessage()    {}
+func (*PortsStatus_ProxyStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{17, 1}
+}
+
+func (m *PortsStatus_ProxyStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortsStatus_ProxyStatus.Unmarshal(m, b)
+}
+func (m *PortsStatus_ProxyStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortsStatus_ProxyStatus.Marshal(b, m, deterministic)
+}
+func (m *PortsStatus_ProxyStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortsStatus_ProxyStatus.Merge(m, src)
+}
+func (m *PortsStatus_ProxyStatus) XXX_Size() int {
+	return xxx_messageInfo_PortsStatus_ProxyStatus.Size(m)
+}
+func (m *PortsStatus_ProxyStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortsStatus_ProxyStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortsStatus_ProxyStatus proto.InternalMessageInfo
+
+func (m *PortsStatus_ProxyStatus) GetAcceptErrors() uint64 {
+	if m != nil {
+		return m.AcceptErrors
+	}
+	return 0
+}
+
+func (m *PortsStatus_ProxyStatus) GetDialErrors() uint64 {
+	if m != nil {
+		return m.DialErrors
+	}
+	return 0
+}
+
+func (m *PortsStatus_ProxyStatus) GetRestarts() uint64 {
+	if m != nil {
+		return m.Restarts
+	}
+	return 0
+}
+
+func (m *PortsStatus_ProxyStatus) GetDegraded() bool {
+	if m != nil {
+		return m.Degraded
+	}
+	return false
+}
+
+func (m *PortsStatus_ProxyStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+type PortsStatus_RemapSuggestion struct {
+	// configured_port is the configured global port, which another process serves
+	ConfiguredPort uint32 `protobuf:"varint,1,opt,name=configured_port,json=configuredPort,proto3" json:"configured_port,omitempty"`
+	// suggested_port is the free global port the port is proxied on once the suggestion is accepted
+	SuggestedPort uint32 `protobuf:"varint,2,opt,name=suggested_port,json=suggestedPort,proto3" json:"suggested_port,omitempty"`
+	// occupied_by is the command name of the process serving the configured port, if known
+	OccupiedBy           string   `protobuf:"bytes,3,opt,name=occupied_by,json=occupiedBy,proto3" json:"occupied_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortsStatus_RemapSuggestion) Reset()         { *m = PortsStatus_RemapSuggestion{} }
+func (m *PortsStatus_RemapSuggestion) String() string { return proto.CompactTextString(m) }
+func (*PortsStatus_RemapSuggestion) ProtoMessage()    {}
+func (*PortsStatus_RemapSuggestion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{17, 2}
+}
+
+func (m *PortsStatus_RemapSuggestion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortsStatus_RemapSuggestion.Unmarshal(m, b)
+}
+func (m *PortsStatus_RemapSuggestion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortsStatus_RemapSuggestion.Marshal(b, m, deterministic)
+}
+func (m *PortsStatus_RemapSuggestion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortsStatus_RemapSuggestion.Merge(m, src)
+}
+func (m *PortsStatus_RemapSuggestion) XXX_Size() int {
+	return xxx_messageInfo_PortsStatus_RemapSuggestion.Size(m)
+}
+func (m *PortsStatus_RemapSuggestion) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortsStatus_RemapSuggestion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortsStatus_RemapSuggestion proto.InternalMessageInfo
+
+func (m *PortsStatus_RemapSuggestion) GetConfiguredPort() uint32 {
+	if m != nil {
+		return m.ConfiguredPort
+	}
+	return 0
+}
+
+func (m *PortsStatus_RemapSuggestion) GetSuggestedPort() uint32 {
+	if m != nil {
+		return m.SuggestedPort
+	}
+	return 0
+}
+
+func (m *PortsStatus_RemapSuggestion) GetOccupiedBy() string {
+	if m != nil {
+		return m.OccupiedBy
+	}
+	return ""
+}
+
+type PortsStatus_ConnectionStats struct {
+	// active is the number of established connections to the port
+	Active uint32 `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	// requests is the number of requests since the port is proxied. Only ports served on localhost
+	// are proxied by supervisor, requests to other ports are not counted.
+	Requests             uint64   `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortsStatus_ConnectionStats) Reset()         { *m = PortsStatus_ConnectionStats{} }
+func (m *PortsStatus_ConnectionStats) String() string { return proto.CompactTextString(m) }
+func (*PortsStatus_ConnectionStats) ProtoMessage()    {}
+func (*PortsStatus_ConnectionStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{17, 3}
+}
+
+func (m *PortsStatus_ConnectionStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortsStatus_ConnectionStats.Unmarshal(m, b)
+}
+func (m *PortsStatus_ConnectionStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortsStatus_ConnectionStats.Marshal(b, m, deterministic)
+}
+func (m *PortsStatus_ConnectionStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortsStatus_ConnectionStats.Merge(m, src)
+}
+func (m *PortsStatus_ConnectionStats) XXX_Size() int {
+	return xxx_messageInfo_PortsStatus_ConnectionStats.Size(m)
+}
+func (m *PortsStatus_ConnectionStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortsStatus_ConnectionStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortsStatus_ConnectionStats proto.InternalMessageInfo
+
+func (m *PortsStatus_ConnectionStats) GetActive() uint32 {
+	if m != nil {
+		return m.Active
+	}
+	return 0
+}
+
+func (m *PortsStatus_ConnectionStats) GetRequests() uint64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+type PortsSubscribersRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortsSubscribersRequest) Reset()         { *m = PortsSubscribersRequest{} }
+func (m *PortsSubscribersRequest) String() string { return proto.CompactTextString(m) }
+func (*PortsSubscribersRequest) ProtoMessage()    {}
+func (*PortsSubscribersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{18}
+}
+
+func (m *PortsSubscribersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortsSubscribersRequest.Unmarshal(m, b)
+}
+func (m *PortsSubscribersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortsSubscribersRequest.Marshal(b, m, deterministic)
+}
+func (m *PortsSubscribersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortsSubscribersRequest.Merge(m, src)
+}
+func (m *PortsSubscribersRequest) XXX_Size() int {
+	return xxx_messageInfo_PortsSubscribersRequest.Size(m)
+}
+func (m *PortsSubscribersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortsSubscribersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortsSubscribersRequest proto.InternalMessageInfo
+
+type PortsSubscribersResponse struct {
+	Subscribers          []*PortsSubscriber `protobuf:"bytes,1,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PortsSubscribersResponse) Reset()         { *m = PortsSubscribersResponse{} }
+func (m *PortsSubscribersResponse) String() string { return proto.CompactTextString(m) }
+func (*PortsSubscribersResponse) ProtoMessage()    {}
+func (*PortsSubscribersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{19}
+}
+
+func (m *PortsSubscribersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortsSubscribersResponse.Unmarshal(m, b)
+}
+func (m *PortsSubscribersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortsSubscribersResponse.Marshal(b, m, deterministic)
+}
+func (m *PortsSubscribersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortsSubscribersResponse.Merge(m, src)
+}
+func (m *PortsSubscribersResponse) XXX_Size() int {
+	return xxx_messageInfo_PortsSubscribersResponse.Size(m)
+}
+func (m *PortsSubscribersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortsSubscribersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortsSubscribersResponse proto.InternalMessageInfo
+
+func (m *PortsSubscribersResponse) GetSubscribers() []*PortsSubscriber {
+	if m != nil {
+		return m.Subscribers
+	}
+	return nil
+}
+
+type PortsSubscriber struct {
+	// client is the label the client provided when it started observing
+	Client string `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	// since is the time the client started observing
+	Since *timestamp.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// queued is the number of updates which were not yet delivered to the client
+	Queued               uint32   `protobuf:"varint,3,opt,name=queued,proto3" json:"queued,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortsSubscriber) Reset()         { *m = PortsSubscriber{} }
+func (m *PortsSubscriber) String() string { return proto.CompactTextString(m) }
+func (*PortsSubscriber) ProtoMessage()    {}
+func (*PortsSubscriber) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{20}
+}
+
+func (m *PortsSubscriber) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortsSubscriber.Unmarshal(m, b)
+}
+func (m *PortsSubscriber) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortsSubscriber.Marshal(b, m, deterministic)
+}
+func (m *PortsSubscriber) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortsSubscriber.Merge(m, src)
+}
+func (m *PortsSubscriber) XXX_Size() int {
+	return xxx_messageInfo_PortsSubscriber.Size(m)
+}
+func (m *PortsSubscriber) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortsSubscriber.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortsSubscriber proto.InternalMessageInfo
+
+func (m *PortsSubscriber) GetClient() string {
+	if m != nil {
+		return m.Client
+	}
+	return ""
+}
+
+func (m *PortsSubscriber) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *PortsSubscriber) GetQueued() uint32 {
+	if m != nil {
+		return m.Queued
+	}
+	return 0
+}
+
+// PortConfigMatch describes which port configuration entry won for a port.
+// Candidates are ranked as follows, the first rule that decides wins:
+//  1. entries with the override flag win over entries without it,
+//  2. exact port entries win over port range entries,
+//  3. instance configs win over workspace configs,
+//  4. earlier port ranges win over later ones.
+type PortConfigMatch struct {
+	// source is the configuration the matching entry comes from
+	Source PortConfigSource `protobuf:"varint,1,opt,name=source,proto3,enum=supervisor.PortConfigSource" json:"source,omitempty"`
+	// port is the port or port range of the matching entry as it was configured
+	Port string `protobuf:"bytes,2,opt,name=port,proto3" json:"port,omitempty"`
+	// range is true if the port matched through a port range
+	Range bool `protobuf:"varint,3,opt,name=range,proto3" json:"range,omitempty"`
+	// override is true if the matching entry has the override flag set
+	Override             bool     `protobuf:"varint,4,opt,name=override,proto3" json:"override,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortConfigMatch) Reset()         { *m = PortConfigMatch{} }
+func (m *PortConfigMatch) String() string { return proto.CompactTextString(m) }
+func (*PortConfigMatch) ProtoMessage()    {}
+func (*PortConfigMatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{21}
+}
+
+func (m *PortConfigMatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortConfigMatch.Unmarshal(m, b)
+}
+func (m *PortConfigMatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortConfigMatch.Marshal(b, m, deterministic)
+}
+func (m *PortConfigMatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortConfigMatch.Merge(m, src)
+}
+func (m *PortConfigMatch) XXX_Size() int {
+	return xxx_messageInfo_PortConfigMatch.Size(m)
+}
+func (m *PortConfigMatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortConfigMatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortConfigMatch proto.InternalMessageInfo
+
+func (m *PortConfigMatch) GetSource() PortConfigSource {
+	if m != nil {
+		return m.Source
+	}
+	return PortConfigSource_workspace_config
+}
+
+func (m *PortConfigMatch) GetPort() string {
+	if m != nil {
+		return m.Port
+	}
+	return ""
+}
+
+func (m *PortConfigMatch) GetRange() bool {
+	if m != nil {
+		return m.Range
+	}
+	return false
+}
+
+func (m *PortConfigMatch) GetOverride() bool {
+	if m != nil {
+		return m.Override
+	}
+	return false
+}
+
+// PortConfigDiagnostics lists all problems found in the port configuration.
+type PortConfigDiagnostics struct {
+	Problems             []*PortConfigDiagnostic `protobuf:"bytes,1,rep,name=problems,proto3" json:"problems,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *PortConfigDiagnostics) Reset()         { *m = PortConfigDiagnostics{} }
+func (m *PortConfigDiagnostics) String() string { return proto.CompactTextString(m) }
+func (*PortConfigDiagnostics) ProtoMessage()    {}
+func (*PortConfigDiagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{22}
+}
+
+func (m *PortConfigDiagnostics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortConfigDiagnostics.Unmarshal(m, b)
+}
+func (m *PortConfigDiagnostics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortConfigDiagnostics.Marshal(b, m, deterministic)
+}
+func (m *PortConfigDiagnostics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortConfigDiagnostics.Merge(m, src)
+}
+func (m *PortConfigDiagnostics) XXX_Size() int {
+	return xxx_messageInfo_PortConfigDiagnostics.Size(m)
+}
+func (m *PortConfigDiagnostics) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortConfigDiagnostics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortConfigDiagnostics proto.InternalMessageInfo
+
+func (m *PortConfigDiagnostics) GetProblems() []*PortConfigDiagnostic {
+	if m != nil {
+		return m.Problems
+	}
+	return nil
+}
+
+// PortConfigDiagnostic describes a port configuration entry which was ignored or
+// only partially applied.
+type PortConfigDiagnostic struct {
+	// source is the configuration the offending entry comes from
+	Source PortConfigSource `protobuf:"varint,1,opt,name=source,proto3,enum=supervisor.PortConfigSource" json:"source,omitempty"`
+	// port is the port or port range of the offending entry as it was configured
+	Port string `protobuf:"bytes,2,opt,name=port,proto3" json:"port,omitempty"`
+	// message describes the problem
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortConfigDiagnostic) Reset()         { *m = PortConfigDiagnostic{} }
+func (m *PortConfigDiagnostic) String() string { return proto.CompactTextString(m) }
+func (*PortConfigDiagnostic) ProtoMessage()    {}
+func (*PortConfigDiagnostic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{23}
+}
+
+func (m *PortConfigDiagnostic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortConfigDiagnostic.Unmarshal(m, b)
+}
+func (m *PortConfigDiagnostic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortConfigDiagnostic.Marshal(b, m, deterministic)
+}
+func (m *PortConfigDiagnostic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortConfigDiagnostic.Merge(m, src)
+}
+func (m *PortConfigDiagnostic) XXX_Size() int {
+	return xxx_messageInfo_PortConfigDiagnostic.Size(m)
+}
+func (m *PortConfigDiagnostic) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortConfigDiagnostic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortConfigDiagnostic proto.InternalMessageInfo
+
+func (m *PortConfigDiagnostic) GetSource() PortConfigSource {
+	if m != nil {
+		return m.Source
+	}
+	return PortConfigSource_workspace_config
+}
+
+func (m *PortConfigDiagnostic) GetPort() string {
+	if m != nil {
+		return m.Port
+	}
+	return ""
+}
+
+func (m *PortConfigDiagnostic) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type TasksStatusRequest struct {
+	// if observe is true, we'll return a stream of changes rather than just the
+	// current state of affairs.
+	Observe              bool     `protobuf:"varint,1,opt,name=observe,proto3" json:"observe,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TasksStatusRequest) Reset()         { *m = TasksStatusRequest{} }
+func (m *TasksStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*TasksStatusRequest) ProtoMessage()    {}
+func (*TasksStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{24}
+}
+
+func (m *TasksStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TasksStatusRequest.Unmarshal(m, b)
+}
+func (m *TasksStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TasksStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *TasksStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TasksStatusRequest.Merge(m, src)
+}
+func (m *TasksStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_TasksStatusRequest.Size(m)
+}
+func (m *TasksStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TasksStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TasksStatusRequest proto.InternalMessageInfo
+
+func (m *TasksStatusRequest) GetObserve() bool {
+	if m != nil {
+		return m.Observe
+	}
+	return false
+}
+
+type TasksStatusResponse struct {
+	Tasks                []*TaskStatus `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *TasksStatusResponse) Reset()         { *m = TasksStatusResponse{} }
+func (m *TasksStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*TasksStatusResponse) ProtoMessage()    {}
+func (*TasksStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{25}
+}
+
+func (m *TasksStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TasksStatusResponse.Unmarshal(m, b)
+}
+func (m *TasksStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TasksStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *TasksStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TasksStatusResponse.Merge(m, src)
+}
+func (m *TasksStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_TasksStatusResponse.Size(m)
+}
+func (m *TasksStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TasksStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TasksStatusResponse proto.InternalMessageInfo
+
+func (m *TasksStatusResponse) GetTasks() []*TaskStatus {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+type TaskStatus struct {
+	Id           string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State        TaskState         `protobuf:"varint,2,opt,name=state,proto3,enum=supervisor.TaskState" json:"state,omitempty"`
+	Terminal     string            `protobuf:"bytes,3,opt,name=terminal,proto3" json:"terminal,omitempty"`
+	Presentation *TaskPresentation `protobuf:"bytes,4,opt,name=presentation,proto3" json:"presentation,omitempty"`
+	// exit_code is the exit code of the task's terminal once the task is closed.
+	// It is -1 if the exit code is unknown, e.g. because the terminal was killed by a signal.
+	ExitCode             int32    `protobuf:"varint,5,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskStatus) Reset()         { *m = TaskStatus{} }
+func (m *TaskStatus) String() string { return proto.CompactTextString(m) }
+func (*TaskStatus) ProtoMessage()    {}
+func (*TaskStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{26}
+}
+
+func (m *TaskStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskStatus.Unmarshal(m, b)
+}
+func (m *TaskStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskStatus.Marshal(b, m, deterministic)
+}
+func (m *TaskStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskStatus.Merge(m, src)
+}
+func (m *TaskStatus) XXX_Size() int {
+	return xxx_messageInfo_TaskStatus.Size(m)
+}
+func (m *TaskStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskStatus proto.InternalMessageInfo
+
+func (m *TaskStatus) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TaskStatus) GetState() TaskState {
+	if m != nil {
+		return m.State
+	}
+	return TaskState_opening
+}
+
+func (m *TaskStatus) GetTerminal() string {
+	if m != nil {
+		return m.Terminal
+	}
+	return ""
+}
+
+func (m *TaskStatus) GetPresentation() *TaskPresentation {
+	if m != nil {
+		return m.Presentation
+	}
+	return nil
+}
+
+func (m *TaskStatus) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+type TaskPresentation struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	OpenIn               string   `protobuf:"bytes,2,opt,name=open_in,json=openIn,proto3" json:"open_in,omitempty"`
+	OpenMode             string   `protobuf:"bytes,3,opt,name=open_mode,json=openMode,proto3" json:"open_mode,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TasksStatusRequest) Reset()         { *m = TasksStatusRequest{} }
-func (m *TasksStatusRequest) String() string { return proto.CompactTextString(m) }
-func (*TasksStatusRequest) ProtoMessage()    {}
-func (*TasksStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{11}
+func (m *TaskPresentation) Reset()         { *m = TaskPresentation{} }
+func (m *TaskPresentation) String() string { return proto.CompactTextString(m) }
+func (*TaskPresentation) ProtoMessage()    {}
+func (*TaskPresentation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{27}
 }
 
-func (m *TasksStatusRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TasksStatusRequest.Unmarshal(m, b)
+func (m *TaskPresentation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskPresentation.Unmarshal(m, b)
 }
-func (m *TasksStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TasksStatusRequest.Marshal(b, m, deterministic)
+func (m *TaskPresentation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskPresentation.Marshal(b, m, deterministic)
 }
-func (m *TasksStatusRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TasksStatusRequest.Merge(m, src)
+func (m *TaskPresentation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskPresentation.Merge(m, src)
 }
-func (m *TasksStatusRequest) XXX_Size() int {
-	return xxx_messageInfo_TasksStatusRequest.Size(m)
+func (m *TaskPresentation) XXX_Size() int {
+	return xxx_messageInfo_TaskPresentation.Size(m)
 }
-func (m *TasksStatusRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TasksStatusRequest.DiscardUnknown(m)
+func (m *TaskPresentation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskPresentation.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TasksStatusRequest proto.InternalMessageInfo
+var xxx_messageInfo_TaskPresentation proto.InternalMessageInfo
 
-func (m *TasksStatusRequest) GetObserve() bool {
+func (m *TaskPresentation) GetName() string {
 	if m != nil {
-		return m.Observe
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TaskPresentation) GetOpenIn() string {
+	if m != nil {
+		return m.OpenIn
+	}
+	return ""
+}
+
+func (m *TaskPresentation) GetOpenMode() string {
+	if m != nil {
+		return m.OpenMode
+	}
+	return ""
+}
+
+type MemoryStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemoryStatusRequest) Reset()         { *m = MemoryStatusRequest{} }
+func (m *MemoryStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*MemoryStatusRequest) ProtoMessage()    {}
+func (*MemoryStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{28}
+}
+
+func (m *MemoryStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemoryStatusRequest.Unmarshal(m, b)
+}
+func (m *MemoryStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemoryStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *MemoryStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemoryStatusRequest.Merge(m, src)
+}
+func (m *MemoryStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_MemoryStatusRequest.Size(m)
+}
+func (m *MemoryStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemoryStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemoryStatusRequest proto.InternalMessageInfo
+
+type MemoryStatusResponse struct {
+	// usage_bytes is the memory the workspace uses
+	UsageBytes uint64 `protobuf:"varint,1,opt,name=usage_bytes,json=usageBytes,proto3" json:"usage_bytes,omitempty"`
+	// limit_bytes is the memory the workspace may use, or 0 if it is unlimited
+	LimitBytes uint64 `protobuf:"varint,2,opt,name=limit_bytes,json=limitBytes,proto3" json:"limit_bytes,omitempty"`
+	// under_pressure is true while processes of the workspace are stalled waiting for memory
+	UnderPressure bool `protobuf:"varint,3,opt,name=under_pressure,json=underPressure,proto3" json:"under_pressure,omitempty"`
+	// pressure is the share of the last 10 seconds in percent in which processes were stalled waiting for memory
+	Pressure float64 `protobuf:"fixed64,4,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	// oom_kills is the number of processes which were killed since the workspace started, because it ran out of memory
+	OomKills uint64 `protobuf:"varint,5,opt,name=oom_kills,json=oomKills,proto3" json:"oom_kills,omitempty"`
+	// recent_oom_kills are the latest processes which were killed, if they could be identified
+	RecentOomKills       []*OOMKill `protobuf:"bytes,6,rep,name=recent_oom_kills,json=recentOomKills,proto3" json:"recent_oom_kills,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *MemoryStatusResponse) Reset()         { *m = MemoryStatusResponse{} }
+func (m *MemoryStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*MemoryStatusResponse) ProtoMessage()    {}
+func (*MemoryStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{29}
+}
+
+func (m *MemoryStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemoryStatusResponse.Unmarshal(m, b)
+}
+func (m *MemoryStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemoryStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *MemoryStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemoryStatusResponse.Merge(m, src)
+}
+func (m *MemoryStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_MemoryStatusResponse.Size(m)
+}
+func (m *MemoryStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemoryStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemoryStatusResponse proto.InternalMessageInfo
+
+func (m *MemoryStatusResponse) GetUsageBytes() uint64 {
+	if m != nil {
+		return m.UsageBytes
+	}
+	return 0
+}
+
+func (m *MemoryStatusResponse) GetLimitBytes() uint64 {
+	if m != nil {
+		return m.LimitBytes
+	}
+	return 0
+}
+
+func (m *MemoryStatusResponse) GetUnderPressure() bool {
+	if m != nil {
+		return m.UnderPressure
 	}
 	return false
 }
 
-type TasksStatusResponse struct {
-	Tasks                []*TaskStatus `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+func (m *MemoryStatusResponse) GetPressure() float64 {
+	if m != nil {
+		return m.Pressure
+	}
+	return 0
 }
 
-func (m *TasksStatusResponse) Reset()         { *m = TasksStatusResponse{} }
-func (m *TasksStatusResponse) String() string { return proto.CompactTextString(m) }
-func (*TasksStatusResponse) ProtoMessage()    {}
-func (*TasksStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{12}
+func (m *MemoryStatusResponse) GetOomKills() uint64 {
+	if m != nil {
+		return m.OomKills
+	}
+	return 0
 }
 
-func (m *TasksStatusResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TasksStatusResponse.Unmarshal(m, b)
+func (m *MemoryStatusResponse) GetRecentOomKills() []*OOMKill {
+	if m != nil {
+		return m.RecentOomKills
+	}
+	return nil
 }
-func (m *TasksStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TasksStatusResponse.Marshal(b, m, deterministic)
+
+type OOMKill struct {
+	Time *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// pid and command identify the process which was likely killed. They are empty if it could not be identified.
+	Pid                  int64    `protobuf:"varint,2,opt,name=pid,proto3" json:"pid,omitempty"`
+	Command              string   `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *TasksStatusResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TasksStatusResponse.Merge(m, src)
+
+func (m *OOMKill) Reset()         { *m = OOMKill{} }
+func (m *OOMKill) String() string { return proto.CompactTextString(m) }
+func (*OOMKill) ProtoMessage()    {}
+func (*OOMKill) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{30}
 }
-func (m *TasksStatusResponse) XXX_Size() int {
-	return xxx_messageInfo_TasksStatusResponse.Size(m)
+
+func (m *OOMKill) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OOMKill.Unmarshal(m, b)
 }
-func (m *TasksStatusResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_TasksStatusResponse.DiscardUnknown(m)
+func (m *OOMKill) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OOMKill.Marshal(b, m, deterministic)
+}
+func (m *OOMKill) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OOMKill.Merge(m, src)
+}
+func (m *OOMKill) XXX_Size() int {
+	return xxx_messageInfo_OOMKill.Size(m)
+}
+func (m *OOMKill) XXX_DiscardUnknown() {
+	xxx_messageInfo_OOMKill.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TasksStatusResponse proto.InternalMessageInfo
+var xxx_messageInfo_OOMKill proto.InternalMessageInfo
 
-func (m *TasksStatusResponse) GetTasks() []*TaskStatus {
+func (m *OOMKill) GetTime() *timestamp.Timestamp {
 	if m != nil {
-		return m.Tasks
+		return m.Time
 	}
 	return nil
 }
 
-type TaskStatus struct {
-	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	State                TaskState         `protobuf:"varint,2,opt,name=state,proto3,enum=supervisor.TaskState" json:"state,omitempty"`
-	Terminal             string            `protobuf:"bytes,3,opt,name=terminal,proto3" json:"terminal,omitempty"`
-	Presentation         *TaskPresentation `protobuf:"bytes,4,opt,name=presentation,proto3" json:"presentation,omitempty"`
+func (m *OOMKill) GetPid() int64 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *OOMKill) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+type DiskStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiskStatusRequest) Reset()         { *m = DiskStatusRequest{} }
+func (m *DiskStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*DiskStatusRequest) ProtoMessage()    {}
+func (*DiskStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{31}
+}
+
+func (m *DiskStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskStatusRequest.Unmarshal(m, b)
+}
+func (m *DiskStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *DiskStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskStatusRequest.Merge(m, src)
+}
+func (m *DiskStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_DiskStatusRequest.Size(m)
+}
+func (m *DiskStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiskStatusRequest proto.InternalMessageInfo
+
+type DiskStatusResponse struct {
+	// path is the location of the workspace filesystem, e.g. /workspace
+	Path           string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	TotalBytes     uint64 `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	UsedBytes      uint64 `protobuf:"varint,3,opt,name=used_bytes,json=usedBytes,proto3" json:"used_bytes,omitempty"`
+	AvailableBytes uint64 `protobuf:"varint,4,opt,name=available_bytes,json=availableBytes,proto3" json:"available_bytes,omitempty"`
+	// usage is the share of the filesystem in percent which is used
+	Usage       float64 `protobuf:"fixed64,5,opt,name=usage,proto3" json:"usage,omitempty"`
+	TotalInodes uint64  `protobuf:"varint,6,opt,name=total_inodes,json=totalInodes,proto3" json:"total_inodes,omitempty"`
+	UsedInodes  uint64  `protobuf:"varint,7,opt,name=used_inodes,json=usedInodes,proto3" json:"used_inodes,omitempty"`
+	// inotify_watches is the number of inotify watches of all processes of the workspace
+	InotifyWatches uint64 `protobuf:"varint,8,opt,name=inotify_watches,json=inotifyWatches,proto3" json:"inotify_watches,omitempty"`
+	// inotify_watch_limit is the maximum number of inotify watches per user
+	InotifyWatchLimit uint64 `protobuf:"varint,9,opt,name=inotify_watch_limit,json=inotifyWatchLimit,proto3" json:"inotify_watch_limit,omitempty"`
+	// inotify_watchers are the processes holding the most inotify watches, most first
+	InotifyWatchers      []*InotifyWatcher `protobuf:"bytes,10,rep,name=inotify_watchers,json=inotifyWatchers,proto3" json:"inotify_watchers,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *TaskStatus) Reset()         { *m = TaskStatus{} }
-func (m *TaskStatus) String() string { return proto.CompactTextString(m) }
-func (*TaskStatus) ProtoMessage()    {}
-func (*TaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{13}
+func (m *DiskStatusResponse) Reset()         { *m = DiskStatusResponse{} }
+func (m *DiskStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*DiskStatusResponse) ProtoMessage()    {}
+func (*DiskStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{32}
 }
 
-func (m *TaskStatus) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TaskStatus.Unmarshal(m, b)
+func (m *DiskStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskStatusResponse.Unmarshal(m, b)
 }
-func (m *TaskStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TaskStatus.Marshal(b, m, deterministic)
+func (m *DiskStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskStatusResponse.Marshal(b, m, deterministic)
 }
-func (m *TaskStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TaskStatus.Merge(m, src)
+func (m *DiskStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskStatusResponse.Merge(m, src)
 }
-func (m *TaskStatus) XXX_Size() int {
-	return xxx_messageInfo_TaskStatus.Size(m)
+func (m *DiskStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_DiskStatusResponse.Size(m)
 }
-func (m *TaskStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_TaskStatus.DiscardUnknown(m)
+func (m *DiskStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskStatusResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TaskStatus proto.InternalMessageInfo
+var xxx_messageInfo_DiskStatusResponse proto.InternalMessageInfo
 
-func (m *TaskStatus) GetId() string {
+func (m *DiskStatusResponse) GetPath() string {
 	if m != nil {
-		return m.Id
+		return m.Path
 	}
 	return ""
 }
 
-func (m *TaskStatus) GetState() TaskState {
+func (m *DiskStatusResponse) GetTotalBytes() uint64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+func (m *DiskStatusResponse) GetUsedBytes() uint64 {
+	if m != nil {
+		return m.UsedBytes
+	}
+	return 0
+}
+
+func (m *DiskStatusResponse) GetAvailableBytes() uint64 {
+	if m != nil {
+		return m.AvailableBytes
+	}
+	return 0
+}
+
+func (m *DiskStatusResponse) GetUsage() float64 {
+	if m != nil {
+		return m.Usage
+	}
+	return 0
+}
+
+func (m *DiskStatusResponse) GetTotalInodes() uint64 {
+	if m != nil {
+		return m.TotalInodes
+	}
+	return 0
+}
+
+func (m *DiskStatusResponse) GetUsedInodes() uint64 {
+	if m != nil {
+		return m.UsedInodes
+	}
+	return 0
+}
+
+func (m *DiskStatusResponse) GetInotifyWatches() uint64 {
+	if m != nil {
+		return m.InotifyWatches
+	}
+	return 0
+}
+
+func (m *DiskStatusResponse) GetInotifyWatchLimit() uint64 {
+	if m != nil {
+		return m.InotifyWatchLimit
+	}
+	return 0
+}
+
+func (m *DiskStatusResponse) GetInotifyWatchers() []*InotifyWatcher {
+	if m != nil {
+		return m.InotifyWatchers
+	}
+	return nil
+}
+
+type InotifyWatcher struct {
+	Pid                  int64    `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Command              string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Watches              uint64   `protobuf:"varint,3,opt,name=watches,proto3" json:"watches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InotifyWatcher) Reset()         { *m = InotifyWatcher{} }
+func (m *InotifyWatcher) String() string { return proto.CompactTextString(m) }
+func (*InotifyWatcher) ProtoMessage()    {}
+func (*InotifyWatcher) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{33}
+}
+
+func (m *InotifyWatcher) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InotifyWatcher.Unmarshal(m, b)
+}
+func (m *InotifyWatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InotifyWatcher.Marshal(b, m, deterministic)
+}
+func (m *InotifyWatcher) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InotifyWatcher.Merge(m, src)
+}
+func (m *InotifyWatcher) XXX_Size() int {
+	return xxx_messageInfo_InotifyWatcher.Size(m)
+}
+func (m *InotifyWatcher) XXX_DiscardUnknown() {
+	xxx_messageInfo_InotifyWatcher.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InotifyWatcher proto.InternalMessageInfo
+
+func (m *InotifyWatcher) GetPid() int64 {
 	if m != nil {
-		return m.State
+		return m.Pid
 	}
-	return TaskState_opening
+	return 0
 }
 
-func (m *TaskStatus) GetTerminal() string {
+func (m *InotifyWatcher) GetCommand() string {
 	if m != nil {
-		return m.Terminal
+		return m.Command
 	}
 	return ""
 }
 
-func (m *TaskStatus) GetPresentation() *TaskPresentation {
+func (m *InotifyWatcher) GetWatches() uint64 {
 	if m != nil {
-		return m.Presentation
+		return m.Watches
 	}
-	return nil
+	return 0
 }
 
-type TaskPresentation struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	OpenIn               string   `protobuf:"bytes,2,opt,name=open_in,json=openIn,proto3" json:"open_in,omitempty"`
-	OpenMode             string   `protobuf:"bytes,3,opt,name=open_mode,json=openMode,proto3" json:"open_mode,omitempty"`
+type CPUStatusRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TaskPresentation) Reset()         { *m = TaskPresentation{} }
-func (m *TaskPresentation) String() string { return proto.CompactTextString(m) }
-func (*TaskPresentation) ProtoMessage()    {}
-func (*TaskPresentation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dfe4fce6682daf5b, []int{14}
+func (m *CPUStatusRequest) Reset()         { *m = CPUStatusRequest{} }
+func (m *CPUStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*CPUStatusRequest) ProtoMessage()    {}
+func (*CPUStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{34}
 }
 
-func (m *TaskPresentation) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TaskPresentation.Unmarshal(m, b)
+func (m *CPUStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CPUStatusRequest.Unmarshal(m, b)
 }
-func (m *TaskPresentation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TaskPresentation.Marshal(b, m, deterministic)
+func (m *CPUStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CPUStatusRequest.Marshal(b, m, deterministic)
 }
-func (m *TaskPresentation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TaskPresentation.Merge(m, src)
+func (m *CPUStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CPUStatusRequest.Merge(m, src)
 }
-func (m *TaskPresentation) XXX_Size() int {
-	return xxx_messageInfo_TaskPresentation.Size(m)
+func (m *CPUStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_CPUStatusRequest.Size(m)
 }
-func (m *TaskPresentation) XXX_DiscardUnknown() {
-	xxx_messageInfo_TaskPresentation.DiscardUnknown(m)
+func (m *CPUStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CPUStatusRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TaskPresentation proto.InternalMessageInfo
+var xxx_messageInfo_CPUStatusRequest proto.InternalMessageInfo
 
-func (m *TaskPresentation) GetName() string {
+type CPUStatusResponse struct {
+	// limit_cores is the number of CPU cores the workspace may use, or 0 if it is unlimited
+	LimitCores float64 `protobuf:"fixed64,1,opt,name=limit_cores,json=limitCores,proto3" json:"limit_cores,omitempty"`
+	// usage_cores is the number of CPU cores the workspace used on average recently
+	UsageCores float64 `protobuf:"fixed64,2,opt,name=usage_cores,json=usageCores,proto3" json:"usage_cores,omitempty"`
+	// throttled is true while the workspace is slowed down, because it hits its CPU limit
+	Throttled bool `protobuf:"varint,3,opt,name=throttled,proto3" json:"throttled,omitempty"`
+	// throttled_share is the share of the recent scheduler periods in percent in which the workspace was throttled
+	ThrottledShare float64 `protobuf:"fixed64,4,opt,name=throttled_share,json=throttledShare,proto3" json:"throttled_share,omitempty"`
+	// throttled_periods is the number of scheduler periods the workspace was throttled in since it started
+	ThrottledPeriods uint64 `protobuf:"varint,5,opt,name=throttled_periods,json=throttledPeriods,proto3" json:"throttled_periods,omitempty"`
+	// periods is the number of scheduler periods since the workspace started
+	Periods uint64 `protobuf:"varint,6,opt,name=periods,proto3" json:"periods,omitempty"`
+	// throttled_seconds is the total time the workspace was throttled for
+	ThrottledSeconds float64 `protobuf:"fixed64,7,opt,name=throttled_seconds,json=throttledSeconds,proto3" json:"throttled_seconds,omitempty"`
+	// pressure is the share of the last 10 seconds in percent in which processes were stalled waiting for CPU
+	Pressure             float64  `protobuf:"fixed64,8,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CPUStatusResponse) Reset()         { *m = CPUStatusResponse{} }
+func (m *CPUStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*CPUStatusResponse) ProtoMessage()    {}
+func (*CPUStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{35}
+}
+
+func (m *CPUStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CPUStatusResponse.Unmarshal(m, b)
+}
+func (m *CPUStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CPUStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *CPUStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CPUStatusResponse.Merge(m, src)
+}
+func (m *CPUStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_CPUStatusResponse.Size(m)
+}
+func (m *CPUStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CPUStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CPUStatusResponse proto.InternalMessageInfo
+
+func (m *CPUStatusResponse) GetLimitCores() float64 {
 	if m != nil {
-		return m.Name
+		return m.LimitCores
 	}
-	return ""
+	return 0
 }
 
-func (m *TaskPresentation) GetOpenIn() string {
+func (m *CPUStatusResponse) GetUsageCores() float64 {
 	if m != nil {
-		return m.OpenIn
+		return m.UsageCores
 	}
-	return ""
+	return 0
 }
 
-func (m *TaskPresentation) GetOpenMode() string {
+func (m *CPUStatusResponse) GetThrottled() bool {
 	if m != nil {
-		return m.OpenMode
+		return m.Throttled
 	}
-	return ""
+	return false
+}
+
+func (m *CPUStatusResponse) GetThrottledShare() float64 {
+	if m != nil {
+		return m.ThrottledShare
+	}
+	return 0
+}
+
+func (m *CPUStatusResponse) GetThrottledPeriods() uint64 {
+	if m != nil {
+		return m.ThrottledPeriods
+	}
+	return 0
+}
+
+func (m *CPUStatusResponse) GetPeriods() uint64 {
+	if m != nil {
+		return m.Periods
+	}
+	return 0
+}
+
+func (m *CPUStatusResponse) GetThrottledSeconds() float64 {
+	if m != nil {
+		return m.ThrottledSeconds
+	}
+	return 0
+}
+
+func (m *CPUStatusResponse) GetPressure() float64 {
+	if m != nil {
+		return m.Pressure
+	}
+	return 0
 }
 
 func init() {
+	proto.RegisterEnum("supervisor.HealthState", HealthState_name, HealthState_value)
 	proto.RegisterEnum("supervisor.ContentSource", ContentSource_name, ContentSource_value)
+	proto.RegisterEnum("supervisor.ContentPhase", ContentPhase_name, ContentPhase_value)
+	proto.RegisterEnum("supervisor.DotfilesPhase", DotfilesPhase_name, DotfilesPhase_value)
+	proto.RegisterEnum("supervisor.PortsUpdateTrigger", PortsUpdateTrigger_name, PortsUpdateTrigger_value)
 	proto.RegisterEnum("supervisor.PortVisibility", PortVisibility_name, PortVisibility_value)
+	proto.RegisterEnum("supervisor.PortProtocol", PortProtocol_name, PortProtocol_value)
 	proto.RegisterEnum("supervisor.OnPortExposedAction", OnPortExposedAction_name, OnPortExposedAction_value)
+	proto.RegisterEnum("supervisor.PortConfigSource", PortConfigSource_name, PortConfigSource_value)
 	proto.RegisterEnum("supervisor.TaskState", TaskState_name, TaskState_value)
 	proto.RegisterType((*SupervisorStatusRequest)(nil), "supervisor.SupervisorStatusRequest")
 	proto.RegisterType((*SupervisorStatusResponse)(nil), "supervisor.SupervisorStatusResponse")
+	proto.RegisterType((*HealthStatusRequest)(nil), "supervisor.HealthStatusRequest")
+	proto.RegisterType((*HealthStatusResponse)(nil), "supervisor.HealthStatusResponse")
+	proto.RegisterType((*SubsystemHealth)(nil), "supervisor.SubsystemHealth")
 	proto.RegisterType((*IDEStatusRequest)(nil), "supervisor.IDEStatusRequest")
 	proto.RegisterType((*IDEStatusResponse)(nil), "supervisor.IDEStatusResponse")
+	proto.RegisterType((*IDEStatusResponse_DesktopStatus)(nil), "supervisor.IDEStatusResponse.DesktopStatus")
 	proto.RegisterType((*ContentStatusRequest)(nil), "supervisor.ContentStatusRequest")
 	proto.RegisterType((*ContentStatusResponse)(nil), "supervisor.ContentStatusResponse")
+	proto.RegisterType((*ContentProgressRequest)(nil), "supervisor.ContentProgressRequest")
+	proto.RegisterType((*ContentProgressResponse)(nil), "supervisor.ContentProgressResponse")
+	proto.RegisterType((*DotfilesStatusRequest)(nil), "supervisor.DotfilesStatusRequest")
+	proto.RegisterType((*DotfilesStatusResponse)(nil), "supervisor.DotfilesStatusResponse")
 	proto.RegisterType((*BackupStatusRequest)(nil), "supervisor.BackupStatusRequest")
 	proto.RegisterType((*BackupStatusResponse)(nil), "supervisor.BackupStatusResponse")
 	proto.RegisterType((*PortsStatusRequest)(nil), "supervisor.PortsStatusRequest")
 	proto.RegisterType((*PortsStatusResponse)(nil), "supervisor.PortsStatusResponse")
+	proto.RegisterMapType((map[uint32]PortsUpdateTrigger)(nil), "supervisor.PortsStatusResponse.TriggersEntry")
 	proto.RegisterType((*PortsStatus)(nil), "supervisor.PortsStatus")
 	proto.RegisterType((*PortsStatus_ExposedPortInfo)(nil), "supervisor.PortsStatus.ExposedPortInfo")
+	proto.RegisterType((*PortsStatus_ProxyStatus)(nil), "supervisor.PortsStatus.ProxyStatus")
+	proto.RegisterType((*PortsStatus_RemapSuggestion)(nil), "supervisor.PortsStatus.RemapSuggestion")
+	proto.RegisterType((*PortsStatus_ConnectionStats)(nil), "supervisor.PortsStatus.ConnectionStats")
+	proto.RegisterType((*PortsSubscribersRequest)(nil), "supervisor.PortsSubscribersRequest")
+	proto.RegisterType((*PortsSubscribersResponse)(nil), "supervisor.PortsSubscribersResponse")
+	proto.RegisterType((*PortsSubscriber)(nil), "supervisor.PortsSubscriber")
+	proto.RegisterType((*PortConfigMatch)(nil), "supervisor.PortConfigMatch")
+	proto.RegisterType((*PortConfigDiagnostics)(nil), "supervisor.PortConfigDiagnostics")
+	proto.RegisterType((*PortConfigDiagnostic)(nil), "supervisor.PortConfigDiagnostic")
 	proto.RegisterType((*TasksStatusRequest)(nil), "supervisor.TasksStatusRequest")
 	proto.RegisterType((*TasksStatusResponse)(nil), "supervisor.TasksStatusResponse")
 	proto.RegisterType((*TaskStatus)(nil), "supervisor.TaskStatus")
 	proto.RegisterType((*TaskPresentation)(nil), "supervisor.TaskPresentation")
+	proto.RegisterType((*MemoryStatusRequest)(nil), "supervisor.MemoryStatusRequest")
+	proto.RegisterType((*MemoryStatusResponse)(nil), "supervisor.MemoryStatusResponse")
+	proto.RegisterType((*OOMKill)(nil), "supervisor.OOMKill")
+	proto.RegisterType((*DiskStatusRequest)(nil), "supervisor.DiskStatusRequest")
+	proto.RegisterType((*DiskStatusResponse)(nil), "supervisor.DiskStatusResponse")
+	proto.RegisterType((*InotifyWatcher)(nil), "supervisor.InotifyWatcher")
+	proto.RegisterType((*CPUStatusRequest)(nil), "supervisor.CPUStatusRequest")
+	proto.RegisterType((*CPUStatusResponse)(nil), "supervisor.CPUStatusResponse")
 }
 
 func init() {
@@ -914,70 +2920,207 @@ func init() {
 }
 
 var fileDescriptor_dfe4fce6682daf5b = []byte{
-	// 1001 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x41, 0x6f, 0xe3, 0x44,
-	0x14, 0xae, 0x9d, 0x36, 0xdd, 0xbc, 0x34, 0xa9, 0xfb, 0xb2, 0xdd, 0xa6, 0xa1, 0xa5, 0x59, 0x17,
-	0xd8, 0x34, 0x40, 0x42, 0xb3, 0x27, 0x40, 0x8b, 0xc8, 0x96, 0x3d, 0xf4, 0x80, 0x58, 0xb9, 0x88,
-	0x43, 0x85, 0x14, 0x39, 0xf1, 0x34, 0x3b, 0xaa, 0x33, 0x63, 0xc6, 0x76, 0x4a, 0xb5, 0x70, 0x81,
-	0x5f, 0x80, 0x10, 0xe2, 0xc8, 0x91, 0xff, 0x02, 0x47, 0xfe, 0x02, 0x3f, 0x04, 0xcd, 0x78, 0x9c,
-	0xd8, 0x69, 0x52, 0xe0, 0x12, 0xcd, 0xfb, 0xe6, 0x7b, 0xef, 0x7d, 0xe3, 0x79, 0xf3, 0x5e, 0x60,
-	0x2b, 0x8c, 0xdc, 0x28, 0x0e, 0x3b, 0x81, 0xe0, 0x11, 0x47, 0x08, 0xe3, 0x80, 0x88, 0x29, 0x0d,
-	0xb9, 0x68, 0x1c, 0x8c, 0x39, 0x1f, 0xfb, 0xa4, 0xeb, 0x06, 0xb4, 0xeb, 0x32, 0xc6, 0x23, 0x37,
-	0xa2, 0x9c, 0x69, 0xa6, 0xbd, 0x0f, 0x7b, 0x17, 0x33, 0xee, 0x85, 0x8a, 0xe1, 0x90, 0x6f, 0x62,
-	0x12, 0x46, 0x76, 0x1b, 0xea, 0x77, 0xb7, 0xc2, 0x80, 0xb3, 0x90, 0x60, 0x15, 0x4c, 0x7e, 0x5d,
-	0x37, 0x9a, 0x46, 0xeb, 0x81, 0x63, 0xf2, 0x6b, 0xfb, 0x1d, 0xb0, 0xce, 0x3f, 0x7b, 0x91, 0xf3,
-	0x47, 0x84, 0xf5, 0x1b, 0x97, 0x46, 0x9a, 0xa5, 0xd6, 0xf6, 0x31, 0xec, 0x64, 0x78, 0x2b, 0x82,
-	0xb5, 0xe1, 0xe1, 0x19, 0x67, 0x11, 0x61, 0xd1, 0xbf, 0x07, 0x7c, 0x05, 0xbb, 0x0b, 0x5c, 0x1d,
-	0xf4, 0x00, 0x4a, 0xee, 0xd4, 0xa5, 0xbe, 0x3b, 0xf4, 0x89, 0xf6, 0x98, 0x03, 0x78, 0x0a, 0xc5,
-	0x90, 0xc7, 0x62, 0x44, 0xea, 0x66, 0xd3, 0x68, 0x55, 0x7b, 0xfb, 0x9d, 0xf9, 0x17, 0xeb, 0xa4,
-	0x01, 0x15, 0xc1, 0xd1, 0x44, 0x7b, 0x17, 0x6a, 0xcf, 0xdd, 0xd1, 0x75, 0x1c, 0xe4, 0xbf, 0x52,
-	0x1f, 0x1e, 0xe6, 0x61, 0x9d, 0xff, 0x04, 0xac, 0x91, 0xcb, 0x5c, 0x71, 0x3b, 0x58, 0x94, 0xb1,
-	0x9d, 0xe0, 0xfd, 0x14, 0xb6, 0x3b, 0x80, 0x2f, 0xb9, 0x88, 0xc2, 0xfc, 0x69, 0xeb, 0xb0, 0xc9,
-	0x87, 0x21, 0x11, 0xd3, 0xd4, 0x2f, 0x35, 0xed, 0x9f, 0x0c, 0xa8, 0xe5, 0x1c, 0x74, 0xca, 0xf7,
-	0x61, 0xc3, 0xf5, 0x3c, 0xe2, 0xd5, 0x8d, 0x66, 0xa1, 0x55, 0xee, 0xed, 0x65, 0xcf, 0x94, 0xe5,
-	0x27, 0x2c, 0x3c, 0x85, 0xcd, 0x38, 0xf0, 0xdc, 0x88, 0x78, 0x75, 0xf3, 0x7e, 0x87, 0x94, 0x27,
-	0x35, 0x09, 0x32, 0xe1, 0x53, 0xe2, 0xd5, 0x0b, 0xcd, 0x42, 0xab, 0xe2, 0xa4, 0xa6, 0xfd, 0x87,
-	0x09, 0xe5, 0x8c, 0x0b, 0x1e, 0x02, 0xf8, 0x7c, 0xe4, 0xfa, 0x83, 0x80, 0x8b, 0xe4, 0xc6, 0x2a,
-	0x4e, 0x49, 0x21, 0x92, 0x85, 0x47, 0x50, 0x1e, 0xfb, 0x7c, 0x98, 0xee, 0x9b, 0x6a, 0x1f, 0x12,
-	0x48, 0x11, 0x1e, 0x41, 0x51, 0x1d, 0xd6, 0xab, 0xaf, 0xab, 0xc3, 0x6b, 0x0b, 0xfb, 0xb0, 0x49,
-	0xbe, 0x0d, 0x78, 0x48, 0xbc, 0xfa, 0x46, 0xd3, 0x68, 0x95, 0x7b, 0x4f, 0x56, 0x88, 0xee, 0xbc,
-	0x48, 0x68, 0x12, 0x3a, 0x67, 0x57, 0xdc, 0x49, 0xfd, 0x1a, 0xbf, 0x19, 0xb0, 0xbd, 0xb0, 0x89,
-	0x1f, 0x01, 0x4c, 0x69, 0x48, 0x87, 0xd4, 0xa7, 0xd1, 0xad, 0x92, 0x5b, 0xed, 0x35, 0x16, 0x23,
-	0x7f, 0x35, 0x63, 0x38, 0x19, 0x36, 0x5a, 0x50, 0x88, 0x85, 0xaf, 0xce, 0x50, 0x72, 0xe4, 0x12,
-	0x3f, 0x01, 0xe0, 0x6c, 0x90, 0xea, 0x2c, 0xa8, 0x68, 0x47, 0xd9, 0x68, 0x5f, 0x30, 0x19, 0x4f,
-	0x8b, 0xe8, 0x8f, 0xe4, 0x83, 0x74, 0x4a, 0x9c, 0x69, 0x40, 0x16, 0xc4, 0x97, 0x6e, 0x78, 0xfd,
-	0x9f, 0x0b, 0xe2, 0x0c, 0x6a, 0x39, 0xbe, 0xae, 0x87, 0xf7, 0x60, 0x23, 0x92, 0xb0, 0xae, 0x87,
-	0x47, 0x59, 0x05, 0x92, 0x9f, 0x96, 0x83, 0x22, 0xd9, 0xbf, 0x1b, 0x00, 0x73, 0x54, 0x3e, 0x4a,
-	0xea, 0xa9, 0x44, 0x25, 0xc7, 0xa4, 0x1e, 0xbe, 0x0b, 0x1b, 0xb2, 0xc5, 0xa4, 0x0f, 0x66, 0x77,
-	0x59, 0x30, 0xe2, 0x24, 0x1c, 0x6c, 0xc0, 0x83, 0x88, 0x88, 0x09, 0x65, 0xae, 0xaf, 0x8e, 0x5f,
-	0x72, 0x66, 0x36, 0x7e, 0x0a, 0x5b, 0x81, 0x20, 0x21, 0x61, 0x49, 0x23, 0x52, 0xf7, 0x5b, 0xee,
-	0x1d, 0x2c, 0xc6, 0x7b, 0x99, 0xe1, 0x38, 0x39, 0x0f, 0xfb, 0x6b, 0xb0, 0x16, 0x19, 0xb2, 0x37,
-	0x30, 0x77, 0x42, 0xb4, 0x60, 0xb5, 0xc6, 0x3d, 0xd8, 0xe4, 0x01, 0x61, 0x03, 0xca, 0xf4, 0xe5,
-	0x14, 0xa5, 0x79, 0xce, 0xf0, 0x0d, 0x28, 0xa9, 0x8d, 0x09, 0xf7, 0x48, 0xaa, 0x4f, 0x02, 0x9f,
-	0x73, 0x8f, 0xb4, 0xcf, 0xa0, 0x92, 0x6b, 0x00, 0x58, 0x05, 0xb8, 0x12, 0x7c, 0x32, 0xe0, 0xd1,
-	0x2b, 0x22, 0xac, 0x35, 0xdc, 0x86, 0xb2, 0xb2, 0x87, 0xea, 0xd9, 0x5b, 0x06, 0xee, 0x40, 0x45,
-	0x01, 0x81, 0x20, 0xc3, 0x98, 0xfa, 0x9e, 0x65, 0xb6, 0x4f, 0xa0, 0x9a, 0xaf, 0x18, 0x2c, 0xc3,
-	0x66, 0x20, 0xe8, 0xd4, 0x8d, 0x88, 0xb5, 0x86, 0x00, 0xc5, 0x20, 0x1e, 0xfa, 0x74, 0x64, 0x19,
-	0x6d, 0x02, 0xb5, 0x25, 0xe5, 0x20, 0x29, 0x74, 0xcc, 0xb8, 0x90, 0x74, 0x0b, 0xb6, 0x94, 0xde,
-	0xa1, 0xe0, 0x37, 0x21, 0x11, 0x96, 0x31, 0x43, 0x02, 0x41, 0xa6, 0x94, 0xdc, 0x58, 0xa6, 0xe4,
-	0x33, 0x1e, 0xd1, 0xab, 0x5b, 0xab, 0x80, 0x08, 0xd5, 0x64, 0x3d, 0x48, 0x53, 0xae, 0xb7, 0x4f,
-	0xa1, 0x34, 0xbb, 0x26, 0x29, 0x46, 0xba, 0x53, 0x36, 0xb6, 0xd6, 0xa4, 0x21, 0x62, 0xa6, 0x0c,
-	0x43, 0x86, 0x19, 0xf9, 0x52, 0x86, 0x65, 0xf6, 0xfe, 0x2c, 0x42, 0x25, 0xa9, 0x86, 0x0b, 0x79,
-	0x33, 0x23, 0x82, 0xdf, 0x81, 0xb5, 0x38, 0x12, 0xf0, 0x38, 0x7b, 0x73, 0x2b, 0x66, 0x49, 0xe3,
-	0xad, 0xfb, 0x49, 0x49, 0xc1, 0xda, 0x87, 0x3f, 0xfc, 0xf5, 0xf7, 0xcf, 0xe6, 0x1e, 0xee, 0x76,
-	0xa7, 0xa7, 0xdd, 0x64, 0xa0, 0x75, 0xe7, 0x7e, 0xf8, 0xa3, 0x01, 0xa5, 0xd9, 0xf4, 0xc0, 0x5c,
-	0xc5, 0x2c, 0x0e, 0x9f, 0xc6, 0xe1, 0x8a, 0x5d, 0x9d, 0xe9, 0x43, 0x95, 0xe9, 0x29, 0x56, 0x33,
-	0x99, 0xa8, 0x47, 0x2e, 0x1f, 0xe3, 0x51, 0x1e, 0xe9, 0xca, 0x29, 0xd3, 0x7d, 0x2d, 0x7f, 0x9f,
-	0x45, 0x22, 0x26, 0xdf, 0xe3, 0xaf, 0xc6, 0xbc, 0x40, 0x12, 0x25, 0xcd, 0x65, 0xc3, 0x23, 0xa7,
-	0xe6, 0xf1, 0x3d, 0x0c, 0xad, 0xa8, 0xaf, 0x14, 0x7d, 0x8c, 0x98, 0xc9, 0x3f, 0x4a, 0x98, 0x97,
-	0x6f, 0xe3, 0xf1, 0x5d, 0xf4, 0xae, 0x32, 0x1f, 0xb6, 0xb2, 0xa3, 0x08, 0x73, 0x2d, 0x67, 0xc9,
-	0xec, 0x6a, 0x34, 0x57, 0x13, 0xb4, 0xaa, 0x7d, 0xa5, 0xaa, 0x86, 0x3b, 0x99, 0xfc, 0x49, 0xdd,
-	0xe3, 0x2f, 0x46, 0xbe, 0xe3, 0xbf, 0xb9, 0x6a, 0x7a, 0xe8, 0x64, 0x47, 0x2b, 0xf7, 0x75, 0xae,
-	0x33, 0x95, 0xeb, 0x19, 0x5a, 0x99, 0x5c, 0x72, 0x3a, 0x84, 0x97, 0x27, 0xf8, 0x64, 0x11, 0xeb,
-	0xea, 0xde, 0xd7, 0x7d, 0xad, 0x17, 0xc9, 0x37, 0xf8, 0xc0, 0x50, 0xba, 0x32, 0xdd, 0x30, 0xaf,
-	0xeb, 0x6e, 0x5b, 0xcd, 0xeb, 0x5a, 0xd2, 0x46, 0x97, 0xea, 0x52, 0x2d, 0xf3, 0x7f, 0xe9, 0x7a,
-	0xbe, 0x71, 0x59, 0x70, 0x03, 0x3a, 0x2c, 0xaa, 0xff, 0x5d, 0x4f, 0xff, 0x09, 0x00, 0x00, 0xff,
-	0xff, 0xfc, 0xab, 0x53, 0xf0, 0xb1, 0x09, 0x00, 0x00,
+	// 3190 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xcf, 0x6f, 0xdc, 0xc6,
+	0xf5, 0x37, 0xf7, 0x87, 0x56, 0xfb, 0x56, 0xbb, 0x4b, 0x8d, 0x7e, 0xd1, 0x6b, 0xd9, 0x92, 0xd7,
+	0x71, 0xec, 0xc8, 0xdf, 0x48, 0xb1, 0xe3, 0x43, 0x7e, 0x7c, 0x5d, 0xd4, 0x96, 0x05, 0xd4, 0x6d,
+	0xdc, 0x08, 0x94, 0xed, 0x22, 0x6e, 0x01, 0x96, 0x4b, 0x8e, 0x56, 0x84, 0xb8, 0x1c, 0x66, 0x86,
+	0x94, 0xa2, 0xa4, 0x2d, 0xd0, 0x14, 0x3d, 0x05, 0x45, 0x51, 0x14, 0x45, 0x7b, 0x28, 0x90, 0x7b,
+	0xd1, 0x3f, 0xa3, 0x97, 0x9e, 0x7b, 0xea, 0xbd, 0xff, 0x43, 0xd1, 0x5b, 0xf1, 0x66, 0x86, 0x5c,
+	0x72, 0x77, 0x25, 0x25, 0x40, 0x2f, 0x8b, 0x9d, 0xcf, 0xfb, 0xcc, 0xcc, 0x9b, 0x37, 0x6f, 0xde,
+	0xbc, 0x37, 0x84, 0x05, 0x91, 0xb8, 0x49, 0x2a, 0xb6, 0x63, 0xce, 0x12, 0x46, 0x40, 0xa4, 0x31,
+	0xe5, 0x27, 0x81, 0x60, 0xbc, 0xb7, 0x3e, 0x64, 0x6c, 0x18, 0xd2, 0x1d, 0x37, 0x0e, 0x76, 0xdc,
+	0x28, 0x62, 0x89, 0x9b, 0x04, 0x2c, 0xd2, 0xcc, 0xde, 0x86, 0x96, 0xca, 0xd6, 0x20, 0x3d, 0xdc,
+	0x49, 0x82, 0x11, 0x15, 0x89, 0x3b, 0x8a, 0x15, 0xa1, 0x7f, 0x15, 0xd6, 0x0e, 0xf2, 0xc1, 0x0e,
+	0xe4, 0x24, 0x36, 0xfd, 0x34, 0xa5, 0x22, 0xe9, 0x6f, 0x81, 0x35, 0x2d, 0x12, 0x31, 0x8b, 0x04,
+	0x25, 0x1d, 0xa8, 0xb0, 0x63, 0xcb, 0xd8, 0x34, 0xee, 0xce, 0xdb, 0x15, 0x76, 0xdc, 0x5f, 0x81,
+	0xa5, 0xef, 0x51, 0x37, 0x4c, 0x8e, 0xca, 0x43, 0x7c, 0x69, 0xc0, 0x72, 0x19, 0xd7, 0xfd, 0xdf,
+	0x86, 0x3a, 0xae, 0x88, 0xca, 0x21, 0x3a, 0x0f, 0xd6, 0xb6, 0xc7, 0x2b, 0xda, 0x1e, 0x77, 0xa0,
+	0xb6, 0x62, 0x91, 0x0f, 0x01, 0x44, 0x3a, 0x10, 0x67, 0x22, 0xa1, 0x23, 0x61, 0x55, 0x36, 0xab,
+	0x77, 0x5b, 0x0f, 0xae, 0x15, 0xfb, 0x1c, 0x64, 0x52, 0xd5, 0xd9, 0x2e, 0xd0, 0xfb, 0xbf, 0xae,
+	0x40, 0x77, 0x42, 0x4e, 0x08, 0xd4, 0x22, 0x77, 0xa4, 0xa6, 0x6f, 0xda, 0xf2, 0xff, 0x58, 0xa7,
+	0xca, 0x37, 0xd2, 0xe9, 0x1d, 0xa8, 0x8b, 0x20, 0xf2, 0xa8, 0x55, 0xdd, 0x34, 0xee, 0xb6, 0x1e,
+	0xf4, 0xb6, 0x95, 0xa9, 0xb7, 0x33, 0x53, 0x6f, 0xbf, 0xc8, 0x4c, 0x6d, 0x2b, 0x22, 0xb9, 0x0e,
+	0x10, 0xba, 0x22, 0x71, 0x28, 0xe7, 0x8c, 0x5b, 0x35, 0x39, 0x75, 0x13, 0x91, 0x3d, 0x04, 0xc8,
+	0x13, 0xe8, 0x8e, 0xc5, 0x0e, 0x6e, 0x94, 0x55, 0xbf, 0x74, 0xe8, 0x76, 0xde, 0x1f, 0x31, 0xd2,
+	0x83, 0x79, 0x8e, 0x12, 0x9e, 0x08, 0x6b, 0x6e, 0xd3, 0xb8, 0xdb, 0xb6, 0xf3, 0x76, 0xff, 0x4d,
+	0x30, 0x9f, 0x3d, 0xdd, 0x2b, 0x6d, 0x10, 0xda, 0xe1, 0xd4, 0x0d, 0x12, 0xbd, 0x93, 0xf2, 0x7f,
+	0xff, 0x2b, 0x03, 0x16, 0x0b, 0xc4, 0xd9, 0x3b, 0x4e, 0xf6, 0xa0, 0xe1, 0x53, 0x71, 0x9c, 0xb0,
+	0x58, 0xda, 0xab, 0xf5, 0xe0, 0x5e, 0xd1, 0x5e, 0x53, 0xfd, 0xb7, 0x9f, 0x2a, 0xb2, 0x46, 0xb3,
+	0xbe, 0xbd, 0x0d, 0x68, 0x97, 0x24, 0x53, 0x9e, 0xb5, 0x05, 0xcb, 0xbb, 0x2c, 0x4a, 0x68, 0x94,
+	0x5c, 0xae, 0xf9, 0x11, 0xac, 0x4c, 0x70, 0xb5, 0xf2, 0xeb, 0xd0, 0x74, 0x4f, 0xdc, 0x20, 0x74,
+	0x07, 0x21, 0xd5, 0x3d, 0xc6, 0x00, 0xb9, 0x0f, 0x73, 0x82, 0xa5, 0xdc, 0xcb, 0x76, 0xfe, 0x6a,
+	0x71, 0x25, 0xd9, 0x80, 0x92, 0x60, 0x6b, 0x62, 0xdf, 0x82, 0x55, 0x2d, 0xd8, 0xe7, 0x6c, 0xc8,
+	0xa9, 0xc8, 0x5d, 0xfe, 0x9f, 0x06, 0xac, 0x4d, 0x89, 0xb4, 0x1a, 0xdb, 0x50, 0x8f, 0x8f, 0x5c,
+	0x91, 0x79, 0xbd, 0x35, 0x63, 0x9e, 0x7d, 0x94, 0xdb, 0x8a, 0x46, 0x6e, 0x00, 0xc4, 0x94, 0x7b,
+	0x34, 0x4a, 0xdc, 0xa1, 0x52, 0xae, 0x6e, 0x17, 0x10, 0x74, 0xa8, 0xc1, 0x59, 0x42, 0x85, 0xe3,
+	0xb3, 0x48, 0xf9, 0x61, 0xcd, 0x6e, 0x4a, 0xe4, 0x29, 0x8b, 0x28, 0xd9, 0x80, 0x96, 0x12, 0x27,
+	0x2c, 0x71, 0x43, 0xe9, 0x70, 0x35, 0x5b, 0xf5, 0x78, 0x81, 0x08, 0xb1, 0xa0, 0x31, 0xa2, 0x42,
+	0xb8, 0x43, 0xe5, 0x69, 0x4d, 0x3b, 0x6b, 0x92, 0x65, 0xa8, 0x2b, 0x2f, 0x9d, 0x93, 0xb8, 0x6a,
+	0xf4, 0xef, 0xc1, 0xca, 0x53, 0x96, 0x1c, 0x06, 0x21, 0x15, 0x97, 0x6f, 0xc6, 0xdf, 0x0d, 0x58,
+	0x9d, 0x64, 0x6b, 0x3b, 0xdc, 0x00, 0xe0, 0x34, 0x66, 0x22, 0x48, 0x18, 0x3f, 0xd3, 0x67, 0xb0,
+	0x80, 0x90, 0x9d, 0xcc, 0x4e, 0x33, 0xf6, 0x23, 0x1b, 0xb2, 0x64, 0xa8, 0xdb, 0xd0, 0x09, 0x22,
+	0x91, 0xb8, 0x61, 0xe8, 0x08, 0x8f, 0x07, 0x71, 0x22, 0x8d, 0xd1, 0xb4, 0xdb, 0x1a, 0x3d, 0x90,
+	0xe0, 0x78, 0x55, 0xb5, 0xc2, 0xaa, 0xc8, 0x4d, 0x58, 0x08, 0xd9, 0xd0, 0x09, 0x99, 0x27, 0x43,
+	0xa7, 0x36, 0x45, 0x2b, 0x64, 0xc3, 0x8f, 0x34, 0x84, 0xe1, 0xed, 0x89, 0xeb, 0x1d, 0xa7, 0x71,
+	0x39, 0xbc, 0x3d, 0x86, 0xe5, 0x32, 0xac, 0xd7, 0xf7, 0x16, 0x98, 0x9e, 0x1b, 0xb9, 0xfc, 0xcc,
+	0x99, 0xf4, 0xba, 0xae, 0xc2, 0x1f, 0x67, 0x70, 0x3f, 0x00, 0xb2, 0xcf, 0x78, 0x32, 0x61, 0x4f,
+	0x0b, 0x1a, 0x6c, 0x20, 0x28, 0x3f, 0xc9, 0xfa, 0x65, 0x4d, 0xb2, 0x0a, 0x73, 0x5e, 0x18, 0xd0,
+	0x28, 0x91, 0xb6, 0x69, 0xda, 0xba, 0x85, 0x8b, 0xe0, 0x54, 0xa4, 0x23, 0xea, 0x24, 0xec, 0x98,
+	0x46, 0x7a, 0xfd, 0x2d, 0x85, 0xbd, 0x40, 0xa8, 0xff, 0xbb, 0x1a, 0x2c, 0x95, 0xe6, 0x1a, 0xc7,
+	0x62, 0xd7, 0xf7, 0xa9, 0x6f, 0x19, 0x32, 0xae, 0x96, 0xe2, 0x5e, 0x91, 0xaf, 0x58, 0xe4, 0x3e,
+	0x34, 0xd2, 0xd8, 0x77, 0x13, 0xea, 0x5b, 0x95, 0x8b, 0x3b, 0x64, 0x3c, 0x5c, 0x0e, 0xa7, 0x23,
+	0x76, 0x42, 0x7d, 0xab, 0xba, 0x59, 0xbd, 0xdb, 0xb6, 0xb3, 0x26, 0xd9, 0x85, 0x96, 0x1f, 0xb8,
+	0xc3, 0x88, 0x89, 0x24, 0xf0, 0x84, 0xdc, 0x97, 0xd6, 0x83, 0x9b, 0x93, 0x03, 0xee, 0xb2, 0xe8,
+	0x30, 0x18, 0x3e, 0x1d, 0x13, 0xed, 0x62, 0x2f, 0xf2, 0x1e, 0x34, 0x12, 0x1e, 0x0c, 0x87, 0x94,
+	0xcb, 0xbd, 0xeb, 0x3c, 0xb8, 0x31, 0xa5, 0xd1, 0x4b, 0xa9, 0xc9, 0x0b, 0xc5, 0xb2, 0x33, 0xba,
+	0x0a, 0x97, 0x27, 0x81, 0xc0, 0x6d, 0x9f, 0x93, 0xc7, 0x23, 0x6f, 0x4f, 0x59, 0xb4, 0x31, 0x65,
+	0x51, 0xb5, 0x2e, 0x6c, 0xfa, 0xd6, 0xbc, 0xda, 0x26, 0xdd, 0x24, 0xcf, 0x60, 0x5e, 0xcf, 0x21,
+	0xac, 0xa6, 0xb4, 0xd2, 0xdb, 0xe7, 0x59, 0x29, 0x0b, 0x90, 0x5a, 0x39, 0xb1, 0x17, 0x25, 0xfc,
+	0xcc, 0xce, 0xbb, 0xf7, 0x7e, 0x0c, 0xed, 0x92, 0x88, 0x98, 0x50, 0x3d, 0xa6, 0xea, 0xd8, 0xb4,
+	0x6d, 0xfc, 0x4b, 0x1e, 0x42, 0xfd, 0xc4, 0x0d, 0xd3, 0xec, 0xbc, 0x5c, 0xb6, 0x7c, 0x45, 0xfe,
+	0xa0, 0xf2, 0x9e, 0xd1, 0xff, 0xcf, 0x02, 0xb4, 0x0a, 0xca, 0xc8, 0x2b, 0x8a, 0x79, 0x6e, 0xe8,
+	0xc4, 0x8c, 0x27, 0x7a, 0x8a, 0xa6, 0x44, 0x90, 0x85, 0x11, 0x65, 0x18, 0xb2, 0x41, 0x26, 0xaf,
+	0x48, 0x39, 0x28, 0x48, 0x12, 0x56, 0x61, 0x4e, 0xfa, 0xa9, 0x2f, 0xb7, 0x72, 0xde, 0xd6, 0x2d,
+	0xf2, 0x18, 0x1a, 0xf4, 0xb3, 0x98, 0x09, 0xea, 0xeb, 0x3b, 0xed, 0xce, 0x39, 0xe6, 0xd8, 0xde,
+	0x53, 0x34, 0x84, 0x9e, 0x45, 0x87, 0xcc, 0xce, 0xfa, 0x91, 0x77, 0x61, 0xce, 0x93, 0x7e, 0x20,
+	0x77, 0x6a, 0xe2, 0xfe, 0x1f, 0x7b, 0xc9, 0x73, 0x37, 0xf1, 0x8e, 0x6c, 0x4d, 0x45, 0x85, 0x7d,
+	0x9a, 0x50, 0x2f, 0xa1, 0xbe, 0xe3, 0x0a, 0xbd, 0x87, 0x90, 0x41, 0x8f, 0x05, 0x86, 0x84, 0x21,
+	0x67, 0x69, 0x2c, 0x37, 0xb0, 0x69, 0xab, 0x06, 0xc6, 0x93, 0x98, 0x46, 0x7e, 0x10, 0x0d, 0x9d,
+	0x38, 0x1d, 0x84, 0x81, 0x67, 0x35, 0xe5, 0x72, 0xda, 0x1a, 0xdd, 0x97, 0x20, 0xf9, 0x3e, 0x2c,
+	0x9c, 0xb2, 0x34, 0xf4, 0x1d, 0xa5, 0xa3, 0x05, 0xdf, 0x6e, 0x69, 0x2d, 0xd9, 0x59, 0xa1, 0xe8,
+	0x8a, 0x49, 0x1a, 0x45, 0x34, 0xa4, 0xbe, 0xd5, 0x92, 0x93, 0xe5, 0x6d, 0x72, 0x07, 0xba, 0x1e,
+	0x1b, 0x21, 0xcd, 0x41, 0x7b, 0x06, 0x1e, 0xb5, 0x16, 0xa4, 0xba, 0x1d, 0x0d, 0x1f, 0x28, 0x94,
+	0xbc, 0x0d, 0xe4, 0x38, 0x1d, 0x50, 0x1e, 0x51, 0x0c, 0xfb, 0x19, 0xb7, 0x2d, 0xb9, 0x8b, 0x63,
+	0x49, 0x46, 0xbf, 0x01, 0xe0, 0xd3, 0x41, 0x3a, 0x1c, 0xca, 0x08, 0xd5, 0x91, 0xb3, 0x16, 0x10,
+	0xd4, 0x49, 0xb5, 0x28, 0xb7, 0xba, 0x72, 0x90, 0xbc, 0x4d, 0xae, 0x41, 0x53, 0xfe, 0x77, 0x52,
+	0x1e, 0x5a, 0x66, 0x41, 0xf8, 0x92, 0x87, 0x18, 0x00, 0x63, 0x16, 0x06, 0xde, 0x99, 0x73, 0x12,
+	0xb0, 0x50, 0x85, 0xd5, 0x45, 0xc9, 0xe9, 0x2a, 0xfc, 0x55, 0x06, 0x93, 0xf7, 0xa1, 0x1e, 0x73,
+	0xf6, 0xd9, 0x99, 0x45, 0xa4, 0xf1, 0x6e, 0x9d, 0x67, 0xbc, 0x7d, 0x24, 0x65, 0x91, 0x48, 0xf6,
+	0xc8, 0x93, 0xb8, 0xa5, 0x42, 0x12, 0x67, 0x41, 0x23, 0xe6, 0xcc, 0xa3, 0x42, 0x58, 0xcb, 0xea,
+	0x4a, 0xd3, 0x4d, 0xa9, 0x93, 0xde, 0x53, 0xb9, 0x5d, 0x29, 0xa7, 0xd6, 0x8a, 0x0a, 0xca, 0x1a,
+	0xdf, 0xd3, 0x30, 0x79, 0x08, 0xf3, 0x32, 0xd5, 0xf2, 0x58, 0x68, 0xad, 0x4e, 0x5f, 0xd5, 0xa8,
+	0xd6, 0xbe, 0x96, 0xdb, 0x39, 0x53, 0x4e, 0xc0, 0x83, 0x93, 0x20, 0xa4, 0x43, 0xea, 0x3b, 0x9c,
+	0x8e, 0xdc, 0xd8, 0x5a, 0xd3, 0x13, 0xe4, 0xb8, 0x8d, 0x30, 0xb1, 0xc1, 0x94, 0x72, 0x47, 0xa0,
+	0x31, 0x85, 0xb4, 0x8f, 0x75, 0xb1, 0xf3, 0xc8, 0x8e, 0x07, 0x39, 0xdd, 0xee, 0xf2, 0x32, 0x40,
+	0x9e, 0x41, 0xcb, 0x63, 0x51, 0x44, 0x3d, 0x6c, 0x09, 0xeb, 0xea, 0xc5, 0xc3, 0xed, 0xe6, 0x54,
+	0x04, 0x84, 0x5d, 0xec, 0x4b, 0xee, 0xc1, 0x62, 0x44, 0x93, 0x53, 0xc6, 0x8f, 0x1d, 0x34, 0xaa,
+	0x88, 0x5d, 0x8f, 0x5a, 0x3d, 0x69, 0x4e, 0x53, 0x0b, 0x7e, 0x98, 0xe1, 0x32, 0xb7, 0x8a, 0x22,
+	0x96, 0x46, 0x1e, 0xf5, 0xad, 0x6b, 0x3a, 0xb7, 0xca, 0x80, 0xde, 0xd7, 0x06, 0x74, 0x27, 0xfc,
+	0x9e, 0x7c, 0x00, 0x80, 0x31, 0x76, 0x10, 0x84, 0x41, 0x72, 0xa6, 0x73, 0xa1, 0xde, 0xa4, 0xa2,
+	0xaf, 0x72, 0x86, 0x5d, 0x60, 0x63, 0xf0, 0x43, 0x87, 0x53, 0x97, 0x1f, 0xfe, 0x25, 0xdf, 0x01,
+	0x60, 0x91, 0x93, 0x45, 0x97, 0xaa, 0x1c, 0x6d, 0xa3, 0x38, 0xda, 0xc7, 0x11, 0x8e, 0xa7, 0x95,
+	0x78, 0x2c, 0x97, 0x68, 0x37, 0x59, 0xa4, 0x81, 0xde, 0x5f, 0x0c, 0x68, 0x15, 0x9c, 0x8b, 0xdc,
+	0x82, 0xb6, 0xeb, 0x79, 0x34, 0xd6, 0x89, 0xb8, 0x90, 0x0a, 0xd6, 0xec, 0x05, 0x05, 0xca, 0x54,
+	0x5b, 0xc8, 0xb8, 0x12, 0xb8, 0x61, 0x46, 0xa9, 0x48, 0x0a, 0x20, 0xa4, 0x09, 0xc5, 0x44, 0xbc,
+	0x9a, 0xdd, 0x2c, 0xaa, 0xad, 0x8e, 0xd5, 0x90, 0xbb, 0x7e, 0x1e, 0x26, 0xf3, 0xf6, 0x44, 0x8d,
+	0x50, 0x9f, 0xa8, 0x11, 0x7a, 0x5f, 0x1a, 0xd0, 0x9d, 0xf0, 0x04, 0x15, 0x1d, 0x30, 0xda, 0xa5,
+	0x9c, 0xfa, 0xc5, 0xc0, 0xdd, 0x19, 0xc3, 0x32, 0x38, 0xdf, 0x86, 0x8e, 0xf6, 0xb7, 0x8c, 0xa7,
+	0x02, 0x78, 0x3b, 0x47, 0xb3, 0x20, 0xcf, 0x3c, 0x2f, 0x8d, 0x03, 0xea, 0x3b, 0x83, 0x33, 0x9d,
+	0x49, 0x40, 0x06, 0x3d, 0x39, 0xeb, 0xed, 0x41, 0x77, 0xc2, 0x7d, 0x30, 0xee, 0xbb, 0x5e, 0x12,
+	0xe8, 0x7c, 0xa5, 0x6d, 0xeb, 0x96, 0x32, 0x83, 0xcc, 0x69, 0x32, 0x23, 0xe5, 0x6d, 0x2c, 0x3d,
+	0x95, 0x47, 0xa6, 0x03, 0x4c, 0xda, 0x06, 0x94, 0xe7, 0x89, 0xd5, 0x27, 0x60, 0x4d, 0x8b, 0x74,
+	0xba, 0xf2, 0x08, 0x5a, 0x62, 0x0c, 0xeb, 0xa4, 0xe5, 0xda, 0xb4, 0x9f, 0xe7, 0x1c, 0xbb, 0xc8,
+	0xef, 0x0b, 0xe8, 0x4e, 0xc8, 0x0b, 0x39, 0x95, 0x51, 0xca, 0xa9, 0xf2, 0x0a, 0xaf, 0xf2, 0x4d,
+	0x2b, 0xbc, 0x55, 0x98, 0xfb, 0x34, 0xa5, 0xa9, 0xf6, 0xc3, 0xb6, 0xad, 0x5b, 0xfd, 0xdf, 0x18,
+	0xd0, 0x9d, 0xb8, 0xa2, 0xc8, 0xc3, 0xbc, 0xea, 0x50, 0x27, 0x60, 0x7d, 0xf6, 0x7d, 0x56, 0x2e,
+	0x3c, 0x30, 0xe6, 0xe5, 0x3b, 0xd7, 0xb4, 0xe5, 0x7f, 0xbc, 0xc3, 0xb8, 0x1b, 0x0d, 0x55, 0x05,
+	0x30, 0x6f, 0xab, 0x06, 0x9a, 0x9e, 0x9d, 0x50, 0xce, 0x03, 0x9f, 0x66, 0x5e, 0x96, 0xb5, 0xfb,
+	0x2f, 0x61, 0x65, 0x66, 0x5e, 0x45, 0xfe, 0x5f, 0x46, 0xbe, 0x41, 0x48, 0x47, 0x99, 0x65, 0x37,
+	0x2f, 0x4b, 0xc6, 0xec, 0xbc, 0x47, 0xff, 0x73, 0x58, 0x9e, 0xc5, 0xf8, 0x1f, 0x2e, 0xb5, 0x50,
+	0xb1, 0x54, 0x4b, 0x15, 0x4b, 0x7f, 0x1b, 0xc8, 0x0b, 0x57, 0x1c, 0x7f, 0xd3, 0x44, 0xba, 0xbf,
+	0x0b, 0x4b, 0x25, 0xbe, 0xf6, 0xae, 0xff, 0x83, 0x7a, 0x82, 0xb0, 0x5e, 0xfd, 0x6a, 0x51, 0x53,
+	0xe4, 0x67, 0x37, 0x90, 0x24, 0xf5, 0xff, 0x66, 0x00, 0x8c, 0x51, 0xac, 0x5d, 0x03, 0x5f, 0x3b,
+	0x51, 0x25, 0xf0, 0xc9, 0xbd, 0xf2, 0x8b, 0xc2, 0xca, 0xac, 0xc1, 0xf2, 0xf7, 0x04, 0x4c, 0x00,
+	0x28, 0x1f, 0x05, 0x91, 0x1b, 0xea, 0xb5, 0xe5, 0x6d, 0xf2, 0x5d, 0x58, 0x88, 0x39, 0x15, 0x58,
+	0xf6, 0xc9, 0xbb, 0x42, 0xe5, 0xc9, 0xeb, 0x93, 0xe3, 0xed, 0x17, 0x38, 0x76, 0xa9, 0x07, 0x5e,
+	0xd7, 0xf4, 0xb3, 0x20, 0x71, 0x3c, 0xe6, 0xab, 0x62, 0xaf, 0x6e, 0xcf, 0x23, 0xb0, 0xcb, 0x7c,
+	0xda, 0xff, 0x09, 0x98, 0x93, 0xdd, 0x67, 0xbe, 0x90, 0xac, 0x41, 0x83, 0xc5, 0x34, 0x72, 0x82,
+	0x28, 0xab, 0x3e, 0xb0, 0xf9, 0x4c, 0x8e, 0x2e, 0x05, 0x23, 0x1c, 0x5d, 0x2b, 0x8f, 0xc0, 0x73,
+	0x1c, 0x7d, 0x05, 0x96, 0x9e, 0xd3, 0x11, 0xe3, 0x67, 0xe5, 0xe2, 0xe9, 0xdf, 0x06, 0x2c, 0x97,
+	0x71, 0xbd, 0x05, 0x1b, 0xd0, 0x4a, 0x71, 0x4b, 0x1d, 0x59, 0xa9, 0xea, 0xf0, 0x0b, 0x12, 0x7a,
+	0x82, 0x08, 0x12, 0xc2, 0x60, 0x14, 0x24, 0x9a, 0xa0, 0x83, 0xaf, 0x84, 0x14, 0xe1, 0x36, 0x74,
+	0xd2, 0xc8, 0xa7, 0xdc, 0x41, 0x13, 0xc8, 0x8b, 0x5e, 0x9d, 0x8c, 0xb6, 0x44, 0xf7, 0x35, 0x88,
+	0x16, 0xcf, 0x09, 0x68, 0x51, 0xc3, 0xce, 0xdb, 0x72, 0x45, 0x6c, 0xe4, 0x1c, 0x07, 0x61, 0x28,
+	0xa4, 0xbd, 0x6a, 0xf6, 0x3c, 0x63, 0xa3, 0x1f, 0x60, 0x9b, 0x3c, 0xc2, 0xeb, 0x1b, 0x8b, 0x70,
+	0x67, 0xcc, 0x99, 0x93, 0xfe, 0xb2, 0x54, 0xba, 0x78, 0x3e, 0x7e, 0x8e, 0x7c, 0xbb, 0xa3, 0xc8,
+	0x1f, 0xeb, 0xee, 0x7d, 0x0a, 0x0d, 0x2d, 0x22, 0xdb, 0x50, 0x93, 0x0f, 0x3d, 0xc6, 0xa5, 0x11,
+	0x46, 0xf2, 0xf0, 0xfa, 0x8b, 0x03, 0x5f, 0x2e, 0xb9, 0x6a, 0xe3, 0x5f, 0xf4, 0x70, 0x8f, 0x8d,
+	0x46, 0x6e, 0xe4, 0x67, 0x27, 0x42, 0x37, 0xfb, 0x4b, 0xb0, 0xf8, 0x34, 0x10, 0xc7, 0x65, 0xab,
+	0x7f, 0x55, 0x05, 0x52, 0x44, 0xb5, 0xcd, 0xf1, 0xac, 0xb9, 0xc9, 0x51, 0xb6, 0xdb, 0xf8, 0x1f,
+	0xcd, 0x2c, 0x1f, 0x0e, 0xca, 0x66, 0x96, 0x90, 0x32, 0xf3, 0x75, 0x80, 0x54, 0x50, 0x5f, 0xcb,
+	0xf5, 0xf3, 0x03, 0x22, 0x4a, 0x7c, 0x07, 0xba, 0x79, 0xf9, 0xab, 0x39, 0xea, 0x09, 0xa2, 0x93,
+	0xc3, 0x8a, 0xb8, 0x0c, 0xf5, 0x34, 0x7f, 0x84, 0x30, 0x6c, 0xd5, 0xc0, 0xfa, 0x4b, 0x4d, 0x1f,
+	0x44, 0xcc, 0xa7, 0x42, 0xd7, 0x67, 0x4a, 0xa5, 0x67, 0x12, 0x52, 0x9e, 0x42, 0xfd, 0x8c, 0xd1,
+	0xc8, 0x3c, 0x85, 0xfa, 0x9a, 0x70, 0x07, 0xba, 0x41, 0xc4, 0x92, 0xe0, 0xf0, 0xcc, 0x39, 0xc5,
+	0xa0, 0x4b, 0x85, 0xcc, 0xf3, 0x6b, 0x76, 0x47, 0xc3, 0x3f, 0x52, 0x28, 0xd9, 0x86, 0xa5, 0x12,
+	0xd1, 0x91, 0xde, 0x24, 0xb3, 0xfe, 0x9a, 0xbd, 0x58, 0x24, 0x7f, 0x84, 0x02, 0xb2, 0x07, 0x66,
+	0x79, 0x60, 0x2e, 0x2c, 0x90, 0x1e, 0x50, 0x4a, 0x64, 0x9e, 0x15, 0x67, 0xe1, 0x76, 0xb7, 0x34,
+	0x2b, 0x17, 0xfd, 0x57, 0xd0, 0x29, 0x53, 0xb2, 0x0d, 0x36, 0x66, 0x6e, 0x70, 0xa5, 0xb4, 0xc1,
+	0x28, 0xc9, 0x56, 0xa5, 0x8c, 0x9f, 0x35, 0xfb, 0x04, 0xcc, 0xdd, 0xfd, 0x97, 0xe5, 0x9d, 0xff,
+	0x6b, 0x05, 0x16, 0x0b, 0xe0, 0xf8, 0xb0, 0xa9, 0xb3, 0xe4, 0x31, 0xae, 0x0f, 0x9b, 0xa1, 0xcf,
+	0xd2, 0x2e, 0x22, 0xe3, 0xd3, 0xa8, 0x08, 0x15, 0x45, 0x90, 0x90, 0x22, 0xac, 0x43, 0x33, 0x39,
+	0xe2, 0x2c, 0x49, 0x42, 0x7d, 0xed, 0xcd, 0xdb, 0x63, 0x00, 0x77, 0x20, 0x6f, 0x38, 0xe2, 0xc8,
+	0xcd, 0x8f, 0x5a, 0x27, 0x87, 0x0f, 0x10, 0xc5, 0x9c, 0x73, 0x4c, 0x8c, 0x29, 0x0f, 0x98, 0x9f,
+	0x1d, 0x3c, 0x33, 0x17, 0xec, 0x2b, 0x5c, 0x66, 0xf9, 0x9a, 0xa2, 0xdc, 0x22, 0x6b, 0x96, 0x87,
+	0x11, 0xd4, 0x63, 0x91, 0xaf, 0x1c, 0xc3, 0x28, 0x0c, 0x73, 0xa0, 0xf0, 0x52, 0x00, 0x98, 0x2f,
+	0x07, 0x80, 0xad, 0x4f, 0xa0, 0x55, 0x78, 0xf4, 0x25, 0x4b, 0xd0, 0x3d, 0x92, 0x4d, 0x47, 0x26,
+	0x71, 0x41, 0x34, 0x34, 0xaf, 0x90, 0x36, 0x34, 0x35, 0xc8, 0x8e, 0x4d, 0xa3, 0xc0, 0xc9, 0xd2,
+	0x39, 0xb3, 0x42, 0x16, 0xa1, 0xad, 0xc1, 0x43, 0x37, 0x08, 0xa9, 0x6f, 0x56, 0xb7, 0x76, 0xa1,
+	0x5d, 0x7a, 0x55, 0x24, 0x1d, 0x80, 0x43, 0xce, 0x46, 0x0e, 0x4b, 0x8e, 0x28, 0x37, 0xaf, 0x90,
+	0x2e, 0xb4, 0x64, 0x7b, 0x20, 0x1f, 0x97, 0x4c, 0x03, 0x07, 0x91, 0x40, 0xcc, 0xe9, 0x20, 0x0d,
+	0x42, 0xdf, 0xac, 0x6c, 0x7d, 0x6d, 0xc0, 0x42, 0xf1, 0xcd, 0x10, 0x67, 0xf7, 0x54, 0xdb, 0xd1,
+	0xf5, 0x8c, 0x79, 0x85, 0xac, 0x83, 0x95, 0x81, 0x9c, 0x8a, 0x84, 0x71, 0x2c, 0x7f, 0xf2, 0x61,
+	0x37, 0x61, 0x3d, 0x93, 0xfa, 0xec, 0x34, 0x0a, 0x99, 0xab, 0x4a, 0xde, 0x7c, 0x96, 0xe2, 0xa0,
+	0x5e, 0xc8, 0x22, 0x1c, 0xb4, 0x8a, 0xda, 0x8c, 0x07, 0x75, 0xfd, 0x33, 0xb3, 0x46, 0x08, 0x74,
+	0x32, 0x48, 0x2f, 0xb3, 0xbe, 0xf5, 0x0b, 0x68, 0x97, 0x1e, 0xeb, 0xb0, 0x9f, 0xaf, 0x01, 0x27,
+	0x62, 0x11, 0x35, 0xaf, 0x90, 0x65, 0x30, 0x73, 0x28, 0x9b, 0xc0, 0x20, 0x6b, 0xb0, 0x94, 0xa3,
+	0xfa, 0x05, 0x0f, 0x05, 0x15, 0xb2, 0x0a, 0x64, 0x52, 0x80, 0x16, 0x45, 0x35, 0x73, 0x5c, 0xcf,
+	0x5f, 0xdb, 0xfa, 0x6d, 0x05, 0xc8, 0xf4, 0xeb, 0x07, 0x0e, 0x9e, 0x46, 0x22, 0xa6, 0x5e, 0x70,
+	0x88, 0x19, 0xae, 0x7e, 0x67, 0x31, 0xaf, 0x10, 0x0b, 0x96, 0xd5, 0x6b, 0x85, 0xcc, 0x8d, 0x85,
+	0xe3, 0x1d, 0x61, 0x1e, 0xe5, 0x9b, 0x06, 0xb9, 0x0a, 0x2b, 0xba, 0xbe, 0x98, 0x10, 0x55, 0xb0,
+	0x13, 0x42, 0x8e, 0x4a, 0xb5, 0xc7, 0x12, 0x69, 0xa5, 0x91, 0x1b, 0xa5, 0x6e, 0xe8, 0xb8, 0x32,
+	0x51, 0x56, 0x56, 0x52, 0xfd, 0xc5, 0x51, 0x9a, 0xa0, 0xc5, 0xcd, 0x3a, 0xaa, 0xae, 0xea, 0xfc,
+	0x71, 0xdf, 0x39, 0x39, 0x2a, 0x96, 0x24, 0x8e, 0x76, 0x9d, 0x4c, 0xd2, 0x20, 0xd7, 0xe1, 0xea,
+	0x64, 0x15, 0x3b, 0xee, 0x38, 0xaf, 0xf7, 0x5b, 0xa7, 0xe6, 0xe8, 0xaa, 0x05, 0x65, 0x9b, 0x5b,
+	0x6f, 0x41, 0xa7, 0x5c, 0x5a, 0x91, 0x16, 0x96, 0xcb, 0xc1, 0x89, 0x9b, 0xe0, 0x66, 0x00, 0xcc,
+	0xa9, 0xd7, 0x0e, 0xd3, 0xd8, 0x7a, 0x08, 0x0b, 0xc5, 0x32, 0x97, 0xcc, 0x43, 0xed, 0x28, 0x49,
+	0x62, 0xf3, 0x0a, 0x69, 0x40, 0x35, 0xf1, 0xd0, 0x7b, 0x1a, 0x50, 0x4d, 0xfd, 0xd8, 0xac, 0xa0,
+	0x6c, 0xc8, 0x63, 0xcf, 0xac, 0x6e, 0x51, 0x58, 0x9a, 0x51, 0x6d, 0xe1, 0xc0, 0xc1, 0x30, 0x62,
+	0x1c, 0x27, 0x31, 0x61, 0x41, 0xa6, 0x0a, 0x03, 0xce, 0x4e, 0x05, 0xe5, 0xa6, 0x91, 0x23, 0x31,
+	0x3e, 0xbd, 0xd1, 0x53, 0xb3, 0x82, 0x7c, 0x15, 0x15, 0xcd, 0x2a, 0xda, 0x4c, 0xfd, 0x77, 0x32,
+	0x45, 0x6b, 0x5b, 0xaf, 0xc0, 0x9c, 0xcc, 0x1a, 0xd1, 0x93, 0xb0, 0x2e, 0x95, 0x35, 0xa9, 0xde,
+	0x0d, 0xf3, 0x0a, 0x5a, 0x57, 0xfa, 0x49, 0x34, 0x06, 0xa5, 0x7b, 0x31, 0x3e, 0x74, 0xa3, 0xe0,
+	0x73, 0x99, 0xea, 0x64, 0x82, 0xca, 0xd6, 0x7d, 0x68, 0xe6, 0x69, 0x19, 0x9a, 0x06, 0xd5, 0x52,
+	0xe7, 0xa8, 0x05, 0x0d, 0x9e, 0x46, 0xda, 0x3d, 0x01, 0xeb, 0x05, 0x5c, 0x9e, 0x59, 0x79, 0xf0,
+	0xa7, 0x36, 0xb4, 0x55, 0x48, 0xcd, 0x1e, 0x55, 0x7e, 0x06, 0xe6, 0xe4, 0x67, 0x33, 0x72, 0xab,
+	0xfc, 0xad, 0x6a, 0xe6, 0xf7, 0xb6, 0xde, 0x1b, 0x17, 0x93, 0x54, 0xc0, 0xee, 0x5f, 0xff, 0xf2,
+	0x1f, 0xff, 0xfa, 0x7d, 0x65, 0x8d, 0xac, 0xec, 0x9c, 0xdc, 0xdf, 0x51, 0x5f, 0x05, 0x77, 0xc6,
+	0xfd, 0x48, 0x08, 0x0b, 0xc5, 0x0f, 0x6e, 0x64, 0x63, 0xf6, 0x57, 0xac, 0xf1, 0xac, 0x9b, 0xe7,
+	0x13, 0xf4, 0x8c, 0x57, 0xe5, 0x8c, 0x4b, 0x64, 0xb1, 0x30, 0xa3, 0xf2, 0x4b, 0xf2, 0x2b, 0x03,
+	0x9a, 0xf9, 0xa7, 0x1e, 0xb2, 0x7e, 0xce, 0x17, 0x20, 0x35, 0xd1, 0xf5, 0x0b, 0xbf, 0x0f, 0xf5,
+	0xdf, 0x97, 0xb3, 0xbc, 0x4b, 0x3a, 0x85, 0x59, 0x02, 0x9f, 0xbe, 0xbe, 0x49, 0x36, 0xca, 0xc8,
+	0x0e, 0x7e, 0x5d, 0xd8, 0xf9, 0x02, 0x7f, 0x1f, 0x25, 0x3c, 0xa5, 0x3f, 0x27, 0x7f, 0x34, 0xc6,
+	0x01, 0x55, 0x69, 0xb2, 0x39, 0xeb, 0x0b, 0x4e, 0x49, 0x9b, 0x9b, 0x17, 0x30, 0xb4, 0x46, 0x8f,
+	0xa5, 0x46, 0x1f, 0x12, 0x52, 0x98, 0x5f, 0x07, 0xb9, 0xd7, 0xb7, 0xc9, 0xad, 0x69, 0x74, 0x5a,
+	0xb3, 0x5f, 0x1a, 0xb2, 0x54, 0x2e, 0x7e, 0x0c, 0x22, 0xfd, 0x59, 0x5f, 0x7d, 0xca, 0x1f, 0x91,
+	0x7a, 0xb7, 0x2e, 0xe4, 0x68, 0xfd, 0x6e, 0x49, 0xfd, 0xae, 0x93, 0x6b, 0x33, 0x34, 0x89, 0x35,
+	0xf9, 0x1d, 0x83, 0xfc, 0xd9, 0x80, 0x4e, 0xf9, 0x3b, 0x0c, 0xb9, 0x39, 0xeb, 0x83, 0x4a, 0xd9,
+	0x3e, 0xfd, 0x8b, 0x28, 0x5a, 0x81, 0x5d, 0xa9, 0xc0, 0x23, 0xb2, 0x54, 0x50, 0x20, 0x0b, 0xc3,
+	0xaf, 0xdf, 0x24, 0x6f, 0xcc, 0x80, 0xa7, 0x4d, 0x14, 0xc2, 0x42, 0xf1, 0x1b, 0x4a, 0xd9, 0x61,
+	0x67, 0x7c, 0x74, 0xe9, 0x6d, 0x9e, 0x4f, 0xb8, 0xc0, 0x61, 0xd5, 0x9d, 0x47, 0xfe, 0x60, 0x94,
+	0xdf, 0xbb, 0x6f, 0x9c, 0xfb, 0x2a, 0xaf, 0x26, 0xdb, 0xb8, 0xe4, 0xd5, 0x3e, 0xb7, 0x81, 0x59,
+	0x98, 0x4b, 0xc6, 0xf8, 0xd7, 0x6f, 0x91, 0x3b, 0x93, 0xd8, 0x8e, 0x2e, 0x3e, 0x77, 0xbe, 0xd0,
+	0x7f, 0x94, 0x0d, 0xde, 0x31, 0xf0, 0x20, 0x99, 0x93, 0x2f, 0x1e, 0xe4, 0xd6, 0x05, 0x8f, 0x1a,
+	0xb3, 0xa3, 0xc6, 0x79, 0x8f, 0x26, 0xfd, 0x37, 0xa4, 0x9a, 0x37, 0xc8, 0xfa, 0x94, 0x4a, 0x85,
+	0xb7, 0x11, 0x69, 0x9d, 0x42, 0x51, 0x5c, 0xb6, 0xce, 0x74, 0x75, 0xdd, 0xdb, 0x38, 0x57, 0x7e,
+	0x81, 0x75, 0x64, 0xe5, 0xfc, 0xed, 0xac, 0x13, 0xc2, 0x42, 0xb1, 0x52, 0x2c, 0xfb, 0xc8, 0x8c,
+	0xda, 0xb2, 0xb7, 0x79, 0x3e, 0xe1, 0x02, 0x1f, 0x19, 0x49, 0x22, 0xf1, 0x01, 0xc6, 0x15, 0x12,
+	0x29, 0x85, 0xad, 0xa9, 0x7a, 0xaa, 0x77, 0xe3, 0x3c, 0xb1, 0x9e, 0x67, 0x4d, 0xce, 0xb3, 0x48,
+	0xba, 0xc5, 0xc3, 0x10, 0x88, 0x63, 0xf2, 0x53, 0x68, 0xe6, 0xd9, 0x78, 0x39, 0x72, 0x4e, 0x66,
+	0xee, 0xbd, 0xeb, 0xe7, 0x48, 0xf5, 0x14, 0xab, 0x72, 0x0a, 0xb3, 0x14, 0x39, 0xbd, 0x38, 0x7d,
+	0x52, 0x7f, 0x5d, 0x75, 0xe3, 0x60, 0x30, 0x27, 0x8b, 0xc9, 0x77, 0xff, 0x3b, 0x00, 0xfd, 0xe0,
+	0xbd, 0x59, 0x43, 0x22, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -994,19 +3137,38 @@ const _ = grpc.SupportPackageIsVersion6
 type StatusServiceClient interface {
 	// SupervisorStatus returns once supervisor is running.
 	SupervisorStatus(ctx context.Context, in *SupervisorStatusRequest, opts ...grpc.CallOption) (*SupervisorStatusResponse, error)
-	// IDEStatus returns OK if the IDE can serve requests.
+	// HealthStatus returns the health of each subsystem of supervisor, e.g. to find out why the workspace
+	// does not behave as expected. Probes use /_supervisor/health, which fails if a subsystem failed.
+	HealthStatus(ctx context.Context, in *HealthStatusRequest, opts ...grpc.CallOption) (*HealthStatusResponse, error)
+	// IDEStatus returns OK if the IDE can serve requests. If the workspace runs a desktop IDE backend next to
+	// the browser IDE, OK is returned once both can serve requests.
 	IDEStatus(ctx context.Context, in *IDEStatusRequest, opts ...grpc.CallOption) (*IDEStatusResponse, error)
 	// ContentStatus returns the status of the workspace content. When used with `wait`, the call
 	// returns when the content has become available.
 	ContentStatus(ctx context.Context, in *ContentStatusRequest, opts ...grpc.CallOption) (*ContentStatusResponse, error)
+	// ContentProgress streams the progress of the workspace content initialization, i.e. of restoring a backup,
+	// downloading a prebuild or cloning the repository. The stream ends once the content is ready or failed.
+	ContentProgress(ctx context.Context, in *ContentProgressRequest, opts ...grpc.CallOption) (StatusService_ContentProgressClient, error)
+	// DotfilesStatus returns the status of installing the dotfiles repository of the user. When used with `wait`,
+	// the call returns when the dotfiles were installed or failed to install.
+	DotfilesStatus(ctx context.Context, in *DotfilesStatusRequest, opts ...grpc.CallOption) (*DotfilesStatusResponse, error)
 	// BackupStatus offers feedback on the workspace backup status. This status information can
 	// be relayed to the user to provide transparency as to how "safe" their files/content
 	// data are w.r.t. to being lost.
 	BackupStatus(ctx context.Context, in *BackupStatusRequest, opts ...grpc.CallOption) (*BackupStatusResponse, error)
 	// PortsStatus provides feedback about the network ports currently in use.
 	PortsStatus(ctx context.Context, in *PortsStatusRequest, opts ...grpc.CallOption) (StatusService_PortsStatusClient, error)
+	// PortsSubscribers lists the clients currently observing the ports status.
+	PortsSubscribers(ctx context.Context, in *PortsSubscribersRequest, opts ...grpc.CallOption) (*PortsSubscribersResponse, error)
 	// TasksStatus provides tasks status information.
 	TasksStatus(ctx context.Context, in *TasksStatusRequest, opts ...grpc.CallOption) (StatusService_TasksStatusClient, error)
+	// MemoryStatus returns the memory usage of the workspace, whether it is under memory pressure
+	// and the processes which were killed because the workspace ran out of memory.
+	MemoryStatus(ctx context.Context, in *MemoryStatusRequest, opts ...grpc.CallOption) (*MemoryStatusResponse, error)
+	// DiskStatus returns the usage of the workspace filesystem and the processes holding the most inotify watches.
+	DiskStatus(ctx context.Context, in *DiskStatusRequest, opts ...grpc.CallOption) (*DiskStatusResponse, error)
+	// CPUStatus returns the CPU usage and limit of the workspace and how much it is throttled for hitting the limit.
+	CPUStatus(ctx context.Context, in *CPUStatusRequest, opts ...grpc.CallOption) (*CPUStatusResponse, error)
 }
 
 type statusServiceClient struct {
@@ -1026,6 +3188,15 @@ func (c *statusServiceClient) SupervisorStatus(ctx context.Context, in *Supervis
 	return out, nil
 }
 
+func (c *statusServiceClient) HealthStatus(ctx context.Context, in *HealthStatusRequest, opts ...grpc.CallOption) (*HealthStatusResponse, error) {
+	out := new(HealthStatusResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.StatusService/HealthStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *statusServiceClient) IDEStatus(ctx context.Context, in *IDEStatusRequest, opts ...grpc.CallOption) (*IDEStatusResponse, error) {
 	out := new(IDEStatusResponse)
 	err := c.cc.Invoke(ctx, "/supervisor.StatusService/IDEStatus", in, out, opts...)
@@ -1044,6 +3215,47 @@ func (c *statusServiceClient) ContentStatus(ctx context.Context, in *ContentStat
 	return out, nil
 }
 
+func (c *statusServiceClient) ContentProgress(ctx context.Context, in *ContentProgressRequest, opts ...grpc.CallOption) (StatusService_ContentProgressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StatusService_serviceDesc.Streams[0], "/supervisor.StatusService/ContentProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &statusServiceContentProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StatusService_ContentProgressClient interface {
+	Recv() (*ContentProgressResponse, error)
+	grpc.ClientStream
+}
+
+type statusServiceContentProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *statusServiceContentProgressClient) Recv() (*ContentProgressResponse, error) {
+	m := new(ContentProgressResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *statusServiceClient) DotfilesStatus(ctx context.Context, in *DotfilesStatusRequest, opts ...grpc.CallOption) (*DotfilesStatusResponse, error) {
+	out := new(DotfilesStatusResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.StatusService/DotfilesStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *statusServiceClient) BackupStatus(ctx context.Context, in *BackupStatusRequest, opts ...grpc.CallOption) (*BackupStatusResponse, error) {
 	out := new(BackupStatusResponse)
 	err := c.cc.Invoke(ctx, "/supervisor.StatusService/BackupStatus", in, out, opts...)
@@ -1054,7 +3266,7 @@ func (c *statusServiceClient) BackupStatus(ctx context.Context, in *BackupStatus
 }
 
 func (c *statusServiceClient) PortsStatus(ctx context.Context, in *PortsStatusRequest, opts ...grpc.CallOption) (StatusService_PortsStatusClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_StatusService_serviceDesc.Streams[0], "/supervisor.StatusService/PortsStatus", opts...)
+	stream, err := c.cc.NewStream(ctx, &_StatusService_serviceDesc.Streams[1], "/supervisor.StatusService/PortsStatus", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1085,8 +3297,17 @@ func (x *statusServicePortsStatusClient) Recv() (*PortsStatusResponse, error) {
 	return m, nil
 }
 
+func (c *statusServiceClient) PortsSubscribers(ctx context.Context, in *PortsSubscribersRequest, opts ...grpc.CallOption) (*PortsSubscribersResponse, error) {
+	out := new(PortsSubscribersResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.StatusService/PortsSubscribers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *statusServiceClient) TasksStatus(ctx context.Context, in *TasksStatusRequest, opts ...grpc.CallOption) (StatusService_TasksStatusClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_StatusService_serviceDesc.Streams[1], "/supervisor.StatusService/TasksStatus", opts...)
+	stream, err := c.cc.NewStream(ctx, &_StatusService_serviceDesc.Streams[2], "/supervisor.StatusService/TasksStatus", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1117,23 +3338,69 @@ func (x *statusServiceTasksStatusClient) Recv() (*TasksStatusResponse, error) {
 	return m, nil
 }
 
+func (c *statusServiceClient) MemoryStatus(ctx context.Context, in *MemoryStatusRequest, opts ...grpc.CallOption) (*MemoryStatusResponse, error) {
+	out := new(MemoryStatusResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.StatusService/MemoryStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusServiceClient) DiskStatus(ctx context.Context, in *DiskStatusRequest, opts ...grpc.CallOption) (*DiskStatusResponse, error) {
+	out := new(DiskStatusResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.StatusService/DiskStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusServiceClient) CPUStatus(ctx context.Context, in *CPUStatusRequest, opts ...grpc.CallOption) (*CPUStatusResponse, error) {
+	out := new(CPUStatusResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.StatusService/CPUStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StatusServiceServer is the server API for StatusService service.
 type StatusServiceServer interface {
 	// SupervisorStatus returns once supervisor is running.
 	SupervisorStatus(context.Context, *SupervisorStatusRequest) (*SupervisorStatusResponse, error)
-	// IDEStatus returns OK if the IDE can serve requests.
+	// HealthStatus returns the health of each subsystem of supervisor, e.g. to find out why the workspace
+	// does not behave as expected. Probes use /_supervisor/health, which fails if a subsystem failed.
+	HealthStatus(context.Context, *HealthStatusRequest) (*HealthStatusResponse, error)
+	// IDEStatus returns OK if the IDE can serve requests. If the workspace runs a desktop IDE backend next to
+	// the browser IDE, OK is returned once both can serve requests.
 	IDEStatus(context.Context, *IDEStatusRequest) (*IDEStatusResponse, error)
 	// ContentStatus returns the status of the workspace content. When used with `wait`, the call
 	// returns when the content has become available.
 	ContentStatus(context.Context, *ContentStatusRequest) (*ContentStatusResponse, error)
+	// ContentProgress streams the progress of the workspace content initialization, i.e. of restoring a backup,
+	// downloading a prebuild or cloning the repository. The stream ends once the content is ready or failed.
+	ContentProgress(*ContentProgressRequest, StatusService_ContentProgressServer) error
+	// DotfilesStatus returns the status of installing the dotfiles repository of the user. When used with `wait`,
+	// the call returns when the dotfiles were installed or failed to install.
+	DotfilesStatus(context.Context, *DotfilesStatusRequest) (*DotfilesStatusResponse, error)
 	// BackupStatus offers feedback on the workspace backup status. This status information can
 	// be relayed to the user to provide transparency as to how "safe" their files/content
 	// data are w.r.t. to being lost.
 	BackupStatus(context.Context, *BackupStatusRequest) (*BackupStatusResponse, error)
 	// PortsStatus provides feedback about the network ports currently in use.
 	PortsStatus(*PortsStatusRequest, StatusService_PortsStatusServer) error
+	// PortsSubscribers lists the clients currently observing the ports status.
+	PortsSubscribers(context.Context, *PortsSubscribersRequest) (*PortsSubscribersResponse, error)
 	// TasksStatus provides tasks status information.
 	TasksStatus(*TasksStatusRequest, StatusService_TasksStatusServer) error
+	// MemoryStatus returns the memory usage of the workspace, whether it is under memory pressure
+	// and the processes which were killed because the workspace ran out of memory.
+	MemoryStatus(context.Context, *MemoryStatusRequest) (*MemoryStatusResponse, error)
+	// DiskStatus returns the usage of the workspace filesystem and the processes holding the most inotify watches.
+	DiskStatus(context.Context, *DiskStatusRequest) (*DiskStatusResponse, error)
+	// CPUStatus returns the CPU usage and limit of the workspace and how much it is throttled for hitting the limit.
+	CPUStatus(context.Context, *CPUStatusRequest) (*CPUStatusResponse, error)
 }
 
 // UnimplementedStatusServiceServer can be embedded to have forward compatible implementations.
@@ -1143,21 +3410,42 @@ type UnimplementedStatusServiceServer struct {
 func (*UnimplementedStatusServiceServer) SupervisorStatus(ctx context.Context, req *SupervisorStatusRequest) (*SupervisorStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SupervisorStatus not implemented")
 }
+func (*UnimplementedStatusServiceServer) HealthStatus(ctx context.Context, req *HealthStatusRequest) (*HealthStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthStatus not implemented")
+}
 func (*UnimplementedStatusServiceServer) IDEStatus(ctx context.Context, req *IDEStatusRequest) (*IDEStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IDEStatus not implemented")
 }
 func (*UnimplementedStatusServiceServer) ContentStatus(ctx context.Context, req *ContentStatusRequest) (*ContentStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ContentStatus not implemented")
 }
+func (*UnimplementedStatusServiceServer) ContentProgress(req *ContentProgressRequest, srv StatusService_ContentProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method ContentProgress not implemented")
+}
+func (*UnimplementedStatusServiceServer) DotfilesStatus(ctx context.Context, req *DotfilesStatusRequest) (*DotfilesStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DotfilesStatus not implemented")
+}
 func (*UnimplementedStatusServiceServer) BackupStatus(ctx context.Context, req *BackupStatusRequest) (*BackupStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BackupStatus not implemented")
 }
 func (*UnimplementedStatusServiceServer) PortsStatus(req *PortsStatusRequest, srv StatusService_PortsStatusServer) error {
 	return status.Errorf(codes.Unimplemented, "method PortsStatus not implemented")
 }
+func (*UnimplementedStatusServiceServer) PortsSubscribers(ctx context.Context, req *PortsSubscribersRequest) (*PortsSubscribersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PortsSubscribers not implemented")
+}
 func (*UnimplementedStatusServiceServer) TasksStatus(req *TasksStatusRequest, srv StatusService_TasksStatusServer) error {
 	return status.Errorf(codes.Unimplemented, "method TasksStatus not implemented")
 }
+func (*UnimplementedStatusServiceServer) MemoryStatus(ctx context.Context, req *MemoryStatusRequest) (*MemoryStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MemoryStatus not implemented")
+}
+func (*UnimplementedStatusServiceServer) DiskStatus(ctx context.Context, req *DiskStatusRequest) (*DiskStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiskStatus not implemented")
+}
+func (*UnimplementedStatusServiceServer) CPUStatus(ctx context.Context, req *CPUStatusRequest) (*CPUStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CPUStatus not implemented")
+}
 
 func RegisterStatusServiceServer(s *grpc.Server, srv StatusServiceServer) {
 	s.RegisterService(&_StatusService_serviceDesc, srv)
@@ -1181,6 +3469,24 @@ func _StatusService_SupervisorStatus_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StatusService_HealthStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServiceServer).HealthStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.StatusService/HealthStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServiceServer).HealthStatus(ctx, req.(*HealthStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StatusService_IDEStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IDEStatusRequest)
 	if err := dec(in); err != nil {
@@ -1217,6 +3523,45 @@ func _StatusService_ContentStatus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StatusService_ContentProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ContentProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatusServiceServer).ContentProgress(m, &statusServiceContentProgressServer{stream})
+}
+
+type StatusService_ContentProgressServer interface {
+	Send(*ContentProgressResponse) error
+	grpc.ServerStream
+}
+
+type statusServiceContentProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *statusServiceContentProgressServer) Send(m *ContentProgressResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StatusService_DotfilesStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DotfilesStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServiceServer).DotfilesStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.StatusService/DotfilesStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServiceServer).DotfilesStatus(ctx, req.(*DotfilesStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StatusService_BackupStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BackupStatusRequest)
 	if err := dec(in); err != nil {
@@ -1256,6 +3601,24 @@ func (x *statusServicePortsStatusServer) Send(m *PortsStatusResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _StatusService_PortsSubscribers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortsSubscribersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServiceServer).PortsSubscribers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.StatusService/PortsSubscribers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServiceServer).PortsSubscribers(ctx, req.(*PortsSubscribersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StatusService_TasksStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(TasksStatusRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1277,6 +3640,60 @@ func (x *statusServiceTasksStatusServer) Send(m *TasksStatusResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _StatusService_MemoryStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServiceServer).MemoryStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.StatusService/MemoryStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServiceServer).MemoryStatus(ctx, req.(*MemoryStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatusService_DiskStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiskStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServiceServer).DiskStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.StatusService/DiskStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServiceServer).DiskStatus(ctx, req.(*DiskStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatusService_CPUStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CPUStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServiceServer).CPUStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.StatusService/CPUStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServiceServer).CPUStatus(ctx, req.(*CPUStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _StatusService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "supervisor.StatusService",
 	HandlerType: (*StatusServiceServer)(nil),
@@ -1285,6 +3702,10 @@ var _StatusService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SupervisorStatus",
 			Handler:    _StatusService_SupervisorStatus_Handler,
 		},
+		{
+			MethodName: "HealthStatus",
+			Handler:    _StatusService_HealthStatus_Handler,
+		},
 		{
 			MethodName: "IDEStatus",
 			Handler:    _StatusService_IDEStatus_Handler,
@@ -1293,12 +3714,37 @@ var _StatusService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ContentStatus",
 			Handler:    _StatusService_ContentStatus_Handler,
 		},
+		{
+			MethodName: "DotfilesStatus",
+			Handler:    _StatusService_DotfilesStatus_Handler,
+		},
 		{
 			MethodName: "BackupStatus",
 			Handler:    _StatusService_BackupStatus_Handler,
 		},
+		{
+			MethodName: "PortsSubscribers",
+			Handler:    _StatusService_PortsSubscribers_Handler,
+		},
+		{
+			MethodName: "MemoryStatus",
+			Handler:    _StatusService_MemoryStatus_Handler,
+		},
+		{
+			MethodName: "DiskStatus",
+			Handler:    _StatusService_DiskStatus_Handler,
+		},
+		{
+			MethodName: "CPUStatus",
+			Handler:    _StatusService_CPUStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ContentProgress",
+			Handler:       _StatusService_ContentProgress_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "PortsStatus",
 			Handler:       _StatusService_PortsStatus_Handler,
@@ -0,0 +1,974 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: task.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type RestartTaskRequest struct {
+	// id is the id of the task as in TasksStatus
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// force restarts the task even if it is still running
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestartTaskRequest) Reset()         { *m = RestartTaskRequest{} }
+func (m *RestartTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*RestartTaskRequest) ProtoMessage()    {}
+func (*RestartTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{0}
+}
+
+func (m *RestartTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestartTaskRequest.Unmarshal(m, b)
+}
+func (m *RestartTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestartTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *RestartTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestartTaskRequest.Merge(m, src)
+}
+func (m *RestartTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_RestartTaskRequest.Size(m)
+}
+func (m *RestartTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestartTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestartTaskRequest proto.InternalMessageInfo
+
+func (m *RestartTaskRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RestartTaskRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type RestartTaskResponse struct {
+	// terminal is the alias of the terminal the task runs in now
+	Terminal             string   `protobuf:"bytes,1,opt,name=terminal,proto3" json:"terminal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestartTaskResponse) Reset()         { *m = RestartTaskResponse{} }
+func (m *RestartTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*RestartTaskResponse) ProtoMessage()    {}
+func (*RestartTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{1}
+}
+
+func (m *RestartTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestartTaskResponse.Unmarshal(m, b)
+}
+func (m *RestartTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestartTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *RestartTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestartTaskResponse.Merge(m, src)
+}
+func (m *RestartTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_RestartTaskResponse.Size(m)
+}
+func (m *RestartTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestartTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestartTaskResponse proto.InternalMessageInfo
+
+func (m *RestartTaskResponse) GetTerminal() string {
+	if m != nil {
+		return m.Terminal
+	}
+	return ""
+}
+
+type ListPrebuildLogsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPrebuildLogsRequest) Reset()         { *m = ListPrebuildLogsRequest{} }
+func (m *ListPrebuildLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPrebuildLogsRequest) ProtoMessage()    {}
+func (*ListPrebuildLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{2}
+}
+
+func (m *ListPrebuildLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPrebuildLogsRequest.Unmarshal(m, b)
+}
+func (m *ListPrebuildLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPrebuildLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPrebuildLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPrebuildLogsRequest.Merge(m, src)
+}
+func (m *ListPrebuildLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPrebuildLogsRequest.Size(m)
+}
+func (m *ListPrebuildLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPrebuildLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPrebuildLogsRequest proto.InternalMessageInfo
+
+type ListPrebuildLogsResponse struct {
+	Logs                 []*PrebuildLog `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListPrebuildLogsResponse) Reset()         { *m = ListPrebuildLogsResponse{} }
+func (m *ListPrebuildLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPrebuildLogsResponse) ProtoMessage()    {}
+func (*ListPrebuildLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{3}
+}
+
+func (m *ListPrebuildLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPrebuildLogsResponse.Unmarshal(m, b)
+}
+func (m *ListPrebuildLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPrebuildLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPrebuildLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPrebuildLogsResponse.Merge(m, src)
+}
+func (m *ListPrebuildLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPrebuildLogsResponse.Size(m)
+}
+func (m *ListPrebuildLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPrebuildLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPrebuildLogsResponse proto.InternalMessageInfo
+
+func (m *ListPrebuildLogsResponse) GetLogs() []*PrebuildLog {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type PrebuildLog struct {
+	// id is the id of the task as in TasksStatus
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// name is the name of the task
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// commands are the before, init and prebuild commands the task ran, as far as configured
+	Commands []string             `protobuf:"bytes,3,rep,name=commands,proto3" json:"commands,omitempty"`
+	Started  *timestamp.Timestamp `protobuf:"bytes,4,opt,name=started,proto3" json:"started,omitempty"`
+	Finished *timestamp.Timestamp `protobuf:"bytes,5,opt,name=finished,proto3" json:"finished,omitempty"`
+	// success is true if the task finished, regardless of its exit code
+	Success bool `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`
+	// exit_code is the exit code of the task's terminal, -1 if unknown
+	ExitCode int32 `protobuf:"varint,7,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// size is the size of the log in bytes
+	Size                 int64    `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrebuildLog) Reset()         { *m = PrebuildLog{} }
+func (m *PrebuildLog) String() string { return proto.CompactTextString(m) }
+func (*PrebuildLog) ProtoMessage()    {}
+func (*PrebuildLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{4}
+}
+
+func (m *PrebuildLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrebuildLog.Unmarshal(m, b)
+}
+func (m *PrebuildLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrebuildLog.Marshal(b, m, deterministic)
+}
+func (m *PrebuildLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrebuildLog.Merge(m, src)
+}
+func (m *PrebuildLog) XXX_Size() int {
+	return xxx_messageInfo_PrebuildLog.Size(m)
+}
+func (m *PrebuildLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrebuildLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrebuildLog proto.InternalMessageInfo
+
+func (m *PrebuildLog) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PrebuildLog) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PrebuildLog) GetCommands() []string {
+	if m != nil {
+		return m.Commands
+	}
+	return nil
+}
+
+func (m *PrebuildLog) GetStarted() *timestamp.Timestamp {
+	if m != nil {
+		return m.Started
+	}
+	return nil
+}
+
+func (m *PrebuildLog) GetFinished() *timestamp.Timestamp {
+	if m != nil {
+		return m.Finished
+	}
+	return nil
+}
+
+func (m *PrebuildLog) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *PrebuildLog) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *PrebuildLog) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type StreamPrebuildLogRequest struct {
+	// id is the id of the task as in ListPrebuildLogs
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamPrebuildLogRequest) Reset()         { *m = StreamPrebuildLogRequest{} }
+func (m *StreamPrebuildLogRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamPrebuildLogRequest) ProtoMessage()    {}
+func (*StreamPrebuildLogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{5}
+}
+
+func (m *StreamPrebuildLogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamPrebuildLogRequest.Unmarshal(m, b)
+}
+func (m *StreamPrebuildLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamPrebuildLogRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamPrebuildLogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamPrebuildLogRequest.Merge(m, src)
+}
+func (m *StreamPrebuildLogRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamPrebuildLogRequest.Size(m)
+}
+func (m *StreamPrebuildLogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamPrebuildLogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamPrebuildLogRequest proto.InternalMessageInfo
+
+func (m *StreamPrebuildLogRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type StreamPrebuildLogResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamPrebuildLogResponse) Reset()         { *m = StreamPrebuildLogResponse{} }
+func (m *StreamPrebuildLogResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamPrebuildLogResponse) ProtoMessage()    {}
+func (*StreamPrebuildLogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{6}
+}
+
+func (m *StreamPrebuildLogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamPrebuildLogResponse.Unmarshal(m, b)
+}
+func (m *StreamPrebuildLogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamPrebuildLogResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamPrebuildLogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamPrebuildLogResponse.Merge(m, src)
+}
+func (m *StreamPrebuildLogResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamPrebuildLogResponse.Size(m)
+}
+func (m *StreamPrebuildLogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamPrebuildLogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamPrebuildLogResponse proto.InternalMessageInfo
+
+func (m *StreamPrebuildLogResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ListTaskLogsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTaskLogsRequest) Reset()         { *m = ListTaskLogsRequest{} }
+func (m *ListTaskLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTaskLogsRequest) ProtoMessage()    {}
+func (*ListTaskLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{7}
+}
+
+func (m *ListTaskLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTaskLogsRequest.Unmarshal(m, b)
+}
+func (m *ListTaskLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTaskLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTaskLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTaskLogsRequest.Merge(m, src)
+}
+func (m *ListTaskLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTaskLogsRequest.Size(m)
+}
+func (m *ListTaskLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTaskLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTaskLogsRequest proto.InternalMessageInfo
+
+type ListTaskLogsResponse struct {
+	Logs                 []*TaskLog `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListTaskLogsResponse) Reset()         { *m = ListTaskLogsResponse{} }
+func (m *ListTaskLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTaskLogsResponse) ProtoMessage()    {}
+func (*ListTaskLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{8}
+}
+
+func (m *ListTaskLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTaskLogsResponse.Unmarshal(m, b)
+}
+func (m *ListTaskLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTaskLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTaskLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTaskLogsResponse.Merge(m, src)
+}
+func (m *ListTaskLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTaskLogsResponse.Size(m)
+}
+func (m *ListTaskLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTaskLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTaskLogsResponse proto.InternalMessageInfo
+
+func (m *ListTaskLogsResponse) GetLogs() []*TaskLog {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type TaskLog struct {
+	// id is the id of the task as in TasksStatus
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// name is the name of the task if it is configured in this workspace
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// size is the size of the log in bytes, including rotated files
+	Size     int64                `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Modified *timestamp.Timestamp `protobuf:"bytes,4,opt,name=modified,proto3" json:"modified,omitempty"`
+	// active is true if the output of the task is currently being logged
+	Active               bool     `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskLog) Reset()         { *m = TaskLog{} }
+func (m *TaskLog) String() string { return proto.CompactTextString(m) }
+func (*TaskLog) ProtoMessage()    {}
+func (*TaskLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{9}
+}
+
+func (m *TaskLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskLog.Unmarshal(m, b)
+}
+func (m *TaskLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskLog.Marshal(b, m, deterministic)
+}
+func (m *TaskLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskLog.Merge(m, src)
+}
+func (m *TaskLog) XXX_Size() int {
+	return xxx_messageInfo_TaskLog.Size(m)
+}
+func (m *TaskLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskLog proto.InternalMessageInfo
+
+func (m *TaskLog) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TaskLog) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TaskLog) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *TaskLog) GetModified() *timestamp.Timestamp {
+	if m != nil {
+		return m.Modified
+	}
+	return nil
+}
+
+func (m *TaskLog) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+type StreamTaskLogRequest struct {
+	// id is the id of the task as in ListTaskLogs
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// tail limits the output to its last bytes if > 0
+	Tail int64 `protobuf:"varint,2,opt,name=tail,proto3" json:"tail,omitempty"`
+	// follow keeps streaming the output the task writes afterwards, until the output of the task ends
+	Follow               bool     `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamTaskLogRequest) Reset()         { *m = StreamTaskLogRequest{} }
+func (m *StreamTaskLogRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamTaskLogRequest) ProtoMessage()    {}
+func (*StreamTaskLogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{10}
+}
+
+func (m *StreamTaskLogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamTaskLogRequest.Unmarshal(m, b)
+}
+func (m *StreamTaskLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamTaskLogRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamTaskLogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamTaskLogRequest.Merge(m, src)
+}
+func (m *StreamTaskLogRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamTaskLogRequest.Size(m)
+}
+func (m *StreamTaskLogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamTaskLogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamTaskLogRequest proto.InternalMessageInfo
+
+func (m *StreamTaskLogRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *StreamTaskLogRequest) GetTail() int64 {
+	if m != nil {
+		return m.Tail
+	}
+	return 0
+}
+
+func (m *StreamTaskLogRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type StreamTaskLogResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamTaskLogResponse) Reset()         { *m = StreamTaskLogResponse{} }
+func (m *StreamTaskLogResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamTaskLogResponse) ProtoMessage()    {}
+func (*StreamTaskLogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{11}
+}
+
+func (m *StreamTaskLogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamTaskLogResponse.Unmarshal(m, b)
+}
+func (m *StreamTaskLogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamTaskLogResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamTaskLogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamTaskLogResponse.Merge(m, src)
+}
+func (m *StreamTaskLogResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamTaskLogResponse.Size(m)
+}
+func (m *StreamTaskLogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamTaskLogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamTaskLogResponse proto.InternalMessageInfo
+
+func (m *StreamTaskLogResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*RestartTaskRequest)(nil), "supervisor.RestartTaskRequest")
+	proto.RegisterType((*RestartTaskResponse)(nil), "supervisor.RestartTaskResponse")
+	proto.RegisterType((*ListPrebuildLogsRequest)(nil), "supervisor.ListPrebuildLogsRequest")
+	proto.RegisterType((*ListPrebuildLogsResponse)(nil), "supervisor.ListPrebuildLogsResponse")
+	proto.RegisterType((*PrebuildLog)(nil), "supervisor.PrebuildLog")
+	proto.RegisterType((*StreamPrebuildLogRequest)(nil), "supervisor.StreamPrebuildLogRequest")
+	proto.RegisterType((*StreamPrebuildLogResponse)(nil), "supervisor.StreamPrebuildLogResponse")
+	proto.RegisterType((*ListTaskLogsRequest)(nil), "supervisor.ListTaskLogsRequest")
+	proto.RegisterType((*ListTaskLogsResponse)(nil), "supervisor.ListTaskLogsResponse")
+	proto.RegisterType((*TaskLog)(nil), "supervisor.TaskLog")
+	proto.RegisterType((*StreamTaskLogRequest)(nil), "supervisor.StreamTaskLogRequest")
+	proto.RegisterType((*StreamTaskLogResponse)(nil), "supervisor.StreamTaskLogResponse")
+}
+
+func init() {
+	proto.RegisterFile("task.proto", fileDescriptor_ce5d8dd45b4a91ff)
+}
+
+var fileDescriptor_ce5d8dd45b4a91ff = []byte{
+	// 668 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4f, 0x53, 0xd4, 0x4e,
+	0x10, 0xad, 0x6c, 0x76, 0xd9, 0xd0, 0x0b, 0xbf, 0x9f, 0x0e, 0xbb, 0x10, 0x82, 0x40, 0x0c, 0x5a,
+	0x6e, 0x49, 0xb9, 0x11, 0xb4, 0x3c, 0x78, 0xb1, 0x4a, 0x0f, 0x5e, 0x38, 0x58, 0x03, 0x27, 0x2f,
+	0xd6, 0x90, 0xcc, 0x2e, 0x53, 0x24, 0x99, 0x98, 0x99, 0x45, 0x4b, 0x8b, 0x8b, 0x07, 0x3d, 0x5b,
+	0x7e, 0x34, 0x3f, 0x82, 0x7e, 0x10, 0x2b, 0x93, 0xc9, 0x92, 0xec, 0x1f, 0xe0, 0x36, 0x3d, 0xf3,
+	0xd2, 0xaf, 0xdf, 0xeb, 0xee, 0x00, 0x48, 0x22, 0xce, 0x07, 0x69, 0xc6, 0x25, 0x47, 0x20, 0xc6,
+	0x29, 0xcd, 0x2e, 0x98, 0xe0, 0x99, 0x73, 0x6f, 0xc4, 0xf9, 0x28, 0xa2, 0x3e, 0x49, 0x99, 0x4f,
+	0x92, 0x84, 0x4b, 0x22, 0x19, 0x4f, 0x44, 0x81, 0x74, 0x76, 0xf5, 0xab, 0x8a, 0x4e, 0xc7, 0x43,
+	0x5f, 0xb2, 0x98, 0x0a, 0x49, 0xe2, 0xb4, 0x00, 0x78, 0x2f, 0x01, 0xe1, 0xfc, 0x22, 0x93, 0x27,
+	0x44, 0x9c, 0x63, 0xfa, 0x71, 0x4c, 0x85, 0x44, 0xff, 0x41, 0x83, 0x85, 0xb6, 0xe1, 0x1a, 0xfd,
+	0x65, 0xdc, 0x60, 0x21, 0xea, 0x42, 0x6b, 0xc8, 0xb3, 0x80, 0xda, 0x0d, 0xd7, 0xe8, 0x5b, 0xb8,
+	0x08, 0xbc, 0x03, 0x58, 0xab, 0x7d, 0x2b, 0x52, 0x9e, 0x08, 0x8a, 0x1c, 0xb0, 0x24, 0xcd, 0x62,
+	0x96, 0x90, 0x48, 0xa7, 0x98, 0xc4, 0xde, 0x26, 0x6c, 0x1c, 0x31, 0x21, 0xdf, 0x65, 0xf4, 0x74,
+	0xcc, 0xa2, 0xf0, 0x88, 0x8f, 0x84, 0xe6, 0xf4, 0xde, 0x82, 0x3d, 0xfb, 0xa4, 0x53, 0xee, 0x43,
+	0x33, 0xe2, 0x23, 0x61, 0x1b, 0xae, 0xd9, 0xef, 0x1c, 0x6e, 0x0c, 0xae, 0xf4, 0x0f, 0x2a, 0x78,
+	0xac, 0x40, 0xde, 0xf7, 0x06, 0x74, 0x2a, 0xb7, 0x33, 0x62, 0x10, 0x34, 0x13, 0x12, 0x17, 0x5a,
+	0x96, 0xb1, 0x3a, 0xe7, 0x35, 0x07, 0x3c, 0x8e, 0x49, 0x12, 0x0a, 0xdb, 0x74, 0xcd, 0xbc, 0xe6,
+	0x32, 0x46, 0xcf, 0xa1, 0xad, 0x44, 0xd2, 0xd0, 0x6e, 0xba, 0x46, 0xbf, 0x73, 0xe8, 0x0c, 0x0a,
+	0x57, 0x07, 0xa5, 0xab, 0x83, 0x93, 0xd2, 0x55, 0x5c, 0x42, 0xd1, 0x0b, 0xb0, 0x86, 0x2c, 0x61,
+	0xe2, 0x8c, 0x86, 0x76, 0xeb, 0xc6, 0xcf, 0x26, 0x58, 0x64, 0x43, 0x5b, 0x8c, 0x83, 0x80, 0x0a,
+	0x61, 0x2f, 0x29, 0xb3, 0xcb, 0x10, 0x6d, 0xc1, 0x32, 0xfd, 0xcc, 0xe4, 0x87, 0x80, 0x87, 0xd4,
+	0x6e, 0xbb, 0x46, 0xbf, 0x85, 0xad, 0xfc, 0xe2, 0x0d, 0x0f, 0x69, 0x2e, 0x4a, 0xb0, 0x2f, 0xd4,
+	0xb6, 0x5c, 0xa3, 0x6f, 0x62, 0x75, 0xf6, 0x1e, 0x83, 0x7d, 0x2c, 0x33, 0x4a, 0xe2, 0xaa, 0x47,
+	0xf3, 0x3b, 0xec, 0xf9, 0xb0, 0x39, 0x07, 0xab, 0xed, 0x47, 0xd0, 0x0c, 0x89, 0x24, 0x0a, 0xbe,
+	0x82, 0xd5, 0xd9, 0xeb, 0xc1, 0x5a, 0xde, 0xae, 0xbc, 0xf3, 0xd5, 0x2e, 0xbe, 0x82, 0x6e, 0xfd,
+	0x5a, 0xa7, 0x78, 0x54, 0xeb, 0xe0, 0x5a, 0xb5, 0x83, 0x1a, 0xab, 0xbb, 0xf7, 0xd3, 0x80, 0xb6,
+	0xbe, 0xb9, 0x55, 0xe7, 0x4a, 0xe1, 0xe6, 0x95, 0xf0, 0xdc, 0xfb, 0x98, 0x87, 0x6c, 0xc8, 0x6e,
+	0xd5, 0xb2, 0x09, 0x16, 0xad, 0xc3, 0x12, 0x09, 0x24, 0xbb, 0xa0, 0xaa, 0x63, 0x16, 0xd6, 0x91,
+	0x87, 0xa1, 0x5b, 0x98, 0x53, 0x96, 0xba, 0x60, 0x4d, 0x10, 0x34, 0x25, 0x61, 0x91, 0xaa, 0xcf,
+	0xc4, 0xea, 0x9c, 0xe7, 0x1c, 0xf2, 0x28, 0xe2, 0x9f, 0x54, 0x85, 0x16, 0xd6, 0x91, 0xb7, 0x0f,
+	0xbd, 0xa9, 0x9c, 0x8b, 0xcd, 0x3e, 0xfc, 0xd3, 0x84, 0x4e, 0x8e, 0x3b, 0xce, 0x3d, 0x0b, 0x28,
+	0x8a, 0xa1, 0x53, 0xd9, 0x3c, 0xb4, 0x53, 0xb5, 0x73, 0x76, 0x9d, 0x9d, 0xdd, 0x85, 0xef, 0x05,
+	0xa7, 0xb7, 0xfd, 0xed, 0xf7, 0xdf, 0x5f, 0x8d, 0x0d, 0xaf, 0xe7, 0x5f, 0x1c, 0xf8, 0xf9, 0x8f,
+	0xc6, 0xcf, 0x0a, 0x94, 0xff, 0x95, 0x85, 0x97, 0xe8, 0x12, 0xee, 0x4c, 0xaf, 0x26, 0xda, 0xab,
+	0xe6, 0x5c, 0xb0, 0xd3, 0xce, 0x83, 0xeb, 0x41, 0x9a, 0x7d, 0x47, 0xb1, 0xdb, 0x68, 0x7d, 0xc2,
+	0x9e, 0x6a, 0xd8, 0x93, 0x7c, 0x24, 0xd0, 0x0f, 0x03, 0xee, 0xce, 0x0c, 0x27, 0xaa, 0xe5, 0x5e,
+	0x34, 0xe7, 0xce, 0xc3, 0x1b, 0x50, 0xba, 0x84, 0x3d, 0x55, 0xc2, 0x36, 0xda, 0x9a, 0x5f, 0x82,
+	0xb2, 0xe1, 0xa9, 0x81, 0xce, 0x60, 0xa5, 0x3a, 0xdd, 0x68, 0x77, 0x5a, 0xdf, 0xd4, 0x3a, 0x38,
+	0xee, 0x62, 0x80, 0x66, 0xee, 0x29, 0xe6, 0xff, 0xd1, 0xea, 0x84, 0x59, 0x69, 0xce, 0x60, 0xb5,
+	0x36, 0x1e, 0xc8, 0x9d, 0x15, 0x52, 0x9f, 0x46, 0xe7, 0xfe, 0x35, 0x08, 0x4d, 0xe6, 0x28, 0xb2,
+	0x2e, 0x42, 0x35, 0x32, 0xad, 0xee, 0x75, 0xeb, 0xbd, 0x49, 0x52, 0x76, 0xba, 0xa4, 0x76, 0xe4,
+	0xd9, 0xbf, 0x01, 0x00, 0x74, 0x8e, 0x7f, 0x0d, 0x72, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// TaskServiceClient is the client API for TaskService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TaskServiceClient interface {
+	// RestartTask re-runs the command of a task in a fresh terminal, e.g. after it failed.
+	// A task which is still running is only restarted if forced, which closes its terminal first.
+	RestartTask(ctx context.Context, in *RestartTaskRequest, opts ...grpc.CallOption) (*RestartTaskResponse, error)
+	// ListPrebuildLogs lists the logs the tasks wrote while the prebuild this workspace started from ran.
+	// The list is empty if the workspace did not start from a prebuild.
+	ListPrebuildLogs(ctx context.Context, in *ListPrebuildLogsRequest, opts ...grpc.CallOption) (*ListPrebuildLogsResponse, error)
+	// StreamPrebuildLog streams the output a task wrote during the prebuild.
+	StreamPrebuildLog(ctx context.Context, in *StreamPrebuildLogRequest, opts ...grpc.CallOption) (TaskService_StreamPrebuildLogClient, error)
+	// ListTaskLogs lists the logs of the output of the tasks, which are kept on the workspace disk
+	// across IDE reloads and supervisor restarts. The list is empty if task logs are disabled.
+	ListTaskLogs(ctx context.Context, in *ListTaskLogsRequest, opts ...grpc.CallOption) (*ListTaskLogsResponse, error)
+	// StreamTaskLog streams the logged output of a task, oldest first. Logs are rotated, hence the
+	// output of long running tasks is truncated at the front.
+	StreamTaskLog(ctx context.Context, in *StreamTaskLogRequest, opts ...grpc.CallOption) (TaskService_StreamTaskLogClient, error)
+}
+
+type taskServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTaskServiceClient(cc grpc.ClientConnInterface) TaskServiceClient {
+	return &taskServiceClient{cc}
+}
+
+func (c *taskServiceClient) RestartTask(ctx context.Context, in *RestartTaskRequest, opts ...grpc.CallOption) (*RestartTaskResponse, error) {
+	out := new(RestartTaskResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TaskService/RestartTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ListPrebuildLogs(ctx context.Context, in *ListPrebuildLogsRequest, opts ...grpc.CallOption) (*ListPrebuildLogsResponse, error) {
+	out := new(ListPrebuildLogsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TaskService/ListPrebuildLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) StreamPrebuildLog(ctx context.Context, in *StreamPrebuildLogRequest, opts ...grpc.CallOption) (TaskService_StreamPrebuildLogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TaskService_serviceDesc.Streams[0], "/supervisor.TaskService/StreamPrebuildLog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taskServiceStreamPrebuildLogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaskService_StreamPrebuildLogClient interface {
+	Recv() (*StreamPrebuildLogResponse, error)
+	grpc.ClientStream
+}
+
+type taskServiceStreamPrebuildLogClient struct {
+	grpc.ClientStream
+}
+
+func (x *taskServiceStreamPrebuildLogClient) Recv() (*StreamPrebuildLogResponse, error) {
+	m := new(StreamPrebuildLogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taskServiceClient) ListTaskLogs(ctx context.Context, in *ListTaskLogsRequest, opts ...grpc.CallOption) (*ListTaskLogsResponse, error) {
+	out := new(ListTaskLogsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TaskService/ListTaskLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) StreamTaskLog(ctx context.Context, in *StreamTaskLogRequest, opts ...grpc.CallOption) (TaskService_StreamTaskLogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TaskService_serviceDesc.Streams[1], "/supervisor.TaskService/StreamTaskLog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taskServiceStreamTaskLogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaskService_StreamTaskLogClient interface {
+	Recv() (*StreamTaskLogResponse, error)
+	grpc.ClientStream
+}
+
+type taskServiceStreamTaskLogClient struct {
+	grpc.ClientStream
+}
+
+func (x *taskServiceStreamTaskLogClient) Recv() (*StreamTaskLogResponse, error) {
+	m := new(StreamTaskLogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TaskServiceServer is the server API for TaskService service.
+type TaskServiceServer interface {
+	// RestartTask re-runs the command of a task in a fresh terminal, e.g. after it failed.
+	// A task which is still running is only restarted if forced, which closes its terminal first.
+	RestartTask(context.Context, *RestartTaskRequest) (*RestartTaskResponse, error)
+	// ListPrebuildLogs lists the logs the tasks wrote while the prebuild this workspace started from ran.
+	// The list is empty if the workspace did not start from a prebuild.
+	ListPrebuildLogs(context.Context, *ListPrebuildLogsRequest) (*ListPrebuildLogsResponse, error)
+	// StreamPrebuildLog streams the output a task wrote during the prebuild.
+	StreamPrebuildLog(*StreamPrebuildLogRequest, TaskService_StreamPrebuildLogServer) error
+	// ListTaskLogs lists the logs of the output of the tasks, which are kept on the workspace disk
+	// across IDE reloads and supervisor restarts. The list is empty if task logs are disabled.
+	ListTaskLogs(context.Context, *ListTaskLogsRequest) (*ListTaskLogsResponse, error)
+	// StreamTaskLog streams the logged output of a task, oldest first. Logs are rotated, hence the
+	// output of long running tasks is truncated at the front.
+	StreamTaskLog(*StreamTaskLogRequest, TaskService_StreamTaskLogServer) error
+}
+
+// UnimplementedTaskServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTaskServiceServer struct {
+}
+
+func (*UnimplementedTaskServiceServer) RestartTask(ctx context.Context, req *RestartTaskRequest) (*RestartTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartTask not implemented")
+}
+func (*UnimplementedTaskServiceServer) ListPrebuildLogs(ctx context.Context, req *ListPrebuildLogsRequest) (*ListPrebuildLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPrebuildLogs not implemented")
+}
+func (*UnimplementedTaskServiceServer) StreamPrebuildLog(req *StreamPrebuildLogRequest, srv TaskService_StreamPrebuildLogServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPrebuildLog not implemented")
+}
+func (*UnimplementedTaskServiceServer) ListTaskLogs(ctx context.Context, req *ListTaskLogsRequest) (*ListTaskLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskLogs not implemented")
+}
+func (*UnimplementedTaskServiceServer) StreamTaskLog(req *StreamTaskLogRequest, srv TaskService_StreamTaskLogServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTaskLog not implemented")
+}
+
+func RegisterTaskServiceServer(s *grpc.Server, srv TaskServiceServer) {
+	s.RegisterService(&_TaskService_serviceDesc, srv)
+}
+
+func _TaskService_RestartTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).RestartTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TaskService/RestartTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).RestartTask(ctx, req.(*RestartTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ListPrebuildLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPrebuildLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ListPrebuildLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TaskService/ListPrebuildLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ListPrebuildLogs(ctx, req.(*ListPrebuildLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_StreamPrebuildLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPrebuildLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServiceServer).StreamPrebuildLog(m, &taskServiceStreamPrebuildLogServer{stream})
+}
+
+type TaskService_StreamPrebuildLogServer interface {
+	Send(*StreamPrebuildLogResponse) error
+	grpc.ServerStream
+}
+
+type taskServiceStreamPrebuildLogServer struct {
+	grpc.ServerStream
+}
+
+func (x *taskServiceStreamPrebuildLogServer) Send(m *StreamPrebuildLogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TaskService_ListTaskLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ListTaskLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TaskService/ListTaskLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ListTaskLogs(ctx, req.(*ListTaskLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_StreamTaskLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTaskLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServiceServer).StreamTaskLog(m, &taskServiceStreamTaskLogServer{stream})
+}
+
+type TaskService_StreamTaskLogServer interface {
+	Send(*StreamTaskLogResponse) error
+	grpc.ServerStream
+}
+
+type taskServiceStreamTaskLogServer struct {
+	grpc.ServerStream
+}
+
+func (x *taskServiceStreamTaskLogServer) Send(m *StreamTaskLogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _TaskService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.TaskService",
+	HandlerType: (*TaskServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RestartTask",
+			Handler:    _TaskService_RestartTask_Handler,
+		},
+		{
+			MethodName: "ListPrebuildLogs",
+			Handler:    _TaskService_ListPrebuildLogs_Handler,
+		},
+		{
+			MethodName: "ListTaskLogs",
+			Handler:    _TaskService_ListTaskLogs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPrebuildLog",
+			Handler:       _TaskService_StreamPrebuildLog_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTaskLog",
+			Handler:       _TaskService_StreamTaskLog_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "task.proto",
+}
@@ -11,6 +11,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -30,10 +31,14 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type OpenTerminalRequest struct {
-	Env                  map[string]string `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Env map[string]string `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// task is the ID of the task the terminal is opened for, whose terminal defaults apply in addition to the global ones
+	Task string `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	// workdir is the working directory of the terminal, which overrides the defaults if set
+	Workdir              string   `protobuf:"bytes,4,opt,name=workdir,proto3" json:"workdir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *OpenTerminalRequest) Reset()         { *m = OpenTerminalRequest{} }
@@ -68,6 +73,20 @@ func (m *OpenTerminalRequest) GetEnv() map[string]string {
 	return nil
 }
 
+func (m *OpenTerminalRequest) GetTask() string {
+	if m != nil {
+		return m.Task
+	}
+	return ""
+}
+
+func (m *OpenTerminalRequest) GetWorkdir() string {
+	if m != nil {
+		return m.Workdir
+	}
+	return ""
+}
+
 type OpenTerminalResponse struct {
 	Alias string `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
 	// starter_token can be used to change the terminal size if there are
@@ -258,9 +277,14 @@ func (m *ListTerminalsResponse) GetTerminals() []*ListTerminalsResponse_Terminal
 }
 
 type ListTerminalsResponse_Terminal struct {
-	Alias                string   `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
-	Command              []string `protobuf:"bytes,2,rep,name=command,proto3" json:"command,omitempty"`
-	Title                string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Alias   string   `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Command []string `protobuf:"bytes,2,rep,name=command,proto3" json:"command,omitempty"`
+	Title   string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// pid is the process ID of the terminal's shell
+	Pid int64 `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	// listeners is the number of clients currently listening to the terminal.
+	// Terminals without listeners keep running and can be attached to again.
+	Listeners            uint32   `protobuf:"varint,5,opt,name=listeners,proto3" json:"listeners,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -312,6 +336,20 @@ func (m *ListTerminalsResponse_Terminal) GetTitle() string {
 	return ""
 }
 
+func (m *ListTerminalsResponse_Terminal) GetPid() int64 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *ListTerminalsResponse_Terminal) GetListeners() uint32 {
+	if m != nil {
+		return m.Listeners
+	}
+	return 0
+}
+
 type ListenTerminalRequest struct {
 	Alias                string   `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -673,6 +711,467 @@ func (m *SetTerminalSizeResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SetTerminalSizeResponse proto.InternalMessageInfo
 
+type ListTerminalRecordingsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTerminalRecordingsRequest) Reset()         { *m = ListTerminalRecordingsRequest{} }
+func (m *ListTerminalRecordingsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTerminalRecordingsRequest) ProtoMessage()    {}
+func (*ListTerminalRecordingsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{12}
+}
+
+func (m *ListTerminalRecordingsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTerminalRecordingsRequest.Unmarshal(m, b)
+}
+func (m *ListTerminalRecordingsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTerminalRecordingsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTerminalRecordingsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTerminalRecordingsRequest.Merge(m, src)
+}
+func (m *ListTerminalRecordingsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTerminalRecordingsRequest.Size(m)
+}
+func (m *ListTerminalRecordingsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTerminalRecordingsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTerminalRecordingsRequest proto.InternalMessageInfo
+
+type ListTerminalRecordingsResponse struct {
+	Recordings           []*ListTerminalRecordingsResponse_Recording `protobuf:"bytes,1,rep,name=recordings,proto3" json:"recordings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *ListTerminalRecordingsResponse) Reset()         { *m = ListTerminalRecordingsResponse{} }
+func (m *ListTerminalRecordingsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTerminalRecordingsResponse) ProtoMessage()    {}
+func (*ListTerminalRecordingsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{13}
+}
+
+func (m *ListTerminalRecordingsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTerminalRecordingsResponse.Unmarshal(m, b)
+}
+func (m *ListTerminalRecordingsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTerminalRecordingsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTerminalRecordingsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTerminalRecordingsResponse.Merge(m, src)
+}
+func (m *ListTerminalRecordingsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTerminalRecordingsResponse.Size(m)
+}
+func (m *ListTerminalRecordingsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTerminalRecordingsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTerminalRecordingsResponse proto.InternalMessageInfo
+
+func (m *ListTerminalRecordingsResponse) GetRecordings() []*ListTerminalRecordingsResponse_Recording {
+	if m != nil {
+		return m.Recordings
+	}
+	return nil
+}
+
+type ListTerminalRecordingsResponse_Recording struct {
+	// alias is the alias of the recorded terminal
+	Alias string `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	// size is the size of the recording in bytes
+	Size    int64                `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Started *timestamp.Timestamp `protobuf:"bytes,3,opt,name=started,proto3" json:"started,omitempty"`
+	// active is true if the terminal is still open and recorded
+	Active               bool     `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTerminalRecordingsResponse_Recording) Reset() {
+	*m = ListTerminalRecordingsResponse_Recording{}
+}
+func (m *ListTerminalRecordingsResponse_Recording) String() string { return proto.CompactTextString(m) }
+func (*ListTerminalRecordingsResponse_Recording) ProtoMessage()    {}
+func (*ListTerminalRecordingsResponse_Recording) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{13, 0}
+}
+
+func (m *ListTerminalRecordingsResponse_Recording) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTerminalRecordingsResponse_Recording.Unmarshal(m, b)
+}
+func (m *ListTerminalRecordingsResponse_Recording) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTerminalRecordingsResponse_Recording.Marshal(b, m, deterministic)
+}
+func (m *ListTerminalRecordingsResponse_Recording) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTerminalRecordingsResponse_Recording.Merge(m, src)
+}
+func (m *ListTerminalRecordingsResponse_Recording) XXX_Size() int {
+	return xxx_messageInfo_ListTerminalRecordingsResponse_Recording.Size(m)
+}
+func (m *ListTerminalRecordingsResponse_Recording) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTerminalRecordingsResponse_Recording.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTerminalRecordingsResponse_Recording proto.InternalMessageInfo
+
+func (m *ListTerminalRecordingsResponse_Recording) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *ListTerminalRecordingsResponse_Recording) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ListTerminalRecordingsResponse_Recording) GetStarted() *timestamp.Timestamp {
+	if m != nil {
+		return m.Started
+	}
+	return nil
+}
+
+func (m *ListTerminalRecordingsResponse_Recording) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+type StreamTerminalRecordingRequest struct {
+	Alias                string   `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamTerminalRecordingRequest) Reset()         { *m = StreamTerminalRecordingRequest{} }
+func (m *StreamTerminalRecordingRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamTerminalRecordingRequest) ProtoMessage()    {}
+func (*StreamTerminalRecordingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{14}
+}
+
+func (m *StreamTerminalRecordingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamTerminalRecordingRequest.Unmarshal(m, b)
+}
+func (m *StreamTerminalRecordingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamTerminalRecordingRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamTerminalRecordingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamTerminalRecordingRequest.Merge(m, src)
+}
+func (m *StreamTerminalRecordingRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamTerminalRecordingRequest.Size(m)
+}
+func (m *StreamTerminalRecordingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamTerminalRecordingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamTerminalRecordingRequest proto.InternalMessageInfo
+
+func (m *StreamTerminalRecordingRequest) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+type StreamTerminalRecordingResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamTerminalRecordingResponse) Reset()         { *m = StreamTerminalRecordingResponse{} }
+func (m *StreamTerminalRecordingResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamTerminalRecordingResponse) ProtoMessage()    {}
+func (*StreamTerminalRecordingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{15}
+}
+
+func (m *StreamTerminalRecordingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamTerminalRecordingResponse.Unmarshal(m, b)
+}
+func (m *StreamTerminalRecordingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamTerminalRecordingResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamTerminalRecordingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamTerminalRecordingResponse.Merge(m, src)
+}
+func (m *StreamTerminalRecordingResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamTerminalRecordingResponse.Size(m)
+}
+func (m *StreamTerminalRecordingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamTerminalRecordingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamTerminalRecordingResponse proto.InternalMessageInfo
+
+func (m *StreamTerminalRecordingResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type TerminalDefaults struct {
+	// env are environment variables which new terminals start with. They override the environment of supervisor.
+	Env map[string]string `protobuf:"bytes,1,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// workdir is the working directory of new terminals if set
+	Workdir              string   `protobuf:"bytes,2,opt,name=workdir,proto3" json:"workdir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TerminalDefaults) Reset()         { *m = TerminalDefaults{} }
+func (m *TerminalDefaults) String() string { return proto.CompactTextString(m) }
+func (*TerminalDefaults) ProtoMessage()    {}
+func (*TerminalDefaults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{16}
+}
+
+func (m *TerminalDefaults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TerminalDefaults.Unmarshal(m, b)
+}
+func (m *TerminalDefaults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TerminalDefaults.Marshal(b, m, deterministic)
+}
+func (m *TerminalDefaults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TerminalDefaults.Merge(m, src)
+}
+func (m *TerminalDefaults) XXX_Size() int {
+	return xxx_messageInfo_TerminalDefaults.Size(m)
+}
+func (m *TerminalDefaults) XXX_DiscardUnknown() {
+	xxx_messageInfo_TerminalDefaults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TerminalDefaults proto.InternalMessageInfo
+
+func (m *TerminalDefaults) GetEnv() map[string]string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *TerminalDefaults) GetWorkdir() string {
+	if m != nil {
+		return m.Workdir
+	}
+	return ""
+}
+
+type SetTerminalDefaultsRequest struct {
+	// task is the ID of the task whose terminals the defaults apply to. The defaults apply to all terminals if empty.
+	// Task defaults take precedence over the global ones.
+	Task string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// env are added to the default environment variables, replacing those of the same name
+	Env map[string]string `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// unset_env are the names of default environment variables to remove
+	UnsetEnv []string `protobuf:"bytes,3,rep,name=unset_env,json=unsetEnv,proto3" json:"unset_env,omitempty"`
+	// workdir replaces the default working directory if set. It must be an absolute path to a directory.
+	Workdir string `protobuf:"bytes,4,opt,name=workdir,proto3" json:"workdir,omitempty"`
+	// clear removes all defaults of the scope before the request is applied
+	Clear                bool     `protobuf:"varint,5,opt,name=clear,proto3" json:"clear,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTerminalDefaultsRequest) Reset()         { *m = SetTerminalDefaultsRequest{} }
+func (m *SetTerminalDefaultsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetTerminalDefaultsRequest) ProtoMessage()    {}
+func (*SetTerminalDefaultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{17}
+}
+
+func (m *SetTerminalDefaultsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTerminalDefaultsRequest.Unmarshal(m, b)
+}
+func (m *SetTerminalDefaultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTerminalDefaultsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetTerminalDefaultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTerminalDefaultsRequest.Merge(m, src)
+}
+func (m *SetTerminalDefaultsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetTerminalDefaultsRequest.Size(m)
+}
+func (m *SetTerminalDefaultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTerminalDefaultsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTerminalDefaultsRequest proto.InternalMessageInfo
+
+func (m *SetTerminalDefaultsRequest) GetTask() string {
+	if m != nil {
+		return m.Task
+	}
+	return ""
+}
+
+func (m *SetTerminalDefaultsRequest) GetEnv() map[string]string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *SetTerminalDefaultsRequest) GetUnsetEnv() []string {
+	if m != nil {
+		return m.UnsetEnv
+	}
+	return nil
+}
+
+func (m *SetTerminalDefaultsRequest) GetWorkdir() string {
+	if m != nil {
+		return m.Workdir
+	}
+	return ""
+}
+
+func (m *SetTerminalDefaultsRequest) GetClear() bool {
+	if m != nil {
+		return m.Clear
+	}
+	return false
+}
+
+type SetTerminalDefaultsResponse struct {
+	// defaults are the defaults of the scope after the change
+	Defaults             *TerminalDefaults `protobuf:"bytes,1,opt,name=defaults,proto3" json:"defaults,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetTerminalDefaultsResponse) Reset()         { *m = SetTerminalDefaultsResponse{} }
+func (m *SetTerminalDefaultsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetTerminalDefaultsResponse) ProtoMessage()    {}
+func (*SetTerminalDefaultsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{18}
+}
+
+func (m *SetTerminalDefaultsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTerminalDefaultsResponse.Unmarshal(m, b)
+}
+func (m *SetTerminalDefaultsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTerminalDefaultsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetTerminalDefaultsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTerminalDefaultsResponse.Merge(m, src)
+}
+func (m *SetTerminalDefaultsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetTerminalDefaultsResponse.Size(m)
+}
+func (m *SetTerminalDefaultsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTerminalDefaultsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTerminalDefaultsResponse proto.InternalMessageInfo
+
+func (m *SetTerminalDefaultsResponse) GetDefaults() *TerminalDefaults {
+	if m != nil {
+		return m.Defaults
+	}
+	return nil
+}
+
+type GetTerminalDefaultsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTerminalDefaultsRequest) Reset()         { *m = GetTerminalDefaultsRequest{} }
+func (m *GetTerminalDefaultsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTerminalDefaultsRequest) ProtoMessage()    {}
+func (*GetTerminalDefaultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{19}
+}
+
+func (m *GetTerminalDefaultsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTerminalDefaultsRequest.Unmarshal(m, b)
+}
+func (m *GetTerminalDefaultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTerminalDefaultsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTerminalDefaultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTerminalDefaultsRequest.Merge(m, src)
+}
+func (m *GetTerminalDefaultsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTerminalDefaultsRequest.Size(m)
+}
+func (m *GetTerminalDefaultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTerminalDefaultsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTerminalDefaultsRequest proto.InternalMessageInfo
+
+type GetTerminalDefaultsResponse struct {
+	Global *TerminalDefaults `protobuf:"bytes,1,opt,name=global,proto3" json:"global,omitempty"`
+	// tasks are the defaults of tasks by task ID
+	Tasks                map[string]*TerminalDefaults `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *GetTerminalDefaultsResponse) Reset()         { *m = GetTerminalDefaultsResponse{} }
+func (m *GetTerminalDefaultsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTerminalDefaultsResponse) ProtoMessage()    {}
+func (*GetTerminalDefaultsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff8b8260c8ef16ad, []int{20}
+}
+
+func (m *GetTerminalDefaultsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTerminalDefaultsResponse.Unmarshal(m, b)
+}
+func (m *GetTerminalDefaultsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTerminalDefaultsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTerminalDefaultsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTerminalDefaultsResponse.Merge(m, src)
+}
+func (m *GetTerminalDefaultsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTerminalDefaultsResponse.Size(m)
+}
+func (m *GetTerminalDefaultsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTerminalDefaultsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTerminalDefaultsResponse proto.InternalMessageInfo
+
+func (m *GetTerminalDefaultsResponse) GetGlobal() *TerminalDefaults {
+	if m != nil {
+		return m.Global
+	}
+	return nil
+}
+
+func (m *GetTerminalDefaultsResponse) GetTasks() map[string]*TerminalDefaults {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*OpenTerminalRequest)(nil), "supervisor.OpenTerminalRequest")
 	proto.RegisterMapType((map[string]string)(nil), "supervisor.OpenTerminalRequest.EnvEntry")
@@ -688,6 +1187,19 @@ func init() {
 	proto.RegisterType((*WriteTerminalResponse)(nil), "supervisor.WriteTerminalResponse")
 	proto.RegisterType((*SetTerminalSizeRequest)(nil), "supervisor.SetTerminalSizeRequest")
 	proto.RegisterType((*SetTerminalSizeResponse)(nil), "supervisor.SetTerminalSizeResponse")
+	proto.RegisterType((*ListTerminalRecordingsRequest)(nil), "supervisor.ListTerminalRecordingsRequest")
+	proto.RegisterType((*ListTerminalRecordingsResponse)(nil), "supervisor.ListTerminalRecordingsResponse")
+	proto.RegisterType((*ListTerminalRecordingsResponse_Recording)(nil), "supervisor.ListTerminalRecordingsResponse.Recording")
+	proto.RegisterType((*StreamTerminalRecordingRequest)(nil), "supervisor.StreamTerminalRecordingRequest")
+	proto.RegisterType((*StreamTerminalRecordingResponse)(nil), "supervisor.StreamTerminalRecordingResponse")
+	proto.RegisterType((*TerminalDefaults)(nil), "supervisor.TerminalDefaults")
+	proto.RegisterMapType((map[string]string)(nil), "supervisor.TerminalDefaults.EnvEntry")
+	proto.RegisterType((*SetTerminalDefaultsRequest)(nil), "supervisor.SetTerminalDefaultsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "supervisor.SetTerminalDefaultsRequest.EnvEntry")
+	proto.RegisterType((*SetTerminalDefaultsResponse)(nil), "supervisor.SetTerminalDefaultsResponse")
+	proto.RegisterType((*GetTerminalDefaultsRequest)(nil), "supervisor.GetTerminalDefaultsRequest")
+	proto.RegisterType((*GetTerminalDefaultsResponse)(nil), "supervisor.GetTerminalDefaultsResponse")
+	proto.RegisterMapType((map[string]*TerminalDefaults)(nil), "supervisor.GetTerminalDefaultsResponse.TasksEntry")
 }
 
 func init() {
@@ -695,50 +1207,82 @@ func init() {
 }
 
 var fileDescriptor_ff8b8260c8ef16ad = []byte{
-	// 686 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4d, 0x4f, 0x13, 0x41,
-	0x18, 0x66, 0xfb, 0x45, 0x79, 0x2d, 0x7e, 0x0c, 0xa5, 0x2c, 0x2b, 0x46, 0x9c, 0x5e, 0x88, 0xd1,
-	0x56, 0x31, 0x31, 0x86, 0x78, 0xaa, 0x21, 0x21, 0xd1, 0x44, 0x5c, 0x88, 0x24, 0x5e, 0xc8, 0xd2,
-	0x0e, 0x30, 0x61, 0x99, 0x59, 0x67, 0xa6, 0x85, 0x6a, 0xbc, 0x78, 0xf3, 0xec, 0x4f, 0xf1, 0x4f,
-	0x78, 0xf7, 0x2f, 0xf8, 0x23, 0x3c, 0x9a, 0xf9, 0xd8, 0x96, 0x6d, 0x97, 0xea, 0x6d, 0xdf, 0x67,
-	0x9e, 0x79, 0xde, 0x79, 0x3f, 0x9e, 0x2c, 0xdc, 0x54, 0x44, 0x9c, 0x53, 0x16, 0xc5, 0xad, 0x44,
-	0x70, 0xc5, 0x11, 0xc8, 0x7e, 0x42, 0xc4, 0x80, 0x4a, 0x2e, 0x82, 0xb5, 0x13, 0xce, 0x4f, 0x62,
-	0xd2, 0x8e, 0x12, 0xda, 0x8e, 0x18, 0xe3, 0x2a, 0x52, 0x94, 0x33, 0x69, 0x99, 0xf8, 0x9b, 0x07,
-	0x4b, 0x6f, 0x13, 0xc2, 0xf6, 0x9d, 0x40, 0x48, 0x3e, 0xf6, 0x89, 0x54, 0x68, 0x0b, 0x8a, 0x84,
-	0x0d, 0xfc, 0xc2, 0x7a, 0x71, 0xe3, 0xc6, 0xe6, 0x46, 0x6b, 0xac, 0xd7, 0xca, 0x61, 0xb7, 0xb6,
-	0xd9, 0x60, 0x9b, 0x29, 0x31, 0x0c, 0xf5, 0xa5, 0xe0, 0x39, 0x54, 0x53, 0x00, 0xdd, 0x86, 0xe2,
-	0x19, 0x19, 0xfa, 0xde, 0xba, 0xb7, 0xb1, 0x10, 0xea, 0x4f, 0x54, 0x87, 0xf2, 0x20, 0x8a, 0xfb,
-	0xc4, 0x2f, 0x18, 0xcc, 0x06, 0x5b, 0x85, 0x17, 0x1e, 0x7e, 0x07, 0xf5, 0xac, 0xb8, 0x4c, 0x38,
-	0x93, 0x44, 0xdf, 0x88, 0x62, 0x1a, 0x49, 0xa7, 0x62, 0x03, 0xd4, 0x84, 0x45, 0xa9, 0x22, 0xa1,
-	0x88, 0x38, 0x54, 0xfc, 0x8c, 0x30, 0xa7, 0x57, 0x73, 0xe0, 0xbe, 0xc6, 0xf0, 0x23, 0xa8, 0xbf,
-	0x8a, 0xb9, 0x24, 0x93, 0xe5, 0xe5, 0x4a, 0xe2, 0x15, 0x58, 0x9e, 0x60, 0xdb, 0x17, 0xe0, 0x06,
-	0xd4, 0xdf, 0x50, 0xa9, 0x52, 0x5c, 0x3a, 0x19, 0xfc, 0xc3, 0x83, 0xe5, 0x89, 0x03, 0xf7, 0xe6,
-	0x1d, 0x58, 0x48, 0x67, 0xa2, 0x93, 0xe8, 0x2e, 0x3e, 0xbc, 0xda, 0xc5, 0xdc, 0x5b, 0xad, 0x51,
-	0xe2, 0xf1, 0xe5, 0x60, 0x17, 0xaa, 0x29, 0x7c, 0x4d, 0x27, 0x7c, 0x98, 0xef, 0xf2, 0xf3, 0xf3,
-	0x88, 0xf5, 0xcc, 0xbc, 0x16, 0xc2, 0x34, 0xd4, 0x7c, 0x45, 0x55, 0x4c, 0xfc, 0xa2, 0xe5, 0x9b,
-	0x00, 0x3f, 0xb6, 0x8f, 0x9e, 0x1e, 0x7a, 0x7e, 0x57, 0xde, 0x43, 0x63, 0x92, 0xee, 0x8a, 0xf4,
-	0xa1, 0x22, 0x55, 0x8f, 0xf7, 0x95, 0xb9, 0x50, 0xdb, 0x99, 0x0b, 0x5d, 0xec, 0x4e, 0x88, 0x10,
-	0x66, 0x2a, 0xe9, 0x09, 0x11, 0xa2, 0x53, 0x85, 0x0a, 0xef, 0xab, 0xa4, 0xaf, 0x70, 0x07, 0xea,
-	0x07, 0x82, 0xaa, 0xff, 0x9b, 0x8d, 0x46, 0xa5, 0xea, 0x51, 0x3b, 0xe6, 0x5a, 0x68, 0x03, 0xfc,
-	0x12, 0x96, 0x27, 0x34, 0xdc, 0xd3, 0x9a, 0xb0, 0x78, 0x34, 0x54, 0x44, 0x1e, 0x5e, 0x08, 0xaa,
-	0x14, 0x61, 0x46, 0x6c, 0x31, 0xac, 0x19, 0xf0, 0xc0, 0x62, 0xf8, 0xa7, 0x07, 0x8d, 0x3d, 0x32,
-	0x9a, 0xc3, 0x1e, 0xfd, 0x44, 0x66, 0x3f, 0xa2, 0x01, 0xe5, 0x2b, 0xbb, 0xb6, 0x33, 0x17, 0xda,
-	0x50, 0xe3, 0xc7, 0x5c, 0x74, 0x6d, 0x9f, 0xab, 0x1a, 0x37, 0x21, 0x42, 0x50, 0x12, 0xfc, 0x42,
-	0xfa, 0x25, 0x93, 0xdc, 0x7c, 0x6b, 0xac, 0xcb, 0x63, 0xe9, 0x97, 0x2d, 0xa6, 0xbf, 0xf5, 0x04,
-	0x2f, 0x68, 0x4f, 0x9d, 0xee, 0x5e, 0xfa, 0x15, 0x03, 0xa7, 0x21, 0x0a, 0xa0, 0x7a, 0x4a, 0xe8,
-	0xc9, 0xa9, 0xda, 0xbd, 0xf4, 0xe7, 0xcd, 0xd1, 0x28, 0xee, 0x00, 0x54, 0x13, 0x41, 0xb9, 0xa0,
-	0x6a, 0x88, 0x57, 0x61, 0x65, 0xaa, 0x12, 0xdb, 0x8a, 0xcd, 0x3f, 0x25, 0xb8, 0x35, 0x3a, 0xd0,
-	0xfb, 0xd7, 0x25, 0xe8, 0x35, 0x94, 0xb4, 0xd5, 0xd0, 0xfd, 0x7f, 0x38, 0x3b, 0x58, 0xbf, 0x9e,
-	0xe0, 0xbc, 0x31, 0x87, 0x12, 0x28, 0x1b, 0xdb, 0xa0, 0x0c, 0x39, 0xcf, 0x77, 0xc1, 0x83, 0x19,
-	0x0c, 0xa7, 0x87, 0xbf, 0xfe, 0xfa, 0xfd, 0xbd, 0xb0, 0x86, 0x82, 0xf6, 0xe0, 0x69, 0x3b, 0xb5,
-	0x41, 0xbb, 0xab, 0xb9, 0xed, 0xcf, 0x66, 0x0c, 0x5f, 0xd0, 0x31, 0x94, 0xf4, 0x4a, 0x66, 0x13,
-	0xe6, 0x39, 0x34, 0x9b, 0x30, 0xd7, 0x74, 0x78, 0xd5, 0x24, 0x5c, 0x42, 0x77, 0x32, 0x09, 0x63,
-	0xad, 0x3f, 0x80, 0x8a, 0x5d, 0x7d, 0x34, 0xa5, 0x33, 0xdd, 0x2a, 0x3c, 0x8b, 0xe2, 0x72, 0x35,
-	0x4d, 0xae, 0x7b, 0xe8, 0xee, 0x54, 0x2e, 0xc2, 0xd2, 0xea, 0x9e, 0x78, 0xba, 0xa3, 0x66, 0xad,
-	0xb3, 0x05, 0xe6, 0xb9, 0x25, 0x5b, 0x60, 0xae, 0x17, 0xd2, 0x8e, 0xe2, 0x6c, 0x47, 0xb5, 0x31,
-	0xc6, 0x1d, 0xdd, 0x87, 0xf9, 0x3d, 0xa2, 0xf4, 0xde, 0xa0, 0x4c, 0x1d, 0xf9, 0xf6, 0x08, 0x9a,
-	0x33, 0x39, 0xe9, 0x66, 0x74, 0xca, 0x1f, 0x8a, 0x51, 0x42, 0x8f, 0x2a, 0xe6, 0x5f, 0xf3, 0xec,
-	0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xf9, 0x30, 0xbf, 0x84, 0xa7, 0x06, 0x00, 0x00,
+	// 1192 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4b, 0x6f, 0x23, 0x45,
+	0x10, 0xde, 0xf1, 0x2b, 0x76, 0x25, 0xd9, 0x5d, 0x7a, 0xf3, 0x98, 0x4c, 0x1e, 0x4e, 0x3a, 0xc0,
+	0x86, 0x00, 0x36, 0x98, 0xb0, 0xac, 0x22, 0x2e, 0x04, 0xa2, 0x44, 0x02, 0x89, 0x65, 0x12, 0x6d,
+	0x24, 0x2e, 0xd1, 0xc4, 0xee, 0x24, 0xad, 0x8c, 0x67, 0x4c, 0x77, 0xdb, 0x49, 0x16, 0xad, 0xb4,
+	0x82, 0x0b, 0x77, 0xc4, 0x91, 0xdf, 0xc1, 0x99, 0x1b, 0x77, 0xfe, 0xc2, 0xfe, 0x05, 0x24, 0x0e,
+	0x1c, 0x50, 0x3f, 0xc6, 0x9e, 0xb1, 0x3d, 0x13, 0x0b, 0x71, 0xeb, 0xaa, 0xae, 0xc7, 0xd7, 0x5f,
+	0x75, 0x57, 0x35, 0xdc, 0x17, 0x84, 0xb5, 0x69, 0xe0, 0xf9, 0xb5, 0x0e, 0x0b, 0x45, 0x88, 0x80,
+	0x77, 0x3b, 0x84, 0xf5, 0x28, 0x0f, 0x99, 0xb3, 0x72, 0x11, 0x86, 0x17, 0x3e, 0xa9, 0x7b, 0x1d,
+	0x5a, 0xf7, 0x82, 0x20, 0x14, 0x9e, 0xa0, 0x61, 0xc0, 0xb5, 0xa5, 0x53, 0x35, 0xbb, 0x4a, 0x3a,
+	0xeb, 0x9e, 0xd7, 0x05, 0x6d, 0x13, 0x2e, 0xbc, 0x76, 0x47, 0x1b, 0xe0, 0xdf, 0x2c, 0x78, 0xf4,
+	0x75, 0x87, 0x04, 0xc7, 0x26, 0x83, 0x4b, 0xbe, 0xeb, 0x12, 0x2e, 0xd0, 0x2e, 0xe4, 0x49, 0xd0,
+	0xb3, 0x73, 0xeb, 0xf9, 0xad, 0xe9, 0xc6, 0x56, 0x6d, 0x90, 0xb0, 0x36, 0xc6, 0xba, 0xb6, 0x1f,
+	0xf4, 0xf6, 0x03, 0xc1, 0x6e, 0x5d, 0xe9, 0x84, 0x10, 0x14, 0x84, 0xc7, 0xaf, 0xec, 0xfc, 0xba,
+	0xb5, 0x55, 0x71, 0xd5, 0x1a, 0xd9, 0x30, 0x75, 0x1d, 0xb2, 0xab, 0x16, 0x65, 0x76, 0x41, 0xa9,
+	0x23, 0xd1, 0x79, 0x02, 0xe5, 0xc8, 0x1d, 0x3d, 0x84, 0xfc, 0x15, 0xb9, 0xb5, 0x2d, 0x65, 0x21,
+	0x97, 0x68, 0x0e, 0x8a, 0x3d, 0xcf, 0xef, 0x12, 0x3b, 0xa7, 0x74, 0x5a, 0xd8, 0xcd, 0x3d, 0xb5,
+	0xf0, 0x37, 0x30, 0x97, 0x84, 0xc2, 0x3b, 0x61, 0xc0, 0x89, 0xf4, 0xf0, 0x7c, 0xea, 0x71, 0x13,
+	0x45, 0x0b, 0x68, 0x13, 0x66, 0xb9, 0xf0, 0x98, 0x20, 0xec, 0x54, 0x84, 0x57, 0x24, 0x30, 0xf1,
+	0x66, 0x8c, 0xf2, 0x58, 0xea, 0xf0, 0x7b, 0x30, 0xf7, 0xb9, 0x1f, 0x72, 0x32, 0x4c, 0xc6, 0xd8,
+	0x90, 0x78, 0x11, 0xe6, 0x87, 0xac, 0x35, 0x02, 0xbc, 0x00, 0x73, 0x5f, 0x51, 0x2e, 0x22, 0x3d,
+	0x37, 0x61, 0xf0, 0x6b, 0x0b, 0xe6, 0x87, 0x36, 0x0c, 0xe6, 0x43, 0xa8, 0x44, 0x25, 0x96, 0x49,
+	0x24, 0xe7, 0xdb, 0x71, 0xce, 0xc7, 0x7a, 0xd5, 0xfa, 0x89, 0x07, 0xce, 0xce, 0x2b, 0x0b, 0xca,
+	0x91, 0x3e, 0x85, 0x0a, 0x1b, 0xa6, 0x9a, 0x61, 0xbb, 0xed, 0x05, 0x2d, 0x55, 0xde, 0x8a, 0x1b,
+	0x89, 0xd2, 0x5e, 0x50, 0xe1, 0x13, 0x53, 0x39, 0x2d, 0xc8, 0xa2, 0x74, 0x68, 0x4b, 0x95, 0x2d,
+	0xef, 0xca, 0x25, 0x5a, 0x81, 0x8a, 0x4f, 0xb9, 0x20, 0x01, 0x61, 0xdc, 0x2e, 0xae, 0x5b, 0x5b,
+	0xb3, 0xee, 0x40, 0x81, 0xdf, 0xd7, 0xa7, 0x1c, 0xbd, 0x53, 0xe3, 0x69, 0x7c, 0x0e, 0x0b, 0xc3,
+	0xe6, 0x86, 0x15, 0x1b, 0x4a, 0x5c, 0xb4, 0xc2, 0xae, 0x50, 0x0e, 0x33, 0x87, 0xf7, 0x5c, 0x23,
+	0x9b, 0x1d, 0xc2, 0x98, 0x9d, 0x8b, 0xed, 0x10, 0xc6, 0xf6, 0xca, 0x50, 0x0a, 0xbb, 0xa2, 0xd3,
+	0x15, 0x78, 0x0f, 0xe6, 0x4e, 0x18, 0x15, 0x93, 0x15, 0x53, 0x6a, 0xb9, 0x68, 0x51, 0x7d, 0x2f,
+	0x66, 0x5c, 0x2d, 0xe0, 0x4f, 0x61, 0x7e, 0x28, 0x86, 0x81, 0xb6, 0x09, 0xb3, 0x67, 0xb7, 0x82,
+	0xf0, 0xd3, 0x6b, 0x46, 0x85, 0x20, 0x81, 0x0a, 0x36, 0xeb, 0xce, 0x28, 0xe5, 0x89, 0xd6, 0xe1,
+	0x3f, 0x2c, 0x58, 0x38, 0x22, 0xfd, 0xc2, 0x1d, 0xd1, 0x17, 0x24, 0x1b, 0xc4, 0x02, 0x14, 0x63,
+	0x97, 0xf3, 0xf0, 0x9e, 0xab, 0x45, 0xa9, 0x3f, 0x0f, 0x59, 0x53, 0xd7, 0xa5, 0x2c, 0xf5, 0x4a,
+	0x94, 0x0f, 0x8d, 0x85, 0xd7, 0x5c, 0x95, 0x66, 0xd6, 0x55, 0x6b, 0xa9, 0x6b, 0x86, 0x7e, 0x54,
+	0x16, 0xb5, 0x56, 0x8f, 0x8f, 0xb6, 0xc4, 0xe5, 0xb3, 0x1b, 0xbb, 0xa4, 0xd4, 0x91, 0x88, 0x1c,
+	0x28, 0x5f, 0x12, 0x7a, 0x71, 0x29, 0x9e, 0xdd, 0xd8, 0x53, 0x6a, 0xab, 0x2f, 0xef, 0x01, 0x94,
+	0x3b, 0x8c, 0x86, 0x8c, 0x8a, 0x5b, 0xbc, 0x04, 0x8b, 0x23, 0x27, 0x31, 0xb7, 0xbd, 0x0a, 0xab,
+	0xf1, 0xeb, 0xe9, 0x92, 0x66, 0xc8, 0x5a, 0x34, 0xb8, 0xe8, 0x5f, 0xfb, 0x7f, 0x2c, 0x58, 0x4b,
+	0xb3, 0x30, 0x74, 0x1e, 0x03, 0xb0, 0xbe, 0xd6, 0x3c, 0x80, 0x9d, 0xb4, 0x07, 0x30, 0xea, 0x5f,
+	0xeb, 0xab, 0xdc, 0x58, 0x1c, 0xe7, 0x47, 0x0b, 0x2a, 0xfd, 0x9d, 0x14, 0xca, 0x11, 0x14, 0x38,
+	0x7d, 0xa1, 0xdb, 0x4b, 0xde, 0x55, 0x6b, 0xb4, 0x03, 0x53, 0xba, 0x2d, 0xb4, 0x14, 0xe1, 0xd3,
+	0x0d, 0xa7, 0xa6, 0xdb, 0x68, 0x2d, 0x6a, 0xa3, 0xb5, 0xe3, 0xa8, 0x8d, 0xba, 0x91, 0x29, 0x5a,
+	0x80, 0x92, 0xd7, 0x14, 0xb4, 0x47, 0x54, 0x39, 0xca, 0xae, 0x91, 0xf0, 0x13, 0x58, 0x3b, 0x12,
+	0x8c, 0x78, 0xed, 0x11, 0xfc, 0xd9, 0xef, 0xe2, 0x63, 0xa8, 0xa6, 0xfa, 0x19, 0xda, 0x10, 0x14,
+	0x5a, 0x9e, 0xf0, 0xf4, 0xf3, 0x70, 0xd5, 0x1a, 0xff, 0x6a, 0xc1, 0xc3, 0xc8, 0xe3, 0x0b, 0x72,
+	0xee, 0x75, 0x7d, 0xc1, 0xd1, 0x27, 0xba, 0x9b, 0x6b, 0x62, 0xdf, 0x8a, 0x13, 0x3b, 0x6c, 0x3a,
+	0xd4, 0xca, 0x63, 0x6d, 0x3b, 0xf7, 0xff, 0xb4, 0xed, 0xbf, 0x2d, 0x70, 0x62, 0x57, 0x29, 0xca,
+	0x1b, 0x71, 0x11, 0xcd, 0x0e, 0x2b, 0x36, 0x3b, 0x3e, 0x8b, 0xcf, 0xa2, 0x7a, 0x1c, 0x7d, 0x7a,
+	0xa0, 0xa1, 0x73, 0x2c, 0x43, 0xa5, 0x1b, 0x70, 0x22, 0x4e, 0x65, 0xa0, 0xbc, 0xea, 0x7a, 0x65,
+	0xa5, 0xd8, 0x4f, 0x1e, 0x32, 0x39, 0x9b, 0xe4, 0x31, 0x9a, 0x3e, 0xf1, 0x98, 0x7a, 0x4d, 0x65,
+	0x57, 0x0b, 0xff, 0xf9, 0xe8, 0x27, 0xb0, 0x3c, 0x16, 0xb0, 0xa9, 0xe6, 0x53, 0x28, 0xb7, 0x8c,
+	0x4e, 0xc5, 0x9b, 0x6e, 0xac, 0x64, 0x55, 0xca, 0xed, 0x5b, 0xe3, 0x15, 0x70, 0x0e, 0x52, 0x99,
+	0xc0, 0x7f, 0x59, 0xb0, 0x7c, 0x90, 0x91, 0x77, 0x07, 0x4a, 0x17, 0x7e, 0x78, 0xe6, 0xf9, 0x13,
+	0x65, 0x35, 0xb6, 0xe8, 0x10, 0x8a, 0xb2, 0x38, 0xdc, 0x94, 0xa5, 0x11, 0x77, 0xca, 0xc8, 0x56,
+	0x3b, 0x96, 0x4e, 0xba, 0x32, 0x3a, 0x80, 0xf3, 0x1c, 0x60, 0xa0, 0x1c, 0x43, 0x68, 0x23, 0x4e,
+	0xe8, 0x5d, 0xf0, 0x06, 0x74, 0x37, 0x7e, 0xaf, 0xc0, 0x83, 0x7e, 0xc7, 0x92, 0x0e, 0x4d, 0x82,
+	0xbe, 0x84, 0x82, 0xfc, 0x34, 0xa0, 0xea, 0x1d, 0x3f, 0x1a, 0x67, 0x3d, 0xdd, 0xc0, 0xf4, 0xbd,
+	0x7b, 0xa8, 0x03, 0x45, 0xf5, 0x01, 0x40, 0x09, 0xe3, 0x71, 0x3f, 0x08, 0x67, 0x23, 0xc3, 0xc2,
+	0xc4, 0xc3, 0x3f, 0xfc, 0xf9, 0xfa, 0xe7, 0xdc, 0x0a, 0x72, 0xea, 0xbd, 0x0f, 0xeb, 0xd1, 0x40,
+	0xaf, 0x37, 0xa5, 0x6d, 0xfd, 0x7b, 0xd5, 0x12, 0x5e, 0xa2, 0x73, 0x28, 0xc8, 0x4e, 0x98, 0x4c,
+	0x38, 0xee, 0xaf, 0xe1, 0x6c, 0x64, 0x58, 0x98, 0x84, 0x4b, 0x2a, 0xe1, 0x23, 0xf4, 0x46, 0x22,
+	0xa1, 0x9c, 0xe3, 0xa8, 0x07, 0x25, 0x3d, 0x93, 0xd1, 0x48, 0x9c, 0x51, 0xaa, 0x70, 0x96, 0x89,
+	0xc9, 0xb5, 0xa9, 0x72, 0xad, 0xa2, 0xe5, 0x91, 0x5c, 0x24, 0x88, 0x4e, 0xf7, 0x81, 0x25, 0x19,
+	0x55, 0xf3, 0x36, 0x79, 0xc0, 0x71, 0x63, 0xdc, 0xd9, 0xc8, 0xb0, 0x48, 0x32, 0x8a, 0x93, 0x8c,
+	0xca, 0x89, 0x3d, 0x60, 0xf4, 0x06, 0xa6, 0x8e, 0x88, 0x90, 0x03, 0x0d, 0xe1, 0x94, 0xce, 0x12,
+	0x9b, 0xdb, 0xce, 0x66, 0xa6, 0x8d, 0xc9, 0xfb, 0xa6, 0xca, 0xbb, 0x86, 0x97, 0x12, 0x79, 0xe5,
+	0x68, 0x89, 0xd2, 0xee, 0x5a, 0xdb, 0xe8, 0x27, 0x0b, 0xee, 0x4b, 0xb6, 0x06, 0xe3, 0x0c, 0xbd,
+	0x33, 0xc9, 0xc8, 0xd3, 0x40, 0xb6, 0x27, 0x9f, 0x8e, 0xb8, 0xaa, 0xf0, 0x2c, 0xa1, 0xc5, 0x04,
+	0x9e, 0xc1, 0xa0, 0x44, 0xbf, 0x58, 0xf0, 0x40, 0xcf, 0x9a, 0xc1, 0xb8, 0x4c, 0x24, 0xc8, 0x1e,
+	0x60, 0xce, 0xbb, 0x13, 0xd9, 0x1a, 0x34, 0x8f, 0x15, 0x9a, 0x0d, 0x54, 0x4d, 0x41, 0x13, 0xbb,
+	0x0e, 0xaf, 0x2c, 0x98, 0x3e, 0x22, 0xa2, 0x3f, 0xc6, 0xde, 0x9e, 0xac, 0xf7, 0x3b, 0x8f, 0xef,
+	0xb4, 0x33, 0x58, 0xd6, 0x15, 0x16, 0x07, 0xcf, 0x27, 0xb0, 0x44, 0x7d, 0x55, 0x56, 0xe9, 0x25,
+	0x4c, 0x1f, 0xa4, 0x21, 0x38, 0x98, 0x10, 0x41, 0x46, 0x3b, 0xc4, 0xab, 0x0a, 0xc1, 0x22, 0x1a,
+	0x8f, 0x60, 0xaf, 0xf8, 0x6d, 0xde, 0xeb, 0xd0, 0xb3, 0x92, 0xfa, 0x78, 0x7c, 0xf4, 0xef, 0x00,
+	0xd6, 0x67, 0x7c, 0x24, 0x09, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -760,12 +1304,23 @@ type TerminalServiceClient interface {
 	Close(ctx context.Context, in *CloseTerminalRequest, opts ...grpc.CallOption) (*CloseTerminalResponse, error)
 	// List lists all open terminals
 	List(ctx context.Context, in *ListTerminalsRequest, opts ...grpc.CallOption) (*ListTerminalsResponse, error)
-	// Listen listens to a terminal
+	// Listen listens to a terminal. Terminals outlive their listeners: a listener which
+	// (re-)attaches to a terminal receives its scrollback first, then its live output.
 	Listen(ctx context.Context, in *ListenTerminalRequest, opts ...grpc.CallOption) (TerminalService_ListenClient, error)
 	// Write writes to a terminal
 	Write(ctx context.Context, in *WriteTerminalRequest, opts ...grpc.CallOption) (*WriteTerminalResponse, error)
 	// SetSize sets the terminal's size
 	SetSize(ctx context.Context, in *SetTerminalSizeRequest, opts ...grpc.CallOption) (*SetTerminalSizeResponse, error)
+	// ListRecordings lists the recorded terminal sessions, if terminal recording is enabled
+	ListRecordings(ctx context.Context, in *ListTerminalRecordingsRequest, opts ...grpc.CallOption) (*ListTerminalRecordingsResponse, error)
+	// StreamRecording streams a recorded terminal session in the asciicast v2 format, which asciinema can replay.
+	// The recording of a terminal which is still open is streamed up to its current end.
+	StreamRecording(ctx context.Context, in *StreamTerminalRecordingRequest, opts ...grpc.CallOption) (TerminalService_StreamRecordingClient, error)
+	// SetDefaults changes the environment variables and working directory of terminals opened afterwards,
+	// either of all terminals or only of those of a task. Open terminals are not affected.
+	SetDefaults(ctx context.Context, in *SetTerminalDefaultsRequest, opts ...grpc.CallOption) (*SetTerminalDefaultsResponse, error)
+	// GetDefaults returns the defaults of newly opened terminals
+	GetDefaults(ctx context.Context, in *GetTerminalDefaultsRequest, opts ...grpc.CallOption) (*GetTerminalDefaultsResponse, error)
 }
 
 type terminalServiceClient struct {
@@ -853,6 +1408,65 @@ func (c *terminalServiceClient) SetSize(ctx context.Context, in *SetTerminalSize
 	return out, nil
 }
 
+func (c *terminalServiceClient) ListRecordings(ctx context.Context, in *ListTerminalRecordingsRequest, opts ...grpc.CallOption) (*ListTerminalRecordingsResponse, error) {
+	out := new(ListTerminalRecordingsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TerminalService/ListRecordings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terminalServiceClient) StreamRecording(ctx context.Context, in *StreamTerminalRecordingRequest, opts ...grpc.CallOption) (TerminalService_StreamRecordingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TerminalService_serviceDesc.Streams[1], "/supervisor.TerminalService/StreamRecording", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terminalServiceStreamRecordingClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TerminalService_StreamRecordingClient interface {
+	Recv() (*StreamTerminalRecordingResponse, error)
+	grpc.ClientStream
+}
+
+type terminalServiceStreamRecordingClient struct {
+	grpc.ClientStream
+}
+
+func (x *terminalServiceStreamRecordingClient) Recv() (*StreamTerminalRecordingResponse, error) {
+	m := new(StreamTerminalRecordingResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *terminalServiceClient) SetDefaults(ctx context.Context, in *SetTerminalDefaultsRequest, opts ...grpc.CallOption) (*SetTerminalDefaultsResponse, error) {
+	out := new(SetTerminalDefaultsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TerminalService/SetDefaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terminalServiceClient) GetDefaults(ctx context.Context, in *GetTerminalDefaultsRequest, opts ...grpc.CallOption) (*GetTerminalDefaultsResponse, error) {
+	out := new(GetTerminalDefaultsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TerminalService/GetDefaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TerminalServiceServer is the server API for TerminalService service.
 type TerminalServiceServer interface {
 	// Open opens a new terminal running the login shell
@@ -862,12 +1476,23 @@ type TerminalServiceServer interface {
 	Close(context.Context, *CloseTerminalRequest) (*CloseTerminalResponse, error)
 	// List lists all open terminals
 	List(context.Context, *ListTerminalsRequest) (*ListTerminalsResponse, error)
-	// Listen listens to a terminal
+	// Listen listens to a terminal. Terminals outlive their listeners: a listener which
+	// (re-)attaches to a terminal receives its scrollback first, then its live output.
 	Listen(*ListenTerminalRequest, TerminalService_ListenServer) error
 	// Write writes to a terminal
 	Write(context.Context, *WriteTerminalRequest) (*WriteTerminalResponse, error)
 	// SetSize sets the terminal's size
 	SetSize(context.Context, *SetTerminalSizeRequest) (*SetTerminalSizeResponse, error)
+	// ListRecordings lists the recorded terminal sessions, if terminal recording is enabled
+	ListRecordings(context.Context, *ListTerminalRecordingsRequest) (*ListTerminalRecordingsResponse, error)
+	// StreamRecording streams a recorded terminal session in the asciicast v2 format, which asciinema can replay.
+	// The recording of a terminal which is still open is streamed up to its current end.
+	StreamRecording(*StreamTerminalRecordingRequest, TerminalService_StreamRecordingServer) error
+	// SetDefaults changes the environment variables and working directory of terminals opened afterwards,
+	// either of all terminals or only of those of a task. Open terminals are not affected.
+	SetDefaults(context.Context, *SetTerminalDefaultsRequest) (*SetTerminalDefaultsResponse, error)
+	// GetDefaults returns the defaults of newly opened terminals
+	GetDefaults(context.Context, *GetTerminalDefaultsRequest) (*GetTerminalDefaultsResponse, error)
 }
 
 // UnimplementedTerminalServiceServer can be embedded to have forward compatible implementations.
@@ -892,6 +1517,18 @@ func (*UnimplementedTerminalServiceServer) Write(ctx context.Context, req *Write
 func (*UnimplementedTerminalServiceServer) SetSize(ctx context.Context, req *SetTerminalSizeRequest) (*SetTerminalSizeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetSize not implemented")
 }
+func (*UnimplementedTerminalServiceServer) ListRecordings(ctx context.Context, req *ListTerminalRecordingsRequest) (*ListTerminalRecordingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecordings not implemented")
+}
+func (*UnimplementedTerminalServiceServer) StreamRecording(req *StreamTerminalRecordingRequest, srv TerminalService_StreamRecordingServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRecording not implemented")
+}
+func (*UnimplementedTerminalServiceServer) SetDefaults(ctx context.Context, req *SetTerminalDefaultsRequest) (*SetTerminalDefaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDefaults not implemented")
+}
+func (*UnimplementedTerminalServiceServer) GetDefaults(ctx context.Context, req *GetTerminalDefaultsRequest) (*GetTerminalDefaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDefaults not implemented")
+}
 
 func RegisterTerminalServiceServer(s *grpc.Server, srv TerminalServiceServer) {
 	s.RegisterService(&_TerminalService_serviceDesc, srv)
@@ -1008,6 +1645,81 @@ func _TerminalService_SetSize_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TerminalService_ListRecordings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTerminalRecordingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerminalServiceServer).ListRecordings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TerminalService/ListRecordings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalServiceServer).ListRecordings(ctx, req.(*ListTerminalRecordingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerminalService_StreamRecording_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTerminalRecordingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerminalServiceServer).StreamRecording(m, &terminalServiceStreamRecordingServer{stream})
+}
+
+type TerminalService_StreamRecordingServer interface {
+	Send(*StreamTerminalRecordingResponse) error
+	grpc.ServerStream
+}
+
+type terminalServiceStreamRecordingServer struct {
+	grpc.ServerStream
+}
+
+func (x *terminalServiceStreamRecordingServer) Send(m *StreamTerminalRecordingResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TerminalService_SetDefaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTerminalDefaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerminalServiceServer).SetDefaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TerminalService/SetDefaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalServiceServer).SetDefaults(ctx, req.(*SetTerminalDefaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerminalService_GetDefaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTerminalDefaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerminalServiceServer).GetDefaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TerminalService/GetDefaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalServiceServer).GetDefaults(ctx, req.(*GetTerminalDefaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TerminalService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "supervisor.TerminalService",
 	HandlerType: (*TerminalServiceServer)(nil),
@@ -1032,6 +1744,18 @@ var _TerminalService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetSize",
 			Handler:    _TerminalService_SetSize_Handler,
 		},
+		{
+			MethodName: "ListRecordings",
+			Handler:    _TerminalService_ListRecordings_Handler,
+		},
+		{
+			MethodName: "SetDefaults",
+			Handler:    _TerminalService_SetDefaults_Handler,
+		},
+		{
+			MethodName: "GetDefaults",
+			Handler:    _TerminalService_GetDefaults_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1039,6 +1763,11 @@ var _TerminalService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _TerminalService_Listen_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamRecording",
+			Handler:       _TerminalService_StreamRecording_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "terminal.proto",
 }
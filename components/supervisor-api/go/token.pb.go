@@ -120,7 +120,9 @@ func (m *GetTokenRequest) GetDescription() string {
 }
 
 type GetTokenResponse struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// user is the user name to authenticate with along with the token, e.g. for Git. Empty if the token needs none.
+	User                 string   `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -158,15 +160,24 @@ func (m *GetTokenResponse) GetToken() string {
 	return ""
 }
 
+func (m *GetTokenResponse) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
 type SetTokenRequest struct {
-	Host                 string               `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
-	Scope                []string             `protobuf:"bytes,2,rep,name=scope,proto3" json:"scope,omitempty"`
-	Token                string               `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
-	ExpiryDate           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
-	Reuse                TokenReuse           `protobuf:"varint,5,opt,name=reuse,proto3,enum=supervisor.TokenReuse" json:"reuse,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Host       string               `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Scope      []string             `protobuf:"bytes,2,rep,name=scope,proto3" json:"scope,omitempty"`
+	Token      string               `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiryDate *timestamp.Timestamp `protobuf:"bytes,4,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	Reuse      TokenReuse           `protobuf:"varint,5,opt,name=reuse,proto3,enum=supervisor.TokenReuse" json:"reuse,omitempty"`
+	// user is the user name to authenticate with along with the token
+	User                 string   `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SetTokenRequest) Reset()         { *m = SetTokenRequest{} }
@@ -229,6 +240,13 @@ func (m *SetTokenRequest) GetReuse() TokenReuse {
 	return TokenReuse_REUSE_NEVER
 }
 
+func (m *SetTokenRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
 type SetTokenResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -547,48 +565,48 @@ func init() {
 }
 
 var fileDescriptor_3aff0bcd502840ab = []byte{
-	// 643 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xcf, 0x6e, 0xd3, 0x4e,
-	0x10, 0x8e, 0x93, 0xa6, 0x7f, 0x26, 0xfd, 0xfd, 0xea, 0x6e, 0x4b, 0xb1, 0xdc, 0xd2, 0x5a, 0x3e,
-	0x20, 0x2b, 0x42, 0x76, 0x09, 0xea, 0x85, 0x8a, 0x03, 0x29, 0x16, 0xa9, 0x54, 0x4a, 0x65, 0x97,
-	0x16, 0x2a, 0xa1, 0x6a, 0x9b, 0x0e, 0xc1, 0xc2, 0x8d, 0xcd, 0xee, 0x3a, 0x80, 0xaa, 0x5c, 0x38,
-	0x71, 0xe7, 0x75, 0x90, 0x78, 0x08, 0x0e, 0xbc, 0x00, 0x0f, 0x82, 0xbc, 0x76, 0x88, 0x93, 0xa6,
-	0x45, 0xe2, 0xe6, 0xd9, 0x99, 0xf9, 0xbe, 0xf9, 0x66, 0x3e, 0x43, 0x4d, 0x44, 0xef, 0xb0, 0x6b,
-	0xc7, 0x2c, 0x12, 0x11, 0x01, 0x9e, 0xc4, 0xc8, 0x7a, 0x01, 0x8f, 0x98, 0xbe, 0xd6, 0x89, 0xa2,
-	0x4e, 0x88, 0x0e, 0x8d, 0x03, 0x87, 0x76, 0xbb, 0x91, 0xa0, 0x22, 0x88, 0xba, 0x3c, 0xab, 0xd4,
-	0x37, 0xf2, 0xac, 0x8c, 0xce, 0x92, 0x37, 0x8e, 0x08, 0x2e, 0x90, 0x0b, 0x7a, 0x11, 0x67, 0x05,
-	0xe6, 0x6b, 0x58, 0x78, 0x8a, 0xe2, 0x30, 0x05, 0xf7, 0xf0, 0x7d, 0x82, 0x5c, 0x10, 0x02, 0x53,
-	0x6f, 0x23, 0x2e, 0x34, 0xc5, 0x50, 0xac, 0x39, 0x4f, 0x7e, 0x93, 0x65, 0xa8, 0xf2, 0x76, 0x14,
-	0xa3, 0x56, 0x36, 0x2a, 0xd6, 0x9c, 0x97, 0x05, 0xc4, 0x80, 0xda, 0x39, 0xf2, 0x36, 0x0b, 0xe2,
-	0x94, 0x53, 0xab, 0xc8, 0x86, 0xe2, 0x93, 0x69, 0x81, 0x3a, 0x84, 0xe7, 0x71, 0xd4, 0xe5, 0x98,
-	0x62, 0x49, 0x31, 0x39, 0x41, 0x16, 0x98, 0xdf, 0x14, 0x58, 0xf0, 0xff, 0x79, 0x92, 0x3f, 0x98,
-	0x95, 0x02, 0x26, 0xd9, 0x86, 0x1a, 0x7e, 0x8c, 0x03, 0xf6, 0xe9, 0xf4, 0x9c, 0x0a, 0xd4, 0xa6,
-	0x0c, 0xc5, 0xaa, 0x35, 0x74, 0x3b, 0xdb, 0x89, 0x3d, 0xd8, 0x89, 0x7d, 0x38, 0xd8, 0x89, 0x07,
-	0x59, 0xf9, 0x13, 0x2a, 0x90, 0xdc, 0x83, 0x2a, 0xc3, 0x84, 0xa3, 0x56, 0x35, 0x14, 0xeb, 0xff,
-	0xc6, 0x8a, 0x3d, 0x5c, 0xba, 0x9d, 0x4f, 0x99, 0x70, 0xf4, 0xb2, 0x22, 0x93, 0x80, 0xea, 0x8f,
-	0x09, 0x35, 0x5b, 0xb0, 0xb8, 0x13, 0x22, 0x65, 0x23, 0x9a, 0x56, 0xa0, 0xda, 0xa3, 0x61, 0x82,
-	0x99, 0xa8, 0x56, 0xc9, 0xcb, 0x42, 0x42, 0xa0, 0x42, 0xc3, 0x50, 0x2b, 0x1b, 0x8a, 0x35, 0xdb,
-	0x2a, 0x79, 0x69, 0xd0, 0x9c, 0xc9, 0x55, 0x99, 0xcb, 0x40, 0x8a, 0x48, 0x39, 0xfe, 0x4f, 0x05,
-	0x96, 0x0e, 0x58, 0xd4, 0x0b, 0xce, 0x71, 0x84, 0xe2, 0x08, 0xe6, 0x19, 0x76, 0x02, 0x2e, 0x98,
-	0xf4, 0x82, 0x64, 0xaa, 0x35, 0x36, 0x8b, 0x02, 0x26, 0xb4, 0xd9, 0x9e, 0xec, 0x41, 0x96, 0xe7,
-	0x58, 0xab, 0xe4, 0x8d, 0xe0, 0x90, 0x2d, 0x98, 0xa6, 0x5d, 0xfe, 0x01, 0x99, 0x9c, 0xb2, 0xd6,
-	0x58, 0x2d, 0x22, 0x8e, 0xdd, 0xae, 0x55, 0xf2, 0xf2, 0x62, 0xfd, 0x2e, 0xa8, 0xe3, 0xd0, 0x93,
-	0x2e, 0xdb, 0x9c, 0x83, 0x99, 0x0b, 0xe4, 0x9c, 0x76, 0xd0, 0x7c, 0x06, 0xcb, 0xa3, 0x13, 0xe6,
-	0xd6, 0xd9, 0x82, 0x19, 0x96, 0xe1, 0xe7, 0xa2, 0x46, 0x46, 0x18, 0x33, 0xb2, 0x37, 0xa8, 0xad,
-	0xef, 0x02, 0x0c, 0x2f, 0x46, 0x16, 0xa0, 0xe6, 0xb9, 0x2f, 0x7c, 0xf7, 0x74, 0xdf, 0x3d, 0x72,
-	0x3d, 0xb5, 0x44, 0x16, 0xe1, 0xbf, 0xec, 0xc1, 0x7d, 0xf9, 0x78, 0xe7, 0x70, 0xef, 0x95, 0xaa,
-	0x90, 0xdb, 0xb0, 0x94, 0x3d, 0x1d, 0xb7, 0xdc, 0xfd, 0xd3, 0x83, 0xe7, 0xbe, 0xbf, 0xdb, 0xdc,
-	0x73, 0xd5, 0x72, 0xe3, 0x7b, 0x05, 0xe6, 0x25, 0x96, 0x9f, 0xb2, 0xb6, 0x91, 0x74, 0x60, 0x76,
-	0xc0, 0x4b, 0x6e, 0x9a, 0x46, 0x5f, 0x9b, 0x9c, 0xcc, 0x6f, 0x69, 0x7c, 0xfe, 0xf1, 0xeb, 0x6b,
-	0x59, 0x27, 0x9a, 0xd3, 0xbb, 0xef, 0xc8, 0xa3, 0x3b, 0x97, 0xe9, 0x56, 0xfa, 0xce, 0xa5, 0x74,
-	0x78, 0x9f, 0x9c, 0xc1, 0xac, 0x3f, 0x91, 0xc8, 0xbf, 0x89, 0xe8, 0x8a, 0x29, 0x57, 0x25, 0xd1,
-	0x2d, 0x53, 0x1d, 0x27, 0x7a, 0xa8, 0xd4, 0xc9, 0x17, 0x05, 0x60, 0x68, 0x34, 0x72, 0xa7, 0x88,
-	0x74, 0xc5, 0xca, 0xfa, 0xfa, 0x75, 0xe9, 0x9c, 0x6a, 0x5b, 0x52, 0x6d, 0xd5, 0x17, 0x0b, 0x54,
-	0xd2, 0xec, 0xfd, 0x13, 0xa3, 0xbe, 0x3e, 0x7c, 0x6c, 0xa7, 0xad, 0x0e, 0x0d, 0x43, 0xe7, 0x92,
-	0x86, 0xe1, 0x23, 0xc1, 0x12, 0xec, 0x93, 0x63, 0x98, 0x2f, 0x5a, 0x80, 0x6c, 0xfc, 0xc5, 0xbe,
-	0xba, 0x71, 0x7d, 0x41, 0x3e, 0x4f, 0xc9, 0x52, 0x36, 0x95, 0x66, 0xf5, 0xa4, 0x42, 0xe3, 0xe0,
-	0x6c, 0x5a, 0xfe, 0xfe, 0x0f, 0x7e, 0x07, 0x00, 0x00, 0xff, 0xff, 0xc7, 0x12, 0xe8, 0x9a, 0x59,
-	0x05, 0x00, 0x00,
+	// 653 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcd, 0x6e, 0xd3, 0x4a,
+	0x14, 0xc7, 0xe3, 0xa4, 0xe9, 0xc7, 0x71, 0xef, 0xad, 0x3b, 0xed, 0xed, 0xb5, 0xdc, 0xde, 0xd6,
+	0xf2, 0xe2, 0x2a, 0x8a, 0x90, 0x5d, 0x82, 0xba, 0xa1, 0xb0, 0x20, 0xc5, 0x22, 0x95, 0x4a, 0xa9,
+	0xec, 0xd2, 0x42, 0x25, 0x54, 0x4d, 0xd3, 0x43, 0xb0, 0x70, 0x63, 0x33, 0x63, 0x07, 0x50, 0x95,
+	0x0d, 0x2b, 0xf6, 0x3c, 0x10, 0xaf, 0x80, 0xc4, 0x82, 0x17, 0xe0, 0x41, 0x90, 0x67, 0x9c, 0xc6,
+	0x49, 0xd3, 0x22, 0xb1, 0xf3, 0x99, 0x39, 0xf3, 0xff, 0x9d, 0x8f, 0xbf, 0x0c, 0x6a, 0x12, 0xbd,
+	0xc5, 0xae, 0x1d, 0xb3, 0x28, 0x89, 0x08, 0xf0, 0x34, 0x46, 0xd6, 0x0b, 0x78, 0xc4, 0x8c, 0xb5,
+	0x4e, 0x14, 0x75, 0x42, 0x74, 0x68, 0x1c, 0x38, 0xb4, 0xdb, 0x8d, 0x12, 0x9a, 0x04, 0x51, 0x97,
+	0xcb, 0x4c, 0x63, 0x23, 0xbf, 0x15, 0xd1, 0x59, 0xfa, 0xda, 0x49, 0x82, 0x0b, 0xe4, 0x09, 0xbd,
+	0x88, 0x65, 0x82, 0xf5, 0x0a, 0x16, 0x9e, 0x60, 0x72, 0x98, 0x89, 0x7b, 0xf8, 0x2e, 0x45, 0x9e,
+	0x10, 0x02, 0x53, 0x6f, 0x22, 0x9e, 0xe8, 0x8a, 0xa9, 0xd4, 0xe6, 0x3c, 0xf1, 0x4d, 0x96, 0xa1,
+	0xca, 0xdb, 0x51, 0x8c, 0x7a, 0xd9, 0xac, 0xd4, 0xe6, 0x3c, 0x19, 0x10, 0x13, 0xd4, 0x73, 0xe4,
+	0x6d, 0x16, 0xc4, 0x19, 0x53, 0xaf, 0x88, 0x07, 0xc5, 0x23, 0xeb, 0x01, 0x68, 0x43, 0x79, 0x1e,
+	0x47, 0x5d, 0x8e, 0x99, 0x96, 0x68, 0x26, 0x07, 0xc8, 0x20, 0xa3, 0xa6, 0x1c, 0x99, 0x5e, 0x96,
+	0xd4, 0xec, 0xdb, 0xfa, 0xa6, 0xc0, 0x82, 0xff, 0xc7, 0xd5, 0x5d, 0x71, 0x2a, 0x45, 0xce, 0x36,
+	0xa8, 0xf8, 0x21, 0x0e, 0xd8, 0xc7, 0xd3, 0x73, 0x9a, 0xa0, 0x3e, 0x65, 0x2a, 0x35, 0xb5, 0x61,
+	0xd8, 0x72, 0x4e, 0xf6, 0x60, 0x4e, 0xf6, 0xe1, 0x60, 0x4e, 0x1e, 0xc8, 0xf4, 0xc7, 0x34, 0x41,
+	0x72, 0x07, 0xaa, 0x0c, 0x53, 0x8e, 0x7a, 0xd5, 0x54, 0x6a, 0x7f, 0x37, 0x56, 0xec, 0xe1, 0x22,
+	0xec, 0xbc, 0xca, 0x94, 0xa3, 0x27, 0x93, 0xae, 0x5a, 0x9a, 0x2e, 0xb4, 0x44, 0x40, 0xf3, 0xc7,
+	0x06, 0x62, 0xb5, 0x60, 0x71, 0x27, 0x44, 0xca, 0x46, 0xfa, 0x5c, 0x81, 0x6a, 0x8f, 0x86, 0x29,
+	0xca, 0x46, 0x5b, 0x25, 0x4f, 0x86, 0x84, 0x40, 0x85, 0x86, 0xa1, 0x18, 0xd3, 0x6c, 0xab, 0xe4,
+	0x65, 0x41, 0x73, 0x26, 0xef, 0xd4, 0x5a, 0x06, 0x52, 0x54, 0xca, 0xf5, 0x7f, 0x28, 0xb0, 0x74,
+	0xc0, 0xa2, 0x5e, 0x70, 0x8e, 0x23, 0x88, 0x23, 0x98, 0x67, 0xd8, 0x09, 0x78, 0xc2, 0x84, 0x67,
+	0x04, 0x49, 0x6d, 0x6c, 0x16, 0x9b, 0x9a, 0xf0, 0xcc, 0xf6, 0xc4, 0x1b, 0x64, 0xf9, 0x1d, 0x6b,
+	0x95, 0xbc, 0x11, 0x1d, 0xb2, 0x05, 0xd3, 0xb4, 0xcb, 0xdf, 0xe7, 0xcb, 0x54, 0x1b, 0xab, 0x45,
+	0xc5, 0xb1, 0x7d, 0xb6, 0x4a, 0x5e, 0x9e, 0x6c, 0xfc, 0x0f, 0xda, 0xb8, 0xf4, 0xa4, 0x6d, 0x37,
+	0xe7, 0x60, 0xe6, 0x02, 0x39, 0xa7, 0x1d, 0xb4, 0x9e, 0xc2, 0xf2, 0x68, 0x85, 0xb9, 0xc5, 0xb6,
+	0x60, 0x86, 0x49, 0x7d, 0x5d, 0xb9, 0x5e, 0xc2, 0x98, 0xe1, 0xbd, 0x41, 0x6e, 0x7d, 0x17, 0x60,
+	0xb8, 0x45, 0xb2, 0x00, 0xaa, 0xe7, 0x3e, 0xf7, 0xdd, 0xd3, 0x7d, 0xf7, 0xc8, 0xf5, 0xb4, 0x12,
+	0x59, 0x84, 0xbf, 0xe4, 0x81, 0xfb, 0xe2, 0xd1, 0xce, 0xe1, 0xde, 0x4b, 0x4d, 0x21, 0xff, 0xc2,
+	0x92, 0x3c, 0x3a, 0x6e, 0xb9, 0xfb, 0xa7, 0x07, 0xcf, 0x7c, 0x7f, 0xb7, 0xb9, 0xe7, 0x6a, 0xe5,
+	0xc6, 0xd7, 0x0a, 0xcc, 0x0b, 0x2d, 0x3f, 0xa3, 0xb6, 0x91, 0x74, 0x60, 0x76, 0xc0, 0x25, 0xb7,
+	0x55, 0x63, 0xac, 0x4d, 0xbe, 0xcc, 0x77, 0x69, 0x7e, 0xfa, 0xfe, 0xf3, 0x4b, 0xd9, 0x20, 0xba,
+	0xd3, 0xbb, 0xeb, 0x88, 0xa5, 0x3b, 0x97, 0xd9, 0x54, 0xfa, 0xce, 0xa5, 0x70, 0x7d, 0x9f, 0x9c,
+	0xc1, 0xac, 0x3f, 0x11, 0xe4, 0xdf, 0x06, 0xba, 0x66, 0xca, 0x55, 0x01, 0xfa, 0xc7, 0xd2, 0xc6,
+	0x41, 0xf7, 0x95, 0x3a, 0xf9, 0xac, 0x00, 0x0c, 0x8d, 0x46, 0xfe, 0x2b, 0x2a, 0x5d, 0xb3, 0xb2,
+	0xb1, 0x7e, 0xd3, 0x75, 0x8e, 0xda, 0x16, 0xa8, 0xad, 0xfa, 0x62, 0x01, 0x25, 0xcc, 0xde, 0x3f,
+	0x31, 0xeb, 0xeb, 0xc3, 0xc3, 0x76, 0xf6, 0xd4, 0xa1, 0x61, 0xe8, 0x5c, 0xd2, 0x30, 0x7c, 0x98,
+	0xb0, 0x14, 0xfb, 0xe4, 0x18, 0xe6, 0x8b, 0x16, 0x20, 0x1b, 0xbf, 0xb1, 0xaf, 0x61, 0xde, 0x9c,
+	0x90, 0xd7, 0x53, 0xaa, 0x29, 0x9b, 0x4a, 0xb3, 0x7a, 0x52, 0xa1, 0x71, 0x70, 0x36, 0x2d, 0x7e,
+	0x09, 0xf7, 0x7e, 0x0d, 0x00, 0x0b, 0x30, 0xdd, 0x4c, 0x81, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,838 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: tunnel.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type TunnelStatus struct {
+	// port is the port in the workspace
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// target_port is the port the client listens on on the developer's machine
+	TargetPort uint32 `protobuf:"varint,2,opt,name=target_port,json=targetPort,proto3" json:"target_port,omitempty"`
+	// client_id is the client which is expected to establish the tunnel, any client if empty
+	ClientId string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// established_by is the client which established the tunnel, empty until it is established
+	EstablishedBy string `protobuf:"bytes,4,opt,name=established_by,json=establishedBy,proto3" json:"established_by,omitempty"`
+	// local_address is the address the client listens on, e.g. "127.0.0.1:3000"
+	LocalAddress string `protobuf:"bytes,5,opt,name=local_address,json=localAddress,proto3" json:"local_address,omitempty"`
+	// error is why the client failed to establish the tunnel, e.g. because the target port is in use
+	Error                string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TunnelStatus) Reset()         { *m = TunnelStatus{} }
+func (m *TunnelStatus) String() string { return proto.CompactTextString(m) }
+func (*TunnelStatus) ProtoMessage()    {}
+func (*TunnelStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{0}
+}
+
+func (m *TunnelStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TunnelStatus.Unmarshal(m, b)
+}
+func (m *TunnelStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TunnelStatus.Marshal(b, m, deterministic)
+}
+func (m *TunnelStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TunnelStatus.Merge(m, src)
+}
+func (m *TunnelStatus) XXX_Size() int {
+	return xxx_messageInfo_TunnelStatus.Size(m)
+}
+func (m *TunnelStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_TunnelStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TunnelStatus proto.InternalMessageInfo
+
+func (m *TunnelStatus) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *TunnelStatus) GetTargetPort() uint32 {
+	if m != nil {
+		return m.TargetPort
+	}
+	return 0
+}
+
+func (m *TunnelStatus) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *TunnelStatus) GetEstablishedBy() string {
+	if m != nil {
+		return m.EstablishedBy
+	}
+	return ""
+}
+
+func (m *TunnelStatus) GetLocalAddress() string {
+	if m != nil {
+		return m.LocalAddress
+	}
+	return ""
+}
+
+func (m *TunnelStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type TunnelPortRequest struct {
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// target_port is the port to listen on on the developer's machine, the same as port if zero
+	TargetPort uint32 `protobuf:"varint,2,opt,name=target_port,json=targetPort,proto3" json:"target_port,omitempty"`
+	// client_id restricts the tunnel to one client, any client establishes it if empty
+	ClientId             string   `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TunnelPortRequest) Reset()         { *m = TunnelPortRequest{} }
+func (m *TunnelPortRequest) String() string { return proto.CompactTextString(m) }
+func (*TunnelPortRequest) ProtoMessage()    {}
+func (*TunnelPortRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{1}
+}
+
+func (m *TunnelPortRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TunnelPortRequest.Unmarshal(m, b)
+}
+func (m *TunnelPortRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TunnelPortRequest.Marshal(b, m, deterministic)
+}
+func (m *TunnelPortRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TunnelPortRequest.Merge(m, src)
+}
+func (m *TunnelPortRequest) XXX_Size() int {
+	return xxx_messageInfo_TunnelPortRequest.Size(m)
+}
+func (m *TunnelPortRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TunnelPortRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TunnelPortRequest proto.InternalMessageInfo
+
+func (m *TunnelPortRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *TunnelPortRequest) GetTargetPort() uint32 {
+	if m != nil {
+		return m.TargetPort
+	}
+	return 0
+}
+
+func (m *TunnelPortRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+type TunnelPortResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TunnelPortResponse) Reset()         { *m = TunnelPortResponse{} }
+func (m *TunnelPortResponse) String() string { return proto.CompactTextString(m) }
+func (*TunnelPortResponse) ProtoMessage()    {}
+func (*TunnelPortResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{2}
+}
+
+func (m *TunnelPortResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TunnelPortResponse.Unmarshal(m, b)
+}
+func (m *TunnelPortResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TunnelPortResponse.Marshal(b, m, deterministic)
+}
+func (m *TunnelPortResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TunnelPortResponse.Merge(m, src)
+}
+func (m *TunnelPortResponse) XXX_Size() int {
+	return xxx_messageInfo_TunnelPortResponse.Size(m)
+}
+func (m *TunnelPortResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TunnelPortResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TunnelPortResponse proto.InternalMessageInfo
+
+type CloseTunnelRequest struct {
+	Port                 uint32   `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseTunnelRequest) Reset()         { *m = CloseTunnelRequest{} }
+func (m *CloseTunnelRequest) String() string { return proto.CompactTextString(m) }
+func (*CloseTunnelRequest) ProtoMessage()    {}
+func (*CloseTunnelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{3}
+}
+
+func (m *CloseTunnelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseTunnelRequest.Unmarshal(m, b)
+}
+func (m *CloseTunnelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseTunnelRequest.Marshal(b, m, deterministic)
+}
+func (m *CloseTunnelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseTunnelRequest.Merge(m, src)
+}
+func (m *CloseTunnelRequest) XXX_Size() int {
+	return xxx_messageInfo_CloseTunnelRequest.Size(m)
+}
+func (m *CloseTunnelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseTunnelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseTunnelRequest proto.InternalMessageInfo
+
+func (m *CloseTunnelRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type CloseTunnelResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseTunnelResponse) Reset()         { *m = CloseTunnelResponse{} }
+func (m *CloseTunnelResponse) String() string { return proto.CompactTextString(m) }
+func (*CloseTunnelResponse) ProtoMessage()    {}
+func (*CloseTunnelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{4}
+}
+
+func (m *CloseTunnelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseTunnelResponse.Unmarshal(m, b)
+}
+func (m *CloseTunnelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseTunnelResponse.Marshal(b, m, deterministic)
+}
+func (m *CloseTunnelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseTunnelResponse.Merge(m, src)
+}
+func (m *CloseTunnelResponse) XXX_Size() int {
+	return xxx_messageInfo_CloseTunnelResponse.Size(m)
+}
+func (m *CloseTunnelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseTunnelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseTunnelResponse proto.InternalMessageInfo
+
+type ListTunnelsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTunnelsRequest) Reset()         { *m = ListTunnelsRequest{} }
+func (m *ListTunnelsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTunnelsRequest) ProtoMessage()    {}
+func (*ListTunnelsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{5}
+}
+
+func (m *ListTunnelsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTunnelsRequest.Unmarshal(m, b)
+}
+func (m *ListTunnelsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTunnelsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTunnelsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTunnelsRequest.Merge(m, src)
+}
+func (m *ListTunnelsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTunnelsRequest.Size(m)
+}
+func (m *ListTunnelsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTunnelsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTunnelsRequest proto.InternalMessageInfo
+
+type ListTunnelsResponse struct {
+	Tunnels              []*TunnelStatus `protobuf:"bytes,1,rep,name=tunnels,proto3" json:"tunnels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListTunnelsResponse) Reset()         { *m = ListTunnelsResponse{} }
+func (m *ListTunnelsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTunnelsResponse) ProtoMessage()    {}
+func (*ListTunnelsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{6}
+}
+
+func (m *ListTunnelsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTunnelsResponse.Unmarshal(m, b)
+}
+func (m *ListTunnelsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTunnelsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTunnelsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTunnelsResponse.Merge(m, src)
+}
+func (m *ListTunnelsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTunnelsResponse.Size(m)
+}
+func (m *ListTunnelsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTunnelsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTunnelsResponse proto.InternalMessageInfo
+
+func (m *ListTunnelsResponse) GetTunnels() []*TunnelStatus {
+	if m != nil {
+		return m.Tunnels
+	}
+	return nil
+}
+
+type EstablishTunnelsRequest struct {
+	// client_id identifies the client, e.g. "vscode-desktop-<machine>"
+	ClientId             string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstablishTunnelsRequest) Reset()         { *m = EstablishTunnelsRequest{} }
+func (m *EstablishTunnelsRequest) String() string { return proto.CompactTextString(m) }
+func (*EstablishTunnelsRequest) ProtoMessage()    {}
+func (*EstablishTunnelsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{7}
+}
+
+func (m *EstablishTunnelsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstablishTunnelsRequest.Unmarshal(m, b)
+}
+func (m *EstablishTunnelsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstablishTunnelsRequest.Marshal(b, m, deterministic)
+}
+func (m *EstablishTunnelsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstablishTunnelsRequest.Merge(m, src)
+}
+func (m *EstablishTunnelsRequest) XXX_Size() int {
+	return xxx_messageInfo_EstablishTunnelsRequest.Size(m)
+}
+func (m *EstablishTunnelsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstablishTunnelsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstablishTunnelsRequest proto.InternalMessageInfo
+
+func (m *EstablishTunnelsRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+type EstablishTunnelsResponse struct {
+	Tunnels              []*TunnelStatus `protobuf:"bytes,1,rep,name=tunnels,proto3" json:"tunnels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *EstablishTunnelsResponse) Reset()         { *m = EstablishTunnelsResponse{} }
+func (m *EstablishTunnelsResponse) String() string { return proto.CompactTextString(m) }
+func (*EstablishTunnelsResponse) ProtoMessage()    {}
+func (*EstablishTunnelsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{8}
+}
+
+func (m *EstablishTunnelsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstablishTunnelsResponse.Unmarshal(m, b)
+}
+func (m *EstablishTunnelsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstablishTunnelsResponse.Marshal(b, m, deterministic)
+}
+func (m *EstablishTunnelsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstablishTunnelsResponse.Merge(m, src)
+}
+func (m *EstablishTunnelsResponse) XXX_Size() int {
+	return xxx_messageInfo_EstablishTunnelsResponse.Size(m)
+}
+func (m *EstablishTunnelsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstablishTunnelsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstablishTunnelsResponse proto.InternalMessageInfo
+
+func (m *EstablishTunnelsResponse) GetTunnels() []*TunnelStatus {
+	if m != nil {
+		return m.Tunnels
+	}
+	return nil
+}
+
+type ReportTunnelRequest struct {
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Port     uint32 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	// local_address is the address the client listens on, empty if it failed
+	LocalAddress string `protobuf:"bytes,3,opt,name=local_address,json=localAddress,proto3" json:"local_address,omitempty"`
+	// error is why the client failed to establish the tunnel
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportTunnelRequest) Reset()         { *m = ReportTunnelRequest{} }
+func (m *ReportTunnelRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportTunnelRequest) ProtoMessage()    {}
+func (*ReportTunnelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{9}
+}
+
+func (m *ReportTunnelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportTunnelRequest.Unmarshal(m, b)
+}
+func (m *ReportTunnelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportTunnelRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportTunnelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportTunnelRequest.Merge(m, src)
+}
+func (m *ReportTunnelRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportTunnelRequest.Size(m)
+}
+func (m *ReportTunnelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportTunnelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportTunnelRequest proto.InternalMessageInfo
+
+func (m *ReportTunnelRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+func (m *ReportTunnelRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *ReportTunnelRequest) GetLocalAddress() string {
+	if m != nil {
+		return m.LocalAddress
+	}
+	return ""
+}
+
+func (m *ReportTunnelRequest) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ReportTunnelResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportTunnelResponse) Reset()         { *m = ReportTunnelResponse{} }
+func (m *ReportTunnelResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportTunnelResponse) ProtoMessage()    {}
+func (*ReportTunnelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6f51ddaa7891a711, []int{10}
+}
+
+func (m *ReportTunnelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportTunnelResponse.Unmarshal(m, b)
+}
+func (m *ReportTunnelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportTunnelResponse.Marshal(b, m, deterministic)
+}
+func (m *ReportTunnelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportTunnelResponse.Merge(m, src)
+}
+func (m *ReportTunnelResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportTunnelResponse.Size(m)
+}
+func (m *ReportTunnelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportTunnelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportTunnelResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*TunnelStatus)(nil), "supervisor.TunnelStatus")
+	proto.RegisterType((*TunnelPortRequest)(nil), "supervisor.TunnelPortRequest")
+	proto.RegisterType((*TunnelPortResponse)(nil), "supervisor.TunnelPortResponse")
+	proto.RegisterType((*CloseTunnelRequest)(nil), "supervisor.CloseTunnelRequest")
+	proto.RegisterType((*CloseTunnelResponse)(nil), "supervisor.CloseTunnelResponse")
+	proto.RegisterType((*ListTunnelsRequest)(nil), "supervisor.ListTunnelsRequest")
+	proto.RegisterType((*ListTunnelsResponse)(nil), "supervisor.ListTunnelsResponse")
+	proto.RegisterType((*EstablishTunnelsRequest)(nil), "supervisor.EstablishTunnelsRequest")
+	proto.RegisterType((*EstablishTunnelsResponse)(nil), "supervisor.EstablishTunnelsResponse")
+	proto.RegisterType((*ReportTunnelRequest)(nil), "supervisor.ReportTunnelRequest")
+	proto.RegisterType((*ReportTunnelResponse)(nil), "supervisor.ReportTunnelResponse")
+}
+
+func init() {
+	proto.RegisterFile("tunnel.proto", fileDescriptor_6f51ddaa7891a711)
+}
+
+var fileDescriptor_6f51ddaa7891a711 = []byte{
+	// 511 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xf9, 0x29, 0x74, 0x92, 0x00, 0x99, 0x84, 0xd6, 0x98, 0x42, 0x22, 0x17, 0xa4, 0xa8,
+	0x87, 0x18, 0x82, 0xc4, 0x81, 0x1b, 0x45, 0x1c, 0x2a, 0x21, 0x84, 0x5c, 0x4e, 0x5c, 0xa2, 0x4d,
+	0xbc, 0x0a, 0x2b, 0x59, 0x5e, 0xb3, 0xbb, 0xa9, 0x54, 0x21, 0x0e, 0x70, 0xe0, 0x05, 0x78, 0x23,
+	0x5e, 0x81, 0x57, 0xe0, 0x41, 0x90, 0x77, 0x1d, 0xba, 0x1b, 0xe3, 0x5c, 0xe0, 0x96, 0xcc, 0x7c,
+	0x3b, 0xdf, 0x7c, 0xf3, 0x7d, 0x32, 0x74, 0xd5, 0x3a, 0xcb, 0x68, 0x3a, 0xcd, 0x05, 0x57, 0x1c,
+	0x41, 0xae, 0x73, 0x2a, 0x2e, 0x98, 0xe4, 0x22, 0x38, 0x5a, 0x71, 0xbe, 0x4a, 0x69, 0x44, 0x72,
+	0x16, 0x91, 0x2c, 0xe3, 0x8a, 0x28, 0xc6, 0x33, 0x69, 0x90, 0xe1, 0x0f, 0x0f, 0xba, 0xef, 0xf4,
+	0xd3, 0x73, 0x45, 0xd4, 0x5a, 0x22, 0x42, 0x2b, 0xe7, 0x42, 0xf9, 0xde, 0xd8, 0x9b, 0xf4, 0x62,
+	0xfd, 0x1b, 0x47, 0xd0, 0x51, 0x44, 0xac, 0xa8, 0x9a, 0xeb, 0x56, 0x43, 0xb7, 0xc0, 0x94, 0xde,
+	0x16, 0x80, 0x7b, 0xb0, 0xbf, 0x4c, 0x19, 0xcd, 0xd4, 0x9c, 0x25, 0x7e, 0x73, 0xec, 0x4d, 0xf6,
+	0xe3, 0x1b, 0xa6, 0x70, 0x96, 0xe0, 0x23, 0xb8, 0x49, 0xa5, 0x22, 0x8b, 0x94, 0xc9, 0x0f, 0x34,
+	0x99, 0x2f, 0x2e, 0xfd, 0x96, 0x46, 0xf4, 0xac, 0xea, 0xe9, 0x25, 0x1e, 0x43, 0x2f, 0xe5, 0x4b,
+	0x92, 0xce, 0x49, 0x92, 0x08, 0x2a, 0xa5, 0xdf, 0xd6, 0xa8, 0xae, 0x2e, 0xbe, 0x30, 0x35, 0x1c,
+	0x42, 0x9b, 0x0a, 0xc1, 0x85, 0xbf, 0xa7, 0x9b, 0xe6, 0x4f, 0x48, 0xa1, 0x6f, 0x34, 0x14, 0xcb,
+	0xc4, 0xf4, 0xe3, 0x9a, 0x4a, 0xf5, 0xff, 0x85, 0x84, 0x43, 0x40, 0x9b, 0x46, 0xe6, 0x3c, 0x93,
+	0x34, 0x9c, 0x00, 0xbe, 0x4c, 0xb9, 0xa4, 0xa6, 0xb5, 0x83, 0x3d, 0xbc, 0x03, 0x03, 0x07, 0x59,
+	0x0e, 0x18, 0x02, 0xbe, 0x66, 0x52, 0x99, 0xaa, 0x2c, 0x07, 0x84, 0x67, 0x30, 0x70, 0xaa, 0x06,
+	0x8c, 0x33, 0xb8, 0x6e, 0x9c, 0x96, 0xbe, 0x37, 0x6e, 0x4e, 0x3a, 0x33, 0x7f, 0x7a, 0xe5, 0xf5,
+	0xd4, 0x76, 0x32, 0xde, 0x00, 0xc3, 0x67, 0x70, 0xf8, 0x6a, 0x73, 0x6a, 0x97, 0xc5, 0xd5, 0xeb,
+	0x6d, 0xe9, 0x7d, 0x03, 0x7e, 0xf5, 0xdd, 0x3f, 0xec, 0xf1, 0xc5, 0x83, 0x41, 0x4c, 0x8b, 0x53,
+	0xb8, 0xb7, 0xda, 0xb5, 0xc4, 0x9f, 0x43, 0x36, 0x2c, 0x1b, 0x2b, 0x51, 0x69, 0xee, 0x8a, 0x4a,
+	0xcb, 0x8e, 0xca, 0x01, 0x0c, 0xdd, 0x15, 0x8c, 0x9e, 0xd9, 0xb7, 0x16, 0xf4, 0xca, 0xad, 0x0b,
+	0x11, 0x4b, 0x8a, 0x0c, 0xe0, 0xca, 0x6d, 0xbc, 0x5f, 0x95, 0x67, 0x85, 0x2d, 0x78, 0x50, 0xd7,
+	0x2e, 0x3d, 0x3e, 0xfa, 0xfa, 0xf3, 0xd7, 0xf7, 0xc6, 0x41, 0xd8, 0x8f, 0x2e, 0x9e, 0x44, 0xe6,
+	0x1e, 0xd1, 0xa7, 0x62, 0x8d, 0xcf, 0xcf, 0xbd, 0x13, 0x64, 0xd0, 0xb1, 0x82, 0x81, 0xce, 0xb0,
+	0x6a, 0xb6, 0x82, 0x51, 0x6d, 0xbf, 0x64, 0xbb, 0xab, 0xd9, 0x06, 0x27, 0x55, 0x36, 0x5c, 0x41,
+	0xc7, 0x8a, 0x95, 0x4b, 0x55, 0x4d, 0x61, 0x30, 0xaa, 0xed, 0x97, 0x54, 0x87, 0x9a, 0xaa, 0x8f,
+	0xb7, 0x2c, 0xaa, 0x94, 0x49, 0x85, 0x04, 0x6e, 0x6f, 0x87, 0x07, 0x8f, 0xed, 0x69, 0x35, 0x91,
+	0x0c, 0x1e, 0xee, 0x06, 0x95, 0xbc, 0xd7, 0x1e, 0x7b, 0x78, 0x0e, 0x5d, 0xdb, 0x4b, 0x74, 0x96,
+	0xfd, 0x4b, 0xd0, 0x82, 0x71, 0x3d, 0x60, 0x33, 0xf6, 0xb4, 0xfd, 0xbe, 0x49, 0x72, 0xb6, 0xd8,
+	0xd3, 0x9f, 0xc7, 0xa7, 0xbf, 0x07, 0x00, 0x83, 0xc6, 0x0e, 0x18, 0x58, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// TunnelServiceClient is the client API for TunnelService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TunnelServiceClient interface {
+	// TunnelPort requests a tunnel of a port to a developer's machine. A tunnel requested for the port before is replaced.
+	TunnelPort(ctx context.Context, in *TunnelPortRequest, opts ...grpc.CallOption) (*TunnelPortResponse, error)
+	// CloseTunnel withdraws the tunnel of a port. Clients stop listening for it.
+	CloseTunnel(ctx context.Context, in *CloseTunnelRequest, opts ...grpc.CallOption) (*CloseTunnelResponse, error)
+	// ListTunnels lists the requested tunnels, ordered by port, and where they are established.
+	ListTunnels(ctx context.Context, in *ListTunnelsRequest, opts ...grpc.CallOption) (*ListTunnelsResponse, error)
+	// EstablishTunnels is called by a client which establishes tunnels. It streams the tunnels the client is
+	// expected to establish, starting with the current ones, whenever they change. The tunnels the client
+	// established are considered closed once the call ends.
+	EstablishTunnels(ctx context.Context, in *EstablishTunnelsRequest, opts ...grpc.CallOption) (TunnelService_EstablishTunnelsClient, error)
+	// ReportTunnel reports that a client established a tunnel, or failed to.
+	ReportTunnel(ctx context.Context, in *ReportTunnelRequest, opts ...grpc.CallOption) (*ReportTunnelResponse, error)
+}
+
+type tunnelServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTunnelServiceClient(cc grpc.ClientConnInterface) TunnelServiceClient {
+	return &tunnelServiceClient{cc}
+}
+
+func (c *tunnelServiceClient) TunnelPort(ctx context.Context, in *TunnelPortRequest, opts ...grpc.CallOption) (*TunnelPortResponse, error) {
+	out := new(TunnelPortResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TunnelService/TunnelPort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunnelServiceClient) CloseTunnel(ctx context.Context, in *CloseTunnelRequest, opts ...grpc.CallOption) (*CloseTunnelResponse, error) {
+	out := new(CloseTunnelResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TunnelService/CloseTunnel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunnelServiceClient) ListTunnels(ctx context.Context, in *ListTunnelsRequest, opts ...grpc.CallOption) (*ListTunnelsResponse, error) {
+	out := new(ListTunnelsResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TunnelService/ListTunnels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunnelServiceClient) EstablishTunnels(ctx context.Context, in *EstablishTunnelsRequest, opts ...grpc.CallOption) (TunnelService_EstablishTunnelsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TunnelService_serviceDesc.Streams[0], "/supervisor.TunnelService/EstablishTunnels", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tunnelServiceEstablishTunnelsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TunnelService_EstablishTunnelsClient interface {
+	Recv() (*EstablishTunnelsResponse, error)
+	grpc.ClientStream
+}
+
+type tunnelServiceEstablishTunnelsClient struct {
+	grpc.ClientStream
+}
+
+func (x *tunnelServiceEstablishTunnelsClient) Recv() (*EstablishTunnelsResponse, error) {
+	m := new(EstablishTunnelsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tunnelServiceClient) ReportTunnel(ctx context.Context, in *ReportTunnelRequest, opts ...grpc.CallOption) (*ReportTunnelResponse, error) {
+	out := new(ReportTunnelResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.TunnelService/ReportTunnel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TunnelServiceServer is the server API for TunnelService service.
+type TunnelServiceServer interface {
+	// TunnelPort requests a tunnel of a port to a developer's machine. A tunnel requested for the port before is replaced.
+	TunnelPort(context.Context, *TunnelPortRequest) (*TunnelPortResponse, error)
+	// CloseTunnel withdraws the tunnel of a port. Clients stop listening for it.
+	CloseTunnel(context.Context, *CloseTunnelRequest) (*CloseTunnelResponse, error)
+	// ListTunnels lists the requested tunnels, ordered by port, and where they are established.
+	ListTunnels(context.Context, *ListTunnelsRequest) (*ListTunnelsResponse, error)
+	// EstablishTunnels is called by a client which establishes tunnels. It streams the tunnels the client is
+	// expected to establish, starting with the current ones, whenever they change. The tunnels the client
+	// established are considered closed once the call ends.
+	EstablishTunnels(*EstablishTunnelsRequest, TunnelService_EstablishTunnelsServer) error
+	// ReportTunnel reports that a client established a tunnel, or failed to.
+	ReportTunnel(context.Context, *ReportTunnelRequest) (*ReportTunnelResponse, error)
+}
+
+// UnimplementedTunnelServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTunnelServiceServer struct {
+}
+
+func (*UnimplementedTunnelServiceServer) TunnelPort(ctx context.Context, req *TunnelPortRequest) (*TunnelPortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TunnelPort not implemented")
+}
+func (*UnimplementedTunnelServiceServer) CloseTunnel(ctx context.Context, req *CloseTunnelRequest) (*CloseTunnelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseTunnel not implemented")
+}
+func (*UnimplementedTunnelServiceServer) ListTunnels(ctx context.Context, req *ListTunnelsRequest) (*ListTunnelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTunnels not implemented")
+}
+func (*UnimplementedTunnelServiceServer) EstablishTunnels(req *EstablishTunnelsRequest, srv TunnelService_EstablishTunnelsServer) error {
+	return status.Errorf(codes.Unimplemented, "method EstablishTunnels not implemented")
+}
+func (*UnimplementedTunnelServiceServer) ReportTunnel(ctx context.Context, req *ReportTunnelRequest) (*ReportTunnelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportTunnel not implemented")
+}
+
+func RegisterTunnelServiceServer(s *grpc.Server, srv TunnelServiceServer) {
+	s.RegisterService(&_TunnelService_serviceDesc, srv)
+}
+
+func _TunnelService_TunnelPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TunnelPortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunnelServiceServer).TunnelPort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TunnelService/TunnelPort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunnelServiceServer).TunnelPort(ctx, req.(*TunnelPortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TunnelService_CloseTunnel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseTunnelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunnelServiceServer).CloseTunnel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TunnelService/CloseTunnel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunnelServiceServer).CloseTunnel(ctx, req.(*CloseTunnelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TunnelService_ListTunnels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTunnelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunnelServiceServer).ListTunnels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TunnelService/ListTunnels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunnelServiceServer).ListTunnels(ctx, req.(*ListTunnelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TunnelService_EstablishTunnels_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EstablishTunnelsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TunnelServiceServer).EstablishTunnels(m, &tunnelServiceEstablishTunnelsServer{stream})
+}
+
+type TunnelService_EstablishTunnelsServer interface {
+	Send(*EstablishTunnelsResponse) error
+	grpc.ServerStream
+}
+
+type tunnelServiceEstablishTunnelsServer struct {
+	grpc.ServerStream
+}
+
+func (x *tunnelServiceEstablishTunnelsServer) Send(m *EstablishTunnelsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TunnelService_ReportTunnel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportTunnelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunnelServiceServer).ReportTunnel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.TunnelService/ReportTunnel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunnelServiceServer).ReportTunnel(ctx, req.(*ReportTunnelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TunnelService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.TunnelService",
+	HandlerType: (*TunnelServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TunnelPort",
+			Handler:    _TunnelService_TunnelPort_Handler,
+		},
+		{
+			MethodName: "CloseTunnel",
+			Handler:    _TunnelService_CloseTunnel_Handler,
+		},
+		{
+			MethodName: "ListTunnels",
+			Handler:    _TunnelService_ListTunnels_Handler,
+		},
+		{
+			MethodName: "ReportTunnel",
+			Handler:    _TunnelService_ReportTunnel_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "EstablishTunnels",
+			Handler:       _TunnelService_EstablishTunnels_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tunnel.proto",
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package api
+
+const (
+	// Version is the version of the supervisor API. It increases whenever methods or fields clients may rely on
+	// are added, s.t. clients built against a newer API can detect older supervisors.
+	Version = 1
+
+	// MinClientVersion is the oldest API version of clients supervisor still supports
+	MinClientVersion = 0
+
+	// VersionHeader is the gRPC metadata key under which supervisor sends its API version with every response
+	// and clients may send the version they were built against
+	VersionHeader = "x-supervisor-api-version"
+)
+
+// Supports returns true if supervisor serves a method, e.g. "/supervisor.PortService/Expose"
+func (r *APIVersionResponse) Supports(method string) bool {
+	for _, m := range r.GetMethods() {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCapability returns true if an optional feature is enabled, e.g. "ssh"
+func (r *APIVersionResponse) HasCapability(capability string) bool {
+	for _, c := range r.GetCapabilities() {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,710 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: workspace.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type SnapshotPhase int32
+
+const (
+	// snapshot_phase_hook means the onSnapshot hook runs
+	SnapshotPhase_snapshot_phase_hook SnapshotPhase = 0
+	// snapshot_phase_capturing means the content of the workspace is captured
+	SnapshotPhase_snapshot_phase_capturing SnapshotPhase = 1
+	// snapshot_phase_done means the snapshot was taken and can be shared
+	SnapshotPhase_snapshot_phase_done SnapshotPhase = 2
+)
+
+var SnapshotPhase_name = map[int32]string{
+	0: "snapshot_phase_hook",
+	1: "snapshot_phase_capturing",
+	2: "snapshot_phase_done",
+}
+
+var SnapshotPhase_value = map[string]int32{
+	"snapshot_phase_hook":      0,
+	"snapshot_phase_capturing": 1,
+	"snapshot_phase_done":      2,
+}
+
+func (x SnapshotPhase) String() string {
+	return proto.EnumName(SnapshotPhase_name, int32(x))
+}
+
+func (SnapshotPhase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{0}
+}
+
+type TimeoutRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TimeoutRequest) Reset()         { *m = TimeoutRequest{} }
+func (m *TimeoutRequest) String() string { return proto.CompactTextString(m) }
+func (*TimeoutRequest) ProtoMessage()    {}
+func (*TimeoutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{0}
+}
+
+func (m *TimeoutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeoutRequest.Unmarshal(m, b)
+}
+func (m *TimeoutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeoutRequest.Marshal(b, m, deterministic)
+}
+func (m *TimeoutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeoutRequest.Merge(m, src)
+}
+func (m *TimeoutRequest) XXX_Size() int {
+	return xxx_messageInfo_TimeoutRequest.Size(m)
+}
+func (m *TimeoutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeoutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeoutRequest proto.InternalMessageInfo
+
+type TimeoutResponse struct {
+	// timeout is the inactivity timeout of the workspace, e.g. "30m"
+	Timeout string `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// can_extend is false if the plan of the user does not allow for extending the timeout
+	CanExtend bool `protobuf:"varint,2,opt,name=can_extend,json=canExtend,proto3" json:"can_extend,omitempty"`
+	// durations are the timeouts the workspace can be extended to
+	Durations            []string `protobuf:"bytes,3,rep,name=durations,proto3" json:"durations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TimeoutResponse) Reset()         { *m = TimeoutResponse{} }
+func (m *TimeoutResponse) String() string { return proto.CompactTextString(m) }
+func (*TimeoutResponse) ProtoMessage()    {}
+func (*TimeoutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{1}
+}
+
+func (m *TimeoutResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeoutResponse.Unmarshal(m, b)
+}
+func (m *TimeoutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeoutResponse.Marshal(b, m, deterministic)
+}
+func (m *TimeoutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeoutResponse.Merge(m, src)
+}
+func (m *TimeoutResponse) XXX_Size() int {
+	return xxx_messageInfo_TimeoutResponse.Size(m)
+}
+func (m *TimeoutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeoutResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeoutResponse proto.InternalMessageInfo
+
+func (m *TimeoutResponse) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
+func (m *TimeoutResponse) GetCanExtend() bool {
+	if m != nil {
+		return m.CanExtend
+	}
+	return false
+}
+
+func (m *TimeoutResponse) GetDurations() []string {
+	if m != nil {
+		return m.Durations
+	}
+	return nil
+}
+
+type ExtendTimeoutRequest struct {
+	// duration is the new timeout, one of the durations of TimeoutResponse. If empty, the longest one is used.
+	Duration             string   `protobuf:"bytes,1,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExtendTimeoutRequest) Reset()         { *m = ExtendTimeoutRequest{} }
+func (m *ExtendTimeoutRequest) String() string { return proto.CompactTextString(m) }
+func (*ExtendTimeoutRequest) ProtoMessage()    {}
+func (*ExtendTimeoutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{2}
+}
+
+func (m *ExtendTimeoutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtendTimeoutRequest.Unmarshal(m, b)
+}
+func (m *ExtendTimeoutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtendTimeoutRequest.Marshal(b, m, deterministic)
+}
+func (m *ExtendTimeoutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtendTimeoutRequest.Merge(m, src)
+}
+func (m *ExtendTimeoutRequest) XXX_Size() int {
+	return xxx_messageInfo_ExtendTimeoutRequest.Size(m)
+}
+func (m *ExtendTimeoutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtendTimeoutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtendTimeoutRequest proto.InternalMessageInfo
+
+func (m *ExtendTimeoutRequest) GetDuration() string {
+	if m != nil {
+		return m.Duration
+	}
+	return ""
+}
+
+type ExtendTimeoutResponse struct {
+	// timeout is the new inactivity timeout of the workspace
+	Timeout string `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// reset_workspaces are the IDs of other running workspaces whose timeout was reset to the default
+	ResetWorkspaces      []string `protobuf:"bytes,2,rep,name=reset_workspaces,json=resetWorkspaces,proto3" json:"reset_workspaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExtendTimeoutResponse) Reset()         { *m = ExtendTimeoutResponse{} }
+func (m *ExtendTimeoutResponse) String() string { return proto.CompactTextString(m) }
+func (*ExtendTimeoutResponse) ProtoMessage()    {}
+func (*ExtendTimeoutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{3}
+}
+
+func (m *ExtendTimeoutResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtendTimeoutResponse.Unmarshal(m, b)
+}
+func (m *ExtendTimeoutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtendTimeoutResponse.Marshal(b, m, deterministic)
+}
+func (m *ExtendTimeoutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtendTimeoutResponse.Merge(m, src)
+}
+func (m *ExtendTimeoutResponse) XXX_Size() int {
+	return xxx_messageInfo_ExtendTimeoutResponse.Size(m)
+}
+func (m *ExtendTimeoutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtendTimeoutResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtendTimeoutResponse proto.InternalMessageInfo
+
+func (m *ExtendTimeoutResponse) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
+func (m *ExtendTimeoutResponse) GetResetWorkspaces() []string {
+	if m != nil {
+		return m.ResetWorkspaces
+	}
+	return nil
+}
+
+type TakeSnapshotRequest struct {
+	// skip_hook does not run the onSnapshot hook of the .gitpod.yml
+	SkipHook             bool     `protobuf:"varint,1,opt,name=skip_hook,json=skipHook,proto3" json:"skip_hook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TakeSnapshotRequest) Reset()         { *m = TakeSnapshotRequest{} }
+func (m *TakeSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*TakeSnapshotRequest) ProtoMessage()    {}
+func (*TakeSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{4}
+}
+
+func (m *TakeSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TakeSnapshotRequest.Unmarshal(m, b)
+}
+func (m *TakeSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TakeSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *TakeSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TakeSnapshotRequest.Merge(m, src)
+}
+func (m *TakeSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_TakeSnapshotRequest.Size(m)
+}
+func (m *TakeSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TakeSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TakeSnapshotRequest proto.InternalMessageInfo
+
+func (m *TakeSnapshotRequest) GetSkipHook() bool {
+	if m != nil {
+		return m.SkipHook
+	}
+	return false
+}
+
+type TakeSnapshotResponse struct {
+	Phase SnapshotPhase `protobuf:"varint,1,opt,name=phase,proto3,enum=supervisor.SnapshotPhase" json:"phase,omitempty"`
+	// elapsed_seconds is the time since the request
+	ElapsedSeconds uint32 `protobuf:"varint,2,opt,name=elapsed_seconds,json=elapsedSeconds,proto3" json:"elapsed_seconds,omitempty"`
+	// snapshot_id is set once the snapshot was taken
+	SnapshotId string `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	// url is the URL to share the snapshot with, set once the snapshot was taken
+	Url                  string   `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TakeSnapshotResponse) Reset()         { *m = TakeSnapshotResponse{} }
+func (m *TakeSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*TakeSnapshotResponse) ProtoMessage()    {}
+func (*TakeSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{5}
+}
+
+func (m *TakeSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TakeSnapshotResponse.Unmarshal(m, b)
+}
+func (m *TakeSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TakeSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *TakeSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TakeSnapshotResponse.Merge(m, src)
+}
+func (m *TakeSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_TakeSnapshotResponse.Size(m)
+}
+func (m *TakeSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TakeSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TakeSnapshotResponse proto.InternalMessageInfo
+
+func (m *TakeSnapshotResponse) GetPhase() SnapshotPhase {
+	if m != nil {
+		return m.Phase
+	}
+	return SnapshotPhase_snapshot_phase_hook
+}
+
+func (m *TakeSnapshotResponse) GetElapsedSeconds() uint32 {
+	if m != nil {
+		return m.ElapsedSeconds
+	}
+	return 0
+}
+
+func (m *TakeSnapshotResponse) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return ""
+}
+
+func (m *TakeSnapshotResponse) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+type StopWorkspaceRequest struct {
+	// exit_code is the exit status of the workspace. 0 stops the workspace regularly.
+	ExitCode uint32 `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// message explains a non-zero exit code
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopWorkspaceRequest) Reset()         { *m = StopWorkspaceRequest{} }
+func (m *StopWorkspaceRequest) String() string { return proto.CompactTextString(m) }
+func (*StopWorkspaceRequest) ProtoMessage()    {}
+func (*StopWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{6}
+}
+
+func (m *StopWorkspaceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopWorkspaceRequest.Unmarshal(m, b)
+}
+func (m *StopWorkspaceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopWorkspaceRequest.Marshal(b, m, deterministic)
+}
+func (m *StopWorkspaceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopWorkspaceRequest.Merge(m, src)
+}
+func (m *StopWorkspaceRequest) XXX_Size() int {
+	return xxx_messageInfo_StopWorkspaceRequest.Size(m)
+}
+func (m *StopWorkspaceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopWorkspaceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopWorkspaceRequest proto.InternalMessageInfo
+
+func (m *StopWorkspaceRequest) GetExitCode() uint32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *StopWorkspaceRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type StopWorkspaceResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopWorkspaceResponse) Reset()         { *m = StopWorkspaceResponse{} }
+func (m *StopWorkspaceResponse) String() string { return proto.CompactTextString(m) }
+func (*StopWorkspaceResponse) ProtoMessage()    {}
+func (*StopWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{7}
+}
+
+func (m *StopWorkspaceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopWorkspaceResponse.Unmarshal(m, b)
+}
+func (m *StopWorkspaceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopWorkspaceResponse.Marshal(b, m, deterministic)
+}
+func (m *StopWorkspaceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopWorkspaceResponse.Merge(m, src)
+}
+func (m *StopWorkspaceResponse) XXX_Size() int {
+	return xxx_messageInfo_StopWorkspaceResponse.Size(m)
+}
+func (m *StopWorkspaceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopWorkspaceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopWorkspaceResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("supervisor.SnapshotPhase", SnapshotPhase_name, SnapshotPhase_value)
+	proto.RegisterType((*TimeoutRequest)(nil), "supervisor.TimeoutRequest")
+	proto.RegisterType((*TimeoutResponse)(nil), "supervisor.TimeoutResponse")
+	proto.RegisterType((*ExtendTimeoutRequest)(nil), "supervisor.ExtendTimeoutRequest")
+	proto.RegisterType((*ExtendTimeoutResponse)(nil), "supervisor.ExtendTimeoutResponse")
+	proto.RegisterType((*TakeSnapshotRequest)(nil), "supervisor.TakeSnapshotRequest")
+	proto.RegisterType((*TakeSnapshotResponse)(nil), "supervisor.TakeSnapshotResponse")
+	proto.RegisterType((*StopWorkspaceRequest)(nil), "supervisor.StopWorkspaceRequest")
+	proto.RegisterType((*StopWorkspaceResponse)(nil), "supervisor.StopWorkspaceResponse")
+}
+
+func init() {
+	proto.RegisterFile("workspace.proto", fileDescriptor_dac718ecaafc2333)
+}
+
+var fileDescriptor_dac718ecaafc2333 = []byte{
+	// 560 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdf, 0x6e, 0xd3, 0x3e,
+	0x18, 0xfd, 0xa5, 0xd9, 0x7e, 0x6b, 0x3e, 0xe8, 0x1a, 0x79, 0x2b, 0x0d, 0x69, 0xa7, 0xa5, 0xbe,
+	0xa1, 0xf4, 0xa2, 0x81, 0x72, 0xd7, 0x4b, 0x10, 0x12, 0x5c, 0x20, 0xa1, 0x74, 0x12, 0x12, 0x42,
+	0x8a, 0x4c, 0x62, 0xb5, 0xa1, 0x9d, 0x6d, 0x62, 0xa7, 0xec, 0x9a, 0x57, 0xe0, 0x11, 0x78, 0x22,
+	0xc4, 0x2b, 0xf0, 0x20, 0x28, 0xce, 0x9f, 0x35, 0xa5, 0x8c, 0xbb, 0xfa, 0xf8, 0xf8, 0x3b, 0xe7,
+	0x3b, 0x39, 0x2a, 0x74, 0xbf, 0xf0, 0x74, 0x2d, 0x05, 0x89, 0xe8, 0x54, 0xa4, 0x5c, 0x71, 0x04,
+	0x32, 0x13, 0x34, 0xdd, 0x26, 0x92, 0xa7, 0xee, 0x70, 0xc9, 0xf9, 0x72, 0x43, 0x7d, 0x22, 0x12,
+	0x9f, 0x30, 0xc6, 0x15, 0x51, 0x09, 0x67, 0xb2, 0x60, 0x62, 0x1b, 0x4e, 0xaf, 0x92, 0x6b, 0xca,
+	0x33, 0x15, 0xd0, 0xcf, 0x19, 0x95, 0x0a, 0xaf, 0xa0, 0x5b, 0x23, 0x52, 0x70, 0x26, 0x29, 0x72,
+	0xe0, 0x44, 0x15, 0x90, 0x63, 0x78, 0xc6, 0xd8, 0x0a, 0xaa, 0x23, 0xba, 0x00, 0x88, 0x08, 0x0b,
+	0xe9, 0x8d, 0xa2, 0x2c, 0x76, 0x5a, 0x9e, 0x31, 0x6e, 0x07, 0x56, 0x44, 0xd8, 0x4b, 0x0d, 0xa0,
+	0x21, 0x58, 0x71, 0x96, 0x16, 0x82, 0x8e, 0xe9, 0x99, 0x63, 0x2b, 0xb8, 0x05, 0xf0, 0x0c, 0xce,
+	0x0b, 0x5e, 0xd3, 0x01, 0x72, 0xa1, 0x5d, 0x91, 0x4a, 0xbd, 0xfa, 0x8c, 0x3f, 0x40, 0x6f, 0xef,
+	0xcd, 0x3f, 0x3d, 0x3e, 0x06, 0x3b, 0xa5, 0x92, 0xaa, 0xb0, 0x4e, 0x49, 0x3a, 0x2d, 0xed, 0xa5,
+	0xab, 0xf1, 0x77, 0x35, 0x8c, 0x67, 0x70, 0x76, 0x45, 0xd6, 0x74, 0xc1, 0x88, 0x90, 0x2b, 0x5e,
+	0x1b, 0x1a, 0x80, 0x25, 0xd7, 0x89, 0x08, 0x57, 0x9c, 0xaf, 0xf5, 0xf4, 0x76, 0xd0, 0xce, 0x81,
+	0x57, 0x9c, 0xaf, 0xf1, 0x77, 0x03, 0xce, 0x9b, 0x8f, 0x4a, 0x47, 0x3e, 0x1c, 0x8b, 0x15, 0x91,
+	0x54, 0xbf, 0x38, 0x9d, 0x3d, 0x9c, 0xde, 0x7e, 0x94, 0x69, 0x45, 0x7e, 0x9b, 0x13, 0x82, 0x82,
+	0x87, 0x1e, 0x41, 0x97, 0x6e, 0x88, 0x90, 0x34, 0x0e, 0x25, 0x8d, 0x38, 0x8b, 0xa5, 0x4e, 0xb4,
+	0x13, 0x9c, 0x96, 0xf0, 0xa2, 0x40, 0xd1, 0x25, 0xdc, 0x93, 0xe5, 0x80, 0x30, 0x89, 0x1d, 0x53,
+	0xef, 0x0b, 0x15, 0xf4, 0x3a, 0x46, 0x36, 0x98, 0x59, 0xba, 0x71, 0x8e, 0xf4, 0x45, 0xfe, 0x13,
+	0xbf, 0x81, 0xf3, 0x85, 0xe2, 0xa2, 0xde, 0x75, 0x67, 0x35, 0x7a, 0x93, 0xa8, 0x30, 0xe2, 0x71,
+	0x61, 0xb4, 0x13, 0xb4, 0x73, 0xe0, 0x05, 0x8f, 0x75, 0xa6, 0xd7, 0x54, 0x4a, 0xb2, 0xa4, 0xda,
+	0x88, 0x15, 0x54, 0x47, 0xdc, 0x87, 0xde, 0xde, 0xb8, 0x62, 0xe9, 0x49, 0x08, 0x9d, 0xc6, 0x6e,
+	0xa8, 0x0f, 0x67, 0xb5, 0x57, 0xbd, 0xa6, 0x4e, 0xd1, 0xfe, 0x0f, 0x0d, 0xc1, 0xd9, 0xbb, 0x88,
+	0x88, 0x50, 0x59, 0x9a, 0xb0, 0xa5, 0x6d, 0x1c, 0x78, 0x16, 0x73, 0x46, 0xed, 0xd6, 0xec, 0x87,
+	0x09, 0x76, 0x2d, 0xbb, 0xc8, 0xf3, 0x8c, 0x28, 0x22, 0x70, 0x52, 0xf6, 0x01, 0xb9, 0xbb, 0x31,
+	0x37, 0x8b, 0xe5, 0x0e, 0x0e, 0xde, 0x15, 0xce, 0xf1, 0xc5, 0xd7, 0x9f, 0xbf, 0xbe, 0xb5, 0xfa,
+	0xa8, 0xe7, 0x6f, 0x9f, 0xfa, 0x75, 0x55, 0xfc, 0xaa, 0x45, 0x5b, 0xe8, 0x34, 0x8a, 0x87, 0xbc,
+	0xdd, 0x61, 0x87, 0x7a, 0xec, 0x8e, 0xee, 0x60, 0x94, 0xa2, 0x9e, 0x16, 0x75, 0xf1, 0x61, 0xd1,
+	0xb9, 0x31, 0x41, 0x5b, 0xb8, 0xbf, 0xdb, 0x2e, 0x74, 0xd9, 0xd8, 0xe1, 0xcf, 0xb2, 0xba, 0xde,
+	0xdf, 0x09, 0xa5, 0xe8, 0x48, 0x8b, 0x0e, 0xf0, 0x83, 0xa6, 0x68, 0x95, 0xf7, 0xdc, 0x98, 0x3c,
+	0x31, 0xd0, 0x27, 0x38, 0xca, 0xbf, 0x70, 0x73, 0xcd, 0x43, 0x15, 0x72, 0x47, 0x77, 0x30, 0x9a,
+	0xd9, 0x62, 0xb4, 0xa7, 0xa8, 0xb8, 0x98, 0x1b, 0x93, 0xe7, 0xc7, 0xef, 0x4d, 0x22, 0x92, 0x8f,
+	0xff, 0xeb, 0xbf, 0xa4, 0x67, 0xbf, 0x07, 0x00, 0xbd, 0xc4, 0xe9, 0x2e, 0xcf, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// WorkspaceServiceClient is the client API for WorkspaceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WorkspaceServiceClient interface {
+	// Timeout returns the inactivity timeout of the workspace and whether the user may extend it.
+	Timeout(ctx context.Context, in *TimeoutRequest, opts ...grpc.CallOption) (*TimeoutResponse, error)
+	// ExtendTimeout changes the inactivity timeout of the workspace, if the plan of the user allows for it.
+	// Other running workspaces of the user fall back to the default timeout.
+	ExtendTimeout(ctx context.Context, in *ExtendTimeoutRequest, opts ...grpc.CallOption) (*ExtendTimeoutResponse, error)
+	// TakeSnapshot runs the onSnapshot hook and takes a snapshot of the workspace which others can start their own
+	// copy of the workspace from. As snapshots of large workspaces take a while, it streams the progress.
+	TakeSnapshot(ctx context.Context, in *TakeSnapshotRequest, opts ...grpc.CallOption) (WorkspaceService_TakeSnapshotClient, error)
+	// Stop stops the workspace, e.g. at the end of a job which runs in the workspace. A non-zero exit code
+	// fails the workspace, s.t. the dashboard shows the exit code and message as the reason the workspace stopped.
+	Stop(ctx context.Context, in *StopWorkspaceRequest, opts ...grpc.CallOption) (*StopWorkspaceResponse, error)
+}
+
+type workspaceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkspaceServiceClient(cc grpc.ClientConnInterface) WorkspaceServiceClient {
+	return &workspaceServiceClient{cc}
+}
+
+func (c *workspaceServiceClient) Timeout(ctx context.Context, in *TimeoutRequest, opts ...grpc.CallOption) (*TimeoutResponse, error) {
+	out := new(TimeoutResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.WorkspaceService/Timeout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) ExtendTimeout(ctx context.Context, in *ExtendTimeoutRequest, opts ...grpc.CallOption) (*ExtendTimeoutResponse, error) {
+	out := new(ExtendTimeoutResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.WorkspaceService/ExtendTimeout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) TakeSnapshot(ctx context.Context, in *TakeSnapshotRequest, opts ...grpc.CallOption) (WorkspaceService_TakeSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WorkspaceService_serviceDesc.Streams[0], "/supervisor.WorkspaceService/TakeSnapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workspaceServiceTakeSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WorkspaceService_TakeSnapshotClient interface {
+	Recv() (*TakeSnapshotResponse, error)
+	grpc.ClientStream
+}
+
+type workspaceServiceTakeSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *workspaceServiceTakeSnapshotClient) Recv() (*TakeSnapshotResponse, error) {
+	m := new(TakeSnapshotResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *workspaceServiceClient) Stop(ctx context.Context, in *StopWorkspaceRequest, opts ...grpc.CallOption) (*StopWorkspaceResponse, error) {
+	out := new(StopWorkspaceResponse)
+	err := c.cc.Invoke(ctx, "/supervisor.WorkspaceService/Stop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkspaceServiceServer is the server API for WorkspaceService service.
+type WorkspaceServiceServer interface {
+	// Timeout returns the inactivity timeout of the workspace and whether the user may extend it.
+	Timeout(context.Context, *TimeoutRequest) (*TimeoutResponse, error)
+	// ExtendTimeout changes the inactivity timeout of the workspace, if the plan of the user allows for it.
+	// Other running workspaces of the user fall back to the default timeout.
+	ExtendTimeout(context.Context, *ExtendTimeoutRequest) (*ExtendTimeoutResponse, error)
+	// TakeSnapshot runs the onSnapshot hook and takes a snapshot of the workspace which others can start their own
+	// copy of the workspace from. As snapshots of large workspaces take a while, it streams the progress.
+	TakeSnapshot(*TakeSnapshotRequest, WorkspaceService_TakeSnapshotServer) error
+	// Stop stops the workspace, e.g. at the end of a job which runs in the workspace. A non-zero exit code
+	// fails the workspace, s.t. the dashboard shows the exit code and message as the reason the workspace stopped.
+	Stop(context.Context, *StopWorkspaceRequest) (*StopWorkspaceResponse, error)
+}
+
+// UnimplementedWorkspaceServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWorkspaceServiceServer struct {
+}
+
+func (*UnimplementedWorkspaceServiceServer) Timeout(ctx context.Context, req *TimeoutRequest) (*TimeoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Timeout not implemented")
+}
+func (*UnimplementedWorkspaceServiceServer) ExtendTimeout(ctx context.Context, req *ExtendTimeoutRequest) (*ExtendTimeoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendTimeout not implemented")
+}
+func (*UnimplementedWorkspaceServiceServer) TakeSnapshot(req *TakeSnapshotRequest, srv WorkspaceService_TakeSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method TakeSnapshot not implemented")
+}
+func (*UnimplementedWorkspaceServiceServer) Stop(ctx context.Context, req *StopWorkspaceRequest) (*StopWorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+
+func RegisterWorkspaceServiceServer(s *grpc.Server, srv WorkspaceServiceServer) {
+	s.RegisterService(&_WorkspaceService_serviceDesc, srv)
+}
+
+func _WorkspaceService_Timeout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).Timeout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.WorkspaceService/Timeout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).Timeout(ctx, req.(*TimeoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_ExtendTimeout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendTimeoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).ExtendTimeout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.WorkspaceService/ExtendTimeout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).ExtendTimeout(ctx, req.(*ExtendTimeoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_TakeSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TakeSnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkspaceServiceServer).TakeSnapshot(m, &workspaceServiceTakeSnapshotServer{stream})
+}
+
+type WorkspaceService_TakeSnapshotServer interface {
+	Send(*TakeSnapshotResponse) error
+	grpc.ServerStream
+}
+
+type workspaceServiceTakeSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *workspaceServiceTakeSnapshotServer) Send(m *TakeSnapshotResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WorkspaceService_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/supervisor.WorkspaceService/Stop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).Stop(ctx, req.(*StopWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WorkspaceService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "supervisor.WorkspaceService",
+	HandlerType: (*WorkspaceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Timeout",
+			Handler:    _WorkspaceService_Timeout_Handler,
+		},
+		{
+			MethodName: "ExtendTimeout",
+			Handler:    _WorkspaceService_ExtendTimeout_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _WorkspaceService_Stop_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TakeSnapshot",
+			Handler:       _WorkspaceService_TakeSnapshot_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "workspace.proto",
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "backs up the workspace content right away, e.g. before upgrading dependencies",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := api.NewControlServiceClient(dialSupervisor())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		resp, err := client.Backup(ctx, &api.BackupRequest{})
+		if err != nil {
+			log.WithError(err).Fatal("cannot back up workspace")
+		}
+		fmt.Printf("backed up workspace in %s\n", time.Duration(resp.DurationMs)*time.Millisecond)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+}
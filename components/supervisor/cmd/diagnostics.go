@@ -0,0 +1,36 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+)
+
+var diagnosticsCmd = &cobra.Command{
+	Use:   "diagnostics",
+	Short: "writes a bundle of supervisor's logs and state to the workspace, which helps support to diagnose problems",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := api.NewControlServiceClient(dialSupervisor())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		resp, err := client.CreateDiagnostics(ctx, &api.CreateDiagnosticsRequest{})
+		if err != nil {
+			log.WithError(err).Fatal("cannot create diagnostic bundle")
+		}
+		fmt.Println(resp.Location)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diagnosticsCmd)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+)
+
+var execOpts struct {
+	Env     []string
+	Workdir string
+	TTY     bool
+}
+
+var execCmd = &cobra.Command{
+	Use:   "exec <command> [args...]",
+	Short: "runs a command in the workspace and exits with its exit code",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := api.NewExecServiceClient(dialSupervisor())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigs
+			cancel()
+		}()
+
+		stream, err := client.Exec(ctx, &api.ExecRequest{
+			Command: args[0],
+			Args:    args[1:],
+			Env:     execOpts.Env,
+			Workdir: execOpts.Workdir,
+			Tty:     execOpts.TTY,
+		})
+		if err != nil {
+			log.WithError(err).Fatal("cannot run command")
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				log.WithError(err).Fatal("cannot run command")
+			}
+			switch output := resp.Output.(type) {
+			case *api.ExecResponse_Stdout:
+				_, _ = os.Stdout.Write(output.Stdout)
+			case *api.ExecResponse_Stderr:
+				_, _ = os.Stderr.Write(output.Stderr)
+			case *api.ExecResponse_ExitCode:
+				if output.ExitCode != 0 {
+					// commands killed by a signal report -1, which is no valid exit code
+					code := int(output.ExitCode)
+					if code < 0 {
+						code = 1
+					}
+					os.Exit(code)
+				}
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	execCmd.Flags().StringArrayVarP(&execOpts.Env, "env", "e", nil, "additional environment variable, e.g. FOO=bar")
+	execCmd.Flags().StringVarP(&execOpts.Workdir, "workdir", "w", "", "working directory, the repository root by default")
+	execCmd.Flags().BoolVarP(&execOpts.TTY, "tty", "t", false, "runs the command in a pseudo-terminal")
+	// flags after the command belong to the command
+	execCmd.Flags().SetInterspersed(false)
+}
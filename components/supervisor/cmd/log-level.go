@@ -0,0 +1,71 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/spf13/cobra"
+)
+
+var logLevelOpts struct {
+	Subsystem string
+	Reset     bool
+}
+
+var logLevelCmd = &cobra.Command{
+	Use:   "log-level [level]",
+	Short: "changes the level supervisor or one of its subsystems logs with, or lists the levels if no level is given",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := api.NewControlServiceClient(dialSupervisor())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if len(args) > 0 || logLevelOpts.Reset {
+			req := &api.SetLogLevelRequest{Subsystem: logLevelOpts.Subsystem}
+			if !logLevelOpts.Reset {
+				req.Level = args[0]
+			}
+			_, err := client.SetLogLevel(ctx, req)
+			if err != nil {
+				log.WithError(err).Fatal("cannot set log level")
+			}
+			return
+		}
+
+		resp, err := client.LogLevels(ctx, &api.LogLevelsRequest{})
+		if err != nil {
+			log.WithError(err).Fatal("cannot get log levels")
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 1, ' ', 0)
+		defer tw.Flush()
+
+		fmt.Fprintf(tw, "SUBSYSTEM\tLEVEL\n")
+		fmt.Fprintf(tw, "supervisor\t%s\n", resp.Level)
+		for _, s := range resp.Subsystems {
+			lvl := s.Level
+			if s.Own {
+				lvl += " (own)"
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", s.Subsystem, lvl)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logLevelCmd)
+
+	logLevelCmd.Flags().StringVarP(&logLevelOpts.Subsystem, "subsystem", "s", "", "subsystem whose level to change, e.g. ports")
+	logLevelCmd.Flags().BoolVar(&logLevelOpts.Reset, "reset", false, "makes the subsystem log with the level of supervisor again")
+}
@@ -34,6 +34,11 @@ const (
 	// This time must give ring1 enough time to shut down (see time budgets in supervisor.go),
 	// and to talk to ws-daemon within the terminationGracePeriod of the workspace pod.
 	ring1ShutdownTimeout = 20 * time.Second
+
+	// ring2 restarts supervisor once it crashed, unless it crashed more than maxSupervisorCrashes times within supervisorCrashWindow
+	maxSupervisorCrashes   = 5
+	supervisorCrashWindow  = 5 * time.Minute
+	supervisorRestartDelay = 1 * time.Second
 )
 
 var ring0Cmd = &cobra.Command{
@@ -364,31 +369,80 @@ var ring2Cmd = &cobra.Command{
 			return
 		}
 
-		cmd := exec.Command("/proc/self/exe", "run", "--inns")
-		cmd.SysProcAttr = &syscall.SysProcAttr{
-			Pdeathsig: syscall.SIGKILL,
-			Credential: &syscall.Credential{
-				Uid: 33333,
-				Gid: 33333,
-			},
-		}
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		cmd.Env = os.Environ()
-		if err := cmd.Start(); err != nil {
-			log.WithError(err).Error("failed to start the child process")
-			failed = true
-			return
+		// restarts run the binary from its path rather than /proc/self/exe, s.t. they pick up upgrades
+		exe, err := os.Executable()
+		if err != nil {
+			exe = "/proc/self/exe"
 		}
-		sigc := sigproxy.ForwardAllSignals(context.Background(), cmd.Process.Pid)
-		defer sigproxysignal.StopCatch(sigc)
+		terminating := make(chan os.Signal, 1)
+		signal.Notify(terminating, os.Interrupt, syscall.SIGTERM)
+
+		// the keeper holds supervisor's terminals, s.t. they survive supervisor crashes and upgrades
+		keeper := supervisor.NewKeeper()
+		var crashes []time.Time
+		for {
+			cmd := exec.Command(exe, "run", "--inns")
+			cmd.SysProcAttr = &syscall.SysProcAttr{
+				Pdeathsig: syscall.SIGKILL,
+				Credential: &syscall.Credential{
+					Uid: 33333,
+					Gid: 33333,
+				},
+			}
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			cmd.Env = os.Environ()
+			if err := keeper.Start(cmd); err != nil {
+				log.WithError(err).Error("failed to start the child process")
+				failed = true
+				return
+			}
+			sigc := sigproxy.ForwardAllSignals(context.Background(), cmd.Process.Pid)
 
-		err = cmd.Wait()
-		if err != nil {
-			log.WithError(err).Error("unexpected exit")
-			failed = true
-			return
+			// as PID 1 of the PID namespace we inherit the orphans of the workspace, which we must reap
+			status, err := supervisor.ReapUntilExit(cmd.Process.Pid)
+			sigproxysignal.StopCatch(sigc)
+			if err != nil {
+				log.WithError(err).Error("cannot wait for supervisor")
+				failed = true
+				return
+			}
+
+			select {
+			case <-terminating:
+				if status.ExitStatus() != 0 {
+					log.WithField("status", status.ExitStatus()).Error("unexpected exit")
+					failed = true
+				}
+				return
+			default:
+			}
+			if status.Exited() && status.ExitStatus() == 0 {
+				return
+			}
+			if status.Exited() && status.ExitStatus() == supervisor.RestartExitCode {
+				log.WithField("terminals", len(keeper.Held())).Info("restarting supervisor")
+				continue
+			}
+			if status.Exited() && status.ExitStatus() == supervisor.StopExitCode {
+				// the workspace failed on request, ws-manager reads the exit status from the termination log
+				log.Info("workspace code stopped the workspace")
+				return
+			}
+
+			now := time.Now()
+			crashes = append(crashes, now)
+			for len(crashes) > 0 && now.Sub(crashes[0]) > supervisorCrashWindow {
+				crashes = crashes[1:]
+			}
+			if len(crashes) > maxSupervisorCrashes {
+				log.WithField("crashes", len(crashes)).Error("supervisor keeps crashing - giving up")
+				failed = true
+				return
+			}
+			log.WithField("status", status).WithField("terminals", len(keeper.Held())).Error("supervisor crashed - restarting it")
+			time.Sleep(supervisorRestartDelay)
 		}
 	},
 }
@@ -6,6 +6,7 @@ package cmd
 
 import (
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/supervisor"
 	"github.com/spf13/cobra"
 )
@@ -21,6 +22,12 @@ var runCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Init(ServiceName, Version, true, true)
 
+		// tracing is configured through the standard Jaeger environment variables (e.g. JAEGER_ENDPOINT)
+		closer := tracing.Init(ServiceName)
+		if closer != nil {
+			defer closer.Close()
+		}
+
 		var opts []supervisor.RunOption
 		if runCmdOpts.InNamespace {
 			opts = append(opts,
@@ -0,0 +1,191 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package activity
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"golang.org/x/xerrors"
+)
+
+// Source names what the user was active with
+type Source string
+
+const (
+	// SourceIDE is an established connection to the IDE
+	SourceIDE Source = "ide"
+	// SourceTerminal is input written to a terminal
+	SourceTerminal Source = "terminal"
+	// SourceSSH is input sent through an SSH session or port forwarding
+	SourceSSH Source = "ssh"
+	// SourcePorts is traffic on a port configured with keepAlive
+	SourcePorts Source = "ports"
+)
+
+const (
+	// defaultInterval is how often activity is evaluated if the policy configures no interval
+	defaultInterval = 30 * time.Second
+	// heartbeatTimeout limits sending a single heartbeat
+	heartbeatTimeout = 10 * time.Second
+)
+
+// Tracker records when sources were last active and holds the policy which activity counts
+type Tracker struct {
+	mu            sync.Mutex
+	last          map[Source]time.Time
+	lastHeartbeat time.Time
+	policy        Policy
+
+	now func() time.Time
+}
+
+// NewTracker creates a new activity tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		last: make(map[Source]time.Time),
+		now:  time.Now,
+	}
+}
+
+// Record records activity of a source
+func (t *Tracker) Record(src Source) {
+	t.mu.Lock()
+	t.last[src] = t.now()
+	t.mu.Unlock()
+}
+
+// Recorder returns a function which records activity of a source, e.g. to be used as a hook
+func (t *Tracker) Recorder(src Source) func() {
+	return func() { t.Record(src) }
+}
+
+// Last returns when each source was last active
+func (t *Tracker) Last() map[Source]time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := make(map[Source]time.Time, len(t.last))
+	for src, ts := range t.last {
+		res[src] = ts
+	}
+	return res
+}
+
+// Policy returns the current activity policy
+func (t *Tracker) Policy() Policy {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.policy
+}
+
+// SetPolicy changes the activity policy. A running heartbeat picks up the new interval after its next evaluation.
+func (t *Tracker) SetPolicy(p Policy) {
+	t.mu.Lock()
+	t.policy = p
+	t.mu.Unlock()
+}
+
+// LastHeartbeat returns when the last heartbeat was sent, the zero time if none was sent yet
+func (t *Tracker) LastHeartbeat() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lastHeartbeat
+}
+
+func (t *Tracker) recordHeartbeat() {
+	t.mu.Lock()
+	t.lastHeartbeat = t.now()
+	t.mu.Unlock()
+}
+
+// Policy decides which activity keeps the workspace running
+type Policy struct {
+	// Interval is how often activity is evaluated. At most one heartbeat is sent per interval.
+	Interval time.Duration
+	// Ignore lists the sources whose activity does not keep the workspace running
+	Ignore []Source
+}
+
+// Ignored returns true if the activity of a source does not keep the workspace running
+func (p Policy) Ignored(src Source) bool {
+	for _, s := range p.Ignore {
+		if s == src {
+			return true
+		}
+	}
+	return false
+}
+
+// Active returns the sources which were active after t and are not ignored, sorted by name
+func (p Policy) Active(last map[Source]time.Time, t time.Time) []Source {
+	var res []Source
+	for src, ts := range last {
+		if ts.After(t) && !p.Ignored(src) {
+			res = append(res, src)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+func (p Policy) interval() time.Duration {
+	if p.Interval == 0 {
+		return defaultInterval
+	}
+	return p.Interval
+}
+
+// Heartbeat sends heartbeats to the Gitpod server while the tracker records activity its policy does not ignore,
+// which keeps the workspace from being stopped by the inactivity timeout
+type Heartbeat struct {
+	InstanceID string
+	API        gitpod.APIInterface
+	Tracker    *Tracker
+}
+
+// Run sends heartbeats until ctx is done
+func (h *Heartbeat) Run(ctx context.Context) error {
+	if h.API == nil {
+		return xerrors.Errorf("cannot send heartbeats without a connection to the Gitpod API")
+	}
+	if h.Tracker == nil {
+		return xerrors.Errorf("cannot send heartbeats without an activity tracker")
+	}
+
+	policy := h.Tracker.Policy()
+	timer := time.NewTimer(policy.interval())
+	defer timer.Stop()
+	last := h.Tracker.now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+		policy = h.Tracker.Policy()
+		timer.Reset(policy.interval())
+
+		now := h.Tracker.now()
+		active := policy.Active(h.Tracker.Last(), last)
+		if len(active) == 0 {
+			continue
+		}
+		last = now
+
+		reqCtx, cancel := context.WithTimeout(ctx, heartbeatTimeout)
+		err := h.API.SendHeartBeat(reqCtx, &gitpod.SendHeartBeatOptions{InstanceID: h.InstanceID})
+		cancel()
+		if err != nil {
+			log.WithError(err).WithField("sources", active).Warn("cannot send heartbeat")
+			continue
+		}
+		h.Tracker.recordHeartbeat()
+		log.WithField("sources", active).Debug("sent heartbeat")
+	}
+}
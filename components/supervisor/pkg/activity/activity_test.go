@@ -0,0 +1,115 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPolicyActive(t *testing.T) {
+	start := time.Date(2020, 11, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Desc        string
+		Policy      Policy
+		Last        map[Source]time.Time
+		Expectation []Source
+	}{
+		{Desc: "no activity"},
+		{
+			Desc: "active sources",
+			Last: map[Source]time.Time{
+				SourceTerminal: start.Add(time.Second),
+				SourceIDE:      start.Add(time.Second),
+				"extension":    start.Add(time.Second),
+			},
+			Expectation: []Source{"extension", SourceIDE, SourceTerminal},
+		},
+		{
+			Desc: "earlier activity",
+			Last: map[Source]time.Time{
+				SourceTerminal: start,
+				SourceSSH:      start.Add(-time.Second),
+				SourcePorts:    start.Add(time.Second),
+			},
+			Expectation: []Source{SourcePorts},
+		},
+		{
+			Desc:   "ignored source",
+			Policy: Policy{Ignore: []Source{SourcePorts}},
+			Last: map[Source]time.Time{
+				SourcePorts: start.Add(time.Second),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := test.Policy.Active(test.Last, start)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected active sources (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	heartbeats := make(chan *gitpod.SendHeartBeatOptions, 1)
+	mockAPI := gitpod.NewMockAPIInterface(ctrl)
+	mockAPI.EXPECT().SendHeartBeat(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, options *gitpod.SendHeartBeatOptions) error {
+		select {
+		case heartbeats <- options:
+		default:
+		}
+		return nil
+	}).MinTimes(1)
+
+	tracker := NewTracker()
+	tracker.SetPolicy(Policy{Interval: 10 * time.Millisecond, Ignore: []Source{SourcePorts}})
+	heartbeat := &Heartbeat{
+		InstanceID: "instance",
+		API:        mockAPI,
+		Tracker:    tracker,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := heartbeat.Run(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tracker.Record(SourcePorts)
+	select {
+	case <-heartbeats:
+		t.Error("heartbeat was sent for an ignored source")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	tracker.Record(SourceTerminal)
+	select {
+	case options := <-heartbeats:
+		if diff := cmp.Diff(&gitpod.SendHeartBeatOptions{InstanceID: "instance"}, options); diff != "" {
+			t.Errorf("unexpected heartbeat (-want +got):\n%s", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("no heartbeat was sent for terminal activity")
+	}
+	cancel()
+	<-done
+
+	if tracker.LastHeartbeat().IsZero() {
+		t.Error("expected the heartbeat to be recorded")
+	}
+}
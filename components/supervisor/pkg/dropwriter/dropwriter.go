@@ -52,17 +52,22 @@ func (b *Bucket) adjustTokens() {
 	defer b.mu.Unlock()
 
 	now := b.clock()
-	defer func() {
-		b.lastTick = now
-	}()
-
 	if b.lastTick.IsZero() {
 		// first adjustment/tick ever - set availableTokens to capacity
 		b.availableTokens = b.capacity
+		b.lastTick = now
+		return
+	}
+
+	refill := int64(now.Sub(b.lastTick).Seconds() * float64(b.refillRate))
+	if refill == 0 {
+		// not enough time has passed to refill a whole token - keep the last tick so that
+		// frequent requests don't swallow the time elapsed between them.
 		return
 	}
+	b.lastTick = now
 
-	b.availableTokens += int64(now.Sub(b.lastTick).Seconds() * float64(b.refillRate))
+	b.availableTokens += refill
 	if b.availableTokens > b.capacity {
 		b.availableTokens = b.capacity
 	}
@@ -67,6 +67,12 @@ func TestBucket(t *testing.T) {
 			Reqs:         append(constv(1, 4), append(constv(30, 1), constv(1, 5)...)...),
 			Expectations: append(constv(1, 4), append(constv(20, 1), constv(1, 5)...)...),
 		},
+		{
+			Name:         "frequent requests",
+			Bucket:       dropwriter.NewBucketClock(1, 1, clock(steps(400*time.Millisecond, 10))),
+			Reqs:         constv(1, 10),
+			Expectations: []int64{1, 1, 0, 0, 1, 0, 0, 1, 0, 0},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,128 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package filewatch
+
+import (
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// Coalesce condenses the events of a batch into one change per file, ordered by their first event,
+// e.g. a file which was created and written to is reported as created only, and a file which was
+// created and removed again is not reported at all. A move within the tree is reported as rename.
+// overflow is true if the events contain an overflow.
+func Coalesce(events []Event) (changes []*api.FileChange, overflow bool) {
+	c := coalescer{idx: make(map[string]int)}
+	for i := 0; i < len(events); i++ {
+		ev := events[i]
+		switch ev.Op {
+		case OpOverflow:
+			overflow = true
+		case OpCreate:
+			c.create(ev.Path)
+		case OpWrite:
+			if c.get(ev.Path) == nil {
+				c.add(&api.FileChange{Type: api.FileChangeType_file_changed, Path: ev.Path})
+			}
+		case OpRemove:
+			c.remove(ev.Path)
+		case OpRename:
+			if i+1 < len(events) && events[i+1].Op == OpCreate {
+				c.rename(ev.Path, events[i+1].Path)
+				i++
+				continue
+			}
+			// moved out of the tree
+			c.remove(ev.Path)
+		}
+	}
+
+	for _, change := range c.changes {
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+	return changes, overflow
+}
+
+type coalescer struct {
+	changes []*api.FileChange
+	idx     map[string]int
+}
+
+func (c *coalescer) get(path string) *api.FileChange {
+	i, ok := c.idx[path]
+	if !ok {
+		return nil
+	}
+	return c.changes[i]
+}
+
+func (c *coalescer) add(change *api.FileChange) {
+	c.drop(change.Path)
+	c.idx[change.Path] = len(c.changes)
+	c.changes = append(c.changes, change)
+}
+
+func (c *coalescer) drop(path string) {
+	i, ok := c.idx[path]
+	if !ok {
+		return
+	}
+	c.changes[i] = nil
+	delete(c.idx, path)
+}
+
+func (c *coalescer) create(path string) {
+	prev := c.get(path)
+	switch {
+	case prev == nil:
+		c.add(&api.FileChange{Type: api.FileChangeType_file_created, Path: path})
+	case prev.Type == api.FileChangeType_file_deleted:
+		// replaced
+		prev.Type = api.FileChangeType_file_changed
+	}
+}
+
+func (c *coalescer) remove(path string) {
+	prev := c.get(path)
+	switch {
+	case prev == nil:
+		c.add(&api.FileChange{Type: api.FileChangeType_file_deleted, Path: path})
+	case prev.Type == api.FileChangeType_file_created:
+		c.drop(path)
+	case prev.Type == api.FileChangeType_file_renamed:
+		// the file the client knows is gone
+		c.drop(path)
+		c.remove(prev.OldPath)
+	default:
+		prev.Type = api.FileChangeType_file_deleted
+	}
+}
+
+func (c *coalescer) rename(oldPath, newPath string) {
+	var (
+		prev   = c.get(oldPath)
+		known  = true
+		origin = oldPath
+	)
+	switch {
+	case prev == nil:
+	case prev.Type == api.FileChangeType_file_created:
+		known = false
+	case prev.Type == api.FileChangeType_file_renamed:
+		origin = prev.OldPath
+	}
+	c.drop(oldPath)
+
+	switch {
+	case !known:
+		c.create(newPath)
+	case origin == newPath:
+		// moved back
+		c.add(&api.FileChange{Type: api.FileChangeType_file_changed, Path: newPath})
+	default:
+		c.add(&api.FileChange{Type: api.FileChangeType_file_renamed, Path: newPath, OldPath: origin})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package filewatch
+
+import (
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/proto"
+)
+
+func TestCoalesce(t *testing.T) {
+	var (
+		created = api.FileChangeType_file_created
+		changed = api.FileChangeType_file_changed
+		deleted = api.FileChangeType_file_deleted
+		renamed = api.FileChangeType_file_renamed
+	)
+	tests := []struct {
+		Desc     string
+		Events   []Event
+		Changes  []*api.FileChange
+		Overflow bool
+	}{
+		{
+			Desc:    "created and written",
+			Events:  []Event{{OpCreate, "/a"}, {OpWrite, "/a"}, {OpWrite, "/b"}, {OpWrite, "/b"}},
+			Changes: []*api.FileChange{{Type: created, Path: "/a"}, {Type: changed, Path: "/b"}},
+		},
+		{
+			Desc:   "created and removed",
+			Events: []Event{{OpCreate, "/a"}, {OpWrite, "/a"}, {OpRemove, "/a"}},
+		},
+		{
+			Desc:    "replaced",
+			Events:  []Event{{OpRemove, "/a"}, {OpCreate, "/a"}},
+			Changes: []*api.FileChange{{Type: changed, Path: "/a"}},
+		},
+		{
+			Desc:    "renamed",
+			Events:  []Event{{OpRename, "/a"}, {OpCreate, "/b"}, {OpWrite, "/b"}},
+			Changes: []*api.FileChange{{Type: renamed, Path: "/b", OldPath: "/a"}},
+		},
+		{
+			Desc:    "renamed twice",
+			Events:  []Event{{OpRename, "/a"}, {OpCreate, "/b"}, {OpRename, "/b"}, {OpCreate, "/c"}},
+			Changes: []*api.FileChange{{Type: renamed, Path: "/c", OldPath: "/a"}},
+		},
+		{
+			Desc:    "renamed back",
+			Events:  []Event{{OpRename, "/a"}, {OpCreate, "/b"}, {OpRename, "/b"}, {OpCreate, "/a"}},
+			Changes: []*api.FileChange{{Type: changed, Path: "/a"}},
+		},
+		{
+			Desc:    "renamed and removed",
+			Events:  []Event{{OpRename, "/a"}, {OpCreate, "/b"}, {OpRemove, "/b"}},
+			Changes: []*api.FileChange{{Type: deleted, Path: "/a"}},
+		},
+		{
+			// editors save atomically by writing a temporary file which replaces the original
+			Desc:    "atomic save",
+			Events:  []Event{{OpCreate, "/a.tmp"}, {OpWrite, "/a.tmp"}, {OpRename, "/a.tmp"}, {OpCreate, "/a"}},
+			Changes: []*api.FileChange{{Type: created, Path: "/a"}},
+		},
+		{
+			Desc:    "moved out",
+			Events:  []Event{{OpRename, "/a"}, {OpWrite, "/b"}},
+			Changes: []*api.FileChange{{Type: deleted, Path: "/a"}, {Type: changed, Path: "/b"}},
+		},
+		{
+			Desc:     "overflow",
+			Events:   []Event{{OpWrite, "/a"}, {Op: OpOverflow}},
+			Changes:  []*api.FileChange{{Type: changed, Path: "/a"}},
+			Overflow: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			changes, overflow := Coalesce(test.Events)
+			if !changesEqual(test.Changes, changes) {
+				t.Errorf("unexpected changes: want %v, got %v", test.Changes, changes)
+			}
+			if overflow != test.Overflow {
+				t.Errorf("unexpected overflow: want %v, got %v", test.Overflow, overflow)
+			}
+		})
+	}
+}
+
+func changesEqual(a, b []*api.FileChange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package filewatch
+
+import (
+	"path"
+	"strings"
+)
+
+// ValidateGlob returns path.ErrBadPattern if the glob pattern is malformed
+func ValidateGlob(pattern string) error {
+	for _, seg := range strings.Split(pattern, "/") {
+		if seg == "**" {
+			continue
+		}
+		_, err := path.Match(seg, "")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MatchGlob reports whether a slash separated, relative name matches a glob pattern. Besides the syntax of path.Match,
+// a ** segment matches any number of segments, e.g. **/node_modules/** matches every file within a node_modules folder.
+func MatchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// try to match the rest of the pattern with every suffix of name, including the empty one
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		ok, err := path.Match(pattern[0], name[0])
+		if err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package filewatch
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		Pattern string
+		Name    string
+		Match   bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"src/**/*.go", "src/main.go", true},
+		{"src/**/*.go", "src/pkg/a/main.go", true},
+		{"src/**/*.go", "other/main.go", false},
+		{"**/node_modules/**", "node_modules/a/index.js", true},
+		{"**/node_modules/**", "web/node_modules/index.js", true},
+		{"**/node_modules/**", "web/modules/index.js", false},
+		{"**", "a/b/c", true},
+		{"a/**", "a", true},
+		{"a/?/c", "a/b/c", true},
+		{"[", "[", false},
+	}
+	for _, test := range tests {
+		t.Run(test.Pattern+" "+test.Name, func(t *testing.T) {
+			if act := MatchGlob(test.Pattern, test.Name); act != test.Match {
+				t.Errorf("unexpected match: want %v, got %v", test.Match, act)
+			}
+		})
+	}
+}
+
+func TestValidateGlob(t *testing.T) {
+	for pattern, valid := range map[string]bool{
+		"src/**/*.go": true,
+		"[a-z].txt":   true,
+		"src/[a-":     false,
+	} {
+		if err := ValidateGlob(pattern); (err == nil) != valid {
+			t.Errorf("unexpected validation result of %s: %v", pattern, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package filewatch
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultDebounce is how long changes are collected if the client does not choose
+	defaultDebounce = 100 * time.Millisecond
+	// maxDebounce is the longest time changes are collected for
+	maxDebounce = 10 * time.Second
+)
+
+// Service implements the api.FileWatchService. All clients share one tree of watches, which is set up
+// once the first client watches and released once the last one stops.
+type Service struct {
+	// Root is the folder which is watched
+	Root string
+
+	mu      sync.Mutex
+	tree    *Tree
+	clients int
+}
+
+// RegisterGRPC registers the gRPC file watch service
+func (s *Service) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterFileWatchServiceServer(srv, s)
+}
+
+// Watch streams the changes of the files a client is interested in
+func (s *Service) Watch(req *api.WatchFilesRequest, srv api.FileWatchService_WatchServer) error {
+	for _, pattern := range append(append([]string{}, req.Includes...), req.Excludes...) {
+		if err := ValidateGlob(pattern); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid pattern %s: %v", pattern, err)
+		}
+	}
+	debounce := time.Duration(req.DebounceMs) * time.Millisecond
+	if debounce == 0 {
+		debounce = defaultDebounce
+	}
+	if debounce > maxDebounce {
+		debounce = maxDebounce
+	}
+
+	sub, err := s.subscribe()
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	defer s.unsubscribe(sub)
+
+	var (
+		batch []Event
+		timer <-chan time.Time
+	)
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case ev, ok := <-sub.Events:
+			if !ok {
+				return status.Error(codes.Unavailable, "file watching stopped")
+			}
+			batch = append(batch, ev)
+			if timer == nil {
+				timer = time.After(debounce)
+			}
+		case <-timer:
+			changes, overflow := Coalesce(batch)
+			batch, timer = nil, nil
+			resp := &api.WatchFilesResponse{
+				Root:     s.Root,
+				Changes:  s.filter(req, changes),
+				Overflow: overflow || sub.Overflowed(),
+			}
+			if len(resp.Changes) == 0 && !resp.Overflow {
+				continue
+			}
+			err := srv.Send(resp)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Service) subscribe() (*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tree == nil {
+		tree, err := NewTree(s.Root)
+		if err != nil {
+			return nil, err
+		}
+		log.WithField("root", s.Root).WithField("watches", tree.Watches()).Info("started watching files")
+		s.tree = tree
+	}
+	s.clients++
+	return s.tree.Subscribe(), nil
+}
+
+func (s *Service) unsubscribe(sub *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub.Close()
+	s.clients--
+	if s.clients > 0 {
+		return
+	}
+	err := s.tree.Close()
+	if err != nil {
+		log.WithError(err).Warn("cannot stop watching files")
+	}
+	s.tree = nil
+	log.WithField("root", s.Root).Info("stopped watching files")
+}
+
+// filter removes the changes the client is not interested in. A file which was renamed
+// from or to an uninteresting path is reported as created or deleted.
+func (s *Service) filter(req *api.WatchFilesRequest, changes []*api.FileChange) []*api.FileChange {
+	res := make([]*api.FileChange, 0, len(changes))
+	for _, change := range changes {
+		matches := s.matches(req, change.Path)
+		if change.Type != api.FileChangeType_file_renamed {
+			if matches {
+				res = append(res, change)
+			}
+			continue
+		}
+
+		oldMatches := s.matches(req, change.OldPath)
+		switch {
+		case matches && oldMatches:
+			res = append(res, change)
+		case matches:
+			res = append(res, &api.FileChange{Type: api.FileChangeType_file_created, Path: change.Path})
+		case oldMatches:
+			res = append(res, &api.FileChange{Type: api.FileChangeType_file_deleted, Path: change.OldPath})
+		}
+	}
+	return res
+}
+
+func (s *Service) matches(req *api.WatchFilesRequest, path string) bool {
+	rel, err := filepath.Rel(s.Root, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range req.Excludes {
+		if MatchGlob(pattern, rel) {
+			return false
+		}
+	}
+	if len(req.Includes) == 0 {
+		return true
+	}
+	for _, pattern := range req.Includes {
+		if MatchGlob(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
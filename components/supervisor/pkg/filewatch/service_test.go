@@ -0,0 +1,146 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package filewatch
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWatch(t *testing.T) {
+	root, err := ioutil.TempDir("", "filewatch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	mustWrite(t, filepath.Join(root, "src", "main.go"))
+	mustWrite(t, filepath.Join(root, "node_modules", "a", "index.js"))
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &Service{Root: root}
+	srv := grpc.NewServer()
+	service.RegisterGRPC(srv)
+	go srv.Serve(l)
+	defer srv.Stop()
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := api.NewFileWatchServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = recvChanges(client.Watch(ctx, &api.WatchFilesRequest{Includes: []string{"src/[a-"}}))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unexpected error for an invalid pattern: %v", err)
+	}
+
+	goStream, err := client.Watch(ctx, &api.WatchFilesRequest{Includes: []string{"**/*.go"}, DebounceMs: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	allStream, err := client.Watch(ctx, &api.WatchFilesRequest{Excludes: []string{"**/node_modules/**"}, DebounceMs: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the watches are set up once the calls arrive at the service
+	waitFor(t, func() bool {
+		service.mu.Lock()
+		defer service.mu.Unlock()
+		return service.clients == 2
+	})
+	if watches := service.tree.Watches(); watches != 4 {
+		t.Errorf("unexpected number of watches: want 4, got %d", watches)
+	}
+
+	mustWrite(t, filepath.Join(root, "src", "pkg", "util.go"))
+	mustWrite(t, filepath.Join(root, "node_modules", "a", "other.js"))
+	err = os.Rename(filepath.Join(root, "src", "main.go"), filepath.Join(root, "src", "cmd.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goChanges, err := recvChanges(goStream, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectChanges(t, goChanges, []*api.FileChange{
+		{Type: api.FileChangeType_file_created, Path: filepath.Join(root, "src", "pkg", "util.go")},
+		{Type: api.FileChangeType_file_renamed, Path: filepath.Join(root, "src", "cmd.go"), OldPath: filepath.Join(root, "src", "main.go")},
+	})
+	allChanges, err := recvChanges(allStream, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectChanges(t, allChanges, []*api.FileChange{
+		{Type: api.FileChangeType_file_created, Path: filepath.Join(root, "src", "pkg")},
+		{Type: api.FileChangeType_file_created, Path: filepath.Join(root, "src", "pkg", "util.go")},
+		{Type: api.FileChangeType_file_renamed, Path: filepath.Join(root, "src", "cmd.go"), OldPath: filepath.Join(root, "src", "main.go")},
+	})
+
+	// the watches are released once the last client stops
+	cancel()
+	waitFor(t, func() bool {
+		service.mu.Lock()
+		defer service.mu.Unlock()
+		return service.tree == nil
+	})
+}
+
+func mustWrite(t *testing.T, fn string) {
+	err := os.MkdirAll(filepath.Dir(fn), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(fn, []byte("content"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// recvChanges receives the changes of the first response of a stream
+func recvChanges(stream api.FileWatchService_WatchClient, err error) ([]*api.FileChange, error) {
+	if err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Changes, nil
+}
+
+func expectChanges(t *testing.T, act, exp []*api.FileChange) {
+	t.Helper()
+	if !changesEqual(exp, act) {
+		t.Errorf("unexpected changes: want %v, got %v", exp, act)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("condition was not met in time")
+}
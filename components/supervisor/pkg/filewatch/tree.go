@@ -0,0 +1,253 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package filewatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/xerrors"
+)
+
+// subscriptionBuffer is the number of events a subscriber may lag behind before events are dropped
+const subscriptionBuffer = 4096
+
+// Op is what happened to a file
+type Op int
+
+const (
+	// OpCreate means a file was created or moved into the tree
+	OpCreate Op = iota
+	// OpWrite means the content of a file changed
+	OpWrite
+	// OpRemove means a file was removed
+	OpRemove
+	// OpRename means a file was moved away. If it was moved within the tree, an OpCreate event
+	// for its new path follows immediately.
+	OpRename
+	// OpOverflow means events were lost
+	OpOverflow
+)
+
+// Event is a change of a file within the tree
+type Event struct {
+	Op Op
+	// Path is the absolute path of the file
+	Path string
+}
+
+// Tree watches a folder and all folders within it
+type Tree struct {
+	Root string
+
+	watcher *fsnotify.Watcher
+
+	mu   sync.Mutex
+	dirs map[string]struct{}
+	subs map[*Subscription]struct{}
+	// gone are the folders whose watches were released after they were removed or moved away.
+	// A watched folder reports its own removal in addition to its parent folder, which is reported once only.
+	gone map[string]struct{}
+	// lastOp is the operation of the previous event, which tells moves from creations
+	lastOp fsnotify.Op
+}
+
+// NewTree watches root recursively. Call Close to release the watches.
+func NewTree(root string) (*Tree, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create watcher: %w", err)
+	}
+	t := &Tree{
+		Root:    root,
+		watcher: watcher,
+		dirs:    make(map[string]struct{}),
+		subs:    make(map[*Subscription]struct{}),
+		gone:    make(map[string]struct{}),
+	}
+	t.addDir(root, false)
+	if _, ok := t.dirs[root]; !ok {
+		watcher.Close()
+		return nil, xerrors.Errorf("cannot watch %s", root)
+	}
+
+	go t.run()
+	return t, nil
+}
+
+// Close releases the watches and closes all subscriptions
+func (t *Tree) Close() error {
+	return t.watcher.Close()
+}
+
+// Watches returns the number of folders being watched
+func (t *Tree) Watches() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.dirs)
+}
+
+// Subscribe returns a subscription to the events of the tree
+func (t *Tree) Subscribe() *Subscription {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ch := make(chan Event, subscriptionBuffer)
+	sub := &Subscription{Events: ch, ch: ch, tree: t}
+	t.subs[sub] = struct{}{}
+	return sub
+}
+
+func (t *Tree) run() {
+	defer func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for sub := range t.subs {
+			close(sub.ch)
+			delete(t.subs, sub)
+		}
+	}()
+
+	for {
+		select {
+		case ev, ok := <-t.watcher.Events:
+			if !ok {
+				return
+			}
+			t.handle(ev)
+		case err, ok := <-t.watcher.Errors:
+			if !ok {
+				return
+			}
+			if err == fsnotify.ErrEventOverflow {
+				log.WithField("root", t.Root).Warn("file events were lost")
+				t.broadcast(Event{Op: OpOverflow})
+				continue
+			}
+			log.WithError(err).WithField("root", t.Root).Warn("file watching error")
+		}
+	}
+}
+
+func (t *Tree) handle(ev fsnotify.Event) {
+	if ev.Name == "" {
+		// the folder of the watch the event stems from was released already
+		return
+	}
+	t.mu.Lock()
+	if _, gone := t.gone[ev.Name]; gone {
+		delete(t.gone, ev.Name)
+		if ev.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+			t.mu.Unlock()
+			return
+		}
+	}
+	moved := t.lastOp&fsnotify.Rename != 0
+	t.lastOp = ev.Op
+	t.mu.Unlock()
+
+	switch {
+	case ev.Op&fsnotify.Create != 0:
+		t.broadcast(Event{Op: OpCreate, Path: ev.Name})
+		if stat, err := os.Lstat(ev.Name); err == nil && stat.IsDir() {
+			// files may have been created in a new folder before it was watched, whereas
+			// the files of a moved folder are known already
+			t.addDir(ev.Name, !moved)
+		}
+	case ev.Op&fsnotify.Write != 0:
+		t.broadcast(Event{Op: OpWrite, Path: ev.Name})
+	case ev.Op&fsnotify.Remove != 0:
+		t.broadcast(Event{Op: OpRemove, Path: ev.Name})
+		t.removeDir(ev.Name)
+	case ev.Op&fsnotify.Rename != 0:
+		t.broadcast(Event{Op: OpRename, Path: ev.Name})
+		// the watches of a moved folder would report its files under the old path
+		t.removeDir(ev.Name)
+	}
+}
+
+// addDir watches dir and the folders within it, and optionally reports the files within it as created
+func (t *Tree) addDir(dir string, report bool) {
+	_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if report && path != dir {
+			t.broadcast(Event{Op: OpCreate, Path: path})
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		err = t.watcher.Add(path)
+		if err != nil {
+			log.WithError(err).WithField("path", path).Warn("cannot watch folder, the inotify watch limit may be reached")
+			return filepath.SkipDir
+		}
+		t.mu.Lock()
+		t.dirs[path] = struct{}{}
+		t.mu.Unlock()
+		return nil
+	})
+}
+
+// removeDir releases the watches of dir and the folders within it, if dir is a watched folder
+func (t *Tree) removeDir(dir string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.dirs[dir]; !ok {
+		return
+	}
+	t.gone[dir] = struct{}{}
+	prefix := dir + string(filepath.Separator)
+	for d := range t.dirs {
+		if d != dir && !strings.HasPrefix(d, prefix) {
+			continue
+		}
+		// the watch of a removed folder is gone already
+		_ = t.watcher.Remove(d)
+		delete(t.dirs, d)
+	}
+}
+
+func (t *Tree) broadcast(ev Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for sub := range t.subs {
+		select {
+		case sub.ch <- ev:
+		default:
+			atomic.StoreInt32(&sub.overflow, 1)
+		}
+	}
+}
+
+// Subscription receives the events of a tree
+type Subscription struct {
+	// Events receives the events until the subscription or the tree is closed
+	Events <-chan Event
+
+	ch       chan Event
+	tree     *Tree
+	overflow int32
+}
+
+// Overflowed returns true if events were dropped since the last call, because the subscriber did not keep up
+func (s *Subscription) Overflowed() bool {
+	return atomic.SwapInt32(&s.overflow, 0) == 1
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	s.tree.mu.Lock()
+	defer s.tree.mu.Unlock()
+	if _, ok := s.tree.subs[s]; !ok {
+		return
+	}
+	delete(s.tree.subs, s)
+	close(s.ch)
+}
@@ -13,6 +13,16 @@ import (
 	"fmt"
 )
 
+// Cors Cross-origin resource sharing (CORS) policy for the exposed port. If set, preflight requests are answered by Gitpod and the CORS headers are added to the responses of the port.
+type Cors struct {
+
+	// Whether cross-origin requests may include credentials, i.e. cookies and authorization headers. Must not be combined with the '*' origin.
+	AllowCredentials bool `yaml:"allowCredentials,omitempty"`
+
+	// Origins which may access the port, e.g. 'https://example.com'. '*' allows any origin.
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+}
+
 // Env Environment variables to set.
 type Env struct {
 }
@@ -36,6 +46,9 @@ type GitpodConfig struct {
 	// Configures Gitpod's GitHub app
 	Github *Github `yaml:"github,omitempty"`
 
+	// Commands to run at points of the workspace lifecycle.
+	Hooks *Hooks `yaml:"hooks,omitempty"`
+
 	// Controls what ide should be used for a workspace.
 	Ide interface{} `yaml:"ide,omitempty"`
 
@@ -45,6 +58,9 @@ type GitpodConfig struct {
 	// List of exposed ports.
 	Ports []*PortsItems `yaml:"ports,omitempty"`
 
+	// Policies which apply to all ports of the workspace.
+	PortsPolicy *PortsPolicy `yaml:"portsPolicy,omitempty"`
+
 	// Whether the workspace is started in privileged mode.
 	Privileged bool `yaml:"privileged,omitempty"`
 
@@ -58,6 +74,32 @@ type GitpodConfig struct {
 	WorkspaceLocation string `yaml:"workspaceLocation,omitempty"`
 }
 
+// Hook
+type Hook struct {
+
+	// The shell command to run. It runs in the repository folder.
+	Command string `yaml:"command"`
+
+	// How long the command may run before it is killed, e.g. '30s' or '5m'. Defaults to 5 minutes, and to 30 seconds for onStop.
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// Hooks Commands to run at points of the workspace lifecycle.
+type Hooks struct {
+
+	// Runs at the end of a prebuild, once all tasks succeeded. If it fails, the prebuild fails.
+	OnPrebuild *Hook `yaml:"onPrebuild,omitempty"`
+
+	// Runs before a snapshot of the workspace is taken.
+	OnSnapshot *Hook `yaml:"onSnapshot,omitempty"`
+
+	// Runs on every start once the workspace content is available, but not during prebuilds.
+	OnStart *Hook `yaml:"onStart,omitempty"`
+
+	// Runs when the workspace stops, e.g. to flush caches. Limited to 30 seconds by default.
+	OnStop *Hook `yaml:"onStop,omitempty"`
+}
+
 // Image_object The Docker image to run your workspace in.
 type Image_object struct {
 
@@ -71,22 +113,50 @@ type Image_object struct {
 // PortsItems
 type PortsItems struct {
 
-	// Port name (deprecated).
+	// The maximum number of new connections per second accepted on the port. Connections above the limit are closed right away. Defaults to no limit. Only enforced for services which listen on localhost only, since those are reached through a proxy.
+	ConnectionRateLimit float64 `yaml:"connectionRateLimit,omitempty"`
+
+	// Cross-origin resource sharing (CORS) policy for the exposed port. If set, preflight requests are answered by Gitpod and the CORS headers are added to the responses of the port.
+	Cors *Cors `yaml:"cors,omitempty"`
+
+	// The port to proxy a service to if it only listens on localhost. Defaults to a dynamically allocated port. Only supported for single ports, not for port ranges.
+	GlobalPort float64 `yaml:"globalPort,omitempty"`
+
+	// What to do with plain HTTP requests to the exposed port. 'allow' (default) will serve them. 'redirect' will redirect them to HTTPS. 'reject' will refuse them.
+	InsecureRequests string `yaml:"insecureRequests,omitempty"`
+
+	// Whether traffic on the port counts as workspace activity, so that the workspace is not stopped by the inactivity timeout while the port serves requests, e.g. when the IDE is closed. Defaults to false.
+	KeepAlive bool `yaml:"keepAlive,omitempty"`
+
+	// Name of the port (e.g. 'api'), which can be used instead of the port number, e.g. with gp url. Must start with a lowercase letter and may only contain lowercase letters, digits and dashes. Only supported for single ports, not for port ranges.
 	Name string `yaml:"name,omitempty"`
 
 	// What to do when a service on this port was detected. 'notify' (default) will show a notification asking the user what to do. 'open-browser' will open a new browser tab. 'open-preview' will open in the preview on the right of the IDE. 'ignore' will do nothing.
 	OnOpen string `yaml:"onOpen,omitempty"`
 
+	// Whether this entry takes precedence over all other entries matching the same port. By default exact ports win over port ranges, and the .gitpod.yml of the running workspace wins over the one the workspace was created with.
+	Override bool `yaml:"override,omitempty"`
+
 	// The port number (e.g. 1337) or range (e.g. 3000-3999) to expose.
 	Port interface{} `yaml:"port"`
 
-	// The protocol to be used. (deprecated)
+	// The protocol spoken on the port, i.e. http, tcp, udp or grpc, which decides how supervisor proxies ports that listen on localhost only. Defaults to http.
 	Protocol string `yaml:"protocol,omitempty"`
 
 	// Whether the port visibility should be private or public. 'public' (default) will allow everyone with the port URL to access the port. 'private' will only allow users with workspace access to access the port.
 	Visibility string `yaml:"visibility,omitempty"`
 }
 
+// PortsPolicy Policies which apply to all ports of the workspace.
+type PortsPolicy struct {
+
+	// Ports and port ranges (e.g. 22 or '6000-6100') which are never exposed or proxied, even if they are configured.
+	Deny []interface{} `yaml:"deny,omitempty"`
+
+	// Which services listening on localhost only are exposed automatically. 'auto' (default) exposes all of them. 'configured' only exposes those whose port is configured, e.g. to keep databases without authentication inside the workspace.
+	LocalhostPorts string `yaml:"localhostPorts,omitempty"`
+}
+
 // Prebuilds_object Set to true to enable workspace prebuilds, false to disable them. Defaults to true.
 type Prebuilds_object struct {
 
@@ -118,6 +188,9 @@ type TasksItems struct {
 	// The main shell command to run after `before` and `init`. This command is executed last on every start and doesn't have to terminate.
 	Command string `yaml:"command,omitempty"`
 
+	// Conditions which must hold before the task starts: names of other tasks, or objects with a `task`, `port`, `file` or `http` property.
+	DependsOn []interface{} `yaml:"dependsOn,omitempty"`
+
 	// Environment variables to set.
 	Env *Env `yaml:"env,omitempty"`
 
@@ -144,6 +217,69 @@ type Vscode struct {
 	Extensions []string `yaml:"extensions,omitempty"`
 }
 
+func (strct *Cors) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0))
+	buf.WriteString("{")
+	comma := false
+	// Marshal the "allowCredentials" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"allowCredentials\": ")
+	if tmp, err := json.Marshal(strct.AllowCredentials); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// "AllowedOrigins" field is required
+	// only required object types supported for marshal checking (for now)
+	// Marshal the "allowedOrigins" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"allowedOrigins\": ")
+	if tmp, err := json.Marshal(strct.AllowedOrigins); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+
+	buf.WriteString("}")
+	rv := buf.Bytes()
+	return rv, nil
+}
+
+func (strct *Cors) UnmarshalJSON(b []byte) error {
+	allowedOriginsReceived := false
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(b, &jsonMap); err != nil {
+		return err
+	}
+	// parse all the defined properties
+	for k, v := range jsonMap {
+		switch k {
+		case "allowCredentials":
+			if err := json.Unmarshal([]byte(v), &strct.AllowCredentials); err != nil {
+				return err
+			}
+		case "allowedOrigins":
+			if err := json.Unmarshal([]byte(v), &strct.AllowedOrigins); err != nil {
+				return err
+			}
+			allowedOriginsReceived = true
+		default:
+			return fmt.Errorf("additional property not allowed: \"" + k + "\"")
+		}
+	}
+	// check if allowedOrigins (a required property) was received
+	if !allowedOriginsReceived {
+		return errors.New("\"allowedOrigins\" is required but was not present")
+	}
+	return nil
+}
+
 func (strct *Github) MarshalJSON() ([]byte, error) {
 	buf := bytes.NewBuffer(make([]byte, 0))
 	buf.WriteString("{")
@@ -221,6 +357,17 @@ func (strct *GitpodConfig) MarshalJSON() ([]byte, error) {
 		buf.Write(tmp)
 	}
 	comma = true
+	// Marshal the "hooks" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"hooks\": ")
+	if tmp, err := json.Marshal(strct.Hooks); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
 	// Marshal the "ide" field
 	if comma {
 		buf.WriteString(",")
@@ -254,6 +401,17 @@ func (strct *GitpodConfig) MarshalJSON() ([]byte, error) {
 		buf.Write(tmp)
 	}
 	comma = true
+	// Marshal the "portsPolicy" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"portsPolicy\": ")
+	if tmp, err := json.Marshal(strct.PortsPolicy); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
 	// Marshal the "privileged" field
 	if comma {
 		buf.WriteString(",")
@@ -324,6 +482,10 @@ func (strct *GitpodConfig) UnmarshalJSON(b []byte) error {
 			if err := json.Unmarshal([]byte(v), &strct.Github); err != nil {
 				return err
 			}
+		case "hooks":
+			if err := json.Unmarshal([]byte(v), &strct.Hooks); err != nil {
+				return err
+			}
 		case "ide":
 			if err := json.Unmarshal([]byte(v), &strct.Ide); err != nil {
 				return err
@@ -336,6 +498,10 @@ func (strct *GitpodConfig) UnmarshalJSON(b []byte) error {
 			if err := json.Unmarshal([]byte(v), &strct.Ports); err != nil {
 				return err
 			}
+		case "portsPolicy":
+			if err := json.Unmarshal([]byte(v), &strct.PortsPolicy); err != nil {
+				return err
+			}
 		case "privileged":
 			if err := json.Unmarshal([]byte(v), &strct.Privileged); err != nil {
 				return err
@@ -359,6 +525,154 @@ func (strct *GitpodConfig) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+func (strct *Hook) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0))
+	buf.WriteString("{")
+	comma := false
+	// "Command" field is required
+	// only required object types supported for marshal checking (for now)
+	// Marshal the "command" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"command\": ")
+	if tmp, err := json.Marshal(strct.Command); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "timeout" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"timeout\": ")
+	if tmp, err := json.Marshal(strct.Timeout); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+
+	buf.WriteString("}")
+	rv := buf.Bytes()
+	return rv, nil
+}
+
+func (strct *Hook) UnmarshalJSON(b []byte) error {
+	commandReceived := false
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(b, &jsonMap); err != nil {
+		return err
+	}
+	// parse all the defined properties
+	for k, v := range jsonMap {
+		switch k {
+		case "command":
+			if err := json.Unmarshal([]byte(v), &strct.Command); err != nil {
+				return err
+			}
+			commandReceived = true
+		case "timeout":
+			if err := json.Unmarshal([]byte(v), &strct.Timeout); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("additional property not allowed: \"" + k + "\"")
+		}
+	}
+	// check if command (a required property) was received
+	if !commandReceived {
+		return errors.New("\"command\" is required but was not present")
+	}
+	return nil
+}
+
+func (strct *Hooks) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0))
+	buf.WriteString("{")
+	comma := false
+	// Marshal the "onPrebuild" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"onPrebuild\": ")
+	if tmp, err := json.Marshal(strct.OnPrebuild); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "onSnapshot" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"onSnapshot\": ")
+	if tmp, err := json.Marshal(strct.OnSnapshot); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "onStart" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"onStart\": ")
+	if tmp, err := json.Marshal(strct.OnStart); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "onStop" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"onStop\": ")
+	if tmp, err := json.Marshal(strct.OnStop); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+
+	buf.WriteString("}")
+	rv := buf.Bytes()
+	return rv, nil
+}
+
+func (strct *Hooks) UnmarshalJSON(b []byte) error {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(b, &jsonMap); err != nil {
+		return err
+	}
+	// parse all the defined properties
+	for k, v := range jsonMap {
+		switch k {
+		case "onPrebuild":
+			if err := json.Unmarshal([]byte(v), &strct.OnPrebuild); err != nil {
+				return err
+			}
+		case "onSnapshot":
+			if err := json.Unmarshal([]byte(v), &strct.OnSnapshot); err != nil {
+				return err
+			}
+		case "onStart":
+			if err := json.Unmarshal([]byte(v), &strct.OnStart); err != nil {
+				return err
+			}
+		case "onStop":
+			if err := json.Unmarshal([]byte(v), &strct.OnStop); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("additional property not allowed: \"" + k + "\"")
+		}
+	}
+	return nil
+}
+
 func (strct *Image_object) MarshalJSON() ([]byte, error) {
 	buf := bytes.NewBuffer(make([]byte, 0))
 	buf.WriteString("{")
@@ -426,6 +740,61 @@ func (strct *PortsItems) MarshalJSON() ([]byte, error) {
 	buf := bytes.NewBuffer(make([]byte, 0))
 	buf.WriteString("{")
 	comma := false
+	// Marshal the "connectionRateLimit" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"connectionRateLimit\": ")
+	if tmp, err := json.Marshal(strct.ConnectionRateLimit); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "cors" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"cors\": ")
+	if tmp, err := json.Marshal(strct.Cors); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "globalPort" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"globalPort\": ")
+	if tmp, err := json.Marshal(strct.GlobalPort); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "insecureRequests" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"insecureRequests\": ")
+	if tmp, err := json.Marshal(strct.InsecureRequests); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "keepAlive" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"keepAlive\": ")
+	if tmp, err := json.Marshal(strct.KeepAlive); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
 	// Marshal the "name" field
 	if comma {
 		buf.WriteString(",")
@@ -448,6 +817,17 @@ func (strct *PortsItems) MarshalJSON() ([]byte, error) {
 		buf.Write(tmp)
 	}
 	comma = true
+	// Marshal the "override" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"override\": ")
+	if tmp, err := json.Marshal(strct.Override); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
 	// "Port" field is required
 	// only required object types supported for marshal checking (for now)
 	// Marshal the "port" field
@@ -498,6 +878,26 @@ func (strct *PortsItems) UnmarshalJSON(b []byte) error {
 	// parse all the defined properties
 	for k, v := range jsonMap {
 		switch k {
+		case "connectionRateLimit":
+			if err := json.Unmarshal([]byte(v), &strct.ConnectionRateLimit); err != nil {
+				return err
+			}
+		case "cors":
+			if err := json.Unmarshal([]byte(v), &strct.Cors); err != nil {
+				return err
+			}
+		case "globalPort":
+			if err := json.Unmarshal([]byte(v), &strct.GlobalPort); err != nil {
+				return err
+			}
+		case "insecureRequests":
+			if err := json.Unmarshal([]byte(v), &strct.InsecureRequests); err != nil {
+				return err
+			}
+		case "keepAlive":
+			if err := json.Unmarshal([]byte(v), &strct.KeepAlive); err != nil {
+				return err
+			}
 		case "name":
 			if err := json.Unmarshal([]byte(v), &strct.Name); err != nil {
 				return err
@@ -506,6 +906,10 @@ func (strct *PortsItems) UnmarshalJSON(b []byte) error {
 			if err := json.Unmarshal([]byte(v), &strct.OnOpen); err != nil {
 				return err
 			}
+		case "override":
+			if err := json.Unmarshal([]byte(v), &strct.Override); err != nil {
+				return err
+			}
 		case "port":
 			if err := json.Unmarshal([]byte(v), &strct.Port); err != nil {
 				return err
@@ -530,6 +934,61 @@ func (strct *PortsItems) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+func (strct *PortsPolicy) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0))
+	buf.WriteString("{")
+	comma := false
+	// Marshal the "deny" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"deny\": ")
+	if tmp, err := json.Marshal(strct.Deny); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+	// Marshal the "localhostPorts" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"localhostPorts\": ")
+	if tmp, err := json.Marshal(strct.LocalhostPorts); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
+
+	buf.WriteString("}")
+	rv := buf.Bytes()
+	return rv, nil
+}
+
+func (strct *PortsPolicy) UnmarshalJSON(b []byte) error {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(b, &jsonMap); err != nil {
+		return err
+	}
+	// parse all the defined properties
+	for k, v := range jsonMap {
+		switch k {
+		case "deny":
+			if err := json.Unmarshal([]byte(v), &strct.Deny); err != nil {
+				return err
+			}
+		case "localhostPorts":
+			if err := json.Unmarshal([]byte(v), &strct.LocalhostPorts); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("additional property not allowed: \"" + k + "\"")
+		}
+	}
+	return nil
+}
+
 func (strct *TasksItems) MarshalJSON() ([]byte, error) {
 	buf := bytes.NewBuffer(make([]byte, 0))
 	buf.WriteString("{")
@@ -556,6 +1015,17 @@ func (strct *TasksItems) MarshalJSON() ([]byte, error) {
 		buf.Write(tmp)
 	}
 	comma = true
+	// Marshal the "dependsOn" field
+	if comma {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\"dependsOn\": ")
+	if tmp, err := json.Marshal(strct.DependsOn); err != nil {
+		return nil, err
+	} else {
+		buf.Write(tmp)
+	}
+	comma = true
 	// Marshal the "env" field
 	if comma {
 		buf.WriteString(",")
@@ -644,6 +1114,10 @@ func (strct *TasksItems) UnmarshalJSON(b []byte) error {
 			if err := json.Unmarshal([]byte(v), &strct.Command); err != nil {
 				return err
 			}
+		case "dependsOn":
+			if err := json.Unmarshal([]byte(v), &strct.DependsOn); err != nil {
+				return err
+			}
 		case "env":
 			if err := json.Unmarshal([]byte(v), &strct.Env); err != nil {
 				return err
@@ -8,11 +8,11 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
-	"github.com/gitpod-io/gitpod/common-go/log"
 	"gopkg.in/yaml.v2"
 )
 
@@ -131,7 +131,9 @@ func (service *ConfigService) watch(ctx context.Context) (err error) {
 		return err
 	}
 
-	err = watcher.Add(service.location)
+	// editors often save by replacing the file, which ends a watch on the file itself,
+	// hence we watch the directory and filter for the config file
+	err = watcher.Add(filepath.Dir(service.location))
 	if err != nil {
 		watcher.Close()
 		return err
@@ -151,7 +153,10 @@ func (service *ConfigService) watch(ctx context.Context) (err error) {
 				return
 			case err := <-watcher.Errors:
 				service.dispatchError(err)
-			case <-watcher.Events:
+			case event := <-watcher.Events:
+				if filepath.Clean(event.Name) != filepath.Clean(service.location) {
+					continue
+				}
 				service.scheduleUpdateConfig(ctx, polling)
 			}
 		}
@@ -208,6 +213,10 @@ func (service *ConfigService) updateConfig() error {
 	defer service.mu.Unlock()
 
 	config, err := service.parse()
+	if err != nil && !os.IsNotExist(err) {
+		// keep the last valid config while the file cannot be parsed, e.g. in the middle of editing it
+		return err
+	}
 	service.config = config
 	for listener := range service.listeners {
 		listener.configs <- service.config
@@ -8,7 +8,9 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -119,3 +121,71 @@ vscode:
 		})
 	}
 }
+
+func TestGitpodConfigLiveReload(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "test-gitpod-config-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	location := filepath.Join(tempDir, ".gitpod.yml")
+	// editors often save by writing a temporary file and renaming it over the original
+	save := func(content string) {
+		tmp := filepath.Join(tempDir, ".gitpod.yml.tmp")
+		err := ioutil.WriteFile(tmp, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Rename(tmp, location)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	save("ports:\n  - port: 3000\n")
+
+	locationReady := make(chan struct{})
+	close(locationReady)
+	configService := NewConfigService(location, locationReady)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	configs, errors := configService.Observe(ctx)
+
+	expectConfig := func(expectation *GitpodConfig) {
+		t.Helper()
+		for {
+			select {
+			case config := <-configs:
+				if config == nil {
+					// the initial config before the file was read
+					continue
+				}
+				if diff := cmp.Diff(expectation, config); diff != "" {
+					t.Errorf("unexpected output (-want +got):\n%s", diff)
+				}
+				return
+			case err := <-errors:
+				t.Fatalf("unexpected error: %v", err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for a config")
+			}
+		}
+	}
+
+	expectConfig(&GitpodConfig{Ports: []*PortsItems{{Port: 3000}}})
+
+	save("ports:\n  - port: 3000\n  - port: 8080\n")
+	expectConfig(&GitpodConfig{Ports: []*PortsItems{{Port: 3000}, {Port: 8080}}})
+
+	save("ports:\n  - port: [")
+	select {
+	case config := <-configs:
+		t.Fatalf("expected the last valid config to be kept, got %v", config)
+	case <-errors:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an error")
+	}
+
+	save("ports:\n  - port: 5000\n")
+	expectConfig(&GitpodConfig{Ports: []*PortsItems{{Port: 5000}}})
+}
@@ -15,7 +15,6 @@ import (
 	"net/url"
 	"sync"
 
-	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/sourcegraph/jsonrpc2"
 	"golang.org/x/xerrors"
 )
@@ -57,6 +56,7 @@ type APIInterface interface {
 	GetOpenPorts(ctx context.Context, workspaceID string) (res []*WorkspaceInstancePort, err error)
 	OpenPort(ctx context.Context, workspaceID string, port *WorkspaceInstancePort) (res *WorkspaceInstancePort, err error)
 	ClosePort(ctx context.Context, workspaceID string, port float32) (err error)
+	AuditPortExposure(ctx context.Context, workspaceID string, record *PortExposureAuditRecord) (err error)
 	GetUserMessages(ctx context.Context, options *GetUserMessagesOptions) (res []*UserMessage, err error)
 	UpdateUserMessages(ctx context.Context, options *UpdateUserMessagesOptions) (err error)
 	GetUserStorageResource(ctx context.Context, options *GetUserStorageResourceOptions) (res string, err error)
@@ -64,6 +64,7 @@ type APIInterface interface {
 	GetEnvVars(ctx context.Context) (res []*UserEnvVarValue, err error)
 	SetEnvVar(ctx context.Context, variable *UserEnvVarValue) (err error)
 	DeleteEnvVar(ctx context.Context, variable *UserEnvVarValue) (err error)
+	GetSSHPublicKeys(ctx context.Context) (res []string, err error)
 	GetGitpodTokens(ctx context.Context) (res []*APIToken, err error)
 	GenerateNewGitpodToken(ctx context.Context, options *GenerateNewGitpodTokenOptions) (res string, err error)
 	DeleteGitpodToken(ctx context.Context, tokenHash string) (err error)
@@ -155,6 +156,8 @@ const (
 	FunctionOpenPort FunctionName = "openPort"
 	// FunctionClosePort is the name of the closePort function
 	FunctionClosePort FunctionName = "closePort"
+	// FunctionAuditPortExposure is the name of the auditPortExposure function
+	FunctionAuditPortExposure FunctionName = "auditPortExposure"
 	// FunctionGetUserMessages is the name of the getUserMessages function
 	FunctionGetUserMessages FunctionName = "getUserMessages"
 	// FunctionUpdateUserMessages is the name of the updateUserMessages function
@@ -169,6 +172,8 @@ const (
 	FunctionSetEnvVar FunctionName = "setEnvVar"
 	// FunctionDeleteEnvVar is the name of the deleteEnvVar function
 	FunctionDeleteEnvVar FunctionName = "deleteEnvVar"
+	// FunctionGetSSHPublicKeys is the name of the getSSHPublicKeys function
+	FunctionGetSSHPublicKeys FunctionName = "getSSHPublicKeys"
 	// FunctionGetGitpodTokens is the name of the getGitpodTokens function
 	FunctionGetGitpodTokens FunctionName = "getGitpodTokens"
 	// FunctionGenerateNewGitpodToken is the name of the generateNewGitpodToken function
@@ -204,6 +209,8 @@ const (
 type ConnectToServerOpts struct {
 	Context context.Context
 	Token   string
+	// OnStateChange is called with nil once connected and with the error if the connection fails or breaks
+	OnStateChange func(err error)
 }
 
 // ConnectToServer establishes a new websocket connection to the server
@@ -231,6 +238,7 @@ func ConnectToServer(endpoint string, opts ConnectToServerOpts) (*APIoverJSONRPC
 		reqHeader.Set("Authorization", "Bearer "+opts.Token)
 	}
 	ws := NewReconnectingWebsocket(endpoint, reqHeader)
+	ws.OnStateChange = opts.OnStateChange
 	go ws.Dial()
 
 	var res APIoverJSONRPC
@@ -834,6 +842,21 @@ func (gp *APIoverJSONRPC) ClosePort(ctx context.Context, workspaceID string, por
 	return
 }
 
+// AuditPortExposure calls auditPortExposure on the server
+func (gp *APIoverJSONRPC) AuditPortExposure(ctx context.Context, workspaceID string, record *PortExposureAuditRecord) (err error) {
+	var _params []interface{}
+
+	_params = append(_params, workspaceID)
+	_params = append(_params, record)
+
+	err = gp.C.Call(ctx, "auditPortExposure", _params, nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
 // GetUserMessages calls getUserMessages on the server
 func (gp *APIoverJSONRPC) GetUserMessages(ctx context.Context, options *GetUserMessagesOptions) (res []*UserMessage, err error) {
 	var _params []interface{}
@@ -936,6 +959,20 @@ func (gp *APIoverJSONRPC) DeleteEnvVar(ctx context.Context, variable *UserEnvVar
 	return
 }
 
+// GetSSHPublicKeys calls getSSHPublicKeys on the server
+func (gp *APIoverJSONRPC) GetSSHPublicKeys(ctx context.Context) (res []string, err error) {
+	var _params []interface{}
+
+	var result []string
+	err = gp.C.Call(ctx, "getSSHPublicKeys", _params, &result)
+	if err != nil {
+		return
+	}
+	res = result
+
+	return
+}
+
 // GetGitpodTokens calls getGitpodTokens on the server
 func (gp *APIoverJSONRPC) GetGitpodTokens(ctx context.Context) (res []*APIToken, err error) {
 	var _params []interface{}
@@ -1380,12 +1417,13 @@ type WorkspaceConfig struct {
 	// definitly-gp - from github.com/gitpod-io/definitely-gp
 	// derived - computed based on analyzing the repository
 	// default - our static catch-all default config
-	Origin            string        `json:"_origin,omitempty"`
-	Ports             []*PortConfig `json:"ports,omitempty"`
-	Privileged        bool          `json:"privileged,omitempty"`
-	Tasks             []*TaskConfig `json:"tasks,omitempty"`
-	Vscode            *VSCodeConfig `json:"vscode,omitempty"`
-	WorkspaceLocation string        `json:"workspaceLocation,omitempty"`
+	Origin            string             `json:"_origin,omitempty"`
+	Ports             []*PortConfig      `json:"ports,omitempty"`
+	PortsPolicy       *PortsPolicyConfig `json:"portsPolicy,omitempty"`
+	Privileged        bool               `json:"privileged,omitempty"`
+	Tasks             []*TaskConfig      `json:"tasks,omitempty"`
+	Vscode            *VSCodeConfig      `json:"vscode,omitempty"`
+	WorkspaceLocation string             `json:"workspaceLocation,omitempty"`
 }
 
 // WorkspaceContext is the WorkspaceContext message type
@@ -1393,6 +1431,9 @@ type WorkspaceContext struct {
 	ForceCreateNewWorkspace bool   `json:"forceCreateNewWorkspace,omitempty"`
 	NormalizedContextURL    string `json:"normalizedContextURL,omitempty"`
 	Title                   string `json:"title,omitempty"`
+
+	// Repository is only set for commit contexts
+	Repository *Repository `json:"repository,omitempty"`
 }
 
 // WorkspaceImageSourceDocker is the WorkspaceImageSourceDocker message type
@@ -1485,10 +1526,21 @@ type GetWorkspaceTimeoutResult struct {
 
 // WorkspaceInstancePort is the WorkspaceInstancePort message type
 type WorkspaceInstancePort struct {
+	Cors             *PortCorsConfig `json:"cors,omitempty"`
+	InsecureRequests string          `json:"insecureRequests,omitempty"`
+	Port             float64         `json:"port,omitempty"`
+	TargetPort       float64         `json:"targetPort,omitempty"`
+	URL              string          `json:"url,omitempty"`
+	Visibility       string          `json:"visibility,omitempty"`
+}
+
+// PortExposureAuditRecord is the PortExposureAuditRecord message type
+type PortExposureAuditRecord struct {
+	ExposedAt  string  `json:"exposedAt,omitempty"`
+	InstanceID string  `json:"instanceId,omitempty"`
+	Manual     bool    `json:"manual,omitempty"`
 	Port       float64 `json:"port,omitempty"`
-	TargetPort float64 `json:"targetPort,omitempty"`
 	URL        string  `json:"url,omitempty"`
-	Visibility string  `json:"visibility,omitempty"`
 }
 
 // GithubAppConfig is the GithubAppConfig message type
@@ -1516,9 +1568,29 @@ type ImageConfigFile struct {
 
 // PortConfig is the PortConfig message type
 type PortConfig struct {
-	OnOpen     string  `json:"onOpen,omitempty"`
-	Port       float64 `json:"port,omitempty"`
-	Visibility string  `json:"visibility,omitempty"`
+	ConnectionRateLimit float64         `json:"connectionRateLimit,omitempty"`
+	Cors                *PortCorsConfig `json:"cors,omitempty"`
+	GlobalPort          float64         `json:"globalPort,omitempty"`
+	InsecureRequests    string          `json:"insecureRequests,omitempty"`
+	KeepAlive           bool            `json:"keepAlive,omitempty"`
+	Name                string          `json:"name,omitempty"`
+	OnOpen              string          `json:"onOpen,omitempty"`
+	Override            bool            `json:"override,omitempty"`
+	Port                float64         `json:"port,omitempty"`
+	Protocol            string          `json:"protocol,omitempty"`
+	Visibility          string          `json:"visibility,omitempty"`
+}
+
+// PortCorsConfig is the PortCorsConfig message type
+type PortCorsConfig struct {
+	AllowCredentials bool     `json:"allowCredentials,omitempty"`
+	AllowedOrigins   []string `json:"allowedOrigins,omitempty"`
+}
+
+// PortsPolicyConfig is the PortsPolicyConfig message type
+type PortsPolicyConfig struct {
+	LocalhostPorts string        `json:"localhostPorts,omitempty"`
+	Deny           []interface{} `json:"deny,omitempty"`
 }
 
 // ResolvedPlugins is the ResolvedPlugins message type
@@ -550,6 +550,20 @@ func (mr *MockAPIInterfaceMockRecorder) ClosePort(ctx, workspaceID, port interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePort", reflect.TypeOf((*MockAPIInterface)(nil).ClosePort), ctx, workspaceID, port)
 }
 
+// AuditPortExposure mocks base method
+func (m *MockAPIInterface) AuditPortExposure(ctx context.Context, workspaceID string, record *PortExposureAuditRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditPortExposure", ctx, workspaceID, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuditPortExposure indicates an expected call of AuditPortExposure
+func (mr *MockAPIInterfaceMockRecorder) AuditPortExposure(ctx, workspaceID, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditPortExposure", reflect.TypeOf((*MockAPIInterface)(nil).AuditPortExposure), ctx, workspaceID, record)
+}
+
 // GetUserMessages mocks base method
 func (m *MockAPIInterface) GetUserMessages(ctx context.Context, options *GetUserMessagesOptions) ([]*UserMessage, error) {
 	m.ctrl.T.Helper()
@@ -651,6 +665,21 @@ func (mr *MockAPIInterfaceMockRecorder) DeleteEnvVar(ctx, variable interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvVar", reflect.TypeOf((*MockAPIInterface)(nil).DeleteEnvVar), ctx, variable)
 }
 
+// GetSSHPublicKeys mocks base method
+func (m *MockAPIInterface) GetSSHPublicKeys(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSSHPublicKeys", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSSHPublicKeys indicates an expected call of GetSSHPublicKeys
+func (mr *MockAPIInterfaceMockRecorder) GetSSHPublicKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSSHPublicKeys", reflect.TypeOf((*MockAPIInterface)(nil).GetSSHPublicKeys), ctx)
+}
+
 // GetGitpodTokens mocks base method
 func (m *MockAPIInterface) GetGitpodTokens(ctx context.Context) ([]*APIToken, error) {
 	m.ctrl.T.Helper()
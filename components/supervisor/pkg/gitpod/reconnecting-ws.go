@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gorilla/websocket"
 )
 
@@ -26,6 +25,10 @@ type ReconnectingWebsocket struct {
 	closedCh chan struct{}
 	connCh   chan chan *websocket.Conn
 	errCh    chan error
+
+	// OnStateChange is called with nil once a connection is established and with the error
+	// if a connection fails or breaks. It must be set before Dial is called.
+	OnStateChange func(err error)
 }
 
 // NewReconnectingWebsocket creates a new instance of ReconnectingWebsocket
@@ -122,6 +125,7 @@ func (rc *ReconnectingWebsocket) Dial() {
 			connCh <- conn
 		case err := <-rc.errCh:
 			log.WithError(err).WithField("url", rc.url).Warn("connection has been closed, reconnecting...")
+			rc.stateChanged(err)
 			conn.Close()
 			conn = rc.connect()
 		}
@@ -135,11 +139,13 @@ func (rc *ReconnectingWebsocket) connect() *websocket.Conn {
 		conn, _, err := dialer.Dial(rc.url, rc.reqHeader)
 		if err == nil {
 			log.WithField("url", rc.url).Info("connection was successfully established")
+			rc.stateChanged(nil)
 
 			return conn
 		}
 
 		log.WithError(err).WithField("url", rc.url).Errorf("failed to connect, trying again in %d seconds...", uint32(delay.Seconds()))
+		rc.stateChanged(err)
 		select {
 		case <-rc.closedCh:
 			return nil
@@ -151,3 +157,9 @@ func (rc *ReconnectingWebsocket) connect() *websocket.Conn {
 		}
 	}
 }
+
+func (rc *ReconnectingWebsocket) stateChanged(err error) {
+	if rc.OnStateChange != nil {
+		rc.OnStateChange(err)
+	}
+}
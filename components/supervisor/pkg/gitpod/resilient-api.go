@@ -0,0 +1,325 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package gitpod
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"golang.org/x/xerrors"
+)
+
+// ErrOffline is returned by calls which cannot be made while the connection to the Gitpod server is lost
+var ErrOffline = xerrors.New("Gitpod server is unreachable")
+
+const (
+	// DefaultRetryAttempts is how often a call which the server did not answer is attempted
+	DefaultRetryAttempts = 3
+	// DefaultRetryDelay is the delay before the first retry, which doubles with every further retry
+	DefaultRetryDelay = 500 * time.Millisecond
+
+	// maxQueuedCalls is the number of non-essential calls queued while offline, the oldest ones are dropped
+	maxQueuedCalls = 100
+	// flushTimeout limits a single queued call once the connection is back
+	flushTimeout = 10 * time.Second
+	// retryBudget is the number of retries which can be spent at once. Every call which succeeds right away
+	// earns back retryBudgetRefill retries, s.t. retries cannot multiply the load on a struggling server.
+	retryBudget       = 10
+	retryBudgetRefill = 0.1
+)
+
+// ResilientAPI wraps the API of the Gitpod server. It retries the calls supervisor makes if the server did not answer,
+// as long as the retry budget lasts. While the connection is lost it is offline: essential calls fail with ErrOffline
+// right away instead of waiting for the connection, non-essential ones, e.g. heartbeats and exposure audits,
+// are queued and made once the connection is back. Calls supervisor does not make are passed on as they are.
+type ResilientAPI struct {
+	APIInterface
+
+	// RetryAttempts is how often a call is attempted, DefaultRetryAttempts if zero
+	RetryAttempts int
+	// RetryDelay is the delay before the first retry, DefaultRetryDelay if zero
+	RetryDelay time.Duration
+	// OnStateChange is called with nil once the connection is back and with the error once it is lost, if set
+	OnStateChange func(err error)
+	// OnCall is called after every attempt of a call, if set
+	OnCall func(method FunctionName, duration time.Duration, err error)
+
+	mu      sync.Mutex
+	offline bool
+	budget  float64
+	queue   []queuedCall
+}
+
+type queuedCall struct {
+	method FunctionName
+	// latestOnly is true if only the latest call of the method is made
+	latestOnly bool
+	call       func(ctx context.Context) error
+}
+
+// ConnectResilient establishes a websocket connection to the server, like ConnectToServer, and wraps it
+func ConnectResilient(endpoint string, opts ConnectToServerOpts) (*ResilientAPI, error) {
+	res := &ResilientAPI{OnStateChange: opts.OnStateChange, budget: retryBudget}
+	opts.OnStateChange = res.StateChanged
+	gp, err := ConnectToServer(endpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+	res.APIInterface = gp
+	return res, nil
+}
+
+// NewResilientAPI wraps an API. Its connection state has to be reported with StateChanged.
+func NewResilientAPI(api APIInterface) *ResilientAPI {
+	return &ResilientAPI{APIInterface: api, budget: retryBudget}
+}
+
+// Offline returns true while the connection to the server is lost
+func (r *ResilientAPI) Offline() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.offline
+}
+
+// StateChanged reports the connection state, i.e. nil once connected and the error if the connection failed or broke.
+// The calls queued while offline are made once the connection is back.
+func (r *ResilientAPI) StateChanged(err error) {
+	r.mu.Lock()
+	wasOffline := r.offline
+	r.offline = err != nil
+	var queue []queuedCall
+	if !r.offline {
+		queue, r.queue = r.queue, nil
+	}
+	r.mu.Unlock()
+
+	if r.offline != wasOffline {
+		if r.offline {
+			log.WithError(err).Warn("Gitpod server is unreachable, going offline")
+		} else {
+			log.WithField("queued", len(queue)).Info("Gitpod server is reachable again")
+		}
+	}
+	if len(queue) > 0 {
+		go r.flush(queue)
+	}
+	if r.OnStateChange != nil {
+		r.OnStateChange(err)
+	}
+}
+
+func (r *ResilientAPI) flush(queue []queuedCall) {
+	for i, c := range queue {
+		ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+		err := r.retry(ctx, c.method, c.call)
+		cancel()
+		if xerrors.Is(err, ErrOffline) {
+			// the connection broke again - the rest waits for the next connection, ahead of calls queued since
+			r.mu.Lock()
+			r.queue = append(append([]queuedCall(nil), queue[i:]...), r.queue...)
+			if len(r.queue) > maxQueuedCalls {
+				r.queue = r.queue[len(r.queue)-maxQueuedCalls:]
+			}
+			r.mu.Unlock()
+			return
+		}
+		if err != nil {
+			log.WithError(err).Warn("cannot make queued call to the Gitpod server")
+		}
+	}
+}
+
+// enqueue queues a call until the connection is back. It returns false if the server is reachable.
+func (r *ResilientAPI) enqueue(method FunctionName, latestOnly bool, call func(ctx context.Context) error) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.offline {
+		return false
+	}
+
+	if latestOnly {
+		for i, c := range r.queue {
+			if c.method == method {
+				r.queue = append(r.queue[:i], r.queue[i+1:]...)
+				break
+			}
+		}
+	}
+	if len(r.queue) >= maxQueuedCalls {
+		r.queue = r.queue[1:]
+	}
+	r.queue = append(r.queue, queuedCall{method: method, latestOnly: latestOnly, call: call})
+	return true
+}
+
+// retry makes an essential call. It fails with ErrOffline while offline and retries if the server did not answer.
+func (r *ResilientAPI) retry(ctx context.Context, method FunctionName, call func(ctx context.Context) error) error {
+	attempts := r.RetryAttempts
+	if attempts == 0 {
+		attempts = DefaultRetryAttempts
+	}
+	delay := r.RetryDelay
+	if delay == 0 {
+		delay = DefaultRetryDelay
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if !r.spendRetry() {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+		if r.Offline() {
+			return ErrOffline
+		}
+
+		start := time.Now()
+		err = call(ctx)
+		if r.OnCall != nil {
+			r.OnCall(method, time.Since(start), err)
+		}
+		if _, answered := err.(*jsonrpc2.Error); err == nil || answered || ctx.Err() != nil {
+			if err == nil && attempt == 0 {
+				r.earnRetry()
+			}
+			return err
+		}
+	}
+	return err
+}
+
+func (r *ResilientAPI) spendRetry() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.budget < 1 {
+		return false
+	}
+	r.budget--
+	return true
+}
+
+func (r *ResilientAPI) earnRetry() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.budget += retryBudgetRefill
+	if r.budget > retryBudget {
+		r.budget = retryBudget
+	}
+}
+
+// SendHeartBeat sends a heartbeat. While offline, the latest heartbeat is sent once the connection is back.
+func (r *ResilientAPI) SendHeartBeat(ctx context.Context, options *SendHeartBeatOptions) (err error) {
+	call := func(ctx context.Context) error {
+		return r.APIInterface.SendHeartBeat(ctx, options)
+	}
+	if r.enqueue(FunctionSendHeartBeat, true, call) {
+		return nil
+	}
+	return r.retry(ctx, FunctionSendHeartBeat, call)
+}
+
+// AuditPortExposure reports a public port. While offline, the report is sent once the connection is back.
+func (r *ResilientAPI) AuditPortExposure(ctx context.Context, workspaceID string, record *PortExposureAuditRecord) (err error) {
+	call := func(ctx context.Context) error {
+		return r.APIInterface.AuditPortExposure(ctx, workspaceID, record)
+	}
+	if r.enqueue(FunctionAuditPortExposure, false, call) {
+		return nil
+	}
+	return r.retry(ctx, FunctionAuditPortExposure, call)
+}
+
+// GetToken calls getToken on the server
+func (r *ResilientAPI) GetToken(ctx context.Context, query *GetTokenSearchOptions) (res *Token, err error) {
+	err = r.retry(ctx, FunctionGetToken, func(ctx context.Context) (err error) {
+		res, err = r.APIInterface.GetToken(ctx, query)
+		return err
+	})
+	return
+}
+
+// GetWorkspace calls getWorkspace on the server
+func (r *ResilientAPI) GetWorkspace(ctx context.Context, id string) (res *WorkspaceInfo, err error) {
+	err = r.retry(ctx, FunctionGetWorkspace, func(ctx context.Context) (err error) {
+		res, err = r.APIInterface.GetWorkspace(ctx, id)
+		return err
+	})
+	return
+}
+
+// GetWorkspaceTimeout calls getWorkspaceTimeout on the server
+func (r *ResilientAPI) GetWorkspaceTimeout(ctx context.Context, workspaceID string) (res *GetWorkspaceTimeoutResult, err error) {
+	err = r.retry(ctx, FunctionGetWorkspaceTimeout, func(ctx context.Context) (err error) {
+		res, err = r.APIInterface.GetWorkspaceTimeout(ctx, workspaceID)
+		return err
+	})
+	return
+}
+
+// SetWorkspaceTimeout calls setWorkspaceTimeout on the server
+func (r *ResilientAPI) SetWorkspaceTimeout(ctx context.Context, workspaceID string, duration *WorkspaceTimeoutDuration) (res *SetWorkspaceTimeoutResult, err error) {
+	err = r.retry(ctx, FunctionSetWorkspaceTimeout, func(ctx context.Context) (err error) {
+		res, err = r.APIInterface.SetWorkspaceTimeout(ctx, workspaceID, duration)
+		return err
+	})
+	return
+}
+
+// OpenPort calls openPort on the server
+func (r *ResilientAPI) OpenPort(ctx context.Context, workspaceID string, port *WorkspaceInstancePort) (res *WorkspaceInstancePort, err error) {
+	err = r.retry(ctx, FunctionOpenPort, func(ctx context.Context) (err error) {
+		res, err = r.APIInterface.OpenPort(ctx, workspaceID, port)
+		return err
+	})
+	return
+}
+
+// ClosePort calls closePort on the server
+func (r *ResilientAPI) ClosePort(ctx context.Context, workspaceID string, port float32) (err error) {
+	return r.retry(ctx, FunctionClosePort, func(ctx context.Context) error {
+		return r.APIInterface.ClosePort(ctx, workspaceID, port)
+	})
+}
+
+// GetEnvVars calls getEnvVars on the server
+func (r *ResilientAPI) GetEnvVars(ctx context.Context) (res []*UserEnvVarValue, err error) {
+	err = r.retry(ctx, FunctionGetEnvVars, func(ctx context.Context) (err error) {
+		res, err = r.APIInterface.GetEnvVars(ctx)
+		return err
+	})
+	return
+}
+
+// SetEnvVar calls setEnvVar on the server
+func (r *ResilientAPI) SetEnvVar(ctx context.Context, variable *UserEnvVarValue) (err error) {
+	return r.retry(ctx, FunctionSetEnvVar, func(ctx context.Context) error {
+		return r.APIInterface.SetEnvVar(ctx, variable)
+	})
+}
+
+// DeleteEnvVar calls deleteEnvVar on the server
+func (r *ResilientAPI) DeleteEnvVar(ctx context.Context, variable *UserEnvVarValue) (err error) {
+	return r.retry(ctx, FunctionDeleteEnvVar, func(ctx context.Context) error {
+		return r.APIInterface.DeleteEnvVar(ctx, variable)
+	})
+}
+
+// GetSSHPublicKeys calls getSSHPublicKeys on the server
+func (r *ResilientAPI) GetSSHPublicKeys(ctx context.Context) (res []string, err error) {
+	err = r.retry(ctx, FunctionGetSSHPublicKeys, func(ctx context.Context) (err error) {
+		res, err = r.APIInterface.GetSSHPublicKeys(ctx)
+		return err
+	})
+	return
+}
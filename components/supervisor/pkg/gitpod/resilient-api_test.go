@@ -0,0 +1,110 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package gitpod
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sourcegraph/jsonrpc2"
+	"golang.org/x/xerrors"
+)
+
+func TestResilientAPIRetry(t *testing.T) {
+	unanswered := xerrors.New("connection reset")
+	rejected := &jsonrpc2.Error{Code: 403, Message: "forbidden"}
+
+	tests := []struct {
+		Desc     string
+		Errors   []error
+		Budget   float64
+		Attempts int
+		Err      error
+	}{
+		{Desc: "success", Errors: []error{nil}, Budget: retryBudget, Attempts: 1},
+		{Desc: "retried", Errors: []error{unanswered, unanswered, nil}, Budget: retryBudget, Attempts: 3},
+		{Desc: "attempts exhausted", Errors: []error{unanswered, unanswered, unanswered}, Budget: retryBudget, Attempts: 3, Err: unanswered},
+		{Desc: "rejected calls are not retried", Errors: []error{rejected}, Budget: retryBudget, Attempts: 1, Err: rejected},
+		{Desc: "budget exhausted", Errors: []error{unanswered, unanswered}, Budget: 1.5, Attempts: 2, Err: unanswered},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			r := NewResilientAPI(nil)
+			r.RetryDelay = time.Millisecond
+			r.budget = test.Budget
+
+			var attempts int
+			err := r.retry(context.Background(), FunctionGetWorkspace, func(ctx context.Context) error {
+				err := test.Errors[attempts]
+				attempts++
+				return err
+			})
+			if err != test.Err {
+				t.Errorf("unexpected error: %v, expected %v", err, test.Err)
+			}
+			if attempts != test.Attempts {
+				t.Errorf("unexpected attempts: %d, expected %d", attempts, test.Attempts)
+			}
+		})
+	}
+}
+
+func TestResilientAPIOffline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	api := NewMockAPIInterface(ctrl)
+
+	var states []error
+	r := NewResilientAPI(api)
+	r.OnStateChange = func(err error) { states = append(states, err) }
+	r.StateChanged(xerrors.New("connection refused"))
+	if !r.Offline() {
+		t.Fatal("expected to be offline")
+	}
+
+	// essential calls fail right away
+	_, err := r.GetSSHPublicKeys(context.Background())
+	if err != ErrOffline {
+		t.Errorf("unexpected error while offline: %v", err)
+	}
+
+	// non-essential calls are queued, of heartbeats only the latest
+	errs := []error{
+		r.SendHeartBeat(context.Background(), &SendHeartBeatOptions{InstanceID: "a"}),
+		r.AuditPortExposure(context.Background(), "ws", &PortExposureAuditRecord{Port: 3000}),
+		r.SendHeartBeat(context.Background(), &SendHeartBeatOptions{InstanceID: "b"}),
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("expected queued call to succeed: %v", err)
+		}
+	}
+
+	flushed := make(chan struct{})
+	gomock.InOrder(
+		api.EXPECT().AuditPortExposure(gomock.Any(), "ws", &PortExposureAuditRecord{Port: 3000}).Return(nil),
+		api.EXPECT().SendHeartBeat(gomock.Any(), &SendHeartBeatOptions{InstanceID: "b"}).DoAndReturn(func(ctx context.Context, options *SendHeartBeatOptions) error {
+			close(flushed)
+			return nil
+		}),
+	)
+	r.StateChanged(nil)
+	select {
+	case <-flushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued calls were not made")
+	}
+
+	api.EXPECT().GetSSHPublicKeys(gomock.Any()).Return([]string{"key"}, nil)
+	keys, err := r.GetSSHPublicKeys(context.Background())
+	if err != nil || len(keys) != 1 {
+		t.Errorf("unexpected result once online: %v, %v", keys, err)
+	}
+	if len(states) != 2 || states[0] == nil || states[1] != nil {
+		t.Errorf("unexpected state changes: %v", states)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package logging
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// SubsystemField is the log field name of the subsystem of supervisor which logged an entry
+const SubsystemField = "subsystem"
+
+// ErrUnknownSubsystem is returned when setting the level of a subsystem which does not exist
+var ErrUnknownSubsystem = xerrors.New("unknown subsystem")
+
+type subsystem struct {
+	logger *logrus.Logger
+	entry  *logrus.Entry
+	// level is the level the subsystem logs with, or nil if it logs with the level of supervisor
+	level *logrus.Level
+}
+
+var (
+	mu         sync.Mutex
+	subsystems = make(map[string]*subsystem)
+)
+
+// Subsystem returns the logger of a subsystem of supervisor, e.g. "ports". Its entries carry the subsystem field
+// and are written like the entries of the application wide logger, but the level of each subsystem can be changed
+// on its own.
+func Subsystem(name string) *logrus.Entry {
+	mu.Lock()
+	defer mu.Unlock()
+	if s, exists := subsystems[name]; exists {
+		return s.entry
+	}
+
+	root := log.Log.Logger
+	logger := &logrus.Logger{
+		Out:       rootWriter{},
+		Formatter: rootFormatter{},
+		// hooks added to the application wide logger later on apply to the subsystems too
+		Hooks: root.Hooks,
+		Level: root.GetLevel(),
+	}
+	subsystems[name] = &subsystem{
+		logger: logger,
+		entry:  logger.WithField(SubsystemField, name),
+	}
+	return subsystems[name].entry
+}
+
+// SetLevel sets the level of a subsystem. The empty level makes the subsystem log with the level of supervisor again.
+func SetLevel(name, level string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	s, exists := subsystems[name]
+	if !exists {
+		return xerrors.Errorf("%w: %s", ErrUnknownSubsystem, name)
+	}
+	if level == "" {
+		s.level = nil
+		s.logger.SetLevel(log.Log.Logger.GetLevel())
+		return nil
+	}
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	s.level = &lvl
+	s.logger.SetLevel(lvl)
+	return nil
+}
+
+// SetDefaultLevel sets the level of supervisor and of the subsystems which log with it
+func SetDefaultLevel(lvl logrus.Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	log.Log.Logger.SetLevel(lvl)
+	for _, s := range subsystems {
+		if s.level == nil {
+			s.logger.SetLevel(lvl)
+		}
+	}
+}
+
+// SubsystemLevel is the level of a subsystem
+type SubsystemLevel struct {
+	Name  string
+	Level logrus.Level
+	// Own is true if the level was set for the subsystem, rather than being the level of supervisor
+	Own bool
+}
+
+// Levels returns the levels of all subsystems, sorted by their name
+func Levels() []SubsystemLevel {
+	mu.Lock()
+	defer mu.Unlock()
+	res := make([]SubsystemLevel, 0, len(subsystems))
+	for name, s := range subsystems {
+		res = append(res, SubsystemLevel{Name: name, Level: s.logger.GetLevel(), Own: s.level != nil})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// rootWriter writes to the output of the application wide logger, which may change after a subsystem was created
+type rootWriter struct{}
+
+func (rootWriter) Write(p []byte) (int, error) {
+	return log.Log.Logger.Out.Write(p)
+}
+
+// rootFormatter formats entries with the formatter and the fields of the application wide logger,
+// both of which are set up once the subsystems were created already
+type rootFormatter struct{}
+
+func (rootFormatter) Format(e *logrus.Entry) ([]byte, error) {
+	root := log.Log
+	data := make(logrus.Fields, len(root.Data)+len(e.Data))
+	for k, v := range root.Data {
+		data[k] = v
+	}
+	for k, v := range e.Data {
+		data[k] = v
+	}
+	entry := *e
+	entry.Data = data
+	return root.Logger.Formatter.Format(&entry)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestSubsystem(t *testing.T) {
+	ports := Subsystem("ports")
+	tasks := Subsystem("tasks")
+	if Subsystem("ports") != ports {
+		t.Error("subsystems are not reused")
+	}
+
+	// the application wide logger is set up after the subsystems were created
+	var buf bytes.Buffer
+	root, out, formatter, level := log.Log, log.Log.Logger.Out, log.Log.Logger.Formatter, log.Log.Logger.GetLevel()
+	defer func() {
+		log.Log = root
+		log.Log.Logger.SetOutput(out)
+		log.Log.Logger.SetFormatter(formatter)
+		SetDefaultLevel(level)
+	}()
+	log.Log = log.Log.WithField(log.WorkspaceField, "ws-1")
+	log.Log.Logger.SetOutput(&buf)
+	log.Log.Logger.SetFormatter(&logrus.JSONFormatter{})
+	SetDefaultLevel(logrus.InfoLevel)
+
+	err := SetLevel("ports", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports.WithField("port", 3000).Debug("port debug")
+	tasks.Debug("tasks debug")
+	tasks.Info("tasks info")
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		err := json.Unmarshal([]byte(line), &entry)
+		if err != nil {
+			t.Fatalf("cannot parse %q: %v", line, err)
+		}
+		delete(entry, "time")
+		entries = append(entries, entry)
+	}
+	if diff := cmp.Diff([]map[string]interface{}{
+		{"level": "debug", "msg": "port debug", "port": float64(3000), "subsystem": "ports", "workspaceId": "ws-1"},
+		{"level": "info", "msg": "tasks info", "subsystem": "tasks", "workspaceId": "ws-1"},
+	}, entries); diff != "" {
+		t.Errorf("unexpected log entries (-want +got):\n%s", diff)
+	}
+
+	SetDefaultLevel(logrus.WarnLevel)
+	if diff := cmp.Diff([]SubsystemLevel{
+		{Name: "ports", Level: logrus.DebugLevel, Own: true},
+		{Name: "tasks", Level: logrus.WarnLevel},
+	}, Levels()); diff != "" {
+		t.Errorf("unexpected levels (-want +got):\n%s", diff)
+	}
+
+	err = SetLevel("ports", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lvl := ports.Logger.GetLevel(); lvl != logrus.WarnLevel {
+		t.Errorf("unexpected level after reset: %v", lvl)
+	}
+
+	if err := SetLevel("unknown", "debug"); err == nil {
+		t.Error("expected an error for an unknown subsystem")
+	}
+	if err := SetLevel("tasks", "nonsense"); err == nil {
+		t.Error("expected an error for an invalid level")
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// trafficInterval is how often traffic and connections are checked for if no interval is configured
+	trafficInterval = 30 * time.Second
+	// tcpEstablished is the state of established connections in /proc/net/tcp
+	tcpEstablished = "01"
+)
+
+// trafficActivity records when ports configured with keepAlive last saw traffic
+type trafficActivity struct {
+	mu      sync.Mutex
+	configs *Configs
+	last    time.Time
+	now     func() time.Time
+}
+
+// setConfigs updates which ports count traffic as activity
+func (a *trafficActivity) setConfigs(configs *Configs) {
+	a.mu.Lock()
+	a.configs = configs
+	a.mu.Unlock()
+}
+
+// record records traffic on a port if the port is configured with keepAlive
+func (a *trafficActivity) record(port uint32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.keepAlive(port) {
+		return
+	}
+	if a.now == nil {
+		a.now = time.Now
+	}
+	a.last = a.now()
+}
+
+// keepAlive returns true if traffic on the port counts as activity. Callers are expected to hold mu.
+func (a *trafficActivity) keepAlive(port uint32) bool {
+	config, _, exists := a.configs.Get(port)
+	return exists && config.KeepAlive
+}
+
+// since returns true if there was traffic after t
+func (a *trafficActivity) since(t time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.last.After(t)
+}
+
+// TrafficObserver reports activity while ports configured with keepAlive serve traffic, so that the workspace
+// is not stopped by the inactivity timeout, e.g. while the IDE is closed. Requests through localhost proxies are
+// recorded by the port manager, connections to other ports are looked up in /proc.
+type TrafficObserver struct {
+	// OnTraffic is called once per interval in which there was traffic
+	OnTraffic func()
+	// Interval is how often traffic is checked for
+	Interval time.Duration
+
+	fileOpener func(fn string) (io.ReadCloser, error)
+}
+
+// Run observes the traffic on the ports of the port manager until ctx is done
+func (o *TrafficObserver) Run(ctx context.Context, pm *Manager) error {
+	if o.OnTraffic == nil {
+		return xerrors.Errorf("traffic observer requires OnTraffic")
+	}
+	interval := o.Interval
+	if interval == 0 {
+		interval = trafficInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		o.observeConnections(pm)
+		if !pm.traffic.since(last) {
+			continue
+		}
+		last = time.Now()
+		o.OnTraffic()
+	}
+}
+
+// observeConnections records traffic on served ports which have established connections
+func (o *TrafficObserver) observeConnections(pm *Manager) {
+	pm.mu.RLock()
+	served := make(map[uint32]struct{}, len(pm.servedByPort))
+	for port := range pm.servedByPort {
+		served[port] = struct{}{}
+	}
+	pm.mu.RUnlock()
+	if len(served) == 0 {
+		return
+	}
+
+	for _, port := range establishedPorts(o.fileOpener) {
+		if _, exists := served[port]; exists {
+			pm.traffic.record(port)
+		}
+	}
+}
+
+// ConnectionObserver reports activity while fixed ports, e.g. the one of the IDE, have established connections
+type ConnectionObserver struct {
+	Ports []uint32
+	// OnConnection is called once per interval in which any of the ports had an established connection
+	OnConnection func()
+	// Interval is how often connections are checked for
+	Interval time.Duration
+
+	fileOpener func(fn string) (io.ReadCloser, error)
+}
+
+// Run observes the connections until ctx is done
+func (o *ConnectionObserver) Run(ctx context.Context) error {
+	if o.OnConnection == nil {
+		return xerrors.Errorf("connection observer requires OnConnection")
+	}
+	interval := o.Interval
+	if interval == 0 {
+		interval = trafficInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if o.connected() {
+			o.OnConnection()
+		}
+	}
+}
+
+func (o *ConnectionObserver) connected() bool {
+	for _, port := range establishedPorts(o.fileOpener) {
+		for _, p := range o.Ports {
+			if port == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// establishedPorts returns the local ports of all established TCP connections. Files which cannot be read are skipped.
+func establishedPorts(fileOpener func(fn string) (io.ReadCloser, error)) (ports []uint32) {
+	if fileOpener == nil {
+		fileOpener = func(fn string) (io.ReadCloser, error) {
+			return os.Open(fn)
+		}
+	}
+	for _, fn := range []string{fnNetTCP, fnNetTCP6} {
+		fc, err := fileOpener(fn)
+		if err != nil {
+			log.WithError(err).WithField("file", fn).Debug("cannot read connections")
+			continue
+		}
+		p, err := readEstablishedPorts(fc)
+		fc.Close()
+		if err != nil {
+			log.WithError(err).WithField("file", fn).Debug("cannot read connections")
+			continue
+		}
+		ports = append(ports, p...)
+	}
+	return ports
+}
+
+// readEstablishedPorts returns the local ports of the established connections listed in a /proc/net/tcp* file
+func readEstablishedPorts(fc io.Reader) (ports []uint32, err error) {
+	scanner := bufio.NewScanner(fc)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[3] != tcpEstablished {
+			continue
+		}
+		segs := strings.Split(fields[1], ":")
+		if len(segs) < 2 {
+			continue
+		}
+		port, err := strconv.ParseUint(segs[1], 16, 32)
+		if err != nil {
+			continue
+		}
+		ports = append(ports, uint32(port))
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ports, nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testNetTCPConnections = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:0BB8 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 1001 1 0000000000000000 100 0 0 10 0
+   1: 00000000:0FA0 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 1002 1 0000000000000000 100 0 0 10 0
+   2: 0100007F:0BB8 0100007F:D2F0 01 00000000:00000000 00:00000000 00000000 33333        0 1003 1 0000000000000000 20 4 30 10 -1
+   3: 0100007F:D2F0 0100007F:0BB8 01 00000000:00000000 00:00000000 00000000 33333        0 1004 1 0000000000000000 20 4 30 10 -1
+   4: 0100007F:1388 0100007F:D2F2 06 00000000:00000000 03:00000000 00000000     0        0 0 3 0000000000000000
+`
+
+func testKeepAliveConfigs(t *testing.T) *Configs {
+	configs := &Configs{}
+	var diagnostics []*ConfigDiagnostic
+	configs.instancePortConfigs, configs.instanceRangeConfigs, diagnostics = parseInstanceConfigs([]*gitpod.PortsItems{
+		{Port: 3000, KeepAlive: true},
+		{Port: 4000},
+		{Port: "5000-5999", KeepAlive: true},
+	})
+	if len(diagnostics) != 0 {
+		t.Fatalf("unexpected config diagnostics: %v", diagnostics)
+	}
+	return configs
+}
+
+func TestTrafficActivity(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Ports       []uint32
+		Expectation bool
+	}{
+		{Desc: "no traffic"},
+		{Desc: "keep-alive port", Ports: []uint32{3000}, Expectation: true},
+		{Desc: "keep-alive range", Ports: []uint32{5432}, Expectation: true},
+		{Desc: "other configured port", Ports: []uint32{4000}},
+		{Desc: "unconfigured port", Ports: []uint32{8080}},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			now := time.Date(2020, 11, 1, 10, 0, 0, 0, time.UTC)
+			activity := &trafficActivity{now: func() time.Time { return now }}
+			activity.setConfigs(testKeepAliveConfigs(t))
+			for _, port := range test.Ports {
+				activity.record(port)
+			}
+			if act := activity.since(now.Add(-time.Second)); act != test.Expectation {
+				t.Errorf("unexpected activity: want %v, got %v", test.Expectation, act)
+			}
+			if activity.since(now) {
+				t.Errorf("expected no activity after the last traffic")
+			}
+		})
+	}
+}
+
+func TestReadEstablishedPorts(t *testing.T) {
+	act, err := readEstablishedPorts(strings.NewReader(testNetTCPConnections))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]uint32{3000, 54000}, act); diff != "" {
+		t.Errorf("unexpected ports (-want +got):\n%s", diff)
+	}
+}
+
+func TestTrafficObserver(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	pm.mu.Lock()
+	pm.setConfigs(testKeepAliveConfigs(t))
+	pm.setServed([]ServedPort{{Port: 3000}, {Port: 4000}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	pm.mu.Unlock()
+
+	traffic := make(chan struct{}, 1)
+	observer := &TrafficObserver{
+		OnTraffic: func() {
+			select {
+			case traffic <- struct{}{}:
+			default:
+			}
+		},
+		Interval:   10 * time.Millisecond,
+		fileOpener: testConnectionsOpener,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := observer.Run(ctx, pm)
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	select {
+	case <-traffic:
+	case <-time.After(5 * time.Second):
+		t.Error("no traffic was reported for a keep-alive port")
+	}
+	cancel()
+	<-done
+}
+
+func TestConnectionObserver(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Ports       []uint32
+		Expectation bool
+	}{
+		{Desc: "established connection", Ports: []uint32{4000, 3000}, Expectation: true},
+		{Desc: "listening only", Ports: []uint32{4000}},
+		{Desc: "closing connection", Ports: []uint32{5000}},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			observer := &ConnectionObserver{Ports: test.Ports, fileOpener: testConnectionsOpener}
+			if act := observer.connected(); act != test.Expectation {
+				t.Errorf("unexpected connection state: want %v, got %v", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func testConnectionsOpener(fn string) (io.ReadCloser, error) {
+	if fn == fnNetTCP6 {
+		return ioutil.NopCloser(strings.NewReader("")), nil
+	}
+	return ioutil.NopCloser(strings.NewReader(testNetTCPConnections)), nil
+}
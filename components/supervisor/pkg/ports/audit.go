@@ -0,0 +1,115 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// auditAttempts is how often reporting a public exposure is attempted before the record is dropped
+	auditAttempts = 5
+	// auditTimeout limits a single attempt to report a public exposure
+	auditTimeout = 10 * time.Second
+)
+
+// ExposureAuditor reports every port which becomes public, automatically or by the user,
+// to the Gitpod server so that organizations can review what was shared from their workspaces.
+type ExposureAuditor struct {
+	WorkspaceID string
+	InstanceID  string
+	API         gitpod.APIInterface
+
+	now        func() time.Time
+	retryDelay time.Duration
+}
+
+// Run audits the public exposures of the port manager until ctx is done
+func (a *ExposureAuditor) Run(ctx context.Context, pm *Manager) error {
+	if a.API == nil {
+		return xerrors.Errorf("cannot audit public ports without a connection to the Gitpod API")
+	}
+	sub := pm.Events().Subscribe("audit", PortExposedKind, PortVisibilityChangedKind)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-sub.Events():
+			if event == nil {
+				return nil
+			}
+			if record := a.apply(event); record != nil {
+				go a.report(ctx, record)
+			}
+		}
+	}
+}
+
+// apply produces an audit record if the event shows that a port became public, or nil otherwise
+func (a *ExposureAuditor) apply(event Event) *gitpod.PortExposureAuditRecord {
+	var (
+		header EventHeader
+		port   uint32
+		url    string
+	)
+	switch e := event.(type) {
+	case PortExposed:
+		if !e.Public {
+			return nil
+		}
+		header, port, url = e.EventHeader, e.Port, e.URL
+	case PortVisibilityChanged:
+		if !e.Public {
+			return nil
+		}
+		header, port, url = e.EventHeader, e.Port, e.URL
+	default:
+		return nil
+	}
+
+	if a.now == nil {
+		a.now = time.Now
+	}
+	return &gitpod.PortExposureAuditRecord{
+		InstanceID: a.InstanceID,
+		Port:       float64(port),
+		URL:        url,
+		ExposedAt:  a.now().UTC().Format(time.RFC3339),
+		Manual:     header.Trigger == api.PortsUpdateTrigger_manual_action,
+	}
+}
+
+// report sends an audit record to the server, and retries with backoff if that fails
+func (a *ExposureAuditor) report(ctx context.Context, record *gitpod.PortExposureAuditRecord) {
+	delay := a.retryDelay
+	if delay == 0 {
+		delay = 1 * time.Second
+	}
+	var err error
+	for attempt := 0; attempt < auditAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, auditTimeout)
+		err = a.API.AuditPortExposure(reqCtx, a.WorkspaceID, record)
+		cancel()
+		if err == nil {
+			return
+		}
+	}
+	log.WithError(err).WithField("port", record.Port).WithField("url", record.URL).Error("cannot report public port to the audit log")
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExposureAuditorApply(t *testing.T) {
+	header := EventHeader{Trigger: api.PortsUpdateTrigger_served_ports_changed}
+	manual := EventHeader{Trigger: api.PortsUpdateTrigger_manual_action}
+	record := func(port uint32, url string, manual bool) *gitpod.PortExposureAuditRecord {
+		return &gitpod.PortExposureAuditRecord{InstanceID: "instance", Port: float64(port), URL: url, ExposedAt: "2020-11-01T10:00:00Z", Manual: manual}
+	}
+
+	tests := []struct {
+		Desc        string
+		Event       Event
+		Expectation *gitpod.PortExposureAuditRecord
+	}{
+		{
+			Desc:        "auto exposed public port",
+			Event:       PortExposed{EventHeader: header, Port: 3000, URL: "3000-url", Public: true},
+			Expectation: record(3000, "3000-url", false),
+		},
+		{
+			Desc:  "auto exposed private port",
+			Event: PortExposed{EventHeader: header, Port: 4000, URL: "4000-url"},
+		},
+		{
+			Desc:        "manually made public",
+			Event:       PortVisibilityChanged{EventHeader: manual, Port: 4000, URL: "4000-url", Public: true},
+			Expectation: record(4000, "4000-url", true),
+		},
+		{
+			Desc:  "made private",
+			Event: PortVisibilityChanged{EventHeader: manual, Port: 4000, URL: "4000-url"},
+		},
+		{
+			Desc:  "other events",
+			Event: PortServed{EventHeader: header, Port: 5000},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			auditor := &ExposureAuditor{
+				InstanceID: "instance",
+				now:        func() time.Time { return time.Date(2020, 11, 1, 10, 0, 0, 0, time.UTC) },
+			}
+			act := auditor.apply(test.Event)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected record (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExposureAuditorReportRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	record := &gitpod.PortExposureAuditRecord{InstanceID: "instance", Port: 3000, URL: "3000-url"}
+	gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+	gomock.InOrder(
+		gitpodAPI.EXPECT().AuditPortExposure(gomock.Any(), "workspace", record).Return(errors.New("unavailable")),
+		gitpodAPI.EXPECT().AuditPortExposure(gomock.Any(), "workspace", record).Return(nil),
+	)
+
+	auditor := &ExposureAuditor{
+		WorkspaceID: "workspace",
+		InstanceID:  "instance",
+		API:         gitpodAPI,
+		retryDelay:  time.Millisecond,
+	}
+	auditor.report(context.Background(), record)
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v2"
+)
+
+// composePort describes how a Docker Compose service uses a port
+type composePort struct {
+	Service string
+	// Internal is true if the port is only meant for other containers, i.e. it is exposed but not published
+	Internal bool
+}
+
+// ComposeDetector labels ports which are served by Docker Compose services with the service name.
+// Ports are correlated by the compose files in the repository and only labeled if the listening
+// process runs in a container, i.e. while the user runs compose inside the workspace.
+type ComposeDetector struct {
+	files   []string
+	procDir string
+
+	parsed  map[string]*composeFile
+	labeled map[uint64]composePort
+}
+
+type composeFile struct {
+	modTime time.Time
+	ports   map[uint32]composePort
+}
+
+// NewComposeDetector creates a new compose detector which reads the given compose files, e.g. docker-compose.yml
+func NewComposeDetector(files ...string) *ComposeDetector {
+	return &ComposeDetector{
+		files:   files,
+		procDir: "/proc",
+		parsed:  make(map[string]*composeFile),
+		labeled: make(map[uint64]composePort),
+	}
+}
+
+// ComposeFiles lists the compose files docker-compose picks up by default in a directory
+func ComposeFiles(dir string) []string {
+	return []string{
+		filepath.Join(dir, "docker-compose.yml"),
+		filepath.Join(dir, "docker-compose.yaml"),
+		filepath.Join(dir, "compose.yml"),
+		filepath.Join(dir, "compose.yaml"),
+	}
+}
+
+// label sets ComposeService and ComposeInternal of the served ports. The PIDs of the sockets have to be resolved already.
+func (d *ComposeDetector) label(sockets []servedSocket) {
+	var (
+		current   = make(map[uint64]struct{}, len(sockets))
+		undecided []int
+	)
+	for i, socket := range sockets {
+		current[socket.Inode] = struct{}{}
+		port, cached := d.labeled[socket.Inode]
+		if !cached {
+			undecided = append(undecided, i)
+			continue
+		}
+		sockets[i].ComposeService = port.Service
+		sockets[i].ComposeInternal = port.Internal
+	}
+	for inode := range d.labeled {
+		if _, exists := current[inode]; !exists {
+			delete(d.labeled, inode)
+		}
+	}
+	if len(undecided) == 0 {
+		return
+	}
+
+	ports := d.ports()
+	for _, i := range undecided {
+		socket := &sockets[i]
+		var port composePort
+		if p, exists := ports[socket.Port]; exists && socket.PID != 0 && d.inContainer(socket.PID) {
+			port = p
+		}
+		socket.ComposeService = port.Service
+		socket.ComposeInternal = port.Internal
+		d.labeled[socket.Inode] = port
+	}
+}
+
+// ports merges the ports of all compose files, files are only parsed again if they changed
+func (d *ComposeDetector) ports() map[uint32]composePort {
+	res := make(map[uint32]composePort)
+	for _, fn := range d.files {
+		stat, err := os.Stat(fn)
+		if err != nil {
+			delete(d.parsed, fn)
+			continue
+		}
+		parsed, exists := d.parsed[fn]
+		if !exists || !parsed.modTime.Equal(stat.ModTime()) {
+			content, err := ioutil.ReadFile(fn)
+			if err != nil {
+				continue
+			}
+			ports, err := parseComposePorts(content)
+			if err != nil {
+				log.WithError(err).WithField("file", fn).Debug("cannot parse compose file")
+			}
+			parsed = &composeFile{modTime: stat.ModTime(), ports: ports}
+			d.parsed[fn] = parsed
+		}
+		for port, p := range parsed.ports {
+			if _, exists := res[port]; !exists {
+				res[port] = p
+			}
+		}
+	}
+	return res
+}
+
+// inContainer returns true if the process or one of its ancestors is a container runtime process,
+// e.g. docker-proxy forwarding a published port or the containerd shim of a container.
+func (d *ComposeDetector) inContainer(pid int) bool {
+	for _, p := range processAncestry(d.procDir, pid) {
+		comm, err := ioutil.ReadFile(filepath.Join(d.procDir, strconv.Itoa(p), "comm"))
+		if err != nil {
+			continue
+		}
+		name := strings.TrimSpace(string(comm))
+		if name == "docker-proxy" || strings.HasPrefix(name, "containerd-shim") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseComposePorts reads the ports of all services from a compose file. Published ports are mapped
+// by their port on the host, ports which are exposed only are internal.
+func parseComposePorts(content []byte) (map[uint32]composePort, error) {
+	var file struct {
+		Services map[string]struct {
+			NetworkMode string        `yaml:"network_mode"`
+			Ports       []interface{} `yaml:"ports"`
+			Expose      []interface{} `yaml:"expose"`
+		} `yaml:"services"`
+	}
+	err := yaml.Unmarshal(content, &file)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[uint32]composePort)
+	for name, service := range file.Services {
+		for _, expose := range service.Expose {
+			ports, err := parseComposePortRange(strings.Split(fmt.Sprint(expose), "/")[0])
+			if err != nil {
+				continue
+			}
+			for _, port := range ports {
+				if _, exists := res[port]; !exists {
+					res[port] = composePort{Service: name, Internal: true}
+				}
+			}
+		}
+		for _, entry := range service.Ports {
+			var (
+				published []uint32
+				err       error
+			)
+			switch e := entry.(type) {
+			case map[interface{}]interface{}:
+				// long syntax
+				if p, exists := e["published"]; exists {
+					published, err = parseComposePortRange(fmt.Sprint(p))
+				} else if service.NetworkMode == "host" {
+					published, err = parseComposePortRange(fmt.Sprint(e["target"]))
+				}
+			default:
+				// short syntax: [HOST_IP:][HOST_PORT:]CONTAINER_PORT[/PROTOCOL]
+				segs := strings.Split(strings.Split(fmt.Sprint(e), "/")[0], ":")
+				if len(segs) >= 2 {
+					published, err = parseComposePortRange(segs[len(segs)-2])
+				} else if service.NetworkMode == "host" {
+					// the container port is the host port
+					published, err = parseComposePortRange(segs[0])
+				}
+			}
+			if err != nil {
+				continue
+			}
+			for _, port := range published {
+				res[port] = composePort{Service: name}
+			}
+		}
+	}
+	return res, nil
+}
+
+// parseComposePortRange parses a port or a port range like 3000-3005
+func parseComposePortRange(s string) ([]uint32, error) {
+	segs := strings.SplitN(s, "-", 2)
+	start, err := strconv.ParseUint(segs[0], 10, 16)
+	if err != nil {
+		return nil, err
+	}
+	end := start
+	if len(segs) == 2 {
+		end, err = strconv.ParseUint(segs[1], 10, 16)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if start == 0 || end < start {
+		return nil, xerrors.Errorf("invalid port range %q", s)
+	}
+	var res []uint32
+	for p := start; p <= end; p++ {
+		res = append(res, uint32(p))
+	}
+	return res, nil
+}
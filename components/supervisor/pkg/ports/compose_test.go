@@ -0,0 +1,154 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseComposePorts(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Content     string
+		Expectation map[uint32]composePort
+	}{
+		{
+			Desc: "short syntax",
+			Content: `
+services:
+  web:
+    ports:
+      - "3000"
+      - "8080:80"
+      - "127.0.0.1:9229:9229/tcp"
+      - "4000-4001:5000-5001"
+  db:
+    expose:
+      - "5432"
+`,
+			Expectation: map[uint32]composePort{
+				8080: {Service: "web"},
+				9229: {Service: "web"},
+				4000: {Service: "web"},
+				4001: {Service: "web"},
+				5432: {Service: "db", Internal: true},
+			},
+		},
+		{
+			Desc: "long syntax",
+			Content: `
+services:
+  web:
+    ports:
+      - target: 80
+        published: 8080
+      - target: 81
+  cache:
+    expose:
+      - 6379
+    ports:
+      - target: 6379
+        published: "6379"
+`,
+			Expectation: map[uint32]composePort{
+				8080: {Service: "web"},
+				6379: {Service: "cache"},
+			},
+		},
+		{
+			Desc: "host network",
+			Content: `
+services:
+  web:
+    network_mode: host
+    ports:
+      - "3000"
+      - target: 3001
+`,
+			Expectation: map[uint32]composePort{
+				3000: {Service: "web"},
+				3001: {Service: "web"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act, err := parseComposePorts([]byte(test.Content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected ports (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestComposeDetector(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	compose := "services:\n  web:\n    ports:\n      - \"8080:80\"\n  db:\n    network_mode: host\n    expose:\n      - \"5432\"\n"
+	err = ioutil.WriteFile(filepath.Join(dir, "docker-compose.yml"), []byte(compose), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	procDir := filepath.Join(dir, "proc")
+	for _, p := range []struct {
+		PID, PPID int
+		Comm      string
+	}{
+		{PID: 10, PPID: 1, Comm: "docker-proxy"},
+		{PID: 20, PPID: 1, Comm: "containerd-shim-runc-v2"},
+		{PID: 21, PPID: 20, Comm: "postgres"},
+		{PID: 30, PPID: 1, Comm: "node"},
+	} {
+		pidDir := filepath.Join(procDir, fmt.Sprint(p.PID))
+		err := os.MkdirAll(pidDir, 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stat := fmt.Sprintf("%d (%s) S %d %d 0 0 -1 4194560 0 0 0 0\n", p.PID, p.Comm, p.PPID, p.PID)
+		err = ioutil.WriteFile(filepath.Join(pidDir, "stat"), []byte(stat), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(filepath.Join(pidDir, "comm"), []byte(p.Comm+"\n"), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewComposeDetector(ComposeFiles(dir)...)
+	d.procDir = procDir
+	sockets := []servedSocket{
+		{ServedPort: ServedPort{Port: 8080}, Inode: 100, PID: 10},
+		{ServedPort: ServedPort{Port: 5432}, Inode: 101, PID: 21},
+		// the same port served outside of compose
+		{ServedPort: ServedPort{Port: 8080}, Inode: 102, PID: 30},
+		{ServedPort: ServedPort{Port: 3000}, Inode: 103, PID: 30},
+	}
+	d.label(sockets)
+
+	expectation := []servedSocket{
+		{ServedPort: ServedPort{Port: 8080, ComposeService: "web"}, Inode: 100, PID: 10},
+		{ServedPort: ServedPort{Port: 5432, ComposeService: "db", ComposeInternal: true}, Inode: 101, PID: 21},
+		{ServedPort: ServedPort{Port: 8080}, Inode: 102, PID: 30},
+		{ServedPort: ServedPort{Port: 3000}, Inode: 103, PID: 30},
+	}
+	if diff := cmp.Diff(expectation, sockets); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// connectionStatsInterval is how often connection statistics are sampled if no interval is configured
+const connectionStatsInterval = 10 * time.Second
+
+// ConnectionStats are the sampled connection statistics of an exposed port
+type ConnectionStats struct {
+	// Active is the number of established connections to the port
+	Active uint32
+	// Requests is the number of requests through the localhost proxy of the port, if the port is proxied
+	Requests uint64
+}
+
+// requestCounter counts the requests through the localhost proxies
+type requestCounter struct {
+	mu     sync.Mutex
+	counts map[uint32]uint64
+}
+
+// count counts a request to a port
+func (c *requestCounter) count(port uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[uint32]uint64)
+	}
+	c.counts[port]++
+}
+
+// get returns the number of requests to a port
+func (c *requestCounter) get(port uint32) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[port]
+}
+
+// reset starts counting the requests to a port over, e.g. once its proxy is stopped
+func (c *requestCounter) reset(port uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.counts, port)
+}
+
+// ConnectionSampler periodically samples the connection statistics of the exposed ports, which are then
+// reported with the ports status. Established connections are looked up in /proc, requests are counted
+// by the localhost proxies.
+type ConnectionSampler struct {
+	// Interval is how often the statistics are sampled
+	Interval time.Duration
+
+	fileOpener func(fn string) (io.ReadCloser, error)
+}
+
+// Run samples the connection statistics of the ports of the port manager until ctx is done
+func (s *ConnectionSampler) Run(ctx context.Context, pm *Manager) {
+	if s.fileOpener == nil {
+		s.fileOpener = func(fn string) (io.ReadCloser, error) {
+			return os.Open(fn)
+		}
+	}
+	interval := s.Interval
+	if interval == 0 {
+		interval = connectionStatsInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pm.updateConnectionStats(ctx, s.established())
+	}
+}
+
+// established counts the established connections per local port
+func (s *ConnectionSampler) established() map[uint32]uint32 {
+	res := make(map[uint32]uint32)
+	for _, fn := range []string{fnNetTCP, fnNetTCP6} {
+		fc, err := s.fileOpener(fn)
+		if err != nil {
+			log.WithError(err).WithField("file", fn).Debug("cannot read connections")
+			continue
+		}
+		ports, err := readEstablishedPorts(fc)
+		fc.Close()
+		if err != nil {
+			log.WithError(err).WithField("file", fn).Debug("cannot read connections")
+			continue
+		}
+		for _, port := range ports {
+			res[port]++
+		}
+	}
+	return res
+}
+
+// updateConnectionStats updates the connection statistics of the exposed ports from the established connections
+// per local port and publishes those which changed. Connections to proxied ports are counted on the proxy port,
+// since connections from the proxy to the local port stay open across requests.
+func (pm *Manager) updateConnectionStats(ctx context.Context, established map[uint32]uint32) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	changed := false
+	for port := range pm.connectionStats {
+		if mp, exists := pm.state[port]; !exists || !mp.Exposed {
+			delete(pm.connectionStats, port)
+			pm.markDirty(port)
+			changed = true
+		}
+	}
+	for port, mp := range pm.state {
+		if !mp.Exposed {
+			continue
+		}
+		next := ConnectionStats{Active: established[port]}
+		if proxy, proxied := pm.proxies[port]; proxied {
+			next.Active = established[proxy.proxyPort]
+			next.Requests = pm.requests.get(port)
+		}
+		if prev, exists := pm.connectionStats[port]; exists && prev == next {
+			continue
+		}
+		pm.connectionStats[port] = next
+		pm.markDirty(port)
+		changed = true
+	}
+	if changed {
+		pm.updateState(ctx, api.PortsUpdateTrigger_connection_stats_changed)
+	}
+}
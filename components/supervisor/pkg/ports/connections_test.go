@@ -0,0 +1,92 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConnectionSamplerEstablished(t *testing.T) {
+	sampler := &ConnectionSampler{
+		fileOpener: func(fn string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(testNetTCPConnections)), nil
+		},
+	}
+	if diff := cmp.Diff(map[uint32]uint32{3000: 2, 54000: 2}, sampler.established()); diff != "" {
+		t.Errorf("unexpected established connections (-want +got):\n%s", diff)
+	}
+}
+
+func TestConnectionStats(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	pm.proxyStarter = func(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (io.Closer, error) {
+		return ioutil.NopCloser(nil), nil
+	}
+	sub := pm.Subscribe("test")
+	defer sub.Close()
+	// skip the snapshot of the yet empty state
+	<-sub.Updates()
+
+	pm.mu.Lock()
+	pm.setServed([]ServedPort{{Port: 3000}, {Port: 4000, BoundToLocalhost: true}, {Port: 5000}})
+	pm.updateProxies()
+	pm.setExposed([]ExposedPort{
+		{LocalPort: 3000, GlobalPort: 3000, URL: "3000-url"},
+		{LocalPort: 4000, GlobalPort: 60000, URL: "4000-url"},
+	})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_exposed_ports_changed)
+	pm.mu.Unlock()
+	<-sub.Updates()
+
+	for i := 0; i < 3; i++ {
+		pm.requests.count(4000)
+	}
+	connections := func() map[uint32]*api.PortsStatus_ConnectionStats {
+		res := make(map[uint32]*api.PortsStatus_ConnectionStats)
+		for _, status := range pm.Status() {
+			res[status.LocalPort] = status.Connections
+		}
+		return res
+	}
+
+	pm.updateConnectionStats(context.Background(), map[uint32]uint32{3000: 2, 4000: 5, 5000: 1, 60000: 1})
+	update := <-sub.Updates()
+	if update.Trigger != api.PortsUpdateTrigger_connection_stats_changed {
+		t.Errorf("unexpected trigger: %v", update.Trigger)
+	}
+	expectation := map[uint32]*api.PortsStatus_ConnectionStats{
+		3000: {Active: 2},
+		// connections of proxied ports are counted on the proxy port
+		4000: {Active: 1, Requests: 3},
+		// ports which are not exposed have no statistics
+		5000: nil,
+	}
+	if diff := cmp.Diff(expectation, connections()); diff != "" {
+		t.Errorf("unexpected connection stats (-want +got):\n%s", diff)
+	}
+
+	revision := pm.Revision()
+	pm.updateConnectionStats(context.Background(), map[uint32]uint32{3000: 2, 4000: 5, 5000: 1, 60000: 1})
+	if pm.Revision() != revision {
+		t.Error("expected no update if the connection stats did not change")
+	}
+
+	pm.mu.Lock()
+	pm.setExposed([]ExposedPort{{LocalPort: 4000, GlobalPort: 60000, URL: "4000-url"}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_exposed_ports_changed)
+	pm.mu.Unlock()
+	pm.updateConnectionStats(context.Background(), map[uint32]uint32{3000: 2, 60000: 1})
+	if stats := connections()[3000]; stats != nil {
+		t.Errorf("unexpected connection stats of a port which is no longer exposed: %v", stats)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DebuggerNodeInspector is the Node.js inspector, i.e. the Chrome DevTools protocol
+	DebuggerNodeInspector = "node-inspector"
+	// DebuggerJDWP is the Java Debug Wire Protocol
+	DebuggerJDWP = "jdwp"
+	// DebuggerDelve is the API of the headless Go debugger delve
+	DebuggerDelve = "delve"
+
+	// nodeInspectorPort is the port node listens on with --inspect if no port is given
+	nodeInspectorPort = 9229
+)
+
+// DebuggerDetector detects ports which speak a debugger protocol. Debug ports are recognized by the command line
+// of the process owning the listening socket, the Node.js inspector is confirmed by its HTTP discovery endpoint.
+// The result is cached per socket.
+type DebuggerDetector struct {
+	procDir string
+	client  *http.Client
+
+	detected map[uint64]debugPort
+}
+
+type debugPort struct {
+	Debugger string
+	URL      string
+}
+
+// NewDebuggerDetector creates a new debugger detector
+func NewDebuggerDetector() *DebuggerDetector {
+	return &DebuggerDetector{
+		procDir: "/proc",
+		client: &http.Client{
+			Timeout: 1 * time.Second,
+		},
+		detected: make(map[uint64]debugPort),
+	}
+}
+
+// detect sets Debugger and DebugURL of the served ports. The PIDs of the sockets have to be resolved already.
+func (d *DebuggerDetector) detect(sockets []servedSocket) {
+	current := make(map[uint64]struct{}, len(sockets))
+	for i := range sockets {
+		socket := &sockets[i]
+		current[socket.Inode] = struct{}{}
+		port, cached := d.detected[socket.Inode]
+		if !cached {
+			port = d.detectPort(socket)
+			d.detected[socket.Inode] = port
+		}
+		socket.Debugger = port.Debugger
+		socket.DebugURL = port.URL
+	}
+	for inode := range d.detected {
+		if _, exists := current[inode]; !exists {
+			delete(d.detected, inode)
+		}
+	}
+}
+
+func (d *DebuggerDetector) detectPort(socket *servedSocket) debugPort {
+	var args []string
+	if socket.PID != 0 {
+		cmdline, err := ioutil.ReadFile(filepath.Join(d.procDir, strconv.Itoa(socket.PID), "cmdline"))
+		if err == nil {
+			args = strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+		}
+	}
+
+	debugger := debuggerOf(args, socket.Port)
+	if debugger == "" && socket.PID == 0 && socket.Port == nodeInspectorPort {
+		// the owner is unknown, e.g. the process runs as another user, but the port is the inspector's default
+		debugger = DebuggerNodeInspector
+	}
+	switch debugger {
+	case DebuggerNodeInspector:
+		// node processes with --inspect serve the application as well, only the inspector has a discovery endpoint
+		url := d.nodeInspectorURL(socket.Port)
+		if url == "" {
+			return debugPort{}
+		}
+		return debugPort{Debugger: debugger, URL: url}
+	case DebuggerJDWP, DebuggerDelve:
+		return debugPort{Debugger: debugger, URL: fmt.Sprintf("localhost:%d", socket.Port)}
+	}
+	return debugPort{}
+}
+
+// debuggerOf returns the debugger protocol the process may speak on the port judging by its command line
+func debuggerOf(args []string, port uint32) string {
+	for i := 0; i < len(args) && i < maxCmdlineArgs; i++ {
+		switch filepath.Base(args[i]) {
+		case "node", "nodejs", "deno":
+			for _, flag := range args[i+1:] {
+				if strings.HasPrefix(flag, "--inspect") {
+					return DebuggerNodeInspector
+				}
+			}
+		case "dlv":
+			// the debuggee is a child process, hence all ports of delve itself are its API
+			return DebuggerDelve
+		case "java":
+			for _, flag := range args[i+1:] {
+				if jdwpPort(flag) == port {
+					return DebuggerJDWP
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// jdwpPort parses the port of a JDWP agent option, e.g. -agentlib:jdwp=transport=dt_socket,server=y,address=*:5005
+func jdwpPort(flag string) uint32 {
+	var opts string
+	switch {
+	case strings.HasPrefix(flag, "-agentlib:jdwp="):
+		opts = strings.TrimPrefix(flag, "-agentlib:jdwp=")
+	case strings.HasPrefix(flag, "-Xrunjdwp:"):
+		opts = strings.TrimPrefix(flag, "-Xrunjdwp:")
+	default:
+		return 0
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		if !strings.HasPrefix(opt, "address=") {
+			continue
+		}
+		address := strings.TrimPrefix(opt, "address=")
+		if i := strings.LastIndex(address, ":"); i >= 0 {
+			address = address[i+1:]
+		}
+		port, err := strconv.ParseUint(address, 10, 16)
+		if err != nil {
+			return 0
+		}
+		return uint32(port)
+	}
+	return 0
+}
+
+// nodeInspectorURL asks the inspector for its debug target and produces the URL DevTools attach with.
+// Returns an empty string if the port is not an inspector.
+func (d *DebuggerDetector) nodeInspectorURL(port uint32) string {
+	resp, err := d.client.Get(fmt.Sprintf("http://localhost:%d/json/list", port))
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	var targets []struct {
+		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+	}
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxFingerprintBody)).Decode(&targets)
+	if err != nil || len(targets) == 0 || targets[0].WebSocketDebuggerURL == "" {
+		return ""
+	}
+	return "devtools://devtools/bundled/js_app.html?experiments=true&v8only=true&ws=" + strings.TrimPrefix(targets[0].WebSocketDebuggerURL, "ws://")
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDebuggerOf(t *testing.T) {
+	tests := []struct {
+		Args        []string
+		Port        uint32
+		Expectation string
+	}{
+		{Args: []string{"node", "--inspect", "server.js"}, Port: 9229, Expectation: DebuggerNodeInspector},
+		{Args: []string{"/usr/bin/node", "--inspect-brk=0.0.0.0:9230", "server.js"}, Port: 9230, Expectation: DebuggerNodeInspector},
+		{Args: []string{"node", "server.js"}, Port: 9229},
+		{Args: []string{"java", "-Xmx1g", "-agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:5005", "-jar", "app.jar"}, Port: 5005, Expectation: DebuggerJDWP},
+		{Args: []string{"java", "-Xrunjdwp:transport=dt_socket,server=y,address=8000", "Main"}, Port: 8000, Expectation: DebuggerJDWP},
+		{Args: []string{"java", "-agentlib:jdwp=transport=dt_socket,server=y,address=*:5005", "-jar", "app.jar"}, Port: 8080},
+		{Args: []string{"/go/bin/dlv", "debug", "--headless", "--listen=:2345"}, Port: 2345, Expectation: DebuggerDelve},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.Args, " "), func(t *testing.T) {
+			act := debuggerOf(test.Args, test.Port)
+			if act != test.Expectation {
+				t.Errorf("unexpected debugger: want %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestDebuggerDetector(t *testing.T) {
+	inspector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/list" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"id": "abc", "type": "node", "webSocketDebuggerUrl": "ws://127.0.0.1:9229/abc"}]`))
+	}))
+	defer inspector.Close()
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello world"))
+	}))
+	defer app.Close()
+	inspectorPort := uint32(inspector.Listener.Addr().(*net.TCPAddr).Port)
+	appPort := uint32(app.Listener.Addr().(*net.TCPAddr).Port)
+
+	procDir, err := ioutil.TempDir("", "proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(procDir)
+	for pid, args := range map[string][]string{
+		"42": {"node", "--inspect", "server.js"},
+		"43": {"java", "-agentlib:jdwp=transport=dt_socket,server=y,address=5005", "Main"},
+	} {
+		err := os.MkdirAll(filepath.Join(procDir, pid), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(filepath.Join(procDir, pid, "cmdline"), []byte(strings.Join(args, "\x00")+"\x00"), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sockets := []servedSocket{
+		{ServedPort: ServedPort{Port: inspectorPort}, Inode: 100, PID: 42},
+		{ServedPort: ServedPort{Port: appPort}, Inode: 101, PID: 42},
+		{ServedPort: ServedPort{Port: 5005}, Inode: 102, PID: 43},
+		{ServedPort: ServedPort{Port: 8080}, Inode: 103, PID: 43},
+	}
+	detector := NewDebuggerDetector()
+	detector.procDir = procDir
+	detector.client.Timeout = 5 * time.Second
+	detector.detect(sockets)
+
+	var act [][2]string
+	for _, s := range sockets {
+		act = append(act, [2]string{s.Debugger, s.DebugURL})
+	}
+	expectation := [][2]string{
+		{DebuggerNodeInspector, "devtools://devtools/bundled/js_app.html?experiments=true&v8only=true&ws=127.0.0.1:9229/abc"},
+		{"", ""},
+		{DebuggerJDWP, "localhost:5005"},
+		{"", ""},
+	}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+// PortRange is an inclusive range of ports
+type PortRange struct {
+	Start uint32
+	End   uint32
+}
+
+// Denylist lists ports which are never exposed or proxied
+type Denylist []PortRange
+
+// Contains returns true if the port is denied
+func (d Denylist) Contains(port uint32) bool {
+	for _, r := range d {
+		if r.Start <= port && port <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDenylist parses a denylist of ports and port ranges, e.g. 22 or 6000-6100
+func ParseDenylist(entries []string) (Denylist, error) {
+	var res Denylist
+	for _, entry := range entries {
+		r, err := parsePortRange(entry)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid denied port %q: %w", entry, err)
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// parseConfigDenylist parses the denylist of a ports policy. Invalid entries are skipped and reported as diagnostics.
+func parseConfigDenylist(source ConfigSource, entries []interface{}) (res Denylist, diagnostics []*ConfigDiagnostic) {
+	for _, entry := range entries {
+		rawPort := fmt.Sprintf("%v", entry)
+		r, err := parsePortRange(rawPort)
+		if err != nil {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  source,
+				Port:    rawPort,
+				Message: fmt.Sprintf("ignoring denied port: %s", err),
+			})
+			continue
+		}
+		res = append(res, r)
+	}
+	return res, diagnostics
+}
+
+func parsePortRange(s string) (PortRange, error) {
+	if port, err := strconv.Atoi(s); err == nil {
+		if !isValidPort(port) {
+			return PortRange{}, xerrors.Errorf("invalid port, must be a number between 1 and %d", math.MaxUint16)
+		}
+		return PortRange{Start: uint32(port), End: uint32(port)}, nil
+	}
+	matches := portRangeRegexp.FindStringSubmatch(s)
+	if len(matches) != 3 {
+		return PortRange{}, xerrors.Errorf("expected a number (e.g. 1337) or a range (e.g. 3000-3999)")
+	}
+	start, err := strconv.Atoi(matches[1])
+	if err != nil || !isValidPort(start) {
+		return PortRange{}, xerrors.Errorf("invalid port range start, must be a number between 1 and %d", math.MaxUint16)
+	}
+	end, err := strconv.Atoi(matches[2])
+	if err != nil || !isValidPort(end) {
+		return PortRange{}, xerrors.Errorf("invalid port range end, must be a number between 1 and %d", math.MaxUint16)
+	}
+	if start > end {
+		return PortRange{}, xerrors.Errorf("invalid port range, start must not be greater than end")
+	}
+	return PortRange{Start: uint32(start), End: uint32(end)}, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseConfigDenylist(t *testing.T) {
+	tests := []struct {
+		Desc                string
+		Entries             []interface{}
+		Expectation         Denylist
+		ExpectedDiagnostics []*ConfigDiagnostic
+	}{
+		{
+			Desc:        "ports and ranges",
+			Entries:     []interface{}{22, "6000-6100", "7000:7001"},
+			Expectation: Denylist{{Start: 22, End: 22}, {Start: 6000, End: 6100}, {Start: 7000, End: 7001}},
+		},
+		{
+			Desc:        "invalid entries",
+			Entries:     []interface{}{0, "ssh", "6100-6000", 8080},
+			Expectation: Denylist{{Start: 8080, End: 8080}},
+			ExpectedDiagnostics: []*ConfigDiagnostic{
+				{Source: InstanceConfigSource, Port: "0", Message: "ignoring denied port: invalid port, must be a number between 1 and 65535"},
+				{Source: InstanceConfigSource, Port: "ssh", Message: "ignoring denied port: expected a number (e.g. 1337) or a range (e.g. 3000-3999)"},
+				{Source: InstanceConfigSource, Port: "6100-6000", Message: "ignoring denied port: invalid port range, start must not be greater than end"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act, diagnostics := parseConfigDenylist(InstanceConfigSource, test.Entries)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected denylist (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.ExpectedDiagnostics, diagnostics); diff != "" {
+				t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+			}
+			for _, r := range act {
+				if !act.Contains(r.Start) || !act.Contains(r.End) {
+					t.Errorf("denylist does not contain %v", r)
+				}
+			}
+		})
+	}
+}
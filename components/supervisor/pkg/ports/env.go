@@ -0,0 +1,179 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+// URLEnv provides the URLs of exposed configured ports as environment variables, e.g. GITPOD_PORT_3000_URL,
+// so that scripts can reference them without calling gp url. Named ports are provided by their name as well,
+// e.g. GITPOD_PORT_API_URL for a port named api. New terminals get the variables in their
+// environment, shells which run already pick them up from File before their next prompt.
+type URLEnv struct {
+	// File is where the variables are written to as shell script, nothing is written if it is empty
+	File string
+
+	urls  map[uint32]string
+	names map[uint32]string
+	mu    sync.RWMutex
+}
+
+// Run keeps the variables up to date with the exposed ports of the port manager until ctx is done
+func (e *URLEnv) Run(ctx context.Context, pm *Manager) error {
+	sub := pm.Subscribe("url-env")
+	if sub == nil {
+		return xerrors.Errorf("cannot subscribe to port updates")
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case diff := <-sub.Updates():
+			if diff == nil {
+				return nil
+			}
+			if !e.apply(diff) || e.File == "" {
+				continue
+			}
+			err := e.write()
+			if err != nil {
+				log.WithError(err).WithField("file", e.File).Warn("cannot write port URL environment variables")
+			}
+		}
+	}
+}
+
+// apply updates the URLs from a port status diff and returns true if they changed
+func (e *URLEnv) apply(diff *Diff) (changed bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.urls == nil {
+		e.urls = make(map[uint32]string)
+		e.names = make(map[uint32]string)
+	}
+	remove := func(port uint32) {
+		if _, exists := e.urls[port]; exists {
+			delete(e.urls, port)
+			delete(e.names, port)
+			changed = true
+		}
+	}
+	for _, status := range append(diff.Added, diff.Updated...) {
+		if status.Exposed == nil || status.Exposed.Url == "" || status.Config == nil {
+			remove(status.LocalPort)
+			continue
+		}
+		url, exists := e.urls[status.LocalPort]
+		if !exists || url != status.Exposed.Url || e.names[status.LocalPort] != status.Name {
+			e.urls[status.LocalPort] = status.Exposed.Url
+			e.names[status.LocalPort] = status.Name
+			changed = true
+		}
+	}
+	for _, port := range diff.Removed {
+		remove(port)
+	}
+	return changed
+}
+
+// Environ returns the variables in the form of os.Environ, along with a PROMPT_COMMAND which
+// makes interactive bash shells load the latest variables from File.
+func (e *URLEnv) Environ() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var res []string
+	for _, port := range e.sortedPorts() {
+		for _, name := range e.envNames(port) {
+			res = append(res, fmt.Sprintf("%s=%s", name, e.urls[port]))
+		}
+	}
+	if e.File != "" {
+		promptCommand := fmt.Sprintf("[ -f %[1]s ] && . %[1]s", shellQuote(e.File))
+		if prev := os.Getenv("PROMPT_COMMAND"); prev != "" {
+			promptCommand += "; " + prev
+		}
+		res = append(res, "PROMPT_COMMAND="+promptCommand)
+	}
+	return res
+}
+
+// write writes the variables as shell script. Variables of ports which are no longer exposed are unset,
+// since shells which sourced the file before still have them.
+func (e *URLEnv) write() error {
+	e.mu.RLock()
+	var script strings.Builder
+	script.WriteString("# generated by supervisor - exposed port URLs\n")
+	script.WriteString("for v in $(compgen -v GITPOD_PORT_); do unset \"$v\"; done\n")
+	for _, port := range e.sortedPorts() {
+		for _, name := range e.envNames(port) {
+			fmt.Fprintf(&script, "export %s=%s\n", name, shellQuote(e.urls[port]))
+		}
+	}
+	e.mu.RUnlock()
+
+	err := os.MkdirAll(filepath.Dir(e.File), 0755)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(e.File), ".ports-env-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.WriteString(script.String())
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	err = os.Chmod(tmp.Name(), 0644)
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	// renaming replaces the file atomically, so shells never source a partially written file
+	return os.Rename(tmp.Name(), e.File)
+}
+
+// sortedPorts returns the ports which have a URL. Callers are expected to hold mu.
+func (e *URLEnv) sortedPorts() []uint32 {
+	res := make([]uint32, 0, len(e.urls))
+	for port := range e.urls {
+		res = append(res, port)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// envNames returns the names of the variables of a port. Callers are expected to hold mu.
+func (e *URLEnv) envNames(port uint32) []string {
+	res := []string{fmt.Sprintf("GITPOD_PORT_%d_URL", port)}
+	if name := e.names[port]; name != "" {
+		res = append(res, fmt.Sprintf("GITPOD_PORT_%s_URL", strings.ToUpper(strings.ReplaceAll(name, "-", "_"))))
+	}
+	return res
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestURLEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "url-env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	exposed := func(port uint32, url string, configured bool) *api.PortsStatus {
+		status := &api.PortsStatus{LocalPort: port, Exposed: &api.PortsStatus_ExposedPortInfo{Url: url}}
+		if configured {
+			status.Config = &api.PortConfigMatch{Port: "3000-4000"}
+		}
+		return status
+	}
+	named := func(status *api.PortsStatus, name string) *api.PortsStatus {
+		status.Name = name
+		return status
+	}
+	env := &URLEnv{File: filepath.Join(dir, "ports.env")}
+
+	tests := []struct {
+		Desc            string
+		Diff            *Diff
+		ExpectedChanged bool
+		ExpectedVars    map[string]string
+	}{
+		{
+			Desc:            "configured ports are exported once exposed",
+			Diff:            &Diff{Added: []*api.PortsStatus{exposed(3000, "https://3000-ws.gitpod.io/", true), exposed(8080, "https://8080-ws.gitpod.io/", false), {LocalPort: 3001, Config: &api.PortConfigMatch{Port: "3001"}}}},
+			ExpectedChanged: true,
+			ExpectedVars:    map[string]string{"GITPOD_PORT_3000_URL": "https://3000-ws.gitpod.io/"},
+		},
+		{
+			Desc:         "unchanged URLs",
+			Diff:         &Diff{Updated: []*api.PortsStatus{exposed(3000, "https://3000-ws.gitpod.io/", true)}},
+			ExpectedVars: map[string]string{"GITPOD_PORT_3000_URL": "https://3000-ws.gitpod.io/"},
+		},
+		{
+			Desc:            "port exposed and another one removed",
+			Diff:            &Diff{Updated: []*api.PortsStatus{exposed(3001, "https://3001-ws.gitpod.io/", true)}, Removed: []uint32{3000}},
+			ExpectedChanged: true,
+			ExpectedVars:    map[string]string{"GITPOD_PORT_3001_URL": "https://3001-ws.gitpod.io/"},
+		},
+		{
+			Desc:            "named port",
+			Diff:            &Diff{Updated: []*api.PortsStatus{named(exposed(3001, "https://3001-ws.gitpod.io/", true), "admin-api")}},
+			ExpectedChanged: true,
+			ExpectedVars: map[string]string{
+				"GITPOD_PORT_3001_URL":      "https://3001-ws.gitpod.io/",
+				"GITPOD_PORT_ADMIN_API_URL": "https://3001-ws.gitpod.io/",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			changed := env.apply(test.Diff)
+			if changed != test.ExpectedChanged {
+				t.Errorf("unexpected change: want %v, got %v", test.ExpectedChanged, changed)
+			}
+
+			vars := make(map[string]string)
+			for _, v := range env.Environ() {
+				segs := strings.SplitN(v, "=", 2)
+				if segs[0] == "PROMPT_COMMAND" {
+					continue
+				}
+				vars[segs[0]] = segs[1]
+			}
+			if diff := cmp.Diff(test.ExpectedVars, vars); diff != "" {
+				t.Errorf("unexpected environment (-want +got):\n%s", diff)
+			}
+
+			err := env.write()
+			if err != nil {
+				t.Fatal(err)
+			}
+			bash, err := exec.LookPath("bash")
+			if err != nil {
+				return
+			}
+			// a shell which sourced the previous file has stale variables
+			script := "export GITPOD_PORT_3000_URL=stale; . " + env.File + "; env | grep ^GITPOD_PORT_ || true"
+			out, err := exec.Command(bash, "-c", script).Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+			sourced := make(map[string]string)
+			for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+				if line == "" {
+					continue
+				}
+				segs := strings.SplitN(line, "=", 2)
+				sourced[segs[0]] = segs[1]
+			}
+			if diff := cmp.Diff(test.ExpectedVars, sourced); diff != "" {
+				t.Errorf("unexpected variables after sourcing %s (-want +got):\n%s", env.File, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// maxQueuedEvents is the number of events queued for a subscriber before the oldest ones are dropped
+const maxQueuedEvents = 100
+
+// EventKind identifies the type of an event
+type EventKind string
+
+const (
+	// PortServedKind is the kind of PortServed events
+	PortServedKind EventKind = "port-served"
+	// PortClosedKind is the kind of PortClosed events
+	PortClosedKind EventKind = "port-closed"
+	// PortExposedKind is the kind of PortExposed events
+	PortExposedKind EventKind = "port-exposed"
+	// PortUnexposedKind is the kind of PortUnexposed events
+	PortUnexposedKind EventKind = "port-unexposed"
+	// PortVisibilityChangedKind is the kind of PortVisibilityChanged events
+	PortVisibilityChangedKind EventKind = "port-visibility-changed"
+	// ConfigDiagnosticsChangedKind is the kind of ConfigDiagnosticsChanged events
+	ConfigDiagnosticsChangedKind EventKind = "config-diagnostics-changed"
+)
+
+// Event is an event published on the EventBus
+type Event interface {
+	Kind() EventKind
+}
+
+// EventHeader describes the state update an event was published with
+type EventHeader struct {
+	// Trigger is what caused the update
+	Trigger api.PortsUpdateTrigger
+	// Revision is the revision of the ports state after the update
+	Revision uint64
+}
+
+// PortServed is published when a service starts listening on a port
+type PortServed struct {
+	EventHeader
+	Port   uint32
+	Status *api.PortsStatus
+}
+
+// Kind implements Event
+func (PortServed) Kind() EventKind { return PortServedKind }
+
+// PortClosed is published when a port is no longer served
+type PortClosed struct {
+	EventHeader
+	Port uint32
+}
+
+// Kind implements Event
+func (PortClosed) Kind() EventKind { return PortClosedKind }
+
+// PortExposed is published when a port is exposed, or exposed on another URL
+type PortExposed struct {
+	EventHeader
+	Port   uint32
+	URL    string
+	Public bool
+	Status *api.PortsStatus
+}
+
+// Kind implements Event
+func (PortExposed) Kind() EventKind { return PortExposedKind }
+
+// PortUnexposed is published when a port is no longer exposed
+type PortUnexposed struct {
+	EventHeader
+	Port uint32
+	// URL is the URL the port was exposed on
+	URL string
+}
+
+// Kind implements Event
+func (PortUnexposed) Kind() EventKind { return PortUnexposedKind }
+
+// PortVisibilityChanged is published when an exposed port becomes public or private
+type PortVisibilityChanged struct {
+	EventHeader
+	Port   uint32
+	URL    string
+	Public bool
+}
+
+// Kind implements Event
+func (PortVisibilityChanged) Kind() EventKind { return PortVisibilityChangedKind }
+
+// ConfigDiagnosticsChanged is published when the problems of the port configs change
+type ConfigDiagnosticsChanged struct {
+	EventHeader
+	Diagnostics *api.PortConfigDiagnostics
+}
+
+// Kind implements Event
+func (ConfigDiagnosticsChanged) Kind() EventKind { return ConfigDiagnosticsChangedKind }
+
+// EventBus publishes typed events to in-process subscribers, e.g. the task runner, the notifier or
+// analytics, which would otherwise have to consume status diffs and track the previous state themselves.
+// Each subscription queues events on its own, so that a slow subscriber never blocks the publisher.
+type EventBus struct {
+	mu     sync.Mutex
+	subs   map[*EventSubscription]struct{}
+	closed bool
+}
+
+// NewEventBus creates a new event bus
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[*EventSubscription]struct{})}
+}
+
+// Subscribe subscribes for the events of the given kinds, or for all events if no kinds are given.
+// The client label identifies the subscriber in logs. Once the bus is closed, subscriptions end right away.
+func (b *EventBus) Subscribe(client string, kinds ...EventKind) *EventSubscription {
+	sub := &EventSubscription{
+		Client:  client,
+		Since:   time.Now(),
+		bus:     b,
+		events:  make(chan Event),
+		notify:  make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+		ended:   make(chan struct{}),
+		filters: make(map[EventKind]struct{}, len(kinds)),
+	}
+	for _, kind := range kinds {
+		sub.filters[kind] = struct{}{}
+	}
+	go sub.forward()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		sub.end()
+		return sub
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// Publish publishes events to all subscribers of their kind without blocking
+func (b *EventBus) Publish(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	for sub := range b.subs {
+		sub.push(events)
+	}
+}
+
+// Close ends all subscriptions once they received the events published so far
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		sub.end()
+	}
+}
+
+// EventSubscription is a subscription to the events of an EventBus.
+// If a subscriber falls behind by more than maxQueuedEvents, the oldest events are dropped.
+type EventSubscription struct {
+	// Client identifies the subscriber
+	Client string
+	// Since is the time the subscription was created
+	Since time.Time
+
+	bus     *EventBus
+	filters map[EventKind]struct{}
+	events  chan Event
+
+	mu        sync.Mutex
+	queue     []Event
+	dropped   uint64
+	notify    chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+	ended     chan struct{}
+	endOnce   sync.Once
+}
+
+// Events returns the events channel, which is closed when the subscription ends
+func (s *EventSubscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the subscriber fell behind
+func (s *EventSubscription) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Close ends the subscription without delivering the queued events
+func (s *EventSubscription) Close() error {
+	s.bus.mu.Lock()
+	delete(s.bus.subs, s)
+	s.bus.mu.Unlock()
+
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+// push queues the events the subscriber is interested in
+func (s *EventSubscription) push(events []Event) {
+	s.mu.Lock()
+	var queued bool
+	for _, event := range events {
+		if _, wanted := s.filters[event.Kind()]; len(s.filters) > 0 && !wanted {
+			continue
+		}
+		if len(s.queue) >= maxQueuedEvents {
+			if s.dropped == 0 {
+				log.WithField("client", s.Client).Warn("event subscriber is falling behind - dropping events")
+			}
+			s.queue = s.queue[1:]
+			s.dropped++
+		}
+		s.queue = append(s.queue, event)
+		queued = true
+	}
+	s.mu.Unlock()
+
+	if !queued {
+		return
+	}
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// end signals that there won't be any further events
+func (s *EventSubscription) end() {
+	s.endOnce.Do(func() { close(s.ended) })
+}
+
+// forward delivers queued events to the events channel until the subscription is closed,
+// or the subscription has ended and all queued events are delivered.
+func (s *EventSubscription) forward() {
+	defer close(s.events)
+	for {
+		s.mu.Lock()
+		var next Event
+		if len(s.queue) > 0 {
+			next = s.queue[0]
+			s.queue = s.queue[1:]
+		}
+		s.mu.Unlock()
+
+		if next == nil {
+			select {
+			case <-s.notify:
+				continue
+			case <-s.ended:
+				s.mu.Lock()
+				drained := len(s.queue) == 0
+				s.mu.Unlock()
+				if drained {
+					return
+				}
+				continue
+			case <-s.closed:
+				return
+			}
+		}
+
+		select {
+		case s.events <- next:
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// portEvents produces the events of a port whose state changed from prev to next, either of which may be nil
+func portEvents(header EventHeader, port uint32, prev, next *managedPort, status *api.PortsStatus) (events []Event) {
+	wasServed := prev != nil && prev.Served
+	isServed := next != nil && next.Served
+	switch {
+	case !wasServed && isServed:
+		events = append(events, PortServed{EventHeader: header, Port: port, Status: status})
+	case wasServed && !isServed:
+		events = append(events, PortClosed{EventHeader: header, Port: port})
+	}
+
+	wasExposed := prev != nil && prev.Exposed
+	isExposed := next != nil && next.Exposed
+	switch {
+	case isExposed && (!wasExposed || prev.URL != next.URL):
+		events = append(events, PortExposed{
+			EventHeader: header,
+			Port:        port,
+			URL:         next.URL,
+			Public:      next.Visibility == api.PortVisibility_public,
+			Status:      status,
+		})
+	case wasExposed && !isExposed:
+		events = append(events, PortUnexposed{EventHeader: header, Port: port, URL: prev.URL})
+	case wasExposed && isExposed && prev.Visibility != next.Visibility:
+		events = append(events, PortVisibilityChanged{
+			EventHeader: header,
+			Port:        port,
+			URL:         next.URL,
+			Public:      next.Visibility == api.PortVisibility_public,
+		})
+	}
+	return events
+}
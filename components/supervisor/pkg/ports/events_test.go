@@ -0,0 +1,189 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPortEvents(t *testing.T) {
+	header := EventHeader{Trigger: api.PortsUpdateTrigger_exposed_ports_changed, Revision: 2}
+	served := &managedPort{Served: true}
+	public := func(url string) *managedPort {
+		return &managedPort{Served: true, Exposed: true, URL: url, Visibility: api.PortVisibility_public}
+	}
+	private := func(url string) *managedPort {
+		return &managedPort{Served: true, Exposed: true, URL: url, Visibility: api.PortVisibility_private}
+	}
+
+	tests := []struct {
+		Desc        string
+		Prev        *managedPort
+		Next        *managedPort
+		Expectation []Event
+	}{
+		{
+			Desc:        "served",
+			Next:        served,
+			Expectation: []Event{PortServed{EventHeader: header, Port: 3000}},
+		},
+		{
+			Desc: "served and exposed",
+			Next: public("3000-url"),
+			Expectation: []Event{
+				PortServed{EventHeader: header, Port: 3000},
+				PortExposed{EventHeader: header, Port: 3000, URL: "3000-url", Public: true},
+			},
+		},
+		{
+			Desc:        "exposed",
+			Prev:        served,
+			Next:        private("3000-url"),
+			Expectation: []Event{PortExposed{EventHeader: header, Port: 3000, URL: "3000-url"}},
+		},
+		{
+			Desc: "stays public",
+			Prev: public("3000-url"),
+			Next: public("3000-url"),
+		},
+		{
+			Desc:        "exposed on another URL",
+			Prev:        public("3000-url"),
+			Next:        public("3000-other-url"),
+			Expectation: []Event{PortExposed{EventHeader: header, Port: 3000, URL: "3000-other-url", Public: true}},
+		},
+		{
+			Desc:        "made public",
+			Prev:        private("3000-url"),
+			Next:        public("3000-url"),
+			Expectation: []Event{PortVisibilityChanged{EventHeader: header, Port: 3000, URL: "3000-url", Public: true}},
+		},
+		{
+			Desc:        "unexposed",
+			Prev:        public("3000-url"),
+			Next:        served,
+			Expectation: []Event{PortUnexposed{EventHeader: header, Port: 3000, URL: "3000-url"}},
+		},
+		{
+			Desc: "removed",
+			Prev: public("3000-url"),
+			Expectation: []Event{
+				PortClosed{EventHeader: header, Port: 3000},
+				PortUnexposed{EventHeader: header, Port: 3000, URL: "3000-url"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := portEvents(header, 3000, test.Prev, test.Next, nil)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected events (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// receiveEvents receives events until the subscription ends or no event arrives for a while
+func receiveEvents(sub *EventSubscription) (res []Event) {
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return res
+			}
+			res = append(res, event)
+		case <-time.After(100 * time.Millisecond):
+			return res
+		}
+	}
+}
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+	all := bus.Subscribe("all")
+	exposures := bus.Subscribe("exposures", PortExposedKind)
+	closed := bus.Subscribe("closed")
+	closed.Close()
+
+	events := []Event{
+		PortServed{Port: 3000},
+		PortExposed{Port: 3000, URL: "3000-url"},
+		PortClosed{Port: 4000},
+	}
+	bus.Publish(events...)
+	bus.Close()
+
+	if diff := cmp.Diff(events, receiveEvents(all)); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]Event{PortExposed{Port: 3000, URL: "3000-url"}}, receiveEvents(exposures)); diff != "" {
+		t.Errorf("unexpected filtered events (-want +got):\n%s", diff)
+	}
+	if act := receiveEvents(closed); len(act) != 0 {
+		t.Errorf("expected no events after the subscription was closed, got %v", act)
+	}
+	if _, ok := <-bus.Subscribe("late").Events(); ok {
+		t.Error("expected subscriptions to a closed bus to end right away")
+	}
+}
+
+func TestEventBusSlowSubscriber(t *testing.T) {
+	bus := NewEventBus()
+	sub := bus.Subscribe("slow")
+	defer sub.Close()
+
+	for i := 0; i < maxQueuedEvents+10; i++ {
+		bus.Publish(PortServed{Port: uint32(i)})
+	}
+	bus.Close()
+
+	act := receiveEvents(sub)
+	// the forwarder may hold the first event already when the queue overflows
+	if len(act) < maxQueuedEvents || len(act) > maxQueuedEvents+1 {
+		t.Fatalf("expected about %d events, got %d", maxQueuedEvents, len(act))
+	}
+	if last := act[len(act)-1].(PortServed).Port; last != maxQueuedEvents+9 {
+		t.Errorf("expected the latest events to be delivered, got %d last", last)
+	}
+	if sub.Dropped() == 0 {
+		t.Error("expected events to be dropped")
+	}
+}
+
+func TestManagerEvents(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	go pm.Run()
+	sub := pm.Events().Subscribe("test")
+	defer sub.Close()
+
+	pm.mu.Lock()
+	pm.setServed([]ServedPort{{Port: 3000}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	pm.setExposed([]ExposedPort{{LocalPort: 3000, GlobalPort: 3000, URL: "3000-url"}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_exposed_ports_changed)
+	pm.mu.Unlock()
+	err := pm.Stop(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectation := []Event{
+		PortServed{EventHeader: EventHeader{Trigger: api.PortsUpdateTrigger_served_ports_changed, Revision: 1}, Port: 3000},
+		PortExposed{EventHeader: EventHeader{Trigger: api.PortsUpdateTrigger_exposed_ports_changed, Revision: 2}, Port: 3000, URL: "3000-url"},
+		PortClosed{EventHeader: EventHeader{Trigger: api.PortsUpdateTrigger_ports_shutdown, Revision: 3}, Port: 3000},
+		PortUnexposed{EventHeader: EventHeader{Trigger: api.PortsUpdateTrigger_ports_shutdown, Revision: 3}, Port: 3000, URL: "3000-url"},
+	}
+	ignoreStatus := cmpopts.IgnoreFields(PortServed{}, "Status")
+	ignoreExposedStatus := cmpopts.IgnoreFields(PortExposed{}, "Status")
+	if diff := cmp.Diff(expectation, receiveEvents(sub), ignoreStatus, ignoreExposedStatus); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
@@ -7,15 +7,33 @@ package ports
 import (
 	"context"
 
+	"github.com/gitpod-io/gitpod/common-go/tracing"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/sourcegraph/jsonrpc2"
+	"golang.org/x/xerrors"
 )
 
+// ErrExposureUnavailable is returned by Expose if the exposure service cannot be reached, e.g. because the
+// connection to the Gitpod server is lost. Such exposures may succeed when they are retried later.
+var ErrExposureUnavailable = xerrors.New("exposure service unavailable")
+
 // ExposedPort represents an exposed pprt
 type ExposedPort struct {
 	LocalPort  uint32
 	GlobalPort uint32
 	URL        string
 	Public     bool
+	// InsecureRequests is how the proxy handles plain HTTP requests to the port, empty means allow
+	InsecureRequests string
+}
+
+// ExposeOptions configures how a port is exposed
+type ExposeOptions struct {
+	Public bool
+	// InsecureRequests is how the proxy handles plain HTTP requests to the port, i.e. allow, redirect or reject
+	InsecureRequests string
+	// Cors is the CORS policy the proxy applies to requests to the port. If nil, CORS requests are passed on to the port.
+	Cors *gitpod.PortCorsConfig
 }
 
 // ExposedPortsInterface provides access to port exposure
@@ -28,7 +46,11 @@ type ExposedPortsInterface interface {
 	Observe(ctx context.Context) (<-chan []ExposedPort, <-chan error)
 
 	// Expose exposes a port to the internet. Upon successful execution any Observer will be updated.
-	Expose(ctx context.Context, local, global uint32, public bool) error
+	// If the exposure service cannot be reached, the error wraps ErrExposureUnavailable. If ctx is done, ctx.Err() is returned.
+	Expose(ctx context.Context, local, global uint32, opts ExposeOptions) error
+
+	// Unexpose closes an exposed port again. Upon successful execution any Observer will be updated.
+	Unexpose(ctx context.Context, local uint32) error
 }
 
 // NoopExposedPorts implements ExposedPortsInterface but does nothing
@@ -40,7 +62,12 @@ func (*NoopExposedPorts) Observe(ctx context.Context) (<-chan []ExposedPort, <-c
 }
 
 // Expose exposes a port to the internet. Upon successful execution any Observer will be updated.
-func (*NoopExposedPorts) Expose(ctx context.Context, local, global uint32, public bool) error {
+func (*NoopExposedPorts) Expose(ctx context.Context, local, global uint32, opts ExposeOptions) error {
+	return nil
+}
+
+// Unexpose closes an exposed port again. Upon successful execution any Observer will be updated.
+func (*NoopExposedPorts) Unexpose(ctx context.Context, local uint32) error {
 	return nil
 }
 
@@ -81,10 +108,11 @@ func (g *GitpodExposedPorts) Observe(ctx context.Context) (<-chan []ExposedPort,
 					}
 
 					res[i] = ExposedPort{
-						LocalPort:  uint32(p.Port),
-						GlobalPort: uint32(globalport),
-						Public:     p.Visibility == "public",
-						URL:        p.URL,
+						LocalPort:        uint32(p.Port),
+						GlobalPort:       uint32(globalport),
+						Public:           p.Visibility == "public",
+						URL:              p.URL,
+						InsecureRequests: p.InsecureRequests,
 					}
 				}
 
@@ -99,21 +127,48 @@ func (g *GitpodExposedPorts) Observe(ctx context.Context) (<-chan []ExposedPort,
 }
 
 // Expose exposes a port to the internet. Upon successful execution any Observer will be updated.
-func (g *GitpodExposedPorts) Expose(ctx context.Context, local, global uint32, public bool) error {
+func (g *GitpodExposedPorts) Expose(ctx context.Context, local, global uint32, opts ExposeOptions) (err error) {
+	span, ctx := tracing.FromContext(ctx, "GitpodExposedPorts.Expose")
+	span.SetTag("workspace", g.WorkspaceID)
+	span.SetTag("port", local)
+	span.SetTag("globalPort", global)
+	span.SetTag("public", opts.Public)
+	defer tracing.FinishSpan(span, &err)
+
 	var v string
-	if public {
+	if opts.Public {
 		v = "public"
 	} else {
 		v = "private"
 	}
-	_, err := g.C.OpenPort(ctx, g.WorkspaceID, &gitpod.WorkspaceInstancePort{
-		Port:       float64(local),
-		TargetPort: float64(global),
-		Visibility: v,
+	_, err = g.C.OpenPort(ctx, g.WorkspaceID, &gitpod.WorkspaceInstancePort{
+		Port:             float64(local),
+		TargetPort:       float64(global),
+		Visibility:       v,
+		Cors:             opts.Cors,
+		InsecureRequests: opts.InsecureRequests,
 	})
+	if err != nil && ctx.Err() != nil {
+		// the caller gave up, which tells nothing about the exposure service
+		return ctx.Err()
+	}
+	if _, rejected := err.(*jsonrpc2.Error); err != nil && !rejected {
+		// the server did not answer, e.g. because the connection is lost and being re-established
+		return xerrors.Errorf("%v: %w", err, ErrExposureUnavailable)
+	}
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// Unexpose closes an exposed port again. Upon successful execution any Observer will be updated.
+func (g *GitpodExposedPorts) Unexpose(ctx context.Context, local uint32) (err error) {
+	span, ctx := tracing.FromContext(ctx, "GitpodExposedPorts.Unexpose")
+	span.SetTag("workspace", g.WorkspaceID)
+	span.SetTag("port", local)
+	defer tracing.FinishSpan(span, &err)
+
+	return g.C.ClosePort(ctx, g.WorkspaceID, float32(local))
+}
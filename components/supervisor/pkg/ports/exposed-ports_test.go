@@ -0,0 +1,54 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/mock/gomock"
+	"github.com/sourcegraph/jsonrpc2"
+	"golang.org/x/xerrors"
+)
+
+func TestGitpodExposedPortsExpose(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		Desc        string
+		Ctx         context.Context
+		Err         error
+		Unavailable bool
+		Canceled    bool
+	}{
+		{Desc: "exposed", Ctx: context.Background()},
+		{Desc: "rejected", Ctx: context.Background(), Err: &jsonrpc2.Error{Code: 400, Message: "invalid port"}},
+		{Desc: "connection lost", Ctx: context.Background(), Err: errors.New("connection lost"), Unavailable: true},
+		{Desc: "canceled", Ctx: canceled, Err: context.Canceled, Canceled: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+			gitpodAPI.EXPECT().OpenPort(gomock.Any(), "workspace", gomock.Any()).Return(nil, test.Err)
+
+			exposed := &GitpodExposedPorts{WorkspaceID: "workspace", C: gitpodAPI}
+			err := exposed.Expose(test.Ctx, 3000, 3000, ExposeOptions{})
+			if (err != nil) != (test.Err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if unavailable := xerrors.Is(err, ErrExposureUnavailable); unavailable != test.Unavailable {
+				t.Errorf("unexpected exposure service availability: %v", err)
+			}
+			if canceled := xerrors.Is(err, context.Canceled); canceled != test.Canceled {
+				t.Errorf("unexpected cancelation: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+)
+
+// Framework is a well-known dev server
+type Framework struct {
+	// Name is reported as detected_as in the port status
+	Name string
+	// OnOpen is the onOpen action applied to unconfigured ports served by this dev server
+	OnOpen string
+
+	// processes are the executable names of the dev server
+	processes []string
+	// runtimes are the executable names of the interpreters which run the dev server, e.g. a custom server.js.
+	// Only ports served by one of them are fingerprinted.
+	runtimes []string
+	// fingerprint recognizes the dev server by its response to GET /
+	fingerprint func(resp *http.Response, body []byte) bool
+}
+
+// config produces the port config applied to unconfigured ports served by this dev server
+func (f *Framework) config(port uint32) *gitpod.PortConfig {
+	return &gitpod.PortConfig{
+		Port:   float64(port),
+		OnOpen: f.OnOpen,
+	}
+}
+
+var frameworks = []*Framework{
+	{
+		Name:      "next",
+		OnOpen:    "open-preview",
+		processes: []string{"next"},
+		runtimes:  []string{"node"},
+		fingerprint: func(resp *http.Response, body []byte) bool {
+			return strings.Contains(resp.Header.Get("X-Powered-By"), "Next.js")
+		},
+	},
+	{
+		Name:      "vite",
+		OnOpen:    "open-preview",
+		processes: []string{"vite"},
+		runtimes:  []string{"node"},
+		fingerprint: func(resp *http.Response, body []byte) bool {
+			return bytes.Contains(body, []byte("/@vite/client"))
+		},
+	},
+	{
+		Name:      "webpack",
+		OnOpen:    "open-preview",
+		processes: []string{"webpack", "webpack-cli", "webpack-dev-server"},
+	},
+	{
+		Name:      "jupyter",
+		OnOpen:    "open-browser",
+		processes: []string{"jupyter", "jupyter-lab", "jupyter-notebook", "jupyter-server"},
+		runtimes:  []string{"python", "python3"},
+		fingerprint: func(resp *http.Response, body []byte) bool {
+			return strings.Contains(resp.Header.Get("Server"), "Tornado") && bytes.Contains(bytes.ToLower(body), []byte("jupyter"))
+		},
+	},
+	{
+		Name:      "rails",
+		OnOpen:    "open-preview",
+		processes: []string{"rails"},
+		runtimes:  []string{"ruby"},
+		fingerprint: func(resp *http.Response, body []byte) bool {
+			return resp.Header.Get("X-Runtime") != "" && resp.Header.Get("X-Request-Id") != ""
+		},
+	},
+}
+
+// frameworkByName returns the framework with the given name, or nil if there is none
+func frameworkByName(name string) *Framework {
+	if name == "" {
+		return nil
+	}
+	for _, f := range frameworks {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+const (
+	// maxCmdlineArgs is the number of command line arguments considered when matching the dev server process,
+	// e.g. `node node_modules/.bin/vite` or `python3 /usr/local/bin/jupyter-lab`.
+	maxCmdlineArgs = 3
+	// maxFingerprintBody is the maximum number of response body bytes considered when fingerprinting
+	maxFingerprintBody = 64 * 1024
+)
+
+// FrameworkDetector detects well-known dev servers serving a port, first by the name of the process
+// owning the listening socket. Ports served by an interpreter which runs dev servers, e.g. node, are
+// fingerprinted by their response to a single GET / request.
+// Detection runs in the background once per socket, i.e. a port is only inspected again once it is served anew.
+type FrameworkDetector struct {
+	procDir string
+	client  *http.Client
+
+	mu       sync.Mutex
+	detected map[uint64]string
+	// detecting are the sockets whose detection is still running
+	detecting map[uint64]struct{}
+}
+
+// NewFrameworkDetector creates a new framework detector
+func NewFrameworkDetector() *FrameworkDetector {
+	return &FrameworkDetector{
+		procDir: "/proc",
+		client: &http.Client{
+			Timeout: 1 * time.Second,
+		},
+		detected:  make(map[uint64]string),
+		detecting: make(map[uint64]struct{}),
+	}
+}
+
+// detect sets DetectedAs of the served ports which were detected already, and starts the detection of new ones
+// in the background. Served ports are observed by polling, hence the result is picked up by the next poll.
+// The PIDs of the sockets have to be resolved already.
+func (d *FrameworkDetector) detect(sockets []servedSocket) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	current := make(map[uint64]struct{}, len(sockets))
+	for i := range sockets {
+		socket := &sockets[i]
+		current[socket.Inode] = struct{}{}
+		if name, cached := d.detected[socket.Inode]; cached {
+			socket.DetectedAs = name
+			continue
+		}
+		if _, running := d.detecting[socket.Inode]; running {
+			continue
+		}
+		d.detecting[socket.Inode] = struct{}{}
+		go d.detectSocket(socket.Inode, socket.PID, socket.Port)
+	}
+	for inode := range d.detected {
+		if _, exists := current[inode]; !exists {
+			delete(d.detected, inode)
+		}
+	}
+	for inode := range d.detecting {
+		if _, exists := current[inode]; !exists {
+			delete(d.detecting, inode)
+		}
+	}
+}
+
+func (d *FrameworkDetector) detectSocket(inode uint64, pid int, port uint32) {
+	var framework *Framework
+	if pid != 0 {
+		var candidates []*Framework
+		framework, candidates = d.detectByProcess(pid)
+		if framework == nil && len(candidates) > 0 {
+			framework = d.detectByFingerprint(port, candidates)
+		}
+	}
+	var name string
+	if framework != nil {
+		name = framework.Name
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, running := d.detecting[inode]; !running {
+		// the socket is gone already
+		return
+	}
+	delete(d.detecting, inode)
+	d.detected[inode] = name
+}
+
+// detectByProcess returns the framework the process is the dev server of. Otherwise it returns the frameworks
+// whose dev server the process could run, if it is an interpreter.
+func (d *FrameworkDetector) detectByProcess(pid int) (framework *Framework, candidates []*Framework) {
+	cmdline, err := ioutil.ReadFile(filepath.Join(d.procDir, strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return nil, nil
+	}
+	args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+	if len(args) > maxCmdlineArgs {
+		args = args[:maxCmdlineArgs]
+	}
+	for _, arg := range args {
+		executable := filepath.Base(arg)
+		for _, f := range frameworks {
+			for _, p := range f.processes {
+				if executable == p {
+					return f, nil
+				}
+			}
+		}
+	}
+
+	interpreter := filepath.Base(args[0])
+	for _, f := range frameworks {
+		if f.fingerprint == nil {
+			continue
+		}
+		for _, r := range f.runtimes {
+			if interpreter == r {
+				candidates = append(candidates, f)
+				break
+			}
+		}
+	}
+	return nil, candidates
+}
+
+func (d *FrameworkDetector) detectByFingerprint(port uint32, candidates []*Framework) *Framework {
+	resp, err := d.client.Get(fmt.Sprintf("http://localhost:%d/", port))
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxFingerprintBody))
+	if err != nil {
+		return nil
+	}
+	for _, f := range candidates {
+		if f.fingerprint(resp, body) {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFrameworkDetector(t *testing.T) {
+	type Process struct {
+		PID     string
+		Cmdline []string
+		Sockets []uint64
+	}
+	tests := []struct {
+		Name        string
+		Processes   []Process
+		Handler     http.HandlerFunc
+		Sockets     []servedSocket
+		Expectation []string
+	}{
+		{
+			Name: "process name",
+			Processes: []Process{
+				{PID: "42", Cmdline: []string{"node", "/workspace/app/node_modules/.bin/vite", "--port", "3000"}, Sockets: []uint64{100}},
+				{PID: "43", Cmdline: []string{"/usr/bin/python3", "/usr/local/bin/jupyter-lab"}, Sockets: []uint64{101}},
+				{PID: "44", Cmdline: []string{"node", "server.js", "--vite"}, Sockets: []uint64{102}},
+			},
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("hello world"))
+			},
+			Sockets: []servedSocket{
+				{ServedPort: ServedPort{Port: 3000}, Inode: 100, PID: 42},
+				{ServedPort: ServedPort{Port: 8888}, Inode: 101, PID: 43},
+				{ServedPort: ServedPort{Port: 8080}, Inode: 102, PID: 44},
+			},
+			Expectation: []string{"vite", "jupyter", ""},
+		},
+		{
+			Name: "fingerprint",
+			Processes: []Process{
+				{PID: "42", Cmdline: []string{"/usr/local/bin/node", "server.js"}, Sockets: []uint64{200}},
+			},
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Powered-By", "Next.js")
+			},
+			Sockets: []servedSocket{
+				{ServedPort: ServedPort{}, Inode: 200, PID: 42},
+			},
+			Expectation: []string{"next"},
+		},
+		{
+			Name: "fingerprint of another runtime",
+			Processes: []Process{
+				{PID: "42", Cmdline: []string{"ruby", "server.rb"}, Sockets: []uint64{300}},
+			},
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Powered-By", "Next.js")
+			},
+			Sockets: []servedSocket{
+				{ServedPort: ServedPort{}, Inode: 300, PID: 42},
+			},
+			Expectation: []string{""},
+		},
+		{
+			Name: "no interpreter",
+			Processes: []Process{
+				{PID: "42", Cmdline: []string{"/usr/bin/caddy", "run"}, Sockets: []uint64{400}},
+			},
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				t.Error("expected no request to a port served by no interpreter")
+				w.Header().Set("X-Powered-By", "Next.js")
+			},
+			Sockets: []servedSocket{
+				{ServedPort: ServedPort{}, Inode: 400, PID: 42},
+			},
+			Expectation: []string{""},
+		},
+		{
+			Name: "unknown owner",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				t.Error("expected no request to a port of an unknown process")
+				w.Header().Set("X-Powered-By", "Next.js")
+			},
+			Sockets: []servedSocket{
+				{ServedPort: ServedPort{}, Inode: 500},
+			},
+			Expectation: []string{""},
+		},
+		{
+			Name: "unknown",
+			Processes: []Process{
+				{PID: "42", Cmdline: []string{"node", "server.js"}, Sockets: []uint64{600}},
+			},
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("hello world"))
+			},
+			Sockets: []servedSocket{
+				{ServedPort: ServedPort{}, Inode: 600, PID: 42},
+			},
+			Expectation: []string{""},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			procDir, err := ioutil.TempDir("", "proc")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(procDir)
+			for _, p := range test.Processes {
+				fdDir := filepath.Join(procDir, p.PID, "fd")
+				err := os.MkdirAll(fdDir, 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = ioutil.WriteFile(filepath.Join(procDir, p.PID, "cmdline"), []byte(strings.Join(p.Cmdline, "\x00")+"\x00"), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i, inode := range p.Sockets {
+					err = os.Symlink(fmt.Sprintf("socket:[%d]", inode), filepath.Join(fdDir, fmt.Sprint(i+3)))
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			sockets := append([]servedSocket(nil), test.Sockets...)
+			if test.Handler != nil {
+				srv := httptest.NewServer(test.Handler)
+				defer srv.Close()
+				port := srv.Listener.Addr().(*net.TCPAddr).Port
+				for i := range sockets {
+					sockets[i].Port = uint32(port)
+				}
+			}
+
+			detector := NewFrameworkDetector()
+			detector.procDir = procDir
+			detector.client.Timeout = 5 * time.Second
+			detector.detect(sockets)
+			// detection runs in the background, the result is picked up by the next poll
+			for i := 0; i < 100 && detector.running(); i++ {
+				time.Sleep(10 * time.Millisecond)
+			}
+			detector.detect(sockets)
+
+			var act []string
+			for _, s := range sockets {
+				act = append(act, s.DetectedAs)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func (d *FrameworkDetector) running() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.detecting) > 0
+}
+
+func TestFrameworkDetectorOncePerSocket(t *testing.T) {
+	procDir, err := ioutil.TempDir("", "proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(procDir)
+	err = os.MkdirAll(filepath.Join(procDir, "42"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(procDir, "42", "cmdline"), []byte("node\x00server.js\x00"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		requests = make(chan struct{}, 10)
+		respond  = make(chan struct{})
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- struct{}{}
+		<-respond
+		w.Header().Set("X-Powered-By", "Next.js")
+	}))
+	defer srv.Close()
+	port := uint32(srv.Listener.Addr().(*net.TCPAddr).Port)
+
+	detector := NewFrameworkDetector()
+	detector.procDir = procDir
+	detector.client.Timeout = 5 * time.Second
+	sockets := []servedSocket{{ServedPort: ServedPort{Port: port}, Inode: 100, PID: 42}}
+
+	// polling must not wait for a slow server, nor probe it again while the detection is running
+	for i := 0; i < 3; i++ {
+		detector.detect(sockets)
+		if sockets[0].DetectedAs != "" {
+			t.Fatalf("expected no result while the detection is running, got %q", sockets[0].DetectedAs)
+		}
+	}
+	<-requests
+	close(respond)
+	for i := 0; i < 100 && detector.running(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	detector.detect(sockets)
+	if sockets[0].DetectedAs != "next" {
+		t.Errorf("expected the port to be detected as next, got %q", sockets[0].DetectedAs)
+	}
+	if len(requests) != 0 {
+		t.Errorf("expected a single request, got %d more", len(requests))
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/sourcegraph/jsonrpc2"
+	wsjsonrpc "github.com/sourcegraph/jsonrpc2/websocket"
+)
+
+const (
+	// MethodSubscribePorts subscribes the connection to port changes, which are then sent as OnDidChangePorts notifications
+	MethodSubscribePorts = "ports/subscribe"
+	// MethodGetPorts returns the current status of all ports
+	MethodGetPorts = "ports/get"
+	// NotificationOnDidChangePorts notifies a subscribed connection that ports changed
+	NotificationOnDidChangePorts = "ports/onDidChange"
+)
+
+// SubscribePortsParams are the params of MethodSubscribePorts
+type SubscribePortsParams struct {
+	// Client identifies the subscriber in the ports subscribers list
+	Client string `json:"client,omitempty"`
+	// ResumeToken resumes from a previous connection, see Manager.Resume
+	ResumeToken string `json:"resumeToken,omitempty"`
+}
+
+// SubscribePortsResult is the result of MethodSubscribePorts
+type SubscribePortsResult struct {
+	// Resumed is true if the notifications continue where the previous connection stopped.
+	// Otherwise the first notification adds all current ports.
+	Resumed bool `json:"resumed"`
+}
+
+// JSONRPCBridge serves the ports status as JSON-RPC over a websocket, for clients in the workspace
+// which don't speak gRPC, e.g. VS Code extensions. A connection which called MethodSubscribePorts
+// receives NotificationOnDidChangePorts notifications carrying the same updates as the PortsStatus
+// gRPC stream, i.e. api.PortsStatusResponse in its JSON form.
+type JSONRPCBridge struct {
+	Ports *Manager
+
+	Upgrader websocket.Upgrader
+}
+
+// ServeHTTP upgrades the request to a websocket and serves JSON-RPC on it until the client disconnects
+func (b *JSONRPCBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := b.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.WithError(err).Debug("cannot upgrade ports JSON-RPC connection")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := &jsonrpcBridgeConn{ports: b.Ports, ctx: ctx}
+	conn := jsonrpc2.NewConn(ctx, wsjsonrpc.NewObjectStream(ws), jsonrpc2.HandlerWithError(c.handle))
+	<-conn.DisconnectNotify()
+
+	c.mu.Lock()
+	if c.sub != nil {
+		c.sub.Close()
+	}
+	c.mu.Unlock()
+}
+
+// jsonrpcBridgeConn is a single JSON-RPC connection of the bridge
+type jsonrpcBridgeConn struct {
+	ports *Manager
+	ctx   context.Context
+
+	mu  sync.Mutex
+	sub *Subscription
+}
+
+func (c *jsonrpcBridgeConn) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (interface{}, error) {
+	switch req.Method {
+	case MethodGetPorts:
+		snapshot := c.ports.Snapshot()
+		return marshalPortsStatus(&api.PortsStatusResponse{
+			Added:       snapshot.Added,
+			Diagnostics: snapshot.Diagnostics,
+			Revision:    snapshot.Revision,
+			ResumeToken: c.ports.ResumeToken(snapshot.Revision),
+		})
+	case MethodSubscribePorts:
+		var params SubscribePortsParams
+		if req.Params != nil {
+			err := json.Unmarshal(*req.Params, &params)
+			if err != nil {
+				return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+			}
+		}
+		return c.subscribe(conn, params)
+	default:
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeMethodNotFound, Message: "unknown method " + req.Method}
+	}
+}
+
+func (c *jsonrpcBridgeConn) subscribe(conn *jsonrpc2.Conn, params SubscribePortsParams) (*SubscribePortsResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sub != nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidRequest, Message: "already subscribed"}
+	}
+
+	client := params.Client
+	if client == "" {
+		client = "jsonrpc"
+	}
+	var (
+		sub     *Subscription
+		resumed bool
+	)
+	if params.ResumeToken != "" {
+		sub, resumed = c.ports.Resume(client, params.ResumeToken)
+	} else {
+		sub = c.ports.Subscribe(client)
+	}
+	if sub == nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "too many subscriptions"}
+	}
+	c.sub = sub
+
+	// notifications may arrive before the response to the subscribe request
+	go c.forward(conn, sub)
+	return &SubscribePortsResult{Resumed: resumed}, nil
+}
+
+// forward notifies the client of the updates of its subscription until either of them is gone
+func (c *jsonrpcBridgeConn) forward(conn *jsonrpc2.Conn, sub *Subscription) {
+	for {
+		var update *Diff
+		select {
+		case <-c.ctx.Done():
+			return
+		case update = <-sub.Updates():
+		}
+		if update == nil {
+			return
+		}
+
+		params, err := marshalPortsStatus(&api.PortsStatusResponse{
+			Added:       update.Added,
+			Updated:     update.Updated,
+			Removed:     update.Removed,
+			Diagnostics: update.Diagnostics,
+			Trigger:     update.Trigger,
+			Triggers:    update.Triggers,
+			Revision:    update.Revision,
+			ResumeToken: c.ports.ResumeToken(update.Revision),
+		})
+		if err != nil {
+			log.WithError(err).Error("cannot marshal ports update")
+			continue
+		}
+		err = conn.Notify(c.ctx, NotificationOnDidChangePorts, params)
+		if err != nil {
+			log.WithError(err).Debug("cannot notify ports JSON-RPC client")
+			return
+		}
+	}
+}
+
+// marshalPortsStatus marshals a ports status like the REST API does, so that clients can use the same types
+func marshalPortsStatus(msg proto.Message) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	err := (&jsonpb.Marshaler{EmitDefaults: true}).Marshal(&buf, msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(buf.Bytes()), nil
+}
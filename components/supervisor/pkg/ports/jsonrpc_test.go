@@ -0,0 +1,117 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/gorilla/websocket"
+	"github.com/sourcegraph/jsonrpc2"
+	wsjsonrpc "github.com/sourcegraph/jsonrpc2/websocket"
+)
+
+func TestJSONRPCBridge(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	pm.mu.Lock()
+	pm.setServed([]ServedPort{{Port: 3000}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	pm.mu.Unlock()
+
+	srv := httptest.NewServer(&JSONRPCBridge{Ports: pm})
+	defer srv.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updates := make(chan *api.PortsStatusResponse, 10)
+	conn := jsonrpc2.NewConn(context.Background(), wsjsonrpc.NewObjectStream(ws), jsonrpc2.HandlerWithError(func(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (interface{}, error) {
+		if req.Method != NotificationOnDidChangePorts {
+			t.Errorf("unexpected notification %s", req.Method)
+			return nil, nil
+		}
+		var update api.PortsStatusResponse
+		err := jsonpb.UnmarshalString(string(*req.Params), &update)
+		if err != nil {
+			t.Errorf("cannot unmarshal ports update: %v", err)
+			return nil, nil
+		}
+		updates <- &update
+		return nil, nil
+	}))
+	defer conn.Close()
+
+	var status json.RawMessage
+	err = conn.Call(context.Background(), MethodGetPorts, nil, &status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snapshot api.PortsStatusResponse
+	err = jsonpb.UnmarshalString(string(status), &snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Added) != 1 || snapshot.Added[0].LocalPort != 3000 {
+		t.Errorf("unexpected ports status: %v", snapshot.Added)
+	}
+
+	var res SubscribePortsResult
+	err = conn.Call(context.Background(), MethodSubscribePorts, SubscribePortsParams{Client: "test"}, &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Resumed {
+		t.Error("unexpected resumed subscription")
+	}
+	err = conn.Call(context.Background(), MethodSubscribePorts, SubscribePortsParams{Client: "test"}, &res)
+	if err == nil {
+		t.Error("expected an error subscribing twice")
+	}
+
+	receive := func() *api.PortsStatusResponse {
+		select {
+		case update := <-updates:
+			return update
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a ports update")
+			return nil
+		}
+	}
+	if update := receive(); len(update.Added) != 1 || update.Added[0].LocalPort != 3000 {
+		t.Errorf("expected the first notification to be a snapshot, got %v", update)
+	}
+
+	pm.mu.Lock()
+	pm.setServed([]ServedPort{{Port: 3000}, {Port: 4000}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	pm.mu.Unlock()
+
+	update := receive()
+	if len(update.Added) != 1 || update.Added[0].LocalPort != 4000 {
+		t.Errorf("unexpected ports update: %v", update)
+	}
+	if update.Trigger != api.PortsUpdateTrigger_served_ports_changed {
+		t.Errorf("unexpected trigger: %v", update.Trigger)
+	}
+	if update.ResumeToken != pm.ResumeToken(update.Revision) {
+		t.Errorf("unexpected resume token %q", update.ResumeToken)
+	}
+
+	conn.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(pm.Subscribers()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription was not closed once the client disconnected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
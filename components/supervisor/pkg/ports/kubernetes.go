@@ -0,0 +1,238 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// kubernetesRefreshInterval is the minimum time between two queries of the services of a cluster
+	kubernetesRefreshInterval = 30 * time.Second
+	// kubernetesQueryTimeout limits the time a query of the services of a cluster may take
+	kubernetesQueryTimeout = 10 * time.Second
+)
+
+// KubernetesDetector labels ports which belong to services of a Kubernetes cluster running in the workspace,
+// e.g. k3s or kind. Ports are attributed to services either because they are listened on by
+// kubectl port-forward, or because they are the node port of a service in the cluster.
+type KubernetesDetector struct {
+	// Kubeconfigs are the locations of kubeconfig files, the first one which exists is used
+	Kubeconfigs []string
+
+	procDir string
+	query   func(ctx context.Context, kubeconfig string) ([]byte, error)
+	now     func() time.Time
+
+	// forwarded caches the port-forward label of sockets, the empty string if the owner is no port-forward
+	forwarded map[uint64]string
+
+	mu         sync.Mutex
+	nodePorts  map[uint32]string
+	lastQuery  time.Time
+	refreshing bool
+}
+
+// NewKubernetesDetector creates a new detector which queries the cluster of the first existing kubeconfig
+func NewKubernetesDetector(kubeconfigs ...string) *KubernetesDetector {
+	return &KubernetesDetector{
+		Kubeconfigs: kubeconfigs,
+		procDir:     "/proc",
+		query:       queryKubernetesServices,
+		now:         time.Now,
+		forwarded:   make(map[uint64]string),
+	}
+}
+
+// Kubeconfigs lists the locations local clusters write their kubeconfig to
+func Kubeconfigs() []string {
+	var res []string
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		res = append(res, filepath.SplitList(env)...)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		// kind
+		res = append(res, filepath.Join(home, ".kube", "config"))
+	}
+	// k3s
+	return append(res, "/etc/rancher/k3s/k3s.yaml")
+}
+
+// label sets KubernetesService of the served ports. The PIDs of the sockets have to be resolved already.
+func (d *KubernetesDetector) label(sockets []servedSocket) {
+	current := make(map[uint64]struct{}, len(sockets))
+	var unlabeled bool
+	for i := range sockets {
+		socket := &sockets[i]
+		current[socket.Inode] = struct{}{}
+		service, cached := d.forwarded[socket.Inode]
+		if !cached {
+			if socket.PID != 0 {
+				service = d.portForwardOf(socket.PID)
+			}
+			d.forwarded[socket.Inode] = service
+		}
+		if service == "" {
+			// services can be created after their node port is listened on, hence node ports are never cached per socket
+			service = d.nodePort(socket.Port)
+		}
+		if service == "" {
+			unlabeled = true
+		}
+		socket.KubernetesService = service
+	}
+	for inode := range d.forwarded {
+		if _, exists := current[inode]; !exists {
+			delete(d.forwarded, inode)
+		}
+	}
+	if unlabeled {
+		d.refresh()
+	}
+}
+
+func (d *KubernetesDetector) nodePort(port uint32) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.nodePorts[port]
+}
+
+// refresh queries the node ports of the cluster in the background, at most once per refresh interval.
+// Served ports are observed by polling, hence the result is picked up by the next poll.
+func (d *KubernetesDetector) refresh() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.refreshing || d.now().Sub(d.lastQuery) < kubernetesRefreshInterval {
+		return
+	}
+	kubeconfig := d.kubeconfig()
+	if kubeconfig == "" {
+		return
+	}
+	d.refreshing = true
+	d.lastQuery = d.now()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), kubernetesQueryTimeout)
+		defer cancel()
+
+		var nodePorts map[uint32]string
+		out, err := d.query(ctx, kubeconfig)
+		if err == nil {
+			nodePorts, err = parseKubernetesNodePorts(out)
+		}
+		if err != nil {
+			log.WithError(err).WithField("kubeconfig", kubeconfig).Debug("cannot query Kubernetes services")
+		}
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.refreshing = false
+		if err == nil {
+			d.nodePorts = nodePorts
+		}
+	}()
+}
+
+func (d *KubernetesDetector) kubeconfig() string {
+	for _, fn := range d.Kubeconfigs {
+		if _, err := os.Stat(fn); err == nil {
+			return fn
+		}
+	}
+	return ""
+}
+
+// portForwardOf returns the service forwarded by the process if it is a kubectl port-forward
+func (d *KubernetesDetector) portForwardOf(pid int) string {
+	cmdline, err := ioutil.ReadFile(filepath.Join(d.procDir, strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return ""
+	}
+	return parsePortForward(strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00"))
+}
+
+// parsePortForward parses the arguments of kubectl port-forward and returns the forwarded resource
+// as <namespace>/<name>, or an empty string if the arguments are not a port-forward.
+func parsePortForward(args []string) string {
+	if len(args) == 0 || filepath.Base(args[0]) != "kubectl" {
+		return ""
+	}
+	var (
+		namespace  = "default"
+		positional []string
+	)
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "-n" || arg == "--namespace":
+			if i+1 < len(args) {
+				namespace = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(arg, "--namespace="):
+			namespace = strings.TrimPrefix(arg, "--namespace=")
+		case strings.HasPrefix(arg, "-n") && len(arg) > 2 && !strings.HasPrefix(arg, "--"):
+			namespace = strings.TrimPrefix(strings.TrimPrefix(arg, "-n"), "=")
+		case strings.HasPrefix(arg, "-"):
+			// other flags of kubectl, e.g. --address, are passed as --flag=value
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) < 2 || positional[0] != "port-forward" {
+		return ""
+	}
+	// resources are either TYPE/NAME or a pod NAME
+	resource := positional[1]
+	if i := strings.LastIndex(resource, "/"); i >= 0 {
+		resource = resource[i+1:]
+	}
+	return namespace + "/" + resource
+}
+
+// parseKubernetesNodePorts reads the node ports from a list of services as printed by kubectl -o json
+func parseKubernetesNodePorts(content []byte) (map[uint32]string, error) {
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Spec struct {
+				Ports []struct {
+					NodePort uint32 `json:"nodePort"`
+				} `json:"ports"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	err := json.Unmarshal(content, &list)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[uint32]string)
+	for _, svc := range list.Items {
+		for _, port := range svc.Spec.Ports {
+			if port.NodePort == 0 {
+				continue
+			}
+			res[port.NodePort] = svc.Metadata.Namespace + "/" + svc.Metadata.Name
+		}
+	}
+	return res, nil
+}
+
+func queryKubernetesServices(ctx context.Context, kubeconfig string) ([]byte, error) {
+	return exec.CommandContext(ctx, "kubectl", "--kubeconfig", kubeconfig, "get", "services", "--all-namespaces", "-o", "json").Output()
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePortForward(t *testing.T) {
+	tests := []struct {
+		Args        []string
+		Expectation string
+	}{
+		{Args: []string{"kubectl", "port-forward", "svc/web", "8080:80"}, Expectation: "default/web"},
+		{Args: []string{"/usr/local/bin/kubectl", "-n", "shop", "port-forward", "service/cart", "3000"}, Expectation: "shop/cart"},
+		{Args: []string{"kubectl", "port-forward", "--namespace=monitoring", "--address=0.0.0.0", "grafana-0", "3000"}, Expectation: "monitoring/grafana-0"},
+		{Args: []string{"kubectl", "port-forward", "-nkube-system", "deployment/coredns", "5353:53"}, Expectation: "kube-system/coredns"},
+		{Args: []string{"kubectl", "proxy"}},
+		{Args: []string{"node", "port-forward", "svc/web"}},
+		{Args: []string{}},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.Args, " "), func(t *testing.T) {
+			act := parsePortForward(test.Args)
+			if act != test.Expectation {
+				t.Errorf("unexpected result: want %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
+
+func TestParseKubernetesNodePorts(t *testing.T) {
+	content := `{"items": [
+		{"metadata": {"name": "web", "namespace": "default"}, "spec": {"ports": [{"port": 80, "nodePort": 30080}, {"port": 443, "nodePort": 30443}]}},
+		{"metadata": {"name": "db", "namespace": "data"}, "spec": {"ports": [{"port": 5432}]}}
+	]}`
+	act, err := parseKubernetesNodePorts([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectation := map[uint32]string{30080: "default/web", 30443: "default/web"}
+	if diff := cmp.Diff(expectation, act); diff != "" {
+		t.Errorf("unexpected node ports (-want +got):\n%s", diff)
+	}
+}
+
+func TestKubernetesDetector(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubernetes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	err = ioutil.WriteFile(kubeconfig, []byte("apiVersion: v1\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	procDir := filepath.Join(dir, "proc")
+	for pid, cmdline := range map[int]string{
+		10: "kubectl\x00port-forward\x00svc/web\x008080:80\x00",
+		20: "k3s\x00server\x00",
+	} {
+		err := os.MkdirAll(filepath.Join(procDir, strconv.Itoa(pid)), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(filepath.Join(procDir, strconv.Itoa(pid), "cmdline"), []byte(cmdline), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queried := make(chan string, 1)
+	d := NewKubernetesDetector(filepath.Join(dir, "does-not-exist"), kubeconfig)
+	d.procDir = procDir
+	d.query = func(ctx context.Context, kubeconfig string) ([]byte, error) {
+		defer func() { queried <- kubeconfig }()
+		return []byte(`{"items": [{"metadata": {"name": "api", "namespace": "default"}, "spec": {"ports": [{"nodePort": 30080}]}}]}`), nil
+	}
+	sockets := func() []servedSocket {
+		return []servedSocket{
+			{ServedPort: ServedPort{Port: 8080}, Inode: 100, PID: 10},
+			{ServedPort: ServedPort{Port: 30080}, Inode: 101, PID: 20},
+			{ServedPort: ServedPort{Port: 3000}, Inode: 102},
+		}
+	}
+
+	first := sockets()
+	d.label(first)
+	if first[0].KubernetesService != "default/web" || first[1].KubernetesService != "" {
+		t.Errorf("unexpected labels before the query: %+v", first)
+	}
+	select {
+	case fn := <-queried:
+		if fn != kubeconfig {
+			t.Errorf("unexpected kubeconfig: %s", fn)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("cluster was not queried")
+	}
+	// wait for the query result to be stored
+	for i := 0; i < 100 && d.nodePort(30080) == ""; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	second := sockets()
+	d.label(second)
+	expectation := []servedSocket{
+		{ServedPort: ServedPort{Port: 8080, KubernetesService: "default/web"}, Inode: 100, PID: 10},
+		{ServedPort: ServedPort{Port: 30080, KubernetesService: "default/api"}, Inode: 101, PID: 20},
+		{ServedPort: ServedPort{Port: 3000}, Inode: 102},
+	}
+	if diff := cmp.Diff(expectation, second); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+	select {
+	case <-queried:
+		t.Error("cluster was queried again within the refresh interval")
+	default:
+	}
+}
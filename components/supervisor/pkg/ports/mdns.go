@@ -0,0 +1,300 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// mdnsTTL is the time to live of announced records in seconds
+	mdnsTTL = 120
+	// mdnsServiceType is the DNS-SD service type served ports are announced as
+	mdnsServiceType = "_http._tcp.local."
+	// mdnsServicesEnumeration lists the service types, see RFC 6763 section 9
+	mdnsServicesEnumeration = "_services._dns-sd._udp.local."
+)
+
+var mdnsGroup = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// MDNSAnnouncer announces the served ports as DNS-SD services via multicast DNS,
+// so that tools which discover services in the network find the dev servers of the workspace.
+type MDNSAnnouncer struct {
+	// Hostname is announced as <Hostname>.local
+	Hostname string
+	// IP is the address the services are reachable at
+	IP net.IP
+
+	services map[uint32]mdnsService
+	conn     *net.UDPConn
+	mu       sync.RWMutex
+}
+
+type mdnsService struct {
+	Port uint32
+	Name string
+}
+
+// instance is the DNS-SD service instance name
+func (s mdnsService) instance() string {
+	return s.Name + "." + mdnsServiceType
+}
+
+// Run announces the served ports of the port manager until ctx is done
+func (a *MDNSAnnouncer) Run(ctx context.Context, pm *Manager) error {
+	if a.IP == nil {
+		a.IP = defaultIPv4()
+		if a.IP == nil {
+			return xerrors.Errorf("cannot find an IPv4 address to announce ports at")
+		}
+	}
+	conn, err := net.ListenMulticastUDP("udp4", nil, mdnsGroup)
+	if err != nil {
+		return xerrors.Errorf("cannot join the mDNS group: %w", err)
+	}
+	a.conn = conn
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	go a.respond()
+
+	sub := pm.Subscribe("mdns")
+	if sub == nil {
+		return xerrors.Errorf("cannot subscribe to port updates")
+	}
+	defer sub.Close()
+	log.WithField("hostname", a.host()).WithField("ip", a.IP).Info("announcing served ports via mDNS")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case diff := <-sub.Updates():
+			if diff == nil {
+				return nil
+			}
+			added, removed := a.apply(diff)
+			if len(added) > 0 {
+				a.send(a.announcement(added, mdnsTTL), mdnsGroup)
+			}
+			if len(removed) > 0 {
+				// goodbye packets, see RFC 6762 section 10.1
+				a.send(a.announcement(removed, 0), mdnsGroup)
+			}
+		}
+	}
+}
+
+// apply updates the announced services from a port status diff
+func (a *MDNSAnnouncer) apply(diff *Diff) (added, removed []mdnsService) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.services == nil {
+		a.services = make(map[uint32]mdnsService)
+	}
+	remove := func(port uint32) {
+		if s, exists := a.services[port]; exists {
+			removed = append(removed, s)
+			delete(a.services, port)
+		}
+	}
+	for _, status := range append(diff.Added, diff.Updated...) {
+		if !status.Served {
+			remove(status.LocalPort)
+			continue
+		}
+		name := status.DetectedAs
+		if name == "" {
+			name = "port"
+		}
+		s := mdnsService{
+			Port: status.LocalPort,
+			// instance names are a single label
+			Name: strings.ReplaceAll(fmt.Sprintf("%s-%d", name, status.LocalPort), ".", "-"),
+		}
+		if prev, exists := a.services[s.Port]; exists && prev == s {
+			continue
+		}
+		remove(s.Port)
+		a.services[s.Port] = s
+		added = append(added, s)
+	}
+	for _, port := range diff.Removed {
+		remove(port)
+	}
+	return added, removed
+}
+
+func (a *MDNSAnnouncer) respond() {
+	buf := make([]byte, 9000)
+	for {
+		n, src, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		var query dnsmessage.Message
+		err = query.Unpack(buf[:n])
+		if err != nil || query.Header.Response {
+			continue
+		}
+		resp, ok := a.answer(query)
+		if !ok {
+			continue
+		}
+		if src.Port != mdnsGroup.Port {
+			// legacy unicast queries get a unicast response, see RFC 6762 section 6.7
+			resp.Header.ID = query.Header.ID
+			resp.Questions = query.Questions
+			a.send(resp, src)
+			continue
+		}
+		a.send(resp, mdnsGroup)
+	}
+}
+
+// answer produces the response to an mDNS query, returns false if there is nothing to answer
+func (a *MDNSAnnouncer) answer(query dnsmessage.Message) (dnsmessage.Message, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	services := a.sortedServices()
+	resp := dnsmessage.Message{Header: dnsmessage.Header{Response: true, Authoritative: true}}
+	for _, q := range query.Questions {
+		name := q.Name.String()
+		any := q.Type == dnsmessage.TypeALL
+		switch {
+		case strings.EqualFold(name, mdnsServicesEnumeration) && (any || q.Type == dnsmessage.TypePTR):
+			if len(services) > 0 {
+				resp.Answers = append(resp.Answers, a.ptr(mdnsServicesEnumeration, mdnsServiceType, mdnsTTL))
+			}
+		case strings.EqualFold(name, mdnsServiceType) && (any || q.Type == dnsmessage.TypePTR):
+			for _, s := range services {
+				resp.Answers = append(resp.Answers, a.ptr(mdnsServiceType, s.instance(), mdnsTTL))
+				resp.Additionals = append(resp.Additionals, a.srv(s, mdnsTTL), a.txt(s, mdnsTTL))
+			}
+			if len(services) > 0 {
+				resp.Additionals = append(resp.Additionals, a.a(mdnsTTL))
+			}
+		case strings.EqualFold(name, a.host()) && (any || q.Type == dnsmessage.TypeA):
+			resp.Answers = append(resp.Answers, a.a(mdnsTTL))
+		default:
+			for _, s := range services {
+				if !strings.EqualFold(name, s.instance()) {
+					continue
+				}
+				if any || q.Type == dnsmessage.TypeSRV {
+					resp.Answers = append(resp.Answers, a.srv(s, mdnsTTL))
+					resp.Additionals = append(resp.Additionals, a.a(mdnsTTL))
+				}
+				if any || q.Type == dnsmessage.TypeTXT {
+					resp.Answers = append(resp.Answers, a.txt(s, mdnsTTL))
+				}
+			}
+		}
+	}
+	return resp, len(resp.Answers) > 0
+}
+
+// announcement produces an unsolicited response announcing services, or retracting them if the ttl is zero
+func (a *MDNSAnnouncer) announcement(services []mdnsService, ttl uint32) dnsmessage.Message {
+	resp := dnsmessage.Message{Header: dnsmessage.Header{Response: true, Authoritative: true}}
+	for _, s := range services {
+		resp.Answers = append(resp.Answers, a.ptr(mdnsServiceType, s.instance(), ttl), a.srv(s, ttl), a.txt(s, ttl))
+	}
+	if ttl > 0 {
+		resp.Answers = append(resp.Answers, a.a(ttl))
+	}
+	return resp
+}
+
+func (a *MDNSAnnouncer) send(msg dnsmessage.Message, dst *net.UDPAddr) {
+	b, err := msg.Pack()
+	if err != nil {
+		log.WithError(err).Debug("cannot pack mDNS message")
+		return
+	}
+	_, err = a.conn.WriteToUDP(b, dst)
+	if err != nil {
+		log.WithError(err).Debug("cannot send mDNS message")
+	}
+}
+
+func (a *MDNSAnnouncer) sortedServices() []mdnsService {
+	res := make([]mdnsService, 0, len(a.services))
+	for _, s := range a.services {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Port < res[j].Port })
+	return res
+}
+
+func (a *MDNSAnnouncer) host() string {
+	return strings.ReplaceAll(a.Hostname, ".", "-") + ".local."
+}
+
+func (a *MDNSAnnouncer) ptr(name, target string, ttl uint32) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: mdnsHeader(name, dnsmessage.TypePTR, ttl),
+		Body:   &dnsmessage.PTRResource{PTR: dnsmessage.MustNewName(target)},
+	}
+}
+
+func (a *MDNSAnnouncer) srv(s mdnsService, ttl uint32) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: mdnsHeader(s.instance(), dnsmessage.TypeSRV, ttl),
+		Body:   &dnsmessage.SRVResource{Target: dnsmessage.MustNewName(a.host()), Port: uint16(s.Port)},
+	}
+}
+
+func (a *MDNSAnnouncer) txt(s mdnsService, ttl uint32) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: mdnsHeader(s.instance(), dnsmessage.TypeTXT, ttl),
+		Body:   &dnsmessage.TXTResource{TXT: []string{"path=/"}},
+	}
+}
+
+func (a *MDNSAnnouncer) a(ttl uint32) dnsmessage.Resource {
+	var ip [4]byte
+	copy(ip[:], a.IP.To4())
+	return dnsmessage.Resource{
+		Header: mdnsHeader(a.host(), dnsmessage.TypeA, ttl),
+		Body:   &dnsmessage.AResource{A: ip},
+	}
+}
+
+func mdnsHeader(name string, typ dnsmessage.Type, ttl uint32) dnsmessage.ResourceHeader {
+	return dnsmessage.ResourceHeader{
+		Name:  dnsmessage.MustNewName(name),
+		Type:  typ,
+		Class: dnsmessage.ClassINET,
+		TTL:   ttl,
+	}
+}
+
+// defaultIPv4 returns the first IPv4 address which is not a loopback address
+func defaultIPv4() net.IP {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		ipnet, ok := addr.(*net.IPNet)
+		if !ok || ipnet.IP.IsLoopback() || ipnet.IP.To4() == nil {
+			continue
+		}
+		return ipnet.IP.To4()
+	}
+	return nil
+}
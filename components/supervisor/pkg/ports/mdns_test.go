@@ -0,0 +1,116 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestMDNSAnswer(t *testing.T) {
+	a := &MDNSAnnouncer{Hostname: "ws-1", IP: net.IPv4(10, 0, 0, 2)}
+	added, _ := a.apply(&Diff{Added: []*api.PortsStatus{
+		{LocalPort: 3000, Served: true, DetectedAs: "vite"},
+		{LocalPort: 8080, Served: true},
+		{LocalPort: 9000},
+	}})
+	if len(added) != 2 {
+		t.Fatalf("expected two services to be announced, got %v", added)
+	}
+
+	tests := []struct {
+		Desc        string
+		Name        string
+		Type        dnsmessage.Type
+		Expectation []string
+	}{
+		{
+			Desc:        "service types",
+			Name:        "_services._dns-sd._udp.local.",
+			Type:        dnsmessage.TypePTR,
+			Expectation: []string{"_services._dns-sd._udp.local. PTR _http._tcp.local."},
+		},
+		{
+			Desc: "service instances",
+			Name: "_http._tcp.local.",
+			Type: dnsmessage.TypePTR,
+			Expectation: []string{
+				"_http._tcp.local. PTR vite-3000._http._tcp.local.",
+				"_http._tcp.local. PTR port-8080._http._tcp.local.",
+			},
+		},
+		{
+			Desc: "service instance",
+			Name: "Vite-3000._http._tcp.local.",
+			Type: dnsmessage.TypeALL,
+			Expectation: []string{
+				"vite-3000._http._tcp.local. SRV ws-1.local.:3000",
+				"vite-3000._http._tcp.local. TXT [path=/]",
+			},
+		},
+		{
+			Desc:        "host",
+			Name:        "ws-1.local.",
+			Type:        dnsmessage.TypeA,
+			Expectation: []string{"ws-1.local. A 10.0.0.2"},
+		},
+		{
+			Desc: "unknown",
+			Name: "_ipp._tcp.local.",
+			Type: dnsmessage.TypePTR,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			resp, ok := a.answer(dnsmessage.Message{Questions: []dnsmessage.Question{{
+				Name:  dnsmessage.MustNewName(test.Name),
+				Type:  test.Type,
+				Class: dnsmessage.ClassINET,
+			}}})
+			if ok != (len(test.Expectation) > 0) {
+				t.Errorf("unexpected answer: %v", ok)
+			}
+
+			var act []string
+			for _, r := range resp.Answers {
+				var v string
+				switch b := r.Body.(type) {
+				case *dnsmessage.PTRResource:
+					v = b.PTR.String()
+				case *dnsmessage.SRVResource:
+					v = fmt.Sprintf("%s:%d", b.Target, b.Port)
+				case *dnsmessage.TXTResource:
+					v = fmt.Sprint(b.TXT)
+				case *dnsmessage.AResource:
+					v = net.IP(b.A[:]).String()
+				}
+				act = append(act, fmt.Sprintf("%s %s %s", r.Header.Name, r.Header.Type.String()[4:], v))
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected answers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMDNSApply(t *testing.T) {
+	a := &MDNSAnnouncer{Hostname: "ws-1", IP: net.IPv4(10, 0, 0, 2)}
+	a.apply(&Diff{Added: []*api.PortsStatus{{LocalPort: 3000, Served: true}, {LocalPort: 8080, Served: true}}})
+
+	added, removed := a.apply(&Diff{
+		Updated: []*api.PortsStatus{{LocalPort: 3000, Served: true, DetectedAs: "vite"}, {LocalPort: 8080}},
+	})
+	if diff := cmp.Diff([]mdnsService{{Port: 3000, Name: "vite-3000"}}, added); diff != "" {
+		t.Errorf("unexpected added services (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]mdnsService{{Port: 3000, Name: "port-3000"}, {Port: 8080, Name: "port-8080"}}, removed); diff != "" {
+		t.Errorf("unexpected removed services (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// containerIDRegexp matches the ID of a container in the cgroup paths of its processes
+var containerIDRegexp = regexp.MustCompile("[0-9a-f]{64}")
+
+// NamespaceDetector reports ports served in network namespaces other than supervisor's, e.g. in containers
+// or in VMs with user-mode networking. Such ports are labeled with their namespace. They are auto-exposed only
+// if their namespace is selected, since they are reachable from the workspace only if the namespace forwards them.
+type NamespaceDetector struct {
+	// AutoExpose are patterns of the labels of the namespaces whose ports are auto-exposed, e.g. "qemu*" or "*".
+	// See path.Match for the pattern syntax.
+	AutoExpose []string
+
+	procDir string
+}
+
+// NewNamespaceDetector creates a namespace detector which auto-exposes the ports of the namespaces matching the patterns
+func NewNamespaceDetector(autoExpose ...string) *NamespaceDetector {
+	return &NamespaceDetector{
+		AutoExpose: autoExpose,
+		procDir:    "/proc",
+	}
+}
+
+// networkNamespace is a network namespace and the process its ports are read from
+type networkNamespace struct {
+	Inode uint64
+	PID   int
+	// Label identifies the namespace to users: the short ID of the container it belongs to,
+	// or otherwise the command name of its first process
+	Label string
+}
+
+// sockets lists the listening sockets of the other network namespaces. Ports which are served in supervisor's
+// namespace already, or in another namespace listed earlier, are skipped, since ports are told apart by number only.
+func (d *NamespaceDetector) sockets(own []servedSocket) []servedSocket {
+	taken := make(map[uint32]struct{}, len(own))
+	for _, s := range own {
+		taken[s.Port] = struct{}{}
+	}
+
+	var res []servedSocket
+	for _, ns := range d.namespaces() {
+		autoExpose := d.autoExposes(ns.Label)
+		for _, fn := range []string{"tcp", "tcp6"} {
+			fc, err := os.Open(filepath.Join(d.procDir, strconv.Itoa(ns.PID), "net", fn))
+			if err != nil {
+				// the process may have exited in the meantime
+				log.WithError(err).WithField("namespace", ns.Label).Debug("cannot read served ports of network namespace")
+				continue
+			}
+			sockets, err := readNetTCPSockets(fc, true)
+			fc.Close()
+			if err != nil {
+				log.WithError(err).WithField("namespace", ns.Label).Debug("cannot read served ports of network namespace")
+				continue
+			}
+			for _, s := range sockets {
+				if _, exists := taken[s.Port]; exists {
+					continue
+				}
+				taken[s.Port] = struct{}{}
+				s.Namespace = ns.Label
+				s.NamespaceInternal = !autoExpose
+				res = append(res, s)
+			}
+		}
+	}
+	return res
+}
+
+// namespaces lists the network namespaces of the processes other than supervisor's, ordered by their inode
+func (d *NamespaceDetector) namespaces() []networkNamespace {
+	own, err := namespaceInode(filepath.Join(d.procDir, "self"))
+	if err != nil {
+		log.WithError(err).Debug("cannot read the network namespace of supervisor")
+		return nil
+	}
+	dirs, err := filepath.Glob(filepath.Join(d.procDir, "[0-9]*"))
+	if err != nil {
+		log.WithError(err).Debug("cannot list processes")
+		return nil
+	}
+
+	byInode := make(map[uint64]*networkNamespace)
+	for _, dir := range dirs {
+		pid, err := strconv.Atoi(filepath.Base(dir))
+		if err != nil {
+			continue
+		}
+		inode, err := namespaceInode(dir)
+		if err != nil || inode == own {
+			continue
+		}
+		if ns, exists := byInode[inode]; exists && ns.PID < pid {
+			continue
+		}
+		byInode[inode] = &networkNamespace{Inode: inode, PID: pid}
+	}
+
+	res := make([]networkNamespace, 0, len(byInode))
+	for _, ns := range byInode {
+		ns.Label = namespaceLabel(d.procDir, ns.PID)
+		res = append(res, *ns)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Inode < res[j].Inode })
+	return res
+}
+
+// autoExposes returns true if the ports of the namespace with the label are auto-exposed
+func (d *NamespaceDetector) autoExposes(label string) bool {
+	for _, pattern := range d.AutoExpose {
+		if matched, _ := path.Match(pattern, label); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceInode reads the inode of the network namespace of a process from the /proc/<pid>/ns/net link, e.g. net:[4026531992]
+func namespaceInode(processDir string) (uint64, error) {
+	target, err := os.Readlink(filepath.Join(processDir, "ns", "net"))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(target, "net:["), "]"), 10, 64)
+}
+
+// namespaceLabel labels the network namespace of a process with the short ID of its container if the process
+// runs in one, or with its command name otherwise
+func namespaceLabel(procDir string, pid int) string {
+	cgroup, err := ioutil.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "cgroup"))
+	if err == nil {
+		if id := containerIDRegexp.Find(cgroup); id != nil {
+			return string(id[:12])
+		}
+	}
+	if name := processName(procDir, pid); name != "" {
+		return name
+	}
+	return strconv.Itoa(pid)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNamespaceDetector(t *testing.T) {
+	type Process struct {
+		PID       int
+		Namespace uint64
+		Comm      string
+		Cgroup    string
+		Listening []uint32
+	}
+	const containerID = "4b825dc642cb6eb9a060e54bf8d69288fbee4904b825dc642cb6eb9a060e54bf"
+	tests := []struct {
+		Desc        string
+		AutoExpose  []string
+		Processes   []Process
+		Own         []uint32
+		Expectation []ServedPort
+	}{
+		{
+			Desc: "no other namespaces",
+			Processes: []Process{
+				{PID: 1, Namespace: 1, Comm: "supervisor"},
+				{PID: 10, Namespace: 1, Comm: "node", Listening: []uint32{3000}},
+			},
+			Own: []uint32{3000},
+		},
+		{
+			Desc:       "container and VM",
+			AutoExpose: []string{"qemu*"},
+			Processes: []Process{
+				{PID: 1, Namespace: 1, Comm: "supervisor"},
+				{PID: 21, Namespace: 2, Comm: "sshd"},
+				{PID: 20, Namespace: 2, Comm: "qemu-system-x86", Listening: []uint32{2222, 3000}},
+				{PID: 30, Namespace: 3, Comm: "postgres", Cgroup: "0::/docker/" + containerID + "\n", Listening: []uint32{5432}},
+			},
+			Own: []uint32{3000},
+			Expectation: []ServedPort{
+				{Port: 2222, Namespace: "qemu-system-x86"},
+				{Port: 5432, Namespace: containerID[:12], NamespaceInternal: true},
+			},
+		},
+		{
+			Desc:       "same port in two namespaces",
+			AutoExpose: []string{"*"},
+			Processes: []Process{
+				{PID: 1, Namespace: 1, Comm: "supervisor"},
+				{PID: 20, Namespace: 2, Comm: "redis-server", Listening: []uint32{6379}},
+				{PID: 30, Namespace: 3, Comm: "redis-server", Listening: []uint32{6379}},
+			},
+			Expectation: []ServedPort{
+				{Port: 6379, Namespace: "redis-server"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			procDir, err := ioutil.TempDir("", "proc")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(procDir)
+
+			link := func(dir string, ns uint64) {
+				err := os.MkdirAll(filepath.Join(dir, "ns"), 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = os.Symlink(fmt.Sprintf("net:[%d]", ns), filepath.Join(dir, "ns", "net"))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			link(filepath.Join(procDir, "self"), 1)
+			for _, p := range test.Processes {
+				dir := filepath.Join(procDir, fmt.Sprint(p.PID))
+				link(dir, p.Namespace)
+				files := map[string]string{
+					"stat":   fmt.Sprintf("%d (%s) S 1 1 1 0 -1", p.PID, p.Comm),
+					"cgroup": p.Cgroup,
+				}
+				var tcp strings.Builder
+				tcp.WriteString("  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n")
+				for i, port := range p.Listening {
+					fmt.Fprintf(&tcp, "   %d: 00000000:%04X 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 %d 1 0000000000000000 100 0 0 10 0\n", i, port, 1000+port)
+				}
+				files[filepath.Join("net", "tcp")] = tcp.String()
+				for fn, content := range files {
+					err := os.MkdirAll(filepath.Dir(filepath.Join(dir, fn)), 0755)
+					if err != nil {
+						t.Fatal(err)
+					}
+					err = ioutil.WriteFile(filepath.Join(dir, fn), []byte(content), 0644)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			var own []servedSocket
+			for _, port := range test.Own {
+				own = append(own, servedSocket{ServedPort: ServedPort{Port: port}})
+			}
+			d := &NamespaceDetector{AutoExpose: test.AutoExpose, procDir: procDir}
+			var act []ServedPort
+			for _, s := range d.sockets(own) {
+				act = append(act, s.ServedPort)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected ports (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNamespacePortsAutoExpose(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	if pm.mayAutoExpose(ServedPort{Port: 5432, Namespace: "postgres", NamespaceInternal: true}) {
+		t.Error("expected a port of a namespace which is not auto-exposed to not be auto-exposed")
+	}
+	if !pm.mayAutoExpose(ServedPort{Port: 2222, Namespace: "qemu-system-x86"}) {
+		t.Error("expected a port of an auto-exposed namespace to be auto-exposed")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"fmt"
+)
+
+// OrganizationPolicy are the port policies and defaults an organization applies to all of its workspaces.
+// Restrictions are enforced regardless of the .gitpod.yml, i.e. denied ports are never exposed, public ports
+// are exposed privately if DenyPublic is set, and LocalhostPorts "configured" cannot be relaxed.
+// OnOpen and Visibility are defaults only, which the .gitpod.yml takes precedence over.
+type OrganizationPolicy struct {
+	// DenyPublic never exposes ports publicly, regardless of their config or the user's request
+	DenyPublic bool `json:"denyPublic,omitempty"`
+	// Deny are ports and port ranges (e.g. 22 or '6000-6100') which are never exposed or proxied
+	Deny []interface{} `json:"deny,omitempty"`
+	// LocalhostPorts 'configured' only exposes services listening on localhost if their port is configured
+	LocalhostPorts string `json:"localhostPorts,omitempty"`
+	// OnOpen is the onOpen action of ports which do not configure one, including ports which are not configured at all
+	OnOpen string `json:"onOpen,omitempty"`
+	// Visibility is the visibility of configured ports which do not configure one
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// parseOrganizationPolicy validates an organization policy and returns the policy which is applied
+func parseOrganizationPolicy(policy *OrganizationPolicy) (valid *OrganizationPolicy, denylist Denylist, diagnostics []*ConfigDiagnostic) {
+	if policy == nil {
+		return nil, nil, nil
+	}
+
+	res := *policy
+	diagnostics = validateAttributes(OrganizationConfigSource, "", policy.OnOpen, policy.Visibility, "")
+	if _, valid := validOnOpen[res.OnOpen]; !valid {
+		res.OnOpen = ""
+	}
+	if _, valid := validVisibility[res.Visibility]; !valid {
+		res.Visibility = ""
+	}
+	if res.LocalhostPorts != "" && res.LocalhostPorts != string(LocalhostPortsAuto) && res.LocalhostPorts != string(LocalhostPortsConfigured) {
+		diagnostics = append(diagnostics, &ConfigDiagnostic{
+			Source:  OrganizationConfigSource,
+			Message: fmt.Sprintf("unknown localhostPorts value %q, ignoring it", res.LocalhostPorts),
+		})
+		res.LocalhostPorts = ""
+	}
+	denylist, denylistDiagnostics := parseConfigDenylist(OrganizationConfigSource, res.Deny)
+	diagnostics = append(diagnostics, denylistDiagnostics...)
+	return &res, denylist, diagnostics
+}
+
+// PublicDenied returns true if the organization never exposes ports publicly
+func (configs *Configs) PublicDenied() bool {
+	return configs != nil && configs.organizationPolicy != nil && configs.organizationPolicy.DenyPublic
+}
+
+// DefaultOnOpen returns the onOpen action of ports which do not configure one, or an empty string if there is no default
+func (configs *Configs) DefaultOnOpen() string {
+	if configs == nil || configs.organizationPolicy == nil {
+		return ""
+	}
+	return configs.organizationPolicy.OnOpen
+}
+
+// withDefaults returns the match with the organization defaults applied to the attributes the config does not set
+func (configs *Configs) withDefaults(match *ConfigMatch) *ConfigMatch {
+	org := configs.organizationPolicy
+	if org == nil {
+		return match
+	}
+	defaultOnOpen := match.Config.OnOpen == "" && org.OnOpen != ""
+	defaultVisibility := match.Config.Visibility == "" && org.Visibility != ""
+	if !defaultOnOpen && !defaultVisibility {
+		return match
+	}
+
+	config := *match.Config
+	if defaultOnOpen {
+		config.OnOpen = org.OnOpen
+	}
+	if defaultVisibility {
+		config.Visibility = org.Visibility
+	}
+	res := *match
+	res.Config = &config
+	return &res
+}
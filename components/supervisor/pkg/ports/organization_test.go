@@ -0,0 +1,152 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOrganizationPolicyUpdates(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Policy      *OrganizationPolicy
+		Expectation *OrganizationPolicy
+		Denied      []uint32
+		Diagnostics []*ConfigDiagnostic
+	}{
+		{
+			Desc:        "valid policy",
+			Policy:      &OrganizationPolicy{DenyPublic: true, Deny: []interface{}{22, "6000-6100"}, LocalhostPorts: "configured", OnOpen: "ignore", Visibility: "private"},
+			Expectation: &OrganizationPolicy{DenyPublic: true, Deny: []interface{}{22, "6000-6100"}, LocalhostPorts: "configured", OnOpen: "ignore", Visibility: "private"},
+			Denied:      []uint32{22, 6050},
+		},
+		{
+			Desc:        "invalid values are ignored",
+			Policy:      &OrganizationPolicy{Deny: []interface{}{"ssh"}, LocalhostPorts: "never", OnOpen: "popup", Visibility: "secret"},
+			Expectation: &OrganizationPolicy{Deny: []interface{}{"ssh"}},
+			Diagnostics: []*ConfigDiagnostic{
+				{Source: OrganizationConfigSource, Message: "unknown onOpen value \"popup\", falling back to notify"},
+				{Source: OrganizationConfigSource, Message: "unknown visibility value \"secret\", falling back to public"},
+				{Source: OrganizationConfigSource, Message: "unknown localhostPorts value \"never\", ignoring it"},
+				{Source: OrganizationConfigSource, Port: "ssh", Message: "ignoring denied port: expected a number (e.g. 1337) or a range (e.g. 3000-3999)"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			configService := &testGitpodConfigService{
+				configs: make(chan *gitpod.GitpodConfig),
+				errors:  make(chan error),
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+			gitpodAPI.EXPECT().GetWorkspace(gomock.Any(), "test").Times(1).Return(&gitpod.WorkspaceInfo{
+				Workspace: &gitpod.Workspace{Config: &gitpod.WorkspaceConfig{}},
+			}, nil)
+
+			service := NewConfigService("test", configService, gitpodAPI)
+			updates, errors := service.Observe(ctx)
+			// skip the workspace configs
+			<-updates
+
+			service.UpdateOrganizationPolicy(test.Policy)
+
+			var change *Configs
+			select {
+			case err := <-errors:
+				t.Fatal(err)
+			case change = <-updates:
+			}
+			if diff := cmp.Diff(test.Expectation, change.organizationPolicy); diff != "" {
+				t.Errorf("unexpected policy (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.Diagnostics, change.Diagnostics()); diff != "" {
+				t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+			}
+			for _, port := range test.Denied {
+				if !change.OrganizationDenied(port) || !change.Denied(port) {
+					t.Errorf("expected port %d to be denied", port)
+				}
+			}
+		})
+	}
+}
+
+func TestOrganizationDenyPublic(t *testing.T) {
+	exposed := &testExposedPorts{}
+	pm := NewManager(exposed, &testServedPorts{}, &testConfigService{})
+	pm.RequirePublicApproval = true
+
+	configs := &Configs{}
+	configs.workspaceConfigs, _ = parseWorkspaceConfigs([]*gitpod.PortConfig{
+		{Port: 3000, Visibility: "public"},
+		{Port: 4000},
+	})
+	configs.organizationPolicy, configs.organizationDenylist, _ = parseOrganizationPolicy(&OrganizationPolicy{
+		DenyPublic: true,
+		Deny:       []interface{}{5000},
+		OnOpen:     "ignore",
+	})
+	pm.mu.Lock()
+	pm.setConfigs(configs)
+	pm.setServed([]ServedPort{{Port: 3000}, {Port: 4000}, {Port: 5000}, {Port: 8080}})
+	pm.setExposed([]ExposedPort{{LocalPort: 4000, GlobalPort: 4000, Public: true, URL: "4000-url"}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_port_configs_changed)
+	pm.mu.Unlock()
+
+	exposed.mu.Lock()
+	exposures := make(map[uint32]ExposeOptions)
+	for port, opts := range exposed.Options {
+		exposures[port] = opts
+	}
+	exposed.mu.Unlock()
+	if diff := cmp.Diff(map[uint32]ExposeOptions{3000: {}, 4000: {}, 8080: {}}, exposures); diff != "" {
+		t.Errorf("expected all ports to be exposed privately (-want +got):\n%s", diff)
+	}
+
+	type portStatus struct {
+		Visibility      api.PortVisibility
+		OnExposed       api.OnPortExposedAction
+		PolicyViolation string
+		PendingPublic   bool
+	}
+	act := make(map[uint32]portStatus)
+	for _, status := range pm.Status() {
+		s := portStatus{PolicyViolation: status.PolicyViolation, PendingPublic: status.PendingPublic}
+		if status.Exposed != nil {
+			s.Visibility = status.Exposed.Visibility
+			s.OnExposed = status.Exposed.OnExposed
+		}
+		act[status.LocalPort] = s
+	}
+	expectation := map[uint32]portStatus{
+		// the public exposure shows until it is replaced with the private one
+		4000: {Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_ignore},
+		5000: {PolicyViolation: "port 5000 is denied by the organization"},
+	}
+	for port := range expectation {
+		if diff := cmp.Diff(expectation[port], act[port]); diff != "" {
+			t.Errorf("unexpected status of port %d (-want +got):\n%s", port, diff)
+		}
+	}
+	if act[3000].PendingPublic {
+		t.Errorf("expected port 3000 not to wait for approval to become public")
+	}
+
+	err := pm.ApprovePublic(context.Background(), 3000, true)
+	if err == nil {
+		t.Errorf("expected an error approving a public port")
+	}
+}
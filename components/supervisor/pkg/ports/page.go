@@ -0,0 +1,130 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// StatusPage serves a page listing the ports of the workspace with their URL, visibility and the process serving them,
+// for users who have no ports view in their IDE, e.g. in an ssh session. Browsers get HTML, other clients
+// such as curl get a plain text table.
+type StatusPage struct {
+	Ports *Manager
+}
+
+// statusPageRow is a port as listed on the status page
+type statusPageRow struct {
+	Port       uint32
+	Name       string
+	State      string
+	Visibility string
+	URL        string
+	Owner      string
+}
+
+var statusPageTemplate = template.Must(template.New("ports").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="5">
+<title>Ports</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { text-align: left; padding: 0.3em 1em; border-bottom: 1px solid #ddd; }
+</style>
+</head>
+<body>
+<h1>Ports</h1>
+{{- if . }}
+<table>
+<tr><th>Port</th><th>Name</th><th>State</th><th>Visibility</th><th>URL</th><th>Process</th></tr>
+{{- range . }}
+<tr><td>{{ .Port }}</td><td>{{ .Name }}</td><td>{{ .State }}</td><td>{{ .Visibility }}</td><td>{{ if .URL }}<a href="{{ .URL }}" target="_blank">{{ .URL }}</a>{{ end }}</td><td>{{ .Owner }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>No ports are served or exposed.</p>
+{{- end }}
+</body>
+</html>
+`))
+
+// ServeHTTP serves the status page
+func (p *StatusPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	rows := statusPageRows(p.Ports.Status())
+	if strings.Contains(r.Header.Get("Accept"), "text/html") {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err := statusPageTemplate.Execute(w, rows)
+		if err != nil {
+			log.WithError(err).Debug("cannot serve ports status page")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PORT\tNAME\tSTATE\tVISIBILITY\tURL\tPROCESS")
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", row.Port, row.Name, row.State, row.Visibility, row.URL, row.Owner)
+	}
+	tw.Flush()
+}
+
+func statusPageRows(ports []*api.PortsStatus) []statusPageRow {
+	rows := make([]statusPageRow, 0, len(ports))
+	for _, port := range ports {
+		row := statusPageRow{
+			Port:  port.LocalPort,
+			Name:  port.Name,
+			State: "not served",
+			Owner: port.Process,
+		}
+		if port.Served {
+			row.State = "served"
+		}
+		if port.PendingExposure {
+			row.State += ", pending exposure"
+		}
+		if port.PrivilegedRemap {
+			row.State += fmt.Sprintf(", remapped to %d", port.GlobalPort)
+		}
+		if suggestion := port.RemapSuggestion; suggestion != nil {
+			row.State += fmt.Sprintf(", global port %d is taken", suggestion.ConfiguredPort)
+		}
+		if port.Exposed != nil {
+			row.Visibility = port.Exposed.Visibility.String()
+			row.URL = port.Exposed.Url
+		}
+		switch {
+		case port.ComposeService != "":
+			row.Owner = "compose service " + port.ComposeService
+		case port.KubernetesService != "":
+			row.Owner = "kubernetes service " + port.KubernetesService
+		case port.NetworkNamespace != "":
+			row.Owner = "network namespace " + port.NetworkNamespace
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Port < rows[j].Port })
+	return rows
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatusPage(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	configs := &Configs{}
+	configs.instancePortConfigs, configs.instanceRangeConfigs, _ = parseInstanceConfigs([]*gitpod.PortsItems{
+		{Port: 3000, Name: "api"},
+	})
+	pm.mu.Lock()
+	pm.setConfigs(configs)
+	pm.setServed([]ServedPort{{Port: 3000, Process: "node"}, {Port: 5432, Process: "postgres", ComposeService: "db"}})
+	pm.setExposed([]ExposedPort{{LocalPort: 3000, GlobalPort: 3000, URL: "https://3000-ws.gitpod.io/?a=1&b=<2>", Public: true}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_port_configs_changed)
+	pm.mu.Unlock()
+	page := &StatusPage{Ports: pm}
+
+	tests := []struct {
+		Desc        string
+		Path        string
+		Accept      string
+		Status      int
+		Expectation []string
+	}{
+		{
+			Desc:   "plain text",
+			Path:   "/",
+			Accept: "*/*",
+			Status: http.StatusOK,
+			Expectation: []string{
+				"PORT  NAME  STATE   VISIBILITY  URL                                   PROCESS",
+				"3000  api   served  public      https://3000-ws.gitpod.io/?a=1&b=<2>  node",
+				"5432        served                                                    compose service db",
+			},
+		},
+		{
+			Desc:   "html",
+			Path:   "/",
+			Accept: "text/html,application/xhtml+xml",
+			Status: http.StatusOK,
+			Expectation: []string{
+				`<tr><td>3000</td><td>api</td><td>served</td><td>public</td><td><a href="https://3000-ws.gitpod.io/?a=1&amp;b=%3c2%3e" target="_blank">https://3000-ws.gitpod.io/?a=1&amp;b=&lt;2&gt;</a></td><td>node</td></tr>`,
+				`<tr><td>5432</td><td></td><td>served</td><td></td><td></td><td>compose service db</td></tr>`,
+			},
+		},
+		{
+			Desc:   "unknown path",
+			Path:   "/foo",
+			Status: http.StatusNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			req := httptest.NewRequest("GET", test.Path, nil)
+			req.Header.Set("Accept", test.Accept)
+			rec := httptest.NewRecorder()
+			page.ServeHTTP(rec, req)
+
+			if rec.Code != test.Status {
+				t.Fatalf("unexpected status: want %d, got %d", test.Status, rec.Code)
+			}
+			if test.Status != http.StatusOK {
+				return
+			}
+			var lines []string
+			for _, line := range strings.Split(rec.Body.String(), "\n") {
+				line = strings.TrimRight(line, " ")
+				if strings.HasPrefix(line, "PORT") || strings.HasPrefix(line, "<tr><td>") || (line != "" && line[0] >= '0' && line[0] <= '9') {
+					lines = append(lines, line)
+				}
+			}
+			if diff := cmp.Diff(test.Expectation, lines); diff != "" {
+				t.Errorf("unexpected page (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// exposureRetryMinDelay is the delay before queued exposures are replayed the first time
+	exposureRetryMinDelay = 1 * time.Second
+	// exposureRetryMaxDelay caps the delay between replays while the Gitpod server stays unreachable
+	exposureRetryMaxDelay = 30 * time.Second
+)
+
+// pendingExposure is an exposure which failed because the Gitpod server was unreachable
+type pendingExposure struct {
+	Global uint32
+	Opts   ExposeOptions
+}
+
+// queueExposure queues an exposure until the Gitpod server is reachable again. A port is queued at most
+// once, later requests for the same port replace earlier ones. Callers are expected to hold mu.
+func (pm *Manager) queueExposure(port, global uint32, opts ExposeOptions) {
+	if prev, queued := pm.pendingExposures[port]; !queued || prev.Global != global || prev.Opts.Public != opts.Public {
+		log.WithField("port", port).WithField("globalPort", global).WithField("public", opts.Public).Info("Gitpod server is unreachable - queueing port exposure")
+	}
+	pm.pendingExposures[port] = pendingExposure{Global: global, Opts: opts}
+	pm.markDirty(port)
+
+	if pm.replaying {
+		return
+	}
+	pm.replaying = true
+	go pm.replayExposures()
+}
+
+// replayExposures replays the queued exposures with backoff until the queue is empty or the manager stops.
+// Updates of the exposed ports show that the Gitpod server is reachable again and trigger a replay right away.
+func (pm *Manager) replayExposures() {
+	minDelay := pm.exposureRetryDelay
+	if minDelay == 0 {
+		minDelay = exposureRetryMinDelay
+	}
+	delay := minDelay
+	for {
+		select {
+		case <-pm.stop:
+			return
+		case <-pm.replayNow:
+			delay = minDelay
+		case <-time.After(delay):
+			delay *= 2
+			if delay > exposureRetryMaxDelay {
+				delay = exposureRetryMaxDelay
+			}
+		}
+
+		pm.mu.Lock()
+		done := pm.replayPendingExposures(context.Background())
+		pm.mu.Unlock()
+		if done {
+			return
+		}
+	}
+}
+
+// replayPendingExposures makes the queued exposures until the Gitpod server turns out to be unreachable still.
+// It returns true and ends replaying if the queue is empty. Callers are expected to hold mu.
+func (pm *Manager) replayPendingExposures(ctx context.Context) (done bool) {
+	if pm.stopped {
+		pm.replaying = false
+		return true
+	}
+
+	ports := make([]uint32, 0, len(pm.pendingExposures))
+	for port := range pm.pendingExposures {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+	var replayed bool
+	for _, port := range ports {
+		pending := pm.pendingExposures[port]
+		if pm.policyViolation(port) != "" || pm.exposedAs(port, pending) {
+			// the port was denied meanwhile, or exposed by other means
+			delete(pm.pendingExposures, port)
+			pm.markDirty(port)
+			replayed = true
+			continue
+		}
+
+		exposeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err := pm.E.Expose(exposeCtx, port, pending.Global, pending.Opts)
+		cancel()
+		pm.logExposure(port, pending.Global, pending.Opts, err)
+		if xerrors.Is(err, ErrExposureUnavailable) {
+			break
+		}
+		if err != nil {
+			log.WithError(err).WithField("port", port).Warn("cannot expose queued port")
+		} else {
+			log.WithField("port", port).WithField("globalPort", pending.Global).Info("exposed queued port")
+		}
+		delete(pm.pendingExposures, port)
+		pm.markDirty(port)
+		replayed = true
+	}
+	if replayed {
+		pm.updateState(ctx, api.PortsUpdateTrigger_pending_exposures_changed)
+	}
+
+	if len(pm.pendingExposures) > 0 {
+		return false
+	}
+	pm.replaying = false
+	return true
+}
+
+// exposedAs returns true if the port is exposed with the visibility of a pending exposure already
+func (pm *Manager) exposedAs(port uint32, pending pendingExposure) bool {
+	exposed, exists := pm.exposedByPort[port]
+	return exists && exposed.GlobalPort == pending.Global && exposed.Public == pending.Opts.Public
+}
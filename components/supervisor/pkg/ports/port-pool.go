@@ -0,0 +1,99 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"time"
+)
+
+// globalPortLeaseTime is how long a global port stays leased to a local port after its proxy
+// was closed, so that a restarted service gets the same global port and URL again.
+const globalPortLeaseTime = 5 * time.Minute
+
+// globalPortLease leases a global port to the local port proxied on it
+type globalPortLease struct {
+	LocalPort uint32
+	// Expires is zero while the proxy is running
+	Expires time.Time
+}
+
+// globalPortPool hands out the global ports of localhost proxies. Ports are leased to a local port,
+// released leases are kept for the lease time and reclaimed afterwards. Ports which were served
+// directly by the user are never leased, so that proxies do not collide with user services.
+type globalPortPool struct {
+	lo, hi    uint32
+	leaseTime time.Duration
+	now       func() time.Time
+
+	leases  map[uint32]*globalPortLease
+	avoided map[uint32]struct{}
+}
+
+func newGlobalPortPool(lo, hi uint32, leaseTime time.Duration) *globalPortPool {
+	return &globalPortPool{
+		lo:        lo,
+		hi:        hi,
+		leaseTime: leaseTime,
+		now:       time.Now,
+		leases:    make(map[uint32]*globalPortLease),
+		avoided:   make(map[uint32]struct{}),
+	}
+}
+
+// lease returns a global port for the local port: the port previously leased to it if there is one,
+// otherwise the highest free port of the pool. isUsed reports ports which are taken otherwise.
+// Returns zero if the pool is exhausted.
+func (p *globalPortPool) lease(localPort uint32, isUsed func(port uint32) bool) uint32 {
+	p.reclaim()
+	for globalPort, lease := range p.leases {
+		if lease.LocalPort != localPort || isUsed(globalPort) {
+			continue
+		}
+		lease.Expires = time.Time{}
+		return globalPort
+	}
+	for port := p.hi; port >= p.lo; port-- {
+		if _, leased := p.leases[port]; leased {
+			continue
+		}
+		if _, avoided := p.avoided[port]; avoided {
+			continue
+		}
+		if isUsed(port) {
+			continue
+		}
+		p.leases[port] = &globalPortLease{LocalPort: localPort}
+		return port
+	}
+	return 0
+}
+
+// release marks the lease of a global port as expiring, e.g. once its proxy was closed
+func (p *globalPortPool) release(globalPort uint32) {
+	lease, exists := p.leases[globalPort]
+	if !exists || !lease.Expires.IsZero() {
+		return
+	}
+	lease.Expires = p.now().Add(p.leaseTime)
+}
+
+// avoid excludes a port from the pool, e.g. because the user served it directly. An existing lease is dropped.
+func (p *globalPortPool) avoid(port uint32) {
+	if port < p.lo || port > p.hi {
+		return
+	}
+	delete(p.leases, port)
+	p.avoided[port] = struct{}{}
+}
+
+// reclaim drops expired leases
+func (p *globalPortPool) reclaim() {
+	now := p.now()
+	for globalPort, lease := range p.leases {
+		if !lease.Expires.IsZero() && now.After(lease.Expires) {
+			delete(p.leases, globalPort)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGlobalPortPool(t *testing.T) {
+	type Step struct {
+		Lease   uint32
+		Used    []uint32
+		Release uint32
+		Avoid   uint32
+		Wait    time.Duration
+
+		Expectation uint32
+	}
+	tests := []struct {
+		Desc  string
+		Steps []Step
+	}{
+		{
+			Desc: "leases from the top",
+			Steps: []Step{
+				{Lease: 3000, Expectation: 60005},
+				{Lease: 8080, Expectation: 60004},
+			},
+		},
+		{
+			Desc: "skips used ports",
+			Steps: []Step{
+				{Lease: 3000, Used: []uint32{60005, 60004}, Expectation: 60003},
+			},
+		},
+		{
+			Desc: "renews the lease of a restarted service",
+			Steps: []Step{
+				{Lease: 3000, Expectation: 60005},
+				{Release: 60005},
+				{Lease: 8080, Expectation: 60004},
+				{Wait: time.Minute},
+				{Lease: 3000, Expectation: 60005},
+			},
+		},
+		{
+			Desc: "reclaims expired leases",
+			Steps: []Step{
+				{Lease: 3000, Expectation: 60005},
+				{Release: 60005},
+				{Wait: 2 * time.Minute},
+				{Lease: 8080, Expectation: 60005},
+			},
+		},
+		{
+			Desc: "avoids ports served by the user",
+			Steps: []Step{
+				{Avoid: 60005},
+				{Avoid: 80},
+				{Lease: 3000, Expectation: 60004},
+			},
+		},
+		{
+			Desc: "exhausted",
+			Steps: []Step{
+				{Lease: 3000, Used: []uint32{60002, 60003}, Expectation: 60005},
+				{Lease: 3001, Used: []uint32{60002, 60003}, Expectation: 60004},
+				{Lease: 3002, Used: []uint32{60002, 60003}, Expectation: 0},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			now := time.Unix(0, 0)
+			pool := newGlobalPortPool(60002, 60005, time.Minute)
+			pool.now = func() time.Time { return now }
+
+			for i, step := range test.Steps {
+				now = now.Add(step.Wait)
+				switch {
+				case step.Release != 0:
+					pool.release(step.Release)
+				case step.Avoid != 0:
+					pool.avoid(step.Avoid)
+				case step.Lease != 0:
+					used := make(map[uint32]struct{})
+					for _, p := range step.Used {
+						used[p] = struct{}{}
+					}
+					act := pool.lease(step.Lease, func(port uint32) bool {
+						_, ok := used[port]
+						return ok
+					})
+					if act != step.Expectation {
+						t.Errorf("step %d: unexpected lease for %d: want %d, got %d", i, step.Lease, step.Expectation, act)
+					}
+				}
+			}
+		})
+	}
+}
@@ -8,9 +8,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"net/url"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
 )
@@ -27,24 +31,157 @@ type Configs struct {
 	workspaceConfigs     map[uint32]*gitpod.PortConfig
 	instancePortConfigs  map[uint32]*gitpod.PortConfig
 	instanceRangeConfigs []*RangeConfig
+
+	workspacePolicy    *gitpod.PortsPolicyConfig
+	instancePolicy     *gitpod.PortsPolicy
+	organizationPolicy *OrganizationPolicy
+
+	workspaceDenylist    Denylist
+	instanceDenylist     Denylist
+	organizationDenylist Denylist
+
+	workspaceDiagnostics    []*ConfigDiagnostic
+	instanceDiagnostics     []*ConfigDiagnostic
+	organizationDiagnostics []*ConfigDiagnostic
+}
+
+// ConfigSource indicates where a port config comes from
+type ConfigSource uint8
+
+const (
+	// WorkspaceConfigSource is the port config the workspace was created with
+	WorkspaceConfigSource ConfigSource = iota
+	// InstanceConfigSource is the port config read from the .gitpod.yml of the running instance
+	InstanceConfigSource
+	// OrganizationConfigSource is the port policy of the organization the workspace belongs to
+	OrganizationConfigSource
+)
+
+// ConfigDiagnostic describes a port config entry which was ignored or only partially applied
+type ConfigDiagnostic struct {
+	Source  ConfigSource
+	Port    string
+	Message string
 }
 
-// ForEach iterates over all configured ports
+// Diagnostics returns all problems found while parsing the port configs
+func (configs *Configs) Diagnostics() []*ConfigDiagnostic {
+	if configs == nil {
+		return nil
+	}
+	var res []*ConfigDiagnostic
+	res = append(res, configs.workspaceDiagnostics...)
+	res = append(res, configs.instanceDiagnostics...)
+	res = append(res, configs.organizationDiagnostics...)
+	return res
+}
+
+// ForEach iterates over all configured ports. The callback receives the config which
+// takes precedence for the port, which can be a port range config with the override flag set.
 func (configs *Configs) ForEach(callback func(port uint32, config *gitpod.PortConfig)) {
 	if configs == nil {
 		return
 	}
 	visited := make(map[uint32]struct{})
-	for _, configs := range []map[uint32]*gitpod.PortConfig{configs.instancePortConfigs, configs.workspaceConfigs} {
-		for port, config := range configs {
+	for _, portConfigs := range []map[uint32]*gitpod.PortConfig{configs.instancePortConfigs, configs.workspaceConfigs} {
+		for port := range portConfigs {
 			_, exists := visited[port]
 			if exists {
 				continue
 			}
 			visited[port] = struct{}{}
-			callback(port, config)
+			callback(port, configs.Match(port).Config)
+		}
+	}
+}
+
+// hasPortConfig returns true if the port is configured explicitly, i.e. it is visited by ForEach
+func (configs *Configs) hasPortConfig(port uint32) bool {
+	if configs == nil {
+		return false
+	}
+	if _, exists := configs.instancePortConfigs[port]; exists {
+		return true
+	}
+	_, exists := configs.workspaceConfigs[port]
+	return exists
+}
+
+// Names maps the names of the configured ports to the ports. The name of a port is the one of the config which takes
+// precedence for the port. If different ports claim the same name, ports configured by the running instance win,
+// followed by the lower port.
+func (configs *Configs) Names() map[string]uint32 {
+	if configs == nil {
+		return nil
+	}
+	var res map[string]uint32
+	for _, portConfigs := range []map[uint32]*gitpod.PortConfig{configs.instancePortConfigs, configs.workspaceConfigs} {
+		ports := make([]uint32, 0, len(portConfigs))
+		for port := range portConfigs {
+			ports = append(ports, port)
+		}
+		sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+		for _, port := range ports {
+			name := configs.Match(port).Config.Name
+			if name == "" {
+				continue
+			}
+			if _, taken := res[name]; taken {
+				continue
+			}
+			if res == nil {
+				res = make(map[string]uint32)
+			}
+			res[name] = port
 		}
 	}
+	return res
+}
+
+// LocalhostPortsPolicy is the policy for auto-exposing services which listen on localhost only
+type LocalhostPortsPolicy string
+
+const (
+	// LocalhostPortsAuto auto-exposes all services listening on localhost only
+	LocalhostPortsAuto LocalhostPortsPolicy = "auto"
+	// LocalhostPortsConfigured auto-exposes services listening on localhost only if their port is configured
+	LocalhostPortsConfigured LocalhostPortsPolicy = "configured"
+)
+
+// LocalhostPorts returns the policy for auto-exposing services which listen on localhost only.
+// The .gitpod.yml of the running instance wins over the one the workspace was created with,
+// unless the organization enforces that only configured ports are exposed.
+func (configs *Configs) LocalhostPorts() LocalhostPortsPolicy {
+	if configs == nil {
+		return LocalhostPortsAuto
+	}
+	if configs.organizationPolicy != nil && LocalhostPortsPolicy(configs.organizationPolicy.LocalhostPorts) == LocalhostPortsConfigured {
+		return LocalhostPortsConfigured
+	}
+	var policy string
+	if configs.workspacePolicy != nil {
+		policy = configs.workspacePolicy.LocalhostPorts
+	}
+	if configs.instancePolicy != nil && configs.instancePolicy.LocalhostPorts != "" {
+		policy = configs.instancePolicy.LocalhostPorts
+	}
+	if LocalhostPortsPolicy(policy) == LocalhostPortsConfigured {
+		return LocalhostPortsConfigured
+	}
+	return LocalhostPortsAuto
+}
+
+// Denied returns true if the port policy of the workspace, of the running instance or of the organization denies the port
+func (configs *Configs) Denied(port uint32) bool {
+	if configs == nil {
+		return false
+	}
+	return configs.workspaceDenylist.Contains(port) || configs.instanceDenylist.Contains(port) || configs.organizationDenylist.Contains(port)
+}
+
+// OrganizationDenied returns true if the port policy of the organization denies the port
+func (configs *Configs) OrganizationDenied(port uint32) bool {
+	return configs != nil && configs.organizationDenylist.Contains(port)
 }
 
 // ConfigKind indicates a type of config
@@ -59,27 +196,83 @@ var (
 
 // Get returns the config for the give port
 func (configs *Configs) Get(port uint32) (*gitpod.PortConfig, ConfigKind, bool) {
-	if configs == nil {
+	match := configs.Match(port)
+	if match == nil {
 		return nil, PortConfigKind, false
 	}
-	config, exists := configs.instancePortConfigs[port]
-	if exists {
-		return config, PortConfigKind, true
+	return match.Config, match.Kind, true
+}
+
+// ConfigMatch describes the config which takes precedence for a port
+type ConfigMatch struct {
+	Config *gitpod.PortConfig
+	Kind   ConfigKind
+	Source ConfigSource
+	// Port is the port or port range of the matching config as it was configured
+	Port string
+}
+
+// Match returns the config which takes precedence for the given port, or nil if the port isn't configured.
+// Candidates are ranked as follows, the first rule that decides wins:
+//  1. configs with the override flag win over configs without it,
+//  2. exact port configs win over range configs,
+//  3. instance configs win over workspace configs,
+//  4. earlier ranges win over later ones.
+//
+// The organization defaults apply to the attributes the winning config does not set.
+func (configs *Configs) Match(port uint32) *ConfigMatch {
+	if configs == nil {
+		return nil
 	}
-	config, exists = configs.workspaceConfigs[port]
-	if exists {
-		return config, PortConfigKind, true
+
+	// candidates are collected in the order of rules 2-4
+	var candidates []*ConfigMatch
+	if config, exists := configs.instancePortConfigs[port]; exists {
+		candidates = append(candidates, &ConfigMatch{
+			Config: config,
+			Kind:   PortConfigKind,
+			Source: InstanceConfigSource,
+			Port:   fmt.Sprintf("%d", port),
+		})
+	}
+	if config, exists := configs.workspaceConfigs[port]; exists {
+		candidates = append(candidates, &ConfigMatch{
+			Config: config,
+			Kind:   PortConfigKind,
+			Source: WorkspaceConfigSource,
+			Port:   fmt.Sprintf("%d", port),
+		})
 	}
 	for _, rangeConfig := range configs.instanceRangeConfigs {
 		if rangeConfig.Start <= port && port <= rangeConfig.End {
-			return &gitpod.PortConfig{
-				Port:       float64(port),
-				OnOpen:     rangeConfig.OnOpen,
-				Visibility: rangeConfig.Visibility,
-			}, RangeConfigKind, true
+			candidates = append(candidates, &ConfigMatch{
+				Config: &gitpod.PortConfig{
+					Port:                float64(port),
+					OnOpen:              rangeConfig.OnOpen,
+					Visibility:          rangeConfig.Visibility,
+					Override:            rangeConfig.Override,
+					ConnectionRateLimit: rangeConfig.ConnectionRateLimit,
+					InsecureRequests:    rangeConfig.InsecureRequests,
+					KeepAlive:           rangeConfig.KeepAlive,
+					Protocol:            rangeConfig.Protocol,
+					Cors:                portCorsConfig(rangeConfig.Cors),
+				},
+				Kind:   RangeConfigKind,
+				Source: InstanceConfigSource,
+				Port:   fmt.Sprintf("%v", rangeConfig.Port),
+			})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	for _, candidate := range candidates {
+		if candidate.Config.Override {
+			return configs.withDefaults(candidate)
 		}
 	}
-	return nil, PortConfigKind, false
+	return configs.withDefaults(candidates[0])
 }
 
 // ConfigInterace allows to watch port configurations
@@ -93,14 +286,35 @@ type ConfigService struct {
 	workspaceID   string
 	configService gitpod.ConfigInterface
 	gitpodAPI     gitpod.APIInterface
+	// organizationPolicies holds the latest organization policy which was not observed yet
+	organizationPolicies chan *OrganizationPolicy
 }
 
 // NewConfigService creates a new instance of ConfigService
 func NewConfigService(workspaceID string, configService gitpod.ConfigInterface, gitpodAPI gitpod.APIInterface) *ConfigService {
 	return &ConfigService{
-		workspaceID:   workspaceID,
-		configService: configService,
-		gitpodAPI:     gitpodAPI,
+		workspaceID:          workspaceID,
+		configService:        configService,
+		gitpodAPI:            gitpodAPI,
+		organizationPolicies: make(chan *OrganizationPolicy, 1),
+	}
+}
+
+// UpdateOrganizationPolicy replaces the port policy of the organization, which is merged with the port configs
+// of the workspace. A nil policy removes it. Only the latest policy is applied if it is updated repeatedly
+// before the change is observed.
+func (service *ConfigService) UpdateOrganizationPolicy(policy *OrganizationPolicy) {
+	for {
+		select {
+		case service.organizationPolicies <- policy:
+			return
+		default:
+		}
+		// drop the policy which was not observed yet
+		select {
+		case <-service.organizationPolicies:
+		default:
+		}
 	}
 }
 
@@ -121,8 +335,19 @@ func (service *ConfigService) Observe(ctx context.Context) (<-chan *Configs, <-c
 			if err != nil {
 				errorsChan <- err
 			} else {
-				current.workspaceConfigs = parseWorkspaceConfigs(info.Workspace.Config.Ports)
-				updatesChan <- &Configs{workspaceConfigs: current.workspaceConfigs}
+				current.workspaceConfigs, current.workspaceDiagnostics = parseWorkspaceConfigs(info.Workspace.Config.Ports)
+				current.workspacePolicy = info.Workspace.Config.PortsPolicy
+				if current.workspacePolicy != nil {
+					var diagnostics []*ConfigDiagnostic
+					current.workspaceDenylist, diagnostics = parseConfigDenylist(WorkspaceConfigSource, current.workspacePolicy.Deny)
+					current.workspaceDiagnostics = append(current.workspaceDiagnostics, diagnostics...)
+				}
+				updatesChan <- &Configs{
+					workspaceConfigs:     current.workspaceConfigs,
+					workspacePolicy:      current.workspacePolicy,
+					workspaceDenylist:    current.workspaceDenylist,
+					workspaceDiagnostics: current.workspaceDiagnostics,
+				}
 			}
 		} else {
 			errorsChan <- errors.New("could not connect to Gitpod API to fetch workspace port configs")
@@ -139,82 +364,501 @@ func (service *ConfigService) Observe(ctx context.Context) (<-chan *Configs, <-c
 				if !changed {
 					continue
 				}
-				updatesChan <- &Configs{
-					workspaceConfigs:     current.workspaceConfigs,
-					instancePortConfigs:  current.instancePortConfigs,
-					instanceRangeConfigs: current.instanceRangeConfigs,
+				updatesChan <- current.copy()
+			case policy := <-service.organizationPolicies:
+				changed := service.updateOrganizationPolicy(policy, current)
+				if !changed {
+					continue
 				}
+				updatesChan <- current.copy()
 			}
 		}
 	}()
 	return updatesChan, errorsChan
 }
 
+// NewConfigs parses the port configs a workspace was created with and the .gitpod.yml of its instance like the
+// ConfigService does, for components which provide the configs to the Manager on their own, e.g. in tests.
+func NewConfigs(workspace []*gitpod.PortConfig, instance *gitpod.GitpodConfig) *Configs {
+	configs := &Configs{}
+	configs.workspaceConfigs, configs.workspaceDiagnostics = parseWorkspaceConfigs(workspace)
+	(&ConfigService{}).update(instance, configs)
+	return configs
+}
+
+// copy copies the port configs, so that they can be passed on while the current ones are updated
+func (configs *Configs) copy() *Configs {
+	res := *configs
+	return &res
+}
+
+func (service *ConfigService) updateOrganizationPolicy(policy *OrganizationPolicy, current *Configs) bool {
+	currentPolicy, currentDiagnostics := current.organizationPolicy, current.organizationDiagnostics
+	current.organizationPolicy, current.organizationDenylist, current.organizationDiagnostics = parseOrganizationPolicy(policy)
+	return !reflect.DeepEqual(currentPolicy, current.organizationPolicy) || !reflect.DeepEqual(currentDiagnostics, current.organizationDiagnostics)
+}
+
 func (service *ConfigService) update(config *gitpod.GitpodConfig, current *Configs) bool {
-	currentPortConfigs, currentRangeConfigs := current.instancePortConfigs, current.instanceRangeConfigs
+	currentPortConfigs, currentRangeConfigs, currentDiagnostics := current.instancePortConfigs, current.instanceRangeConfigs, current.instanceDiagnostics
+	currentPolicy := current.instancePolicy
 	var ports []*gitpod.PortsItems
+	current.instancePolicy = nil
 	if config != nil {
 		ports = config.Ports
+		current.instancePolicy = config.PortsPolicy
 	}
-	portConfigs, rangeConfigs := parseInstanceConfigs(ports)
+	portConfigs, rangeConfigs, diagnostics := parseInstanceConfigs(ports)
 	current.instancePortConfigs = portConfigs
 	current.instanceRangeConfigs = rangeConfigs
-	return !reflect.DeepEqual(currentPortConfigs, portConfigs) || !reflect.DeepEqual(currentRangeConfigs, rangeConfigs)
+	current.instanceDenylist = nil
+	if current.instancePolicy != nil {
+		var denylistDiagnostics []*ConfigDiagnostic
+		current.instanceDenylist, denylistDiagnostics = parseConfigDenylist(InstanceConfigSource, current.instancePolicy.Deny)
+		diagnostics = append(diagnostics, denylistDiagnostics...)
+	}
+	current.instanceDiagnostics = diagnostics
+	return !reflect.DeepEqual(currentPortConfigs, portConfigs) || !reflect.DeepEqual(currentRangeConfigs, rangeConfigs) || !reflect.DeepEqual(currentDiagnostics, diagnostics) || !reflect.DeepEqual(currentPolicy, current.instancePolicy)
 }
 
-var portRangeRegexp = regexp.MustCompile("^(\\d+)[-:](\\d+)$")
+var (
+	portRangeRegexp = regexp.MustCompile("^(\\d+)[-:](\\d+)$")
+	// portNameRegexp matches valid port names, which can never be mistaken for port numbers
+	portNameRegexp = regexp.MustCompile("^[a-z][a-z0-9-]*$")
+)
 
-func parseWorkspaceConfigs(ports []*gitpod.PortConfig) (portConfigs map[uint32]*gitpod.PortConfig) {
-	if len(ports) == 0 {
+// maxPortNameLength keeps port names usable as DNS labels
+const maxPortNameLength = 63
+
+var (
+	validOnOpen     = map[string]struct{}{"": {}, "open-browser": {}, "open-preview": {}, "notify": {}, "ignore": {}}
+	validVisibility = map[string]struct{}{"": {}, "public": {}, "private": {}}
+	// validInsecureRequests are the ways the proxy handles plain HTTP requests to an exposed port
+	validInsecureRequests = map[string]struct{}{"": {}, "allow": {}, "redirect": {}, "reject": {}}
+	// validProtocols are the protocols a port can speak, which decide how it is proxied
+	validProtocols = map[string]struct{}{"": {}, ProtocolHTTP: {}, ProtocolTCP: {}, ProtocolUDP: {}, ProtocolGRPC: {}}
+)
+
+const (
+	// ProtocolHTTP ports are proxied as HTTP/1.1, which is the default
+	ProtocolHTTP = "http"
+	// ProtocolTCP ports are proxied as raw TCP connections without interpreting them
+	ProtocolTCP = "tcp"
+	// ProtocolUDP ports are not proxied
+	ProtocolUDP = "udp"
+	// ProtocolGRPC ports are proxied as HTTP/2 without TLS (h2c)
+	ProtocolGRPC = "grpc"
+)
+
+// parseProtocol returns the protocol a port is configured with. The spelling is not case sensitive
+// for compatibility with the formerly deprecated "TCP" and "UDP" values. Unknown protocols fall back to http.
+func parseProtocol(source ConfigSource, port string, protocol string) (string, *ConfigDiagnostic) {
+	res := strings.ToLower(protocol)
+	if _, valid := validProtocols[res]; valid {
+		return res, nil
+	}
+	return "", &ConfigDiagnostic{
+		Source:  source,
+		Port:    port,
+		Message: fmt.Sprintf("unknown protocol value %q, falling back to http", protocol),
+	}
+}
+
+// portProtocol returns the protocol spoken on a port with the given config, which may be nil
+func portProtocol(config *gitpod.PortConfig) string {
+	if config == nil || config.Protocol == "" {
+		return ProtocolHTTP
+	}
+	return config.Protocol
+}
+
+// validateAttributes reports config attributes which are not understood, and hence fall back to their defaults.
+func validateAttributes(source ConfigSource, port string, onOpen string, visibility string, insecureRequests string) (diagnostics []*ConfigDiagnostic) {
+	if _, valid := validOnOpen[onOpen]; !valid {
+		diagnostics = append(diagnostics, &ConfigDiagnostic{
+			Source:  source,
+			Port:    port,
+			Message: fmt.Sprintf("unknown onOpen value %q, falling back to notify", onOpen),
+		})
+	}
+	if _, valid := validVisibility[visibility]; !valid {
+		diagnostics = append(diagnostics, &ConfigDiagnostic{
+			Source:  source,
+			Port:    port,
+			Message: fmt.Sprintf("unknown visibility value %q, falling back to public", visibility),
+		})
+	}
+	if _, valid := validInsecureRequests[insecureRequests]; !valid {
+		diagnostics = append(diagnostics, &ConfigDiagnostic{
+			Source:  source,
+			Port:    port,
+			Message: fmt.Sprintf("unknown insecureRequests value %q, falling back to allow", insecureRequests),
+		})
+	}
+	return diagnostics
+}
+
+// validateConnectionRateLimit reports connection rate limits which cannot be enforced.
+func validateConnectionRateLimit(source ConfigSource, port string, limit float64) *ConfigDiagnostic {
+	if limit >= 1 && float64(int64(limit)) == limit {
+		return nil
+	}
+	return &ConfigDiagnostic{
+		Source:  source,
+		Port:    port,
+		Message: "invalid connectionRateLimit, must be a whole number of connections per second, ignoring it",
+	}
+}
+
+// validateCors reports CORS policies which cannot be applied as given and returns the policy which is applied.
+// Origins are normalized to scheme and host, invalid origins are dropped and credentials are only allowed
+// for explicit origins. Returns nil if no valid origin remains.
+func validateCors(source ConfigSource, port string, cors *gitpod.PortCorsConfig) (*gitpod.PortCorsConfig, []*ConfigDiagnostic) {
+	var (
+		diagnostics []*ConfigDiagnostic
+		valid       = &gitpod.PortCorsConfig{AllowCredentials: cors.AllowCredentials}
+		anyOrigin   bool
+	)
+	for _, origin := range cors.AllowedOrigins {
+		if origin == "*" {
+			anyOrigin = true
+			valid.AllowedOrigins = append(valid.AllowedOrigins, origin)
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  source,
+				Port:    port,
+				Message: fmt.Sprintf("invalid CORS origin %q, must be '*' or a scheme and host (e.g. https://example.com), ignoring it", origin),
+			})
+			continue
+		}
+		valid.AllowedOrigins = append(valid.AllowedOrigins, u.Scheme+"://"+u.Host)
+	}
+	if len(valid.AllowedOrigins) == 0 {
+		diagnostics = append(diagnostics, &ConfigDiagnostic{
+			Source:  source,
+			Port:    port,
+			Message: "CORS policy does not allow any origin, ignoring it",
+		})
+		return nil, diagnostics
+	}
+	if anyOrigin && valid.AllowCredentials {
+		diagnostics = append(diagnostics, &ConfigDiagnostic{
+			Source:  source,
+			Port:    port,
+			Message: "CORS credentials cannot be allowed for any origin ('*'), disallowing credentials",
+		})
+		valid.AllowCredentials = false
+	}
+	return valid, diagnostics
+}
+
+// portCorsConfig converts the CORS policy of a .gitpod.yml port entry
+func portCorsConfig(cors *gitpod.Cors) *gitpod.PortCorsConfig {
+	if cors == nil {
 		return nil
 	}
+	return &gitpod.PortCorsConfig{
+		AllowedOrigins:   cors.AllowedOrigins,
+		AllowCredentials: cors.AllowCredentials,
+	}
+}
+
+// validatePortName reports port names which cannot be used. Valid names are added to used.
+func validatePortName(source ConfigSource, port string, name string, used map[string]string) *ConfigDiagnostic {
+	if !portNameRegexp.MatchString(name) || len(name) > maxPortNameLength {
+		return &ConfigDiagnostic{
+			Source:  source,
+			Port:    port,
+			Message: fmt.Sprintf("invalid name %q, must start with a lowercase letter and may only contain lowercase letters, digits and dashes, ignoring it", name),
+		}
+	}
+	if other, exists := used[name]; exists {
+		return &ConfigDiagnostic{
+			Source:  source,
+			Port:    port,
+			Message: fmt.Sprintf("name %q is already used by port %s, ignoring it", name, other),
+		}
+	}
+	used[name] = port
+	return nil
+}
+
+func isValidPort(port int) bool {
+	return 0 < port && port <= math.MaxUint16
+}
+
+// validateGlobalPort reports global ports which cannot be used. Valid global ports are added to used.
+func validateGlobalPort(source ConfigSource, port string, globalPort float64, used map[uint32]string) *ConfigDiagnostic {
+	if !isValidPort(int(globalPort)) || float64(uint32(globalPort)) != globalPort {
+		return &ConfigDiagnostic{
+			Source:  source,
+			Port:    port,
+			Message: fmt.Sprintf("invalid globalPort, must be a number between 1 and %d", math.MaxUint16),
+		}
+	}
+	if other, exists := used[uint32(globalPort)]; exists {
+		return &ConfigDiagnostic{
+			Source:  source,
+			Port:    port,
+			Message: fmt.Sprintf("globalPort %d is already used by port %s, falling back to a dynamic port", uint32(globalPort), other),
+		}
+	}
+	used[uint32(globalPort)] = port
+	return nil
+}
+
+func parseWorkspaceConfigs(ports []*gitpod.PortConfig) (portConfigs map[uint32]*gitpod.PortConfig, diagnostics []*ConfigDiagnostic) {
+	if len(ports) == 0 {
+		return nil, nil
+	}
 	portConfigs = make(map[uint32]*gitpod.PortConfig)
+	globalPorts := make(map[uint32]string)
+	names := make(map[string]string)
 	for _, config := range ports {
+		rawPort := fmt.Sprintf("%v", config.Port)
+		if !isValidPort(int(config.Port)) || float64(uint32(config.Port)) != config.Port {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  WorkspaceConfigSource,
+				Port:    rawPort,
+				Message: fmt.Sprintf("invalid port, must be a number between 1 and %d", math.MaxUint16),
+			})
+			continue
+		}
 		port := uint32(config.Port)
 		_, exists := portConfigs[port]
-		if !exists {
-			portConfigs[port] = config
+		if exists {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  WorkspaceConfigSource,
+				Port:    rawPort,
+				Message: "port is configured more than once, only the first definition is used",
+			})
+			continue
 		}
+		diagnostics = append(diagnostics, validateAttributes(WorkspaceConfigSource, rawPort, config.OnOpen, config.Visibility, config.InsecureRequests)...)
+		if config.GlobalPort != 0 {
+			if d := validateGlobalPort(WorkspaceConfigSource, rawPort, config.GlobalPort, globalPorts); d != nil {
+				diagnostics = append(diagnostics, d)
+				withoutGlobalPort := *config
+				withoutGlobalPort.GlobalPort = 0
+				config = &withoutGlobalPort
+			}
+		}
+		if config.Name != "" {
+			if d := validatePortName(WorkspaceConfigSource, rawPort, config.Name, names); d != nil {
+				diagnostics = append(diagnostics, d)
+				withoutName := *config
+				withoutName.Name = ""
+				config = &withoutName
+			}
+		}
+		if config.ConnectionRateLimit != 0 {
+			if d := validateConnectionRateLimit(WorkspaceConfigSource, rawPort, config.ConnectionRateLimit); d != nil {
+				diagnostics = append(diagnostics, d)
+				withoutRateLimit := *config
+				withoutRateLimit.ConnectionRateLimit = 0
+				config = &withoutRateLimit
+			}
+		}
+		if config.Protocol != "" {
+			protocol, d := parseProtocol(WorkspaceConfigSource, rawPort, config.Protocol)
+			if d != nil {
+				diagnostics = append(diagnostics, d)
+			}
+			if protocol != config.Protocol {
+				withValidProtocol := *config
+				withValidProtocol.Protocol = protocol
+				config = &withValidProtocol
+			}
+		}
+		if config.Cors != nil {
+			cors, d := validateCors(WorkspaceConfigSource, rawPort, config.Cors)
+			diagnostics = append(diagnostics, d...)
+			withValidCors := *config
+			withValidCors.Cors = cors
+			config = &withValidCors
+		}
+		portConfigs[port] = config
 	}
-	return portConfigs
+	return portConfigs, diagnostics
 }
 
-func parseInstanceConfigs(ports []*gitpod.PortsItems) (portConfigs map[uint32]*gitpod.PortConfig, rangeConfigs []*RangeConfig) {
+func parseInstanceConfigs(ports []*gitpod.PortsItems) (portConfigs map[uint32]*gitpod.PortConfig, rangeConfigs []*RangeConfig, diagnostics []*ConfigDiagnostic) {
+	globalPorts := make(map[uint32]string)
+	names := make(map[string]string)
 	for _, config := range ports {
 		rawPort := fmt.Sprintf("%v", config.Port)
 		Port, err := strconv.Atoi(rawPort)
 		if err == nil {
+			if !isValidPort(Port) {
+				diagnostics = append(diagnostics, &ConfigDiagnostic{
+					Source:  InstanceConfigSource,
+					Port:    rawPort,
+					Message: fmt.Sprintf("invalid port, must be a number between 1 and %d", math.MaxUint16),
+				})
+				continue
+			}
 			if portConfigs == nil {
 				portConfigs = make(map[uint32]*gitpod.PortConfig)
 			}
 			port := uint32(Port)
 			_, exists := portConfigs[port]
-			if !exists {
-				portConfigs[port] = &gitpod.PortConfig{
-					OnOpen:     config.OnOpen,
-					Port:       float64(Port),
-					Visibility: config.Visibility,
+			if exists {
+				diagnostics = append(diagnostics, &ConfigDiagnostic{
+					Source:  InstanceConfigSource,
+					Port:    rawPort,
+					Message: "port is configured more than once, only the first definition is used",
+				})
+				continue
+			}
+			diagnostics = append(diagnostics, validateAttributes(InstanceConfigSource, rawPort, config.OnOpen, config.Visibility, config.InsecureRequests)...)
+			globalPort := config.GlobalPort
+			if globalPort != 0 {
+				if d := validateGlobalPort(InstanceConfigSource, rawPort, globalPort, globalPorts); d != nil {
+					diagnostics = append(diagnostics, d)
+					globalPort = 0
+				}
+			}
+			name := config.Name
+			if name != "" {
+				if d := validatePortName(InstanceConfigSource, rawPort, name, names); d != nil {
+					diagnostics = append(diagnostics, d)
+					name = ""
 				}
 			}
+			connectionRateLimit := config.ConnectionRateLimit
+			if connectionRateLimit != 0 {
+				if d := validateConnectionRateLimit(InstanceConfigSource, rawPort, connectionRateLimit); d != nil {
+					diagnostics = append(diagnostics, d)
+					connectionRateLimit = 0
+				}
+			}
+			protocol, d := parseProtocol(InstanceConfigSource, rawPort, config.Protocol)
+			if d != nil {
+				diagnostics = append(diagnostics, d)
+			}
+			cors := portCorsConfig(config.Cors)
+			if cors != nil {
+				var d []*ConfigDiagnostic
+				cors, d = validateCors(InstanceConfigSource, rawPort, cors)
+				diagnostics = append(diagnostics, d...)
+			}
+			portConfigs[port] = &gitpod.PortConfig{
+				OnOpen:              config.OnOpen,
+				Port:                float64(Port),
+				Visibility:          config.Visibility,
+				Override:            config.Override,
+				GlobalPort:          globalPort,
+				ConnectionRateLimit: connectionRateLimit,
+				InsecureRequests:    config.InsecureRequests,
+				Cors:                cors,
+				Name:                name,
+				KeepAlive:           config.KeepAlive,
+				Protocol:            protocol,
+			}
 			continue
 		}
 		matches := portRangeRegexp.FindStringSubmatch(rawPort)
 		if len(matches) != 3 {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  InstanceConfigSource,
+				Port:    rawPort,
+				Message: "invalid port or port range, expected a number (e.g. 1337) or a range (e.g. 3000-3999)",
+			})
 			continue
 		}
 		start, err := strconv.Atoi(matches[1])
-		if err != nil {
+		if err != nil || !isValidPort(start) {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  InstanceConfigSource,
+				Port:    rawPort,
+				Message: fmt.Sprintf("invalid port range start, must be a number between 1 and %d", math.MaxUint16),
+			})
 			continue
 		}
 		end, err := strconv.Atoi(matches[2])
-		if err != nil || start >= end {
+		if err != nil || !isValidPort(end) {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  InstanceConfigSource,
+				Port:    rawPort,
+				Message: fmt.Sprintf("invalid port range end, must be a number between 1 and %d", math.MaxUint16),
+			})
 			continue
 		}
+		if start >= end {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  InstanceConfigSource,
+				Port:    rawPort,
+				Message: "invalid port range, start must be lower than end",
+			})
+			continue
+		}
+		for _, other := range rangeConfigs {
+			if uint32(start) <= other.End && other.Start <= uint32(end) {
+				diagnostics = append(diagnostics, &ConfigDiagnostic{
+					Source:  InstanceConfigSource,
+					Port:    rawPort,
+					Message: fmt.Sprintf("port range overlaps with %v, the first matching range takes precedence", other.Port),
+				})
+				break
+			}
+		}
+		diagnostics = append(diagnostics, validateAttributes(InstanceConfigSource, rawPort, config.OnOpen, config.Visibility, config.InsecureRequests)...)
+		if config.GlobalPort != 0 {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  InstanceConfigSource,
+				Port:    rawPort,
+				Message: "globalPort is not supported for port ranges, ignoring it",
+			})
+		}
+		if config.Name != "" {
+			diagnostics = append(diagnostics, &ConfigDiagnostic{
+				Source:  InstanceConfigSource,
+				Port:    rawPort,
+				Message: "name is not supported for port ranges, ignoring it",
+			})
+			withoutName := *config
+			withoutName.Name = ""
+			config = &withoutName
+		}
+		if config.ConnectionRateLimit != 0 {
+			if d := validateConnectionRateLimit(InstanceConfigSource, rawPort, config.ConnectionRateLimit); d != nil {
+				diagnostics = append(diagnostics, d)
+				withoutRateLimit := *config
+				withoutRateLimit.ConnectionRateLimit = 0
+				config = &withoutRateLimit
+			}
+		}
+		if config.Protocol != "" {
+			protocol, d := parseProtocol(InstanceConfigSource, rawPort, config.Protocol)
+			if d != nil {
+				diagnostics = append(diagnostics, d)
+			}
+			if protocol != config.Protocol {
+				withValidProtocol := *config
+				withValidProtocol.Protocol = protocol
+				config = &withValidProtocol
+			}
+		}
+		if config.Cors != nil {
+			cors, d := validateCors(InstanceConfigSource, rawPort, portCorsConfig(config.Cors))
+			diagnostics = append(diagnostics, d...)
+			withValidCors := *config
+			withValidCors.Cors = nil
+			if cors != nil {
+				withValidCors.Cors = &gitpod.Cors{
+					AllowedOrigins:   cors.AllowedOrigins,
+					AllowCredentials: cors.AllowCredentials,
+				}
+			}
+			config = &withValidCors
+		}
 		rangeConfigs = append(rangeConfigs, &RangeConfig{
 			PortsItems: config,
 			Start:      uint32(start),
 			End:        uint32(end),
 		})
 	}
-	return portConfigs, rangeConfigs
+	return portConfigs, rangeConfigs, diagnostics
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestPortsConfig(t *testing.T) {
@@ -89,6 +90,181 @@ func TestPortsConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Desc: "invalid configs",
+			WorkspacePorts: []*gitpod.PortConfig{
+				{Port: 3000},
+				{Port: 3000, OnOpen: "ignore"},
+				{Port: 70000},
+				{Port: 8080, ConnectionRateLimit: 0.5},
+				{Port: 8081, InsecureRequests: "forbid"},
+			},
+			GitpodConfig: &gitpod.GitpodConfig{
+				Ports: []*gitpod.PortsItems{
+					{Port: "foo"},
+					{Port: "3000-3100", Visibility: "secret"},
+					{Port: "3050-3200", GlobalPort: 13050},
+					{Port: 5432, GlobalPort: 15432},
+					{Port: 5433, GlobalPort: 15432},
+					{Port: 5434, ConnectionRateLimit: 100},
+					{Port: 5435, ConnectionRateLimit: -1},
+				},
+			},
+			Expectation: &PortConfigTestExpectations{
+				WorkspaceConfigs: []*gitpod.PortConfig{
+					{Port: 3000},
+					{Port: 8080},
+					{Port: 8081, InsecureRequests: "forbid"},
+				},
+				InstancePortConfigs: []*gitpod.PortConfig{
+					{Port: 5432, GlobalPort: 15432},
+					{Port: 5433},
+					{Port: 5434, ConnectionRateLimit: 100},
+					{Port: 5435},
+				},
+				InstanceRangeConfigs: []*RangeConfig{
+					{
+						PortsItems: &gitpod.PortsItems{Port: "3000-3100", Visibility: "secret"},
+						Start:      3000,
+						End:        3100,
+					},
+					{
+						PortsItems: &gitpod.PortsItems{Port: "3050-3200", GlobalPort: 13050},
+						Start:      3050,
+						End:        3200,
+					},
+				},
+				Diagnostics: []*ConfigDiagnostic{
+					{Source: WorkspaceConfigSource, Port: "3000", Message: "port is configured more than once, only the first definition is used"},
+					{Source: WorkspaceConfigSource, Port: "70000", Message: "invalid port, must be a number between 1 and 65535"},
+					{Source: WorkspaceConfigSource, Port: "8080", Message: "invalid connectionRateLimit, must be a whole number of connections per second, ignoring it"},
+					{Source: WorkspaceConfigSource, Port: "8081", Message: "unknown insecureRequests value \"forbid\", falling back to allow"},
+					{Source: InstanceConfigSource, Port: "foo", Message: "invalid port or port range, expected a number (e.g. 1337) or a range (e.g. 3000-3999)"},
+					{Source: InstanceConfigSource, Port: "3000-3100", Message: "unknown visibility value \"secret\", falling back to public"},
+					{Source: InstanceConfigSource, Port: "3050-3200", Message: "port range overlaps with 3000-3100, the first matching range takes precedence"},
+					{Source: InstanceConfigSource, Port: "3050-3200", Message: "globalPort is not supported for port ranges, ignoring it"},
+					{Source: InstanceConfigSource, Port: "5433", Message: "globalPort 15432 is already used by port 5432, falling back to a dynamic port"},
+					{Source: InstanceConfigSource, Port: "5435", Message: "invalid connectionRateLimit, must be a whole number of connections per second, ignoring it"},
+				},
+			},
+		},
+		{
+			Desc: "cors configs",
+			WorkspacePorts: []*gitpod.PortConfig{
+				{Port: 3000, Cors: &gitpod.PortCorsConfig{AllowedOrigins: []string{"https://example.com/"}, AllowCredentials: true}},
+				{Port: 3001, Cors: &gitpod.PortCorsConfig{AllowedOrigins: []string{"example.com"}}},
+			},
+			GitpodConfig: &gitpod.GitpodConfig{
+				Ports: []*gitpod.PortsItems{
+					{Port: 8080, Cors: &gitpod.Cors{AllowedOrigins: []string{"*"}, AllowCredentials: true}},
+					{Port: 8081, Cors: &gitpod.Cors{AllowedOrigins: []string{"http://localhost:3000", "https://example.com/path"}}},
+					{Port: "9000-9100", Cors: &gitpod.Cors{AllowedOrigins: []string{"*"}}},
+				},
+			},
+			Expectation: &PortConfigTestExpectations{
+				WorkspaceConfigs: []*gitpod.PortConfig{
+					{Port: 3000, Cors: &gitpod.PortCorsConfig{AllowedOrigins: []string{"https://example.com"}, AllowCredentials: true}},
+					{Port: 3001},
+				},
+				InstancePortConfigs: []*gitpod.PortConfig{
+					{Port: 8080, Cors: &gitpod.PortCorsConfig{AllowedOrigins: []string{"*"}}},
+					{Port: 8081, Cors: &gitpod.PortCorsConfig{AllowedOrigins: []string{"http://localhost:3000"}}},
+				},
+				InstanceRangeConfigs: []*RangeConfig{
+					{
+						PortsItems: &gitpod.PortsItems{Port: "9000-9100", Cors: &gitpod.Cors{AllowedOrigins: []string{"*"}}},
+						Start:      9000,
+						End:        9100,
+					},
+				},
+				Diagnostics: []*ConfigDiagnostic{
+					{Source: WorkspaceConfigSource, Port: "3001", Message: "invalid CORS origin \"example.com\", must be '*' or a scheme and host (e.g. https://example.com), ignoring it"},
+					{Source: WorkspaceConfigSource, Port: "3001", Message: "CORS policy does not allow any origin, ignoring it"},
+					{Source: InstanceConfigSource, Port: "8080", Message: "CORS credentials cannot be allowed for any origin ('*'), disallowing credentials"},
+					{Source: InstanceConfigSource, Port: "8081", Message: "invalid CORS origin \"https://example.com/path\", must be '*' or a scheme and host (e.g. https://example.com), ignoring it"},
+				},
+			},
+		},
+		{
+			Desc: "named configs",
+			WorkspacePorts: []*gitpod.PortConfig{
+				{Port: 3000, Name: "api"},
+				{Port: 3001, Name: "api"},
+				{Port: 3002, Name: "3002"},
+			},
+			GitpodConfig: &gitpod.GitpodConfig{
+				Ports: []*gitpod.PortsItems{
+					{Port: 8080, Name: "web"},
+					{Port: 8081, Name: "Web"},
+					{Port: "9000-9100", Name: "range"},
+				},
+			},
+			Expectation: &PortConfigTestExpectations{
+				WorkspaceConfigs: []*gitpod.PortConfig{
+					{Port: 3000, Name: "api"},
+					{Port: 3001},
+					{Port: 3002},
+				},
+				InstancePortConfigs: []*gitpod.PortConfig{
+					{Port: 8080, Name: "web"},
+					{Port: 8081},
+				},
+				InstanceRangeConfigs: []*RangeConfig{
+					{
+						PortsItems: &gitpod.PortsItems{Port: "9000-9100"},
+						Start:      9000,
+						End:        9100,
+					},
+				},
+				Diagnostics: []*ConfigDiagnostic{
+					{Source: WorkspaceConfigSource, Port: "3001", Message: "name \"api\" is already used by port 3000, ignoring it"},
+					{Source: WorkspaceConfigSource, Port: "3002", Message: "invalid name \"3002\", must start with a lowercase letter and may only contain lowercase letters, digits and dashes, ignoring it"},
+					{Source: InstanceConfigSource, Port: "8081", Message: "invalid name \"Web\", must start with a lowercase letter and may only contain lowercase letters, digits and dashes, ignoring it"},
+					{Source: InstanceConfigSource, Port: "9000-9100", Message: "name is not supported for port ranges, ignoring it"},
+				},
+			},
+		},
+		{
+			Desc: "protocol configs",
+			WorkspacePorts: []*gitpod.PortConfig{
+				{Port: 3000, Protocol: "grpc"},
+				{Port: 3001, Protocol: "quic"},
+			},
+			GitpodConfig: &gitpod.GitpodConfig{
+				Ports: []*gitpod.PortsItems{
+					{Port: 5432, Protocol: "TCP"},
+					{Port: 8080, Protocol: "http"},
+					{Port: "9000-9100", Protocol: "udp"},
+					{Port: "9200-9300", Protocol: "sctp"},
+				},
+			},
+			Expectation: &PortConfigTestExpectations{
+				WorkspaceConfigs: []*gitpod.PortConfig{
+					{Port: 3000, Protocol: "grpc"},
+					{Port: 3001},
+				},
+				InstancePortConfigs: []*gitpod.PortConfig{
+					{Port: 5432, Protocol: "tcp"},
+					{Port: 8080, Protocol: "http"},
+				},
+				InstanceRangeConfigs: []*RangeConfig{
+					{
+						PortsItems: &gitpod.PortsItems{Port: "9000-9100", Protocol: "udp"},
+						Start:      9000,
+						End:        9100,
+					},
+					{
+						PortsItems: &gitpod.PortsItems{Port: "9200-9300"},
+						Start:      9200,
+						End:        9300,
+					},
+				},
+				Diagnostics: []*ConfigDiagnostic{
+					{Source: WorkspaceConfigSource, Port: "3001", Message: "unknown protocol value \"quic\", falling back to http"},
+					{Source: InstanceConfigSource, Port: "9200-9300", Message: "unknown protocol value \"sctp\", falling back to http"},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.Desc, func(t *testing.T) {
@@ -141,9 +317,153 @@ func TestPortsConfig(t *testing.T) {
 					for _, config := range change.instancePortConfigs {
 						actual.InstancePortConfigs = append(actual.InstancePortConfigs, config)
 					}
+					actual.Diagnostics = change.Diagnostics()
 				}
 			}
 
+			sortConfigs := cmpopts.SortSlices(func(x, y *gitpod.PortConfig) bool { return x.Port < y.Port })
+			if diff := cmp.Diff(test.Expectation, actual, sortConfigs); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPortsConfigMatch(t *testing.T) {
+	tests := []struct {
+		Desc           string
+		WorkspacePorts []*gitpod.PortConfig
+		InstancePorts  []*gitpod.PortsItems
+		Organization   *OrganizationPolicy
+		Port           uint32
+		Expectation    *ConfigMatch
+	}{
+		{
+			Desc: "not configured",
+			Port: 8080,
+		},
+		{
+			Desc:           "exact wins over range",
+			WorkspacePorts: []*gitpod.PortConfig{{Port: 8080, OnOpen: "ignore"}},
+			InstancePorts:  []*gitpod.PortsItems{{Port: "8000-9000", OnOpen: "open-browser"}},
+			Port:           8080,
+			Expectation: &ConfigMatch{
+				Config: &gitpod.PortConfig{Port: 8080, OnOpen: "ignore"},
+				Kind:   PortConfigKind,
+				Source: WorkspaceConfigSource,
+				Port:   "8080",
+			},
+		},
+		{
+			Desc:           "instance wins over workspace",
+			WorkspacePorts: []*gitpod.PortConfig{{Port: 8080, OnOpen: "ignore"}},
+			InstancePorts:  []*gitpod.PortsItems{{Port: 8080, OnOpen: "open-browser"}},
+			Port:           8080,
+			Expectation: &ConfigMatch{
+				Config: &gitpod.PortConfig{Port: 8080, OnOpen: "open-browser"},
+				Kind:   PortConfigKind,
+				Source: InstanceConfigSource,
+				Port:   "8080",
+			},
+		},
+		{
+			Desc:           "override wins",
+			WorkspacePorts: []*gitpod.PortConfig{{Port: 8080, OnOpen: "ignore"}},
+			InstancePorts: []*gitpod.PortsItems{
+				{Port: 8080, OnOpen: "notify"},
+				{Port: "8000-8100", OnOpen: "open-preview"},
+				{Port: "8000-9000", OnOpen: "open-browser", Override: true},
+			},
+			Port: 8080,
+			Expectation: &ConfigMatch{
+				Config: &gitpod.PortConfig{Port: 8080, OnOpen: "open-browser", Override: true},
+				Kind:   RangeConfigKind,
+				Source: InstanceConfigSource,
+				Port:   "8000-9000",
+			},
+		},
+		{
+			Desc: "first range wins",
+			InstancePorts: []*gitpod.PortsItems{
+				{Port: "8000-8100", OnOpen: "open-preview"},
+				{Port: "8000-9000", OnOpen: "open-browser"},
+			},
+			Port: 8080,
+			Expectation: &ConfigMatch{
+				Config: &gitpod.PortConfig{Port: 8080, OnOpen: "open-preview"},
+				Kind:   RangeConfigKind,
+				Source: InstanceConfigSource,
+				Port:   "8000-8100",
+			},
+		},
+		{
+			Desc:           "organization defaults",
+			WorkspacePorts: []*gitpod.PortConfig{{Port: 8080, OnOpen: "ignore"}},
+			Organization:   &OrganizationPolicy{OnOpen: "notify", Visibility: "private"},
+			Port:           8080,
+			Expectation: &ConfigMatch{
+				Config: &gitpod.PortConfig{Port: 8080, OnOpen: "ignore", Visibility: "private"},
+				Kind:   PortConfigKind,
+				Source: WorkspaceConfigSource,
+				Port:   "8080",
+			},
+		},
+		{
+			Desc:         "organization defaults do not configure ports",
+			Organization: &OrganizationPolicy{OnOpen: "ignore", Visibility: "private"},
+			Port:         8080,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			configs := &Configs{}
+			configs.workspaceConfigs, _ = parseWorkspaceConfigs(test.WorkspacePorts)
+			configs.instancePortConfigs, configs.instanceRangeConfigs, _ = parseInstanceConfigs(test.InstancePorts)
+			configs.organizationPolicy, configs.organizationDenylist, _ = parseOrganizationPolicy(test.Organization)
+
+			actual := configs.Match(test.Port)
+			if diff := cmp.Diff(test.Expectation, actual); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPortNames(t *testing.T) {
+	tests := []struct {
+		Desc           string
+		WorkspacePorts []*gitpod.PortConfig
+		InstancePorts  []*gitpod.PortsItems
+		Expectation    map[string]uint32
+	}{
+		{
+			Desc: "no names",
+		},
+		{
+			Desc:           "names of both configs",
+			WorkspacePorts: []*gitpod.PortConfig{{Port: 3000, Name: "api"}},
+			InstancePorts:  []*gitpod.PortsItems{{Port: 8080, Name: "web"}},
+			Expectation:    map[string]uint32{"api": 3000, "web": 8080},
+		},
+		{
+			Desc:           "instance wins over workspace",
+			WorkspacePorts: []*gitpod.PortConfig{{Port: 3000, Name: "api"}, {Port: 8080, Name: "web"}},
+			InstancePorts:  []*gitpod.PortsItems{{Port: 3001, Name: "api"}, {Port: 8080}},
+			Expectation:    map[string]uint32{"api": 3001},
+		},
+		{
+			Desc:           "override wins",
+			WorkspacePorts: []*gitpod.PortConfig{{Port: 3000, Name: "api"}},
+			InstancePorts:  []*gitpod.PortsItems{{Port: "3000-3100", Override: true}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			configs := &Configs{}
+			configs.workspaceConfigs, _ = parseWorkspaceConfigs(test.WorkspacePorts)
+			configs.instancePortConfigs, configs.instanceRangeConfigs, _ = parseInstanceConfigs(test.InstancePorts)
+
+			actual := configs.Names()
 			if diff := cmp.Diff(test.Expectation, actual); diff != "" {
 				t.Errorf("unexpected output (-want +got):\n%s", diff)
 			}
@@ -155,6 +475,7 @@ type PortConfigTestExpectations struct {
 	WorkspaceConfigs     []*gitpod.PortConfig
 	InstancePortConfigs  []*gitpod.PortConfig
 	InstanceRangeConfigs []*RangeConfig
+	Diagnostics          []*ConfigDiagnostic
 }
 
 type testGitpodConfigService struct {
@@ -165,3 +486,65 @@ type testGitpodConfigService struct {
 func (service *testGitpodConfigService) Observe(ctx context.Context) (<-chan *gitpod.GitpodConfig, <-chan error) {
 	return service.configs, service.errors
 }
+
+func TestLocalhostPortsPolicy(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Configs     *Configs
+		Expectation LocalhostPortsPolicy
+	}{
+		{
+			Desc:        "no configs",
+			Expectation: LocalhostPortsAuto,
+		},
+		{
+			Desc:        "workspace policy",
+			Configs:     &Configs{workspacePolicy: &gitpod.PortsPolicyConfig{LocalhostPorts: "configured"}},
+			Expectation: LocalhostPortsConfigured,
+		},
+		{
+			Desc: "instance policy wins",
+			Configs: &Configs{
+				workspacePolicy: &gitpod.PortsPolicyConfig{LocalhostPorts: "configured"},
+				instancePolicy:  &gitpod.PortsPolicy{LocalhostPorts: "auto"},
+			},
+			Expectation: LocalhostPortsAuto,
+		},
+		{
+			Desc: "instance policy without localhost ports",
+			Configs: &Configs{
+				workspacePolicy: &gitpod.PortsPolicyConfig{LocalhostPorts: "configured"},
+				instancePolicy:  &gitpod.PortsPolicy{},
+			},
+			Expectation: LocalhostPortsConfigured,
+		},
+		{
+			Desc:        "unknown policy",
+			Configs:     &Configs{instancePolicy: &gitpod.PortsPolicy{LocalhostPorts: "never"}},
+			Expectation: LocalhostPortsAuto,
+		},
+		{
+			Desc: "organization enforces configured",
+			Configs: &Configs{
+				instancePolicy:     &gitpod.PortsPolicy{LocalhostPorts: "auto"},
+				organizationPolicy: &OrganizationPolicy{LocalhostPorts: "configured"},
+			},
+			Expectation: LocalhostPortsConfigured,
+		},
+		{
+			Desc: "organization auto is a default",
+			Configs: &Configs{
+				workspacePolicy:    &gitpod.PortsPolicyConfig{LocalhostPorts: "configured"},
+				organizationPolicy: &OrganizationPolicy{LocalhostPorts: "auto"},
+			},
+			Expectation: LocalhostPortsConfigured,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			if act := test.Configs.LocalhostPorts(); act != test.Expectation {
+				t.Errorf("unexpected policy: want %q, got %q", test.Expectation, act)
+			}
+		})
+	}
+}
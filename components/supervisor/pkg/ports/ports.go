@@ -9,16 +9,18 @@ import (
 	"fmt"
 	"io"
 	"net"
-	"net/http"
-	"net/http/httputil"
-	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/common-go/tracing"
 	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/dropwriter"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/protobuf/ptypes"
 	"golang.org/x/xerrors"
 )
 
@@ -37,18 +39,54 @@ func NewManager(exposed ExposedPortsInterface, served ServedPortsObserver, confi
 		internal[p] = struct{}{}
 	}
 
-	return &Manager{
+	pm := &Manager{
 		E: exposed,
 		S: served,
 		C: config,
 
-		internal: internal,
-		proxies:  make(map[uint32]*localhostProxy),
-
-		state:         state,
-		subscriptions: make(map[*Subscription]struct{}),
-		proxyStarter:  startLocalhostProxy,
+		internal:      internal,
+		proxies:       make(map[uint32]*localhostProxy),
+		globalPorts:   newGlobalPortPool(proxyPortRangeLo, proxyPortRangeHi, globalPortLeaseTime),
+		closedProxies: make(map[uint32]struct{}),
+		mayBind:       (&bindPrivileges{}).mayBind,
+
+		state:            state,
+		exposedByPort:    make(map[uint32]ExposedPort),
+		servedByPort:     make(map[uint32]ServedPort),
+		dirty:            make(map[uint32]struct{}),
+		unsettled:        make(map[uint32]struct{}),
+		autoExposed:      make(map[uint32]struct{}),
+		requested:        make(map[uint32]struct{}),
+		closed:           make(map[uint32]struct{}),
+		pendingPublic:    make(map[uint32]uint32),
+		approved:         make(map[uint32]struct{}),
+		remapSuggestions: make(map[uint32]RemapSuggestion),
+		acceptedRemaps:   make(map[uint32]struct{}),
+		connectionStats:  make(map[uint32]ConnectionStats),
+		simulatedServed:  make(map[uint32]ServedPort),
+		simulatedExposed: make(map[uint32]ExposedPort),
+		dryRunExposures:  make(map[uint32]ExposeOptions),
+		tunnels:          make(map[uint32]int),
+		pendingExposures: make(map[uint32]pendingExposure),
+		subscriptions:    make(map[*Subscription]struct{}),
+		events:           NewEventBus(),
+		epoch:            strconv.FormatInt(time.Now().UnixNano(), 36),
+
+		replayNow: make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
 	}
+	pm.proxyStarter = func(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (io.Closer, error) {
+		proxy, err := startLocalhostProxy(localPort, globalPort, config, onHealthChange, func() {
+			pm.traffic.record(localPort)
+			pm.requests.count(localPort)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return proxy, nil
+	}
+	return pm
 }
 
 type localhostProxy struct {
@@ -56,6 +94,16 @@ type localhostProxy struct {
 	proxyPort uint32
 }
 
+// health returns the error counters of the proxy, or nil if the proxy does not track them
+func (p *localhostProxy) health() *ProxyHealth {
+	h, ok := p.Closer.(interface{ Health() ProxyHealth })
+	if !ok {
+		return nil
+	}
+	health := h.Health()
+	return &health
+}
+
 // Manager brings together served and exposed ports. It keeps track of which port is exposed, which one is served,
 // auto-exposes ports and proxies ports served on localhost only.
 type Manager struct {
@@ -63,17 +111,112 @@ type Manager struct {
 	S ServedPortsObserver
 	C ConfigInterace
 
-	internal     map[uint32]struct{}
-	proxies      map[uint32]*localhostProxy
-	proxyStarter func(LocalhostPort uint32, GlobalPort uint32) (proxy io.Closer, err error)
+	// MaxSubscriptions limits the number of concurrent subscriptions. Zero means no limit.
+	MaxSubscriptions int
+	// RequirePublicApproval holds ports which would be exposed publicly as pending until the user
+	// approves them with ApprovePublic. Until then such ports are exposed privately.
+	RequirePublicApproval bool
+	// DryRun only records and reports which ports would be exposed, the exposure service is never called
+	DryRun bool
+	// RemapPrivilegedPorts proxies ports below 1024 which are served on all interfaces to a high global port,
+	// since the workspace proxy cannot route to privileged ports
+	RemapPrivilegedPorts bool
+	// AllowSimulation allows to inject synthetic ports with Simulate, e.g. to test IDE integrations
+	AllowSimulation bool
+	// AnnounceTaskPorts marks the ports served by tasks, which clients would notify about on exposure, as announced.
+	// Their on-exposed action is ignore then, since supervisor notifies about them together with their task.
+	// Ports which clients offer to make public on exposure, i.e. notify_private, are not announced.
+	AnnounceTaskPorts bool
+	// verbose is 1 while verbose logging is switched on, see SetVerbose
+	verbose int32
+	// Denylist are ports the operator denies, which are never auto-exposed or proxied.
+	// Users can deny further ports in the ports policy of their .gitpod.yml.
+	Denylist Denylist
+
+	internal    map[uint32]struct{}
+	proxies     map[uint32]*localhostProxy
+	globalPorts *globalPortPool
+	// closedProxies are the global ports of closed proxies which are still internal until they are no longer served
+	closedProxies map[uint32]struct{}
+	// proxyStarter starts a proxy, which calls onHealthChange if it provides its ProxyHealth and that changes
+	proxyStarter func(LocalhostPort uint32, GlobalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (proxy io.Closer, err error)
+	// mayBind returns true if proxies may listen on a global port, which is not the case for privileged ports
+	// unless the supervisor has CAP_NET_BIND_SERVICE
+	mayBind func(port uint32) bool
+
+	configs     *Configs
+	diagnostics []*ConfigDiagnostic
+	exposed     []ExposedPort
+	served      []ServedPort
+	// observedExposed and observedServed are the ports as observed, i.e. without the simulated ones
+	observedExposed []ExposedPort
+	observedServed  []ServedPort
+	// simulatedExposed and simulatedServed are the synthetic ports injected with Simulate
+	simulatedExposed map[uint32]ExposedPort
+	simulatedServed  map[uint32]ServedPort
+	// names maps the names of the configured ports to the ports, portNames is the reverse
+	names     map[string]uint32
+	portNames map[uint32]string
+
+	exposedByPort map[uint32]ExposedPort
+	servedByPort  map[uint32]ServedPort
+	// dirty ports are recomputed with the next state update
+	dirty map[uint32]struct{}
+	// unsettled ports were auto-exposed but the exposure did not show up yet
+	unsettled map[uint32]struct{}
+
+	state       map[uint32]*managedPort
+	autoExposed map[uint32]struct{}
+	requested   map[uint32]struct{}
+	// closed ports were closed by the user and are not exposed automatically until they are exposed explicitly
+	closed map[uint32]struct{}
+	// pendingPublic maps ports waiting for approval to become public to their global port
+	pendingPublic map[uint32]uint32
+	approved      map[uint32]struct{}
+	// remapSuggestions are the ports whose configured global port is served by another process
+	remapSuggestions map[uint32]RemapSuggestion
+	// acceptedRemaps are the ports the user accepted the remap suggestion for
+	acceptedRemaps map[uint32]struct{}
+	// dryRunExposures are the exposures which would have been made in dry-run mode
+	dryRunExposures map[uint32]ExposeOptions
+	// tunnels counts the open tunnels per port
+	tunnels map[uint32]int
+	// traffic records traffic on ports configured with keepAlive
+	traffic trafficActivity
+	// requests counts the requests through the localhost proxies
+	requests requestCounter
+	// connectionStats are the latest sampled connection statistics of the exposed ports
+	connectionStats map[uint32]ConnectionStats
+	// pendingExposures are exposures which wait for the Gitpod server to become reachable again
+	pendingExposures map[uint32]pendingExposure
+	// replaying is true while pending exposures are replayed in the background
+	replaying bool
+	// replayNow triggers replaying the pending exposures right away
+	replayNow chan struct{}
+	// exposureRetryDelay is the initial delay between replays of pending exposures, exposureRetryMinDelay if zero
+	exposureRetryDelay time.Duration
 
-	configs *Configs
-	exposed []ExposedPort
-	served  []ServedPort
-
-	state         map[uint32]*managedPort
 	subscriptions map[*Subscription]struct{}
-	mu            sync.RWMutex
+	events        *EventBus
+	// epoch identifies this manager in resume tokens, as revisions start over with every manager
+	epoch    string
+	revision uint64
+	// history holds the latest diffs for subscribers which resume
+	history  []*Diff
+	stopped  bool
+	finished bool
+	mu       sync.RWMutex
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// configMatchStatus is the part of a ConfigMatch reported in the port status
+type configMatchStatus struct {
+	Source   ConfigSource
+	Port     string
+	Range    bool
+	Override bool
 }
 
 type managedPort struct {
@@ -82,6 +225,45 @@ type managedPort struct {
 	Visibility api.PortVisibility
 	URL        string
 	OnExposed  api.OnPortExposedAction
+	Config     *configMatchStatus
+	DetectedAs string
+	Group      string
+	// PendingPublic is true if the port waits for approval to become public
+	PendingPublic bool
+	// WouldExpose are the options the port would have been exposed with in dry-run mode
+	WouldExpose *ExposeOptions
+	// Tunneled is true while clients tunnel to the port
+	Tunneled bool
+	// ComposeService is the Docker Compose service serving the port
+	ComposeService string
+	// Namespace labels the network namespace serving the port if it is not supervisor's
+	Namespace string
+	// KubernetesService is the service of a cluster in the workspace serving the port
+	KubernetesService string
+	// Debugger is the debugger protocol spoken on the port, if any
+	Debugger string
+	// DebugURL is what debugger clients attach with
+	DebugURL string
+	// Announced is true if supervisor notifies about the port together with the task serving it
+	Announced bool
+	// PolicyViolation explains why the port is not exposed even though it would have been
+	PolicyViolation string
+	// Proxy is the health of the proxy of a port served on localhost only
+	Proxy *ProxyHealth
+	// Name is the configured name of the port
+	Name string
+	// Process is the command name of the process serving the port
+	Process string
+	// PendingExposure is true if the port waits for the Gitpod server to become reachable to be exposed
+	PendingExposure bool
+	// Protocol is the protocol configured for the port, i.e. http, tcp, udp or grpc
+	Protocol string
+	// PrivilegedRemap is true if the privileged port is proxied to a high global port
+	PrivilegedRemap bool
+	// RemapSuggestion suggests a free global port if another process serves the configured one
+	RemapSuggestion *RemapSuggestion
+	// Connections are the sampled connection statistics of an exposed port
+	Connections *ConnectionStats
 
 	LocalhostPort uint32
 	GlobalPort    uint32
@@ -92,12 +274,206 @@ type Diff struct {
 	Added   []*api.PortsStatus
 	Updated []*api.PortsStatus
 	Removed []uint32
+
+	// Diagnostics is nil if the port config problems did not change
+	Diagnostics *api.PortConfigDiagnostics
+
+	// Trigger is what caused the update
+	Trigger api.PortsUpdateTrigger
+
+	// Triggers is what caused the update of each port, if diffs with different triggers were coalesced.
+	// Trigger is what caused the latest update then.
+	Triggers map[uint32]api.PortsUpdateTrigger
+
+	// Revision numbers the state after this diff. Revisions increase monotonically.
+	Revision uint64
 }
 
-// Subscription is a Subscription to status updates
+// maxDiffHistory is the number of past diffs kept for subscribers which resume after a reconnect
+const maxDiffHistory = 100
+
+// maxQueuedDiffs is the number of diffs queued for a subscriber before they are coalesced
+const maxQueuedDiffs = 50
+
+// Subscription is a Subscription to status updates.
+// Each subscription queues diffs on its own, so that a slow subscriber never blocks the manager.
+// If a subscriber falls behind by more than maxQueuedDiffs, the queued diffs are coalesced into one.
 type Subscription struct {
+	// Client identifies the subscriber
+	Client string
+	// Since is the time the subscription was created
+	Since time.Time
+
 	updates chan *Diff
 	Close   func() error
+
+	mu      sync.Mutex
+	queue   []*Diff
+	notify  chan struct{}
+	closed  chan struct{}
+	ended   chan struct{}
+	endOnce sync.Once
+}
+
+func newSubscription(client string) *Subscription {
+	sub := &Subscription{
+		Client:  client,
+		Since:   time.Now(),
+		updates: make(chan *Diff),
+		notify:  make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+		ended:   make(chan struct{}),
+	}
+	go sub.forward()
+	return sub
+}
+
+// push queues a diff for the subscriber without blocking
+func (s *Subscription) push(diff *Diff) {
+	s.mu.Lock()
+	if len(s.queue) >= maxQueuedDiffs {
+		log.WithField("queued", len(s.queue)).Debug("ports subscriber is falling behind - coalescing updates")
+		s.queue = []*Diff{mergeDiffs(append(s.queue, diff)...)}
+	} else {
+		s.queue = append(s.queue, diff)
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// end signals that there won't be any further diffs. Queued diffs are still delivered before
+// the updates channel is closed.
+func (s *Subscription) end() {
+	s.endOnce.Do(func() { close(s.ended) })
+}
+
+// forward delivers queued diffs to the updates channel until the subscription is closed,
+// or the subscription has ended and all queued diffs are delivered.
+func (s *Subscription) forward() {
+	defer close(s.updates)
+	for {
+		s.mu.Lock()
+		var next *Diff
+		if len(s.queue) > 0 {
+			next = s.queue[0]
+			s.queue = s.queue[1:]
+		}
+		s.mu.Unlock()
+
+		if next == nil {
+			select {
+			case <-s.notify:
+				continue
+			case <-s.ended:
+				s.mu.Lock()
+				drained := len(s.queue) == 0
+				s.mu.Unlock()
+				if drained {
+					return
+				}
+				continue
+			case <-s.closed:
+				return
+			}
+		}
+
+		select {
+		case s.updates <- next:
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// mergeDiffs coalesces consecutive diffs into a single diff with the same outcome. If the diffs have different
+// triggers, the merged diff keeps the trigger of the latest change of each port.
+func mergeDiffs(diffs ...*Diff) *Diff {
+	type change int
+	const (
+		unchanged change = iota
+		added
+		updated
+		removed
+	)
+	type portChange struct {
+		change  change
+		status  *api.PortsStatus
+		trigger api.PortsUpdateTrigger
+	}
+	var (
+		res           = &Diff{}
+		order         []uint32
+		changes       = make(map[uint32]*portChange)
+		mixedTriggers bool
+	)
+	apply := func(port uint32, c change, status *api.PortsStatus, trigger api.PortsUpdateTrigger) {
+		pc, exists := changes[port]
+		if !exists {
+			order = append(order, port)
+			changes[port] = &portChange{change: c, status: status, trigger: trigger}
+			return
+		}
+		pc.trigger = trigger
+		switch {
+		case pc.change == added && c == removed:
+			// the subscriber never needs to know about the port
+			pc.change, pc.status = unchanged, nil
+		case pc.change == added && c == updated:
+			pc.status = status
+		case pc.change == removed && c == added:
+			pc.change, pc.status = updated, status
+		default:
+			pc.change, pc.status = c, status
+		}
+	}
+	for i, diff := range diffs {
+		for _, status := range diff.Added {
+			apply(status.LocalPort, added, status, diff.Trigger)
+		}
+		for _, status := range diff.Updated {
+			apply(status.LocalPort, updated, status, diff.Trigger)
+		}
+		for _, port := range diff.Removed {
+			apply(port, removed, nil, diff.Trigger)
+		}
+		for port, trigger := range diff.Triggers {
+			if pc, exists := changes[port]; exists {
+				pc.trigger = trigger
+			}
+		}
+		if diff.Diagnostics != nil {
+			res.Diagnostics = diff.Diagnostics
+		}
+		if diff.Triggers != nil || (i > 0 && diff.Trigger != res.Trigger) {
+			mixedTriggers = true
+		}
+		res.Trigger = diff.Trigger
+		res.Revision = diff.Revision
+	}
+	for _, port := range order {
+		pc := changes[port]
+		switch pc.change {
+		case added:
+			res.Added = append(res.Added, pc.status)
+		case updated:
+			res.Updated = append(res.Updated, pc.status)
+		case removed:
+			res.Removed = append(res.Removed, port)
+		default:
+			continue
+		}
+		if mixedTriggers {
+			if res.Triggers == nil {
+				res.Triggers = make(map[uint32]api.PortsUpdateTrigger)
+			}
+			res.Triggers[port] = pc.trigger
+		}
+	}
+	return res
 }
 
 // Updates returns the updates channel
@@ -105,22 +481,21 @@ func (s *Subscription) Updates() <-chan *Diff {
 	return s.updates
 }
 
-// Run starts the port manager which keeps running until one of its observers stops.
+// Run starts the port manager which keeps running until one of its observers stops or the manager is stopped.
 func (pm *Manager) Run() {
+	defer close(pm.done)
+	// every update the observers trigger starts a trace of its own, see updateState
 	ctx, cancel := context.WithCancel(context.Background())
 	defer func() {
-		// We copy the subscriptions to a list prior to closing them, to prevent a data race
-		// between the map iteration and entry removal when closing the subscription.
-		pm.mu.RLock()
-		subs := make([]*Subscription, 0, len(pm.subscriptions))
+		// Subscribers still receive the updates queued so far, but no further ones.
+		pm.mu.Lock()
+		pm.finished = true
 		for s := range pm.subscriptions {
-			subs = append(subs, s)
-		}
-		pm.mu.RUnlock()
-
-		for _, s := range subs {
-			s.Close()
+			delete(pm.subscriptions, s)
+			s.end()
 		}
+		pm.events.Close()
+		pm.mu.Unlock()
 	}()
 	defer cancel()
 
@@ -129,15 +504,34 @@ func (pm *Manager) Run() {
 	configUpdates, configErrors := pm.C.Observe(ctx)
 	for {
 		select {
+		case <-pm.stop:
+			return
 		case exposed := <-exposedUpdates:
 			if exposed == nil {
 				log.Error("exposed ports observer stopped")
 				return
 			}
+			pm.logObservation("exposed", exposed)
 			pm.mu.Lock()
+			pm.observedExposed = exposed
+			exposed = pm.withSimulatedExposed(exposed)
 			if !reflect.DeepEqual(pm.exposed, exposed) {
-				pm.exposed = exposed
-				pm.updateState()
+				pm.setExposed(exposed)
+				trigger := api.PortsUpdateTrigger_exposed_ports_changed
+				for _, e := range exposed {
+					if _, requested := pm.requested[e.LocalPort]; requested {
+						trigger = api.PortsUpdateTrigger_manual_action
+						delete(pm.requested, e.LocalPort)
+					}
+				}
+				pm.updateState(ctx, trigger)
+			}
+			if len(pm.pendingExposures) > 0 {
+				// the Gitpod server is reachable again
+				select {
+				case pm.replayNow <- struct{}{}:
+				default:
+				}
 			}
 			pm.mu.Unlock()
 		case served := <-servedUpdates:
@@ -145,11 +539,14 @@ func (pm *Manager) Run() {
 				log.Error("served ports observer stopped")
 				return
 			}
+			pm.logObservation("served", served)
 			pm.mu.Lock()
+			pm.observedServed = served
+			served = pm.withSimulatedServed(served)
 			if !reflect.DeepEqual(pm.served, served) {
-				pm.served = served
+				pm.setServed(served)
 				pm.updateProxies()
-				pm.updateState()
+				pm.updateState(ctx, api.PortsUpdateTrigger_served_ports_changed)
 			}
 			pm.mu.Unlock()
 		case configs := <-configUpdates:
@@ -157,9 +554,10 @@ func (pm *Manager) Run() {
 				log.Error("configured ports observer stopped")
 				return
 			}
+			pm.logObservation("configs", configs)
 			pm.mu.Lock()
-			pm.configs = configs
-			pm.updateState()
+			pm.setConfigs(configs)
+			pm.updateState(ctx, api.PortsUpdateTrigger_port_configs_changed)
 			pm.mu.Unlock()
 		case err := <-exposedErrors:
 			if err == nil {
@@ -183,6 +581,65 @@ func (pm *Manager) Run() {
 	}
 }
 
+// Stop closes all localhost proxies, publishes the removal of all ports to the subscribers and stops Run.
+// If retractExposures is true, ports which were exposed automatically are closed again.
+// Stop waits for Run to return until ctx is done.
+func (pm *Manager) Stop(ctx context.Context, retractExposures bool) error {
+	pm.mu.Lock()
+	if pm.stopped {
+		pm.mu.Unlock()
+		return nil
+	}
+	pm.stopped = true
+
+	for localPort, proxy := range pm.proxies {
+		err := proxy.Close()
+		if err != nil {
+			log.WithError(err).WithField("globalPort", proxy.proxyPort).WithField("port", localPort).Warn("cannot stop localhost proxy")
+		}
+	}
+	pm.proxies = make(map[uint32]*localhostProxy)
+
+	var retract []uint32
+	if retractExposures {
+		for port := range pm.autoExposed {
+			if pm.simulatesExposure(port) {
+				continue
+			}
+			retract = append(retract, port)
+		}
+		pm.autoExposed = make(map[uint32]struct{})
+	}
+	pm.pendingExposures = make(map[uint32]pendingExposure)
+
+	var removed []uint32
+	previous := pm.state
+	for port := range pm.state {
+		removed = append(removed, port)
+	}
+	pm.state = make(map[uint32]*managedPort)
+	pm.publishStatus(nil, nil, removed, false, api.PortsUpdateTrigger_ports_shutdown)
+	pm.publishEvents(previous, false, api.PortsUpdateTrigger_ports_shutdown)
+	pm.events.Close()
+	pm.mu.Unlock()
+
+	// retracting exposures calls the exposure service, which must not block the status of the manager
+	for _, port := range retract {
+		err := pm.E.Unexpose(ctx, port)
+		if err != nil {
+			log.WithError(err).WithField("port", port).Warn("cannot retract auto-exposed port")
+		}
+	}
+
+	close(pm.stop)
+	select {
+	case <-pm.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Status provides the current port status
 func (pm *Manager) Status() []*api.PortsStatus {
 	pm.mu.RLock()
@@ -191,7 +648,19 @@ func (pm *Manager) Status() []*api.PortsStatus {
 	return pm.getStatus()
 }
 
+// Diagnostics provides the problems found in the current port configs
+func (pm *Manager) Diagnostics() *api.PortConfigDiagnostics {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	return pm.getDiagnostics()
+}
+
 func (pm *Manager) updateProxies() {
+	if pm.stopped {
+		return
+	}
+
 	opened := make(map[uint32]struct{}, len(pm.served))
 	for _, p := range pm.served {
 		opened[p.Port] = struct{}{}
@@ -202,104 +671,283 @@ func (pm *Manager) updateProxies() {
 		_, openedLocal := opened[localPort]
 		_, openedGlobal := opened[globalPort]
 
-		if !openedLocal && openedGlobal {
+		if (!openedLocal || pm.policyViolation(localPort) != "") && openedGlobal {
 			delete(pm.proxies, localPort)
 
 			err := proxy.Close()
 			if err != nil {
-				log.WithError(err).WithField("globalPort", globalPort).WithField("localPort", localPort).Warn("cannot stop localhost proxy")
+				log.WithError(err).WithField("globalPort", globalPort).WithField("port", localPort).Warn("cannot stop localhost proxy")
 			} else {
-				log.WithField("globalPort", globalPort).WithField("localPort", localPort).Info("localhost proxy has been stopped")
+				log.WithField("globalPort", globalPort).WithField("port", localPort).Info("localhost proxy has been stopped")
 			}
+			pm.globalPorts.release(globalPort)
+			pm.requests.reset(localPort)
+			pm.closedProxies[globalPort] = struct{}{}
+			pm.markDirty(localPort)
 		}
 
 		if !openedGlobal {
 			delete(pm.internal, globalPort)
+			pm.markDirty(globalPort)
 		}
 	}
+	for globalPort := range pm.closedProxies {
+		if _, openedGlobal := opened[globalPort]; !openedGlobal {
+			delete(pm.internal, globalPort)
+			delete(pm.closedProxies, globalPort)
+			pm.markDirty(globalPort)
+		}
+	}
+
+	// global ports statically mapped in the port configs are never allocated dynamically
+	reserved := make(map[uint32]struct{})
+	pm.configs.ForEach(func(port uint32, config *gitpod.PortConfig) {
+		if config.GlobalPort != 0 {
+			reserved[uint32(config.GlobalPort)] = struct{}{}
+		}
+	})
+	isProxyPort := func(port uint32) bool {
+		for _, proxy := range pm.proxies {
+			if proxy.proxyPort == port {
+				return true
+			}
+		}
+		return false
+	}
+	// ports the user serves directly must never be leased to proxies later on
+	for port := range opened {
+		if _, internal := pm.internal[port]; !internal && !isProxyPort(port) {
+			pm.globalPorts.avoid(port)
+		}
+	}
+	isUsed := func(port uint32) bool {
+		if _, used := opened[port]; used {
+			return true
+		}
+		if _, used := pm.internal[port]; used {
+			return true
+		}
+		return isProxyPort(port)
+	}
 
+	leaseGlobalPort := func(localPort uint32) uint32 {
+		return pm.globalPorts.lease(localPort, func(port uint32) bool {
+			_, used := reserved[port]
+			return used || isUsed(port)
+		})
+	}
+	suggestions := make(map[uint32]RemapSuggestion)
 	for _, served := range pm.served {
 		localPort := served.Port
 		_, exists := pm.proxies[localPort]
-		if exists || !served.BoundToLocalhost {
+		if exists || !(served.BoundToLocalhost || pm.remapsPrivileged(served)) || !pm.mayAutoExpose(served) {
+			continue
+		}
+		if served.Namespace != "" {
+			// the port is served on the localhost of another network namespace, which proxies cannot reach
+			continue
+		}
+		config, kind, exists := pm.configs.Get(localPort)
+		if portProtocol(config) == ProtocolUDP {
+			// served ports are TCP ports, a port configured as UDP has nothing to proxy
 			continue
 		}
 
 		var globalPort uint32
-		for port := proxyPortRangeHi; port >= proxyPortRangeLo; port-- {
-			if _, used := opened[port]; used {
-				continue
+		if exists && kind == PortConfigKind && config.GlobalPort != 0 {
+			globalPort = uint32(config.GlobalPort)
+			_, accepted := pm.acceptedRemaps[localPort]
+			if occupant, servedByUser := pm.servedByPort[globalPort]; servedByUser && !accepted && !isProxyPort(globalPort) {
+				// another process serves the configured global port, the user decides whether to remap the port
+				if suggested := leaseGlobalPort(localPort); suggested != 0 {
+					suggestions[localPort] = RemapSuggestion{
+						ConfiguredPort: globalPort,
+						SuggestedPort:  suggested,
+						OccupiedBy:     occupant.Process,
+					}
+					continue
+				}
 			}
-			if _, used := pm.internal[port]; used {
-				continue
+			if isUsed(globalPort) {
+				log.WithField("globalPort", globalPort).WithField("port", localPort).Warn("configured global port is already in use - falling back to a dynamic port")
+				globalPort = 0
+			} else if !pm.mayBind(globalPort) {
+				log.WithField("globalPort", globalPort).WithField("port", localPort).Warn("configured global port is privileged and the supervisor lacks CAP_NET_BIND_SERVICE - falling back to a dynamic port")
+				globalPort = 0
 			}
-
-			globalPort = port
-			break
+		}
+		if globalPort == 0 {
+			globalPort = leaseGlobalPort(localPort)
 		}
 		if globalPort == 0 {
 			log.WithField("port", localPort).Error("cannot find a free proxy port")
 			continue
 		}
 
-		proxy, err := pm.proxyStarter(localPort, globalPort)
+		proxy, err := pm.proxyStarter(localPort, globalPort, config, func() { pm.proxyHealthChanged(localPort) })
 		if err != nil {
-			log.WithError(err).WithField("globalPort", globalPort).WithField("localPort", localPort).Warn("cannot start localhost proxy")
+			log.WithError(err).WithField("globalPort", globalPort).WithField("port", localPort).Warn("cannot start localhost proxy")
+			// most likely someone else listens on the port already
+			pm.globalPorts.avoid(globalPort)
 			continue
 		}
-		log.WithField("globalPort", globalPort).WithField("localPort", localPort).Info("localhost proxy has been started")
+		log.WithField("globalPort", globalPort).WithField("port", localPort).Info("localhost proxy has been started")
 
 		pm.internal[globalPort] = struct{}{}
 		pm.proxies[localPort] = &localhostProxy{
 			Closer:    proxy,
 			proxyPort: globalPort,
 		}
+		pm.markDirty(localPort)
+		pm.markDirty(globalPort)
 	}
+	pm.setRemapSuggestions(suggestions)
 }
 
-func (pm *Manager) updateState() {
-	var added, updated, removed []uint32
-	newState := pm.nextState()
-	for port := range newState {
-		_, exists := pm.state[port]
-		if !exists {
-			added = append(added, port)
+// setExposed replaces the exposed ports and marks the ports whose exposure changed.
+// Callers are expected to hold mu.
+func (pm *Manager) setExposed(exposed []ExposedPort) {
+	pm.exposed = exposed
+	byPort := make(map[uint32]ExposedPort, len(exposed))
+	for _, e := range exposed {
+		byPort[e.LocalPort] = e
+	}
+	for port, e := range byPort {
+		if prev, exists := pm.exposedByPort[port]; !exists || !reflect.DeepEqual(prev, e) {
+			pm.markDirty(port)
 		}
 	}
-	for port, mp := range pm.state {
-		newMp, exists := newState[port]
-		if !exists {
-			removed = append(removed, port)
-			continue
+	for port := range pm.exposedByPort {
+		if _, exists := byPort[port]; !exists {
+			pm.markDirty(port)
 		}
-		if reflect.DeepEqual(newMp, mp) {
-			continue
+	}
+	pm.exposedByPort = byPort
+}
+
+// setServed replaces the served ports and marks the ports whose served state changed.
+// Callers are expected to hold mu.
+func (pm *Manager) setServed(served []ServedPort) {
+	pm.served = served
+	byPort := make(map[uint32]ServedPort, len(served))
+	for _, s := range served {
+		byPort[s.Port] = s
+	}
+	for port, s := range byPort {
+		if prev, exists := pm.servedByPort[port]; !exists || prev != s {
+			pm.markDirty(port)
 		}
-		updated = append(updated, port)
 	}
-	pm.state = newState
-	pm.publishStatus(added, updated, removed)
+	for port := range pm.servedByPort {
+		if _, exists := byPort[port]; !exists {
+			pm.markDirty(port)
+		}
+	}
+	pm.servedByPort = byPort
 }
 
-func (pm *Manager) nextState() map[uint32]*managedPort {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// setConfigs replaces the port configs. Range configs and policies can affect any port,
+// hence all known ports are marked. Callers are expected to hold mu.
+func (pm *Manager) setConfigs(configs *Configs) {
+	pm.configs.ForEach(func(port uint32, _ *gitpod.PortConfig) { pm.markDirty(port) })
+	configs.ForEach(func(port uint32, _ *gitpod.PortConfig) { pm.markDirty(port) })
+	for port := range pm.state {
+		pm.markDirty(port)
+	}
+	for port := range pm.exposedByPort {
+		pm.markDirty(port)
+	}
+	for port := range pm.servedByPort {
+		pm.markDirty(port)
+	}
+	pm.configs = configs
+	pm.traffic.setConfigs(configs)
+	pm.names = configs.Names()
+	pm.portNames = make(map[uint32]string, len(pm.names))
+	for name, port := range pm.names {
+		pm.portNames[port] = name
+	}
+}
 
-	state := make(map[uint32]*managedPort)
+// markDirty makes the next state update recompute the port.
+// Callers are expected to hold mu.
+func (pm *Manager) markDirty(port uint32) {
+	pm.dirty[port] = struct{}{}
+}
 
-	// 1. first capture exposed since they don't depend on configured or served ports
-	for _, exposed := range pm.exposed {
-		port := exposed.LocalPort
-		if pm.boundInternally(port) {
-			continue
+func (pm *Manager) updateState(ctx context.Context, trigger api.PortsUpdateTrigger) {
+	if pm.stopped {
+		return
+	}
+	span, ctx := tracing.FromContext(ctx, "ports.Manager.updateState")
+	span.SetTag("trigger", trigger.String())
+	defer tracing.FinishSpan(span, nil)
+
+	var added, updated, removed []uint32
+	previous := make(map[uint32]*managedPort)
+	for port, newMp := range pm.nextState(ctx) {
+		mp, exists := pm.state[port]
+		switch {
+		case newMp == nil && exists:
+			removed = append(removed, port)
+			previous[port] = mp
+			delete(pm.state, port)
+		case newMp == nil:
+		case !exists:
+			added = append(added, port)
+			previous[port] = nil
+			pm.state[port] = newMp
+		case !reflect.DeepEqual(newMp, mp):
+			updated = append(updated, port)
+			previous[port] = mp
+			pm.state[port] = newMp
 		}
+	}
+
+	diagnostics := pm.configs.Diagnostics()
+	diagnosticsChanged := !reflect.DeepEqual(pm.diagnostics, diagnostics)
+	pm.diagnostics = diagnostics
+
+	pm.logTransitions(previous, trigger)
+	pm.publishStatus(added, updated, removed, diagnosticsChanged, trigger)
+	pm.publishEvents(previous, diagnosticsChanged, trigger)
+}
+
+// nextState recomputes the ports which were marked dirty or which are not exposed as desired yet.
+// The result maps those ports to their new state, or to nil if the port is no longer managed.
+func (pm *Manager) nextState(ctx context.Context) map[uint32]*managedPort {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	for port := range pm.unsettled {
+		pm.markDirty(port)
+	}
+	changes := make(map[uint32]*managedPort, len(pm.dirty))
+	for port := range pm.dirty {
+		changes[port] = pm.portState(ctx, port)
+	}
+	pm.dirty = make(map[uint32]struct{})
+	return changes
+}
 
+// portState computes the state of a single port from its exposure, config and whether it is served.
+// Ports which get auto-exposed are recomputed with every update until they are exposed as desired.
+func (pm *Manager) portState(ctx context.Context, port uint32) *managedPort {
+	delete(pm.unsettled, port)
+	if pm.boundInternally(port) {
+		return nil
+	}
+
+	var mp *managedPort
+
+	// 1. first capture exposed since they don't depend on configured or served ports
+	if exposed, exists := pm.exposedByPort[port]; exists {
 		config, _, _ := pm.configs.Get(port)
 		Visibility := api.PortVisibility_private
 		if exposed.Public {
 			Visibility = api.PortVisibility_public
 		}
-		state[port] = &managedPort{
+		mp = &managedPort{
 			LocalhostPort: port,
 			GlobalPort:    exposed.GlobalPort,
 			Exposed:       true,
@@ -307,63 +955,58 @@ func (pm *Manager) nextState() map[uint32]*managedPort {
 			URL:           exposed.URL,
 			OnExposed:     getOnExposedAction(config, port),
 		}
+		if exposed.Public && pm.configs.PublicDenied() {
+			pm.enforcePrivate(ctx, mp)
+		}
 	}
 
 	// 2. second capture configured since we don't want to auto expose already exposed ports
-	if pm.configs != nil {
-		pm.configs.ForEach(func(port uint32, config *gitpod.PortConfig) {
-			if pm.boundInternally(port) {
-				return
-			}
-
-			mp, exists := state[port]
-			if !exists {
-				mp = &managedPort{}
-				state[port] = mp
-			}
-			mp.LocalhostPort = port
+	if pm.configs.hasPortConfig(port) {
+		config := pm.configs.Match(port).Config
+		if mp == nil {
+			mp = &managedPort{}
+		}
+		mp.LocalhostPort = port
 
-			if mp.Exposed {
-				return
-			}
+		if !mp.Exposed {
 			mp.OnExposed = getOnExposedAction(config, port)
 			mp.Visibility = api.PortVisibility_public
-			if config.Visibility == "private" {
+			if config.Visibility == "private" || pm.configs.PublicDenied() {
 				mp.Visibility = api.PortVisibility_private
 			}
 			public := mp.Visibility == api.PortVisibility_public
-			err := pm.E.Expose(ctx, mp.LocalhostPort, mp.GlobalPort, public)
-			if err != nil {
-				log.WithError(err).WithField("port", *mp).Warn("cannot auto-expose port")
-				return
+			if pm.policyViolation(port) == "" {
+				pm.autoExpose(ctx, mp, public)
 			}
-			log.WithField("port", *mp).Warn("auto-expose port")
-		})
+		}
 	}
 
 	// 3. at last capture served ports since
 	// we don't want to auto expose already exposed ports on the same port
 	// and need configured to decide about default visiblity properly
-	for _, served := range pm.served {
-		port := served.Port
-		if pm.boundInternally(port) {
-			continue
-		}
-
-		mp, exists := state[port]
-		if !exists {
+	if served, exists := pm.servedByPort[port]; exists {
+		if mp == nil {
 			mp = &managedPort{}
-			state[port] = mp
 		}
 
 		mp.LocalhostPort = port
 		mp.Served = true
+		mp.DetectedAs = served.DetectedAs
+		mp.Group = served.Group
+		mp.ComposeService = served.ComposeService
+		mp.Namespace = served.Namespace
+		mp.KubernetesService = served.KubernetesService
+		mp.Debugger = served.Debugger
+		mp.DebugURL = served.DebugURL
+		mp.Process = served.Process
 
 		exposedGlobalPort := mp.GlobalPort
-		if served.BoundToLocalhost {
+		if served.BoundToLocalhost || pm.remapsPrivileged(served) {
 			proxy, exists := pm.proxies[port]
 			if exists {
 				mp.GlobalPort = proxy.proxyPort
+				mp.Proxy = proxy.health()
+				mp.PrivilegedRemap = !served.BoundToLocalhost
 			} else {
 				mp.GlobalPort = 0
 			}
@@ -372,27 +1015,254 @@ func (pm *Manager) nextState() map[uint32]*managedPort {
 			mp.GlobalPort = port
 		}
 
-		if mp.GlobalPort == 0 || (mp.Exposed && mp.GlobalPort == exposedGlobalPort) {
-			continue
+		if mp.GlobalPort != 0 && !(mp.Exposed && mp.GlobalPort == exposedGlobalPort) && pm.mayAutoExpose(served) {
+			var public bool
+			config, kind, exists := pm.configs.Get(mp.LocalhostPort)
+			configured := exists && kind == PortConfigKind
+			if mp.Exposed || configured {
+				public = mp.Visibility == api.PortVisibility_public
+			} else if served.Debugger != "" {
+				// debug ports allow to execute code, hence they are private regardless of range configs
+				public = false
+			} else {
+				public = exists && config.Visibility != "private"
+			}
+			pm.autoExpose(ctx, mp, public)
 		}
+	}
 
-		var public bool
-		config, kind, exists := pm.configs.Get(mp.LocalhostPort)
-		configured := exists && kind == PortConfigKind
-		if mp.Exposed || configured {
-			public = mp.Visibility == api.PortVisibility_public
-		} else {
-			public = exists && config.Visibility != "private"
+	if mp == nil {
+		return nil
+	}
+
+	if _, pending := pm.pendingPublic[port]; pending && mp.Exposed && mp.Visibility == api.PortVisibility_public {
+		// the port was made public by other means, e.g. by the user in the IDE
+		delete(pm.pendingPublic, port)
+	}
+	_, mp.PendingPublic = pm.pendingPublic[port]
+	_, mp.PendingExposure = pm.pendingExposures[port]
+	if suggestion, exists := pm.remapSuggestions[port]; exists {
+		mp.RemapSuggestion = &suggestion
+	}
+	if stats, exists := pm.connectionStats[port]; exists && mp.Exposed {
+		mp.Connections = &stats
+	}
+	mp.PolicyViolation = pm.policyViolation(port)
+	mp.Tunneled = pm.tunnels[port] > 0
+	mp.Name = pm.portNames[port]
+	config, _, _ := pm.configs.Get(port)
+	mp.Protocol = portProtocol(config)
+	if opts, exists := pm.dryRunExposures[port]; exists {
+		mp.WouldExpose = &opts
+		if !mp.Exposed {
+			// the action the port would get once exposed
+			config, _, _ := pm.configs.Get(port)
+			mp.OnExposed = getOnExposedAction(config, port)
+		}
+	}
+
+	if mp.Debugger != "" {
+		if _, kind, _ := pm.configs.Get(port); kind != PortConfigKind {
+			mp.OnExposed = api.OnPortExposedAction_ignore
 		}
+	}
+
+	match := pm.configs.Match(port)
+	if match == nil {
+		if onOpen := pm.configs.DefaultOnOpen(); onOpen != "" && mp.Debugger == "" {
+			mp.OnExposed = getOnExposedAction(&gitpod.PortConfig{OnOpen: onOpen}, port)
+		} else if framework := frameworkByName(mp.DetectedAs); framework != nil {
+			mp.OnExposed = getOnExposedAction(framework.config(port), port)
+		}
+	} else {
+		mp.Config = &configMatchStatus{
+			Source:   match.Source,
+			Port:     match.Port,
+			Range:    match.Kind == RangeConfigKind,
+			Override: match.Config.Override,
+		}
+	}
+
+	// supervisor announces the ports of tasks itself, hence IDEs need not notify about them
+	if pm.AnnounceTaskPorts && mp.Group != "" && mp.OnExposed == api.OnPortExposedAction_notify {
+		mp.Announced = true
+		mp.OnExposed = api.OnPortExposedAction_ignore
+	}
+	return mp
+}
+
+func (pm *Manager) autoExpose(ctx context.Context, mp *managedPort, public bool) {
+	if _, closed := pm.closed[mp.LocalhostPort]; closed {
+		return
+	}
+	span, ctx := tracing.FromContext(ctx, "autoExpose")
+	span.SetTag("port", mp.LocalhostPort)
+	span.SetTag("globalPort", mp.GlobalPort)
+	span.SetTag("public", public)
+	// retried with every update until the exposure shows up
+	pm.unsettled[mp.LocalhostPort] = struct{}{}
+	public = pm.mayExposePublicly(mp.LocalhostPort, mp.GlobalPort, public)
+	err := pm.expose(ctx, mp.LocalhostPort, mp.GlobalPort, pm.exposeOptions(mp.LocalhostPort, public))
+	tracing.FinishSpan(span, &err)
+	if err != nil {
+		log.WithError(err).WithField("port", *mp).Warn("cannot auto-expose port")
+		return
+	}
+	if pm.DryRun {
+		return
+	}
+	pm.autoExposed[mp.LocalhostPort] = struct{}{}
+	log.WithField("port", *mp).Warn("auto-expose port")
+}
+
+// enforcePrivate exposes a public port privately again if the organization denies public ports,
+// e.g. because it was made public by other means. It is retried with every update until the port is private.
+func (pm *Manager) enforcePrivate(ctx context.Context, mp *managedPort) {
+	pm.unsettled[mp.LocalhostPort] = struct{}{}
+	err := pm.expose(ctx, mp.LocalhostPort, mp.GlobalPort, pm.exposeOptions(mp.LocalhostPort, false))
+	if err != nil {
+		log.WithError(err).WithField("port", mp.LocalhostPort).Warn("cannot make public port private as required by the organization")
+		return
+	}
+	log.WithField("port", mp.LocalhostPort).Info("public ports are denied by the organization - making port private")
+}
+
+// mayAutoExpose decides whether a served port is proxied and exposed automatically. Denied ports never are. Depending on the ports policy,
+// services which listen on localhost only are kept inside the workspace unless their port is configured.
+// Ports which Docker Compose services expose to other containers only, or which are served in network namespaces
+// not selected for auto-exposure, are never exposed unless configured.
+func (pm *Manager) mayAutoExpose(served ServedPort) bool {
+	if pm.policyViolation(served.Port) != "" {
+		return false
+	}
+	if served.ComposeInternal || served.NamespaceInternal {
+		_, _, configured := pm.configs.Get(served.Port)
+		return configured
+	}
+	if !served.BoundToLocalhost || pm.configs.LocalhostPorts() != LocalhostPortsConfigured {
+		return true
+	}
+	_, _, configured := pm.configs.Get(served.Port)
+	return configured
+}
 
-		err := pm.E.Expose(ctx, mp.LocalhostPort, mp.GlobalPort, public)
+// policyViolation returns why the port must not be exposed, or an empty string if it may be exposed
+func (pm *Manager) policyViolation(port uint32) string {
+	if pm.Denylist.Contains(port) {
+		return fmt.Sprintf("port %d is denied by the workspace operator", port)
+	}
+	if pm.configs.OrganizationDenied(port) {
+		return fmt.Sprintf("port %d is denied by the organization", port)
+	}
+	if pm.configs.Denied(port) {
+		return fmt.Sprintf("port %d is denied by the ports policy", port)
+	}
+	return ""
+}
+
+// mayExposePublicly decides whether a port may be exposed publicly right away. If public exposure requires
+// approval, the port is held as pending public and exposed privately in the meantime.
+func (pm *Manager) mayExposePublicly(port, global uint32, public bool) bool {
+	if pm.configs.PublicDenied() {
+		return false
+	}
+	if !public || !pm.RequirePublicApproval {
+		return public
+	}
+	if _, approved := pm.approved[port]; approved {
+		return true
+	}
+	pm.pendingPublic[port] = global
+	return false
+}
+
+// ApprovePublic approves or rejects making a port public which waits for approval.
+// Approved ports are exposed publicly right away, rejected ports stay private.
+func (pm *Manager) ApprovePublic(ctx context.Context, port uint32, approve bool) (err error) {
+	span, ctx := tracing.FromContext(ctx, "ports.Manager.ApprovePublic")
+	span.SetTag("port", port)
+	span.SetTag("approve", approve)
+	defer tracing.FinishSpan(span, &err)
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	global, pending := pm.pendingPublic[port]
+	if !pending {
+		return xerrors.Errorf("port %d does not wait for approval to become public", port)
+	}
+	if approve && pm.configs.PublicDenied() {
+		return xerrors.Errorf("public ports are denied by the organization")
+	}
+	if approve {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		err = pm.expose(ctx, port, global, pm.exposeOptions(port, true))
 		if err != nil {
-			log.WithError(err).WithField("port", *mp).Warn("cannot auto-expose port")
-			continue
+			log.WithError(err).WithField("port", port).Error("cannot expose port publicly")
+			return err
+		}
+		pm.approved[port] = struct{}{}
+		// the public exposure shows up with the next exposed ports update, which is then attributed to this request
+		pm.requested[port] = struct{}{}
+	}
+	delete(pm.pendingPublic, port)
+	pm.markDirty(port)
+	pm.updateState(ctx, api.PortsUpdateTrigger_manual_action)
+	return nil
+}
+
+// expose exposes a port, or only records the exposure in dry-run mode. Simulated ports are exposed in the simulation only.
+// If the Gitpod server is unreachable, the exposure is queued and made once the server is reachable again.
+func (pm *Manager) expose(ctx context.Context, port, global uint32, opts ExposeOptions) error {
+	if pm.simulatesExposure(port) {
+		pm.exposeSimulated(port, global, opts)
+		return nil
+	}
+	if !pm.DryRun {
+		if len(pm.pendingExposures) > 0 {
+			// keep the order of exposures, and don't wait for an unreachable server again
+			pm.queueExposure(port, global, opts)
+			return nil
+		}
+		err := pm.E.Expose(ctx, port, global, opts)
+		pm.logExposure(port, global, opts, err)
+		if xerrors.Is(err, ErrExposureUnavailable) {
+			pm.queueExposure(port, global, opts)
+			return nil
+		}
+		return err
+	}
+	if prev, exists := pm.dryRunExposures[port]; !exists || !reflect.DeepEqual(prev, opts) {
+		log.WithField("port", port).WithField("globalPort", global).WithField("public", opts.Public).Info("dry-run: would expose port")
+	}
+	pm.dryRunExposures[port] = opts
+	return nil
+}
+
+// exposeOptions produces the options a port is exposed with
+func (pm *Manager) exposeOptions(port uint32, public bool) ExposeOptions {
+	opts := ExposeOptions{Public: public}
+	if config, _, exists := pm.configs.Get(port); exists {
+		opts.Cors = config.Cors
+		if _, valid := validInsecureRequests[config.InsecureRequests]; valid {
+			opts.InsecureRequests = config.InsecureRequests
 		}
-		log.WithField("port", *mp).Warn("auto-expose port")
 	}
-	return state
+	return opts
+}
+
+func getPortProtocol(protocol string) api.PortProtocol {
+	switch protocol {
+	case ProtocolTCP:
+		return api.PortProtocol_tcp
+	case ProtocolUDP:
+		return api.PortProtocol_udp
+	case ProtocolGRPC:
+		return api.PortProtocol_grpc
+	default:
+		return api.PortProtocol_http
+	}
 }
 
 func getOnExposedAction(config *gitpod.PortConfig, port uint32) api.OnPortExposedAction {
@@ -422,75 +1292,341 @@ func (pm *Manager) boundInternally(port uint32) bool {
 	return exists
 }
 
-// Expose exposes a port
-func (pm *Manager) Expose(port uint32, targetPort uint32) error {
+// SetInternal marks a port supervisor serves itself, e.g. the port of a backend started on demand, as internal
+// or no longer internal. Internal ports are not managed, i.e. they are neither listed nor exposed.
+func (pm *Manager) SetInternal(ctx context.Context, port uint32, internal bool) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	mp, ok := pm.state[port]
-	if ok {
-		if mp.Exposed {
-			return nil
-		}
-		if pm.boundInternally(port) {
-			return xerrors.New("internal service cannot be exposed")
+	if internal {
+		pm.internal[port] = struct{}{}
+	} else {
+		for _, proxy := range pm.proxies {
+			if proxy.proxyPort == port {
+				// the port is still used by a proxy
+				return
+			}
 		}
+		delete(pm.internal, port)
 	}
+	pm.markDirty(port)
+	pm.updateState(ctx, api.PortsUpdateTrigger_manual_action)
+}
+
+// Expose exposes a port
+func (pm *Manager) Expose(ctx context.Context, port uint32, targetPort uint32) (err error) {
+	span, ctx := tracing.FromContext(ctx, "ports.Manager.Expose")
+	span.SetTag("port", port)
+	span.SetTag("targetPort", targetPort)
+	defer tracing.FinishSpan(span, &err)
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.boundInternally(port) {
+		return xerrors.New("internal service cannot be exposed")
+	}
+	if mp, ok := pm.state[port]; ok && mp.Exposed {
+		return nil
+	}
+	if violation := pm.policyViolation(port); violation != "" {
+		log.WithField("port", port).Warn("refusing to expose a denied port")
+		return xerrors.New(violation)
+	}
+	_, closed := pm.closed[port]
+	delete(pm.closed, port)
 
 	config, kind, exists := pm.configs.Get(port)
 	if exists && kind == PortConfigKind {
 		// will be auto-exposed
+		if closed {
+			pm.markDirty(port)
+			pm.updateState(ctx, api.PortsUpdateTrigger_manual_action)
+		}
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	global := targetPort
 	if global == 0 {
 		global = port
 	}
-	public := exists && config.Visibility != "private"
-	err := pm.E.Expose(ctx, port, global, public)
+	public := pm.mayExposePublicly(port, global, exists && config.Visibility != "private")
+	err = pm.expose(ctx, port, global, pm.exposeOptions(port, public))
 	if err != nil {
 		log.WithError(err).WithField("port", port).WithField("targetPort", targetPort).Error("cannot expose port")
 		return err
 	}
+	// the exposure shows up with the next exposed ports update, which is then attributed to this request
+	pm.requested[port] = struct{}{}
+	pm.markDirty(port)
+	if _, pending := pm.pendingExposures[port]; pending {
+		// no exposed ports update is coming while the Gitpod server is unreachable
+		pm.updateState(ctx, api.PortsUpdateTrigger_pending_exposures_changed)
+	}
 	return nil
 }
 
-// Subscribe subscribes for status updates
-func (pm *Manager) Subscribe() *Subscription {
+// Close retracts the exposure of a port. The port is not exposed automatically anymore until it is exposed explicitly.
+func (pm *Manager) Close(ctx context.Context, port uint32) (err error) {
+	span, ctx := tracing.FromContext(ctx, "ports.Manager.Close")
+	span.SetTag("port", port)
+	defer tracing.FinishSpan(span, &err)
+
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	if len(pm.subscriptions) > maxSubscriptions {
+	if pm.boundInternally(port) {
+		return xerrors.New("internal service cannot be closed")
+	}
+	pm.closed[port] = struct{}{}
+	delete(pm.autoExposed, port)
+	delete(pm.pendingPublic, port)
+	delete(pm.pendingExposures, port)
+	delete(pm.unsettled, port)
+
+	switch {
+	case pm.simulatesExposure(port):
+		delete(pm.simulatedExposed, port)
+		pm.setExposed(pm.withSimulatedExposed(pm.observedExposed))
+	case pm.DryRun:
+		delete(pm.dryRunExposures, port)
+	default:
+		if _, exposed := pm.exposedByPort[port]; !exposed {
+			break
+		}
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		err = pm.E.Unexpose(ctx, port)
+		if err != nil {
+			log.WithError(err).WithField("port", port).Error("cannot close port")
+			return err
+		}
+	}
+	pm.markDirty(port)
+	pm.updateState(ctx, api.PortsUpdateTrigger_manual_action)
+	return nil
+}
+
+// SetVisibility exposes an exposed port publicly or privately. Making a port public waits for the user's approval
+// if public ports require approval.
+func (pm *Manager) SetVisibility(ctx context.Context, port uint32, public bool) (err error) {
+	span, ctx := tracing.FromContext(ctx, "ports.Manager.SetVisibility")
+	span.SetTag("port", port)
+	span.SetTag("public", public)
+	defer tracing.FinishSpan(span, &err)
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	exposed, ok := pm.exposedByPort[port]
+	if !ok {
+		return xerrors.Errorf("port %d is not exposed", port)
+	}
+	if public && pm.configs.PublicDenied() {
+		return xerrors.Errorf("public ports are denied by the organization")
+	}
+	if !public {
+		delete(pm.pendingPublic, port)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	public = pm.mayExposePublicly(port, exposed.GlobalPort, public)
+	err = pm.expose(ctx, port, exposed.GlobalPort, pm.exposeOptions(port, public))
+	if err != nil {
+		log.WithError(err).WithField("port", port).Error("cannot change port visibility")
+		return err
+	}
+	// the new visibility shows up with the next exposed ports update, which is then attributed to this request
+	pm.requested[port] = struct{}{}
+	pm.markDirty(port)
+	pm.updateState(ctx, api.PortsUpdateTrigger_manual_action)
+	return nil
+}
+
+// Resolve resolves the name of a configured port, or a port number, to the status of the port. Ports which are
+// neither configured, served nor exposed resolve to a status with the local port only.
+func (pm *Manager) Resolve(name string) (*api.PortsStatus, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	var port uint32
+	if p, err := strconv.Atoi(name); err == nil {
+		if !isValidPort(p) {
+			return nil, xerrors.Errorf("port %d is out of range", p)
+		}
+		port = uint32(p)
+	} else {
+		var exists bool
+		port, exists = pm.names[name]
+		if !exists {
+			return nil, xerrors.Errorf("there is no port named %q", name)
+		}
+	}
+	if _, exists := pm.state[port]; !exists {
+		return &api.PortsStatus{LocalPort: port, Name: pm.portNames[port]}, nil
+	}
+	return pm.getPortStatus(port), nil
+}
+
+// Events returns the bus the manager publishes typed port events on. Unlike Subscribe, it suits in-process
+// components which react to particular changes, e.g. ports becoming public, rather than mirror the status.
+func (pm *Manager) Events() *EventBus {
+	return pm.events
+}
+
+// Subscribe subscribes for status updates. The client label identifies the subscriber in Subscribers.
+// The first update is a snapshot which adds all current ports, see Snapshot. Once the manager stopped,
+// subscriptions end right away.
+// Returns nil if there are too many subscriptions already.
+func (pm *Manager) Subscribe(client string) *Subscription {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	return pm.subscribe(client, true)
+}
+
+// Snapshot produces a diff which adds all current ports, along with the current config diagnostics.
+// It lets a subscriber start from the current state rather than from an empty one.
+func (pm *Manager) Snapshot() *Diff {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	return pm.snapshot()
+}
+
+// snapshot produces a diff which adds all current ports. Callers are expected to hold mu.
+func (pm *Manager) snapshot() *Diff {
+	return &Diff{
+		Added:       pm.getStatus(),
+		Diagnostics: pm.getDiagnostics(),
+		Revision:    pm.revision,
+	}
+}
+
+// Resume subscribes for status updates like Subscribe, but first delivers the diffs published after
+// the state the resume token refers to instead of a snapshot. If those diffs are no longer available,
+// resumed is false and the subscription starts with a snapshot like with Subscribe.
+func (pm *Manager) Resume(client string, token string) (sub *Subscription, resumed bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	epoch, revision, err := parseResumeToken(token)
+	resumable := err == nil && epoch == pm.epoch && revision <= pm.revision &&
+		(revision == pm.revision || (len(pm.history) > 0 && revision+1 >= pm.history[0].Revision))
+
+	sub = pm.subscribe(client, !resumable)
+	if sub == nil || !resumable {
+		return sub, false
+	}
+	for _, diff := range pm.history {
+		if diff.Revision > revision {
+			sub.push(diff)
+		}
+	}
+	return sub, true
+}
+
+// ResumeToken produces the token a subscriber can resume from after it received the given revision
+func (pm *Manager) ResumeToken(revision uint64) string {
+	return fmt.Sprintf("%s.%d", pm.epoch, revision)
+}
+
+// Revision returns the revision of the current state
+func (pm *Manager) Revision() uint64 {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	return pm.revision
+}
+
+func parseResumeToken(token string) (epoch string, revision uint64, err error) {
+	segs := strings.Split(token, ".")
+	if len(segs) != 2 {
+		return "", 0, xerrors.Errorf("invalid resume token %q", token)
+	}
+	revision, err = strconv.ParseUint(segs[1], 10, 64)
+	if err != nil {
+		return "", 0, xerrors.Errorf("invalid resume token %q: %w", token, err)
+	}
+	return segs[0], revision, nil
+}
+
+// subscribe creates a subscription, which starts with a snapshot if requested. Callers are expected to hold mu.
+func (pm *Manager) subscribe(client string, withSnapshot bool) *Subscription {
+	if pm.MaxSubscriptions > 0 && len(pm.subscriptions) >= pm.MaxSubscriptions {
 		return nil
 	}
 
-	sub := &Subscription{updates: make(chan *Diff, 5)}
+	sub := newSubscription(client)
 	var once sync.Once
 	sub.Close = func() error {
 		pm.mu.Lock()
 		defer pm.mu.Unlock()
 
-		once.Do(func() { close(sub.updates) })
+		once.Do(func() { close(sub.closed) })
 		delete(pm.subscriptions, sub)
 
 		return nil
 	}
+	if pm.stopped || pm.finished {
+		// there won't be any further updates
+		sub.end()
+		return sub
+	}
+	if withSnapshot {
+		sub.push(pm.snapshot())
+	}
 	pm.subscriptions[sub] = struct{}{}
 
 	return sub
 }
 
+// Subscribers lists the current subscriptions
+func (pm *Manager) Subscribers() []*api.PortsSubscriber {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	subs := make([]*Subscription, 0, len(pm.subscriptions))
+	for sub := range pm.subscriptions {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Since.Before(subs[j].Since) })
+
+	res := make([]*api.PortsSubscriber, 0, len(subs))
+	for _, sub := range subs {
+		since, err := ptypes.TimestampProto(sub.Since)
+		if err != nil {
+			log.WithError(err).WithField("client", sub.Client).Warn("cannot convert subscription time")
+		}
+
+		sub.mu.Lock()
+		queued := len(sub.queue)
+		sub.mu.Unlock()
+
+		res = append(res, &api.PortsSubscriber{
+			Client: sub.Client,
+			Since:  since,
+			Queued: uint32(queued),
+		})
+	}
+	return res
+}
+
 // publishStatus pushes status updates to all subscribers.
 // Callers are expected to hold mu.
-func (pm *Manager) publishStatus(added []uint32, updated []uint32, removed []uint32) {
-	if len(added) == 0 && len(updated) == 0 && len(removed) == 0 {
+func (pm *Manager) publishStatus(added []uint32, updated []uint32, removed []uint32, diagnosticsChanged bool, trigger api.PortsUpdateTrigger) {
+	if len(added) == 0 && len(updated) == 0 && len(removed) == 0 && !diagnosticsChanged {
 		return
 	}
 
-	diff := &Diff{Removed: removed}
+	pm.revision++
+	diff := &Diff{Removed: removed, Trigger: trigger, Revision: pm.revision}
+	if diagnosticsChanged {
+		diff.Diagnostics = pm.getDiagnostics()
+	}
 	for _, port := range added {
 		diff.Added = append(diff.Added, pm.getPortStatus(port))
 	}
@@ -500,13 +1636,38 @@ func (pm *Manager) publishStatus(added []uint32, updated []uint32, removed []uin
 
 	log.WithField("ports", fmt.Sprintf("%+v", diff)).Debug("ports changed")
 
+	pm.history = append(pm.history, diff)
+	if len(pm.history) > maxDiffHistory {
+		pm.history = pm.history[len(pm.history)-maxDiffHistory:]
+	}
 	for sub := range pm.subscriptions {
-		select {
-		case sub.updates <- diff:
-		default:
-			log.Warn("cannot to push ports update to a subscriber")
+		sub.push(diff)
+	}
+}
+
+// publishEvents publishes the events of the ports whose state changed from the previous one.
+// Callers are expected to hold mu and to publish the status first, so that the events carry its revision.
+func (pm *Manager) publishEvents(previous map[uint32]*managedPort, diagnosticsChanged bool, trigger api.PortsUpdateTrigger) {
+	header := EventHeader{Trigger: trigger, Revision: pm.revision}
+	ports := make([]uint32, 0, len(previous))
+	for port := range previous {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+	var events []Event
+	for _, port := range ports {
+		next := pm.state[port]
+		var status *api.PortsStatus
+		if next != nil {
+			status = pm.getPortStatus(port)
 		}
+		events = append(events, portEvents(header, port, previous[port], next, status)...)
 	}
+	if diagnosticsChanged {
+		events = append(events, ConfigDiagnosticsChanged{EventHeader: header, Diagnostics: pm.getDiagnostics()})
+	}
+	pm.events.Publish(events...)
 }
 
 // getStatus produces an API compatible port status list.
@@ -519,12 +1680,74 @@ func (pm *Manager) getStatus() []*api.PortsStatus {
 	return res
 }
 
+// getDiagnostics produces API compatible port config diagnostics.
+// Callers are expected to hold mu.
+func (pm *Manager) getDiagnostics() *api.PortConfigDiagnostics {
+	res := &api.PortConfigDiagnostics{
+		Problems: make([]*api.PortConfigDiagnostic, 0, len(pm.diagnostics)),
+	}
+	for _, d := range pm.diagnostics {
+		source := api.PortConfigSource_workspace_config
+		switch d.Source {
+		case InstanceConfigSource:
+			source = api.PortConfigSource_instance_config
+		case OrganizationConfigSource:
+			source = api.PortConfigSource_organization_config
+		}
+		res.Problems = append(res.Problems, &api.PortConfigDiagnostic{
+			Source:  source,
+			Port:    d.Port,
+			Message: d.Message,
+		})
+	}
+	return res
+}
+
 func (pm *Manager) getPortStatus(port uint32) *api.PortsStatus {
 	mp := pm.state[port]
 	ps := &api.PortsStatus{
-		GlobalPort: mp.GlobalPort,
-		LocalPort:  mp.LocalhostPort,
-		Served:     mp.Served,
+		GlobalPort:        mp.GlobalPort,
+		LocalPort:         mp.LocalhostPort,
+		Served:            mp.Served,
+		DetectedAs:        mp.DetectedAs,
+		Group:             mp.Group,
+		Announced:         mp.Announced,
+		PendingPublic:     mp.PendingPublic,
+		Tunneled:          mp.Tunneled,
+		ComposeService:    mp.ComposeService,
+		NetworkNamespace:  mp.Namespace,
+		KubernetesService: mp.KubernetesService,
+		Debuggable:        mp.Debugger != "",
+		PolicyViolation:   mp.PolicyViolation,
+		Debugger:          mp.Debugger,
+		DebugUrl:          mp.DebugURL,
+		Name:              mp.Name,
+		Process:           mp.Process,
+		PendingExposure:   mp.PendingExposure,
+		Protocol:          getPortProtocol(mp.Protocol),
+		PrivilegedRemap:   mp.PrivilegedRemap,
+	}
+	if mp.RemapSuggestion != nil {
+		ps.RemapSuggestion = &api.PortsStatus_RemapSuggestion{
+			ConfiguredPort: mp.RemapSuggestion.ConfiguredPort,
+			SuggestedPort:  mp.RemapSuggestion.SuggestedPort,
+			OccupiedBy:     mp.RemapSuggestion.OccupiedBy,
+		}
+	}
+	if mp.Connections != nil {
+		ps.Connections = &api.PortsStatus_ConnectionStats{
+			Active:   mp.Connections.Active,
+			Requests: mp.Connections.Requests,
+		}
+	}
+	if mp.Proxy != nil {
+		ps.Proxy = &api.PortsStatus_ProxyStatus{
+			AcceptErrors: mp.Proxy.AcceptErrors,
+			DialErrors:   mp.Proxy.DialErrors,
+			Restarts:     mp.Proxy.Restarts,
+			Degraded:     mp.Proxy.Degraded,
+			LastError:    mp.Proxy.LastError,
+		}
 	}
 	if mp.Exposed {
 		ps.Exposed = &api.PortsStatus_ExposedPortInfo{
@@ -533,42 +1756,49 @@ func (pm *Manager) getPortStatus(port uint32) *api.PortsStatus {
 			OnExposed:  mp.OnExposed,
 		}
 	}
+	if mp.WouldExpose != nil {
+		visibility := api.PortVisibility_private
+		if mp.WouldExpose.Public {
+			visibility = api.PortVisibility_public
+		}
+		ps.WouldExpose = &api.PortsStatus_ExposedPortInfo{
+			Visibility: visibility,
+			OnExposed:  mp.OnExposed,
+		}
+	}
+	if mp.Config != nil {
+		source := api.PortConfigSource_workspace_config
+		if mp.Config.Source == InstanceConfigSource {
+			source = api.PortConfigSource_instance_config
+		}
+		ps.Config = &api.PortConfigMatch{
+			Source:   source,
+			Port:     mp.Config.Port,
+			Range:    mp.Config.Range,
+			Override: mp.Config.Override,
+		}
+	}
 	return ps
 }
 
-func startLocalhostProxy(localPort uint32, globalPort uint32) (io.Closer, error) {
-	host := fmt.Sprintf("localhost:%d", localPort)
-	dsturl, err := url.Parse("http://" + host)
-	if err != nil {
-		return nil, xerrors.Errorf("cannot produce proxy destination URL: %w", err)
-	}
-	proxy := httputil.NewSingleHostReverseProxy(dsturl)
-	originalDirector := proxy.Director
-	proxy.Director = func(req *http.Request) {
-		req.Host = host
-		originalDirector(req)
-	}
-	proxy.ErrorHandler = func(rw http.ResponseWriter, req *http.Request, err error) {
-		log.WithError(err).WithField("local-port", localPort).WithField("url", req.URL.String()).Warn("localhost proxy request failed")
-		rw.WriteHeader(http.StatusBadGateway)
-	}
-	proxyAddr := fmt.Sprintf(":%d", globalPort)
-	lis, err := net.Listen("tcp", proxyAddr)
-	if err != nil {
-		return nil, xerrors.Errorf("cannot listen on proxy port %d: %w", globalPort, err)
-	}
+// rateLimitedListener closes accepted connections right away if they exceed the connection rate limit,
+// so that a misbehaving client cannot starve the proxied service of file descriptors.
+type rateLimitedListener struct {
+	net.Listener
+	bucket    *dropwriter.Bucket
+	localPort uint32
+}
 
-	srv := &http.Server{
-		Addr:    proxyAddr,
-		Handler: proxy,
-	}
-	go func() {
-		err := srv.Serve(lis)
-		if err == http.ErrServerClosed {
-			return
+func (l *rateLimitedListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
 		}
-		log.WithError(err).WithField("local-port", localPort).Error("localhost proxy failed")
-	}()
-
-	return srv, nil
+		if l.bucket.TakeAvailable(1) == 1 {
+			return conn, nil
+		}
+		log.WithField("local-port", l.localPort).WithField("remote-addr", conn.RemoteAddr().String()).Debug("connection rate limit exceeded - closing connection")
+		conn.Close()
+	}
 }
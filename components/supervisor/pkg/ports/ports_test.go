@@ -6,15 +6,21 @@ package ports
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
+	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/dropwriter"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"golang.org/x/xerrors"
 )
 
 func TestPortsUpdateState(t *testing.T) {
@@ -23,6 +29,7 @@ func TestPortsUpdateState(t *testing.T) {
 	type ConfigChange struct {
 		workspace []*gitpod.PortConfig
 		instance  []*gitpod.PortsItems
+		policy    *gitpod.PortsPolicy
 	}
 	type Change struct {
 		Config     *ConfigChange
@@ -33,19 +40,23 @@ func TestPortsUpdateState(t *testing.T) {
 		ExposedErr error
 	}
 	tests := []struct {
-		Desc             string
-		InternalPorts    []uint32
-		Changes          []Change
-		ExpectedExposure ExposureExpectation
-		ExpectedUpdates  UpdateExpectation
+		Desc              string
+		InternalPorts     []uint32
+		Denylist          Denylist
+		AnnounceTaskPorts bool
+		Changes           []Change
+		ExpectedExposure  ExposureExpectation
+		ExpectedUpdates   UpdateExpectation
+		// ExpectedTriggers are the triggers of the expected updates, they are not checked if not set
+		ExpectedTriggers []api.PortsUpdateTrigger
 	}{
 		{
 			Desc: "basic locally served",
 			Changes: []Change{
-				{Served: []ServedPort{{8080, true}}},
+				{Served: []ServedPort{{Port: 8080, BoundToLocalhost: true}}},
 				{Exposed: []ExposedPort{{LocalPort: 8080, GlobalPort: 60000}}},
-				{Served: []ServedPort{{8080, true}, {60000, false}}},
-				{Served: []ServedPort{{60000, false}}},
+				{Served: []ServedPort{{Port: 8080, BoundToLocalhost: true}, {Port: 60000, BoundToLocalhost: false}}},
+				{Served: []ServedPort{{Port: 60000, BoundToLocalhost: false}}},
 				{Served: []ServedPort{}},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -56,11 +67,16 @@ func TestPortsUpdateState(t *testing.T) {
 				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 60000, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{OnExposed: api.OnPortExposedAction_notify_private, Visibility: api.PortVisibility_private}}}},
 				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 60000, Served: false, Exposed: &api.PortsStatus_ExposedPortInfo{OnExposed: api.OnPortExposedAction_notify_private, Visibility: api.PortVisibility_private}}}},
 			},
+			ExpectedTriggers: []api.PortsUpdateTrigger{
+				api.PortsUpdateTrigger_served_ports_changed,
+				api.PortsUpdateTrigger_exposed_ports_changed,
+				api.PortsUpdateTrigger_served_ports_changed,
+			},
 		},
 		{
 			Desc: "basic globally served",
 			Changes: []Change{
-				{Served: []ServedPort{{8080, false}}},
+				{Served: []ServedPort{{Port: 8080, BoundToLocalhost: false}}},
 				{Served: []ServedPort{}},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -84,12 +100,106 @@ func TestPortsUpdateState(t *testing.T) {
 				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 8080, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, Url: "foobar", OnExposed: api.OnPortExposedAction_notify_private}}}},
 			},
 		},
+		{
+			Desc: "detected dev server",
+			Changes: []Change{
+				{Served: []ServedPort{{Port: 5173, DetectedAs: "vite"}}},
+				{Exposed: []ExposedPort{{LocalPort: 5173, GlobalPort: 5173, URL: "foobar"}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 5173, GlobalPort: 5173},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{Added: []*api.PortsStatus{{LocalPort: 5173, GlobalPort: 5173, Served: true, DetectedAs: "vite"}}},
+				{Updated: []*api.PortsStatus{{LocalPort: 5173, GlobalPort: 5173, Served: true, DetectedAs: "vite", Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, Url: "foobar", OnExposed: api.OnPortExposedAction_open_preview}}}},
+			},
+		},
+		{
+			Desc: "localhost ports exposed only if configured",
+			Changes: []Change{
+				{Config: &ConfigChange{
+					instance: []*gitpod.PortsItems{{Port: 8080, Visibility: "private"}},
+					policy:   &gitpod.PortsPolicy{LocalhostPorts: "configured"},
+				}},
+				{Served: []ServedPort{
+					{Port: 8080, BoundToLocalhost: true},
+					{Port: 5432, BoundToLocalhost: true},
+					{Port: 3000},
+				}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 3000, GlobalPort: 3000},
+				{LocalPort: 8080},
+				{LocalPort: 8080},
+				{LocalPort: 8080, GlobalPort: 60000},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{Added: []*api.PortsStatus{{LocalPort: 8080, Config: &api.PortConfigMatch{Port: "8080", Source: api.PortConfigSource_instance_config}}}},
+				{
+					Added: []*api.PortsStatus{
+						{LocalPort: 3000, GlobalPort: 3000, Served: true},
+						{LocalPort: 5432, Served: true},
+					},
+					Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 60000, Served: true, Config: &api.PortConfigMatch{Port: "8080", Source: api.PortConfigSource_instance_config}}},
+				},
+			},
+		},
+		{
+			Desc: "port served by a task",
+			Changes: []Change{
+				{Served: []ServedPort{{Port: 3000, Group: "0"}}},
+				{Exposed: []ExposedPort{{LocalPort: 3000, GlobalPort: 3000, URL: "foobar"}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 3000, GlobalPort: 3000},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{Added: []*api.PortsStatus{{LocalPort: 3000, GlobalPort: 3000, Served: true, Group: "0"}}},
+				{Updated: []*api.PortsStatus{{LocalPort: 3000, GlobalPort: 3000, Served: true, Group: "0", Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, Url: "foobar", OnExposed: api.OnPortExposedAction_notify_private}}}},
+			},
+		},
+		{
+			Desc:              "announced port served by a task",
+			AnnounceTaskPorts: true,
+			Changes: []Change{
+				{Config: &ConfigChange{workspace: []*gitpod.PortConfig{{Port: 3000, OnOpen: "notify"}}}},
+				{Served: []ServedPort{{Port: 3000, Group: "0"}, {Port: 4000, Group: "0"}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 3000, Public: true},
+				{LocalPort: 3000, Public: true},
+				{LocalPort: 3000, GlobalPort: 3000, Public: true},
+				{LocalPort: 4000, GlobalPort: 4000},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{Added: []*api.PortsStatus{{LocalPort: 3000, Config: &api.PortConfigMatch{Port: "3000"}}}},
+				{
+					Added:   []*api.PortsStatus{{LocalPort: 4000, GlobalPort: 4000, Served: true, Group: "0"}},
+					Updated: []*api.PortsStatus{{LocalPort: 3000, GlobalPort: 3000, Served: true, Group: "0", Config: &api.PortConfigMatch{Port: "3000"}, Announced: true}},
+				},
+			},
+		},
+		{
+			Desc: "ports served by compose services",
+			Changes: []Change{
+				{Served: []ServedPort{{Port: 3000, ComposeService: "web"}, {Port: 5432, ComposeService: "db", ComposeInternal: true}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 3000, GlobalPort: 3000},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{Added: []*api.PortsStatus{
+					{LocalPort: 3000, GlobalPort: 3000, Served: true, ComposeService: "web"},
+					{LocalPort: 5432, GlobalPort: 5432, Served: true, ComposeService: "db"},
+				}},
+			},
+		},
 		{
 			Desc:          "internal ports served",
 			InternalPorts: []uint32{8080},
 			Changes: []Change{
 				{Served: []ServedPort{}},
-				{Served: []ServedPort{{8080, false}}},
+				{Served: []ServedPort{{Port: 8080, BoundToLocalhost: false}}},
 			},
 
 			ExpectedExposure: ExposureExpectation(nil),
@@ -112,8 +222,8 @@ func TestPortsUpdateState(t *testing.T) {
 				},
 				{
 					Served: []ServedPort{
-						{8080, false},
-						{9229, true},
+						{Port: 8080, BoundToLocalhost: false},
+						{Port: 9229, BoundToLocalhost: true},
 					},
 				},
 			},
@@ -123,14 +233,14 @@ func TestPortsUpdateState(t *testing.T) {
 				{LocalPort: 9229, GlobalPort: 60000},
 			},
 			ExpectedUpdates: UpdateExpectation{
-				{Added: []*api.PortsStatus{{LocalPort: 8080}, {LocalPort: 9229}}},
+				{Added: []*api.PortsStatus{{LocalPort: 8080, Config: &api.PortConfigMatch{Port: "8080"}}, {LocalPort: 9229, Config: &api.PortConfigMatch{Port: "9229"}}}},
 				{Updated: []*api.PortsStatus{
-					{LocalPort: 8080, GlobalPort: 8080, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, Url: "8080-foobar", OnExposed: api.OnPortExposedAction_open_browser}},
-					{LocalPort: 9229, GlobalPort: 9229, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, Url: "9229-foobar", OnExposed: api.OnPortExposedAction_ignore}},
+					{LocalPort: 8080, GlobalPort: 8080, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, Url: "8080-foobar", OnExposed: api.OnPortExposedAction_open_browser}, Config: &api.PortConfigMatch{Port: "8080"}},
+					{LocalPort: 9229, GlobalPort: 9229, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, Url: "9229-foobar", OnExposed: api.OnPortExposedAction_ignore}, Config: &api.PortConfigMatch{Port: "9229"}},
 				}},
 				{Updated: []*api.PortsStatus{
-					{LocalPort: 8080, GlobalPort: 8080, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, Url: "8080-foobar", OnExposed: api.OnPortExposedAction_open_browser}},
-					{LocalPort: 9229, GlobalPort: 60000, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, Url: "9229-foobar", OnExposed: api.OnPortExposedAction_ignore}},
+					{LocalPort: 8080, GlobalPort: 8080, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, Url: "8080-foobar", OnExposed: api.OnPortExposedAction_open_browser}, Config: &api.PortConfigMatch{Port: "8080"}},
+					{LocalPort: 9229, GlobalPort: 60000, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, Url: "9229-foobar", OnExposed: api.OnPortExposedAction_ignore}, Config: &api.PortConfigMatch{Port: "9229"}},
 				}},
 			},
 		},
@@ -143,17 +253,64 @@ func TestPortsUpdateState(t *testing.T) {
 						Port:   "4000-5000",
 					}},
 				}},
-				{Served: []ServedPort{{4040, true}}},
+				{Served: []ServedPort{{Port: 4040, BoundToLocalhost: true}}},
 				{Exposed: []ExposedPort{{LocalPort: 4040, GlobalPort: 60000, Public: true, URL: "4040-foobar"}}},
-				{Served: []ServedPort{{4040, true}, {60000, false}}},
+				{Served: []ServedPort{{Port: 4040, BoundToLocalhost: true}, {Port: 60000, BoundToLocalhost: false}}},
 			},
 			ExpectedExposure: []ExposedPort{
 				{LocalPort: 4040, GlobalPort: 60000, Public: true},
 			},
 			ExpectedUpdates: UpdateExpectation{
-				{Added: []*api.PortsStatus{{LocalPort: 4040, GlobalPort: 60000, Served: true}}},
+				{Added: []*api.PortsStatus{{LocalPort: 4040, GlobalPort: 60000, Served: true, Config: &api.PortConfigMatch{Source: api.PortConfigSource_instance_config, Port: "4000-5000", Range: true}}}},
 				{Updated: []*api.PortsStatus{
-					{LocalPort: 4040, GlobalPort: 60000, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, Url: "4040-foobar", OnExposed: api.OnPortExposedAction_open_browser}},
+					{LocalPort: 4040, GlobalPort: 60000, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, Url: "4040-foobar", OnExposed: api.OnPortExposedAction_open_browser}, Config: &api.PortConfigMatch{Source: api.PortConfigSource_instance_config, Port: "4000-5000", Range: true}},
+				}},
+			},
+		},
+		{
+			Desc: "debug port in a public port range",
+			Changes: []Change{
+				{Config: &ConfigChange{
+					instance: []*gitpod.PortsItems{{
+						OnOpen:     "open-browser",
+						Port:       "9000-9999",
+						Visibility: "public",
+					}},
+				}},
+				{Served: []ServedPort{{Port: 9229, Debugger: DebuggerNodeInspector, DebugURL: "devtools://foobar"}}},
+				{Exposed: []ExposedPort{{LocalPort: 9229, GlobalPort: 9229, URL: "9229-foobar"}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 9229, GlobalPort: 9229},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{Added: []*api.PortsStatus{{LocalPort: 9229, GlobalPort: 9229, Served: true, Debuggable: true, Debugger: "node-inspector", DebugUrl: "devtools://foobar", Config: &api.PortConfigMatch{Source: api.PortConfigSource_instance_config, Port: "9000-9999", Range: true}}}},
+				{Updated: []*api.PortsStatus{
+					{LocalPort: 9229, GlobalPort: 9229, Served: true, Debuggable: true, Debugger: "node-inspector", DebugUrl: "devtools://foobar", Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, Url: "9229-foobar", OnExposed: api.OnPortExposedAction_ignore}, Config: &api.PortConfigMatch{Source: api.PortConfigSource_instance_config, Port: "9000-9999", Range: true}},
+				}},
+			},
+		},
+		{
+			Desc:     "denied ports",
+			Denylist: Denylist{{Start: 22, End: 22}},
+			Changes: []Change{
+				{Config: &ConfigChange{
+					instance: []*gitpod.PortsItems{{Port: 6000, Visibility: "public"}},
+					policy:   &gitpod.PortsPolicy{Deny: []interface{}{"6000-6100"}},
+				}},
+				{Served: []ServedPort{{Port: 22}, {Port: 6001, BoundToLocalhost: true}, {Port: 8080}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 8080, GlobalPort: 8080},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{Added: []*api.PortsStatus{
+					{LocalPort: 6000, PolicyViolation: "port 6000 is denied by the ports policy", Config: &api.PortConfigMatch{Source: api.PortConfigSource_instance_config, Port: "6000"}},
+				}},
+				{Added: []*api.PortsStatus{
+					{LocalPort: 22, GlobalPort: 22, Served: true, PolicyViolation: "port 22 is denied by the workspace operator"},
+					{LocalPort: 6001, Served: true, PolicyViolation: "port 6001 is denied by the ports policy"},
+					{LocalPort: 8080, GlobalPort: 8080, Served: true},
 				}},
 			},
 		},
@@ -172,22 +329,22 @@ func TestPortsUpdateState(t *testing.T) {
 					Exposed: []ExposedPort{{LocalPort: 8080, GlobalPort: 8080, Public: true, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{8080, true}},
+					Served: []ServedPort{{Port: 8080, BoundToLocalhost: true}},
 				},
 				{
 					Exposed: []ExposedPort{{LocalPort: 8080, GlobalPort: 60000, Public: true, URL: "foobar"}},
 				},
 				{
-					Served: []ServedPort{{8080, true}, {60000, false}},
+					Served: []ServedPort{{Port: 8080, BoundToLocalhost: true}, {Port: 60000, BoundToLocalhost: false}},
 				},
 				{
-					Served: []ServedPort{{60000, false}},
+					Served: []ServedPort{{Port: 60000, BoundToLocalhost: false}},
 				},
 				{
 					Served: []ServedPort{},
 				},
 				{
-					Served: []ServedPort{{8080, false}},
+					Served: []ServedPort{{Port: 8080, BoundToLocalhost: false}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -196,19 +353,71 @@ func TestPortsUpdateState(t *testing.T) {
 				{LocalPort: 8080, GlobalPort: 8080, Public: true},
 			},
 			ExpectedUpdates: UpdateExpectation{
-				{Added: []*api.PortsStatus{{LocalPort: 8080}}},
-				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 8080, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}}}},
-				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 8080, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}}}},
-				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 60000, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}}}},
-				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 60000, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}}}},
-				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 8080, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}}}},
+				{Added: []*api.PortsStatus{{LocalPort: 8080, Config: &api.PortConfigMatch{Port: "8080"}}}},
+				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 8080, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}, Config: &api.PortConfigMatch{Port: "8080"}}}},
+				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 8080, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}, Config: &api.PortConfigMatch{Port: "8080"}}}},
+				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 60000, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}, Config: &api.PortConfigMatch{Port: "8080"}}}},
+				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 60000, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}, Config: &api.PortConfigMatch{Port: "8080"}}}},
+				{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 8080, Served: true, Exposed: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_notify, Url: "foobar"}, Config: &api.PortConfigMatch{Port: "8080"}}}},
+			},
+		},
+		{
+			Desc: "invalid port configs",
+			Changes: []Change{
+				{Config: &ConfigChange{
+					instance: []*gitpod.PortsItems{
+						{Port: "5000-4000"},
+						{Port: 8080, OnOpen: "open-somewhere"},
+					},
+				}},
+				{Config: &ConfigChange{
+					instance: []*gitpod.PortsItems{
+						{Port: 8080},
+					},
+				}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 8080, Public: true},
+				{LocalPort: 8080, Public: true},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{
+					Added: []*api.PortsStatus{{LocalPort: 8080, Config: &api.PortConfigMatch{Source: api.PortConfigSource_instance_config, Port: "8080"}}},
+					Diagnostics: &api.PortConfigDiagnostics{Problems: []*api.PortConfigDiagnostic{
+						{Source: api.PortConfigSource_instance_config, Port: "5000-4000", Message: "invalid port range, start must be lower than end"},
+						{Source: api.PortConfigSource_instance_config, Port: "8080", Message: "unknown onOpen value \"open-somewhere\", falling back to notify"},
+					}},
+				},
+				{Diagnostics: &api.PortConfigDiagnostics{Problems: []*api.PortConfigDiagnostic{}}},
+			},
+		},
+		{
+			Desc: "static global port mapping",
+			Changes: []Change{
+				{Config: &ConfigChange{
+					instance: []*gitpod.PortsItems{{Port: 5432, GlobalPort: 15432, OnOpen: "ignore"}},
+				}},
+				{Served: []ServedPort{{Port: 5432, BoundToLocalhost: true}, {Port: 3000, BoundToLocalhost: true}}},
+			},
+			ExpectedExposure: []ExposedPort{
+				{LocalPort: 5432, Public: true},
+				{LocalPort: 5432, Public: true},
+				{LocalPort: 5432, GlobalPort: 15432, Public: true},
+				{LocalPort: 3000, GlobalPort: 60000},
+			},
+			ExpectedUpdates: UpdateExpectation{
+				{Added: []*api.PortsStatus{{LocalPort: 5432, Config: &api.PortConfigMatch{Source: api.PortConfigSource_instance_config, Port: "5432"}}}},
+				{
+					Added:   []*api.PortsStatus{{LocalPort: 3000, GlobalPort: 60000, Served: true}},
+					Updated: []*api.PortsStatus{{LocalPort: 5432, GlobalPort: 15432, Served: true, Config: &api.PortConfigMatch{Source: api.PortConfigSource_instance_config, Port: "5432"}}},
+				},
 			},
 		},
 		{
 			Desc: "starting multiple proxies for the same served event",
 			Changes: []Change{
 				{
-					Served: []ServedPort{{8080, true}, {3000, true}},
+					Served: []ServedPort{{Port: 8080, BoundToLocalhost: true}, {Port: 3000, BoundToLocalhost: true}},
 				},
 			},
 			ExpectedExposure: []ExposedPort{
@@ -243,10 +452,17 @@ func TestPortsUpdateState(t *testing.T) {
 				pm    = NewManager(exposed, served, config, test.InternalPorts...)
 				updts []*Diff
 			)
-			pm.proxyStarter = func(localPort uint32, globalPort uint32) (io.Closer, error) {
+			pm.Denylist = test.Denylist
+			pm.AnnounceTaskPorts = test.AnnounceTaskPorts
+			pm.proxyStarter = func(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (io.Closer, error) {
 				return ioutil.NopCloser(nil), nil
 			}
 
+			// subscribe before running the manager to not miss any updates
+			sub := pm.Subscribe("test")
+			// skip the snapshot of the yet empty state
+			<-sub.Updates()
+
 			var wg sync.WaitGroup
 			wg.Add(3)
 			go func() {
@@ -265,10 +481,15 @@ func TestPortsUpdateState(t *testing.T) {
 				for _, c := range test.Changes {
 					if c.Config != nil {
 						change := &Configs{}
-						change.workspaceConfigs = parseWorkspaceConfigs(c.Config.workspace)
-						portConfigs, rangeConfigs := parseInstanceConfigs(c.Config.instance)
+						change.workspaceConfigs, change.workspaceDiagnostics = parseWorkspaceConfigs(c.Config.workspace)
+						portConfigs, rangeConfigs, diagnostics := parseInstanceConfigs(c.Config.instance)
 						change.instancePortConfigs = portConfigs
 						change.instanceRangeConfigs = rangeConfigs
+						change.instanceDiagnostics = diagnostics
+						change.instancePolicy = c.Config.policy
+						if c.Config.policy != nil {
+							change.instanceDenylist, _ = parseConfigDenylist(InstanceConfigSource, c.Config.policy.Deny)
+						}
 						config.Changes <- change
 					} else if c.ConfigErr != nil {
 						config.Error <- c.ConfigErr
@@ -285,8 +506,6 @@ func TestPortsUpdateState(t *testing.T) {
 			}()
 			go func() {
 				defer wg.Done()
-
-				sub := pm.Subscribe()
 				defer sub.Close()
 
 				for up := range sub.Updates() {
@@ -303,13 +522,889 @@ func TestPortsUpdateState(t *testing.T) {
 
 			sorPorts := cmpopts.SortSlices(func(x, y uint32) bool { return x < y })
 			sortPortStatus := cmpopts.SortSlices(func(x, y *api.PortsStatus) bool { return x.LocalPort < y.LocalPort })
-			if diff := cmp.Diff(test.ExpectedUpdates, UpdateExpectation(updts), sorPorts, sortPortStatus); diff != "" {
+			// triggers and revisions are checked separately
+			ignoreTrigger := cmpopts.IgnoreFields(Diff{}, "Trigger", "Revision")
+			if diff := cmp.Diff(test.ExpectedUpdates, UpdateExpectation(updts), sorPorts, sortPortStatus, ignoreTrigger); diff != "" {
 				t.Errorf("unexpected updates (-want +got):\n%s", diff)
 			}
+			if test.ExpectedTriggers != nil {
+				var triggers []api.PortsUpdateTrigger
+				for _, u := range updts {
+					triggers = append(triggers, u.Trigger)
+				}
+				if diff := cmp.Diff(test.ExpectedTriggers, triggers); diff != "" {
+					t.Errorf("unexpected update triggers (-want +got):\n%s", diff)
+				}
+			}
 		})
 	}
 }
 
+func TestPortsManagerStop(t *testing.T) {
+	var (
+		exposed = &testExposedPorts{
+			Changes: make(chan []ExposedPort),
+			Error:   make(chan error),
+		}
+		served = &testServedPorts{
+			Changes: make(chan []ServedPort),
+			Error:   make(chan error),
+		}
+		config = &testConfigService{
+			Changes: make(chan *Configs),
+			Error:   make(chan error),
+		}
+		pm    = NewManager(exposed, served, config)
+		proxy = &testProxy{}
+	)
+	pm.proxyStarter = func(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (io.Closer, error) {
+		return proxy, nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pm.Run()
+	}()
+	sub := pm.Subscribe("test")
+	// skip the snapshot of the yet empty state
+	<-sub.Updates()
+
+	served.Changes <- []ServedPort{{Port: 8080, BoundToLocalhost: true}}
+	if diff := <-sub.Updates(); len(diff.Added) != 1 {
+		t.Fatalf("expected port 8080 to be added, got %+v", diff)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := pm.Stop(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-done:
+	default:
+		t.Error("Run did not return")
+	}
+	if !proxy.closed {
+		t.Error("localhost proxy was not closed")
+	}
+	if diff := cmp.Diff([]uint32{8080}, exposed.Unexposures); diff != "" {
+		t.Errorf("unexpected unexposures (-want +got):\n%s", diff)
+	}
+	var updates []*Diff
+	for update := range sub.Updates() {
+		updates = append(updates, update)
+	}
+	if diff := cmp.Diff([]*Diff{{Removed: []uint32{8080}, Trigger: api.PortsUpdateTrigger_ports_shutdown, Revision: 2}}, updates); diff != "" {
+		t.Errorf("unexpected updates after stop (-want +got):\n%s", diff)
+	}
+
+	_, ok := <-pm.Subscribe("test").Updates()
+	if ok {
+		t.Error("subscription after stop is not closed")
+	}
+}
+
+func TestPortsResolve(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	configs := &Configs{}
+	configs.instancePortConfigs, configs.instanceRangeConfigs, _ = parseInstanceConfigs([]*gitpod.PortsItems{
+		{Port: 3000, Name: "api"},
+		{Port: 3001, Name: "admin"},
+	})
+	pm.mu.Lock()
+	pm.setConfigs(configs)
+	pm.setServed([]ServedPort{{Port: 3000}})
+	pm.setExposed([]ExposedPort{{LocalPort: 3000, GlobalPort: 3000, URL: "https://3000-ws.gitpod.io/"}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_port_configs_changed)
+	pm.mu.Unlock()
+
+	type resolvedPort struct {
+		LocalPort  uint32
+		GlobalPort uint32
+		Name       string
+		URL        string
+	}
+	tests := []struct {
+		Name        string
+		Expectation *resolvedPort
+		Error       string
+	}{
+		{Name: "api", Expectation: &resolvedPort{LocalPort: 3000, GlobalPort: 3000, Name: "api", URL: "https://3000-ws.gitpod.io/"}},
+		{Name: "3000", Expectation: &resolvedPort{LocalPort: 3000, GlobalPort: 3000, Name: "api", URL: "https://3000-ws.gitpod.io/"}},
+		{Name: "admin", Expectation: &resolvedPort{LocalPort: 3001, Name: "admin"}},
+		{Name: "8080", Expectation: &resolvedPort{LocalPort: 8080}},
+		{Name: "web", Error: "there is no port named \"web\""},
+		{Name: "70000", Error: "port 70000 is out of range"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			status, err := pm.Resolve(test.Name)
+			if test.Error != "" {
+				if err == nil || err.Error() != test.Error {
+					t.Fatalf("unexpected error: want %q, got %v", test.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := &resolvedPort{LocalPort: status.LocalPort, GlobalPort: status.GlobalPort, Name: status.Name}
+			if status.Exposed != nil {
+				actual.URL = status.Exposed.Url
+			}
+			if diff := cmp.Diff(test.Expectation, actual); diff != "" {
+				t.Errorf("unexpected port (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPortsSetInternal(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	pm.mu.Lock()
+	pm.setServed([]ServedPort{{Port: 5990}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	pm.mu.Unlock()
+
+	listed := func() bool {
+		for _, p := range pm.Status() {
+			if p.LocalPort == 5990 {
+				return true
+			}
+		}
+		return false
+	}
+	if !listed() {
+		t.Fatal("expected served port to be listed")
+	}
+
+	pm.SetInternal(context.Background(), 5990, true)
+	if listed() {
+		t.Error("expected internal port not to be listed")
+	}
+	if err := pm.Expose(context.Background(), 5990, 0); err == nil {
+		t.Error("expected internal port not to be exposed")
+	}
+
+	pm.SetInternal(context.Background(), 5990, false)
+	if !listed() {
+		t.Error("expected port to be listed once no longer internal")
+	}
+}
+
+func TestPortsCloseAndVisibility(t *testing.T) {
+	exposed := &testExposedPorts{}
+	pm := NewManager(exposed, &testServedPorts{}, &testConfigService{})
+	observe := func(ports ...ExposedPort) {
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+		pm.setExposed(ports)
+		pm.updateState(context.Background(), api.PortsUpdateTrigger_exposed_ports_changed)
+	}
+	exposures := func() int {
+		exposed.mu.Lock()
+		defer exposed.mu.Unlock()
+		return len(exposed.Exposures)
+	}
+
+	pm.mu.Lock()
+	pm.setServed([]ServedPort{{Port: 3000}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	pm.mu.Unlock()
+	if exposures() != 1 {
+		t.Fatalf("expected served port to be auto-exposed, got %v", exposed.Exposures)
+	}
+	observe(ExposedPort{LocalPort: 3000, GlobalPort: 3000, URL: "foobar"})
+
+	err := pm.Close(context.Background(), 3000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]uint32{3000}, exposed.Unexposures); diff != "" {
+		t.Errorf("unexpected unexposures (-want +got):\n%s", diff)
+	}
+	observe()
+	if exposures() != 1 {
+		t.Errorf("expected closed port not to be auto-exposed again, got %v", exposed.Exposures)
+	}
+	if err := pm.SetVisibility(context.Background(), 3000, true); err == nil {
+		t.Error("expected an error changing the visibility of a closed port")
+	}
+
+	err = pm.Expose(context.Background(), 3000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exposures() != 2 {
+		t.Fatalf("expected port to be exposed again, got %v", exposed.Exposures)
+	}
+	observe(ExposedPort{LocalPort: 3000, GlobalPort: 3000, URL: "foobar"})
+
+	err = pm.SetVisibility(context.Background(), 3000, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exposed.mu.Lock()
+	defer exposed.mu.Unlock()
+	if last := exposed.Exposures[len(exposed.Exposures)-1]; !last.Public || last.GlobalPort != 3000 {
+		t.Errorf("expected port to be exposed publicly, got %v", last)
+	}
+}
+
+type testProxy struct {
+	closed bool
+}
+
+func (p *testProxy) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestPortsManualExposeTrigger(t *testing.T) {
+	var (
+		exposed = &testExposedPorts{
+			Changes: make(chan []ExposedPort),
+			Error:   make(chan error),
+		}
+		served = &testServedPorts{
+			Changes: make(chan []ServedPort),
+			Error:   make(chan error),
+		}
+		config = &testConfigService{
+			Changes: make(chan *Configs),
+			Error:   make(chan error),
+		}
+		pm = NewManager(exposed, served, config)
+	)
+	go pm.Run()
+	defer pm.Stop(context.Background(), false)
+	sub := pm.Subscribe("test")
+	// skip the snapshot of the yet empty state
+	<-sub.Updates()
+
+	err := pm.Expose(context.Background(), 3000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exposed.Changes <- []ExposedPort{{LocalPort: 3000, GlobalPort: 3000, URL: "foobar"}}
+	if update := <-sub.Updates(); update.Trigger != api.PortsUpdateTrigger_manual_action {
+		t.Errorf("expected manual action trigger, got %v", update.Trigger)
+	}
+
+	exposed.Changes <- []ExposedPort{{LocalPort: 3000, GlobalPort: 3000, URL: "foobar", Public: true}}
+	if update := <-sub.Updates(); update.Trigger != api.PortsUpdateTrigger_exposed_ports_changed {
+		t.Errorf("expected exposed ports trigger, got %v", update.Trigger)
+	}
+}
+
+func TestPortsPendingExposures(t *testing.T) {
+	var (
+		exposed = &unreachableExposedPorts{
+			testExposedPorts: testExposedPorts{
+				Changes: make(chan []ExposedPort),
+				Error:   make(chan error),
+			},
+			Unreachable: true,
+		}
+		served = &testServedPorts{
+			Changes: make(chan []ServedPort),
+			Error:   make(chan error),
+		}
+		config = &testConfigService{
+			Changes: make(chan *Configs),
+			Error:   make(chan error),
+		}
+		pm = NewManager(exposed, served, config)
+	)
+	// replays are triggered by exposed ports updates only
+	pm.exposureRetryDelay = time.Hour
+	go pm.Run()
+	defer pm.Stop(context.Background(), false)
+	sub := pm.Subscribe("test")
+	// skip the snapshot of the yet empty state
+	<-sub.Updates()
+
+	pending := func(update *Diff) map[uint32]bool {
+		res := make(map[uint32]bool)
+		for _, status := range append(update.Added, update.Updated...) {
+			res[status.LocalPort] = status.PendingExposure
+		}
+		return res
+	}
+
+	served.Changes <- []ServedPort{{Port: 3000}, {Port: 3001}}
+	update := <-sub.Updates()
+	if diff := cmp.Diff(map[uint32]bool{3000: true, 3001: true}, pending(update)); diff != "" {
+		t.Errorf("unexpected pending exposures (-want +got):\n%s", diff)
+	}
+
+	err := pm.Expose(context.Background(), 3000, 0)
+	if err != nil {
+		t.Fatalf("expected queued exposure to succeed, got %v", err)
+	}
+	if attempts := exposed.attempts(); attempts != 1 {
+		t.Errorf("expected exposures to be queued without contacting the server again, got %d attempts", attempts)
+	}
+	pm.mu.RLock()
+	queued := len(pm.pendingExposures)
+	pm.mu.RUnlock()
+	if queued != 2 {
+		t.Errorf("expected 2 queued exposures, got %d", queued)
+	}
+
+	exposed.mu.Lock()
+	exposed.Unreachable = false
+	exposed.mu.Unlock()
+	exposed.Changes <- []ExposedPort{{LocalPort: 4000, GlobalPort: 4000, URL: "foobar"}}
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case update = <-sub.Updates():
+		case <-timeout:
+			t.Fatal("queued exposures were not replayed")
+		}
+		if update.Trigger == api.PortsUpdateTrigger_pending_exposures_changed {
+			break
+		}
+	}
+	if diff := cmp.Diff(map[uint32]bool{3000: false, 3001: false}, pending(update)); diff != "" {
+		t.Errorf("unexpected pending exposures after replay (-want +got):\n%s", diff)
+	}
+	exposed.mu.Lock()
+	replayed := make(map[uint32]bool)
+	for _, e := range exposed.Exposures {
+		replayed[e.LocalPort] = true
+	}
+	exposed.mu.Unlock()
+	if diff := cmp.Diff(map[uint32]bool{3000: true, 3001: true}, replayed); diff != "" {
+		t.Errorf("unexpected replayed exposures (-want +got):\n%s", diff)
+	}
+}
+
+func TestPortsExposeOptions(t *testing.T) {
+	var (
+		exposed = &testExposedPorts{
+			Changes: make(chan []ExposedPort),
+			Error:   make(chan error),
+		}
+		served = &testServedPorts{
+			Changes: make(chan []ServedPort),
+			Error:   make(chan error),
+		}
+		config = &testConfigService{
+			Changes: make(chan *Configs),
+			Error:   make(chan error),
+		}
+		pm   = NewManager(exposed, served, config)
+		cors = &gitpod.PortCorsConfig{AllowedOrigins: []string{"https://example.com"}, AllowCredentials: true}
+	)
+	go pm.Run()
+	defer pm.Stop(context.Background(), false)
+	sub := pm.Subscribe("test")
+	// skip the snapshot of the yet empty state
+	<-sub.Updates()
+
+	change := &Configs{}
+	change.workspaceConfigs, change.workspaceDiagnostics = parseWorkspaceConfigs([]*gitpod.PortConfig{
+		{Port: 8080, Cors: cors, InsecureRequests: "redirect"},
+		{Port: 3000, Visibility: "private", InsecureRequests: "forbid"},
+		{Port: 5000, Protocol: "grpc"},
+	})
+	config.Changes <- change
+	<-sub.Updates()
+	err := pm.Expose(context.Background(), 4000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exposed.mu.Lock()
+	defer exposed.mu.Unlock()
+	expectation := map[uint32]ExposeOptions{
+		8080: {Public: true, Cors: cors, InsecureRequests: "redirect"},
+		3000: {},
+		4000: {},
+		5000: {Public: true},
+	}
+	if diff := cmp.Diff(expectation, exposed.Options); diff != "" {
+		t.Errorf("unexpected expose options (-want +got):\n%s", diff)
+	}
+}
+
+func TestPortsProtocol(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	var proxied []uint32
+	pm.proxyStarter = func(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (io.Closer, error) {
+		proxied = append(proxied, localPort)
+		return ioutil.NopCloser(nil), nil
+	}
+
+	configs := &Configs{}
+	configs.workspaceConfigs, _ = parseWorkspaceConfigs([]*gitpod.PortConfig{
+		{Port: 5000, Protocol: "tcp"},
+		{Port: 6000, Protocol: "udp"},
+	})
+	pm.mu.Lock()
+	pm.setConfigs(configs)
+	pm.setServed([]ServedPort{
+		{Port: 5000, BoundToLocalhost: true},
+		{Port: 6000, BoundToLocalhost: true},
+		{Port: 8080, BoundToLocalhost: true},
+	})
+	pm.updateProxies()
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	pm.mu.Unlock()
+
+	sort.Slice(proxied, func(i, j int) bool { return proxied[i] < proxied[j] })
+	if diff := cmp.Diff([]uint32{5000, 8080}, proxied); diff != "" {
+		t.Errorf("unexpected proxied ports (-want +got):\n%s", diff)
+	}
+	act := make(map[uint32]api.PortProtocol)
+	for _, status := range pm.Status() {
+		act[status.LocalPort] = status.Protocol
+	}
+	expectation := map[uint32]api.PortProtocol{
+		5000: api.PortProtocol_tcp,
+		6000: api.PortProtocol_udp,
+		8080: api.PortProtocol_http,
+	}
+	for port, protocol := range expectation {
+		if act[port] != protocol {
+			t.Errorf("unexpected protocol of port %d: want %v, got %v", port, protocol, act[port])
+		}
+	}
+}
+
+func TestPortsPublicApproval(t *testing.T) {
+	var (
+		exposed = &testExposedPorts{
+			Changes: make(chan []ExposedPort),
+			Error:   make(chan error),
+		}
+		served = &testServedPorts{
+			Changes: make(chan []ServedPort),
+			Error:   make(chan error),
+		}
+		config = &testConfigService{
+			Changes: make(chan *Configs),
+			Error:   make(chan error),
+		}
+		pm = NewManager(exposed, served, config)
+	)
+	pm.RequirePublicApproval = true
+	go pm.Run()
+	defer pm.Stop(context.Background(), false)
+	sub := pm.Subscribe("test")
+	// skip the snapshot of the yet empty state
+	<-sub.Updates()
+
+	change := &Configs{}
+	change.workspaceConfigs, change.workspaceDiagnostics = parseWorkspaceConfigs([]*gitpod.PortConfig{
+		{Port: 8080, Visibility: "public"},
+		{Port: 9000, Visibility: "private"},
+	})
+	config.Changes <- change
+	update := <-sub.Updates()
+	pending := make(map[uint32]bool)
+	for _, p := range update.Added {
+		pending[p.LocalPort] = p.PendingPublic
+	}
+	if diff := cmp.Diff(map[uint32]bool{8080: true, 9000: false}, pending); diff != "" {
+		t.Errorf("unexpected pending public ports (-want +got):\n%s", diff)
+	}
+
+	err := pm.ApprovePublic(context.Background(), 9000, true)
+	if err == nil {
+		t.Errorf("expected an error approving a port which does not wait for approval")
+	}
+	err = pm.ApprovePublic(context.Background(), 8080, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update = <-sub.Updates()
+	if len(update.Updated) != 1 || update.Updated[0].PendingPublic || update.Trigger != api.PortsUpdateTrigger_manual_action {
+		t.Errorf("expected 8080 to be no longer pending public, got %v", update)
+	}
+
+	exposed.mu.Lock()
+	defer exposed.mu.Unlock()
+	if !cmp.Equal(exposed.Exposures[0], ExposedPort{LocalPort: 8080}) && !cmp.Equal(exposed.Exposures[1], ExposedPort{LocalPort: 8080}) {
+		t.Errorf("expected 8080 to be exposed privately before approval, got %v", exposed.Exposures)
+	}
+	if diff := cmp.Diff(map[uint32]ExposeOptions{8080: {Public: true}, 9000: {}}, exposed.Options); diff != "" {
+		t.Errorf("unexpected expose options (-want +got):\n%s", diff)
+	}
+}
+
+func TestPortsManagerStopDoesNotBlockStatus(t *testing.T) {
+	exposed := &callbackExposedPorts{}
+	pm := NewManager(exposed, &testServedPorts{}, &testConfigService{})
+	exposed.OnUnexpose = func() {
+		// the exposure service is slow to answer while clients still ask for the status
+		_ = pm.Status()
+	}
+	pm.proxyStarter = func(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (io.Closer, error) {
+		return &testProxy{}, nil
+	}
+	pm.setServed([]ServedPort{{Port: 8080}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		// the manager was never run, hence there is nothing to wait for
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = pm.Stop(ctx, true)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop blocks the status while it retracts exposures")
+	}
+	if diff := cmp.Diff([]uint32{8080}, exposed.Unexposures); diff != "" {
+		t.Errorf("unexpected unexposures (-want +got):\n%s", diff)
+	}
+}
+
+func TestPortsDryRun(t *testing.T) {
+	exposed := &testExposedPorts{}
+	pm := NewManager(exposed, &testServedPorts{}, &testConfigService{})
+	pm.DryRun = true
+
+	configs := &Configs{}
+	configs.workspaceConfigs, configs.workspaceDiagnostics = parseWorkspaceConfigs([]*gitpod.PortConfig{
+		{Port: 8080, Visibility: "public", OnOpen: "open-browser"},
+	})
+	pm.setConfigs(configs)
+	pm.setServed([]ServedPort{{Port: 8080}, {Port: 3000}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+
+	var act []*api.PortsStatus
+	for _, p := range pm.Status() {
+		act = append(act, &api.PortsStatus{LocalPort: p.LocalPort, Exposed: p.Exposed, WouldExpose: p.WouldExpose})
+	}
+	expectation := []*api.PortsStatus{
+		{LocalPort: 3000, WouldExpose: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_private, OnExposed: api.OnPortExposedAction_notify_private}},
+		{LocalPort: 8080, WouldExpose: &api.PortsStatus_ExposedPortInfo{Visibility: api.PortVisibility_public, OnExposed: api.OnPortExposedAction_open_browser}},
+	}
+	sortPortStatus := cmpopts.SortSlices(func(x, y *api.PortsStatus) bool { return x.LocalPort < y.LocalPort })
+	if diff := cmp.Diff(expectation, act, sortPortStatus); diff != "" {
+		t.Errorf("unexpected status (-want +got):\n%s", diff)
+	}
+
+	// the manager was never run, hence there is nothing to wait for
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = pm.Stop(ctx, true)
+	if len(exposed.Exposures) != 0 || len(exposed.Unexposures) != 0 {
+		t.Errorf("expected no calls to the exposure service, got exposures %v and unexposures %v", exposed.Exposures, exposed.Unexposures)
+	}
+}
+
+func TestPortsSubscribeSnapshot(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	pm.setServed([]ServedPort{{Port: 3000}, {Port: 8080}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+
+	sub := pm.Subscribe("test")
+	defer sub.Close()
+	snapshot := <-sub.Updates()
+
+	var ports []uint32
+	for _, p := range snapshot.Added {
+		ports = append(ports, p.LocalPort)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	if diff := cmp.Diff([]uint32{3000, 8080}, ports); diff != "" {
+		t.Errorf("unexpected ports in snapshot (-want +got):\n%s", diff)
+	}
+	if snapshot.Revision != pm.Revision() || snapshot.Diagnostics == nil {
+		t.Errorf("expected the snapshot to carry the current revision and diagnostics, got %+v", snapshot)
+	}
+}
+
+func TestPortsResume(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	serve := func(ports ...uint32) {
+		var served []ServedPort
+		for _, p := range ports {
+			served = append(served, ServedPort{Port: p})
+		}
+		pm.setServed(served)
+		pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	}
+	received := func(sub *Subscription, n int) (res []uint64) {
+		for i := 0; i < n; i++ {
+			select {
+			case diff := <-sub.Updates():
+				res = append(res, diff.Revision)
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for update %d", i)
+			}
+		}
+		return res
+	}
+
+	serve(3000)
+	token := pm.ResumeToken(pm.Revision())
+	serve(3000, 8080)
+	serve(8080)
+
+	sub, resumed := pm.Resume("test", token)
+	if !resumed {
+		t.Fatal("expected to resume")
+	}
+	if diff := cmp.Diff([]uint64{2, 3}, received(sub, 2)); diff != "" {
+		t.Errorf("unexpected missed revisions (-want +got):\n%s", diff)
+	}
+	serve(3000)
+	if diff := cmp.Diff([]uint64{4}, received(sub, 1)); diff != "" {
+		t.Errorf("unexpected revisions (-want +got):\n%s", diff)
+	}
+	sub.Close()
+
+	current, resumed := pm.Resume("test", pm.ResumeToken(pm.Revision()))
+	if !resumed {
+		t.Error("expected to resume from the current revision")
+	}
+	current.Close()
+
+	for _, invalid := range []string{"", "foo", "other.1", pm.ResumeToken(42)} {
+		sub, resumed := pm.Resume("test", invalid)
+		if resumed {
+			t.Errorf("expected not to resume from %q", invalid)
+		}
+		sub.Close()
+	}
+
+	for i := 0; i < maxDiffHistory; i++ {
+		serve(uint32(9000 + i))
+	}
+	sub, resumed = pm.Resume("test", token)
+	if resumed {
+		t.Error("expected not to resume from a revision which is no longer in the history")
+	}
+	sub.Close()
+}
+
+func TestPortsSubscribers(t *testing.T) {
+	pm := NewManager(&NoopExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	pm.MaxSubscriptions = 2
+
+	vscode := pm.Subscribe("vscode")
+	cli := pm.Subscribe("gp-cli")
+	if vscode == nil || cli == nil {
+		t.Fatal("expected subscriptions within the limit to succeed")
+	}
+	if sub := pm.Subscribe("extension"); sub != nil {
+		t.Error("expected subscription above the limit to fail")
+	}
+
+	clients := func() (res []string) {
+		for _, s := range pm.Subscribers() {
+			res = append(res, s.Client)
+		}
+		return
+	}
+	if diff := cmp.Diff([]string{"vscode", "gp-cli"}, clients()); diff != "" {
+		t.Errorf("unexpected subscribers (-want +got):\n%s", diff)
+	}
+
+	vscode.Close()
+	if diff := cmp.Diff([]string{"gp-cli"}, clients()); diff != "" {
+		t.Errorf("unexpected subscribers after close (-want +got):\n%s", diff)
+	}
+}
+
+func TestMergeDiffs(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Diffs       []*Diff
+		Expectation *Diff
+	}{
+		{
+			Desc: "added then updated",
+			Diffs: []*Diff{
+				{Added: []*api.PortsStatus{{LocalPort: 8080}}},
+				{Updated: []*api.PortsStatus{{LocalPort: 8080, Served: true}}},
+			},
+			Expectation: &Diff{Added: []*api.PortsStatus{{LocalPort: 8080, Served: true}}},
+		},
+		{
+			Desc: "latest revision",
+			Diffs: []*Diff{
+				{Added: []*api.PortsStatus{{LocalPort: 8080}}, Revision: 4},
+				{Added: []*api.PortsStatus{{LocalPort: 3000}}, Revision: 5},
+			},
+			Expectation: &Diff{Added: []*api.PortsStatus{{LocalPort: 8080}, {LocalPort: 3000}}, Revision: 5},
+		},
+		{
+			Desc: "added then removed",
+			Diffs: []*Diff{
+				{Added: []*api.PortsStatus{{LocalPort: 8080}}},
+				{Removed: []uint32{8080}},
+			},
+			Expectation: &Diff{},
+		},
+		{
+			Desc: "removed then added",
+			Diffs: []*Diff{
+				{Removed: []uint32{8080}},
+				{Added: []*api.PortsStatus{{LocalPort: 8080, Served: true}}},
+			},
+			Expectation: &Diff{Updated: []*api.PortsStatus{{LocalPort: 8080, Served: true}}},
+		},
+		{
+			Desc: "updated then removed",
+			Diffs: []*Diff{
+				{Updated: []*api.PortsStatus{{LocalPort: 8080}}, Diagnostics: &api.PortConfigDiagnostics{}},
+				{Removed: []uint32{8080}, Added: []*api.PortsStatus{{LocalPort: 3000}}},
+			},
+			Expectation: &Diff{Added: []*api.PortsStatus{{LocalPort: 3000}}, Removed: []uint32{8080}, Diagnostics: &api.PortConfigDiagnostics{}},
+		},
+		{
+			Desc: "same trigger",
+			Diffs: []*Diff{
+				{Added: []*api.PortsStatus{{LocalPort: 8080}}, Trigger: api.PortsUpdateTrigger_served_ports_changed},
+				{Added: []*api.PortsStatus{{LocalPort: 3000}}, Trigger: api.PortsUpdateTrigger_served_ports_changed},
+			},
+			Expectation: &Diff{Added: []*api.PortsStatus{{LocalPort: 8080}, {LocalPort: 3000}}, Trigger: api.PortsUpdateTrigger_served_ports_changed},
+		},
+		{
+			Desc: "different triggers",
+			Diffs: []*Diff{
+				{Updated: []*api.PortsStatus{{LocalPort: 8080}}, Trigger: api.PortsUpdateTrigger_manual_action},
+				{Added: []*api.PortsStatus{{LocalPort: 3000}}, Trigger: api.PortsUpdateTrigger_served_ports_changed},
+				{Updated: []*api.PortsStatus{{LocalPort: 3000, Served: true}}, Trigger: api.PortsUpdateTrigger_port_configs_changed},
+			},
+			Expectation: &Diff{
+				Added:    []*api.PortsStatus{{LocalPort: 3000, Served: true}},
+				Updated:  []*api.PortsStatus{{LocalPort: 8080}},
+				Trigger:  api.PortsUpdateTrigger_port_configs_changed,
+				Triggers: map[uint32]api.PortsUpdateTrigger{8080: api.PortsUpdateTrigger_manual_action, 3000: api.PortsUpdateTrigger_port_configs_changed},
+			},
+		},
+		{
+			Desc: "coalesced before",
+			Diffs: []*Diff{
+				{
+					Updated:  []*api.PortsStatus{{LocalPort: 8080}, {LocalPort: 3000}},
+					Trigger:  api.PortsUpdateTrigger_served_ports_changed,
+					Triggers: map[uint32]api.PortsUpdateTrigger{8080: api.PortsUpdateTrigger_manual_action, 3000: api.PortsUpdateTrigger_served_ports_changed},
+				},
+				{Removed: []uint32{3000}, Trigger: api.PortsUpdateTrigger_served_ports_changed},
+			},
+			Expectation: &Diff{
+				Updated:  []*api.PortsStatus{{LocalPort: 8080}},
+				Removed:  []uint32{3000},
+				Trigger:  api.PortsUpdateTrigger_served_ports_changed,
+				Triggers: map[uint32]api.PortsUpdateTrigger{8080: api.PortsUpdateTrigger_manual_action, 3000: api.PortsUpdateTrigger_served_ports_changed},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := mergeDiffs(test.Diffs...)
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptionSlowConsumer(t *testing.T) {
+	sub := newSubscription("test")
+
+	// pushing must never block, no matter how far the subscriber is behind
+	for i := 0; i < 2*maxQueuedDiffs; i++ {
+		sub.push(&Diff{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: uint32(i)}}})
+	}
+	sub.end()
+
+	var updates []*Diff
+	for update := range sub.Updates() {
+		updates = append(updates, update)
+	}
+	if len(updates) > maxQueuedDiffs+1 {
+		t.Errorf("expected updates to be coalesced, got %d updates", len(updates))
+	}
+	last := updates[len(updates)-1]
+	if diff := cmp.Diff(&Diff{Updated: []*api.PortsStatus{{LocalPort: 8080, GlobalPort: 2*maxQueuedDiffs - 1}}}, last); diff != "" {
+		t.Errorf("unexpected last update (-want +got):\n%s", diff)
+	}
+}
+
+func TestRateLimitedListener(t *testing.T) {
+	var (
+		conns  []*testConn
+		accept = make(chan net.Conn, 5)
+	)
+	for i := 0; i < 5; i++ {
+		conn := &testConn{}
+		conns = append(conns, conn)
+		accept <- conn
+	}
+	close(accept)
+
+	now := time.Now()
+	lis := &rateLimitedListener{
+		Listener: &testListener{accept: accept},
+		bucket:   dropwriter.NewBucketClock(2, 2, func() time.Time { return now }),
+	}
+
+	var accepted int
+	for {
+		_, err := lis.Accept()
+		if err != nil {
+			break
+		}
+		accepted++
+	}
+	if accepted != 2 {
+		t.Errorf("expected 2 accepted connections, got %d", accepted)
+	}
+	var closed int
+	for _, conn := range conns {
+		if conn.closed {
+			closed++
+		}
+	}
+	if closed != 3 {
+		t.Errorf("expected 3 closed connections, got %d", closed)
+	}
+}
+
+type testListener struct {
+	net.Listener
+	accept chan net.Conn
+}
+
+func (l *testListener) Accept() (net.Conn, error) {
+	conn, ok := <-l.accept
+	if !ok {
+		return nil, io.EOF
+	}
+	return conn, nil
+}
+
+type testConn struct {
+	net.Conn
+	closed bool
+}
+
+func (c *testConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+}
+
+func (c *testConn) Close() error {
+	c.closed = true
+	return nil
+}
+
 type testConfigService struct {
 	Changes chan *Configs
 	Error   chan error
@@ -319,30 +1414,127 @@ func (tep *testConfigService) Observe(ctx context.Context) (<-chan *Configs, <-c
 	return tep.Changes, tep.Error
 }
 
+// BenchmarkPortsUpdateState shows that a state update costs in the number of changed ports,
+// not in the number of managed ports.
+func BenchmarkPortsUpdateState(b *testing.B) {
+	for _, size := range []int{10, 100, 1000} {
+		var (
+			served  []ServedPort
+			exposed []ExposedPort
+		)
+		for port := uint32(3000); port < 3000+uint32(size); port++ {
+			served = append(served, ServedPort{Port: port})
+			exposed = append(exposed, ExposedPort{LocalPort: port, GlobalPort: port, Public: true})
+		}
+		_, rangeConfigs, _ := parseInstanceConfigs([]*gitpod.PortsItems{{Port: "3000-9999", OnOpen: "ignore"}})
+		configs := &Configs{instanceRangeConfigs: rangeConfigs}
+
+		newManager := func() *Manager {
+			pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+			pm.setConfigs(configs)
+			pm.setExposed(exposed)
+			pm.setServed(served)
+			pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+			return pm
+		}
+
+		b.Run(fmt.Sprintf("ports=%d/changed=1", size), func(b *testing.B) {
+			pm := newManager()
+			alternate := [][]ServedPort{served[1:], served}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				pm.setServed(alternate[i%2])
+				b.StartTimer()
+				pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+			}
+		})
+		b.Run(fmt.Sprintf("ports=%d/changed=all", size), func(b *testing.B) {
+			pm := newManager()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				pm.setConfigs(configs)
+				b.StartTimer()
+				pm.updateState(context.Background(), api.PortsUpdateTrigger_port_configs_changed)
+			}
+		})
+	}
+}
+
 type testExposedPorts struct {
 	Changes chan []ExposedPort
 	Error   chan error
 
-	Exposures []ExposedPort
-	mu        sync.Mutex
+	Exposures   []ExposedPort
+	Unexposures []uint32
+	Options     map[uint32]ExposeOptions
+	mu          sync.Mutex
 }
 
 func (tep *testExposedPorts) Observe(ctx context.Context) (<-chan []ExposedPort, <-chan error) {
 	return tep.Changes, tep.Error
 }
 
-func (tep *testExposedPorts) Expose(ctx context.Context, local, global uint32, public bool) error {
+func (tep *testExposedPorts) Expose(ctx context.Context, local, global uint32, opts ExposeOptions) error {
 	tep.mu.Lock()
 	defer tep.mu.Unlock()
 
 	tep.Exposures = append(tep.Exposures, ExposedPort{
 		GlobalPort: global,
 		LocalPort:  local,
-		Public:     public,
+		Public:     opts.Public,
 	})
+	if tep.Options == nil {
+		tep.Options = make(map[uint32]ExposeOptions)
+	}
+	tep.Options[local] = opts
+	return nil
+}
+
+func (tep *testExposedPorts) Unexpose(ctx context.Context, local uint32) error {
+	tep.mu.Lock()
+	defer tep.mu.Unlock()
+
+	tep.Unexposures = append(tep.Unexposures, local)
 	return nil
 }
 
+// callbackExposedPorts calls OnUnexpose before it unexposes a port
+type callbackExposedPorts struct {
+	testExposedPorts
+	OnUnexpose func()
+}
+
+func (cep *callbackExposedPorts) Unexpose(ctx context.Context, local uint32) error {
+	cep.OnUnexpose()
+	return cep.testExposedPorts.Unexpose(ctx, local)
+}
+
+// unreachableExposedPorts fails exposures as unavailable while Unreachable is true
+type unreachableExposedPorts struct {
+	testExposedPorts
+	Unreachable bool
+	Attempts    int
+}
+
+func (uep *unreachableExposedPorts) Expose(ctx context.Context, local, global uint32, opts ExposeOptions) error {
+	uep.mu.Lock()
+	uep.Attempts++
+	unreachable := uep.Unreachable
+	uep.mu.Unlock()
+	if unreachable {
+		return xerrors.Errorf("connection lost: %w", ErrExposureUnavailable)
+	}
+	return uep.testExposedPorts.Expose(ctx, local, global, opts)
+}
+
+func (uep *unreachableExposedPorts) attempts() int {
+	uep.mu.Lock()
+	defer uep.mu.Unlock()
+	return uep.Attempts
+}
+
 type testServedPorts struct {
 	Changes chan []ServedPort
 	Error   chan error
@@ -0,0 +1,186 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+// Package portstest provides fakes of the observers the ports Manager depends on, along with builders and
+// assertion helpers, for components which embed the Manager and test their integration with it.
+package portstest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+)
+
+// ExposedPorts is a fake of the Gitpod server exposing ports. It records the exposures the Manager requests,
+// but does not observe them on its own, see Update.
+type ExposedPorts struct {
+	changes chan []ports.ExposedPort
+	errors  chan error
+
+	mu          sync.Mutex
+	exposures   []ports.ExposedPort
+	unexposures []uint32
+	options     map[uint32]ports.ExposeOptions
+	err         error
+}
+
+// NewExposedPorts creates a new fake of the exposed ports
+func NewExposedPorts() *ExposedPorts {
+	return &ExposedPorts{
+		changes: make(chan []ports.ExposedPort),
+		errors:  make(chan error),
+		options: make(map[uint32]ports.ExposeOptions),
+	}
+}
+
+// Observe implements ports.ExposedPortsInterface
+func (e *ExposedPorts) Observe(ctx context.Context) (<-chan []ports.ExposedPort, <-chan error) {
+	return e.changes, e.errors
+}
+
+// Expose implements ports.ExposedPortsInterface
+func (e *ExposedPorts) Expose(ctx context.Context, local, global uint32, opts ports.ExposeOptions) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err != nil {
+		return e.err
+	}
+	e.exposures = append(e.exposures, ports.ExposedPort{
+		LocalPort:  local,
+		GlobalPort: global,
+		Public:     opts.Public,
+	})
+	e.options[local] = opts
+	return nil
+}
+
+// Unexpose implements ports.ExposedPortsInterface
+func (e *ExposedPorts) Unexpose(ctx context.Context, local uint32) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err != nil {
+		return e.err
+	}
+	e.unexposures = append(e.unexposures, local)
+	return nil
+}
+
+// Fail fails all further exposures with the error, e.g. ports.ErrExposureUnavailable. A nil error lets them succeed again.
+func (e *ExposedPorts) Fail(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.err = err
+}
+
+// Exposures returns the exposures requested so far, in order
+func (e *ExposedPorts) Exposures() []ports.ExposedPort {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]ports.ExposedPort(nil), e.exposures...)
+}
+
+// Unexposures returns the ports requested to be unexposed so far, in order
+func (e *ExposedPorts) Unexposures() []uint32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]uint32(nil), e.unexposures...)
+}
+
+// Options returns the options the port was last exposed with
+func (e *ExposedPorts) Options(port uint32) (opts ports.ExposeOptions, exposed bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	opts, exposed = e.options[port]
+	return
+}
+
+// Update lets the Manager observe the exposed ports. It blocks until the Manager receives them.
+func (e *ExposedPorts) Update(exposed ...ports.ExposedPort) {
+	if exposed == nil {
+		// nil tells the Manager that the observer stopped
+		exposed = []ports.ExposedPort{}
+	}
+	e.changes <- exposed
+}
+
+// Close stops observing exposed ports, which stops the Manager
+func (e *ExposedPorts) Close() {
+	close(e.changes)
+	close(e.errors)
+}
+
+// ServedPorts is a fake of the observer of the ports served in the workspace
+type ServedPorts struct {
+	changes chan []ports.ServedPort
+	errors  chan error
+}
+
+// NewServedPorts creates a new fake of the served ports
+func NewServedPorts() *ServedPorts {
+	return &ServedPorts{
+		changes: make(chan []ports.ServedPort),
+		errors:  make(chan error),
+	}
+}
+
+// Observe implements ports.ServedPortsObserver
+func (s *ServedPorts) Observe(ctx context.Context) (<-chan []ports.ServedPort, <-chan error) {
+	return s.changes, s.errors
+}
+
+// Update lets the Manager observe the served ports. It blocks until the Manager receives them.
+func (s *ServedPorts) Update(served ...ports.ServedPort) {
+	if served == nil {
+		// nil tells the Manager that the observer stopped
+		served = []ports.ServedPort{}
+	}
+	s.changes <- served
+}
+
+// Close stops observing served ports, which stops the Manager
+func (s *ServedPorts) Close() {
+	close(s.changes)
+	close(s.errors)
+}
+
+// ConfigService is a fake of the service observing port configs
+type ConfigService struct {
+	changes chan *ports.Configs
+	errors  chan error
+}
+
+// NewConfigService creates a new fake of the port config service
+func NewConfigService() *ConfigService {
+	return &ConfigService{
+		changes: make(chan *ports.Configs),
+		errors:  make(chan error),
+	}
+}
+
+// Observe implements ports.ConfigInterace
+func (c *ConfigService) Observe(ctx context.Context) (<-chan *ports.Configs, <-chan error) {
+	return c.changes, c.errors
+}
+
+// Update lets the Manager observe the port configs, see Configs. It blocks until the Manager receives them.
+func (c *ConfigService) Update(configs *ports.Configs) {
+	if configs == nil {
+		// nil tells the Manager that the observer stopped
+		configs = ports.NewConfigs(nil, nil)
+	}
+	c.changes <- configs
+}
+
+// Close stops observing port configs, which stops the Manager
+func (c *ConfigService) Close() {
+	close(c.changes)
+	close(c.errors)
+}
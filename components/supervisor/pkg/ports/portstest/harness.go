@@ -0,0 +1,173 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package portstest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+	"github.com/google/go-cmp/cmp"
+)
+
+// DefaultTimeout is how long the Await helpers wait for the Manager
+var DefaultTimeout = 5 * time.Second
+
+// Harness runs a Manager on fake observers
+type Harness struct {
+	Exposed *ExposedPorts
+	Served  *ServedPorts
+	Config  *ConfigService
+	Manager *ports.Manager
+
+	done chan struct{}
+}
+
+// NewHarness creates a Manager on fake observers. The Manager does not run until Start is called,
+// so that it can be configured first.
+func NewHarness(internalPorts ...uint32) *Harness {
+	h := &Harness{
+		Exposed: NewExposedPorts(),
+		Served:  NewServedPorts(),
+		Config:  NewConfigService(),
+	}
+	h.Manager = ports.NewManager(h.Exposed, h.Served, h.Config, internalPorts...)
+	return h
+}
+
+// Start runs the Manager
+func (h *Harness) Start() {
+	h.done = make(chan struct{})
+	go func() {
+		defer close(h.done)
+		h.Manager.Run()
+	}()
+}
+
+// Stop stops observing and waits for the Manager to stop running
+func (h *Harness) Stop() {
+	h.Config.Close()
+	h.Served.Close()
+	h.Exposed.Close()
+	if h.done != nil {
+		<-h.done
+	}
+}
+
+// Served builds served ports which are bound to all interfaces
+func Served(port ...uint32) []ports.ServedPort {
+	res := make([]ports.ServedPort, 0, len(port))
+	for _, p := range port {
+		res = append(res, ports.ServedPort{Port: p})
+	}
+	return res
+}
+
+// ServedOnLocalhost builds served ports which are bound to localhost only, and hence proxied by the Manager
+func ServedOnLocalhost(port ...uint32) []ports.ServedPort {
+	res := make([]ports.ServedPort, 0, len(port))
+	for _, p := range port {
+		res = append(res, ports.ServedPort{Port: p, BoundToLocalhost: true})
+	}
+	return res
+}
+
+// Exposed builds private exposed ports with the same global port and a URL derived from the port
+func Exposed(port ...uint32) []ports.ExposedPort {
+	res := make([]ports.ExposedPort, 0, len(port))
+	for _, p := range port {
+		res = append(res, ports.ExposedPort{LocalPort: p, GlobalPort: p, URL: URL(p)})
+	}
+	return res
+}
+
+// URL is the URL Exposed builds for a port
+func URL(port uint32) string {
+	return fmt.Sprintf("https://%d-workspace.example.com/", port)
+}
+
+// Configs builds port configs as if the workspace was created with them
+func Configs(config ...*gitpod.PortConfig) *ports.Configs {
+	return ports.NewConfigs(config, nil)
+}
+
+// AwaitStatus waits until the ports status satisfies the condition and returns it.
+// It fails the test if the condition is not met within DefaultTimeout.
+func AwaitStatus(t testing.TB, pm *ports.Manager, condition func(status []*api.PortsStatus) bool) []*api.PortsStatus {
+	t.Helper()
+
+	deadline := time.Now().Add(DefaultTimeout)
+	for {
+		status := pm.Status()
+		if condition(status) {
+			return status
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the ports status, last status: %v", status)
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// AwaitPort waits until the status of the port satisfies the condition and returns it
+func AwaitPort(t testing.TB, pm *ports.Manager, port uint32, condition func(status *api.PortsStatus) bool) *api.PortsStatus {
+	t.Helper()
+
+	var res *api.PortsStatus
+	AwaitStatus(t, pm, func(status []*api.PortsStatus) bool {
+		for _, s := range status {
+			if s.LocalPort == port && condition(s) {
+				res = s
+				return true
+			}
+		}
+		return false
+	})
+	return res
+}
+
+// AwaitExposed waits until the last exposure the Manager requested for each of the expected ports matches.
+// Earlier exposures are ignored, as the Manager may expose a port repeatedly, e.g. configured ports before
+// they are served. It fails the test with a diff of the last exposures otherwise.
+func AwaitExposed(t testing.TB, exposed *ExposedPorts, expectation ...ports.ExposedPort) {
+	t.Helper()
+
+	deadline := time.Now().Add(DefaultTimeout)
+	for {
+		last := make(map[uint32]ports.ExposedPort)
+		for _, e := range exposed.Exposures() {
+			last[e.LocalPort] = e
+		}
+		act := make([]ports.ExposedPort, 0, len(expectation))
+		for _, e := range expectation {
+			if l, ok := last[e.LocalPort]; ok {
+				act = append(act, l)
+			}
+		}
+		diff := cmp.Diff(expectation, act)
+		if diff == "" {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("unexpected exposures (-want +got):\n%s", diff)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// IsServed is a condition for AwaitPort which is met once the port is served
+func IsServed(status *api.PortsStatus) bool {
+	return status.Served
+}
+
+// IsExposed is a condition for AwaitPort which is met once the port is exposed
+func IsExposed(status *api.PortsStatus) bool {
+	return status.Exposed != nil
+}
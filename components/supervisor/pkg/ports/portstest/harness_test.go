@@ -0,0 +1,39 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package portstest
+
+import (
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+)
+
+func TestHarness(t *testing.T) {
+	h := NewHarness()
+	h.Start()
+	defer h.Stop()
+
+	h.Config.Update(Configs(&gitpod.PortConfig{Port: 3000, Visibility: "public"}))
+	h.Served.Update(Served(3000, 4000)...)
+	AwaitPort(t, h.Manager, 4000, IsServed)
+	AwaitExposed(t, h.Exposed,
+		ports.ExposedPort{LocalPort: 3000, GlobalPort: 3000, Public: true},
+		ports.ExposedPort{LocalPort: 4000, GlobalPort: 4000},
+	)
+	if opts, exposed := h.Exposed.Options(3000); !exposed || !opts.Public {
+		t.Errorf("expected port 3000 to be exposed publicly, got %+v", opts)
+	}
+
+	h.Exposed.Update(Exposed(3000)...)
+	status := AwaitPort(t, h.Manager, 3000, IsExposed)
+	if status.Exposed.Url != URL(3000) {
+		t.Errorf("unexpected URL %q", status.Exposed.Url)
+	}
+
+	h.Served.Update()
+	AwaitPort(t, h.Manager, 3000, func(status *api.PortsStatus) bool { return !status.Served })
+}
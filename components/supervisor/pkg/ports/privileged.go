@@ -0,0 +1,96 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// privilegedPortsEnd is the first port which is not privileged
+	privilegedPortsEnd = 1024
+	// capNetBindService is the bit of CAP_NET_BIND_SERVICE in the capability sets
+	capNetBindService = 10
+
+	fnUnprivilegedPortStart = "/proc/sys/net/ipv4/ip_unprivileged_port_start"
+	fnProcSelfStatus        = "/proc/self/status"
+)
+
+// isPrivilegedPort returns true for ports below 1024, which the workspace proxy cannot route to
+func isPrivilegedPort(port uint32) bool {
+	return port < privilegedPortsEnd
+}
+
+// bindPrivileges tells which ports the supervisor may listen on. Ports below the unprivileged port start
+// (1024 unless lowered via sysctl) require CAP_NET_BIND_SERVICE, which the workspace may grant the supervisor.
+type bindPrivileges struct {
+	readFile func(fn string) ([]byte, error)
+
+	once              sync.Once
+	unprivilegedStart uint32
+	capable           bool
+}
+
+// mayBind returns true if the supervisor may listen on the port
+func (b *bindPrivileges) mayBind(port uint32) bool {
+	b.once.Do(b.detect)
+	return b.capable || port >= b.unprivilegedStart
+}
+
+func (b *bindPrivileges) detect() {
+	if b.readFile == nil {
+		b.readFile = ioutil.ReadFile
+	}
+
+	b.unprivilegedStart = privilegedPortsEnd
+	if content, err := b.readFile(fnUnprivilegedPortStart); err == nil {
+		start, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 32)
+		if err == nil {
+			b.unprivilegedStart = uint32(start)
+		}
+	}
+
+	content, err := b.readFile(fnProcSelfStatus)
+	if err != nil {
+		log.WithError(err).Debug("cannot read capabilities, assuming privileged ports cannot be bound")
+		return
+	}
+	capEff, err := parseEffectiveCapabilities(content)
+	if err != nil {
+		log.WithError(err).Debug("cannot parse capabilities, assuming privileged ports cannot be bound")
+		return
+	}
+	b.capable = capEff&(1<<capNetBindService) != 0
+	log.WithField("capNetBindService", b.capable).WithField("unprivilegedPortStart", b.unprivilegedStart).Debug("detected port bind privileges")
+}
+
+// parseEffectiveCapabilities returns the effective capability set listed in a /proc/<pid>/status file
+func parseEffectiveCapabilities(status []byte) (uint64, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(status))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "CapEff:") {
+			continue
+		}
+		return strconv.ParseUint(strings.TrimSpace(strings.TrimPrefix(line, "CapEff:")), 16, 64)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, xerrors.Errorf("no effective capabilities listed")
+}
+
+// remapsPrivileged returns true if a port served on all interfaces is proxied to a high global port
+// because it is privileged
+func (pm *Manager) remapsPrivileged(served ServedPort) bool {
+	return pm.RemapPrivilegedPorts && !served.BoundToLocalhost && isPrivilegedPort(served.Port)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBindPrivileges(t *testing.T) {
+	tests := []struct {
+		Desc              string
+		UnprivilegedStart string
+		Status            string
+		Expectation       map[uint32]bool
+	}{
+		{
+			Desc:        "no capability",
+			Status:      "Name:\tsupervisor\nCapEff:\t0000000000000000\n",
+			Expectation: map[uint32]bool{80: false, 1023: false, 1024: true, 8080: true},
+		},
+		{
+			Desc:        "CAP_NET_BIND_SERVICE",
+			Status:      "Name:\tsupervisor\nCapEff:\t0000000000000400\n",
+			Expectation: map[uint32]bool{80: true, 1023: true, 8080: true},
+		},
+		{
+			Desc:              "lowered unprivileged port start",
+			UnprivilegedStart: "80\n",
+			Status:            "CapEff:\t00000000a80421fb\n",
+			Expectation:       map[uint32]bool{22: false, 80: true, 443: true},
+		},
+		{
+			Desc:        "unknown capabilities",
+			Expectation: map[uint32]bool{80: false, 8080: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			b := &bindPrivileges{
+				readFile: func(fn string) ([]byte, error) {
+					var content string
+					switch fn {
+					case fnUnprivilegedPortStart:
+						content = test.UnprivilegedStart
+					case fnProcSelfStatus:
+						content = test.Status
+					}
+					if content == "" {
+						return nil, os.ErrNotExist
+					}
+					return []byte(content), nil
+				},
+			}
+			act := make(map[uint32]bool)
+			for port := range test.Expectation {
+				act[port] = b.mayBind(port)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected bind privileges (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrivilegedPortRemap(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Remap       bool
+		MayBind     bool
+		Expectation map[uint32]uint32
+		Remapped    []uint32
+	}{
+		{
+			Desc:        "no remapping",
+			Expectation: map[uint32]uint32{80: 80, 8443: 50443, 3000: 3000},
+		},
+		{
+			Desc:        "remapping",
+			Remap:       true,
+			Expectation: map[uint32]uint32{80: 60000, 8443: 59999, 3000: 3000},
+			Remapped:    []uint32{80},
+		},
+		{
+			Desc:        "remapping with CAP_NET_BIND_SERVICE",
+			Remap:       true,
+			MayBind:     true,
+			Expectation: map[uint32]uint32{80: 60000, 8443: 443, 3000: 3000},
+			Remapped:    []uint32{80},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+			pm.RemapPrivilegedPorts = test.Remap
+			pm.mayBind = func(port uint32) bool { return test.MayBind || !isPrivilegedPort(port) }
+			pm.proxyStarter = func(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (io.Closer, error) {
+				return ioutil.NopCloser(nil), nil
+			}
+
+			configs := &Configs{}
+			configs.workspaceConfigs, _ = parseWorkspaceConfigs([]*gitpod.PortConfig{
+				{Port: 8443, GlobalPort: 443},
+			})
+			if !test.Remap && !test.MayBind {
+				// a configured global port which cannot be bound falls back to a dynamic one
+				pm.globalPorts = newGlobalPortPool(50443, 50443, globalPortLeaseTime)
+			}
+			pm.mu.Lock()
+			pm.setConfigs(configs)
+			pm.setServed([]ServedPort{
+				{Port: 80},
+				{Port: 8443, BoundToLocalhost: true},
+				{Port: 3000},
+			})
+			pm.updateProxies()
+			pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+			pm.mu.Unlock()
+
+			act := make(map[uint32]uint32)
+			var remapped []uint32
+			for _, status := range pm.Status() {
+				act[status.LocalPort] = status.GlobalPort
+				if status.PrivilegedRemap {
+					remapped = append(remapped, status.LocalPort)
+				}
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected global ports (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.Remapped, remapped); diff != "" {
+				t.Errorf("unexpected remapped ports (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProcessGroups groups processes, e.g. by the task which started them
+type ProcessGroups interface {
+	// GroupOf returns the group of a process. The ancestry lists the process first, followed by its
+	// parent, grand parent and so on. Returns an empty string if the process does not belong to a group.
+	GroupOf(ancestry []int) string
+}
+
+// socketOwnerCache resolves the processes which own listening sockets. Resolving requires scanning
+// the file descriptors of all processes, hence /proc is only scanned if there are new sockets.
+type socketOwnerCache struct {
+	procDir string
+	owners  map[uint64]int
+	// commands are the command names of the owners
+	commands map[uint64]string
+}
+
+func newSocketOwnerCache(procDir string) *socketOwnerCache {
+	return &socketOwnerCache{
+		procDir:  procDir,
+		owners:   make(map[uint64]int),
+		commands: make(map[uint64]string),
+	}
+}
+
+// resolve sets the PID and the process name of the sockets. Sockets whose owner cannot be found keep a zero PID.
+func (c *socketOwnerCache) resolve(sockets []servedSocket) {
+	var (
+		current = make(map[uint64]struct{}, len(sockets))
+		rescan  bool
+	)
+	for _, socket := range sockets {
+		current[socket.Inode] = struct{}{}
+		if _, cached := c.owners[socket.Inode]; !cached {
+			rescan = true
+		}
+	}
+	for inode := range c.owners {
+		if _, exists := current[inode]; !exists {
+			delete(c.owners, inode)
+			delete(c.commands, inode)
+		}
+	}
+	if rescan {
+		owners := socketOwners(c.procDir)
+		for inode := range current {
+			if _, cached := c.owners[inode]; !cached {
+				c.owners[inode] = owners[inode]
+				if pid := owners[inode]; pid != 0 {
+					c.commands[inode] = processName(c.procDir, pid)
+				}
+			}
+		}
+	}
+	for i := range sockets {
+		sockets[i].PID = c.owners[sockets[i].Inode]
+		sockets[i].Process = c.commands[sockets[i].Inode]
+	}
+}
+
+// socketOwners maps socket inodes to the PID of a process which holds the socket
+func socketOwners(procDir string) map[uint64]int {
+	owners := make(map[uint64]int)
+	fds, err := filepath.Glob(filepath.Join(procDir, "[0-9]*", "fd", "*"))
+	if err != nil {
+		log.WithError(err).Debug("cannot list file descriptors")
+		return owners
+	}
+	for _, fd := range fds {
+		target, err := os.Readlink(fd)
+		if err != nil || !strings.HasPrefix(target, "socket:[") {
+			continue
+		}
+		inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]"), 10, 64)
+		if err != nil {
+			continue
+		}
+		pid, err := strconv.Atoi(filepath.Base(filepath.Dir(filepath.Dir(fd))))
+		if err != nil {
+			continue
+		}
+		owners[inode] = pid
+	}
+	return owners
+}
+
+// ListeningPorts maps the processes of a proc filesystem, e.g. /proc, to the TCP ports they listen on
+func ListeningPorts(procDir string) (map[int][]uint32, error) {
+	var sockets []servedSocket
+	for _, fn := range []string{"tcp", "tcp6"} {
+		f, err := os.Open(filepath.Join(procDir, "net", fn))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		ss, err := readNetTCPSockets(f, true)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		sockets = append(sockets, ss...)
+	}
+	if len(sockets) == 0 {
+		return nil, nil
+	}
+
+	owners := socketOwners(procDir)
+	res := make(map[int][]uint32)
+	for _, s := range sockets {
+		pid, owned := owners[s.Inode]
+		if !owned {
+			continue
+		}
+		known := false
+		for _, p := range res[pid] {
+			known = known || p == s.Port
+		}
+		if !known {
+			res[pid] = append(res[pid], s.Port)
+		}
+	}
+	for _, ports := range res {
+		sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	}
+	return res, nil
+}
+
+// processName reads the command name of a process from /proc/<pid>/stat, returns an empty string if it is unknown
+func processName(procDir string, pid int) string {
+	stat, err := ioutil.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return ""
+	}
+	// the command name is in parentheses and can contain spaces and parentheses itself
+	start := strings.IndexByte(string(stat), '(')
+	end := strings.LastIndexByte(string(stat), ')')
+	if start < 0 || end < start {
+		return ""
+	}
+	return string(stat[start+1 : end])
+}
+
+// processAncestry lists a process followed by its parent, grand parent and so on
+func processAncestry(procDir string, pid int) []int {
+	var ancestry []int
+	for pid > 0 {
+		ancestry = append(ancestry, pid)
+		pid = parentPID(procDir, pid)
+	}
+	return ancestry
+}
+
+// parentPID reads the parent PID from /proc/<pid>/stat, returns zero if the parent is unknown
+func parentPID(procDir string, pid int) int {
+	stat, err := ioutil.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0
+	}
+	// the command name is in parentheses and can contain spaces and parentheses itself
+	idx := strings.LastIndexByte(string(stat), ')')
+	if idx < 0 {
+		return 0
+	}
+	// fields after the command name: state ppid ...
+	fields := strings.Fields(string(stat[idx+1:]))
+	if len(fields) < 2 {
+		return 0
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil || ppid == pid {
+		return 0
+	}
+	return ppid
+}
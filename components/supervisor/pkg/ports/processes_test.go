@@ -0,0 +1,158 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testProcessGroups map[int]string
+
+func (g testProcessGroups) GroupOf(ancestry []int) string {
+	for _, pid := range ancestry {
+		if group, ok := g[pid]; ok {
+			return group
+		}
+	}
+	return ""
+}
+
+func TestProcessGroups(t *testing.T) {
+	type Process struct {
+		PID     int
+		PPID    int
+		Comm    string
+		Sockets []uint64
+	}
+	tests := []struct {
+		Name        string
+		Processes   []Process
+		Groups      testProcessGroups
+		Sockets     []servedSocket
+		Expectation []servedSocket
+	}{
+		{
+			Name: "grouped by ancestor",
+			Processes: []Process{
+				{PID: 1, Comm: "supervisor"},
+				{PID: 10, PPID: 1, Comm: "bash"},
+				{PID: 11, PPID: 10, Comm: "npm run dev"},
+				{PID: 12, PPID: 11, Comm: "node (vite)", Sockets: []uint64{100}},
+				{PID: 20, PPID: 1, Comm: "bash"},
+				{PID: 21, PPID: 20, Comm: "python3", Sockets: []uint64{101}},
+				{PID: 30, PPID: 1, Comm: "code", Sockets: []uint64{102}},
+			},
+			Groups: testProcessGroups{10: "0", 20: "1"},
+			Sockets: []servedSocket{
+				{ServedPort: ServedPort{Port: 3000}, Inode: 100},
+				{ServedPort: ServedPort{Port: 8888}, Inode: 101},
+				{ServedPort: ServedPort{Port: 23000}, Inode: 102},
+				{ServedPort: ServedPort{Port: 8080}, Inode: 103},
+			},
+			Expectation: []servedSocket{
+				{ServedPort: ServedPort{Port: 3000, Group: "0", Process: "node (vite)"}, Inode: 100, PID: 12},
+				{ServedPort: ServedPort{Port: 8888, Group: "1", Process: "python3"}, Inode: 101, PID: 21},
+				{ServedPort: ServedPort{Port: 23000, Process: "code"}, Inode: 102, PID: 30},
+				{ServedPort: ServedPort{Port: 8080}, Inode: 103},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			procDir, err := ioutil.TempDir("", "proc")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(procDir)
+			for _, p := range test.Processes {
+				pidDir := filepath.Join(procDir, fmt.Sprint(p.PID))
+				err := os.MkdirAll(filepath.Join(pidDir, "fd"), 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+				stat := fmt.Sprintf("%d (%s) S %d %d 0 0 -1 4194560 0 0 0 0\n", p.PID, p.Comm, p.PPID, p.PID)
+				err = ioutil.WriteFile(filepath.Join(pidDir, "stat"), []byte(stat), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i, inode := range p.Sockets {
+					err = os.Symlink(fmt.Sprintf("socket:[%d]", inode), filepath.Join(pidDir, "fd", fmt.Sprint(i+3)))
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			obs := &PollingServedPortsObserver{
+				Groups:  test.Groups,
+				procDir: procDir,
+				owners:  newSocketOwnerCache(procDir),
+			}
+			sockets := append([]servedSocket(nil), test.Sockets...)
+			obs.owners.resolve(sockets)
+			obs.group(sockets)
+
+			if diff := cmp.Diff(test.Expectation, sockets); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListeningPorts(t *testing.T) {
+	procDir, err := ioutil.TempDir("", "proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(procDir)
+
+	for pid, inodes := range map[int][]uint64{12: {100, 101}, 21: {102}, 30: {103}} {
+		fdDir := filepath.Join(procDir, fmt.Sprint(pid), "fd")
+		err := os.MkdirAll(fdDir, 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, inode := range inodes {
+			err = os.Symlink(fmt.Sprintf("socket:[%d]", inode), filepath.Join(fdDir, fmt.Sprint(i+3)))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	err = os.MkdirAll(filepath.Join(procDir, "net"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tcp := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 101 1 0000000000000000 100 0 0 10 0
+   1: 00000000:0BB8 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 100 1 0000000000000000 100 0 0 10 0
+   2: 0100007F:22B8 00000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 102 1 0000000000000000 100 0 0 10 0
+   3: 0100007F:0BB8 0100007F:D2F0 01 00000000:00000000 00:00000000 00000000 33333        0 103 1 0000000000000000 100 0 0 10 0
+`
+	tcp6 := `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000000000000:0BB8 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000 33333        0 101 1 0000000000000000 100 0 0 10 0
+`
+	for fn, content := range map[string]string{"tcp": tcp, "tcp6": tcp6} {
+		err = ioutil.WriteFile(filepath.Join(procDir, "net", fn), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	act, err := ListeningPorts(procDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// process 30 only holds a connection, which is not listening
+	if diff := cmp.Diff(map[int][]uint32{12: {3000, 8080}, 21: {8888}}, act); diff != "" {
+		t.Errorf("unexpected ports (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,457 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/dropwriter"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// proxyRestartMinBackoff is the delay before a failed proxy is restarted the first time
+	proxyRestartMinBackoff = 1 * time.Second
+	// proxyRestartMaxBackoff caps the delay between restarts of a proxy which keeps failing
+	proxyRestartMaxBackoff = 30 * time.Second
+	// proxyHealthyAfter is how long a proxy has to serve until its failures are forgotten
+	proxyHealthyAfter = 1 * time.Minute
+	// proxyDegradedFailures is the number of consecutive serve failures after which a proxy is degraded
+	proxyDegradedFailures = 3
+	// proxyDegradedDialErrors is the number of consecutive failed requests after which a proxy is degraded
+	proxyDegradedDialErrors = 10
+)
+
+// ProxyHealth describes the errors of the proxy of a port served on localhost only
+type ProxyHealth struct {
+	// AcceptErrors counts the times the proxy stopped accepting connections
+	AcceptErrors uint64
+	// DialErrors counts the requests which could not be forwarded to the served port
+	DialErrors uint64
+	// Restarts counts the times the proxy was restarted after it failed
+	Restarts uint64
+	// Degraded is true while the proxy keeps failing
+	Degraded bool
+	// LastError is the latest error of the proxy
+	LastError string
+}
+
+// proxyServer serves the connections of a listener until it is closed, like http.Server does
+type proxyServer interface {
+	Serve(lis net.Listener) error
+	Close() error
+}
+
+// localhostProxyServer proxies a port served on localhost only to a global port.
+// It restarts itself with backoff if it stops accepting connections.
+// HTTP and gRPC ports are proxied per request, TCP ports per connection.
+type localhostProxyServer struct {
+	localPort  uint32
+	globalPort uint32
+	config     *gitpod.PortConfig
+	protocol   string
+	handler    http.Handler
+	listen     func(addr string) (net.Listener, error)
+	// onHealthChange is called whenever the proxy restarts or becomes degraded or healthy again
+	onHealthChange func()
+	// onTraffic is called for every request to the proxy
+	onTraffic func()
+
+	mu                  sync.Mutex
+	srv                 proxyServer
+	health              ProxyHealth
+	consecutiveFailures int
+	consecutiveDialErrs int
+	closed              bool
+	closeChan           chan struct{}
+	minBackoff          time.Duration
+}
+
+func startLocalhostProxy(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func(), onTraffic func()) (*localhostProxyServer, error) {
+	p, err := newLocalhostProxyServer(localPort, globalPort, config, onHealthChange)
+	if err != nil {
+		return nil, err
+	}
+	p.onTraffic = onTraffic
+	err = p.start()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func newLocalhostProxyServer(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (*localhostProxyServer, error) {
+	p := &localhostProxyServer{
+		localPort:      localPort,
+		globalPort:     globalPort,
+		config:         config,
+		protocol:       portProtocol(config),
+		listen:         func(addr string) (net.Listener, error) { return net.Listen("tcp", addr) },
+		onHealthChange: onHealthChange,
+		closeChan:      make(chan struct{}),
+		minBackoff:     proxyRestartMinBackoff,
+	}
+
+	if p.protocol == ProtocolTCP {
+		// TCP connections are forwarded as they are, hence there is no handler
+		return p, nil
+	}
+
+	host := fmt.Sprintf("localhost:%d", localPort)
+	dsturl, err := url.Parse("http://" + host)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot produce proxy destination URL: %w", err)
+	}
+	proxy := httputil.NewSingleHostReverseProxy(dsturl)
+	originalDirector := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		if p.onTraffic != nil {
+			p.onTraffic()
+		}
+		req.Host = host
+		originalDirector(req)
+	}
+	proxy.ModifyResponse = func(resp *http.Response) error {
+		p.dialed(nil)
+		return nil
+	}
+	proxy.ErrorHandler = func(rw http.ResponseWriter, req *http.Request, err error) {
+		log.WithError(err).WithField("local-port", localPort).WithField("url", req.URL.String()).Warn("localhost proxy request failed")
+		p.dialed(err)
+		rw.WriteHeader(http.StatusBadGateway)
+	}
+	p.handler = proxy
+	if p.protocol == ProtocolGRPC {
+		// gRPC services speak HTTP/2 without TLS (h2c), which must not be downgraded to HTTP/1.1
+		proxy.Transport = &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		}
+		// streaming calls must not be buffered
+		proxy.FlushInterval = -1
+		p.handler = h2c.NewHandler(proxy, &http2.Server{})
+	}
+	return p, nil
+}
+
+// newServer produces the server which serves a listener of the proxy
+func (p *localhostProxyServer) newServer() proxyServer {
+	if p.protocol == ProtocolTCP {
+		return &tcpProxyServer{
+			target:    fmt.Sprintf("localhost:%d", p.localPort),
+			dialed:    p.dialed,
+			onTraffic: p.onTraffic,
+			conns:     make(map[net.Conn]struct{}),
+		}
+	}
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%d", p.globalPort),
+		Handler: p.handler,
+	}
+}
+
+// start listens on the global port and serves the proxy in the background
+func (p *localhostProxyServer) start() error {
+	lis, err := p.listenProxyPort()
+	if err != nil {
+		return err
+	}
+	go p.serve(lis)
+	return nil
+}
+
+func (p *localhostProxyServer) listenProxyPort() (net.Listener, error) {
+	lis, err := p.listen(fmt.Sprintf(":%d", p.globalPort))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot listen on proxy port %d: %w", p.globalPort, err)
+	}
+	if p.config != nil && p.config.ConnectionRateLimit > 0 {
+		limit := int64(p.config.ConnectionRateLimit)
+		lis = &rateLimitedListener{
+			Listener:  lis,
+			bucket:    dropwriter.NewBucket(limit, limit),
+			localPort: p.localPort,
+		}
+	}
+	return lis, nil
+}
+
+// serve serves the proxy until it is closed. If serving fails, the proxy is restarted with exponential backoff.
+func (p *localhostProxyServer) serve(lis net.Listener) {
+	backoff := p.minBackoff
+	for {
+		if lis != nil {
+			srv := p.newServer()
+			p.mu.Lock()
+			if p.closed {
+				p.mu.Unlock()
+				lis.Close()
+				return
+			}
+			p.srv = srv
+			p.mu.Unlock()
+
+			started := time.Now()
+			err := srv.Serve(lis)
+			if err == http.ErrServerClosed {
+				return
+			}
+			if time.Since(started) >= proxyHealthyAfter {
+				p.mu.Lock()
+				p.consecutiveFailures = 0
+				p.mu.Unlock()
+				backoff = p.minBackoff
+			}
+			log.WithError(err).WithField("local-port", p.localPort).WithField("backoff", backoff.String()).Error("localhost proxy failed - restarting")
+			p.failed(err, true)
+		}
+
+		select {
+		case <-p.closeChan:
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > proxyRestartMaxBackoff {
+			backoff = proxyRestartMaxBackoff
+		}
+
+		var err error
+		lis, err = p.listenProxyPort()
+		if err != nil {
+			log.WithError(err).WithField("local-port", p.localPort).Warn("cannot restart localhost proxy")
+			p.failed(err, false)
+			continue
+		}
+		p.restarted()
+	}
+}
+
+// failed records a failure to serve. Accept errors are failures of a listener which served already.
+func (p *localhostProxyServer) failed(err error, accept bool) {
+	p.mu.Lock()
+	if accept {
+		p.health.AcceptErrors++
+	}
+	p.health.LastError = err.Error()
+	p.consecutiveFailures++
+	changed := p.updateDegraded()
+	p.mu.Unlock()
+
+	if changed {
+		p.notify()
+	}
+}
+
+func (p *localhostProxyServer) restarted() {
+	p.mu.Lock()
+	p.health.Restarts++
+	p.mu.Unlock()
+
+	log.WithField("local-port", p.localPort).WithField("global-port", p.globalPort).Info("localhost proxy has been restarted")
+	p.notify()
+}
+
+// dialed records the outcome of forwarding a request to the served port
+func (p *localhostProxyServer) dialed(err error) {
+	p.mu.Lock()
+	if err != nil {
+		p.health.DialErrors++
+		p.health.LastError = err.Error()
+		p.consecutiveDialErrs++
+	} else {
+		// serving requests shows that the proxy works, even if it had to be restarted
+		p.consecutiveDialErrs = 0
+		p.consecutiveFailures = 0
+	}
+	changed := p.updateDegraded()
+	p.mu.Unlock()
+
+	if changed {
+		p.notify()
+	}
+}
+
+// updateDegraded returns true if the degraded state changed. Callers are expected to hold mu.
+func (p *localhostProxyServer) updateDegraded() bool {
+	degraded := p.consecutiveFailures >= proxyDegradedFailures || p.consecutiveDialErrs >= proxyDegradedDialErrors
+	if degraded == p.health.Degraded {
+		return false
+	}
+	p.health.Degraded = degraded
+	if degraded {
+		log.WithField("local-port", p.localPort).WithField("health", p.health).Warn("localhost proxy is degraded")
+	} else {
+		log.WithField("local-port", p.localPort).Info("localhost proxy recovered")
+	}
+	return true
+}
+
+func (p *localhostProxyServer) notify() {
+	if p.onHealthChange != nil {
+		p.onHealthChange()
+	}
+}
+
+// Health returns the error counters of the proxy
+func (p *localhostProxyServer) Health() ProxyHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.health
+}
+
+// Close stops the proxy
+func (p *localhostProxyServer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	close(p.closeChan)
+	if p.srv == nil {
+		return nil
+	}
+	return p.srv.Close()
+}
+
+// tcpProxyServer forwards raw TCP connections to the served port without interpreting them
+type tcpProxyServer struct {
+	target string
+	// dialed is called with the outcome of connecting to the served port
+	dialed func(err error)
+	// onTraffic is called for every forwarded connection
+	onTraffic func()
+
+	mu     sync.Mutex
+	lis    net.Listener
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+// Serve forwards the connections of the listener until the server is closed,
+// in which case it returns http.ErrServerClosed like http.Server does.
+func (s *tcpProxyServer) Serve(lis net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		lis.Close()
+		return http.ErrServerClosed
+	}
+	s.lis = lis
+	s.mu.Unlock()
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return http.ErrServerClosed
+			}
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				time.Sleep(5 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+		go s.forward(conn)
+	}
+}
+
+func (s *tcpProxyServer) forward(conn net.Conn) {
+	if !s.track(conn) {
+		conn.Close()
+		return
+	}
+	defer s.untrack(conn)
+
+	upstream, err := net.Dial("tcp", s.target)
+	if s.dialed != nil {
+		s.dialed(err)
+	}
+	if err != nil {
+		log.WithError(err).WithField("target", s.target).Warn("localhost proxy connection failed")
+		conn.Close()
+		return
+	}
+	if s.onTraffic != nil {
+		s.onTraffic()
+	}
+	if !s.track(upstream) {
+		upstream.Close()
+		conn.Close()
+		return
+	}
+	defer s.untrack(upstream)
+
+	done := make(chan struct{})
+	go func() {
+		copyAndCloseWrite(upstream, conn)
+		close(done)
+	}()
+	copyAndCloseWrite(conn, upstream)
+	<-done
+	conn.Close()
+	upstream.Close()
+}
+
+// copyAndCloseWrite copies src to dst and closes the write side of dst once src is drained,
+// so that half-closed connections keep working through the proxy
+func copyAndCloseWrite(dst, src net.Conn) {
+	_, _ = io.Copy(dst, src)
+	if c, ok := dst.(interface{ CloseWrite() error }); ok {
+		_ = c.CloseWrite()
+	} else {
+		dst.Close()
+	}
+}
+
+func (s *tcpProxyServer) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	return true
+}
+
+func (s *tcpProxyServer) untrack(conn net.Conn) {
+	s.mu.Lock()
+	delete(s.conns, conn)
+	s.mu.Unlock()
+}
+
+// Close stops accepting connections and closes the forwarded ones
+func (s *tcpProxyServer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	if s.lis == nil {
+		return nil
+	}
+	return s.lis.Close()
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// failingListener fails to accept connections once fail is closed
+type failingListener struct {
+	fail chan struct{}
+}
+
+func (l *failingListener) Accept() (net.Conn, error) {
+	<-l.fail
+	return nil, errors.New("accept failed")
+}
+
+func (l *failingListener) Close() error { return nil }
+
+func (l *failingListener) Addr() net.Addr { return &net.TCPAddr{} }
+
+func TestLocalhostProxyRestart(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	defer backend.Close()
+	localPort := uint32(backend.Listener.Addr().(*net.TCPAddr).Port)
+
+	var (
+		mu        sync.Mutex
+		listens   int
+		addr      string
+		fail      = make(chan struct{})
+		restarted = make(chan struct{}, 10)
+	)
+	proxy, err := newLocalhostProxyServer(localPort, 0, nil, func() { restarted <- struct{}{} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy.minBackoff = 10 * time.Millisecond
+	proxy.listen = func(string) (net.Listener, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		listens++
+		if listens == 1 {
+			return &failingListener{fail: fail}, nil
+		}
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, err
+		}
+		addr = lis.Addr().String()
+		return lis, nil
+	}
+	err = proxy.start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxy.Close()
+
+	close(fail)
+	select {
+	case <-restarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("proxy was not restarted")
+	}
+	health := proxy.Health()
+	if health.AcceptErrors != 1 || health.Restarts != 1 || health.Degraded {
+		t.Errorf("unexpected health after restart: %+v", health)
+	}
+
+	mu.Lock()
+	url := fmt.Sprintf("http://%s/", addr)
+	mu.Unlock()
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = http.Get(url)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("restarted proxy does not serve: %v", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "hello" {
+		t.Errorf("unexpected response: %s", body)
+	}
+}
+
+func TestLocalhostProxyDegraded(t *testing.T) {
+	t.Run("cannot restart", func(t *testing.T) {
+		changes := make(chan struct{}, 10)
+		proxy, err := newLocalhostProxyServer(8080, 0, nil, func() { changes <- struct{}{} })
+		if err != nil {
+			t.Fatal(err)
+		}
+		proxy.minBackoff = time.Millisecond
+		fail := make(chan struct{})
+		close(fail)
+		var listens int
+		proxy.listen = func(string) (net.Listener, error) {
+			listens++
+			if listens == 1 {
+				return &failingListener{fail: fail}, nil
+			}
+			return nil, errors.New("address already in use")
+		}
+		err = proxy.start()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer proxy.Close()
+
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			t.Fatal("proxy did not become degraded")
+		}
+		health := proxy.Health()
+		if !health.Degraded || health.AcceptErrors != 1 || health.Restarts != 0 {
+			t.Errorf("unexpected health: %+v", health)
+		}
+	})
+
+	t.Run("served port does not answer", func(t *testing.T) {
+		changes := make(chan struct{}, 10)
+		proxy, err := newLocalhostProxyServer(1, 0, nil, func() { changes <- struct{}{} })
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < proxyDegradedDialErrors; i++ {
+			rec := httptest.NewRecorder()
+			proxy.handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+			if rec.Code != http.StatusBadGateway {
+				t.Fatalf("unexpected status: %d", rec.Code)
+			}
+		}
+		if len(changes) != 1 {
+			t.Errorf("expected one health change, got %d", len(changes))
+		}
+		health := proxy.Health()
+		if !health.Degraded || health.DialErrors != proxyDegradedDialErrors || health.LastError == "" {
+			t.Errorf("unexpected health: %+v", health)
+		}
+
+		proxy.dialed(nil)
+		if proxy.Health().Degraded {
+			t.Error("proxy is still degraded after a successful request")
+		}
+	})
+}
+
+// startTestProxy starts a proxy for the local port on a random loopback port and returns its address
+func startTestProxy(t *testing.T, localPort uint32, config *gitpod.PortConfig, onTraffic func()) (*localhostProxyServer, string) {
+	proxy, err := newLocalhostProxyServer(localPort, 0, config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy.onTraffic = onTraffic
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy.listen = func(string) (net.Listener, error) { return lis, nil }
+	err = proxy.start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return proxy, lis.Addr().String()
+}
+
+func TestLocalhostProxyTCP(t *testing.T) {
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	go func() {
+		conn, err := backend.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// answer only once the client closed its write side, which must pass through the proxy
+		req, _ := ioutil.ReadAll(conn)
+		conn.Write(append([]byte("echo: "), req...))
+	}()
+	localPort := uint32(backend.Addr().(*net.TCPAddr).Port)
+
+	traffic := make(chan struct{}, 1)
+	proxy, addr := startTestProxy(t, localPort, &gitpod.PortConfig{Port: float64(localPort), Protocol: ProtocolTCP}, func() { traffic <- struct{}{} })
+	defer proxy.Close()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	// not an HTTP request, which an HTTP proxy would reject
+	_, err = conn.Write([]byte("PING\x00\x01"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.(*net.TCPConn).CloseWrite()
+	resp, err := ioutil.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp) != "echo: PING\x00\x01" {
+		t.Errorf("unexpected response: %q", resp)
+	}
+	if health := proxy.Health(); health.DialErrors != 0 || health.Degraded {
+		t.Errorf("unexpected health: %+v", health)
+	}
+	if len(traffic) != 1 {
+		t.Error("expected the connection to be recorded as traffic")
+	}
+}
+
+func TestLocalhostProxyGRPC(t *testing.T) {
+	backend := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.Write([]byte(r.Proto))
+		w.Header().Set("Grpc-Status", "0")
+	}), &http2.Server{}))
+	defer backend.Close()
+	localPort := uint32(backend.Listener.Addr().(*net.TCPAddr).Port)
+
+	proxy, addr := startTestProxy(t, localPort, &gitpod.PortConfig{Port: float64(localPort), Protocol: ProtocolGRPC}, nil)
+	defer proxy.Close()
+
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+	}
+	resp, err := client.Get(fmt.Sprintf("http://%s/test.Service/Call", addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "HTTP/2.0" {
+		t.Errorf("expected the request to reach the backend as HTTP/2, got %q", body)
+	}
+	if status := resp.Trailer.Get("Grpc-Status"); status != "0" {
+		t.Errorf("expected the grpc-status trailer to pass the proxy, got %q", status)
+	}
+}
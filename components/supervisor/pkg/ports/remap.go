@@ -0,0 +1,81 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"golang.org/x/xerrors"
+)
+
+// RemapSuggestion suggests to proxy a port on a free global port, because another process
+// serves the global port the port is configured with
+type RemapSuggestion struct {
+	// ConfiguredPort is the configured global port
+	ConfiguredPort uint32
+	// SuggestedPort is the free global port the port is proxied on once the suggestion is accepted
+	SuggestedPort uint32
+	// OccupiedBy is the command name of the process serving the configured global port, if known
+	OccupiedBy string
+}
+
+// setRemapSuggestions replaces the remap suggestions and marks the ports whose suggestion changed.
+// Suggested ports which are not used by a proxy are released. Callers are expected to hold mu.
+func (pm *Manager) setRemapSuggestions(suggestions map[uint32]RemapSuggestion) {
+	for port, prev := range pm.remapSuggestions {
+		next, exists := suggestions[port]
+		if exists && reflect.DeepEqual(prev, next) {
+			continue
+		}
+		pm.markDirty(port)
+		if proxy, proxied := pm.proxies[port]; !proxied || proxy.proxyPort != prev.SuggestedPort {
+			pm.globalPorts.release(prev.SuggestedPort)
+		}
+	}
+	for port, next := range suggestions {
+		if prev, exists := pm.remapSuggestions[port]; exists && reflect.DeepEqual(prev, next) {
+			continue
+		}
+		log.WithField("port", port).WithField("suggestion", next).Info("configured global port is served by another process - suggesting to remap the port")
+		pm.markDirty(port)
+	}
+	pm.remapSuggestions = suggestions
+
+	served := make(map[uint32]struct{}, len(pm.served))
+	for _, p := range pm.served {
+		served[p.Port] = struct{}{}
+	}
+	for port := range pm.acceptedRemaps {
+		if _, exists := served[port]; !exists {
+			// the configured global port is tried again once the port is served again
+			delete(pm.acceptedRemaps, port)
+		}
+	}
+}
+
+// AcceptRemap accepts the suggestion to proxy a port on a free global port because another process serves
+// the configured global port. The port is proxied and exposed on the suggested port right away.
+func (pm *Manager) AcceptRemap(ctx context.Context, port uint32) (err error) {
+	span, ctx := tracing.FromContext(ctx, "ports.Manager.AcceptRemap")
+	span.SetTag("port", port)
+	defer tracing.FinishSpan(span, &err)
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if _, suggested := pm.remapSuggestions[port]; !suggested {
+		return xerrors.Errorf("there is no suggestion to remap port %d", port)
+	}
+	pm.acceptedRemaps[port] = struct{}{}
+	// the exposure on the suggested port shows up with the next exposed ports update, which is then attributed to this request
+	pm.requested[port] = struct{}{}
+	pm.updateProxies()
+	pm.markDirty(port)
+	pm.updateState(ctx, api.PortsUpdateTrigger_manual_action)
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRemapSuggestions(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	proxied := make(map[uint32]uint32)
+	pm.proxyStarter = func(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (io.Closer, error) {
+		proxied[localPort] = globalPort
+		return ioutil.NopCloser(nil), nil
+	}
+	configs := &Configs{}
+	configs.workspaceConfigs, _ = parseWorkspaceConfigs([]*gitpod.PortConfig{
+		{Port: 3000, GlobalPort: 8080},
+		{Port: 4000, GlobalPort: 9090},
+	})
+	update := func(served []ServedPort) {
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+		pm.setConfigs(configs)
+		pm.setServed(served)
+		pm.updateProxies()
+		pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	}
+	status := func(port uint32) *api.PortsStatus {
+		for _, s := range pm.Status() {
+			if s.LocalPort == port {
+				return s
+			}
+		}
+		t.Fatalf("port %d is not managed", port)
+		return nil
+	}
+	suggestion := func(port uint32) *api.PortsStatus_RemapSuggestion {
+		return status(port).RemapSuggestion
+	}
+
+	update([]ServedPort{
+		{Port: 3000, BoundToLocalhost: true},
+		{Port: 4000, BoundToLocalhost: true},
+		{Port: 8080, Process: "nginx"},
+	})
+	if diff := cmp.Diff(map[uint32]uint32{4000: 9090}, proxied); diff != "" {
+		t.Errorf("unexpected proxies (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&api.PortsStatus_RemapSuggestion{ConfiguredPort: 8080, SuggestedPort: 60000, OccupiedBy: "nginx"}, suggestion(3000)); diff != "" {
+		t.Errorf("unexpected remap suggestion (-want +got):\n%s", diff)
+	}
+	if s := suggestion(4000); s != nil {
+		t.Errorf("unexpected remap suggestion for a port whose global port is free: %v", s)
+	}
+	if err := pm.AcceptRemap(context.Background(), 4000); err == nil {
+		t.Error("expected an error accepting a remap which was not suggested")
+	}
+
+	err := pm.AcceptRemap(context.Background(), 3000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[uint32]uint32{3000: 60000, 4000: 9090}, proxied); diff != "" {
+		t.Errorf("unexpected proxies after accepting the remap (-want +got):\n%s", diff)
+	}
+	if s := suggestion(3000); s != nil {
+		t.Errorf("unexpected remap suggestion after accepting it: %v", s)
+	}
+	if global := status(3000).GlobalPort; global != 60000 {
+		t.Errorf("expected port 3000 to be proxied on the suggested port, got %d", global)
+	}
+}
+
+func TestRemapSuggestionWithdrawn(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	proxied := make(map[uint32]uint32)
+	pm.proxyStarter = func(localPort uint32, globalPort uint32, config *gitpod.PortConfig, onHealthChange func()) (io.Closer, error) {
+		proxied[localPort] = globalPort
+		return ioutil.NopCloser(nil), nil
+	}
+	configs := &Configs{}
+	configs.workspaceConfigs, _ = parseWorkspaceConfigs([]*gitpod.PortConfig{{Port: 3000, GlobalPort: 8080}})
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.setConfigs(configs)
+	pm.setServed([]ServedPort{{Port: 3000, BoundToLocalhost: true}, {Port: 8080}})
+	pm.updateProxies()
+	if _, suggested := pm.remapSuggestions[3000]; !suggested {
+		t.Fatal("expected a remap suggestion")
+	}
+
+	// the other process stopped serving the configured global port
+	pm.setServed([]ServedPort{{Port: 3000, BoundToLocalhost: true}})
+	pm.updateProxies()
+	if len(pm.remapSuggestions) != 0 {
+		t.Errorf("unexpected remap suggestions: %v", pm.remapSuggestions)
+	}
+	if diff := cmp.Diff(map[uint32]uint32{3000: 8080}, proxied); diff != "" {
+		t.Errorf("expected the configured global port to be used (-want +got):\n%s", diff)
+	}
+}
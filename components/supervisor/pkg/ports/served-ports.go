@@ -9,17 +9,45 @@ import (
 	"context"
 	"io"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
-
-	"github.com/gitpod-io/gitpod/common-go/log"
 )
 
 // ServedPort describes a port served by a local service
 type ServedPort struct {
 	Port             uint32
 	BoundToLocalhost bool
+	// DetectedAs is the name of the well-known dev server serving this port, if one was detected
+	DetectedAs string
+	// Group is the group of the process serving this port, e.g. the task which started it
+	Group string
+	// ComposeService is the name of the Docker Compose service serving this port, if any
+	ComposeService string
+	// ComposeInternal is true if the compose service exposes the port to other containers only
+	ComposeInternal bool
+	// KubernetesService is the <namespace>/<name> of the service of a cluster in the workspace serving this port, if any
+	KubernetesService string
+	// Debugger is the debugger protocol spoken on this port, e.g. node-inspector, jdwp or delve, if one was detected
+	Debugger string
+	// DebugURL is what debugger clients attach with, e.g. a devtools:// URL for the Node.js inspector
+	DebugURL string
+	// Process is the command name of the process serving this port, if it is known
+	Process string
+	// Namespace labels the network namespace serving this port if it is not supervisor's, e.g. a container
+	Namespace string
+	// NamespaceInternal is true if the ports of the network namespace are not auto-exposed
+	NamespaceInternal bool
+}
+
+// servedSocket is a served port and the listening socket
+type servedSocket struct {
+	ServedPort
+	Inode uint64
+	// PID is the process owning the socket, zero if unknown
+	PID int
 }
 
 // ServedPortsObserver observes the locally served ports and provides
@@ -34,17 +62,90 @@ type ServedPortsObserver interface {
 }
 
 const (
-	maxSubscriptions = 10
-
 	fnNetTCP  = "/proc/net/tcp"
 	fnNetTCP6 = "/proc/net/tcp6"
 )
 
 // PollingServedPortsObserver regularly polls "/proc" to observe port changes
 type PollingServedPortsObserver struct {
+	// RefreshInterval is the interval ports are polled with while the workspace is active,
+	// i.e. after a nudge or a change of the served ports
 	RefreshInterval time.Duration
+	// IdleInterval is the interval polling slows down to while the workspace is idle.
+	// If it is not greater than RefreshInterval, ports are always polled with RefreshInterval.
+	IdleInterval time.Duration
+	// Frameworks detects well-known dev servers if set
+	Frameworks *FrameworkDetector
+	// Groups groups served ports by the processes serving them if set
+	Groups ProcessGroups
+	// Compose labels ports served by Docker Compose services if set
+	Compose *ComposeDetector
+	// Kubernetes labels ports of services of a Kubernetes cluster running in the workspace if set
+	Kubernetes *KubernetesDetector
+	// Debuggers detects debug ports if set
+	Debuggers *DebuggerDetector
+	// Namespaces reports the ports served in other network namespaces if set
+	Namespaces *NamespaceDetector
 
 	fileOpener func(fn string) (io.ReadCloser, error)
+	procDir    string
+	owners     *socketOwnerCache
+	groups     map[uint64]string
+	nudges     chan struct{}
+	nudgesOnce sync.Once
+}
+
+// servedPortsActiveFor is how long ports are polled with the refresh interval after the workspace was active
+const servedPortsActiveFor = 1 * time.Minute
+
+// Nudge polls the served ports right away and keeps polling with the refresh interval for a while.
+// Call it whenever new ports are likely to be served soon, e.g. when a task or a command starts.
+func (p *PollingServedPortsObserver) Nudge() {
+	select {
+	case p.nudgeChan() <- struct{}{}:
+	default:
+		// a nudge is pending already
+	}
+}
+
+func (p *PollingServedPortsObserver) nudgeChan() chan struct{} {
+	p.nudgesOnce.Do(func() {
+		p.nudges = make(chan struct{}, 1)
+	})
+	return p.nudges
+}
+
+// pollBackoff decides when to poll next. While the workspace is active polling happens with min,
+// afterwards the interval doubles with every poll until it reaches max.
+type pollBackoff struct {
+	min       time.Duration
+	max       time.Duration
+	activeFor time.Duration
+
+	lastActivity time.Time
+	interval     time.Duration
+}
+
+// active records that new ports are likely to be served soon
+func (b *pollBackoff) active(now time.Time) {
+	b.lastActivity = now
+	b.interval = b.min
+}
+
+// next returns the delay until the next poll
+func (b *pollBackoff) next(now time.Time) time.Duration {
+	if b.max <= b.min || now.Sub(b.lastActivity) < b.activeFor {
+		b.interval = b.min
+		return b.interval
+	}
+	b.interval *= 2
+	if b.interval < b.min {
+		b.interval = b.min
+	}
+	if b.interval > b.max {
+		b.interval = b.max
+	}
+	return b.interval
 }
 
 // Observe starts observing the served ports until the context is canceled.
@@ -54,52 +155,139 @@ func (p *PollingServedPortsObserver) Observe(ctx context.Context) (<-chan []Serv
 			return os.Open(fn)
 		}
 	}
+	if p.procDir == "" {
+		p.procDir = "/proc"
+	}
 
 	var (
 		errchan = make(chan error, 1)
 		reschan = make(chan []ServedPort)
-		ticker  = time.NewTicker(p.RefreshInterval)
+		backoff = &pollBackoff{
+			min:       p.RefreshInterval,
+			max:       p.IdleInterval,
+			activeFor: servedPortsActiveFor,
+		}
+		nudges = p.nudgeChan()
 	)
+	backoff.active(time.Now())
 
 	go func() {
 		defer close(errchan)
 		defer close(reschan)
 
+		var previous []ServedPort
+		timer := time.NewTimer(backoff.next(time.Now()))
+		defer timer.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				log.Warn("done")
 				return
-			case <-ticker.C:
+			case <-timer.C:
+			case <-nudges:
+				backoff.active(time.Now())
+				if !timer.Stop() {
+					<-timer.C
+				}
 			}
 
-			var ports []ServedPort
+			var sockets []servedSocket
 			for _, fn := range []string{fnNetTCP, fnNetTCP6} {
 				fc, err := p.fileOpener(fn)
 				if err != nil {
 					errchan <- err
 					continue
 				}
-				ps, err := readNetTCPFile(fc, true)
+				ss, err := readNetTCPSockets(fc, true)
 				fc.Close()
 
 				if err != nil {
 					errchan <- err
 					continue
 				}
-				ports = append(ports, ps...)
+				sockets = append(sockets, ss...)
+			}
+			if p.Namespaces != nil {
+				sockets = append(sockets, p.Namespaces.sockets(sockets)...)
+			}
+			if p.Frameworks != nil || p.Groups != nil || p.Compose != nil || p.Kubernetes != nil || p.Debuggers != nil {
+				if p.owners == nil {
+					p.owners = newSocketOwnerCache(p.procDir)
+				}
+				p.owners.resolve(sockets)
+			}
+			if p.Frameworks != nil {
+				p.Frameworks.detect(sockets)
+			}
+			if p.Debuggers != nil {
+				p.Debuggers.detect(sockets)
+			}
+			if p.Groups != nil {
+				p.group(sockets)
+			}
+			if p.Compose != nil {
+				p.Compose.label(sockets)
+			}
+			if p.Kubernetes != nil {
+				p.Kubernetes.label(sockets)
 			}
 
+			var ports []ServedPort
+			for _, s := range sockets {
+				ports = append(ports, s.ServedPort)
+			}
+			if !reflect.DeepEqual(previous, ports) {
+				// ports are often served one after another, e.g. by a dev server and its HMR endpoint
+				backoff.active(time.Now())
+			}
+			previous = ports
+
 			if len(ports) > 0 {
 				reschan <- ports
 			}
+			timer.Reset(backoff.next(time.Now()))
 		}
 	}()
 
 	return reschan, errchan
 }
 
+// group sets the Group of the served ports. A socket's group is resolved once, since the task
+// which started a process does not change.
+func (p *PollingServedPortsObserver) group(sockets []servedSocket) {
+	current := make(map[uint64]struct{}, len(sockets))
+	if p.groups == nil {
+		p.groups = make(map[uint64]string)
+	}
+	for i := range sockets {
+		socket := &sockets[i]
+		current[socket.Inode] = struct{}{}
+		group, cached := p.groups[socket.Inode]
+		if !cached && socket.PID != 0 {
+			group = p.Groups.GroupOf(processAncestry(p.procDir, socket.PID))
+			p.groups[socket.Inode] = group
+		}
+		socket.Group = group
+	}
+	for inode := range p.groups {
+		if _, exists := current[inode]; !exists {
+			delete(p.groups, inode)
+		}
+	}
+}
+
 func readNetTCPFile(fc io.Reader, listeningOnly bool) (ports []ServedPort, err error) {
+	sockets, err := readNetTCPSockets(fc, listeningOnly)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sockets {
+		ports = append(ports, s.ServedPort)
+	}
+	return
+}
+
+func readNetTCPSockets(fc io.Reader, listeningOnly bool) (sockets []servedSocket, err error) {
 	scanner := bufio.NewScanner(fc)
 	for scanner.Scan() {
 		fields := strings.Fields(scanner.Text())
@@ -123,9 +311,17 @@ func readNetTCPFile(fc io.Reader, listeningOnly bool) (ports []ServedPort, err e
 			continue
 		}
 
-		ports = append(ports, ServedPort{
-			BoundToLocalhost: !globallyBound,
-			Port:             uint32(port),
+		var inode uint64
+		if len(fields) > 9 {
+			inode, _ = strconv.ParseUint(fields[9], 10, 64)
+		}
+
+		sockets = append(sockets, servedSocket{
+			ServedPort: ServedPort{
+				BoundToLocalhost: !globallyBound,
+				Port:             uint32(port),
+			},
+			Inode: inode,
 		})
 	}
 	if err = scanner.Err(); err != nil {
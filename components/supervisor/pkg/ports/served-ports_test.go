@@ -101,6 +101,95 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveNudge(t *testing.T) {
+	obs := PollingServedPortsObserver{
+		RefreshInterval: 1 * time.Hour,
+		IdleInterval:    2 * time.Hour,
+		fileOpener: func(fn string) (io.ReadCloser, error) {
+			if fn == fnNetTCP {
+				return ioutil.NopCloser(strings.NewReader(validTCPInput)), nil
+			}
+			return ioutil.NopCloser(strings.NewReader("")), nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates, errs := obs.Observe(ctx)
+	go func() {
+		for range errs {
+		}
+	}()
+
+	// nudges before the first poll must not be lost, and polling must not wait for the refresh interval
+	obs.Nudge()
+	select {
+	case <-updates:
+	case <-time.After(5 * time.Second):
+		t.Fatal("served ports were not polled after a nudge")
+	}
+	obs.Nudge()
+	select {
+	case <-updates:
+	case <-time.After(5 * time.Second):
+		t.Fatal("served ports were not polled after a second nudge")
+	}
+}
+
+func TestPollBackoff(t *testing.T) {
+	start := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Desc        string
+		Max         time.Duration
+		Polls       []time.Duration
+		Activity    map[int]bool
+		Expectation []time.Duration
+	}{
+		{
+			Desc:        "active workspace",
+			Max:         10 * time.Second,
+			Polls:       []time.Duration{0, 10 * time.Second, 59 * time.Second},
+			Expectation: []time.Duration{1 * time.Second, 1 * time.Second, 1 * time.Second},
+		},
+		{
+			Desc:        "idle workspace backs off",
+			Max:         10 * time.Second,
+			Polls:       []time.Duration{1 * time.Minute, 61 * time.Second, 63 * time.Second, 67 * time.Second, 75 * time.Second, 85 * time.Second},
+			Expectation: []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second, 10 * time.Second},
+		},
+		{
+			Desc:        "activity resets the backoff",
+			Max:         10 * time.Second,
+			Polls:       []time.Duration{1 * time.Minute, 61 * time.Second, 63 * time.Second, 64 * time.Second},
+			Activity:    map[int]bool{2: true},
+			Expectation: []time.Duration{2 * time.Second, 4 * time.Second, 1 * time.Second, 1 * time.Second},
+		},
+		{
+			Desc:        "no idle interval",
+			Polls:       []time.Duration{0, 2 * time.Minute},
+			Expectation: []time.Duration{1 * time.Second, 1 * time.Second},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			backoff := &pollBackoff{min: 1 * time.Second, max: test.Max, activeFor: 1 * time.Minute}
+			backoff.active(start)
+
+			var act []time.Duration
+			for i, poll := range test.Polls {
+				now := start.Add(poll)
+				if test.Activity[i] {
+					backoff.active(now)
+				}
+				act = append(act, backoff.next(now))
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected intervals (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestReadNetTCPFile(t *testing.T) {
 	type Expectation struct {
 		Ports []ServedPort
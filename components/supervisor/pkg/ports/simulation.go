@@ -0,0 +1,149 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gitpod-io/gitpod/common-go/tracing"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"golang.org/x/xerrors"
+)
+
+// SimulatedPort is the synthetic state of a port, which lets tooling test clients of the ports status,
+// e.g. the ports view of an IDE and onOpen actions, without running servers.
+type SimulatedPort struct {
+	Port uint32
+	// Served simulates a process serving the port on all interfaces
+	Served bool
+	// Process is the command name of the simulated process serving the port
+	Process string
+	// Exposed simulates that the port is exposed, regardless of whether it is served
+	Exposed bool
+	// Public simulates a public exposure
+	Public bool
+	// URL is the URL the port is exposed on, a localhost URL if empty
+	URL string
+}
+
+// Simulate sets the synthetic state of a port. Simulated ports are merged with the observed ones, simulated
+// served ports which would be exposed are exposed in the simulation only. A port which is neither served
+// nor exposed is no longer simulated.
+func (pm *Manager) Simulate(ctx context.Context, port SimulatedPort) (err error) {
+	span, ctx := tracing.FromContext(ctx, "ports.Manager.Simulate")
+	span.SetTag("port", port.Port)
+	defer tracing.FinishSpan(span, &err)
+
+	if !pm.AllowSimulation {
+		return xerrors.Errorf("ports simulation is not enabled")
+	}
+	if port.Port == 0 {
+		return xerrors.Errorf("port is required")
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if _, internal := pm.internal[port.Port]; internal {
+		return xerrors.Errorf("port %d is used internally", port.Port)
+	}
+
+	delete(pm.simulatedServed, port.Port)
+	delete(pm.simulatedExposed, port.Port)
+	if port.Served {
+		pm.simulatedServed[port.Port] = ServedPort{Port: port.Port, Process: port.Process}
+	}
+	if port.Exposed {
+		pm.simulatedExposed[port.Port] = simulatedExposure(port.Port, port.Port, port.Public, port.URL)
+	}
+	log.WithField("port", port).Info("simulating port")
+
+	pm.setServed(pm.withSimulatedServed(pm.observedServed))
+	pm.setExposed(pm.withSimulatedExposed(pm.observedExposed))
+	pm.updateState(ctx, api.PortsUpdateTrigger_manual_action)
+	return nil
+}
+
+// simulatedExposure produces the exposure of a simulated port
+func simulatedExposure(port, global uint32, public bool, url string) ExposedPort {
+	if url == "" {
+		url = fmt.Sprintf("http://localhost:%d/", global)
+	}
+	return ExposedPort{LocalPort: port, GlobalPort: global, Public: public, URL: url}
+}
+
+// simulatesExposure returns true if exposing the port only affects the simulation. Callers are expected to hold mu.
+func (pm *Manager) simulatesExposure(port uint32) bool {
+	_, simulated := pm.simulatedServed[port]
+	return simulated
+}
+
+// exposeSimulated exposes a simulated served port in the simulation. Like real exposures, it shows up with
+// a later update. Callers are expected to hold mu.
+func (pm *Manager) exposeSimulated(port, global uint32, opts ExposeOptions) {
+	exposure := simulatedExposure(port, global, opts.Public, "")
+	if prev, exists := pm.simulatedExposed[port]; exists {
+		exposure.URL = prev.URL
+	}
+	go func() {
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+
+		if _, simulated := pm.simulatedServed[port]; !simulated {
+			return
+		}
+		pm.simulatedExposed[port] = exposure
+		pm.setExposed(pm.withSimulatedExposed(pm.observedExposed))
+		pm.updateState(context.Background(), api.PortsUpdateTrigger_exposed_ports_changed)
+	}()
+}
+
+// withSimulatedServed merges the simulated served ports into the observed ones. Simulated ports take precedence.
+// Callers are expected to hold mu.
+func (pm *Manager) withSimulatedServed(observed []ServedPort) []ServedPort {
+	if len(pm.simulatedServed) == 0 {
+		return observed
+	}
+	res := make([]ServedPort, 0, len(observed)+len(pm.simulatedServed))
+	for _, s := range observed {
+		if _, simulated := pm.simulatedServed[s.Port]; !simulated {
+			res = append(res, s)
+		}
+	}
+	ports := make([]uint32, 0, len(pm.simulatedServed))
+	for port := range pm.simulatedServed {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	for _, port := range ports {
+		res = append(res, pm.simulatedServed[port])
+	}
+	return res
+}
+
+// withSimulatedExposed merges the simulated exposures into the observed ones. Simulated exposures take precedence.
+// Callers are expected to hold mu.
+func (pm *Manager) withSimulatedExposed(observed []ExposedPort) []ExposedPort {
+	if len(pm.simulatedExposed) == 0 {
+		return observed
+	}
+	res := make([]ExposedPort, 0, len(observed)+len(pm.simulatedExposed))
+	for _, e := range observed {
+		if _, simulated := pm.simulatedExposed[e.LocalPort]; !simulated {
+			res = append(res, e)
+		}
+	}
+	ports := make([]uint32, 0, len(pm.simulatedExposed))
+	for port := range pm.simulatedExposed {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	for _, port := range ports {
+		res = append(res, pm.simulatedExposed[port])
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestSimulateDisabled(t *testing.T) {
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{})
+	err := pm.Simulate(context.Background(), SimulatedPort{Port: 3000, Served: true})
+	if err == nil {
+		t.Error("expected an error simulating ports without allowing it")
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	var (
+		exposed = &testExposedPorts{}
+		served  = &testServedPorts{Changes: make(chan []ServedPort)}
+		pm      = NewManager(exposed, served, &testConfigService{})
+	)
+	pm.AllowSimulation = true
+	go pm.Run()
+	defer pm.Stop(context.Background(), true)
+
+	awaitStatus := func(desc string, condition func(status map[uint32]*api.PortsStatus) bool) map[uint32]*api.PortsStatus {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			status := make(map[uint32]*api.PortsStatus)
+			for _, s := range pm.Status() {
+				status[s.LocalPort] = s
+			}
+			if condition(status) {
+				return status
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s, status: %v", desc, status)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	served.Changes <- []ServedPort{{Port: 8080}}
+	err := pm.Simulate(context.Background(), SimulatedPort{Port: 3000, Served: true, Process: "vite"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := awaitStatus("the simulated port to be exposed", func(status map[uint32]*api.PortsStatus) bool {
+		return status[3000] != nil && status[3000].Exposed != nil
+	})
+	if s := status[3000]; !s.Served || s.Process != "vite" || s.Exposed.Url != "http://localhost:3000/" {
+		t.Errorf("unexpected status of the simulated port: %v", s)
+	}
+	if status[8080] == nil || !status[8080].Served {
+		t.Errorf("expected the observed port to be served: %v", status[8080])
+	}
+	exposed.mu.Lock()
+	for _, e := range exposed.Exposures {
+		if e.LocalPort == 3000 {
+			t.Error("simulated port was exposed on the Gitpod server")
+		}
+	}
+	exposed.mu.Unlock()
+
+	// observed updates keep the simulated ports
+	served.Changes <- []ServedPort{{Port: 8080}, {Port: 8081}}
+	awaitStatus("the observed port to be served", func(status map[uint32]*api.PortsStatus) bool {
+		return status[8081] != nil && status[3000] != nil && status[3000].Served
+	})
+
+	err = pm.Simulate(context.Background(), SimulatedPort{Port: 5000, Exposed: true, Public: true, URL: "https://5000-simulated/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = pm.Simulate(context.Background(), SimulatedPort{Port: 3000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status = awaitStatus("the simulation to change", func(status map[uint32]*api.PortsStatus) bool {
+		return status[3000] == nil && status[5000] != nil
+	})
+	if s := status[5000]; s.Served || s.Exposed == nil || s.Exposed.Visibility != api.PortVisibility_public || s.Exposed.Url != "https://5000-simulated/" {
+		t.Errorf("unexpected status of the simulated exposed port: %v", s)
+	}
+}
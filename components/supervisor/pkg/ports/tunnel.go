@@ -0,0 +1,88 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"golang.org/x/xerrors"
+)
+
+// OpenTunnel connects to a port served in the workspace on behalf of a tunneling client.
+// The port is reported as tunneled until the returned connection is closed.
+func (pm *Manager) OpenTunnel(ctx context.Context, port uint32, client string) (net.Conn, error) {
+	pm.mu.RLock()
+	_, served := pm.servedByPort[port]
+	internal := pm.boundInternally(port)
+	pm.mu.RUnlock()
+	if internal {
+		return nil, xerrors.Errorf("port %d is internal and cannot be tunneled", port)
+	}
+	if !served {
+		return nil, xerrors.Errorf("port %d is not served", port)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return nil, err
+	}
+	log.WithField("port", port).WithField("client", client).Info("tunnel opened")
+
+	pm.updateTunnels(ctx, port, 1)
+	return &tunnelConn{
+		Conn: conn,
+		onClose: func() {
+			log.WithField("port", port).WithField("client", client).Info("tunnel closed")
+			pm.updateTunnels(context.Background(), port, -1)
+		},
+	}, nil
+}
+
+func (pm *Manager) updateTunnels(ctx context.Context, port uint32, delta int) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.tunnels[port] += delta
+	if pm.tunnels[port] <= 0 {
+		delete(pm.tunnels, port)
+	}
+	pm.markDirty(port)
+	pm.updateState(ctx, api.PortsUpdateTrigger_tunnels_changed)
+}
+
+// proxyHealthChanged updates the state of a port whose proxy restarted, or became degraded or healthy again
+func (pm *Manager) proxyHealthChanged(port uint32) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.markDirty(port)
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_proxy_health_changed)
+}
+
+// tunnelConn is a tunneled connection which reports when it is closed
+type tunnelConn struct {
+	net.Conn
+	onClose   func()
+	closeOnce sync.Once
+}
+
+func (c *tunnelConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(c.onClose)
+	return err
+}
+
+// CloseWrite closes the writing side of the connection, i.e. the tunneled service receives EOF
+func (c *tunnelConn) CloseWrite() error {
+	if cw, ok := c.Conn.(interface{ CloseWrite() error }); ok {
+		return cw.CloseWrite()
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestPortsOpenTunnel(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+
+	pm := NewManager(&testExposedPorts{}, &testServedPorts{}, &testConfigService{}, 24000)
+	pm.setServed([]ServedPort{{Port: port}, {Port: 24000}})
+	pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+
+	tunneled := func() bool {
+		for _, p := range pm.Status() {
+			if p.LocalPort == port {
+				return p.Tunneled
+			}
+		}
+		t.Fatalf("port %d is not in the status", port)
+		return false
+	}
+
+	if _, err := pm.OpenTunnel(context.Background(), 24000, "test"); err == nil {
+		t.Error("expected tunnels to internal ports to fail")
+	}
+	if _, err := pm.OpenTunnel(context.Background(), port+1, "test"); err == nil {
+		t.Error("expected tunnels to ports which are not served to fail")
+	}
+
+	conn, err := pm.OpenTunnel(context.Background(), port, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tunneled() {
+		t.Error("expected the port to be tunneled")
+	}
+
+	_, err = conn.Write([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = conn.(interface{ CloseWrite() error }).CloseWrite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo, err := ioutil.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(echo) != "hello" {
+		t.Errorf("unexpected echo: %q", echo)
+	}
+
+	conn.Close()
+	conn.Close()
+	if tunneled() {
+		t.Error("expected the port to be no longer tunneled")
+	}
+	if len(pm.tunnels) != 0 {
+		t.Errorf("unexpected tunnels after close: %v", pm.tunnels)
+	}
+}
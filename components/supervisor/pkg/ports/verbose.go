@@ -0,0 +1,80 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+)
+
+// SetVerbose switches verbose logging on or off at runtime. A verbose manager logs every observation, every
+// change of a port with its state before and after, and every exposure it makes, which helps diagnosing ports
+// which are not exposed as expected without restarting the workspace.
+func (pm *Manager) SetVerbose(verbose bool) {
+	var v int32
+	if verbose {
+		v = 1
+	}
+	if atomic.SwapInt32(&pm.verbose, v) != v {
+		log.WithField("verbose", verbose).Info("switched verbose ports logging")
+	}
+}
+
+// Verbose returns true if verbose logging is switched on
+func (pm *Manager) Verbose() bool {
+	return atomic.LoadInt32(&pm.verbose) == 1
+}
+
+// logObservation logs what an observer reported if the manager is verbose
+func (pm *Manager) logObservation(observer string, observation interface{}) {
+	if !pm.Verbose() {
+		return
+	}
+	if configs, ok := observation.(*Configs); ok {
+		// the configs don't reveal their content otherwise
+		ports := make(map[uint32]*gitpod.PortConfig)
+		configs.ForEach(func(port uint32, config *gitpod.PortConfig) { ports[port] = config })
+		observation = map[string]interface{}{
+			"ports":       ports,
+			"diagnostics": configs.Diagnostics(),
+		}
+	}
+	log.WithField("observer", observer).WithField("observation", observation).Info("ports debug: observed update")
+}
+
+// logTransitions logs the state before and after of the ports which changed with an update if the manager
+// is verbose. Callers are expected to hold mu.
+func (pm *Manager) logTransitions(previous map[uint32]*managedPort, trigger api.PortsUpdateTrigger) {
+	if !pm.Verbose() {
+		return
+	}
+	ports := make([]uint32, 0, len(previous))
+	for port := range previous {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	for _, port := range ports {
+		log.WithField("port", port).
+			WithField("trigger", trigger.String()).
+			WithField("before", previous[port]).
+			WithField("after", pm.state[port]).
+			Info("ports debug: port changed")
+	}
+}
+
+// logExposure logs an exposure and its outcome if the manager is verbose
+func (pm *Manager) logExposure(port, global uint32, opts ExposeOptions, err error) {
+	if !pm.Verbose() {
+		return
+	}
+	entry := log.WithField("port", port).WithField("globalPort", global).WithField("options", opts)
+	if err != nil {
+		entry = entry.WithError(err)
+	}
+	entry.Info("ports debug: exposed port")
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package ports
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestVerbose(t *testing.T) {
+	var buf bytes.Buffer
+	out := log.Logger.Out
+	log.Logger.SetOutput(&buf)
+	defer log.Logger.SetOutput(out)
+
+	exposed := &testExposedPorts{}
+	pm := NewManager(exposed, &testServedPorts{}, &testConfigService{})
+	serve := func(served ...ServedPort) {
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+		pm.setServed(served)
+		pm.updateState(context.Background(), api.PortsUpdateTrigger_served_ports_changed)
+	}
+
+	serve(ServedPort{Port: 3000})
+	if strings.Contains(buf.String(), "ports debug") {
+		t.Errorf("unexpected debug logs while not verbose:\n%s", buf.String())
+	}
+
+	pm.SetVerbose(true)
+	if !pm.Verbose() {
+		t.Fatal("expected the manager to be verbose")
+	}
+	buf.Reset()
+	serve(ServedPort{Port: 3000}, ServedPort{Port: 4000})
+	logs := buf.String()
+	for _, expectation := range []string{"ports debug: port changed", "ports debug: exposed port"} {
+		if !strings.Contains(logs, expectation) {
+			t.Errorf("expected %q to be logged, got:\n%s", expectation, logs)
+		}
+	}
+	if strings.Count(logs, "ports debug: port changed") != 1 {
+		t.Errorf("expected only the changed port to be logged, got:\n%s", logs)
+	}
+
+	pm.SetVerbose(false)
+	buf.Reset()
+	serve(ServedPort{Port: 3000})
+	if strings.Contains(buf.String(), "ports debug") {
+		t.Errorf("unexpected debug logs after switching verbose logging off:\n%s", buf.String())
+	}
+}
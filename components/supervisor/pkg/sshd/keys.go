@@ -0,0 +1,145 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+)
+
+// KeySource provides the public keys which may log into the workspace
+type KeySource interface {
+	AuthorizedKeys(ctx context.Context) ([]ssh.PublicKey, error)
+}
+
+// DefaultGitpodKeysTTL is the time the keys registered with the Gitpod account are cached for
+const DefaultGitpodKeysTTL = 1 * time.Minute
+
+// GitpodKeys are the SSH public keys registered with the Gitpod account of the workspace owner
+type GitpodKeys struct {
+	API gitpod.APIInterface
+	// TTL is the time the keys are cached for, DefaultGitpodKeysTTL if zero
+	TTL time.Duration
+
+	mu      sync.Mutex
+	keys    []ssh.PublicKey
+	fetched time.Time
+}
+
+// AuthorizedKeys returns the keys registered with the Gitpod account. If the keys cannot be fetched,
+// the keys fetched last are returned along with the error.
+func (g *GitpodKeys) AuthorizedKeys(ctx context.Context) ([]ssh.PublicKey, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ttl := g.TTL
+	if ttl == 0 {
+		ttl = DefaultGitpodKeysTTL
+	}
+	if !g.fetched.IsZero() && time.Since(g.fetched) < ttl {
+		return g.keys, nil
+	}
+
+	lines, err := g.API.GetSSHPublicKeys(ctx)
+	if err != nil {
+		return g.keys, xerrors.Errorf("cannot fetch SSH keys of the Gitpod account: %w", err)
+	}
+	var keys []ssh.PublicKey
+	for _, line := range lines {
+		keys = append(keys, parseAuthorizedKeys([]byte(line))...)
+	}
+	g.keys, g.fetched = keys, time.Now()
+	return keys, nil
+}
+
+// AuthorizedKeysFile are the keys in an authorized_keys file, e.g. ~/.ssh/authorized_keys.
+// A file which does not exist authorizes no keys.
+type AuthorizedKeysFile string
+
+// AuthorizedKeys reads the keys from the file
+func (fn AuthorizedKeysFile) AuthorizedKeys(ctx context.Context) ([]ssh.PublicKey, error) {
+	content, err := ioutil.ReadFile(string(fn))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseAuthorizedKeys(content), nil
+}
+
+// KeySources authorizes the keys of all its sources. Sources which fail are skipped.
+type KeySources []KeySource
+
+// AuthorizedKeys collects the keys of all sources
+func (srcs KeySources) AuthorizedKeys(ctx context.Context) ([]ssh.PublicKey, error) {
+	var res []ssh.PublicKey
+	for _, src := range srcs {
+		keys, err := src.AuthorizedKeys(ctx)
+		if err != nil {
+			log.WithError(err).Warn("cannot read authorized SSH keys")
+		}
+		res = append(res, keys...)
+	}
+	return res, nil
+}
+
+// parseAuthorizedKeys parses keys in the authorized_keys format, skipping lines which are no keys
+func parseAuthorizedKeys(content []byte) []ssh.PublicKey {
+	var res []ssh.PublicKey
+	for len(bytes.TrimSpace(content)) > 0 {
+		key, _, _, rest, err := ssh.ParseAuthorizedKey(content)
+		if err != nil {
+			// ParseAuthorizedKey skips invalid lines until it finds a key, hence there's no key left
+			break
+		}
+		res = append(res, key)
+		content = rest
+	}
+	return res
+}
+
+// LoadOrGenerateHostKey loads the host key from a file. If the file does not exist, a new ed25519 key is
+// generated and stored in it, s.t. the host key survives workspace restarts.
+func LoadOrGenerateHostKey(fn string) (ssh.Signer, error) {
+	content, err := ioutil.ReadFile(fn)
+	if err == nil {
+		return ssh.ParsePrivateKey(content)
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot generate host key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot marshal host key: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(fn), 0700)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot store host key: %w", err)
+	}
+	err = ioutil.WriteFile(fn, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot store host key: %w", err)
+	}
+	return ssh.NewSignerFromKey(key)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/mock/gomock"
+	"golang.org/x/crypto/ssh"
+)
+
+func newPublicKey(t *testing.T) ssh.PublicKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestParseAuthorizedKeys(t *testing.T) {
+	first, second := newPublicKey(t), newPublicKey(t)
+	tests := []struct {
+		Desc        string
+		Content     string
+		Expectation []ssh.PublicKey
+	}{
+		{Desc: "empty"},
+		{Desc: "only comments", Content: "# no keys\n\n"},
+		{
+			Desc:        "keys with comments and options",
+			Content:     "# keys\n" + string(ssh.MarshalAuthorizedKey(first)) + "\ninvalid line\nno-pty " + string(ssh.MarshalAuthorizedKey(second)),
+			Expectation: []ssh.PublicKey{first, second},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := parseAuthorizedKeys([]byte(test.Content))
+			if len(act) != len(test.Expectation) {
+				t.Fatalf("unexpected number of keys: %d", len(act))
+			}
+			for i := range act {
+				if !bytes.Equal(act[i].Marshal(), test.Expectation[i].Marshal()) {
+					t.Errorf("unexpected key %d: %s", i, ssh.FingerprintSHA256(act[i]))
+				}
+			}
+		})
+	}
+}
+
+func TestGitpodKeys(t *testing.T) {
+	key := newPublicKey(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	api := gitpod.NewMockAPIInterface(ctrl)
+	gomock.InOrder(
+		api.EXPECT().GetSSHPublicKeys(gomock.Any()).Return([]string{string(ssh.MarshalAuthorizedKey(key))}, nil),
+		api.EXPECT().GetSSHPublicKeys(gomock.Any()).Return(nil, errors.New("unavailable")),
+	)
+
+	keys := &GitpodKeys{API: api}
+	for i := 0; i < 2; i++ {
+		act, err := keys.AuthorizedKeys(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(act) != 1 {
+			t.Fatalf("unexpected keys: %v", act)
+		}
+	}
+
+	// the cache expired, but the server is unavailable
+	keys.TTL = -1
+	act, err := keys.AuthorizedKeys(context.Background())
+	if err == nil {
+		t.Error("expected an error if the keys cannot be fetched")
+	}
+	if len(act) != 1 {
+		t.Errorf("expected the keys fetched last: %v", act)
+	}
+}
+
+func TestLoadOrGenerateHostKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sshd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fn := filepath.Join(dir, "ssh", "ssh_host_ed25519_key")
+	generated, err := LoadOrGenerateHostKey(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stat, err := os.Stat(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Mode().Perm() != 0600 {
+		t.Errorf("unexpected host key permissions: %v", stat.Mode())
+	}
+
+	loaded, err := LoadOrGenerateHostKey(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(generated.PublicKey().Marshal(), loaded.PublicKey().Marshal()) {
+		t.Error("expected the stored host key to be loaded")
+	}
+}
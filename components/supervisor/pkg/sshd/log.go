@@ -0,0 +1,10 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import "github.com/gitpod-io/gitpod/supervisor/pkg/logging"
+
+// log is the logger of the ssh subsystem, whose level can be changed at runtime
+var log = logging.Subsystem("ssh")
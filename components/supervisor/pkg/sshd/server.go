@@ -0,0 +1,348 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/creack/pty"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+)
+
+// Server is an SSH server which serves shells, commands and local port forwardings in the workspace.
+// Clients authenticate with the public keys of the key source, regardless of the user they log in as.
+type Server struct {
+	HostKey ssh.Signer
+	Keys    KeySource
+
+	// Shell is the login shell which serves interactive sessions. Commands are run with its first element and -c.
+	Shell   []string
+	Workdir string
+	// Env provides additional environment variables for sessions if set
+	Env func() []string
+	// OnInput is called whenever a client sends input to a session or through a port forwarding, if set
+	OnInput func()
+	// OnConnect is called once a client authenticated with the name it logged in as and the fingerprint of its key,
+	// if set. The returned function is called once the client disconnected.
+	OnConnect func(user, fingerprint string) (disconnect func())
+}
+
+// Serve accepts SSH connections on the listener until the context is canceled
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+	for {
+		conn, err := l.Accept()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		go s.handleConn(ctx, conn)
+	}
+}
+
+func (s *Server) config(ctx context.Context) *ssh.ServerConfig {
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			keys, err := s.Keys.AuthorizedKeys(ctx)
+			if err != nil {
+				log.WithError(err).Warn("cannot read authorized SSH keys")
+			}
+			marshalled := key.Marshal()
+			for _, k := range keys {
+				if bytes.Equal(k.Marshal(), marshalled) {
+					return &ssh.Permissions{Extensions: map[string]string{"fingerprint": ssh.FingerprintSHA256(key)}}, nil
+				}
+			}
+			return nil, xerrors.Errorf("unknown public key for %s", meta.User())
+		},
+	}
+	cfg.AddHostKey(s.HostKey)
+	return cfg
+}
+
+func (s *Server) handleConn(ctx context.Context, nc net.Conn) {
+	conn, chans, reqs, err := ssh.NewServerConn(nc, s.config(ctx))
+	if err != nil {
+		log.WithError(err).WithField("remote", nc.RemoteAddr().String()).Debug("SSH handshake failed")
+		nc.Close()
+		return
+	}
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	defer conn.Close()
+
+	log := log.WithField("remote", conn.RemoteAddr().String()).WithField("key", conn.Permissions.Extensions["fingerprint"])
+	log.Info("SSH client connected")
+	defer log.Info("SSH client disconnected")
+	if s.OnConnect != nil {
+		disconnect := s.OnConnect(conn.User(), conn.Permissions.Extensions["fingerprint"])
+		defer disconnect()
+	}
+
+	// keepalives and remote port forwardings are declined
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		switch newChan.ChannelType() {
+		case "session":
+			go s.handleSession(newChan)
+		case "direct-tcpip":
+			go handleDirectTCPIP(newChan, s.OnInput)
+		default:
+			newChan.Reject(ssh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
+
+// session is an SSH session, which runs a single shell or command
+type session struct {
+	ch  ssh.Channel
+	env []string
+
+	mu      sync.Mutex
+	term    string
+	size    *pty.Winsize
+	ptmx    *os.File
+	started bool
+}
+
+func (s *Server) handleSession(newChan ssh.NewChannel) {
+	ch, reqs, err := newChan.Accept()
+	if err != nil {
+		log.WithError(err).Debug("cannot accept SSH session")
+		return
+	}
+	sess := &session{ch: ch}
+	for req := range reqs {
+		ok := true
+		switch req.Type {
+		case "env":
+			var kv struct{ Name, Value string }
+			if err := ssh.Unmarshal(req.Payload, &kv); err != nil {
+				ok = false
+				break
+			}
+			sess.env = append(sess.env, kv.Name+"="+kv.Value)
+		case "pty-req":
+			var p struct {
+				Term                      string
+				Cols, Rows, Width, Height uint32
+				Modes                     string
+			}
+			if err := ssh.Unmarshal(req.Payload, &p); err != nil {
+				ok = false
+				break
+			}
+			sess.mu.Lock()
+			sess.term = p.Term
+			sess.size = winsize(p.Cols, p.Rows, p.Width, p.Height)
+			sess.mu.Unlock()
+		case "window-change":
+			var p struct{ Cols, Rows, Width, Height uint32 }
+			if err := ssh.Unmarshal(req.Payload, &p); err != nil {
+				ok = false
+				break
+			}
+			sess.resize(winsize(p.Cols, p.Rows, p.Width, p.Height))
+		case "shell", "exec":
+			var command string
+			if req.Type == "exec" {
+				var p struct{ Command string }
+				if err := ssh.Unmarshal(req.Payload, &p); err != nil {
+					ok = false
+					break
+				}
+				command = p.Command
+			}
+			err := s.run(sess, command)
+			if err != nil {
+				log.WithError(err).Warn("cannot start SSH session")
+				ok = false
+			}
+		default:
+			ok = false
+		}
+		if req.WantReply {
+			req.Reply(ok, nil)
+		}
+	}
+}
+
+// run runs the login shell, or a command if not empty, in the session. The channel is closed once the process exits.
+func (s *Server) run(sess *session, command string) error {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.started {
+		return xerrors.Errorf("session runs a process already")
+	}
+
+	var cmd *exec.Cmd
+	if command == "" {
+		cmd = exec.Command(s.Shell[0], s.Shell[1:]...)
+	} else {
+		cmd = exec.Command(s.Shell[0], "-c", command)
+	}
+	cmd.Dir = s.Workdir
+	cmd.Env = os.Environ()
+	if s.Env != nil {
+		cmd.Env = append(cmd.Env, s.Env()...)
+	}
+	cmd.Env = append(cmd.Env, sess.env...)
+
+	var output func()
+	if sess.size != nil {
+		cmd.Env = append(cmd.Env, "TERM="+sess.term)
+		ptmx, err := pty.StartWithSize(cmd, sess.size)
+		if err != nil {
+			return err
+		}
+		sess.ptmx = ptmx
+		go io.Copy(ptmx, &inputReader{sess.ch, s.OnInput})
+		done := make(chan struct{})
+		go func() {
+			io.Copy(sess.ch, ptmx)
+			close(done)
+		}()
+		output = func() {
+			// background processes may keep the pseudo-terminal open after the process exited
+			select {
+			case <-done:
+			case <-time.After(1 * time.Second):
+			}
+			ptmx.Close()
+		}
+	} else {
+		cmd.Stdout = sess.ch
+		cmd.Stderr = sess.ch.Stderr()
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return err
+		}
+		err = cmd.Start()
+		if err != nil {
+			return err
+		}
+		go func() {
+			io.Copy(stdin, &inputReader{sess.ch, s.OnInput})
+			stdin.Close()
+		}()
+		output = func() {}
+	}
+	sess.started = true
+
+	go func() {
+		err := cmd.Wait()
+		output()
+
+		exitCode := 0
+		if err != nil {
+			exitErr, ok := err.(*exec.ExitError)
+			if !ok {
+				log.WithError(err).Debug("cannot wait for SSH session process")
+				sess.ch.Close()
+				return
+			}
+			exitCode = exitErr.ExitCode()
+			if exitCode < 0 {
+				// killed by a signal, which OpenSSH reports like this too
+				exitCode = 255
+			}
+		}
+		sess.ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{uint32(exitCode)}))
+		sess.ch.Close()
+	}()
+	return nil
+}
+
+func (sess *session) resize(size *pty.Winsize) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	sess.size = size
+	if sess.ptmx == nil {
+		return
+	}
+	err := pty.Setsize(sess.ptmx, size)
+	if err != nil {
+		log.WithError(err).Debug("cannot resize SSH session")
+	}
+}
+
+func winsize(cols, rows, width, height uint32) *pty.Winsize {
+	return &pty.Winsize{Cols: uint16(cols), Rows: uint16(rows), X: uint16(width), Y: uint16(height)}
+}
+
+// inputReader calls onInput whenever data was read
+type inputReader struct {
+	r       io.Reader
+	onInput func()
+}
+
+func (r *inputReader) Read(p []byte) (n int, err error) {
+	n, err = r.r.Read(p)
+	if n > 0 && r.onInput != nil {
+		r.onInput()
+	}
+	return
+}
+
+// handleDirectTCPIP relays a local port forwarding, e.g. ssh -L, to its destination
+func handleDirectTCPIP(newChan ssh.NewChannel, onInput func()) {
+	var p struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	err := ssh.Unmarshal(newChan.ExtraData(), &p)
+	if err != nil {
+		newChan.Reject(ssh.ConnectionFailed, "invalid forwarding request")
+		return
+	}
+	conn, err := net.Dial("tcp", net.JoinHostPort(p.Host, strconv.FormatUint(uint64(p.Port), 10)))
+	if err != nil {
+		newChan.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	ch, reqs, err := newChan.Accept()
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		io.Copy(ch, conn)
+		ch.CloseWrite()
+	}()
+	go func() {
+		defer wg.Done()
+		io.Copy(conn, &inputReader{ch, onInput})
+		if tcp, ok := conn.(*net.TCPConn); ok {
+			tcp.CloseWrite()
+		}
+	}()
+	wg.Wait()
+	ch.Close()
+	conn.Close()
+}
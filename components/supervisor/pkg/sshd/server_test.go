@@ -0,0 +1,148 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+type staticKeys []ssh.PublicKey
+
+func (k staticKeys) AuthorizedKeys(ctx context.Context) ([]ssh.PublicKey, error) {
+	return k, nil
+}
+
+func newSigner(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestServer(t *testing.T) {
+	hostKey, clientKey := newSigner(t), newSigner(t)
+	connected := make(chan string, 1)
+	srv := &Server{
+		HostKey: hostKey,
+		Keys:    staticKeys{clientKey.PublicKey()},
+		Shell:   []string{"/bin/sh"},
+		Workdir: os.TempDir(),
+		Env:     func() []string { return []string{"GREETING=hello"} },
+		OnConnect: func(user, fingerprint string) func() {
+			connected <- user + " " + fingerprint
+			return func() {}
+		},
+	}
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.Serve(ctx, l)
+
+	dial := func(key ssh.Signer) (*ssh.Client, error) {
+		return ssh.Dial("tcp", l.Addr().String(), &ssh.ClientConfig{
+			User:            "gitpod",
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(key)},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		})
+	}
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := dial(newSigner(t))
+		if err == nil {
+			t.Error("expected an unknown key to be rejected")
+		}
+	})
+
+	client, err := dial(clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	t.Run("connect", func(t *testing.T) {
+		select {
+		case c := <-connected:
+			if exp := "gitpod " + ssh.FingerprintSHA256(clientKey.PublicKey()); c != exp {
+				t.Errorf("unexpected connection: %q, expected %q", c, exp)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("OnConnect was not called")
+		}
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		sess, err := client.NewSession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sess.Close()
+		out, err := sess.Output("echo $GREETING; pwd")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := "hello\n" + os.TempDir() + "\n"; string(out) != exp {
+			t.Errorf("unexpected output: %q", out)
+		}
+	})
+
+	t.Run("exit status", func(t *testing.T) {
+		sess, err := client.NewSession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sess.Close()
+		err = sess.Run("exit 3")
+		if exitErr, ok := err.(*ssh.ExitError); !ok || exitErr.ExitStatus() != 3 {
+			t.Errorf("unexpected exit: %v", err)
+		}
+	})
+
+	t.Run("local port forwarding", func(t *testing.T) {
+		target, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer target.Close()
+		go func() {
+			conn, err := target.Accept()
+			if err != nil {
+				return
+			}
+			conn.Write([]byte("forwarded"))
+			conn.Close()
+		}()
+
+		conn, err := client.Dial("tcp", target.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		out, err := ioutil.ReadAll(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, []byte("forwarded")) {
+			t.Errorf("unexpected output: %q", out)
+		}
+	})
+}
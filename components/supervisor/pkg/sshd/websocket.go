@@ -0,0 +1,109 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebsocketHandler serves SSH connections tunneled through websockets, s.t. clients outside the workspace reach
+// the server through the workspace proxy, e.g. with a ProxyCommand. The workspace proxy only admits clients which
+// present the owner cookie, SSH then authenticates them as on any other connection. Cross-origin requests are rejected,
+// SSH tools send no origin. Connections are closed once ctx is done.
+func (s *Server) WebsocketHandler(ctx context.Context) http.Handler {
+	var upgrader websocket.Upgrader
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.WithError(err).Debug("cannot upgrade SSH tunnel connection")
+			return
+		}
+		s.handleConn(ctx, &websocketConn{ws: ws})
+	})
+}
+
+// websocketConn is a net.Conn of the binary messages of a websocket
+type websocketConn struct {
+	ws *websocket.Conn
+
+	reader  io.Reader
+	readMu  sync.Mutex
+	writeMu sync.Mutex
+}
+
+func (c *websocketConn) Read(p []byte) (n int, err error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	for {
+		if c.reader == nil {
+			var typ int
+			typ, c.reader, err = c.ws.NextReader()
+			if err != nil {
+				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					return 0, io.EOF
+				}
+				return 0, err
+			}
+			if typ != websocket.BinaryMessage {
+				c.reader = nil
+				continue
+			}
+		}
+		n, err = c.reader.Read(p)
+		if err == io.EOF {
+			c.reader = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (c *websocketConn) Write(p []byte) (n int, err error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	err = c.ws.WriteMessage(websocket.BinaryMessage, p)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (c *websocketConn) Close() error {
+	return c.ws.Close()
+}
+
+func (c *websocketConn) LocalAddr() net.Addr {
+	return c.ws.LocalAddr()
+}
+
+func (c *websocketConn) RemoteAddr() net.Addr {
+	return c.ws.RemoteAddr()
+}
+
+func (c *websocketConn) SetDeadline(t time.Time) error {
+	err := c.ws.SetReadDeadline(t)
+	if err != nil {
+		return err
+	}
+	return c.ws.SetWriteDeadline(t)
+}
+
+func (c *websocketConn) SetReadDeadline(t time.Time) error {
+	return c.ws.SetReadDeadline(t)
+}
+
+func (c *websocketConn) SetWriteDeadline(t time.Time) error {
+	return c.ws.SetWriteDeadline(t)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package sshd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestWebsocketHandler(t *testing.T) {
+	hostKey, clientKey := newSigner(t), newSigner(t)
+	srv := &Server{
+		HostKey: hostKey,
+		Keys:    staticKeys{clientKey.PublicKey()},
+		Shell:   []string{"/bin/sh"},
+		Workdir: os.TempDir(),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	httpSrv := httptest.NewServer(srv.WebsocketHandler(ctx))
+	defer httpSrv.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpSrv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nc := &websocketConn{ws: ws}
+	conn, chans, reqs, err := ssh.NewClientConn(nc, "workspace", &ssh.ClientConfig{
+		User:            "gitpod",
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(clientKey)},
+		HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := ssh.NewClient(conn, chans, reqs)
+	defer client.Close()
+
+	sess, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sess.Close()
+	out, err := sess.Output("echo tunneled")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "tunneled\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestWebsocketHandlerRejectsCrossOrigin(t *testing.T) {
+	srv := &Server{HostKey: newSigner(t)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	httpSrv := httptest.NewServer(srv.WebsocketHandler(ctx))
+	defer httpSrv.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpSrv.URL, "http"), http.Header{"Origin": {"https://example.com"}})
+	if err == nil {
+		t.Fatal("expected the cross-origin handshake to fail")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("unexpected response: %v", resp)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/activity"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ActivityService implements the api.ActivityService
+type ActivityService struct {
+	Tracker *activity.Tracker
+}
+
+// RegisterGRPC registers the gRPC activity service
+func (s *ActivityService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterActivityServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST activity service
+func (s *ActivityService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterActivityServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// RecordActivity records activity of a source
+func (s *ActivityService) RecordActivity(ctx context.Context, req *api.RecordActivityRequest) (*api.RecordActivityResponse, error) {
+	if req.Source == "" {
+		return nil, status.Error(codes.InvalidArgument, "source is required")
+	}
+	s.Tracker.Record(activity.Source(req.Source))
+	return &api.RecordActivityResponse{}, nil
+}
+
+// ActivityStatus returns when each source was last active
+func (s *ActivityService) ActivityStatus(ctx context.Context, req *api.ActivityStatusRequest) (*api.ActivityStatusResponse, error) {
+	res := &api.ActivityStatusResponse{}
+	policy := s.Tracker.Policy()
+	for src, ts := range s.Tracker.Last() {
+		last, err := ptypes.TimestampProto(ts)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		res.Sources = append(res.Sources, &api.ActivityStatusResponse_Source{
+			Name:         string(src),
+			LastActivity: last,
+			Ignored:      policy.Ignored(src),
+		})
+	}
+	sort.Slice(res.Sources, func(i, j int) bool { return res.Sources[i].Name < res.Sources[j].Name })
+
+	if hb := s.Tracker.LastHeartbeat(); !hb.IsZero() {
+		last, err := ptypes.TimestampProto(hb)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		res.LastHeartbeat = last
+	}
+	return res, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Capabilities are the optional features of supervisor, which clients can detect with the APIVersion method
+const (
+	// capabilitySSH means supervisor serves SSH
+	capabilitySSH = "ssh"
+	// capabilityPublicPortApproval means ports wait for the user's approval before they become public
+	capabilityPublicPortApproval = "public-port-approval"
+	// capabilityPortsSimulation means the SimulatePort control method is enabled
+	capabilityPortsSimulation = "ports-simulation"
+	// capabilityNamespacePorts means ports served in other network namespaces are reported
+	capabilityNamespacePorts = "namespace-ports"
+	// capabilityJetBrainsGateway means supervisor starts a JetBrains backend for Gateway on demand
+	capabilityJetBrainsGateway = "jetbrains-gateway"
+)
+
+// capabilities returns the optional features enabled by the config, sorted by name
+func capabilities(cfg *Config, ssh bool) []string {
+	res := []string{}
+	if ssh {
+		res = append(res, capabilitySSH)
+	}
+	if cfg.RequirePublicPortApproval {
+		res = append(res, capabilityPublicPortApproval)
+	}
+	if cfg.AllowPortsSimulation {
+		res = append(res, capabilityPortsSimulation)
+	}
+	if cfg.ReportNamespacePorts {
+		res = append(res, capabilityNamespacePorts)
+	}
+	if cfg.JetBrainsBackendURL != "" {
+		res = append(res, capabilityJetBrainsGateway)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// grpcMethods returns the full names of the methods a gRPC server serves, sorted by name
+func grpcMethods(srv *grpc.Server) []string {
+	if srv == nil {
+		return nil
+	}
+	var res []string
+	for service, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			res = append(res, "/"+service+"/"+m.Name)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// apiVersionHeader is sent with every response, s.t. clients learn the API version without calling APIVersion
+var apiVersionHeader = metadata.Pairs(api.VersionHeader, strconv.Itoa(api.Version))
+
+func apiVersionUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	_ = grpc.SetHeader(ctx, apiVersionHeader)
+	return handler(ctx, req)
+}
+
+func apiVersionStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	_ = ss.SetHeader(apiVersionHeader)
+	return handler(srv, ss)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAPIVersion(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(apiVersionUnaryInterceptor),
+		grpc.ChainStreamInterceptor(apiVersionStreamInterceptor),
+	)
+	cfg := &Config{}
+	cfg.RequirePublicPortApproval = true
+	info := &InfoService{cfg: cfg}
+	info.RegisterGRPC(srv)
+	(&ActivityService{}).RegisterGRPC(srv)
+	go srv.Serve(l)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var header metadata.MD
+	resp, err := api.NewInfoServiceClient(conn).APIVersion(context.Background(), &api.APIVersionRequest{ClientVersion: api.Version, Client: "test"}, grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Compatible || resp.Version != api.Version {
+		t.Errorf("unexpected version: %v", resp)
+	}
+	if !resp.Supports("/supervisor.InfoService/APIVersion") || !resp.Supports("/supervisor.ActivityService/RecordActivity") {
+		t.Errorf("missing methods: %v", resp.Methods)
+	}
+	if resp.Supports("/supervisor.PortService/Expose") {
+		t.Errorf("unexpected method of an unregistered service: %v", resp.Methods)
+	}
+	if diff := cmp.Diff([]string{capabilityPublicPortApproval}, resp.Capabilities); diff != "" {
+		t.Errorf("unexpected capabilities (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{strconv.Itoa(api.Version)}, header.Get(api.VersionHeader)); diff != "" {
+		t.Errorf("unexpected version header (-want +got):\n%s", diff)
+	}
+}
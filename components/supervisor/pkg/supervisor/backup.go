@@ -0,0 +1,39 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"time"
+
+	daemon "github.com/gitpod-io/gitpod/ws-daemon/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// backupConnectTimeout limits waiting for the socket of ws-daemon, which exists only in workspaces
+	// with full workspace backup or user namespaces
+	backupConnectTimeout = 2 * time.Second
+	// backupTimeout limits how long a backup on request may take
+	backupTimeout = 5 * time.Minute
+)
+
+// backupWorkspace asks ws-daemon to back up the workspace content and waits for the backup to complete
+func backupWorkspace(ctx context.Context) error {
+	connectCtx, cancel := context.WithTimeout(ctx, backupConnectTimeout)
+	client, conn, err := ConnectToInWorkspaceDaemonService(connectCtx)
+	cancel()
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "backups on request are not available in this workspace: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel = context.WithTimeout(ctx, backupTimeout)
+	defer cancel()
+	// ws-daemon's status, e.g. FailedPrecondition or ResourceExhausted, tells the client what went wrong
+	_, err = client.Backup(ctx, &daemon.BackupRequest{})
+	return err
+}
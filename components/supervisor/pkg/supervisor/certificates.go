@@ -0,0 +1,372 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// certificatesLocation is where the CA and the certificates of the workspace are kept, s.t. they survive restarts
+	certificatesLocation = "/workspace/.gitpod/certificates"
+	// caTrustStore is the folder of the system trust store update-ca-certificates picks CA certificates up from
+	caTrustStore = "/usr/local/share/ca-certificates"
+
+	// caValidity is how long the CA of the workspace is valid
+	caValidity = 10 * 365 * 24 * time.Hour
+	// certificateValidity is how long certificates are valid. Browsers reject longer lived certificates.
+	certificateValidity = 397 * 24 * time.Hour
+	// certificateRenewal is how long before they expire certificates are issued anew
+	certificateRenewal = 30 * 24 * time.Hour
+)
+
+// CertificateService implements the api.CertificateService
+type CertificateService struct {
+	// Dir is where the CA and the certificates are written to
+	Dir string
+	// TrustStore is the folder the CA certificate is installed into. If empty, the CA is not installed.
+	TrustStore string
+	// PortHosts returns the host names a port is reachable with from outside the workspace
+	PortHosts func(port uint32) []string
+
+	mu      sync.Mutex
+	ca      *x509.Certificate
+	caKey   *ecdsa.PrivateKey
+	trusted bool
+}
+
+// RegisterGRPC registers the gRPC certificate service
+func (s *CertificateService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterCertificateServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST certificate service
+func (s *CertificateService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterCertificateServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// CA returns the CA certificate of the workspace
+func (s *CertificateService) CA(ctx context.Context, req *api.CARequest) (*api.CAResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.loadCA()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.CAResponse{
+		CaFile:  s.caFile(),
+		CaPem:   string(encodeCertificate(s.ca)),
+		Trusted: s.trusted,
+	}, nil
+}
+
+// Certificate issues a certificate for a port
+func (s *CertificateService) Certificate(ctx context.Context, req *api.CertificateRequest) (*api.CertificateResponse, error) {
+	if req.Port == 0 || req.Port > 65535 {
+		return nil, status.Errorf(codes.InvalidArgument, "port %d is out of range", req.Port)
+	}
+
+	hosts := []string{"localhost", "127.0.0.1"}
+	if s.PortHosts != nil {
+		hosts = append(hosts, s.PortHosts(req.Port)...)
+	}
+	hosts = append(hosts, req.Hosts...)
+	hosts = uniqueStrings(hosts)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.loadCA()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var (
+		name     = strconv.Itoa(int(req.Port))
+		certFile = filepath.Join(s.Dir, name+".crt")
+		keyFile  = filepath.Join(s.Dir, name+".key")
+	)
+	cert, err := readCertificate(certFile)
+	if err != nil || !s.reusable(cert, hosts, time.Now()) {
+		cert, err = s.issue(name, hosts, certFile, keyFile, time.Now())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		log.WithField("port", req.Port).WithField("hosts", hosts).Info("issued development certificate")
+	}
+
+	notAfter, _ := ptypes.TimestampProto(cert.NotAfter)
+	return &api.CertificateResponse{
+		CertFile: certFile,
+		KeyFile:  keyFile,
+		CaFile:   s.caFile(),
+		Hosts:    certificateHosts(cert),
+		NotAfter: notAfter,
+	}, nil
+}
+
+func (s *CertificateService) caFile() string {
+	return filepath.Join(s.Dir, "ca.crt")
+}
+
+// loadCA reads the CA of the workspace, or creates it on first use
+func (s *CertificateService) loadCA() error {
+	if s.ca != nil {
+		return nil
+	}
+
+	keyFile := filepath.Join(s.Dir, "ca.key")
+	ca, caErr := readCertificate(s.caFile())
+	key, keyErr := readPrivateKey(keyFile)
+	if caErr != nil || keyErr != nil || time.Now().After(ca.NotAfter) {
+		err := os.MkdirAll(s.Dir, 0700)
+		if err != nil {
+			return xerrors.Errorf("cannot create certificates folder: %w", err)
+		}
+		ca, key, err = createCA(time.Now())
+		if err != nil {
+			return err
+		}
+		err = writePrivateKey(keyFile, key)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(s.caFile(), encodeCertificate(ca), 0644)
+		if err != nil {
+			return xerrors.Errorf("cannot write CA certificate: %w", err)
+		}
+		log.WithField("location", s.caFile()).Info("created development CA")
+	}
+	s.ca, s.caKey = ca, key
+
+	s.trusted = s.installCA()
+	// unlike the system trust store, Node.js must be told about the CA explicitly
+	if os.Getenv("NODE_EXTRA_CA_CERTS") == "" {
+		_ = os.Setenv("NODE_EXTRA_CA_CERTS", s.caFile())
+	}
+	return nil
+}
+
+// installCA installs the CA certificate into the system trust store and returns whether it is trusted
+func (s *CertificateService) installCA() bool {
+	if s.TrustStore == "" {
+		return false
+	}
+	fn := filepath.Join(s.TrustStore, "gitpod-workspace-ca.crt")
+	content := encodeCertificate(s.ca)
+	if existing, err := ioutil.ReadFile(fn); err == nil && bytes.Equal(existing, content) {
+		return true
+	}
+	err := ioutil.WriteFile(fn, content, 0644)
+	if err != nil {
+		log.WithError(err).Warn("cannot install development CA into the system trust store")
+		return false
+	}
+	out, err := exec.Command("update-ca-certificates").CombinedOutput()
+	if err != nil {
+		log.WithError(err).WithField("output", string(out)).Warn("cannot update the system trust store")
+		return false
+	}
+	return true
+}
+
+// reusable returns true if a certificate was issued by the current CA for all hosts and does not expire soon
+func (s *CertificateService) reusable(cert *x509.Certificate, hosts []string, now time.Time) bool {
+	if cert.CheckSignatureFrom(s.ca) != nil || now.Add(certificateRenewal).After(cert.NotAfter) {
+		return false
+	}
+	for _, host := range hosts {
+		if cert.VerifyHostname(host) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *CertificateService) issue(name string, hosts []string, certFile, keyFile string, now time.Time) (*x509.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot generate key: %w", err)
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Gitpod workspace"}, CommonName: "port " + name},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, s.ca, &key.PublicKey, s.caKey)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writePrivateKey(keyFile, key)
+	if err != nil {
+		return nil, err
+	}
+	// servers send the chain, s.t. clients which trust the CA only verify the certificate
+	chain := append(encodeCertificate(cert), encodeCertificate(s.ca)...)
+	err = ioutil.WriteFile(certFile, chain, 0644)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot write certificate: %w", err)
+	}
+	return cert, nil
+}
+
+func createCA(now time.Time) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("cannot generate CA key: %w", err)
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Gitpod workspace"}, CommonName: "Gitpod workspace development CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("cannot create CA certificate: %w", err)
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ca, key, nil
+}
+
+func randomSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, xerrors.Errorf("cannot generate serial number: %w", err)
+	}
+	return serial, nil
+}
+
+func encodeCertificate(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+// readCertificate reads the first certificate of a PEM file
+func readCertificate(fn string) (*x509.Certificate, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, xerrors.Errorf("%s contains no certificate", fn)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func readPrivateKey(fn string) (*ecdsa.PrivateKey, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, xerrors.Errorf("%s contains no key", fn)
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+func writePrivateKey(fn string, key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(fn, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600)
+	if err != nil {
+		return xerrors.Errorf("cannot write key: %w", err)
+	}
+	return nil
+}
+
+func certificateHosts(cert *x509.Certificate) []string {
+	hosts := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		hosts = append(hosts, ip.String())
+	}
+	return hosts
+}
+
+// portURLHosts returns the host names of a port's URLs: the URL derived from the workspace URL, e.g.
+// 3000-abc.ws.gitpod.io for https://abc.ws.gitpod.io, and the URL the port is exposed with, if any.
+func portURLHosts(workspaceURL string, port uint32, exposedURL string) []string {
+	var hosts []string
+	if u, err := url.Parse(workspaceURL); err == nil && u.Hostname() != "" {
+		hosts = append(hosts, fmt.Sprintf("%d-%s", port, u.Hostname()))
+	}
+	if u, err := url.Parse(exposedURL); err == nil && u.Hostname() != "" {
+		hosts = append(hosts, u.Hostname())
+	}
+	return uniqueStrings(hosts)
+}
+
+func uniqueStrings(s []string) []string {
+	seen := make(map[string]struct{}, len(s))
+	res := make([]string, 0, len(s))
+	for _, e := range s {
+		if _, ok := seen[e]; ok || e == "" {
+			continue
+		}
+		seen[e] = struct{}{}
+		res = append(res, e)
+	}
+	return res
+}
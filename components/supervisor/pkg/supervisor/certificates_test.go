@@ -0,0 +1,145 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCertificate(t *testing.T) {
+	tests := []struct {
+		Desc  string
+		Req   *api.CertificateRequest
+		Hosts []string
+		Code  codes.Code
+	}{
+		{
+			Desc:  "port URL",
+			Req:   &api.CertificateRequest{Port: 3000},
+			Hosts: []string{"localhost", "3000-abc.ws.gitpod.io", "127.0.0.1"},
+		},
+		{
+			Desc:  "additional hosts",
+			Req:   &api.CertificateRequest{Port: 8080, Hosts: []string{"app.local", "localhost", "10.0.0.1"}},
+			Hosts: []string{"localhost", "8080-abc.ws.gitpod.io", "app.local", "127.0.0.1", "10.0.0.1"},
+		},
+		{
+			Desc: "port out of range",
+			Req:  &api.CertificateRequest{Port: 70000},
+			Code: codes.InvalidArgument,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			svc := newTestCertificateService(t)
+
+			res, err := svc.Certificate(context.Background(), test.Req)
+			if status.Code(err) != test.Code {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.Hosts, res.Hosts); diff != "" {
+				t.Errorf("unexpected hosts (-want +got):\n%s", diff)
+			}
+
+			cert, err := tls.LoadX509KeyPair(res.CertFile, res.KeyFile)
+			if err != nil {
+				t.Fatalf("cannot load certificate: %v", err)
+			}
+			if len(cert.Certificate) != 2 {
+				t.Errorf("expected the certificate and the CA, got %d certificates", len(cert.Certificate))
+			}
+			leaf, _ := x509.ParseCertificate(cert.Certificate[0])
+			roots := x509.NewCertPool()
+			roots.AddCert(svc.ca)
+			for _, host := range test.Hosts {
+				_, err = leaf.Verify(x509.VerifyOptions{DNSName: host, Roots: roots})
+				if err != nil {
+					t.Errorf("certificate is invalid for %s: %v", host, err)
+				}
+			}
+			if stat, err := os.Stat(res.KeyFile); err != nil || stat.Mode().Perm() != 0600 {
+				t.Errorf("key must be readable by the owner only")
+			}
+		})
+	}
+}
+
+func TestCertificateReuse(t *testing.T) {
+	svc := newTestCertificateService(t)
+	issue := func(req *api.CertificateRequest) *x509.Certificate {
+		res, err := svc.Certificate(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := readCertificate(res.CertFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert
+	}
+
+	first := issue(&api.CertificateRequest{Port: 3000})
+	if second := issue(&api.CertificateRequest{Port: 3000}); !first.Equal(second) {
+		t.Errorf("certificate was issued anew although it is valid")
+	}
+	if third := issue(&api.CertificateRequest{Port: 3000, Hosts: []string{"app.local"}}); first.Equal(third) {
+		t.Errorf("certificate was reused although it lacks a host")
+	}
+
+	// the CA survives restarts
+	restarted := &CertificateService{Dir: svc.Dir}
+	ca, err := restarted.CA(context.Background(), &api.CARequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ca.CaPem != string(encodeCertificate(svc.ca)) {
+		t.Errorf("CA was created anew")
+	}
+	if ca.Trusted {
+		t.Errorf("CA must not be trusted without a trust store")
+	}
+	if !restarted.reusable(first, []string{"localhost"}, time.Now()) {
+		t.Errorf("certificate is not reusable after restart")
+	}
+	if restarted.reusable(first, []string{"localhost"}, time.Now().Add(certificateValidity-certificateRenewal)) {
+		t.Errorf("certificate which expires soon is reusable")
+	}
+}
+
+func newTestCertificateService(t *testing.T) *CertificateService {
+	dir, err := ioutil.TempDir("", "certificates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	if env, ok := os.LookupEnv("NODE_EXTRA_CA_CERTS"); ok {
+		t.Cleanup(func() { os.Setenv("NODE_EXTRA_CA_CERTS", env) })
+	} else {
+		t.Cleanup(func() { os.Unsetenv("NODE_EXTRA_CA_CERTS") })
+	}
+
+	return &CertificateService{
+		Dir: filepath.Join(dir, "certs"),
+		PortHosts: func(port uint32) []string {
+			return portURLHosts("https://abc.ws.gitpod.io", port, "")
+		},
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// cgroupDir is where the workspace's cgroups are mounted
+const cgroupDir = "/sys/fs/cgroup"
+
+// isCgroupV2 returns true if the cgroups are mounted as unified hierarchy
+func isCgroupV2(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "cgroup.controllers"))
+	return err == nil
+}
+
+// readCgroupUint reads a file of a cgroup which contains a single number. The value "max" reads as 0, i.e. unlimited.
+func readCgroupUint(fn string) (uint64, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return 0, err
+	}
+	v := strings.TrimSpace(string(b))
+	if v == "max" {
+		return 0, nil
+	}
+	res, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, xerrors.Errorf("invalid value in %s: %w", fn, err)
+	}
+	return res, nil
+}
+
+// readCgroupInt reads a file of a cgroup which contains a single signed number, e.g. cpu.cfs_quota_us of cgroup v1
+func readCgroupInt(fn string) (int64, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return 0, err
+	}
+	res, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, xerrors.Errorf("invalid value in %s: %w", fn, err)
+	}
+	return res, nil
+}
+
+// readCgroupKeyValues reads a file of a cgroup which contains "key value" lines, e.g. memory.events or cpu.stat
+func readCgroupKeyValues(fn string) (map[string]uint64, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	res := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		segs := strings.Fields(scanner.Text())
+		if len(segs) != 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(segs[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		res[segs[0]] = v
+	}
+	return res, scanner.Err()
+}
+
+// readPressure reads the share of the last 10 seconds in percent in which some processes were stalled
+// from a pressure stall information file, e.g. memory.pressure or /proc/pressure/memory
+func readPressure(fn string) (float64, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		segs := strings.Fields(scanner.Text())
+		if len(segs) < 2 || segs[0] != "some" {
+			continue
+		}
+		for _, seg := range segs[1:] {
+			if !strings.HasPrefix(seg, "avg10=") {
+				continue
+			}
+			res, err := strconv.ParseFloat(strings.TrimPrefix(seg, "avg10="), 64)
+			if err != nil {
+				return 0, xerrors.Errorf("invalid pressure in %s: %w", fn, err)
+			}
+			return res, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, xerrors.Errorf("no pressure found in %s", fn)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultClipboardMaxSize limits the clipboard content to what comfortably fits a single gRPC message
+	defaultClipboardMaxSize = 1 << 20
+
+	defaultClipboardMimeType = "text/plain"
+)
+
+// ClipboardService implements the api.ClipboardService. It holds a single clipboard shared by all clients.
+type ClipboardService struct {
+	// MaxSize is the size limit of the content in bytes. Defaults to defaultClipboardMaxSize.
+	MaxSize int
+
+	mu      sync.Mutex
+	content *api.ClipboardContent
+	// subscribers are signaled whenever the content changes. Signals are coalesced, subscribers send the latest content.
+	subscribers map[chan struct{}]struct{}
+}
+
+// RegisterGRPC registers the gRPC clipboard service
+func (s *ClipboardService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterClipboardServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST clipboard service
+func (s *ClipboardService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterClipboardServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+func (s *ClipboardService) maxSize() int {
+	if s.MaxSize > 0 {
+		return s.MaxSize
+	}
+	return defaultClipboardMaxSize
+}
+
+// GetClipboard returns the content of the clipboard
+func (s *ClipboardService) GetClipboard(ctx context.Context, req *api.GetClipboardRequest) (*api.GetClipboardResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &api.GetClipboardResponse{Content: s.content, MaxSize: uint32(s.maxSize())}, nil
+}
+
+// SetClipboard replaces the content of the clipboard
+func (s *ClipboardService) SetClipboard(ctx context.Context, req *api.SetClipboardRequest) (*api.SetClipboardResponse, error) {
+	if len(req.Data) > s.maxSize() {
+		return nil, status.Errorf(codes.ResourceExhausted, "content of %d bytes exceeds the clipboard limit of %d bytes", len(req.Data), s.maxSize())
+	}
+	mimeType := req.MimeType
+	if mimeType == "" {
+		mimeType = defaultClipboardMimeType
+	}
+	updated, _ := ptypes.TimestampProto(time.Now())
+	content := &api.ClipboardContent{
+		MimeType: mimeType,
+		Data:     req.Data,
+		Source:   req.Source,
+		Updated:  updated,
+	}
+
+	s.mu.Lock()
+	s.content = content
+	for sub := range s.subscribers {
+		select {
+		case sub <- struct{}{}:
+		default:
+		}
+	}
+	s.mu.Unlock()
+
+	return &api.SetClipboardResponse{Content: content}, nil
+}
+
+// WatchClipboard streams the content of the clipboard whenever it changes
+func (s *ClipboardService) WatchClipboard(req *api.WatchClipboardRequest, srv api.ClipboardService_WatchClipboardServer) error {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	s.mu.Lock()
+	if s.subscribers == nil {
+		s.subscribers = make(map[chan struct{}]struct{})
+	}
+	s.subscribers[changes] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, changes)
+		s.mu.Unlock()
+	}()
+
+	var sent *api.ClipboardContent
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case <-changes:
+			s.mu.Lock()
+			content := s.content
+			s.mu.Unlock()
+			if content == nil || content == sent {
+				continue
+			}
+			sent = content
+			if req.ExcludeSource != "" && content.Source == req.ExcludeSource {
+				continue
+			}
+			err := srv.Send(&api.WatchClipboardResponse{Content: content})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
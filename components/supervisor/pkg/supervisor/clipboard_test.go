@@ -0,0 +1,77 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testClipboardWatcher struct {
+	grpc.ServerStream
+	ctx     context.Context
+	changes chan *api.ClipboardContent
+}
+
+func (s *testClipboardWatcher) Context() context.Context { return s.ctx }
+
+func (s *testClipboardWatcher) Send(e *api.WatchClipboardResponse) error {
+	s.changes <- e.Content
+	return nil
+}
+
+func TestClipboardService(t *testing.T) {
+	srv := &ClipboardService{MaxSize: 8}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	res, err := srv.GetClipboard(ctx, &api.GetClipboardRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Content != nil || res.MaxSize != 8 {
+		t.Errorf("unexpected initial clipboard: %v", res)
+	}
+
+	_, err = srv.SetClipboard(ctx, &api.SetClipboardRequest{Data: []byte("too large")})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted for content beyond the limit, got %v", err)
+	}
+
+	watcher := &testClipboardWatcher{ctx: ctx, changes: make(chan *api.ClipboardContent, 10)}
+	go srv.WatchClipboard(&api.WatchClipboardRequest{ExcludeSource: "ide"}, watcher)
+
+	_, err = srv.SetClipboard(ctx, &api.SetClipboardRequest{Data: []byte("from ide"), Source: "ide"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = srv.SetClipboard(ctx, &api.SetClipboardRequest{Data: []byte("from gp"), Source: "gp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case c := <-watcher.changes:
+		if string(c.Data) != "from gp" || c.Source != "gp" || c.MimeType != defaultClipboardMimeType {
+			t.Errorf("unexpected change: %v", c)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the clipboard change")
+	}
+
+	res, err = srv.GetClipboard(ctx, &api.GetClipboardRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res.Content.Data) != "from gp" {
+		t.Errorf("unexpected clipboard content: %q", res.Content.Data)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/activity"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/logging"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// staticConfigReloadDelay debounces reloads, since editors and config map updates change the file in several steps
+const staticConfigReloadDelay = 500 * time.Millisecond
+
+// reloadableStaticConfig lists the JSON names of the static config fields which are applied at runtime
+var reloadableStaticConfig = map[string]struct{}{
+	"logLevel":     {},
+	"verbosePorts": {},
+	"activity":     {},
+	"pprofPort":    {},
+}
+
+// staticConfigWatcher reloads the static config once its file changes
+type staticConfigWatcher struct {
+	Location string
+	Current  StaticConfig
+	// Apply applies a changed config. It is called only with valid configs.
+	Apply func(old, new StaticConfig)
+}
+
+// Run watches the config file until ctx is done
+func (w *staticConfigWatcher) Run(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	// config maps are updated by swapping a symlink and editors often save by replacing the file,
+	// both of which end a watch on the file itself, hence we watch the directory
+	err = watcher.Add(filepath.Dir(w.Location))
+	if err != nil {
+		return xerrors.Errorf("cannot watch %s: %w", w.Location, err)
+	}
+	log.WithField("location", w.Location).Info("watching supervisor config for changes")
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watcher.Errors:
+			log.WithError(err).Warn("error while watching supervisor config")
+		case <-watcher.Events:
+			// a config map update touches several files of the directory, none of which is the config file
+			reload = time.After(staticConfigReloadDelay)
+		case <-reload:
+			reload = nil
+			w.reload()
+		}
+	}
+}
+
+func (w *staticConfigWatcher) reload() {
+	cfg, err := readStaticConfig(w.Location)
+	if err != nil {
+		log.WithError(err).Warn("cannot reload supervisor config")
+		return
+	}
+	err = cfg.Validate()
+	if err != nil {
+		log.WithError(err).Warn("supervisor config is invalid - keeping the current one")
+		return
+	}
+	if reflect.DeepEqual(*cfg, w.Current) {
+		return
+	}
+
+	if changed := staticConfigChanges(w.Current, *cfg, false); len(changed) > 0 {
+		log.WithField("fields", changed).Warn("supervisor config changes which require a restart are ignored")
+	}
+	log.WithField("fields", staticConfigChanges(w.Current, *cfg, true)).Info("applying supervisor config changes")
+	w.Apply(w.Current, *cfg)
+	w.Current = *cfg
+}
+
+// staticConfigChanges returns the JSON names of the fields which differ between both configs,
+// either of the reloadable fields or of all others
+func staticConfigChanges(old, new StaticConfig, reloadable bool) []string {
+	var (
+		res = []string{}
+		ov  = reflect.ValueOf(old)
+		nv  = reflect.ValueOf(new)
+	)
+	for i := 0; i < ov.NumField(); i++ {
+		name := strings.Split(ov.Type().Field(i).Tag.Get("json"), ",")[0]
+		if _, ok := reloadableStaticConfig[name]; ok != reloadable {
+			continue
+		}
+		if !reflect.DeepEqual(ov.Field(i).Interface(), nv.Field(i).Interface()) {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// staticConfigApplier applies the reloadable settings of the static config to the running subsystems
+type staticConfigApplier struct {
+	Ports    *ports.Manager
+	Activity *activity.Tracker
+	Pprof    *pprofServer
+}
+
+func (a *staticConfigApplier) Apply(old, new StaticConfig) {
+	if new.LogLevel != old.LogLevel {
+		setLogLevel(new.LogLevel)
+	}
+	if new.VerbosePorts != old.VerbosePorts {
+		a.Ports.SetVerbose(new.VerbosePorts)
+	}
+	if !reflect.DeepEqual(new.Activity, old.Activity) {
+		// the config was validated already
+		policy, _ := new.ActivityPolicy()
+		a.Activity.SetPolicy(policy)
+	}
+	if new.PprofPort != old.PprofPort {
+		// the profiles are never exposed, hence the port is internal before it is served
+		if new.PprofPort != 0 {
+			a.Ports.SetInternal(context.Background(), uint32(new.PprofPort), true)
+		}
+		a.Pprof.Serve(new.PprofPort)
+		if old.PprofPort != 0 {
+			a.Ports.SetInternal(context.Background(), uint32(old.PprofPort), false)
+		}
+	}
+}
+
+// setLogLevel changes the level supervisor and the subsystems without a level of their own log with.
+// Invalid and empty levels are ignored.
+func setLogLevel(level string) {
+	if level == "" {
+		return
+	}
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		log.WithError(err).WithField("level", level).Warn("cannot set log level")
+		return
+	}
+	logging.SetDefaultLevel(lvl)
+	log.WithField("level", lvl.String()).Info("log level changed")
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStaticConfigChanges(t *testing.T) {
+	base := StaticConfig{IDEConfigLocation: "/ide", FrontendLocation: "/frontend", APIEndpointPort: 22999}
+	tests := []struct {
+		Desc          string
+		Change        func(c *StaticConfig)
+		Reloadable    []string
+		NonReloadable []string
+	}{
+		{
+			Desc:          "no change",
+			Change:        func(c *StaticConfig) {},
+			Reloadable:    []string{},
+			NonReloadable: []string{},
+		},
+		{
+			Desc: "reloadable",
+			Change: func(c *StaticConfig) {
+				c.LogLevel = "debug"
+				c.VerbosePorts = true
+				c.Activity.IgnoredSources = []string{"ports"}
+				c.PprofPort = 6060
+			},
+			Reloadable:    []string{"logLevel", "pprofPort", "verbosePorts", "activity"},
+			NonReloadable: []string{},
+		},
+		{
+			Desc: "requires restart",
+			Change: func(c *StaticConfig) {
+				c.LogLevel = "warn"
+				c.SSHPort = 23001
+			},
+			Reloadable:    []string{"logLevel"},
+			NonReloadable: []string{"sshPort"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			changed := base
+			test.Change(&changed)
+
+			if diff := cmp.Diff(test.Reloadable, staticConfigChanges(base, changed, true)); diff != "" {
+				t.Errorf("unexpected reloadable changes (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.NonReloadable, staticConfigChanges(base, changed, false)); diff != "" {
+				t.Errorf("unexpected changes requiring a restart (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStaticConfigWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "supervisor-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	loc := filepath.Join(dir, supervisorConfigFile)
+	const config = `{"ideConfigLocation": "/ide", "frontendLocation": "/frontend", "apiEndpointPort": 22999%s}`
+	write := func(extra string) {
+		// config maps replace the file rather than writing to it
+		tmp := loc + ".tmp"
+		err := ioutil.WriteFile(tmp, []byte(fmt.Sprintf(config, extra)), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Rename(tmp, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("")
+	current, err := readStaticConfig(loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied := make(chan StaticConfig, 10)
+	watcher := &staticConfigWatcher{
+		Location: loc,
+		Current:  *current,
+		Apply: func(old, new StaticConfig) {
+			applied <- new
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+	// give the watcher a chance to start watching
+	time.Sleep(100 * time.Millisecond)
+
+	write(`, "logLevel": "nonsense"`)
+	write(`, "logLevel": "debug"`)
+	select {
+	case cfg := <-applied:
+		if cfg.LogLevel != "debug" {
+			t.Errorf("unexpected log level %q", cfg.LogLevel)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("config change was not applied")
+	}
+
+	write(`, "logLevel": "nonsense"`)
+	select {
+	case cfg := <-applied:
+		t.Errorf("invalid config was applied: %v", cfg)
+	case <-time.After(2 * staticConfigReloadDelay):
+	}
+}
@@ -12,16 +12,23 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"time"
 
 	env "github.com/Netflix/go-env"
 	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/activity"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
 )
 
 const supervisorConfigFile = "supervisor-config.json"
 
+// desktopIDEConfigFile is the name of the IDE config in the GITPOD_DESKTOP_IDE_ROOT
+const desktopIDEConfigFile = "supervisor-ide-config.json"
+
 // Supervisor's configuration is dictated by three different lifecycles/sources:
 //   1. supervisor (static):
 //                  there's some configuration that lives with supervisor and its "installation",
@@ -35,6 +42,9 @@ type Config struct {
 	StaticConfig
 	IDEConfig
 	WorkspaceConfig
+
+	// DesktopIDE is the config of the desktop IDE backend which runs next to the browser IDE, nil if there is none
+	DesktopIDE *IDEConfig
 }
 
 // Validate validates the configuration
@@ -48,6 +58,14 @@ func (c Config) Validate() error {
 	if err := c.WorkspaceConfig.Validate(); err != nil {
 		return fmt.Errorf("Workspace config is invalid: %w", err)
 	}
+	if c.DesktopIDE != nil {
+		if err := c.DesktopIDE.Validate(); err != nil {
+			return fmt.Errorf("desktop IDE config is invalid: %w", err)
+		}
+		if c.DesktopIDEPort == 0 || c.DesktopIDEPort == c.IDEPort {
+			return fmt.Errorf("GITPOD_DESKTOP_IDE_PORT must be set to a port other than the one of the IDE")
+		}
+	}
 
 	return nil
 }
@@ -55,14 +73,23 @@ func (c Config) Validate() error {
 // LogRateLimit returns the log rate limit for the IDE process in kib/sec.
 // If log rate limiting is disbaled, this function returns 0.
 func (c Config) LogRateLimit() int {
-	if c.WorkspaceLogRateLimit < c.IDELogRateLimit {
+	return c.ideLogRateLimit(&c.IDEConfig)
+}
+
+// ideLogRateLimit returns the log rate limit for the process of an IDE in kib/sec
+func (c Config) ideLogRateLimit(ide *IDEConfig) int {
+	if c.WorkspaceLogRateLimit < ide.IDELogRateLimit {
 		return c.WorkspaceLogRateLimit
 	}
-	return c.IDELogRateLimit
+	return ide.IDELogRateLimit
 }
 
-// StaticConfig is the supervisor-wide configuration
+// StaticConfig is the supervisor-wide configuration. Supervisor watches the file it is loaded from and applies
+// changes of logLevel, verbosePorts, activity and pprofPort at runtime. Other changes require a restart.
 type StaticConfig struct {
+	// LogLevel is the level supervisor logs with, e.g. "info". If empty, supervisor keeps the level it starts with.
+	LogLevel string `json:"logLevel,omitempty"`
+
 	// IDEConfigLocation is a path in the filesystem where to find the IDE configuration
 	IDEConfigLocation string `json:"ideConfigLocation"`
 
@@ -71,10 +98,120 @@ type StaticConfig struct {
 
 	// APIEndpointPort is the port where to serve the API endpoint on
 	APIEndpointPort int `json:"apiEndpointPort"`
+
+	// MaxPortSubscriptions is the maximum number of clients observing the ports status at the same time.
+	// Zero means no limit.
+	MaxPortSubscriptions int `json:"maxPortSubscriptions"`
+
+	// RequirePublicPortApproval makes ports which would become public wait for the user's approval.
+	// Until approved they are exposed privately.
+	RequirePublicPortApproval bool `json:"requirePublicPortApproval"`
+
+	// PortsDryRun makes the supervisor only report which ports it would expose, without exposing them.
+	// This helps validating the port configuration of a .gitpod.yml.
+	PortsDryRun bool `json:"portsDryRun"`
+
+	// AnnouncePortsMDNS announces the served ports via multicast DNS within the workspace network,
+	// so that tools which discover services find the dev servers.
+	AnnouncePortsMDNS bool `json:"announcePortsMDNS"`
+
+	// DeniedPorts are ports and port ranges, e.g. "22" or "6000-6100", which are never exposed or proxied
+	DeniedPorts []string `json:"deniedPorts"`
+
+	// PortsPagePort is the port where to serve a page listing the ports of the workspace on, e.g. for users
+	// of ssh sessions. The page is served on localhost only and never exposed. Zero disables the page.
+	PortsPagePort int `json:"portsPagePort"`
+
+	// SSHPort is the port where to serve SSH on. Clients authenticate with the SSH keys registered with the
+	// Gitpod account of the workspace owner or listed in ~/.ssh/authorized_keys. Zero disables the SSH server.
+	SSHPort int `json:"sshPort"`
+
+	// PprofPort is the port where to serve supervisor's runtime profiles on, e.g. to profile the CPU usage of the
+	// ports observer. The profiles are served on localhost only and never exposed. Zero disables profiling.
+	// The port can be changed at runtime, s.t. profiling can be enabled without restarting supervisor.
+	PprofPort int `json:"pprofPort"`
+
+	// RemapPrivilegedPorts proxies ports below 1024 which users serve on all interfaces, e.g. 80 or 443,
+	// to high global ports, since the workspace proxy cannot route to privileged ports. Global ports configured
+	// below 1024 are bound only if the supervisor has CAP_NET_BIND_SERVICE.
+	RemapPrivilegedPorts bool `json:"remapPrivilegedPorts"`
+
+	// VerbosePorts starts the ports manager with verbose logging, which can be switched at runtime
+	// with the SetPortsVerbose control RPC
+	VerbosePorts bool `json:"verbosePorts"`
+
+	// ReportNamespacePorts reports the ports served in other network namespaces of the workspace too,
+	// e.g. in containers or in VMs with user-mode networking
+	ReportNamespacePorts bool `json:"reportNamespacePorts"`
+
+	// AutoExposeNamespaces are patterns of the labels of the network namespaces whose ports are auto-exposed, e.g. "qemu*".
+	// Ports of other namespaces are reported but only exposed if configured.
+	AutoExposeNamespaces []string `json:"autoExposeNamespaces"`
+
+	// AllowPortsSimulation enables the SimulatePort control RPC, which injects synthetic ports for testing
+	// IDE integrations. Simulated ports are never exposed on the Gitpod server.
+	AllowPortsSimulation bool `json:"allowPortsSimulation"`
+
+	// TerminalRecordings configures the recording of terminal sessions to the workspace disk in the asciicast v2 format
+	TerminalRecordings struct {
+		// Location is the directory where to store the recordings. Terminals are not recorded if empty.
+		Location string `json:"location"`
+
+		// MaxSize is the maximum size of a single recording in bytes. Larger recordings are truncated.
+		MaxSize int64 `json:"maxSize"`
+
+		// MaxTotalSize is the maximum size of all recordings in bytes. The oldest recordings are removed beyond it.
+		MaxTotalSize int64 `json:"maxTotalSize"`
+	} `json:"terminalRecordings"`
+
+	// TaskLogs configures the logging of the output of tasks to the workspace disk, s.t. it survives IDE reloads
+	// and supervisor restarts
+	TaskLogs struct {
+		// Location is the directory where to store the logs. The output of tasks is not logged if empty.
+		Location string `json:"location"`
+
+		// MaxSize is the maximum size of a single log file in bytes. Larger logs are rotated.
+		MaxSize int64 `json:"maxSize"`
+
+		// MaxFiles is the maximum number of files kept per task, including rotated ones. The oldest files are removed beyond it.
+		MaxFiles int `json:"maxFiles"`
+	} `json:"taskLogs"`
+
+	// Activity configures which user activity keeps the workspace from being stopped by the inactivity timeout
+	Activity struct {
+		// HeartbeatInterval is how often activity is evaluated and a heartbeat is sent if there was any, e.g. "30s"
+		HeartbeatInterval string `json:"heartbeatInterval"`
+
+		// IgnoredSources are the activity sources, e.g. "ports", which do not keep the workspace running
+		IgnoredSources []string `json:"ignoredSources"`
+	} `json:"activity"`
+
+	// DiskUsage configures when the user is warned about the usage of the workspace filesystem
+	DiskUsage struct {
+		// Thresholds are the usages in percent, e.g. [80, 95], at which the user is warned once each.
+		// If empty, the user is warned at 80%, 90% and 95%.
+		Thresholds []float64 `json:"thresholds"`
+
+		// InotifyThreshold is the share of the inotify watch limit in percent at which the user is warned.
+		// If zero, the user is warned at 80%.
+		InotifyThreshold float64 `json:"inotifyThreshold"`
+	} `json:"diskUsage"`
+
+	// NotifyCPUThrottling notifies the user once the workspace is slowed down, because it hits its CPU limit
+	NotifyCPUThrottling bool `json:"notifyCPUThrottling"`
+
+	// MetricsPushInterval is how often supervisor pushes its metrics if GITPOD_SUPERVISOR_METRICS_PUSH_URL is set,
+	// e.g. "1m". If empty, they are pushed every minute.
+	MetricsPushInterval string `json:"metricsPushInterval"`
 }
 
 // Validate validates this configuration
 func (c StaticConfig) Validate() error {
+	if c.LogLevel != "" {
+		if _, err := logrus.ParseLevel(c.LogLevel); err != nil {
+			return xerrors.Errorf("logLevel: %w", err)
+		}
+	}
 	if c.IDEConfigLocation == "" {
 		return fmt.Errorf("ideConfigLocation is required")
 	}
@@ -84,10 +221,109 @@ func (c StaticConfig) Validate() error {
 	if !(0 < c.APIEndpointPort && c.APIEndpointPort <= math.MaxUint16) {
 		return fmt.Errorf("apiEndpointPort must be between 0 and %d", math.MaxUint16)
 	}
+	if !(0 <= c.PortsPagePort && c.PortsPagePort <= math.MaxUint16) {
+		return fmt.Errorf("portsPagePort must be between 0 and %d", math.MaxUint16)
+	}
+	if c.PortsPagePort != 0 && c.PortsPagePort == c.APIEndpointPort {
+		return fmt.Errorf("portsPagePort must differ from apiEndpointPort")
+	}
+	if !(0 <= c.SSHPort && c.SSHPort <= math.MaxUint16) {
+		return fmt.Errorf("sshPort must be between 0 and %d", math.MaxUint16)
+	}
+	if c.SSHPort != 0 && (c.SSHPort == c.APIEndpointPort || c.SSHPort == c.PortsPagePort) {
+		return fmt.Errorf("sshPort must differ from apiEndpointPort and portsPagePort")
+	}
+	if !(0 <= c.PprofPort && c.PprofPort <= math.MaxUint16) {
+		return fmt.Errorf("pprofPort must be between 0 and %d", math.MaxUint16)
+	}
+	if c.PprofPort != 0 && (c.PprofPort == c.APIEndpointPort || c.PprofPort == c.PortsPagePort || c.PprofPort == c.SSHPort) {
+		return fmt.Errorf("pprofPort must differ from apiEndpointPort, portsPagePort and sshPort")
+	}
+	if _, err := ports.ParseDenylist(c.DeniedPorts); err != nil {
+		return xerrors.Errorf("deniedPorts: %w", err)
+	}
+	for _, pattern := range c.AutoExposeNamespaces {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return xerrors.Errorf("autoExposeNamespaces: invalid pattern %q: %w", pattern, err)
+		}
+	}
+	if rec := c.TerminalRecordings; rec.Location != "" {
+		if !filepath.IsAbs(rec.Location) {
+			return fmt.Errorf("terminalRecordings.location must be an absolute path")
+		}
+		if rec.MaxSize <= 0 {
+			return fmt.Errorf("terminalRecordings.maxSize must be > 0")
+		}
+		if rec.MaxTotalSize < rec.MaxSize {
+			return fmt.Errorf("terminalRecordings.maxTotalSize must be >= terminalRecordings.maxSize")
+		}
+	}
+	if logs := c.TaskLogs; logs.Location != "" {
+		if !filepath.IsAbs(logs.Location) {
+			return fmt.Errorf("taskLogs.location must be an absolute path")
+		}
+		if logs.MaxSize <= 0 {
+			return fmt.Errorf("taskLogs.maxSize must be > 0")
+		}
+		if logs.MaxFiles < 1 {
+			return fmt.Errorf("taskLogs.maxFiles must be >= 1")
+		}
+	}
+	if _, err := c.ActivityPolicy(); err != nil {
+		return err
+	}
+	for _, threshold := range c.DiskUsage.Thresholds {
+		if !(0 < threshold && threshold <= 100) {
+			return fmt.Errorf("diskUsage.thresholds must be between 0 and 100")
+		}
+	}
+	if !(0 <= c.DiskUsage.InotifyThreshold && c.DiskUsage.InotifyThreshold <= 100) {
+		return fmt.Errorf("diskUsage.inotifyThreshold must be between 0 and 100")
+	}
+	if _, err := c.MetricsPushPeriod(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// defaultMetricsPushInterval is how often the metrics are pushed if the static config does not say otherwise
+const defaultMetricsPushInterval = 1 * time.Minute
+
+// MetricsPushPeriod returns how often the metrics are pushed
+func (c StaticConfig) MetricsPushPeriod() (time.Duration, error) {
+	if c.MetricsPushInterval == "" {
+		return defaultMetricsPushInterval, nil
+	}
+	interval, err := time.ParseDuration(c.MetricsPushInterval)
+	if err != nil {
+		return 0, xerrors.Errorf("metricsPushInterval: %w", err)
+	}
+	if interval < 10*time.Second {
+		return 0, fmt.Errorf("metricsPushInterval must be at least 10s")
+	}
+	return interval, nil
+}
+
+// ActivityPolicy returns the policy of which activity keeps the workspace running
+func (c StaticConfig) ActivityPolicy() (activity.Policy, error) {
+	var res activity.Policy
+	if c.Activity.HeartbeatInterval != "" {
+		interval, err := time.ParseDuration(c.Activity.HeartbeatInterval)
+		if err != nil {
+			return res, xerrors.Errorf("activity.heartbeatInterval: %w", err)
+		}
+		if interval < time.Second {
+			return res, fmt.Errorf("activity.heartbeatInterval must be at least 1s")
+		}
+		res.Interval = interval
+	}
+	for _, src := range c.Activity.IgnoredSources {
+		res.Ignore = append(res.Ignore, activity.Source(src))
+	}
+	return res, nil
+}
+
 // ReadinessProbeType determines the IDE readiness probe type
 type ReadinessProbeType string
 
@@ -158,6 +394,17 @@ type WorkspaceConfig struct {
 	// is located. If there's no Git repo in this workspace, this will be empty.
 	RepoRoot string `env:"GITPOD_REPO_ROOT"`
 
+	// DesktopIDERoot is the location of a desktop IDE backend which runs next to the browser IDE. The directory contains
+	// the supervisor-ide-config.json of the backend. There is no desktop IDE if empty.
+	DesktopIDERoot string `env:"GITPOD_DESKTOP_IDE_ROOT"`
+
+	// DesktopIDEPort is the port the desktop IDE backend runs on
+	DesktopIDEPort int `env:"GITPOD_DESKTOP_IDE_PORT"`
+
+	// JetBrainsBackendURL is the location of the JetBrains backend distribution supervisor downloads once Gateway
+	// asks for the backend. Gateway is not offered if empty.
+	JetBrainsBackendURL string `env:"GITPOD_JETBRAINS_BACKEND_URL"`
+
 	// PreventMetadataAccess exits supervisor/stops the workspace if we can access Google Cloud
 	// compute metadata from within the container.
 	PreventMetadataAccess bool `env:"THEIA_PREVENT_METADATA_ACCESS"`
@@ -172,6 +419,9 @@ type WorkspaceConfig struct {
 	// GitEmail makes supervisor configure the global user.email Git setting.
 	GitEmail string `env:"GITPOD_GIT_USER_EMAIL"`
 
+	// DotfileRepo is the dotfiles repository of the user, which is installed during workspace startup
+	DotfileRepo string `env:"SUPERVISOR_DOTFILE_REPO"`
+
 	// Tokens is a JSON encoded list of WorkspaceGitpodToken
 	Tokens string `env:"THEIA_SUPERVISOR_TOKENS"`
 
@@ -181,6 +431,15 @@ type WorkspaceConfig struct {
 	// WorkspaceInstanceID is the instance ID of the workspace
 	WorkspaceInstanceID string `env:"GITPOD_INSTANCE_ID"`
 
+	// OwnerToken is the token with which the workspace proxy admits the owner of the workspace
+	OwnerToken string `env:"THEIA_SUPERVISOR_OWNER_TOKEN"`
+
+	// WorkspaceClass is the class of the workspace, which determines its resources
+	WorkspaceClass string `env:"GITPOD_WORKSPACE_CLASS"`
+
+	// WorkspaceURL is the URL the workspace is reachable with, e.g. https://abc.ws.gitpod.io
+	WorkspaceURL string `env:"GITPOD_WORKSPACE_URL"`
+
 	// GitpodHost points to the Gitpod API server we're to talk to
 	GitpodHost string `env:"GITPOD_HOST"`
 
@@ -189,6 +448,13 @@ type WorkspaceConfig struct {
 
 	// GitpodHeadless controls whether the workspace is running headless
 	GitpodHeadless *string `env:"GITPOD_HEADLESS"`
+
+	// OrganizationPortsPolicy is the JSON encoded port policy of the organization the workspace belongs to
+	OrganizationPortsPolicy *string `env:"GITPOD_ORGANIZATION_PORTS_POLICY"`
+
+	// MetricsPushURL is the Prometheus push gateway supervisor pushes its metrics to, e.g. the one of ws-manager.
+	// The metrics are not pushed if empty.
+	MetricsPushURL string `env:"GITPOD_SUPERVISOR_METRICS_PUSH_URL"`
 }
 
 // WorkspaceGitpodToken is a list of tokens that should be added to supervisor's token service
@@ -207,6 +473,65 @@ type TaskConfig struct {
 	Env      *map[string]string `json:"env,omitempty"`
 	OpenIn   *string            `json:"openIn,omitempty"`
 	OpenMode *string            `json:"openMode,omitempty"`
+	// DependsOn are conditions which must hold before the task starts
+	DependsOn *[]TaskDependency `json:"dependsOn,omitempty"`
+	// TerminationGracePeriod is how long the processes of the task may take to exit once the workspace stops, e.g. 10s
+	TerminationGracePeriod *string `json:"terminationGracePeriod,omitempty"`
+}
+
+// TaskDependency is a condition which must hold before a task starts. Exactly one of its fields is set.
+type TaskDependency struct {
+	// Task is the name of another task. It holds once the task runs, or in prebuilds once it succeeded.
+	Task *string `json:"task,omitempty"`
+	// Port holds once the port is served on localhost
+	Port *int `json:"port,omitempty"`
+	// File holds once the file exists. Relative paths are resolved against the checkout location.
+	File *string `json:"file,omitempty"`
+	// HTTP holds once the URL responds with a successful status
+	HTTP *string `json:"http,omitempty"`
+}
+
+// UnmarshalJSON accepts the name of a task as shorthand for a task dependency
+func (d *TaskDependency) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*d = TaskDependency{Task: &name}
+		return nil
+	}
+	type plain TaskDependency
+	return json.Unmarshal(b, (*plain)(d))
+}
+
+// Validate validates the dependency
+func (d TaskDependency) Validate() error {
+	var set int
+	for _, isSet := range []bool{d.Task != nil, d.Port != nil, d.File != nil, d.HTTP != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("exactly one of task, port, file or http must be set")
+	}
+	if d.Port != nil && !(0 < *d.Port && *d.Port <= math.MaxUint16) {
+		return fmt.Errorf("port must be between 0 and %d", math.MaxUint16)
+	}
+	return nil
+}
+
+func (d TaskDependency) String() string {
+	switch {
+	case d.Task != nil:
+		return "task " + *d.Task
+	case d.Port != nil:
+		return fmt.Sprintf("port %d", *d.Port)
+	case d.File != nil:
+		return "file " + *d.File
+	case d.HTTP != nil:
+		return "http " + *d.HTTP
+	default:
+		return "nothing"
+	}
 }
 
 // Validate validates this configuration
@@ -231,6 +556,16 @@ func (c WorkspaceConfig) Validate() error {
 		return err
 	}
 
+	if _, err := c.getOrganizationPortsPolicy(); err != nil {
+		return err
+	}
+
+	if c.MetricsPushURL != "" {
+		if u, err := url.Parse(c.MetricsPushURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("GITPOD_SUPERVISOR_METRICS_PUSH_URL must be an HTTP(S) URL")
+		}
+	}
+
 	return nil
 }
 
@@ -300,6 +635,18 @@ func (c WorkspaceConfig) getGitpodTasks() (tasks *[]TaskConfig, err error) {
 	return
 }
 
+// getOrganizationPortsPolicy parses the port policy of the organization
+func (c WorkspaceConfig) getOrganizationPortsPolicy() (policy *ports.OrganizationPolicy, err error) {
+	if c.OrganizationPortsPolicy == nil {
+		return
+	}
+	err = json.Unmarshal([]byte(*c.OrganizationPortsPolicy), &policy)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse GITPOD_ORGANIZATION_PORTS_POLICY: %w", err)
+	}
+	return
+}
+
 // GetConfig loads the supervisor configuration
 func GetConfig() (*Config, error) {
 	static, err := loadStaticConfigFromFile()
@@ -317,10 +664,19 @@ func GetConfig() (*Config, error) {
 		return nil, err
 	}
 
+	var desktopIDE *IDEConfig
+	if workspace.DesktopIDERoot != "" {
+		desktopIDE, err = loadIDEConfigFromFile(filepath.Join(workspace.DesktopIDERoot, desktopIDEConfigFile))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Config{
 		StaticConfig:    *static,
 		IDEConfig:       *ide,
 		WorkspaceConfig: *workspace,
+		DesktopIDE:      desktopIDE,
 	}, nil
 }
 
@@ -328,12 +684,22 @@ func GetConfig() (*Config, error) {
 // a file named "supervisor-config.json" which is expected right next to
 // the supervisor executable.
 func loadStaticConfigFromFile() (*StaticConfig, error) {
+	loc, err := staticConfigLocation()
+	if err != nil {
+		return nil, err
+	}
+	return readStaticConfig(loc)
+}
+
+func staticConfigLocation() (string, error) {
 	loc, err := os.Executable()
 	if err != nil {
-		return nil, xerrors.Errorf("cannot get executable path: %w", err)
+		return "", xerrors.Errorf("cannot get executable path: %w", err)
 	}
+	return filepath.Join(filepath.Dir(loc), supervisorConfigFile), nil
+}
 
-	loc = filepath.Join(filepath.Dir(loc), supervisorConfigFile)
+func readStaticConfig(loc string) (*StaticConfig, error) {
 	fc, err := ioutil.ReadFile(loc)
 	if err != nil {
 		return nil, xerrors.Errorf("cannot read supervisor config file %s: %w", loc, err)
@@ -0,0 +1,88 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"sync"
+
+	"github.com/gitpod-io/gitpod/content-service/pkg/progress"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/proto"
+)
+
+var contentPhases = map[progress.Phase]api.ContentPhase{
+	progress.PhaseRestoringBackup:     api.ContentPhase_content_restoring_backup,
+	progress.PhaseDownloadingPrebuild: api.ContentPhase_content_downloading_prebuild,
+	progress.PhaseCloning:             api.ContentPhase_content_cloning,
+}
+
+// contentProgress keeps the latest progress of the content initialization. Observers are notified of changes,
+// but may skip intermediate progress if they are slower than the content initialization.
+type contentProgress struct {
+	mu      sync.Mutex
+	current *api.ContentProgressResponse
+	changed chan struct{}
+}
+
+func newContentProgress() *contentProgress {
+	return &contentProgress{
+		current: &api.ContentProgressResponse{Phase: api.ContentPhase_content_pending, Percentage: -1},
+		changed: make(chan struct{}),
+	}
+}
+
+// Report is the progress.Reporter of the content initialization
+func (p *contentProgress) Report(pr progress.Progress) {
+	phase, ok := contentPhases[pr.Phase]
+	if !ok {
+		return
+	}
+	bytesDone, bytesTotal := pr.BytesDone, pr.BytesTotal
+	if bytesDone < 0 {
+		bytesDone = 0
+	}
+	if bytesTotal < 0 {
+		bytesTotal = 0
+	}
+	p.update(&api.ContentProgressResponse{
+		Phase:      phase,
+		Percentage: int32(pr.Percentage),
+		BytesDone:  uint64(bytesDone),
+		BytesTotal: uint64(bytesTotal),
+		Message:    pr.Message,
+	})
+}
+
+// finish marks the content initialization as ready, or as failed if err is not nil
+func (p *contentProgress) finish(err error) {
+	if err != nil {
+		p.update(&api.ContentProgressResponse{Phase: api.ContentPhase_content_failed, Percentage: -1, Error: err.Error()})
+		return
+	}
+	p.update(&api.ContentProgressResponse{Phase: api.ContentPhase_content_ready, Percentage: 100})
+}
+
+func (p *contentProgress) update(resp *api.ContentProgressResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if isContentDone(p.current.Phase) {
+		return
+	}
+	p.current = resp
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// get returns the latest progress and a channel which is closed once it changes
+func (p *contentProgress) get() (*api.ContentProgressResponse, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return proto.Clone(p.current).(*api.ContentProgressResponse), p.changed
+}
+
+func isContentDone(phase api.ContentPhase) bool {
+	return phase == api.ContentPhase_content_ready || phase == api.ContentPhase_content_failed
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/gitpod-io/gitpod/content-service/pkg/progress"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+)
+
+func TestContentProgress(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Reports     []progress.Progress
+		Err         error
+		Expectation []*api.ContentProgressResponse
+	}{
+		{
+			Desc: "clone",
+			Reports: []progress.Progress{
+				{Phase: progress.PhaseCloning, Percentage: -1},
+				{Phase: progress.PhaseCloning, Percentage: 45, BytesDone: 1024, Message: "Receiving objects"},
+			},
+			Expectation: []*api.ContentProgressResponse{
+				{Phase: api.ContentPhase_content_cloning, Percentage: -1},
+				{Phase: api.ContentPhase_content_cloning, Percentage: 45, BytesDone: 1024, Message: "Receiving objects"},
+				{Phase: api.ContentPhase_content_ready, Percentage: 100},
+			},
+		},
+		{
+			Desc: "backup",
+			Reports: []progress.Progress{
+				{Phase: progress.PhaseRestoringBackup, Percentage: 50, BytesDone: 50, BytesTotal: 100},
+				{Phase: "unknown", Percentage: 10},
+			},
+			Expectation: []*api.ContentProgressResponse{
+				{Phase: api.ContentPhase_content_restoring_backup, Percentage: 50, BytesDone: 50, BytesTotal: 100},
+				{Phase: api.ContentPhase_content_ready, Percentage: 100},
+			},
+		},
+		{
+			Desc:    "failure",
+			Reports: []progress.Progress{{Phase: progress.PhaseDownloadingPrebuild, Percentage: -1, BytesTotal: -1}},
+			Err:     xerrors.Errorf("snapshot not found"),
+			Expectation: []*api.ContentProgressResponse{
+				{Phase: api.ContentPhase_content_downloading_prebuild, Percentage: -1},
+				{Phase: api.ContentPhase_content_failed, Percentage: -1, Error: "snapshot not found"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			prog := newContentProgress()
+			initial, changed := prog.get()
+			if initial.Phase != api.ContentPhase_content_pending {
+				t.Errorf("unexpected initial phase: %v", initial.Phase)
+			}
+
+			var act []*api.ContentProgressResponse
+			observe := func() {
+				select {
+				case <-changed:
+				default:
+					return
+				}
+				var current *api.ContentProgressResponse
+				current, changed = prog.get()
+				act = append(act, current)
+			}
+			for _, r := range test.Reports {
+				prog.Report(r)
+				observe()
+			}
+			prog.finish(test.Err)
+			observe()
+
+			// the content initialization finished, s.t. later progress is not reported anymore
+			prog.Report(progress.Progress{Phase: progress.PhaseCloning})
+			observe()
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected progress (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// cpuPollInterval is how often the CPU statistics of the workspace are read
+	cpuPollInterval = 10 * time.Second
+	// cpuThrottledThreshold is the share of scheduler periods in percent which may be throttled
+	// before the workspace counts as throttled
+	cpuThrottledThreshold = 50
+	// cpuThrottlingNotificationInterval is the minimum time between two notifications about throttling
+	cpuThrottlingNotificationInterval = 30 * time.Minute
+)
+
+// cpuWatcher watches the CPU cgroup of the workspace and optionally notifies the user
+// once the workspace is slowed down, because it hits its CPU limit
+type cpuWatcher struct {
+	// Notifications receives the notifications about throttling. If nil, the user is not notified.
+	Notifications *NotificationService
+	// ThrottledThreshold is the threshold of throttled periods in percent. Throttling ends once it drops below half of it.
+	ThrottledThreshold float64
+
+	cgroupDir string
+	procDir   string
+
+	mu         sync.Mutex
+	status     api.CPUStatusResponse
+	last       *cgroupCPU
+	lastPoll   time.Time
+	lastNotify time.Time
+}
+
+func newCPUWatcher(notifications *NotificationService) *cpuWatcher {
+	return &cpuWatcher{
+		Notifications:      notifications,
+		ThrottledThreshold: cpuThrottledThreshold,
+		cgroupDir:          cgroupDir,
+		procDir:            "/proc",
+	}
+}
+
+// Status returns the current CPU status of the workspace
+func (w *cpuWatcher) Status() *api.CPUStatusResponse {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return proto.Clone(&w.status).(*api.CPUStatusResponse)
+}
+
+// Run watches the CPU of the workspace until ctx is done
+func (w *cpuWatcher) Run(ctx context.Context) {
+	t := time.NewTicker(cpuPollInterval)
+	defer t.Stop()
+	for {
+		err := w.poll(ctx, time.Now())
+		if err != nil {
+			resourcesLog.WithError(err).Debug("cannot read CPU statistics of the workspace")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (w *cpuWatcher) poll(ctx context.Context, now time.Time) error {
+	cpu, err := readCgroupCPU(w.cgroupDir, w.procDir)
+	if err != nil {
+		return err
+	}
+
+	var notification *api.NotifyRequest
+	w.mu.Lock()
+	w.status.LimitCores = cpu.Limit
+	w.status.Periods = cpu.Periods
+	w.status.ThrottledPeriods = cpu.ThrottledPeriods
+	w.status.ThrottledSeconds = cpu.Throttled.Seconds()
+	w.status.Pressure = cpu.Pressure
+
+	// usage and throttling are rates, i.e. need two polls
+	if w.last != nil && now.After(w.lastPoll) {
+		w.status.UsageCores = float64(cpu.Usage-w.last.Usage) / float64(now.Sub(w.lastPoll))
+		w.status.ThrottledShare = 0
+		if periods := cpu.Periods - w.last.Periods; periods > 0 {
+			w.status.ThrottledShare = float64(cpu.ThrottledPeriods-w.last.ThrottledPeriods) / float64(periods) * 100
+		}
+	}
+	switch {
+	case !w.status.Throttled && w.status.ThrottledShare >= w.ThrottledThreshold:
+		w.status.Throttled = true
+		if w.Notifications != nil && now.Sub(w.lastNotify) >= cpuThrottlingNotificationInterval {
+			w.lastNotify = now
+			notification = &api.NotifyRequest{
+				Level:   api.NotificationLevel_notification_warning,
+				Message: cpuThrottlingMessage(cpu.Limit),
+			}
+		}
+	case w.status.Throttled && w.status.ThrottledShare < w.ThrottledThreshold/2:
+		w.status.Throttled = false
+	}
+	w.last = cpu
+	w.lastPoll = now
+	w.mu.Unlock()
+
+	if notification == nil {
+		return nil
+	}
+	resourcesLog.WithField("message", notification.Message).Info("CPU notification")
+	_, err = w.Notifications.Notify(ctx, notification)
+	if err != nil {
+		resourcesLog.WithError(err).Warn("cannot notify about CPU throttling")
+	}
+	return nil
+}
+
+func cpuThrottlingMessage(limit float64) string {
+	return fmt.Sprintf("The workspace hits its CPU limit of %s cores and is slowed down, which makes builds and other processes take longer. A larger workspace class provides more CPU.",
+		strconv.FormatFloat(limit, 'f', -1, 64))
+}
+
+type cgroupCPU struct {
+	// Limit is the number of cores the cgroup may use, or 0 if it is unlimited
+	Limit float64
+	// Usage is the CPU time the cgroup used
+	Usage            time.Duration
+	Periods          uint64
+	ThrottledPeriods uint64
+	// Throttled is the time the cgroup was throttled for
+	Throttled time.Duration
+	// Pressure is the share of the last 10 seconds in percent in which processes were stalled waiting for CPU
+	Pressure float64
+}
+
+// readCgroupCPU reads the CPU statistics of a cgroup, either of cgroup v2 or v1
+func readCgroupCPU(cgroupDir, procDir string) (*cgroupCPU, error) {
+	var (
+		res          cgroupCPU
+		err          error
+		pressureFile string
+	)
+	if isCgroupV2(cgroupDir) {
+		res.Limit, err = readCPUMax(filepath.Join(cgroupDir, "cpu.max"))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		stat, err := readCgroupKeyValues(filepath.Join(cgroupDir, "cpu.stat"))
+		if err != nil {
+			return nil, err
+		}
+		res.Usage = time.Duration(stat["usage_usec"]) * time.Microsecond
+		res.Periods = stat["nr_periods"]
+		res.ThrottledPeriods = stat["nr_throttled"]
+		res.Throttled = time.Duration(stat["throttled_usec"]) * time.Microsecond
+		pressureFile = filepath.Join(cgroupDir, "cpu.pressure")
+	} else {
+		dir := filepath.Join(cgroupDir, "cpu")
+		quota, err := readCgroupInt(filepath.Join(dir, "cpu.cfs_quota_us"))
+		if err != nil {
+			return nil, err
+		}
+		period, err := readCgroupInt(filepath.Join(dir, "cpu.cfs_period_us"))
+		if err != nil {
+			return nil, err
+		}
+		// a quota of -1 means unlimited
+		if quota > 0 && period > 0 {
+			res.Limit = float64(quota) / float64(period)
+		}
+		stat, err := readCgroupKeyValues(filepath.Join(dir, "cpu.stat"))
+		if err != nil {
+			return nil, err
+		}
+		res.Periods = stat["nr_periods"]
+		res.ThrottledPeriods = stat["nr_throttled"]
+		res.Throttled = time.Duration(stat["throttled_time"])
+		usage, err := readCgroupUint(filepath.Join(cgroupDir, "cpuacct", "cpuacct.usage"))
+		if err != nil {
+			return nil, err
+		}
+		res.Usage = time.Duration(usage)
+		// cgroup v1 has no pressure per cgroup
+		pressureFile = filepath.Join(procDir, "pressure", "cpu")
+	}
+
+	res.Pressure, err = readPressure(pressureFile)
+	if err != nil && !os.IsNotExist(err) {
+		resourcesLog.WithError(err).Debug("cannot read CPU pressure")
+	}
+	return &res, nil
+}
+
+// readCPUMax reads the number of cores a cgroup v2 may use from its cpu.max file, e.g. "200000 100000" for two cores.
+// The quota "max" reads as 0, i.e. unlimited.
+func readCPUMax(fn string) (float64, error) {
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return 0, err
+	}
+	segs := strings.Fields(string(b))
+	if len(segs) != 2 {
+		return 0, xerrors.Errorf("invalid value in %s", fn)
+	}
+	if segs[0] == "max" {
+		return 0, nil
+	}
+	quota, err := strconv.ParseUint(segs[0], 10, 64)
+	if err != nil {
+		return 0, xerrors.Errorf("invalid quota in %s: %w", fn, err)
+	}
+	period, err := strconv.ParseUint(segs[1], 10, 64)
+	if err != nil || period == 0 {
+		return 0, xerrors.Errorf("invalid period in %s", fn)
+	}
+	return float64(quota) / float64(period), nil
+}
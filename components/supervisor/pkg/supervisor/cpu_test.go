@@ -0,0 +1,176 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadCgroupCPU(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Files       map[string]string
+		Expectation *cgroupCPU
+	}{
+		{
+			Desc: "cgroup v2",
+			Files: map[string]string{
+				"cgroup/cgroup.controllers": "cpu memory",
+				"cgroup/cpu.max":            "400000 100000\n",
+				"cgroup/cpu.stat":           "usage_usec 2000000\nuser_usec 1500000\nsystem_usec 500000\nnr_periods 100\nnr_throttled 25\nthrottled_usec 300000\n",
+				"cgroup/cpu.pressure":       "some avg10=12.00 avg60=1.00 avg300=0.00 total=1\n",
+			},
+			Expectation: &cgroupCPU{Limit: 4, Usage: 2 * time.Second, Periods: 100, ThrottledPeriods: 25, Throttled: 300 * time.Millisecond, Pressure: 12},
+		},
+		{
+			Desc: "cgroup v2 unlimited",
+			Files: map[string]string{
+				"cgroup/cgroup.controllers": "cpu memory",
+				"cgroup/cpu.max":            "max 100000\n",
+				"cgroup/cpu.stat":           "usage_usec 1000\n",
+			},
+			Expectation: &cgroupCPU{Usage: time.Millisecond},
+		},
+		{
+			Desc: "cgroup v1",
+			Files: map[string]string{
+				"cgroup/cpu/cpu.cfs_quota_us":  "150000\n",
+				"cgroup/cpu/cpu.cfs_period_us": "100000\n",
+				"cgroup/cpu/cpu.stat":          "nr_periods 10\nnr_throttled 2\nthrottled_time 5000000\n",
+				"cgroup/cpuacct/cpuacct.usage": "3000000000\n",
+				"proc/pressure/cpu":            "some avg10=2.50 avg60=0.00 avg300=0.00 total=1\n",
+			},
+			Expectation: &cgroupCPU{Limit: 1.5, Usage: 3 * time.Second, Periods: 10, ThrottledPeriods: 2, Throttled: 5 * time.Millisecond, Pressure: 2.5},
+		},
+		{
+			Desc: "cgroup v1 unlimited",
+			Files: map[string]string{
+				"cgroup/cpu/cpu.cfs_quota_us":  "-1\n",
+				"cgroup/cpu/cpu.cfs_period_us": "100000\n",
+				"cgroup/cpu/cpu.stat":          "nr_periods 0\nnr_throttled 0\nthrottled_time 0\n",
+				"cgroup/cpuacct/cpuacct.usage": "1000\n",
+			},
+			Expectation: &cgroupCPU{Usage: time.Microsecond},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "supervisor-cpu")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			for fn, content := range test.Files {
+				fn = filepath.Join(dir, fn)
+				_ = os.MkdirAll(filepath.Dir(fn), 0755)
+				err = ioutil.WriteFile(fn, []byte(content), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			act, err := readCgroupCPU(filepath.Join(dir, "cgroup"), filepath.Join(dir, "proc"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected CPU statistics (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCPUWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "supervisor-cpu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(usage time.Duration, periods, throttled uint64) {
+		for fn, content := range map[string]string{
+			"cgroup.controllers": "cpu",
+			"cpu.max":            "200000 100000",
+			"cpu.stat":           fmt.Sprintf("usage_usec %d\nnr_periods %d\nnr_throttled %d\nthrottled_usec 0\n", usage.Microseconds(), periods, throttled),
+		} {
+			err := ioutil.WriteFile(filepath.Join(dir, fn), []byte(content), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	notifications := NewNotificationService()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub := &testNotificationSubscriber{ctx: ctx, events: make(chan *api.SubscribeNotificationsResponse, 10)}
+	go notifications.Subscribe(&api.SubscribeNotificationsRequest{}, sub)
+	// give the subscriber a chance to subscribe
+	time.Sleep(100 * time.Millisecond)
+
+	w := newCPUWatcher(notifications)
+	w.cgroupDir, w.procDir = dir, dir
+
+	type cpuSummary struct {
+		UsageCores     float64
+		ThrottledShare float64
+		Throttled      bool
+	}
+	start := time.Now()
+	steps := []struct {
+		Offset    time.Duration
+		Usage     time.Duration
+		Periods   uint64
+		Throttled uint64
+		Expected  cpuSummary
+	}{
+		{Offset: 0, Usage: 0, Periods: 0, Throttled: 0, Expected: cpuSummary{}},
+		{Offset: 10 * time.Second, Usage: 10 * time.Second, Periods: 100, Throttled: 10, Expected: cpuSummary{UsageCores: 1, ThrottledShare: 10}},
+		{Offset: 20 * time.Second, Usage: 30 * time.Second, Periods: 200, Throttled: 70, Expected: cpuSummary{UsageCores: 2, ThrottledShare: 60, Throttled: true}},
+		{Offset: 30 * time.Second, Usage: 45 * time.Second, Periods: 300, Throttled: 100, Expected: cpuSummary{UsageCores: 1.5, ThrottledShare: 30, Throttled: true}},
+		{Offset: 40 * time.Second, Usage: 50 * time.Second, Periods: 400, Throttled: 110, Expected: cpuSummary{UsageCores: 0.5, ThrottledShare: 10}},
+		// throttled again within the notification interval
+		{Offset: 50 * time.Second, Usage: 70 * time.Second, Periods: 500, Throttled: 190, Expected: cpuSummary{UsageCores: 2, ThrottledShare: 80, Throttled: true}},
+	}
+	for i, step := range steps {
+		write(step.Usage, step.Periods, step.Throttled)
+		err := w.poll(ctx, start.Add(step.Offset))
+		if err != nil {
+			t.Fatal(err)
+		}
+		status := w.Status()
+		act := cpuSummary{UsageCores: status.UsageCores, ThrottledShare: status.ThrottledShare, Throttled: status.Throttled}
+		if diff := cmp.Diff(step.Expected, act); diff != "" {
+			t.Errorf("step %d: unexpected CPU status (-want +got):\n%s", i, diff)
+		}
+		if status.LimitCores != 2 {
+			t.Errorf("step %d: unexpected limit: %f", i, status.LimitCores)
+		}
+	}
+
+	var messages []string
+	for {
+		select {
+		case e := <-sub.events:
+			messages = append(messages, e.Request.Message)
+			continue
+		case <-time.After(200 * time.Millisecond):
+		}
+		break
+	}
+	if diff := cmp.Diff([]string{
+		"The workspace hits its CPU limit of 2 cores and is slowed down, which makes builds and other processes take longer. A larger workspace class provides more CPU.",
+	}, messages); diff != "" {
+		t.Errorf("unexpected notifications (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// diagnosticsLocation is the directory where supervisor writes diagnostic bundles to
+	diagnosticsLocation = "/workspace/.gitpod/diagnostics"
+	// maxDiagnosticBundles is the number of bundles kept. Older bundles are removed.
+	maxDiagnosticBundles = 5
+	// maxRecentLogs is the number of supervisor's log entries a bundle contains
+	maxRecentLogs = 2000
+)
+
+// recentLogs is a logrus hook which keeps the latest log entries of supervisor for diagnostic bundles
+type recentLogs struct {
+	Size int
+
+	mu        sync.Mutex
+	entries   [][]byte
+	formatter logrus.JSONFormatter
+}
+
+func newRecentLogs(size int) *recentLogs {
+	return &recentLogs{Size: size}
+}
+
+// Levels returns the levels of the entries the hook keeps, i.e. all
+func (l *recentLogs) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire keeps a log entry
+func (l *recentLogs) Fire(e *logrus.Entry) error {
+	b, err := l.formatter.Format(e)
+	if err != nil {
+		return err
+	}
+	// formatters may reuse the buffer of the entry
+	b = append([]byte{}, b...)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, b)
+	if len(l.entries) > l.Size {
+		l.entries = l.entries[len(l.entries)-l.Size:]
+	}
+	return nil
+}
+
+// Bytes returns the kept log entries, one JSON object per line
+func (l *recentLogs) Bytes() []byte {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return bytes.Join(l.entries, nil)
+}
+
+// diagnosticsSource provides a file of a diagnostic bundle. Collect returns either a proto message,
+// which is marshalled with jsonpb, raw bytes or any other value, which is marshalled as JSON.
+type diagnosticsSource struct {
+	Name    string
+	Collect func(ctx context.Context) (interface{}, error)
+}
+
+// diagnosticsBundler writes bundles of supervisor's recent logs, goroutines and the state of its subsystems
+type diagnosticsBundler struct {
+	Location string
+	Logs     *recentLogs
+	Sources  []diagnosticsSource
+
+	// mu serialises bundles, which are named by the second they were created in
+	mu sync.Mutex
+}
+
+// Create writes a new bundle and returns its location
+func (d *diagnosticsBundler) Create(ctx context.Context, now time.Time) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err := os.MkdirAll(d.Location, 0755)
+	if err != nil {
+		return "", xerrors.Errorf("cannot create diagnostics location: %w", err)
+	}
+	fn := filepath.Join(d.Location, "supervisor-diagnostics-"+now.UTC().Format("20060102T150405Z")+".tar.gz")
+	f, err := ioutil.TempFile(d.Location, ".supervisor-diagnostics-*")
+	if err != nil {
+		return "", xerrors.Errorf("cannot create diagnostic bundle: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	add := func(name string, content []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: now,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(content)
+		return err
+	}
+
+	var (
+		files = make(map[string][]byte)
+		errs  []string
+	)
+	if d.Logs != nil {
+		files["supervisor.log"] = d.Logs.Bytes()
+	}
+	var goroutines bytes.Buffer
+	err = pprof.Lookup("goroutine").WriteTo(&goroutines, 2)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("goroutines.txt: %v", err))
+	}
+	files["goroutines.txt"] = goroutines.Bytes()
+	for _, src := range d.Sources {
+		content, err := collectDiagnostics(ctx, src)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", src.Name, err))
+			continue
+		}
+		files[src.Name] = content
+	}
+	if len(errs) > 0 {
+		files["errors.txt"] = []byte(strings.Join(errs, "\n") + "\n")
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		err = add(name, files[name])
+		if err != nil {
+			return "", xerrors.Errorf("cannot write %s to diagnostic bundle: %w", name, err)
+		}
+	}
+	err = tw.Close()
+	if err != nil {
+		return "", xerrors.Errorf("cannot write diagnostic bundle: %w", err)
+	}
+	err = gz.Close()
+	if err != nil {
+		return "", xerrors.Errorf("cannot write diagnostic bundle: %w", err)
+	}
+	err = f.Close()
+	if err != nil {
+		return "", xerrors.Errorf("cannot write diagnostic bundle: %w", err)
+	}
+	err = os.Rename(f.Name(), fn)
+	if err != nil {
+		return "", xerrors.Errorf("cannot write diagnostic bundle: %w", err)
+	}
+
+	d.removeOldBundles()
+	log.WithField("location", fn).Info("created diagnostic bundle")
+	return fn, nil
+}
+
+func collectDiagnostics(ctx context.Context, src diagnosticsSource) ([]byte, error) {
+	v, err := src.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case proto.Message:
+		var buf bytes.Buffer
+		err = (&jsonpb.Marshaler{EmitDefaults: true, Indent: "  "}).Marshal(&buf, v)
+		return buf.Bytes(), err
+	default:
+		return json.MarshalIndent(v, "", "  ")
+	}
+}
+
+// removeOldBundles keeps the latest maxDiagnosticBundles bundles
+func (d *diagnosticsBundler) removeOldBundles() {
+	bundles, err := filepath.Glob(filepath.Join(d.Location, "supervisor-diagnostics-*.tar.gz"))
+	if err != nil || len(bundles) <= maxDiagnosticBundles {
+		return
+	}
+	// the names sort by the time the bundles were created
+	sort.Strings(bundles)
+	for _, fn := range bundles[:len(bundles)-maxDiagnosticBundles] {
+		err := os.Remove(fn)
+		if err != nil {
+			log.WithError(err).WithField("location", fn).Warn("cannot remove old diagnostic bundle")
+		}
+	}
+}
+
+// supervisorDiagnostics lists the config and the state of the subsystems a diagnostic bundle of supervisor contains
+func supervisorDiagnostics(cfg *Config, status *statusService, processes *processTracker) []diagnosticsSource {
+	return []diagnosticsSource{
+		{Name: "config.json", Collect: func(ctx context.Context) (interface{}, error) {
+			// the config may have been changed since supervisor started
+			if loc, err := staticConfigLocation(); err == nil {
+				if current, err := readStaticConfig(loc); err == nil {
+					return current, nil
+				}
+			}
+			return cfg.StaticConfig, nil
+		}},
+		{Name: "ide-config.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return cfg.IDEConfig, nil
+		}},
+		{Name: "desktop-ide-config.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return cfg.DesktopIDE, nil
+		}},
+		{Name: "health.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return status.HealthStatus(ctx, &api.HealthStatusRequest{})
+		}},
+		{Name: "content.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return status.ContentStatus(ctx, &api.ContentStatusRequest{})
+		}},
+		{Name: "ports.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return &api.PortsStatusResponse{Added: status.Ports.Status(), Diagnostics: status.Ports.Diagnostics()}, nil
+		}},
+		{Name: "tasks.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return &api.TasksStatusResponse{Tasks: status.Tasks.getStatus()}, nil
+		}},
+		{Name: "processes.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return processes.ListProcesses(ctx, &api.ListProcessesRequest{})
+		}},
+		{Name: "memory.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return status.MemoryStatus(ctx, &api.MemoryStatusRequest{})
+		}},
+		{Name: "disk.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return status.DiskStatus(ctx, &api.DiskStatusRequest{})
+		}},
+		{Name: "cpu.json", Collect: func(ctx context.Context) (interface{}, error) {
+			return status.CPUStatus(ctx, &api.CPUStatusRequest{})
+		}},
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestDiagnosticsBundler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "supervisor-diagnostics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	logs := newRecentLogs(2)
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	logger.AddHook(logs)
+	logger.Info("first")
+	logger.Info("second")
+	logger.WithField("port", 3000).Warn("third")
+
+	bundler := &diagnosticsBundler{
+		Location: dir,
+		Logs:     logs,
+		Sources: []diagnosticsSource{
+			{Name: "tasks.json", Collect: func(ctx context.Context) (interface{}, error) {
+				return &api.TasksStatusResponse{Tasks: []*api.TaskStatus{{Id: "0", State: api.TaskState_running}}}, nil
+			}},
+			{Name: "config.json", Collect: func(ctx context.Context) (interface{}, error) {
+				return map[string]int{"apiEndpointPort": 22999}, nil
+			}},
+			{Name: "broken.json", Collect: func(ctx context.Context) (interface{}, error) {
+				return nil, fmt.Errorf("not available")
+			}},
+		},
+	}
+	start := time.Date(2020, 11, 2, 10, 0, 0, 0, time.UTC)
+	loc, err := bundler.Create(context.Background(), start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := filepath.Join(dir, "supervisor-diagnostics-20201102T100000Z.tar.gz"); loc != exp {
+		t.Errorf("unexpected location: want %s, got %s", exp, loc)
+	}
+
+	files := readTarGz(t, loc)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if diff := cmp.Diff([]string{"config.json", "errors.txt", "goroutines.txt", "supervisor.log", "tasks.json"}, names); diff != "" {
+		t.Errorf("unexpected files (-want +got):\n%s", diff)
+	}
+	if lines := strings.Split(strings.TrimSpace(files["supervisor.log"]), "\n"); len(lines) != 2 || !strings.Contains(lines[0], `"msg":"second"`) || !strings.Contains(lines[1], `"port":3000`) {
+		t.Errorf("unexpected logs: %s", files["supervisor.log"])
+	}
+	if !strings.Contains(files["tasks.json"], `"state": "running"`) {
+		t.Errorf("unexpected tasks: %s", files["tasks.json"])
+	}
+	if files["errors.txt"] != "broken.json: not available\n" {
+		t.Errorf("unexpected errors: %s", files["errors.txt"])
+	}
+	if !strings.Contains(files["goroutines.txt"], "TestDiagnosticsBundler") {
+		t.Errorf("goroutines miss the test")
+	}
+
+	// only the latest bundles are kept
+	for i := 1; i <= maxDiagnosticBundles; i++ {
+		_, err = bundler.Create(context.Background(), start.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	bundles, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(bundles) != maxDiagnosticBundles {
+		t.Errorf("unexpected number of bundles: want %d, got %d", maxDiagnosticBundles, len(bundles))
+	}
+	if _, err := os.Stat(loc); !os.IsNotExist(err) {
+		t.Errorf("oldest bundle was not removed")
+	}
+}
+
+func readTarGz(t *testing.T, fn string) map[string]string {
+	f, err := os.Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	res := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return res
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res[hdr.Name] = string(b)
+	}
+}
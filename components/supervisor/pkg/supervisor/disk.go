@@ -0,0 +1,298 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// diskPollInterval is how often the disk usage of the workspace is checked
+	diskPollInterval = 30 * time.Second
+	// diskUsageHysteresis is how many percentage points the usage must drop below a threshold
+	// before the user is warned about it again
+	diskUsageHysteresis = 5
+	// defaultInotifyThreshold is the share of the inotify watch limit in percent at which the user is warned by default
+	defaultInotifyThreshold = 80
+	// maxInotifyWatchers is the number of processes with the most inotify watches the disk status lists
+	maxInotifyWatchers = 5
+)
+
+// defaultDiskUsageThresholds are the usages in percent at which the user is warned by default
+var defaultDiskUsageThresholds = []float64{80, 90, 95}
+
+// diskWatcher watches the usage of the workspace filesystem and the inotify watches of the workspace processes,
+// and warns the user once they exceed a threshold
+type diskWatcher struct {
+	Notifications *NotificationService
+	// Path is a location on the watched filesystem, e.g. /workspace
+	Path string
+	// Thresholds are the usages in percent at which the user is warned, sorted ascending
+	Thresholds []float64
+	// InotifyThreshold is the share of the inotify watch limit in percent at which the user is warned
+	InotifyThreshold float64
+
+	procDir   string
+	readUsage func(path string) (*fsUsage, error)
+
+	mu              sync.Mutex
+	status          api.DiskStatusResponse
+	notified        float64
+	inotifyNotified bool
+}
+
+func newDiskWatcher(path string, cfg *StaticConfig, notifications *NotificationService) *diskWatcher {
+	thresholds := append([]float64{}, cfg.DiskUsage.Thresholds...)
+	if len(thresholds) == 0 {
+		thresholds = defaultDiskUsageThresholds
+	}
+	sort.Float64s(thresholds)
+	inotifyThreshold := cfg.DiskUsage.InotifyThreshold
+	if inotifyThreshold == 0 {
+		inotifyThreshold = defaultInotifyThreshold
+	}
+	return &diskWatcher{
+		Notifications:    notifications,
+		Path:             path,
+		Thresholds:       thresholds,
+		InotifyThreshold: inotifyThreshold,
+		procDir:          "/proc",
+		readUsage:        readFSUsage,
+	}
+}
+
+// Status returns the current disk status of the workspace
+func (w *diskWatcher) Status() *api.DiskStatusResponse {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return proto.Clone(&w.status).(*api.DiskStatusResponse)
+}
+
+// Run watches the disk usage until ctx is done
+func (w *diskWatcher) Run(ctx context.Context) {
+	t := time.NewTicker(diskPollInterval)
+	defer t.Stop()
+	for {
+		err := w.poll(ctx)
+		if err != nil {
+			resourcesLog.WithError(err).Debug("cannot read disk usage of the workspace")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (w *diskWatcher) poll(ctx context.Context) error {
+	usage, err := w.readUsage(w.Path)
+	if err != nil {
+		return err
+	}
+	watchers := readInotifyWatchers(w.procDir)
+	limit, err := readCgroupUint(filepath.Join(w.procDir, "sys", "fs", "inotify", "max_user_watches"))
+	if err != nil && !os.IsNotExist(err) {
+		resourcesLog.WithError(err).Debug("cannot read inotify watch limit")
+	}
+	var watches uint64
+	for _, watcher := range watchers {
+		watches += watcher.Watches
+	}
+	if len(watchers) > maxInotifyWatchers {
+		watchers = watchers[:maxInotifyWatchers]
+	}
+
+	var notifications []*api.NotifyRequest
+	w.mu.Lock()
+	w.status = api.DiskStatusResponse{
+		Path:              w.Path,
+		TotalBytes:        usage.Total,
+		UsedBytes:         usage.Used,
+		AvailableBytes:    usage.Available,
+		Usage:             usage.Percent(),
+		TotalInodes:       usage.Inodes,
+		UsedInodes:        usage.UsedInodes,
+		InotifyWatches:    watches,
+		InotifyWatchLimit: limit,
+		InotifyWatchers:   watchers,
+	}
+
+	var exceeded float64
+	for _, threshold := range w.Thresholds {
+		if w.status.Usage >= threshold {
+			exceeded = threshold
+		}
+	}
+	switch {
+	case exceeded > w.notified:
+		w.notified = exceeded
+		level := api.NotificationLevel_notification_warning
+		if exceeded == w.Thresholds[len(w.Thresholds)-1] {
+			level = api.NotificationLevel_notification_error
+		}
+		notifications = append(notifications, &api.NotifyRequest{
+			Level:   level,
+			Message: diskUsageMessage(usage),
+		})
+	case w.status.Usage < w.notified-diskUsageHysteresis:
+		w.notified = exceeded
+	}
+
+	if limit > 0 {
+		share := float64(watches) / float64(limit) * 100
+		switch {
+		case !w.inotifyNotified && share >= w.InotifyThreshold:
+			w.inotifyNotified = true
+			notifications = append(notifications, &api.NotifyRequest{
+				Level:   api.NotificationLevel_notification_warning,
+				Message: inotifyMessage(watches, limit, watchers),
+			})
+		case w.inotifyNotified && share < w.InotifyThreshold/2:
+			w.inotifyNotified = false
+		}
+	}
+	w.mu.Unlock()
+
+	for _, n := range notifications {
+		resourcesLog.WithField("message", n.Message).Warn("disk notification")
+		if w.Notifications == nil {
+			continue
+		}
+		_, err := w.Notifications.Notify(ctx, n)
+		if err != nil {
+			resourcesLog.WithError(err).Warn("cannot notify about disk usage")
+		}
+	}
+	return nil
+}
+
+func diskUsageMessage(usage *fsUsage) string {
+	return fmt.Sprintf("The workspace disk is %.0f%% full (%s of %s used). Processes fail to write files once it is full, consider removing files you do not need, e.g. build outputs or caches.",
+		usage.Percent(), formatBytes(usage.Used), formatBytes(usage.Used+usage.Available))
+}
+
+func inotifyMessage(watches, limit uint64, watchers []*api.InotifyWatcher) string {
+	msg := fmt.Sprintf("Processes of the workspace hold %d of %d inotify watches", watches, limit)
+	if len(watchers) > 0 {
+		msg += fmt.Sprintf(", most of them %s (PID %d)", watchers[0].Command, watchers[0].Pid)
+	}
+	return msg + ". Watching files fails beyond the limit, consider excluding large folders like node_modules from file watchers."
+}
+
+// formatBytes formats a number of bytes with a binary unit, e.g. 1.5 GiB
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+type fsUsage struct {
+	Total      uint64
+	Used       uint64
+	Available  uint64
+	Inodes     uint64
+	UsedInodes uint64
+}
+
+// Percent returns the share of the filesystem in percent which is used. Like df, it does not count
+// the space reserved for root as available.
+func (u *fsUsage) Percent() float64 {
+	if u.Used+u.Available == 0 {
+		return 0
+	}
+	return float64(u.Used) / float64(u.Used+u.Available) * 100
+}
+
+// readFSUsage reads the usage of the filesystem path is located on
+func readFSUsage(path string) (*fsUsage, error) {
+	var st unix.Statfs_t
+	err := unix.Statfs(path, &st)
+	if err != nil {
+		return nil, err
+	}
+	bsize := uint64(st.Bsize)
+	return &fsUsage{
+		Total:      st.Blocks * bsize,
+		Used:       (st.Blocks - st.Bfree) * bsize,
+		Available:  st.Bavail * bsize,
+		Inodes:     st.Files,
+		UsedInodes: st.Files - st.Ffree,
+	}, nil
+}
+
+// readInotifyWatchers counts the inotify watches of the processes, sorted by the most watches first
+func readInotifyWatchers(procDir string) []*api.InotifyWatcher {
+	entries, err := ioutil.ReadDir(procDir)
+	if err != nil {
+		return nil
+	}
+	var res []*api.InotifyWatcher
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		watches := countInotifyWatches(filepath.Join(procDir, e.Name()))
+		if watches == 0 {
+			continue
+		}
+		var command string
+		if stat, err := readProcStat(procDir, pid); err == nil {
+			command = stat.Comm
+		}
+		res = append(res, &api.InotifyWatcher{Pid: int64(pid), Command: command, Watches: watches})
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Watches > res[j].Watches })
+	return res
+}
+
+// countInotifyWatches counts the watches of all inotify instances a process holds
+func countInotifyWatches(pidDir string) (watches uint64) {
+	fds, err := ioutil.ReadDir(filepath.Join(pidDir, "fd"))
+	if err != nil {
+		return 0
+	}
+	for _, fd := range fds {
+		target, err := os.Readlink(filepath.Join(pidDir, "fd", fd.Name()))
+		if err != nil || target != "anon_inode:inotify" {
+			continue
+		}
+		f, err := os.Open(filepath.Join(pidDir, "fdinfo", fd.Name()))
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if strings.HasPrefix(scanner.Text(), "inotify ") {
+				watches++
+			}
+		}
+		f.Close()
+	}
+	return watches
+}
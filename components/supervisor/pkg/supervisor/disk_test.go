@@ -0,0 +1,136 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiskWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "supervisor-disk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFakeProc(t, dir, []fakeProcess{
+		{PID: 10, PPID: 1, Comm: "supervisor", State: "S"},
+		{PID: 20, PPID: 10, Comm: "node", State: "S"},
+		{PID: 30, PPID: 10, Comm: "java", State: "S"},
+	})
+	writeInotify := func(pid string, fd string, watches int) {
+		_ = os.MkdirAll(filepath.Join(dir, pid, "fd"), 0755)
+		_ = os.MkdirAll(filepath.Join(dir, pid, "fdinfo"), 0755)
+		err := os.Symlink("anon_inode:inotify", filepath.Join(dir, pid, "fd", fd))
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := "pos:\t0\nflags:\t00\nmnt_id:\t13\n" + strings.Repeat("inotify wd:1 ino:2 sdev:3 mask:fc6 ignored_mask:0\n", watches)
+		err = ioutil.WriteFile(filepath.Join(dir, pid, "fdinfo", fd), []byte(info), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeInotify("20", "3", 50)
+	writeInotify("20", "4", 20)
+	writeInotify("30", "5", 15)
+	_ = os.MkdirAll(filepath.Join(dir, "sys", "fs", "inotify"), 0755)
+	err = ioutil.WriteFile(filepath.Join(dir, "sys", "fs", "inotify", "max_user_watches"), []byte("100\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifications := NewNotificationService()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub := &testNotificationSubscriber{ctx: ctx, events: make(chan *api.SubscribeNotificationsResponse, 10)}
+	go notifications.Subscribe(&api.SubscribeNotificationsRequest{}, sub)
+	// give the subscriber a chance to subscribe
+	time.Sleep(100 * time.Millisecond)
+
+	var cfg StaticConfig
+	cfg.DiskUsage.Thresholds = []float64{90, 80}
+	w := newDiskWatcher("/workspace", &cfg, notifications)
+	w.procDir = dir
+	var used uint64
+	w.readUsage = func(path string) (*fsUsage, error) {
+		return &fsUsage{Total: 10 << 30, Used: used, Available: 10<<30 - used}, nil
+	}
+
+	var messages []string
+	poll := func(usage uint64) {
+		used = usage
+		err := w.poll(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	collect := func() {
+		for {
+			select {
+			case e := <-sub.events:
+				messages = append(messages, e.Request.Message)
+			case <-time.After(200 * time.Millisecond):
+				return
+			}
+		}
+	}
+
+	poll(5 << 30)
+	status := w.Status()
+	if status.Usage != 50 || status.InotifyWatches != 85 || status.InotifyWatchLimit != 100 {
+		t.Errorf("unexpected disk status: %v", status)
+	}
+	if len(status.InotifyWatchers) != 2 || status.InotifyWatchers[0].Pid != 20 || status.InotifyWatchers[0].Watches != 70 {
+		t.Errorf("unexpected inotify watchers: %v", status.InotifyWatchers)
+	}
+
+	// crossing a threshold warns once
+	poll(8 << 30)
+	poll(8 << 30)
+	// dropping within the hysteresis does not warn again
+	poll(7885 << 20)
+	poll(8 << 30)
+	// crossing the last threshold warns
+	poll(9 << 30)
+	// dropping well below re-arms the threshold
+	poll(6 << 30)
+	poll(8 << 30)
+	collect()
+
+	if diff := cmp.Diff([]string{
+		"Processes of the workspace hold 85 of 100 inotify watches, most of them node (PID 20). Watching files fails beyond the limit, consider excluding large folders like node_modules from file watchers.",
+		"The workspace disk is 80% full (8.0 GiB of 10.0 GiB used). Processes fail to write files once it is full, consider removing files you do not need, e.g. build outputs or caches.",
+		"The workspace disk is 90% full (9.0 GiB of 10.0 GiB used). Processes fail to write files once it is full, consider removing files you do not need, e.g. build outputs or caches.",
+		"The workspace disk is 80% full (8.0 GiB of 10.0 GiB used). Processes fail to write files once it is full, consider removing files you do not need, e.g. build outputs or caches.",
+	}, messages); diff != "" {
+		t.Errorf("unexpected notifications (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		Bytes       uint64
+		Expectation string
+	}{
+		{Bytes: 512, Expectation: "512 B"},
+		{Bytes: 1536, Expectation: "1.5 KiB"},
+		{Bytes: 10 << 30, Expectation: "10.0 GiB"},
+	}
+	for _, test := range tests {
+		if act := formatBytes(test.Bytes); act != test.Expectation {
+			t.Errorf("formatBytes(%d): want %q, got %q", test.Bytes, test.Expectation, act)
+		}
+	}
+}
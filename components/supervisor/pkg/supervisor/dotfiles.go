@@ -0,0 +1,271 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// dotfilesCloneTimeout is the time cloning the dotfiles repository may take
+	dotfilesCloneTimeout = 60 * time.Second
+	// dotfilesInstallTimeout is the time the install script of the dotfiles may take
+	dotfilesInstallTimeout = 120 * time.Second
+	// dotfilesMaxLogSize is the maximum size of the dotfiles log. Further output is dropped.
+	dotfilesMaxLogSize = 1 << 20
+)
+
+// dotfilesInstallScripts are the scripts which install the dotfiles, in the order they are looked for
+var dotfilesInstallScripts = []string{
+	"install.sh",
+	"install",
+	"bootstrap.sh",
+	"bootstrap",
+	"script/bootstrap",
+	"setup.sh",
+	"setup",
+	"script/setup",
+}
+
+// dotfilesInstaller installs the dotfiles repository of the user. The repository is cloned and its install
+// script run. Repositories without install script have their files linked into the home directory.
+type dotfilesInstaller struct {
+	Repository string
+	// Location is where the repository is cloned to
+	Location string
+	Home     string
+	// LogLocation is the file the output of git and the install script is written to, s.t. users can
+	// debug broken dotfiles without the output ending up in the supervisor log
+	LogLocation string
+
+	CloneTimeout   time.Duration
+	InstallTimeout time.Duration
+
+	mu       sync.Mutex
+	status   api.DotfilesStatusResponse
+	done     chan struct{}
+	restored bool
+}
+
+func newDotfilesInstaller(repository, home string) *dotfilesInstaller {
+	return &dotfilesInstaller{
+		Repository:     repository,
+		Location:       filepath.Join(home, ".dotfiles"),
+		Home:           home,
+		LogLocation:    filepath.Join(home, ".dotfiles.log"),
+		CloneTimeout:   dotfilesCloneTimeout,
+		InstallTimeout: dotfilesInstallTimeout,
+		done:           make(chan struct{}),
+	}
+}
+
+// Status returns the current status of the installation
+func (d *dotfilesInstaller) Status() *api.DotfilesStatusResponse {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return proto.Clone(&d.status).(*api.DotfilesStatusResponse)
+}
+
+// Done returns a channel which closes once the dotfiles were installed, failed to install or there are none
+func (d *dotfilesInstaller) Done() <-chan struct{} {
+	return d.done
+}
+
+func (d *dotfilesInstaller) setPhase(phase api.DotfilesPhase) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.status.Phase = phase
+}
+
+// restore takes over the status of an installation by a previous supervisor process, s.t. Run does not install again
+func (d *dotfilesInstaller) restore(status *api.DotfilesStatusResponse) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.status = *status
+	d.restored = true
+}
+
+// Run installs the dotfiles
+func (d *dotfilesInstaller) Run(ctx context.Context) {
+	defer close(d.done)
+	if d.Repository == "" || d.restored {
+		return
+	}
+
+	d.mu.Lock()
+	d.status.Repository = d.Repository
+	d.status.LogLocation = d.LogLocation
+	d.mu.Unlock()
+
+	err := d.install(ctx)
+	if err != nil {
+		dotfilesLog.WithError(err).WithField("repository", d.Repository).WithField("log", d.LogLocation).Warn("cannot install dotfiles")
+		d.mu.Lock()
+		d.status.Phase = api.DotfilesPhase_dotfiles_failed
+		d.status.Error = err.Error()
+		d.mu.Unlock()
+		return
+	}
+	dotfilesLog.WithField("repository", d.Repository).Info("dotfiles installed")
+	d.setPhase(api.DotfilesPhase_dotfiles_installed)
+}
+
+func (d *dotfilesInstaller) install(ctx context.Context) error {
+	logFile, err := os.OpenFile(d.LogLocation, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return xerrors.Errorf("cannot create log: %w", err)
+	}
+	defer logFile.Close()
+	out := &limitedWriter{W: logFile, N: dotfilesMaxLogSize}
+
+	d.setPhase(api.DotfilesPhase_dotfiles_cloning)
+	fmt.Fprintf(out, "cloning %s\n", d.Repository)
+	clone := exec.Command("git", "clone", "--depth=1", "--", d.Repository, d.Location)
+	// the clone must never wait for credentials
+	clone.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	err = runWithTimeout(ctx, clone, out, d.CloneTimeout)
+	if err != nil {
+		return xerrors.Errorf("cannot clone repository: %w", err)
+	}
+
+	d.setPhase(api.DotfilesPhase_dotfiles_installing)
+	script := findInstallScript(d.Location)
+	if script == "" {
+		fmt.Fprintf(out, "no install script found - linking files into %s\n", d.Home)
+		return linkDotfiles(d.Location, d.Home, out)
+	}
+
+	d.mu.Lock()
+	d.status.InstallScript = script
+	d.mu.Unlock()
+	fmt.Fprintf(out, "running %s\n", script)
+	fn := filepath.Join(d.Location, script)
+	if stat, err := os.Stat(fn); err == nil && stat.Mode()&0100 == 0 {
+		err = os.Chmod(fn, stat.Mode()|0111)
+		if err != nil {
+			return xerrors.Errorf("cannot make %s executable: %w", script, err)
+		}
+	}
+	install := exec.Command(fn)
+	install.Dir = d.Location
+	install.Env = append(os.Environ(), "DOTFILES_LOCATION="+d.Location)
+	err = runWithTimeout(ctx, install, out, d.InstallTimeout)
+	if err != nil {
+		return xerrors.Errorf("%s failed: %w", script, err)
+	}
+	return nil
+}
+
+// findInstallScript returns the install script of a dotfiles repository relative to it, or an empty string if there is none
+func findInstallScript(location string) string {
+	for _, script := range dotfilesInstallScripts {
+		stat, err := os.Stat(filepath.Join(location, script))
+		if err == nil && stat.Mode().IsRegular() {
+			return script
+		}
+	}
+	return ""
+}
+
+// linkDotfiles links the files of a dotfiles repository into the home directory. Existing files are kept.
+func linkDotfiles(location, home string, out io.Writer) error {
+	files, err := ioutil.ReadDir(location)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.Name() == ".git" {
+			continue
+		}
+		target := filepath.Join(home, f.Name())
+		if _, err := os.Lstat(target); err == nil {
+			fmt.Fprintf(out, "not linking %s: file exists\n", target)
+			continue
+		}
+		err = os.Symlink(filepath.Join(location, f.Name()), target)
+		if err != nil {
+			return xerrors.Errorf("cannot link %s: %w", f.Name(), err)
+		}
+		fmt.Fprintf(out, "linked %s\n", target)
+	}
+	return nil
+}
+
+// runWithTimeout runs a command with its output written to out. The command and all processes it started
+// are killed once the timeout passes.
+func runWithTimeout(ctx context.Context, cmd *exec.Cmd, out io.Writer, timeout time.Duration) error {
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err = <-done:
+	case <-time.After(timeout):
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		<-done
+		fmt.Fprintf(out, "killed after %s\n", timeout)
+		return xerrors.Errorf("timed out after %s", timeout)
+	case <-ctx.Done():
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		<-done
+		return ctx.Err()
+	}
+	if xerrors.Is(err, syscall.ECHILD) {
+		// the reaper collected the process before us, which leaves its exit code unknown
+		return nil
+	}
+	return err
+}
+
+// limitedWriter writes up to N bytes to W and drops everything beyond
+type limitedWriter struct {
+	W io.Writer
+	N int64
+
+	mu        sync.Mutex
+	truncated bool
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := len(p)
+	if w.N <= 0 {
+		if !w.truncated {
+			w.truncated = true
+			_, _ = fmt.Fprintln(w.W, "\n[output truncated]")
+		}
+		return n, nil
+	}
+	if int64(len(p)) > w.N {
+		p = p[:w.N]
+	}
+	written, err := w.W.Write(p)
+	w.N -= int64(written)
+	if err != nil {
+		return written, err
+	}
+	return n, nil
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+// newDotfilesRepo creates a Git repository with the given files
+func newDotfilesRepo(t *testing.T, dir string, files map[string]string) string {
+	repo := filepath.Join(dir, "repo")
+	for name, content := range files {
+		fn := filepath.Join(repo, name)
+		err := os.MkdirAll(filepath.Dir(fn), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(fn, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@gitpod.io", "commit", "-q", "-m", "dotfiles"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	return repo
+}
+
+func TestDotfilesInstaller(t *testing.T) {
+	tests := []struct {
+		Desc           string
+		Files          map[string]string
+		NoRepository   bool
+		InstallTimeout time.Duration
+		Phase          api.DotfilesPhase
+		InstallScript  string
+		Error          string
+		Home           []string
+	}{
+		{
+			Desc:         "no repository",
+			NoRepository: true,
+			Phase:        api.DotfilesPhase_dotfiles_none,
+		},
+		{
+			Desc:          "install script",
+			Files:         map[string]string{"setup.sh": "#!/bin/sh\nexit 1", "install.sh": "#!/bin/sh\necho installed > $HOME/.installed"},
+			Phase:         api.DotfilesPhase_dotfiles_installed,
+			InstallScript: "install.sh",
+			Home:          []string{".dotfiles", ".dotfiles.log", ".installed"},
+		},
+		{
+			Desc:  "linked files",
+			Files: map[string]string{".bashrc": "alias ll='ls -l'", ".config/git/ignore": ".DS_Store"},
+			Phase: api.DotfilesPhase_dotfiles_installed,
+			Home:  []string{".bashrc", ".config", ".dotfiles", ".dotfiles.log"},
+		},
+		{
+			Desc:          "failing install script",
+			Files:         map[string]string{"script/bootstrap": "#!/bin/sh\nexit 3"},
+			Phase:         api.DotfilesPhase_dotfiles_failed,
+			InstallScript: "script/bootstrap",
+			Error:         "script/bootstrap failed: exit status 3",
+		},
+		{
+			Desc:           "install script timeout",
+			Files:          map[string]string{"install": "#!/bin/sh\nsleep 60"},
+			InstallTimeout: 100 * time.Millisecond,
+			Phase:          api.DotfilesPhase_dotfiles_failed,
+			InstallScript:  "install",
+			Error:          "install failed: timed out after 100ms",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "dotfiles")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			home := filepath.Join(dir, "home")
+			err = os.Mkdir(home, 0755)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Setenv("HOME", os.Getenv("HOME"))
+			os.Setenv("HOME", home)
+
+			var repo string
+			if !test.NoRepository {
+				repo = newDotfilesRepo(t, dir, test.Files)
+			}
+			installer := newDotfilesInstaller(repo, home)
+			if test.InstallTimeout != 0 {
+				installer.InstallTimeout = test.InstallTimeout
+			}
+			installer.Run(context.Background())
+			select {
+			case <-installer.Done():
+			default:
+				t.Error("expected the installer to be done")
+			}
+
+			status := installer.Status()
+			if status.Phase != test.Phase {
+				t.Errorf("unexpected phase: %v", status.Phase)
+			}
+			if status.InstallScript != test.InstallScript {
+				t.Errorf("unexpected install script: %s", status.InstallScript)
+			}
+			if status.Error != test.Error {
+				t.Errorf("unexpected error: %s", status.Error)
+			}
+			if test.Home == nil {
+				return
+			}
+			files, err := ioutil.ReadDir(home)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, f := range files {
+				names = append(names, f.Name())
+			}
+			if strings.Join(names, " ") != strings.Join(test.Home, " ") {
+				t.Errorf("unexpected home directory: %v", names)
+			}
+		})
+	}
+}
+
+func TestLimitedWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := &limitedWriter{W: &out, N: 5}
+	for _, p := range []string{"abc", "def", "ghi"} {
+		n, err := w.Write([]byte(p))
+		if err != nil || n != len(p) {
+			t.Fatalf("unexpected write: %d %v", n, err)
+		}
+	}
+	if exp := "abcde\n[output truncated]\n"; out.String() != exp {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var envVarNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// EnvVarService implements the api.EnvVarService. The variables are stored with the Gitpod server.
+// Changes are applied to the supervisor's environment, which new terminals, tasks and SSH sessions inherit.
+// Shells which run already pick them up from File before their next prompt.
+type EnvVarService struct {
+	API         gitpod.APIInterface
+	WorkspaceID string
+
+	// File is where changed variables are written to as shell script, nothing is written if it is empty
+	File string
+
+	// setenv and unsetenv change the environment, os.Setenv and os.Unsetenv if nil
+	setenv   func(name, value string) error
+	unsetenv func(name string) error
+
+	mu   sync.Mutex
+	repo *gitpod.Repository
+
+	// changes are the variables changed since supervisor started, nil values are unset
+	changes   map[string]*string
+	changesMu sync.Mutex
+}
+
+// RegisterGRPC registers the gRPC env var service
+func (s *EnvVarService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterEnvVarServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST env var service
+func (s *EnvVarService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterEnvVarServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// PromptCommand returns a PROMPT_COMMAND which makes interactive bash shells load the changed variables
+// from File, chained with the current PROMPT_COMMAND.
+func (s *EnvVarService) PromptCommand() string {
+	if s.File == "" {
+		return os.Getenv("PROMPT_COMMAND")
+	}
+	res := fmt.Sprintf("[ -f %[1]s ] && . %[1]s", shellQuote(s.File))
+	prev := os.Getenv("PROMPT_COMMAND")
+	if strings.Contains(prev, res) {
+		// a restarted supervisor inherits the PROMPT_COMMAND of the previous one
+		return prev
+	}
+	if prev != "" {
+		res += "; " + prev
+	}
+	return res
+}
+
+// repository returns the repository of the workspace, which does not change during the workspace's lifetime
+func (s *EnvVarService) repository(ctx context.Context) (*gitpod.Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.repo != nil {
+		return s.repo, nil
+	}
+
+	ws, err := s.API.GetWorkspace(ctx, s.WorkspaceID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "cannot get workspace: %v", err)
+	}
+	if ws.Workspace == nil || ws.Workspace.Context == nil || ws.Workspace.Context.Repository == nil {
+		return nil, status.Error(codes.FailedPrecondition, "workspace has no repository")
+	}
+	s.repo = ws.Workspace.Context.Repository
+	return s.repo, nil
+}
+
+// applicableEnvVars fetches the variables which apply to the repository of the workspace
+func (s *EnvVarService) applicableEnvVars(ctx context.Context) (vars []*gitpod.UserEnvVarValue, repo *gitpod.Repository, err error) {
+	repo, err = s.repository(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	all, err := s.API.GetEnvVars(ctx)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Unavailable, "cannot get environment variables: %v", err)
+	}
+	return filterEnvVars(all, repo.Owner, repo.Name), repo, nil
+}
+
+// ListEnvVars lists the environment variables which apply to the workspace
+func (s *EnvVarService) ListEnvVars(ctx context.Context, req *api.ListEnvVarsRequest) (*api.ListEnvVarsResponse, error) {
+	vars, repo, err := s.applicableEnvVars(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &api.ListEnvVarsResponse{RepositoryPattern: repositoryPattern(repo)}
+	for _, v := range vars {
+		res.Variables = append(res.Variables, &api.EnvVar{Name: v.Name, Value: v.Value, RepositoryPattern: v.RepositoryPattern})
+	}
+	return res, nil
+}
+
+// scopePattern returns the repository pattern variables of a scope are stored with
+func (s *EnvVarService) scopePattern(ctx context.Context, scope api.EnvVarScope) (string, error) {
+	switch scope {
+	case api.EnvVarScope_env_var_scope_project:
+		repo, err := s.repository(ctx)
+		if err != nil {
+			return "", err
+		}
+		return repositoryPattern(repo), nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unknown scope %v", scope)
+	}
+}
+
+// SetEnvVars sets environment variables for the repository of the workspace
+func (s *EnvVarService) SetEnvVars(ctx context.Context, req *api.SetEnvVarsRequest) (*api.SetEnvVarsResponse, error) {
+	for _, v := range req.Variables {
+		if !envVarNamePattern.MatchString(v.Name) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid variable name %q", v.Name)
+		}
+		if v.Value == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "variable %s must have a value - unset it instead", v.Name)
+		}
+	}
+	pattern, err := s.scopePattern(ctx, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range req.Variables {
+		// the server overwrites an existing variable of the same name and pattern
+		err := s.API.SetEnvVar(ctx, &gitpod.UserEnvVarValue{Name: v.Name, Value: v.Value, RepositoryPattern: pattern})
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "cannot set %s: %v", v.Name, err)
+		}
+	}
+	// a variable of the repository takes precedence over those of broader patterns
+	for _, v := range req.Variables {
+		s.apply(v.Name, v.Value)
+	}
+	s.writeChanges()
+	return &api.SetEnvVarsResponse{}, nil
+}
+
+// UnsetEnvVars deletes the variables of the repository of the workspace
+func (s *EnvVarService) UnsetEnvVars(ctx context.Context, req *api.UnsetEnvVarsRequest) (*api.UnsetEnvVarsResponse, error) {
+	pattern, err := s.scopePattern(ctx, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.API.GetEnvVars(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "cannot get environment variables: %v", err)
+	}
+
+	res := &api.UnsetEnvVarsResponse{}
+	for _, name := range req.Names {
+		var existing *gitpod.UserEnvVarValue
+		for _, v := range all {
+			if v.Name == name && strings.ToLower(v.RepositoryPattern) == pattern {
+				existing = v
+				break
+			}
+		}
+		if existing == nil {
+			res.NotUnset = append(res.NotUnset, name)
+			continue
+		}
+		err := s.API.DeleteEnvVar(ctx, existing)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "cannot unset %s: %v", name, err)
+		}
+		res.Unset = append(res.Unset, name)
+	}
+	if len(res.Unset) == 0 {
+		return res, nil
+	}
+
+	// a variable of a broader pattern, e.g. */*, applies once the variable of the repository is gone
+	err = s.applyEffective(ctx, res.Unset)
+	if err != nil {
+		log.WithError(err).Warn("cannot update environment after unsetting variables")
+	}
+	return res, nil
+}
+
+// applyEffective applies the values of the variables which apply to the workspace, and removes those which do not apply anymore
+func (s *EnvVarService) applyEffective(ctx context.Context, names []string) error {
+	vars, _, err := s.applicableEnvVars(ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		value, found := "", false
+		for _, v := range vars {
+			if v.Name == name {
+				value, found = v.Value, true
+				break
+			}
+		}
+		if found {
+			s.apply(name, value)
+		} else {
+			s.remove(name)
+		}
+	}
+	s.writeChanges()
+	return nil
+}
+
+func (s *EnvVarService) apply(name, value string) {
+	setenv := s.setenv
+	if setenv == nil {
+		setenv = os.Setenv
+	}
+	err := setenv(name, value)
+	if err != nil {
+		log.WithError(err).WithField("name", name).Warn("cannot set environment variable")
+	}
+	s.recordChange(name, &value)
+}
+
+func (s *EnvVarService) remove(name string) {
+	unsetenv := s.unsetenv
+	if unsetenv == nil {
+		unsetenv = os.Unsetenv
+	}
+	err := unsetenv(name)
+	if err != nil {
+		log.WithError(err).WithField("name", name).Warn("cannot unset environment variable")
+	}
+	s.recordChange(name, nil)
+}
+
+func (s *EnvVarService) recordChange(name string, value *string) {
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+	if s.changes == nil {
+		s.changes = make(map[string]*string)
+	}
+	s.changes[name] = value
+}
+
+// writeChanges writes the changed variables to File, s.t. running shells pick them up
+func (s *EnvVarService) writeChanges() {
+	if s.File == "" {
+		return
+	}
+	err := s.write()
+	if err != nil {
+		log.WithError(err).WithField("file", s.File).Warn("cannot write environment variables")
+	}
+}
+
+// write replaces File at once, s.t. shells never source a partially written file
+func (s *EnvVarService) write() error {
+	s.changesMu.Lock()
+	defer s.changesMu.Unlock()
+
+	names := make([]string, 0, len(s.changes))
+	for name := range s.changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var script strings.Builder
+	script.WriteString("# generated by supervisor - changed environment variables\n")
+	for _, name := range names {
+		if value := s.changes[name]; value != nil {
+			fmt.Fprintf(&script, "export %s=%s\n", name, shellQuote(*value))
+		} else {
+			fmt.Fprintf(&script, "unset %s\n", name)
+		}
+	}
+
+	err := os.MkdirAll(filepath.Dir(s.File), 0755)
+	if err != nil {
+		return err
+	}
+	tmp := s.File + ".tmp"
+	err = ioutil.WriteFile(tmp, []byte(script.String()), 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.File)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+func repositoryPattern(repo *gitpod.Repository) string {
+	return strings.ToLower(repo.Owner) + "/" + strings.ToLower(repo.Name)
+}
+
+// filterEnvVars returns the variables which apply to a repository. If several variables of the same name apply,
+// the one with the most specific pattern wins: owner/repo, then owner/*, then */repo, then */* and last #/#.
+// This mirrors UserEnvVar.filter of the Gitpod server.
+func filterEnvVars(vars []*gitpod.UserEnvVarValue, owner, repo string) []*gitpod.UserEnvVarValue {
+	owner, repo = strings.ToLower(owner), strings.ToLower(repo)
+	type candidate struct {
+		idx   int
+		score int
+	}
+	var (
+		res  []*gitpod.UserEnvVarValue
+		best = make(map[string]candidate)
+	)
+	for _, v := range vars {
+		segs := strings.SplitN(strings.ToLower(v.RepositoryPattern), "/", 2)
+		ownerPattern, repoPattern := segs[0], ""
+		if len(segs) > 1 {
+			repoPattern = segs[1]
+		}
+		if ownerPattern != "*" && ownerPattern != "#" && owner != "" && ownerPattern != owner {
+			continue
+		}
+		if repoPattern != "*" && repoPattern != "#" && repo != "" && repoPattern != repo {
+			continue
+		}
+
+		// the lower the score, the higher the precedence
+		var score int
+		if repoPattern == "*" {
+			score++
+		}
+		if ownerPattern == "*" {
+			score += 2
+		}
+		if ownerPattern == "#" || repoPattern == "#" {
+			score = 4
+		}
+
+		if c, exists := best[v.Name]; exists {
+			if score < c.score {
+				res[c.idx] = v
+				best[v.Name] = candidate{c.idx, score}
+			}
+			continue
+		}
+		best[v.Name] = candidate{len(res), score}
+		res = append(res, v)
+	}
+	return res
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFilterEnvVars(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Vars        []*gitpod.UserEnvVarValue
+		Expectation []string
+	}{
+		{
+			Desc: "other repositories",
+			Vars: []*gitpod.UserEnvVarValue{
+				{Name: "A", RepositoryPattern: "gitpod-io/website"},
+				{Name: "B", RepositoryPattern: "foo/*"},
+				{Name: "C", RepositoryPattern: "*/website"},
+			},
+		},
+		{
+			Desc: "precedence",
+			Vars: []*gitpod.UserEnvVarValue{
+				{Name: "A", Value: "any", RepositoryPattern: "*/*"},
+				{Name: "A", Value: "exact", RepositoryPattern: "Gitpod-IO/Gitpod"},
+				{Name: "A", Value: "owner", RepositoryPattern: "gitpod-io/*"},
+				{Name: "B", Value: "url", RepositoryPattern: "#/#"},
+				{Name: "B", Value: "repo", RepositoryPattern: "*/gitpod"},
+				{Name: "C", Value: "url", RepositoryPattern: "#/#"},
+			},
+			Expectation: []string{"A=exact", "B=repo", "C=url"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			var act []string
+			for _, v := range filterEnvVars(test.Vars, "gitpod-io", "gitpod") {
+				act = append(act, v.Name+"="+v.Value)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected variables (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnvVarService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+	gitpodAPI.EXPECT().GetWorkspace(gomock.Any(), "ws").Return(&gitpod.WorkspaceInfo{
+		Workspace: &gitpod.Workspace{Context: &gitpod.WorkspaceContext{Repository: &gitpod.Repository{Owner: "gitpod-io", Name: "Gitpod"}}},
+	}, nil)
+
+	env := make(map[string]string)
+	srv := &EnvVarService{
+		API:         gitpodAPI,
+		WorkspaceID: "ws",
+		setenv: func(name, value string) error {
+			env[name] = value
+			return nil
+		},
+		unsetenv: func(name string) error {
+			delete(env, name)
+			return nil
+		},
+	}
+
+	_, err := srv.SetEnvVars(context.Background(), &api.SetEnvVarsRequest{Variables: []*api.EnvVar{{Name: "1FOO", Value: "bar"}}})
+	if err == nil {
+		t.Error("expected an invalid name to be rejected")
+	}
+
+	gitpodAPI.EXPECT().SetEnvVar(gomock.Any(), &gitpod.UserEnvVarValue{Name: "FOO", Value: "bar", RepositoryPattern: "gitpod-io/gitpod"}).Return(nil)
+	_, err = srv.SetEnvVars(context.Background(), &api.SetEnvVarsRequest{Variables: []*api.EnvVar{{Name: "FOO", Value: "bar"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env["FOO"] != "bar" {
+		t.Errorf("expected the variable to be applied: %v", env)
+	}
+
+	exact := &gitpod.UserEnvVarValue{ID: "1", Name: "FOO", Value: "bar", RepositoryPattern: "gitpod-io/gitpod"}
+	broader := &gitpod.UserEnvVarValue{ID: "2", Name: "FOO", Value: "any", RepositoryPattern: "*/*"}
+	other := &gitpod.UserEnvVarValue{ID: "3", Name: "BAR", Value: "baz", RepositoryPattern: "*/*"}
+	gomock.InOrder(
+		gitpodAPI.EXPECT().GetEnvVars(gomock.Any()).Return([]*gitpod.UserEnvVarValue{exact, broader, other}, nil),
+		gitpodAPI.EXPECT().DeleteEnvVar(gomock.Any(), exact).Return(nil),
+		gitpodAPI.EXPECT().GetEnvVars(gomock.Any()).Return([]*gitpod.UserEnvVarValue{broader, other}, nil),
+	)
+	resp, err := srv.UnsetEnvVars(context.Background(), &api.UnsetEnvVarsRequest{Names: []string{"FOO", "BAR"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"FOO"}, resp.Unset); diff != "" {
+		t.Errorf("unexpected unset variables (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"BAR"}, resp.NotUnset); diff != "" {
+		t.Errorf("unexpected variables not unset (-want +got):\n%s", diff)
+	}
+	if env["FOO"] != "any" {
+		t.Errorf("expected the variable of the broader pattern to apply: %v", env)
+	}
+}
+
+func TestEnvVarServiceWritesChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+	gitpodAPI.EXPECT().GetWorkspace(gomock.Any(), "ws").Return(&gitpod.WorkspaceInfo{
+		Workspace: &gitpod.Workspace{Context: &gitpod.WorkspaceContext{Repository: &gitpod.Repository{Owner: "gitpod-io", Name: "gitpod"}}},
+	}, nil)
+
+	dir, err := ioutil.TempDir("", "envvars")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	env := make(map[string]string)
+	srv := &EnvVarService{
+		API:         gitpodAPI,
+		WorkspaceID: "ws",
+		File:        filepath.Join(dir, "env-vars.env"),
+		setenv: func(name, value string) error {
+			env[name] = value
+			return nil
+		},
+		unsetenv: func(name string) error {
+			delete(env, name)
+			return nil
+		},
+	}
+
+	_, err = srv.SetEnvVars(context.Background(), &api.SetEnvVarsRequest{
+		Variables: []*api.EnvVar{{Name: "FOO", Value: "user"}},
+		Scope:     api.EnvVarScope(1),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected the scope of all repositories of the user to be rejected, got %v", err)
+	}
+
+	foo := &gitpod.UserEnvVarValue{Name: "FOO", Value: "repo", RepositoryPattern: "gitpod-io/gitpod"}
+	bar := &gitpod.UserEnvVarValue{Name: "BAR", Value: "it's", RepositoryPattern: "gitpod-io/gitpod"}
+	gomock.InOrder(
+		gitpodAPI.EXPECT().SetEnvVar(gomock.Any(), foo).Return(nil),
+		gitpodAPI.EXPECT().SetEnvVar(gomock.Any(), bar).Return(nil),
+	)
+	_, err = srv.SetEnvVars(context.Background(), &api.SetEnvVarsRequest{
+		Variables: []*api.EnvVar{{Name: "FOO", Value: "repo"}, {Name: "BAR", Value: "it's"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"FOO": "repo", "BAR": "it's"}, env); diff != "" {
+		t.Errorf("unexpected environment (-want +got):\n%s", diff)
+	}
+
+	gomock.InOrder(
+		gitpodAPI.EXPECT().GetEnvVars(gomock.Any()).Return([]*gitpod.UserEnvVarValue{foo, bar}, nil),
+		gitpodAPI.EXPECT().DeleteEnvVar(gomock.Any(), bar).Return(nil),
+		gitpodAPI.EXPECT().GetEnvVars(gomock.Any()).Return([]*gitpod.UserEnvVarValue{foo}, nil),
+	)
+	resp, err := srv.UnsetEnvVars(context.Background(), &api.UnsetEnvVarsRequest{Names: []string{"BAR"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"BAR"}, resp.Unset); diff != "" {
+		t.Errorf("unexpected unset variables (-want +got):\n%s", diff)
+	}
+
+	script, err := ioutil.ReadFile(srv.File)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectation := "# generated by supervisor - changed environment variables\nunset BAR\nexport FOO='repo'\n"
+	if diff := cmp.Diff(expectation, string(script)); diff != "" {
+		t.Errorf("unexpected script (-want +got):\n%s", diff)
+	}
+}
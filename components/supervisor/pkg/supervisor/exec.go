@@ -0,0 +1,198 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+
+	"github.com/creack/pty"
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExecService implements the api.ExecService. Commands run with the environment of terminals, but without
+// occupying one.
+type ExecService struct {
+	// Workdir is the working directory of commands which do not specify one
+	Workdir string
+	// Env provides additional environment variables for commands if set
+	Env func() []string
+	// Processes provides the exit status of commands the reaper reaped before they were waited for, if set
+	Processes *processTracker
+}
+
+// RegisterGRPC registers the gRPC exec service
+func (s *ExecService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterExecServiceServer(srv, s)
+}
+
+// Exec runs a command and streams its output and exit code
+func (s *ExecService) Exec(req *api.ExecRequest, srv api.ExecService_ExecServer) error {
+	if req.Command == "" {
+		return status.Error(codes.InvalidArgument, "command is required")
+	}
+
+	cmd := exec.Command(req.Command, req.Args...)
+	cmd.Dir = req.Workdir
+	if cmd.Dir == "" {
+		cmd.Dir = s.Workdir
+	}
+	cmd.Env = os.Environ()
+	if req.Tty {
+		cmd.Env = append(cmd.Env, "TERM=xterm-color")
+	}
+	if s.Env != nil {
+		cmd.Env = append(cmd.Env, s.Env()...)
+	}
+	cmd.Env = append(cmd.Env, req.Env...)
+
+	out := &execOutput{srv: srv}
+	output := make(chan struct{})
+	if req.Tty {
+		var size *pty.Winsize
+		if req.TtySize != nil {
+			size = &pty.Winsize{Cols: uint16(req.TtySize.Cols), Rows: uint16(req.TtySize.Rows)}
+		}
+		// the command runs in its own session and hence process group
+		ptmx, err := pty.StartWithSize(cmd, size)
+		if err != nil {
+			return startError(err)
+		}
+		defer ptmx.Close()
+		go func() {
+			defer close(output)
+			// reading fails once all processes closed the terminal
+			_, _ = io.Copy(out.Writer(false), ptmx)
+		}()
+		if len(req.Stdin) > 0 {
+			_, err = ptmx.Write(req.Stdin)
+			if err != nil {
+				log.WithError(err).Debug("cannot write stdin of command")
+			}
+		}
+	} else {
+		if len(req.Stdin) > 0 {
+			cmd.Stdin = bytes.NewReader(req.Stdin)
+		}
+		cmd.Stdout = out.Writer(false)
+		cmd.Stderr = out.Writer(true)
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		err := cmd.Start()
+		if err != nil {
+			return startError(err)
+		}
+		// os/exec copies the output before Wait returns
+		close(output)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	var err error
+	select {
+	case err = <-exited:
+	case <-srv.Context().Done():
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		<-exited
+		return status.Error(codes.Canceled, srv.Context().Err().Error())
+	}
+	select {
+	case <-output:
+	case <-srv.Context().Done():
+		// processes the command left behind still hold the terminal
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		return status.Error(codes.Canceled, srv.Context().Err().Error())
+	}
+
+	var exitCode int32
+	if errors.Is(err, syscall.ECHILD) && s.Processes != nil {
+		// the reaper collected the process before us
+		ws, err := s.Processes.awaitReaped(srv.Context(), cmd.Process.Pid)
+		if err != nil {
+			return status.Error(codes.Canceled, err.Error())
+		}
+		exitCode = -1
+		if ws.Exited() {
+			exitCode = int32(ws.ExitStatus())
+		}
+	} else if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return status.Errorf(codes.Internal, "cannot wait for command: %v", err)
+		}
+		// ExitCode is -1 if the command was killed by a signal
+		exitCode = int32(exitErr.ExitCode())
+	}
+	if err := out.Err(); err != nil {
+		return err
+	}
+	return srv.Send(&api.ExecResponse{Output: &api.ExecResponse_ExitCode{ExitCode: exitCode}})
+}
+
+func startError(err error) error {
+	if errors.Is(err, exec.ErrNotFound) || os.IsNotExist(err) {
+		return status.Errorf(codes.NotFound, "cannot start command: %v", err)
+	}
+	return status.Errorf(codes.FailedPrecondition, "cannot start command: %v", err)
+}
+
+// execOutput sends the stdout and stderr of a command to the client, one message at a time
+type execOutput struct {
+	srv api.ExecService_ExecServer
+
+	mu  sync.Mutex
+	err error
+}
+
+// Writer returns the writer of stdout, or of stderr
+func (o *execOutput) Writer(stderr bool) io.Writer {
+	return execOutputWriter{o, stderr}
+}
+
+// Err returns the error sending the output failed with, if any
+func (o *execOutput) Err() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.err
+}
+
+func (o *execOutput) send(p []byte, stderr bool) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return 0, o.err
+	}
+
+	// the stream marshals the message before Send returns, hence p need not be copied
+	resp := &api.ExecResponse{Output: &api.ExecResponse_Stdout{Stdout: p}}
+	if stderr {
+		resp.Output = &api.ExecResponse_Stderr{Stderr: p}
+	}
+	o.err = o.srv.Send(resp)
+	if o.err != nil {
+		return 0, o.err
+	}
+	return len(p), nil
+}
+
+type execOutputWriter struct {
+	o      *execOutput
+	stderr bool
+}
+
+func (w execOutputWriter) Write(p []byte) (int, error) {
+	return w.o.send(p, w.stderr)
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExec(t *testing.T) {
+	workdir, err := ioutil.TempDir("", "supervisor-exec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workdir)
+
+	type Expectation struct {
+		Stdout   string
+		Stderr   string
+		ExitCode int32
+		Code     codes.Code
+	}
+	tests := []struct {
+		Desc        string
+		Req         *api.ExecRequest
+		Expectation Expectation
+	}{
+		{
+			Desc: "stdout and stderr",
+			Req:  &api.ExecRequest{Command: "/bin/sh", Args: []string{"-c", "echo out; echo err >&2"}},
+			Expectation: Expectation{
+				Stdout: "out\n",
+				Stderr: "err\n",
+			},
+		},
+		{
+			Desc:        "exit code",
+			Req:         &api.ExecRequest{Command: "/bin/sh", Args: []string{"-c", "exit 3"}},
+			Expectation: Expectation{ExitCode: 3},
+		},
+		{
+			Desc:        "env",
+			Req:         &api.ExecRequest{Command: "/bin/sh", Args: []string{"-c", "echo $SERVICE_ENV $REQUEST_ENV"}, Env: []string{"REQUEST_ENV=request"}},
+			Expectation: Expectation{Stdout: "service request\n"},
+		},
+		{
+			Desc:        "default workdir",
+			Req:         &api.ExecRequest{Command: "pwd"},
+			Expectation: Expectation{Stdout: workdir + "\n"},
+		},
+		{
+			Desc:        "workdir",
+			Req:         &api.ExecRequest{Command: "pwd", Workdir: "/"},
+			Expectation: Expectation{Stdout: "/\n"},
+		},
+		{
+			Desc:        "stdin",
+			Req:         &api.ExecRequest{Command: "cat", Stdin: []byte("hello")},
+			Expectation: Expectation{Stdout: "hello"},
+		},
+		{
+			Desc:        "tty",
+			Req:         &api.ExecRequest{Command: "/bin/sh", Args: []string{"-c", "test -t 1 && echo tty; echo err >&2"}, Tty: true},
+			Expectation: Expectation{Stdout: "tty\r\nerr\r\n"},
+		},
+		{
+			Desc:        "missing command",
+			Req:         &api.ExecRequest{},
+			Expectation: Expectation{Code: codes.InvalidArgument},
+		},
+		{
+			Desc:        "unknown command",
+			Req:         &api.ExecRequest{Command: "does-not-exist"},
+			Expectation: Expectation{Code: codes.NotFound},
+		},
+	}
+
+	client := startExecService(t, &ExecService{
+		Workdir: workdir,
+		Env:     func() []string { return []string{"SERVICE_ENV=service"} },
+	})
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			var act Expectation
+			stream, err := client.Exec(ctx, test.Req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					act.Code = status.Code(err)
+					break
+				}
+				switch output := resp.Output.(type) {
+				case *api.ExecResponse_Stdout:
+					act.Stdout += string(output.Stdout)
+				case *api.ExecResponse_Stderr:
+					act.Stderr += string(output.Stderr)
+				case *api.ExecResponse_ExitCode:
+					act.ExitCode = output.ExitCode
+				}
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExecCancel(t *testing.T) {
+	client := startExecService(t, &ExecService{Workdir: "/"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the background process must be killed too, otherwise it holds stdout open
+	stream, err := client.Exec(ctx, &api.ExecRequest{Command: "/bin/sh", Args: []string{"-c", "sleep 60 & echo $!; wait"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(resp.GetStdout())))
+	if err != nil {
+		t.Fatalf("unexpected output: %q", resp.GetStdout())
+	}
+
+	cancel()
+	_, err = stream.Recv()
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for i := 0; ; i++ {
+		// the killed process may remain a zombie until it is reaped
+		stat, err := readProcStat("/proc", pid)
+		if err != nil || stat.State == "Z" {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("background process %d was not killed", pid)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestExecWithReaper(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+	processes := newProcessTracker(nil)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// unlike the reaper, which reaps once signaled, this reaps as soon as a child exits and hence wins the race
+		// against the service for most commands
+		for ctx.Err() == nil {
+			var status unix.WaitStatus
+			pid, err := unix.Wait4(-1, &status, 0, nil)
+			if err != nil {
+				time.Sleep(time.Millisecond)
+				continue
+			}
+			processes.reapedChild(pid, syscall.WaitStatus(status))
+		}
+	}()
+
+	client := startExecService(t, &ExecService{Workdir: "/", Processes: processes})
+	for i := 0; i < 50; i++ {
+		stream, err := client.Exec(ctx, &api.ExecRequest{Command: "/bin/sh", Args: []string{"-c", "exit 3"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("command %d failed: %v", i, err)
+		}
+		if resp.GetExitCode() != 3 {
+			t.Fatalf("unexpected exit code of command %d: %d", i, resp.GetExitCode())
+		}
+	}
+}
+
+func startExecService(t *testing.T, service *ExecService) api.ExecServiceClient {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	service.RegisterGRPC(srv)
+	go srv.Serve(l)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return api.NewExecServiceClient(conn)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// gitScopeRead is the token scope to fetch from the repositories of a Git host
+	gitScopeRead = "git:read"
+	// gitScopeWrite is the token scope to push to the repositories of a Git host
+	gitScopeWrite = "git:write"
+
+	// gitTokenTTL is the maximum time Git tokens are cached for, s.t. tokens the server refreshed or the user revoked
+	// are picked up eventually
+	gitTokenTTL = 30 * time.Minute
+)
+
+// gitTokenProvider provides the tokens of the Git hosts the workspace owner authorized Gitpod with.
+// It is asked for tokens of any host and only provides tokens for requests which ask for Git scopes only.
+// The workspace token only grants access to the tokens of some hosts, e.g. of the host of the repository
+// the workspace was started from. Tokens of other hosts are not fetched.
+type gitTokenProvider struct {
+	API gitpod.APIInterface
+	// Tokens provides the workspace token, which has to grant access to the Git token of a host
+	Tokens api.TokenServiceServer
+	// GitpodHost is the host of the Gitpod API
+	GitpodHost string
+}
+
+// gitTokenAccessScopes are the scopes of the workspace token which grant access to the Git token of a host
+func gitTokenAccessScopes(host string) []string {
+	return []string{"function:getToken", "resource:token::" + host + "::get"}
+}
+
+// GetToken fetches the Git token of a host from the Gitpod server
+func (p *gitTokenProvider) GetToken(ctx context.Context, req *api.GetTokenRequest) (*token, error) {
+	if !isGitTokenRequest(req.Scope) {
+		return nil, nil
+	}
+
+	_, err := p.Tokens.GetToken(ctx, &api.GetTokenRequest{Host: p.GitpodHost, Scope: gitTokenAccessScopes(req.Host)})
+	if status.Code(err) == codes.NotFound {
+		// the server would deny access to the token of this host anyway
+		return nil, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("cannot get access to the Git token of %s: %w", req.Host, err)
+	}
+
+	tkn, err := p.API.GetToken(ctx, &gitpod.GetTokenSearchOptions{Host: req.Host})
+	if err != nil {
+		return nil, xerrors.Errorf("cannot fetch Git token: %w", err)
+	}
+	if tkn == nil || tkn.Value == "" {
+		return nil, xerrors.Errorf("no Git token available for %s", req.Host)
+	}
+
+	// The server hands out the OAuth token of the host, which grants whatever access the user authorized on that host.
+	// The token is not narrowed to the requested scope, hence it is cached for reading and writing alike.
+	scopes := []string{gitScopeRead, gitScopeWrite}
+
+	expiry := time.Now().Add(gitTokenTTL)
+	if tkn.ExpiryDate != "" {
+		if t, err := time.Parse(time.RFC3339, tkn.ExpiryDate); err == nil && t.Before(expiry) {
+			expiry = t
+		}
+	}
+	user := tkn.Username
+	if user == "" {
+		// Git hosts ignore the user name for OAuth tokens, but Git requires one
+		user = "oauth2"
+	}
+	return &token{
+		Token:      tkn.Value,
+		User:       user,
+		Host:       req.Host,
+		Scope:      mapScopes(scopes),
+		ExpiryDate: &expiry,
+		Reuse:      api.TokenReuse_REUSE_WHEN_POSSIBLE,
+	}, nil
+}
+
+// isGitTokenRequest returns true if all requested scopes are Git scopes
+func isGitTokenRequest(scopes []string) bool {
+	if len(scopes) == 0 {
+		return false
+	}
+	for _, scp := range scopes {
+		if !strings.HasPrefix(scp, "git:") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGitTokenProvider(t *testing.T) {
+	soon := time.Now().Add(5 * time.Minute).UTC().Truncate(time.Second)
+	tests := []struct {
+		Desc string
+		// Host is the host a token is requested for, github.com if empty
+		Host   string
+		Scopes []string
+		Token  *gitpod.Token
+		// Fetches is true if the provider is expected to ask the server
+		Fetches bool
+
+		User   string
+		Scope  []string
+		Expiry time.Time
+		Err    bool
+	}{
+		{
+			Desc:   "no git scopes",
+			Scopes: []string{"function:getToken"},
+		},
+		{
+			Desc:   "mixed scopes",
+			Scopes: []string{"git:read", "function:getToken"},
+		},
+		{
+			Desc:    "read",
+			Scopes:  []string{"git:read"},
+			Token:   &gitpod.Token{Value: "secret", Username: "octocat"},
+			Fetches: true,
+			User:    "octocat",
+			Scope:   []string{"git:read", "git:write"},
+		},
+		{
+			Desc:    "write",
+			Scopes:  []string{"git:write"},
+			Token:   &gitpod.Token{Value: "secret", ExpiryDate: soon.Format(time.RFC3339)},
+			Fetches: true,
+			User:    "oauth2",
+			Scope:   []string{"git:read", "git:write"},
+			Expiry:  soon,
+		},
+		{
+			Desc:    "no token",
+			Scopes:  []string{"git:read"},
+			Fetches: true,
+			Err:     true,
+		},
+		{
+			Desc:   "host without access",
+			Host:   "gitlab.com",
+			Scopes: []string{"git:read"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+			if test.Fetches {
+				gitpodAPI.EXPECT().GetToken(gomock.Any(), &gitpod.GetTokenSearchOptions{Host: "github.com"}).Return(test.Token, nil)
+			}
+
+			host := test.Host
+			if host == "" {
+				host = "github.com"
+			}
+			p := &gitTokenProvider{API: gitpodAPI, Tokens: workspaceTokenService(t, "github.com"), GitpodHost: "gitpod.io"}
+			tkn, err := p.GetToken(context.Background(), &api.GetTokenRequest{Host: host, Scope: test.Scopes})
+			if test.Err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.Scope == nil {
+				if tkn != nil {
+					t.Errorf("expected no token: %+v", tkn)
+				}
+				return
+			}
+
+			if tkn.Token != test.Token.Value || tkn.User != test.User || tkn.Host != "github.com" {
+				t.Errorf("unexpected token: %+v", tkn)
+			}
+			if diff := cmp.Diff(mapScopes(test.Scope), tkn.Scope); diff != "" {
+				t.Errorf("unexpected scopes (-want +got):\n%s", diff)
+			}
+			expiry := test.Expiry
+			if expiry.IsZero() {
+				expiry = time.Now().Add(gitTokenTTL)
+			}
+			if d := tkn.ExpiryDate.Sub(expiry); d < -time.Second || d > time.Second {
+				t.Errorf("unexpected expiry date: %v", tkn.ExpiryDate)
+			}
+		})
+	}
+}
+
+// defaultWorkspaceTokenScopes are the scopes the server grants the default workspace token, see
+// createDefaultGitpodAPITokenScopes in workspace-starter.ts. contextHost is the host of the repository the
+// workspace was started from, empty if it was not started from a repository.
+func defaultWorkspaceTokenScopes(contextHost string) []string {
+	res := []string{
+		"function:getWorkspace",
+		"function:getLoggedInUser",
+		"function:getPortAuthenticationToken",
+		"function:getWorkspaceOwner",
+		"function:getWorkspaceUsers",
+		"function:isWorkspaceOwner",
+		"function:controlAdmission",
+		"function:setWorkspaceTimeout",
+		"function:getWorkspaceTimeout",
+		"function:sendHeartBeat",
+		"function:getOpenPorts",
+		"function:openPort",
+		"function:closePort",
+		"function:auditPortExposure",
+		"function:getSSHPublicKeys",
+		"function:getToken",
+		"function:getEnvVars",
+		"function:setEnvVar",
+		"function:deleteEnvVar",
+		"function:getLayout",
+		"function:generateNewGitpodToken",
+		"function:takeSnapshot",
+		"function:storeLayout",
+		"function:stopWorkspace",
+		"resource:workspace::workspace-id::get/update",
+		"resource:workspaceInstance::instance-id::get/update/delete",
+		"resource:snapshot::*::create/get",
+		"resource:gitpodToken::*::create",
+		"resource:userStorage::*::create/get/update",
+	}
+	if contextHost != "" {
+		res = append(res,
+			"resource:envVar::owner/repo::create/update/delete",
+			"resource:token::"+contextHost+"::get",
+		)
+	}
+	return res
+}
+
+// workspaceTokenService provides the default workspace token for gitpod.io
+func workspaceTokenService(t *testing.T, contextHost string) *InMemoryTokenService {
+	tokens := NewInMemoryTokenService()
+	_, err := tokens.SetToken(context.Background(), &api.SetTokenRequest{
+		Host:  "gitpod.io",
+		Scope: defaultWorkspaceTokenScopes(contextHost),
+		Token: "workspace-token",
+		Reuse: api.TokenReuse_REUSE_WHEN_POSSIBLE,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokens
+}
+
+func TestGitpodAPIScopes(t *testing.T) {
+	for _, contextHost := range []string{"github.com", ""} {
+		tokens := workspaceTokenService(t, contextHost)
+		resp, err := tokens.GetToken(context.Background(), &api.GetTokenRequest{Host: "gitpod.io", Scope: gitpodAPIScopes})
+		if err != nil {
+			t.Fatalf("cannot get token for the Gitpod API with context host %q: %v", contextHost, err)
+		}
+		if resp.Token != "workspace-token" {
+			t.Errorf("unexpected token %q", resp.Token)
+		}
+
+		_, err = tokens.GetToken(context.Background(), &api.GetTokenRequest{Host: "gitpod.io", Scope: gitTokenAccessScopes("github.com")})
+		if hasAccess := err == nil; hasAccess != (contextHost != "") {
+			t.Errorf("unexpected access to the Git token of github.com with context host %q: %v", contextHost, err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Names of the subsystems which report their health
+const (
+	healthIDE        = "ide"
+	healthDesktopIDE = "desktop-ide"
+	healthContent    = "content"
+	healthTasks      = "tasks"
+	healthTerminals  = "terminals"
+	healthPorts      = "ports"
+	healthGitpodAPI  = "gitpod-api"
+	healthDotfiles   = "dotfiles"
+	healthSSH        = "ssh"
+)
+
+// healthRegistry collects the health of supervisor's subsystems
+type healthRegistry struct {
+	mu         sync.RWMutex
+	subsystems []*subsystemHealth
+}
+
+func newHealthRegistry() *healthRegistry {
+	return &healthRegistry{}
+}
+
+// register adds a subsystem, which is starting until it reports otherwise
+func (r *healthRegistry) register(name string) *subsystemHealth {
+	h := &subsystemHealth{name: name, since: time.Now()}
+	r.mu.Lock()
+	r.subsystems = append(r.subsystems, h)
+	r.mu.Unlock()
+	return h
+}
+
+// status returns the health of all subsystems in the order they were registered
+func (r *healthRegistry) status() *api.HealthStatusResponse {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := &api.HealthStatusResponse{State: api.HealthState_health_ok}
+	for _, h := range r.subsystems {
+		sub := h.status()
+		if healthSeverity(sub.State) > healthSeverity(res.State) {
+			res.State = sub.State
+		}
+		res.Subsystems = append(res.Subsystems, sub)
+	}
+	return res
+}
+
+// RegisterHTTP registers the health probe. It fails once a subsystem failed and,
+// if called with ready=true, also as long as a subsystem is still starting.
+func (r *healthRegistry) RegisterHTTP(mux *http.ServeMux) {
+	mux.HandleFunc("/_supervisor/health", func(w http.ResponseWriter, req *http.Request) {
+		res := r.status()
+
+		code := http.StatusOK
+		switch res.State {
+		case api.HealthState_health_failed:
+			code = http.StatusServiceUnavailable
+		case api.HealthState_health_starting:
+			if req.URL.Query().Get("ready") == "true" {
+				code = http.StatusServiceUnavailable
+			}
+		}
+
+		m := jsonpb.Marshaler{EmitDefaults: true}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		err := m.Marshal(w, res)
+		if err != nil {
+			log.WithError(err).Debug("cannot write health status")
+		}
+	})
+}
+
+// healthSeverity orders the states from healthy to failed
+func healthSeverity(s api.HealthState) int {
+	switch s {
+	case api.HealthState_health_ok:
+		return 0
+	case api.HealthState_health_starting:
+		return 1
+	case api.HealthState_health_degraded:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// subsystemHealth is the health of a single subsystem. All methods are no-ops on nil,
+// s.t. subsystems need not care whether their health is tracked.
+type subsystemHealth struct {
+	mu            sync.Mutex
+	name          string
+	state         api.HealthState
+	since         time.Time
+	lastError     string
+	lastErrorTime time.Time
+	restarts      uint32
+}
+
+// ok marks the subsystem healthy
+func (h *subsystemHealth) ok() {
+	h.set(api.HealthState_health_ok, nil)
+}
+
+// degraded marks the subsystem working with problems
+func (h *subsystemHealth) degraded(err error) {
+	h.set(api.HealthState_health_degraded, err)
+}
+
+// failed marks the subsystem not working
+func (h *subsystemHealth) failed(err error) {
+	h.set(api.HealthState_health_failed, err)
+}
+
+// restarted counts a restart of the subsystem
+func (h *subsystemHealth) restarted() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	h.restarts++
+	h.mu.Unlock()
+}
+
+func (h *subsystemHealth) set(state api.HealthState, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if err != nil {
+		h.lastError = err.Error()
+		h.lastErrorTime = now
+	}
+	if h.state != state {
+		h.state = state
+		h.since = now
+	}
+}
+
+func (h *subsystemHealth) status() *api.SubsystemHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	res := &api.SubsystemHealth{
+		Name:      h.name,
+		State:     h.state,
+		LastError: h.lastError,
+		Restarts:  h.restarts,
+	}
+	res.Since, _ = ptypes.TimestampProto(h.since)
+	if !h.lastErrorTime.IsZero() {
+		res.LastErrorTime, _ = ptypes.TimestampProto(h.lastErrorTime)
+	}
+	return res
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+)
+
+func TestHealthRegistry(t *testing.T) {
+	tests := []struct {
+		Desc         string
+		Report       func(a, b *subsystemHealth)
+		State        api.HealthState
+		Probe        int
+		ReadyProbe   int
+		LastErrors   []string
+		RestartCount []uint32
+	}{
+		{
+			Desc:         "starting",
+			Report:       func(a, b *subsystemHealth) { a.ok() },
+			State:        api.HealthState_health_starting,
+			Probe:        http.StatusOK,
+			ReadyProbe:   http.StatusServiceUnavailable,
+			LastErrors:   []string{"", ""},
+			RestartCount: []uint32{0, 0},
+		},
+		{
+			Desc: "ok",
+			Report: func(a, b *subsystemHealth) {
+				a.ok()
+				b.ok()
+			},
+			State:        api.HealthState_health_ok,
+			Probe:        http.StatusOK,
+			ReadyProbe:   http.StatusOK,
+			LastErrors:   []string{"", ""},
+			RestartCount: []uint32{0, 0},
+		},
+		{
+			Desc: "degraded",
+			Report: func(a, b *subsystemHealth) {
+				a.ok()
+				b.degraded(xerrors.New("unreachable"))
+			},
+			State:        api.HealthState_health_degraded,
+			Probe:        http.StatusOK,
+			ReadyProbe:   http.StatusOK,
+			LastErrors:   []string{"", "unreachable"},
+			RestartCount: []uint32{0, 0},
+		},
+		{
+			Desc: "failed",
+			Report: func(a, b *subsystemHealth) {
+				a.failed(xerrors.New("crashed"))
+				b.degraded(xerrors.New("unreachable"))
+			},
+			State:        api.HealthState_health_failed,
+			Probe:        http.StatusServiceUnavailable,
+			ReadyProbe:   http.StatusServiceUnavailable,
+			LastErrors:   []string{"crashed", "unreachable"},
+			RestartCount: []uint32{0, 0},
+		},
+		{
+			Desc: "recovered",
+			Report: func(a, b *subsystemHealth) {
+				a.failed(xerrors.New("crashed"))
+				a.restarted()
+				a.ok()
+				b.ok()
+			},
+			State:        api.HealthState_health_ok,
+			Probe:        http.StatusOK,
+			ReadyProbe:   http.StatusOK,
+			LastErrors:   []string{"crashed", ""},
+			RestartCount: []uint32{1, 0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			reg := newHealthRegistry()
+			test.Report(reg.register("a"), reg.register("b"))
+
+			res := reg.status()
+			if res.State != test.State {
+				t.Errorf("unexpected state: want %v, got %v", test.State, res.State)
+			}
+			var (
+				lastErrors []string
+				restarts   []uint32
+			)
+			for _, sub := range res.Subsystems {
+				lastErrors = append(lastErrors, sub.LastError)
+				restarts = append(restarts, sub.Restarts)
+				if sub.Since == nil {
+					t.Errorf("subsystem %s has no since time", sub.Name)
+				}
+				if (sub.LastError == "") != (sub.LastErrorTime == nil) {
+					t.Errorf("subsystem %s has an inconsistent last error time", sub.Name)
+				}
+			}
+			if diff := cmp.Diff(test.LastErrors, lastErrors); diff != "" {
+				t.Errorf("unexpected last errors (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.RestartCount, restarts); diff != "" {
+				t.Errorf("unexpected restarts (-want +got):\n%s", diff)
+			}
+
+			mux := http.NewServeMux()
+			reg.RegisterHTTP(mux)
+			for url, code := range map[string]int{"/_supervisor/health": test.Probe, "/_supervisor/health?ready=true": test.ReadyProbe} {
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+				if rec.Code != code {
+					t.Errorf("unexpected status of %s: want %d, got %d", url, code, rec.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestNilSubsystemHealth(t *testing.T) {
+	var h *subsystemHealth
+	h.ok()
+	h.degraded(xerrors.New("ignored"))
+	h.failed(xerrors.New("ignored"))
+	h.restarted()
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"golang.org/x/xerrors"
+)
+
+// Names of the lifecycle hooks as in the .gitpod.yml
+const (
+	hookOnStart    = "onStart"
+	hookOnStop     = "onStop"
+	hookOnSnapshot = "onSnapshot"
+	hookOnPrebuild = "onPrebuild"
+)
+
+// defaultHookTimeout limits hooks which configure no timeout
+const defaultHookTimeout = 5 * time.Minute
+
+// errUnknownHook is returned when running a hook which does not exist
+var errUnknownHook = xerrors.New("unknown lifecycle hook")
+
+// lifecycleHooks runs the commands the .gitpod.yml configures for points of the workspace lifecycle
+type lifecycleHooks struct {
+	Config  gitpod.ConfigInterface
+	Workdir string
+
+	mu     sync.RWMutex
+	hooks  *gitpod.Hooks
+	loaded chan struct{}
+	once   sync.Once
+}
+
+func newLifecycleHooks(config gitpod.ConfigInterface, workdir string) *lifecycleHooks {
+	return &lifecycleHooks{
+		Config:  config,
+		Workdir: workdir,
+		loaded:  make(chan struct{}),
+	}
+}
+
+// Observe keeps the hooks up to date with the .gitpod.yml until ctx is done
+func (h *lifecycleHooks) Observe(ctx context.Context) {
+	// hooks must not wait forever if the .gitpod.yml cannot be read
+	defer h.markLoaded()
+
+	configs, errs := h.Config.Observe(ctx)
+	for configs != nil || errs != nil {
+		select {
+		case cfg, ok := <-configs:
+			if !ok {
+				configs = nil
+				continue
+			}
+			var hooks *gitpod.Hooks
+			if cfg != nil {
+				hooks = cfg.Hooks
+			}
+			h.mu.Lock()
+			h.hooks = hooks
+			h.mu.Unlock()
+			h.markLoaded()
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			tasksLog.WithError(err).Warn("cannot read lifecycle hooks")
+			h.markLoaded()
+		}
+	}
+}
+
+func (h *lifecycleHooks) markLoaded() {
+	h.once.Do(func() { close(h.loaded) })
+}
+
+func (h *lifecycleHooks) get(name string) (*gitpod.Hook, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	hooks := h.hooks
+	if hooks == nil {
+		hooks = &gitpod.Hooks{}
+	}
+	switch name {
+	case hookOnStart:
+		return hooks.OnStart, nil
+	case hookOnStop:
+		return hooks.OnStop, nil
+	case hookOnSnapshot:
+		return hooks.OnSnapshot, nil
+	case hookOnPrebuild:
+		return hooks.OnPrebuild, nil
+	default:
+		return nil, xerrors.Errorf("%s: %w", name, errUnknownHook)
+	}
+}
+
+// Run runs a hook once the .gitpod.yml was read and returns false if the hook is not configured.
+// It fails if the command fails or exceeds its timeout, in which case the command and its children are killed.
+func (h *lifecycleHooks) Run(ctx context.Context, name string) (configured bool, err error) {
+	select {
+	case <-h.loaded:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+
+	hook, err := h.get(name)
+	if err != nil {
+		return false, err
+	}
+	if hook == nil || hook.Command == "" {
+		return false, nil
+	}
+
+	timeout := defaultHookTimeout
+	if hook.Timeout != "" {
+		timeout, err = time.ParseDuration(hook.Timeout)
+		if err != nil {
+			return true, xerrors.Errorf("invalid timeout of %s: %w", name, err)
+		}
+	}
+	// the onStop hook runs within the termination grace period of the workspace, before tasks stop and the content is backed up
+	if name == hookOnStop && timeout > timeBudgetStopHook {
+		if hook.Timeout != "" {
+			tasksLog.WithField("hook", name).WithField("timeout", hook.Timeout).Warnf("timeout exceeds the maximum of %s", timeBudgetStopHook)
+		}
+		timeout = timeBudgetStopHook
+	}
+
+	hookLog := tasksLog.WithField("hook", name)
+	hookLog.WithField("timeout", timeout.String()).Info("running lifecycle hook")
+	start := time.Now()
+	err = runHookCommand(ctx, hook.Command, h.Workdir, timeout, func(line string) {
+		hookLog.WithField("output", line).Info("lifecycle hook output")
+	})
+	if err != nil {
+		return true, xerrors.Errorf("%s failed: %w", name, err)
+	}
+	hookLog.WithField("duration", time.Since(start).String()).Info("lifecycle hook finished")
+	return true, nil
+}
+
+// runHookCommand runs a shell command in its own process group, s.t. the command and its children can be killed on timeout
+func runHookCommand(ctx context.Context, command, workdir string, timeout time.Duration, onOutput func(line string)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Dir = workdir
+	cmd.Env = os.Environ()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	err := cmd.Start()
+	if err != nil {
+		pw.Close()
+		return err
+	}
+
+	output := make(chan struct{})
+	go func() {
+		defer close(output)
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			onOutput(scanner.Text())
+		}
+	}()
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+		pw.Close()
+	}()
+
+	select {
+	case err = <-exited:
+	case <-ctx.Done():
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		<-exited
+		err = xerrors.Errorf("did not finish within %s", timeout)
+	}
+	<-output
+	return err
+}
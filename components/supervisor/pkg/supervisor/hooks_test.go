@@ -0,0 +1,118 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"golang.org/x/xerrors"
+)
+
+type testConfigService struct {
+	config *gitpod.GitpodConfig
+}
+
+func (s *testConfigService) Observe(ctx context.Context) (<-chan *gitpod.GitpodConfig, <-chan error) {
+	configs := make(chan *gitpod.GitpodConfig, 1)
+	configs <- s.config
+	return configs, make(chan error)
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	tests := []struct {
+		Desc       string
+		Hooks      *gitpod.Hooks
+		Hook       string
+		Configured bool
+		Error      bool
+		Ran        bool
+	}{
+		{
+			Desc: "no hooks",
+			Hook: hookOnStart,
+		},
+		{
+			Desc:  "not configured",
+			Hooks: &gitpod.Hooks{OnStop: &gitpod.Hook{Command: "touch ran"}},
+			Hook:  hookOnStart,
+		},
+		{
+			Desc:       "succeeds",
+			Hooks:      &gitpod.Hooks{OnSnapshot: &gitpod.Hook{Command: "echo snapshot; touch ran"}},
+			Hook:       hookOnSnapshot,
+			Configured: true,
+			Ran:        true,
+		},
+		{
+			Desc:       "fails",
+			Hooks:      &gitpod.Hooks{OnPrebuild: &gitpod.Hook{Command: "touch ran; exit 1"}},
+			Hook:       hookOnPrebuild,
+			Configured: true,
+			Error:      true,
+			Ran:        true,
+		},
+		{
+			Desc:       "times out",
+			Hooks:      &gitpod.Hooks{OnStop: &gitpod.Hook{Command: "sleep 10; touch ran", Timeout: "100ms"}},
+			Hook:       hookOnStop,
+			Configured: true,
+			Error:      true,
+		},
+		{
+			Desc:       "onStop timeout exceeds budget",
+			Hooks:      &gitpod.Hooks{OnStop: &gitpod.Hook{Command: "sleep 10; touch ran", Timeout: "1h"}},
+			Hook:       hookOnStop,
+			Configured: true,
+			Error:      true,
+		},
+		{
+			Desc:       "invalid timeout",
+			Hooks:      &gitpod.Hooks{OnStart: &gitpod.Hook{Command: "touch ran", Timeout: "forever"}},
+			Hook:       hookOnStart,
+			Configured: true,
+			Error:      true,
+		},
+		{
+			Desc:  "unknown hook",
+			Hooks: &gitpod.Hooks{OnStart: &gitpod.Hook{Command: "touch ran"}},
+			Hook:  "onDelete",
+			Error: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "supervisor-hooks")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			hooks := newLifecycleHooks(&testConfigService{config: &gitpod.GitpodConfig{Hooks: test.Hooks}}, dir)
+			go hooks.Observe(ctx)
+
+			configured, err := hooks.Run(ctx, test.Hook)
+			if configured != test.Configured {
+				t.Errorf("unexpected configured: want %v, got %v", test.Configured, configured)
+			}
+			if (err != nil) != test.Error {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if test.Hook == "onDelete" && !xerrors.Is(err, errUnknownHook) {
+				t.Errorf("expected errUnknownHook, got %v", err)
+			}
+			_, statErr := os.Stat(filepath.Join(dir, "ran"))
+			if ran := statErr == nil; ran != test.Ran {
+				t.Errorf("unexpected command run: want %v, got %v", test.Ran, ran)
+			}
+		})
+	}
+}
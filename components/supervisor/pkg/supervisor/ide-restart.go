@@ -0,0 +1,70 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"time"
+)
+
+const (
+	// ideRestartInitialDelay is the delay before the IDE is restarted after it stopped the first time
+	ideRestartInitialDelay = 1 * time.Second
+	// ideRestartMaxDelay limits the delay, which doubles with every crash in a row
+	ideRestartMaxDelay = 1 * time.Minute
+	// ideStableAfter is how long the IDE has to run until a crash no longer counts as being in a row
+	ideStableAfter = 1 * time.Minute
+	// ideCrashLoopThreshold is the number of crashes within ideCrashLoopWindow from which on the IDE is crash-looping
+	ideCrashLoopThreshold = 5
+	ideCrashLoopWindow    = 5 * time.Minute
+)
+
+// ideRestartPolicy decides when the IDE is restarted after it stopped. The delay grows exponentially while the IDE
+// keeps crashing right after it started, and is reset once the IDE ran stable for a while.
+type ideRestartPolicy struct {
+	InitialDelay       time.Duration
+	MaxDelay           time.Duration
+	StableAfter        time.Duration
+	CrashLoopThreshold int
+	CrashLoopWindow    time.Duration
+
+	delay   time.Duration
+	crashes []time.Time
+}
+
+func newIDERestartPolicy() *ideRestartPolicy {
+	return &ideRestartPolicy{
+		InitialDelay:       ideRestartInitialDelay,
+		MaxDelay:           ideRestartMaxDelay,
+		StableAfter:        ideStableAfter,
+		CrashLoopThreshold: ideCrashLoopThreshold,
+		CrashLoopWindow:    ideCrashLoopWindow,
+	}
+}
+
+// stopped records a stop of the IDE which ran from started until stopped. It returns the delay before the IDE is
+// restarted and whether the IDE is crash-looping, i.e. it stopped too often recently.
+func (p *ideRestartPolicy) stopped(started, stopped time.Time) (delay time.Duration, crashLoop bool) {
+	if stopped.Sub(started) >= p.StableAfter {
+		p.delay = 0
+	}
+	if p.delay == 0 {
+		p.delay = p.InitialDelay
+	} else {
+		p.delay *= 2
+	}
+	if p.delay > p.MaxDelay {
+		p.delay = p.MaxDelay
+	}
+
+	crashes := p.crashes[:0]
+	for _, c := range p.crashes {
+		if stopped.Sub(c) < p.CrashLoopWindow {
+			crashes = append(crashes, c)
+		}
+	}
+	p.crashes = append(crashes, stopped)
+
+	return p.delay, len(p.crashes) >= p.CrashLoopThreshold
+}
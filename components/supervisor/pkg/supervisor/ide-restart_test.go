@@ -0,0 +1,88 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIDERestartPolicy(t *testing.T) {
+	type stop struct {
+		Delay     time.Duration
+		CrashLoop bool
+	}
+	tests := []struct {
+		Desc string
+		// Runs are how long the IDE ran before it stopped, one after another
+		Runs        []time.Duration
+		Expectation []stop
+	}{
+		{
+			Desc: "backoff",
+			Runs: []time.Duration{time.Second, time.Second, time.Second},
+			Expectation: []stop{
+				{Delay: 1 * time.Second},
+				{Delay: 2 * time.Second},
+				{Delay: 4 * time.Second},
+			},
+		},
+		{
+			Desc: "stable run resets the backoff",
+			Runs: []time.Duration{time.Second, time.Second, 10 * time.Minute},
+			Expectation: []stop{
+				{Delay: 1 * time.Second},
+				{Delay: 2 * time.Second},
+				{Delay: 1 * time.Second},
+			},
+		},
+		{
+			Desc: "crash loop",
+			Runs: []time.Duration{time.Second, time.Second, time.Second, time.Second, time.Second, time.Second},
+			Expectation: []stop{
+				{Delay: 1 * time.Second},
+				{Delay: 2 * time.Second},
+				{Delay: 4 * time.Second},
+				{Delay: 8 * time.Second},
+				{Delay: 16 * time.Second, CrashLoop: true},
+				{Delay: 30 * time.Second, CrashLoop: true},
+			},
+		},
+		{
+			Desc: "crashes outside of the window",
+			Runs: []time.Duration{time.Second, 3 * time.Minute, 3 * time.Minute, 3 * time.Minute, time.Second},
+			Expectation: []stop{
+				{Delay: 1 * time.Second},
+				{Delay: 1 * time.Second},
+				{Delay: 1 * time.Second},
+				{Delay: 1 * time.Second},
+				{Delay: 2 * time.Second},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			p := newIDERestartPolicy()
+			p.MaxDelay = 30 * time.Second
+
+			var (
+				now = time.Unix(0, 0)
+				act []stop
+			)
+			for _, run := range test.Runs {
+				started := now
+				now = now.Add(run)
+				delay, crashLoop := p.stopped(started, now)
+				act = append(act, stop{Delay: delay, CrashLoop: crashLoop})
+				now = now.Add(delay)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected restarts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// jetbrainsBackendLocation is where the JetBrains backend is installed, s.t. it is downloaded only once per workspace
+	jetbrainsBackendLocation = "/workspace/.gitpod/jetbrains-backend"
+	// jetbrainsBackendPort is the port the JetBrains backend listens on for Gateway
+	jetbrainsBackendPort = 5990
+	// jetbrainsBackendLauncher is the launcher of the backend relative to its installation
+	jetbrainsBackendLauncher = "bin/remote-dev-server.sh"
+	// jetbrainsBackendStartTimeout limits how long the backend may take until it accepts connections
+	jetbrainsBackendStartTimeout = 2 * time.Minute
+	// jetbrainsBackendStopTimeout is how long the backend has to stop before it is killed
+	jetbrainsBackendStopTimeout = 10 * time.Second
+	// jetbrainsJoinLinkPrefix precedes the join link in the output of the backend
+	jetbrainsJoinLinkPrefix = "Join link: "
+)
+
+// JetBrainsService implements the api.JetBrainsService. It downloads the JetBrains backend on demand, runs it for
+// the repository and stops it on request. The port of the backend is internal, Gateway reaches it via SSH.
+type JetBrainsService struct {
+	// DownloadURL is the location of the backend distribution, a .tar.gz archive with a single top-level folder
+	DownloadURL string
+	// Dir is where the backend is installed
+	Dir string
+	// Project is the folder the backend opens
+	Project string
+	// Port is the port the backend listens on
+	Port uint32
+	// Env is the environment of the backend process
+	Env []string
+	// Ports are told that the port of the backend is internal while it runs, if set
+	Ports *ports.Manager
+
+	mu     sync.Mutex
+	status api.JetBrainsBackendStatus
+	// starting is closed once the current start attempt is done, nil if there is none
+	starting chan struct{}
+	cmd      *exec.Cmd
+	// exited is closed once the running backend exited
+	exited   chan struct{}
+	stopping bool
+}
+
+// RegisterGRPC registers the gRPC JetBrains service
+func (s *JetBrainsService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterJetBrainsServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST JetBrains service
+func (s *JetBrainsService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterJetBrainsServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// Status returns the status of the backend
+func (s *JetBrainsService) Status() *api.JetBrainsBackendStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := proto.Clone(&s.status).(*api.JetBrainsBackendStatus)
+	res.Port = s.Port
+	return res
+}
+
+func (s *JetBrainsService) setState(state api.JetBrainsBackendState, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.State = state
+	s.status.Error = ""
+	if err != nil {
+		s.status.Error = err.Error()
+	}
+	if state != api.JetBrainsBackendState_jetbrains_backend_running {
+		s.status.JoinLink = ""
+	}
+}
+
+// StartBackend downloads the backend unless it is installed already and starts it
+func (s *JetBrainsService) StartBackend(ctx context.Context, req *api.StartJetBrainsBackendRequest) (*api.StartJetBrainsBackendResponse, error) {
+	s.mu.Lock()
+	if s.starting == nil && s.status.State != api.JetBrainsBackendState_jetbrains_backend_running {
+		// the start does not depend on the request, s.t. a canceled request does not abort a download other requests wait for
+		s.starting = make(chan struct{})
+		go s.start(s.starting)
+	}
+	starting := s.starting
+	s.mu.Unlock()
+
+	if starting != nil {
+		select {
+		case <-starting:
+		case <-ctx.Done():
+			return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+		}
+	}
+	return &api.StartJetBrainsBackendResponse{Status: s.Status()}, nil
+}
+
+func (s *JetBrainsService) start(done chan struct{}) {
+	defer func() {
+		s.mu.Lock()
+		s.starting = nil
+		s.mu.Unlock()
+		close(done)
+	}()
+
+	err := s.install()
+	if err == nil {
+		err = s.launch()
+	}
+	if err != nil {
+		log.WithError(err).Error("cannot start JetBrains backend")
+		s.setState(api.JetBrainsBackendState_jetbrains_backend_failed, err)
+	}
+}
+
+// install downloads and unpacks the backend unless it is installed already
+func (s *JetBrainsService) install() (err error) {
+	if _, err := os.Stat(filepath.Join(s.Dir, jetbrainsBackendLauncher)); err == nil {
+		return nil
+	}
+	if s.DownloadURL == "" {
+		return xerrors.Errorf("JetBrains backend is not installed and there is no download URL")
+	}
+	s.setState(api.JetBrainsBackendState_jetbrains_backend_downloading, nil)
+	log.WithField("url", s.DownloadURL).Info("downloading JetBrains backend")
+
+	resp, err := http.Get(s.DownloadURL)
+	if err != nil {
+		return xerrors.Errorf("cannot download JetBrains backend: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("cannot download JetBrains backend: %s", resp.Status)
+	}
+
+	// the backend is unpacked next to its final location first, s.t. an interrupted download is never mistaken for an installation
+	tmp := s.Dir + ".download"
+	err = os.RemoveAll(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	err = untarStripped(resp.Body, tmp)
+	if err != nil {
+		return xerrors.Errorf("cannot unpack JetBrains backend: %w", err)
+	}
+	err = os.RemoveAll(s.Dir)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.Dir)
+}
+
+// untarStripped unpacks a .tar.gz archive to dst, stripping the top-level folder of the archive
+func untarStripped(r io.Reader, dst string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		segs := strings.SplitN(strings.TrimPrefix(hdr.Name, "./"), "/", 2)
+		if len(segs) < 2 || segs[1] == "" {
+			continue
+		}
+		fn := filepath.Join(dst, filepath.Clean("/"+segs[1]))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(fn, 0755)
+		case tar.TypeSymlink:
+			err = os.MkdirAll(filepath.Dir(fn), 0755)
+			if err == nil {
+				err = os.Symlink(hdr.Linkname, fn)
+			}
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(fn), 0755)
+			if err != nil {
+				return err
+			}
+			var f *os.File
+			f, err = os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode)&os.ModePerm)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// launch starts the backend and waits until it accepts connections
+func (s *JetBrainsService) launch() error {
+	s.setState(api.JetBrainsBackendState_jetbrains_backend_starting, nil)
+	if s.Ports != nil {
+		s.Ports.SetInternal(context.Background(), s.Port, true)
+	}
+
+	cmd := exec.Command(filepath.Join(s.Dir, jetbrainsBackendLauncher), "run", s.Project, "--listenOn", "127.0.0.1", "--port", strconv.Itoa(int(s.Port)))
+	cmd.Env = s.Env
+	cmd.Dir = s.Project
+	cmd.Stderr = os.Stderr
+	// the backend runs in its own process group, s.t. stopping it stops its children too
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid:   true,
+		Pdeathsig: syscall.SIGKILL,
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	log.WithField("args", cmd.Args).Info("launching JetBrains backend")
+	err = cmd.Start()
+	if err != nil {
+		if s.Ports != nil {
+			s.Ports.SetInternal(context.Background(), s.Port, false)
+		}
+		return err
+	}
+
+	exited := make(chan struct{})
+	s.mu.Lock()
+	s.cmd = cmd
+	s.exited = exited
+	s.stopping = false
+	s.mu.Unlock()
+
+	go s.scanOutput(stdout)
+	go s.wait(cmd, exited)
+
+	ctx, cancel := context.WithTimeout(context.Background(), jetbrainsBackendStartTimeout)
+	defer cancel()
+	for {
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", s.Port), time.Second)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		select {
+		case <-exited:
+			return xerrors.Errorf("JetBrains backend exited while starting")
+		case <-ctx.Done():
+			s.stop()
+			return xerrors.Errorf("JetBrains backend did not accept connections within %s", jetbrainsBackendStartTimeout)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	s.mu.Lock()
+	if s.cmd == cmd {
+		s.status.State = api.JetBrainsBackendState_jetbrains_backend_running
+		s.status.Error = ""
+	}
+	s.mu.Unlock()
+	log.Info("JetBrains backend is running")
+	return nil
+}
+
+// scanOutput passes the output of the backend on and picks up the join link from it
+func (s *JetBrainsService) scanOutput(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fmt.Fprintln(os.Stdout, line)
+		if idx := strings.Index(line, jetbrainsJoinLinkPrefix); idx >= 0 {
+			s.mu.Lock()
+			s.status.JoinLink = strings.TrimSpace(line[idx+len(jetbrainsJoinLinkPrefix):])
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *JetBrainsService) wait(cmd *exec.Cmd, exited chan struct{}) {
+	err := cmd.Wait()
+
+	s.mu.Lock()
+	stopping := s.stopping
+	s.cmd = nil
+	s.mu.Unlock()
+	if s.Ports != nil {
+		s.Ports.SetInternal(context.Background(), s.Port, false)
+	}
+
+	if stopping {
+		log.Info("JetBrains backend was stopped")
+		s.setState(api.JetBrainsBackendState_jetbrains_backend_stopped, nil)
+	} else {
+		if err == nil {
+			err = xerrors.New("JetBrains backend exited")
+		}
+		log.WithError(err).Warn("JetBrains backend stopped unexpectedly")
+		s.setState(api.JetBrainsBackendState_jetbrains_backend_failed, err)
+	}
+	close(exited)
+}
+
+// StopBackend stops the backend if it runs
+func (s *JetBrainsService) StopBackend(ctx context.Context, req *api.StopJetBrainsBackendRequest) (*api.StopJetBrainsBackendResponse, error) {
+	s.stop()
+	return &api.StopJetBrainsBackendResponse{Status: s.Status()}, nil
+}
+
+// stop terminates the backend and its children and waits until it exited, killing it if it does not stop in time
+func (s *JetBrainsService) stop() {
+	s.mu.Lock()
+	cmd, exited := s.cmd, s.exited
+	if cmd == nil {
+		s.mu.Unlock()
+		return
+	}
+	s.stopping = true
+	s.mu.Unlock()
+
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	select {
+	case <-exited:
+	case <-time.After(jetbrainsBackendStopTimeout):
+		log.Warn("JetBrains backend did not stop in time - sending SIGKILL")
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		<-exited
+	}
+}
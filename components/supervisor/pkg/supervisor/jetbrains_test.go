@@ -0,0 +1,137 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+func TestJetBrainsService(t *testing.T) {
+	// the test listens on the port of the backend, s.t. the fake backend accepts connections right away
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	launcher := []byte("#!/bin/sh\necho \"Join link: tcp://127.0.0.1:5990#jt=test\"\nexec sleep 60\n")
+	for _, f := range []struct {
+		Name string
+		Mode int64
+		Body []byte
+	}{
+		{Name: "backend/bin/remote-dev-server.sh", Mode: 0755, Body: launcher},
+		{Name: "backend/product-info.json", Mode: 0644, Body: []byte("{}")},
+	} {
+		err = tw.WriteHeader(&tar.Header{Name: f.Name, Mode: f.Mode, Size: int64(len(f.Body)), Typeflag: tar.TypeReg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write(f.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+
+	var downloads int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&downloads, 1)
+		w.Write(archive.Bytes())
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "supervisor-jetbrains")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	jb := &JetBrainsService{
+		DownloadURL: srv.URL,
+		Dir:         filepath.Join(dir, "backend"),
+		Project:     dir,
+		Port:        uint32(l.Addr().(*net.TCPAddr).Port),
+	}
+	start := func() *api.JetBrainsBackendStatus {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		resp, err := jb.StartBackend(ctx, &api.StartJetBrainsBackendRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Status
+	}
+
+	if s := start(); s.State != api.JetBrainsBackendState_jetbrains_backend_running {
+		t.Fatalf("unexpected status after start: %v", s)
+	}
+	// the join link is picked up from the output, which may come after the backend accepted connections
+	for i := 0; jb.Status().JoinLink == ""; i++ {
+		if i > 100 {
+			t.Fatal("join link was not picked up")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if s := jb.Status(); s.JoinLink != "tcp://127.0.0.1:5990#jt=test" || s.Port != jb.Port {
+		t.Errorf("unexpected status of the running backend: %v", s)
+	}
+	if s := start(); s.State != api.JetBrainsBackendState_jetbrains_backend_running {
+		t.Errorf("unexpected status when starting the running backend: %v", s)
+	}
+
+	resp, err := jb.StopBackend(context.Background(), &api.StopJetBrainsBackendRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status.State != api.JetBrainsBackendState_jetbrains_backend_stopped || resp.Status.JoinLink != "" {
+		t.Errorf("unexpected status after stop: %v", resp.Status)
+	}
+
+	// the backend is installed already
+	if s := start(); s.State != api.JetBrainsBackendState_jetbrains_backend_running {
+		t.Errorf("unexpected status after restart: %v", s)
+	}
+	if d := atomic.LoadInt32(&downloads); d != 1 {
+		t.Errorf("expected the backend to be downloaded once, got %d downloads", d)
+	}
+	jb.stop()
+}
+
+func TestJetBrainsServiceDownloadFails(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "supervisor-jetbrains")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	jb := &JetBrainsService{DownloadURL: srv.URL, Dir: filepath.Join(dir, "backend")}
+	resp, err := jb.StartBackend(context.Background(), &api.StartJetBrainsBackendRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status.State != api.JetBrainsBackendState_jetbrains_backend_failed || resp.Status.Error == "" {
+		t.Errorf("unexpected status: %v", resp.Status)
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// keeperSocketEnv names the file descriptor of the socket supervisor hands its pseudo-terminals to the keeper with
+	keeperSocketEnv = "SUPERVISOR_KEEPER_FD"
+	// keeperFilesEnv lists the pseudo-terminals a restarted supervisor inherits as alias=fd pairs
+	keeperFilesEnv = "SUPERVISOR_KEEPER_FILES"
+)
+
+// RestartExitCode is the exit code with which supervisor asks the keeper to restart it, e.g. after an upgrade of its binary
+const RestartExitCode = 75
+
+// StopExitCode is the exit code with which supervisor exits once the workspace failed on request of the workspace
+// code, which the keeper must not restart it for
+const StopExitCode = 76
+
+type keeperOp string
+
+const (
+	keeperHold    keeperOp = "hold"
+	keeperRelease keeperOp = "release"
+)
+
+type keeperMessage struct {
+	Op    keeperOp `json:"op"`
+	Alias string   `json:"alias"`
+}
+
+// Keeper runs supervisor and holds the pseudo-terminals of supervisor's terminals on its behalf. Once supervisor
+// crashes or is upgraded, the processes in those terminals keep running and the restarted supervisor inherits them.
+type Keeper struct {
+	mu    sync.Mutex
+	files map[string]*os.File
+}
+
+// NewKeeper creates a new keeper which holds no pseudo-terminals yet
+func NewKeeper() *Keeper {
+	return &Keeper{files: make(map[string]*os.File)}
+}
+
+// Start starts a supervisor process which hands its pseudo-terminals to the keeper
+// and inherits all pseudo-terminals the keeper holds already.
+func (k *Keeper) Start(cmd *exec.Cmd) error {
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_SEQPACKET|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return xerrors.Errorf("cannot create keeper socket: %w", err)
+	}
+	parent := os.NewFile(uintptr(fds[0]), "keeper")
+	child := os.NewFile(uintptr(fds[1]), "keeper-supervisor")
+	defer child.Close()
+
+	k.mu.Lock()
+	aliases := make([]string, 0, len(k.files))
+	for alias := range k.files {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	// ExtraFiles start at fd 3
+	cmd.ExtraFiles = append(cmd.ExtraFiles, child)
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%d", keeperSocketEnv, 2+len(cmd.ExtraFiles)))
+	if len(aliases) > 0 {
+		inherited := make([]string, 0, len(aliases))
+		for _, alias := range aliases {
+			cmd.ExtraFiles = append(cmd.ExtraFiles, k.files[alias])
+			inherited = append(inherited, fmt.Sprintf("%s=%d", alias, 2+len(cmd.ExtraFiles)))
+		}
+		cmd.Env = append(cmd.Env, keeperFilesEnv+"="+strings.Join(inherited, ","))
+	}
+	k.mu.Unlock()
+
+	err = cmd.Start()
+	if err != nil {
+		parent.Close()
+		return err
+	}
+	go k.serve(parent)
+	return nil
+}
+
+// serve receives the pseudo-terminals of one supervisor process until it exits
+func (k *Keeper) serve(f *os.File) {
+	conn, err := net.FileConn(f)
+	f.Close()
+	if err != nil {
+		log.WithError(err).Error("cannot serve keeper socket")
+		return
+	}
+	defer conn.Close()
+	uconn, ok := conn.(*net.UnixConn)
+	if !ok {
+		log.Error("keeper socket is no unix socket")
+		return
+	}
+
+	var (
+		buf = make([]byte, 1024)
+		oob = make([]byte, unix.CmsgSpace(4))
+	)
+	for {
+		n, oobn, _, _, err := uconn.ReadMsgUnix(buf, oob)
+		if err != nil || n == 0 {
+			// supervisor has exited
+			return
+		}
+
+		var files []*os.File
+		if scms, err := unix.ParseSocketControlMessage(oob[:oobn]); err == nil {
+			for _, scm := range scms {
+				fds, err := unix.ParseUnixRights(&scm)
+				if err != nil {
+					continue
+				}
+				for _, fd := range fds {
+					unix.CloseOnExec(fd)
+					files = append(files, os.NewFile(uintptr(fd), "pty"))
+				}
+			}
+		}
+
+		var msg keeperMessage
+		err = json.Unmarshal(buf[:n], &msg)
+		if err != nil {
+			log.WithError(err).Warn("cannot unmarshal keeper message")
+			closeFiles(files)
+			continue
+		}
+		k.handle(msg, files)
+	}
+}
+
+func (k *Keeper) handle(msg keeperMessage, files []*os.File) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if old, exists := k.files[msg.Alias]; exists {
+		old.Close()
+		delete(k.files, msg.Alias)
+	}
+	switch msg.Op {
+	case keeperHold:
+		if len(files) != 1 {
+			log.WithField("alias", msg.Alias).Warn("keeper received no pseudo-terminal to hold")
+			closeFiles(files)
+			return
+		}
+		k.files[msg.Alias] = files[0]
+	case keeperRelease:
+		closeFiles(files)
+	default:
+		log.WithField("op", msg.Op).Warn("unknown keeper operation")
+		closeFiles(files)
+	}
+}
+
+// Held returns the aliases of the pseudo-terminals the keeper holds
+func (k *Keeper) Held() []string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	res := make([]string, 0, len(k.files))
+	for alias := range k.files {
+		res = append(res, alias)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		f.Close()
+	}
+}
+
+// keeperClient hands supervisor's pseudo-terminals to the keeper which started supervisor
+type keeperClient struct {
+	conn *net.UnixConn
+	mu   sync.Mutex
+
+	// Inherited are the pseudo-terminals of a previous supervisor process by alias
+	Inherited map[string]*os.File
+	// Restarted is true if supervisor was restarted by the keeper
+	Restarted bool
+}
+
+// connectToKeeper connects to the keeper which started supervisor. It returns nil if supervisor was not started by a keeper.
+func connectToKeeper() (*keeperClient, error) {
+	sockFD := os.Getenv(keeperSocketEnv)
+	if sockFD == "" {
+		return nil, nil
+	}
+	// the file descriptors must not leak into the processes we start
+	os.Unsetenv(keeperSocketEnv)
+	fd, err := strconv.Atoi(sockFD)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid %s: %w", keeperSocketEnv, err)
+	}
+	unix.CloseOnExec(fd)
+	f := os.NewFile(uintptr(fd), "keeper")
+	conn, err := net.FileConn(f)
+	f.Close()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot connect to keeper: %w", err)
+	}
+	uconn, ok := conn.(*net.UnixConn)
+	if !ok {
+		conn.Close()
+		return nil, xerrors.Errorf("keeper socket is no unix socket")
+	}
+
+	res := &keeperClient{conn: uconn, Inherited: make(map[string]*os.File)}
+	inherited, restarted := os.LookupEnv(keeperFilesEnv)
+	os.Unsetenv(keeperFilesEnv)
+	res.Restarted = restarted
+	for _, pair := range strings.Split(inherited, ",") {
+		segs := strings.SplitN(pair, "=", 2)
+		if len(segs) != 2 {
+			continue
+		}
+		fd, err := strconv.Atoi(segs[1])
+		if err != nil {
+			log.WithField("file", pair).Warn("invalid inherited pseudo-terminal")
+			continue
+		}
+		unix.CloseOnExec(fd)
+		res.Inherited[segs[0]] = os.NewFile(uintptr(fd), "pty:"+segs[0])
+	}
+	return res, nil
+}
+
+// Hold hands a pseudo-terminal to the keeper
+func (c *keeperClient) Hold(alias string, pty *os.File) error {
+	rc, err := pty.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sendErr error
+	err = rc.Control(func(fd uintptr) {
+		sendErr = c.send(keeperMessage{Op: keeperHold, Alias: alias}, unix.UnixRights(int(fd)))
+	})
+	if err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// Release tells the keeper that a pseudo-terminal is closed
+func (c *keeperClient) Release(alias string) error {
+	return c.send(keeperMessage{Op: keeperRelease, Alias: alias}, nil)
+}
+
+func (c *keeperClient) send(msg keeperMessage, oob []byte) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _, err = c.conn.WriteMsgUnix(b, oob, nil)
+	return err
+}
+
+// ReapUntilExit reaps all children, including the orphans we inherit as PID 1 of a PID namespace,
+// until the process with the given PID exits and returns its wait status.
+func ReapUntilExit(pid int) (syscall.WaitStatus, error) {
+	for {
+		var status syscall.WaitStatus
+		wpid, err := syscall.Wait4(-1, &status, 0, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			return status, err
+		}
+		if wpid == pid {
+			return status, nil
+		}
+		log.WithField("pid", wpid).Debug("reaped orphaned process")
+	}
+}
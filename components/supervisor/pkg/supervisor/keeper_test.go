@@ -0,0 +1,150 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sys/unix"
+)
+
+// connectedKeeper returns a keeper and a client which is connected to it
+func connectedKeeper(t *testing.T) (*Keeper, *keeperClient) {
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_SEQPACKET|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keeper := NewKeeper()
+	go keeper.serve(os.NewFile(uintptr(fds[0]), "keeper"))
+
+	f := os.NewFile(uintptr(fds[1]), "keeper-supervisor")
+	conn, err := net.FileConn(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return keeper, &keeperClient{conn: conn.(*net.UnixConn), Inherited: make(map[string]*os.File)}
+}
+
+func awaitHeld(t *testing.T, keeper *Keeper, expectation []string) {
+	var act []string
+	for i := 0; i < 50; i++ {
+		act = keeper.Held()
+		if cmp.Equal(expectation, act) {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Errorf("unexpected held terminals (-want +got):\n%s", cmp.Diff(expectation, act))
+}
+
+func TestKeeper(t *testing.T) {
+	keeper, client := connectedKeeper(t)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	err = client.Hold("a", r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.Hold("b", w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitHeld(t, keeper, []string{"a", "b"})
+
+	err = client.Release("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitHeld(t, keeper, []string{"b"})
+}
+
+func TestRecoverFromKeeper(t *testing.T) {
+	dir, err := ioutil.TempDir("", "supervisor-recovery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	proc := exec.Command("sleep", "30")
+	err = proc.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proc.Process.Kill()
+
+	loc := filepath.Join(dir, "state.json")
+	err = writeRecoveryState(loc, &recoveryState{
+		ContentReady:  true,
+		ContentSource: "from-backup",
+		Terminals: []recoveredTerminal{
+			{Alias: "known", Pid: proc.Process.Pid, Args: []string{"/bin/bash", "-i", "-l"}, Title: "bash"},
+		},
+		Tasks: []recoveredTask{{ID: "0", Terminal: "known", State: api.TaskState_running}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keeper, client := connectedKeeper(t)
+	known, _, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown, unknownW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unknownW.Close()
+	err = client.Hold("unknown", unknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	awaitHeld(t, keeper, []string{"unknown"})
+	client.Restarted = true
+	client.Inherited = map[string]*os.File{"known": known, "unknown": unknown}
+
+	mux := terminal.NewMux()
+	state := recoverFromKeeper(client, loc, mux)
+	if state == nil {
+		t.Fatal("expected state to be recovered")
+	}
+	if !state.ContentReady || state.ContentSource != "from-backup" || len(state.Tasks) != 1 {
+		t.Errorf("unexpected recovered state: %+v", state)
+	}
+	if diff := cmp.Diff([]string{"known"}, mux.Aliases()); diff != "" {
+		t.Errorf("unexpected adopted terminals (-want +got):\n%s", diff)
+	}
+	// unknown terminals are released
+	awaitHeld(t, keeper, []string{})
+
+	term, _ := mux.Get("known")
+	proc.Process.Kill()
+	proc.Wait()
+	select {
+	case <-term.Exited():
+	case <-time.After(5 * time.Second):
+		t.Fatal("adopted terminal did not notice its process exiting")
+	}
+	if code := term.ExitCode(); code != -1 {
+		t.Errorf("expected exit code of adopted terminal to be unknown, got %d", code)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import "github.com/gitpod-io/gitpod/supervisor/pkg/logging"
+
+var (
+	// tasksLog is the logger of the tasks and the lifecycle hooks
+	tasksLog = logging.Subsystem("tasks")
+	// resourcesLog is the logger of the watchers of the processes and of the memory, disk and CPU usage
+	resourcesLog = logging.Subsystem("resources")
+	// dotfilesLog is the logger of the dotfiles installation
+	dotfilesLog = logging.Subsystem("dotfiles")
+)
@@ -0,0 +1,260 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	// memoryPollInterval is how often the memory of the workspace is checked
+	memoryPollInterval = 5 * time.Second
+	// memoryPressureThreshold is the share of time in percent processes may be stalled waiting for memory
+	// before the workspace counts as under memory pressure
+	memoryPressureThreshold = 20
+	// maxRecentOOMKills is the number of OOM kills the memory status keeps
+	maxRecentOOMKills = 10
+	// cgroupV1Unlimited is the value from which on memory limits of cgroup v1 mean unlimited
+	cgroupV1Unlimited = 1 << 62
+)
+
+// memoryWatcher watches the memory cgroup of the workspace and notifies the user about processes
+// which were killed because the workspace ran out of memory and once the workspace is under memory pressure
+type memoryWatcher struct {
+	Notifications *NotificationService
+	// PressureThreshold is the threshold of memory pressure in percent. The pressure ends once it drops below half of it.
+	PressureThreshold float64
+
+	cgroupDir string
+	procDir   string
+
+	mu      sync.Mutex
+	status  api.MemoryStatusResponse
+	polled  bool
+	lastRSS map[int]processMemory
+}
+
+type processMemory struct {
+	Command string
+	RSS     uint64
+}
+
+func newMemoryWatcher(notifications *NotificationService) *memoryWatcher {
+	return &memoryWatcher{
+		Notifications:     notifications,
+		PressureThreshold: memoryPressureThreshold,
+		cgroupDir:         cgroupDir,
+		procDir:           "/proc",
+	}
+}
+
+// Status returns the current memory status of the workspace
+func (w *memoryWatcher) Status() *api.MemoryStatusResponse {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return proto.Clone(&w.status).(*api.MemoryStatusResponse)
+}
+
+// Run watches the memory of the workspace until ctx is done
+func (w *memoryWatcher) Run(ctx context.Context) {
+	t := time.NewTicker(memoryPollInterval)
+	defer t.Stop()
+	for {
+		err := w.poll(ctx, time.Now())
+		if err != nil {
+			resourcesLog.WithError(err).Debug("cannot read memory of the workspace")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (w *memoryWatcher) poll(ctx context.Context, now time.Time) error {
+	mem, err := readCgroupMemory(w.cgroupDir, w.procDir)
+	if err != nil {
+		return err
+	}
+	procs := readProcessMemory(w.procDir)
+
+	var notifications []*api.NotifyRequest
+	w.mu.Lock()
+	w.status.UsageBytes = mem.Usage
+	w.status.LimitBytes = mem.Limit
+	w.status.Pressure = mem.Pressure
+
+	// the kills before supervisor started watching are counted, but not reported
+	if w.polled && mem.OOMKills > w.status.OomKills {
+		for _, kill := range identifyOOMKills(w.lastRSS, procs, int(mem.OOMKills-w.status.OomKills)) {
+			kill.Time, _ = ptypes.TimestampProto(now)
+			w.status.RecentOomKills = append(w.status.RecentOomKills, kill)
+			notifications = append(notifications, &api.NotifyRequest{
+				Level:   api.NotificationLevel_notification_error,
+				Message: oomKillMessage(kill, mem.Limit),
+			})
+		}
+		if len(w.status.RecentOomKills) > maxRecentOOMKills {
+			w.status.RecentOomKills = w.status.RecentOomKills[len(w.status.RecentOomKills)-maxRecentOOMKills:]
+		}
+	}
+	w.status.OomKills = mem.OOMKills
+
+	switch {
+	case !w.status.UnderPressure && mem.Pressure >= w.PressureThreshold:
+		w.status.UnderPressure = true
+		notifications = append(notifications, &api.NotifyRequest{
+			Level:   api.NotificationLevel_notification_warning,
+			Message: "The workspace is running low on memory, which slows down its processes. Consider stopping processes you do not need.",
+		})
+	case w.status.UnderPressure && mem.Pressure < w.PressureThreshold/2:
+		w.status.UnderPressure = false
+	}
+	w.polled = true
+	w.lastRSS = procs
+	w.mu.Unlock()
+
+	for _, n := range notifications {
+		resourcesLog.WithField("message", n.Message).Warn("memory notification")
+		if w.Notifications == nil {
+			continue
+		}
+		_, err := w.Notifications.Notify(ctx, n)
+		if err != nil {
+			resourcesLog.WithError(err).Warn("cannot notify about memory")
+		}
+	}
+	return nil
+}
+
+// identifyOOMKills attributes OOM kills to the processes which disappeared since the last poll, largest first,
+// as the OOM killer picks the process using the most memory
+func identifyOOMKills(before, after map[int]processMemory, kills int) []*api.OOMKill {
+	var gone []int
+	for pid := range before {
+		if _, exists := after[pid]; !exists {
+			gone = append(gone, pid)
+		}
+	}
+	sort.Slice(gone, func(i, j int) bool { return before[gone[i]].RSS > before[gone[j]].RSS })
+
+	res := make([]*api.OOMKill, kills)
+	for i := range res {
+		res[i] = &api.OOMKill{}
+		if i < len(gone) {
+			res[i].Pid = int64(gone[i])
+			res[i].Command = before[gone[i]].Command
+		}
+	}
+	return res
+}
+
+func oomKillMessage(kill *api.OOMKill, limit uint64) string {
+	var msg string
+	if kill.Command != "" {
+		msg = fmt.Sprintf("%s (PID %d) was killed, because the workspace ran out of memory.", kill.Command, kill.Pid)
+	} else {
+		msg = "A process was killed, because the workspace ran out of memory."
+	}
+	if limit > 0 {
+		msg += fmt.Sprintf(" The workspace may use %d MiB.", limit>>20)
+	}
+	return msg
+}
+
+type cgroupMemory struct {
+	Usage    uint64
+	Limit    uint64
+	OOMKills uint64
+	// Pressure is the share of the last 10 seconds in percent in which processes were stalled waiting for memory
+	Pressure float64
+}
+
+// readCgroupMemory reads the memory statistics of a cgroup, either of cgroup v2 or v1
+func readCgroupMemory(cgroupDir, procDir string) (*cgroupMemory, error) {
+	var (
+		res          cgroupMemory
+		err          error
+		pressureFile string
+	)
+	if isCgroupV2(cgroupDir) {
+		res.Usage, err = readCgroupUint(filepath.Join(cgroupDir, "memory.current"))
+		if err != nil {
+			return nil, err
+		}
+		res.Limit, err = readCgroupUint(filepath.Join(cgroupDir, "memory.max"))
+		if err != nil {
+			return nil, err
+		}
+		events, err := readCgroupKeyValues(filepath.Join(cgroupDir, "memory.events"))
+		if err != nil {
+			return nil, err
+		}
+		res.OOMKills = events["oom_kill"]
+		pressureFile = filepath.Join(cgroupDir, "memory.pressure")
+	} else {
+		dir := filepath.Join(cgroupDir, "memory")
+		res.Usage, err = readCgroupUint(filepath.Join(dir, "memory.usage_in_bytes"))
+		if err != nil {
+			return nil, err
+		}
+		res.Limit, err = readCgroupUint(filepath.Join(dir, "memory.limit_in_bytes"))
+		if err != nil {
+			return nil, err
+		}
+		if res.Limit >= cgroupV1Unlimited {
+			res.Limit = 0
+		}
+		oomControl, err := readCgroupKeyValues(filepath.Join(dir, "memory.oom_control"))
+		if err != nil {
+			return nil, err
+		}
+		res.OOMKills = oomControl["oom_kill"]
+		// cgroup v1 has no pressure per cgroup
+		pressureFile = filepath.Join(procDir, "pressure", "memory")
+	}
+
+	res.Pressure, err = readPressure(pressureFile)
+	if err != nil && !os.IsNotExist(err) {
+		resourcesLog.WithError(err).Debug("cannot read memory pressure")
+	}
+	return &res, nil
+}
+
+// readProcessMemory reads the memory the processes use in bytes
+func readProcessMemory(procDir string) map[int]processMemory {
+	entries, err := ioutil.ReadDir(procDir)
+	if err != nil {
+		return nil
+	}
+	pageSize := uint64(os.Getpagesize())
+	res := make(map[int]processMemory, len(entries))
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := readProcStat(procDir, pid)
+		if err != nil {
+			continue
+		}
+		res[pid] = processMemory{Command: stat.Comm, RSS: stat.RSS * pageSize}
+	}
+	return res
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadCgroupMemory(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Files       map[string]string
+		Expectation *cgroupMemory
+	}{
+		{
+			Desc: "cgroup v2",
+			Files: map[string]string{
+				"cgroup/cgroup.controllers": "cpu memory",
+				"cgroup/memory.current":     "1048576\n",
+				"cgroup/memory.max":         "8589934592\n",
+				"cgroup/memory.events":      "low 0\nhigh 0\nmax 3\noom 2\noom_kill 2\n",
+				"cgroup/memory.pressure":    "some avg10=25.50 avg60=10.00 avg300=1.00 total=12345\nfull avg10=5.00 avg60=1.00 avg300=0.00 total=123\n",
+			},
+			Expectation: &cgroupMemory{Usage: 1 << 20, Limit: 8 << 30, OOMKills: 2, Pressure: 25.5},
+		},
+		{
+			Desc: "cgroup v2 unlimited",
+			Files: map[string]string{
+				"cgroup/cgroup.controllers": "cpu memory",
+				"cgroup/memory.current":     "1048576\n",
+				"cgroup/memory.max":         "max\n",
+				"cgroup/memory.events":      "oom_kill 0\n",
+			},
+			Expectation: &cgroupMemory{Usage: 1 << 20},
+		},
+		{
+			Desc: "cgroup v1",
+			Files: map[string]string{
+				"cgroup/memory/memory.usage_in_bytes": "2097152\n",
+				"cgroup/memory/memory.limit_in_bytes": "9223372036854771712\n",
+				"cgroup/memory/memory.oom_control":    "oom_kill_disable 0\nunder_oom 0\noom_kill 1\n",
+				"proc/pressure/memory":                "some avg10=1.00 avg60=0.00 avg300=0.00 total=1\n",
+			},
+			Expectation: &cgroupMemory{Usage: 2 << 20, OOMKills: 1, Pressure: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "supervisor-memory")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			for fn, content := range test.Files {
+				fn = filepath.Join(dir, fn)
+				_ = os.MkdirAll(filepath.Dir(fn), 0755)
+				err = ioutil.WriteFile(fn, []byte(content), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			act, err := readCgroupMemory(filepath.Join(dir, "cgroup"), filepath.Join(dir, "proc"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected memory (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMemoryWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "supervisor-memory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cgroup, proc := filepath.Join(dir, "cgroup"), filepath.Join(dir, "proc")
+	_ = os.MkdirAll(cgroup, 0755)
+	_ = os.MkdirAll(proc, 0755)
+	write := func(oomKills int, pressure float64) {
+		for fn, content := range map[string]string{
+			"cgroup.controllers": "memory",
+			"memory.current":     "1048576",
+			"memory.max":         "1073741824",
+			"memory.events":      fmt.Sprintf("oom_kill %d\n", oomKills),
+			"memory.pressure":    fmt.Sprintf("some avg10=%.2f avg60=0.00 avg300=0.00 total=1\n", pressure),
+		} {
+			err := ioutil.WriteFile(filepath.Join(cgroup, fn), []byte(content), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	notifications := NewNotificationService()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub := &testNotificationSubscriber{ctx: ctx, events: make(chan *api.SubscribeNotificationsResponse, 10)}
+	go notifications.Subscribe(&api.SubscribeNotificationsRequest{}, sub)
+	// give the subscriber a chance to subscribe
+	time.Sleep(100 * time.Millisecond)
+
+	w := newMemoryWatcher(notifications)
+	w.cgroupDir, w.procDir = cgroup, proc
+
+	writeFakeProc(t, proc, []fakeProcess{
+		{PID: 10, PPID: 1, Comm: "supervisor", State: "S"},
+		{PID: 20, PPID: 10, Comm: "java", State: "S"},
+	})
+	// kills before supervisor watches are not reported
+	write(1, 0)
+	err = w.poll(ctx, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFakeProc(t, proc, []fakeProcess{
+		{PID: 10, PPID: 1, Comm: "supervisor", State: "S"},
+	})
+	write(2, 30)
+	err = w.poll(ctx, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := w.Status()
+	if status.OomKills != 2 || !status.UnderPressure || status.LimitBytes != 1<<30 {
+		t.Errorf("unexpected memory status: %v", status)
+	}
+	if len(status.RecentOomKills) != 1 || status.RecentOomKills[0].Pid != 20 || status.RecentOomKills[0].Command != "java" {
+		t.Errorf("unexpected OOM kills: %v", status.RecentOomKills)
+	}
+
+	var messages []string
+	for len(messages) < 2 {
+		select {
+		case e := <-sub.events:
+			messages = append(messages, e.Request.Message)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("missing notifications, got %v", messages)
+		}
+	}
+	if diff := cmp.Diff([]string{
+		"java (PID 20) was killed, because the workspace ran out of memory. The workspace may use 1024 MiB.",
+		"The workspace is running low on memory, which slows down its processes. Consider stopping processes you do not need.",
+	}, messages); diff != "" {
+		t.Errorf("unexpected notifications (-want +got):\n%s", diff)
+	}
+
+	// the pressure ends below half of the threshold
+	write(2, 15)
+	_ = w.poll(ctx, time.Now())
+	if !w.Status().UnderPressure {
+		t.Error("expected pressure to hold above half of the threshold")
+	}
+	write(2, 5)
+	_ = w.poll(ctx, time.Now())
+	if w.Status().UnderPressure {
+		t.Error("expected pressure to end")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// metadataFetchTimeout limits fetching the workspace from the Gitpod server
+	metadataFetchTimeout = 10 * time.Second
+	// metadataRetryInterval is how long to wait before fetching the workspace again after a failure
+	metadataRetryInterval = 10 * time.Second
+)
+
+// workspaceMetadata keeps the metadata of the workspace. It starts with what supervisor's config knows
+// and is completed and kept up to date with the workspace and its instance updates from the Gitpod server.
+type workspaceMetadata struct {
+	mu      sync.Mutex
+	current *api.WorkspaceMetadataResponse
+	changed chan struct{}
+}
+
+func newWorkspaceMetadata(cfg *Config) *workspaceMetadata {
+	return &workspaceMetadata{
+		current: &api.WorkspaceMetadataResponse{
+			WorkspaceId:    cfg.WorkspaceID,
+			InstanceId:     cfg.WorkspaceInstanceID,
+			WorkspaceClass: cfg.WorkspaceClass,
+		},
+		changed: make(chan struct{}),
+	}
+}
+
+// get returns the current metadata and a channel which is closed once they change
+func (m *workspaceMetadata) get() (*api.WorkspaceMetadataResponse, <-chan struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return proto.Clone(m.current).(*api.WorkspaceMetadataResponse), m.changed
+}
+
+// update changes the metadata and notifies observers if anything changed
+func (m *workspaceMetadata) update(change func(md *api.WorkspaceMetadataResponse)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	md := proto.Clone(m.current).(*api.WorkspaceMetadataResponse)
+	change(md)
+	if proto.Equal(md, m.current) {
+		return
+	}
+	m.current = md
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+// Run completes the metadata with the workspace from the Gitpod server and applies the instance updates until ctx is done
+func (m *workspaceMetadata) Run(ctx context.Context, gitpodAPI gitpod.APIInterface) error {
+	if gitpodAPI == nil {
+		return xerrors.Errorf("cannot fetch workspace metadata without a connection to the Gitpod API")
+	}
+
+	md, _ := m.get()
+	updates := gitpodAPI.InstanceUpdates(ctx, md.InstanceId)
+	for {
+		reqCtx, cancel := context.WithTimeout(ctx, metadataFetchTimeout)
+		ws, err := gitpodAPI.GetWorkspace(reqCtx, md.WorkspaceId)
+		cancel()
+		if err == nil {
+			m.applyWorkspace(ws)
+			break
+		}
+		log.WithError(err).Warn("cannot fetch workspace metadata - retrying")
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(metadataRetryInterval):
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case inst, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			m.update(func(md *api.WorkspaceMetadataResponse) { applyInstance(md, inst) })
+		}
+	}
+}
+
+func (m *workspaceMetadata) applyWorkspace(ws *gitpod.WorkspaceInfo) {
+	m.update(func(md *api.WorkspaceMetadataResponse) {
+		md.Complete = true
+		if ws.Workspace != nil {
+			md.OwnerId = ws.Workspace.OwnerID
+			md.ContextUrl = ws.Workspace.ContextURL
+		}
+		if ws.LatestInstance != nil && ws.LatestInstance.ID == md.InstanceId {
+			applyInstance(md, ws.LatestInstance)
+		}
+	})
+}
+
+func applyInstance(md *api.WorkspaceMetadataResponse, inst *gitpod.WorkspaceInstance) {
+	if inst == nil {
+		return
+	}
+	md.Cluster = inst.Region
+	if inst.StartedTime != "" {
+		if t, err := time.Parse(time.RFC3339, inst.StartedTime); err == nil {
+			md.StartedTime, _ = ptypes.TimestampProto(t)
+		}
+	}
+	if inst.Status != nil {
+		md.Phase = inst.Status.Phase
+		md.NodeName = inst.Status.NodeName
+		md.Timeout = inst.Status.Timeout
+	}
+}
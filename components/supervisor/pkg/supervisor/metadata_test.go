@@ -0,0 +1,85 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestWorkspaceMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	updates := make(chan *gitpod.WorkspaceInstance)
+	gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+	gitpodAPI.EXPECT().InstanceUpdates(gomock.Any(), "instance").Return(updates)
+	gitpodAPI.EXPECT().GetWorkspace(gomock.Any(), "ws").Return(&gitpod.WorkspaceInfo{
+		Workspace: &gitpod.Workspace{ID: "ws", OwnerID: "owner", ContextURL: "https://github.com/gitpod-io/gitpod"},
+		LatestInstance: &gitpod.WorkspaceInstance{
+			ID:          "instance",
+			Region:      "eu",
+			StartedTime: "2020-11-02T10:00:00Z",
+			Status:      &gitpod.WorkspaceInstanceStatus{Phase: "running", NodeName: "node", Timeout: "30m"},
+		},
+	}, nil)
+
+	md := newWorkspaceMetadata(&Config{WorkspaceConfig: WorkspaceConfig{WorkspaceID: "ws", WorkspaceInstanceID: "instance", WorkspaceClass: "default"}})
+	initial, changed := md.get()
+	if initial.Complete {
+		t.Error("expected metadata to be incomplete before they were fetched")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go md.Run(ctx, gitpodAPI)
+
+	await := func(changed <-chan struct{}) *api.WorkspaceMetadataResponse {
+		select {
+		case <-changed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("metadata did not change")
+		}
+		res, _ := md.get()
+		return res
+	}
+
+	started, _ := ptypes.TimestampProto(time.Date(2020, 11, 2, 10, 0, 0, 0, time.UTC))
+	expectation := &api.WorkspaceMetadataResponse{
+		WorkspaceId:    "ws",
+		InstanceId:     "instance",
+		OwnerId:        "owner",
+		ContextUrl:     "https://github.com/gitpod-io/gitpod",
+		WorkspaceClass: "default",
+		Cluster:        "eu",
+		NodeName:       "node",
+		Phase:          "running",
+		Timeout:        "30m",
+		StartedTime:    started,
+		Complete:       true,
+	}
+	if act := await(changed); !proto.Equal(expectation, act) {
+		t.Errorf("unexpected metadata: want %v, got %v", expectation, act)
+	}
+
+	_, changed = md.get()
+	updates <- &gitpod.WorkspaceInstance{
+		ID:          "instance",
+		Region:      "eu",
+		StartedTime: "2020-11-02T10:00:00Z",
+		Status:      &gitpod.WorkspaceInstanceStatus{Phase: "running", NodeName: "node", Timeout: "60m"},
+	}
+	expectation.Timeout = "60m"
+	if act := await(changed); !proto.Equal(expectation, act) {
+		t.Errorf("unexpected metadata after instance update: want %v, got %v", expectation, act)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// metricsJob is the job supervisor's metrics are pushed as
+const metricsJob = "supervisor"
+
+// metricsPusher periodically pushes supervisor's metrics to a Prometheus push gateway, grouped by the workspace
+// they belong to s.t. they can be told apart from the metrics of other workspaces.
+type metricsPusher struct {
+	URL      string
+	Interval time.Duration
+	Gatherer prometheus.Gatherer
+
+	WorkspaceID    string
+	InstanceID     string
+	WorkspaceClass string
+}
+
+func (p *metricsPusher) pusher() *push.Pusher {
+	res := push.New(p.URL, metricsJob).
+		Gatherer(p.Gatherer).
+		Grouping("workspace_id", p.WorkspaceID).
+		Grouping("instance_id", p.InstanceID)
+	if p.WorkspaceClass != "" {
+		res = res.Grouping("workspace_class", p.WorkspaceClass)
+	}
+	return res
+}
+
+// Run pushes the metrics every interval until the context is canceled. The metrics are pushed one last time once
+// the context is canceled, s.t. the final state of a stopping workspace is not lost.
+func (p *metricsPusher) Run(ctx context.Context) {
+	pusher := p.pusher()
+	t := time.NewTicker(p.Interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			err := pusher.Push()
+			if err != nil {
+				log.WithError(err).Warn("cannot push final metrics")
+			}
+			return
+		case <-t.C:
+			err := pusher.Push()
+			if err != nil {
+				log.WithError(err).Debug("cannot push metrics")
+			}
+		}
+	}
+}
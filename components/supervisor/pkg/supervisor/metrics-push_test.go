@@ -0,0 +1,78 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMetricsPusher(t *testing.T) {
+	type request struct {
+		Method string
+		Path   string
+		Body   []byte
+	}
+	requests := make(chan request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{Method: r.Method, Path: r.URL.Path, Body: body}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "supervisor_test_total"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		(&metricsPusher{
+			URL:            srv.URL,
+			Interval:       10 * time.Millisecond,
+			Gatherer:       reg,
+			WorkspaceID:    "ws",
+			InstanceID:     "inst",
+			WorkspaceClass: "large",
+		}).Run(ctx)
+		close(done)
+	}()
+
+	var req request
+	select {
+	case req = <-requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("metrics were not pushed")
+	}
+	if req.Method != http.MethodPut {
+		t.Errorf("unexpected method: %s", req.Method)
+	}
+	// the order of the grouping labels is not defined
+	if !strings.HasPrefix(req.Path, "/metrics/job/supervisor/") {
+		t.Errorf("unexpected path: %s", req.Path)
+	}
+	for _, label := range []string{"/workspace_id/ws", "/instance_id/inst", "/workspace_class/large"} {
+		if !strings.Contains(req.Path, label) {
+			t.Errorf("expected path %s to contain %s", req.Path, label)
+		}
+	}
+	if len(req.Body) == 0 {
+		t.Error("expected metrics to be pushed")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pusher did not stop")
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsService serves supervisor's Prometheus metrics
+type metricsService struct {
+	Registry *prometheus.Registry
+
+	// IDEReady is how long it took until the IDE was ready
+	IDEReady prometheus.Gauge
+	// TaskFailures counts the tasks which exited with an error
+	TaskFailures prometheus.Counter
+	// APICalls observes the duration of the calls to the Gitpod API by method and outcome
+	APICalls *prometheus.HistogramVec
+}
+
+func newMetricsService() *metricsService {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+	res := &metricsService{
+		Registry: reg,
+		IDEReady: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "supervisor_ide_ready_seconds",
+			Help: "Time from the start of supervisor until the IDE was ready",
+		}),
+		TaskFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "supervisor_task_failures_total",
+			Help: "Number of tasks which exited with a non-zero exit code",
+		}),
+		APICalls: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "supervisor_gitpod_api_call_duration_seconds",
+			Help:    "Duration of the calls to the Gitpod API",
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"method", "outcome"}),
+	}
+	reg.MustRegister(res.IDEReady, res.TaskFailures, res.APICalls)
+	return res
+}
+
+// ObserveAPICall records a call to the Gitpod API
+func (s *metricsService) ObserveAPICall(method gitpod.FunctionName, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	s.APICalls.WithLabelValues(string(method), outcome).Observe(duration.Seconds())
+}
+
+// RegisterPorts registers the number of served and exposed ports of the port manager
+func (s *metricsService) RegisterPorts(pm *ports.Manager) {
+	count := func(served bool) func() float64 {
+		return func() float64 {
+			var n int
+			for _, p := range pm.Status() {
+				if served && p.Served || !served && p.Exposed != nil {
+					n++
+				}
+			}
+			return float64(n)
+		}
+	}
+	s.Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name:        "supervisor_ports",
+			Help:        "Number of ports by state",
+			ConstLabels: prometheus.Labels{"state": "served"},
+		}, count(true)),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name:        "supervisor_ports",
+			Help:        "Number of ports by state",
+			ConstLabels: prometheus.Labels{"state": "exposed"},
+		}, count(false)),
+	)
+}
+
+// RegisterHTTP registers the metrics endpoint
+func (s *metricsService) RegisterHTTP(mux *http.ServeMux) {
+	mux.Handle("/_supervisor/metrics", promhttp.HandlerFor(s.Registry, promhttp.HandlerOpts{}))
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxPendingNotifications is the number of notifications which may wait for a response at once
+	maxPendingNotifications = 100
+	// maxQueuedNotifications is the number of notifications queued for a subscriber before they are dropped
+	maxQueuedNotifications = 50
+)
+
+// NotificationService implements the api.NotificationService. Subsystems of supervisor call Notify directly.
+type NotificationService struct {
+	mu          sync.Mutex
+	nextID      uint64
+	pending     map[uint64]*pendingNotification
+	subscribers map[chan *api.SubscribeNotificationsResponse]struct{}
+}
+
+type pendingNotification struct {
+	req  *api.NotifyRequest
+	resp chan *api.NotifyResponse
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		pending:     make(map[uint64]*pendingNotification),
+		subscribers: make(map[chan *api.SubscribeNotificationsResponse]struct{}),
+	}
+}
+
+// RegisterGRPC registers the gRPC notification service
+func (s *NotificationService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterNotificationServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST notification service
+func (s *NotificationService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterNotificationServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// Notify shows a notification and waits for the response if it has actions
+func (s *NotificationService) Notify(ctx context.Context, req *api.NotifyRequest) (*api.NotifyResponse, error) {
+	if req.Message == "" {
+		return nil, status.Error(codes.InvalidArgument, "message is required")
+	}
+	actions := make(map[string]struct{}, len(req.Actions))
+	for _, a := range req.Actions {
+		if a == "" {
+			return nil, status.Error(codes.InvalidArgument, "actions must not be empty")
+		}
+		if _, exists := actions[a]; exists {
+			return nil, status.Errorf(codes.InvalidArgument, "duplicate action %q", a)
+		}
+		actions[a] = struct{}{}
+	}
+	if _, exists := actions[req.DefaultAction]; req.DefaultAction != "" && !exists {
+		return nil, status.Errorf(codes.InvalidArgument, "default action %q is not one of the actions", req.DefaultAction)
+	}
+
+	req = proto.Clone(req).(*api.NotifyRequest)
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	if len(req.Actions) == 0 {
+		s.broadcast(&api.SubscribeNotificationsResponse{RequestId: id, Request: req})
+		s.mu.Unlock()
+		return &api.NotifyResponse{}, nil
+	}
+	if len(s.pending) >= maxPendingNotifications {
+		s.mu.Unlock()
+		return nil, status.Error(codes.ResourceExhausted, "too many notifications wait for a response")
+	}
+	p := &pendingNotification{req: req, resp: make(chan *api.NotifyResponse, 1)}
+	s.pending[id] = p
+	s.broadcast(&api.SubscribeNotificationsResponse{RequestId: id, Request: req})
+	s.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if req.TimeoutSeconds > 0 {
+		t := time.NewTimer(time.Duration(req.TimeoutSeconds) * time.Second)
+		defer t.Stop()
+		timeout = t.C
+	}
+	select {
+	case resp := <-p.resp:
+		return resp, nil
+	case <-timeout:
+		s.resolve(id, &api.NotifyResponse{Action: req.DefaultAction, TimedOut: true})
+		// a client may have responded right before
+		return <-p.resp, nil
+	case <-ctx.Done():
+		s.resolve(id, nil)
+		return nil, status.Error(codes.Canceled, ctx.Err().Error())
+	}
+}
+
+// Subscribe streams the notifications to show
+func (s *NotificationService) Subscribe(req *api.SubscribeNotificationsRequest, srv api.NotificationService_SubscribeServer) error {
+	events := make(chan *api.SubscribeNotificationsResponse, maxQueuedNotifications)
+	s.mu.Lock()
+	// notifications which wait for a response are shown by clients which connect later, too
+	for id, p := range s.pending {
+		select {
+		case events <- &api.SubscribeNotificationsResponse{RequestId: id, Request: p.req}:
+		default:
+		}
+	}
+	s.subscribers[events] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, events)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case e := <-events:
+			err := srv.Send(e)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Respond delivers the action the user chose for a notification
+func (s *NotificationService) Respond(ctx context.Context, req *api.RespondNotificationRequest) (*api.RespondNotificationResponse, error) {
+	s.mu.Lock()
+	p, exists := s.pending[req.RequestId]
+	s.mu.Unlock()
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "notification %d does not wait for a response", req.RequestId)
+	}
+	if req.Action != "" {
+		var valid bool
+		for _, a := range p.req.Actions {
+			if a == req.Action {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, status.Errorf(codes.InvalidArgument, "%q is not an action of notification %d", req.Action, req.RequestId)
+		}
+	}
+	if !s.resolve(req.RequestId, &api.NotifyResponse{Action: req.Action}) {
+		return nil, status.Errorf(codes.NotFound, "notification %d does not wait for a response", req.RequestId)
+	}
+	return &api.RespondNotificationResponse{}, nil
+}
+
+// resolve ends waiting for the response of a notification and tells the subscribers to hide it.
+// It returns false if the notification was resolved already.
+func (s *NotificationService) resolve(id uint64, resp *api.NotifyResponse) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, exists := s.pending[id]
+	if !exists {
+		return false
+	}
+	delete(s.pending, id)
+	if resp != nil {
+		p.resp <- resp
+	}
+	s.broadcast(&api.SubscribeNotificationsResponse{RequestId: id, Request: p.req, Closed: true})
+	return true
+}
+
+// broadcast sends an event to all subscribers. Callers are expected to hold mu.
+func (s *NotificationService) broadcast(e *api.SubscribeNotificationsResponse) {
+	for sub := range s.subscribers {
+		select {
+		case sub <- e:
+		default:
+			log.WithField("request", e.RequestId).Warn("cannot push notification to a subscriber")
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testNotificationSubscriber struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *api.SubscribeNotificationsResponse
+}
+
+func (s *testNotificationSubscriber) Context() context.Context { return s.ctx }
+
+func (s *testNotificationSubscriber) Send(e *api.SubscribeNotificationsResponse) error {
+	s.events <- e
+	return nil
+}
+
+func TestNotificationService(t *testing.T) {
+	type Expectation struct {
+		Action   string
+		TimedOut bool
+		Code     codes.Code
+		// Events are the request ids of the events the subscriber received, negative for closed notifications
+		Events []int64
+	}
+	tests := []struct {
+		Desc        string
+		Request     *api.NotifyRequest
+		Respond     *api.RespondNotificationRequest
+		Cancel      bool
+		Expectation Expectation
+	}{
+		{
+			Desc:        "no actions",
+			Request:     &api.NotifyRequest{Message: "hello"},
+			Expectation: Expectation{Events: []int64{1}},
+		},
+		{
+			Desc:        "response",
+			Request:     &api.NotifyRequest{Message: "public?", Actions: []string{"yes", "no"}},
+			Respond:     &api.RespondNotificationRequest{RequestId: 1, Action: "no"},
+			Expectation: Expectation{Action: "no", Events: []int64{1, -1}},
+		},
+		{
+			Desc:        "dismissed",
+			Request:     &api.NotifyRequest{Message: "public?", Actions: []string{"yes", "no"}},
+			Respond:     &api.RespondNotificationRequest{RequestId: 1},
+			Expectation: Expectation{Events: []int64{1, -1}},
+		},
+		{
+			Desc:        "timeout",
+			Request:     &api.NotifyRequest{Message: "public?", Actions: []string{"yes", "no"}, TimeoutSeconds: 1, DefaultAction: "no"},
+			Expectation: Expectation{Action: "no", TimedOut: true, Events: []int64{1, -1}},
+		},
+		{
+			Desc:        "canceled",
+			Request:     &api.NotifyRequest{Message: "public?", Actions: []string{"yes"}},
+			Cancel:      true,
+			Expectation: Expectation{Code: codes.Canceled, Events: []int64{1, -1}},
+		},
+		{
+			Desc:        "invalid default action",
+			Request:     &api.NotifyRequest{Message: "public?", Actions: []string{"yes"}, DefaultAction: "no"},
+			Expectation: Expectation{Code: codes.InvalidArgument},
+		},
+		{
+			Desc:        "duplicate action",
+			Request:     &api.NotifyRequest{Message: "public?", Actions: []string{"yes", "yes"}},
+			Expectation: Expectation{Code: codes.InvalidArgument},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			srv := NewNotificationService()
+			subCtx, cancelSub := context.WithCancel(context.Background())
+			defer cancelSub()
+			sub := &testNotificationSubscriber{ctx: subCtx, events: make(chan *api.SubscribeNotificationsResponse, 10)}
+			go srv.Subscribe(&api.SubscribeNotificationsRequest{}, sub)
+			for {
+				srv.mu.Lock()
+				subscribed := len(srv.subscribers) > 0
+				srv.mu.Unlock()
+				if subscribed {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var (
+				resp *api.NotifyResponse
+				err  error
+				done = make(chan struct{})
+			)
+			go func() {
+				defer close(done)
+				resp, err = srv.Notify(ctx, test.Request)
+			}()
+
+			var act Expectation
+			if test.Expectation.Code != codes.InvalidArgument {
+				e := <-sub.events
+				act.Events = append(act.Events, int64(e.RequestId))
+			}
+			if test.Respond != nil {
+				_, rerr := srv.Respond(context.Background(), test.Respond)
+				if rerr != nil {
+					t.Fatal(rerr)
+				}
+			}
+			if test.Cancel {
+				cancel()
+			}
+			<-done
+			if err != nil {
+				act.Code = status.Code(err)
+			} else {
+				act.Action, act.TimedOut = resp.Action, resp.TimedOut
+			}
+			if len(test.Request.Actions) > 0 && act.Code != codes.InvalidArgument {
+				e := <-sub.events
+				if !e.Closed {
+					t.Errorf("expected the notification to be closed: %v", e)
+				}
+				act.Events = append(act.Events, -int64(e.RequestId))
+			}
+
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotificationServiceRespond(t *testing.T) {
+	srv := NewNotificationService()
+	_, err := srv.Respond(context.Background(), &api.RespondNotificationRequest{RequestId: 42, Action: "yes"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected unknown notifications not to be found, got %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		srv.Notify(context.Background(), &api.NotifyRequest{Message: "public?", Actions: []string{"yes"}})
+	}()
+	for {
+		srv.mu.Lock()
+		pending := len(srv.pending) > 0
+		srv.mu.Unlock()
+		if pending {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	_, err = srv.Respond(context.Background(), &api.RespondNotificationRequest{RequestId: 1, Action: "no"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected other actions to be rejected, got %v", err)
+	}
+	_, err = srv.Respond(context.Background(), &api.RespondNotificationRequest{RequestId: 1, Action: "yes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-done
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultOpenHandlerTimeout is the time an IDE has to handle an open request before the next one is asked
+	defaultOpenHandlerTimeout = 10 * time.Second
+	// maxQueuedOpenRequests is the number of open requests queued for a handler before it is skipped
+	maxQueuedOpenRequests = 10
+)
+
+// OpenerService implements the api.OpenerService. It hands open requests to the IDE which was focused last
+// and notifies the user if no IDE handles them.
+type OpenerService struct {
+	Notifications *NotificationService
+	// HandlerTimeout defaults to defaultOpenHandlerTimeout
+	HandlerTimeout time.Duration
+
+	mu     sync.Mutex
+	nextID uint64
+	// handlers are ordered by focus, the one focused last comes last
+	handlers []*openHandler
+	pending  map[uint64]chan bool
+}
+
+type openHandler struct {
+	id         string
+	clientName string
+	requests   chan *api.SubscribeOpenResponse
+	done       chan struct{}
+}
+
+// RegisterGRPC registers the gRPC opener service
+func (s *OpenerService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterOpenerServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST opener service
+func (s *OpenerService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterOpenerServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// Open asks the IDEs to open the URI, most recently focused first
+func (s *OpenerService) Open(ctx context.Context, req *api.OpenRequest) (*api.OpenResponse, error) {
+	uri, err := url.Parse(req.Uri)
+	if err != nil || uri.Scheme == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid URI %q: must be absolute, e.g. file:///workspace/README.md", req.Uri)
+	}
+
+	s.mu.Lock()
+	handlers := make([]*openHandler, len(s.handlers))
+	copy(handlers, s.handlers)
+	s.mu.Unlock()
+
+	for i := len(handlers) - 1; i >= 0; i-- {
+		handled, err := s.ask(ctx, handlers[i], req)
+		if err != nil {
+			return nil, err
+		}
+		if handled {
+			return &api.OpenResponse{HandledBy: handlers[i].clientName}, nil
+		}
+	}
+
+	if s.Notifications == nil {
+		return nil, status.Errorf(codes.Unavailable, "no IDE can open %s", req.Uri)
+	}
+	msg := fmt.Sprintf("Open %s in your browser.", req.Uri)
+	if uri.Scheme == "file" {
+		msg = fmt.Sprintf("Cannot open %s: there is no IDE connected to the workspace.", uri.Path)
+	}
+	_, err = s.Notifications.Notify(ctx, &api.NotifyRequest{
+		Level:   api.NotificationLevel_notification_info,
+		Message: msg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &api.OpenResponse{Notified: true}, nil
+}
+
+// ask hands the request to a handler and waits until it responded, timed out or went away
+func (s *OpenerService) ask(ctx context.Context, h *openHandler, req *api.OpenRequest) (handled bool, err error) {
+	resp := make(chan bool, 1)
+	s.mu.Lock()
+	if s.pending == nil {
+		s.pending = make(map[uint64]chan bool)
+	}
+	s.nextID++
+	id := s.nextID
+	s.pending[id] = resp
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+	}()
+
+	select {
+	case h.requests <- &api.SubscribeOpenResponse{RequestId: id, Request: req}:
+	default:
+		log.WithField("client", h.clientName).Warn("open request queue is full - skipping IDE")
+		return false, nil
+	}
+
+	timeout := s.HandlerTimeout
+	if timeout == 0 {
+		timeout = defaultOpenHandlerTimeout
+	}
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	select {
+	case handled = <-resp:
+		return handled, nil
+	case <-h.done:
+		return false, nil
+	case <-t.C:
+		log.WithField("client", h.clientName).WithField("uri", req.Uri).Warn("IDE did not handle open request in time")
+		return false, nil
+	case <-ctx.Done():
+		return false, status.Error(codes.Canceled, ctx.Err().Error())
+	}
+}
+
+// Subscribe registers an IDE as handler of open requests until the call is canceled
+func (s *OpenerService) Subscribe(req *api.SubscribeOpenRequest, srv api.OpenerService_SubscribeServer) error {
+	h := &openHandler{
+		clientName: req.ClientName,
+		requests:   make(chan *api.SubscribeOpenResponse, maxQueuedOpenRequests),
+		done:       make(chan struct{}),
+	}
+	s.mu.Lock()
+	s.nextID++
+	h.id = strconv.FormatUint(s.nextID, 10)
+	s.handlers = append(s.handlers, h)
+	s.mu.Unlock()
+	defer func() {
+		close(h.done)
+		s.mu.Lock()
+		s.removeHandler(h)
+		s.mu.Unlock()
+	}()
+
+	err := srv.Send(&api.SubscribeOpenResponse{HandlerId: h.id})
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case r := <-h.requests:
+			err := srv.Send(r)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// removeHandler removes h from the handlers. Callers are expected to hold mu.
+func (s *OpenerService) removeHandler(h *openHandler) bool {
+	for i, c := range s.handlers {
+		if c == h {
+			s.handlers = append(s.handlers[:i], s.handlers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Focus moves a handler to the front of the line
+func (s *OpenerService) Focus(ctx context.Context, req *api.FocusOpenHandlerRequest) (*api.FocusOpenHandlerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, h := range s.handlers {
+		if h.id == req.HandlerId {
+			s.removeHandler(h)
+			s.handlers = append(s.handlers, h)
+			return &api.FocusOpenHandlerResponse{}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "handler %s does not exist", req.HandlerId)
+}
+
+// Respond delivers whether an IDE handled an open request
+func (s *OpenerService) Respond(ctx context.Context, req *api.RespondOpenRequest) (*api.RespondOpenResponse, error) {
+	s.mu.Lock()
+	resp, ok := s.pending[req.RequestId]
+	delete(s.pending, req.RequestId)
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "open request %d is not pending", req.RequestId)
+	}
+	resp <- req.Handled
+	return &api.RespondOpenResponse{}, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testOpenHandler struct {
+	grpc.ServerStream
+	ctx      context.Context
+	id       chan string
+	requests chan *api.SubscribeOpenResponse
+}
+
+func (s *testOpenHandler) Context() context.Context { return s.ctx }
+
+func (s *testOpenHandler) Send(e *api.SubscribeOpenResponse) error {
+	if e.HandlerId != "" {
+		s.id <- e.HandlerId
+		return nil
+	}
+	s.requests <- e
+	return nil
+}
+
+// subscribeOpenHandler subscribes an IDE which responds to open requests with handled
+func subscribeOpenHandler(ctx context.Context, t *testing.T, srv *OpenerService, clientName string, handled bool) (id string, requests chan *api.SubscribeOpenResponse) {
+	h := &testOpenHandler{ctx: ctx, id: make(chan string, 1), requests: make(chan *api.SubscribeOpenResponse, 10)}
+	go srv.Subscribe(&api.SubscribeOpenRequest{ClientName: clientName}, h)
+
+	requests = make(chan *api.SubscribeOpenResponse, 10)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case r := <-h.requests:
+				requests <- r
+				_, err := srv.Respond(ctx, &api.RespondOpenRequest{RequestId: r.RequestId, Handled: handled})
+				if err != nil {
+					t.Error(err)
+				}
+			}
+		}
+	}()
+
+	select {
+	case id = <-h.id:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the handler id")
+	}
+	return id, requests
+}
+
+func TestOpenerService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifications := NewNotificationService()
+	srv := &OpenerService{Notifications: notifications, HandlerTimeout: time.Second}
+
+	_, err := srv.Open(ctx, &api.OpenRequest{Uri: "README.md"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a relative URI, got %v", err)
+	}
+
+	resp, err := srv.Open(ctx, &api.OpenRequest{Uri: "https://8080-workspace-url"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Notified {
+		t.Errorf("expected the user to be notified without IDEs, got %v", resp)
+	}
+
+	browserID, browser := subscribeOpenHandler(ctx, t, srv, "browser", true)
+	_, desktop := subscribeOpenHandler(ctx, t, srv, "desktop", false)
+
+	// desktop was subscribed last but cannot handle the request, hence browser is asked next
+	resp, err = srv.Open(ctx, &api.OpenRequest{Uri: "file:///workspace/README.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.HandledBy != "browser" {
+		t.Errorf("expected browser to handle the request, got %v", resp)
+	}
+	if len(desktop) != 1 || len(browser) != 1 {
+		t.Errorf("expected both IDEs to be asked once, got desktop %d, browser %d", len(desktop), len(browser))
+	}
+	<-desktop
+	<-browser
+
+	_, err = srv.Focus(ctx, &api.FocusOpenHandlerRequest{HandlerId: browserID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = srv.Open(ctx, &api.OpenRequest{Uri: "file:///workspace/README.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.HandledBy != "browser" || len(desktop) != 0 {
+		t.Errorf("expected the focused browser to handle the request alone, got %v", resp)
+	}
+
+	_, err = srv.Focus(ctx, &api.FocusOpenHandlerRequest{HandlerId: "unknown"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for an unknown handler, got %v", err)
+	}
+	_, err = srv.Respond(ctx, &api.RespondOpenRequest{RequestId: 1000})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for an unknown request, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+)
+
+const (
+	actionMakePublic  = "Make Public"
+	actionKeepPrivate = "Keep Private"
+
+	// publicApprovalTimeoutSeconds is how long the user is asked to approve a public port before it stays private
+	publicApprovalTimeoutSeconds = 10 * 60
+)
+
+// publicPortApprover asks the user through a notification whether a port which waits for approval becomes public
+type publicPortApprover struct {
+	Notifications *NotificationService
+	// Approve approves or rejects making a port public
+	Approve func(ctx context.Context, port uint32, approve bool) error
+
+	mu     sync.Mutex
+	asking map[uint32]struct{}
+}
+
+// Run asks for approval of the ports of the port manager until ctx is done
+func (a *publicPortApprover) Run(ctx context.Context, pm *ports.Manager) {
+	sub := pm.Events().Subscribe("public-approval", ports.PortExposedKind)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-sub.Events():
+			if event == nil {
+				return
+			}
+			e, ok := event.(ports.PortExposed)
+			if !ok || e.Status == nil || !e.Status.PendingPublic {
+				continue
+			}
+			go a.ask(ctx, e.Port)
+		}
+	}
+}
+
+// ask asks the user whether a port becomes public, unless the user is asked already
+func (a *publicPortApprover) ask(ctx context.Context, port uint32) {
+	a.mu.Lock()
+	if a.asking == nil {
+		a.asking = make(map[uint32]struct{})
+	}
+	if _, asking := a.asking[port]; asking {
+		a.mu.Unlock()
+		return
+	}
+	a.asking[port] = struct{}{}
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		delete(a.asking, port)
+		a.mu.Unlock()
+	}()
+
+	resp, err := a.Notifications.Notify(ctx, &api.NotifyRequest{
+		Level:          api.NotificationLevel_notification_warning,
+		Message:        fmt.Sprintf("Port %d is configured to be public, which makes it accessible to anyone who knows its URL.", port),
+		Actions:        []string{actionMakePublic, actionKeepPrivate},
+		TimeoutSeconds: publicApprovalTimeoutSeconds,
+		DefaultAction:  actionKeepPrivate,
+	})
+	if err != nil {
+		log.WithError(err).WithField("port", port).Debug("cannot ask for approval of public port")
+		return
+	}
+	if resp.Action == "" {
+		// dismissed - the port keeps waiting for approval, e.g. through the ports view of the IDE
+		return
+	}
+	err = a.Approve(ctx, port, resp.Action == actionMakePublic)
+	if err != nil {
+		log.WithError(err).WithField("port", port).Warn("cannot apply approval of public port")
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+)
+
+// pprofMutexProfileFraction samples one in this many mutex contention events while profiling is enabled
+const pprofMutexProfileFraction = 5
+
+// pprofHandler serves the runtime profiles of supervisor, e.g. /debug/pprof/profile for the CPU profile
+func pprofHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// pprofServer serves the runtime profiles on localhost. The profiles are never exposed.
+type pprofServer struct {
+	ctx context.Context
+
+	mu     sync.Mutex
+	port   int
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newPprofServer(ctx context.Context) *pprofServer {
+	return &pprofServer{ctx: ctx}
+}
+
+// Serve serves the profiles on a port instead of the current one. Port zero stops serving.
+func (s *pprofServer) Serve(port int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if port == s.port {
+		return
+	}
+	if s.cancel != nil {
+		s.cancel()
+		// the port must be free before serving again
+		<-s.done
+		s.cancel = nil
+	}
+	s.port = port
+	if port == 0 {
+		runtime.SetMutexProfileFraction(0)
+		log.Info("stopped serving pprof")
+		return
+	}
+
+	// the mutex profile is empty unless sampled, which costs a little performance, hence only while profiling is enabled
+	runtime.SetMutexProfileFraction(pprofMutexProfileFraction)
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	srv := &http.Server{
+		Addr:    fmt.Sprintf("localhost:%d", port),
+		Handler: pprofHandler(),
+	}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	go func(done chan struct{}) {
+		defer close(done)
+		log.WithField("port", port).Info("serving pprof")
+		err := srv.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			log.WithError(err).WithField("port", port).Error("cannot serve pprof")
+		}
+	}(s.done)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports/portstest"
+)
+
+func TestPprofServer(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := newPprofServer(ctx)
+	get := func() (int, error) {
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d/debug/pprof/heap", port))
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	srv.Serve(port)
+	var code int
+	for i := 0; i < 50; i++ {
+		code, err = get()
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil || code != http.StatusOK {
+		t.Fatalf("cannot get heap profile: %d, %v", code, err)
+	}
+	if fraction := runtime.SetMutexProfileFraction(-1); fraction != pprofMutexProfileFraction {
+		t.Errorf("unexpected mutex profile fraction while serving: %d", fraction)
+	}
+
+	srv.Serve(0)
+	if _, err = get(); err == nil {
+		t.Error("pprof is still served")
+	}
+	if fraction := runtime.SetMutexProfileFraction(-1); fraction != 0 {
+		t.Errorf("unexpected mutex profile fraction after serving: %d", fraction)
+	}
+}
+
+func TestPprofPortIsInternal(t *testing.T) {
+	freePort := func() int {
+		l, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l.Close()
+		return l.Addr().(*net.TCPAddr).Port
+	}
+	port, newPort := freePort(), freePort()
+	listed := func(status []*api.PortsStatus, port int) bool {
+		for _, s := range status {
+			if s.LocalPort == uint32(port) {
+				return true
+			}
+		}
+		return false
+	}
+
+	cfg := &Config{
+		StaticConfig:    StaticConfig{APIEndpointPort: 22999, PprofPort: port},
+		WorkspaceConfig: WorkspaceConfig{IDEPort: 23000},
+	}
+	h := portstest.NewHarness(internalPorts(cfg)...)
+	h.Start()
+	defer h.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pprof := newPprofServer(ctx)
+	pprof.Serve(port)
+	defer pprof.Serve(0)
+
+	h.Served.Update(portstest.Served(uint32(port), 3000)...)
+	status := portstest.AwaitStatus(t, h.Manager, func(status []*api.PortsStatus) bool { return listed(status, 3000) })
+	if listed(status, port) {
+		t.Errorf("pprof port %d is listed: %v", port, status)
+	}
+
+	newCfg := cfg.StaticConfig
+	newCfg.PprofPort = newPort
+	(&staticConfigApplier{Ports: h.Manager, Pprof: pprof}).Apply(cfg.StaticConfig, newCfg)
+	h.Served.Update(portstest.Served(uint32(port), uint32(newPort), 3000)...)
+	status = portstest.AwaitStatus(t, h.Manager, func(status []*api.PortsStatus) bool { return listed(status, port) })
+	if listed(status, newPort) {
+		t.Errorf("reloaded pprof port %d is listed: %v", newPort, status)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// prebuildLogDir is where the logs of the tasks are written to during a prebuild, s.t. they are part of its snapshot
+	prebuildLogDir = "/workspace/.gitpod"
+	// prebuildLogPrefix prefixes the names of the log files, which end with the task id
+	prebuildLogPrefix = "prebuild-log-"
+	// prebuildManifestSuffix is appended to the log file name for the file describing the log
+	prebuildManifestSuffix = ".json"
+)
+
+// prebuildLog describes the log of a task which ran during a prebuild
+type prebuildLog struct {
+	TaskID   string    `json:"taskId"`
+	Name     string    `json:"name"`
+	Commands []string  `json:"commands"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Success  bool      `json:"success"`
+	ExitCode int       `json:"exitCode"`
+}
+
+func prebuildLogFileName(dir, taskID string) string {
+	return filepath.Join(dir, prebuildLogPrefix+taskID)
+}
+
+// writePrebuildLogManifest writes the description of a task's prebuild log next to the log
+func writePrebuildLogManifest(dir string, l *prebuildLog) error {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(prebuildLogFileName(dir, l.TaskID)+prebuildManifestSuffix, b, 0644)
+}
+
+// readPrebuildLogs reads the descriptions of the prebuild logs in dir, ordered by task id.
+// Logs of prebuilds which predate the descriptions are not listed.
+func readPrebuildLogs(dir string) ([]*prebuildLog, error) {
+	fns, err := filepath.Glob(filepath.Join(dir, prebuildLogPrefix+"*"+prebuildManifestSuffix))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*prebuildLog, 0, len(fns))
+	for _, fn := range fns {
+		b, err := ioutil.ReadFile(fn)
+		if err != nil {
+			return nil, err
+		}
+		var l prebuildLog
+		err = json.Unmarshal(b, &l)
+		if err != nil {
+			return nil, xerrors.Errorf("cannot parse %s: %w", fn, err)
+		}
+		res = append(res, &l)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		a, aerr := strconv.Atoi(res[i].TaskID)
+		b, berr := strconv.Atoi(res[j].TaskID)
+		if aerr != nil || berr != nil {
+			return res[i].TaskID < res[j].TaskID
+		}
+		return a < b
+	})
+	return res, nil
+}
+
+// openPrebuildLog opens the log of a task listed by readPrebuildLogs
+func openPrebuildLog(dir, taskID string) (*os.File, error) {
+	if taskID == "" || strings.ContainsAny(taskID, `/\`) || strings.HasPrefix(taskID, ".") {
+		return nil, os.ErrNotExist
+	}
+	if _, err := os.Stat(prebuildLogFileName(dir, taskID) + prebuildManifestSuffix); err != nil {
+		return nil, err
+	}
+	return os.Open(prebuildLogFileName(dir, taskID))
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPrebuildLogs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prebuild-logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	started := time.Date(2020, 11, 1, 10, 0, 0, 0, time.UTC)
+	for _, l := range []*prebuildLog{
+		{TaskID: "10", Name: "watch", Commands: []string{"yarn"}, Started: started, Finished: started.Add(time.Minute), Success: true},
+		{TaskID: "2", Name: "build", Commands: []string{"go get", "go build"}, Started: started, Finished: started.Add(time.Minute), ExitCode: 1},
+	} {
+		err := writePrebuildLogManifest(dir, l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(prebuildLogFileName(dir, l.TaskID), []byte("output of "+l.Name), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// logs of older prebuilds have no description
+	err = ioutil.WriteFile(filepath.Join(dir, prebuildLogPrefix+"3"), []byte("legacy"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := &TaskService{logDir: dir}
+	resp, err := srv.ListPrebuildLogs(context.Background(), &api.ListPrebuildLogsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var act []string
+	for _, l := range resp.Logs {
+		act = append(act, l.Id+":"+l.Name)
+		if l.Size != int64(len("output of "+l.Name)) {
+			t.Errorf("unexpected size of log %s: %d", l.Id, l.Size)
+		}
+	}
+	if diff := cmp.Diff([]string{"2:build", "10:watch"}, act); diff != "" {
+		t.Errorf("unexpected logs (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		Desc        string
+		ID          string
+		Expectation string
+	}{
+		{Desc: "described log", ID: "2", Expectation: "output of build"},
+		{Desc: "legacy log", ID: "3"},
+		{Desc: "unknown task", ID: "4"},
+		{Desc: "path traversal", ID: "../" + filepath.Base(dir) + "/" + prebuildLogPrefix + "2"},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			f, err := openPrebuildLog(dir, test.ID)
+			if test.Expectation == "" {
+				if !os.IsNotExist(err) {
+					t.Errorf("expected the log not to exist, got %v", err)
+				}
+				if f != nil {
+					f.Close()
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			b, err := ioutil.ReadAll(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != test.Expectation {
+				t.Errorf("unexpected log: want %q, got %q", test.Expectation, string(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PresenceService implements the api.PresenceService. It tracks the IDE and SSH connections to the workspace.
+type PresenceService struct {
+	mu          sync.Mutex
+	nextID      uint64
+	connections map[string]*api.PresenceConnection
+	// subscribers are signaled whenever the connections change. Signals are coalesced, subscribers send the latest state.
+	subscribers map[chan struct{}]struct{}
+}
+
+// NewPresenceService creates a new presence service
+func NewPresenceService() *PresenceService {
+	return &PresenceService{
+		connections: make(map[string]*api.PresenceConnection),
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// RegisterGRPC registers the gRPC presence service
+func (s *PresenceService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterPresenceServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST presence service
+func (s *PresenceService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterPresenceServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// Connect marks a connection as present until the returned function is called. It returns the id of the connection.
+func (s *PresenceService) Connect(conn *api.PresenceConnection) (id string, disconnect func()) {
+	conn = proto.Clone(conn).(*api.PresenceConnection)
+	conn.Since, _ = ptypes.TimestampProto(time.Now())
+
+	s.mu.Lock()
+	s.nextID++
+	conn.Id = strconv.FormatUint(s.nextID, 10)
+	s.connections[conn.Id] = conn
+	s.changed()
+	s.mu.Unlock()
+
+	log.WithField("client", conn.Client.String()).WithField("user", conn.UserName).Debug("client connected")
+
+	var once sync.Once
+	return conn.Id, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.connections, conn.Id)
+			s.changed()
+			s.mu.Unlock()
+		})
+	}
+}
+
+// changed signals the subscribers. Callers are expected to hold mu.
+func (s *PresenceService) changed() {
+	for sub := range s.subscribers {
+		select {
+		case sub <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Join marks a user as connected until the call is canceled
+func (s *PresenceService) Join(req *api.JoinPresenceRequest, srv api.PresenceService_JoinServer) error {
+	if req.UserId == "" {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	id, disconnect := s.Connect(&api.PresenceConnection{
+		Client:     api.PresenceClient_presence_ide,
+		UserId:     req.UserId,
+		UserName:   req.UserName,
+		ClientName: req.ClientName,
+	})
+	defer disconnect()
+
+	err := srv.Send(&api.JoinPresenceResponse{ConnectionId: id})
+	if err != nil {
+		return err
+	}
+	<-srv.Context().Done()
+	return nil
+}
+
+// Presence streams the connections to the workspace whenever they change
+func (s *PresenceService) Presence(req *api.PresenceRequest, srv api.PresenceService_PresenceServer) error {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	s.mu.Lock()
+	s.subscribers[changes] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, changes)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case <-changes:
+			err := srv.Send(s.status())
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// status returns the current connections, oldest first
+func (s *PresenceService) status() *api.PresenceResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := &api.PresenceResponse{Connections: make([]*api.PresenceConnection, 0, len(s.connections))}
+	users := make(map[string]struct{}, len(s.connections))
+	for _, c := range s.connections {
+		res.Connections = append(res.Connections, c)
+		users[c.UserId] = struct{}{}
+	}
+	sort.Slice(res.Connections, func(i, j int) bool {
+		a, _ := strconv.ParseUint(res.Connections[i].Id, 10, 64)
+		b, _ := strconv.ParseUint(res.Connections[j].Id, 10, 64)
+		return a < b
+	})
+	res.Users = uint32(len(users))
+	return res
+}
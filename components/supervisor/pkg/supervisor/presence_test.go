@@ -0,0 +1,107 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testPresenceSubscriber struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *api.PresenceResponse
+}
+
+func (s *testPresenceSubscriber) Context() context.Context { return s.ctx }
+
+func (s *testPresenceSubscriber) Send(e *api.PresenceResponse) error {
+	s.events <- e
+	return nil
+}
+
+type testPresenceJoiner struct {
+	grpc.ServerStream
+	ctx context.Context
+	ids chan string
+}
+
+func (s *testPresenceJoiner) Context() context.Context { return s.ctx }
+
+func (s *testPresenceJoiner) Send(e *api.JoinPresenceResponse) error {
+	s.ids <- e.ConnectionId
+	return nil
+}
+
+func TestPresenceService(t *testing.T) {
+	srv := NewPresenceService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub := &testPresenceSubscriber{ctx: ctx, events: make(chan *api.PresenceResponse, 10)}
+	go srv.Presence(&api.PresenceRequest{}, sub)
+
+	// updates are coalesced, hence await waits for the update with the expected number of connections
+	await := func(connections int) *api.PresenceResponse {
+		for {
+			select {
+			case e := <-sub.events:
+				if len(e.Connections) == connections {
+					return e
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("no presence update with %d connections", connections)
+				return nil
+			}
+		}
+	}
+	if e := await(0); e.Users != 0 {
+		t.Fatalf("expected no connections initially: %v", e)
+	}
+
+	joinCtx, leave := context.WithCancel(context.Background())
+	joiner := &testPresenceJoiner{ctx: joinCtx, ids: make(chan string, 1)}
+	joined := make(chan error, 1)
+	go func() {
+		joined <- srv.Join(&api.JoinPresenceRequest{UserId: "foo", UserName: "Foo", ClientName: "vscode"}, joiner)
+	}()
+	id := <-joiner.ids
+	if e := await(1); e.Connections[0].Id != id || e.Connections[0].Client != api.PresenceClient_presence_ide || e.Users != 1 {
+		t.Errorf("unexpected presence after joining: %v", e)
+	}
+
+	// the same user connected via SSH counts once
+	_, disconnect := srv.Connect(&api.PresenceConnection{Client: api.PresenceClient_presence_ssh, UserId: "foo"})
+	if e := await(2); e.Users != 1 {
+		t.Errorf("unexpected presence after connecting: %v", e)
+	}
+	_, disconnectOther := srv.Connect(&api.PresenceConnection{Client: api.PresenceClient_presence_ssh, UserId: "bar"})
+	if e := await(3); e.Users != 2 {
+		t.Errorf("unexpected presence after another user connected: %v", e)
+	}
+	disconnect()
+	disconnect()
+	disconnectOther()
+	if e := await(1); e.Connections[0].Id != id {
+		t.Errorf("unexpected presence after disconnecting: %v", e)
+	}
+
+	leave()
+	if err := <-joined; err != nil {
+		t.Errorf("unexpected error after leaving: %v", err)
+	}
+	await(0)
+
+	err := srv.Join(&api.JoinPresenceRequest{}, joiner)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected joining without a user to fail: %v", err)
+	}
+}
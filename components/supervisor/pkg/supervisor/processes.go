@@ -0,0 +1,520 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+)
+
+const (
+	// processScanInterval is how often the process table is scanned
+	processScanInterval = 10 * time.Second
+	// longLivedOrphanAge is the time after which an orphan counts as long-lived, which hints at a leaking dev server
+	longLivedOrphanAge = 10 * time.Minute
+	// runawayTreeSize is the number of processes beyond which a process tree below supervisor counts as runaway
+	runawayTreeSize = 500
+	// userHZ is the unit of the times in /proc/<pid>/stat, which Linux reports in 1/100ths of a second on all platforms
+	userHZ = 100
+	// maxReapedExits is the number of exit statuses of reaped children kept for those who started them
+	maxReapedExits = 128
+)
+
+// processTracker keeps track of the processes of the workspace, in particular of the zombies supervisor reaps,
+// orphans supervisor adopts and runaway process trees, and exports them as metrics and through the ProcessService.
+type processTracker struct {
+	LongLivedOrphanAge time.Duration
+	RunawayTreeSize    int
+
+	procDir string
+	self    int
+
+	mu        sync.Mutex
+	processes []*api.Process
+	parents   map[int]int
+	orphans   map[int]time.Time
+	runaways  map[int]struct{}
+	reaped    uint64
+	// exits are the exit statuses of reaped children which nobody waits for yet, in the order of reapedPIDs
+	exits      map[int]syscall.WaitStatus
+	reapedPIDs []int
+	exitWaiter map[int]chan syscall.WaitStatus
+	// cpu are the CPU times of the processes as of lastScan, from which the CPU usage until the next scan is computed
+	cpu      map[int]cpuTime
+	lastScan time.Time
+
+	metrics struct {
+		Reaped           prometheus.Counter
+		Processes        prometheus.Gauge
+		Zombies          prometheus.Gauge
+		Orphans          prometheus.Gauge
+		LongLivedOrphans prometheus.Gauge
+		RunawayTrees     prometheus.Gauge
+	}
+}
+
+func newProcessTracker(reg prometheus.Registerer) *processTracker {
+	t := &processTracker{
+		LongLivedOrphanAge: longLivedOrphanAge,
+		RunawayTreeSize:    runawayTreeSize,
+		procDir:            "/proc",
+		self:               os.Getpid(),
+		parents:            make(map[int]int),
+		orphans:            make(map[int]time.Time),
+		runaways:           make(map[int]struct{}),
+		exits:              make(map[int]syscall.WaitStatus),
+		exitWaiter:         make(map[int]chan syscall.WaitStatus),
+	}
+	t.metrics.Reaped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "supervisor_reaped_zombies_total",
+		Help: "Number of exited processes supervisor has reaped",
+	})
+	t.metrics.Processes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "supervisor_processes",
+		Help: "Number of processes in the workspace",
+	})
+	t.metrics.Zombies = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "supervisor_zombie_processes",
+		Help: "Number of processes which have exited but were not reaped yet",
+	})
+	t.metrics.Orphans = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "supervisor_orphan_processes",
+		Help: "Number of processes whose parent exited",
+	})
+	t.metrics.LongLivedOrphans = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "supervisor_long_lived_orphan_processes",
+		Help: "Number of orphans which have been running for a long time",
+	})
+	t.metrics.RunawayTrees = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "supervisor_runaway_process_trees",
+		Help: "Number of process trees below supervisor which grew beyond the runaway limit",
+	})
+	if reg != nil {
+		for _, c := range []prometheus.Collector{
+			t.metrics.Reaped,
+			t.metrics.Processes,
+			t.metrics.Zombies,
+			t.metrics.Orphans,
+			t.metrics.LongLivedOrphans,
+			t.metrics.RunawayTrees,
+		} {
+			err := reg.Register(c)
+			if err != nil {
+				resourcesLog.WithError(err).Warn("cannot register Prometheus metric")
+			}
+		}
+	}
+	return t
+}
+
+// RegisterGRPC registers the gRPC process service
+func (t *processTracker) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterProcessServiceServer(srv, t)
+}
+
+// RegisterREST registers the REST process service
+func (t *processTracker) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterProcessServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// ListProcesses lists the processes of the workspace as of the last scan
+func (t *processTracker) ListProcesses(ctx context.Context, req *api.ListProcessesRequest) (*api.ListProcessesResponse, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := &api.ListProcessesResponse{
+		Processes:     make([]*api.Process, 0, len(t.processes)),
+		ReapedZombies: t.reaped,
+	}
+	for _, p := range t.processes {
+		res.Processes = append(res.Processes, proto.Clone(p).(*api.Process))
+	}
+	return res, nil
+}
+
+// reapedChild records that the reaper reaped a process, and hands its exit status to whoever started it
+func (t *processTracker) reapedChild(pid int, status syscall.WaitStatus) {
+	t.metrics.Reaped.Inc()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.reaped++
+
+	if waiter, ok := t.exitWaiter[pid]; ok {
+		delete(t.exitWaiter, pid)
+		waiter <- status
+		return
+	}
+	// the one who started the process may not have noticed yet that the reaper was faster
+	t.exits[pid] = status
+	t.reapedPIDs = append(t.reapedPIDs, pid)
+	if len(t.reapedPIDs) > maxReapedExits {
+		delete(t.exits, t.reapedPIDs[0])
+		t.reapedPIDs = t.reapedPIDs[1:]
+	}
+}
+
+// awaitReaped returns the exit status of a child the reaper reaped, e.g. once waiting for the child failed with ECHILD,
+// and waits for the reaper to record it if need be
+func (t *processTracker) awaitReaped(ctx context.Context, pid int) (syscall.WaitStatus, error) {
+	t.mu.Lock()
+	if status, ok := t.exits[pid]; ok {
+		delete(t.exits, pid)
+		for i, p := range t.reapedPIDs {
+			if p == pid {
+				t.reapedPIDs = append(t.reapedPIDs[:i], t.reapedPIDs[i+1:]...)
+				break
+			}
+		}
+		t.mu.Unlock()
+		return status, nil
+	}
+	waiter := make(chan syscall.WaitStatus, 1)
+	t.exitWaiter[pid] = waiter
+	t.mu.Unlock()
+
+	select {
+	case status := <-waiter:
+		return status, nil
+	case <-ctx.Done():
+		t.mu.Lock()
+		delete(t.exitWaiter, pid)
+		t.mu.Unlock()
+		return 0, ctx.Err()
+	}
+}
+
+// Run scans the process table regularly until ctx is done
+func (t *processTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(processScanInterval)
+	defer ticker.Stop()
+	for {
+		err := t.scan(time.Now())
+		if err != nil {
+			resourcesLog.WithError(err).Warn("cannot scan processes")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *processTracker) scan(now time.Time) error {
+	procs, cpu, err := readProcesses(t.procDir)
+	if err != nil {
+		return err
+	}
+	listening, err := ports.ListeningPorts(t.procDir)
+	if err != nil {
+		resourcesLog.WithError(err).Debug("cannot attribute listening ports to processes")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var (
+		parents  = make(map[int]int, len(procs))
+		children = make(map[int][]int, len(procs))
+		orphans  = make(map[int]time.Time)
+		byPID    = make(map[int]*api.Process, len(procs))
+		zombies  int
+	)
+	for _, p := range procs {
+		pid, ppid := int(p.Pid), int(p.Ppid)
+		parents[pid] = ppid
+		children[ppid] = append(children[ppid], pid)
+		byPID[pid] = p
+		if p.State == "Z" {
+			zombies++
+		}
+
+		adopter := ppid == t.self || ppid == 1
+		if since, known := t.orphans[pid]; known && adopter {
+			orphans[pid] = since
+		} else if prev, seen := t.parents[pid]; seen && prev != ppid && adopter {
+			// the parent exited since the last scan and we or the PID namespace's init adopted the process
+			orphans[pid] = now
+		}
+	}
+	t.parents = parents
+	t.orphans = orphans
+
+	descendants := make(map[int]uint32, len(procs))
+	var countDescendants func(pid int) uint32
+	countDescendants = func(pid int) uint32 {
+		if res, counted := descendants[pid]; counted {
+			return res
+		}
+		var res uint32
+		for _, child := range children[pid] {
+			res += 1 + countDescendants(child)
+		}
+		descendants[pid] = res
+		return res
+	}
+
+	elapsed := now.Sub(t.lastScan).Seconds()
+	for pid, p := range byPID {
+		p.Ports = listening[pid]
+		prev, known := t.cpu[pid]
+		// a known PID with another start time is a new process which reuses the PID
+		if known && prev.StartTime == cpu[pid].StartTime && cpu[pid].Ticks >= prev.Ticks && elapsed > 0 {
+			p.CpuPercent = float64(cpu[pid].Ticks-prev.Ticks) / userHZ / elapsed * 100
+		}
+	}
+	t.cpu = cpu
+	t.lastScan = now
+
+	type usage struct {
+		CPU float64
+		RSS uint64
+	}
+	treeUsage := make(map[int]usage, len(procs))
+	var sumTreeUsage func(pid int) usage
+	sumTreeUsage = func(pid int) usage {
+		if res, summed := treeUsage[pid]; summed {
+			return res
+		}
+		var res usage
+		if p, exists := byPID[pid]; exists {
+			res = usage{CPU: p.CpuPercent, RSS: p.RssBytes}
+		}
+		for _, child := range children[pid] {
+			u := sumTreeUsage(child)
+			res.CPU += u.CPU
+			res.RSS += u.RSS
+		}
+		treeUsage[pid] = res
+		return res
+	}
+
+	var (
+		longLived int
+		runaways  = make(map[int]struct{})
+	)
+	for pid, p := range byPID {
+		u := sumTreeUsage(pid)
+		p.TreeCpuPercent, p.TreeRssBytes = u.CPU, u.RSS
+
+		if since, orphan := orphans[pid]; orphan {
+			p.Orphan = true
+			p.OrphanedSince, _ = ptypes.TimestampProto(since)
+			if now.Sub(since) >= t.LongLivedOrphanAge {
+				longLived++
+			}
+		}
+		p.Descendants = countDescendants(pid)
+		if int(p.Ppid) == t.self && int(p.Descendants)+1 > t.RunawayTreeSize {
+			p.Runaway = true
+			runaways[pid] = struct{}{}
+			if _, logged := t.runaways[pid]; !logged {
+				resourcesLog.WithField("pid", pid).WithField("command", p.Command).WithField("processes", p.Descendants+1).Warn("runaway process tree")
+			}
+		}
+	}
+	t.runaways = runaways
+	t.processes = procs
+
+	t.metrics.Processes.Set(float64(len(procs)))
+	t.metrics.Zombies.Set(float64(zombies))
+	t.metrics.Orphans.Set(float64(len(orphans)))
+	t.metrics.LongLivedOrphans.Set(float64(longLived))
+	t.metrics.RunawayTrees.Set(float64(len(runaways)))
+	return nil
+}
+
+// cpuTime is the CPU time a process has spent
+type cpuTime struct {
+	// Ticks is the time spent in user and kernel mode in 1/userHZ seconds
+	Ticks uint64
+	// StartTime tells processes with the same PID apart
+	StartTime uint64
+}
+
+// readProcesses reads all processes of a proc filesystem in order of their PID, and the CPU time they spent
+func readProcesses(procDir string) ([]*api.Process, map[int]cpuTime, error) {
+	bootTime, err := readBootTime(procDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := ioutil.ReadDir(procDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		res      []*api.Process
+		cpu      = make(map[int]cpuTime, len(entries))
+		pageSize = uint64(os.Getpagesize())
+	)
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		stat, err := readProcStat(procDir, pid)
+		if err != nil {
+			// the process may have exited in the meantime
+			continue
+		}
+		p := &api.Process{
+			Pid:      int64(pid),
+			Ppid:     int64(stat.PPID),
+			Command:  stat.Comm,
+			State:    stat.State,
+			RssBytes: stat.RSS * pageSize,
+			Cgroup:   readProcCgroup(procDir, pid),
+		}
+		cpu[pid] = cpuTime{Ticks: stat.UTime + stat.STime, StartTime: stat.StartTime}
+		started := bootTime.Add(time.Duration(stat.StartTime) * time.Second / userHZ)
+		p.Started, _ = ptypes.TimestampProto(started)
+		if cmdline, err := ioutil.ReadFile(filepath.Join(procDir, e.Name(), "cmdline")); err == nil && len(cmdline) > 0 {
+			p.Args = strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+		}
+		res = append(res, p)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Pid < res[j].Pid })
+	return res, cpu, nil
+}
+
+// readProcCgroup returns the cgroup of a process as in /proc/<pid>/cgroup, i.e. the unified cgroup on cgroup v2
+// and the memory cgroup on cgroup v1. Returns an empty string if it is unknown.
+func readProcCgroup(procDir string, pid int) string {
+	b, err := ioutil.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return ""
+	}
+	var res string
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		// lines are of the form hierarchy-ID:controllers:path
+		segs := strings.SplitN(line, ":", 3)
+		if len(segs) < 3 {
+			continue
+		}
+		if segs[0] == "0" && segs[1] == "" {
+			return segs[2]
+		}
+		for _, controller := range strings.Split(segs[1], ",") {
+			if controller == "memory" {
+				res = segs[2]
+			}
+		}
+	}
+	return res
+}
+
+type procStat struct {
+	Comm  string
+	State string
+	PPID  int
+	// Session is the ID of the session the process belongs to, which is the PID of the session leader
+	Session int
+	// UTime and STime are the times the process spent in user and kernel mode in 1/userHZ seconds
+	UTime uint64
+	STime uint64
+	// StartTime is the time the process started after boot in 1/userHZ seconds
+	StartTime uint64
+	// RSS is the number of pages the process has in memory
+	RSS uint64
+}
+
+// readProcStat reads /proc/<pid>/stat, see proc(5)
+func readProcStat(procDir string, pid int) (*procStat, error) {
+	b, err := ioutil.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return nil, err
+	}
+	// the command may contain spaces and parentheses, hence we look for the last parenthesis
+	stat := string(b)
+	start, end := strings.IndexByte(stat, '('), strings.LastIndexByte(stat, ')')
+	if start < 0 || end < start {
+		return nil, xerrors.Errorf("invalid stat of process %d", pid)
+	}
+	fields := strings.Fields(stat[end+1:])
+	// fields start with the third field of the stat, the state. The user and system time are the 14th and 15th,
+	// the start time is the 22nd and the RSS the 24th.
+	if len(fields) < 22 {
+		return nil, xerrors.Errorf("invalid stat of process %d", pid)
+	}
+	res := &procStat{
+		Comm:  stat[start+1 : end],
+		State: fields[0],
+	}
+	res.PPID, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, xerrors.Errorf("invalid parent of process %d: %w", pid, err)
+	}
+	res.Session, err = strconv.Atoi(fields[3])
+	if err != nil {
+		return nil, xerrors.Errorf("invalid session of process %d: %w", pid, err)
+	}
+	res.UTime, err = strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid user time of process %d: %w", pid, err)
+	}
+	res.STime, err = strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid system time of process %d: %w", pid, err)
+	}
+	res.StartTime, err = strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid start time of process %d: %w", pid, err)
+	}
+	// the RSS is reported as signed number
+	rss, err := strconv.ParseInt(fields[21], 10, 64)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid RSS of process %d: %w", pid, err)
+	}
+	if rss > 0 {
+		res.RSS = uint64(rss)
+	}
+	return res, nil
+}
+
+// readBootTime reads the boot time from /proc/stat
+func readBootTime(procDir string) (time.Time, error) {
+	f, err := os.Open(filepath.Join(procDir, "stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "btime ") {
+			continue
+		}
+		secs, err := strconv.ParseInt(strings.TrimSpace(strings.TrimPrefix(line, "btime ")), 10, 64)
+		if err != nil {
+			return time.Time{}, xerrors.Errorf("invalid boot time: %w", err)
+		}
+		return time.Unix(secs, 0), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return time.Time{}, err
+	}
+	return time.Time{}, xerrors.Errorf("boot time not found in %s", f.Name())
+}
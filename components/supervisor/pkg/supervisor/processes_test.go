@@ -0,0 +1,222 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeProcess struct {
+	PID, PPID int
+	Comm      string
+	State     string
+	// UTime is the user time in 1/userHZ seconds
+	UTime uint64
+	// RSS is the number of pages in memory
+	RSS    uint64
+	Cgroup string
+}
+
+func writeFakeProc(t *testing.T, dir string, procs []fakeProcess) {
+	entries, _ := ioutil.ReadDir(dir)
+	for _, e := range entries {
+		os.RemoveAll(filepath.Join(dir, e.Name()))
+	}
+	err := ioutil.WriteFile(filepath.Join(dir, "stat"), []byte("cpu  1 2 3 4\nbtime 1604311200\nprocesses 42\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range procs {
+		pdir := filepath.Join(dir, strconv.Itoa(p.PID))
+		err = os.MkdirAll(pdir, 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// utime is the 14th field, starttime the 22nd, i.e. the process started 10s after boot, and rss the 24th
+		stat := fmt.Sprintf("%d (%s) %s %d 0 0 0 -1 0 0 0 0 0 %d 0 0 0 20 0 1 0 1000 0 %d\n", p.PID, p.Comm, p.State, p.PPID, p.UTime, p.RSS)
+		err = ioutil.WriteFile(filepath.Join(pdir, "stat"), []byte(stat), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(filepath.Join(pdir, "cmdline"), []byte(p.Comm+"\x00--flag\x00"), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Cgroup != "" {
+			err = ioutil.WriteFile(filepath.Join(pdir, "cgroup"), []byte(p.Cgroup), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestProcessTracker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "supervisor-proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tracker := newProcessTracker(nil)
+	tracker.procDir = dir
+	tracker.self = 10
+	tracker.RunawayTreeSize = 3
+	tracker.LongLivedOrphanAge = 5 * time.Minute
+
+	type processSummary struct {
+		PID         int64
+		Orphan      bool
+		Descendants uint32
+		Runaway     bool
+	}
+	summarize := func() (res []processSummary) {
+		list, _ := tracker.ListProcesses(context.Background(), &api.ListProcessesRequest{})
+		for _, p := range list.Processes {
+			res = append(res, processSummary{PID: p.Pid, Orphan: p.Orphan, Descendants: p.Descendants, Runaway: p.Runaway})
+		}
+		return res
+	}
+
+	start := time.Now()
+	writeFakeProc(t, dir, []fakeProcess{
+		{PID: 1, PPID: 0, Comm: "supervisor", State: "S"},
+		{PID: 10, PPID: 1, Comm: "supervisor", State: "S"},
+		{PID: 20, PPID: 10, Comm: "bash", State: "S"},
+		{PID: 21, PPID: 20, Comm: "npm run dev", State: "S"},
+		{PID: 22, PPID: 21, Comm: "node", State: "S"},
+		{PID: 30, PPID: 10, Comm: "bash", State: "S"},
+	})
+	err = tracker.scan(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]processSummary{
+		{PID: 1, Descendants: 5},
+		{PID: 10, Descendants: 4},
+		{PID: 20, Descendants: 2},
+		{PID: 21, Descendants: 1},
+		{PID: 22},
+		{PID: 30},
+	}, summarize()); diff != "" {
+		t.Errorf("unexpected processes (-want +got):\n%s", diff)
+	}
+
+	// npm exits, leaving node behind, and bash forks a tree which grows beyond the runaway limit
+	writeFakeProc(t, dir, []fakeProcess{
+		{PID: 1, PPID: 0, Comm: "supervisor", State: "S"},
+		{PID: 10, PPID: 1, Comm: "supervisor", State: "S"},
+		{PID: 20, PPID: 10, Comm: "bash", State: "S"},
+		{PID: 22, PPID: 10, Comm: "node", State: "S"},
+		{PID: 30, PPID: 10, Comm: "bash", State: "S"},
+		{PID: 31, PPID: 30, Comm: "fork", State: "S"},
+		{PID: 32, PPID: 31, Comm: "fork", State: "S"},
+		{PID: 33, PPID: 32, Comm: "fork", State: "Z"},
+	})
+	tracker.reapedChild(21, 0)
+	err = tracker.scan(start.Add(10 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]processSummary{
+		{PID: 1, Descendants: 7},
+		{PID: 10, Descendants: 6},
+		{PID: 20},
+		{PID: 22, Orphan: true},
+		{PID: 30, Descendants: 3, Runaway: true},
+		{PID: 31, Descendants: 2},
+		{PID: 32, Descendants: 1},
+		{PID: 33},
+	}, summarize()); diff != "" {
+		t.Errorf("unexpected processes (-want +got):\n%s", diff)
+	}
+
+	list, _ := tracker.ListProcesses(context.Background(), &api.ListProcessesRequest{})
+	if list.ReapedZombies != 1 {
+		t.Errorf("unexpected reaped zombies: want 1, got %d", list.ReapedZombies)
+	}
+	p := list.Processes[0]
+	if p.Command != "supervisor" || p.State != "S" || !cmp.Equal(p.Args, []string{"supervisor", "--flag"}) || p.Started.Seconds != 1604311210 {
+		t.Errorf("unexpected process details: %v", p)
+	}
+
+	// orphans stay orphans and become long-lived
+	err = tracker.scan(start.Add(20 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker.mu.Lock()
+	since := tracker.orphans[22]
+	tracker.mu.Unlock()
+	if !since.Equal(start.Add(10 * time.Minute)) {
+		t.Errorf("unexpected orphaned since: %v", since)
+	}
+}
+
+func TestProcessTrackerUsage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "supervisor-proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tracker := newProcessTracker(nil)
+	tracker.procDir = dir
+	tracker.self = 1
+
+	pageSize := uint64(os.Getpagesize())
+	start := time.Now()
+	writeFakeProc(t, dir, []fakeProcess{
+		{PID: 1, Comm: "supervisor", State: "S", UTime: 100, RSS: 10, Cgroup: "0::/\n"},
+		{PID: 20, PPID: 1, Comm: "bash", State: "S", UTime: 0, RSS: 5, Cgroup: "0::/\n"},
+		{PID: 21, PPID: 20, Comm: "node", State: "R", UTime: 1000, RSS: 100, Cgroup: "12:pids:/\n4:memory:/docker/abc\n"},
+	})
+	err = tracker.scan(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// node spends half a core over the next 10 seconds
+	writeFakeProc(t, dir, []fakeProcess{
+		{PID: 1, Comm: "supervisor", State: "S", UTime: 100, RSS: 10, Cgroup: "0::/\n"},
+		{PID: 20, PPID: 1, Comm: "bash", State: "S", UTime: 0, RSS: 5, Cgroup: "0::/\n"},
+		{PID: 21, PPID: 20, Comm: "node", State: "R", UTime: 1500, RSS: 100, Cgroup: "12:pids:/\n4:memory:/docker/abc\n"},
+	})
+	err = tracker.scan(start.Add(10 * time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type usage struct {
+		PID            int64
+		CPUPercent     float64
+		RSSBytes       uint64
+		Cgroup         string
+		TreeCPUPercent float64
+		TreeRSSBytes   uint64
+	}
+	list, _ := tracker.ListProcesses(context.Background(), &api.ListProcessesRequest{})
+	var act []usage
+	for _, p := range list.Processes {
+		act = append(act, usage{PID: p.Pid, CPUPercent: p.CpuPercent, RSSBytes: p.RssBytes, Cgroup: p.Cgroup, TreeCPUPercent: p.TreeCpuPercent, TreeRSSBytes: p.TreeRssBytes})
+	}
+	if diff := cmp.Diff([]usage{
+		{PID: 1, RSSBytes: 10 * pageSize, Cgroup: "/", TreeCPUPercent: 50, TreeRSSBytes: 115 * pageSize},
+		{PID: 20, RSSBytes: 5 * pageSize, Cgroup: "/", TreeCPUPercent: 50, TreeRSSBytes: 105 * pageSize},
+		{PID: 21, CPUPercent: 50, RSSBytes: 100 * pageSize, Cgroup: "/docker/abc", TreeCPUPercent: 50, TreeRSSBytes: 100 * pageSize},
+	}, act); diff != "" {
+		t.Errorf("unexpected usage (-want +got):\n%s", diff)
+	}
+}
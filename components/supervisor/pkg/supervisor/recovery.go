@@ -0,0 +1,236 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	csapi "github.com/gitpod-io/gitpod/content-service/api"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
+	"golang.org/x/xerrors"
+)
+
+// recoveryPersistDelay debounces persisting the recovery state, since terminals and tasks change in bursts
+const recoveryPersistDelay = 200 * time.Millisecond
+
+// recoveryStateLocation is where supervisor persists what a restarted supervisor needs to re-adopt the user's processes
+var recoveryStateLocation = filepath.Join(os.TempDir(), "gitpod", "supervisor-state.json")
+
+// recoveryState is what a restarted supervisor recovers instead of starting from scratch
+type recoveryState struct {
+	// ContentReady is true if the content was initialized, in which case it must not be initialized again
+	ContentReady  bool                        `json:"contentReady"`
+	ContentSource csapi.WorkspaceInitSource   `json:"contentSource,omitempty"`
+	Dotfiles      *api.DotfilesStatusResponse `json:"dotfiles,omitempty"`
+	Terminals     []recoveredTerminal         `json:"terminals,omitempty"`
+	Tasks         []recoveredTask             `json:"tasks,omitempty"`
+}
+
+type recoveredTerminal struct {
+	Alias string   `json:"alias"`
+	Pid   int      `json:"pid"`
+	Args  []string `json:"args"`
+	Title string   `json:"title,omitempty"`
+}
+
+type recoveredTask struct {
+	ID       string        `json:"id"`
+	Terminal string        `json:"terminal,omitempty"`
+	State    api.TaskState `json:"state"`
+	ExitCode int32         `json:"exitCode,omitempty"`
+}
+
+func readRecoveryState(loc string) (*recoveryState, error) {
+	b, err := ioutil.ReadFile(loc)
+	if err != nil {
+		return nil, err
+	}
+	var res recoveryState
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot unmarshal recovery state: %w", err)
+	}
+	return &res, nil
+}
+
+// writeRecoveryState replaces the recovery state at once, s.t. a crash never leaves half of it behind
+func writeRecoveryState(loc string, state *recoveryState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(loc), 0755)
+	if err != nil {
+		return err
+	}
+	tmp := loc + ".tmp"
+	err = ioutil.WriteFile(tmp, b, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, loc)
+}
+
+// recoverFromKeeper adopts the terminals a previous supervisor process left with the keeper. It returns nil
+// if supervisor was not restarted or there is nothing to recover.
+func recoverFromKeeper(keeper *keeperClient, loc string, mux *terminal.Mux) *recoveryState {
+	if keeper == nil || !keeper.Restarted {
+		return nil
+	}
+
+	state, err := readRecoveryState(loc)
+	if err != nil {
+		log.WithError(err).Warn("cannot read recovery state - starting from scratch")
+		state = &recoveryState{}
+	}
+
+	terms := make(map[string]recoveredTerminal, len(state.Terminals))
+	for _, t := range state.Terminals {
+		terms[t.Alias] = t
+	}
+	adopted := make([]recoveredTerminal, 0, len(keeper.Inherited))
+	for alias, pty := range keeper.Inherited {
+		t, known := terms[alias]
+		if known {
+			err = mux.Adopt(alias, pty, t.Pid, t.Args, t.Title)
+		} else {
+			err = xerrors.Errorf("terminal is unknown")
+		}
+		if err != nil {
+			log.WithError(err).WithField("alias", alias).Warn("cannot adopt terminal - closing it")
+			pty.Close()
+			_ = keeper.Release(alias)
+			continue
+		}
+		adopted = append(adopted, t)
+	}
+	state.Terminals = adopted
+	log.WithField("terminals", len(adopted)).WithField("contentReady", state.ContentReady).Info("supervisor restarted - recovered previous state")
+	return state
+}
+
+// recoverContent marks the content ready which a previous supervisor process has initialized already
+func recoverContent(wg *sync.WaitGroup, cst ContentState, src csapi.WorkspaceInitSource, prog *contentProgress, health *subsystemHealth) {
+	defer wg.Done()
+	cst.MarkContentReady(src)
+	prog.finish(nil)
+	health.ok()
+	log.WithField("source", src).Info("supervisor: workspace content recovered")
+}
+
+// recoveryRecorder persists the recovery state whenever it changes
+type recoveryRecorder struct {
+	Location string
+	Content  ContentState
+	Dotfiles *dotfilesInstaller
+	Mux      *terminal.Mux
+	Tasks    *tasksManager
+
+	nudge chan struct{}
+}
+
+func newRecoveryRecorder(loc string, content ContentState, dotfiles *dotfilesInstaller, mux *terminal.Mux, tasks *tasksManager) *recoveryRecorder {
+	return &recoveryRecorder{
+		Location: loc,
+		Content:  content,
+		Dotfiles: dotfiles,
+		Mux:      mux,
+		Tasks:    tasks,
+		nudge:    make(chan struct{}, 1),
+	}
+}
+
+// Nudge tells the recorder that the state has changed
+func (r *recoveryRecorder) Nudge() {
+	select {
+	case r.nudge <- struct{}{}:
+	default:
+	}
+}
+
+// Run persists the recovery state until ctx is done
+func (r *recoveryRecorder) Run(ctx context.Context) {
+	var (
+		contentReady = r.Content.ContentReady()
+		dotfilesDone = r.Dotfiles.Done()
+		tasksReady   = r.Tasks.ready
+		taskUpdates  <-chan []*api.TaskStatus
+		persist      <-chan time.Time
+	)
+	r.Persist()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-contentReady:
+			contentReady = nil
+		case <-dotfilesDone:
+			dotfilesDone = nil
+		case <-tasksReady:
+			tasksReady = nil
+			if sub := r.Tasks.Subscribe(); sub != nil {
+				defer sub.Close()
+				taskUpdates = sub.Updates()
+			}
+		case <-taskUpdates:
+		case <-r.nudge:
+		case <-persist:
+			persist = nil
+			r.Persist()
+			continue
+		}
+		if persist == nil {
+			persist = time.After(recoveryPersistDelay)
+		}
+	}
+}
+
+// Persist writes the current recovery state
+func (r *recoveryRecorder) Persist() {
+	err := writeRecoveryState(r.Location, r.snapshot())
+	if err != nil {
+		log.WithError(err).Warn("cannot persist recovery state")
+	}
+}
+
+func (r *recoveryRecorder) snapshot() *recoveryState {
+	res := &recoveryState{}
+	res.ContentSource, res.ContentReady = r.Content.ContentSource()
+	select {
+	case <-r.Dotfiles.Done():
+		res.Dotfiles = r.Dotfiles.Status()
+	default:
+	}
+	for _, alias := range r.Mux.Aliases() {
+		term, ok := r.Mux.Get(alias)
+		if !ok || term.Command.Process == nil {
+			continue
+		}
+		res.Terminals = append(res.Terminals, recoveredTerminal{
+			Alias: alias,
+			Pid:   term.Command.Process.Pid,
+			Args:  term.Command.Args,
+			Title: term.Title,
+		})
+	}
+	for _, t := range r.Tasks.getStatus() {
+		res.Tasks = append(res.Tasks, recoveredTask{
+			ID:       t.Id,
+			Terminal: t.Terminal,
+			State:    t.State,
+			ExitCode: t.ExitCode,
+		})
+	}
+	return res
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+)
+
+// serviceReadyNotifier notifies the user once a task serves an announced port which is exposed, i.e. the service
+// the task started is ready. It replaces the separate notifications about the task and the exposed port.
+type serviceReadyNotifier struct {
+	Notifications *NotificationService
+	Tasks         *tasksManager
+
+	// notified are the terminals of the tasks which were announced by port. A port is announced again
+	// once it was closed, e.g. because its task restarted.
+	notified map[uint32]string
+}
+
+// Run notifies about the ports of the port manager until ctx is done
+func (n *serviceReadyNotifier) Run(ctx context.Context, pm *ports.Manager) {
+	sub := pm.Events().Subscribe("service-ready", ports.PortServedKind, ports.PortExposedKind, ports.PortClosedKind)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-sub.Events():
+			if event == nil {
+				return
+			}
+			n.handle(ctx, event)
+		}
+	}
+}
+
+func (n *serviceReadyNotifier) handle(ctx context.Context, event ports.Event) {
+	var (
+		port   uint32
+		status *api.PortsStatus
+	)
+	switch e := event.(type) {
+	case ports.PortServed:
+		port, status = e.Port, e.Status
+	case ports.PortExposed:
+		port, status = e.Port, e.Status
+	case ports.PortClosed:
+		delete(n.notified, e.Port)
+		return
+	}
+	if status == nil || !status.Announced || !status.Served || status.Exposed == nil || status.Exposed.Url == "" {
+		return
+	}
+
+	name, terminal, ok := n.task(status.Group)
+	if !ok {
+		return
+	}
+	if n.notified == nil {
+		n.notified = make(map[uint32]string)
+	}
+	if alias, notified := n.notified[port]; notified && alias == terminal {
+		return
+	}
+	n.notified[port] = terminal
+
+	label := status.Name
+	if label == "" {
+		label = name
+	}
+	_, err := n.Notifications.Notify(ctx, &api.NotifyRequest{
+		Level:   api.NotificationLevel_notification_info,
+		Message: fmt.Sprintf("%s is ready at %s", label, status.Exposed.Url),
+	})
+	if err != nil {
+		log.WithError(err).WithField("port", port).Debug("cannot notify about ready service")
+	}
+}
+
+// task returns the name and the terminal of a running task
+func (n *serviceReadyNotifier) task(id string) (name, terminal string, ok bool) {
+	n.Tasks.mu.RLock()
+	defer n.Tasks.mu.RUnlock()
+
+	t, exists := n.Tasks.tasks[id]
+	if !exists || t.State != api.TaskState_running {
+		return "", "", false
+	}
+	name = "Task " + t.Id
+	if t.Presentation != nil && t.Presentation.Name != "" {
+		name = t.Presentation.Name
+	}
+	return name, t.Terminal, true
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServiceReadyNotifier(t *testing.T) {
+	served := func(port uint32, group string, announced bool) *api.PortsStatus {
+		return &api.PortsStatus{LocalPort: port, Served: true, Group: group, Announced: announced}
+	}
+	exposed := func(port uint32, group string, name string) *api.PortsStatus {
+		res := served(port, group, true)
+		res.Name = name
+		res.Exposed = &api.PortsStatus_ExposedPortInfo{Url: "https://3000-foobar"}
+		return res
+	}
+
+	tests := []struct {
+		Desc        string
+		Events      []ports.Event
+		Expectation []string
+	}{
+		{
+			Desc:        "served before exposed",
+			Events:      []ports.Event{ports.PortServed{Port: 3000, Status: served(3000, "0", true)}, ports.PortExposed{Port: 3000, Status: exposed(3000, "0", "")}},
+			Expectation: []string{"dev is ready at https://3000-foobar"},
+		},
+		{
+			Desc:        "exposed before served",
+			Events:      []ports.Event{ports.PortExposed{Port: 3000, Status: &api.PortsStatus{LocalPort: 3000, Announced: true, Exposed: &api.PortsStatus_ExposedPortInfo{Url: "https://3000-foobar"}}}, ports.PortServed{Port: 3000, Status: exposed(3000, "0", "")}},
+			Expectation: []string{"dev is ready at https://3000-foobar"},
+		},
+		{
+			Desc:        "named port",
+			Events:      []ports.Event{ports.PortExposed{Port: 3000, Status: exposed(3000, "0", "API")}},
+			Expectation: []string{"API is ready at https://3000-foobar"},
+		},
+		{
+			Desc:        "unnamed task",
+			Events:      []ports.Event{ports.PortExposed{Port: 3000, Status: exposed(3000, "1", "")}},
+			Expectation: []string{"Task 1 is ready at https://3000-foobar"},
+		},
+		{
+			Desc:   "not announced",
+			Events: []ports.Event{ports.PortExposed{Port: 3000, Status: &api.PortsStatus{LocalPort: 3000, Served: true, Group: "0", Exposed: &api.PortsStatus_ExposedPortInfo{Url: "https://3000-foobar"}}}},
+		},
+		{
+			Desc:   "task not running",
+			Events: []ports.Event{ports.PortExposed{Port: 3000, Status: exposed(3000, "2", "")}},
+		},
+		{
+			Desc: "notified once",
+			Events: []ports.Event{
+				ports.PortExposed{Port: 3000, Status: exposed(3000, "0", "")},
+				ports.PortServed{Port: 3000, Status: exposed(3000, "0", "")},
+			},
+			Expectation: []string{"dev is ready at https://3000-foobar"},
+		},
+		{
+			Desc: "notified again once served again",
+			Events: []ports.Event{
+				ports.PortExposed{Port: 3000, Status: exposed(3000, "0", "")},
+				ports.PortClosed{Port: 3000},
+				ports.PortServed{Port: 3000, Status: exposed(3000, "0", "")},
+			},
+			Expectation: []string{"dev is ready at https://3000-foobar", "dev is ready at https://3000-foobar"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			notifications := NewNotificationService()
+			events := make(chan *api.SubscribeNotificationsResponse, 10)
+			notifications.subscribers[events] = struct{}{}
+
+			n := &serviceReadyNotifier{
+				Notifications: notifications,
+				Tasks: &tasksManager{tasks: map[string]*task{
+					"0": {TaskStatus: api.TaskStatus{Id: "0", State: api.TaskState_running, Terminal: "term-0", Presentation: &api.TaskPresentation{Name: "dev"}}},
+					"1": {TaskStatus: api.TaskStatus{Id: "1", State: api.TaskState_running, Terminal: "term-1", Presentation: &api.TaskPresentation{}}},
+					"2": {TaskStatus: api.TaskStatus{Id: "2", State: api.TaskState_closed, Presentation: &api.TaskPresentation{Name: "build"}}},
+				}},
+			}
+			for _, e := range test.Events {
+				n.handle(context.Background(), e)
+			}
+			close(events)
+
+			var act []string
+			for e := range events {
+				act = append(act, e.Request.Message)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected notifications (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -6,7 +6,11 @@ package supervisor
 
 import (
 	"context"
+	"io"
+	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,10 +18,14 @@ import (
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/supervisor/api"
 
+	"github.com/gitpod-io/gitpod/supervisor/pkg/logging"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -38,6 +46,12 @@ type RegisterableRESTService interface {
 	RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error
 }
 
+// RegisterableHTTPService can register plain HTTP handlers, e.g. for websockets
+type RegisterableHTTPService interface {
+	// RegisterHTTP registers HTTP handlers. Patterns are relative to the API endpoint root.
+	RegisterHTTP(mux *http.ServeMux)
+}
+
 type ideReadyState struct {
 	ready bool
 	cond  *sync.Cond
@@ -78,9 +92,17 @@ func (service *ideReadyState) Set(ready bool) {
 
 type statusService struct {
 	ContentState ContentState
+	Progress     *contentProgress
 	Ports        *ports.Manager
 	Tasks        *tasksManager
+	Dotfiles     *dotfilesInstaller
+	Health       *healthRegistry
+	Memory       *memoryWatcher
+	Disk         *diskWatcher
+	CPU          *cpuWatcher
 	ideReady     *ideReadyState
+	// desktopReady is the ready state of the desktop IDE backend, nil if the workspace does not run one
+	desktopReady *ideReadyState
 }
 
 func (s *statusService) RegisterGRPC(srv *grpc.Server) {
@@ -95,18 +117,52 @@ func (s *statusService) SupervisorStatus(context.Context, *api.SupervisorStatusR
 	return &api.SupervisorStatusResponse{Ok: true}, nil
 }
 
+// HealthStatus returns the health of supervisor's subsystems
+func (s *statusService) HealthStatus(ctx context.Context, req *api.HealthStatusRequest) (*api.HealthStatusResponse, error) {
+	return s.Health.status(), nil
+}
+
+func (s *statusService) MemoryStatus(ctx context.Context, req *api.MemoryStatusRequest) (*api.MemoryStatusResponse, error) {
+	if s.Memory == nil {
+		return nil, status.Error(codes.Unavailable, "memory is not watched")
+	}
+	return s.Memory.Status(), nil
+}
+
+func (s *statusService) DiskStatus(ctx context.Context, req *api.DiskStatusRequest) (*api.DiskStatusResponse, error) {
+	if s.Disk == nil {
+		return nil, status.Error(codes.Unavailable, "disk usage is not watched")
+	}
+	return s.Disk.Status(), nil
+}
+
+func (s *statusService) CPUStatus(ctx context.Context, req *api.CPUStatusRequest) (*api.CPUStatusResponse, error) {
+	if s.CPU == nil {
+		return nil, status.Error(codes.Unavailable, "CPU is not watched")
+	}
+	return s.CPU.Status(), nil
+}
+
 func (s *statusService) IDEStatus(ctx context.Context, req *api.IDEStatusRequest) (*api.IDEStatusResponse, error) {
 	if req.Wait {
-		select {
-		case <-s.ideReady.Wait():
-			return &api.IDEStatusResponse{Ok: true}, nil
-		case <-ctx.Done():
-			return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+		for _, ready := range []*ideReadyState{s.ideReady, s.desktopReady} {
+			if ready == nil {
+				continue
+			}
+			select {
+			case <-ready.Wait():
+			case <-ctx.Done():
+				return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+			}
 		}
 	}
 
-	ok := s.ideReady.Get()
-	return &api.IDEStatusResponse{Ok: ok}, nil
+	res := &api.IDEStatusResponse{Ok: s.ideReady.Get()}
+	if s.desktopReady != nil {
+		res.Desktop = &api.IDEStatusResponse_DesktopStatus{Ok: s.desktopReady.Get()}
+		res.Ok = res.Ok && res.Desktop.Ok
+	}
+	return res, nil
 }
 
 // ContentStatus provides feedback regarding the workspace content readiness
@@ -144,27 +200,86 @@ func (s *statusService) ContentStatus(ctx context.Context, req *api.ContentStatu
 	}, nil
 }
 
+// ContentProgress streams the progress of the content initialization until the content is ready or failed
+func (s *statusService) ContentProgress(req *api.ContentProgressRequest, srv api.StatusService_ContentProgressServer) error {
+	for {
+		current, changed := s.Progress.get()
+		err := srv.Send(current)
+		if err != nil {
+			return err
+		}
+		if isContentDone(current.Phase) {
+			return nil
+		}
+
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+// DotfilesStatus provides feedback regarding the installation of the user's dotfiles
+func (s *statusService) DotfilesStatus(ctx context.Context, req *api.DotfilesStatusRequest) (*api.DotfilesStatusResponse, error) {
+	if req.Wait {
+		select {
+		case <-s.Dotfiles.Done():
+		case <-ctx.Done():
+			return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+		}
+	}
+	return s.Dotfiles.Status(), nil
+}
+
 func (s *statusService) BackupStatus(ctx context.Context, req *api.BackupStatusRequest) (*api.BackupStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "not implemented")
 }
 
 func (s *statusService) PortsStatus(req *api.PortsStatusRequest, srv api.StatusService_PortsStatusServer) error {
-	err := srv.Send(&api.PortsStatusResponse{
-		Added: s.Ports.Status(),
-	})
-	if err != nil {
-		return err
-	}
 	if !req.Observe {
-		return nil
+		snapshot := s.Ports.Snapshot()
+		return srv.Send(&api.PortsStatusResponse{
+			Added:       snapshot.Added,
+			Diagnostics: snapshot.Diagnostics,
+			Revision:    snapshot.Revision,
+			ResumeToken: s.Ports.ResumeToken(snapshot.Revision),
+		})
 	}
 
-	sub := s.Ports.Subscribe()
+	client := req.Client
+	if client == "" {
+		client = "unknown"
+	}
+	// unless resumed, the subscription starts with a snapshot of all ports
+	var (
+		sub     *ports.Subscription
+		resumed bool
+	)
+	if req.ResumeToken != "" {
+		sub, resumed = s.Ports.Resume(client, req.ResumeToken)
+	} else {
+		sub = s.Ports.Subscribe(client)
+	}
 	if sub == nil {
 		return status.Error(codes.ResourceExhausted, "too many subscriptions")
 	}
 	defer sub.Close()
 
+	if resumed {
+		err := srv.Send(&api.PortsStatusResponse{
+			Resumed:     true,
+			ResumeToken: req.ResumeToken,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return forwardPortsStatus(srv, sub, s.Ports)
+}
+
+// forwardPortsStatus sends the updates of a ports subscription until the client or the subscription is gone
+func forwardPortsStatus(srv api.StatusService_PortsStatusServer, sub *ports.Subscription, mgr *ports.Manager) error {
 	for {
 		select {
 		case <-srv.Context().Done():
@@ -174,9 +289,14 @@ func (s *statusService) PortsStatus(req *api.PortsStatusRequest, srv api.StatusS
 				return nil
 			}
 			err := srv.Send(&api.PortsStatusResponse{
-				Added:   update.Added,
-				Updated: update.Updated,
-				Removed: update.Removed,
+				Added:       update.Added,
+				Updated:     update.Updated,
+				Removed:     update.Removed,
+				Diagnostics: update.Diagnostics,
+				Trigger:     update.Trigger,
+				Triggers:    update.Triggers,
+				Revision:    update.Revision,
+				ResumeToken: mgr.ResumeToken(update.Revision),
 			})
 			if err != nil {
 				return err
@@ -185,6 +305,12 @@ func (s *statusService) PortsStatus(req *api.PortsStatusRequest, srv api.StatusS
 	}
 }
 
+func (s *statusService) PortsSubscribers(ctx context.Context, req *api.PortsSubscribersRequest) (*api.PortsSubscribersResponse, error) {
+	return &api.PortsSubscribersResponse{
+		Subscribers: s.Ports.Subscribers(),
+	}, nil
+}
+
 func (s *statusService) TasksStatus(req *api.TasksStatusRequest, srv api.StatusService_TasksStatusServer) error {
 	select {
 	case <-srv.Context().Done():
@@ -243,37 +369,129 @@ func (s RegistrableTokenService) RegisterREST(mux *runtime.ServeMux, grpcEndpoin
 func NewInMemoryTokenService() *InMemoryTokenService {
 	return &InMemoryTokenService{
 		provider: make(map[string][]tokenProvider),
+		inflight: make(map[string]*tokenFetch),
 	}
 }
 
+// tokenRefreshMargin is how long before their expiry cached tokens are fetched anew
+const tokenRefreshMargin = 2 * time.Minute
+
 type token struct {
 	Token      string
 	Host       string
 	Scope      map[string]struct{}
 	ExpiryDate *time.Time
 	Reuse      api.TokenReuse
+	User       string
+}
+
+func (tkn *token) expired() bool {
+	return tkn.ExpiryDate != nil && time.Now().After(*tkn.ExpiryDate)
+}
+
+// outlives returns true if the token expires after the other token
+func (tkn *token) outlives(other *token) bool {
+	if tkn.ExpiryDate == nil {
+		return other.ExpiryDate != nil
+	}
+	return other.ExpiryDate != nil && tkn.ExpiryDate.After(*other.ExpiryDate)
+}
+
+// expiresSoon returns true if the token expires within the refresh margin
+func (tkn *token) expiresSoon() bool {
+	return tkn.ExpiryDate != nil && time.Now().Add(tokenRefreshMargin).After(*tkn.ExpiryDate)
 }
 
 type tokenProvider interface {
 	GetToken(ctx context.Context, req *api.GetTokenRequest) (tkn *token, err error)
 }
 
-// InMemoryTokenService provides an in-memory caching token service
+// InMemoryTokenService provides an in-memory caching token service.
+// Tokens are cached by host and scopes, and fetched anew from the providers shortly before they expire.
+// Concurrent requests for the same host and scopes share a single fetch.
 type InMemoryTokenService struct {
 	token    []*token
 	provider map[string][]tokenProvider
+	fallback []tokenProvider
+	inflight map[string]*tokenFetch
+	revoked  bool
 	mu       sync.RWMutex
 }
 
+// tokenFetch is a fetch from the token providers other requests can wait for
+type tokenFetch struct {
+	done chan struct{}
+	tkn  *token
+}
+
 // GetToken returns a token for a host
 func (s *InMemoryTokenService) GetToken(ctx context.Context, req *api.GetTokenRequest) (*api.GetTokenResponse, error) {
-	tkn, ok := s.getCachedTokenFor(req.Host, req.Scope)
-	if ok {
-		return &api.GetTokenResponse{Token: tkn}, nil
+	s.mu.RLock()
+	revoked := s.revoked
+	s.mu.RUnlock()
+	if revoked {
+		return nil, status.Error(codes.Unavailable, "tokens were revoked because the workspace is stopping")
 	}
 
+	cached, ok := s.getCachedTokenFor(req.Host, req.Scope)
+	if ok && !cached.expiresSoon() {
+		return &api.GetTokenResponse{Token: cached.Token, User: cached.User}, nil
+	}
+
+	tkn, err := s.fetchToken(ctx, req)
+	if tkn == nil && ok {
+		// the cached token is still valid, even if it could not be refreshed
+		log.WithField("host", req.Host).Debug("cannot refresh token - using the cached one")
+		tkn, err = cached, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tkn == nil {
+		return nil, status.Error(codes.NotFound, "no token available")
+	}
+	return &api.GetTokenResponse{Token: tkn.Token, User: tkn.User}, nil
+}
+
+// fetchToken fetches a token from the providers. If the same host and scopes are fetched already, it waits for that fetch.
+func (s *InMemoryTokenService) fetchToken(ctx context.Context, req *api.GetTokenRequest) (*token, error) {
+	key := tokenFetchKey(req)
+	for {
+		s.mu.Lock()
+		f, exists := s.inflight[key]
+		if !exists {
+			f = &tokenFetch{done: make(chan struct{})}
+			s.inflight[key] = f
+		}
+		s.mu.Unlock()
+
+		if !exists {
+			f.tkn = s.fetchFromProviders(ctx, req)
+
+			s.mu.Lock()
+			delete(s.inflight, key)
+			s.mu.Unlock()
+			close(f.done)
+			return f.tkn, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+		case <-f.done:
+		}
+		if f.tkn != nil && f.tkn.Reuse == api.TokenReuse_REUSE_NEVER {
+			// tokens which must not be reused are not shared either - fetch our own
+			continue
+		}
+		return f.tkn, nil
+	}
+}
+
+func (s *InMemoryTokenService) fetchFromProviders(ctx context.Context, req *api.GetTokenRequest) *token {
 	s.mu.RLock()
 	prov := s.provider[req.Host]
+	fallback := s.fallback
 	s.mu.RUnlock()
 	for _, p := range prov {
 		tkn, err := p.GetToken(ctx, req)
@@ -287,23 +505,61 @@ func (s *InMemoryTokenService) GetToken(ctx context.Context, req *api.GetTokenRe
 		}
 
 		s.cacheToken(tkn)
-		return &api.GetTokenResponse{Token: tkn.Token}, nil
+		return tkn
+	}
+	for _, p := range fallback {
+		tkn, err := p.GetToken(ctx, req)
+		if err != nil {
+			log.WithError(err).WithField("host", req.Host).Warn("cannot get token from fallback provider")
+			continue
+		}
+		if tkn == nil {
+			// fallback providers only provide the tokens they know about
+			continue
+		}
+
+		s.cacheToken(tkn)
+		return tkn
 	}
+	return nil
+}
+
+func tokenFetchKey(req *api.GetTokenRequest) string {
+	scopes := make([]string, len(req.Scope))
+	copy(scopes, req.Scope)
+	sort.Strings(scopes)
+	return req.Host + "/" + strings.Join(scopes, ",")
+}
+
+// Revoke forgets all cached tokens and refuses to hand out tokens from now on, e.g. because the workspace is stopping
+func (s *InMemoryTokenService) Revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.revoked = true
+	s.token = nil
+	log.Info("revoked all tokens")
+}
 
-	return nil, status.Error(codes.NotFound, "no token available")
+// addFallbackProvider adds a provider which is asked for tokens of any host, if no provider registered for the host has one
+func (s *InMemoryTokenService) addFallbackProvider(p tokenProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fallback = append(s.fallback, p)
 }
 
-func (s *InMemoryTokenService) getCachedTokenFor(host string, scopes []string) (tkn string, ok bool) {
+func (s *InMemoryTokenService) getCachedTokenFor(host string, scopes []string) (tkn *token, ok bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	// the token with the fewest scopes is used, s.t. clients get no more access than they asked for if possible
 	var res *token
 	for _, tkn := range s.token {
 		if tkn.Host != host {
 			continue
 		}
 
-		if tkn.ExpiryDate != nil && time.Now().After(*tkn.ExpiryDate) {
+		if tkn.expired() {
 			continue
 		}
 
@@ -325,14 +581,15 @@ func (s *InMemoryTokenService) getCachedTokenFor(host string, scopes []string) (
 			continue
 		}
 
-		res = tkn
-		break
+		if res == nil || len(tkn.Scope) < len(res.Scope) || (len(tkn.Scope) == len(res.Scope) && tkn.outlives(res)) {
+			res = tkn
+		}
 	}
 
 	if res == nil {
-		return "", false
+		return nil, false
 	}
-	return res.Token, true
+	return res, true
 }
 
 func (s *InMemoryTokenService) cacheToken(tkn *token) {
@@ -344,7 +601,14 @@ func (s *InMemoryTokenService) cacheToken(tkn *token) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.token = append(s.token, tkn)
+	// expired tokens are never used again
+	valid := s.token[:0]
+	for _, t := range s.token {
+		if !t.expired() {
+			valid = append(valid, t)
+		}
+	}
+	s.token = append(valid, tkn)
 	log.WithField("host", tkn.Host).WithField("scopes", tkn.Scope).WithField("reuse", tkn.Reuse.String()).Info("registered new token")
 }
 
@@ -361,6 +625,7 @@ func convertReceivedToken(req *api.SetTokenRequest) (tkn *token, err error) {
 		Scope: mapScopes(req.Scope),
 		Token: req.Token,
 		Reuse: req.Reuse,
+		User:  req.User,
 	}
 	if req.ExpiryDate != nil {
 		te, err := ptypes.Timestamp(req.GetExpiryDate())
@@ -390,6 +655,12 @@ func (s *InMemoryTokenService) SetToken(ctx context.Context, req *api.SetTokenRe
 	if err != nil {
 		return nil, err
 	}
+	s.mu.RLock()
+	revoked := s.revoked
+	s.mu.RUnlock()
+	if revoked {
+		return nil, status.Error(codes.Unavailable, "tokens were revoked because the workspace is stopping")
+	}
 	s.cacheToken(tkn)
 
 	return &api.SetTokenResponse{}, nil
@@ -534,12 +805,34 @@ func (rt *remoteTokenProvider) GetToken(ctx context.Context, req *api.GetTokenRe
 
 // InfoService implements the api.InfoService
 type InfoService struct {
-	cfg *Config
+	cfg      *Config
+	metadata *workspaceMetadata
+
+	// sshHostKey is the host key of the SSH server, nil if it does not run
+	sshHostKey ssh.PublicKey
+	// jetbrains manages the JetBrains Gateway backend, nil if the workspace does not offer one
+	jetbrains *JetBrainsService
+	// grpcServer serves the API, whose methods the info service lists
+	grpcServer *grpc.Server
 }
 
 // RegisterGRPC registers the gRPC info service
 func (is *InfoService) RegisterGRPC(srv *grpc.Server) {
 	api.RegisterInfoServiceServer(srv, is)
+	is.grpcServer = srv
+}
+
+// APIVersion returns the version of the API supervisor implements and the methods it serves
+func (is *InfoService) APIVersion(ctx context.Context, req *api.APIVersionRequest) (*api.APIVersionResponse, error) {
+	resp := &api.APIVersionResponse{
+		Version:          api.Version,
+		MinClientVersion: api.MinClientVersion,
+		Compatible:       req.ClientVersion >= api.MinClientVersion,
+		Methods:          grpcMethods(is.grpcServer),
+		Capabilities:     capabilities(is.cfg, is.sshHostKey != nil),
+	}
+	log.WithField("client", req.Client).WithField("clientVersion", req.ClientVersion).WithField("compatible", resp.Compatible).Debug("API version negotiated")
+	return resp, nil
 }
 
 // RegisterREST registers the REST info service
@@ -547,6 +840,26 @@ func (is *InfoService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string)
 	return api.RegisterInfoServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
 }
 
+// WorkspaceMetadata provides the metadata of the workspace and, if observed, their changes
+func (is *InfoService) WorkspaceMetadata(req *api.WorkspaceMetadataRequest, srv api.InfoService_WorkspaceMetadataServer) error {
+	for {
+		current, changed := is.metadata.get()
+		err := srv.Send(current)
+		if err != nil {
+			return err
+		}
+		if !req.Observe {
+			return nil
+		}
+
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
 // WorkspaceInfo provides information about the workspace
 func (is *InfoService) WorkspaceInfo(context.Context, *api.WorkspaceInfoRequest) (*api.WorkspaceInfoResponse, error) {
 	resp := &api.WorkspaceInfoResponse{
@@ -579,12 +892,29 @@ func (is *InfoService) WorkspaceInfo(context.Context, *api.WorkspaceInfoRequest)
 		Host:     host,
 	}
 
+	if is.sshHostKey != nil {
+		resp.Ssh = &api.WorkspaceInfoResponse_SSH{
+			Port:               uint32(is.cfg.SSHPort),
+			HostKeyFingerprint: ssh.FingerprintSHA256(is.sshHostKey),
+			HostKey:            strings.TrimSpace(string(ssh.MarshalAuthorizedKey(is.sshHostKey))),
+			TunnelPath:         sshTunnelPath,
+			TunnelCookie:       ownerCookie(is.cfg),
+		}
+	}
+	if is.jetbrains != nil {
+		resp.JetbrainsBackend = is.jetbrains.Status()
+	}
+
 	return resp, nil
 }
 
 // ControlService implements the supervisor control service
 type ControlService struct {
 	portsManager *ports.Manager
+	hooks        *lifecycleHooks
+	diagnostics  *diagnosticsBundler
+	// backup backs up the workspace content, see backupWorkspace
+	backup func(ctx context.Context) error
 }
 
 // RegisterGRPC registers the gRPC info service
@@ -594,10 +924,445 @@ func (c *ControlService) RegisterGRPC(srv *grpc.Server) {
 
 // ExposePort exposes a port
 func (c *ControlService) ExposePort(ctx context.Context, req *api.ExposePortRequest) (*api.ExposePortResponse, error) {
-	err := c.portsManager.Expose(req.Port, req.TargetPort)
+	err := c.portsManager.Expose(ctx, req.Port, req.TargetPort)
 	return &api.ExposePortResponse{}, err
 }
 
+// ApprovePublicPort approves or rejects making a port public
+func (c *ControlService) ApprovePublicPort(ctx context.Context, req *api.ApprovePublicPortRequest) (*api.ApprovePublicPortResponse, error) {
+	err := c.portsManager.ApprovePublic(ctx, req.Port, req.Approve)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &api.ApprovePublicPortResponse{}, nil
+}
+
+// AcceptPortRemap proxies a port on the suggested free global port
+func (c *ControlService) AcceptPortRemap(ctx context.Context, req *api.AcceptPortRemapRequest) (*api.AcceptPortRemapResponse, error) {
+	err := c.portsManager.AcceptRemap(ctx, req.Port)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &api.AcceptPortRemapResponse{}, nil
+}
+
+// RunLifecycleHook runs a lifecycle hook of the .gitpod.yml, e.g. the onSnapshot hook before a snapshot is taken
+func (c *ControlService) RunLifecycleHook(ctx context.Context, req *api.RunLifecycleHookRequest) (*api.RunLifecycleHookResponse, error) {
+	if c.hooks == nil {
+		return nil, status.Error(codes.Unavailable, "lifecycle hooks are not available")
+	}
+	configured, err := c.hooks.Run(ctx, req.Hook)
+	if xerrors.Is(err, errUnknownHook) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	return &api.RunLifecycleHookResponse{Configured: configured}, nil
+}
+
+// CreateDiagnostics writes a diagnostic bundle of supervisor to the workspace
+func (c *ControlService) CreateDiagnostics(ctx context.Context, req *api.CreateDiagnosticsRequest) (*api.CreateDiagnosticsResponse, error) {
+	if c.diagnostics == nil {
+		return nil, status.Error(codes.Unavailable, "diagnostics are not available")
+	}
+	loc, err := c.diagnostics.Create(ctx, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.CreateDiagnosticsResponse{Location: loc}, nil
+}
+
+// SetLogLevel changes the level supervisor or one of its subsystems logs with
+func (c *ControlService) SetLogLevel(ctx context.Context, req *api.SetLogLevelRequest) (*api.SetLogLevelResponse, error) {
+	if req.Subsystem == "" {
+		lvl, err := logrus.ParseLevel(req.Level)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		logging.SetDefaultLevel(lvl)
+		log.WithField("level", lvl.String()).Info("log level changed")
+		return &api.SetLogLevelResponse{}, nil
+	}
+
+	err := logging.SetLevel(req.Subsystem, req.Level)
+	if xerrors.Is(err, logging.ErrUnknownSubsystem) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.WithField(logging.SubsystemField, req.Subsystem).WithField("level", req.Level).Info("log level of subsystem changed")
+	return &api.SetLogLevelResponse{}, nil
+}
+
+// LogLevels returns the levels supervisor and its subsystems log with
+func (c *ControlService) LogLevels(ctx context.Context, req *api.LogLevelsRequest) (*api.LogLevelsResponse, error) {
+	res := &api.LogLevelsResponse{Level: log.Log.Logger.GetLevel().String()}
+	for _, s := range logging.Levels() {
+		res.Subsystems = append(res.Subsystems, &api.SubsystemLogLevel{
+			Subsystem: s.Name,
+			Level:     s.Level.String(),
+			Own:       s.Own,
+		})
+	}
+	return res, nil
+}
+
+// Backup backs up the workspace content
+func (c *ControlService) Backup(ctx context.Context, req *api.BackupRequest) (*api.BackupResponse, error) {
+	if c.backup == nil {
+		return nil, status.Error(codes.Unavailable, "backups are not available")
+	}
+	start := time.Now()
+	err := c.backup(ctx)
+	if err != nil {
+		log.WithError(err).Warn("backup on request failed")
+		return nil, err
+	}
+	duration := time.Since(start)
+	log.WithField("duration", duration.String()).Info("backed up workspace on request")
+	return &api.BackupResponse{DurationMs: uint64(duration.Milliseconds())}, nil
+}
+
+// SetPortsVerbose switches verbose logging of the ports manager on or off
+func (c *ControlService) SetPortsVerbose(ctx context.Context, req *api.SetPortsVerboseRequest) (*api.SetPortsVerboseResponse, error) {
+	c.portsManager.SetVerbose(req.Verbose)
+	return &api.SetPortsVerboseResponse{}, nil
+}
+
+// SimulatePort injects or removes a synthetic port
+func (c *ControlService) SimulatePort(ctx context.Context, req *api.SimulatePortRequest) (*api.SimulatePortResponse, error) {
+	if !c.portsManager.AllowSimulation {
+		return nil, status.Error(codes.PermissionDenied, "ports simulation is not enabled")
+	}
+	err := c.portsManager.Simulate(ctx, ports.SimulatedPort{
+		Port:    req.Port,
+		Served:  req.Served,
+		Process: req.Process,
+		Exposed: req.Exposed,
+		Public:  req.Public,
+		URL:     req.Url,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &api.SimulatePortResponse{}, nil
+}
+
+// TaskService implements the supervisor task service
+type TaskService struct {
+	tasks *tasksManager
+	// logDir is where the prebuild logs are located, prebuildLogDir if empty
+	logDir string
+}
+
+// RegisterGRPC registers the gRPC task service
+func (s *TaskService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterTaskServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST task service
+func (s *TaskService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterTaskServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// RestartTask re-runs the command of a task in a fresh terminal
+func (s *TaskService) RestartTask(ctx context.Context, req *api.RestartTaskRequest) (*api.RestartTaskResponse, error) {
+	alias, err := s.tasks.Restart(ctx, req.Id, req.Force)
+	if xerrors.Is(err, errTaskNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if xerrors.Is(err, errTaskNotRestartable) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.RestartTaskResponse{Terminal: alias}, nil
+}
+
+func (s *TaskService) prebuildLogDir() string {
+	if s.logDir == "" {
+		return prebuildLogDir
+	}
+	return s.logDir
+}
+
+// ListPrebuildLogs lists the logs the tasks wrote during the prebuild
+func (s *TaskService) ListPrebuildLogs(ctx context.Context, req *api.ListPrebuildLogsRequest) (*api.ListPrebuildLogsResponse, error) {
+	logs, err := readPrebuildLogs(s.prebuildLogDir())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &api.ListPrebuildLogsResponse{}
+	for _, l := range logs {
+		started, _ := ptypes.TimestampProto(l.Started)
+		finished, _ := ptypes.TimestampProto(l.Finished)
+		var size int64
+		if stat, err := os.Stat(prebuildLogFileName(s.prebuildLogDir(), l.TaskID)); err == nil {
+			size = stat.Size()
+		}
+		res.Logs = append(res.Logs, &api.PrebuildLog{
+			Id:       l.TaskID,
+			Name:     l.Name,
+			Commands: l.Commands,
+			Started:  started,
+			Finished: finished,
+			Success:  l.Success,
+			ExitCode: int32(l.ExitCode),
+			Size:     size,
+		})
+	}
+	return res, nil
+}
+
+// StreamPrebuildLog streams the log a task wrote during the prebuild
+func (s *TaskService) StreamPrebuildLog(req *api.StreamPrebuildLogRequest, srv api.TaskService_StreamPrebuildLogServer) error {
+	f, err := openPrebuildLog(s.prebuildLogDir(), req.Id)
+	if os.IsNotExist(err) {
+		return status.Errorf(codes.NotFound, "no prebuild log for task %s", req.Id)
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer f.Close()
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			serr := srv.Send(&api.StreamPrebuildLogResponse{Data: buf[:n]})
+			if serr != nil {
+				return serr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
+
+// ListTaskLogs lists the logs of the output of the tasks
+func (s *TaskService) ListTaskLogs(ctx context.Context, req *api.ListTaskLogsRequest) (*api.ListTaskLogsResponse, error) {
+	res := &api.ListTaskLogsResponse{}
+	if s.tasks.logs == nil {
+		return res, nil
+	}
+	logs, err := s.tasks.logs.List()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	s.tasks.mu.RLock()
+	defer s.tasks.mu.RUnlock()
+	for _, l := range logs {
+		modified, _ := ptypes.TimestampProto(l.Modified)
+		var name string
+		if t, ok := s.tasks.tasks[l.TaskID]; ok && t.Presentation != nil {
+			name = t.Presentation.Name
+		}
+		res.Logs = append(res.Logs, &api.TaskLog{
+			Id:       l.TaskID,
+			Name:     name,
+			Size:     l.Size,
+			Modified: modified,
+			Active:   l.Active,
+		})
+	}
+	return res, nil
+}
+
+// StreamTaskLog streams the logged output of a task
+func (s *TaskService) StreamTaskLog(req *api.StreamTaskLogRequest, srv api.TaskService_StreamTaskLogServer) error {
+	if s.tasks.logs == nil {
+		return status.Error(codes.FailedPrecondition, "task logs are disabled")
+	}
+	w := &taskLogStream{srv: srv}
+	follower, err := s.tasks.logs.Read(req.Id, req.Tail, req.Follow, w)
+	if os.IsNotExist(err) {
+		return status.Errorf(codes.NotFound, "no log for task %s", req.Id)
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if follower == nil {
+		return nil
+	}
+	defer follower.Close()
+
+	for {
+		select {
+		case data, ok := <-follower.Output:
+			if !ok {
+				if err := follower.Err(); err != nil {
+					return status.Error(codes.Aborted, err.Error())
+				}
+				return nil
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		case <-srv.Context().Done():
+			return status.Error(codes.DeadlineExceeded, srv.Context().Err().Error())
+		}
+	}
+}
+
+// taskLogStream sends what is written to it in chunks of a stream response
+type taskLogStream struct {
+	srv api.TaskService_StreamTaskLogServer
+}
+
+func (w *taskLogStream) Write(p []byte) (n int, err error) {
+	const chunkSize = 32 * 1024
+	for n < len(p) {
+		end := n + chunkSize
+		if end > len(p) {
+			end = len(p)
+		}
+		err = w.srv.Send(&api.StreamTaskLogResponse{Data: p[n:end]})
+		if err != nil {
+			return n, err
+		}
+		n = end
+	}
+	return n, nil
+}
+
+// PortService implements the supervisor port service
+type PortService struct {
+	portsManager *ports.Manager
+}
+
+// RegisterGRPC registers the gRPC port service
+func (s *PortService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterPortServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST port service
+func (s *PortService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterPortServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// RegisterHTTP registers the JSON-RPC bridge for clients which observe port changes without gRPC
+func (s *PortService) RegisterHTTP(mux *http.ServeMux) {
+	mux.Handle("/_supervisor/v1/ports/jsonrpc", &ports.JSONRPCBridge{Ports: s.portsManager})
+}
+
+// ResolvePort resolves a port name or number to the current ports of the port
+func (s *PortService) ResolvePort(ctx context.Context, req *api.ResolvePortRequest) (*api.ResolvePortResponse, error) {
+	port, err := s.portsManager.Resolve(req.Name)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	resp := &api.ResolvePortResponse{
+		LocalPort:  port.LocalPort,
+		GlobalPort: port.GlobalPort,
+		Name:       port.Name,
+	}
+	if port.Exposed != nil {
+		resp.Url = port.Exposed.Url
+	}
+	return resp, nil
+}
+
+// ListPorts returns the status of the ports ordered by local port
+func (s *PortService) ListPorts(ctx context.Context, req *api.ListPortsRequest) (*api.ListPortsResponse, error) {
+	ports := s.portsManager.Status()
+	sort.Slice(ports, func(i, j int) bool { return ports[i].LocalPort < ports[j].LocalPort })
+	return &api.ListPortsResponse{Ports: ports}, nil
+}
+
+// ExposePort exposes a port
+func (s *PortService) ExposePort(ctx context.Context, req *api.ExposePortRequest) (*api.ExposePortResponse, error) {
+	err := s.portsManager.Expose(ctx, req.Port, req.TargetPort)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &api.ExposePortResponse{}, nil
+}
+
+// ClosePort retracts the exposure of a port
+func (s *PortService) ClosePort(ctx context.Context, req *api.ClosePortRequest) (*api.ClosePortResponse, error) {
+	err := s.portsManager.Close(ctx, req.Port)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &api.ClosePortResponse{}, nil
+}
+
+// SetPortVisibility makes an exposed port public or private
+func (s *PortService) SetPortVisibility(ctx context.Context, req *api.SetPortVisibilityRequest) (*api.SetPortVisibilityResponse, error) {
+	err := s.portsManager.SetVisibility(ctx, req.Port, req.Visibility == api.PortVisibility_public)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &api.SetPortVisibilityResponse{}, nil
+}
+
+// tunnelBufferSize is the maximum amount of data sent in one tunnel response
+const tunnelBufferSize = 32 * 1024
+
+// Tunnel relays a TCP connection to a served port over the stream
+func (s *PortService) Tunnel(srv api.PortService_TunnelServer) error {
+	req, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+	open := req.GetOpen()
+	if open == nil {
+		return status.Error(codes.InvalidArgument, "the first request must open the tunnel")
+	}
+	conn, err := s.portsManager.OpenTunnel(srv.Context(), open.Port, open.Client)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	defer conn.Close()
+
+	go func() {
+		for {
+			req, err := srv.Recv()
+			if err == io.EOF {
+				// the client won't send any more data, but still receives the response
+				if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+					_ = cw.CloseWrite()
+				}
+				return
+			}
+			if err != nil {
+				conn.Close()
+				return
+			}
+			_, err = conn.Write(req.GetData())
+			if err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}()
+
+	buf := make([]byte, tunnelBufferSize)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			sendErr := srv.Send(&api.TunnelResponse{Data: buf[:n]})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF || srv.Context().Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+	}
+}
+
 // ContentState signals the workspace content state
 type ContentState interface {
 	MarkContentReady(src csapi.WorkspaceInitSource)
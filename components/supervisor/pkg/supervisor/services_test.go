@@ -6,6 +6,8 @@ package supervisor
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -43,6 +45,7 @@ func TestInMemoryTokenServiceGetToken(t *testing.T) {
 		Req         *api.GetTokenRequest
 		Cache       []*token
 		Provider    map[string][]tokenProvider
+		Fallback    []tokenProvider
 		Expectation Expectation
 	}{
 		{
@@ -153,6 +156,69 @@ func TestInMemoryTokenServiceGetToken(t *testing.T) {
 				Err: errNoToken,
 			},
 		},
+		{
+			Desc: "cached token (fewest scopes)",
+			Req: &api.GetTokenRequest{
+				Host:  defaultHost,
+				Scope: []string{"a1"},
+			},
+			Cache: []*token{
+				newToken("a1", "a2", "a3"),
+				func(t *token) *token {
+					t.Token = "narrow"
+					return t
+				}(newToken("a1", "a2")),
+			},
+			Expectation: Expectation{
+				Resp: &api.GetTokenResponse{Token: "narrow"},
+			},
+		},
+		{
+			Desc: "cached token (expires soon, refreshed)",
+			Req: &api.GetTokenRequest{
+				Host:  defaultHost,
+				Scope: []string{"a1"},
+			},
+			Cache: []*token{
+				func(t *token) *token {
+					exp := time.Now().Add(tokenRefreshMargin / 2)
+					t.ExpiryDate = &exp
+					t.Token = "old"
+					return t
+				}(newToken("a1")),
+			},
+			Provider: map[string][]tokenProvider{
+				defaultHost: {tokenProviderFunc(func(ctx context.Context, req *api.GetTokenRequest) (tkn *token, err error) {
+					return newToken("a1"), nil
+				})},
+			},
+			Expectation: Expectation{
+				Resp: &api.GetTokenResponse{Token: defaultToken},
+			},
+		},
+		{
+			Desc: "cached token (expires soon, refresh fails)",
+			Req: &api.GetTokenRequest{
+				Host:  defaultHost,
+				Scope: []string{"a1"},
+			},
+			Cache: []*token{
+				func(t *token) *token {
+					exp := time.Now().Add(tokenRefreshMargin / 2)
+					t.ExpiryDate = &exp
+					t.Token = "old"
+					return t
+				}(newToken("a1")),
+			},
+			Provider: map[string][]tokenProvider{
+				defaultHost: {tokenProviderFunc(func(ctx context.Context, req *api.GetTokenRequest) (tkn *token, err error) {
+					return nil, status.Error(codes.Unavailable, "server is down")
+				})},
+			},
+			Expectation: Expectation{
+				Resp: &api.GetTokenResponse{Token: "old"},
+			},
+		},
 		{
 			Desc: "token provider (no token)",
 			Req: &api.GetTokenRequest{
@@ -183,6 +249,48 @@ func TestInMemoryTokenServiceGetToken(t *testing.T) {
 				Resp: &api.GetTokenResponse{Token: defaultToken},
 			},
 		},
+		{
+			Desc: "fallback provider",
+			Req: &api.GetTokenRequest{
+				Host:  "github.com",
+				Scope: []string{"git:read"},
+			},
+			Fallback: []tokenProvider{
+				tokenProviderFunc(func(ctx context.Context, req *api.GetTokenRequest) (tkn *token, err error) {
+					return
+				}),
+				tokenProviderFunc(func(ctx context.Context, req *api.GetTokenRequest) (tkn *token, err error) {
+					tkn = newToken("git:read")
+					tkn.Host, tkn.User = req.Host, "oauth2"
+					return tkn, nil
+				}),
+			},
+			Expectation: Expectation{
+				Resp: &api.GetTokenResponse{Token: defaultToken, User: "oauth2"},
+			},
+		},
+		{
+			Desc: "token provider before fallback provider",
+			Req: &api.GetTokenRequest{
+				Host:  defaultHost,
+				Scope: []string{"a1"},
+			},
+			Provider: map[string][]tokenProvider{
+				defaultHost: {tokenProviderFunc(func(ctx context.Context, req *api.GetTokenRequest) (tkn *token, err error) {
+					return newToken("a1"), nil
+				})},
+			},
+			Fallback: []tokenProvider{
+				tokenProviderFunc(func(ctx context.Context, req *api.GetTokenRequest) (tkn *token, err error) {
+					tkn = newToken("a1")
+					tkn.Token = "fallback"
+					return tkn, nil
+				}),
+			},
+			Expectation: Expectation{
+				Resp: &api.GetTokenResponse{Token: defaultToken},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -190,6 +298,7 @@ func TestInMemoryTokenServiceGetToken(t *testing.T) {
 			service := NewInMemoryTokenService()
 			service.token = test.Cache
 			service.provider = test.Provider
+			service.fallback = test.Fallback
 
 			resp, err := service.GetToken(context.Background(), test.Req)
 
@@ -207,6 +316,62 @@ func TestInMemoryTokenServiceGetToken(t *testing.T) {
 	}
 }
 
+func TestInMemoryTokenServiceSharedFetch(t *testing.T) {
+	var (
+		fetches int32
+		release = make(chan struct{})
+	)
+	service := NewInMemoryTokenService()
+	service.provider["gitpod.io"] = []tokenProvider{tokenProviderFunc(func(ctx context.Context, req *api.GetTokenRequest) (*token, error) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		return &token{Host: req.Host, Token: "foobar", Scope: mapScopes(req.Scope), Reuse: api.TokenReuse_REUSE_WHEN_POSSIBLE}, nil
+	})}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := service.GetToken(context.Background(), &api.GetTokenRequest{Host: "gitpod.io", Scope: []string{"b", "a"}})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if resp.Token != "foobar" {
+				t.Errorf("unexpected token %q", resp.Token)
+			}
+		}()
+	}
+	// give all requests a chance to wait for the first fetch
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("expected a single fetch, got %d", n)
+	}
+}
+
+func TestInMemoryTokenServiceRevoke(t *testing.T) {
+	service := NewInMemoryTokenService()
+	_, err := service.SetToken(context.Background(), &api.SetTokenRequest{Host: "gitpod.io", Token: "foobar", Reuse: api.TokenReuse_REUSE_WHEN_POSSIBLE})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service.Revoke()
+
+	_, err = service.GetToken(context.Background(), &api.GetTokenRequest{Host: "gitpod.io"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected revoked tokens to be unavailable, got %v", err)
+	}
+	_, err = service.SetToken(context.Background(), &api.SetTokenRequest{Host: "gitpod.io", Token: "foobar"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected no tokens to be set after revocation, got %v", err)
+	}
+}
+
 func TestInMemoryTokenServiceSetToken(t *testing.T) {
 	var (
 		defaultHost  = "gitpod.io"
@@ -328,3 +493,97 @@ type tokenProviderFunc func(ctx context.Context, req *api.GetTokenRequest) (tkn
 func (f tokenProviderFunc) GetToken(ctx context.Context, req *api.GetTokenRequest) (tkn *token, err error) {
 	return f(ctx, req)
 }
+
+func TestIDEStatus(t *testing.T) {
+	newReady := func(ready bool) *ideReadyState {
+		return &ideReadyState{ready: ready, cond: sync.NewCond(&sync.Mutex{})}
+	}
+	tests := []struct {
+		Desc string
+		IDE  bool
+		// Desktop is the ready state of the desktop IDE, nil if there is none
+		Desktop     *bool
+		Expectation *api.IDEStatusResponse
+	}{
+		{Desc: "IDE ready", IDE: true, Expectation: &api.IDEStatusResponse{Ok: true}},
+		{Desc: "IDE not ready", Expectation: &api.IDEStatusResponse{}},
+		{
+			Desc:        "both ready",
+			IDE:         true,
+			Desktop:     func() *bool { b := true; return &b }(),
+			Expectation: &api.IDEStatusResponse{Ok: true, Desktop: &api.IDEStatusResponse_DesktopStatus{Ok: true}},
+		},
+		{
+			Desc:        "desktop IDE not ready",
+			IDE:         true,
+			Desktop:     func() *bool { b := false; return &b }(),
+			Expectation: &api.IDEStatusResponse{Ok: false, Desktop: &api.IDEStatusResponse_DesktopStatus{Ok: false}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			srv := &statusService{ideReady: newReady(test.IDE)}
+			if test.Desktop != nil {
+				srv.desktopReady = newReady(*test.Desktop)
+			}
+			act, err := srv.IDEStatus(context.Background(), &api.IDEStatusRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected status (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("wait for both", func(t *testing.T) {
+		srv := &statusService{ideReady: newReady(false), desktopReady: newReady(false)}
+		res := make(chan *api.IDEStatusResponse, 1)
+		go func() {
+			r, _ := srv.IDEStatus(context.Background(), &api.IDEStatusRequest{Wait: true})
+			res <- r
+		}()
+
+		srv.ideReady.Set(true)
+		select {
+		case r := <-res:
+			t.Fatalf("returned before the desktop IDE was ready: %v", r)
+		case <-time.After(100 * time.Millisecond):
+		}
+		srv.desktopReady.Set(true)
+		select {
+		case r := <-res:
+			if !r.Ok {
+				t.Errorf("expected both IDEs to be ready: %v", r)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("did not return once both IDEs were ready")
+		}
+	})
+}
+
+func TestOwnerCookie(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Config      WorkspaceConfig
+		Expectation string
+	}{
+		{
+			Desc:   "no owner token",
+			Config: WorkspaceConfig{GitpodHost: "https://gitpod.io", WorkspaceInstanceID: "a3b5c7"},
+		},
+		{
+			Desc:        "escaped owner token",
+			Config:      WorkspaceConfig{GitpodHost: "https://gitpod-staging.com", WorkspaceInstanceID: "a3b5c7", OwnerToken: "%7J'[Of/8N"},
+			Expectation: "_gitpod_staging_com_ws_a3b5c7_owner_=%257J%27%5BOf%2F8N",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := ownerCookie(&Config{WorkspaceConfig: test.Config})
+			if act != test.Expectation {
+				t.Errorf("unexpected cookie: %q, expected %q", act, test.Expectation)
+			}
+		})
+	}
+}
@@ -11,9 +11,11 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -25,15 +27,23 @@ import (
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/content-service/pkg/executor"
 	"github.com/gitpod-io/gitpod/content-service/pkg/initializer"
+	"github.com/gitpod-io/gitpod/content-service/pkg/progress"
 	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/activity"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/dropwriter"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/filewatch"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/logging"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/ports"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/sshd"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
 	daemon "github.com/gitpod-io/gitpod/ws-daemon/api"
+	"golang.org/x/crypto/ssh"
 	"golang.org/x/sys/unix"
+	"golang.org/x/xerrors"
 
 	grpcruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/sirupsen/logrus"
 	"github.com/soheilhy/cmux"
 	"google.golang.org/grpc"
 )
@@ -80,16 +90,21 @@ func InNamespace() RunOption {
 	}
 }
 
-// The sum of those timeBudget* times has to fit within the terminationGracePeriod of the workspace pod.
+// The sum of those timeBudget* times has to fit within the terminationGracePeriod of the workspace pod,
+// which is 30s: 5s + 3s + 6s + 2s + 3s + 10s = 29s.
 const (
 	timeBudgetIDEShutdown      = 5 * time.Second
-	timeBudgetTeardownCommands = 15 * time.Second
+	timeBudgetStopHook         = 3 * time.Second
+	timeBudgetTasksShutdown    = 6 * time.Second
+	timeBudgetPortsShutdown    = 2 * time.Second
+	timeBudgetTeardownCommands = 3 * time.Second
 	timeBudgetDaemonTeardown   = 10 * time.Second
 )
 
 // Run serves as main entrypoint to the supervisor
 func Run(options ...RunOption) {
 	defer log.Info("supervisor shut down")
+	started := time.Now()
 
 	opts := runOptions{
 		Args: os.Args,
@@ -107,6 +122,15 @@ func Run(options ...RunOption) {
 		return
 	}
 
+	log.Log = log.Log.WithFields(logrus.Fields{
+		log.WorkspaceField: cfg.WorkspaceID,
+		log.InstanceField:  cfg.WorkspaceInstanceID,
+	})
+	// the subsystems were created before the logger was initialised
+	logging.SetDefaultLevel(log.Log.Logger.GetLevel())
+	setLogLevel(cfg.LogLevel)
+	recentLogs := newRecentLogs(maxRecentLogs)
+	log.Log.Logger.AddHook(recentLogs)
 	buildIDEEnv(&Config{})
 	configureGit(cfg)
 
@@ -122,54 +146,436 @@ func Run(options ...RunOption) {
 		}
 	}
 
+	health := newHealthRegistry()
+	gitpodAPIHealth := health.register(healthGitpodAPI)
+
 	ctx, cancel := context.WithCancel(context.Background())
 	var (
 		shutdown            = make(chan struct{})
+		workspaceFailed     = make(chan struct{})
 		ideReady            = &ideReadyState{cond: sync.NewCond(&sync.Mutex{})}
+		desktopIDEReady     *ideReadyState
 		cstate              = NewInMemoryContentState(cfg.RepoRoot)
-		gitpodService       = createGitpodService(cfg, tokenService)
+		contentProgress     = newContentProgress()
+		gitpodService       = createGitpodService(cfg, tokenService, gitpodAPIHealth)
 		gitpodConfigService = gitpod.NewConfigService(cfg.RepoRoot+"/.gitpod.yml", cstate.ContentReady())
-		portMgmt            = ports.NewManager(
+		termMux             = terminal.NewMux()
+		termMuxSrv          = terminal.NewMuxTerminalService(termMux)
+		taskManager         = newTasksManager(cfg, termMuxSrv, cstate)
+		servedPorts         = &ports.PollingServedPortsObserver{
+			RefreshInterval: 1 * time.Second,
+			IdleInterval:    10 * time.Second,
+			Frameworks:      ports.NewFrameworkDetector(),
+			Debuggers:       ports.NewDebuggerDetector(),
+			Groups:          taskManager,
+			Compose:         ports.NewComposeDetector(ports.ComposeFiles(cfg.RepoRoot)...),
+			Kubernetes:      ports.NewKubernetesDetector(ports.Kubeconfigs()...),
+		}
+		portsConfigService = ports.NewConfigService(cfg.WorkspaceID, gitpodConfigService, gitpodService)
+		portMgmt           = ports.NewManager(
 			createExposedPortsImpl(cfg, gitpodService),
-			&ports.PollingServedPortsObserver{
-				RefreshInterval: 2 * time.Second,
-			},
-			ports.NewConfigService(cfg.WorkspaceID, gitpodConfigService, gitpodService),
-			uint32(cfg.IDEPort),
-			uint32(cfg.APIEndpointPort),
+			servedPorts,
+			portsConfigService,
+			internalPorts(cfg)...,
 		)
-		termMux    = terminal.NewMux()
-		termMuxSrv = terminal.NewMuxTerminalService(termMux)
 	)
-	taskManager := newTasksManager(cfg, termMuxSrv, cstate)
+	if cfg.DesktopIDE != nil {
+		desktopIDEReady = &ideReadyState{cond: sync.NewCond(&sync.Mutex{})}
+	}
+	if cfg.ReportNamespacePorts {
+		servedPorts.Namespaces = ports.NewNamespaceDetector(cfg.AutoExposeNamespaces...)
+	}
+	portMgmt.MaxSubscriptions = cfg.MaxPortSubscriptions
+	portMgmt.RequirePublicApproval = cfg.RequirePublicPortApproval
+	portMgmt.DryRun = cfg.PortsDryRun
+	portMgmt.SetVerbose(cfg.VerbosePorts)
+	portMgmt.AllowSimulation = cfg.AllowPortsSimulation
+	portMgmt.RemapPrivilegedPorts = cfg.RemapPrivilegedPorts
+	portMgmt.AnnounceTaskPorts = true
+	// the denylist was validated with the static config already
+	portMgmt.Denylist, _ = ports.ParseDenylist(cfg.DeniedPorts)
+	// the organization policy was validated with the workspace config already
+	if orgPolicy, _ := cfg.getOrganizationPortsPolicy(); orgPolicy != nil {
+		portsConfigService.UpdateOrganizationPolicy(orgPolicy)
+	}
 
+	home, err := os.UserHomeDir()
+	if err != nil {
+		log.WithError(err).Fatal("cannot find home directory")
+	}
+	dotfiles := newDotfilesInstaller(cfg.DotfileRepo, home)
+	taskManager.dotfiles = dotfiles
+	taskManager.health = health.register(healthTasks)
+	hooks := newLifecycleHooks(gitpodConfigService, cfg.RepoRoot)
+	taskManager.prebuildHook = func(ctx context.Context) error {
+		_, err := hooks.Run(ctx, hookOnPrebuild)
+		return err
+	}
+	headless := cfg.GitpodHeadless != nil && *cfg.GitpodHeadless == "true"
+
+	// terminals are kept by ring2, s.t. they and the processes running in them survive a crash or upgrade of supervisor
+	keeper, err := connectToKeeper()
+	if err != nil {
+		log.WithError(err).Warn("cannot connect to keeper - terminals will not survive a supervisor restart")
+	}
+	if keeper != nil {
+		termMux.Keeper = keeper
+	}
+	recovered := recoverFromKeeper(keeper, recoveryStateLocation, termMux)
+	if recovered != nil {
+		if recovered.Dotfiles != nil {
+			dotfiles.restore(recovered.Dotfiles)
+		}
+		if headless {
+			// the output of prebuild tasks cannot be watched anymore, hence they are run again
+			for _, t := range recovered.Terminals {
+				_ = termMux.Close(t.Alias)
+			}
+		} else {
+			taskManager.recovered = recovered.Tasks
+		}
+	}
+
+	if rec := cfg.TerminalRecordings; rec.Location != "" {
+		termMux.Recordings = terminal.NewRecordings(rec.Location, rec.MaxSize, rec.MaxTotalSize)
+	}
+	if logs := cfg.TaskLogs; logs.Location != "" {
+		taskManager.logs = &taskLogs{Dir: logs.Location, MaxSize: logs.MaxSize, MaxFiles: logs.MaxFiles}
+	}
 	termMuxSrv.DefaultWorkdir = cfg.RepoRoot
+	portsEnv := &ports.URLEnv{File: filepath.Join(os.TempDir(), "gitpod", "ports.env")}
+	termMuxSrv.Env = portsEnv.Environ
+	// tasks and commands entered in terminals are likely to serve new ports
+	termMuxSrv.OnCommand = servedPorts.Nudge
+	terminalsHealth := health.register(healthTerminals)
+	terminalsHealth.ok()
+	termMuxSrv.OnOpen = func(err error) {
+		if err != nil {
+			terminalsHealth.degraded(err)
+		} else {
+			terminalsHealth.ok()
+		}
+	}
+
+	// the policy was validated with the static config already
+	activityPolicy, _ := cfg.ActivityPolicy()
+	activityTracker := activity.NewTracker()
+	activityTracker.SetPolicy(activityPolicy)
+	termMuxSrv.OnInput = activityTracker.Recorder(activity.SourceTerminal)
+
+	notificationService := NewNotificationService()
+	presenceService := NewPresenceService()
+	metrics := newMetricsService()
+	metrics.RegisterPorts(portMgmt)
+	taskManager.failures = metrics.TaskFailures
+	if gitpodService != nil {
+		gitpodService.OnCall = metrics.ObserveAPICall
+	}
+	processes := newProcessTracker(metrics.Registry)
+	memory := newMemoryWatcher(notificationService)
+	disk := newDiskWatcher("/workspace", &cfg.StaticConfig, notificationService)
+	cpu := newCPUWatcher(nil)
+	if cfg.NotifyCPUThrottling {
+		cpu.Notifications = notificationService
+	}
+	metadata := newWorkspaceMetadata(cfg)
+	infoService := &InfoService{cfg: cfg, metadata: metadata}
+	var (
+		sshServer *sshd.Server
+		sshHealth *subsystemHealth
+	)
+	if cfg.SSHPort != 0 {
+		sshHealth = health.register(healthSSH)
+		sshServer, err = createSSHServer(cfg, termMuxSrv, gitpodService)
+		if err != nil {
+			log.WithError(err).Error("cannot create SSH server")
+			sshHealth.failed(err)
+		} else {
+			infoService.sshHostKey = sshServer.HostKey.PublicKey()
+			sshServer.OnInput = activityTracker.Recorder(activity.SourceSSH)
+			sshServer.OnConnect = func(user, fingerprint string) func() {
+				_, disconnect := presenceService.Connect(&api.PresenceConnection{
+					Client:     api.PresenceClient_presence_ssh,
+					UserId:     fingerprint,
+					UserName:   user,
+					ClientName: fingerprint,
+				})
+				return disconnect
+			}
+		}
+	}
+
+	statusSvc := &statusService{
+		ContentState: cstate,
+		Progress:     contentProgress,
+		Ports:        portMgmt,
+		Tasks:        taskManager,
+		Dotfiles:     dotfiles,
+		Health:       health,
+		Memory:       memory,
+		Disk:         disk,
+		CPU:          cpu,
+		ideReady:     ideReady,
+		desktopReady: desktopIDEReady,
+	}
+	diagnostics := &diagnosticsBundler{
+		Location: diagnosticsLocation,
+		Logs:     recentLogs,
+		Sources:  supervisorDiagnostics(cfg, statusSvc, processes),
+	}
 
 	apiServices := []RegisterableService{
-		&statusService{
-			ContentState: cstate,
-			Ports:        portMgmt,
-			Tasks:        taskManager,
-			ideReady:     ideReady,
-		},
+		statusSvc,
+		health,
 		termMuxSrv,
+		&ExecService{Workdir: cfg.RepoRoot, Env: portsEnv.Environ, Processes: processes},
+		&filewatch.Service{Root: cfg.RepoRoot},
 		RegistrableTokenService{tokenService},
-		&InfoService{cfg: cfg},
-		&ControlService{portsManager: portMgmt},
+		infoService,
+		&ControlService{portsManager: portMgmt, hooks: hooks, diagnostics: diagnostics, backup: backupWorkspace},
+		&PortService{portsManager: portMgmt},
+		&CertificateService{
+			Dir:        certificatesLocation,
+			TrustStore: caTrustStore,
+			PortHosts: func(port uint32) []string {
+				var exposedURL string
+				if status, err := portMgmt.Resolve(strconv.Itoa(int(port))); err == nil && status.Exposed != nil {
+					exposedURL = status.Exposed.Url
+				}
+				return portURLHosts(cfg.WorkspaceURL, port, exposedURL)
+			},
+		},
+		&TaskService{tasks: taskManager},
+		&ActivityService{Tracker: activityTracker},
+		notificationService,
+		presenceService,
+		processes,
+		metrics,
+		&ClipboardService{},
+		&OpenerService{Notifications: notificationService},
+		NewTunnelService(),
+	}
+	if cfg.JetBrainsBackendURL != "" {
+		infoService.jetbrains = &JetBrainsService{
+			DownloadURL: cfg.JetBrainsBackendURL,
+			Dir:         jetbrainsBackendLocation,
+			Project:     cfg.RepoRoot,
+			Port:        jetbrainsBackendPort,
+			Env:         buildIDEEnv(cfg),
+			Ports:       portMgmt,
+		}
+		apiServices = append(apiServices, infoService.jetbrains)
+	}
+	if sshServer != nil {
+		apiServices = append(apiServices, &sshTunnel{handler: sshServer.WebsocketHandler(ctx)})
+	}
+	if gitpodService != nil {
+		envVarService := &EnvVarService{API: gitpodService, WorkspaceID: cfg.WorkspaceID, File: filepath.Join(os.TempDir(), "gitpod", "env-vars.env")}
+		// terminals, tasks and SSH sessions inherit the PROMPT_COMMAND, s.t. running shells pick up changed variables
+		err := os.Setenv("PROMPT_COMMAND", envVarService.PromptCommand())
+		if err != nil {
+			log.WithError(err).Warn("cannot set PROMPT_COMMAND")
+		}
+		apiServices = append(apiServices,
+			envVarService,
+			&WorkspaceService{API: gitpodService, WorkspaceID: cfg.WorkspaceID, GitpodHost: cfg.GitpodHost, metadata: metadata, hooks: hooks, failed: workspaceFailed},
+		)
 	}
 	apiServices = append(apiServices, additionalServices...)
 
 	var wg sync.WaitGroup
 	wg.Add(6)
-	go reaper(ctx, &wg)
-	go startAndWatchIDE(ctx, cfg, &wg, ideReady)
-	go startContentInit(ctx, cfg, &wg, cstate)
+	go reaper(ctx, &wg, processes)
+	go processes.Run(ctx)
+	go memory.Run(ctx)
+	go disk.Run(ctx)
+	go cpu.Run(ctx)
+	go startAndWatchIDE(ctx, cfg, &ideProcess{
+		Name:      "IDE",
+		Config:    &cfg.IDEConfig,
+		Port:      cfg.IDEPort,
+		Essential: true,
+		Ready:     ideReady,
+		Health:    health.register(healthIDE),
+	}, &wg, notificationService)
+	if desktopIDEReady != nil {
+		wg.Add(1)
+		go startAndWatchIDE(ctx, cfg, &ideProcess{
+			Name:   "desktop IDE",
+			Config: cfg.DesktopIDE,
+			Port:   cfg.DesktopIDEPort,
+			Ready:  desktopIDEReady,
+			Health: health.register(healthDesktopIDE),
+		}, &wg, notificationService)
+	}
+	go func() {
+		select {
+		case <-ideReady.Wait():
+			metrics.IDEReady.Set(time.Since(started).Seconds())
+		case <-ctx.Done():
+		}
+	}()
+	if cfg.MetricsPushURL != "" {
+		interval, _ := cfg.MetricsPushPeriod()
+		go (&metricsPusher{
+			URL:            cfg.MetricsPushURL,
+			Interval:       interval,
+			Gatherer:       metrics.Registry,
+			WorkspaceID:    cfg.WorkspaceID,
+			InstanceID:     cfg.WorkspaceInstanceID,
+			WorkspaceClass: cfg.WorkspaceClass,
+		}).Run(ctx)
+	}
+	if recovered != nil && recovered.ContentReady {
+		go recoverContent(&wg, cstate, recovered.ContentSource, contentProgress, health.register(healthContent))
+	} else {
+		go startContentInit(ctx, cfg, &wg, cstate, contentProgress, health.register(healthContent))
+	}
+	go dotfiles.Run(ctx)
 	go startAPIEndpoint(ctx, cfg, &wg, apiServices, apiEndpointOpts...)
 	go taskManager.Run(ctx, &wg)
+	portsHealth := health.register(healthPorts)
 	go func() {
 		defer wg.Done()
+		portsHealth.ok()
 		portMgmt.Run()
 	}()
+	dotfilesHealth := health.register(healthDotfiles)
+	go func() {
+		<-dotfiles.Done()
+		if s := dotfiles.Status(); s.Phase == api.DotfilesPhase_dotfiles_failed {
+			dotfilesHealth.degraded(xerrors.New(s.Error))
+		} else {
+			dotfilesHealth.ok()
+		}
+	}()
+	go hooks.Observe(ctx)
+	if keeper != nil {
+		recorder := newRecoveryRecorder(recoveryStateLocation, cstate, dotfiles, termMux, taskManager)
+		termMux.OnChange = recorder.Nudge
+		go recorder.Run(ctx)
+		go func() {
+			restart := make(chan os.Signal, 1)
+			signal.Notify(restart, syscall.SIGUSR2)
+			<-restart
+			// the keeper restarts us, e.g. to pick up an upgraded binary, while terminals and tasks keep running
+			log.Info("received SIGUSR2 - restarting supervisor")
+			recorder.Persist()
+			os.Exit(RestartExitCode)
+		}()
+	}
+	if !headless && recovered == nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-cstate.ContentReady():
+			}
+			if _, err := hooks.Run(ctx, hookOnStart); err != nil {
+				log.WithError(err).Warn("lifecycle hook failed")
+			}
+		}()
+	}
+
+	pprof := newPprofServer(ctx)
+	pprof.Serve(cfg.PprofPort)
+	if loc, err := staticConfigLocation(); err == nil {
+		go func() {
+			watcher := &staticConfigWatcher{
+				Location: loc,
+				Current:  cfg.StaticConfig,
+				Apply:    (&staticConfigApplier{Ports: portMgmt, Activity: activityTracker, Pprof: pprof}).Apply,
+			}
+			err := watcher.Run(ctx)
+			if err != nil {
+				log.WithError(err).Warn("cannot watch supervisor config for changes")
+			}
+		}()
+	}
+	if cfg.PortsPagePort != 0 {
+		go servePortsPage(ctx, cfg, portMgmt)
+	}
+	if sshServer != nil {
+		go serveSSH(ctx, cfg, sshServer, sshHealth)
+	}
+
+	go func() {
+		err := portsEnv.Run(ctx, portMgmt)
+		if err != nil {
+			log.WithError(err).Warn("cannot provide port URL environment variables")
+		}
+	}()
+	go (&ports.ConnectionSampler{}).Run(ctx, portMgmt)
+	if cfg.RequirePublicPortApproval {
+		approver := &publicPortApprover{Notifications: notificationService, Approve: portMgmt.ApprovePublic}
+		go approver.Run(ctx, portMgmt)
+	}
+	go (&serviceReadyNotifier{Notifications: notificationService, Tasks: taskManager}).Run(ctx, portMgmt)
+	go func() {
+		observer := &ports.TrafficObserver{OnTraffic: activityTracker.Recorder(activity.SourcePorts)}
+		err := observer.Run(ctx, portMgmt)
+		if err != nil {
+			log.WithError(err).Warn("cannot observe port traffic")
+		}
+	}()
+	go func() {
+		idePorts := []uint32{uint32(cfg.IDEPort)}
+		if cfg.DesktopIDE != nil {
+			idePorts = append(idePorts, uint32(cfg.DesktopIDEPort))
+		}
+		observer := &ports.ConnectionObserver{
+			Ports:        idePorts,
+			OnConnection: activityTracker.Recorder(activity.SourceIDE),
+		}
+		err := observer.Run(ctx)
+		if err != nil {
+			log.WithError(err).Warn("cannot observe IDE connections")
+		}
+	}()
+
+	if gitpodService != nil {
+		_, gitpodHost, _ := cfg.GitpodAPIEndpoint()
+		tokenService.addFallbackProvider(&gitTokenProvider{API: gitpodService, Tokens: tokenService, GitpodHost: gitpodHost})
+		go func() {
+			auditor := &ports.ExposureAuditor{
+				WorkspaceID: cfg.WorkspaceID,
+				InstanceID:  cfg.WorkspaceInstanceID,
+				API:         gitpodService,
+			}
+			err := auditor.Run(ctx, portMgmt)
+			if err != nil {
+				log.WithError(err).Warn("cannot audit public ports")
+			}
+		}()
+		go func() {
+			err := metadata.Run(ctx, gitpodService)
+			if err != nil {
+				log.WithError(err).Warn("cannot keep workspace metadata up to date")
+			}
+		}()
+		go func() {
+			heartbeat := &activity.Heartbeat{
+				InstanceID: cfg.WorkspaceInstanceID,
+				API:        gitpodService,
+				Tracker:    activityTracker,
+			}
+			err := heartbeat.Run(ctx)
+			if err != nil {
+				log.WithError(err).Warn("cannot send heartbeats")
+			}
+		}()
+	}
+
+	if cfg.AnnouncePortsMDNS {
+		go func() {
+			hostname, _ := os.Hostname()
+			announcer := &ports.MDNSAnnouncer{Hostname: hostname}
+			err := announcer.Run(ctx, portMgmt)
+			if err != nil {
+				log.WithError(err).Warn("cannot announce ports via mDNS")
+			}
+		}()
+	}
 
 	if cfg.PreventMetadataAccess {
 		go func() {
@@ -187,44 +593,84 @@ func Run(options ...RunOption) {
 	select {
 	case <-sigChan:
 	case <-shutdown:
+	case <-workspaceFailed:
 	}
 
 	log.Info("received SIGTERM - tearing down")
+	if _, err := hooks.Run(ctx, hookOnStop); err != nil {
+		log.WithError(err).Warn("lifecycle hook failed")
+	}
+	stopTasks(taskManager)
+	stopPorts(portMgmt)
+	tokenService.Revoke()
 	teardown(!opts.InNamespace)
 
 	cancel()
 	wg.Wait()
+
+	select {
+	case <-workspaceFailed:
+		// the container terminates with the exit status the workspace code recorded in the termination log
+		os.Exit(StopExitCode)
+	default:
+	}
+}
+
+// gitpodAPIScopes are the scopes of the token supervisor calls the Gitpod API with. They are a subset of the scopes
+// the server grants the workspace token. Access to Git credentials is requested per host, see gitTokenProvider.
+var gitpodAPIScopes = []string{
+	"function:getWorkspace",
+	"function:openPort",
+	"function:closePort",
+	"function:getOpenPorts",
+	"function:auditPortExposure",
+	"function:sendHeartBeat",
+	"function:getSSHPublicKeys",
+	"function:getEnvVars",
+	"function:setEnvVar",
+	"function:deleteEnvVar",
+	"function:getWorkspaceTimeout",
+	"function:setWorkspaceTimeout",
+	"function:takeSnapshot",
+	"function:stopWorkspace",
 }
 
-func createGitpodService(cfg *Config, tknsrv api.TokenServiceServer) *gitpod.APIoverJSONRPC {
+func createGitpodService(cfg *Config, tknsrv api.TokenServiceServer, health *subsystemHealth) *gitpod.ResilientAPI {
 	endpoint, host, err := cfg.GitpodAPIEndpoint()
 	if err != nil {
 		log.WithError(err).Fatal("cannot find Gitpod API endpoint")
 		return nil
 	}
 	tknres, err := tknsrv.GetToken(context.Background(), &api.GetTokenRequest{
-		Host: host,
-		Scope: []string{
-			"function:openPort",
-			"function:getOpenPorts",
-		},
+		Host:  host,
+		Scope: gitpodAPIScopes,
 	})
 	if err != nil {
 		log.WithError(err).Error("cannot get token for Gitpod API")
+		health.failed(err)
 		return nil
 	}
 
-	gitpodService, err := gitpod.ConnectToServer(endpoint, gitpod.ConnectToServerOpts{
+	// while the server is unreachable, e.g. during maintenance, the workspace stays usable without it
+	gitpodService, err := gitpod.ConnectResilient(endpoint, gitpod.ConnectToServerOpts{
 		Token: tknres.Token,
+		OnStateChange: func(err error) {
+			if err != nil {
+				health.degraded(err)
+			} else {
+				health.ok()
+			}
+		},
 	})
 	if err != nil {
 		log.WithError(err).Error("cannot connect to Gitpod API")
+		health.failed(err)
 		return nil
 	}
 	return gitpodService
 }
 
-func createExposedPortsImpl(cfg *Config, gitpodService *gitpod.APIoverJSONRPC) (res ports.ExposedPortsInterface) {
+func createExposedPortsImpl(cfg *Config, gitpodService *gitpod.ResilientAPI) (res ports.ExposedPortsInterface) {
 	if gitpodService == nil {
 		log.Error("auto-port exposure won't work")
 		return &ports.NoopExposedPorts{}
@@ -284,11 +730,18 @@ func hasMetadataAccess() bool {
 	return false
 }
 
-func reaper(ctx context.Context, wg *sync.WaitGroup) {
+func reaper(ctx context.Context, wg *sync.WaitGroup, processes *processTracker) {
 	defer wg.Done()
 
+	// orphans are re-parented to us rather than to ring2, s.t. we reap and track them
+	err := unix.Prctl(unix.PR_SET_CHILD_SUBREAPER, 1, 0, 0, 0)
+	if err != nil {
+		log.WithError(err).Warn("cannot become child subreaper - orphans will not be tracked")
+	}
+
 	sigs := make(chan os.Signal, 128)
 	signal.Notify(sigs, syscall.SIGCHLD)
+	defer signal.Stop(sigs)
 	for {
 		select {
 		case <-ctx.Done():
@@ -296,20 +749,45 @@ func reaper(ctx context.Context, wg *sync.WaitGroup) {
 		case <-sigs:
 		}
 
-		pid, err := unix.Wait4(-1, nil, 0, nil)
+		// signals coalesce, hence one signal may stand for several exited children
+		for {
+			var status unix.WaitStatus
+			pid, err := unix.Wait4(-1, &status, unix.WNOHANG, nil)
+			if err == unix.EINTR {
+				continue
+			}
+			if err != nil || pid <= 0 {
+				// ECHILD: the calling process does not have any unwaited-for children.
+				break
+			}
 
-		if err == unix.ECHILD {
-			// The calling process does not have any unwaited-for children.
-			continue
+			log.WithField("pid", pid).Debug("reaped child process")
+			processes.reapedChild(pid, syscall.WaitStatus(status))
 		}
-
-		log.WithField("pid", pid).Debug("reaped child process")
 	}
 }
 
-func startAndWatchIDE(ctx context.Context, cfg *Config, wg *sync.WaitGroup, ideReady *ideReadyState) {
+// ideProcess is an IDE supervisor runs and restarts, i.e. the browser IDE and optionally a desktop IDE backend next to it
+type ideProcess struct {
+	// Name is how the IDE is referred to in logs and notifications, e.g. "IDE"
+	Name   string
+	Config *IDEConfig
+	Port   int
+	// Essential IDEs stop supervisor if they cannot be started at all
+	Essential bool
+	Ready     *ideReadyState
+	Health    *subsystemHealth
+}
+
+func startAndWatchIDE(ctx context.Context, cfg *Config, ide *ideProcess, wg *sync.WaitGroup, notifications *NotificationService) {
 	defer wg.Done()
 
+	var (
+		ideReady = ide.Ready
+		health   = ide.Health
+		log      = log.WithField("ide", ide.Name)
+	)
+
 	type status int
 	const (
 		statusNeverRan status = iota
@@ -321,6 +799,8 @@ func startAndWatchIDE(ctx context.Context, cfg *Config, wg *sync.WaitGroup, ideR
 	var (
 		cmd        *exec.Cmd
 		ideStopped chan struct{}
+		restarts   = newIDERestartPolicy()
+		crashLoop  bool
 	)
 supervisorLoop:
 	for {
@@ -328,9 +808,10 @@ supervisorLoop:
 			break
 		}
 
+		started := time.Now()
 		ideStopped = make(chan struct{}, 1)
 		go func() {
-			cmd = prepareIDELaunch(cfg)
+			cmd = prepareIDELaunch(cfg, ide)
 
 			// prepareIDELaunch sets Pdeathsig, which on on Linux, will kill the
 			// child process when the thread dies, not when the process dies.
@@ -343,23 +824,33 @@ supervisorLoop:
 
 			err := cmd.Start()
 			if err != nil {
-				if s == statusNeverRan {
+				health.failed(err)
+				if s == statusNeverRan && ide.Essential {
 					log.WithError(err).Fatal("IDE failed to start")
 				}
+				log.WithError(err).Error("IDE failed to start")
 
+				close(ideStopped)
 				return
 			}
 			s = statusShouldRun
 
 			go func() {
-				runIDEReadinessProbe(cfg)
+				runIDEReadinessProbe(ide, log)
 				ideReady.Set(true)
+				health.ok()
 			}()
 
 			err = cmd.Wait()
 			if err != nil && !strings.Contains(err.Error(), "signal: interrupt") {
 				log.WithError(err).Warn("IDE was stopped")
 			}
+			if s != statusShouldShutdown {
+				if err == nil {
+					err = xerrors.New("IDE exited")
+				}
+				health.degraded(err)
+			}
 
 			ideReady.Set(false)
 			close(ideStopped)
@@ -367,11 +858,35 @@ supervisorLoop:
 
 		select {
 		case <-ideStopped:
-			// IDE was stopped - let's just restart it after a small delay (in case the IDE doesn't start at all) in the next round
+			// IDE was stopped - let's restart it in the next round, backing off in case it keeps crashing
 			if s == statusShouldShutdown {
 				break supervisorLoop
 			}
-			time.Sleep(1 * time.Second)
+			delay, looping := restarts.stopped(started, time.Now())
+			if looping && !crashLoop {
+				err := xerrors.Errorf("%s keeps crashing, restarting it with a delay of up to %s", ide.Name, ideRestartMaxDelay)
+				log.WithError(err).Error("IDE is crash-looping")
+				health.failed(err)
+				if notifications != nil {
+					go func() {
+						_, err := notifications.Notify(ctx, &api.NotifyRequest{
+							Level:   api.NotificationLevel_notification_error,
+							Message: fmt.Sprintf("The %s keeps crashing and is restarted with a growing delay. Check its log output, e.g. for a broken extension or an IDE setting, or restart the workspace.", ide.Name),
+						})
+						if err != nil {
+							log.WithError(err).Warn("cannot notify about the crashing IDE")
+						}
+					}()
+				}
+			}
+			crashLoop = looping
+			log.WithField("delay", delay.String()).Info("restarting IDE")
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				break supervisorLoop
+			}
+			health.restarted()
 		case <-ctx.Done():
 			// we've been asked to shut down
 			s = statusShouldShutdown
@@ -390,14 +905,14 @@ supervisorLoop:
 	}
 }
 
-func prepareIDELaunch(cfg *Config) *exec.Cmd {
+func prepareIDELaunch(cfg *Config, ide *ideProcess) *exec.Cmd {
 	var args []string
 	args = append(args, cfg.WorkspaceRoot)
-	args = append(args, "--port", strconv.Itoa(cfg.IDEPort))
+	args = append(args, "--port", strconv.Itoa(ide.Port))
 	args = append(args, "--hostname", "0.0.0.0")
-	log.WithField("args", args).WithField("entrypoint", cfg.Entrypoint).Info("launching IDE")
+	log.WithField("args", args).WithField("entrypoint", ide.Config.Entrypoint).WithField("ide", ide.Name).Info("launching IDE")
 
-	cmd := exec.Command(cfg.Entrypoint, args...)
+	cmd := exec.Command(ide.Config.Entrypoint, args...)
 	cmd.Env = buildIDEEnv(cfg)
 
 	// We need the IDE to run in its own process group, s.t. we can suspend and resume
@@ -411,7 +926,7 @@ func prepareIDELaunch(cfg *Config) *exec.Cmd {
 	// This would break the JSON parsing of the headless builds.
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if lrr := cfg.LogRateLimit(); lrr > 0 {
+	if lrr := cfg.ideLogRateLimit(ide.Config); lrr > 0 {
 		limit := int64(lrr)
 		cmd.Stdout = dropwriter.Writer(cmd.Stdout, dropwriter.NewBucket(limit*1024*3, limit*1024))
 		cmd.Stderr = dropwriter.Writer(cmd.Stderr, dropwriter.NewBucket(limit*1024*3, limit*1024))
@@ -453,16 +968,16 @@ func buildIDEEnv(cfg *Config) []string {
 	return env
 }
 
-func runIDEReadinessProbe(cfg *Config) {
+func runIDEReadinessProbe(ide *ideProcess, log *logrus.Entry) {
 	defer log.Info("IDE is ready")
 
-	switch cfg.ReadinessProbe.Type {
+	switch ide.Config.ReadinessProbe.Type {
 	case ReadinessProcessProbe:
 		return
 
 	case ReadinessHTTPProbe:
 		var (
-			url    = fmt.Sprintf("http://localhost:%d/%s", cfg.IDEPort, strings.TrimPrefix(cfg.ReadinessProbe.HTTPProbe.Path, "/"))
+			url    = fmt.Sprintf("http://localhost:%d/%s", ide.Port, strings.TrimPrefix(ide.Config.ReadinessProbe.HTTPProbe.Path, "/"))
 			client = http.Client{Timeout: 5 * time.Second}
 			tick   = time.NewTicker(5 * time.Second)
 		)
@@ -502,6 +1017,109 @@ func isBlacklistedEnvvar(name string) bool {
 	return false
 }
 
+// internalPorts are the ports supervisor and the IDE serve, which are never exposed
+func internalPorts(cfg *Config) []uint32 {
+	res := []uint32{uint32(cfg.IDEPort), uint32(cfg.APIEndpointPort)}
+	if cfg.DesktopIDE != nil {
+		res = append(res, uint32(cfg.DesktopIDEPort))
+	}
+	if cfg.PortsPagePort != 0 {
+		res = append(res, uint32(cfg.PortsPagePort))
+	}
+	if cfg.SSHPort != 0 {
+		res = append(res, uint32(cfg.SSHPort))
+	}
+	if cfg.PprofPort != 0 {
+		res = append(res, uint32(cfg.PprofPort))
+	}
+	return res
+}
+
+func servePortsPage(ctx context.Context, cfg *Config, portMgmt *ports.Manager) {
+	srv := &http.Server{
+		Addr:    fmt.Sprintf("localhost:%d", cfg.PortsPagePort),
+		Handler: &ports.StatusPage{Ports: portMgmt},
+	}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	err := srv.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		log.WithError(err).WithField("port", cfg.PortsPagePort).Error("cannot serve ports status page")
+	}
+}
+
+// sshHostKeyLocation is where the host key of the SSH server is kept, s.t. it survives workspace restarts
+const sshHostKeyLocation = "/workspace/.gitpod/ssh/ssh_host_ed25519_key"
+
+func createSSHServer(cfg *Config, termMuxSrv *terminal.MuxTerminalService, gitpodService *gitpod.ResilientAPI) (*sshd.Server, error) {
+	hostKey, err := sshd.LoadOrGenerateHostKey(sshHostKeyLocation)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot load SSH host key: %w", err)
+	}
+	var keys sshd.KeySources
+	if gitpodService != nil {
+		keys = append(keys, &sshd.GitpodKeys{API: gitpodService})
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		keys = append(keys, sshd.AuthorizedKeysFile(filepath.Join(home, ".ssh", "authorized_keys")))
+	}
+	return &sshd.Server{
+		HostKey: hostKey,
+		Keys:    keys,
+		Shell:   termMuxSrv.LoginShell,
+		Workdir: termMuxSrv.DefaultWorkdir,
+		Env:     termMuxSrv.Env,
+	}, nil
+}
+
+// sshTunnelPath is where the API endpoint tunnels SSH through websockets. The workspace proxy authenticates
+// clients of it with the owner cookie, as for the rest of the API.
+const sshTunnelPath = "/_supervisor/v1/ssh/tunnel"
+
+// sshTunnel serves SSH for clients outside the workspace, which cannot reach the SSH port
+type sshTunnel struct {
+	handler http.Handler
+}
+
+// RegisterHTTP registers the SSH tunnel
+func (t *sshTunnel) RegisterHTTP(mux *http.ServeMux) {
+	mux.Handle(sshTunnelPath, t.handler)
+}
+
+// ownerCookie returns the cookie with which the workspace proxy admits the owner of the workspace, or an empty string
+// if there is no owner token. The cookie name is derived from the Gitpod host the same way the server does.
+func ownerCookie(cfg *Config) string {
+	if cfg.OwnerToken == "" {
+		return ""
+	}
+	host := cfg.GitpodHost
+	if u, err := url.Parse(cfg.GitpodHost); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	for _, c := range []string{" ", "-", "."} {
+		host = strings.ReplaceAll(host, c, "_")
+	}
+	return fmt.Sprintf("_%s_ws_%s_owner_=%s", host, cfg.WorkspaceInstanceID, url.QueryEscape(cfg.OwnerToken))
+}
+
+func serveSSH(ctx context.Context, cfg *Config, srv *sshd.Server, health *subsystemHealth) {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.SSHPort))
+	if err != nil {
+		log.WithError(err).WithField("port", cfg.SSHPort).Error("cannot serve SSH")
+		health.failed(err)
+		return
+	}
+	log.WithField("port", cfg.SSHPort).WithField("hostKey", ssh.FingerprintSHA256(srv.HostKey.PublicKey())).Info("serving SSH")
+	health.ok()
+	err = srv.Serve(ctx, l)
+	if err != nil {
+		log.WithError(err).WithField("port", cfg.SSHPort).Error("cannot serve SSH")
+		health.failed(err)
+	}
+}
+
 func startAPIEndpoint(ctx context.Context, cfg *Config, wg *sync.WaitGroup, services []RegisterableService, opts ...grpc.ServerOption) {
 	defer wg.Done()
 
@@ -515,6 +1133,10 @@ func startAPIEndpoint(ctx context.Context, cfg *Config, wg *sync.WaitGroup, serv
 		grpcruntime.WithMarshalerOption(grpcruntime.MIMEWildcard, &grpcruntime.JSONPb{EnumsAsInts: false, EmitDefaults: true}),
 	)
 	grpcMux := m.MatchWithWriters(cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc"))
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(apiVersionUnaryInterceptor),
+		grpc.ChainStreamInterceptor(apiVersionStreamInterceptor),
+	}, opts...)
 	grpcServer := grpc.NewServer(opts...)
 	grpcEndpoint := fmt.Sprintf("localhost:%d", cfg.APIEndpointPort)
 	for _, reg := range services {
@@ -534,6 +1156,11 @@ func startAPIEndpoint(ctx context.Context, cfg *Config, wg *sync.WaitGroup, serv
 	routes := http.NewServeMux()
 	routes.Handle("/_supervisor/v1/", http.StripPrefix("/_supervisor", restMux))
 	routes.Handle("/_supervisor/frontend", http.FileServer(http.Dir(cfg.FrontendLocation)))
+	for _, reg := range services {
+		if reg, ok := reg.(RegisterableHTTPService); ok {
+			reg.RegisterHTTP(routes)
+		}
+	}
 	go http.Serve(httpMux, routes)
 
 	go m.Serve()
@@ -543,15 +1170,18 @@ func startAPIEndpoint(ctx context.Context, cfg *Config, wg *sync.WaitGroup, serv
 	l.Close()
 }
 
-func startContentInit(ctx context.Context, cfg *Config, wg *sync.WaitGroup, cst ContentState) {
+func startContentInit(ctx context.Context, cfg *Config, wg *sync.WaitGroup, cst ContentState, prog *contentProgress, health *subsystemHealth) {
 	defer wg.Done()
 	defer log.Info("supervisor: workspace content available")
 
 	var err error
 	defer func() {
+		prog.finish(err)
 		if err == nil {
+			health.ok()
 			return
 		}
+		health.failed(err)
 
 		ferr := ioutil.WriteFile("/dev/termination-log", []byte(err.Error()), 0644)
 		if ferr != nil {
@@ -600,7 +1230,7 @@ func startContentInit(ctx context.Context, cfg *Config, wg *sync.WaitGroup, cst
 		return
 	}
 
-	src, err := executor.Execute(ctx, "/workspace", f, initializer.WithInWorkspace)
+	src, err := executor.Execute(progress.WithReporter(ctx, prog.Report), "/workspace", f, initializer.WithInWorkspace)
 	if err != nil {
 		return
 	}
@@ -618,6 +1248,35 @@ func startContentInit(ctx context.Context, cfg *Config, wg *sync.WaitGroup, cst
 	cst.MarkContentReady(src)
 }
 
+func stopTasks(taskManager *tasksManager) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeBudgetTasksShutdown)
+	defer cancel()
+
+	var killed []string
+	stopped := taskManager.Stop(ctx)
+	for _, t := range stopped {
+		if !t.Clean {
+			killed = append(killed, t.ID)
+		}
+	}
+	if len(killed) > 0 {
+		log.WithField("killed", killed).Warnf("%d of %d tasks did not exit within their grace period", len(killed), len(stopped))
+	} else if len(stopped) > 0 {
+		log.WithField("tasks", len(stopped)).Info("all tasks exited cleanly")
+	}
+}
+
+func stopPorts(portMgmt *ports.Manager) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeBudgetPortsShutdown)
+	defer cancel()
+
+	// exposed ports are closed by the server once the workspace is stopped - no need to retract them
+	err := portMgmt.Stop(ctx, false)
+	if err != nil {
+		log.WithError(err).Warn("cannot stop port management")
+	}
+}
+
 func teardown(withDaemonCall bool) {
 	if withDaemonCall {
 		log.Info("asking ws-daemon to tear down this workspace")
@@ -0,0 +1,204 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"golang.org/x/xerrors"
+)
+
+// dependencyPollInterval is the interval in which the readiness conditions of tasks are checked
+const dependencyPollInterval = 1 * time.Second
+
+// resolveDependencies resolves the task dependencies of the tasks by name. It returns the tasks which
+// cannot be scheduled, because their dependencies are invalid or cyclic, along with the reason.
+func resolveDependencies(tasks []*task) map[*task]error {
+	byName := make(map[string][]*task, len(tasks))
+	for _, t := range tasks {
+		if t.config.Name != nil {
+			byName[*t.config.Name] = append(byName[*t.config.Name], t)
+		}
+	}
+
+	invalid := make(map[*task]error)
+	for _, t := range tasks {
+		t.dependsOn, t.conditions = nil, nil
+		if t.config.DependsOn == nil {
+			continue
+		}
+		for _, dep := range *t.config.DependsOn {
+			if err := dep.Validate(); err != nil {
+				invalid[t] = xerrors.Errorf("invalid dependency: %w", err)
+				break
+			}
+			if dep.Task == nil {
+				t.conditions = append(t.conditions, dep)
+				continue
+			}
+			named := byName[*dep.Task]
+			if len(named) == 0 {
+				invalid[t] = xerrors.Errorf("depends on unknown task %s", *dep.Task)
+				break
+			}
+			if len(named) > 1 {
+				invalid[t] = xerrors.Errorf("depends on task %s, but several tasks have this name", *dep.Task)
+				break
+			}
+			t.dependsOn = append(t.dependsOn, named[0])
+		}
+	}
+
+	// tasks on a cycle would wait for each other forever
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[*task]int, len(tasks))
+	var visit func(t *task) bool
+	visit = func(t *task) (cyclic bool) {
+		switch marks[t] {
+		case visiting:
+			return true
+		case visited:
+			return false
+		}
+		marks[t] = visiting
+		for _, dep := range t.dependsOn {
+			if visit(dep) {
+				if _, exists := invalid[t]; !exists {
+					invalid[t] = xerrors.Errorf("depends on task %s, which depends on it in turn", taskName(dep))
+				}
+				cyclic = true
+			}
+		}
+		marks[t] = visited
+		return cyclic
+	}
+	for _, t := range tasks {
+		visit(t)
+	}
+	return invalid
+}
+
+// awaitDependencies blocks until all dependencies of the task hold. It fails if a task the task depends on fails to run.
+func (tm *tasksManager) awaitDependencies(ctx context.Context, t *task, headless bool) error {
+	for _, dep := range t.dependsOn {
+		tasksLog.WithField("task", t.Id).WithField("dependency", taskName(dep)).Info("task is waiting for another task")
+		err := tm.awaitTask(ctx, dep, headless)
+		if err != nil {
+			return err
+		}
+	}
+	for _, cond := range t.conditions {
+		tasksLog.WithField("task", t.Id).WithField("dependency", cond.String()).Info("task is waiting for a readiness condition")
+		for !tm.holds(ctx, cond) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(dependencyPollInterval):
+			}
+		}
+	}
+	return nil
+}
+
+// awaitTask blocks until a task runs, or in prebuilds until it succeeded
+func (tm *tasksManager) awaitTask(ctx context.Context, t *task, headless bool) error {
+	if !headless {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.started:
+			return nil
+		case <-t.closed:
+			select {
+			case <-t.started:
+				return nil
+			default:
+				return xerrors.Errorf("task %s did not run", taskName(t))
+			}
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.closed:
+	}
+	tm.mu.RLock()
+	exitCode := t.ExitCode
+	tm.mu.RUnlock()
+	if exitCode != 0 {
+		return xerrors.Errorf("task %s failed with exit code %d", taskName(t), exitCode)
+	}
+	return nil
+}
+
+// holds returns true if a readiness condition holds
+func (tm *tasksManager) holds(ctx context.Context, cond TaskDependency) bool {
+	switch {
+	case cond.Port != nil:
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", *cond.Port), dependencyPollInterval)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	case cond.File != nil:
+		fn := *cond.File
+		if !filepath.IsAbs(fn) {
+			fn = filepath.Join(tm.terminalService.DefaultWorkdir, fn)
+		}
+		_, err := os.Stat(fn)
+		return err == nil
+	case cond.HTTP != nil:
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, *cond.HTTP, nil)
+		if err != nil {
+			return false
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return 200 <= resp.StatusCode && resp.StatusCode < 400
+	default:
+		return false
+	}
+}
+
+// skip closes a task which does not run because its dependencies do not hold
+func (tm *tasksManager) skip(t *task, headless bool, reason error) {
+	tasksLog.WithError(reason).WithField("task", t.Id).Error("task does not run because its dependencies do not hold")
+	if headless {
+		// the prebuild fails if one of its tasks does not run
+		t.prebuildChan = make(chan bool, 1)
+		t.prebuildChan <- false
+	}
+	tm.updateState(func() *task {
+		t.State = api.TaskState_closed
+		t.ExitCode = -1
+		return t
+	})
+}
+
+// taskName returns the name of a task, or its id if it has no name
+func taskName(t *task) string {
+	if t.config.Name != nil {
+		return *t.config.Name
+	}
+	return t.Id
+}
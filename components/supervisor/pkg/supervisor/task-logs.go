@@ -0,0 +1,347 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// taskLogPrefix prefixes the names of the task logs, which are followed by the task id
+	taskLogPrefix = "task-"
+	// taskLogSuffix is the extension of task logs. Rotated logs have a number appended, .1 being the most recent.
+	taskLogSuffix = ".log"
+	// taskLogFollowerBuffer is the number of writes a follower of a log can lag behind before it is dropped
+	taskLogFollowerBuffer = 128
+)
+
+// errTaskLogFollowerLagging is returned to a follower of a task log which could not keep up with the output
+var errTaskLogFollowerLagging = xerrors.New("follower cannot keep up with the output of the task")
+
+// taskLogs keeps the output of tasks in rotated files
+type taskLogs struct {
+	Dir      string
+	MaxSize  int64
+	MaxFiles int
+
+	mu sync.Mutex
+	// active are the logs which are currently written by task id
+	active map[string]*taskLog
+}
+
+// taskLogInfo describes the log of a task on disk
+type taskLogInfo struct {
+	TaskID   string
+	Size     int64
+	Modified time.Time
+	Active   bool
+}
+
+func validTaskLogID(taskID string) bool {
+	return taskID != "" && !strings.ContainsAny(taskID, `/\`) && !strings.HasPrefix(taskID, ".")
+}
+
+func (l *taskLogs) fileName(taskID string, rotation int) string {
+	fn := filepath.Join(l.Dir, taskLogPrefix+taskID+taskLogSuffix)
+	if rotation > 0 {
+		fn += "." + strconv.Itoa(rotation)
+	}
+	return fn
+}
+
+// files returns the existing files of the log of a task, oldest first
+func (l *taskLogs) files(taskID string) []string {
+	var res []string
+	for i := l.MaxFiles - 1; i >= 0; i-- {
+		fn := l.fileName(taskID, i)
+		if _, err := os.Stat(fn); err == nil {
+			res = append(res, fn)
+		}
+	}
+	return res
+}
+
+// Open opens the log of a task for appending output. A log which is still open for the task is closed first.
+func (l *taskLogs) Open(taskID string) (*taskLog, error) {
+	if !validTaskLogID(taskID) {
+		return nil, xerrors.Errorf("invalid task id %q", taskID)
+	}
+	err := os.MkdirAll(l.Dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	prev := l.active[taskID]
+	l.mu.Unlock()
+	if prev != nil {
+		prev.Close()
+	}
+
+	res := &taskLog{logs: l, taskID: taskID}
+	err = res.openFile()
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	if l.active == nil {
+		l.active = make(map[string]*taskLog)
+	}
+	l.active[taskID] = res
+	l.mu.Unlock()
+	return res, nil
+}
+
+// List lists the logs on disk, ordered by task id
+func (l *taskLogs) List() ([]taskLogInfo, error) {
+	fns, err := filepath.Glob(filepath.Join(l.Dir, taskLogPrefix+"*"+taskLogSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	res := make([]taskLogInfo, 0, len(fns))
+	for _, fn := range fns {
+		taskID := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(fn), taskLogPrefix), taskLogSuffix)
+		info := taskLogInfo{TaskID: taskID, Active: l.active[taskID] != nil}
+		for _, f := range l.files(taskID) {
+			stat, err := os.Stat(f)
+			if err != nil {
+				continue
+			}
+			info.Size += stat.Size()
+			if stat.ModTime().After(info.Modified) {
+				info.Modified = stat.ModTime()
+			}
+		}
+		res = append(res, info)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		a, aerr := strconv.Atoi(res[i].TaskID)
+		b, berr := strconv.Atoi(res[j].TaskID)
+		if aerr != nil || berr != nil {
+			return res[i].TaskID < res[j].TaskID
+		}
+		return a < b
+	})
+	return res, nil
+}
+
+// Read writes the last tail bytes of the log of a task to w, or all of it if tail is not positive. If follow
+// is set and the log is active, the returned follower receives the output written afterwards until the log is closed.
+func (l *taskLogs) Read(taskID string, tail int64, follow bool, w io.Writer) (*taskLogFollower, error) {
+	if !validTaskLogID(taskID) {
+		return nil, os.ErrNotExist
+	}
+
+	l.mu.Lock()
+	active := l.active[taskID]
+	l.mu.Unlock()
+	if active != nil {
+		// output which is written while the files are read is neither missed nor sent twice
+		active.mu.Lock()
+		defer active.mu.Unlock()
+	}
+
+	fns := l.files(taskID)
+	if len(fns) == 0 {
+		return nil, os.ErrNotExist
+	}
+	err := readTail(fns, tail, w)
+	if err != nil {
+		return nil, err
+	}
+	if !follow || active == nil || active.closed {
+		return nil, nil
+	}
+
+	f := &taskLogFollower{Output: make(chan []byte, taskLogFollowerBuffer), log: active}
+	active.followers = append(active.followers, f)
+	return f, nil
+}
+
+// taskLogFollower receives the output written to a log
+type taskLogFollower struct {
+	// Output receives the output and is closed once the log is closed or the follower falls behind
+	Output chan []byte
+
+	log     *taskLog
+	lagging bool
+}
+
+// Err returns errTaskLogFollowerLagging once Output is closed because the follower fell behind
+func (f *taskLogFollower) Err() error {
+	if f.lagging {
+		return errTaskLogFollowerLagging
+	}
+	return nil
+}
+
+// Close stops following the log
+func (f *taskLogFollower) Close() {
+	f.log.unfollow(f)
+}
+
+// readTail writes the last tail bytes of the files to w, or all of their content if tail is not positive
+func readTail(fns []string, tail int64, w io.Writer) error {
+	var (
+		sizes = make([]int64, len(fns))
+		total int64
+	)
+	for i, fn := range fns {
+		stat, err := os.Stat(fn)
+		if err != nil {
+			return err
+		}
+		sizes[i] = stat.Size()
+		total += sizes[i]
+	}
+	skip := total - tail
+	if tail <= 0 || skip < 0 {
+		skip = 0
+	}
+
+	for i, fn := range fns {
+		if skip >= sizes[i] {
+			skip -= sizes[i]
+			continue
+		}
+		f, err := os.Open(fn)
+		if err != nil {
+			return err
+		}
+		_, err = f.Seek(skip, io.SeekStart)
+		if err == nil {
+			_, err = io.Copy(w, io.LimitReader(f, sizes[i]-skip))
+		}
+		f.Close()
+		if err != nil {
+			return err
+		}
+		skip = 0
+	}
+	return nil
+}
+
+// taskLog is the log of a task which is being written. Once a file exceeds the maximum size, it is rotated.
+type taskLog struct {
+	logs   *taskLogs
+	taskID string
+
+	mu        sync.Mutex
+	f         *os.File
+	size      int64
+	closed    bool
+	followers []*taskLogFollower
+}
+
+func (t *taskLog) openFile() error {
+	f, err := os.OpenFile(t.logs.fileName(t.taskID, 0), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	t.f, t.size = f, stat.Size()
+	return nil
+}
+
+// rotate moves the current file to the first rotation and opens a new one. The oldest file is removed.
+func (t *taskLog) rotate() error {
+	err := t.f.Close()
+	if err != nil {
+		return err
+	}
+	_ = os.Remove(t.logs.fileName(t.taskID, t.logs.MaxFiles-1))
+	for i := t.logs.MaxFiles - 2; i >= 0; i-- {
+		err := os.Rename(t.logs.fileName(t.taskID, i), t.logs.fileName(t.taskID, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return t.openFile()
+}
+
+// Write appends output to the log
+func (t *taskLog) Write(p []byte) (n int, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return 0, os.ErrClosed
+	}
+	if t.size > 0 && t.size+int64(len(p)) > t.logs.MaxSize {
+		err = t.rotate()
+		if err != nil {
+			return 0, xerrors.Errorf("cannot rotate log of task %s: %w", t.taskID, err)
+		}
+	}
+	n, err = t.f.Write(p)
+	t.size += int64(n)
+
+	for i := 0; i < len(t.followers); i++ {
+		f := t.followers[i]
+		select {
+		case f.Output <- append([]byte(nil), p[:n]...):
+		default:
+			f.lagging = true
+			close(f.Output)
+			t.followers = append(t.followers[:i], t.followers[i+1:]...)
+			i--
+		}
+	}
+	return n, err
+}
+
+func (t *taskLog) unfollow(follower *taskLogFollower) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, f := range t.followers {
+		if f == follower {
+			close(f.Output)
+			t.followers = append(t.followers[:i], t.followers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Close closes the log, which ends its followers
+func (t *taskLog) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	for _, f := range t.followers {
+		close(f.Output)
+	}
+	t.followers = nil
+
+	t.logs.mu.Lock()
+	if t.logs.active[t.taskID] == t {
+		delete(t.logs.active, t.taskID)
+	}
+	t.logs.mu.Unlock()
+
+	return t.f.Close()
+}
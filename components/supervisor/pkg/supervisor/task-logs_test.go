@@ -0,0 +1,150 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestTaskLogRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "task-logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	logs := &taskLogs{Dir: filepath.Join(dir, "logs"), MaxSize: 10, MaxFiles: 3}
+	l, err := logs.Open("0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, out := range []string{"aaaaaa", "bbbbbb", "cccccc", "dddddd", "eeee"} {
+		_, err := l.Write([]byte(out))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the file with the output of a was removed on the third rotation
+	tests := []struct {
+		Desc        string
+		Tail        int64
+		Expectation string
+	}{
+		{Desc: "all", Expectation: "bbbbbbccccccddddddeeee"},
+		{Desc: "tail within the current file", Tail: 3, Expectation: "eee"},
+		{Desc: "tail across files", Tail: 12, Expectation: "ccddddddeeee"},
+		{Desc: "tail beyond the log", Tail: 100, Expectation: "bbbbbbccccccddddddeeee"},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			var out bytes.Buffer
+			_, err := logs.Read("0", test.Tail, false, &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.Expectation {
+				t.Errorf("unexpected output: %q, expected %q", out.String(), test.Expectation)
+			}
+		})
+	}
+
+	list, err := logs.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]taskLogInfo{{TaskID: "0", Size: 22, Active: true}}, list, cmpopts.IgnoreFields(taskLogInfo{}, "Modified")); diff != "" {
+		t.Errorf("unexpected logs (-want +got):\n%s", diff)
+	}
+
+	err = l.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, _ = logs.List()
+	if len(list) != 1 || list[0].Active {
+		t.Errorf("expected the log to be inactive once closed: %v", list)
+	}
+
+	// reopening a log appends to it
+	l, err = logs.Open("0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	_, _ = l.Write([]byte("ff"))
+	var out bytes.Buffer
+	_, _ = logs.Read("0", 6, false, &out)
+	if out.String() != "eeeeff" {
+		t.Errorf("unexpected output after reopening: %q", out.String())
+	}
+
+	_, err = logs.Read("../0", 0, false, &out)
+	if !os.IsNotExist(err) {
+		t.Errorf("expected invalid task ids not to exist: %v", err)
+	}
+}
+
+func TestTaskLogFollow(t *testing.T) {
+	dir, err := ioutil.TempDir("", "task-logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	logs := &taskLogs{Dir: dir, MaxSize: 1024, MaxFiles: 2}
+	l, err := logs.Open("0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = l.Write([]byte("before"))
+
+	var out bytes.Buffer
+	follower, err := logs.Read("0", 0, true, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if follower == nil {
+		t.Fatal("expected to follow an active log")
+	}
+	_, _ = l.Write([]byte("after"))
+	l.Close()
+
+	for data := range follower.Output {
+		out.Write(data)
+	}
+	if follower.Err() != nil {
+		t.Errorf("unexpected error: %v", follower.Err())
+	}
+	if out.String() != "beforeafter" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+
+	// lagging followers are dropped rather than blocking the task
+	l, err = logs.Open("0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	follower, err = logs.Read("0", 0, true, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i <= taskLogFollowerBuffer; i++ {
+		_, _ = l.Write([]byte("x"))
+	}
+	for range follower.Output {
+	}
+	if follower.Err() != errTaskLogFollowerLagging {
+		t.Errorf("expected the follower to lag: %v", follower.Err())
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"io/ioutil"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+)
+
+const (
+	// defaultTaskGracePeriod is how long the processes of a task may take to exit unless the task configures otherwise
+	defaultTaskGracePeriod = 5 * time.Second
+	// taskExitPollInterval is the interval in which stopping tasks are checked for remaining processes
+	taskExitPollInterval = 100 * time.Millisecond
+)
+
+// stoppedTask is how a task ended when the workspace stopped
+type stoppedTask struct {
+	ID string
+	// Clean is true if the processes of the task exited within the grace period, and false if they were killed
+	Clean bool
+}
+
+// taskGracePeriod returns the termination grace period of a task. The period cannot exceed the time budget of the shutdown.
+func taskGracePeriod(config TaskConfig) time.Duration {
+	if config.TerminationGracePeriod == nil {
+		return defaultTaskGracePeriod
+	}
+	period, err := time.ParseDuration(*config.TerminationGracePeriod)
+	if err != nil || period < 0 {
+		tasksLog.WithField("terminationGracePeriod", *config.TerminationGracePeriod).Warn("invalid termination grace period, using the default")
+		return defaultTaskGracePeriod
+	}
+	if period > timeBudgetTasksShutdown {
+		tasksLog.WithField("terminationGracePeriod", *config.TerminationGracePeriod).Warnf("termination grace period exceeds the maximum of %s", timeBudgetTasksShutdown)
+		return timeBudgetTasksShutdown
+	}
+	return period
+}
+
+// Stop ends the running tasks: their processes receive SIGTERM and are killed if they have not exited once the
+// grace period of their task passed. Tasks stop in parallel. Stop returns how the tasks ended.
+func (tm *tasksManager) Stop(ctx context.Context) []stoppedTask {
+	type runningTask struct {
+		t     *task
+		alias string
+	}
+	tm.mu.RLock()
+	var running []runningTask
+	for _, t := range tm.tasks {
+		if t.State == api.TaskState_running {
+			running = append(running, runningTask{t, t.Terminal})
+		}
+	}
+	tm.mu.RUnlock()
+
+	var (
+		wg  sync.WaitGroup
+		res = make([]stoppedTask, len(running))
+	)
+	for i, rt := range running {
+		wg.Add(1)
+		go func(i int, rt runningTask) {
+			defer wg.Done()
+			clean := tm.stopTask(ctx, rt.t, rt.alias)
+			res[i] = stoppedTask{ID: rt.t.Id, Clean: clean}
+
+			log := tasksLog.WithField("task", rt.t.Id).WithField("terminal", rt.alias)
+			if clean {
+				log.Info("task exited cleanly")
+			} else {
+				log.WithField("gracePeriod", rt.t.gracePeriod.String()).Warn("task did not exit within its grace period and was killed")
+			}
+		}(i, rt)
+	}
+	wg.Wait()
+	return res
+}
+
+// stopTask terminates the processes of a task's terminal and closes the terminal. It returns true
+// if all processes exited within the grace period of the task.
+func (tm *tasksManager) stopTask(ctx context.Context, t *task, alias string) (clean bool) {
+	term, ok := tm.terminalService.Mux.Get(alias)
+	if !ok || term.Command.Process == nil {
+		return true
+	}
+	defer func() {
+		err := tm.terminalService.Mux.Close(alias)
+		if err != nil {
+			tasksLog.WithError(err).WithField("terminal", alias).Debug("cannot close the terminal of a stopped task")
+		}
+	}()
+
+	// the shell of a terminal leads a session, which all processes the task starts belong to unless they detach
+	sid := term.Command.Process.Pid
+	signalSession("/proc", sid, syscall.SIGTERM)
+
+	ctx, cancel := context.WithTimeout(ctx, t.gracePeriod)
+	defer cancel()
+	ticker := time.NewTicker(taskExitPollInterval)
+	defer ticker.Stop()
+	for {
+		// interactive shells ignore SIGTERM, hence the task is done once its shell is the only process left
+		var remaining int
+		for _, pid := range sessionProcesses("/proc", sid) {
+			if pid != sid {
+				remaining++
+			}
+		}
+		if remaining == 0 {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			signalSession("/proc", sid, syscall.SIGKILL)
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// sessionProcesses returns the live processes of a session, including its leader
+func sessionProcesses(procDir string, sid int) []int {
+	entries, err := ioutil.ReadDir(procDir)
+	if err != nil {
+		tasksLog.WithError(err).Warn("cannot list processes")
+		return nil
+	}
+	var res []int
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		stat, err := readProcStat(procDir, pid)
+		if err != nil || stat.Session != sid || stat.State == "Z" {
+			continue
+		}
+		res = append(res, pid)
+	}
+	return res
+}
+
+func signalSession(procDir string, sid int, sig syscall.Signal) {
+	for _, pid := range sessionProcesses(procDir, sid) {
+		err := syscall.Kill(pid, sig)
+		if err != nil && err != syscall.ESRCH {
+			tasksLog.WithError(err).WithField("pid", pid).WithField("signal", sig.String()).Warn("cannot signal task process")
+		}
+	}
+}
@@ -16,10 +16,12 @@ import (
 	"sync"
 	"time"
 
-	"github.com/gitpod-io/gitpod/common-go/log"
 	csapi "github.com/gitpod-io/gitpod/content-service/api"
 	"github.com/gitpod-io/gitpod/supervisor/api"
 	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/xerrors"
 )
 
 type runContext struct {
@@ -39,6 +41,9 @@ func (sub *tasksSubscription) Updates() <-chan []*api.TaskStatus {
 
 const maxSubscriptions = 10
 
+// prebuildExitTimeout is how long to wait for the exit code of a prebuild task once its output ended
+const prebuildExitTimeout = 5 * time.Second
+
 func (tm *tasksManager) Subscribe() *tasksSubscription {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
@@ -69,18 +74,63 @@ type task struct {
 	config       TaskConfig
 	command      string
 	prebuildChan chan bool
+
+	// dependsOn are the tasks and conditions are the readiness conditions which must hold before the task starts
+	dependsOn  []*task
+	conditions []TaskDependency
+
+	// gracePeriod is how long the processes of the task may take to exit once the workspace stops
+	gracePeriod time.Duration
+
+	// started is closed once the task runs for the first time, closed once it is closed for the first time
+	started     chan struct{}
+	startedOnce sync.Once
+	closed      chan struct{}
+	closedOnce  sync.Once
+}
+
+// notify signals waiters of the task's state. Callers are expected to hold mu.
+func (t *task) notify() {
+	switch {
+	case t.State == api.TaskState_running && t.started != nil:
+		t.startedOnce.Do(func() { close(t.started) })
+	case t.State == api.TaskState_closed && t.closed != nil:
+		t.closedOnce.Do(func() { close(t.closed) })
+	}
 }
 
 type tasksManager struct {
 	config          *Config
 	tasks           map[string]*task
+	headless        bool
 	subscriptions   map[*tasksSubscription]struct{}
 	mu              sync.RWMutex
 	ready           chan struct{}
 	terminalService *terminal.MuxTerminalService
 	contentState    ContentState
+	// dotfiles are installed before the tasks start, s.t. their shells are configured by the dotfiles
+	dotfiles *dotfilesInstaller
+	// restartMu serializes restarts s.t. a task is never restarted in two terminals at once
+	restartMu sync.Mutex
+	// health reports tasks which failed
+	health *subsystemHealth
+	// prebuildHook runs once all prebuild tasks succeeded. The prebuild fails if it fails.
+	prebuildHook func(ctx context.Context) error
+	// recovered are the tasks of a previous supervisor process, which are re-adopted rather than started again
+	recovered []recoveredTask
+	// logs keep the output of the tasks on disk if set
+	logs *taskLogs
+	// failures counts the tasks which exited with an error if set
+	failures prometheus.Counter
 }
 
+var (
+	// errTaskNotFound is returned when restarting a task which does not exist
+	errTaskNotFound = xerrors.New("task not found")
+	// errTaskNotRestartable is returned when restarting a task which cannot be restarted in its state
+	errTaskNotRestartable = xerrors.New("task cannot be restarted")
+)
+
 func newTasksManager(config *Config, terminalService *terminal.MuxTerminalService, contentState ContentState) *tasksManager {
 	return &tasksManager{
 		config:          config,
@@ -99,12 +149,37 @@ func (tm *tasksManager) getStatus() []*api.TaskStatus {
 	i := 0
 	status := make([]*api.TaskStatus, len(tm.tasks))
 	for _, task := range tm.tasks {
-		status[i] = &task.TaskStatus
+		status[i] = proto.Clone(&task.TaskStatus).(*api.TaskStatus)
 		i++
 	}
 	return status
 }
 
+// GroupOf returns the id of the task whose terminal started a process.
+// The ancestry lists the process first, followed by its parent, grand parent and so on.
+func (tm *tasksManager) GroupOf(ancestry []int) string {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	shells := make(map[int]string, len(tm.tasks))
+	for _, t := range tm.tasks {
+		if t.Terminal == "" {
+			continue
+		}
+		term, ok := tm.terminalService.Mux.Get(t.Terminal)
+		if !ok || term.Command.Process == nil {
+			continue
+		}
+		shells[term.Command.Process.Pid] = t.Id
+	}
+	for _, pid := range ancestry {
+		if id, ok := shells[pid]; ok {
+			return id
+		}
+	}
+	return ""
+}
+
 func (tm *tasksManager) updateState(doUpdate func() *task) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
@@ -113,13 +188,15 @@ func (tm *tasksManager) updateState(doUpdate func() *task) {
 	if updated == nil {
 		return
 	}
+	updated.notify()
+	// subscribers receive a copy, since the task's status keeps changing while updates are sent
 	updates := make([]*api.TaskStatus, 1)
-	updates[0] = &updated.TaskStatus
+	updates[0] = proto.Clone(&updated.TaskStatus).(*api.TaskStatus)
 	for sub := range tm.subscriptions {
 		select {
 		case sub.updates <- updates:
 		default:
-			log.Warn("cannot to push tasks update to a subscriber")
+			tasksLog.Warn("cannot to push tasks update to a subscriber")
 		}
 	}
 }
@@ -139,11 +216,11 @@ func (tm *tasksManager) init(ctx context.Context) *runContext {
 
 	tasks, err := tm.config.getGitpodTasks()
 	if err != nil {
-		log.WithError(err).Error()
+		tasksLog.WithError(err).Error()
 		return nil
 	}
 	if tasks == nil {
-		log.Info("no gitpod tasks found")
+		tasksLog.Info("no gitpod tasks found")
 		return nil
 	}
 
@@ -152,9 +229,17 @@ func (tm *tasksManager) init(ctx context.Context) *runContext {
 		return nil
 	case <-tm.contentState.ContentReady():
 	}
+	if tm.dotfiles != nil {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-tm.dotfiles.Done():
+		}
+	}
 
 	contentSource, _ := tm.contentState.ContentSource()
 	headless := tm.config.GitpodHeadless != nil && *tm.config.GitpodHeadless == "true"
+	tm.headless = headless
 	runContext := &runContext{
 		contentSource: contentSource,
 		headless:      headless,
@@ -181,7 +266,10 @@ func (tm *tasksManager) init(ctx context.Context) *runContext {
 				State:        api.TaskState_opening,
 				Presentation: presentation,
 			},
-			config: config,
+			config:      config,
+			gracePeriod: taskGracePeriod(config),
+			started:     make(chan struct{}),
+			closed:      make(chan struct{}),
 		}
 		task.command = task.getCommand(runContext)
 		if task.command == "" {
@@ -201,55 +289,215 @@ func (tm *tasksManager) Run(ctx context.Context, wg *sync.WaitGroup) {
 	if runContext == nil {
 		return
 	}
+	tm.health.ok()
 	if len(runContext.tasks) == 0 {
-		log.Info("no gitpod tasks to run")
+		tasksLog.Info("no gitpod tasks to run")
 		return
 	}
 
-	for _, t := range runContext.tasks {
-		taskLog := log.WithField("command", t.command)
-		taskLog.Info("starting a task terminal...")
-		openRequest := &api.OpenTerminalRequest{}
-		if t.config.Env != nil {
-			openRequest.Env = *t.config.Env
+	tasks := runContext.tasks
+	if len(tm.recovered) > 0 && !runContext.headless {
+		tasks = tm.recover(tasks)
+	}
+	tm.schedule(ctx, tasks, runContext.headless)
+
+	if runContext.headless {
+		tm.report(ctx)
+	}
+}
+
+// schedule starts the tasks once their dependencies hold. Tasks without dependencies start right away in order.
+// It returns once all tasks have been started or skipped, because their dependencies do not hold.
+func (tm *tasksManager) schedule(ctx context.Context, tasks []*task, headless bool) {
+	invalid := resolveDependencies(tasks)
+
+	var wg sync.WaitGroup
+	for _, t := range tasks {
+		if err, isInvalid := invalid[t]; isInvalid {
+			tm.skip(t, headless, err)
+			continue
 		}
-		resp, err := tm.terminalService.Open(ctx, openRequest)
-		if err != nil {
-			taskLog.WithError(err).Error("cannot open new task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
+		if len(t.dependsOn) == 0 && len(t.conditions) == 0 {
+			tm.start(ctx, t, headless)
 			continue
 		}
 
-		taskLog = taskLog.WithField("terminal", resp.Alias)
-		terminal, ok := tm.terminalService.Mux.Get(resp.Alias)
-		if !ok {
-			taskLog.Error("cannot find a task terminal")
-			tm.setTaskState(t, api.TaskState_closed)
+		wg.Add(1)
+		go func(t *task) {
+			defer wg.Done()
+			err := tm.awaitDependencies(ctx, t, headless)
+			if err != nil {
+				tm.skip(t, headless, err)
+				return
+			}
+			tm.start(ctx, t, headless)
+		}(t)
+	}
+	wg.Wait()
+}
+
+// start runs the command of a task in a new terminal. If the terminal cannot be opened, the task is closed.
+func (tm *tasksManager) start(ctx context.Context, t *task, headless bool) (alias string, err error) {
+	taskLog := tasksLog.WithField("command", t.command)
+	taskLog.Info("starting a task terminal...")
+	openRequest := &api.OpenTerminalRequest{Task: t.Id}
+	if t.config.Env != nil {
+		openRequest.Env = *t.config.Env
+	}
+	resp, err := tm.terminalService.Open(ctx, openRequest)
+	if err != nil {
+		taskLog.WithError(err).Error("cannot open new task terminal")
+		tm.setTaskState(t, api.TaskState_closed)
+		return "", err
+	}
+
+	taskLog = taskLog.WithField("terminal", resp.Alias)
+	terminal, ok := tm.terminalService.Mux.Get(resp.Alias)
+	if !ok {
+		taskLog.Error("cannot find a task terminal")
+		tm.setTaskState(t, api.TaskState_closed)
+		return "", xerrors.Errorf("cannot find the task terminal %s", resp.Alias)
+	}
+
+	taskLog.Info("task terminal has been started")
+	tm.updateState(func() *task {
+		t.Terminal = resp.Alias
+		t.State = api.TaskState_running
+		t.ExitCode = 0
+		return t
+	})
+	go tm.awaitExit(t, resp.Alias, terminal)
+	tm.logOutput(t, resp.Alias, terminal)
+
+	if headless {
+		tm.watch(t, terminal)
+	}
+	terminal.PTY.Write([]byte(t.command + "\r\n"))
+	return resp.Alias, nil
+}
+
+// awaitExit closes a task once the process of its terminal has exited
+func (tm *tasksManager) awaitExit(t *task, alias string, terminal *terminal.Term) {
+	<-terminal.Exited()
+	tasksLog.WithField("command", t.command).WithField("terminal", alias).Info("task terminal has been closed")
+	var exitCode int32
+	tm.updateState(func() *task {
+		if t.Terminal != alias {
+			// the task has been restarted in another terminal in the meantime
+			return nil
+		}
+		t.State = api.TaskState_closed
+		t.ExitCode = int32(terminal.ExitCode())
+		exitCode = t.ExitCode
+		return t
+	})
+	if exitCode != 0 {
+		tm.health.degraded(xerrors.Errorf("task %s exited with code %d", t.Id, exitCode))
+		if tm.failures != nil {
+			tm.failures.Inc()
+		}
+	}
+}
+
+// logOutput appends the output of a task's terminal to the log of the task, until the output ends
+func (tm *tasksManager) logOutput(t *task, alias string, terminal *terminal.Term) {
+	if tm.logs == nil {
+		return
+	}
+	l, err := tm.logs.Open(t.Id)
+	if err != nil {
+		tasksLog.WithError(err).WithField("task", t.Id).Warn("cannot open task log")
+		return
+	}
+	_, err = fmt.Fprintf(l, "\r\n--- %s: task %s runs in terminal %s ---\r\n", time.Now().UTC().Format(time.RFC3339), t.Id, alias)
+	if err != nil {
+		tasksLog.WithError(err).WithField("task", t.Id).Warn("cannot write task log")
+	}
+	terminal.Stdout.Tee(l)
+}
+
+// recover re-adopts the tasks a previous supervisor process started and returns the tasks which have not been started yet
+func (tm *tasksManager) recover(tasks []*task) (pending []*task) {
+	recovered := make(map[string]recoveredTask, len(tm.recovered))
+	for _, rt := range tm.recovered {
+		recovered[rt.ID] = rt
+	}
+	for _, t := range tasks {
+		rt, ok := recovered[t.Id]
+		if !ok || rt.State == api.TaskState_opening {
+			pending = append(pending, t)
 			continue
 		}
 
-		taskLog.Info("task terminal has been started")
+		term, running := tm.terminalService.Mux.Get(rt.Terminal)
+		running = running && rt.State == api.TaskState_running
 		tm.updateState(func() *task {
-			t.Terminal = resp.Alias
-			t.State = api.TaskState_running
+			t.Terminal = rt.Terminal
+			t.ExitCode = rt.ExitCode
+			if running {
+				t.State = api.TaskState_running
+			} else {
+				t.State = api.TaskState_closed
+			}
 			return t
 		})
+		if running {
+			go tm.awaitExit(t, rt.Terminal, term)
+			tm.logOutput(t, rt.Terminal, term)
+		}
+		tasksLog.WithField("task", t.Id).WithField("terminal", rt.Terminal).WithField("running", running).Info("recovered task")
+	}
+	return pending
+}
 
-		go func(t *task) {
-			terminal.Command.Process.Wait()
-			taskLog.Info("task terminal has been closed")
-			tm.setTaskState(t, api.TaskState_closed)
-		}(t)
+// Restart re-runs the command of a task in a new terminal and returns the terminal's alias.
+// Tasks which are still running are restarted only if forced, closing their terminal first.
+func (tm *tasksManager) Restart(ctx context.Context, id string, force bool) (alias string, err error) {
+	select {
+	case <-tm.ready:
+	default:
+		return "", xerrors.Errorf("tasks are not initialized yet: %w", errTaskNotRestartable)
+	}
 
-		if runContext.headless {
-			tm.watch(t, terminal)
-		}
-		terminal.PTY.Write([]byte(t.command + "\r\n"))
+	tm.restartMu.Lock()
+	defer tm.restartMu.Unlock()
+
+	tm.mu.RLock()
+	t, exists := tm.tasks[id]
+	var (
+		state api.TaskState
+		term  string
+	)
+	if exists {
+		state, term = t.State, t.Terminal
 	}
+	tm.mu.RUnlock()
 
-	if runContext.headless {
-		tm.report(ctx)
+	if !exists {
+		return "", xerrors.Errorf("%s: %w", id, errTaskNotFound)
+	}
+	if tm.headless {
+		return "", xerrors.Errorf("tasks of prebuilds cannot be restarted: %w", errTaskNotRestartable)
+	}
+	if t.command == "" {
+		return "", xerrors.Errorf("task %s has no command: %w", id, errTaskNotRestartable)
 	}
+	switch state {
+	case api.TaskState_opening:
+		return "", xerrors.Errorf("task %s has not been started yet: %w", id, errTaskNotRestartable)
+	case api.TaskState_running:
+		if !force {
+			return "", xerrors.Errorf("task %s is still running: %w", id, errTaskNotRestartable)
+		}
+		err := tm.terminalService.Mux.Close(term)
+		if err != nil {
+			tasksLog.WithError(err).WithField("terminal", term).Warn("cannot close the terminal of a restarted task")
+		}
+	}
+
+	tasksLog.WithField("task", id).Info("restarting task")
+	tm.health.restarted()
+	return tm.start(ctx, t, false)
 }
 
 func (task *task) getCommand(context *runContext) string {
@@ -280,7 +528,7 @@ func (task *task) getCommand(context *runContext) string {
 		format:   "%s\r\n",
 	})), 0644)
 	if err != nil {
-		log.WithField("histfile", histfile).WithError(err).Error("cannot write histfile")
+		tasksLog.WithField("histfile", histfile).WithError(err).Error("cannot write histfile")
 		return command
 	}
 	// the space at beginning of the HISTFILE command prevents the HISTFILE command itself from appearing in
@@ -310,12 +558,39 @@ func (task *task) getCommands(context *runContext) []*string {
 }
 
 func (task *task) prebuildLogFileName() string {
-	return "/workspace/.gitpod/prebuild-log-" + task.Id
+	return prebuildLogFileName(prebuildLogDir, task.Id)
+}
+
+// prebuildLog describes the log the task wrote during a prebuild
+func (task *task) prebuildLog(terminal *terminal.Term, started time.Time, success bool) *prebuildLog {
+	var commands []string
+	for _, c := range []*string{task.config.Before, task.config.Init, task.config.Prebuild} {
+		if c != nil && strings.TrimSpace(*c) != "" {
+			commands = append(commands, *c)
+		}
+	}
+
+	// the output ends before the terminal's process has exited
+	exitCode := -1
+	select {
+	case <-terminal.Exited():
+		exitCode = terminal.ExitCode()
+	case <-time.After(prebuildExitTimeout):
+	}
+	return &prebuildLog{
+		TaskID:   task.Id,
+		Name:     task.Presentation.Name,
+		Commands: commands,
+		Started:  started,
+		Finished: time.Now(),
+		Success:  success,
+		ExitCode: exitCode,
+	}
 }
 
 func (tm *tasksManager) watch(task *task, terminal *terminal.Term) {
 	var (
-		workspaceLog = log.WithField("component", "workspace")
+		workspaceLog = tasksLog.WithField("component", "workspace")
 		stdout       = terminal.Stdout.Listen()
 		start        = time.Now()
 	)
@@ -333,6 +608,12 @@ func (tm *tasksManager) watch(task *task, terminal *terminal.Term) {
 			return
 		}
 		defer file.Close()
+		defer func() {
+			err := writePrebuildLogManifest(prebuildLogDir, task.prebuildLog(terminal, start, success))
+			if err != nil {
+				workspaceLog.WithError(err).Error("cannot describe the prebuild log")
+			}
+		}()
 
 		fileWriter := bufio.NewWriter(file)
 
@@ -374,7 +655,7 @@ func (tm *tasksManager) watch(task *task, terminal *terminal.Term) {
 }
 
 func (tm *tasksManager) report(ctx context.Context) {
-	workspaceLog := log.WithField("component", "workspace")
+	workspaceLog := tasksLog.WithField("component", "workspace")
 	ok := true
 	for _, task := range tm.tasks {
 		if task.prebuildChan != nil {
@@ -388,9 +669,17 @@ func (tm *tasksManager) report(ctx context.Context) {
 			}
 		}
 	}
+	failure := "one of the tasks failed with non-zero exit code"
+	if ok && tm.prebuildHook != nil {
+		err := tm.prebuildHook(ctx)
+		if err != nil {
+			ok = false
+			failure = err.Error()
+		}
+	}
 	workspaceLog.WithField("type", "workspaceTaskOutput").WithField("data", "🚛 uploading prebuilt workspace").Info()
 	if !ok {
-		workspaceLog.WithField("type", "workspaceTaskFailed").WithField("error", "one of the tasks failed with non-zero exit code").Info()
+		workspaceLog.WithField("type", "workspaceTaskFailed").WithField("error", failure).Info()
 		return
 	}
 	workspaceLog.WithField("type", "workspaceTaskDone").Info()
@@ -0,0 +1,318 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/terminal"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+)
+
+func TestRestartTask(t *testing.T) {
+	termSrv := terminal.NewMuxTerminalService(terminal.NewMux())
+	termSrv.DefaultWorkdir = os.TempDir()
+	termSrv.LoginShell = []string{"/bin/sh"}
+	tm := newTasksManager(&Config{}, termSrv, nil)
+	tm.tasks["0"] = &task{
+		TaskStatus: api.TaskStatus{Id: "0", State: api.TaskState_closed, ExitCode: 1},
+		command:    "exit 3",
+	}
+	tm.tasks["1"] = &task{
+		TaskStatus: api.TaskStatus{Id: "1", State: api.TaskState_closed},
+	}
+	tm.tasks["2"] = &task{
+		TaskStatus: api.TaskStatus{Id: "2", State: api.TaskState_closed},
+		command:    "sleep 60",
+	}
+	close(tm.ready)
+
+	sub := tm.Subscribe()
+	defer sub.Close()
+	awaitState := func(id string, state api.TaskState) *api.TaskStatus {
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case updates := <-sub.Updates():
+				for _, update := range updates {
+					if update.Id == id && update.State == state {
+						return update
+					}
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for task %s to become %v", id, state)
+				return nil
+			}
+		}
+	}
+
+	_, err := tm.Restart(context.Background(), "0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := awaitState("0", api.TaskState_closed); status.ExitCode != 3 {
+		t.Errorf("unexpected exit code: %d", status.ExitCode)
+	}
+
+	_, err = tm.Restart(context.Background(), "1", false)
+	if !xerrors.Is(err, errTaskNotRestartable) {
+		t.Errorf("expected a task without command to not be restartable: %v", err)
+	}
+	_, err = tm.Restart(context.Background(), "3", false)
+	if !xerrors.Is(err, errTaskNotFound) {
+		t.Errorf("expected an unknown task to not be found: %v", err)
+	}
+
+	first, err := tm.Restart(context.Background(), "2", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer termSrv.Mux.Close(first)
+	awaitState("2", api.TaskState_running)
+	_, err = tm.Restart(context.Background(), "2", false)
+	if !xerrors.Is(err, errTaskNotRestartable) {
+		t.Errorf("expected a running task to not be restarted without force: %v", err)
+	}
+	second, err := tm.Restart(context.Background(), "2", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer termSrv.Mux.Close(second)
+	if _, running := termSrv.Mux.Get(first); running {
+		t.Error("expected the terminal of the restarted task to be closed")
+	}
+	tm.mu.RLock()
+	alias, state := tm.tasks["2"].Terminal, tm.tasks["2"].State
+	tm.mu.RUnlock()
+	if alias != second || state != api.TaskState_running {
+		t.Errorf("unexpected status of the restarted task: %s %v", alias, state)
+	}
+}
+
+func TestResolveDependencies(t *testing.T) {
+	tests := []struct {
+		Desc        string
+		Tasks       string
+		Expectation map[string]string
+	}{
+		{
+			Desc:  "no dependencies",
+			Tasks: `[{"name":"a"},{"name":"b"}]`,
+		},
+		{
+			Desc:  "valid graph",
+			Tasks: `[{"name":"db","dependsOn":[{"file":"docker-compose.yml"}]},{"name":"api","dependsOn":["db",{"port":5432}]},{"name":"web","dependsOn":[{"task":"api"},{"http":"http://localhost:3000/health"}]}]`,
+		},
+		{
+			Desc:        "unknown task",
+			Tasks:       `[{"name":"a","dependsOn":["b"]}]`,
+			Expectation: map[string]string{"0": "depends on unknown task b"},
+		},
+		{
+			Desc:        "ambiguous task",
+			Tasks:       `[{"name":"a"},{"name":"a"},{"name":"b","dependsOn":["a"]}]`,
+			Expectation: map[string]string{"2": "depends on task a, but several tasks have this name"},
+		},
+		{
+			Desc:        "invalid condition",
+			Tasks:       `[{"name":"a","dependsOn":[{"port":3000,"file":"ready"}]},{"name":"b","dependsOn":[{"port":70000}]}]`,
+			Expectation: map[string]string{"0": "invalid dependency: exactly one of task, port, file or http must be set", "1": "invalid dependency: port must be between 0 and 65535"},
+		},
+		{
+			Desc:  "cycle",
+			Tasks: `[{"name":"a","dependsOn":["c"]},{"name":"b","dependsOn":["a"]},{"name":"c","dependsOn":["b"]},{"name":"d","dependsOn":["d"]},{"name":"e","dependsOn":["a"]}]`,
+			Expectation: map[string]string{
+				"0": "depends on task c, which depends on it in turn",
+				"1": "depends on task a, which depends on it in turn",
+				"2": "depends on task b, which depends on it in turn",
+				"3": "depends on task d, which depends on it in turn",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			cfg := &Config{WorkspaceConfig: WorkspaceConfig{GitpodTasks: &test.Tasks}}
+			configs, err := cfg.getGitpodTasks()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var tasks []*task
+			for i, config := range *configs {
+				tasks = append(tasks, &task{TaskStatus: api.TaskStatus{Id: strconv.Itoa(i)}, config: config})
+			}
+
+			act := make(map[string]string)
+			for t, err := range resolveDependencies(tasks) {
+				act[t.Id] = err.Error()
+			}
+			if len(act) == 0 {
+				act = nil
+			}
+			if diff := cmp.Diff(test.Expectation, act); diff != "" {
+				t.Errorf("unexpected invalid tasks (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScheduleTasks(t *testing.T) {
+	workdir, err := ioutil.TempDir("", "tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workdir)
+
+	termSrv := terminal.NewMuxTerminalService(terminal.NewMux())
+	termSrv.DefaultWorkdir = workdir
+	termSrv.LoginShell = []string{"/bin/sh"}
+	tm := newTasksManager(&Config{}, termSrv, nil)
+
+	name := func(n string) *string { return &n }
+	newTask := func(id string, config TaskConfig) *task {
+		t := &task{
+			TaskStatus: api.TaskStatus{Id: id, State: api.TaskState_opening},
+			config:     config,
+			command:    "sleep 60",
+			started:    make(chan struct{}),
+			closed:     make(chan struct{}),
+		}
+		tm.tasks[id] = t
+		return t
+	}
+	tasks := []*task{
+		newTask("0", TaskConfig{Name: name("web"), DependsOn: &[]TaskDependency{{Task: name("api")}}}),
+		newTask("1", TaskConfig{Name: name("api"), DependsOn: &[]TaskDependency{{File: name("ready")}}}),
+		newTask("2", TaskConfig{Name: name("db")}),
+		newTask("3", TaskConfig{Name: name("worker"), DependsOn: &[]TaskDependency{{Task: name("broken")}}}),
+		newTask("4", TaskConfig{Name: name("broken"), DependsOn: &[]TaskDependency{{Task: name("missing")}}}),
+	}
+	defer func() {
+		for _, t := range tasks {
+			if t.Terminal != "" {
+				termSrv.Mux.Close(t.Terminal)
+			}
+		}
+	}()
+	state := func(id string) api.TaskState {
+		tm.mu.RLock()
+		defer tm.mu.RUnlock()
+		return tm.tasks[id].State
+	}
+
+	scheduled := make(chan struct{})
+	go func() {
+		tm.schedule(context.Background(), tasks, false)
+		close(scheduled)
+	}()
+
+	<-tasks[2].started
+	<-tasks[3].closed
+	if s := state("3"); s != api.TaskState_closed {
+		t.Errorf("expected the task depending on a skipped task to be closed: %v", s)
+	}
+	time.Sleep(2 * dependencyPollInterval)
+	if s0, s1 := state("0"), state("1"); s0 != api.TaskState_opening || s1 != api.TaskState_opening {
+		t.Errorf("expected tasks to wait for their dependencies: %v %v", s0, s1)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(workdir, "ready"), nil, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-scheduled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the tasks to be scheduled")
+	}
+	if s0, s1 := state("0"), state("1"); s0 != api.TaskState_running || s1 != api.TaskState_running {
+		t.Errorf("expected tasks to run once their dependencies hold: %v %v", s0, s1)
+	}
+}
+
+func TestStopTasks(t *testing.T) {
+	termSrv := terminal.NewMuxTerminalService(terminal.NewMux())
+	termSrv.DefaultWorkdir = os.TempDir()
+	termSrv.LoginShell = []string{"/bin/sh"}
+	tm := newTasksManager(&Config{}, termSrv, nil)
+
+	newTask := func(id, command string, gracePeriod time.Duration) *task {
+		t := &task{
+			TaskStatus:  api.TaskStatus{Id: id, State: api.TaskState_opening},
+			command:     command,
+			gracePeriod: gracePeriod,
+			closed:      make(chan struct{}),
+		}
+		tm.tasks[id] = t
+		return t
+	}
+	tasks := []*task{
+		newTask("0", "sleep 60", time.Minute),
+		newTask("1", "sh -c 'trap \"\" TERM; sleep 60'", 200*time.Millisecond),
+		newTask("2", "exit 0", time.Minute),
+	}
+	for _, task := range tasks {
+		_, err := tm.start(context.Background(), task, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the commands must run before the workspace stops
+	for _, task := range tasks[:2] {
+		term, _ := termSrv.Mux.Get(task.Terminal)
+		timeout := time.After(5 * time.Second)
+		for len(sessionProcesses("/proc", term.Command.Process.Pid)) < 2 {
+			select {
+			case <-timeout:
+				t.Fatalf("timed out waiting for task %s to run", task.Id)
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+	<-tasks[2].closed
+
+	stopped := tm.Stop(context.Background())
+	sort.Slice(stopped, func(i, j int) bool { return stopped[i].ID < stopped[j].ID })
+	if diff := cmp.Diff([]stoppedTask{{ID: "0", Clean: true}, {ID: "1", Clean: false}}, stopped); diff != "" {
+		t.Errorf("unexpected stopped tasks (-want +got):\n%s", diff)
+	}
+	for _, task := range tasks {
+		if _, open := termSrv.Mux.Get(task.Terminal); open {
+			t.Errorf("expected the terminal of task %s to be closed", task.Id)
+		}
+	}
+}
+
+func TestTaskGracePeriod(t *testing.T) {
+	duration := func(d string) *string { return &d }
+	tests := []struct {
+		Desc        string
+		GracePeriod *string
+		Expectation time.Duration
+	}{
+		{Desc: "default", Expectation: defaultTaskGracePeriod},
+		{Desc: "configured", GracePeriod: duration("3s"), Expectation: 3 * time.Second},
+		{Desc: "none", GracePeriod: duration("0s"), Expectation: 0},
+		{Desc: "invalid", GracePeriod: duration("ten seconds"), Expectation: defaultTaskGracePeriod},
+		{Desc: "negative", GracePeriod: duration("-1s"), Expectation: defaultTaskGracePeriod},
+		{Desc: "exceeds budget", GracePeriod: duration("10m"), Expectation: timeBudgetTasksShutdown},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			act := taskGracePeriod(TaskConfig{TerminationGracePeriod: test.GracePeriod})
+			if act != test.Expectation {
+				t.Errorf("unexpected grace period: %s, expected %s", act, test.Expectation)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TunnelService implements the api.TunnelService. It keeps the tunnels requested from within the workspace
+// and hands them to the clients which establish them on the developer's machine.
+type TunnelService struct {
+	mu      sync.Mutex
+	tunnels map[uint32]*api.TunnelStatus
+	// subscribers are the clients which establish tunnels, signaled whenever the tunnels change. Signals are
+	// coalesced, subscribers send the latest state.
+	subscribers map[chan struct{}]string
+}
+
+// NewTunnelService creates a new tunnel service
+func NewTunnelService() *TunnelService {
+	return &TunnelService{
+		tunnels:     make(map[uint32]*api.TunnelStatus),
+		subscribers: make(map[chan struct{}]string),
+	}
+}
+
+// RegisterGRPC registers the gRPC tunnel service
+func (s *TunnelService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterTunnelServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST tunnel service
+func (s *TunnelService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterTunnelServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// TunnelPort requests a tunnel of a port
+func (s *TunnelService) TunnelPort(ctx context.Context, req *api.TunnelPortRequest) (*api.TunnelPortResponse, error) {
+	if req.Port == 0 || req.Port > 65535 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid port %d", req.Port)
+	}
+	if req.TargetPort > 65535 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid target port %d", req.TargetPort)
+	}
+	target := req.TargetPort
+	if target == 0 {
+		target = req.Port
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, exists := s.tunnels[req.Port]; exists && t.TargetPort == target && t.ClientId == req.ClientId {
+		return &api.TunnelPortResponse{}, nil
+	}
+	s.tunnels[req.Port] = &api.TunnelStatus{
+		Port:       req.Port,
+		TargetPort: target,
+		ClientId:   req.ClientId,
+	}
+	s.changed()
+	log.WithField("port", req.Port).WithField("targetPort", target).Debug("tunnel requested")
+	return &api.TunnelPortResponse{}, nil
+}
+
+// CloseTunnel withdraws the tunnel of a port
+func (s *TunnelService) CloseTunnel(ctx context.Context, req *api.CloseTunnelRequest) (*api.CloseTunnelResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.tunnels[req.Port]; !exists {
+		return nil, status.Errorf(codes.NotFound, "port %d is not tunneled", req.Port)
+	}
+	delete(s.tunnels, req.Port)
+	s.changed()
+	return &api.CloseTunnelResponse{}, nil
+}
+
+// ListTunnels lists the requested tunnels
+func (s *TunnelService) ListTunnels(ctx context.Context, req *api.ListTunnelsRequest) (*api.ListTunnelsResponse, error) {
+	return &api.ListTunnelsResponse{Tunnels: s.list("", true)}, nil
+}
+
+// EstablishTunnels streams the tunnels a client is expected to establish whenever they change
+func (s *TunnelService) EstablishTunnels(req *api.EstablishTunnelsRequest, srv api.TunnelService_EstablishTunnelsServer) error {
+	if req.ClientId == "" {
+		return status.Error(codes.InvalidArgument, "client_id is required")
+	}
+
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	s.mu.Lock()
+	s.subscribers[changes] = req.ClientId
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, changes)
+		s.disconnected(req.ClientId)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case <-changes:
+			err := srv.Send(&api.EstablishTunnelsResponse{Tunnels: s.list(req.ClientId, false)})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ReportTunnel records that a client established a tunnel, or failed to
+func (s *TunnelService) ReportTunnel(ctx context.Context, req *api.ReportTunnelRequest) (*api.ReportTunnelResponse, error) {
+	if req.ClientId == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, exists := s.tunnels[req.Port]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "port %d is not tunneled", req.Port)
+	}
+	if t.ClientId != "" && t.ClientId != req.ClientId {
+		return nil, status.Errorf(codes.PermissionDenied, "port %d is tunneled by client %s", req.Port, t.ClientId)
+	}
+	if req.Error != "" {
+		if t.EstablishedBy != "" && t.EstablishedBy != req.ClientId {
+			// another client established the tunnel already
+			return &api.ReportTunnelResponse{}, nil
+		}
+		t.EstablishedBy, t.LocalAddress, t.Error = "", "", req.Error
+		return &api.ReportTunnelResponse{}, nil
+	}
+	t.EstablishedBy, t.LocalAddress, t.Error = req.ClientId, req.LocalAddress, ""
+	log.WithField("port", req.Port).WithField("client", req.ClientId).WithField("address", req.LocalAddress).Debug("tunnel established")
+	return &api.ReportTunnelResponse{}, nil
+}
+
+// disconnected marks the tunnels of a client as closed, unless it is still connected otherwise. Callers are expected to hold mu.
+func (s *TunnelService) disconnected(clientID string) {
+	for _, id := range s.subscribers {
+		if id == clientID {
+			return
+		}
+	}
+	for _, t := range s.tunnels {
+		if t.EstablishedBy == clientID {
+			t.EstablishedBy, t.LocalAddress = "", ""
+		}
+	}
+}
+
+// list returns copies of the tunnels ordered by port, either all or those which a client is expected to establish
+func (s *TunnelService) list(clientID string, all bool) []*api.TunnelStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]*api.TunnelStatus, 0, len(s.tunnels))
+	for _, t := range s.tunnels {
+		if !all && t.ClientId != "" && t.ClientId != clientID {
+			continue
+		}
+		res = append(res, proto.Clone(t).(*api.TunnelStatus))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Port < res[j].Port })
+	return res
+}
+
+// changed signals the subscribers. Callers are expected to hold mu.
+func (s *TunnelService) changed() {
+	for sub := range s.subscribers {
+		select {
+		case sub <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testTunnelClient struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *api.EstablishTunnelsResponse
+}
+
+func (c *testTunnelClient) Context() context.Context { return c.ctx }
+
+func (c *testTunnelClient) Send(e *api.EstablishTunnelsResponse) error {
+	c.updates <- e
+	return nil
+}
+
+func TestTunnelService(t *testing.T) {
+	srv := NewTunnelService()
+	ctx := context.Background()
+
+	_, err := srv.TunnelPort(ctx, &api.TunnelPortRequest{Port: 0})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected port 0 to be rejected: %v", err)
+	}
+
+	clientCtx, disconnect := context.WithCancel(context.Background())
+	client := &testTunnelClient{ctx: clientCtx, updates: make(chan *api.EstablishTunnelsResponse, 10)}
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.EstablishTunnels(&api.EstablishTunnelsRequest{ClientId: "desktop"}, client)
+	}()
+
+	// updates are coalesced, hence await waits for the update with the expected number of tunnels
+	await := func(tunnels int) *api.EstablishTunnelsResponse {
+		for {
+			select {
+			case e := <-client.updates:
+				if len(e.Tunnels) == tunnels {
+					return e
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("no update with %d tunnels", tunnels)
+				return nil
+			}
+		}
+	}
+	await(0)
+
+	_, err = srv.TunnelPort(ctx, &api.TunnelPortRequest{Port: 3000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = srv.TunnelPort(ctx, &api.TunnelPortRequest{Port: 8080, ClientId: "other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := await(1); e.Tunnels[0].Port != 3000 || e.Tunnels[0].TargetPort != 3000 {
+		t.Errorf("unexpected tunnels of the client: %v", e.Tunnels)
+	}
+
+	_, err = srv.ReportTunnel(ctx, &api.ReportTunnelRequest{ClientId: "desktop", Port: 8080, LocalAddress: "127.0.0.1:8080"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected the tunnel of another client to be denied: %v", err)
+	}
+	_, err = srv.ReportTunnel(ctx, &api.ReportTunnelRequest{ClientId: "desktop", Port: 3000, LocalAddress: "127.0.0.1:3000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, _ := srv.ListTunnels(ctx, &api.ListTunnelsRequest{})
+	if len(list.Tunnels) != 2 || list.Tunnels[0].LocalAddress != "127.0.0.1:3000" || list.Tunnels[0].EstablishedBy != "desktop" {
+		t.Errorf("unexpected tunnels: %v", list.Tunnels)
+	}
+
+	disconnect()
+	<-done
+	list, _ = srv.ListTunnels(ctx, &api.ListTunnelsRequest{})
+	if list.Tunnels[0].LocalAddress != "" || list.Tunnels[0].EstablishedBy != "" {
+		t.Errorf("expected the tunnel to be closed once the client disconnected: %v", list.Tunnels[0])
+	}
+
+	_, err = srv.CloseTunnel(ctx, &api.CloseTunnelRequest{Port: 3000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = srv.CloseTunnel(ctx, &api.CloseTunnelRequest{Port: 3000})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected closing an unknown tunnel to fail: %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workspaceTimeoutDurations are the timeouts the Gitpod server accepts, from the shortest to the longest
+var workspaceTimeoutDurations = []string{
+	gitpod.WorkspaceTimeoutDuration30m,
+	gitpod.WorkspaceTimeoutDuration60m,
+	gitpod.WorkspaceTimeoutDuration180m,
+}
+
+// terminationLogPath is the file Kubernetes reads the termination message of the container from,
+// which ws-manager reports as the reason the workspace failed
+var terminationLogPath = "/dev/termination-log"
+
+// snapshotProgressInterval is the interval in which TakeSnapshot reports that the snapshot is still being taken
+var snapshotProgressInterval = 5 * time.Second
+
+// WorkspaceService implements the api.WorkspaceService
+type WorkspaceService struct {
+	API         gitpod.APIInterface
+	WorkspaceID string
+	// GitpodHost is the URL of the Gitpod installation snapshot URLs point to
+	GitpodHost string
+
+	metadata *workspaceMetadata
+	hooks    *lifecycleHooks
+	// failed is closed once the workspace code stopped the workspace with a non-zero exit code,
+	// upon which supervisor shuts down
+	failed     chan struct{}
+	failedOnce sync.Once
+}
+
+// RegisterGRPC registers the gRPC workspace service
+func (s *WorkspaceService) RegisterGRPC(srv *grpc.Server) {
+	api.RegisterWorkspaceServiceServer(srv, s)
+}
+
+// RegisterREST registers the REST workspace service
+func (s *WorkspaceService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint string) error {
+	return api.RegisterWorkspaceServiceHandlerFromEndpoint(context.Background(), mux, grpcEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+// Timeout returns the timeout of the workspace
+func (s *WorkspaceService) Timeout(ctx context.Context, req *api.TimeoutRequest) (*api.TimeoutResponse, error) {
+	res, err := s.API.GetWorkspaceTimeout(ctx, s.WorkspaceID)
+	if err != nil {
+		err = gitpodAPIError(err, "cannot get workspace timeout")
+		if status.Code(err) != codes.Unimplemented {
+			return nil, err
+		}
+		// the installation does not support custom timeouts
+		md, _ := s.metadata.get()
+		return &api.TimeoutResponse{Timeout: md.Timeout}, nil
+	}
+	return &api.TimeoutResponse{
+		Timeout:   res.Duration,
+		CanExtend: res.CanChange,
+		Durations: workspaceTimeoutDurations,
+	}, nil
+}
+
+// ExtendTimeout changes the timeout of the workspace
+func (s *WorkspaceService) ExtendTimeout(ctx context.Context, req *api.ExtendTimeoutRequest) (*api.ExtendTimeoutResponse, error) {
+	duration := req.Duration
+	if duration == "" {
+		duration = workspaceTimeoutDurations[len(workspaceTimeoutDurations)-1]
+	}
+	var valid bool
+	for _, d := range workspaceTimeoutDurations {
+		valid = valid || d == duration
+	}
+	if !valid {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid duration %s, must be one of %v", duration, workspaceTimeoutDurations)
+	}
+
+	d := gitpod.WorkspaceTimeoutDuration(duration)
+	res, err := s.API.SetWorkspaceTimeout(ctx, s.WorkspaceID, &d)
+	if err != nil {
+		return nil, gitpodAPIError(err, "cannot extend workspace timeout")
+	}
+	// the instance update from the server follows, but clients should see the timeout right away
+	s.metadata.update(func(md *api.WorkspaceMetadataResponse) { md.Timeout = duration })
+	log.WithField("timeout", duration).Info("extended workspace timeout")
+
+	return &api.ExtendTimeoutResponse{
+		Timeout:         duration,
+		ResetWorkspaces: res.ResetTimeoutOnWorkspaces,
+	}, nil
+}
+
+// TakeSnapshot runs the onSnapshot hook and takes a snapshot of the workspace
+func (s *WorkspaceService) TakeSnapshot(req *api.TakeSnapshotRequest, srv api.WorkspaceService_TakeSnapshotServer) error {
+	ctx := srv.Context()
+	start := time.Now()
+	progress := func(resp *api.TakeSnapshotResponse) error {
+		resp.ElapsedSeconds = uint32(time.Since(start).Seconds())
+		return srv.Send(resp)
+	}
+
+	if !req.SkipHook && s.hooks != nil {
+		err := progress(&api.TakeSnapshotResponse{Phase: api.SnapshotPhase_snapshot_phase_hook})
+		if err != nil {
+			return err
+		}
+		_, err = s.hooks.Run(ctx, hookOnSnapshot)
+		if err != nil {
+			return status.Errorf(codes.FailedPrecondition, "cannot take snapshot: %v", err)
+		}
+	}
+
+	type result struct {
+		id  string
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		// the call returns once the snapshot was taken
+		id, err := s.API.TakeSnapshot(ctx, &gitpod.TakeSnapshotOptions{WorkspaceID: s.WorkspaceID})
+		done <- result{id, err}
+	}()
+
+	ticker := time.NewTicker(snapshotProgressInterval)
+	defer ticker.Stop()
+	err := progress(&api.TakeSnapshotResponse{Phase: api.SnapshotPhase_snapshot_phase_capturing})
+	for err == nil {
+		select {
+		case <-ticker.C:
+			err = progress(&api.TakeSnapshotResponse{Phase: api.SnapshotPhase_snapshot_phase_capturing})
+		case r := <-done:
+			if r.err != nil {
+				return gitpodAPIError(r.err, "cannot take snapshot")
+			}
+			log.WithField("snapshot", r.id).WithField("duration", time.Since(start).String()).Info("took workspace snapshot")
+			return progress(&api.TakeSnapshotResponse{
+				Phase:      api.SnapshotPhase_snapshot_phase_done,
+				SnapshotId: r.id,
+				Url:        strings.TrimSuffix(s.GitpodHost, "/") + "/#snapshot/" + r.id,
+			})
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, ctx.Err().Error())
+		}
+	}
+	return err
+}
+
+// Stop stops the workspace regularly or, given a non-zero exit code, fails it
+func (s *WorkspaceService) Stop(ctx context.Context, req *api.StopWorkspaceRequest) (*api.StopWorkspaceResponse, error) {
+	if req.ExitCode == 0 {
+		err := s.API.StopWorkspace(ctx, s.WorkspaceID)
+		if err != nil {
+			return nil, gitpodAPIError(err, "cannot stop workspace")
+		}
+		log.WithField("message", req.Message).Info("workspace code stopped the workspace")
+		return &api.StopWorkspaceResponse{}, nil
+	}
+	if req.ExitCode > 255 {
+		return nil, status.Errorf(codes.InvalidArgument, "exit code %d is out of range", req.ExitCode)
+	}
+	if s.failed == nil {
+		return nil, status.Error(codes.Unavailable, "workspace cannot be stopped with an exit code")
+	}
+
+	msg := fmt.Sprintf("workspace exited with code %d", req.ExitCode)
+	if req.Message != "" {
+		msg += ": " + req.Message
+	}
+	err := ioutil.WriteFile(terminationLogPath, []byte(msg), 0644)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot record exit status: %v", err)
+	}
+	log.WithField("exitCode", req.ExitCode).WithField("message", req.Message).Info("workspace code failed the workspace")
+	s.failedOnce.Do(func() { close(s.failed) })
+	return &api.StopWorkspaceResponse{}, nil
+}
+
+// gitpodAPIError converts an error of a Gitpod API call into a status error with a fitting code
+func gitpodAPIError(err error, msg string) error {
+	var rpcErr *jsonrpc2.Error
+	if !errors.As(err, &rpcErr) {
+		return status.Errorf(codes.Unavailable, "%s: %v", msg, err)
+	}
+	code := codes.Unknown
+	// see the ErrorCodes of the Gitpod protocol
+	switch rpcErr.Code {
+	case 400:
+		code = codes.InvalidArgument
+	case 401:
+		code = codes.Unauthenticated
+	case 403:
+		code = codes.PermissionDenied
+	case 404:
+		code = codes.NotFound
+	case 429:
+		code = codes.ResourceExhausted
+	case 481:
+		// the plan of the user does not include the feature
+		code = codes.PermissionDenied
+	case 501:
+		// the feature is not part of this installation
+		code = codes.Unimplemented
+	}
+	return status.Errorf(code, "%s: %s", msg, rpcErr.Message)
+}
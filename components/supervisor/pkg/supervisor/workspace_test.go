@@ -0,0 +1,178 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package supervisor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/gitpod-io/gitpod/supervisor/pkg/gitpod"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWorkspaceServiceTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+
+	metadata := newWorkspaceMetadata(&Config{})
+	metadata.update(func(md *api.WorkspaceMetadataResponse) { md.Timeout = "30m" })
+	srv := &WorkspaceService{API: gitpodAPI, WorkspaceID: "ws", metadata: metadata}
+
+	gitpodAPI.EXPECT().GetWorkspaceTimeout(gomock.Any(), "ws").Return(&gitpod.GetWorkspaceTimeoutResult{Duration: "30m", CanChange: true}, nil)
+	resp, err := srv.Timeout(context.Background(), &api.TimeoutRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.CanExtend || resp.Timeout != "30m" || len(resp.Durations) != len(workspaceTimeoutDurations) {
+		t.Errorf("unexpected timeout: %v", resp)
+	}
+
+	// installations without custom timeouts report the timeout of the instance
+	gitpodAPI.EXPECT().GetWorkspaceTimeout(gomock.Any(), "ws").Return(nil, &jsonrpc2.Error{Code: 501, Message: "enterprise feature"})
+	resp, err = srv.Timeout(context.Background(), &api.TimeoutRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.CanExtend || resp.Timeout != "30m" {
+		t.Errorf("unexpected timeout: %v", resp)
+	}
+
+	_, err = srv.ExtendTimeout(context.Background(), &api.ExtendTimeoutRequest{Duration: "1h"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected an invalid duration to be rejected: %v", err)
+	}
+
+	gitpodAPI.EXPECT().SetWorkspaceTimeout(gomock.Any(), "ws", gomock.Any()).Return(nil, &jsonrpc2.Error{Code: 481, Message: "Plan upgrade is required"})
+	_, err = srv.ExtendTimeout(context.Background(), &api.ExtendTimeoutRequest{Duration: "60m"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected the plan limit to deny the extension: %v", err)
+	}
+
+	longest := gitpod.WorkspaceTimeoutDuration(gitpod.WorkspaceTimeoutDuration180m)
+	gitpodAPI.EXPECT().SetWorkspaceTimeout(gomock.Any(), "ws", &longest).Return(&gitpod.SetWorkspaceTimeoutResult{ResetTimeoutOnWorkspaces: []string{"other"}}, nil)
+	ext, err := srv.ExtendTimeout(context.Background(), &api.ExtendTimeoutRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ext.Timeout != "180m" {
+		t.Errorf("unexpected timeout: %s", ext.Timeout)
+	}
+	if diff := cmp.Diff([]string{"other"}, ext.ResetWorkspaces); diff != "" {
+		t.Errorf("unexpected reset workspaces (-want +got):\n%s", diff)
+	}
+	if md, _ := metadata.get(); md.Timeout != "180m" {
+		t.Errorf("expected the metadata to reflect the new timeout, got %s", md.Timeout)
+	}
+}
+
+type testSnapshotProgress struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*api.TakeSnapshotResponse
+}
+
+func (s *testSnapshotProgress) Context() context.Context { return s.ctx }
+
+func (s *testSnapshotProgress) Send(e *api.TakeSnapshotResponse) error {
+	s.responses = append(s.responses, e)
+	return nil
+}
+
+func TestWorkspaceServiceTakeSnapshot(t *testing.T) {
+	defer func(interval time.Duration) { snapshotProgressInterval = interval }(snapshotProgressInterval)
+	snapshotProgressInterval = 10 * time.Millisecond
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+	srv := &WorkspaceService{API: gitpodAPI, WorkspaceID: "ws", GitpodHost: "https://gitpod.io/"}
+
+	gitpodAPI.EXPECT().TakeSnapshot(gomock.Any(), &gitpod.TakeSnapshotOptions{WorkspaceID: "ws"}).DoAndReturn(func(ctx context.Context, options *gitpod.TakeSnapshotOptions) (string, error) {
+		time.Sleep(50 * time.Millisecond)
+		return "snap", nil
+	})
+	progress := &testSnapshotProgress{ctx: context.Background()}
+	err := srv.TakeSnapshot(&api.TakeSnapshotRequest{}, progress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progress.responses) < 3 {
+		t.Fatalf("expected progress while capturing, got %v", progress.responses)
+	}
+	if phase := progress.responses[0].Phase; phase != api.SnapshotPhase_snapshot_phase_capturing {
+		t.Errorf("unexpected first phase: %v", phase)
+	}
+	last := progress.responses[len(progress.responses)-1]
+	if last.Phase != api.SnapshotPhase_snapshot_phase_done || last.SnapshotId != "snap" || last.Url != "https://gitpod.io/#snapshot/snap" {
+		t.Errorf("unexpected result: %v", last)
+	}
+
+	gitpodAPI.EXPECT().TakeSnapshot(gomock.Any(), gomock.Any()).Return("", &jsonrpc2.Error{Code: 501, Message: "enterprise feature"})
+	err = srv.TakeSnapshot(&api.TakeSnapshotRequest{}, &testSnapshotProgress{ctx: context.Background()})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected Unimplemented without snapshot support, got %v", err)
+	}
+}
+
+func TestWorkspaceServiceStop(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "workspace-stop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+	defer func(path string) { terminationLogPath = path }(terminationLogPath)
+	terminationLogPath = filepath.Join(tmpdir, "termination-log")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gitpodAPI := gitpod.NewMockAPIInterface(ctrl)
+	failed := make(chan struct{})
+	srv := &WorkspaceService{API: gitpodAPI, WorkspaceID: "ws", failed: failed}
+
+	gitpodAPI.EXPECT().StopWorkspace(gomock.Any(), "ws").Return(nil)
+	_, err = srv.Stop(context.Background(), &api.StopWorkspaceRequest{Message: "done"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = srv.Stop(context.Background(), &api.StopWorkspaceRequest{ExitCode: 256})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an exit code out of range, got %v", err)
+	}
+
+	_, err = srv.Stop(context.Background(), &api.StopWorkspaceRequest{ExitCode: 3, Message: "tests failed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-failed:
+	default:
+		t.Error("expected the workspace to fail")
+	}
+	msg, err := ioutil.ReadFile(terminationLogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(msg) != "workspace exited with code 3: tests failed" {
+		t.Errorf("unexpected termination message: %s", msg)
+	}
+
+	// stopping twice must not panic
+	_, err = srv.Stop(context.Background(), &api.StopWorkspaceRequest{ExitCode: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var envVarNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// defaults are the environment variables and working directory new terminals start with
+type defaults struct {
+	Env     map[string]string
+	Workdir string
+}
+
+func (d *defaults) toAPI() *api.TerminalDefaults {
+	res := &api.TerminalDefaults{Env: make(map[string]string, len(d.Env)), Workdir: d.Workdir}
+	for k, v := range d.Env {
+		res.Env[k] = v
+	}
+	return res
+}
+
+// defaultsRegistry keeps the global terminal defaults and those of tasks
+type defaultsRegistry struct {
+	mu     sync.RWMutex
+	global defaults
+	tasks  map[string]*defaults
+}
+
+// scope returns the defaults of a task, or the global ones if task is empty
+func (r *defaultsRegistry) scope(task string, create bool) *defaults {
+	if task == "" {
+		return &r.global
+	}
+	d, ok := r.tasks[task]
+	if !ok && create {
+		if r.tasks == nil {
+			r.tasks = make(map[string]*defaults)
+		}
+		d = &defaults{}
+		r.tasks[task] = d
+	}
+	return d
+}
+
+// environ returns the default environment of a terminal of a task in the form of os.Environ.
+// Task defaults are listed after the global ones, so that they take precedence.
+func (r *defaultsRegistry) environ(task string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := sortedEnv(r.global.Env)
+	if d := r.scope(task, false); task != "" && d != nil {
+		res = append(res, sortedEnv(d.Env)...)
+	}
+	return res
+}
+
+// workdir returns the default working directory of a terminal of a task, or an empty string if there is none
+func (r *defaultsRegistry) workdir(task string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if d := r.scope(task, false); task != "" && d != nil && d.Workdir != "" {
+		return d.Workdir
+	}
+	return r.global.Workdir
+}
+
+func sortedEnv(env map[string]string) []string {
+	res := make([]string, 0, len(env))
+	for k, v := range env {
+		res = append(res, k+"="+v)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// SetDefaults changes the defaults of terminals opened afterwards
+func (srv *MuxTerminalService) SetDefaults(ctx context.Context, req *api.SetTerminalDefaultsRequest) (*api.SetTerminalDefaultsResponse, error) {
+	for name := range req.Env {
+		if !envVarNamePattern.MatchString(name) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid variable name %q", name)
+		}
+	}
+	if req.Workdir != "" {
+		if !filepath.IsAbs(req.Workdir) {
+			return nil, status.Errorf(codes.InvalidArgument, "workdir %s is not an absolute path", req.Workdir)
+		}
+		stat, err := os.Stat(req.Workdir)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid workdir: %v", err)
+		}
+		if !stat.IsDir() {
+			return nil, status.Errorf(codes.InvalidArgument, "workdir %s is not a directory", req.Workdir)
+		}
+	}
+
+	srv.defaults.mu.Lock()
+	defer srv.defaults.mu.Unlock()
+
+	d := srv.defaults.scope(req.Task, true)
+	if req.Clear {
+		*d = defaults{}
+	}
+	if d.Env == nil {
+		d.Env = make(map[string]string)
+	}
+	for _, name := range req.UnsetEnv {
+		delete(d.Env, name)
+	}
+	for name, value := range req.Env {
+		d.Env[name] = value
+	}
+	if req.Workdir != "" {
+		d.Workdir = filepath.Clean(req.Workdir)
+	}
+	log.WithField("task", req.Task).WithField("workdir", d.Workdir).WithField("env", len(d.Env)).Debug("terminal defaults changed")
+
+	return &api.SetTerminalDefaultsResponse{Defaults: d.toAPI()}, nil
+}
+
+// GetDefaults returns the defaults of newly opened terminals
+func (srv *MuxTerminalService) GetDefaults(ctx context.Context, req *api.GetTerminalDefaultsRequest) (*api.GetTerminalDefaultsResponse, error) {
+	srv.defaults.mu.RLock()
+	defer srv.defaults.mu.RUnlock()
+
+	res := &api.GetTerminalDefaultsResponse{
+		Global: srv.defaults.global.toAPI(),
+		Tasks:  make(map[string]*api.TerminalDefaults, len(srv.defaults.tasks)),
+	}
+	for task, d := range srv.defaults.tasks {
+		res.Tasks[task] = d.toAPI()
+	}
+	return res, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDefaults(t *testing.T) {
+	globalDir, err := ioutil.TempDir("", "terminal-defaults")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(globalDir)
+	taskDir, err := ioutil.TempDir("", "terminal-defaults")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(taskDir)
+
+	srv := NewMuxTerminalService(NewMux())
+	srv.DefaultWorkdir = os.TempDir()
+	srv.LoginShell = []string{"/bin/sh"}
+
+	set := func(req *api.SetTerminalDefaultsRequest) {
+		if _, err := srv.SetDefaults(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	set(&api.SetTerminalDefaultsRequest{Env: map[string]string{"FOO": "global", "BAR": "global"}, Workdir: globalDir})
+	set(&api.SetTerminalDefaultsRequest{Task: "0", Env: map[string]string{"FOO": "task"}, Workdir: taskDir})
+	set(&api.SetTerminalDefaultsRequest{UnsetEnv: []string{"BAR"}})
+
+	tests := []struct {
+		Desc    string
+		Req     *api.OpenTerminalRequest
+		Workdir string
+		Env     []string
+	}{
+		{Desc: "global", Req: &api.OpenTerminalRequest{}, Workdir: globalDir, Env: []string{"FOO=global"}},
+		{Desc: "task", Req: &api.OpenTerminalRequest{Task: "0"}, Workdir: taskDir, Env: []string{"FOO=global", "FOO=task"}},
+		{Desc: "other task", Req: &api.OpenTerminalRequest{Task: "1"}, Workdir: globalDir, Env: []string{"FOO=global"}},
+		{
+			Desc:    "request overrides",
+			Req:     &api.OpenTerminalRequest{Task: "0", Workdir: os.TempDir(), Env: map[string]string{"FOO": "request"}},
+			Workdir: os.TempDir(),
+			Env:     []string{"FOO=global", "FOO=task", "FOO=request"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			resp, err := srv.Open(context.Background(), test.Req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer srv.Close(context.Background(), &api.CloseTerminalRequest{Alias: resp.Alias})
+			term, ok := srv.Mux.Get(resp.Alias)
+			if !ok {
+				t.Fatal("no terminal")
+			}
+
+			if term.Command.Dir != test.Workdir {
+				t.Errorf("unexpected workdir: %s, expected %s", term.Command.Dir, test.Workdir)
+			}
+			var env []string
+			for _, e := range term.Command.Env {
+				if len(e) > 4 && (e[:4] == "FOO=" || e[:4] == "BAR=") {
+					env = append(env, e)
+				}
+			}
+			if diff := cmp.Diff(test.Env, env); diff != "" {
+				t.Errorf("unexpected environment (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	set(&api.SetTerminalDefaultsRequest{Task: "0", Clear: true})
+	defaults, err := srv.GetDefaults(context.Background(), &api.GetTerminalDefaultsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectation := &api.GetTerminalDefaultsResponse{
+		Global: &api.TerminalDefaults{Env: map[string]string{"FOO": "global"}, Workdir: globalDir},
+		Tasks:  map[string]*api.TerminalDefaults{"0": {Env: map[string]string{}}},
+	}
+	if diff := cmp.Diff(expectation, defaults, cmp.Comparer(func(a, b *api.TerminalDefaults) bool {
+		return a.Workdir == b.Workdir && cmp.Equal(a.Env, b.Env)
+	})); diff != "" {
+		t.Errorf("unexpected defaults (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetDefaultsValidation(t *testing.T) {
+	tests := []struct {
+		Desc string
+		Req  *api.SetTerminalDefaultsRequest
+	}{
+		{Desc: "invalid variable name", Req: &api.SetTerminalDefaultsRequest{Env: map[string]string{"FOO-BAR": "baz"}}},
+		{Desc: "relative workdir", Req: &api.SetTerminalDefaultsRequest{Workdir: "foo"}},
+		{Desc: "missing workdir", Req: &api.SetTerminalDefaultsRequest{Workdir: "/does/not/exist"}},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			srv := NewMuxTerminalService(NewMux())
+			_, err := srv.SetDefaults(context.Background(), test.Req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
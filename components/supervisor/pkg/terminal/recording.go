@@ -0,0 +1,318 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// recordingExt is the file extension of terminal recordings
+	recordingExt = ".cast"
+
+	// defaultRecordingWidth and defaultRecordingHeight are the size recorded for terminals which no client has resized yet
+	defaultRecordingWidth  = 80
+	defaultRecordingHeight = 24
+)
+
+// NewRecordings creates a store of terminal recordings in a directory. Single recordings are truncated
+// beyond maxSize bytes, the oldest recordings are removed if all of them exceed maxTotalSize bytes.
+func NewRecordings(location string, maxSize, maxTotalSize int64) *Recordings {
+	return &Recordings{
+		Location:     location,
+		MaxSize:      maxSize,
+		MaxTotalSize: maxTotalSize,
+		active:       make(map[string]*castWriter),
+	}
+}
+
+// Recordings records terminal sessions in the asciicast v2 format, one file per terminal
+type Recordings struct {
+	Location     string
+	MaxSize      int64
+	MaxTotalSize int64
+
+	mu     sync.Mutex
+	active map[string]*castWriter
+}
+
+// Recording is a recorded terminal session
+type Recording struct {
+	Alias   string
+	Size    int64
+	Started time.Time
+	Active  bool
+}
+
+// List lists the recordings ordered by the time they started
+func (r *Recordings) List() ([]Recording, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.list()
+}
+
+func (r *Recordings) list() ([]Recording, error) {
+	files, err := filepath.Glob(filepath.Join(r.Location, "*"+recordingExt))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Recording, 0, len(files))
+	for _, fn := range files {
+		stat, err := os.Stat(fn)
+		if err != nil || stat.IsDir() {
+			continue
+		}
+		alias := strings.TrimSuffix(filepath.Base(fn), recordingExt)
+		_, active := r.active[alias]
+		res = append(res, Recording{
+			Alias:   alias,
+			Size:    stat.Size(),
+			Started: recordingStarted(fn, stat.ModTime()),
+			Active:  active,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Started.Before(res[j].Started) })
+	return res, nil
+}
+
+// Open opens a recording for reading
+func (r *Recordings) Open(alias string) (*os.File, error) {
+	if !validRecordingAlias(alias) {
+		return nil, xerrors.Errorf("invalid terminal alias: %s", alias)
+	}
+	return os.Open(filepath.Join(r.Location, alias+recordingExt))
+}
+
+// start starts recording a terminal. It removes the oldest finished recordings to make room for the new one.
+func (r *Recordings) start(alias string, cmd *exec.Cmd) (*castWriter, error) {
+	if !validRecordingAlias(alias) {
+		return nil, xerrors.Errorf("invalid terminal alias: %s", alias)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := os.MkdirAll(r.Location, 0755)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create recordings location: %w", err)
+	}
+	r.prune()
+
+	f, err := os.OpenFile(filepath.Join(r.Location, alias+recordingExt), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot create recording: %w", err)
+	}
+	w := &castWriter{
+		f:       f,
+		start:   time.Now(),
+		maxSize: r.MaxSize,
+		onClose: func() {
+			r.mu.Lock()
+			delete(r.active, alias)
+			r.mu.Unlock()
+		},
+	}
+	err = w.writeHeader(cmd)
+	if err != nil {
+		f.Close()
+		return nil, xerrors.Errorf("cannot write recording header: %w", err)
+	}
+	r.active[alias] = w
+	return w, nil
+}
+
+// prune removes the oldest finished recordings until a new recording of maximum size fits into the total size.
+// Callers are expected to hold mu.
+func (r *Recordings) prune() {
+	if r.MaxTotalSize <= 0 {
+		return
+	}
+	recordings, err := r.list()
+	if err != nil {
+		log.WithError(err).Warn("cannot list terminal recordings")
+		return
+	}
+	var total int64
+	for _, rec := range recordings {
+		total += rec.Size
+	}
+	for _, rec := range recordings {
+		if total+r.MaxSize <= r.MaxTotalSize {
+			return
+		}
+		if rec.Active {
+			continue
+		}
+		err := os.Remove(filepath.Join(r.Location, rec.Alias+recordingExt))
+		if err != nil {
+			log.WithError(err).WithField("alias", rec.Alias).Warn("cannot remove terminal recording")
+			continue
+		}
+		total -= rec.Size
+	}
+}
+
+// validRecordingAlias returns true if the alias cannot escape the recordings location
+func validRecordingAlias(alias string) bool {
+	return alias != "" && alias != "." && alias != ".." && filepath.Base(alias) == alias
+}
+
+// recordingStarted reads the time a recording started from its header, falling back to the given time
+func recordingStarted(fn string, fallback time.Time) time.Time {
+	f, err := os.Open(fn)
+	if err != nil {
+		return fallback
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil {
+		return fallback
+	}
+	var header castHeader
+	if json.Unmarshal(line, &header) != nil || header.Timestamp == 0 {
+		return fallback
+	}
+	return time.Unix(header.Timestamp, 0)
+}
+
+// castHeader is the header of an asciicast v2 file, see https://github.com/asciinema/asciinema/blob/develop/doc/asciicast-v2.md
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// castWriter writes the events of a terminal session to an asciicast v2 file
+type castWriter struct {
+	mu      sync.Mutex
+	f       *os.File
+	start   time.Time
+	size    int64
+	maxSize int64
+	full    bool
+	closed  bool
+	// pending is the start of a UTF-8 sequence which is continued by the next output
+	pending []byte
+	onClose func()
+}
+
+func (w *castWriter) writeHeader(cmd *exec.Cmd) error {
+	header := castHeader{
+		Version:   2,
+		Width:     defaultRecordingWidth,
+		Height:    defaultRecordingHeight,
+		Timestamp: w.start.Unix(),
+		Command:   strings.Join(cmd.Args, " "),
+		Env:       make(map[string]string),
+	}
+	for _, e := range cmd.Env {
+		if strings.HasPrefix(e, "TERM=") || strings.HasPrefix(e, "SHELL=") {
+			segs := strings.SplitN(e, "=", 2)
+			header.Env[segs[0]] = segs[1]
+		}
+	}
+	line, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	return w.writeLine(line)
+}
+
+// Output records output of the terminal
+func (w *castWriter) Output(p []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data := append(w.pending, p...)
+	data, w.pending = splitIncompleteUTF8(data)
+	if len(data) == 0 {
+		return
+	}
+	w.event("o", string(data))
+}
+
+// Resize records a change of the terminal's size
+func (w *castWriter) Resize(cols, rows uint32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.event("r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// event writes an event unless the recording is closed or full. The event which would exceed
+// the maximum size is replaced by a marker. Callers are expected to hold mu.
+func (w *castWriter) event(code, data string) {
+	if w.closed || w.full {
+		return
+	}
+	elapsed := float64(time.Since(w.start).Microseconds()) / float64(time.Second/time.Microsecond)
+	line, err := json.Marshal([]interface{}{elapsed, code, data})
+	if err != nil {
+		return
+	}
+	if w.maxSize > 0 && w.size+int64(len(line))+1 > w.maxSize {
+		w.full = true
+		line, _ = json.Marshal([]interface{}{elapsed, "m", "recording size limit reached"})
+	}
+	err = w.writeLine(line)
+	if err != nil {
+		log.WithError(err).Warn("cannot record terminal, stopping the recording")
+		w.full = true
+	}
+}
+
+func (w *castWriter) writeLine(line []byte) error {
+	n, err := w.f.Write(append(line, '\n'))
+	w.size += int64(n)
+	return err
+}
+
+// Close finishes the recording
+func (w *castWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.onClose != nil {
+		w.onClose()
+	}
+	return w.f.Close()
+}
+
+// splitIncompleteUTF8 splits an incomplete UTF-8 sequence off the end of p
+func splitIncompleteUTF8(p []byte) (complete, rest []byte) {
+	// UTF-8 sequences are at most utf8.UTFMax bytes long, hence only the last bytes can be incomplete
+	for i := 1; i < utf8.UTFMax && i <= len(p); i++ {
+		start := len(p) - i
+		if !utf8.RuneStart(p[start]) {
+			continue
+		}
+		if utf8.FullRune(p[start:]) {
+			return p, nil
+		}
+		return p[:start], append([]byte(nil), p[start:]...)
+	}
+	return p, nil
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2020 TypeFox GmbH. All rights reserved.
+// Licensed under the GNU Affero General Public License (AGPL).
+// See License-AGPL.txt in the project root for license information.
+
+package terminal
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplitIncompleteUTF8(t *testing.T) {
+	tests := []struct {
+		Desc     string
+		Input    []byte
+		Complete []byte
+		Rest     []byte
+	}{
+		{Desc: "empty"},
+		{Desc: "ascii", Input: []byte("abc"), Complete: []byte("abc")},
+		{Desc: "complete multi-byte", Input: []byte("a€"), Complete: []byte("a€")},
+		{Desc: "complete four-byte", Input: []byte("a😀"), Complete: []byte("a😀")},
+		{Desc: "incomplete multi-byte", Input: []byte("a€")[:3], Complete: []byte("a"), Rest: []byte("€")[:2]},
+		{Desc: "incomplete four-byte", Input: []byte("a😀")[:4], Complete: []byte("a"), Rest: []byte("😀")[:3]},
+	}
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			complete, rest := splitIncompleteUTF8(test.Input)
+			if diff := cmp.Diff(string(test.Complete), string(complete)); diff != "" {
+				t.Errorf("unexpected complete part (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(string(test.Rest), string(rest)); diff != "" {
+				t.Errorf("unexpected rest (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecording(t *testing.T) {
+	location, err := ioutil.TempDir("", "recordings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(location)
+
+	recordings := NewRecordings(location, 200, 1000)
+	cmd := exec.Command("/bin/sh")
+	cmd.Env = []string{"TERM=xterm-color"}
+	w, err := recordings.start("alias", cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Output([]byte("hello "))
+	// the euro sign is split across two outputs
+	w.Output([]byte("€")[:1])
+	w.Output([]byte("€")[1:])
+	w.Resize(120, 40)
+	w.Output([]byte(string(make([]byte, 200))))
+	w.Output([]byte("dropped"))
+
+	list, err := recordings.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Alias != "alias" || !list[0].Active {
+		t.Errorf("unexpected recordings: %v", list)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := recordings.Open("alias")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Scan()
+	var header castHeader
+	err = json.Unmarshal(scanner.Bytes(), &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Version != 2 || header.Command != "/bin/sh" || header.Env["TERM"] != "xterm-color" {
+		t.Errorf("unexpected header: %+v", header)
+	}
+	var events [][]string
+	for scanner.Scan() {
+		var event []interface{}
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, []string{event[1].(string), event[2].(string)})
+	}
+	expectation := [][]string{
+		{"o", "hello "},
+		{"o", "€"},
+		{"r", "120x40"},
+		{"m", "recording size limit reached"},
+	}
+	if diff := cmp.Diff(expectation, events); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+
+	list, err = recordings.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Active {
+		t.Errorf("expected the recording to be finished: %v", list)
+	}
+
+	_, err = recordings.Open("../alias")
+	if err == nil {
+		t.Error("expected an error opening a recording outside of the location")
+	}
+}
+
+func TestRecordingsPrune(t *testing.T) {
+	location, err := ioutil.TempDir("", "recordings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(location)
+
+	for _, alias := range []string{"old", "older"} {
+		err := ioutil.WriteFile(filepath.Join(location, alias+recordingExt), make([]byte, 300), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for alias, mtime := range map[string]time.Time{"older": time.Now().Add(-2 * time.Hour), "old": time.Now().Add(-1 * time.Hour)} {
+		err := os.Chtimes(filepath.Join(location, alias+recordingExt), mtime, mtime)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recordings := NewRecordings(location, 200, 600)
+	w, err := recordings.start("new", exec.Command("/bin/sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	list, err := recordings.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var aliases []string
+	for _, rec := range list {
+		aliases = append(aliases, rec.Alias)
+	}
+	if diff := cmp.Diff([]string{"old", "new"}, aliases); diff != "" {
+		t.Errorf("unexpected recordings (-want +got):\n%s", diff)
+	}
+}
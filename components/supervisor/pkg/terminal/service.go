@@ -5,14 +5,15 @@
 package terminal
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"os"
 	"os/exec"
 
 	"github.com/creack/pty"
-	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/gitpod-io/gitpod/supervisor/api"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -34,8 +35,17 @@ type MuxTerminalService struct {
 
 	DefaultWorkdir string
 	LoginShell     []string
+	// Env provides additional environment variables for new terminals if set
+	Env func() []string
+	// OnCommand is called whenever a terminal is opened or a line is entered in a terminal if set
+	OnCommand func()
+	// OnInput is called whenever input is written to a terminal if set
+	OnInput func()
+	// OnOpen is called with the result of opening a terminal if set
+	OnOpen func(err error)
 
-	tokens map[*Term]string
+	tokens   map[*Term]string
+	defaults defaultsRegistry
 }
 
 // RegisterGRPC registers a gRPC service
@@ -52,19 +62,33 @@ func (srv *MuxTerminalService) RegisterREST(mux *runtime.ServeMux, grpcEndpoint
 func (srv *MuxTerminalService) Open(ctx context.Context, req *api.OpenTerminalRequest) (*api.OpenTerminalResponse, error) {
 	cmd := exec.Command(srv.LoginShell[0], srv.LoginShell[1:]...)
 	cmd.Dir = srv.DefaultWorkdir
+	if wd := srv.defaults.workdir(req.Task); wd != "" {
+		cmd.Dir = wd
+	}
+	if req.Workdir != "" {
+		cmd.Dir = req.Workdir
+	}
 	cmd.Env = append(os.Environ(), "TERM=xterm-color")
+	if srv.Env != nil {
+		cmd.Env = append(cmd.Env, srv.Env()...)
+	}
+	cmd.Env = append(cmd.Env, srv.defaults.environ(req.Task)...)
 	for key, value := range req.Env {
 		cmd.Env = append(cmd.Env, key+"="+value)
 	}
 	alias, err := srv.Mux.Start(cmd)
+	if srv.OnOpen != nil {
+		srv.OnOpen(err)
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	srv.onCommand()
 
 	// starterToken is just relevant for the service, hence it's not exposed at the Start() call
 	var starterToken string
-	term := srv.Mux.terms[alias]
-	if term != nil {
+	term, ok := srv.Mux.Get(alias)
+	if ok {
 		starterToken = term.StarterToken
 	}
 
@@ -76,6 +100,9 @@ func (srv *MuxTerminalService) Open(ctx context.Context, req *api.OpenTerminalRe
 
 // Close closes a terminal for the given alias
 func (srv *MuxTerminalService) Close(ctx context.Context, req *api.CloseTerminalRequest) (*api.CloseTerminalResponse, error) {
+	if _, ok := srv.Mux.Get(req.Alias); !ok {
+		return nil, status.Error(codes.NotFound, "terminal not found")
+	}
 	err := srv.Mux.Close(req.Alias)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -90,9 +117,16 @@ func (srv *MuxTerminalService) List(ctx context.Context, req *api.ListTerminalsR
 
 	res := make([]*api.ListTerminalsResponse_Terminal, 0, len(srv.Mux.terms))
 	for alias, term := range srv.Mux.terms {
+		var pid int64
+		if term.Command.Process != nil {
+			pid = int64(term.Command.Process.Pid)
+		}
 		res = append(res, &api.ListTerminalsResponse_Terminal{
-			Alias:   alias,
-			Command: term.Command.Args,
+			Alias:     alias,
+			Command:   term.Command.Args,
+			Title:     term.Title,
+			Pid:       pid,
+			Listeners: uint32(term.Stdout.ListenerCount()),
 		})
 	}
 
@@ -101,7 +135,7 @@ func (srv *MuxTerminalService) List(ctx context.Context, req *api.ListTerminalsR
 	}, nil
 }
 
-// Listen listens to a terminal
+// Listen listens to a terminal. The terminal keeps running when the listener leaves.
 func (srv *MuxTerminalService) Listen(req *api.ListenTerminalRequest, resp api.TerminalService_ListenServer) error {
 	srv.Mux.mu.RLock()
 	term, ok := srv.Mux.terms[req.Alias]
@@ -110,6 +144,8 @@ func (srv *MuxTerminalService) Listen(req *api.ListenTerminalRequest, resp api.T
 		return status.Error(codes.NotFound, "terminal not found")
 	}
 	stdout := term.Stdout.Listen()
+	// detach from the terminal as soon as the client leaves, otherwise the terminal's output stalls until the listener times out
+	defer stdout.Close()
 
 	log.WithField("alias", req.Alias).Info("new terminal client")
 	defer log.WithField("alias", req.Alias).Info("terminal client left")
@@ -157,9 +193,21 @@ func (srv *MuxTerminalService) Write(ctx context.Context, req *api.WriteTerminal
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if srv.OnInput != nil && n > 0 {
+		srv.OnInput()
+	}
+	if bytes.ContainsAny(req.Stdin, "\r\n") {
+		srv.onCommand()
+	}
 	return &api.WriteTerminalResponse{BytesWritten: uint32(n)}, nil
 }
 
+func (srv *MuxTerminalService) onCommand() {
+	if srv.OnCommand != nil {
+		srv.OnCommand()
+	}
+}
+
 // SetSize sets the terminal's size
 func (srv *MuxTerminalService) SetSize(ctx context.Context, req *api.SetTerminalSizeRequest) (*api.SetTerminalSizeResponse, error) {
 	srv.Mux.mu.RLock()
@@ -185,5 +233,69 @@ func (srv *MuxTerminalService) SetSize(ctx context.Context, req *api.SetTerminal
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	if term.Stdout.recording != nil {
+		term.Stdout.recording.Resize(req.Cols, req.Rows)
+	}
+
 	return &api.SetTerminalSizeResponse{}, nil
 }
+
+// ListRecordings lists the recorded terminal sessions
+func (srv *MuxTerminalService) ListRecordings(ctx context.Context, req *api.ListTerminalRecordingsRequest) (*api.ListTerminalRecordingsResponse, error) {
+	if srv.Mux.Recordings == nil {
+		return nil, status.Error(codes.FailedPrecondition, "terminal recording is disabled")
+	}
+	recordings, err := srv.Mux.Recordings.List()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := make([]*api.ListTerminalRecordingsResponse_Recording, 0, len(recordings))
+	for _, rec := range recordings {
+		started, err := ptypes.TimestampProto(rec.Started)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		res = append(res, &api.ListTerminalRecordingsResponse_Recording{
+			Alias:   rec.Alias,
+			Size:    rec.Size,
+			Started: started,
+			Active:  rec.Active,
+		})
+	}
+	return &api.ListTerminalRecordingsResponse{
+		Recordings: res,
+	}, nil
+}
+
+// StreamRecording streams a recorded terminal session in the asciicast v2 format
+func (srv *MuxTerminalService) StreamRecording(req *api.StreamTerminalRecordingRequest, resp api.TerminalService_StreamRecordingServer) error {
+	if srv.Mux.Recordings == nil {
+		return status.Error(codes.FailedPrecondition, "terminal recording is disabled")
+	}
+	f, err := srv.Mux.Recordings.Open(req.Alias)
+	if os.IsNotExist(err) {
+		return status.Error(codes.NotFound, "recording not found")
+	}
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer f.Close()
+
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			sendErr := resp.Send(&api.StreamTerminalRecordingResponse{Data: buf[:n]})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
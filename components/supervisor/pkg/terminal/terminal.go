@@ -9,11 +9,12 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"sort"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/creack/pty"
-	"github.com/gitpod-io/gitpod/common-go/log"
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 )
@@ -25,12 +26,41 @@ func NewMux() *Mux {
 	}
 }
 
+// Keeper keeps pseudo-terminals open on behalf of a mux, s.t. the processes running in them survive
+// a restart of the process which runs the mux
+type Keeper interface {
+	Hold(alias string, pty *os.File) error
+	Release(alias string) error
+}
+
+// adoptedExitPollInterval is how often the process of an adopted terminal is checked for its exit
+const adoptedExitPollInterval = 1 * time.Second
+
 // Mux can mux pseudo-terminals
 type Mux struct {
+	// Recordings records the terminals if set
+	Recordings *Recordings
+	// Keeper holds the pseudo-terminals of started terminals if set
+	Keeper Keeper
+	// OnChange is called whenever a terminal was started, adopted or closed if set
+	OnChange func()
+
 	terms map[string]*Term
 	mu    sync.RWMutex
 }
 
+// Aliases returns the aliases of all terminals in order
+func (m *Mux) Aliases() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	res := make([]string, 0, len(m.terms))
+	for alias := range m.terms {
+		res = append(res, alias)
+	}
+	sort.Strings(res)
+	return res
+}
+
 // Get returns a terminal for the given alias
 func (m *Mux) Get(alias string) (*Term, bool) {
 	m.mu.RLock()
@@ -56,23 +86,90 @@ func (m *Mux) Start(cmd *exec.Cmd) (alias string, err error) {
 	}
 	alias = uid.String()
 
-	term, err := newTerm(pty, cmd)
+	var recording *castWriter
+	if m.Recordings != nil {
+		recording, err = m.Recordings.start(alias, cmd)
+		if err != nil {
+			log.WithError(err).WithField("alias", alias).Warn("cannot record terminal")
+		}
+	}
+
+	term, err := newTerm(pty, cmd, recording)
 	if err != nil {
 		pty.Close()
+		if recording != nil {
+			recording.Close()
+		}
 		return "", err
 	}
 	m.terms[alias] = term
+	if m.Keeper != nil {
+		err = m.Keeper.Hold(alias, pty)
+		if err != nil {
+			log.WithError(err).WithField("alias", alias).Warn("cannot keep terminal - it will not survive a supervisor restart")
+		}
+	}
 
 	log.WithField("alias", alias).WithField("cmd", cmd.Path).Info("started new terminal")
 
 	go func() {
-		cmd.Process.Wait()
+		state, err := cmd.Process.Wait()
+		term.exit(state, err)
 		m.Close(alias)
 	}()
+	m.changed()
 
 	return alias, nil
 }
 
+// Adopt takes over a terminal whose process was started by a previous supervisor process. As the process
+// is no child of ours, its exit is noticed by polling and its exit code is unknown.
+func (m *Mux) Adopt(alias string, pty *os.File, pid int, args []string, title string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.terms[alias]; exists {
+		return xerrors.Errorf("terminal %s exists already", alias)
+	}
+	if len(args) == 0 {
+		return xerrors.Errorf("terminal %s has no command", alias)
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return xerrors.Errorf("cannot find process of terminal %s: %w", alias, err)
+	}
+	cmd := &exec.Cmd{Path: args[0], Args: args, Process: proc}
+	term, err := newTerm(pty, cmd, nil)
+	if err != nil {
+		return err
+	}
+	term.Title = title
+	m.terms[alias] = term
+
+	log.WithField("alias", alias).WithField("pid", pid).Info("adopted terminal")
+
+	go func() {
+		t := time.NewTicker(adoptedExitPollInterval)
+		defer t.Stop()
+		for range t.C {
+			if err := syscall.Kill(pid, 0); err == syscall.ESRCH {
+				break
+			}
+		}
+		term.exit(nil, nil)
+		m.Close(alias)
+	}()
+	m.changed()
+
+	return nil
+}
+
+func (m *Mux) changed() {
+	if m.OnChange != nil {
+		go m.OnChange()
+	}
+}
+
 // Close closes a terminal and ends the process that runs in it
 func (m *Mux) Close(alias string) error {
 	m.mu.Lock()
@@ -85,7 +182,9 @@ func (m *Mux) Close(alias string) error {
 
 	log := log.WithField("alias", alias)
 	log.Info("closing terminal")
-	if term.Command.ProcessState == nil || !term.Command.ProcessState.Exited() {
+	select {
+	case <-term.exited:
+	default:
 		log.WithField("cmd", term.Command.Args).Debug("killing process")
 		term.Command.Process.Kill()
 	}
@@ -93,11 +192,24 @@ func (m *Mux) Close(alias string) error {
 	if err != nil {
 		log.WithError(err).Warn("cannot close connection to terminal clients")
 	}
+	if term.Stdout.recording != nil {
+		err = term.Stdout.recording.Close()
+		if err != nil {
+			log.WithError(err).Warn("cannot close terminal recording")
+		}
+	}
 	err = term.PTY.Close()
 	if err != nil {
 		log.WithError(err).Warn("cannot close pseudo-terminal")
 	}
+	if m.Keeper != nil {
+		err = m.Keeper.Release(alias)
+		if err != nil {
+			log.WithError(err).Warn("cannot release kept pseudo-terminal")
+		}
+	}
 	delete(m.terms, alias)
+	m.changed()
 
 	return nil
 }
@@ -107,7 +219,7 @@ func (m *Mux) Close(alias string) error {
 // For now we assume an average of five terminals per workspace, which makes this consume 1MiB of RAM.
 const terminalBacklogSize = 256 << 10
 
-func newTerm(pty *os.File, cmd *exec.Cmd) (*Term, error) {
+func newTerm(pty *os.File, cmd *exec.Cmd, recording *castWriter) (*Term, error) {
 	token, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -122,13 +234,20 @@ func newTerm(pty *os.File, cmd *exec.Cmd) (*Term, error) {
 		PTY:     pty,
 		Command: cmd,
 		Stdout: &multiWriter{
-			listener: make(map[*multiWriterListener]struct{}),
-			recorder: recorder,
+			listener:  make(map[*multiWriterListener]struct{}),
+			recorder:  recorder,
+			recording: recording,
 		},
 
 		StarterToken: token.String(),
+
+		exited:   make(chan struct{}),
+		exitCode: -1,
 	}
-	go io.Copy(res.Stdout, pty)
+	go func() {
+		_, _ = io.Copy(res.Stdout, pty)
+		res.Stdout.endOutput()
+	}()
 	return res, nil
 }
 
@@ -140,6 +259,38 @@ type Term struct {
 	StarterToken string
 
 	Stdout *multiWriter
+
+	exited   chan struct{}
+	exitCode int
+}
+
+// Exited returns a channel which is closed once the terminal's process has exited
+func (term *Term) Exited() <-chan struct{} {
+	return term.exited
+}
+
+// ExitCode returns the exit code of the terminal's process once it has exited. It is -1 if the process
+// has not exited yet or its exit code is unknown, e.g. because it was killed by a signal.
+func (term *Term) ExitCode() int {
+	select {
+	case <-term.exited:
+		return term.exitCode
+	default:
+		return -1
+	}
+}
+
+func (term *Term) exit(state *os.ProcessState, err error) {
+	switch {
+	case err != nil:
+		// another waiter, e.g. a child reaper, may have collected the exit status before us
+		log.WithError(err).WithField("cmd", term.Command.Args).Debug("cannot wait for terminal process")
+	case state != nil:
+		term.exitCode = state.ExitCode()
+	default:
+		// the process of an adopted terminal is no child of ours, hence its exit code is unknown
+	}
+	close(term.exited)
 }
 
 // multiWriter is like io.MultiWriter, except that we can listener at runtime.
@@ -150,6 +301,12 @@ type multiWriter struct {
 	// ring buffer to record last 256kb of pty output
 	// new listener is initialized with the latest recodring first
 	recorder *RingBuffer
+	// recording records the output to disk if set
+	recording *castWriter
+	// sinks receive the output in addition to the listeners until the output ends
+	sinks []io.WriteCloser
+	// ended is true once the output of the terminal has ended
+	ended bool
 }
 
 type multiWriterListener struct {
@@ -190,10 +347,10 @@ func (mw *multiWriter) Listen() *multiWriterListener {
 		closeChan: closeChan,
 	}
 
+	// the recording is taken while holding the lock s.t. the listener neither misses nor repeats output
+	// written in the meantime, and such writes don't block until the listener starts receiving.
+	recording := mw.recorder.Bytes()
 	go func() {
-		mw.mu.RLock()
-		recording := mw.recorder.Bytes()
-		mw.mu.RUnlock()
 		w.Write(recording)
 
 		// copy bytes from channel to writer.
@@ -206,7 +363,12 @@ func (mw *multiWriter) Listen() *multiWriterListener {
 				err = io.ErrShortWrite
 			}
 			if err != nil {
-				log.WithError(err).Error("terminal listener droped out")
+				select {
+				case <-closeChan:
+					// the listener left on its own
+				default:
+					log.WithError(err).Error("terminal listener droped out")
+				}
 				res.Close()
 			}
 		}
@@ -214,12 +376,14 @@ func (mw *multiWriter) Listen() *multiWriterListener {
 	go func() {
 		// listener cleanup on close
 		<-closeChan
+		// closing the writer first unblocks a pending write, s.t. the multi-writer is not blocked while we wait for the lock
 		w.Close()
-		close(cchan)
 
+		// Write sends to listeners only while holding the lock, hence cchan can be closed once the listener is gone
 		mw.mu.Lock()
 		delete(mw.listener, res)
 		mw.mu.Unlock()
+		close(cchan)
 	}()
 
 	mw.listener[res] = struct{}{}
@@ -232,6 +396,19 @@ func (mw *multiWriter) Write(p []byte) (n int, err error) {
 	defer mw.mu.Unlock()
 
 	mw.recorder.Write(p)
+	if mw.recording != nil {
+		mw.recording.Output(p)
+	}
+	for i := 0; i < len(mw.sinks); i++ {
+		_, err := mw.sinks[i].Write(p)
+		if err == nil {
+			continue
+		}
+		log.WithError(err).Warn("cannot write terminal output to sink")
+		mw.sinks[i].Close()
+		mw.sinks = append(mw.sinks[:i], mw.sinks[i+1:]...)
+		i--
+	}
 
 	for lstr := range mw.listener {
 		if lstr.closed {
@@ -253,7 +430,37 @@ func (mw *multiWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// Tee writes the output to w in addition to the listeners, starting with the output written next.
+// w is closed once the output ends, or right away if it has ended already.
+func (mw *multiWriter) Tee(w io.WriteCloser) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	if mw.ended {
+		w.Close()
+		return
+	}
+	mw.sinks = append(mw.sinks, w)
+}
+
+// endOutput closes the sinks once no more output is written
+func (mw *multiWriter) endOutput() {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	mw.ended = true
+	for _, w := range mw.sinks {
+		err := w.Close()
+		if err != nil {
+			log.WithError(err).Warn("cannot close terminal output sink")
+		}
+	}
+	mw.sinks = nil
+}
+
 func (mw *multiWriter) Close() error {
+	mw.endOutput()
+
 	mw.mu.Lock()
 	defer mw.mu.Unlock()
 
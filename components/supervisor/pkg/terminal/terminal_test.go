@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -67,3 +68,82 @@ func TestTerminals(t *testing.T) {
 		})
 	}
 }
+
+func TestReattach(t *testing.T) {
+	terminalService := NewMuxTerminalService(NewMux())
+	terminalService.DefaultWorkdir = os.TempDir()
+	terminalService.LoginShell = []string{"/bin/sh"}
+	resp, err := terminalService.Open(context.Background(), &api.OpenTerminalRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer terminalService.Close(context.Background(), &api.CloseTerminalRequest{Alias: resp.Alias})
+	terminal, ok := terminalService.Mux.Get(resp.Alias)
+	if !ok {
+		t.Fatal("no terminal")
+	}
+
+	listeners := func() uint32 {
+		list, err := terminalService.List(context.Background(), &api.ListTerminalsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.Terminals) != 1 {
+			t.Fatalf("unexpected terminals: %v", list.Terminals)
+		}
+		if list.Terminals[0].Pid != int64(terminal.Command.Process.Pid) {
+			t.Errorf("unexpected pid: %d", list.Terminals[0].Pid)
+		}
+		return list.Terminals[0].Listeners
+	}
+	awaitOutput := func(r io.Reader, marker string) string {
+		var (
+			output = make(chan string, 1)
+			buf    bytes.Buffer
+		)
+		go func() {
+			b := make([]byte, 4096)
+			for {
+				n, err := r.Read(b)
+				buf.Write(b[:n])
+				if strings.Contains(buf.String(), marker) || err != nil {
+					output <- buf.String()
+					return
+				}
+			}
+		}()
+		select {
+		case out := <-output:
+			return out
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", marker)
+			return ""
+		}
+	}
+
+	first := terminal.Stdout.Listen()
+	if n := listeners(); n != 1 {
+		t.Errorf("unexpected listeners: %d", n)
+	}
+	terminal.PTY.Write([]byte("echo before-$((20+1))\n"))
+	awaitOutput(first, "before-21")
+	first.Close()
+
+	// the shell keeps running without listeners
+	deadline := time.Now().Add(5 * time.Second)
+	for listeners() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("listener did not detach")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	terminal.PTY.Write([]byte("echo after-$((20+1))\n"))
+
+	// a new listener receives the scrollback, including the output while nobody was listening
+	second := terminal.Stdout.Listen()
+	defer second.Close()
+	out := awaitOutput(second, "after-21")
+	if !strings.Contains(out, "before-21") {
+		t.Errorf("expected scrollback to contain the output before the reattach: %q", out)
+	}
+}
@@ -395,6 +395,68 @@ func (m *TeardownResponse) GetSuccess() bool {
 	return false
 }
 
+type BackupRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{8}
+}
+
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupRequest.Size(m)
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+type BackupResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupResponse) Reset()         { *m = BackupResponse{} }
+func (m *BackupResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupResponse) ProtoMessage()    {}
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dac718ecaafc2333, []int{9}
+}
+
+func (m *BackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupResponse.Unmarshal(m, b)
+}
+func (m *BackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupResponse.Merge(m, src)
+}
+func (m *BackupResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupResponse.Size(m)
+}
+func (m *BackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*PrepareForUserNSRequest)(nil), "iws.PrepareForUserNSRequest")
 	proto.RegisterType((*PrepareForUserNSResponse)(nil), "iws.PrepareForUserNSResponse")
@@ -405,6 +467,8 @@ func init() {
 	proto.RegisterType((*MountProcResponse)(nil), "iws.MountProcResponse")
 	proto.RegisterType((*TeardownRequest)(nil), "iws.TeardownRequest")
 	proto.RegisterType((*TeardownResponse)(nil), "iws.TeardownResponse")
+	proto.RegisterType((*BackupRequest)(nil), "iws.BackupRequest")
+	proto.RegisterType((*BackupResponse)(nil), "iws.BackupResponse")
 }
 
 func init() {
@@ -412,35 +476,37 @@ func init() {
 }
 
 var fileDescriptor_dac718ecaafc2333 = []byte{
-	// 447 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0xd1, 0x6e, 0xd3, 0x30,
-	0x14, 0xa5, 0x0b, 0x6a, 0xd7, 0x3b, 0xca, 0x3a, 0x43, 0xbb, 0x10, 0x98, 0x54, 0xf2, 0x54, 0x04,
-	0x4b, 0xa5, 0xf1, 0x84, 0xd4, 0x27, 0x40, 0x48, 0x15, 0x1a, 0xda, 0x32, 0xd0, 0x04, 0x2f, 0x95,
-	0x17, 0x5f, 0x65, 0x16, 0x6a, 0xae, 0xb1, 0x5d, 0x22, 0xf1, 0x4f, 0xfc, 0x0d, 0x1f, 0x84, 0x6a,
-	0xec, 0x0c, 0xc2, 0xfa, 0x76, 0xef, 0xb9, 0x3e, 0x27, 0xbe, 0xe7, 0x38, 0xb0, 0x5f, 0x93, 0xfe,
-	0x6a, 0x14, 0x2f, 0x30, 0x53, 0x9a, 0x2c, 0xb1, 0x48, 0xd6, 0x26, 0x7d, 0x04, 0x87, 0x67, 0x1a,
-	0x15, 0xd7, 0xf8, 0x8e, 0xf4, 0x27, 0x83, 0xfa, 0xc3, 0x45, 0x8e, 0xdf, 0xd6, 0x68, 0x6c, 0x9a,
-	0x40, 0xfc, 0xff, 0xc8, 0x28, 0xaa, 0x0c, 0xa6, 0xe7, 0x30, 0xbe, 0xd4, 0xd2, 0xe2, 0xe2, 0xed,
-	0x29, 0x57, 0x4a, 0x56, 0x65, 0x98, 0xb0, 0x18, 0x7a, 0x2b, 0x34, 0x86, 0x97, 0x18, 0x77, 0x26,
-	0x9d, 0x69, 0x3f, 0x0f, 0x2d, 0x3b, 0x02, 0x40, 0xad, 0x49, 0x2f, 0x0b, 0x12, 0x18, 0xef, 0x4c,
-	0x3a, 0xd3, 0x41, 0xde, 0x77, 0xc8, 0x1b, 0x12, 0x98, 0xfe, 0xea, 0xc0, 0xa8, 0xad, 0xe9, 0x2e,
-	0xc2, 0x86, 0x10, 0x29, 0x29, 0x9c, 0x5c, 0x94, 0x6f, 0xca, 0x0d, 0x52, 0x4a, 0xe1, 0x34, 0x76,
-	0xf3, 0x4d, 0xc9, 0xe6, 0xd0, 0x5b, 0xfd, 0x61, 0xc5, 0xd1, 0x24, 0x9a, 0xee, 0x9d, 0xa4, 0x99,
-	0xac, 0x4d, 0x76, 0xab, 0x60, 0x16, 0xda, 0x40, 0x49, 0x3e, 0x43, 0xcf, 0x63, 0xec, 0x29, 0xdc,
-	0x2b, 0xa8, 0xb2, 0x5c, 0x56, 0xa8, 0x97, 0xfe, 0xab, 0x83, 0x7c, 0xaf, 0xc1, 0x16, 0x82, 0x1d,
-	0x42, 0xef, 0x9a, 0x8c, 0x5d, 0xfa, 0x1b, 0x0c, 0xf2, 0xee, 0xa6, 0x5d, 0x08, 0xc6, 0xe0, 0xae,
-	0x91, 0x3f, 0x30, 0x8e, 0x1c, 0xea, 0xea, 0x74, 0x0e, 0xc3, 0x53, 0x5a, 0x57, 0xf6, 0x4c, 0x53,
-	0x11, 0x16, 0x1a, 0x43, 0xd7, 0x72, 0x5d, 0xa2, 0xf5, 0x16, 0xf9, 0x2e, 0x2c, 0xba, 0xd3, 0x2c,
-	0x9a, 0x3e, 0x80, 0x83, 0xbf, 0xd8, 0xde, 0xfc, 0x03, 0xd8, 0xff, 0x88, 0x5c, 0x0b, 0xaa, 0xab,
-	0x90, 0xd5, 0x0b, 0x18, 0xde, 0x40, 0x37, 0x49, 0x98, 0x75, 0x51, 0xa0, 0x31, 0xde, 0xa8, 0xd0,
-	0x9e, 0xfc, 0xdc, 0x01, 0xb6, 0xa8, 0x2e, 0xc3, 0x7b, 0xb8, 0x40, 0xfd, 0x5d, 0x16, 0xc8, 0xce,
-	0x61, 0xd8, 0x0e, 0x9c, 0x3d, 0x71, 0x36, 0x6e, 0x79, 0x22, 0xc9, 0xd1, 0x96, 0xa9, 0xbf, 0xe8,
-	0x1d, 0xf6, 0x1e, 0xee, 0xff, 0x1b, 0x01, 0x4b, 0xb6, 0xe7, 0x92, 0x3c, 0xbe, 0x75, 0xd6, 0x88,
-	0xcd, 0xa1, 0xdf, 0x98, 0xc1, 0x46, 0xee, 0x6c, 0xdb, 0xda, 0x64, 0xdc, 0x86, 0x1b, 0xf6, 0x2b,
-	0xd8, 0x0d, 0x16, 0xb1, 0x87, 0xee, 0x54, 0xcb, 0xc4, 0x64, 0xd4, 0x42, 0x03, 0xf5, 0xf5, 0xf3,
-	0x2f, 0xcf, 0x4a, 0x69, 0xaf, 0xd7, 0x57, 0x59, 0x41, 0xab, 0x59, 0x29, 0xad, 0x22, 0x71, 0x2c,
-	0xc9, 0x57, 0xb3, 0xda, 0x1c, 0x0b, 0x8e, 0x2b, 0xaa, 0x66, 0x5c, 0xc9, 0xab, 0xae, 0xfb, 0xbb,
-	0x5e, 0xfe, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x0d, 0x70, 0x6c, 0x8d, 0x70, 0x03, 0x00, 0x00,
+	// 475 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x51, 0x6f, 0xd3, 0x30,
+	0x10, 0xa6, 0x0d, 0x6a, 0xd7, 0x1b, 0x5d, 0x33, 0x8f, 0x76, 0x21, 0x30, 0xa9, 0xe4, 0xa9, 0x08,
+	0x96, 0x4a, 0xdb, 0x13, 0x52, 0x9f, 0x06, 0x42, 0xaa, 0xd0, 0xd0, 0x96, 0x81, 0x26, 0x78, 0xa9,
+	0xbc, 0xe4, 0x94, 0x59, 0x53, 0x63, 0x63, 0x3b, 0x44, 0xe2, 0x77, 0xf2, 0x0b, 0xf8, 0x25, 0xa8,
+	0xae, 0x9d, 0xd1, 0x6c, 0x7d, 0xbb, 0xfb, 0xee, 0xbe, 0x2f, 0xbe, 0xef, 0x2e, 0x30, 0xa8, 0xb8,
+	0xbc, 0x53, 0x82, 0xa6, 0x18, 0x0b, 0xc9, 0x35, 0x27, 0x1e, 0xab, 0x54, 0xf4, 0x02, 0x0e, 0x2f,
+	0x24, 0x0a, 0x2a, 0xf1, 0x13, 0x97, 0xdf, 0x14, 0xca, 0x2f, 0x57, 0x09, 0xfe, 0x2c, 0x51, 0xe9,
+	0x28, 0x84, 0xe0, 0x61, 0x49, 0x09, 0x5e, 0x28, 0x8c, 0x2e, 0x61, 0x74, 0x2d, 0x99, 0xc6, 0xf9,
+	0xc7, 0x73, 0x2a, 0x04, 0x2b, 0x72, 0x57, 0x21, 0x01, 0x74, 0x97, 0xa8, 0x14, 0xcd, 0x31, 0x68,
+	0x8d, 0x5b, 0x93, 0x5e, 0xe2, 0x52, 0x72, 0x04, 0x80, 0x52, 0x72, 0xb9, 0x48, 0x79, 0x86, 0x41,
+	0x7b, 0xdc, 0x9a, 0xf4, 0x93, 0x9e, 0x41, 0x3e, 0xf0, 0x0c, 0xa3, 0x3f, 0x2d, 0x18, 0x36, 0x35,
+	0xcd, 0x43, 0x88, 0x0f, 0x9e, 0x60, 0x99, 0x91, 0xf3, 0x92, 0x55, 0xb8, 0x42, 0x72, 0x96, 0x19,
+	0x8d, 0x9d, 0x64, 0x15, 0x92, 0x19, 0x74, 0x97, 0x6b, 0x56, 0xe0, 0x8d, 0xbd, 0xc9, 0xee, 0x49,
+	0x14, 0xb3, 0x4a, 0xc5, 0x8f, 0x0a, 0xc6, 0x2e, 0x75, 0x94, 0xf0, 0x3b, 0x74, 0x2d, 0x46, 0x5e,
+	0xc3, 0xb3, 0x94, 0x17, 0x9a, 0xb2, 0x02, 0xe5, 0xc2, 0x7e, 0xb5, 0x9f, 0xec, 0xd6, 0xd8, 0x3c,
+	0x23, 0x87, 0xd0, 0xbd, 0xe5, 0x4a, 0x2f, 0xec, 0x0b, 0xfa, 0x49, 0x67, 0x95, 0xce, 0x33, 0x42,
+	0xe0, 0xa9, 0x62, 0xbf, 0x31, 0xf0, 0x0c, 0x6a, 0xe2, 0x68, 0x06, 0xfe, 0x39, 0x2f, 0x0b, 0x7d,
+	0x21, 0x79, 0xea, 0x06, 0x1a, 0x41, 0x47, 0x53, 0x99, 0xa3, 0xb6, 0x16, 0xd9, 0xcc, 0x0d, 0xda,
+	0xae, 0x07, 0x8d, 0x0e, 0x60, 0xff, 0x3f, 0xb6, 0x35, 0x7f, 0x1f, 0x06, 0x5f, 0x91, 0xca, 0x8c,
+	0x57, 0x85, 0xdb, 0xd5, 0x3b, 0xf0, 0xef, 0xa1, 0xfb, 0x4d, 0xa8, 0x32, 0x4d, 0x51, 0x29, 0x6b,
+	0x94, 0x4b, 0xa3, 0x01, 0xf4, 0xcf, 0x68, 0x7a, 0x57, 0x0a, 0x47, 0xf7, 0x61, 0xcf, 0x01, 0x6b,
+	0xf2, 0xc9, 0xdf, 0x36, 0x90, 0x79, 0x71, 0xed, 0x4e, 0xe6, 0x0a, 0xe5, 0x2f, 0x96, 0x22, 0xb9,
+	0x04, 0xbf, 0x79, 0x13, 0xe4, 0x95, 0x71, 0x7a, 0xcb, 0x15, 0x85, 0x47, 0x5b, 0xaa, 0x76, 0x96,
+	0x27, 0xe4, 0x33, 0xec, 0x6d, 0x6e, 0x89, 0x84, 0xdb, 0x57, 0x17, 0xbe, 0x7c, 0xb4, 0x56, 0x8b,
+	0xcd, 0xa0, 0x57, 0xfb, 0x45, 0x86, 0xa6, 0xb7, 0xe9, 0x7e, 0x38, 0x6a, 0xc2, 0x35, 0xfb, 0x3d,
+	0xec, 0x38, 0x17, 0xc9, 0x73, 0xd3, 0xd5, 0xf0, 0x39, 0x1c, 0x36, 0xd0, 0x9a, 0x7a, 0x0a, 0x9d,
+	0xb5, 0x83, 0x84, 0x98, 0x96, 0x0d, 0x7f, 0xc3, 0x83, 0x0d, 0xcc, 0x91, 0xce, 0xde, 0xfe, 0x78,
+	0x93, 0x33, 0x7d, 0x5b, 0xde, 0xc4, 0x29, 0x5f, 0x4e, 0x73, 0xa6, 0x05, 0xcf, 0x8e, 0x19, 0xb7,
+	0xd1, 0xb4, 0x52, 0xc7, 0x19, 0xc5, 0x25, 0x2f, 0xa6, 0x54, 0xb0, 0x9b, 0x8e, 0xf9, 0x6b, 0x4f,
+	0xff, 0x0d, 0x00, 0x4a, 0xfc, 0x93, 0x35, 0xc8, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -471,6 +537,9 @@ type InWorkspaceServiceClient interface {
 	// Teardown prepares workspace content backups and unmounts shiftfs mounts. The canary is supposed to be triggered
 	// when the workspace is about to shut down, e.g. using the PreStop hook of a Kubernetes container.
 	Teardown(ctx context.Context, in *TeardownRequest, opts ...grpc.CallOption) (*TeardownResponse, error)
+	// Backup takes a live backup of the workspace content right away, e.g. before the user attempts a risky operation.
+	// The call returns once the backup is complete. Only workspaces with full workspace backup support it.
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
 }
 
 type inWorkspaceServiceClient struct {
@@ -517,6 +586,15 @@ func (c *inWorkspaceServiceClient) Teardown(ctx context.Context, in *TeardownReq
 	return out, nil
 }
 
+func (c *inWorkspaceServiceClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, "/iws.InWorkspaceService/Backup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InWorkspaceServiceServer is the server API for InWorkspaceService service.
 type InWorkspaceServiceServer interface {
 	// PrepareForUserNS prepares a workspace container for wrapping it in a user namespace.
@@ -535,6 +613,9 @@ type InWorkspaceServiceServer interface {
 	// Teardown prepares workspace content backups and unmounts shiftfs mounts. The canary is supposed to be triggered
 	// when the workspace is about to shut down, e.g. using the PreStop hook of a Kubernetes container.
 	Teardown(context.Context, *TeardownRequest) (*TeardownResponse, error)
+	// Backup takes a live backup of the workspace content right away, e.g. before the user attempts a risky operation.
+	// The call returns once the backup is complete. Only workspaces with full workspace backup support it.
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
 }
 
 // UnimplementedInWorkspaceServiceServer can be embedded to have forward compatible implementations.
@@ -553,6 +634,9 @@ func (*UnimplementedInWorkspaceServiceServer) MountProc(ctx context.Context, req
 func (*UnimplementedInWorkspaceServiceServer) Teardown(ctx context.Context, req *TeardownRequest) (*TeardownResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Teardown not implemented")
 }
+func (*UnimplementedInWorkspaceServiceServer) Backup(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
 
 func RegisterInWorkspaceServiceServer(s *grpc.Server, srv InWorkspaceServiceServer) {
 	s.RegisterService(&_InWorkspaceService_serviceDesc, srv)
@@ -630,6 +714,24 @@ func _InWorkspaceService_Teardown_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InWorkspaceService_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InWorkspaceServiceServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iws.InWorkspaceService/Backup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InWorkspaceServiceServer).Backup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _InWorkspaceService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "iws.InWorkspaceService",
 	HandlerType: (*InWorkspaceServiceServer)(nil),
@@ -650,6 +752,10 @@ var _InWorkspaceService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Teardown",
 			Handler:    _InWorkspaceService_Teardown_Handler,
 		},
+		{
+			MethodName: "Backup",
+			Handler:    _InWorkspaceService_Backup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "workspace.proto",
@@ -155,6 +155,9 @@ func (wbs *InWorkspaceServiceServer) Start() error {
 		"/iws.InWorkspaceService/Teardown": ratelimit{
 			UseOnce: true,
 		},
+		"/iws.InWorkspaceService/Backup": ratelimit{
+			Limiter: rate.NewLimiter(rate.Every(time.Minute), 2),
+		},
 	}
 
 	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(limits.UnaryInterceptor()))
@@ -376,6 +379,21 @@ func (wbs *InWorkspaceServiceServer) Teardown(ctx context.Context, req *api.Tear
 	return &api.TeardownResponse{Success: success}, nil
 }
 
+// Backup takes a live backup on request of the user
+func (wbs *InWorkspaceServiceServer) Backup(ctx context.Context, req *api.BackupRequest) (*api.BackupResponse, error) {
+	if !wbs.Session.FullWorkspaceBackup {
+		return nil, status.Error(codes.FailedPrecondition, "not supported for this workspace")
+	}
+
+	err := wbs.performLiveBackup()
+	if err != nil {
+		log.WithError(err).WithFields(wbs.Session.OWI()).Error("cannot take live backup on request")
+		return nil, status.Error(codes.Internal, "cannot take backup")
+	}
+	log.WithFields(wbs.Session.OWI()).Info("took live backup on request")
+	return &api.BackupResponse{}, nil
+}
+
 func (wbs *InWorkspaceServiceServer) performLiveBackup() error {
 	if !wbs.Session.FullWorkspaceBackup {
 		return nil
@@ -111,6 +111,38 @@ func (PortVisibility) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_f7e43720d1edc0fe, []int{2}
 }
 
+// PortInsecureRequests defines how the proxy handles plain HTTP requests to a workspace port
+type PortInsecureRequests int32
+
+const (
+	// allow (default) passes plain HTTP requests on to the port
+	PortInsecureRequests_PORT_INSECURE_REQUESTS_ALLOW PortInsecureRequests = 0
+	// redirect redirects plain HTTP requests to HTTPS
+	PortInsecureRequests_PORT_INSECURE_REQUESTS_REDIRECT PortInsecureRequests = 1
+	// reject rejects plain HTTP requests
+	PortInsecureRequests_PORT_INSECURE_REQUESTS_REJECT PortInsecureRequests = 2
+)
+
+var PortInsecureRequests_name = map[int32]string{
+	0: "PORT_INSECURE_REQUESTS_ALLOW",
+	1: "PORT_INSECURE_REQUESTS_REDIRECT",
+	2: "PORT_INSECURE_REQUESTS_REJECT",
+}
+
+var PortInsecureRequests_value = map[string]int32{
+	"PORT_INSECURE_REQUESTS_ALLOW":    0,
+	"PORT_INSECURE_REQUESTS_REDIRECT": 1,
+	"PORT_INSECURE_REQUESTS_REJECT":   2,
+}
+
+func (x PortInsecureRequests) String() string {
+	return proto.EnumName(PortInsecureRequests_name, int32(x))
+}
+
+func (PortInsecureRequests) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f7e43720d1edc0fe, []int{3}
+}
+
 // WorkspaceConditionBool is a trinary bool: true/false/empty
 type WorkspaceConditionBool int32
 
@@ -137,7 +169,7 @@ func (x WorkspaceConditionBool) String() string {
 }
 
 func (WorkspaceConditionBool) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{3}
+	return fileDescriptor_f7e43720d1edc0fe, []int{4}
 }
 
 // WorkspacePhase is a simple, high-level summary of where the workspace is in its lifecycle.
@@ -200,7 +232,7 @@ func (x WorkspacePhase) String() string {
 }
 
 func (WorkspacePhase) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{4}
+	return fileDescriptor_f7e43720d1edc0fe, []int{5}
 }
 
 // WorkspaceFeatureFlag enable non-standard behaviour in workspaces
@@ -254,7 +286,7 @@ func (x WorkspaceFeatureFlag) String() string {
 }
 
 func (WorkspaceFeatureFlag) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{5}
+	return fileDescriptor_f7e43720d1edc0fe, []int{6}
 }
 
 // WorkspaceType specifies the purpose/use of a workspace. Different workspace types are handled differently by all parts of the system.
@@ -287,7 +319,7 @@ func (x WorkspaceType) String() string {
 }
 
 func (WorkspaceType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{6}
+	return fileDescriptor_f7e43720d1edc0fe, []int{7}
 }
 
 // GetWorkspacesRequest requests a list of running workspaces
@@ -1413,10 +1445,14 @@ type PortSpec struct {
 	// visibility defines the visibility of the port
 	Visibility PortVisibility `protobuf:"varint,3,opt,name=visibility,proto3,enum=wsman.PortVisibility" json:"visibility,omitempty"`
 	// url is the public-facing URL this port is available at
-	Url                  string   `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Url string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	// cors is the CORS policy the proxy applies to requests to this port. If not set, CORS requests are passed on to the port.
+	Cors *PortCorsPolicy `protobuf:"bytes,5,opt,name=cors,proto3" json:"cors,omitempty"`
+	// insecure_requests defines how the proxy handles plain HTTP requests to this port
+	InsecureRequests     PortInsecureRequests `protobuf:"varint,6,opt,name=insecure_requests,json=insecureRequests,proto3,enum=wsman.PortInsecureRequests" json:"insecure_requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *PortSpec) Reset()         { *m = PortSpec{} }
@@ -1472,6 +1508,70 @@ func (m *PortSpec) GetUrl() string {
 	return ""
 }
 
+func (m *PortSpec) GetCors() *PortCorsPolicy {
+	if m != nil {
+		return m.Cors
+	}
+	return nil
+}
+
+func (m *PortSpec) GetInsecureRequests() PortInsecureRequests {
+	if m != nil {
+		return m.InsecureRequests
+	}
+	return PortInsecureRequests_PORT_INSECURE_REQUESTS_ALLOW
+}
+
+// PortCorsPolicy describes which cross-origin requests the proxy admits to a workspace port
+type PortCorsPolicy struct {
+	// allowed_origins are the origins which may access the port, "*" allows any origin
+	AllowedOrigins []string `protobuf:"bytes,1,rep,name=allowed_origins,json=allowedOrigins,proto3" json:"allowed_origins,omitempty"`
+	// allow_credentials admits cross-origin requests which include credentials. Never combined with the "*" origin.
+	AllowCredentials     bool     `protobuf:"varint,2,opt,name=allow_credentials,json=allowCredentials,proto3" json:"allow_credentials,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortCorsPolicy) Reset()         { *m = PortCorsPolicy{} }
+func (m *PortCorsPolicy) String() string { return proto.CompactTextString(m) }
+func (*PortCorsPolicy) ProtoMessage()    {}
+func (*PortCorsPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f7e43720d1edc0fe, []int{23}
+}
+
+func (m *PortCorsPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortCorsPolicy.Unmarshal(m, b)
+}
+func (m *PortCorsPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortCorsPolicy.Marshal(b, m, deterministic)
+}
+func (m *PortCorsPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortCorsPolicy.Merge(m, src)
+}
+func (m *PortCorsPolicy) XXX_Size() int {
+	return xxx_messageInfo_PortCorsPolicy.Size(m)
+}
+func (m *PortCorsPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortCorsPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortCorsPolicy proto.InternalMessageInfo
+
+func (m *PortCorsPolicy) GetAllowedOrigins() []string {
+	if m != nil {
+		return m.AllowedOrigins
+	}
+	return nil
+}
+
+func (m *PortCorsPolicy) GetAllowCredentials() bool {
+	if m != nil {
+		return m.AllowCredentials
+	}
+	return false
+}
+
 // WorkspaceCondition gives more detailed information as to the state of the workspace. Which condition actually
 // has a value depends on the phase the workspace is in.
 type WorkspaceConditions struct {
@@ -1503,7 +1603,7 @@ func (m *WorkspaceConditions) Reset()         { *m = WorkspaceConditions{} }
 func (m *WorkspaceConditions) String() string { return proto.CompactTextString(m) }
 func (*WorkspaceConditions) ProtoMessage()    {}
 func (*WorkspaceConditions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{23}
+	return fileDescriptor_f7e43720d1edc0fe, []int{24}
 }
 
 func (m *WorkspaceConditions) XXX_Unmarshal(b []byte) error {
@@ -1604,7 +1704,7 @@ func (m *WorkspaceMetadata) Reset()         { *m = WorkspaceMetadata{} }
 func (m *WorkspaceMetadata) String() string { return proto.CompactTextString(m) }
 func (*WorkspaceMetadata) ProtoMessage()    {}
 func (*WorkspaceMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{24}
+	return fileDescriptor_f7e43720d1edc0fe, []int{25}
 }
 
 func (m *WorkspaceMetadata) XXX_Unmarshal(b []byte) error {
@@ -1661,7 +1761,7 @@ func (m *WorkspaceRuntimeInfo) Reset()         { *m = WorkspaceRuntimeInfo{} }
 func (m *WorkspaceRuntimeInfo) String() string { return proto.CompactTextString(m) }
 func (*WorkspaceRuntimeInfo) ProtoMessage()    {}
 func (*WorkspaceRuntimeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{25}
+	return fileDescriptor_f7e43720d1edc0fe, []int{26}
 }
 
 func (m *WorkspaceRuntimeInfo) XXX_Unmarshal(b []byte) error {
@@ -1705,7 +1805,7 @@ func (m *WorkspaceAuthentication) Reset()         { *m = WorkspaceAuthentication
 func (m *WorkspaceAuthentication) String() string { return proto.CompactTextString(m) }
 func (*WorkspaceAuthentication) ProtoMessage()    {}
 func (*WorkspaceAuthentication) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{26}
+	return fileDescriptor_f7e43720d1edc0fe, []int{27}
 }
 
 func (m *WorkspaceAuthentication) XXX_Unmarshal(b []byte) error {
@@ -1773,7 +1873,7 @@ func (m *StartWorkspaceSpec) Reset()         { *m = StartWorkspaceSpec{} }
 func (m *StartWorkspaceSpec) String() string { return proto.CompactTextString(m) }
 func (*StartWorkspaceSpec) ProtoMessage()    {}
 func (*StartWorkspaceSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{27}
+	return fileDescriptor_f7e43720d1edc0fe, []int{28}
 }
 
 func (m *StartWorkspaceSpec) XXX_Unmarshal(b []byte) error {
@@ -1886,7 +1986,7 @@ func (m *GitSpec) Reset()         { *m = GitSpec{} }
 func (m *GitSpec) String() string { return proto.CompactTextString(m) }
 func (*GitSpec) ProtoMessage()    {}
 func (*GitSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{28}
+	return fileDescriptor_f7e43720d1edc0fe, []int{29}
 }
 
 func (m *GitSpec) XXX_Unmarshal(b []byte) error {
@@ -1934,7 +2034,7 @@ func (m *EnvironmentVariable) Reset()         { *m = EnvironmentVariable{} }
 func (m *EnvironmentVariable) String() string { return proto.CompactTextString(m) }
 func (*EnvironmentVariable) ProtoMessage()    {}
 func (*EnvironmentVariable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{29}
+	return fileDescriptor_f7e43720d1edc0fe, []int{30}
 }
 
 func (m *EnvironmentVariable) XXX_Unmarshal(b []byte) error {
@@ -1986,7 +2086,7 @@ func (m *WorkspaceLogMessage) Reset()         { *m = WorkspaceLogMessage{} }
 func (m *WorkspaceLogMessage) String() string { return proto.CompactTextString(m) }
 func (*WorkspaceLogMessage) ProtoMessage()    {}
 func (*WorkspaceLogMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f7e43720d1edc0fe, []int{30}
+	return fileDescriptor_f7e43720d1edc0fe, []int{31}
 }
 
 func (m *WorkspaceLogMessage) XXX_Unmarshal(b []byte) error {
@@ -2032,6 +2132,7 @@ func init() {
 	proto.RegisterEnum("wsman.StopWorkspacePolicy", StopWorkspacePolicy_name, StopWorkspacePolicy_value)
 	proto.RegisterEnum("wsman.AdmissionLevel", AdmissionLevel_name, AdmissionLevel_value)
 	proto.RegisterEnum("wsman.PortVisibility", PortVisibility_name, PortVisibility_value)
+	proto.RegisterEnum("wsman.PortInsecureRequests", PortInsecureRequests_name, PortInsecureRequests_value)
 	proto.RegisterEnum("wsman.WorkspaceConditionBool", WorkspaceConditionBool_name, WorkspaceConditionBool_value)
 	proto.RegisterEnum("wsman.WorkspacePhase", WorkspacePhase_name, WorkspacePhase_value)
 	proto.RegisterEnum("wsman.WorkspaceFeatureFlag", WorkspaceFeatureFlag_name, WorkspaceFeatureFlag_value)
@@ -2060,6 +2161,7 @@ func init() {
 	proto.RegisterType((*WorkspaceStatus)(nil), "wsman.WorkspaceStatus")
 	proto.RegisterType((*WorkspaceSpec)(nil), "wsman.WorkspaceSpec")
 	proto.RegisterType((*PortSpec)(nil), "wsman.PortSpec")
+	proto.RegisterType((*PortCorsPolicy)(nil), "wsman.PortCorsPolicy")
 	proto.RegisterType((*WorkspaceConditions)(nil), "wsman.WorkspaceConditions")
 	proto.RegisterType((*WorkspaceMetadata)(nil), "wsman.WorkspaceMetadata")
 	proto.RegisterType((*WorkspaceRuntimeInfo)(nil), "wsman.WorkspaceRuntimeInfo")
@@ -2075,136 +2177,145 @@ func init() {
 }
 
 var fileDescriptor_f7e43720d1edc0fe = []byte{
-	// 2064 bytes of a gzipped FileDescriptorProto
+	// 2207 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdd, 0x6f, 0xdb, 0xc8,
-	0x11, 0xb7, 0x3e, 0x2c, 0x4b, 0x63, 0x5b, 0xa6, 0xd7, 0x5f, 0x8a, 0x72, 0x77, 0x31, 0xd8, 0x0b,
-	0x6a, 0xf8, 0x6a, 0xfb, 0xe0, 0x24, 0xc0, 0x25, 0x57, 0xe0, 0x2a, 0x4b, 0xb4, 0xc3, 0x8b, 0x2c,
-	0xa9, 0x2b, 0xc9, 0x39, 0xe7, 0x85, 0x58, 0x8b, 0x6b, 0x99, 0x30, 0x45, 0xb2, 0xe4, 0xca, 0x89,
-	0x0a, 0x14, 0x7d, 0xe8, 0x7b, 0x8b, 0x02, 0x7d, 0xee, 0x3f, 0xd7, 0xbf, 0xa2, 0x0f, 0x05, 0x8a,
-	0x5d, 0x2e, 0x29, 0xea, 0x2b, 0xce, 0xc3, 0xbd, 0x71, 0x76, 0x7e, 0x33, 0xbb, 0x3b, 0x3b, 0xf3,
-	0xdb, 0x59, 0x02, 0xf4, 0x5c, 0x9f, 0x1e, 0x7b, 0xbe, 0xcb, 0x5c, 0xb4, 0xfc, 0x31, 0x18, 0x10,
-	0xa7, 0xfc, 0xbc, 0xe7, 0x3a, 0x8c, 0x3a, 0xec, 0x28, 0xa0, 0xfe, 0x83, 0xd5, 0xa3, 0x47, 0xc4,
-	0xb3, 0x4e, 0x2c, 0xc7, 0x62, 0x16, 0xb1, 0xad, 0x3f, 0x53, 0x3f, 0x44, 0x97, 0x9f, 0xf5, 0x5d,
-	0xb7, 0x6f, 0xd3, 0x13, 0x21, 0xdd, 0x0c, 0x6f, 0x4f, 0x98, 0x35, 0xa0, 0x01, 0x23, 0x03, 0x2f,
-	0x04, 0xa8, 0xbb, 0xb0, 0x7d, 0x41, 0xd9, 0x7b, 0xd7, 0xbf, 0x0f, 0x3c, 0xd2, 0xa3, 0x01, 0xa6,
-	0x7f, 0x1a, 0xd2, 0x80, 0xa9, 0x17, 0xb0, 0x33, 0x35, 0x1e, 0x78, 0xae, 0x13, 0x50, 0x74, 0x0c,
-	0xb9, 0x80, 0x11, 0x36, 0x0c, 0x4a, 0xa9, 0xfd, 0xcc, 0xc1, 0xea, 0xe9, 0xee, 0xb1, 0x58, 0xd0,
-	0x71, 0x0c, 0x6d, 0x0b, 0x2d, 0x96, 0x28, 0xf5, 0x3f, 0x29, 0xd8, 0x69, 0x33, 0xe2, 0x8f, 0x7d,
-	0xc9, 0x29, 0x50, 0x11, 0xd2, 0x96, 0x59, 0x4a, 0xed, 0xa7, 0x0e, 0x0a, 0x38, 0x6d, 0x99, 0xe8,
-	0x39, 0x14, 0xe5, 0x66, 0x0c, 0xcf, 0xa7, 0xb7, 0xd6, 0xa7, 0x52, 0x5a, 0xe8, 0xd6, 0xe5, 0x68,
-	0x4b, 0x0c, 0xa2, 0x97, 0x90, 0x1f, 0x50, 0x46, 0x4c, 0xc2, 0x48, 0x29, 0xb3, 0x9f, 0x3a, 0x58,
-	0x3d, 0x2d, 0x4d, 0x2f, 0xe1, 0x52, 0xea, 0x71, 0x8c, 0x44, 0x47, 0x90, 0x0d, 0x3c, 0xda, 0x2b,
-	0x65, 0x85, 0xc5, 0x13, 0x69, 0x31, 0xb9, 0xb0, 0xb6, 0x47, 0x7b, 0x58, 0xc0, 0xd0, 0x01, 0x64,
-	0xd9, 0xc8, 0xa3, 0xa5, 0xdc, 0x7e, 0xea, 0xa0, 0x78, 0xba, 0x3d, 0x3d, 0x41, 0x67, 0xe4, 0x51,
-	0x2c, 0x10, 0x3f, 0x67, 0xf3, 0xcb, 0x4a, 0x4e, 0x3d, 0x84, 0xdd, 0xe9, 0x4d, 0xca, 0x78, 0x29,
-	0x90, 0x19, 0xfa, 0xb6, 0xdc, 0x26, 0xff, 0x54, 0x3f, 0xc0, 0x76, 0x9b, 0xb9, 0xde, 0xa3, 0xf1,
-	0x38, 0x85, 0x9c, 0xe7, 0xda, 0x56, 0x6f, 0x24, 0xe2, 0x50, 0x3c, 0x2d, 0xc7, 0x8b, 0x4e, 0x18,
-	0xb7, 0x04, 0x02, 0x4b, 0xa4, 0xba, 0xc7, 0x83, 0x3d, 0xe1, 0x3b, 0x5c, 0x86, 0x7a, 0x08, 0xa5,
-	0x1a, 0x0d, 0x7a, 0xbe, 0x75, 0x43, 0x1f, 0x9b, 0x58, 0x75, 0xe1, 0xc9, 0x1c, 0xec, 0x9c, 0xf3,
-	0x4f, 0x3d, 0x7e, 0xfe, 0x48, 0x85, 0x35, 0x9b, 0x04, 0xac, 0xd2, 0x63, 0xd6, 0x83, 0xc5, 0x46,
-	0xf2, 0x4c, 0x27, 0xc6, 0x54, 0x04, 0x4a, 0x7b, 0x78, 0x13, 0xce, 0x18, 0x25, 0xe0, 0x7f, 0x53,
-	0xb0, 0x99, 0x18, 0x94, 0xb3, 0x7f, 0xff, 0x65, 0xb3, 0xbf, 0x5d, 0x8a, 0xe7, 0x3f, 0x86, 0x8c,
-	0xed, 0xf6, 0xc5, 0xb4, 0xab, 0x71, 0x08, 0x63, 0x78, 0xdd, 0xed, 0x5f, 0xd2, 0x20, 0x20, 0x7d,
-	0xfa, 0x76, 0x09, 0x73, 0x20, 0xfa, 0x3d, 0xe4, 0xee, 0x28, 0x31, 0xa9, 0x5f, 0xca, 0x88, 0xfc,
-	0xfe, 0x36, 0x8a, 0xfa, 0xf4, 0x5a, 0x8e, 0xdf, 0x0a, 0x98, 0xe6, 0x30, 0x7f, 0x84, 0xa5, 0x4d,
-	0xf9, 0x35, 0xac, 0x26, 0x86, 0xf9, 0xe1, 0xdf, 0xd3, 0x51, 0x74, 0xf8, 0xf7, 0x74, 0x84, 0xb6,
-	0x61, 0xf9, 0x81, 0xd8, 0x43, 0x2a, 0xe3, 0x10, 0x0a, 0x6f, 0xd2, 0x3f, 0xa4, 0xce, 0x0a, 0xb0,
-	0xe2, 0x91, 0x91, 0xed, 0x12, 0x53, 0xfd, 0x11, 0x36, 0x2f, 0x89, 0x7f, 0x2f, 0xe2, 0xb3, 0x30,
-	0x3d, 0x76, 0x21, 0xd7, 0xb3, 0xdd, 0x80, 0x9a, 0xc2, 0x55, 0x1e, 0x4b, 0x49, 0xdd, 0x06, 0x94,
-	0x34, 0x96, 0xe7, 0xff, 0x13, 0x6c, 0xb6, 0x29, 0xeb, 0x58, 0x03, 0xea, 0x0e, 0xd9, 0x22, 0x97,
-	0x65, 0xc8, 0x9b, 0x43, 0x9f, 0x30, 0xcb, 0x75, 0xe4, 0xfa, 0x62, 0x99, 0xbb, 0x4d, 0x3a, 0x90,
-	0x6e, 0x09, 0xa0, 0xaa, 0xeb, 0x30, 0xdf, 0xb5, 0x5b, 0xae, 0xcf, 0x3e, 0xb3, 0x54, 0xfa, 0xc9,
-	0x73, 0x03, 0x1a, 0x2d, 0x35, 0x94, 0xd0, 0x6f, 0x64, 0x51, 0x86, 0x65, 0xbc, 0x21, 0x23, 0xcd,
-	0x3d, 0x8d, 0x4b, 0x51, 0xdd, 0x81, 0xad, 0x89, 0x29, 0xe4, 0xcc, 0xcf, 0x61, 0xab, 0x43, 0xee,
-	0x69, 0xdb, 0x21, 0x5e, 0x70, 0xe7, 0x2e, 0x9a, 0x5a, 0x3d, 0x80, 0xed, 0x49, 0xd8, 0xc2, 0xb2,
-	0xbc, 0x82, 0x3d, 0x39, 0x4f, 0xc5, 0x1c, 0x58, 0x41, 0x60, 0xb9, 0xce, 0xa2, 0xfd, 0x7c, 0x07,
-	0xcb, 0x36, 0x7d, 0xa0, 0xb6, 0x2c, 0xcc, 0x1d, 0xb9, 0xf0, 0xd8, 0xae, 0xce, 0x95, 0x38, 0xc4,
-	0xa8, 0x65, 0x28, 0xcd, 0xfa, 0x95, 0x9b, 0xf8, 0x77, 0x06, 0x36, 0xa6, 0x52, 0x77, 0x66, 0xb2,
-	0x24, 0xdf, 0xa5, 0xbf, 0x98, 0xef, 0x0e, 0x26, 0x42, 0x3b, 0x43, 0x60, 0x09, 0xaa, 0xfb, 0x0e,
-	0x96, 0xbd, 0x3b, 0x12, 0x50, 0x41, 0x8d, 0xe3, 0xcd, 0x8c, 0x19, 0x86, 0x2b, 0x71, 0x88, 0x41,
-	0x6f, 0xf8, 0x5d, 0xe4, 0x98, 0x16, 0x4f, 0x89, 0xa0, 0xb4, 0x3c, 0xbf, 0xa8, 0xaa, 0x31, 0x02,
-	0x27, 0xd0, 0xa8, 0x04, 0x2b, 0x83, 0xb0, 0xd6, 0x04, 0xad, 0x16, 0x70, 0x24, 0x72, 0x72, 0xf6,
-	0xa9, 0xe7, 0x96, 0x56, 0x24, 0x39, 0xcb, 0xbb, 0x4d, 0xf2, 0xfe, 0xf1, 0x85, 0xc5, 0x24, 0xa9,
-	0x08, 0x18, 0x7a, 0x05, 0x2b, 0xfe, 0xd0, 0xe1, 0x37, 0x59, 0x29, 0x2f, 0x2c, 0x9e, 0x4e, 0xaf,
-	0x00, 0x87, 0x6a, 0xdd, 0xb9, 0x75, 0x71, 0x84, 0x45, 0xa7, 0x90, 0x25, 0x43, 0x76, 0x57, 0x2a,
-	0x08, 0x9b, 0x6f, 0xa6, 0x6d, 0x2a, 0x43, 0x76, 0x47, 0x1d, 0x66, 0xf5, 0x44, 0xbe, 0x63, 0x81,
-	0x55, 0xff, 0x97, 0x82, 0xf5, 0x89, 0xa0, 0xa1, 0xdf, 0xc2, 0xc6, 0xc7, 0x68, 0xc0, 0xb0, 0x06,
-	0x7c, 0x37, 0xe1, 0x59, 0x15, 0xe3, 0x61, 0x9d, 0x8f, 0xa2, 0xa7, 0x50, 0xb0, 0xcc, 0x08, 0x22,
-	0xab, 0xc9, 0x32, 0xa5, 0xb2, 0x0c, 0x79, 0xce, 0x18, 0x36, 0x0d, 0x02, 0x71, 0x44, 0x79, 0x1c,
-	0xcb, 0x51, 0x6a, 0x66, 0xe3, 0xd4, 0x44, 0x2f, 0x61, 0x3d, 0xac, 0x18, 0xd3, 0xf0, 0x5c, 0x9f,
-	0xf1, 0xc0, 0x67, 0xe6, 0x15, 0xcc, 0x9a, 0x44, 0xf1, 0x81, 0xe0, 0xcb, 0xef, 0x30, 0x7e, 0x32,
-	0x2c, 0x2c, 0x6c, 0x71, 0x04, 0x05, 0x1c, 0x89, 0xea, 0x5f, 0x21, 0x1f, 0x79, 0x47, 0x08, 0xb2,
-	0x7c, 0x76, 0xb1, 0xdd, 0x75, 0x2c, 0xbe, 0x79, 0x65, 0x33, 0xe2, 0xf7, 0x29, 0x13, 0x3b, 0x5c,
-	0xc7, 0x52, 0x42, 0xaf, 0x00, 0x1e, 0xac, 0xc0, 0xba, 0xb1, 0x6c, 0xce, 0xf9, 0x99, 0x89, 0xcc,
-	0xe2, 0x0e, 0xaf, 0x62, 0x25, 0x4e, 0x00, 0x67, 0xb7, 0xae, 0xfe, 0x2b, 0x0b, 0x5b, 0x73, 0x12,
-	0x8b, 0x4f, 0x7c, 0x4b, 0x2c, 0x9b, 0x46, 0x95, 0x22, 0xa5, 0xe4, 0x56, 0xd2, 0x13, 0x5b, 0x41,
-	0x35, 0x28, 0x7a, 0x43, 0xdb, 0xb6, 0x9c, 0x7e, 0x78, 0x26, 0x81, 0x5c, 0xd6, 0xd7, 0x0b, 0xd3,
-	0xf7, 0xcc, 0x75, 0x6d, 0xbc, 0x2e, 0x8d, 0xc4, 0xb9, 0x05, 0xdc, 0x4b, 0xd4, 0xa4, 0xd0, 0x4f,
-	0x56, 0xc0, 0x02, 0x59, 0x36, 0x8f, 0x79, 0x91, 0x46, 0x9a, 0xb0, 0xe1, 0xc7, 0x1f, 0x48, 0x46,
-	0x12, 0x45, 0x54, 0xc0, 0xb1, 0x8c, 0xfe, 0x08, 0x3b, 0xb7, 0x96, 0x43, 0x6c, 0xe3, 0x86, 0xf4,
-	0xee, 0x87, 0x9e, 0xd1, 0x73, 0x07, 0x9e, 0x4d, 0x59, 0x74, 0x8e, 0x8f, 0x4c, 0xb4, 0x25, 0x6c,
-	0xcf, 0x84, 0x69, 0x55, 0x5a, 0xa2, 0xd7, 0x90, 0x37, 0xa9, 0x67, 0xbb, 0x23, 0x6a, 0x8a, 0x03,
-	0x7e, 0xd4, 0x4b, 0x0c, 0x47, 0x3a, 0x6c, 0x3a, 0x94, 0xf1, 0xd4, 0x36, 0x1c, 0x97, 0x19, 0x3e,
-	0x25, 0xe6, 0x48, 0x54, 0xdd, 0xa3, 0x3e, 0x36, 0xa4, 0x5d, 0x83, 0xb3, 0x2e, 0x31, 0x47, 0xe8,
-	0x67, 0xd8, 0xba, 0xb5, 0xfc, 0x80, 0x19, 0xc3, 0x80, 0xfa, 0x06, 0x89, 0x1a, 0x82, 0x82, 0x24,
-	0x91, 0xb0, 0x53, 0x3d, 0x8e, 0x3a, 0xd5, 0xe3, 0x4e, 0xd4, 0xa9, 0xe2, 0x4d, 0x61, 0xd6, 0x0d,
-	0xa8, 0x1f, 0x77, 0x0c, 0x7f, 0x81, 0xcd, 0x19, 0xf6, 0xe3, 0x77, 0xab, 0xfb, 0xd1, 0xa1, 0xbe,
-	0x4c, 0x89, 0x50, 0x40, 0x7b, 0x9c, 0x76, 0x18, 0x31, 0x2c, 0x53, 0x66, 0x44, 0x8e, 0x8b, 0xba,
-	0x89, 0x5e, 0x03, 0x04, 0xbc, 0x67, 0xa3, 0xa6, 0x41, 0x98, 0x24, 0xca, 0xcf, 0x2d, 0xa3, 0x20,
-	0xd1, 0x15, 0xa6, 0xbe, 0x80, 0xed, 0x79, 0x5c, 0xc3, 0x6b, 0xde, 0x71, 0x4d, 0x6a, 0x38, 0x64,
-	0x10, 0xd1, 0x42, 0x9e, 0x0f, 0x34, 0xc8, 0x80, 0xaa, 0x2e, 0xec, 0x2d, 0x20, 0x1b, 0xf4, 0x02,
-	0x0a, 0x24, 0xba, 0x1c, 0x84, 0xdd, 0xc2, 0x4b, 0x65, 0x8c, 0x43, 0xcf, 0x60, 0x55, 0xec, 0xd0,
-	0x60, 0xee, 0x3d, 0x8d, 0x2e, 0x6c, 0x10, 0x43, 0x1d, 0x3e, 0xa2, 0xfe, 0x3d, 0x0b, 0x68, 0xb6,
-	0xc3, 0xfd, 0x95, 0x18, 0xec, 0x0f, 0xb0, 0x7e, 0x4b, 0x09, 0x1b, 0xfa, 0xd4, 0xb8, 0xb5, 0x49,
-	0x3f, 0x10, 0xed, 0x52, 0x71, 0x96, 0x8a, 0xcf, 0x43, 0xd0, 0xb9, 0x4d, 0xfa, 0x78, 0xed, 0x76,
-	0x2c, 0x04, 0xe8, 0x1c, 0x56, 0x13, 0x0f, 0x16, 0xd9, 0x99, 0x7f, 0x3b, 0x4d, 0xfe, 0xb1, 0x23,
-	0x7d, 0x8c, 0xc5, 0x49, 0x43, 0xf4, 0x1c, 0x96, 0x3f, 0xcb, 0x8a, 0xa1, 0x16, 0xbd, 0x84, 0x15,
-	0xea, 0x3c, 0x3c, 0x10, 0x3f, 0x28, 0xe5, 0x04, 0x30, 0xba, 0xb7, 0x34, 0xe7, 0xc1, 0xf2, 0x5d,
-	0x67, 0x40, 0x1d, 0x76, 0x45, 0x7c, 0x8b, 0xdc, 0xd8, 0x14, 0x47, 0x50, 0xf4, 0x1d, 0x6c, 0xf6,
-	0xee, 0x68, 0xef, 0xde, 0x1d, 0x32, 0xc3, 0x76, 0xc3, 0xe3, 0x92, 0x24, 0xa9, 0x44, 0x8a, 0xba,
-	0x1c, 0x47, 0x47, 0x80, 0xc6, 0x91, 0x8d, 0xd1, 0x79, 0x81, 0xde, 0xfc, 0x38, 0xee, 0x39, 0x25,
-	0x7c, 0x1f, 0x32, 0x7d, 0x8b, 0xc9, 0x02, 0x28, 0xca, 0xd5, 0xf0, 0xcb, 0x8e, 0xaf, 0x9a, 0xab,
-	0x92, 0x6c, 0x06, 0x93, 0x6c, 0x36, 0x91, 0x31, 0xab, 0x5f, 0x96, 0x31, 0xea, 0x8f, 0xb0, 0x22,
-	0xdd, 0x73, 0x06, 0xe2, 0x65, 0x98, 0x4c, 0xd4, 0x48, 0xe6, 0x75, 0x44, 0x07, 0xc4, 0xb2, 0xa3,
-	0x1e, 0x55, 0x08, 0xea, 0x4f, 0xb0, 0x35, 0x27, 0x52, 0xfc, 0x56, 0x48, 0x38, 0xc9, 0x46, 0x0e,
-	0x66, 0x9b, 0x5c, 0x75, 0x98, 0x60, 0xf2, 0x71, 0xdf, 0xfd, 0x2b, 0xf5, 0x3b, 0x89, 0xe6, 0x22,
-	0x3b, 0xd1, 0x5c, 0x1c, 0xbe, 0x84, 0xad, 0x39, 0x2f, 0x26, 0xb4, 0x06, 0xf9, 0x46, 0x13, 0x5f,
-	0x56, 0xea, 0xf5, 0x6b, 0x65, 0x09, 0x6d, 0xc0, 0xaa, 0x7e, 0x79, 0xa9, 0xd5, 0xf4, 0x4a, 0x47,
-	0xab, 0x5f, 0x2b, 0xa9, 0xc3, 0x37, 0x50, 0x9c, 0x8c, 0x23, 0xda, 0x06, 0xa5, 0x52, 0xbb, 0xd4,
-	0x3b, 0x46, 0xf3, 0x7d, 0x43, 0xc3, 0x46, 0xb3, 0x21, 0x0c, 0x11, 0x14, 0xc3, 0x51, 0xed, 0x4a,
-	0xc3, 0xd7, 0xcd, 0x86, 0xa6, 0xa4, 0x0e, 0x75, 0x28, 0x4e, 0xde, 0x71, 0xe8, 0x29, 0xec, 0xb5,
-	0x9a, 0xb8, 0x63, 0x5c, 0xe9, 0x6d, 0xfd, 0x4c, 0xaf, 0xeb, 0x9d, 0x6b, 0xa3, 0x85, 0xf5, 0xab,
-	0x4a, 0x47, 0x53, 0x96, 0x50, 0x19, 0x76, 0x67, 0x94, 0xdd, 0xb3, 0xba, 0x5e, 0x55, 0x52, 0x87,
-	0x3f, 0xc0, 0xee, 0x7c, 0x7a, 0x45, 0x05, 0x58, 0x3e, 0xaf, 0xd4, 0xdb, 0xdc, 0x41, 0x1e, 0xb2,
-	0x1d, 0xdc, 0xd5, 0x94, 0x14, 0x1f, 0xd4, 0x2e, 0x5b, 0x9d, 0x6b, 0x25, 0x7d, 0xf8, 0xb7, 0x14,
-	0x14, 0x27, 0x7b, 0x38, 0xb4, 0x0a, 0x2b, 0xdd, 0xc6, 0xbb, 0x46, 0xf3, 0x7d, 0x43, 0x59, 0xe2,
-	0x42, 0x4b, 0x6b, 0xd4, 0xf4, 0xc6, 0x85, 0x92, 0xe2, 0xc1, 0xa8, 0x62, 0xad, 0xd2, 0xe1, 0x52,
-	0x1a, 0x29, 0xb0, 0xa6, 0x37, 0xf4, 0x8e, 0x5e, 0xa9, 0xeb, 0x1f, 0xf8, 0x48, 0x86, 0x83, 0x71,
-	0xb7, 0xd1, 0xe0, 0x42, 0x56, 0xc4, 0xaa, 0xd1, 0xd1, 0x30, 0xee, 0xb6, 0x3a, 0x5a, 0x4d, 0x59,
-	0xe1, 0xd6, 0xed, 0x4e, 0xb3, 0xd5, 0xe2, 0xea, 0x65, 0x8e, 0x15, 0x92, 0x56, 0x53, 0x72, 0x87,
-	0xff, 0x48, 0x25, 0x98, 0x32, 0x41, 0x05, 0x7c, 0xcd, 0x8d, 0x66, 0xb3, 0xa5, 0x2c, 0xa1, 0x22,
-	0x00, 0x8f, 0x85, 0x5e, 0xd7, 0x2e, 0xb4, 0x9a, 0x92, 0x42, 0x5b, 0xb0, 0x81, 0xb5, 0x0b, 0xbd,
-	0xdd, 0xc1, 0xd7, 0xc6, 0x79, 0xa5, 0x5a, 0xa9, 0x69, 0x4a, 0x06, 0x3d, 0x81, 0x9d, 0xf3, 0x6e,
-	0xbd, 0x6e, 0xbc, 0x6f, 0xe2, 0x77, 0xed, 0x56, 0xa5, 0xaa, 0x19, 0x67, 0x95, 0xea, 0xbb, 0x6e,
-	0x4b, 0xc9, 0x72, 0xfc, 0xb9, 0xfe, 0x8b, 0x56, 0x33, 0xb0, 0xd6, 0x6e, 0x76, 0x71, 0x55, 0x6b,
-	0x2b, 0xcb, 0xfc, 0x58, 0xba, 0x6d, 0x0d, 0x1b, 0x8d, 0xca, 0xa5, 0x26, 0xf0, 0x4a, 0x4e, 0xcd,
-	0xe6, 0xd3, 0x4a, 0xfa, 0xf0, 0x55, 0xa2, 0xa1, 0xe3, 0x2d, 0x90, 0xd8, 0x9b, 0x76, 0xd1, 0xad,
-	0x57, 0xb0, 0xb2, 0xc4, 0xb7, 0xd2, 0xc2, 0xda, 0x59, 0x57, 0xaf, 0xd7, 0xc2, 0x70, 0xb6, 0x70,
-	0xf3, 0x4c, 0x53, 0xd2, 0xa7, 0xff, 0xcc, 0x81, 0x32, 0xce, 0x3f, 0xe2, 0x90, 0x3e, 0xf5, 0x51,
-	0x1d, 0xd6, 0x27, 0x7e, 0x92, 0xa0, 0x88, 0xfd, 0xe6, 0xfd, 0x52, 0x29, 0x7f, 0x35, 0x5f, 0x29,
-	0x9f, 0x02, 0x4b, 0xa8, 0x09, 0xc5, 0x49, 0xb6, 0x46, 0x5f, 0xcd, 0xfd, 0x4d, 0x11, 0xf9, 0xfb,
-	0x7a, 0x81, 0x36, 0x76, 0x58, 0x87, 0xf5, 0x89, 0xcc, 0x8f, 0x97, 0x37, 0xef, 0xf7, 0x43, 0xf9,
-	0xab, 0xf9, 0xca, 0xd8, 0xdb, 0x2f, 0xb0, 0x39, 0xf3, 0x57, 0x00, 0x3d, 0x93, 0x46, 0x8b, 0xfe,
-	0x2d, 0x94, 0xf7, 0x17, 0x03, 0x62, 0xcf, 0x67, 0x50, 0x88, 0x5f, 0xd7, 0x68, 0x6f, 0xf6, 0xbd,
-	0x1d, 0x7a, 0x2a, 0x2d, 0x7a, 0x88, 0xab, 0x4b, 0xdf, 0xa7, 0x50, 0x15, 0x60, 0xfc, 0xea, 0x45,
-	0x11, 0x76, 0xe6, 0x15, 0x5d, 0x7e, 0x32, 0x47, 0x13, 0x2f, 0xa4, 0x0a, 0x30, 0x7e, 0xe3, 0xc6,
-	0x4e, 0x66, 0xde, 0xcd, 0xb1, 0x93, 0x39, 0x0f, 0xe2, 0x25, 0x7e, 0xad, 0x25, 0xde, 0xab, 0x28,
-	0xc2, 0xce, 0x3e, 0x93, 0xcb, 0xe5, 0x79, 0xaa, 0xd8, 0x8f, 0x0e, 0x6b, 0xc9, 0x97, 0x2b, 0x8a,
-	0xd0, 0x73, 0x5e, 0xbd, 0xe5, 0xa7, 0x73, 0x75, 0xb1, 0xab, 0x2e, 0x28, 0xd3, 0x4f, 0x50, 0xf4,
-	0xcd, 0xe4, 0xe4, 0xd3, 0x6f, 0xde, 0xf2, 0xb3, 0x85, 0xfa, 0xc8, 0xed, 0xd9, 0xef, 0x3e, 0x1c,
-	0xf6, 0x2d, 0x76, 0x37, 0xbc, 0x39, 0xee, 0xb9, 0x83, 0x93, 0xbe, 0xc5, 0x3c, 0xd7, 0x3c, 0xb2,
-	0x5c, 0xf9, 0x75, 0xf2, 0x31, 0x38, 0x1a, 0x84, 0x85, 0x72, 0x42, 0x3c, 0xeb, 0x26, 0x27, 0x5a,
-	0xaa, 0x17, 0xff, 0x0f, 0x00, 0x00, 0xff, 0xff, 0x26, 0x02, 0x2a, 0x9d, 0xcd, 0x14, 0x00, 0x00,
+	0x11, 0xb7, 0x3e, 0x6c, 0x4b, 0x63, 0x5b, 0xa6, 0xd7, 0x5f, 0x8a, 0x92, 0x5c, 0x5c, 0xde, 0x05,
+	0x75, 0x9d, 0xda, 0x3e, 0x38, 0x09, 0x70, 0xc9, 0x15, 0xb8, 0xca, 0x12, 0xed, 0x30, 0x91, 0x25,
+	0xdd, 0x4a, 0x72, 0xce, 0x79, 0x21, 0xd6, 0xd2, 0x5a, 0x5e, 0x98, 0x22, 0x59, 0x72, 0x65, 0xc7,
+	0x05, 0x0a, 0x14, 0xe8, 0x7b, 0x8b, 0x02, 0x7d, 0xee, 0x3f, 0xd7, 0x7f, 0xa1, 0x2f, 0x7d, 0x28,
+	0x70, 0xd8, 0xe5, 0x92, 0xfa, 0xbe, 0xf8, 0xe1, 0xde, 0x38, 0x33, 0xbf, 0x99, 0xfd, 0x98, 0x8f,
+	0x1d, 0x0e, 0x40, 0xdb, 0xf5, 0xe9, 0x81, 0xe7, 0xbb, 0xdc, 0x45, 0xf3, 0x77, 0x41, 0x8f, 0x38,
+	0x85, 0xe7, 0x6d, 0xd7, 0xe1, 0xd4, 0xe1, 0xfb, 0x01, 0xf5, 0x6f, 0x59, 0x9b, 0xee, 0x13, 0x8f,
+	0x1d, 0x32, 0x87, 0x71, 0x46, 0x6c, 0xf6, 0x67, 0xea, 0x87, 0xe8, 0xc2, 0xb3, 0xae, 0xeb, 0x76,
+	0x6d, 0x7a, 0x28, 0xa9, 0xcb, 0xfe, 0xd5, 0x21, 0x67, 0x3d, 0x1a, 0x70, 0xd2, 0xf3, 0x42, 0x80,
+	0xbe, 0x05, 0x1b, 0xa7, 0x94, 0x7f, 0x74, 0xfd, 0x9b, 0xc0, 0x23, 0x6d, 0x1a, 0x60, 0xfa, 0xa7,
+	0x3e, 0x0d, 0xb8, 0x7e, 0x0a, 0x9b, 0x63, 0xfc, 0xc0, 0x73, 0x9d, 0x80, 0xa2, 0x03, 0x58, 0x08,
+	0x38, 0xe1, 0xfd, 0x20, 0x9f, 0xd8, 0x49, 0xed, 0x2e, 0x1d, 0x6d, 0x1d, 0xc8, 0x0d, 0x1d, 0xc4,
+	0xd0, 0x86, 0x94, 0x62, 0x85, 0xd2, 0xff, 0x93, 0x80, 0xcd, 0x06, 0x27, 0xfe, 0xc0, 0x96, 0x5a,
+	0x02, 0xe5, 0x20, 0xc9, 0x3a, 0xf9, 0xc4, 0x4e, 0x62, 0x37, 0x8b, 0x93, 0xac, 0x83, 0x9e, 0x43,
+	0x4e, 0x1d, 0xc6, 0xf2, 0x7c, 0x7a, 0xc5, 0x3e, 0xe7, 0x93, 0x52, 0xb6, 0xa2, 0xb8, 0x75, 0xc9,
+	0x44, 0xaf, 0x20, 0xd3, 0xa3, 0x9c, 0x74, 0x08, 0x27, 0xf9, 0xd4, 0x4e, 0x62, 0x77, 0xe9, 0x28,
+	0x3f, 0xbe, 0x85, 0x33, 0x25, 0xc7, 0x31, 0x12, 0xed, 0x43, 0x3a, 0xf0, 0x68, 0x3b, 0x9f, 0x96,
+	0x1a, 0x8f, 0x94, 0xc6, 0xe8, 0xc6, 0x1a, 0x1e, 0x6d, 0x63, 0x09, 0x43, 0xbb, 0x90, 0xe6, 0xf7,
+	0x1e, 0xcd, 0x2f, 0xec, 0x24, 0x76, 0x73, 0x47, 0x1b, 0xe3, 0x0b, 0x34, 0xef, 0x3d, 0x8a, 0x25,
+	0xe2, 0x7d, 0x3a, 0x33, 0xaf, 0x2d, 0xe8, 0x7b, 0xb0, 0x35, 0x7e, 0x48, 0x75, 0x5f, 0x1a, 0xa4,
+	0xfa, 0xbe, 0xad, 0x8e, 0x29, 0x3e, 0xf5, 0x4f, 0xb0, 0xd1, 0xe0, 0xae, 0xf7, 0xc5, 0xfb, 0x38,
+	0x82, 0x05, 0xcf, 0xb5, 0x59, 0xfb, 0x5e, 0xde, 0x43, 0xee, 0xa8, 0x10, 0x6f, 0x7a, 0x48, 0xb9,
+	0x2e, 0x11, 0x58, 0x21, 0xf5, 0x6d, 0xd8, 0x1c, 0x11, 0x47, 0xdb, 0xd0, 0xf7, 0x20, 0x5f, 0xa6,
+	0x41, 0xdb, 0x67, 0x97, 0xf4, 0x4b, 0x0b, 0xeb, 0x2e, 0x3c, 0x9a, 0x82, 0x9d, 0xe2, 0xff, 0xc4,
+	0x97, 0xfd, 0x8f, 0x74, 0x58, 0xb6, 0x49, 0xc0, 0x8b, 0x6d, 0xce, 0x6e, 0x19, 0xbf, 0x57, 0x3e,
+	0x1d, 0xe1, 0xe9, 0x08, 0xb4, 0x46, 0xff, 0x32, 0x5c, 0x31, 0x0a, 0xc0, 0xff, 0x25, 0x60, 0x6d,
+	0x88, 0xa9, 0x56, 0xff, 0xf6, 0x61, 0xab, 0xbf, 0x9b, 0x8b, 0xd7, 0x3f, 0x80, 0x94, 0xed, 0x76,
+	0xe5, 0xb2, 0x4b, 0x47, 0x85, 0x71, 0x78, 0xc5, 0xed, 0x9e, 0xd1, 0x20, 0x20, 0x5d, 0xfa, 0x6e,
+	0x0e, 0x0b, 0x20, 0xfa, 0x03, 0x2c, 0x5c, 0x53, 0xd2, 0xa1, 0x7e, 0x3e, 0x25, 0xe3, 0xfb, 0x9b,
+	0xe8, 0xd6, 0xc7, 0xf7, 0x72, 0xf0, 0x4e, 0xc2, 0x0c, 0x87, 0xfb, 0xf7, 0x58, 0xe9, 0x14, 0xde,
+	0xc0, 0xd2, 0x10, 0x5b, 0x38, 0xff, 0x86, 0xde, 0x47, 0xce, 0xbf, 0xa1, 0xf7, 0x68, 0x03, 0xe6,
+	0x6f, 0x89, 0xdd, 0xa7, 0xea, 0x1e, 0x42, 0xe2, 0x6d, 0xf2, 0xbb, 0xc4, 0x71, 0x16, 0x16, 0x3d,
+	0x72, 0x6f, 0xbb, 0xa4, 0xa3, 0x7f, 0x0f, 0x6b, 0x67, 0xc4, 0xbf, 0x91, 0xf7, 0x33, 0x33, 0x3c,
+	0xb6, 0x60, 0xa1, 0x6d, 0xbb, 0x01, 0xed, 0x48, 0x53, 0x19, 0xac, 0x28, 0x7d, 0x03, 0xd0, 0xb0,
+	0xb2, 0xf2, 0xff, 0x0f, 0xb0, 0xd6, 0xa0, 0xbc, 0xc9, 0x7a, 0xd4, 0xed, 0xf3, 0x59, 0x26, 0x0b,
+	0x90, 0xe9, 0xf4, 0x7d, 0xc2, 0x99, 0xeb, 0xa8, 0xfd, 0xc5, 0xb4, 0x30, 0x3b, 0x6c, 0x40, 0x99,
+	0x25, 0x80, 0x4a, 0xae, 0xc3, 0x7d, 0xd7, 0xae, 0xbb, 0x3e, 0xff, 0x85, 0xad, 0xd2, 0xcf, 0x9e,
+	0x1b, 0xd0, 0x68, 0xab, 0x21, 0x85, 0xbe, 0x56, 0x49, 0x19, 0xa6, 0xf1, 0xaa, 0xba, 0x69, 0x61,
+	0x69, 0x90, 0x8a, 0xfa, 0x26, 0xac, 0x8f, 0x2c, 0xa1, 0x56, 0x7e, 0x0e, 0xeb, 0x4d, 0x72, 0x43,
+	0x1b, 0x0e, 0xf1, 0x82, 0x6b, 0x77, 0xd6, 0xd2, 0xfa, 0x2e, 0x6c, 0x8c, 0xc2, 0x66, 0xa6, 0xe5,
+	0x39, 0x6c, 0xab, 0x75, 0x8a, 0x9d, 0x1e, 0x0b, 0x02, 0xe6, 0x3a, 0xb3, 0xce, 0xf3, 0x02, 0xe6,
+	0x6d, 0x7a, 0x4b, 0x6d, 0x95, 0x98, 0x9b, 0x6a, 0xe3, 0xb1, 0x5e, 0x45, 0x08, 0x71, 0x88, 0xd1,
+	0x0b, 0x90, 0x9f, 0xb4, 0xab, 0x0e, 0xf1, 0xef, 0x14, 0xac, 0x8e, 0x85, 0xee, 0xc4, 0x62, 0xc3,
+	0xf5, 0x2e, 0xf9, 0xe0, 0x7a, 0xb7, 0x3b, 0x72, 0xb5, 0x13, 0x05, 0x6c, 0xa8, 0xd4, 0xbd, 0x80,
+	0x79, 0xef, 0x9a, 0x04, 0x34, 0x9f, 0x1e, 0x39, 0xcc, 0xa0, 0xc2, 0x08, 0x21, 0x0e, 0x31, 0xe8,
+	0xad, 0x78, 0x8b, 0x9c, 0x0e, 0x13, 0x21, 0x11, 0xe4, 0xe7, 0xa7, 0x27, 0x55, 0x29, 0x46, 0xe0,
+	0x21, 0x34, 0xca, 0xc3, 0x62, 0x2f, 0xcc, 0x35, 0x59, 0x56, 0xb3, 0x38, 0x22, 0x45, 0x71, 0xf6,
+	0xa9, 0xe7, 0xe6, 0x17, 0x55, 0x71, 0x56, 0x6f, 0x9b, 0xaa, 0xfb, 0x07, 0xa7, 0x8c, 0xab, 0xa2,
+	0x22, 0x61, 0xe8, 0x35, 0x2c, 0xfa, 0x7d, 0x47, 0xbc, 0x64, 0xf9, 0x8c, 0xd4, 0x78, 0x3c, 0xbe,
+	0x03, 0x1c, 0x8a, 0x4d, 0xe7, 0xca, 0xc5, 0x11, 0x16, 0x1d, 0x41, 0x9a, 0xf4, 0xf9, 0x75, 0x3e,
+	0x2b, 0x75, 0xbe, 0x1a, 0xd7, 0x29, 0xf6, 0xf9, 0x35, 0x75, 0x38, 0x6b, 0xcb, 0x78, 0xc7, 0x12,
+	0xab, 0xff, 0x3f, 0x01, 0x2b, 0x23, 0x97, 0x86, 0x7e, 0x0b, 0xab, 0x77, 0x11, 0xc3, 0x62, 0x3d,
+	0x71, 0x9a, 0xd0, 0x57, 0xb9, 0x98, 0x6d, 0x0a, 0x2e, 0x7a, 0x0c, 0x59, 0xd6, 0x89, 0x20, 0x2a,
+	0x9b, 0x58, 0x47, 0x09, 0x0b, 0x90, 0x11, 0x15, 0xc3, 0xa6, 0x41, 0x20, 0x5d, 0x94, 0xc1, 0x31,
+	0x1d, 0x85, 0x66, 0x3a, 0x0e, 0x4d, 0xf4, 0x0a, 0x56, 0xc2, 0x8c, 0xe9, 0x58, 0x9e, 0xeb, 0x73,
+	0x71, 0xf1, 0xa9, 0x69, 0x09, 0xb3, 0xac, 0x50, 0x82, 0x11, 0x3c, 0xfc, 0x0d, 0x13, 0x9e, 0xe1,
+	0x61, 0x62, 0x4b, 0x17, 0x64, 0x71, 0x44, 0xea, 0xff, 0x4d, 0x40, 0x26, 0x32, 0x8f, 0x10, 0xa4,
+	0xc5, 0xf2, 0xf2, 0xbc, 0x2b, 0x58, 0x7e, 0x8b, 0xd4, 0xe6, 0xc4, 0xef, 0x52, 0x2e, 0x8f, 0xb8,
+	0x82, 0x15, 0x85, 0x5e, 0x03, 0xdc, 0xb2, 0x80, 0x5d, 0x32, 0x5b, 0x14, 0xfd, 0xd4, 0x48, 0x68,
+	0x09, 0x83, 0xe7, 0xb1, 0x10, 0x0f, 0x01, 0xa7, 0x9c, 0xfd, 0x77, 0x90, 0x6e, 0xbb, 0x7e, 0x14,
+	0x6b, 0xc3, 0x26, 0x4a, 0xae, 0x1f, 0xa8, 0xe7, 0x4f, 0x42, 0xd0, 0x3b, 0x58, 0x63, 0x4e, 0x40,
+	0xdb, 0x7d, 0x9f, 0x5a, 0x7e, 0x98, 0xba, 0x81, 0x3a, 0xfd, 0xe3, 0x21, 0x3d, 0x53, 0x61, 0x54,
+	0x76, 0x07, 0x58, 0x63, 0x63, 0x1c, 0xfd, 0x0a, 0x72, 0xa3, 0x2b, 0x08, 0xb7, 0x13, 0xdb, 0x76,
+	0xef, 0x68, 0xc7, 0x72, 0x7d, 0xd6, 0x65, 0x4e, 0xd8, 0xff, 0x64, 0x71, 0x4e, 0xb1, 0x6b, 0x21,
+	0x17, 0xbd, 0x80, 0x35, 0xc9, 0xb1, 0xda, 0x3e, 0xed, 0x88, 0x78, 0x22, 0x76, 0xa0, 0xca, 0x9e,
+	0x26, 0x05, 0xa5, 0x01, 0x5f, 0xff, 0x57, 0x1a, 0xd6, 0xa7, 0xa4, 0x8d, 0xb8, 0xd5, 0x2b, 0xc2,
+	0x6c, 0x1a, 0xd5, 0x01, 0x45, 0x0d, 0x3b, 0x2a, 0x39, 0xe2, 0x28, 0x54, 0x86, 0x9c, 0xd7, 0xb7,
+	0x6d, 0xe6, 0x74, 0xc3, 0x88, 0x0b, 0xd4, 0x9d, 0x3f, 0x9d, 0x99, 0x9c, 0xc7, 0xae, 0x6b, 0xe3,
+	0x15, 0xa5, 0x24, 0xa3, 0x32, 0x10, 0x56, 0xa2, 0x16, 0x8c, 0x7e, 0x66, 0xe2, 0xfa, 0xd2, 0x0f,
+	0xb2, 0xa2, 0x94, 0x0c, 0xa9, 0x23, 0x82, 0x3b, 0x50, 0xf5, 0x56, 0xba, 0x2d, 0x8b, 0x63, 0x1a,
+	0xfd, 0x08, 0x9b, 0x57, 0xcc, 0x21, 0xb6, 0x75, 0x49, 0xda, 0x37, 0x7d, 0xcf, 0x6a, 0xbb, 0x3d,
+	0xcf, 0xa6, 0x3c, 0x8a, 0xd2, 0x2f, 0x2c, 0xb4, 0x2e, 0x75, 0x8f, 0xa5, 0x6a, 0x49, 0x69, 0xa2,
+	0x37, 0x90, 0xe9, 0x50, 0xcf, 0x76, 0xef, 0x69, 0x27, 0xbf, 0xf8, 0x10, 0x2b, 0x31, 0x1c, 0x99,
+	0xb0, 0xe6, 0x50, 0x2e, 0x12, 0xd7, 0x72, 0x5c, 0x6e, 0xf9, 0x94, 0x74, 0xee, 0xf3, 0x99, 0x87,
+	0xd8, 0x58, 0x55, 0x7a, 0x55, 0xf1, 0xa6, 0x90, 0xce, 0x3d, 0x7a, 0x0f, 0xeb, 0x57, 0xcc, 0x0f,
+	0xb8, 0xd5, 0x0f, 0xa8, 0x6f, 0x91, 0xa8, 0xdd, 0xc9, 0xaa, 0x12, 0x19, 0xf6, 0xe1, 0x07, 0x51,
+	0x1f, 0x7e, 0xd0, 0x8c, 0xfa, 0x70, 0xbc, 0x26, 0xd5, 0x5a, 0x01, 0xf5, 0xe3, 0x7e, 0xe8, 0x2f,
+	0xb0, 0x36, 0x51, 0xdb, 0x45, 0xe7, 0xe0, 0xde, 0x39, 0xd4, 0x57, 0x21, 0x11, 0x12, 0x68, 0x5b,
+	0x14, 0x55, 0x4e, 0x2c, 0xd6, 0x51, 0x11, 0xb1, 0x20, 0x48, 0xb3, 0x83, 0xde, 0x00, 0x04, 0x9c,
+	0xf8, 0x9c, 0x76, 0x2c, 0xc2, 0xf3, 0xa9, 0x2f, 0x6e, 0x23, 0xab, 0xd0, 0x45, 0xae, 0xbf, 0x84,
+	0x8d, 0x69, 0x95, 0x54, 0x54, 0x34, 0xc7, 0xed, 0x50, 0xcb, 0x21, 0xbd, 0xa8, 0xe8, 0x65, 0x04,
+	0xa3, 0x4a, 0x7a, 0x54, 0x77, 0x61, 0x7b, 0x46, 0x29, 0x45, 0x2f, 0x21, 0x4b, 0xa2, 0xa7, 0x2f,
+	0x9f, 0x18, 0x29, 0x05, 0x63, 0x4f, 0xe6, 0x00, 0x87, 0x9e, 0xc1, 0x92, 0x3c, 0xa1, 0xc5, 0xdd,
+	0x1b, 0x1a, 0xb5, 0x23, 0x20, 0x59, 0x4d, 0xc1, 0xd1, 0xff, 0x9e, 0x06, 0x34, 0xd9, 0xbf, 0xff,
+	0x4a, 0xf5, 0xf9, 0x8f, 0xb0, 0x72, 0x45, 0x09, 0x17, 0x95, 0xe4, 0xca, 0x26, 0xdd, 0x40, 0x36,
+	0x83, 0xb9, 0xc9, 0x87, 0xe6, 0x24, 0x04, 0x9d, 0xd8, 0xa4, 0x8b, 0x97, 0xaf, 0x06, 0x44, 0x80,
+	0x4e, 0x60, 0x69, 0xe8, 0x77, 0x4c, 0xfd, 0x77, 0x7c, 0x33, 0xfe, 0xb4, 0xc5, 0x86, 0xcc, 0x01,
+	0x16, 0x0f, 0x2b, 0xa2, 0xe7, 0x30, 0xff, 0x8b, 0x35, 0x3f, 0x94, 0xa2, 0x57, 0xb0, 0x48, 0x9d,
+	0xdb, 0x5b, 0xe2, 0x8b, 0x8a, 0x97, 0x1a, 0x7a, 0x95, 0x0d, 0xe7, 0x96, 0xf9, 0xae, 0xd3, 0xa3,
+	0x0e, 0x3f, 0x27, 0x3e, 0x23, 0x97, 0x36, 0xc5, 0x11, 0x54, 0x14, 0xab, 0xf6, 0x35, 0x6d, 0xdf,
+	0xb8, 0x7d, 0x6e, 0xd9, 0x6e, 0xe8, 0x2e, 0xf5, 0x04, 0x68, 0x91, 0xa0, 0xa2, 0xf8, 0x68, 0x1f,
+	0xd0, 0xe0, 0x66, 0x63, 0x74, 0x46, 0xa2, 0xd7, 0xee, 0x06, 0x1d, 0xb5, 0x82, 0xef, 0x40, 0xaa,
+	0xcb, 0xb8, 0x4a, 0x80, 0x9c, 0xda, 0xcd, 0x29, 0x0b, 0x77, 0x2d, 0x44, 0xc3, 0xd5, 0x0c, 0x46,
+	0xab, 0xd9, 0x48, 0xc4, 0x2c, 0x3d, 0x2c, 0x62, 0xf4, 0xef, 0x61, 0x51, 0x99, 0x17, 0x15, 0x48,
+	0xa4, 0xe1, 0x70, 0xa0, 0x46, 0xb4, 0xc8, 0x23, 0xda, 0x23, 0xcc, 0x8e, 0x3a, 0x70, 0x49, 0xe8,
+	0x3f, 0xc0, 0xfa, 0x94, 0x9b, 0x12, 0x4f, 0xde, 0x90, 0x91, 0x74, 0x64, 0x60, 0xb2, 0x85, 0xd7,
+	0xfb, 0xb0, 0x3e, 0xe5, 0xaf, 0xe2, 0x57, 0xea, 0xe6, 0x86, 0x5a, 0xa7, 0xf4, 0x48, 0xeb, 0xb4,
+	0xf7, 0x0a, 0xd6, 0xa7, 0xfc, 0x0f, 0xa2, 0x65, 0xc8, 0x54, 0x6b, 0xf8, 0xac, 0x58, 0xa9, 0x5c,
+	0x68, 0x73, 0x68, 0x15, 0x96, 0xcc, 0xb3, 0x33, 0xa3, 0x6c, 0x16, 0x9b, 0x46, 0xe5, 0x42, 0x4b,
+	0xec, 0xbd, 0x85, 0xdc, 0xe8, 0x3d, 0xa2, 0x0d, 0xd0, 0x8a, 0xe5, 0x33, 0xb3, 0x69, 0xd5, 0x3e,
+	0x56, 0x0d, 0x6c, 0xd5, 0xaa, 0x52, 0x11, 0x41, 0x2e, 0xe4, 0x1a, 0xe7, 0x06, 0xbe, 0xa8, 0x55,
+	0x0d, 0x2d, 0xb1, 0x67, 0x42, 0x6e, 0xf4, 0x01, 0x47, 0x8f, 0x61, 0xbb, 0x5e, 0xc3, 0x4d, 0xeb,
+	0xdc, 0x6c, 0x98, 0xc7, 0x66, 0xc5, 0x6c, 0x5e, 0x58, 0x75, 0x6c, 0x9e, 0x17, 0x9b, 0x86, 0x36,
+	0x87, 0x0a, 0xb0, 0x35, 0x21, 0x6c, 0x1d, 0x57, 0xcc, 0x92, 0x96, 0xd8, 0xfb, 0x6b, 0x02, 0x36,
+	0xa6, 0xbd, 0xc8, 0x68, 0x07, 0x9e, 0x48, 0x25, 0xb3, 0xda, 0x30, 0x4a, 0x2d, 0x6c, 0x58, 0xd8,
+	0xf8, 0xb1, 0x65, 0x34, 0x9a, 0x0d, 0xab, 0x58, 0xa9, 0xd4, 0x3e, 0x6a, 0x73, 0xe8, 0x6b, 0x78,
+	0x36, 0x03, 0x81, 0x8d, 0xb2, 0x89, 0x8d, 0x52, 0x53, 0x4b, 0xa0, 0xdf, 0xc0, 0xd3, 0x99, 0xa0,
+	0xf7, 0x02, 0x92, 0xdc, 0xfb, 0x0e, 0xb6, 0xa6, 0x57, 0x78, 0x94, 0x85, 0xf9, 0x93, 0x62, 0xa5,
+	0x21, 0xce, 0x90, 0x81, 0x74, 0x13, 0xb7, 0x0c, 0x2d, 0x21, 0x98, 0xc6, 0x59, 0xbd, 0x79, 0xa1,
+	0x25, 0xf7, 0xfe, 0x96, 0x80, 0xdc, 0x68, 0x93, 0x8c, 0x96, 0x60, 0xb1, 0x55, 0xfd, 0x50, 0xad,
+	0x7d, 0xac, 0x6a, 0x73, 0x82, 0xa8, 0x1b, 0xd5, 0xb2, 0x59, 0x3d, 0xd5, 0x12, 0xc2, 0x1f, 0x25,
+	0x6c, 0x14, 0x9b, 0x82, 0x4a, 0x22, 0x0d, 0x96, 0xcd, 0xaa, 0xd9, 0x34, 0x8b, 0x15, 0xf3, 0x93,
+	0xe0, 0xa4, 0x04, 0x18, 0xb7, 0xaa, 0x55, 0x41, 0xa4, 0xa5, 0xbb, 0xaa, 0x4d, 0x03, 0xe3, 0x56,
+	0xbd, 0x69, 0x94, 0xb5, 0x45, 0xa1, 0xdd, 0x68, 0xd6, 0xea, 0x75, 0x21, 0x9e, 0x17, 0x58, 0x49,
+	0x19, 0x65, 0x6d, 0x61, 0xef, 0x1f, 0x09, 0xd8, 0x98, 0x56, 0x8d, 0xc4, 0x9e, 0xab, 0xb5, 0x5a,
+	0x5d, 0x9b, 0x43, 0x39, 0x00, 0xe1, 0x0e, 0xb3, 0x62, 0x9c, 0x1a, 0x65, 0x2d, 0x81, 0xd6, 0x61,
+	0x15, 0x1b, 0xa7, 0x66, 0xa3, 0x89, 0x2f, 0xac, 0x93, 0x62, 0xa9, 0x58, 0x36, 0xb4, 0x14, 0x7a,
+	0x04, 0x9b, 0x27, 0xad, 0x4a, 0xc5, 0xfa, 0x58, 0xc3, 0x1f, 0x1a, 0xf5, 0x62, 0xc9, 0xb0, 0x8e,
+	0x8b, 0xa5, 0x0f, 0xad, 0xba, 0x96, 0x16, 0xf8, 0x13, 0xf3, 0x27, 0xa3, 0x6c, 0x61, 0xa3, 0x51,
+	0x6b, 0xe1, 0x92, 0xd1, 0xd0, 0xe6, 0x45, 0x64, 0xb4, 0x1a, 0x06, 0xb6, 0xaa, 0xc5, 0x33, 0x43,
+	0xe2, 0xb5, 0x05, 0x3d, 0x9d, 0x49, 0x6a, 0xc9, 0xbd, 0xd7, 0xb0, 0x32, 0xd2, 0x63, 0xca, 0xb3,
+	0x19, 0xa7, 0xad, 0x4a, 0x11, 0x6b, 0x73, 0xe2, 0x28, 0x75, 0x6c, 0x1c, 0xb7, 0xcc, 0x4a, 0x39,
+	0xbc, 0xce, 0x3a, 0xae, 0x1d, 0x1b, 0x5a, 0xf2, 0xe8, 0x9f, 0x0b, 0xa0, 0x0d, 0x52, 0x80, 0x38,
+	0xa4, 0x4b, 0x7d, 0x54, 0x81, 0x95, 0x91, 0x29, 0x14, 0x8a, 0x0a, 0xf0, 0xb4, 0x99, 0x55, 0xe1,
+	0xc9, 0x74, 0xa1, 0xfa, 0xd7, 0x9a, 0x43, 0x35, 0xc8, 0x8d, 0x3e, 0x18, 0xe8, 0xc9, 0xd4, 0x39,
+	0x50, 0x64, 0xef, 0xe9, 0x0c, 0x69, 0x6c, 0xb0, 0x02, 0x2b, 0x23, 0xc9, 0x17, 0x6f, 0x6f, 0xda,
+	0x7c, 0xa7, 0xf0, 0x64, 0xba, 0x30, 0xb6, 0xf6, 0x13, 0xac, 0x4d, 0x8c, 0x5d, 0xd0, 0x33, 0xa5,
+	0x34, 0x6b, 0x78, 0x53, 0xd8, 0x99, 0x0d, 0x88, 0x2d, 0x1f, 0x43, 0x36, 0x1e, 0x5f, 0xa0, 0xed,
+	0xc9, 0x81, 0x46, 0x68, 0x29, 0x3f, 0x6b, 0xd2, 0xa1, 0xcf, 0x7d, 0x9b, 0x40, 0x25, 0x80, 0xc1,
+	0x58, 0x01, 0x45, 0xd8, 0x89, 0x31, 0x45, 0xe1, 0xd1, 0x14, 0x49, 0xbc, 0x91, 0x12, 0xc0, 0x60,
+	0x88, 0x10, 0x1b, 0x99, 0x18, 0x4c, 0x14, 0x1e, 0x4d, 0x91, 0xc4, 0x46, 0x4e, 0x60, 0x69, 0x68,
+	0x20, 0x80, 0x22, 0xec, 0xe4, 0x1c, 0xa2, 0x50, 0x98, 0x26, 0x8a, 0xed, 0x98, 0xb0, 0x3c, 0x3c,
+	0x1a, 0x40, 0x11, 0x7a, 0xca, 0x58, 0xa1, 0xf0, 0x78, 0xaa, 0x2c, 0x36, 0xd5, 0x02, 0x6d, 0xfc,
+	0x1f, 0x1f, 0x7d, 0x35, 0xba, 0xf8, 0xf8, 0x50, 0xa1, 0xf0, 0x6c, 0xa6, 0x3c, 0x32, 0x7b, 0xfc,
+	0xfb, 0x4f, 0x7b, 0x5d, 0xc6, 0xaf, 0xfb, 0x97, 0x07, 0x6d, 0xb7, 0x77, 0xd8, 0x65, 0xdc, 0x73,
+	0x3b, 0xfb, 0xcc, 0x55, 0x5f, 0x87, 0x77, 0xc1, 0x7e, 0x2f, 0x4c, 0x94, 0x43, 0xe2, 0xb1, 0xcb,
+	0x05, 0xd9, 0xd5, 0xbd, 0xfc, 0x79, 0x00, 0xba, 0x2d, 0x57, 0x3d, 0x2e, 0x16, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -623,6 +623,15 @@ export class PortSpec extends jspb.Message {
     setUrl(value: string): void;
 
 
+    hasCors(): boolean;
+    clearCors(): void;
+    getCors(): PortCorsPolicy | undefined;
+    setCors(value?: PortCorsPolicy): void;
+
+    getInsecureRequests(): PortInsecureRequests;
+    setInsecureRequests(value: PortInsecureRequests): void;
+
+
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): PortSpec.AsObject;
     static toObject(includeInstance: boolean, msg: PortSpec): PortSpec.AsObject;
@@ -639,6 +648,35 @@ export namespace PortSpec {
         target: number,
         visibility: PortVisibility,
         url: string,
+        cors?: PortCorsPolicy.AsObject,
+        insecureRequests: PortInsecureRequests,
+    }
+}
+
+export class PortCorsPolicy extends jspb.Message { 
+    clearAllowedOriginsList(): void;
+    getAllowedOriginsList(): Array<string>;
+    setAllowedOriginsList(value: Array<string>): void;
+    addAllowedOrigins(value: string, index?: number): string;
+
+    getAllowCredentials(): boolean;
+    setAllowCredentials(value: boolean): void;
+
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): PortCorsPolicy.AsObject;
+    static toObject(includeInstance: boolean, msg: PortCorsPolicy): PortCorsPolicy.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: PortCorsPolicy, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): PortCorsPolicy;
+    static deserializeBinaryFromReader(message: PortCorsPolicy, reader: jspb.BinaryReader): PortCorsPolicy;
+}
+
+export namespace PortCorsPolicy {
+    export type AsObject = {
+        allowedOriginsList: Array<string>,
+        allowCredentials: boolean,
     }
 }
 
@@ -946,6 +984,12 @@ export enum PortVisibility {
     PORT_VISIBILITY_PUBLIC = 1,
 }
 
+export enum PortInsecureRequests {
+    PORT_INSECURE_REQUESTS_ALLOW = 0,
+    PORT_INSECURE_REQUESTS_REDIRECT = 1,
+    PORT_INSECURE_REQUESTS_REJECT = 2,
+}
+
 export enum WorkspaceConditionBool {
     FALSE = 0,
     TRUE = 1,
@@ -34,6 +34,8 @@ goog.exportSymbol('proto.wsman.GetWorkspacesResponse', null, global);
 goog.exportSymbol('proto.wsman.GitSpec', null, global);
 goog.exportSymbol('proto.wsman.MarkActiveRequest', null, global);
 goog.exportSymbol('proto.wsman.MarkActiveResponse', null, global);
+goog.exportSymbol('proto.wsman.PortCorsPolicy', null, global);
+goog.exportSymbol('proto.wsman.PortInsecureRequests', null, global);
 goog.exportSymbol('proto.wsman.PortSpec', null, global);
 goog.exportSymbol('proto.wsman.PortVisibility', null, global);
 goog.exportSymbol('proto.wsman.SetTimeoutRequest', null, global);
@@ -542,6 +544,27 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.wsman.PortSpec.displayName = 'proto.wsman.PortSpec';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.wsman.PortCorsPolicy = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.wsman.PortCorsPolicy.repeatedFields_, null);
+};
+goog.inherits(proto.wsman.PortCorsPolicy, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.wsman.PortCorsPolicy.displayName = 'proto.wsman.PortCorsPolicy';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -4375,7 +4398,9 @@ proto.wsman.PortSpec.toObject = function(includeInstance, msg) {
     port: jspb.Message.getFieldWithDefault(msg, 1, 0),
     target: jspb.Message.getFieldWithDefault(msg, 2, 0),
     visibility: jspb.Message.getFieldWithDefault(msg, 3, 0),
-    url: jspb.Message.getFieldWithDefault(msg, 4, "")
+    url: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    cors: (f = msg.getCors()) && proto.wsman.PortCorsPolicy.toObject(includeInstance, f),
+    insecureRequests: jspb.Message.getFieldWithDefault(msg, 6, 0)
   };
 
   if (includeInstance) {
@@ -4428,6 +4453,15 @@ proto.wsman.PortSpec.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {string} */ (reader.readString());
       msg.setUrl(value);
       break;
+    case 5:
+      var value = new proto.wsman.PortCorsPolicy;
+      reader.readMessage(value,proto.wsman.PortCorsPolicy.deserializeBinaryFromReader);
+      msg.setCors(value);
+      break;
+    case 6:
+      var value = /** @type {!proto.wsman.PortInsecureRequests} */ (reader.readEnum());
+      msg.setInsecureRequests(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -4485,6 +4519,21 @@ proto.wsman.PortSpec.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
+  f = message.getCors();
+  if (f != null) {
+    writer.writeMessage(
+      5,
+      f,
+      proto.wsman.PortCorsPolicy.serializeBinaryToWriter
+    );
+  }
+  f = message.getInsecureRequests();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      6,
+      f
+    );
+  }
 };
 
 
@@ -4548,6 +4597,232 @@ proto.wsman.PortSpec.prototype.setUrl = function(value) {
 };
 
 
+/**
+ * optional PortCorsPolicy cors = 5;
+ * @return {?proto.wsman.PortCorsPolicy}
+ */
+proto.wsman.PortSpec.prototype.getCors = function() {
+  return /** @type{?proto.wsman.PortCorsPolicy} */ (
+    jspb.Message.getWrapperField(this, proto.wsman.PortCorsPolicy, 5));
+};
+
+
+/** @param {?proto.wsman.PortCorsPolicy|undefined} value */
+proto.wsman.PortSpec.prototype.setCors = function(value) {
+  jspb.Message.setWrapperField(this, 5, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ */
+proto.wsman.PortSpec.prototype.clearCors = function() {
+  this.setCors(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.wsman.PortSpec.prototype.hasCors = function() {
+  return jspb.Message.getField(this, 5) != null;
+};
+
+
+/**
+ * optional PortInsecureRequests insecure_requests = 6;
+ * @return {!proto.wsman.PortInsecureRequests}
+ */
+proto.wsman.PortSpec.prototype.getInsecureRequests = function() {
+  return /** @type {!proto.wsman.PortInsecureRequests} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/** @param {!proto.wsman.PortInsecureRequests} value */
+proto.wsman.PortSpec.prototype.setInsecureRequests = function(value) {
+  jspb.Message.setProto3EnumField(this, 6, value);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.wsman.PortCorsPolicy.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto suitable for use in Soy templates.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     com.google.apps.jspb.JsClassTemplate.JS_RESERVED_WORDS.
+ * @param {boolean=} opt_includeInstance Whether to include the JSPB instance
+ *     for transitional soy proto support: http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.wsman.PortCorsPolicy.prototype.toObject = function(opt_includeInstance) {
+  return proto.wsman.PortCorsPolicy.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Whether to include the JSPB
+ *     instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.wsman.PortCorsPolicy} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.PortCorsPolicy.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    allowedOriginsList: jspb.Message.getRepeatedField(msg, 1),
+    allowCredentials: jspb.Message.getFieldWithDefault(msg, 2, false)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.wsman.PortCorsPolicy}
+ */
+proto.wsman.PortCorsPolicy.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.wsman.PortCorsPolicy;
+  return proto.wsman.PortCorsPolicy.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.wsman.PortCorsPolicy} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.wsman.PortCorsPolicy}
+ */
+proto.wsman.PortCorsPolicy.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addAllowedOrigins(value);
+      break;
+    case 2:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setAllowCredentials(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.wsman.PortCorsPolicy.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.wsman.PortCorsPolicy.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.wsman.PortCorsPolicy} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.wsman.PortCorsPolicy.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getAllowedOriginsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      1,
+      f
+    );
+  }
+  f = message.getAllowCredentials();
+  if (f) {
+    writer.writeBool(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * repeated string allowed_origins = 1;
+ * @return {!Array<string>}
+ */
+proto.wsman.PortCorsPolicy.prototype.getAllowedOriginsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 1));
+};
+
+
+/** @param {!Array<string>} value */
+proto.wsman.PortCorsPolicy.prototype.setAllowedOriginsList = function(value) {
+  jspb.Message.setField(this, 1, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ */
+proto.wsman.PortCorsPolicy.prototype.addAllowedOrigins = function(value, opt_index) {
+  jspb.Message.addToRepeatedField(this, 1, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ */
+proto.wsman.PortCorsPolicy.prototype.clearAllowedOriginsList = function() {
+  this.setAllowedOriginsList([]);
+};
+
+
+/**
+ * optional bool allow_credentials = 2;
+ * Note that Boolean fields may be set to 0/1 when serialized from a Java server.
+ * You should avoid comparisons like {@code val === true/false} in those cases.
+ * @return {boolean}
+ */
+proto.wsman.PortCorsPolicy.prototype.getAllowCredentials = function() {
+  return /** @type {boolean} */ (jspb.Message.getFieldWithDefault(this, 2, false));
+};
+
+
+/** @param {boolean} value */
+proto.wsman.PortCorsPolicy.prototype.setAllowCredentials = function(value) {
+  jspb.Message.setProto3BooleanField(this, 2, value);
+};
+
+
 
 
 
@@ -6415,6 +6690,15 @@ proto.wsman.PortVisibility = {
   PORT_VISIBILITY_PUBLIC: 1
 };
 
+/**
+ * @enum {number}
+ */
+proto.wsman.PortInsecureRequests = {
+  PORT_INSECURE_REQUESTS_ALLOW: 0,
+  PORT_INSECURE_REQUESTS_REDIRECT: 1,
+  PORT_INSECURE_REQUESTS_REJECT: 2
+};
+
 /**
  * @enum {number}
  */
@@ -6,8 +6,8 @@
 
 import { inject, injectable } from "inversify";
 import { MessageBusIntegration } from "./messagebus-integration";
-import { Disposable, WorkspaceInstance, Queue, WorkspaceInstancePort, PortVisibility, RunningWorkspaceInfo } from "@gitpod/gitpod-protocol";
-import { WorkspaceManagerClient, WorkspaceStatus, WorkspacePhase, GetWorkspacesRequest, GetWorkspacesResponse, WorkspaceConditionBool, WorkspaceLogMessage, PortVisibility as WsManPortVisibility } from "@gitpod/ws-manager/lib";
+import { Disposable, WorkspaceInstance, Queue, WorkspaceInstancePort, PortVisibility, PortInsecureRequests, PortCorsConfig, RunningWorkspaceInfo } from "@gitpod/gitpod-protocol";
+import { WorkspaceManagerClient, WorkspaceStatus, WorkspacePhase, GetWorkspacesRequest, GetWorkspacesResponse, WorkspaceConditionBool, WorkspaceLogMessage, PortVisibility as WsManPortVisibility, PortInsecureRequests as WsManPortInsecureRequests, PortCorsPolicy as WsManPortCorsPolicy } from "@gitpod/ws-manager/lib";
 import { WorkspaceDB } from "@gitpod/gitpod-db/lib/workspace-db";
 import { UserDB } from "@gitpod/gitpod-db/lib/user-db";
 import { log } from '@gitpod/gitpod-protocol/lib/util/logging';
@@ -99,6 +99,8 @@ export class WorkspaceManagerBridge implements Disposable {
                         targetPort: !!p.target ? p.target : undefined,
                         visibility: mapPortVisibility(p.visibility),
                         url: p.url,
+                        insecureRequests: mapPortInsecureRequests(p.insecureRequests),
+                        cors: mapPortCors(p.cors),
                     };
                 });
             }
@@ -302,6 +304,29 @@ const mapPortVisibility = (visibility: WsManPortVisibility | undefined): PortVis
     }
 };
 
+const mapPortInsecureRequests = (insecureRequests: WsManPortInsecureRequests | undefined): PortInsecureRequests | undefined => {
+    switch (insecureRequests) {
+        case undefined:
+            return undefined;
+        case WsManPortInsecureRequests.PORT_INSECURE_REQUESTS_ALLOW:
+            return "allow";
+        case WsManPortInsecureRequests.PORT_INSECURE_REQUESTS_REDIRECT:
+            return "redirect";
+        case WsManPortInsecureRequests.PORT_INSECURE_REQUESTS_REJECT:
+            return "reject";
+    }
+};
+
+const mapPortCors = (cors: WsManPortCorsPolicy.AsObject | undefined): PortCorsConfig | undefined => {
+    if (!cors) {
+        return undefined;
+    }
+    return {
+        allowedOrigins: cors.allowedOriginsList,
+        allowCredentials: cors.allowCredentials,
+    };
+};
+
 const durationLongerThanSeconds = (time: number, durationSeconds: number, now: number = Date.now()) => {
     return (now - time) / 1000 > durationSeconds;
 };
\ No newline at end of file
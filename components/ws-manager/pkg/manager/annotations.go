@@ -79,6 +79,12 @@ const (
 	// ingressPortsAnnotation holds the mapping workspace port -> allocated ingress port on kubernetes services
 	ingressPortsAnnotation = "gitpod/ingressPorts"
 
+	// portCorsAnnotationPrefix prefixes the annotations on kubernetes services which hold the CORS policy of a port, e.g. gitpod/port-cors-3000
+	portCorsAnnotationPrefix = "gitpod/port-cors-"
+
+	// portInsecureRequestsAnnotationPrefix prefixes the annotations on kubernetes services which hold how plain HTTP requests to a port are handled, e.g. gitpod/port-insecure-requests-3000
+	portInsecureRequestsAnnotationPrefix = "gitpod/port-insecure-requests-"
+
 	// withUsernamespaceAnnotation is set on workspaces which are wrapped in a user namespace (or have some form of user namespace support)
 	// Beware: this annotation is duplicated/copied in ws-daemon
 	withUsernamespaceAnnotation = "gitpod/withUsernamespace"
@@ -79,6 +79,8 @@ type Configuration struct {
 	RegistryFacadeHost string `json:"registryFacadeHost"`
 	// IngressPortAllocator contains all config for the IngressPortAllocator
 	IngressPortAllocator *IngressPortAllocatorConfig `json:"ingressPortAllocator"`
+	// SupervisorMetricsPushURL is the Prometheus push gateway the supervisor of each workspace pushes its metrics to, if set
+	SupervisorMetricsPushURL string `json:"supervisorMetricsPushURL,omitempty"`
 }
 
 // AllContainerConfiguration contains the configuration for all container in a workspace pod
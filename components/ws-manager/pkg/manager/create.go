@@ -590,7 +590,12 @@ func (m *Manager) createWorkspaceEnvironment(startContext *startWorkspaceContext
 	result = append(result, corev1.EnvVar{Name: "GITPOD_WORKSPACE_URL", Value: startContext.WorkspaceURL})
 	result = append(result, corev1.EnvVar{Name: "THEIA_SUPERVISOR_TOKEN", Value: m.Config.TheiaSupervisorToken})
 	result = append(result, corev1.EnvVar{Name: "THEIA_SUPERVISOR_ENDPOINT", Value: fmt.Sprintf(":%d", startContext.SupervisorPort)})
+	// supervisor passes the owner token to SSH clients outside the workspace, the workspace proxy admits them with it
+	result = append(result, corev1.EnvVar{Name: "THEIA_SUPERVISOR_OWNER_TOKEN", Value: startContext.OwnerToken})
 	result = append(result, corev1.EnvVar{Name: "THEIA_WEBVIEW_EXTERNAL_ENDPOINT", Value: "webview-{{hostname}}"})
+	if m.Config.SupervisorMetricsPushURL != "" {
+		result = append(result, corev1.EnvVar{Name: "GITPOD_SUPERVISOR_METRICS_PUSH_URL", Value: m.Config.SupervisorMetricsPushURL})
+	}
 
 	// We don't require that Git be configured for workspaces
 	if spec.Git != nil {
@@ -747,6 +752,10 @@ func (m *Manager) createPortsService(workspaceID string, metaID string, serviceP
 			return nil, xerrors.Errorf("cannot render public URL for %d: %w", p.Port, err)
 		}
 		annotations[fmt.Sprintf("gitpod/port-url-%d", p.Port)] = url
+		err = setPortOptionAnnotations(annotations, p)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &corev1.Service{
@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -718,6 +719,14 @@ func (m *Manager) ControlPort(ctx context.Context, req *api.ControlPortRequest)
 			service.Annotations = make(map[string]string)
 		}
 		service.Annotations[ingressPortsAnnotation] = string(serializedPorts)
+		if req.Expose {
+			err = setPortOptionAnnotations(service.Annotations, req.Spec)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot control port: %w", err)
+			}
+		} else {
+			deletePortOptionAnnotations(service.Annotations, req.Spec.Port)
+		}
 
 		for _, p := range service.Spec.Ports {
 			ingressPort, _ := alloc.AllocatedPort(int(p.Port))
@@ -755,6 +764,54 @@ func portSpecToName(spec *api.PortSpec) string {
 	return fmt.Sprintf("p%d-%s", spec.Port, visibilityStr)
 }
 
+// setPortOptionAnnotations stores the options of a port which the service port itself cannot hold, e.g. its CORS policy
+func setPortOptionAnnotations(annotations map[string]string, spec *api.PortSpec) error {
+	insecureRequestsAnnotation := fmt.Sprintf("%s%d", portInsecureRequestsAnnotationPrefix, spec.Port)
+	if spec.InsecureRequests == api.PortInsecureRequests_PORT_INSECURE_REQUESTS_ALLOW {
+		delete(annotations, insecureRequestsAnnotation)
+	} else {
+		annotations[insecureRequestsAnnotation] = spec.InsecureRequests.String()
+	}
+
+	corsAnnotation := fmt.Sprintf("%s%d", portCorsAnnotationPrefix, spec.Port)
+	if spec.Cors == nil {
+		delete(annotations, corsAnnotation)
+		return nil
+	}
+	cors, err := json.Marshal(spec.Cors)
+	if err != nil {
+		return xerrors.Errorf("cannot serialize CORS policy of port %d: %w", spec.Port, err)
+	}
+	annotations[corsAnnotation] = string(cors)
+	return nil
+}
+
+// deletePortOptionAnnotations removes the options of a port which is no longer exposed
+func deletePortOptionAnnotations(annotations map[string]string, port uint32) {
+	delete(annotations, fmt.Sprintf("%s%d", portCorsAnnotationPrefix, port))
+	delete(annotations, fmt.Sprintf("%s%d", portInsecureRequestsAnnotationPrefix, port))
+}
+
+// portOptionsFromAnnotations restores the options of a port stored using setPortOptionAnnotations
+func portOptionsFromAnnotations(spec *api.PortSpec, annotations map[string]string) error {
+	if cors, ok := annotations[fmt.Sprintf("%s%d", portCorsAnnotationPrefix, spec.Port)]; ok {
+		var policy api.PortCorsPolicy
+		err := json.Unmarshal([]byte(cors), &policy)
+		if err != nil {
+			return xerrors.Errorf("cannot unmarshal CORS policy of port %d: %w", spec.Port, err)
+		}
+		spec.Cors = &policy
+	}
+	if insecureRequests, ok := annotations[fmt.Sprintf("%s%d", portInsecureRequestsAnnotationPrefix, spec.Port)]; ok {
+		v, present := api.PortInsecureRequests_value[insecureRequests]
+		if !present {
+			return xerrors.Errorf("unknown insecure requests option of port %d: %s", spec.Port, insecureRequests)
+		}
+		spec.InsecureRequests = api.PortInsecureRequests(v)
+	}
+	return nil
+}
+
 // portNameToVisibility parses the port name with the pattern defined in PortSpecToName and return the ports visibility (or default value if not specified)
 func portNameToVisibility(s string) api.PortVisibility {
 	parts := strings.Split(s, "-")
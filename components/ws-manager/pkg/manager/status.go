@@ -396,6 +396,10 @@ func (m *Manager) getWorkspaceStatus(wso workspaceObjects) (*api.WorkspaceStatus
 				Visibility: portNameToVisibility(p.Name),
 				Url:        service.Annotations[fmt.Sprintf("gitpod/port-url-%d", p.Port)],
 			}
+			err := portOptionsFromAnnotations(port, service.Annotations)
+			if err != nil {
+				return nil, xerrors.Errorf("cannot get workspace status: %w", err)
+			}
 
 			// enforce the cannonical form where target defaults to port
 			if port.Port == port.Target {
@@ -71,7 +71,7 @@ func (p *WorkspaceProxy) Handler() (http.Handler, error) {
 	}
 	theiaRouter, portRouter, blobserveRouter := p.WorkspaceRouter(r, p.WorkspaceInfoProvider)
 	installWorkspaceRoutes(theiaRouter, handlerConfig, p.WorkspaceInfoProvider)
-	err = installWorkspacePortRoutes(portRouter, handlerConfig)
+	err = installWorkspacePortRoutes(portRouter, handlerConfig, p.WorkspaceInfoProvider)
 	if err != nil {
 		return nil, err
 	}
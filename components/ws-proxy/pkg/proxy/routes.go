@@ -14,10 +14,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/gitpod-io/gitpod/common-go/log"
+	"github.com/gitpod-io/gitpod/ws-manager/api"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
@@ -257,13 +259,16 @@ func installBlobserveRoutes(r *mux.Router, config *RouteHandlerConfig) {
 }
 
 // installWorkspacePortRoutes configures routing for exposed ports
-func installWorkspacePortRoutes(r *mux.Router, config *RouteHandlerConfig) error {
+func installWorkspacePortRoutes(r *mux.Router, config *RouteHandlerConfig, ip WorkspaceInfoProvider) error {
 	showPortNotFoundPage, err := servePortNotFoundPage(config.Config)
 	if err != nil {
 		return err
 	}
 
 	r.Use(logHandler)
+	r.Use(portInsecureRequestsHandler(ip))
+	// preflight requests carry no credentials, hence they are answered before authentication
+	r.Use(portCorsPreflightHandler(ip))
 	r.Use(config.WorkspaceAuthHandler)
 	// filter all session cookies
 	r.Use(sensitiveCookieHandler(config.Config.GitpodInstallation.HostName))
@@ -274,12 +279,140 @@ func installWorkspacePortRoutes(r *mux.Router, config *RouteHandlerConfig) error
 			config,
 			workspacePodPortResolver,
 			withHTTPErrorHandler(showPortNotFoundPage),
+			withPortCorsPolicy(ip),
 		),
 	)
 
 	return nil
 }
 
+// getPortInfo returns the workspace port a request is addressed to, or nil if the port is not exposed
+func getPortInfo(ip WorkspaceInfoProvider, req *http.Request) *PortInfo {
+	coords := getWorkspaceCoords(req)
+	ws := ip.WorkspaceInfo(coords.ID)
+	if ws == nil {
+		return nil
+	}
+	port, err := strconv.Atoi(coords.Port)
+	if err != nil {
+		return nil
+	}
+	for i := range ws.Ports {
+		if int(ws.Ports[i].Port) == port {
+			return &ws.Ports[i]
+		}
+	}
+	return nil
+}
+
+// portInsecureRequestsHandler redirects or rejects plain HTTP requests to a port as configured for the port.
+// The proxy in front of ws-proxy terminates TLS, hence the scheme is taken from X-Forwarded-Proto.
+func portInsecureRequestsHandler(ip WorkspaceInfoProvider) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("X-Forwarded-Proto") != "http" {
+				h.ServeHTTP(resp, req)
+				return
+			}
+			port := getPortInfo(ip, req)
+			if port == nil {
+				h.ServeHTTP(resp, req)
+				return
+			}
+
+			switch port.InsecureRequests {
+			case api.PortInsecureRequests_PORT_INSECURE_REQUESTS_REDIRECT:
+				target := *req.URL
+				target.Scheme = "https"
+				target.Host = req.Host
+				http.Redirect(resp, req, target.String(), http.StatusPermanentRedirect)
+			case api.PortInsecureRequests_PORT_INSECURE_REQUESTS_REJECT:
+				http.Error(resp, "this port only accepts HTTPS requests", http.StatusForbidden)
+			default:
+				h.ServeHTTP(resp, req)
+			}
+		})
+	}
+}
+
+// portCorsAllowedOrigin returns the value of the Access-Control-Allow-Origin header for a request from the origin,
+// or false if the CORS policy does not admit the origin
+func portCorsAllowedOrigin(policy *api.PortCorsPolicy, origin string) (string, bool) {
+	if origin == "" {
+		return "", false
+	}
+	for _, o := range policy.AllowedOrigins {
+		if o == "*" {
+			return "*", true
+		}
+		if o == origin {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+// setPortCorsHeaders sets the CORS headers which admit the origin to the port
+func setPortCorsHeaders(header http.Header, policy *api.PortCorsPolicy, allowedOrigin string) {
+	header.Set("Access-Control-Allow-Origin", allowedOrigin)
+	// credentials are never admitted for any origin
+	if policy.AllowCredentials && allowedOrigin != "*" {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// portCorsPreflightHandler answers CORS preflight requests to ports which have a CORS policy
+func portCorsPreflightHandler(ip WorkspaceInfoProvider) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			if req.Method != http.MethodOptions || req.Header.Get("Origin") == "" || req.Header.Get("Access-Control-Request-Method") == "" {
+				h.ServeHTTP(resp, req)
+				return
+			}
+			port := getPortInfo(ip, req)
+			if port == nil || port.Cors == nil {
+				h.ServeHTTP(resp, req)
+				return
+			}
+
+			resp.Header().Add("Vary", "Origin")
+			allowedOrigin, ok := portCorsAllowedOrigin(port.Cors, req.Header.Get("Origin"))
+			if !ok {
+				getLog(req.Context()).WithField("origin", req.Header.Get("Origin")).Debug("CORS policy of port does not admit origin")
+				resp.WriteHeader(http.StatusForbidden)
+				return
+			}
+			setPortCorsHeaders(resp.Header(), port.Cors, allowedOrigin)
+			resp.Header().Set("Access-Control-Allow-Methods", req.Header.Get("Access-Control-Request-Method"))
+			if headers := req.Header.Get("Access-Control-Request-Headers"); headers != "" {
+				resp.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			resp.Header().Set("Access-Control-Max-Age", "60")
+			resp.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// withPortCorsPolicy replaces the CORS headers of the port's responses with the ones of its CORS policy
+func withPortCorsPolicy(ip WorkspaceInfoProvider) proxyPassOpt {
+	return func(cfg *proxyPassConfig) {
+		cfg.ResponseHandler = func(resp *http.Response, req *http.Request) error {
+			port := getPortInfo(ip, req)
+			if port == nil || port.Cors == nil {
+				return nil
+			}
+
+			resp.Header.Del("Access-Control-Allow-Origin")
+			resp.Header.Del("Access-Control-Allow-Credentials")
+			resp.Header.Add("Vary", "Origin")
+			if allowedOrigin, ok := portCorsAllowedOrigin(port.Cors, req.Header.Get("Origin")); ok {
+				setPortCorsHeaders(resp.Header, port.Cors, allowedOrigin)
+			}
+			return nil
+		}
+	}
+}
+
 // workspacePodResolver resolves to the workspace pod's url from the given request
 func workspacePodResolver(config *Config, req *http.Request) (url *url.URL, err error) {
 	coords := getWorkspaceCoords(req)
@@ -84,6 +84,24 @@ var (
 	}
 )
 
+// workspacesWithPortCors returns the test workspaces with a private port which has the CORS policy
+func workspacesWithPortCors(cors *api.PortCorsPolicy) []WorkspaceInfo {
+	ws := workspaces[0]
+	ws.Ports = []PortInfo{
+		{PortSpec: api.PortSpec{Port: 28080, Target: 38080, Url: workspaces[0].Ports[0].Url, Visibility: api.PortVisibility_PORT_VISIBILITY_PRIVATE, Cors: cors}},
+	}
+	return []WorkspaceInfo{ws}
+}
+
+// workspacesWithPortInsecureRequests returns the test workspaces with a private port which handles plain HTTP requests as configured
+func workspacesWithPortInsecureRequests(insecureRequests api.PortInsecureRequests) []WorkspaceInfo {
+	ws := workspaces[0]
+	ws.Ports = []PortInfo{
+		{PortSpec: api.PortSpec{Port: 28080, Target: 38080, Url: workspaces[0].Ports[0].Url, Visibility: api.PortVisibility_PORT_VISIBILITY_PRIVATE, InsecureRequests: insecureRequests}},
+	}
+	return []WorkspaceInfo{ws}
+}
+
 func configWithBlobserve() *Config {
 	cfg := config
 	cfg.BlobServer = &BlobServerConfig{
@@ -405,6 +423,30 @@ func TestRoutes(t *testing.T) {
 				Body: "supervisor hit: /_supervisor/v1/status/content\n",
 			},
 		},
+		{
+			Desc: "unauthenticated supervisor API (SSH tunnel)",
+			Request: modifyRequest(httptest.NewRequest("GET", workspaces[0].URL+"_supervisor/v1/ssh/tunnel", nil),
+				addHostHeader,
+			),
+			Expectation: Expectation{
+				Status: http.StatusUnauthorized,
+			},
+		},
+		{
+			Desc: "authenticated supervisor API (SSH tunnel)",
+			Request: modifyRequest(httptest.NewRequest("GET", workspaces[0].URL+"_supervisor/v1/ssh/tunnel", nil),
+				addHostHeader,
+				addOwnerToken(workspaces[0].InstanceID, workspaces[0].Auth.OwnerToken),
+			),
+			Expectation: Expectation{
+				Status: http.StatusOK,
+				Header: http.Header{
+					"Content-Length": {"43"},
+					"Content-Type":   {"text/plain; charset=utf-8"},
+				},
+				Body: "supervisor hit: /_supervisor/v1/ssh/tunnel\n",
+			},
+		},
 		{
 			Desc: "non-existent authorized GET /",
 			Request: modifyRequest(httptest.NewRequest("GET", strings.ReplaceAll(workspaces[0].URL, "c95fd41c", "00000000"), nil),
@@ -501,6 +543,137 @@ func TestRoutes(t *testing.T) {
 				Status: http.StatusNotFound,
 			},
 		},
+		{
+			Desc:       "port CORS preflight",
+			Workspaces: workspacesWithPortCors(&api.PortCorsPolicy{AllowedOrigins: []string{"https://example.com"}, AllowCredentials: true}),
+			Request: modifyRequest(httptest.NewRequest("OPTIONS", workspaces[0].Ports[0].Url, nil),
+				addHostHeader,
+				addHeader("Origin", "https://example.com"),
+				addHeader("Access-Control-Request-Method", "PUT"),
+				addHeader("Access-Control-Request-Headers", "Content-Type"),
+			),
+			Expectation: Expectation{
+				Status: http.StatusNoContent,
+				Header: http.Header{
+					"Access-Control-Allow-Credentials": {"true"},
+					"Access-Control-Allow-Headers":     {"Content-Type"},
+					"Access-Control-Allow-Methods":     {"PUT"},
+					"Access-Control-Allow-Origin":      {"https://example.com"},
+					"Access-Control-Max-Age":           {"60"},
+					"Vary":                             {"Origin"},
+				},
+			},
+		},
+		{
+			Desc:       "port CORS preflight from other origin",
+			Workspaces: workspacesWithPortCors(&api.PortCorsPolicy{AllowedOrigins: []string{"https://example.com"}}),
+			Request: modifyRequest(httptest.NewRequest("OPTIONS", workspaces[0].Ports[0].Url, nil),
+				addHostHeader,
+				addHeader("Origin", "https://evil.com"),
+				addHeader("Access-Control-Request-Method", "PUT"),
+			),
+			Expectation: Expectation{
+				Status: http.StatusForbidden,
+				Header: http.Header{"Vary": {"Origin"}},
+			},
+		},
+		{
+			Desc:       "port CORS GET",
+			Workspaces: workspacesWithPortCors(&api.PortCorsPolicy{AllowedOrigins: []string{"https://example.com"}, AllowCredentials: true}),
+			Request: modifyRequest(httptest.NewRequest("GET", workspaces[0].Ports[0].Url, nil),
+				addHostHeader,
+				addOwnerToken(workspaces[0].InstanceID, workspaces[0].Auth.OwnerToken),
+				addHeader("Origin", "https://example.com"),
+			),
+			Targets: &Targets{Port: &Target{
+				Handler: func(w http.ResponseWriter, r *http.Request, requestCount uint8) {
+					w.Header().Set("Access-Control-Allow-Origin", "*")
+					w.WriteHeader(http.StatusOK)
+				},
+			}},
+			Expectation: Expectation{
+				Status: http.StatusOK,
+				Header: http.Header{
+					"Access-Control-Allow-Credentials": {"true"},
+					"Access-Control-Allow-Origin":      {"https://example.com"},
+					"Content-Length":                   {"0"},
+					"Vary":                             {"Origin"},
+				},
+			},
+		},
+		{
+			Desc:       "port CORS GET from other origin",
+			Workspaces: workspacesWithPortCors(&api.PortCorsPolicy{AllowedOrigins: []string{"https://example.com"}}),
+			Request: modifyRequest(httptest.NewRequest("GET", workspaces[0].Ports[0].Url, nil),
+				addHostHeader,
+				addOwnerToken(workspaces[0].InstanceID, workspaces[0].Auth.OwnerToken),
+				addHeader("Origin", "https://evil.com"),
+			),
+			Targets: &Targets{Port: &Target{
+				Handler: func(w http.ResponseWriter, r *http.Request, requestCount uint8) {
+					w.Header().Set("Access-Control-Allow-Origin", "*")
+					w.WriteHeader(http.StatusOK)
+				},
+			}},
+			Expectation: Expectation{
+				Status: http.StatusOK,
+				Header: http.Header{
+					"Content-Length": {"0"},
+					"Vary":           {"Origin"},
+				},
+			},
+		},
+		{
+			Desc:       "port insecure request redirect",
+			Workspaces: workspacesWithPortInsecureRequests(api.PortInsecureRequests_PORT_INSECURE_REQUESTS_REDIRECT),
+			Request: modifyRequest(httptest.NewRequest("GET", workspaces[0].Ports[0].Url+"some/path?q=1", nil),
+				addHostHeader,
+				addHeader("X-Forwarded-Proto", "http"),
+			),
+			Expectation: Expectation{
+				Status: http.StatusPermanentRedirect,
+				Header: http.Header{
+					"Content-Type": {"text/html; charset=utf-8"},
+					"Location":     {workspaces[0].Ports[0].Url + "some/path?q=1"},
+				},
+				Body: "<a href=\"" + workspaces[0].Ports[0].Url + "some/path?q=1\">Permanent Redirect</a>.\n\n",
+			},
+		},
+		{
+			Desc:       "port insecure request reject",
+			Workspaces: workspacesWithPortInsecureRequests(api.PortInsecureRequests_PORT_INSECURE_REQUESTS_REJECT),
+			Request: modifyRequest(httptest.NewRequest("GET", workspaces[0].Ports[0].Url, nil),
+				addHostHeader,
+				addOwnerToken(workspaces[0].InstanceID, workspaces[0].Auth.OwnerToken),
+				addHeader("X-Forwarded-Proto", "http"),
+			),
+			Expectation: Expectation{
+				Status: http.StatusForbidden,
+				Header: http.Header{
+					"Content-Type":           {"text/plain; charset=utf-8"},
+					"X-Content-Type-Options": {"nosniff"},
+				},
+				Body: "this port only accepts HTTPS requests\n",
+			},
+		},
+		{
+			Desc:       "port secure request with insecure requests rejected",
+			Workspaces: workspacesWithPortInsecureRequests(api.PortInsecureRequests_PORT_INSECURE_REQUESTS_REJECT),
+			Request: modifyRequest(httptest.NewRequest("GET", workspaces[0].Ports[0].Url, nil),
+				addHostHeader,
+				addOwnerToken(workspaces[0].InstanceID, workspaces[0].Auth.OwnerToken),
+				addHeader("X-Forwarded-Proto", "https"),
+			),
+			Targets: &Targets{Port: &Target{Status: http.StatusOK}},
+			Expectation: Expectation{
+				Status: http.StatusOK,
+				Header: http.Header{
+					"Content-Length": {"12"},
+					"Content-Type":   {"text/plain; charset=utf-8"},
+				},
+				Body: "port hit: /\n",
+			},
+		},
 		{
 			Desc: "port GET unexposed",
 			Request: modifyRequest(httptest.NewRequest("GET", workspaces[0].Ports[0].Url+"this-does-not-exist", nil),
@@ -571,7 +744,11 @@ func TestRoutes(t *testing.T) {
 				router = test.Router(&cfg)
 			}
 
-			proxy := NewWorkspaceProxy(":8080", cfg, router, &fakeWsInfoProvider{infos: workspaces})
+			infos := workspaces
+			if test.Workspaces != nil {
+				infos = test.Workspaces
+			}
+			proxy := NewWorkspaceProxy(":8080", cfg, router, &fakeWsInfoProvider{infos: infos})
 			handler, err := proxy.Handler()
 			if err != nil {
 				t.Fatalf("cannot create proxy handler: %q", err)